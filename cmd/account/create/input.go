@@ -25,6 +25,7 @@ import (
 )
 
 type dataIn struct {
+	quiet   bool
 	timeout time.Duration
 	// For all accounts.
 	wallet           e2wtypes.Wallet
@@ -42,6 +43,9 @@ func input(ctx context.Context) (*dataIn, error) {
 	var err error
 	data := &dataIn{}
 
+	// Quiet.
+	data.quiet = viper.GetBool("quiet")
+
 	if viper.GetDuration("timeout") == 0 {
 		return nil, errors.New("timeout is required")
 	}
@@ -15,7 +15,8 @@ package accountcreate
 
 import (
 	"context"
-	"regexp"
+	"fmt"
+	"os"
 
 	"github.com/pkg/errors"
 	"github.com/wealdtech/ethdo/util"
@@ -86,12 +87,12 @@ func processPathed(ctx context.Context, data *dataIn) (*dataOut, error) {
 	if data.passphrase == "" {
 		return nil, errors.New("passphrase is required")
 	}
-	match, err := regexp.MatchString("^m/[0-9]+/[0-9]+(/[0-9+])+", data.path)
+	warning, err := util.ValidateDerivationPath(data.path)
 	if err != nil {
-		return nil, errors.Wrap(err, "unable to match path to regular expression")
+		return nil, err
 	}
-	if !match {
-		return nil, errors.New("path does not match expected format m/…")
+	if warning != "" && !data.quiet {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
 	}
 
 	results := &dataOut{}
@@ -15,9 +15,12 @@ package accountderive
 
 import (
 	"context"
+	"fmt"
+	"os"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
 )
 
 type dataIn struct {
@@ -25,7 +28,7 @@ type dataIn struct {
 	json  bool
 	// Derivation information.
 	mnemonic string
-	path     string
+	paths    []string
 	// Output options.
 	showPrivateKey            bool
 	showWithdrawalCredentials bool
@@ -51,7 +54,19 @@ func input(_ context.Context) (*dataIn, error) {
 	if viper.GetString("path") == "" {
 		return nil, errors.New("path is required")
 	}
-	data.path = viper.GetString("path")
+	var err error
+	data.paths, err = util.ExpandPathRange(viper.GetString("path"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid path")
+	}
+	// All paths in a range share the same structure, so only the first needs checking for a warning.
+	warning, err := util.ValidateDerivationPath(data.paths[0])
+	if err != nil {
+		return nil, err
+	}
+	if warning != "" && !data.quiet {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
 
 	// Show private key.
 	data.showPrivateKey = viper.GetBool("show-private-key")
@@ -53,9 +53,28 @@ func TestInput(t *testing.T) {
 			},
 			res: &dataIn{
 				mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
-				path:     "m/12381/3600/0/0",
+				paths:    []string{"m/12381/3600/0/0"},
 			},
 		},
+		{
+			name: "PathRange",
+			vars: map[string]interface{}{
+				"mnemonic": "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"path":     "m/12381/3600/{0..2}/0/0",
+			},
+			res: &dataIn{
+				mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				paths:    []string{"m/12381/3600/0/0/0", "m/12381/3600/1/0/0", "m/12381/3600/2/0/0"},
+			},
+		},
+		{
+			name: "PathRangeInvalid",
+			vars: map[string]interface{}{
+				"mnemonic": "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"path":     "m/12381/3600/{2..0}/0/0",
+			},
+			err: `invalid path: range "{2..0}" in path ends before it starts`,
+		},
 	}
 
 	for _, test := range tests {
@@ -71,7 +90,7 @@ func TestInput(t *testing.T) {
 				require.NoError(t, err)
 				// Cannot compare accounts directly, so need to check each element individually.
 				require.Equal(t, test.res.mnemonic, res.mnemonic)
-				require.Equal(t, test.res.path, res.path)
+				require.Equal(t, test.res.paths, res.paths)
 			}
 		})
 	}
@@ -35,74 +35,101 @@ type dataOut struct {
 	showPrivateKey            bool
 	showWithdrawalCredentials bool
 	generateKeystore          bool
-	key                       *e2types.BLSPrivateKey
-	path                      string
+	derivations               []*derivation
+}
+
+type derivation struct {
+	key  *e2types.BLSPrivateKey
+	path string
 }
 
 func output(ctx context.Context, data *dataOut) (string, error) {
 	if data == nil {
 		return "", errors.New("no data")
 	}
-	if data.key == nil {
+	if len(data.derivations) == 0 {
 		return "", errors.New("no key")
 	}
+	for _, derivation := range data.derivations {
+		if derivation.key == nil {
+			return "", errors.New("no key")
+		}
+	}
 
 	if data.generateKeystore {
-		return outputKeystore(ctx, data)
+		return outputKeystores(ctx, data)
 	}
 
 	builder := strings.Builder{}
 
-	if data.showPrivateKey {
-		builder.WriteString(fmt.Sprintf("Private key: %#x\n", data.key.Marshal()))
-	}
-	if data.showWithdrawalCredentials {
-		withdrawalCredentials := ethutil.SHA256(data.key.PublicKey().Marshal())
-		withdrawalCredentials[0] = byte(0) // BLS_WITHDRAWAL_PREFIX
-		builder.WriteString(fmt.Sprintf("Withdrawal credentials: %#x\n", withdrawalCredentials))
-	}
-	if !(data.showPrivateKey || data.showWithdrawalCredentials) {
-		builder.WriteString(fmt.Sprintf("Public key: %#x\n", data.key.PublicKey().Marshal()))
+	for i, derivation := range data.derivations {
+		if len(data.derivations) > 1 {
+			if i > 0 {
+				builder.WriteString("\n")
+			}
+			builder.WriteString(fmt.Sprintf("Path: %s\n", derivation.path))
+		}
+		if data.showPrivateKey {
+			builder.WriteString(fmt.Sprintf("Private key: %#x\n", derivation.key.Marshal()))
+		}
+		if data.showWithdrawalCredentials {
+			withdrawalCredentials := ethutil.SHA256(derivation.key.PublicKey().Marshal())
+			withdrawalCredentials[0] = byte(0) // BLS_WITHDRAWAL_PREFIX
+			builder.WriteString(fmt.Sprintf("Withdrawal credentials: %#x\n", withdrawalCredentials))
+		}
+		if !(data.showPrivateKey || data.showWithdrawalCredentials) {
+			builder.WriteString(fmt.Sprintf("Public key: %#x\n", derivation.key.PublicKey().Marshal()))
+		}
 	}
 
 	return builder.String(), nil
 }
 
-func outputKeystore(_ context.Context, data *dataOut) (string, error) {
+func outputKeystores(ctx context.Context, data *dataOut) (string, error) {
 	passphrase, err := util.GetPassphrase()
 	if err != nil {
 		return "", errors.New("no passphrase supplied")
 	}
 
+	for _, derivation := range data.derivations {
+		if err := outputKeystore(ctx, data.json, derivation, passphrase); err != nil {
+			return "", err
+		}
+	}
+
+	return "", nil
+}
+
+func outputKeystore(_ context.Context, jsonOutput bool, derivation *derivation, passphrase string) error {
 	encryptor := keystorev4.New()
-	crypto, err := encryptor.Encrypt(data.key.Marshal(), passphrase)
+	crypto, err := encryptor.Encrypt(derivation.key.Marshal(), passphrase)
 	if err != nil {
-		return "", errors.New("failed to encrypt private key")
+		return errors.New("failed to encrypt private key")
 	}
 
 	uuid, err := uuid.NewRandom()
 	if err != nil {
-		return "", errors.New("failed to generate UUID")
+		return errors.New("failed to generate UUID")
 	}
 	ks := make(map[string]interface{})
 	ks["uuid"] = uuid.String()
-	ks["pubkey"] = hex.EncodeToString(data.key.PublicKey().Marshal())
+	ks["pubkey"] = hex.EncodeToString(derivation.key.PublicKey().Marshal())
 	ks["version"] = 4
-	ks["path"] = data.path
+	ks["path"] = derivation.path
 	ks["crypto"] = crypto
 	out, err := json.Marshal(ks)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to marshal keystore JSON")
+		return errors.Wrap(err, "failed to marshal keystore JSON")
 	}
 
-	if data.json {
+	if jsonOutput {
 		fmt.Fprintf(os.Stdout, "%s\n", string(out))
 	} else {
-		keystoreFilename := fmt.Sprintf("keystore-%s-%d.json", strings.ReplaceAll(data.path, "/", "_"), time.Now().Unix())
+		keystoreFilename := fmt.Sprintf("keystore-%s-%d.json", strings.ReplaceAll(derivation.path, "/", "_"), time.Now().Unix())
 
 		if err := os.WriteFile(keystoreFilename, out, 0o600); err != nil {
-			return "", errors.Wrap(err, fmt.Sprintf("failed to write %s", keystoreFilename))
+			return errors.Wrap(err, fmt.Sprintf("failed to write %s", keystoreFilename))
 		}
 	}
-	return "", nil
+	return nil
 }
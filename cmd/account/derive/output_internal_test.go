@@ -54,14 +54,18 @@ func TestOutput(t *testing.T) {
 		{
 			name: "Good",
 			dataOut: &dataOut{
-				key: blsPrivateKey("0x068dce0c90cb428ab37a74af0191eac49648035f1aaef077734b91e05985ec55"),
+				derivations: []*derivation{{
+					key: blsPrivateKey("0x068dce0c90cb428ab37a74af0191eac49648035f1aaef077734b91e05985ec55"),
+				}},
 			},
 			needs: []string{"Public key"},
 		},
 		{
 			name: "PrivatKey",
 			dataOut: &dataOut{
-				key:            blsPrivateKey("0x068dce0c90cb428ab37a74af0191eac49648035f1aaef077734b91e05985ec55"),
+				derivations: []*derivation{{
+					key: blsPrivateKey("0x068dce0c90cb428ab37a74af0191eac49648035f1aaef077734b91e05985ec55"),
+				}},
 				showPrivateKey: true,
 			},
 			needs: []string{"Private key"},
@@ -69,7 +73,9 @@ func TestOutput(t *testing.T) {
 		{
 			name: "WithdrawalCredentials",
 			dataOut: &dataOut{
-				key:                       blsPrivateKey("0x068dce0c90cb428ab37a74af0191eac49648035f1aaef077734b91e05985ec55"),
+				derivations: []*derivation{{
+					key: blsPrivateKey("0x068dce0c90cb428ab37a74af0191eac49648035f1aaef077734b91e05985ec55"),
+				}},
 				showWithdrawalCredentials: true,
 			},
 			needs: []string{"Withdrawal credentials"},
@@ -77,12 +83,30 @@ func TestOutput(t *testing.T) {
 		{
 			name: "All",
 			dataOut: &dataOut{
-				key:                       blsPrivateKey("0x068dce0c90cb428ab37a74af0191eac49648035f1aaef077734b91e05985ec55"),
+				derivations: []*derivation{{
+					key: blsPrivateKey("0x068dce0c90cb428ab37a74af0191eac49648035f1aaef077734b91e05985ec55"),
+				}},
 				showPrivateKey:            true,
 				showWithdrawalCredentials: true,
 			},
 			needs: []string{"Private key", "Withdrawal credentials"},
 		},
+		{
+			name: "Multiple",
+			dataOut: &dataOut{
+				derivations: []*derivation{
+					{
+						key:  blsPrivateKey("0x068dce0c90cb428ab37a74af0191eac49648035f1aaef077734b91e05985ec55"),
+						path: "m/12381/3600/0/0",
+					},
+					{
+						key:  blsPrivateKey("0x1eec38ed3bf5eadf50150c6e6b7ea444d98f7911446daeca3bfa669310398f32"),
+						path: "m/12381/3600/0/0/0",
+					},
+				},
+			},
+			needs: []string{"Path: m/12381/3600/0/0\n", "Path: m/12381/3600/0/0/0\n", "Public key"},
+		},
 	}
 
 	for _, test := range tests {
@@ -27,14 +27,10 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		return nil, errors.New("no data")
 	}
 
-	account, err := util.ParseAccount(ctx, data.mnemonic, []string{data.path}, true)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to derive account")
-	}
-
-	key, err := account.(e2wtypes.AccountPrivateKeyProvider).PrivateKey(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to obtain account private key")
+	paths := data.paths
+	if len(paths) == 0 {
+		// Allow the derivation function to report the missing path.
+		paths = []string{""}
 	}
 
 	results := &dataOut{
@@ -42,8 +38,24 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		showPrivateKey:            data.showPrivateKey,
 		showWithdrawalCredentials: data.showWithdrawalCredentials,
 		generateKeystore:          data.generateKeystore,
-		key:                       key.(*e2types.BLSPrivateKey),
-		path:                      data.path,
+		derivations:               make([]*derivation, 0, len(paths)),
+	}
+
+	for _, path := range paths {
+		account, err := util.ParseAccount(ctx, data.mnemonic, []string{path}, true)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to derive account")
+		}
+
+		key, err := account.(e2wtypes.AccountPrivateKeyProvider).PrivateKey(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain account private key")
+		}
+
+		results.derivations = append(results.derivations, &derivation{
+			key:  key.(*e2types.BLSPrivateKey),
+			path: path,
+		})
 	}
 
 	return results, nil
@@ -26,10 +26,11 @@ func TestProcess(t *testing.T) {
 	require.NoError(t, e2types.InitBLS())
 
 	tests := []struct {
-		name    string
-		dataIn  *dataIn
-		privKey []byte
-		err     string
+		name     string
+		dataIn   *dataIn
+		privKey  []byte
+		privKeys [][]byte
+		err      string
 	}{
 		{
 			name: "Nil",
@@ -38,7 +39,7 @@ func TestProcess(t *testing.T) {
 		{
 			name: "MnemonicMissing",
 			dataIn: &dataIn{
-				path: "m/12381/3600/0/0",
+				paths: []string{"m/12381/3600/0/0"},
 			},
 			err: "failed to derive account: no account specified",
 		},
@@ -46,7 +47,7 @@ func TestProcess(t *testing.T) {
 			name: "MnemonicInvalid",
 			dataIn: &dataIn{
 				mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
-				path:     "m/12381/3600/0/0",
+				paths:    []string{"m/12381/3600/0/0"},
 			},
 			err: "failed to derive account: mnemonic is invalid",
 		},
@@ -61,7 +62,7 @@ func TestProcess(t *testing.T) {
 			name: "PathInvalid",
 			dataIn: &dataIn{
 				mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
-				path:     "n/12381/3600/0/0",
+				paths:    []string{"n/12381/3600/0/0"},
 			},
 			err: "failed to derive account: path does not match expected format m/…",
 		},
@@ -69,7 +70,7 @@ func TestProcess(t *testing.T) {
 			name: "Good",
 			dataIn: &dataIn{
 				mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
-				path:     "m/12381/3600/0/0",
+				paths:    []string{"m/12381/3600/0/0"},
 			},
 			privKey: testutil.HexToBytes("0x068dce0c90cb428ab37a74af0191eac49648035f1aaef077734b91e05985ec55"),
 		},
@@ -77,10 +78,22 @@ func TestProcess(t *testing.T) {
 			name: "Extended",
 			dataIn: &dataIn{
 				mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art extended",
-				path:     "m/12381/3600/0/0",
+				paths:    []string{"m/12381/3600/0/0"},
 			},
 			privKey: testutil.HexToBytes("0x58c8b280ae035de0452797b52fb62555f27f78541ea2f04b23e7bb0fcd0fc2d6"),
 		},
+		{
+			name: "Multiple",
+			dataIn: &dataIn{
+				mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				paths:    []string{"m/12381/3600/0/0", "m/12381/3600/0/0/0", "m/12381/3600/1/0/0"},
+			},
+			privKeys: [][]byte{
+				testutil.HexToBytes("0x068dce0c90cb428ab37a74af0191eac49648035f1aaef077734b91e05985ec55"),
+				testutil.HexToBytes("0x1eec38ed3bf5eadf50150c6e6b7ea444d98f7911446daeca3bfa669310398f32"),
+				testutil.HexToBytes("0x41e118fd8a94b4f5c82add7b76bb5e41cf12d280bf3cd41c65e46b991fbb154e"),
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -90,7 +103,13 @@ func TestProcess(t *testing.T) {
 				require.EqualError(t, err, test.err)
 			} else {
 				require.NoError(t, err)
-				require.Equal(t, test.privKey, res.key.Marshal())
+				if test.privKeys == nil {
+					test.privKeys = [][]byte{test.privKey}
+				}
+				require.Len(t, res.derivations, len(test.privKeys))
+				for i := range test.privKeys {
+					require.Equal(t, test.privKeys[i], res.derivations[i].key.Marshal())
+				}
 			}
 		})
 	}
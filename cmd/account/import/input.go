@@ -36,6 +36,7 @@ type dataIn struct {
 	walletPassphrase   string
 	keystore           []byte
 	keystorePassphrase []byte
+	pubKey             []byte
 }
 
 func input(ctx context.Context) (*dataIn, error) {
@@ -77,11 +78,24 @@ func input(ctx context.Context) (*dataIn, error) {
 	// Wallet passphrase.
 	data.walletPassphrase = util.GetWalletPassphrase()
 
-	if viper.GetString("key") == "" && viper.GetString("keystore") == "" {
-		return nil, errors.New("key or keystore is required")
+	sources := 0
+	for _, source := range []string{"key", "keystore", "pubkey"} {
+		if viper.GetString(source) != "" {
+			sources++
+		}
+	}
+	if sources == 0 {
+		return nil, errors.New("key, keystore or pubkey is required")
+	}
+	if sources > 1 {
+		return nil, errors.New("only one of key, keystore and pubkey is required")
 	}
-	if viper.GetString("key") != "" && viper.GetString("keystore") != "" {
-		return nil, errors.New("only one of key and keystore is required")
+
+	if viper.GetString("pubkey") != "" {
+		data.pubKey, err = hex.DecodeString(strings.TrimPrefix(viper.GetString("pubkey"), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "pubkey is malformed")
+		}
 	}
 
 	if viper.GetString("key") != "" {
@@ -102,7 +102,7 @@ func TestInput(t *testing.T) {
 				"account":    "Test wallet/Test account",
 				"passphrase": "ce%NohGhah4ye5ra",
 			},
-			err: "key or keystore is required",
+			err: "key, keystore or pubkey is required",
 		},
 		{
 			name: "KeyMalformed",
@@ -123,7 +123,27 @@ func TestInput(t *testing.T) {
 				"key":        "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866",
 				"keystore":   "{}",
 			},
-			err: "only one of key and keystore is required",
+			err: "only one of key, keystore and pubkey is required",
+		},
+		{
+			name: "KeyAndPubKey",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"account":    "Test wallet/Test account",
+				"passphrase": "ce%NohGhah4ye5ra",
+				"key":        "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866",
+				"pubkey":     "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+			},
+			err: "only one of key, keystore and pubkey is required",
+		},
+		{
+			name: "PubKeyMalformed",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"account": "Test wallet/Test account",
+				"pubkey":  "invalid",
+			},
+			err: "pubkey is malformed: encoding/hex: invalid byte: U+0069 'i'",
 		},
 		{
 			name: "KeystoreNoKeystorePassphrase",
@@ -31,6 +31,10 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	if data == nil {
 		return nil, errors.New("no data")
 	}
+	if len(data.pubKey) > 0 {
+		// Watch-only accounts have no key to protect, so do not require a passphrase.
+		return processFromPubKey(ctx, data)
+	}
 	if data.passphrase == "" {
 		return nil, errors.New("passphrase is required")
 	}
@@ -74,6 +78,17 @@ func processFromKey(ctx context.Context, data *dataIn) (*dataOut, error) {
 	return results, nil
 }
 
+func processFromPubKey(ctx context.Context, data *dataIn) (*dataOut, error) {
+	account, err := util.ImportWatchOnlyAccount(ctx, data.wallet, data.accountName, data.pubKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to import watch-only account")
+	}
+
+	return &dataOut{
+		account: account,
+	}, nil
+}
+
 func processFromKeystore(ctx context.Context, data *dataIn) (*dataOut, error) {
 	// Need to import the keystore in to a temporary wallet to fetch the private key.
 	store := scratch.New()
@@ -79,6 +79,35 @@ func TestProcess(t *testing.T) {
 				key:              hexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"),
 			},
 		},
+		{
+			name: "WatchOnlyBadPubKey",
+			dataIn: &dataIn{
+				timeout:     5 * time.Second,
+				wallet:      testNDWallet,
+				accountName: "Watched",
+				pubKey:      hexToBytes("0x0102"),
+			},
+			err: "failed to import watch-only account: invalid public key: public key must be 48 bytes",
+		},
+		{
+			name: "WatchOnly",
+			dataIn: &dataIn{
+				timeout:     5 * time.Second,
+				wallet:      testNDWallet,
+				accountName: "Watched",
+				pubKey:      hexToBytes("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"),
+			},
+		},
+		{
+			name: "WatchOnlyDuplicate",
+			dataIn: &dataIn{
+				timeout:     5 * time.Second,
+				wallet:      testNDWallet,
+				accountName: "Good",
+				pubKey:      hexToBytes("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"),
+			},
+			err: `failed to import watch-only account: account with name "Good" already exists`,
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,76 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountpassphrasechange
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	timeout time.Duration
+
+	// Input.
+	wallet        e2wtypes.Wallet
+	account       e2wtypes.Account
+	passphrases   []string
+	newPassphrase string
+}
+
+func newCommand(ctx context.Context) (*command, error) {
+	c := &command{
+		quiet:         viper.GetBool("quiet"),
+		verbose:       viper.GetBool("verbose"),
+		debug:         viper.GetBool("debug"),
+		timeout:       viper.GetDuration("timeout"),
+		passphrases:   util.GetPassphrases(),
+		newPassphrase: viper.GetString("new-passphrase"),
+	}
+
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if viper.GetString("account") == "" {
+		return nil, errors.New("account is required")
+	}
+	if len(c.passphrases) == 0 {
+		return nil, errors.New("passphrase is required")
+	}
+	if c.newPassphrase == "" {
+		return nil, errors.New("new passphrase is required")
+	}
+	if !util.AcceptablePassphrase(c.newPassphrase) {
+		return nil, errors.New("supplied new passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	var err error
+	c.wallet, c.account, err = util.WalletAndAccountFromInput(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain account")
+	}
+
+	return c, nil
+}
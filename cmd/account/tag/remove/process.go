@@ -0,0 +1,59 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accounttagremove
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	existing, err := util.AccountTags(ctx, c.wallet, c.account)
+	if err != nil {
+		return err
+	}
+
+	remove := make(map[string]struct{}, len(c.tags))
+	for _, tag := range c.tags {
+		remove[tag] = struct{}{}
+	}
+	tags := make([]string, 0, len(existing))
+	for _, tag := range existing {
+		if _, exists := remove[tag]; exists {
+			delete(remove, tag)
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	if len(remove) > 0 && !c.quiet {
+		for _, tag := range c.tags {
+			if _, exists := remove[tag]; exists {
+				fmt.Fprintf(os.Stderr, "Warning: account does not have tag %s\n", tag)
+			}
+		}
+	}
+
+	if err := util.SetAccountTags(ctx, c.wallet, c.account, tags); err != nil {
+		return err
+	}
+
+	c.accountTags = tags
+	sort.Strings(c.accountTags)
+
+	return nil
+}
@@ -28,6 +28,10 @@ var accountDeriveCmd = &cobra.Command{
 
     ethdo account derive --mnemonic="..." --path="m/12381/3600/0/0"
 
+Ranges can be supplied as path components to derive multiple accounts at once.  For example:
+
+    ethdo account derive --mnemonic="..." --path="m/12381/3600/{0..99}/0/0"
+
 In quiet mode this will return 0 if the inputs can derive an account account, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := accountderive.Run(cmd)
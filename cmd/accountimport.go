@@ -28,6 +28,10 @@ var accountImportCmd = &cobra.Command{
 
     ethdo account import --account="primary/testing" --key="0x..." --passphrase="my secret"
 
+A watch-only account, which cannot sign but can be used by commands that only require a public key, can be imported from its public key.  For example:
+
+    ethdo account import --account="primary/watched" --pubkey="0x..."
+
 In quiet mode this will return 0 if the account is imported successfully, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := accountimport.Run(cmd)
@@ -50,6 +54,7 @@ func init() {
 	accountImportCmd.Flags().String("key", "", "Private key of the account to import (0x...)")
 	accountImportCmd.Flags().String("keystore", "", "Keystore, or path to keystore ")
 	accountImportCmd.Flags().String("keystore-passphrase", "", "Passphrase of keystore")
+	accountImportCmd.Flags().String("pubkey", "", "Public key of a watch-only account to import (0x...)")
 }
 
 func accountImportBindings(cmd *cobra.Command) {
@@ -62,4 +67,7 @@ func accountImportBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("keystore-passphrase", cmd.Flags().Lookup("keystore-passphrase")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("pubkey", cmd.Flags().Lookup("pubkey")); err != nil {
+		panic(err)
+	}
 }
@@ -17,9 +17,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	ethdoutil "github.com/wealdtech/ethdo/util"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	util "github.com/wealdtech/go-eth2-util"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
@@ -77,6 +79,12 @@ In quiet mode this will return 0 if the account exists, otherwise 1.`,
 				fmt.Printf("Path: %s\n", pathProvider.Path())
 			}
 		}
+		if ethdoutil.IsWatchOnlyAccount(account) {
+			fmt.Println("Watch-only: true")
+		}
+		if tags, err := ethdoutil.AccountTags(ctx, wallet, account); err == nil && len(tags) > 0 {
+			fmt.Printf("Tags: %s\n", strings.Join(tags, ", "))
+		}
 
 		os.Exit(_exitSuccess)
 	},
@@ -0,0 +1,56 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	accountpassphrasechange "github.com/wealdtech/ethdo/cmd/account/passphrase/change"
+)
+
+var accountPassphraseChangeCmd = &cobra.Command{
+	Use:   "change",
+	Short: "Change the passphrase of an account",
+	Long: `Change the passphrase of an account.  For example:
+
+    ethdo account passphrase change --account="primary/validator" --passphrase="old secret" --new-passphrase="new secret"
+
+The account's key is decrypted with the existing passphrase and re-encrypted with the new passphrase in place.
+
+In quiet mode this will return 0 if the passphrase has been changed, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := accountpassphrasechange.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	accountPassphraseCmd.AddCommand(accountPassphraseChangeCmd)
+	accountPassphraseFlags(accountPassphraseChangeCmd)
+	accountPassphraseChangeCmd.Flags().String("new-passphrase", "", "New passphrase for the account")
+}
+
+func accountPassphraseChangeBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("new-passphrase", cmd.Flags().Lookup("new-passphrase")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	accounttagadd "github.com/wealdtech/ethdo/cmd/account/tag/add"
+)
+
+var accountTagAddCmd = &cobra.Command{
+	Use:   "add <tag>...",
+	Short: "Add tags to an account",
+	Long: `Add one or more tags to an account.  For example:
+
+    ethdo account tag add --account="primary/validator 1" mainnet-batch-3
+
+Tags are stored alongside the account in its wallet.  Accounts carrying a tag can be selected by commands that operate on multiple accounts with the --accounts-tagged option.
+
+In quiet mode this will return 0 if the tags have been added, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := accounttagadd.Run(cmd, args)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	accountTagCmd.AddCommand(accountTagAddCmd)
+	accountTagFlags(accountTagAddCmd)
+}
@@ -0,0 +1,46 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	accounttagremove "github.com/wealdtech/ethdo/cmd/account/tag/remove"
+)
+
+var accountTagRemoveCmd = &cobra.Command{
+	Use:   "remove <tag>...",
+	Short: "Remove tags from an account",
+	Long: `Remove one or more tags from an account.  For example:
+
+    ethdo account tag remove --account="primary/validator 1" mainnet-batch-3
+
+In quiet mode this will return 0 if the tags have been removed, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := accounttagremove.Run(cmd, args)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	accountTagCmd.AddCommand(accountTagRemoveCmd)
+	accountTagFlags(accountTagRemoveCmd)
+}
@@ -0,0 +1,58 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/cmd/approve"
+)
+
+var approveCmd = &cobra.Command{
+	Use:   "approve",
+	Short: "Approve a request for a sensitive operation",
+	Long: `Approve a request for a sensitive operation, such as a validator exit or withdrawal credentials change, that requires approval by the approval policy.  For example:
+
+    ethdo approve --request=exit-approval-request.json --account=Approvers/Alice --passphrase=secret
+
+The approval is added to the request file, which is then passed back to the operator that created it.
+
+In quiet mode this will return 0 if the request has been approved, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := approve.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(approveCmd)
+	approveCmd.Flags().String("request", "", "File holding the approval request")
+}
+
+func approveBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("request", cmd.Flags().Lookup("request")); err != nil {
+		panic(err)
+	}
+}
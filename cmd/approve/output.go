@@ -0,0 +1,49 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package approve
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	builder := strings.Builder{}
+
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Operation: %s\n", c.approvalRequest.Operation))
+		for _, operation := range c.approvalRequest.Operations {
+			compacted := bytes.Buffer{}
+			if err := json.Compact(&compacted, operation); err != nil {
+				return "", err
+			}
+			builder.WriteString(fmt.Sprintf("  %s\n", compacted.String()))
+		}
+	}
+
+	if c.threshold > 0 {
+		builder.WriteString(fmt.Sprintf("Approved; request has %d of %d required approvals", c.approvals, c.threshold))
+	} else {
+		builder.WriteString(fmt.Sprintf("Approved; request has %d approvals", c.approvals))
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,76 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package approve
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/signing"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	var err error
+	c.approvalRequest, err = util.ReadApprovalRequest(c.request)
+	if err != nil {
+		return err
+	}
+
+	account, err := util.ParseAccount(ctx, c.account, c.passphrases, true)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain account")
+	}
+	pubKey, err := util.BestPublicKey(account)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain account public key")
+	}
+
+	policy, err := util.ObtainApprovalPolicy()
+	if err != nil {
+		return err
+	}
+	if policy != nil && !policy.IsApprover(pubKey) {
+		return fmt.Errorf("account %#x is not an approver in the approval policy", pubKey.Marshal())
+	}
+
+	root, err := c.approvalRequest.Root()
+	if err != nil {
+		return err
+	}
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Approving request root %#x with public key %#x\n", root, pubKey.Marshal())
+	}
+	signature, err := signing.SignRoot(ctx, account, nil, root, util.ApprovalDomain())
+	if err != nil {
+		return errors.Wrap(err, "failed to sign approval")
+	}
+	if err := c.approvalRequest.AddApproval(pubKey, signature); err != nil {
+		return err
+	}
+
+	if err := c.approvalRequest.Write(c.request); err != nil {
+		return err
+	}
+
+	c.approvals = len(c.approvalRequest.Approvals)
+	if policy != nil {
+		c.approvals = policy.Approvals(c.approvalRequest)
+		c.threshold = policy.Threshold
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package approve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	approver := "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"
+	approverPubKey := "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+	other := "0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b"
+
+	request := &util.ApprovalRequest{
+		Operation:  util.ApprovalOperationValidatorExit,
+		Domain:     "0x04000000bba4da96354c9f25476cf1bc69bf583a7f9e0af049305b62de676640",
+		Roots:      []string{"0x0d4b7d19155bde7e02092ce3e74ffbdb6c31281743ead09036cca58c6263d407"},
+		Operations: []json.RawMessage{json.RawMessage(`{"epoch":"300000","validator_index":"12"}`)},
+	}
+	dir := t.TempDir()
+	requestFile := filepath.Join(dir, "approval-request.json")
+	require.NoError(t, request.Write(requestFile))
+
+	tests := []struct {
+		name      string
+		approvers []string
+		cmd       *command
+		approvals int
+		err       string
+	}{
+		{
+			name: "RequestMissing",
+			cmd: &command{
+				account: approver,
+				request: filepath.Join(dir, "missing.json"),
+			},
+			err: fmt.Sprintf("failed to read approval request: open %s: no such file or directory", filepath.Join(dir, "missing.json")),
+		},
+		{
+			name:      "NotApprover",
+			approvers: []string{other},
+			cmd: &command{
+				account: approver,
+				request: requestFile,
+			},
+			err: fmt.Sprintf("account %s is not an approver in the approval policy", approverPubKey),
+		},
+		{
+			name:      "Good",
+			approvers: []string{approverPubKey, other},
+			cmd: &command{
+				account: approver,
+				request: requestFile,
+			},
+			approvals: 1,
+		},
+		{
+			name:      "Repeated",
+			approvers: []string{approverPubKey, other},
+			cmd: &command{
+				account: approver,
+				request: requestFile,
+			},
+			approvals: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			t.Cleanup(viper.Reset)
+			if test.approvers != nil {
+				viper.Set("approval", map[string]any{"approvers": test.approvers})
+			}
+			err := test.cmd.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.approvals, test.cmd.approvals)
+			require.Equal(t, 1, test.cmd.threshold)
+
+			stored, err := util.ReadApprovalRequest(test.cmd.request)
+			require.NoError(t, err)
+			require.Len(t, stored.Approvals, test.approvals)
+		})
+	}
+}
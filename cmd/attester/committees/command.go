@@ -0,0 +1,101 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestercommittees
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	slot       string
+	epoch      string
+	validators []string
+
+	// Data access.
+	eth2Client               eth2client.Service
+	chainTime                chaintime.Service
+	validatorsProvider       eth2client.ValidatorsProvider
+	beaconCommitteesProvider eth2client.BeaconCommitteesProvider
+
+	// Output.
+	results *results
+}
+
+type results struct {
+	Epoch       phase0.Epoch  `json:"epoch"`
+	Slot        *phase0.Slot  `json:"slot,omitempty"`
+	Committees  []*committee  `json:"committees"`
+	Assignments []*assignment `json:"assignments,omitempty"`
+	// Unassigned are the requested validators without an assignment.
+	Unassigned []phase0.ValidatorIndex `json:"unassigned,omitempty"`
+}
+
+type committee struct {
+	Slot       phase0.Slot             `json:"slot"`
+	Index      phase0.CommitteeIndex   `json:"index"`
+	Validators []phase0.ValidatorIndex `json:"validators"`
+}
+
+type assignment struct {
+	ValidatorIndex phase0.ValidatorIndex `json:"validator_index"`
+	Slot           phase0.Slot           `json:"slot"`
+	CommitteeIndex phase0.CommitteeIndex `json:"committee_index"`
+	Position       int                   `json:"position"`
+	CommitteeSize  int                   `json:"committee_size"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.slot = viper.GetString("slot")
+	c.epoch = viper.GetString("epoch")
+	if c.slot != "" && c.epoch != "" {
+		return nil, errors.New("only one of slot and epoch is allowed")
+	}
+	c.validators = viper.GetStringSlice("validators")
+
+	return c, nil
+}
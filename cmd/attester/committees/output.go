@@ -0,0 +1,75 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestercommittees
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.results)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if len(c.validators) > 0 {
+		// Reverse lookup of the committees for the validators.
+		for _, assignment := range c.results.Assignments {
+			builder.WriteString(fmt.Sprintf("Validator %d attests in slot %d committee %d (position %d of %d)\n", assignment.ValidatorIndex, assignment.Slot, assignment.CommitteeIndex, assignment.Position, assignment.CommitteeSize))
+		}
+		for _, index := range c.results.Unassigned {
+			if c.results.Slot != nil {
+				builder.WriteString(fmt.Sprintf("Validator %d does not attest in slot %d\n", index, *c.results.Slot))
+			} else {
+				builder.WriteString(fmt.Sprintf("Validator %d does not attest in epoch %d\n", index, c.results.Epoch))
+			}
+		}
+
+		return strings.TrimSuffix(builder.String(), "\n"), nil
+	}
+
+	for _, committee := range c.results.Committees {
+		builder.WriteString(fmt.Sprintf("Slot %d committee %d: %d validators\n", committee.Slot, committee.Index, len(committee.Validators)))
+		if c.verbose {
+			indices := make([]string, len(committee.Validators))
+			for i := range committee.Validators {
+				indices[i] = fmt.Sprintf("%d", committee.Validators[i])
+			}
+			builder.WriteString(fmt.Sprintf("  %s\n", strings.Join(indices, ", ")))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
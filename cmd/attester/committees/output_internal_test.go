@@ -0,0 +1,108 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestercommittees
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	slot := phase0.Slot(100)
+	committees := []*committee{
+		{Slot: 100, Index: 0, Validators: []phase0.ValidatorIndex{1, 2, 3}},
+		{Slot: 100, Index: 1, Validators: []phase0.ValidatorIndex{4, 5}},
+	}
+
+	tests := []struct {
+		name string
+		cmd  *command
+		res  string
+	}{
+		{
+			name: "Committees",
+			cmd: &command{
+				results: &results{
+					Epoch:      3,
+					Slot:       &slot,
+					Committees: committees,
+				},
+			},
+			res: "Slot 100 committee 0: 3 validators\nSlot 100 committee 1: 2 validators",
+		},
+		{
+			name: "CommitteesVerbose",
+			cmd: &command{
+				verbose: true,
+				results: &results{
+					Epoch:      3,
+					Slot:       &slot,
+					Committees: committees,
+				},
+			},
+			res: "Slot 100 committee 0: 3 validators\n  1, 2, 3\nSlot 100 committee 1: 2 validators\n  4, 5",
+		},
+		{
+			name: "Validators",
+			cmd: &command{
+				validators: []string{"3", "9"},
+				results: &results{
+					Epoch:      3,
+					Committees: committees,
+					Assignments: []*assignment{
+						{ValidatorIndex: 3, Slot: 100, CommitteeIndex: 0, Position: 2, CommitteeSize: 3},
+					},
+					Unassigned: []phase0.ValidatorIndex{9},
+				},
+			},
+			res: "Validator 3 attests in slot 100 committee 0 (position 2 of 3)\nValidator 9 does not attest in epoch 3",
+		},
+		{
+			name: "ValidatorsSlot",
+			cmd: &command{
+				validators: []string{"9"},
+				results: &results{
+					Epoch:      3,
+					Slot:       &slot,
+					Committees: committees,
+					Unassigned: []phase0.ValidatorIndex{9},
+				},
+			},
+			res: "Validator 9 does not attest in slot 100",
+		},
+		{
+			name: "JSON",
+			cmd: &command{
+				json: true,
+				results: &results{
+					Epoch:      3,
+					Slot:       &slot,
+					Committees: committees[:1],
+				},
+			},
+			res: `{"epoch":"3","slot":"100","committees":[{"slot":"100","index":0,"validators":["1","2","3"]}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.cmd.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestercommittees
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	c.results = &results{}
+	if c.slot != "" {
+		slot, err := util.ParseSlot(ctx, c.chainTime, c.slot)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse slot")
+		}
+		c.results.Slot = &slot
+		c.results.Epoch = c.chainTime.SlotToEpoch(slot)
+	} else {
+		var err error
+		c.results.Epoch, err = util.ParseEpoch(ctx, c.chainTime, c.epoch)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse epoch")
+		}
+	}
+
+	opts := &api.BeaconCommitteesOpts{
+		State: fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(c.results.Epoch)),
+	}
+	if c.chainTime.FirstSlotOfEpoch(c.results.Epoch) > c.chainTime.CurrentSlot() {
+		// Committees for the next epoch are available from the head state.
+		opts.State = "head"
+		opts.Epoch = &c.results.Epoch
+	}
+	response, err := c.beaconCommitteesProvider.BeaconCommittees(ctx, opts)
+	if err != nil {
+		return errors.Wrapf(err, "failed to obtain beacon committees for epoch %d", c.results.Epoch)
+	}
+	c.results.Committees = selectCommittees(response.Data, c.results.Slot)
+
+	if len(c.validators) > 0 {
+		validators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validators, "head")
+		if err != nil {
+			return err
+		}
+		c.results.Assignments, c.results.Unassigned = assignments(c.results.Committees, validators)
+	}
+
+	return nil
+}
+
+// selectCommittees returns the committees, restricted to the given slot if
+// supplied, in slot and index order.
+func selectCommittees(beaconCommittees []*apiv1.BeaconCommittee, slot *phase0.Slot) []*committee {
+	res := make([]*committee, 0, len(beaconCommittees))
+	for _, beaconCommittee := range beaconCommittees {
+		if slot != nil && beaconCommittee.Slot != *slot {
+			continue
+		}
+		res = append(res, &committee{
+			Slot:       beaconCommittee.Slot,
+			Index:      beaconCommittee.Index,
+			Validators: beaconCommittee.Validators,
+		})
+	}
+	sort.Slice(res, func(i int, j int) bool {
+		if res[i].Slot != res[j].Slot {
+			return res[i].Slot < res[j].Slot
+		}
+
+		return res[i].Index < res[j].Index
+	})
+
+	return res
+}
+
+// assignments returns the committee assignments for the validators, along
+// with the validators that are not in any of the committees.
+func assignments(committees []*committee, validators []*apiv1.Validator) ([]*assignment, []phase0.ValidatorIndex) {
+	positions := make(map[phase0.ValidatorIndex]*assignment)
+	for _, committee := range committees {
+		for position, index := range committee.Validators {
+			positions[index] = &assignment{
+				ValidatorIndex: index,
+				Slot:           committee.Slot,
+				CommitteeIndex: committee.Index,
+				Position:       position,
+				CommitteeSize:  len(committee.Validators),
+			}
+		}
+	}
+
+	res := make([]*assignment, 0, len(validators))
+	unassigned := make([]phase0.ValidatorIndex, 0)
+	for _, validator := range validators {
+		if assignment, exists := positions[validator.Index]; exists {
+			res = append(res, assignment)
+		} else {
+			unassigned = append(unassigned, validator.Index)
+		}
+	}
+
+	return res, unassigned
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validators")
+	}
+	c.beaconCommitteesProvider, isProvider = c.eth2Client.(eth2client.BeaconCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon committees")
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestercommittees
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectCommittees(t *testing.T) {
+	beaconCommittees := []*apiv1.BeaconCommittee{
+		{Slot: 101, Index: 1, Validators: []phase0.ValidatorIndex{7, 8}},
+		{Slot: 100, Index: 1, Validators: []phase0.ValidatorIndex{3, 4}},
+		{Slot: 101, Index: 0, Validators: []phase0.ValidatorIndex{5, 6}},
+		{Slot: 100, Index: 0, Validators: []phase0.ValidatorIndex{1, 2}},
+	}
+
+	res := selectCommittees(beaconCommittees, nil)
+	require.Len(t, res, 4)
+	require.Equal(t, &committee{Slot: 100, Index: 0, Validators: []phase0.ValidatorIndex{1, 2}}, res[0])
+	require.Equal(t, &committee{Slot: 101, Index: 1, Validators: []phase0.ValidatorIndex{7, 8}}, res[3])
+
+	slot := phase0.Slot(101)
+	res = selectCommittees(beaconCommittees, &slot)
+	require.Equal(t, []*committee{
+		{Slot: 101, Index: 0, Validators: []phase0.ValidatorIndex{5, 6}},
+		{Slot: 101, Index: 1, Validators: []phase0.ValidatorIndex{7, 8}},
+	}, res)
+}
+
+func TestAssignments(t *testing.T) {
+	committees := []*committee{
+		{Slot: 100, Index: 0, Validators: []phase0.ValidatorIndex{1, 2, 3}},
+		{Slot: 101, Index: 2, Validators: []phase0.ValidatorIndex{4, 5}},
+	}
+	validators := []*apiv1.Validator{
+		{Index: 3},
+		{Index: 4},
+		{Index: 9},
+	}
+
+	res, unassigned := assignments(committees, validators)
+	require.Equal(t, []*assignment{
+		{ValidatorIndex: 3, Slot: 100, CommitteeIndex: 0, Position: 2, CommitteeSize: 3},
+		{ValidatorIndex: 4, Slot: 101, CommitteeIndex: 2, Position: 0, CommitteeSize: 2},
+	}, res)
+	require.Equal(t, []phase0.ValidatorIndex{9}, unassigned)
+}
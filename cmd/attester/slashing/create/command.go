@@ -0,0 +1,83 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attesterslashingcreate
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	attestation1 string
+	attestation2 string
+	submit       bool
+
+	// Data access.
+	eth2Client         eth2client.Service
+	chainTime          chaintime.Service
+	domainProvider     eth2client.DomainProvider
+	attesterDomainType phase0.DomainType
+
+	// Output.
+	slashing  *phase0.AttesterSlashing
+	slashable []phase0.ValidatorIndex
+	submitted bool
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.attestation1 = viper.GetString("attestation1")
+	if c.attestation1 == "" {
+		return nil, errors.New("attestation1 is required")
+	}
+	c.attestation2 = viper.GetString("attestation2")
+	if c.attestation2 == "" {
+		return nil, errors.New("attestation2 is required")
+	}
+	c.submit = viper.GetBool("submit")
+
+	return c, nil
+}
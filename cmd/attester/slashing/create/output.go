@@ -0,0 +1,45 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attesterslashingcreate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.submitted && !c.json {
+		slashable := make([]string, len(c.slashable))
+		for i := range c.slashable {
+			slashable[i] = fmt.Sprintf("%d", c.slashable[i])
+		}
+
+		return fmt.Sprintf("Attester slashing of validator %s submitted", strings.Join(slashable, ", ")), nil
+	}
+
+	data, err := json.Marshal(c.slashing)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal attester slashing")
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,365 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attesterslashingcreate
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func (c *command) process(ctx context.Context) error {
+	indexed1, attestation1, err := parseAttestation(c.attestation1)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse attestation1")
+	}
+	indexed2, attestation2, err := parseAttestation(c.attestation2)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse attestation2")
+	}
+
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if indexed1 == nil {
+		if indexed1, err = c.indexAttestation(ctx, attestation1); err != nil {
+			return errors.Wrap(err, "failed to obtain indexed attestation for attestation1")
+		}
+	}
+	if indexed2 == nil {
+		if indexed2, err = c.indexAttestation(ctx, attestation2); err != nil {
+			return errors.Wrap(err, "failed to obtain indexed attestation for attestation2")
+		}
+	}
+
+	slashable, swap := isSlashableAttestationData(indexed1.Data, indexed2.Data)
+	if !slashable {
+		return errors.New("attestations are neither a double vote nor a surround vote")
+	}
+	if swap {
+		// The first attestation of a slashing must be the surrounding one.
+		indexed1, indexed2 = indexed2, indexed1
+	}
+	c.slashing = &phase0.AttesterSlashing{
+		Attestation1: indexed1,
+		Attestation2: indexed2,
+	}
+
+	if err := c.checkValidators(ctx); err != nil {
+		return err
+	}
+
+	for i, attestation := range []*phase0.IndexedAttestation{indexed1, indexed2} {
+		if err := c.verifySignature(ctx, attestation); err != nil {
+			return errors.Wrapf(err, "failed to verify signature of attestation %d", i+1)
+		}
+	}
+
+	if c.submit {
+		submitter, isSubmitter := c.eth2Client.(eth2client.AttesterSlashingSubmitter)
+		if !isSubmitter {
+			return errors.New("connection does not support submitting attester slashings")
+		}
+		if err := submitter.SubmitAttesterSlashing(ctx, c.slashing); err != nil {
+			return errors.Wrap(err, "failed to submit attester slashing")
+		}
+		c.submitted = true
+	}
+
+	return nil
+}
+
+// parseAttestation parses an attestation supplied as JSON, hex-encoded SSZ
+// or the name of a file containing either.  An attestation supplied as JSON
+// can be either an indexed attestation or an attestation with aggregation
+// bits; an attestation supplied as SSZ is an attestation with aggregation bits.
+func parseAttestation(input string) (*phase0.IndexedAttestation, *phase0.Attestation, error) {
+	input = strings.TrimSpace(input)
+	if !strings.HasPrefix(input, "{") && !strings.HasPrefix(input, "0x") {
+		// This looks like a file; read it in.
+		data, err := os.ReadFile(input)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to read attestation file")
+		}
+		input = strings.TrimSpace(string(data))
+	}
+
+	if strings.HasPrefix(input, "0x") {
+		data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "invalid hex")
+		}
+		attestation := &phase0.Attestation{}
+		if err := attestation.UnmarshalSSZ(data); err != nil {
+			return nil, nil, errors.Wrap(err, "invalid SSZ attestation")
+		}
+
+		return nil, attestation, nil
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal([]byte(input), &fields); err != nil {
+		return nil, nil, errors.Wrap(err, "invalid JSON")
+	}
+	if _, exists := fields["attesting_indices"]; exists {
+		indexed := &phase0.IndexedAttestation{}
+		if err := json.Unmarshal([]byte(input), indexed); err != nil {
+			return nil, nil, errors.Wrap(err, "invalid indexed attestation")
+		}
+
+		return indexed, nil, nil
+	}
+	attestation := &phase0.Attestation{}
+	if err := json.Unmarshal([]byte(input), attestation); err != nil {
+		return nil, nil, errors.Wrap(err, "invalid attestation")
+	}
+
+	return nil, attestation, nil
+}
+
+// isSlashableAttestationData returns true if the attestation data is
+// slashable, and if the data needs to be swapped for the first to surround
+// the second.
+func isSlashableAttestationData(data1 *phase0.AttestationData, data2 *phase0.AttestationData) (bool, bool) {
+	// Double vote.
+	if data1.Target.Epoch == data2.Target.Epoch {
+		root1, err := data1.HashTreeRoot()
+		if err != nil {
+			return false, false
+		}
+		root2, err := data2.HashTreeRoot()
+		if err != nil {
+			return false, false
+		}
+
+		return root1 != root2, false
+	}
+
+	// Surround vote.
+	if data1.Source.Epoch < data2.Source.Epoch && data2.Target.Epoch < data1.Target.Epoch {
+		return true, false
+	}
+	if data2.Source.Epoch < data1.Source.Epoch && data1.Target.Epoch < data2.Target.Epoch {
+		return true, true
+	}
+
+	return false, false
+}
+
+// indexAttestation converts an attestation to an indexed attestation
+// using its beacon committee.
+func (c *command) indexAttestation(ctx context.Context, attestation *phase0.Attestation) (*phase0.IndexedAttestation, error) {
+	provider, isProvider := c.eth2Client.(eth2client.BeaconCommitteesProvider)
+	if !isProvider {
+		return nil, errors.New("connection does not provide beacon committees")
+	}
+	response, err := provider.BeaconCommittees(ctx, &api.BeaconCommitteesOpts{
+		State: fmt.Sprintf("%d", attestation.Data.Slot),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain beacon committees")
+	}
+	for _, committee := range response.Data {
+		if committee.Slot == attestation.Data.Slot && committee.Index == attestation.Data.Index {
+			return indexedAttestation(attestation, committee.Validators)
+		}
+	}
+
+	return nil, fmt.Errorf("no committee %d for slot %d", attestation.Data.Index, attestation.Data.Slot)
+}
+
+// indexedAttestation creates an indexed attestation from an attestation
+// and its committee.
+func indexedAttestation(attestation *phase0.Attestation, committee []phase0.ValidatorIndex) (*phase0.IndexedAttestation, error) {
+	if attestation.AggregationBits.Len() != uint64(len(committee)) {
+		return nil, fmt.Errorf("attestation has %d aggregation bits but committee has %d members", attestation.AggregationBits.Len(), len(committee))
+	}
+
+	indices := make([]uint64, 0)
+	for i := range committee {
+		if attestation.AggregationBits.BitAt(uint64(i)) {
+			indices = append(indices, uint64(committee[i]))
+		}
+	}
+	if len(indices) == 0 {
+		return nil, errors.New("attestation has no attesting validators")
+	}
+	sort.Slice(indices, func(i int, j int) bool {
+		return indices[i] < indices[j]
+	})
+
+	return &phase0.IndexedAttestation{
+		AttestingIndices: indices,
+		Data:             attestation.Data,
+		Signature:        attestation.Signature,
+	}, nil
+}
+
+// checkValidators checks that the slashing slashes at least one validator.
+func (c *command) checkValidators(ctx context.Context) error {
+	indices := util.AttesterSlashingIndices(c.slashing)
+	if len(indices) == 0 {
+		return errors.New("attestations have no attesting validators in common")
+	}
+
+	validators, err := util.FetchValidators(ctx, c.eth2Client.(eth2client.ValidatorsProvider), "head", indices, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+	c.slashable = slashableIndices(indices, validators, c.chainTime.CurrentEpoch())
+	if len(c.slashable) == 0 {
+		return errors.New("none of the validators in common are slashable")
+	}
+
+	return nil
+}
+
+// slashableIndices returns the indices of the validators that are slashable.
+func slashableIndices(indices []phase0.ValidatorIndex,
+	validators map[phase0.ValidatorIndex]*apiv1.Validator,
+	epoch phase0.Epoch,
+) []phase0.ValidatorIndex {
+	res := make([]phase0.ValidatorIndex, 0, len(indices))
+	for _, index := range indices {
+		validator, exists := validators[index]
+		if !exists {
+			continue
+		}
+		if !validator.Validator.Slashed &&
+			validator.Validator.ActivationEpoch <= epoch &&
+			epoch < validator.Validator.WithdrawableEpoch {
+			res = append(res, index)
+		}
+	}
+
+	return res
+}
+
+// verifySignature verifies the aggregate signature of the indexed attestation.
+func (c *command) verifySignature(ctx context.Context, attestation *phase0.IndexedAttestation) error {
+	indices := make([]phase0.ValidatorIndex, len(attestation.AttestingIndices))
+	for i := range attestation.AttestingIndices {
+		indices[i] = phase0.ValidatorIndex(attestation.AttestingIndices[i])
+	}
+	validators, err := util.FetchValidators(ctx, c.eth2Client.(eth2client.ValidatorsProvider), "head", indices, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain attesting validators")
+	}
+	pubKeys := make([]e2types.PublicKey, 0, len(indices))
+	for _, index := range indices {
+		validator, exists := validators[index]
+		if !exists {
+			return fmt.Errorf("attesting validator %d not known", index)
+		}
+		pubKey, err := e2types.BLSPublicKeyFromBytes(validator.Validator.PublicKey[:])
+		if err != nil {
+			return errors.Wrapf(err, "invalid public key for validator %d", index)
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+
+	domain, err := c.domainProvider.Domain(ctx, c.attesterDomainType, attestation.Data.Target.Epoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain attester domain")
+	}
+	root, err := signingRoot(attestation.Data, domain)
+	if err != nil {
+		return err
+	}
+
+	signature, err := e2types.BLSSignatureFromBytes(attestation.Signature[:])
+	if err != nil {
+		return errors.Wrap(err, "invalid signature")
+	}
+	if !signature.VerifyAggregateCommon(root[:], pubKeys) {
+		return errors.New("signature is invalid")
+	}
+
+	return nil
+}
+
+// signingRoot calculates the signing root of the attestation data.
+func signingRoot(data *phase0.AttestationData, domain phase0.Domain) (phase0.Root, error) {
+	objectRoot, err := data.HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, errors.Wrap(err, "failed to obtain attestation data root")
+	}
+	root, err := (&phase0.SigningData{
+		ObjectRoot: objectRoot,
+		Domain:     domain,
+	}).HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, errors.Wrap(err, "failed to obtain signing root")
+	}
+
+	return root, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	if _, isProvider = c.eth2Client.(eth2client.ValidatorsProvider); !isProvider {
+		return errors.New("connection does not provide validators")
+	}
+	c.domainProvider, isProvider = c.eth2Client.(eth2client.DomainProvider)
+	if !isProvider {
+		return errors.New("connection does not provide domains")
+	}
+
+	specResponse, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	c.attesterDomainType, isProvider = specResponse.Data["DOMAIN_BEACON_ATTESTER"].(phase0.DomainType)
+	if !isProvider {
+		return errors.New("DOMAIN_BEACON_ATTESTER not found in spec")
+	}
+
+	return nil
+}
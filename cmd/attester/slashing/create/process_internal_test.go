@@ -0,0 +1,214 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attesterslashingcreate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+)
+
+func attestationData(slot phase0.Slot, source phase0.Epoch, target phase0.Epoch, root byte) *phase0.AttestationData {
+	return &phase0.AttestationData{
+		Slot:            slot,
+		Index:           2,
+		BeaconBlockRoot: phase0.Root{root},
+		Source:          &phase0.Checkpoint{Epoch: source},
+		Target:          &phase0.Checkpoint{Epoch: target},
+	}
+}
+
+func TestParseAttestation(t *testing.T) {
+	bits := bitfield.NewBitlist(4)
+	bits.SetBitAt(1, true)
+	attestation := &phase0.Attestation{
+		AggregationBits: bits,
+		Data:            attestationData(100, 2, 3, 0x01),
+		Signature:       phase0.BLSSignature{0x02},
+	}
+	ssz, err := attestation.MarshalSSZ()
+	require.NoError(t, err)
+	attestationJSON := `{"aggregation_bits":"0x12","data":{"slot":"100","index":"2","beacon_block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","source":{"epoch":"2","root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"target":{"epoch":"3","root":"0x0000000000000000000000000000000000000000000000000000000000000000"}},"signature":"0x020000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"}`
+	indexedJSON := `{"attesting_indices":["5","9"],"data":{"slot":"100","index":"2","beacon_block_root":"0x0100000000000000000000000000000000000000000000000000000000000000","source":{"epoch":"2","root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"target":{"epoch":"3","root":"0x0000000000000000000000000000000000000000000000000000000000000000"}},"signature":"0x020000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"}`
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "attestation.json")
+	require.NoError(t, os.WriteFile(file, []byte(indexedJSON+"\n"), 0o600))
+
+	tests := []struct {
+		name        string
+		input       string
+		indexed     bool
+		attestation bool
+		err         string
+	}{
+		{
+			name:        "JSON",
+			input:       attestationJSON,
+			attestation: true,
+		},
+		{
+			name:    "IndexedJSON",
+			input:   indexedJSON,
+			indexed: true,
+		},
+		{
+			name:        "SSZ",
+			input:       fmt.Sprintf("%#x", ssz),
+			attestation: true,
+		},
+		{
+			name:    "File",
+			input:   file,
+			indexed: true,
+		},
+		{
+			name:  "FileMissing",
+			input: filepath.Join(dir, "missing.json"),
+			err:   "failed to read attestation file",
+		},
+		{
+			name:  "BadHex",
+			input: "0xzz",
+			err:   "invalid hex: encoding/hex: invalid byte: U+007A 'z'",
+		},
+		{
+			name:  "BadSSZ",
+			input: "0x0102",
+			err:   "invalid SSZ attestation",
+		},
+		{
+			name:  "BadJSON",
+			input: "{",
+			err:   "invalid JSON: unexpected end of JSON input",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			indexed, attestation, err := parseAttestation(test.input)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.indexed, indexed != nil)
+			require.Equal(t, test.attestation, attestation != nil)
+			if indexed != nil {
+				require.Equal(t, []uint64{5, 9}, indexed.AttestingIndices)
+			}
+			if attestation != nil {
+				require.Equal(t, phase0.Slot(100), attestation.Data.Slot)
+				require.True(t, attestation.AggregationBits.BitAt(1))
+			}
+		})
+	}
+}
+
+func TestIsSlashableAttestationData(t *testing.T) {
+	tests := []struct {
+		name      string
+		data1     *phase0.AttestationData
+		data2     *phase0.AttestationData
+		slashable bool
+		swap      bool
+	}{
+		{
+			name:      "DoubleVote",
+			data1:     attestationData(100, 2, 3, 0x01),
+			data2:     attestationData(100, 2, 3, 0x02),
+			slashable: true,
+		},
+		{
+			name:  "Identical",
+			data1: attestationData(100, 2, 3, 0x01),
+			data2: attestationData(100, 2, 3, 0x01),
+		},
+		{
+			name:      "Surrounds",
+			data1:     attestationData(100, 1, 4, 0x01),
+			data2:     attestationData(100, 2, 3, 0x01),
+			slashable: true,
+		},
+		{
+			name:      "Surrounded",
+			data1:     attestationData(100, 2, 3, 0x01),
+			data2:     attestationData(100, 1, 4, 0x01),
+			slashable: true,
+			swap:      true,
+		},
+		{
+			name:  "Sequential",
+			data1: attestationData(100, 2, 3, 0x01),
+			data2: attestationData(132, 3, 4, 0x01),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			slashable, swap := isSlashableAttestationData(test.data1, test.data2)
+			require.Equal(t, test.slashable, slashable)
+			require.Equal(t, test.swap, swap)
+		})
+	}
+}
+
+func TestIndexedAttestation(t *testing.T) {
+	bits := bitfield.NewBitlist(4)
+	bits.SetBitAt(0, true)
+	bits.SetBitAt(2, true)
+	attestation := &phase0.Attestation{
+		AggregationBits: bits,
+		Data:            attestationData(100, 2, 3, 0x01),
+		Signature:       phase0.BLSSignature{0x02},
+	}
+
+	indexed, err := indexedAttestation(attestation, []phase0.ValidatorIndex{40, 10, 30, 20})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{30, 40}, indexed.AttestingIndices)
+	require.Equal(t, attestation.Data, indexed.Data)
+	require.Equal(t, attestation.Signature, indexed.Signature)
+
+	_, err = indexedAttestation(attestation, []phase0.ValidatorIndex{10, 20})
+	require.EqualError(t, err, "attestation has 4 aggregation bits but committee has 2 members")
+
+	_, err = indexedAttestation(&phase0.Attestation{AggregationBits: bitfield.NewBitlist(2), Data: attestation.Data}, []phase0.ValidatorIndex{10, 20})
+	require.EqualError(t, err, "attestation has no attesting validators")
+}
+
+func TestSlashableIndices(t *testing.T) {
+	validator := func(slashed bool, activationEpoch phase0.Epoch, withdrawableEpoch phase0.Epoch) *apiv1.Validator {
+		return &apiv1.Validator{
+			Validator: &phase0.Validator{
+				Slashed:           slashed,
+				ActivationEpoch:   activationEpoch,
+				WithdrawableEpoch: withdrawableEpoch,
+			},
+		}
+	}
+	validators := map[phase0.ValidatorIndex]*apiv1.Validator{
+		1: validator(false, 10, 1000),
+		2: validator(true, 10, 1000),
+		3: validator(false, 200, 1000),
+		4: validator(false, 10, 50),
+	}
+
+	require.Equal(t, []phase0.ValidatorIndex{1}, slashableIndices([]phase0.ValidatorIndex{1, 2, 3, 4, 5}, validators, 100))
+}
@@ -0,0 +1,69 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	attestercommittees "github.com/wealdtech/ethdo/cmd/attester/committees"
+)
+
+var attesterCommitteesCmd = &cobra.Command{
+	Use:   "committees",
+	Short: "Obtain beacon committees",
+	Long: `Obtain the beacon committees for a slot or epoch.  For example:
+
+    ethdo attester committees --slot=12345
+
+If validators are supplied then the committees in which they attest are shown instead.  For example:
+
+    ethdo attester committees --epoch=1234 --validators=Validators/*
+
+In quiet mode this will return 0 if the committees are obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := attestercommittees.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	attesterCmd.AddCommand(attesterCommitteesCmd)
+	attesterFlags(attesterCommitteesCmd)
+	attesterCommitteesCmd.Flags().String("slot", "", "the slot for which to obtain committees")
+	attesterCommitteesCmd.Flags().String("epoch", "", "the epoch for which to obtain committees (defaults to current epoch)")
+	attesterCommitteesCmd.Flags().StringSlice("validators", nil, "the validators for which to find committees, as indices, public keys or account specifiers")
+}
+
+func attesterCommitteesBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("slot", cmd.Flags().Lookup("slot")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("epoch", cmd.Flags().Lookup("epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	attesterslashingcreate "github.com/wealdtech/ethdo/cmd/attester/slashing/create"
+)
+
+var attesterSlashingCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an attester slashing from conflicting attestations",
+	Long: `Create an attester slashing from two conflicting signed attestations.  For example:
+
+    ethdo attester slashing create --attestation1=attestation1.json --attestation2=attestation2.json
+
+Each attestation can be supplied as JSON, as hex-encoded SSZ, or as the name of a file containing either.  The attestations must form a double vote or a surround vote, and both signatures must be valid.  The slashing is submitted to the beacon node's pool if --submit is supplied.
+
+In quiet mode this will return 0 if the slashing is created, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := attesterslashingcreate.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	attesterSlashingCmd.AddCommand(attesterSlashingCreateCmd)
+	attesterFlags(attesterSlashingCreateCmd)
+	attesterSlashingCreateCmd.Flags().String("attestation1", "", "the first conflicting attestation")
+	attesterSlashingCreateCmd.Flags().String("attestation2", "", "the second conflicting attestation")
+	attesterSlashingCreateCmd.Flags().Bool("submit", false, "submit the slashing to the beacon node")
+}
+
+func attesterSlashingCreateBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("attestation1", cmd.Flags().Lookup("attestation1")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("attestation2", cmd.Flags().Lookup("attestation2")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("submit", cmd.Flags().Lookup("submit")); err != nil {
+		panic(err)
+	}
+}
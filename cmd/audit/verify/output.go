@@ -0,0 +1,39 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditverify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.last == nil {
+		return "Audit log verified; no entries", nil
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("Audit log verified; %d entries\n", c.last.Index+1))
+	builder.WriteString(fmt.Sprintf("Head: %s", c.last.Hash))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("\nLast entry: %s signing root %s with domain %s at %s (%s)", c.last.Account, c.last.Root, c.last.Domain, c.last.Time, c.last.Result))
+	}
+
+	return builder.String(), nil
+}
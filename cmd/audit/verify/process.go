@@ -0,0 +1,46 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditverify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(_ context.Context) error {
+	key, err := util.AuditLogKey()
+	if err != nil {
+		return err
+	}
+	c.last, err = util.VerifyAuditLog(c.auditLog, key)
+	if err != nil {
+		return errors.Wrap(err, "audit log failed verification")
+	}
+
+	if c.head != "" {
+		// Ensure that the log has not been truncated since the head was recorded.
+		if c.last == nil {
+			return errors.New("audit log is empty but a head was supplied")
+		}
+		if !strings.EqualFold(c.head, c.last.Hash) {
+			return fmt.Errorf("audit log head %s does not match the supplied head %s", c.last.Hash, c.head)
+		}
+	}
+
+	return nil
+}
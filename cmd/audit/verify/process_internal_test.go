@@ -0,0 +1,101 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditverify
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	account, err := util.NewScratchAccount(testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"), nil)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	auditLog := filepath.Join(dir, "audit.log")
+	viper.Set("audit-log", auditLog)
+	require.NoError(t, util.AuditSigning(account, phase0.Root{0x01}, phase0.Domain{0x04}, nil))
+	head, err := util.VerifyAuditLog(auditLog, nil)
+	require.NoError(t, err)
+
+	emptyLog := filepath.Join(dir, "empty.log")
+	require.NoError(t, os.WriteFile(emptyLog, nil, 0o600))
+
+	tests := []struct {
+		name string
+		cmd  *command
+		err  string
+	}{
+		{
+			name: "Missing",
+			cmd: &command{
+				auditLog: filepath.Join(dir, "missing.log"),
+			},
+			err: "audit log failed verification: failed to open audit log: open " + filepath.Join(dir, "missing.log") + ": no such file or directory",
+		},
+		{
+			name: "Empty",
+			cmd: &command{
+				auditLog: emptyLog,
+			},
+		},
+		{
+			name: "EmptyWithHead",
+			cmd: &command{
+				auditLog: emptyLog,
+				head:     head.Hash,
+			},
+			err: "audit log is empty but a head was supplied",
+		},
+		{
+			name: "HeadMismatch",
+			cmd: &command{
+				auditLog: auditLog,
+				head:     "0x0000000000000000000000000000000000000000000000000000000000000000",
+			},
+			err: "audit log head " + head.Hash + " does not match the supplied head 0x0000000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name: "Good",
+			cmd: &command{
+				auditLog: auditLog,
+				head:     head.Hash,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.cmd.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
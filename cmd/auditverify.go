@@ -0,0 +1,67 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	auditverify "github.com/wealdtech/ethdo/cmd/audit/verify"
+)
+
+var auditVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify the signing audit log",
+	Long: `Verify that the signing audit log has not been modified.  For example:
+
+    ethdo audit verify --audit-log=/var/log/ethdo-audit.log
+
+The head of the log is reported, and can be supplied with --head in a later verification to ensure that the log has not been truncated.
+
+In quiet mode this will return 0 if the audit log is verified, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := auditverify.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	auditCmd.AddCommand(auditVerifyCmd)
+	auditFlags(auditVerifyCmd)
+	auditVerifyCmd.Flags().String("audit-log", "", "the audit log to verify (defaults to the audit log in the configuration)")
+	auditVerifyCmd.Flags().String("audit-log-key", "", "the key with which the audit log was written, or the path to a file containing it (defaults to the audit log key in the configuration)")
+	auditVerifyCmd.Flags().String("head", "", "the hash of the last entry from a previous verification, to check that the log has not been truncated")
+}
+
+func auditVerifyBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("audit-log", cmd.Flags().Lookup("audit-log")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("audit-log-key", cmd.Flags().Lookup("audit-log-key")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("head", cmd.Flags().Lookup("head")); err != nil {
+		panic(err)
+	}
+}
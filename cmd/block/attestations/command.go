@@ -0,0 +1,101 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockattestations
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	blockID string
+	analyze bool
+
+	// Data access.
+	eth2Client              eth2client.Service
+	blocksProvider          eth2client.SignedBeaconBlockProvider
+	attestationPoolProvider eth2client.AttestationPoolProvider
+
+	// Output.
+	slot         phase0.Slot
+	attestations []*attestation
+	analysis     *analysis
+}
+
+type attestation struct {
+	Slot            phase0.Slot           `json:"slot"`
+	CommitteeIndex  phase0.CommitteeIndex `json:"committee_index"`
+	BeaconBlockRoot phase0.Root           `json:"beacon_block_root"`
+	Votes           uint64                `json:"votes"`
+	CommitteeSize   uint64                `json:"committee_size"`
+	Duplicate       bool                  `json:"duplicate,omitempty"`
+}
+
+type analysis struct {
+	Votes               uint64      `json:"votes"`
+	DistinctVotes       uint64      `json:"distinct_votes"`
+	Duplicates          int         `json:"duplicates"`
+	PoolSlot            phase0.Slot `json:"pool_slot"`
+	PoolAttestations    int         `json:"pool_attestations"`
+	OmittedAttestations int         `json:"omitted_attestations"`
+	OmittedVotes        uint64      `json:"omitted_votes"`
+}
+
+type results struct {
+	Slot         phase0.Slot    `json:"slot"`
+	Attestations []*attestation `json:"attestations"`
+	Analysis     *analysis      `json:"analysis,omitempty"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.blockID = viper.GetString("blockid")
+	if c.blockID == "" {
+		return nil, errors.New("blockid is required")
+	}
+	c.analyze = viper.GetBool("analyze")
+
+	return c, nil
+}
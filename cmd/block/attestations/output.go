@@ -0,0 +1,76 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockattestations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(&results{
+		Slot:         c.slot,
+		Attestations: c.attestations,
+		Analysis:     c.analysis,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Slot: %d\n", c.slot))
+	builder.WriteString(fmt.Sprintf("Attestations: %d\n", len(c.attestations)))
+	for i, attestation := range c.attestations {
+		builder.WriteString(fmt.Sprintf("  %d: slot %d, committee %d, %d/%d votes", i, attestation.Slot, attestation.CommitteeIndex, attestation.Votes, attestation.CommitteeSize))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf(", beacon block root %#x", attestation.BeaconBlockRoot))
+		}
+		if attestation.Duplicate {
+			builder.WriteString(" (duplicate)")
+		}
+		builder.WriteString("\n")
+	}
+
+	if c.analysis != nil {
+		builder.WriteString(fmt.Sprintf("Votes: %d (%d distinct)\n", c.analysis.Votes, c.analysis.DistinctVotes))
+		builder.WriteString(fmt.Sprintf("Duplicate attestations: %d\n", c.analysis.Duplicates))
+		if c.analysis.PoolAttestations == 0 {
+			builder.WriteString(fmt.Sprintf("No attestations for slot %d in the pool\n", c.analysis.PoolSlot))
+		} else {
+			builder.WriteString(fmt.Sprintf("Attestations for slot %d in the pool: %d\n", c.analysis.PoolSlot, c.analysis.PoolAttestations))
+			builder.WriteString(fmt.Sprintf("Omitted attestations: %d (%d votes)\n", c.analysis.OmittedAttestations, c.analysis.OmittedVotes))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
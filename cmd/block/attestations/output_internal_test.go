@@ -0,0 +1,98 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockattestations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	attestations := []*attestation{
+		{
+			Slot:           99,
+			CommitteeIndex: 0,
+			Votes:          3,
+			CommitteeSize:  8,
+		},
+		{
+			Slot:           99,
+			CommitteeIndex: 0,
+			Votes:          2,
+			CommitteeSize:  8,
+			Duplicate:      true,
+		},
+	}
+
+	tests := []struct {
+		name string
+		cmd  *command
+		res  string
+	}{
+		{
+			name: "Attestations",
+			cmd: &command{
+				slot:         100,
+				attestations: attestations,
+			},
+			res: "Slot: 100\nAttestations: 2\n  0: slot 99, committee 0, 3/8 votes\n  1: slot 99, committee 0, 2/8 votes (duplicate)",
+		},
+		{
+			name: "Analysis",
+			cmd: &command{
+				slot:         100,
+				attestations: attestations,
+				analysis: &analysis{
+					Votes:               5,
+					DistinctVotes:       3,
+					Duplicates:          1,
+					PoolSlot:            99,
+					PoolAttestations:    4,
+					OmittedAttestations: 2,
+					OmittedVotes:        3,
+				},
+			},
+			res: "Slot: 100\nAttestations: 2\n  0: slot 99, committee 0, 3/8 votes\n  1: slot 99, committee 0, 2/8 votes (duplicate)\nVotes: 5 (3 distinct)\nDuplicate attestations: 1\nAttestations for slot 99 in the pool: 4\nOmitted attestations: 2 (3 votes)",
+		},
+		{
+			name: "AnalysisNoPool",
+			cmd: &command{
+				slot: 100,
+				analysis: &analysis{
+					PoolSlot: 99,
+				},
+			},
+			res: "Slot: 100\nAttestations: 0\nVotes: 0 (0 distinct)\nDuplicate attestations: 0\nNo attestations for slot 99 in the pool",
+		},
+		{
+			name: "JSON",
+			cmd: &command{
+				json:         true,
+				slot:         100,
+				attestations: attestations[:1],
+			},
+			res: `{"slot":"100","attestations":[{"slot":"99","committee_index":0,"beacon_block_root":"0x0000000000000000000000000000000000000000000000000000000000000000","votes":3,"committee_size":8}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.cmd.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockattestations
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	blockResponse, err := c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+		Block: c.blockID,
+	})
+	if err != nil {
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return errors.New("empty beacon block")
+		}
+
+		return errors.Wrap(err, "failed to obtain beacon block")
+	}
+	c.slot, err = blockResponse.Data.Slot()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain block slot")
+	}
+	blockAttestations, err := blockResponse.Data.Attestations()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain block attestations")
+	}
+
+	c.attestations = make([]*attestation, len(blockAttestations))
+	for i, blockAttestation := range blockAttestations {
+		c.attestations[i] = &attestation{
+			Slot:            blockAttestation.Data.Slot,
+			CommitteeIndex:  blockAttestation.Data.Index,
+			BeaconBlockRoot: blockAttestation.Data.BeaconBlockRoot,
+			Votes:           blockAttestation.AggregationBits.Count(),
+			CommitteeSize:   blockAttestation.AggregationBits.Len(),
+		}
+	}
+
+	if !c.analyze {
+		return nil
+	}
+
+	// Attestations for the slot before the block cannot have been included in
+	// an earlier block, so any in the pool that are not in this block have
+	// been omitted.
+	var poolAttestations []*phase0.Attestation
+	if c.slot > 0 {
+		poolSlot := c.slot - 1
+		if c.debug {
+			fmt.Printf("Fetching attestation pool for slot %d\n", poolSlot)
+		}
+		poolResponse, err := c.attestationPoolProvider.AttestationPool(ctx, &api.AttestationPoolOpts{
+			Slot: &poolSlot,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain attestation pool")
+		}
+		poolAttestations = poolResponse.Data
+	}
+
+	c.analysis, err = analyze(c.attestations, blockAttestations, poolAttestations)
+	if err != nil {
+		return err
+	}
+	if c.slot > 0 {
+		c.analysis.PoolSlot = c.slot - 1
+	}
+
+	return nil
+}
+
+// analyze analyzes the packing of attestations in a block, marking duplicate
+// attestations and comparing the votes included with those in the pool.
+func analyze(attestations []*attestation,
+	blockAttestations []*phase0.Attestation,
+	poolAttestations []*phase0.Attestation,
+) (
+	*analysis,
+	error,
+) {
+	res := &analysis{}
+
+	// Votes included in the block, keyed by attestation data root.
+	included := make(map[phase0.Root]bitfield.Bitlist)
+	keys := make([]phase0.Root, len(blockAttestations))
+	for i, blockAttestation := range blockAttestations {
+		key, err := blockAttestation.Data.HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to calculate attestation data root")
+		}
+		keys[i] = key
+		res.Votes += blockAttestation.AggregationBits.Count()
+
+		// An attestation is a duplicate if all of its votes have already
+		// been included.
+		if votes, exists := included[key]; exists {
+			contained, err := votes.Contains(blockAttestation.AggregationBits)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to compare attestations")
+			}
+			attestations[i].Duplicate = contained
+			votes, err = votes.Or(blockAttestation.AggregationBits)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to combine attestations")
+			}
+			included[key] = votes
+		} else {
+			included[key] = bitfield.Bitlist(append([]byte{}, blockAttestation.AggregationBits...))
+		}
+	}
+
+	// An attestation is also a duplicate if a later attestation contains all
+	// of its votes.
+	for i := range blockAttestations {
+		for j := i + 1; j < len(blockAttestations) && !attestations[i].Duplicate; j++ {
+			if keys[i] != keys[j] || attestations[j].Duplicate {
+				continue
+			}
+			contained, err := blockAttestations[j].AggregationBits.Contains(blockAttestations[i].AggregationBits)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to compare attestations")
+			}
+			attestations[i].Duplicate = contained
+		}
+	}
+
+	for i := range attestations {
+		if attestations[i].Duplicate {
+			res.Duplicates++
+		}
+	}
+	for _, votes := range included {
+		res.DistinctVotes += votes.Count()
+	}
+
+	// Votes in the pool that are not in the block, keyed by attestation data root.
+	omitted := make(map[phase0.Root]bitfield.Bitlist)
+	for _, poolAttestation := range poolAttestations {
+		res.PoolAttestations++
+		key, err := poolAttestation.Data.HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to calculate attestation data root")
+		}
+		missing := bitfield.Bitlist(append([]byte{}, poolAttestation.AggregationBits...))
+		if votes, exists := included[key]; exists {
+			missing, err = missing.And(votes.Not())
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to compare attestations")
+			}
+		}
+		if missing.Count() == 0 {
+			continue
+		}
+		res.OmittedAttestations++
+		if votes, exists := omitted[key]; exists {
+			missing, err = missing.Or(votes)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to combine attestations")
+			}
+		}
+		omitted[key] = missing
+	}
+	for _, votes := range omitted {
+		res.OmittedVotes += votes.Count()
+	}
+
+	return res, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	var isProvider bool
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+	c.attestationPoolProvider, isProvider = c.eth2Client.(eth2client.AttestationPoolProvider)
+	if !isProvider {
+		return errors.New("connection does not provide attestation pools")
+	}
+
+	return nil
+}
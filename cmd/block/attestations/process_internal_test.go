@@ -0,0 +1,106 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockattestations
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+)
+
+func votes(size uint64, indices ...uint64) bitfield.Bitlist {
+	res := bitfield.NewBitlist(size)
+	for _, index := range indices {
+		res.SetBitAt(index, true)
+	}
+
+	return res
+}
+
+func attestationWithVotes(data *phase0.AttestationData, bits bitfield.Bitlist) *phase0.Attestation {
+	return &phase0.Attestation{
+		AggregationBits: bits,
+		Data:            data,
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	data1 := &phase0.AttestationData{
+		Slot:   99,
+		Index:  0,
+		Source: &phase0.Checkpoint{},
+		Target: &phase0.Checkpoint{},
+	}
+	data2 := &phase0.AttestationData{
+		Slot:   99,
+		Index:  1,
+		Source: &phase0.Checkpoint{},
+		Target: &phase0.Checkpoint{},
+	}
+
+	blockAttestations := []*phase0.Attestation{
+		attestationWithVotes(data1, votes(8, 0, 1, 2)),
+		attestationWithVotes(data1, votes(8, 1, 2)),
+		attestationWithVotes(data2, votes(8, 0)),
+		attestationWithVotes(data2, votes(8, 0, 3)),
+	}
+	poolAttestations := []*phase0.Attestation{
+		attestationWithVotes(data1, votes(8, 0, 1)),
+		attestationWithVotes(data1, votes(8, 4, 5)),
+		attestationWithVotes(data1, votes(8, 5, 6)),
+		attestationWithVotes(data2, votes(8, 7)),
+	}
+
+	attestations := make([]*attestation, len(blockAttestations))
+	for i := range attestations {
+		attestations[i] = &attestation{}
+	}
+
+	res, err := analyze(attestations, blockAttestations, poolAttestations)
+	require.NoError(t, err)
+	require.Equal(t, &analysis{
+		Votes:               8,
+		DistinctVotes:       5,
+		Duplicates:          2,
+		PoolAttestations:    4,
+		OmittedAttestations: 3,
+		OmittedVotes:        4,
+	}, res)
+	require.False(t, attestations[0].Duplicate)
+	require.True(t, attestations[1].Duplicate)
+	require.True(t, attestations[2].Duplicate)
+	require.False(t, attestations[3].Duplicate)
+}
+
+func TestAnalyzeNoPool(t *testing.T) {
+	data := &phase0.AttestationData{
+		Slot:   99,
+		Source: &phase0.Checkpoint{},
+		Target: &phase0.Checkpoint{},
+	}
+	blockAttestations := []*phase0.Attestation{
+		attestationWithVotes(data, votes(4, 0, 1)),
+		attestationWithVotes(data, votes(4, 2)),
+	}
+	attestations := []*attestation{{}, {}}
+
+	res, err := analyze(attestations, blockAttestations, nil)
+	require.NoError(t, err)
+	require.Equal(t, &analysis{
+		Votes:         3,
+		DistinctVotes: 3,
+	}, res)
+}
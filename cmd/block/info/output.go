@@ -16,10 +16,8 @@ package blockinfo
 import (
 	"bytes"
 	"context"
-	"encoding/hex"
 	"fmt"
 	"math/big"
-	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -35,6 +33,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/wealdtech/ethdo/util"
 	"github.com/wealdtech/go-string2eth"
 )
 
@@ -1058,88 +1057,35 @@ func attestingIndices(input bitfield.Bitlist, indices []phase0.ValidatorIndex) s
 }
 
 func blockGraffiti(_ context.Context, graffiti []byte) string {
-	if len(graffiti) == 0 || hex.EncodeToString(graffiti) == "0000000000000000000000000000000000000000000000000000000000000000" {
+	decoded := util.DecodeGraffiti(graffiti)
+	if decoded == nil {
 		// No graffiti.
 		return ""
 	}
 
-	// Remove any trailing null characters.
-	graffiti = bytes.TrimRight(graffiti, "\u0000")
-
-	if !utf8.Valid(graffiti) {
-		// Graffiti is not valid UTF-8, return hex.
-		return fmt.Sprintf("Graffiti: %#x\n", graffiti)
-	}
-
-	// See if there is client identification information present in the graffiti.
-	// The client identification will always be the last entry in the graffiti, with a space beforehand.
-	parts := bytes.Split(graffiti, []byte{' '})
-
-	// Consensus and execution client values come from
-	// https://github.com/ethereum/execution-apis/blob/main/src/engine/identification.md
-	consensusClients := map[string]string{
-		"GR": "grandine",
-		"LH": "lighthouse",
-		"LS": "lodestar",
-		"NB": "nimbus",
-		"PM": "prysm",
-		"TK": "teku",
-	}
-	consensusRegex := regexp.MustCompile(`(GR|LH|LS|NB|PM|TK)([0-9a-f]*)`)
-	consensusData := consensusRegex.Find(parts[len(parts)-1])
-
-	executionClients := map[string]string{
-		"BU": "besu",
-		"EG": "erigon",
-		"EJ": "ethereumJS",
-		"GE": "go-ethereum",
-		"NM": "nethermind",
-		"RH": "reth",
-	}
-	executionRegex := regexp.MustCompile(`(BU|EG|EJ|GE|NM|RH)([0-9a-f]*)`)
-	executionData := executionRegex.Find(parts[len(parts)-1])
-
-	if len(consensusData) == 0 && len(executionData) == 0 {
-		// There is no identifier; return the graffiti as-is.
-		return fmt.Sprintf("Graffiti: %s\n", string(graffiti))
-	}
-
 	res := strings.Builder{}
 
-	truncatedGraffiti := bytes.Join(parts[0:len(parts)-1], []byte(" "))
-	if len(truncatedGraffiti) > 0 {
-		res.WriteString(fmt.Sprintf("Graffiti: %s\n", string(truncatedGraffiti)))
+	if decoded.Text != "" {
+		res.WriteString(fmt.Sprintf("Graffiti: %s\n", decoded.Text))
 	}
 
-	if len(consensusData) > 0 {
-		consensusClient := consensusData[0:2]
-		consensusHash := ""
-		if len(consensusData) > 2 {
-			consensusHash = string(consensusData[2:])
-		}
-
+	if decoded.ConsensusClient != "" {
 		res.WriteString("Consensus client: ")
-		res.WriteString(consensusClients[string(consensusClient)])
-		if consensusHash != "" {
+		res.WriteString(decoded.ConsensusClient)
+		if decoded.ConsensusVersion != "" {
 			res.WriteString(" (version hash ")
-			res.WriteString(consensusHash)
+			res.WriteString(decoded.ConsensusVersion)
 			res.WriteString(")")
 		}
 		res.WriteString("\n")
 	}
 
-	if len(executionData) > 0 {
-		executionClient := executionData[0:2]
-		executionHash := ""
-		if len(executionData) > 2 {
-			executionHash = string(executionData[2:])
-		}
-
+	if decoded.ExecutionClient != "" {
 		res.WriteString("Execution client: ")
-		res.WriteString(executionClients[string(executionClient)])
-		if executionHash != "" {
+		res.WriteString(decoded.ExecutionClient)
+		if decoded.ExecutionVersion != "" {
 			res.WriteString(" (version hash ")
-			res.WriteString(executionHash)
+			res.WriteString(decoded.ExecutionVersion)
 			res.WriteString(")")
 		}
 		res.WriteString("\n")
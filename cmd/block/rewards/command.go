@@ -0,0 +1,95 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockrewards
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	blockID string
+	relays  []string
+
+	// Data access.
+	eth2Client     eth2client.Service
+	blocksProvider eth2client.SignedBeaconBlockProvider
+
+	// Output.
+	rewards *rewards
+}
+
+type rewards struct {
+	Slot              phase0.Slot           `json:"slot"`
+	Root              phase0.Root           `json:"root"`
+	ProposerIndex     phase0.ValidatorIndex `json:"proposer_index"`
+	Total             phase0.Gwei           `json:"total"`
+	Attestations      phase0.Gwei           `json:"attestations"`
+	SyncAggregate     phase0.Gwei           `json:"sync_aggregate"`
+	ProposerSlashings phase0.Gwei           `json:"proposer_slashings"`
+	AttesterSlashings phase0.Gwei           `json:"attester_slashings"`
+	Execution         *executionRewards     `json:"execution,omitempty"`
+}
+
+type executionRewards struct {
+	BlockNumber  uint64                     `json:"block_number"`
+	BlockHash    phase0.Hash32              `json:"block_hash"`
+	FeeRecipient bellatrix.ExecutionAddress `json:"fee_recipient"`
+	Relay        string                     `json:"relay,omitempty"`
+	Value        *big.Int                   `json:"value,omitempty"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.blockID = viper.GetString("blockid")
+	if c.blockID == "" {
+		return nil, errors.New("blockid is required")
+	}
+	c.relays = viper.GetStringSlice("relays")
+
+	return c, nil
+}
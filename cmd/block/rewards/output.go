@@ -0,0 +1,74 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockrewards
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.rewards)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Slot: %d\n", c.rewards.Slot))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Block root: %#x\n", c.rewards.Root))
+	}
+	builder.WriteString(fmt.Sprintf("Proposer index: %d\n", c.rewards.ProposerIndex))
+	builder.WriteString(fmt.Sprintf("Consensus reward: %s\n", string2eth.GWeiToString(uint64(c.rewards.Total), true)))
+	builder.WriteString(fmt.Sprintf("  Attestations: %s\n", string2eth.GWeiToString(uint64(c.rewards.Attestations), true)))
+	builder.WriteString(fmt.Sprintf("  Sync aggregate: %s\n", string2eth.GWeiToString(uint64(c.rewards.SyncAggregate), true)))
+	builder.WriteString(fmt.Sprintf("  Proposer slashings: %s\n", string2eth.GWeiToString(uint64(c.rewards.ProposerSlashings), true)))
+	builder.WriteString(fmt.Sprintf("  Attester slashings: %s\n", string2eth.GWeiToString(uint64(c.rewards.AttesterSlashings), true)))
+
+	if c.rewards.Execution != nil {
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("Execution block: %d (%#x)\n", c.rewards.Execution.BlockNumber, c.rewards.Execution.BlockHash))
+		}
+		builder.WriteString(fmt.Sprintf("Fee recipient: %s\n", c.rewards.Execution.FeeRecipient.String()))
+		switch {
+		case c.rewards.Execution.Value != nil:
+			builder.WriteString(fmt.Sprintf("Execution value: %s (delivered by %s)\n", string2eth.WeiToString(c.rewards.Execution.Value, true), c.rewards.Execution.Relay))
+		case len(c.relays) > 0:
+			builder.WriteString("Execution value: unknown (not delivered by a supplied relay)\n")
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
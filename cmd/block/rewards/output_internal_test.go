@@ -0,0 +1,99 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockrewards
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	consensusRewards := func() *rewards {
+		return &rewards{
+			Slot:              100,
+			ProposerIndex:     12,
+			Total:             40000000,
+			Attestations:      35000000,
+			SyncAggregate:     5000000,
+			ProposerSlashings: 0,
+			AttesterSlashings: 0,
+		}
+	}
+
+	tests := []struct {
+		name string
+		cmd  *command
+		res  string
+	}{
+		{
+			name: "ConsensusOnly",
+			cmd: &command{
+				rewards: consensusRewards(),
+			},
+			res: "Slot: 100\nProposer index: 12\nConsensus reward: 0.04 Ether\n  Attestations: 0.035 Ether\n  Sync aggregate: 0.005 Ether\n  Proposer slashings: 0\n  Attester slashings: 0",
+		},
+		{
+			name: "ExecutionNotDelivered",
+			cmd: &command{
+				relays: []string{"relay.example.com"},
+				rewards: func() *rewards {
+					r := consensusRewards()
+					r.Execution = &executionRewards{
+						FeeRecipient: bellatrix.ExecutionAddress{0x01},
+					}
+
+					return r
+				}(),
+			},
+			res: "Slot: 100\nProposer index: 12\nConsensus reward: 0.04 Ether\n  Attestations: 0.035 Ether\n  Sync aggregate: 0.005 Ether\n  Proposer slashings: 0\n  Attester slashings: 0\nFee recipient: 0x0100000000000000000000000000000000000000\nExecution value: unknown (not delivered by a supplied relay)",
+		},
+		{
+			name: "ExecutionDelivered",
+			cmd: &command{
+				relays: []string{"relay.example.com"},
+				rewards: func() *rewards {
+					r := consensusRewards()
+					r.Execution = &executionRewards{
+						FeeRecipient: bellatrix.ExecutionAddress{0x01},
+						Relay:        "relay.example.com",
+						Value:        big.NewInt(50000000000000000),
+					}
+
+					return r
+				}(),
+			},
+			res: "Slot: 100\nProposer index: 12\nConsensus reward: 0.04 Ether\n  Attestations: 0.035 Ether\n  Sync aggregate: 0.005 Ether\n  Proposer slashings: 0\n  Attester slashings: 0\nFee recipient: 0x0100000000000000000000000000000000000000\nExecution value: 0.05 Ether (delivered by relay.example.com)",
+		},
+		{
+			name: "JSON",
+			cmd: &command{
+				json:    true,
+				rewards: consensusRewards(),
+			},
+			res: `{"slot":"100","root":"0x0000000000000000000000000000000000000000000000000000000000000000","proposer_index":"12","total":"40000000","attestations":"35000000","sync_aggregate":"5000000","proposer_slashings":"0","attester_slashings":"0"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.cmd.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockrewards
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	blockResponse, err := c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+		Block: c.blockID,
+	})
+	if err != nil {
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return errors.New("empty beacon block")
+		}
+
+		return errors.Wrap(err, "failed to obtain beacon block")
+	}
+	block := blockResponse.Data
+
+	slot, err := block.Slot()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain block slot")
+	}
+	root, err := block.Root()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain block root")
+	}
+
+	blockRewards, err := util.FetchBlockRewards(ctx, c.eth2Client, fmt.Sprintf("%#x", root))
+	if err != nil {
+		return util.ExplainNodeCapabilityFailure(ctx, c.eth2Client, util.NodeCapabilityRewards, err)
+	}
+	c.rewards = &rewards{
+		Slot:              slot,
+		Root:              root,
+		ProposerIndex:     blockRewards.ProposerIndex,
+		Total:             blockRewards.Total,
+		Attestations:      blockRewards.Attestations,
+		SyncAggregate:     blockRewards.SyncAggregate,
+		ProposerSlashings: blockRewards.ProposerSlashings,
+		AttesterSlashings: blockRewards.AttesterSlashings,
+	}
+
+	c.rewards.Execution, err = blockExecutionRewards(block)
+	if err != nil {
+		return err
+	}
+	if c.rewards.Execution != nil {
+		if err := c.obtainExecutionValue(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// blockExecutionRewards returns the execution information for the block, or
+// nil if the block predates the merge.
+func blockExecutionRewards(block *spec.VersionedSignedBeaconBlock) (*executionRewards, error) {
+	res := &executionRewards{}
+	switch block.Version {
+	case spec.DataVersionPhase0, spec.DataVersionAltair:
+		return nil, nil
+	case spec.DataVersionBellatrix:
+		res.FeeRecipient = block.Bellatrix.Message.Body.ExecutionPayload.FeeRecipient
+	case spec.DataVersionCapella:
+		res.FeeRecipient = block.Capella.Message.Body.ExecutionPayload.FeeRecipient
+	case spec.DataVersionDeneb:
+		res.FeeRecipient = block.Deneb.Message.Body.ExecutionPayload.FeeRecipient
+	default:
+		return nil, fmt.Errorf("unhandled block version %v", block.Version)
+	}
+
+	var err error
+	res.BlockNumber, err = block.ExecutionBlockNumber()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain execution block number")
+	}
+	res.BlockHash, err = block.ExecutionBlockHash()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain execution block hash")
+	}
+
+	return res, nil
+}
+
+// obtainExecutionValue obtains the value of the execution payload paid to the
+// proposer from the relay that delivered it, if any.
+func (c *command) obtainExecutionValue(ctx context.Context) error {
+	for _, input := range c.relays {
+		relay, err := util.ParseRelayURL(input)
+		if err != nil {
+			return err
+		}
+		payload, err := util.FetchDeliveredPayload(ctx, relay, c.rewards.Slot)
+		if err != nil {
+			// Relays are only an optional source of information.
+			if c.debug {
+				fmt.Printf("Failed to obtain delivered payload from %s: %v\n", relay.Host, err)
+			}
+			continue
+		}
+		if payload == nil || payload.BlockHash != c.rewards.Execution.BlockHash {
+			continue
+		}
+		c.rewards.Execution.Relay = relay.Host
+		c.rewards.Execution.Value = payload.Value
+
+		return nil
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	var isProvider bool
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockrewards
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockExecutionRewards(t *testing.T) {
+	feeRecipient := bellatrix.ExecutionAddress{0x8f, 0x08, 0x44}
+	blockHash := phase0.Hash32{0x01, 0x02}
+
+	tests := []struct {
+		name  string
+		block *spec.VersionedSignedBeaconBlock
+		res   *executionRewards
+		err   string
+	}{
+		{
+			name: "Altair",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionAltair,
+				Altair:  &altair.SignedBeaconBlock{},
+			},
+		},
+		{
+			name: "Capella",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionCapella,
+				Capella: &capella.SignedBeaconBlock{
+					Message: &capella.BeaconBlock{
+						Body: &capella.BeaconBlockBody{
+							ExecutionPayload: &capella.ExecutionPayload{
+								FeeRecipient: feeRecipient,
+								BlockNumber:  12345,
+								BlockHash:    blockHash,
+							},
+						},
+					},
+				},
+			},
+			res: &executionRewards{
+				BlockNumber:  12345,
+				BlockHash:    blockHash,
+				FeeRecipient: feeRecipient,
+			},
+		},
+		{
+			name: "Unknown",
+			block: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersion(99),
+			},
+			err: "unhandled block version unknown",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := blockExecutionRewards(test.block)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	blockattestations "github.com/wealdtech/ethdo/cmd/block/attestations"
+)
+
+var blockAttestationsCmd = &cobra.Command{
+	Use:   "attestations",
+	Short: "Obtain the attestations in a block",
+	Long: `Obtain the attestations included in a block, and optionally analyze how well they were packed.  For example:
+
+    ethdo block attestations --blockid=12345 --analyze
+
+Analysis reports the total and distinct votes included in the block, attestations that did not add any new votes, and attestations for the previous slot that were in the beacon node's attestation pool but were not included.
+
+In quiet mode this will return 0 if the block attestations are obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := blockattestations.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	blockCmd.AddCommand(blockAttestationsCmd)
+	blockFlags(blockAttestationsCmd)
+	blockAttestationsCmd.Flags().String("blockid", "head", "the ID of the block for which to obtain attestations")
+	blockAttestationsCmd.Flags().Bool("analyze", false, "analyze the packing of attestations in the block")
+}
+
+func blockAttestationsBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("blockid", cmd.Flags().Lookup("blockid")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("analyze", cmd.Flags().Lookup("analyze")); err != nil {
+		panic(err)
+	}
+}
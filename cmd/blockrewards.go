@@ -0,0 +1,63 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	blockrewards "github.com/wealdtech/ethdo/cmd/block/rewards"
+)
+
+var blockRewardsCmd = &cobra.Command{
+	Use:   "rewards",
+	Short: "Obtain the rewards for a block",
+	Long: `Obtain a breakdown of the rewards paid to the proposer of a block.  For example:
+
+    ethdo block rewards --blockid=12345
+
+The value of the execution payload is obtained from the relay that delivered it, if it is one of those supplied with --relays.
+
+In quiet mode this will return 0 if the block rewards are obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := blockrewards.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	blockCmd.AddCommand(blockRewardsCmd)
+	blockFlags(blockRewardsCmd)
+	blockRewardsCmd.Flags().String("blockid", "head", "the ID of the block for which to obtain rewards")
+	blockRewardsCmd.Flags().StringSlice("relays", nil, "relays from which to obtain the value of the execution payload")
+}
+
+func blockRewardsBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("blockid", cmd.Flags().Lookup("blockid")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("relays", cmd.Flags().Lookup("relays")); err != nil {
+		panic(err)
+	}
+}
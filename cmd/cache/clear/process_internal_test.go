@@ -0,0 +1,45 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cacheclear
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	dir := t.TempDir()
+	viper.Reset()
+	viper.Set("cache-dir", dir)
+	defer viper.Reset()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "validators-0x0102030405060708.json"), []byte("{}"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.json"), []byte("{}"), 0o600))
+
+	ctx := context.Background()
+	c, err := newCommand(ctx)
+	require.NoError(t, err)
+	require.NoError(t, c.process(ctx))
+	require.Equal(t, 1, c.cleared)
+
+	_, err = os.Stat(filepath.Join(dir, "validators-0x0102030405060708.json"))
+	require.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(dir, "other.json"))
+	require.NoError(t, err)
+}
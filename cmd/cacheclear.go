@@ -0,0 +1,50 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	cacheclear "github.com/wealdtech/ethdo/cmd/cache/clear"
+)
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Clear the local cache",
+	Long: `Clear the local cache of validator indices and activation information.  For example:
+
+    ethdo cache clear
+
+In quiet mode this will return 0 if the cache is cleared, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := cacheclear.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheClearCmd)
+	cacheFlags(cacheClearCmd)
+}
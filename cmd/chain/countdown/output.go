@@ -0,0 +1,76 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaincountdown
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wealdtech/ethdo/util"
+)
+
+type targetJSON struct {
+	*target
+	SecondsRemaining int64 `json:"seconds_remaining"`
+}
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet || c.live {
+		// The countdown is output as it progresses when live.
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON()
+	}
+
+	return c.outputText(), nil
+}
+
+func (c *command) outputJSON() (string, error) {
+	remaining := c.remaining.Truncate(time.Second)
+	if remaining < 0 {
+		remaining = 0
+	}
+	data, err := json.Marshal(&targetJSON{
+		target:           c.target,
+		SecondsRemaining: int64(remaining.Seconds()),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText() string {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Target: %s\n", c.target.Description))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Epoch: %d\n", c.target.Epoch))
+		builder.WriteString(fmt.Sprintf("Slot: %d\n", c.target.Slot))
+	}
+	builder.WriteString(fmt.Sprintf("Time: %s\n", c.target.Time.Format("2006-01-02 15:04:05")))
+	if c.remaining > 0 {
+		builder.WriteString(fmt.Sprintf("Remaining: %s\n", util.FormatDuration(c.remaining)))
+	} else {
+		builder.WriteString(fmt.Sprintf("Remaining: none (reached %s ago)\n", util.FormatDuration(-c.remaining)))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n")
+}
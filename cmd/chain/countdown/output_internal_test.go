@@ -0,0 +1,118 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaincountdown
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	denebTarget := &target{
+		Description: "Deneb fork",
+		Slot:        8626176,
+		Epoch:       269568,
+		Time:        time.Date(2024, 3, 13, 13, 55, 35, 0, time.Local),
+	}
+
+	tests := []struct {
+		name    string
+		command *command
+		res     string
+		err     string
+	}{
+		{
+			name: "Quiet",
+			command: &command{
+				quiet:  true,
+				target: denebTarget,
+			},
+		},
+		{
+			name: "Live",
+			command: &command{
+				live:   true,
+				target: denebTarget,
+			},
+		},
+		{
+			name: "Remaining",
+			command: &command{
+				target:    denebTarget,
+				remaining: 26*time.Hour + 3*time.Minute + 4*time.Second + 500*time.Millisecond,
+			},
+			res: "Target: Deneb fork\nTime: 2024-03-13 13:55:35\nRemaining: 1d2h3m4s",
+		},
+		{
+			name: "Reached",
+			command: &command{
+				target:    denebTarget,
+				remaining: -90 * time.Second,
+			},
+			res: "Target: Deneb fork\nTime: 2024-03-13 13:55:35\nRemaining: none (reached 1m30s ago)",
+		},
+		{
+			name: "Verbose",
+			command: &command{
+				verbose:   true,
+				target:    denebTarget,
+				remaining: time.Minute,
+			},
+			res: "Target: Deneb fork\nEpoch: 269568\nSlot: 8626176\nTime: 2024-03-13 13:55:35\nRemaining: 1m0s",
+		},
+		{
+			name: "JSON",
+			command: &command{
+				json: true,
+				target: &target{
+					Description: "Deneb fork",
+					Slot:        8626176,
+					Epoch:       269568,
+					Time:        time.Date(2024, 3, 13, 13, 55, 35, 0, time.UTC),
+				},
+				remaining: 90*time.Second + 500*time.Millisecond,
+			},
+			res: `{"description":"Deneb fork","slot":"8626176","epoch":"269568","time":"2024-03-13T13:55:35Z","seconds_remaining":90}`,
+		},
+		{
+			name: "JSONReached",
+			command: &command{
+				json: true,
+				target: &target{
+					Description: "Epoch 1",
+					Slot:        32,
+					Epoch:       1,
+					Time:        time.Date(2020, 12, 1, 12, 6, 47, 0, time.UTC),
+				},
+				remaining: -time.Hour,
+			},
+			res: `{"description":"Epoch 1","slot":"32","epoch":"1","time":"2020-12-01T12:06:47Z","seconds_remaining":0}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.command.output(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
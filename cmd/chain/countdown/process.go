@@ -0,0 +1,205 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaincountdown
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	var err error
+	c.target, err = c.parseTarget(time.Now())
+	if err != nil {
+		return err
+	}
+	c.remaining = time.Until(c.target.Time)
+
+	if c.live {
+		return c.countdown(ctx)
+	}
+
+	return nil
+}
+
+// countdown updates the remaining time each second until the target is reached.
+func (c *command) countdown(ctx context.Context) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		c.remaining = time.Until(c.target.Time)
+		if c.remaining <= 0 {
+			if !c.quiet {
+				fmt.Printf("\r%s reached\033[K\n", c.target.Description)
+			}
+			return nil
+		}
+		if !c.quiet {
+			// Round up, so that the final second of the countdown shows as 1s.
+			fmt.Printf("\r%s in %s\033[K", c.target.Description, util.FormatDuration(c.remaining+time.Second-1))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// parseTarget parses the target of the countdown, which can be a slot,
+// an epoch, the name of a fork or a timestamp.
+func (c *command) parseTarget(now time.Time) (*target, error) {
+	to := strings.TrimSpace(c.to)
+
+	switch {
+	case strings.HasPrefix(to, "slot:"):
+		return c.slotTarget(strings.TrimPrefix(to, "slot:"))
+	case strings.HasPrefix(to, "epoch:"):
+		return c.epochTarget(strings.TrimPrefix(to, "epoch:"))
+	case strings.HasPrefix(to, "timestamp:"):
+		return c.timestampTarget(strings.TrimPrefix(to, "timestamp:"), now)
+	}
+
+	if _, err := strconv.ParseUint(to, 10, 64); err == nil {
+		// A bare number is a slot.
+		return c.slotTarget(to)
+	}
+
+	if _, exists := c.spec[forkEpochKey(to)]; exists {
+		return c.forkTarget(to)
+	}
+
+	return c.timestampTarget(to, now)
+}
+
+func (c *command) slotTarget(input string) (*target, error) {
+	val, err := strconv.ParseUint(input, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid slot")
+	}
+	slot := phase0.Slot(val)
+
+	return &target{
+		Description: fmt.Sprintf("Slot %d", slot),
+		Slot:        slot,
+		Epoch:       c.chainTime.SlotToEpoch(slot),
+		Time:        c.chainTime.StartOfSlot(slot),
+	}, nil
+}
+
+func (c *command) epochTarget(input string) (*target, error) {
+	val, err := strconv.ParseUint(input, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid epoch")
+	}
+	epoch := phase0.Epoch(val)
+
+	return &target{
+		Description: fmt.Sprintf("Epoch %d", epoch),
+		Slot:        c.chainTime.FirstSlotOfEpoch(epoch),
+		Epoch:       epoch,
+		Time:        c.chainTime.StartOfEpoch(epoch),
+	}, nil
+}
+
+func (c *command) forkTarget(name string) (*target, error) {
+	key := forkEpochKey(name)
+	val, isEpoch := c.spec[key].(uint64)
+	if !isEpoch {
+		return nil, fmt.Errorf("invalid %s in spec", key)
+	}
+	if val == math.MaxUint64 {
+		return nil, fmt.Errorf("%s fork is not scheduled", strings.ToLower(name))
+	}
+	epoch := phase0.Epoch(val)
+
+	return &target{
+		Description: fmt.Sprintf("%s fork", strings.ToUpper(name[:1])+strings.ToLower(name[1:])),
+		Slot:        c.chainTime.FirstSlotOfEpoch(epoch),
+		Epoch:       epoch,
+		Time:        c.chainTime.StartOfEpoch(epoch),
+	}, nil
+}
+
+func (c *command) timestampTarget(input string, now time.Time) (*target, error) {
+	timestamp, err := util.ParseTimestamp(input, time.Local, now)
+	if err != nil {
+		return nil, errors.Wrap(err, "target must be a slot, epoch, fork name or timestamp")
+	}
+	if timestamp.Before(c.chainTime.GenesisTime()) {
+		return nil, errors.New("timestamp is before genesis")
+	}
+	slot := c.chainTime.TimestampToSlot(timestamp)
+
+	return &target{
+		Description: timestamp.Format("2006-01-02 15:04:05"),
+		Slot:        slot,
+		Epoch:       c.chainTime.SlotToEpoch(slot),
+		Time:        timestamp,
+	}, nil
+}
+
+// forkEpochKey returns the spec key for the epoch of the named fork.
+func forkEpochKey(name string) string {
+	return fmt.Sprintf("%s_FORK_EPOCH", strings.ToUpper(name))
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	specResponse, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	c.spec = specResponse.Data
+
+	return nil
+}
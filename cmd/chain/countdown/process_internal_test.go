@@ -0,0 +1,170 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaincountdown
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/testing/mock"
+)
+
+func TestParseTarget(t *testing.T) {
+	ctx := context.Background()
+	genesisTime := time.Unix(1606824023, 0)
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider(12*time.Second, 32, 256)),
+	)
+	require.NoError(t, err)
+	spec := map[string]any{
+		"DENEB_FORK_EPOCH":   uint64(269568),
+		"ELECTRA_FORK_EPOCH": uint64(math.MaxUint64),
+	}
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name     string
+		to       string
+		expected *target
+		err      string
+	}{
+		{
+			name: "Slot",
+			to:   "100",
+			expected: &target{
+				Description: "Slot 100",
+				Slot:        100,
+				Epoch:       3,
+				Time:        time.Unix(1606825223, 0),
+			},
+		},
+		{
+			name: "SlotPrefix",
+			to:   "slot:64",
+			expected: &target{
+				Description: "Slot 64",
+				Slot:        64,
+				Epoch:       2,
+				Time:        time.Unix(1606824791, 0),
+			},
+		},
+		{
+			name: "SlotInvalid",
+			to:   "slot:-1",
+			err:  `invalid slot: strconv.ParseUint: parsing "-1": invalid syntax`,
+		},
+		{
+			name: "Epoch",
+			to:   "epoch:2",
+			expected: &target{
+				Description: "Epoch 2",
+				Slot:        64,
+				Epoch:       2,
+				Time:        time.Unix(1606824791, 0),
+			},
+		},
+		{
+			name: "EpochInvalid",
+			to:   "epoch:two",
+			err:  `invalid epoch: strconv.ParseUint: parsing "two": invalid syntax`,
+		},
+		{
+			name: "Fork",
+			to:   "deneb",
+			expected: &target{
+				Description: "Deneb fork",
+				Slot:        8626176,
+				Epoch:       269568,
+				Time:        time.Unix(1710338135, 0),
+			},
+		},
+		{
+			name: "ForkUpperCase",
+			to:   "DENEB",
+			expected: &target{
+				Description: "Deneb fork",
+				Slot:        8626176,
+				Epoch:       269568,
+				Time:        time.Unix(1710338135, 0),
+			},
+		},
+		{
+			name: "ForkUnscheduled",
+			to:   "electra",
+			err:  "electra fork is not scheduled",
+		},
+		{
+			name: "Timestamp",
+			to:   "2020-12-01T12:06:50Z",
+			expected: &target{
+				Description: time.Unix(1606824410, 0).Format("2006-01-02 15:04:05"),
+				Slot:        32,
+				Epoch:       1,
+				Time:        time.Unix(1606824410, 0),
+			},
+		},
+		{
+			name: "TimestampUnix",
+			to:   "timestamp:1606824410",
+			expected: &target{
+				Description: time.Unix(1606824410, 0).Format("2006-01-02 15:04:05"),
+				Slot:        32,
+				Epoch:       1,
+				Time:        time.Unix(1606824410, 0),
+			},
+		},
+		{
+			name: "TimestampBeforeGenesis",
+			to:   "timestamp:1606824000",
+			err:  "timestamp is before genesis",
+		},
+		{
+			name: "Invalid",
+			to:   "fulu",
+			err:  "target must be a slot, epoch, fork name or timestamp: invalid timestamp specified",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				to:        test.to,
+				chainTime: chainTime,
+				spec:      spec,
+			}
+			res, err := c.parseTarget(now)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected.Description, res.Description)
+				require.Equal(t, test.expected.Slot, res.Slot)
+				require.Equal(t, test.expected.Epoch, res.Epoch)
+				require.True(t, test.expected.Time.Equal(res.Time))
+			}
+		})
+	}
+}
+
+func TestForkEpochKey(t *testing.T) {
+	require.Equal(t, "DENEB_FORK_EPOCH", forkEpochKey("deneb"))
+	require.Equal(t, "ELECTRA_FORK_EPOCH", forkEpochKey("Electra"))
+}
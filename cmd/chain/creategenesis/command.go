@@ -0,0 +1,114 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaincreategenesis
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// defaultETH1BlockHash is the execution block hash used by convention for interop genesis states.
+var defaultETH1BlockHash = phase0.Hash32{
+	0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42,
+	0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42,
+}
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Input.
+	depositData            string
+	interopValidators      uint64
+	genesisTime            uint64
+	eth1BlockHash          phase0.Hash32
+	executionPayloadHeader string
+	file                   string
+
+	// Data.
+	spec     map[string]any
+	deposits []*util.DepositInfo
+
+	// Output.
+	state   beaconState
+	summary *summary
+}
+
+// summary is a summary of the genesis state.
+type summary struct {
+	Fork                  spec.DataVersion
+	ForkVersion           phase0.Version
+	GenesisTime           uint64
+	GenesisValidatorsRoot phase0.Root
+	Deposits              uint64
+	InvalidDeposits       uint64
+	Validators            uint64
+	ActiveValidators      uint64
+	StateRoot             phase0.Root
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// The network configuration itself is obtained when processing.
+	if viper.GetString("network-config") == "" {
+		return nil, errors.New("network config is required")
+	}
+
+	c.depositData = viper.GetString("deposit-data")
+	c.interopValidators = viper.GetUint64("interop-validators")
+	if c.depositData == "" && c.interopValidators == 0 {
+		return nil, errors.New("deposit data or interop validators is required")
+	}
+
+	c.genesisTime = viper.GetUint64("genesis-time")
+	if c.genesisTime == 0 {
+		return nil, errors.New("genesis time is required")
+	}
+
+	c.eth1BlockHash = defaultETH1BlockHash
+	if viper.GetString("eth1-block-hash") != "" {
+		data, err := hex.DecodeString(strings.TrimPrefix(viper.GetString("eth1-block-hash"), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid eth1 block hash")
+		}
+		if len(data) != len(c.eth1BlockHash) {
+			return nil, errors.New("eth1 block hash must be 32 bytes")
+		}
+		copy(c.eth1BlockHash[:], data)
+	}
+
+	c.executionPayloadHeader = viper.GetString("execution-payload-header")
+
+	c.file = viper.GetString("file")
+	if c.file == "" {
+		return nil, errors.New("file is required")
+	}
+
+	return c, nil
+}
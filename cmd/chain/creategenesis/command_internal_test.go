@@ -0,0 +1,115 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaincreategenesis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "NetworkConfigMissing",
+			vars: map[string]interface{}{
+				"interop-validators": 64,
+				"genesis-time":       1700000000,
+				"file":               "genesis.ssz",
+			},
+			err: "network config is required",
+		},
+		{
+			name: "DepositsMissing",
+			vars: map[string]interface{}{
+				"network-config": "config.yaml",
+				"genesis-time":   1700000000,
+				"file":           "genesis.ssz",
+			},
+			err: "deposit data or interop validators is required",
+		},
+		{
+			name: "GenesisTimeMissing",
+			vars: map[string]interface{}{
+				"network-config":     "config.yaml",
+				"interop-validators": 64,
+				"file":               "genesis.ssz",
+			},
+			err: "genesis time is required",
+		},
+		{
+			name: "ETH1BlockHashInvalid",
+			vars: map[string]interface{}{
+				"network-config":     "config.yaml",
+				"interop-validators": 64,
+				"genesis-time":       1700000000,
+				"eth1-block-hash":    "invalid",
+				"file":               "genesis.ssz",
+			},
+			err: "invalid eth1 block hash: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name: "ETH1BlockHashShort",
+			vars: map[string]interface{}{
+				"network-config":     "config.yaml",
+				"interop-validators": 64,
+				"genesis-time":       1700000000,
+				"eth1-block-hash":    "0x0102",
+				"file":               "genesis.ssz",
+			},
+			err: "eth1 block hash must be 32 bytes",
+		},
+		{
+			name: "FileMissing",
+			vars: map[string]interface{}{
+				"network-config":     "config.yaml",
+				"interop-validators": 64,
+				"genesis-time":       1700000000,
+			},
+			err: "file is required",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"network-config":     "config.yaml",
+				"interop-validators": 64,
+				"genesis-time":       1700000000,
+				"eth1-block-hash":    "0x0000000000000000000000000000000000000000000000000000000000000001",
+				"file":               "genesis.ssz",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,607 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaincreategenesis
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	"github.com/pkg/errors"
+	bitfield "github.com/prysmaticlabs/go-bitfield"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// The sizes of the beacon state are fixed by the mainnet preset.
+const (
+	slotsPerHistoricalRoot    = 8192
+	epochsPerHistoricalVector = 65536
+	epochsPerSlashingsVector  = 8192
+	syncCommitteeSize         = 512
+	shuffleRoundCount         = 90
+	minSeedLookahead          = 1
+	maxEffectiveBalance       = 32_000_000_000
+	effectiveBalanceIncrement = 1_000_000_000
+	depositContractTreeDepth  = 32
+	validatorRegistryLimit    = 1_099_511_627_776
+)
+
+// domainSyncCommittee is the domain type for sync committees.
+var domainSyncCommittee = phase0.DomainType{0x07, 0x00, 0x00, 0x00}
+
+// genesisFork returns the latest fork scheduled for genesis, and its fork version.
+func genesisFork(chainSpec map[string]any) (spec.DataVersion, phase0.Version, error) {
+	fork := spec.DataVersionPhase0
+	forkVersion, isVersion := chainSpec["GENESIS_FORK_VERSION"].(phase0.Version)
+	if !isVersion {
+		return fork, forkVersion, errors.New("network config missing GENESIS_FORK_VERSION")
+	}
+
+	for _, candidate := range []struct {
+		fork spec.DataVersion
+		name string
+	}{
+		{fork: spec.DataVersionAltair, name: "ALTAIR"},
+		{fork: spec.DataVersionBellatrix, name: "BELLATRIX"},
+		{fork: spec.DataVersionCapella, name: "CAPELLA"},
+		{fork: spec.DataVersionDeneb, name: "DENEB"},
+	} {
+		if epoch, isEpoch := chainSpec[candidate.name+"_FORK_EPOCH"].(uint64); !isEpoch || epoch != 0 {
+			continue
+		}
+		fork = candidate.fork
+		forkVersion, isVersion = chainSpec[candidate.name+"_FORK_VERSION"].(phase0.Version)
+		if !isVersion {
+			return fork, forkVersion, fmt.Errorf("network config missing %s_FORK_VERSION", candidate.name)
+		}
+	}
+
+	if epoch, isEpoch := chainSpec["ELECTRA_FORK_EPOCH"].(uint64); isEpoch && epoch == 0 {
+		return fork, forkVersion, errors.New("genesis at electra is not supported")
+	}
+
+	return fork, forkVersion, nil
+}
+
+// generateGenesisState generates the genesis state from the given deposits,
+// as per initialize_beacon_state_from_eth1 in the consensus specifications.
+func generateGenesisState(chainSpec map[string]any,
+	deposits []*util.DepositInfo,
+	genesisTime uint64,
+	eth1BlockHash phase0.Hash32,
+	executionPayloadHeader []byte,
+) (
+	beaconState,
+	*summary,
+	error,
+) {
+	if presetBase, exists := chainSpec["PRESET_BASE"]; exists && presetBase != "mainnet" {
+		return nil, nil, fmt.Errorf("preset %v is not supported; only the mainnet preset is supported", presetBase)
+	}
+	genesisForkVersion, isVersion := chainSpec["GENESIS_FORK_VERSION"].(phase0.Version)
+	if !isVersion {
+		return nil, nil, errors.New("network config missing GENESIS_FORK_VERSION")
+	}
+	fork, forkVersion, err := genesisFork(chainSpec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	validators, balances, depositRoot, invalidDeposits, err := applyDeposits(deposits, genesisForkVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(validators) == 0 {
+		return nil, nil, errors.New("no valid deposits")
+	}
+
+	validatorRoots := make([]phase0.Root, len(validators))
+	activeValidators := uint64(0)
+	for i, validator := range validators {
+		if validator.ActivationEpoch == 0 {
+			activeValidators++
+		}
+		validatorRoots[i], err = validator.HashTreeRoot()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to calculate validator root")
+		}
+	}
+	genesisValidatorsRoot, err := util.SSZListRoot(validatorRoots, validatorRegistryLimit)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to calculate genesis validators root")
+	}
+
+	base := &phase0.BeaconState{
+		GenesisTime:           genesisTime,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+		Fork: &phase0.Fork{
+			PreviousVersion: forkVersion,
+			CurrentVersion:  forkVersion,
+		},
+		LatestBlockHeader: &phase0.BeaconBlockHeader{},
+		BlockRoots:        make([]phase0.Root, slotsPerHistoricalRoot),
+		StateRoots:        make([]phase0.Root, slotsPerHistoricalRoot),
+		HistoricalRoots:   make([]phase0.Root, 0),
+		ETH1Data: &phase0.ETH1Data{
+			DepositRoot:  depositRoot,
+			DepositCount: uint64(len(deposits)),
+			BlockHash:    eth1BlockHash[:],
+		},
+		ETH1DataVotes:               make([]*phase0.ETH1Data, 0),
+		ETH1DepositIndex:            uint64(len(deposits)),
+		Validators:                  validators,
+		Balances:                    balances,
+		RANDAOMixes:                 make([]phase0.Root, epochsPerHistoricalVector),
+		Slashings:                   make([]phase0.Gwei, epochsPerSlashingsVector),
+		PreviousEpochAttestations:   make([]*phase0.PendingAttestation, 0),
+		CurrentEpochAttestations:    make([]*phase0.PendingAttestation, 0),
+		JustificationBits:           bitfield.NewBitvector4(),
+		PreviousJustifiedCheckpoint: &phase0.Checkpoint{},
+		CurrentJustifiedCheckpoint:  &phase0.Checkpoint{},
+		FinalizedCheckpoint:         &phase0.Checkpoint{},
+	}
+	for i := range base.RANDAOMixes {
+		base.RANDAOMixes[i] = phase0.Root(eth1BlockHash)
+	}
+	base.LatestBlockHeader.BodyRoot, err = emptyBodyRoot(fork)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	state, err := versionedState(fork, base, executionPayloadHeader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stateRoot, err := state.HashTreeRoot()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to calculate state root")
+	}
+
+	return state, &summary{
+		Fork:                  fork,
+		ForkVersion:           forkVersion,
+		GenesisTime:           genesisTime,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+		Deposits:              uint64(len(deposits)),
+		InvalidDeposits:       invalidDeposits,
+		Validators:            uint64(len(validators)),
+		ActiveValidators:      activeValidators,
+		StateRoot:             stateRoot,
+	}, nil
+}
+
+// applyDeposits applies the deposits to an empty validator registry, returning
+// the validators, their balances, the deposit root and the number of deposits
+// ignored due to invalid signatures.
+func applyDeposits(deposits []*util.DepositInfo,
+	genesisForkVersion phase0.Version,
+) (
+	[]*phase0.Validator,
+	[]phase0.Gwei,
+	phase0.Root,
+	uint64,
+	error,
+) {
+	validators := make([]*phase0.Validator, 0, len(deposits))
+	balances := make([]phase0.Gwei, 0, len(deposits))
+	depositRoots := make([]phase0.Root, len(deposits))
+	indices := make(map[phase0.BLSPubKey]int)
+	invalidDeposits := uint64(0)
+
+	for i, deposit := range deposits {
+		var err error
+		depositRoots[i], err = util.DepositDataRoot(deposit)
+		if err != nil {
+			return nil, nil, phase0.Root{}, 0, errors.Wrapf(err, "invalid deposit %d", i)
+		}
+
+		pubKey := phase0.BLSPubKey(deposit.PublicKey)
+		if index, exists := indices[pubKey]; exists {
+			// Top-up deposits do not require a valid signature.
+			balances[index] += phase0.Gwei(deposit.Amount)
+
+			continue
+		}
+
+		verified, err := util.VerifyDepositSignature(deposit, genesisForkVersion)
+		if err != nil {
+			return nil, nil, phase0.Root{}, 0, errors.Wrapf(err, "failed to verify deposit %d", i)
+		}
+		if !verified {
+			invalidDeposits++
+
+			continue
+		}
+
+		indices[pubKey] = len(validators)
+		validators = append(validators, &phase0.Validator{
+			PublicKey:                  pubKey,
+			WithdrawalCredentials:      deposit.WithdrawalCredentials,
+			ActivationEligibilityEpoch: util.FarFutureEpoch,
+			ActivationEpoch:            util.FarFutureEpoch,
+			ExitEpoch:                  util.FarFutureEpoch,
+			WithdrawableEpoch:          util.FarFutureEpoch,
+		})
+		balances = append(balances, phase0.Gwei(deposit.Amount))
+	}
+
+	// Activate validators with the maximum effective balance.
+	for i, validator := range validators {
+		validator.EffectiveBalance = effectiveBalance(balances[i])
+		if validator.EffectiveBalance == maxEffectiveBalance {
+			validator.ActivationEligibilityEpoch = 0
+			validator.ActivationEpoch = 0
+		}
+	}
+
+	depositRoot, err := util.SSZListRoot(depositRoots, 1<<depositContractTreeDepth)
+	if err != nil {
+		return nil, nil, phase0.Root{}, 0, errors.Wrap(err, "failed to calculate deposit root")
+	}
+
+	return validators, balances, depositRoot, invalidDeposits, nil
+}
+
+func effectiveBalance(balance phase0.Gwei) phase0.Gwei {
+	res := balance - balance%effectiveBalanceIncrement
+	if res > maxEffectiveBalance {
+		res = maxEffectiveBalance
+	}
+
+	return res
+}
+
+// beaconState is a beacon state of any fork.
+type beaconState interface {
+	MarshalSSZ() ([]byte, error)
+	HashTreeRoot() ([32]byte, error)
+}
+
+// versionedState creates the state for the given fork from the phase 0 state.
+func versionedState(fork spec.DataVersion,
+	base *phase0.BeaconState,
+	executionPayloadHeader []byte,
+) (
+	beaconState,
+	error,
+) {
+	if fork == spec.DataVersionPhase0 {
+		if len(executionPayloadHeader) > 0 {
+			return nil, errors.New("execution payload header cannot be supplied for a phase0 genesis")
+		}
+
+		return base, nil
+	}
+
+	participation := make([]altair.ParticipationFlags, len(base.Validators))
+	inactivityScores := make([]uint64, len(base.Validators))
+	syncCommittee, err := genesisSyncCommittee(base)
+	if err != nil {
+		return nil, err
+	}
+
+	switch fork {
+	case spec.DataVersionAltair:
+		if len(executionPayloadHeader) > 0 {
+			return nil, errors.New("execution payload header cannot be supplied for an altair genesis")
+		}
+
+		return &altair.BeaconState{
+			GenesisTime:                 base.GenesisTime,
+			GenesisValidatorsRoot:       base.GenesisValidatorsRoot,
+			Slot:                        base.Slot,
+			Fork:                        base.Fork,
+			LatestBlockHeader:           base.LatestBlockHeader,
+			BlockRoots:                  base.BlockRoots,
+			StateRoots:                  base.StateRoots,
+			HistoricalRoots:             base.HistoricalRoots,
+			ETH1Data:                    base.ETH1Data,
+			ETH1DataVotes:               base.ETH1DataVotes,
+			ETH1DepositIndex:            base.ETH1DepositIndex,
+			Validators:                  base.Validators,
+			Balances:                    base.Balances,
+			RANDAOMixes:                 base.RANDAOMixes,
+			Slashings:                   base.Slashings,
+			PreviousEpochParticipation:  participation,
+			CurrentEpochParticipation:   participation,
+			JustificationBits:           base.JustificationBits,
+			PreviousJustifiedCheckpoint: base.PreviousJustifiedCheckpoint,
+			CurrentJustifiedCheckpoint:  base.CurrentJustifiedCheckpoint,
+			FinalizedCheckpoint:         base.FinalizedCheckpoint,
+			InactivityScores:            inactivityScores,
+			CurrentSyncCommittee:        syncCommittee,
+			NextSyncCommittee:           syncCommittee,
+		}, nil
+	case spec.DataVersionBellatrix:
+		header := &bellatrix.ExecutionPayloadHeader{}
+		if err := unmarshalExecutionPayloadHeader(executionPayloadHeader, header); err != nil {
+			return nil, err
+		}
+
+		return &bellatrix.BeaconState{
+			GenesisTime:                  base.GenesisTime,
+			GenesisValidatorsRoot:        base.GenesisValidatorsRoot,
+			Slot:                         base.Slot,
+			Fork:                         base.Fork,
+			LatestBlockHeader:            base.LatestBlockHeader,
+			BlockRoots:                   base.BlockRoots,
+			StateRoots:                   base.StateRoots,
+			HistoricalRoots:              base.HistoricalRoots,
+			ETH1Data:                     base.ETH1Data,
+			ETH1DataVotes:                base.ETH1DataVotes,
+			ETH1DepositIndex:             base.ETH1DepositIndex,
+			Validators:                   base.Validators,
+			Balances:                     base.Balances,
+			RANDAOMixes:                  base.RANDAOMixes,
+			Slashings:                    base.Slashings,
+			PreviousEpochParticipation:   participation,
+			CurrentEpochParticipation:    participation,
+			JustificationBits:            base.JustificationBits,
+			PreviousJustifiedCheckpoint:  base.PreviousJustifiedCheckpoint,
+			CurrentJustifiedCheckpoint:   base.CurrentJustifiedCheckpoint,
+			FinalizedCheckpoint:          base.FinalizedCheckpoint,
+			InactivityScores:             inactivityScores,
+			CurrentSyncCommittee:         syncCommittee,
+			NextSyncCommittee:            syncCommittee,
+			LatestExecutionPayloadHeader: header,
+		}, nil
+	case spec.DataVersionCapella:
+		header := &capella.ExecutionPayloadHeader{}
+		if err := unmarshalExecutionPayloadHeader(executionPayloadHeader, header); err != nil {
+			return nil, err
+		}
+
+		return &capella.BeaconState{
+			GenesisTime:                  base.GenesisTime,
+			GenesisValidatorsRoot:        base.GenesisValidatorsRoot,
+			Slot:                         base.Slot,
+			Fork:                         base.Fork,
+			LatestBlockHeader:            base.LatestBlockHeader,
+			BlockRoots:                   base.BlockRoots,
+			StateRoots:                   base.StateRoots,
+			HistoricalRoots:              base.HistoricalRoots,
+			ETH1Data:                     base.ETH1Data,
+			ETH1DataVotes:                base.ETH1DataVotes,
+			ETH1DepositIndex:             base.ETH1DepositIndex,
+			Validators:                   base.Validators,
+			Balances:                     base.Balances,
+			RANDAOMixes:                  base.RANDAOMixes,
+			Slashings:                    base.Slashings,
+			PreviousEpochParticipation:   participation,
+			CurrentEpochParticipation:    participation,
+			JustificationBits:            base.JustificationBits,
+			PreviousJustifiedCheckpoint:  base.PreviousJustifiedCheckpoint,
+			CurrentJustifiedCheckpoint:   base.CurrentJustifiedCheckpoint,
+			FinalizedCheckpoint:          base.FinalizedCheckpoint,
+			InactivityScores:             inactivityScores,
+			CurrentSyncCommittee:         syncCommittee,
+			NextSyncCommittee:            syncCommittee,
+			LatestExecutionPayloadHeader: header,
+			HistoricalSummaries:          make([]*capella.HistoricalSummary, 0),
+		}, nil
+	case spec.DataVersionDeneb:
+		header := &deneb.ExecutionPayloadHeader{
+			BaseFeePerGas: uint256.NewInt(0),
+		}
+		if err := unmarshalExecutionPayloadHeader(executionPayloadHeader, header); err != nil {
+			return nil, err
+		}
+
+		return &deneb.BeaconState{
+			GenesisTime:                  base.GenesisTime,
+			GenesisValidatorsRoot:        base.GenesisValidatorsRoot,
+			Slot:                         base.Slot,
+			Fork:                         base.Fork,
+			LatestBlockHeader:            base.LatestBlockHeader,
+			BlockRoots:                   base.BlockRoots,
+			StateRoots:                   base.StateRoots,
+			HistoricalRoots:              base.HistoricalRoots,
+			ETH1Data:                     base.ETH1Data,
+			ETH1DataVotes:                base.ETH1DataVotes,
+			ETH1DepositIndex:             base.ETH1DepositIndex,
+			Validators:                   base.Validators,
+			Balances:                     base.Balances,
+			RANDAOMixes:                  base.RANDAOMixes,
+			Slashings:                    base.Slashings,
+			PreviousEpochParticipation:   participation,
+			CurrentEpochParticipation:    participation,
+			JustificationBits:            base.JustificationBits,
+			PreviousJustifiedCheckpoint:  base.PreviousJustifiedCheckpoint,
+			CurrentJustifiedCheckpoint:   base.CurrentJustifiedCheckpoint,
+			FinalizedCheckpoint:          base.FinalizedCheckpoint,
+			InactivityScores:             inactivityScores,
+			CurrentSyncCommittee:         syncCommittee,
+			NextSyncCommittee:            syncCommittee,
+			LatestExecutionPayloadHeader: header,
+			HistoricalSummaries:          make([]*capella.HistoricalSummary, 0),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported fork %v", fork)
+	}
+}
+
+// unmarshalExecutionPayloadHeader unmarshals the execution payload header if
+// supplied, otherwise leaving the default empty header.
+func unmarshalExecutionPayloadHeader(data []byte, header json.Unmarshaler) error {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	if err := header.UnmarshalJSON(data); err != nil {
+		return errors.Wrap(err, "invalid execution payload header")
+	}
+
+	return nil
+}
+
+// emptyBodyRoot returns the root of an empty beacon block body for the fork.
+func emptyBodyRoot(fork spec.DataVersion) (phase0.Root, error) {
+	eth1Data := &phase0.ETH1Data{
+		BlockHash: make([]byte, 32),
+	}
+	var root phase0.Root
+	var err error
+	switch fork {
+	case spec.DataVersionPhase0:
+		root, err = (&phase0.BeaconBlockBody{
+			ETH1Data: eth1Data,
+		}).HashTreeRoot()
+	case spec.DataVersionAltair:
+		root, err = (&altair.BeaconBlockBody{
+			ETH1Data:      eth1Data,
+			SyncAggregate: emptySyncAggregate(),
+		}).HashTreeRoot()
+	case spec.DataVersionBellatrix:
+		root, err = (&bellatrix.BeaconBlockBody{
+			ETH1Data:         eth1Data,
+			SyncAggregate:    emptySyncAggregate(),
+			ExecutionPayload: &bellatrix.ExecutionPayload{},
+		}).HashTreeRoot()
+	case spec.DataVersionCapella:
+		root, err = (&capella.BeaconBlockBody{
+			ETH1Data:         eth1Data,
+			SyncAggregate:    emptySyncAggregate(),
+			ExecutionPayload: &capella.ExecutionPayload{},
+		}).HashTreeRoot()
+	case spec.DataVersionDeneb:
+		root, err = (&deneb.BeaconBlockBody{
+			ETH1Data:      eth1Data,
+			SyncAggregate: emptySyncAggregate(),
+			ExecutionPayload: &deneb.ExecutionPayload{
+				BaseFeePerGas: uint256.NewInt(0),
+			},
+		}).HashTreeRoot()
+	default:
+		return phase0.Root{}, fmt.Errorf("unsupported fork %v", fork)
+	}
+	if err != nil {
+		return phase0.Root{}, errors.Wrap(err, "failed to calculate empty block body root")
+	}
+
+	return root, nil
+}
+
+func emptySyncAggregate() *altair.SyncAggregate {
+	return &altair.SyncAggregate{
+		SyncCommitteeBits: bitfield.NewBitvector512(),
+	}
+}
+
+// genesisSyncCommittee returns the sync committee at genesis, which is used
+// for both the current and next sync committees.
+func genesisSyncCommittee(state *phase0.BeaconState) (*altair.SyncCommittee, error) {
+	// The committee is selected for the epoch after genesis.
+	epoch := phase0.Epoch(1)
+
+	activeIndices := make([]phase0.ValidatorIndex, 0, len(state.Validators))
+	for i, validator := range state.Validators {
+		if validator.ActivationEpoch <= epoch && epoch < validator.ExitEpoch {
+			activeIndices = append(activeIndices, phase0.ValidatorIndex(i))
+		}
+	}
+	if len(activeIndices) == 0 {
+		return nil, errors.New("no active validators for sync committee")
+	}
+
+	seed := syncCommitteeSeed(state, epoch)
+	pubKeys := make([]phase0.BLSPubKey, 0, syncCommitteeSize)
+	activeCount := uint64(len(activeIndices))
+	for i := uint64(0); len(pubKeys) < syncCommitteeSize; i++ {
+		shuffledIndex := computeShuffledIndex(i%activeCount, activeCount, seed)
+		candidate := state.Validators[activeIndices[shuffledIndex]]
+		randomByte := hashWithUint64(seed, i/32)[i%32]
+		if uint64(candidate.EffectiveBalance)*255 >= maxEffectiveBalance*uint64(randomByte) {
+			pubKeys = append(pubKeys, candidate.PublicKey)
+		}
+	}
+
+	aggregatePubKey, err := aggregatePublicKeys(pubKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &altair.SyncCommittee{
+		Pubkeys:         pubKeys,
+		AggregatePubkey: aggregatePubKey,
+	}, nil
+}
+
+// syncCommitteeSeed returns the seed for the sync committee at the given epoch.
+func syncCommitteeSeed(state *phase0.BeaconState, epoch phase0.Epoch) [32]byte {
+	mix := state.RANDAOMixes[(uint64(epoch)+epochsPerHistoricalVector-minSeedLookahead-1)%epochsPerHistoricalVector]
+	data := make([]byte, 0, 44)
+	data = append(data, domainSyncCommittee[:]...)
+	data = binary.LittleEndian.AppendUint64(data, uint64(epoch))
+	data = append(data, mix[:]...)
+
+	return sha256.Sum256(data)
+}
+
+func hashWithUint64(seed [32]byte, value uint64) [32]byte {
+	data := make([]byte, 0, 40)
+	data = append(data, seed[:]...)
+	data = binary.LittleEndian.AppendUint64(data, value)
+
+	return sha256.Sum256(data)
+}
+
+// computeShuffledIndex returns the shuffled index using the swap-or-not shuffle.
+func computeShuffledIndex(index uint64, indexCount uint64, seed [32]byte) uint64 {
+	buf := make([]byte, 0, 37)
+	for round := 0; round < shuffleRoundCount; round++ {
+		buf = append(buf[:0], seed[:]...)
+		buf = append(buf, byte(round))
+		pivotHash := sha256.Sum256(buf)
+		pivot := binary.LittleEndian.Uint64(pivotHash[:8]) % indexCount
+		flip := (pivot + indexCount - index) % indexCount
+		position := index
+		if flip > position {
+			position = flip
+		}
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(position/256))
+		source := sha256.Sum256(buf)
+		if (source[(position%256)/8]>>(position%8))&1 == 1 {
+			index = flip
+		}
+	}
+
+	return index
+}
+
+func aggregatePublicKeys(pubKeys []phase0.BLSPubKey) (phase0.BLSPubKey, error) {
+	var aggregate *e2types.BLSPublicKey
+	for _, pubKey := range pubKeys {
+		key, err := e2types.BLSPublicKeyFromBytes(pubKey[:])
+		if err != nil {
+			return phase0.BLSPubKey{}, errors.Wrap(err, "invalid public key")
+		}
+		if aggregate == nil {
+			aggregate = key
+			continue
+		}
+		aggregate.Aggregate(key)
+	}
+
+	return phase0.BLSPubKey(aggregate.Marshal()), nil
+}
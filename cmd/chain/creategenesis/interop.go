@@ -0,0 +1,88 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaincreategenesis
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// curveOrder is the order of the BLS12-381 curve.
+var curveOrder, _ = new(big.Int).SetString("52435875175126190479447740508185965837690552500527637822603658699938581184513", 10)
+
+// interopPrivateKey returns the well-known interop private key for the given index.
+func interopPrivateKey(index uint64) (*e2types.BLSPrivateKey, error) {
+	var data [32]byte
+	binary.LittleEndian.PutUint64(data[:], index)
+	hash := sha256.Sum256(data[:])
+
+	// The hash is interpreted as a little-endian integer.
+	for i, j := 0, len(hash)-1; i < j; i, j = i+1, j-1 {
+		hash[i], hash[j] = hash[j], hash[i]
+	}
+	key := new(big.Int).Mod(new(big.Int).SetBytes(hash[:]), curveOrder)
+
+	return e2types.BLSPrivateKeyFromBytes(key.FillBytes(make([]byte, 32)))
+}
+
+// interopDeposits generates deposits of the maximum effective balance for the
+// given number of interop validators, with BLS withdrawal credentials.
+func interopDeposits(spec map[string]any, count uint64) ([]*util.DepositInfo, error) {
+	genesisForkVersion, isVersion := spec["GENESIS_FORK_VERSION"].(phase0.Version)
+	if !isVersion {
+		return nil, errors.New("network config missing GENESIS_FORK_VERSION")
+	}
+	var domain phase0.Domain
+	copy(domain[:], e2types.Domain(e2types.DomainDeposit, genesisForkVersion[:], e2types.ZeroGenesisValidatorsRoot))
+
+	deposits := make([]*util.DepositInfo, 0, count)
+	for i := uint64(0); i < count; i++ {
+		privateKey, err := interopPrivateKey(i)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to generate interop key %d", i)
+		}
+		publicKey := privateKey.PublicKey().Marshal()
+
+		withdrawalCredentials := sha256.Sum256(publicKey)
+		withdrawalCredentials[0] = 0x00
+
+		deposit := &util.DepositInfo{
+			PublicKey:             publicKey,
+			WithdrawalCredentials: withdrawalCredentials[:],
+			Amount:                maxEffectiveBalance,
+			ForkVersion:           genesisForkVersion[:],
+		}
+		depositMessageRoot, err := util.DepositMessageRoot(deposit)
+		if err != nil {
+			return nil, err
+		}
+		signingRoot, err := (&phase0.SigningData{
+			ObjectRoot: depositMessageRoot,
+			Domain:     domain,
+		}).HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate signing root")
+		}
+		deposit.Signature = privateKey.Sign(signingRoot[:]).Marshal()
+		deposits = append(deposits, deposit)
+	}
+
+	return deposits, nil
+}
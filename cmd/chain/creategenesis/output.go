@@ -0,0 +1,87 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaincreategenesis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type summaryJSON struct {
+	Fork                  string `json:"fork"`
+	ForkVersion           string `json:"fork_version"`
+	GenesisTime           string `json:"genesis_time"`
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+	Deposits              uint64 `json:"deposits"`
+	InvalidDeposits       uint64 `json:"invalid_deposits"`
+	Validators            uint64 `json:"validators"`
+	ActiveValidators      uint64 `json:"active_validators"`
+	StateRoot             string `json:"state_root"`
+	File                  string `json:"file"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(&summaryJSON{
+		Fork:                  c.summary.Fork.String(),
+		ForkVersion:           fmt.Sprintf("%#x", c.summary.ForkVersion),
+		GenesisTime:           fmt.Sprintf("%d", c.summary.GenesisTime),
+		GenesisValidatorsRoot: fmt.Sprintf("%#x", c.summary.GenesisValidatorsRoot),
+		Deposits:              c.summary.Deposits,
+		InvalidDeposits:       c.summary.InvalidDeposits,
+		Validators:            c.summary.Validators,
+		ActiveValidators:      c.summary.ActiveValidators,
+		StateRoot:             fmt.Sprintf("%#x", c.summary.StateRoot),
+		File:                  c.file,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Fork: %s (%#x)\n", c.summary.Fork, c.summary.ForkVersion))
+	builder.WriteString(fmt.Sprintf("Genesis time: %s\n", time.Unix(int64(c.summary.GenesisTime), 0).UTC().Format(time.UnixDate)))
+	builder.WriteString(fmt.Sprintf("Genesis validators root: %#x\n", c.summary.GenesisValidatorsRoot))
+	if c.summary.InvalidDeposits > 0 {
+		builder.WriteString(fmt.Sprintf("Deposits: %d (%d with invalid signatures ignored)\n", c.summary.Deposits, c.summary.InvalidDeposits))
+	} else {
+		builder.WriteString(fmt.Sprintf("Deposits: %d\n", c.summary.Deposits))
+	}
+	builder.WriteString(fmt.Sprintf("Validators: %d (%d active)\n", c.summary.Validators, c.summary.ActiveValidators))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("State root: %#x\n", c.summary.StateRoot))
+	}
+	builder.WriteString(fmt.Sprintf("Genesis state written to %s\n", c.file))
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,84 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaincreategenesis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	genesisSummary := &summary{
+		Fork:                  spec.DataVersionDeneb,
+		ForkVersion:           phase0.Version{0x50, 0x00, 0x00, 0x00},
+		GenesisTime:           1700000000,
+		GenesisValidatorsRoot: phase0.Root{0x01},
+		Deposits:              65,
+		InvalidDeposits:       1,
+		Validators:            64,
+		ActiveValidators:      64,
+		StateRoot:             phase0.Root{0x02},
+	}
+
+	tests := []struct {
+		name     string
+		json     bool
+		verbose  bool
+		expected string
+	}{
+		{
+			name: "Text",
+			expected: `Fork: deneb (0x50000000)
+Genesis time: Tue Nov 14 22:13:20 UTC 2023
+Genesis validators root: 0x0100000000000000000000000000000000000000000000000000000000000000
+Deposits: 65 (1 with invalid signatures ignored)
+Validators: 64 (64 active)
+Genesis state written to genesis.ssz`,
+		},
+		{
+			name:    "Verbose",
+			verbose: true,
+			expected: `Fork: deneb (0x50000000)
+Genesis time: Tue Nov 14 22:13:20 UTC 2023
+Genesis validators root: 0x0100000000000000000000000000000000000000000000000000000000000000
+Deposits: 65 (1 with invalid signatures ignored)
+Validators: 64 (64 active)
+State root: 0x0200000000000000000000000000000000000000000000000000000000000000
+Genesis state written to genesis.ssz`,
+		},
+		{
+			name:     "JSON",
+			json:     true,
+			expected: `{"fork":"deneb","fork_version":"0x50000000","genesis_time":"1700000000","genesis_validators_root":"0x0100000000000000000000000000000000000000000000000000000000000000","deposits":65,"invalid_deposits":1,"validators":64,"active_validators":64,"state_root":"0x0200000000000000000000000000000000000000000000000000000000000000","file":"genesis.ssz"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				json:    test.json,
+				verbose: test.verbose,
+				file:    "genesis.ssz",
+				summary: genesisSummary,
+			}
+			res, err := c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.expected, res)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaincreategenesis
+
+import (
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	var executionPayloadHeader []byte
+	if c.executionPayloadHeader != "" {
+		var err error
+		executionPayloadHeader, err = os.ReadFile(c.executionPayloadHeader)
+		if err != nil {
+			return errors.Wrap(err, "failed to read execution payload header")
+		}
+	}
+
+	var err error
+	c.state, c.summary, err = generateGenesisState(c.spec, c.deposits, c.genesisTime, c.eth1BlockHash, executionPayloadHeader)
+	if err != nil {
+		return err
+	}
+
+	data, err := c.state.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal genesis state")
+	}
+	if err := os.WriteFile(c.file, data, 0o600); err != nil {
+		return errors.Wrap(err, "failed to write genesis state")
+	}
+
+	return nil
+}
+
+func (c *command) setup(_ context.Context) error {
+	var err error
+	c.spec, err = util.NetworkConfig()
+	if err != nil {
+		return err
+	}
+
+	if c.interopValidators > 0 {
+		deposits, err := interopDeposits(c.spec, c.interopValidators)
+		if err != nil {
+			return err
+		}
+		c.deposits = append(c.deposits, deposits...)
+	}
+
+	if c.depositData != "" {
+		data, err := os.ReadFile(c.depositData)
+		if err != nil {
+			return errors.Wrap(err, "failed to read deposit data")
+		}
+		deposits, err := util.DepositInfoFromJSON(data)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse deposit data")
+		}
+		c.deposits = append(c.deposits, deposits...)
+	}
+
+	return nil
+}
@@ -0,0 +1,255 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaincreategenesis
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestInteropPrivateKey(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	tests := []struct {
+		index      uint64
+		privateKey string
+		publicKey  string
+	}{
+		{
+			index:      0,
+			privateKey: "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866",
+			publicKey:  "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+		},
+		{
+			index:      1,
+			privateKey: "0x51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000",
+			publicKey:  "0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%d", test.index), func(t *testing.T) {
+			key, err := interopPrivateKey(test.index)
+			require.NoError(t, err)
+			require.Equal(t, test.privateKey, fmt.Sprintf("%#x", key.Marshal()))
+			require.Equal(t, test.publicKey, fmt.Sprintf("%#x", key.PublicKey().Marshal()))
+		})
+	}
+}
+
+func TestComputeShuffledIndex(t *testing.T) {
+	shuffled := make([]uint64, 10)
+	for i := range shuffled {
+		shuffled[i] = computeShuffledIndex(uint64(i), 100, [32]byte{})
+	}
+	require.Equal(t, []uint64{79, 25, 97, 2, 29, 3, 4, 80, 18, 63}, shuffled)
+}
+
+func TestGenesisFork(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        map[string]any
+		fork        spec.DataVersion
+		forkVersion phase0.Version
+		err         string
+	}{
+		{
+			name: "GenesisForkVersionMissing",
+			spec: map[string]any{},
+			err:  "network config missing GENESIS_FORK_VERSION",
+		},
+		{
+			name: "Phase0",
+			spec: map[string]any{
+				"GENESIS_FORK_VERSION": phase0.Version{0x10, 0x00, 0x00, 0x00},
+				"ALTAIR_FORK_EPOCH":    uint64(10),
+			},
+			fork:        spec.DataVersionPhase0,
+			forkVersion: phase0.Version{0x10, 0x00, 0x00, 0x00},
+		},
+		{
+			name: "Capella",
+			spec: map[string]any{
+				"GENESIS_FORK_VERSION":   phase0.Version{0x10, 0x00, 0x00, 0x00},
+				"ALTAIR_FORK_EPOCH":      uint64(0),
+				"ALTAIR_FORK_VERSION":    phase0.Version{0x20, 0x00, 0x00, 0x00},
+				"BELLATRIX_FORK_EPOCH":   uint64(0),
+				"BELLATRIX_FORK_VERSION": phase0.Version{0x30, 0x00, 0x00, 0x00},
+				"CAPELLA_FORK_EPOCH":     uint64(0),
+				"CAPELLA_FORK_VERSION":   phase0.Version{0x40, 0x00, 0x00, 0x00},
+				"DENEB_FORK_EPOCH":       uint64(100),
+				"DENEB_FORK_VERSION":     phase0.Version{0x50, 0x00, 0x00, 0x00},
+			},
+			fork:        spec.DataVersionCapella,
+			forkVersion: phase0.Version{0x40, 0x00, 0x00, 0x00},
+		},
+		{
+			name: "ForkVersionMissing",
+			spec: map[string]any{
+				"GENESIS_FORK_VERSION": phase0.Version{0x10, 0x00, 0x00, 0x00},
+				"ALTAIR_FORK_EPOCH":    uint64(0),
+			},
+			err: "network config missing ALTAIR_FORK_VERSION",
+		},
+		{
+			name: "Electra",
+			spec: map[string]any{
+				"GENESIS_FORK_VERSION": phase0.Version{0x10, 0x00, 0x00, 0x00},
+				"ELECTRA_FORK_EPOCH":   uint64(0),
+			},
+			err: "genesis at electra is not supported",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fork, forkVersion, err := genesisFork(test.spec)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.fork, fork)
+				require.Equal(t, test.forkVersion, forkVersion)
+			}
+		})
+	}
+}
+
+func TestGenerateGenesisState(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	phase0Spec := map[string]any{
+		"PRESET_BASE":          "mainnet",
+		"GENESIS_FORK_VERSION": phase0.Version{0x10, 0x00, 0x00, 0x00},
+	}
+	denebSpec := map[string]any{
+		"GENESIS_FORK_VERSION":   phase0.Version{0x10, 0x00, 0x00, 0x00},
+		"ALTAIR_FORK_EPOCH":      uint64(0),
+		"ALTAIR_FORK_VERSION":    phase0.Version{0x20, 0x00, 0x00, 0x00},
+		"BELLATRIX_FORK_EPOCH":   uint64(0),
+		"BELLATRIX_FORK_VERSION": phase0.Version{0x30, 0x00, 0x00, 0x00},
+		"CAPELLA_FORK_EPOCH":     uint64(0),
+		"CAPELLA_FORK_VERSION":   phase0.Version{0x40, 0x00, 0x00, 0x00},
+		"DENEB_FORK_EPOCH":       uint64(0),
+		"DENEB_FORK_VERSION":     phase0.Version{0x50, 0x00, 0x00, 0x00},
+	}
+	deposits, err := interopDeposits(phase0Spec, 4)
+	require.NoError(t, err)
+
+	// A deposit with an invalid signature, and a top-up for the first validator.
+	invalidDeposit := *deposits[1]
+	invalidDeposit.PublicKey = deposits[2].PublicKey
+	invalidDeposit.Signature = deposits[3].Signature
+	topUp := *deposits[0]
+	topUp.Signature = make([]byte, phase0.SignatureLength)
+
+	tests := []struct {
+		name                   string
+		spec                   map[string]any
+		deposits               []*util.DepositInfo
+		executionPayloadHeader []byte
+		summary                *summary
+		err                    string
+	}{
+		{
+			name: "Minimal",
+			spec: map[string]any{
+				"PRESET_BASE":          "minimal",
+				"GENESIS_FORK_VERSION": phase0.Version{0x10, 0x00, 0x00, 0x00},
+			},
+			deposits: deposits,
+			err:      "preset minimal is not supported; only the mainnet preset is supported",
+		},
+		{
+			name:     "NoValidDeposits",
+			spec:     phase0Spec,
+			deposits: []*util.DepositInfo{&invalidDeposit},
+			err:      "no valid deposits",
+		},
+		{
+			name:                   "Phase0ExecutionPayloadHeader",
+			spec:                   phase0Spec,
+			deposits:               deposits,
+			executionPayloadHeader: []byte("{}"),
+			err:                    "execution payload header cannot be supplied for a phase0 genesis",
+		},
+		{
+			name:     "Phase0",
+			spec:     phase0Spec,
+			deposits: append(append([]*util.DepositInfo{&invalidDeposit}, deposits...), &topUp),
+			summary: &summary{
+				Fork:             spec.DataVersionPhase0,
+				ForkVersion:      phase0.Version{0x10, 0x00, 0x00, 0x00},
+				GenesisTime:      1700000000,
+				Deposits:         6,
+				InvalidDeposits:  1,
+				Validators:       4,
+				ActiveValidators: 4,
+			},
+		},
+		{
+			name:     "Deneb",
+			spec:     denebSpec,
+			deposits: deposits,
+			summary: &summary{
+				Fork:             spec.DataVersionDeneb,
+				ForkVersion:      phase0.Version{0x50, 0x00, 0x00, 0x00},
+				GenesisTime:      1700000000,
+				Deposits:         4,
+				Validators:       4,
+				ActiveValidators: 4,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state, summary, err := generateGenesisState(test.spec, test.deposits, 1700000000, defaultETH1BlockHash, test.executionPayloadHeader)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+
+			// The genesis validators root and state root are calculated.
+			require.NotEqual(t, phase0.Root{}, summary.GenesisValidatorsRoot)
+			require.NotEqual(t, phase0.Root{}, summary.StateRoot)
+			test.summary.GenesisValidatorsRoot = summary.GenesisValidatorsRoot
+			test.summary.StateRoot = summary.StateRoot
+			require.Equal(t, test.summary, summary)
+
+			// The state must be valid SSZ.
+			data, err := state.MarshalSSZ()
+			require.NoError(t, err)
+			stateRoot, err := state.HashTreeRoot()
+			require.NoError(t, err)
+			require.Equal(t, summary.StateRoot, phase0.Root(stateRoot))
+
+			if test.summary.Fork == spec.DataVersionDeneb {
+				decoded := &deneb.BeaconState{}
+				require.NoError(t, decoded.UnmarshalSSZ(data))
+				require.Len(t, decoded.Validators, 4)
+				require.Len(t, decoded.CurrentSyncCommittee.Pubkeys, syncCommitteeSize)
+				require.Equal(t, decoded.CurrentSyncCommittee, decoded.NextSyncCommittee)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaindepositcontract
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Execution node connection.
+	connectionExecution string
+
+	// Data access.
+	eth2Client      eth2client.Service
+	executionClient *util.ExecutionClient
+
+	// Output.
+	contract *depositContract
+}
+
+type depositContract struct {
+	Address bellatrix.ExecutionAddress `json:"address"`
+	// Slot is the slot of the state from which the beacon chain data is taken.
+	Slot phase0.Slot `json:"slot"`
+	// ETH1Data is the deposit contract state known to the beacon chain.
+	ETH1Data *phase0.ETH1Data `json:"eth1_data"`
+	// DepositIndex is the index of the next deposit to be processed by the beacon chain.
+	DepositIndex uint64 `json:"deposit_index"`
+	// PendingProcessing is the number of deposits known to the beacon chain but not yet processed.
+	PendingProcessing uint64 `json:"pending_processing"`
+	// Execution is the deposit contract state on the execution chain, if available.
+	Execution *executionState `json:"execution,omitempty"`
+}
+
+type executionState struct {
+	BlockNumber  uint64      `json:"block_number"`
+	DepositCount uint64      `json:"deposit_count"`
+	DepositRoot  phase0.Root `json:"deposit_root"`
+	// PendingInclusion is the number of deposits on the execution chain not yet known to the beacon chain.
+	PendingInclusion uint64 `json:"pending_inclusion"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.connectionExecution = viper.GetString("connection-execution")
+
+	return c, nil
+}
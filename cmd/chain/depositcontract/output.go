@@ -0,0 +1,75 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaindepositcontract
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.contract)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString("Deposit contract: ")
+	builder.WriteString(c.contract.Address.String())
+	builder.WriteString("\n")
+
+	if c.contract.Execution != nil {
+		builder.WriteString(fmt.Sprintf("Execution chain (block %d):\n", c.contract.Execution.BlockNumber))
+		builder.WriteString(fmt.Sprintf("  Deposit count: %d\n", c.contract.Execution.DepositCount))
+		builder.WriteString(fmt.Sprintf("  Deposit root: %#x\n", c.contract.Execution.DepositRoot))
+	}
+
+	builder.WriteString(fmt.Sprintf("Beacon chain (slot %d):\n", c.contract.Slot))
+	builder.WriteString(fmt.Sprintf("  Deposit count: %d\n", c.contract.ETH1Data.DepositCount))
+	builder.WriteString(fmt.Sprintf("  Deposit root: %#x\n", c.contract.ETH1Data.DepositRoot))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("  Execution block hash: %#x\n", c.contract.ETH1Data.BlockHash))
+	}
+	builder.WriteString(fmt.Sprintf("  Deposits processed: %d\n", c.contract.DepositIndex))
+
+	if c.contract.Execution != nil {
+		if c.contract.Execution.PendingInclusion > 0 {
+			builder.WriteString(fmt.Sprintf("Deposits awaiting inclusion in the beacon chain: %d\n", c.contract.Execution.PendingInclusion))
+		}
+	}
+	if c.contract.PendingProcessing > 0 {
+		builder.WriteString(fmt.Sprintf("Deposits awaiting processing by the beacon chain: %d\n", c.contract.PendingProcessing))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
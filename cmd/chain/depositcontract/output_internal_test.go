@@ -0,0 +1,110 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaindepositcontract
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	address := bellatrix.ExecutionAddress{0x00, 0x00, 0x00, 0x00, 0x21, 0x9a, 0xb5, 0x40, 0x35, 0x6c, 0xbb, 0x83, 0x9c, 0xbe, 0x05, 0x30, 0x3d, 0x77, 0x05, 0xfa}
+	eth1Data := &phase0.ETH1Data{
+		DepositRoot:  phase0.Root{0x01},
+		DepositCount: 1000,
+		BlockHash:    []byte{0x02, 0x03},
+	}
+
+	tests := []struct {
+		name string
+		c    *command
+		res  string
+	}{
+		{
+			name: "Quiet",
+			c: &command{
+				quiet: true,
+			},
+		},
+		{
+			name: "BeaconOnly",
+			c: &command{
+				contract: &depositContract{
+					Address:      address,
+					Slot:         12345,
+					ETH1Data:     eth1Data,
+					DepositIndex: 1000,
+				},
+			},
+			res: `Deposit contract: 0x00000000219ab540356cBB839Cbe05303d7705Fa
+Beacon chain (slot 12345):
+  Deposit count: 1000
+  Deposit root: 0x0100000000000000000000000000000000000000000000000000000000000000
+  Deposits processed: 1000`,
+		},
+		{
+			name: "Lagging",
+			c: &command{
+				contract: &depositContract{
+					Address:           address,
+					Slot:              12345,
+					ETH1Data:          eth1Data,
+					DepositIndex:      990,
+					PendingProcessing: 10,
+					Execution: &executionState{
+						BlockNumber:      54321,
+						DepositCount:     1005,
+						DepositRoot:      phase0.Root{0x04},
+						PendingInclusion: 5,
+					},
+				},
+			},
+			res: `Deposit contract: 0x00000000219ab540356cBB839Cbe05303d7705Fa
+Execution chain (block 54321):
+  Deposit count: 1005
+  Deposit root: 0x0400000000000000000000000000000000000000000000000000000000000000
+Beacon chain (slot 12345):
+  Deposit count: 1000
+  Deposit root: 0x0100000000000000000000000000000000000000000000000000000000000000
+  Deposits processed: 990
+Deposits awaiting inclusion in the beacon chain: 5
+Deposits awaiting processing by the beacon chain: 10`,
+		},
+		{
+			name: "JSON",
+			c: &command{
+				json: true,
+				contract: &depositContract{
+					Address:      address,
+					Slot:         12345,
+					ETH1Data:     eth1Data,
+					DepositIndex: 1000,
+				},
+			},
+			res: `{"address":"0x00000000219ab540356cBB839Cbe05303d7705Fa","slot":"12345","eth1_data":{"deposit_root":"0x0100000000000000000000000000000000000000000000000000000000000000","deposit_count":"1000","block_hash":"0x0203"},"deposit_index":1000,"pending_processing":0}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
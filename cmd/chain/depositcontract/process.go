@@ -0,0 +1,121 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaindepositcontract
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	specResponse, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	tmp, exists := specResponse.Data["DEPOSIT_CONTRACT_ADDRESS"]
+	if !exists {
+		return errors.New("spec did not contain DEPOSIT_CONTRACT_ADDRESS")
+	}
+	addressBytes, isBytes := tmp.([]byte)
+	if !isBytes || len(addressBytes) != bellatrix.ExecutionAddressLength {
+		return errors.New("DEPOSIT_CONTRACT_ADDRESS value invalid")
+	}
+	c.contract = &depositContract{}
+	copy(c.contract.Address[:], addressBytes)
+
+	// Only the start of the state is required, so stream it rather than
+	// fetching it in full.
+	err = util.StreamBeaconState(ctx, c.eth2Client, "head", &util.StateStreamHandler{
+		Header: func(header *util.StateStreamHeader) error {
+			c.contract.Slot = header.Slot
+			c.contract.ETH1Data = header.ETH1Data
+			c.contract.DepositIndex = header.ETH1DepositIndex
+			return nil
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain state")
+	}
+	c.contract.PendingProcessing = pending(c.contract.ETH1Data.DepositCount, c.contract.DepositIndex)
+
+	if c.executionClient == nil {
+		return nil
+	}
+
+	execution := &executionState{}
+	execution.BlockNumber, err = c.executionClient.BlockNumber(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain execution block number")
+	}
+	execution.DepositCount, err = c.executionClient.DepositCount(ctx, c.contract.Address)
+	if err != nil {
+		return err
+	}
+	execution.DepositRoot, err = c.executionClient.DepositRoot(ctx, c.contract.Address)
+	if err != nil {
+		return err
+	}
+	execution.PendingInclusion = pending(execution.DepositCount, c.contract.ETH1Data.DepositCount)
+	c.contract.Execution = execution
+
+	return nil
+}
+
+// pending returns the number of deposits in total that are not yet in done,
+// or 0 if done is ahead of total.
+func pending(total uint64, done uint64) uint64 {
+	if done > total {
+		return 0
+	}
+
+	return total - done
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	if _, isProvider := c.eth2Client.(eth2client.SpecProvider); !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+
+	if c.connectionExecution != "" {
+		c.executionClient, err = util.NewExecutionClient(c.connectionExecution)
+		if err != nil {
+			return errors.Wrap(err, "failed to set up execution client")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaindomain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type jsonOutput struct {
+	DomainType            string `json:"domain_type"`
+	DomainTypeName        string `json:"domain_type_name,omitempty"`
+	ForkVersion           string `json:"fork_version"`
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+	Domain                string `json:"domain"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := &jsonOutput{
+		DomainType:            fmt.Sprintf("%#x", c.domainTypeValue),
+		DomainTypeName:        c.domainTypeName,
+		ForkVersion:           fmt.Sprintf("%#x", c.forkVersionValue),
+		GenesisValidatorsRoot: fmt.Sprintf("%#x", c.genesisValidatorsRootValue),
+		Domain:                fmt.Sprintf("%#x", c.domain),
+	}
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	if !c.verbose {
+		return fmt.Sprintf("%#x", c.domain), nil
+	}
+
+	builder := strings.Builder{}
+	if c.domainTypeName != "" {
+		builder.WriteString(fmt.Sprintf("Domain type: %#x (%s)\n", c.domainTypeValue, c.domainTypeName))
+	} else {
+		builder.WriteString(fmt.Sprintf("Domain type: %#x\n", c.domainTypeValue))
+	}
+	builder.WriteString(fmt.Sprintf("Fork version: %#x\n", c.forkVersionValue))
+	builder.WriteString(fmt.Sprintf("Genesis validators root: %#x\n", c.genesisValidatorsRootValue))
+	builder.WriteString(fmt.Sprintf("Domain: %#x", c.domain))
+
+	return builder.String(), nil
+}
@@ -0,0 +1,172 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaindomain
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	var err error
+	c.domainTypeName, c.domainTypeValue, err = util.ParseDomainType(c.domainType)
+	if err != nil {
+		return err
+	}
+
+	forkIndependent := util.IsForkIndependentDomainType(c.domainTypeName)
+
+	if c.forkVersion == "" || (c.genesisValidatorsRoot == "" && !forkIndependent) {
+		if err := c.setup(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := c.obtainDomainType(ctx); err != nil {
+		return err
+	}
+
+	c.forkVersionValue, err = c.obtainForkVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !forkIndependent || c.genesisValidatorsRoot != "" {
+		c.genesisValidatorsRootValue, err = c.obtainGenesisValidatorsRoot(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.domain, err = util.ComputeDomain(c.domainTypeValue, c.forkVersionValue, c.genesisValidatorsRootValue)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// obtainDomainType uses the chain's value for a named domain type if
+// available, in case it differs from that in the specification.
+func (c *command) obtainDomainType(ctx context.Context) error {
+	if c.specProvider == nil || strings.HasPrefix(c.domainType, "0x") {
+		return nil
+	}
+
+	specResponse, err := c.specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	if domainType, isDomainType := specResponse.Data[c.domainTypeName].(phase0.DomainType); isDomainType {
+		c.domainTypeValue = domainType
+	}
+
+	return nil
+}
+
+func (c *command) obtainForkVersion(ctx context.Context) (phase0.Version, error) {
+	if c.forkVersion != "" {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Fork version supplied on the command line\n")
+		}
+		version, err := hex.DecodeString(strings.TrimPrefix(c.forkVersion, "0x"))
+		if err != nil {
+			return phase0.Version{}, errors.Wrap(err, "invalid fork version supplied")
+		}
+		if len(version) != phase0.ForkVersionLength {
+			return phase0.Version{}, errors.New("invalid length for fork version")
+		}
+		var forkVersion phase0.Version
+		copy(forkVersion[:], version)
+
+		return forkVersion, nil
+	}
+
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Fork version obtained from chain\n")
+	}
+
+	return util.DomainForkVersion(ctx, c.specProvider, c.forkProvider, c.domainTypeName)
+}
+
+func (c *command) obtainGenesisValidatorsRoot(ctx context.Context) (phase0.Root, error) {
+	if c.genesisValidatorsRoot != "" {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Genesis validators root supplied on the command line\n")
+		}
+		root, err := hex.DecodeString(strings.TrimPrefix(c.genesisValidatorsRoot, "0x"))
+		if err != nil {
+			return phase0.Root{}, errors.Wrap(err, "invalid genesis validators root supplied")
+		}
+		if len(root) != phase0.RootLength {
+			return phase0.Root{}, errors.New("invalid length for genesis validators root")
+		}
+		var genesisValidatorsRoot phase0.Root
+		copy(genesisValidatorsRoot[:], root)
+
+		return genesisValidatorsRoot, nil
+	}
+
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Genesis validators root obtained from chain\n")
+	}
+	genesisResponse, err := c.genesisProvider.Genesis(ctx, &api.GenesisOpts{})
+	if err != nil {
+		return phase0.Root{}, errors.Wrap(err, "failed to obtain genesis information")
+	}
+
+	return genesisResponse.Data.GenesisValidatorsRoot, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	if c.specProvider != nil {
+		// Already set up.
+		return nil
+	}
+
+	eth2Client, err := util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	var isProvider bool
+	c.specProvider, isProvider = eth2Client.(eth2client.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+	c.genesisProvider, isProvider = eth2Client.(eth2client.GenesisProvider)
+	if !isProvider {
+		return errors.New("connection does not provide genesis information")
+	}
+	c.forkProvider, isProvider = eth2Client.(eth2client.ForkProvider)
+	if !isProvider {
+		return errors.New("connection does not provide fork information")
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaindomain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+)
+
+// chainClient is a mock client that returns mainnet chain information.
+type chainClient struct{}
+
+func (*chainClient) Spec(_ context.Context, _ *api.SpecOpts) (*api.Response[map[string]any], error) {
+	return &api.Response[map[string]any]{
+		Data: map[string]any{
+			"GENESIS_FORK_VERSION":  phase0.Version{0x00, 0x00, 0x00, 0x00},
+			"CAPELLA_FORK_VERSION":  phase0.Version{0x03, 0x00, 0x00, 0x00},
+			"DOMAIN_VOLUNTARY_EXIT": phase0.DomainType{0x04, 0x00, 0x00, 0x00},
+		},
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func (*chainClient) Genesis(_ context.Context, _ *api.GenesisOpts) (*api.Response[*apiv1.Genesis], error) {
+	genesis := &apiv1.Genesis{}
+	copy(genesis.GenesisValidatorsRoot[:], testutil.HexToBytes("0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"))
+
+	return &api.Response[*apiv1.Genesis]{
+		Data:     genesis,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func (*chainClient) Fork(_ context.Context, _ *api.ForkOpts) (*api.Response[*phase0.Fork], error) {
+	return &api.Response[*phase0.Fork]{
+		Data: &phase0.Fork{
+			PreviousVersion: phase0.Version{0x03, 0x00, 0x00, 0x00},
+			CurrentVersion:  phase0.Version{0x04, 0x00, 0x00, 0x00},
+			Epoch:           269568,
+		},
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func TestProcess(t *testing.T) {
+	client := &chainClient{}
+
+	tests := []struct {
+		name   string
+		cmd    *command
+		domain string
+		err    string
+	}{
+		{
+			name: "TypeUnknown",
+			cmd: &command{
+				domainType: "DOMAIN_UNKNOWN",
+			},
+			err: "unknown domain type DOMAIN_UNKNOWN",
+		},
+		{
+			name: "ForkVersionInvalid",
+			cmd: &command{
+				domainType:            "DOMAIN_VOLUNTARY_EXIT",
+				forkVersion:           "0x0300",
+				genesisValidatorsRoot: "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95",
+			},
+			err: "invalid length for fork version",
+		},
+		{
+			name: "GenesisValidatorsRootInvalid",
+			cmd: &command{
+				domainType:            "DOMAIN_VOLUNTARY_EXIT",
+				forkVersion:           "0x03000000",
+				genesisValidatorsRoot: "0xinvalid",
+			},
+			err: "invalid genesis validators root supplied: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name: "Offline",
+			cmd: &command{
+				domainType:            "DOMAIN_VOLUNTARY_EXIT",
+				forkVersion:           "0x03000000",
+				genesisValidatorsRoot: "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95",
+			},
+			domain: "0x04000000bba4da96354c9f25476cf1bc69bf583a7f9e0af049305b62de676640",
+		},
+		{
+			name: "OfflineDeposit",
+			cmd: &command{
+				domainType:  "deposit",
+				forkVersion: "0x00000000",
+			},
+			domain: "0x03000000f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9",
+		},
+		{
+			name: "ChainVoluntaryExit",
+			cmd: &command{
+				domainType:      "DOMAIN_VOLUNTARY_EXIT",
+				specProvider:    client,
+				genesisProvider: client,
+				forkProvider:    client,
+			},
+			domain: "0x04000000bba4da96354c9f25476cf1bc69bf583a7f9e0af049305b62de676640",
+		},
+		{
+			name: "ChainBLSToExecutionChange",
+			cmd: &command{
+				domainType:      "DOMAIN_BLS_TO_EXECUTION_CHANGE",
+				specProvider:    client,
+				genesisProvider: client,
+				forkProvider:    client,
+			},
+			domain: "0x0a000000b5303f2ad2010d699a76c8e62350947421a3e4a979779642cfdb0f66",
+		},
+		{
+			name: "ChainApplicationBuilder",
+			cmd: &command{
+				domainType:      "DOMAIN_APPLICATION_BUILDER",
+				specProvider:    client,
+				genesisProvider: client,
+				forkProvider:    client,
+			},
+			domain: "0x00000001f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9",
+		},
+		{
+			name: "ChainCurrentFork",
+			cmd: &command{
+				domainType:      "0x01000000",
+				specProvider:    client,
+				genesisProvider: client,
+				forkProvider:    client,
+			},
+			domain: "0x010000006a95a1a967855d676d48be69883b712607f952d5198d0f5677564636",
+		},
+		{
+			name: "ChainForkVersionOverride",
+			cmd: &command{
+				domainType:      "DOMAIN_VOLUNTARY_EXIT",
+				forkVersion:     "0x03000000",
+				specProvider:    client,
+				genesisProvider: client,
+				forkProvider:    client,
+			},
+			domain: "0x04000000bba4da96354c9f25476cf1bc69bf583a7f9e0af049305b62de676640",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.cmd.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, testutil.HexToBytes(test.domain), test.cmd.domain[:])
+			}
+		})
+	}
+}
@@ -42,9 +42,11 @@ type command struct {
 	// Data access.
 	eth2Client                eth2client.Service
 	chainTime                 chaintime.Service
-	beaconStateProvider       eth2client.BeaconStateProvider
+	blocksProvider            eth2client.SignedBeaconBlockProvider
 	slotsPerEpoch             uint64
 	epochsPerEth1VotingPeriod uint64
+	eth1FollowDistance        uint64
+	secondsPerEth1Block       time.Duration
 
 	// Output.
 	slot          phase0.Slot
@@ -55,6 +57,17 @@ type command struct {
 	incumbent     *phase0.ETH1Data
 	eth1DataVotes []*phase0.ETH1Data
 	votes         map[string]*vote
+	// slotsThroughPeriod is the number of slots of the period that have passed.
+	slotsThroughPeriod uint64
+	// leadingBlock is the execution block of the leading vote, if found.
+	leadingBlock *executionBlock
+	// headBlockNumber is the number of the execution block at the head of the chain.
+	headBlockNumber *uint64
+}
+
+type executionBlock struct {
+	Number uint64      `json:"number"`
+	Slot   phase0.Slot `json:"slot"`
 }
 
 type vote struct {
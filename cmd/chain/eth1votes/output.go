@@ -17,20 +17,31 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"sort"
 	"strings"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 )
 
 type jsonOutput struct {
-	Period      uint64           `json:"period"`
-	PeriodStart int64            `json:"period_start"`
-	PeriodEnd   int64            `json:"period_end"`
-	Epoch       phase0.Epoch     `json:"epoch"`
-	Slot        phase0.Slot      `json:"slot"`
-	Incumbent   *phase0.ETH1Data `json:"incumbent"`
-	Votes       []*vote          `json:"votes"`
+	Period             uint64           `json:"period"`
+	PeriodStart        int64            `json:"period_start"`
+	PeriodEnd          int64            `json:"period_end"`
+	Epoch              phase0.Epoch     `json:"epoch"`
+	Slot               phase0.Slot      `json:"slot"`
+	SlotsThroughPeriod uint64           `json:"slots_through_period"`
+	Incumbent          *phase0.ETH1Data `json:"incumbent"`
+	Votes              []*vote          `json:"votes"`
+	Leading            *jsonLeading     `json:"leading,omitempty"`
+}
+
+type jsonLeading struct {
+	Vote                  *phase0.ETH1Data `json:"vote"`
+	Count                 int              `json:"count"`
+	Status                string           `json:"status"`
+	NewDeposits           int64            `json:"new_deposits"`
+	ExecutionBlock        *executionBlock  `json:"execution_block,omitempty"`
+	ExecutionHead         *uint64          `json:"execution_head,omitempty"`
+	ExecutionBlocksBehind *uint64          `json:"execution_blocks_behind,omitempty"`
 }
 
 func (c *command) output(ctx context.Context) (string, error) {
@@ -45,27 +56,30 @@ func (c *command) output(ctx context.Context) (string, error) {
 }
 
 func (c *command) outputJSON(_ context.Context) (string, error) {
-	votes := make([]*vote, 0, len(c.votes))
-	totalVotes := 0
-	for _, vote := range c.votes {
-		votes = append(votes, vote)
-		totalVotes += vote.Count
-	}
-	sort.Slice(votes, func(i int, j int) bool {
-		if votes[i].Count != votes[j].Count {
-			return votes[i].Count > votes[j].Count
-		}
-		return votes[i].Vote.DepositCount < votes[j].Vote.DepositCount
-	})
+	votes := c.sortedVotes()
 
 	output := &jsonOutput{
-		Period:      c.period,
-		PeriodStart: c.periodStart.Unix(),
-		PeriodEnd:   c.periodEnd.Unix(),
-		Epoch:       c.epoch,
-		Slot:        c.slot,
-		Incumbent:   c.incumbent,
-		Votes:       votes,
+		Period:             c.period,
+		PeriodStart:        c.periodStart.Unix(),
+		PeriodEnd:          c.periodEnd.Unix(),
+		Epoch:              c.epoch,
+		Slot:               c.slot,
+		SlotsThroughPeriod: c.slotsThroughPeriod,
+		Incumbent:          c.incumbent,
+		Votes:              votes,
+	}
+	if len(votes) > 0 {
+		output.Leading = &jsonLeading{
+			Vote:           votes[0].Vote,
+			Count:          votes[0].Count,
+			Status:         c.leadingStatus(votes[0]),
+			NewDeposits:    c.newDeposits(votes[0]),
+			ExecutionBlock: c.leadingBlock,
+			ExecutionHead:  c.headBlockNumber,
+		}
+		if behind, known := c.blocksBehind(); known {
+			output.Leading.ExecutionBlocksBehind = &behind
+		}
 	}
 	data, err := json.Marshal(output)
 	if err != nil {
@@ -91,27 +105,14 @@ func (c *command) outputText(_ context.Context) (string, error) {
 		builder.WriteString(fmt.Sprintf("block %#x, deposit count %d\n", c.incumbent.BlockHash, c.incumbent.DepositCount))
 	}
 
-	votes := make([]*vote, 0, len(c.votes))
+	votes := c.sortedVotes()
 	totalVotes := 0
-	for _, vote := range c.votes {
-		votes = append(votes, vote)
+	for _, vote := range votes {
 		totalVotes += vote.Count
 	}
-	sort.Slice(votes, func(i int, j int) bool {
-		if votes[i].Count != votes[j].Count {
-			return votes[i].Count > votes[j].Count
-		}
-		return votes[i].Vote.DepositCount < votes[j].Vote.DepositCount
-	})
 
-	slot := c.chainTime.CurrentSlot()
-	if slot > c.slot {
-		slot = c.slot
-	}
-
-	slotsThroughPeriod := slot + 1 - phase0.Slot(c.period*(c.slotsPerEpoch*c.epochsPerEth1VotingPeriod))
 	builder.WriteString("Slots through period: ")
-	builder.WriteString(fmt.Sprintf("%d (%d)\n", slotsThroughPeriod, c.slot))
+	builder.WriteString(fmt.Sprintf("%d (%d)\n", c.slotsThroughPeriod, c.slot))
 
 	builder.WriteString("Votes this period: ")
 	builder.WriteString(fmt.Sprintf("%d\n", totalVotes))
@@ -123,12 +124,46 @@ func (c *command) outputText(_ context.Context) (string, error) {
 				if vote.Count != 1 {
 					builder.WriteString("s")
 				}
-				builder.WriteString(fmt.Sprintf(" (%0.2f%%)\n", 100.0*float64(vote.Count)/float64(slotsThroughPeriod)))
+				builder.WriteString(fmt.Sprintf(" (%0.2f%%)\n", 100.0*float64(vote.Count)/float64(c.slotsThroughPeriod)))
+			}
+		}
+		builder.WriteString(fmt.Sprintf("Leading vote is for block %#x with %d votes (%0.2f%%)\n", votes[0].Vote.BlockHash, votes[0].Count, 100.0*float64(votes[0].Count)/float64(c.slotsThroughPeriod)))
+		builder.WriteString("Leading vote status: ")
+		builder.WriteString(c.leadingStatus(votes[0]))
+		builder.WriteString("\n")
+		builder.WriteString("Leading vote new deposits: ")
+		builder.WriteString(fmt.Sprintf("%d\n", c.newDeposits(votes[0])))
+		switch {
+		case c.leadingBlock != nil:
+			builder.WriteString(fmt.Sprintf("Leading vote execution block: %d", c.leadingBlock.Number))
+			if behind, known := c.blocksBehind(); known {
+				builder.WriteString(fmt.Sprintf(" (%d blocks behind execution head)", behind))
 			}
-		} else {
-			builder.WriteString(fmt.Sprintf("Leading vote is for block %#x with %d votes (%0.2f%%)\n", votes[0].Vote.BlockHash, votes[0].Count, 100.0*float64(votes[0].Count)/float64(slotsThroughPeriod)))
+			builder.WriteString("\n")
+		case c.headBlockNumber != nil:
+			builder.WriteString("Leading vote execution block: not found on the canonical chain\n")
 		}
 	}
 
 	return strings.TrimSuffix(builder.String(), "\n"), nil
 }
+
+// leadingStatus returns the status of the leading vote.
+func (c *command) leadingStatus(leading *vote) string {
+	return voteStatus(uint64(leading.Count), c.slotsThroughPeriod, c.slotsPerEpoch*c.epochsPerEth1VotingPeriod)
+}
+
+// newDeposits returns the number of deposits that the vote would add to the chain.
+func (c *command) newDeposits(leading *vote) int64 {
+	return int64(leading.Vote.DepositCount) - int64(c.incumbent.DepositCount)
+}
+
+// blocksBehind returns the number of execution blocks by which the leading
+// vote trails the execution head, if known.
+func (c *command) blocksBehind() (uint64, bool) {
+	if c.leadingBlock == nil || c.headBlockNumber == nil || *c.headBlockNumber < c.leadingBlock.Number {
+		return 0, false
+	}
+
+	return *c.headBlockNumber - c.leadingBlock.Number, true
+}
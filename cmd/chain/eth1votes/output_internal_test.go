@@ -0,0 +1,106 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaineth1votes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	incumbent := &phase0.ETH1Data{
+		DepositRoot:  phase0.Root{0x01},
+		DepositCount: 100,
+		BlockHash:    []byte{0x01, 0x02},
+	}
+	leading := &phase0.ETH1Data{
+		DepositRoot:  phase0.Root{0x02},
+		DepositCount: 105,
+		BlockHash:    []byte{0x03, 0x04},
+	}
+	headBlockNumber := uint64(2100)
+
+	tests := []struct {
+		name string
+		c    *command
+		res  string
+	}{
+		{
+			name: "Quiet",
+			c: &command{
+				quiet: true,
+			},
+		},
+		{
+			name: "Found",
+			c: &command{
+				slotsPerEpoch:             32,
+				epochsPerEth1VotingPeriod: 64,
+				period:                    1,
+				slot:                      2147,
+				slotsThroughPeriod:        100,
+				incumbent:                 incumbent,
+				votes: map[string]*vote{
+					"leading": {Vote: leading, Count: 60},
+					"other":   {Vote: incumbent, Count: 40},
+				},
+				leadingBlock: &executionBlock{
+					Number: 2000,
+					Slot:   1000,
+				},
+				headBlockNumber: &headBlockNumber,
+			},
+			res: `Voting period: 1
+Slots through period: 100 (2147)
+Votes this period: 100
+Leading vote is for block 0x0304 with 60 votes (60.00%)
+Leading vote status: on track
+Leading vote new deposits: 5
+Leading vote execution block: 2000 (100 blocks behind execution head)`,
+		},
+		{
+			name: "NotFound",
+			c: &command{
+				slotsPerEpoch:             32,
+				epochsPerEth1VotingPeriod: 64,
+				period:                    1,
+				slot:                      2147,
+				slotsThroughPeriod:        100,
+				incumbent:                 incumbent,
+				votes: map[string]*vote{
+					"leading": {Vote: leading, Count: 40},
+				},
+				headBlockNumber: &headBlockNumber,
+			},
+			res: `Voting period: 1
+Slots through period: 100 (2147)
+Votes this period: 40
+Leading vote is for block 0x0304 with 40 votes (40.00%)
+Leading vote status: behind
+Leading vote new deposits: 5
+Leading vote execution block: not found on the canonical chain`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
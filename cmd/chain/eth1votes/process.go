@@ -14,14 +14,17 @@
 package chaineth1votes
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"sort"
 	"strconv"
+	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
-	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
@@ -59,48 +62,31 @@ func (c *command) process(ctx context.Context) error {
 	if fetchSlot > c.chainTime.CurrentSlot() {
 		fetchSlot = c.chainTime.CurrentSlot()
 	}
-	stateResponse, err := c.beaconStateProvider.BeaconState(ctx, &api.BeaconStateOpts{
-		State: fmt.Sprintf("%d", fetchSlot),
+	// Only the start of the state is required, so stream it rather than
+	// fetching it in full.
+	c.eth1DataVotes = make([]*phase0.ETH1Data, 0)
+	err = util.StreamBeaconState(ctx, c.eth2Client, fmt.Sprintf("%d", fetchSlot), &util.StateStreamHandler{
+		Header: func(header *util.StateStreamHeader) error {
+			c.slot = header.Slot
+			c.incumbent = header.ETH1Data
+			return nil
+		},
+		ETH1DataVote: func(vote *phase0.ETH1Data) error {
+			c.eth1DataVotes = append(c.eth1DataVotes, vote)
+			return nil
+		},
 	})
 	if err != nil {
 		return errors.Wrap(err, "failed to obtain state")
 	}
-	state := stateResponse.Data
-	if state == nil {
-		return errors.New("state not returned by beacon node")
-	}
 
 	if c.debug {
-		data, err := json.Marshal(state)
+		data, err := json.Marshal(c.eth1DataVotes)
 		if err == nil {
 			fmt.Printf("%s\n", string(data))
 		}
 	}
 
-	c.slot, err = state.Slot()
-	if err != nil {
-		return errors.Wrap(err, "failed to obtain slot")
-	}
-	switch state.Version {
-	case spec.DataVersionPhase0:
-		c.incumbent = state.Phase0.ETH1Data
-		c.eth1DataVotes = state.Phase0.ETH1DataVotes
-	case spec.DataVersionAltair:
-		c.incumbent = state.Altair.ETH1Data
-		c.eth1DataVotes = state.Altair.ETH1DataVotes
-	case spec.DataVersionBellatrix:
-		c.incumbent = state.Bellatrix.ETH1Data
-		c.eth1DataVotes = state.Bellatrix.ETH1DataVotes
-	case spec.DataVersionCapella:
-		c.incumbent = state.Capella.ETH1Data
-		c.eth1DataVotes = state.Capella.ETH1DataVotes
-	case spec.DataVersionDeneb:
-		c.incumbent = state.Deneb.ETH1Data
-		c.eth1DataVotes = state.Deneb.ETH1DataVotes
-	default:
-		return fmt.Errorf("unhandled beacon state version %v", state.Version)
-	}
-
 	c.period = uint64(c.epoch) / c.epochsPerEth1VotingPeriod
 	c.periodStart = c.chainTime.StartOfEpoch(phase0.Epoch(c.period * c.epochsPerEth1VotingPeriod))
 	c.periodEnd = c.chainTime.StartOfEpoch(phase0.Epoch((c.period + 1) * c.epochsPerEth1VotingPeriod))
@@ -116,9 +102,119 @@ func (c *command) process(ctx context.Context) error {
 		c.votes[key].Count++
 	}
 
+	slot := c.chainTime.CurrentSlot()
+	if slot > c.slot {
+		slot = c.slot
+	}
+	c.slotsThroughPeriod = uint64(slot) + 1 - c.period*c.slotsPerEpoch*c.epochsPerEth1VotingPeriod
+
+	votes := c.sortedVotes()
+	if len(votes) > 0 {
+		if err := c.obtainExecutionBlocks(ctx, votes[0].Vote.BlockHash); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// sortedVotes returns the votes in order of decreasing count.
+func (c *command) sortedVotes() []*vote {
+	votes := make([]*vote, 0, len(c.votes))
+	for _, vote := range c.votes {
+		votes = append(votes, vote)
+	}
+	sort.Slice(votes, func(i int, j int) bool {
+		if votes[i].Count != votes[j].Count {
+			return votes[i].Count > votes[j].Count
+		}
+		return votes[i].Vote.DepositCount < votes[j].Vote.DepositCount
+	})
+
+	return votes
+}
+
+// obtainExecutionBlocks obtains the execution block at the head of the chain,
+// and the execution block of the leading vote if it is on the canonical chain.
+// Voters select the latest execution block that is at least the follow
+// distance before the start of the period, so the leading vote's block is
+// searched for in the beacon blocks shortly before that time.
+func (c *command) obtainExecutionBlocks(ctx context.Context, blockHash []byte) error {
+	headResponse, err := c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+		Block: "head",
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain head block")
+	}
+	headBlockNumber, err := headResponse.Data.ExecutionBlockNumber()
+	if err != nil {
+		// Prior to the merge, so no execution blocks on the beacon chain.
+		return nil
+	}
+	c.headBlockNumber = &headBlockNumber
+
+	followTime := c.periodStart.Add(-time.Duration(c.eth1FollowDistance) * c.secondsPerEth1Block)
+	if followTime.Before(c.chainTime.GenesisTime()) {
+		return nil
+	}
+	expectedSlot := c.chainTime.TimestampToSlot(followTime)
+	for slot := expectedSlot; slot+phase0.Slot(c.slotsPerEpoch) > expectedSlot; slot-- {
+		if c.debug {
+			fmt.Printf("Fetching block for slot %d\n", slot)
+		}
+		blockResponse, err := c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+			Block: fmt.Sprintf("%d", slot),
+		})
+		if err != nil {
+			var apiErr *api.Error
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+				return errors.Wrapf(err, "failed to obtain block for slot %d", slot)
+			}
+			// No block for this slot.
+		}
+		if err == nil && blockResponse.Data != nil {
+			executionBlockHash, err := blockResponse.Data.ExecutionBlockHash()
+			if err != nil {
+				// Prior to the merge.
+				return nil
+			}
+			if bytes.Equal(executionBlockHash[:], blockHash) {
+				number, err := blockResponse.Data.ExecutionBlockNumber()
+				if err != nil {
+					return errors.Wrapf(err, "failed to obtain execution block number for slot %d", slot)
+				}
+				c.leadingBlock = &executionBlock{
+					Number: number,
+					Slot:   slot,
+				}
+
+				return nil
+			}
+		}
+		if slot == 0 {
+			break
+		}
+	}
+
+	return nil
+}
+
+// voteStatus returns the status of a vote with the given number of votes.
+// A vote is adopted as soon as it has votes from more than half of the
+// slots in the period.
+func voteStatus(votes uint64, slotsThroughPeriod uint64, slotsPerPeriod uint64) string {
+	switch {
+	case votes*2 > slotsPerPeriod:
+		return "adopted"
+	case (votes+slotsPerPeriod-slotsThroughPeriod)*2 <= slotsPerPeriod:
+		return "cannot be adopted this period"
+	case votes*2 > slotsThroughPeriod:
+		return "on track"
+	default:
+		return "behind"
+	}
+}
+
 func (c *command) setup(ctx context.Context) error {
 	var err error
 
@@ -141,11 +237,6 @@ func (c *command) setup(ctx context.Context) error {
 		return errors.Wrap(err, "failed to set up chaintime service")
 	}
 
-	var isProvider bool
-	c.beaconStateProvider, isProvider = c.eth2Client.(eth2client.BeaconStateProvider)
-	if !isProvider {
-		return errors.New("connection does not provide beacon state")
-	}
 	specProvider, isProvider := c.eth2Client.(eth2client.SpecProvider)
 	if !isProvider {
 		return errors.New("connection does not provide spec information")
@@ -173,6 +264,27 @@ func (c *command) setup(ctx context.Context) error {
 	if !good {
 		return errors.New("EPOCHS_PER_ETH1_VOTING_PERIOD value invalid")
 	}
+	tmp, exists = specResponse.Data["ETH1_FOLLOW_DISTANCE"]
+	if !exists {
+		return errors.New("spec did not contain ETH1_FOLLOW_DISTANCE")
+	}
+	c.eth1FollowDistance, good = tmp.(uint64)
+	if !good {
+		return errors.New("ETH1_FOLLOW_DISTANCE value invalid")
+	}
+	tmp, exists = specResponse.Data["SECONDS_PER_ETH1_BLOCK"]
+	if !exists {
+		return errors.New("spec did not contain SECONDS_PER_ETH1_BLOCK")
+	}
+	c.secondsPerEth1Block, good = tmp.(time.Duration)
+	if !good {
+		return errors.New("SECONDS_PER_ETH1_BLOCK value invalid")
+	}
+
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
 
 	return nil
 }
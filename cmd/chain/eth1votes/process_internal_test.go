@@ -64,3 +64,55 @@ func TestProcess(t *testing.T) {
 		})
 	}
 }
+
+func TestVoteStatus(t *testing.T) {
+	tests := []struct {
+		name               string
+		votes              uint64
+		slotsThroughPeriod uint64
+		slotsPerPeriod     uint64
+		expected           string
+	}{
+		{
+			name:               "Adopted",
+			votes:              1025,
+			slotsThroughPeriod: 1100,
+			slotsPerPeriod:     2048,
+			expected:           "adopted",
+		},
+		{
+			name:               "OnTrack",
+			votes:              60,
+			slotsThroughPeriod: 100,
+			slotsPerPeriod:     2048,
+			expected:           "on track",
+		},
+		{
+			name:               "Behind",
+			votes:              50,
+			slotsThroughPeriod: 100,
+			slotsPerPeriod:     2048,
+			expected:           "behind",
+		},
+		{
+			name:               "CannotBeAdopted",
+			votes:              10,
+			slotsThroughPeriod: 2000,
+			slotsPerPeriod:     2048,
+			expected:           "cannot be adopted this period",
+		},
+		{
+			name:               "ExactlyHalf",
+			votes:              1024,
+			slotsThroughPeriod: 2048,
+			slotsPerPeriod:     2048,
+			expected:           "cannot be adopted this period",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, voteStatus(test.votes, test.slotsThroughPeriod, test.slotsPerPeriod))
+		})
+	}
+}
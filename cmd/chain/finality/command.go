@@ -0,0 +1,107 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainfinality
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	follow           bool
+	thresholds       []uint64
+	projectionEpochs uint64
+
+	// Data access.
+	eth2Client       eth2client.Service
+	chainTime        chaintime.Service
+	finalityProvider eth2client.FinalityProvider
+
+	// Chain parameters.
+	minEpochsToInactivityPenalty uint64
+	inactivityScoreBias          uint64
+	inactivityPenaltyQuotient    uint64
+	effectiveBalance             phase0.Gwei
+
+	// Output.
+	summary *summary
+}
+
+type summary struct {
+	Epoch               phase0.Epoch `json:"epoch"`
+	FinalizedEpoch      phase0.Epoch `json:"finalized_epoch"`
+	EpochsSinceFinality uint64       `json:"epochs_since_finality"`
+	// Threshold is the highest threshold exceeded by the epochs since finality, or 0 if none.
+	Threshold uint64 `json:"threshold,omitempty"`
+	// InactivityLeak is true if the chain is currently in an inactivity leak.
+	InactivityLeak      bool         `json:"inactivity_leak"`
+	InactivityLeakEpoch phase0.Epoch `json:"inactivity_leak_epoch"`
+	InactivityLeakTime  time.Time    `json:"inactivity_leak_time"`
+	// ProjectedLoss is the loss of an offline validator with the given effective balance
+	// if the chain does not finalize for the next projection epochs.
+	ProjectionEpochs uint64      `json:"projection_epochs"`
+	EffectiveBalance phase0.Gwei `json:"effective_balance"`
+	ProjectedLoss    phase0.Gwei `json:"projected_loss"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.follow = viper.GetBool("follow")
+
+	for _, threshold := range viper.GetIntSlice("thresholds") {
+		if threshold <= 0 {
+			return nil, errors.New("thresholds must be greater than 0")
+		}
+		c.thresholds = append(c.thresholds, uint64(threshold))
+	}
+
+	c.projectionEpochs = viper.GetUint64("projection-epochs")
+	if c.projectionEpochs == 0 {
+		return nil, errors.New("projection epochs must be greater than 0")
+	}
+
+	return c, nil
+}
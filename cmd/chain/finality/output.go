@@ -0,0 +1,77 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainfinality
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet || c.follow {
+		// Finality is output each epoch when following.
+		return "", nil
+	}
+
+	return c.outputSummary(c.summary)
+}
+
+// outputSummary returns the output for a single summary.
+func (c *command) outputSummary(summary *summary) (string, error) {
+	if c.json {
+		data, err := json.Marshal(summary)
+		if err != nil {
+			return "", err
+		}
+
+		return string(data), nil
+	}
+
+	return summaryText(summary), nil
+}
+
+func summaryText(summary *summary) string {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Epoch: %d\n", summary.Epoch))
+	builder.WriteString(fmt.Sprintf("Finalized epoch: %d\n", summary.FinalizedEpoch))
+	builder.WriteString(fmt.Sprintf("Epochs since finality: %d\n", summary.EpochsSinceFinality))
+
+	if summary.InactivityLeak {
+		builder.WriteString(fmt.Sprintf("Inactivity leak: active since epoch %d\n", summary.InactivityLeakEpoch))
+	} else {
+		builder.WriteString(fmt.Sprintf("Inactivity leak: starts at epoch %d (%s, in %s) without further finality\n",
+			summary.InactivityLeakEpoch,
+			summary.InactivityLeakTime.Format("2006-01-02 15:04:05"),
+			time.Until(summary.InactivityLeakTime).Round(time.Second).String(),
+		))
+	}
+
+	builder.WriteString(fmt.Sprintf("Projected loss over %d epochs without finality: %s for an offline validator with effective balance %s\n",
+		summary.ProjectionEpochs,
+		string2eth.GWeiToString(uint64(summary.ProjectedLoss), true),
+		string2eth.GWeiToString(uint64(summary.EffectiveBalance), true),
+	))
+
+	if summary.Threshold != 0 {
+		builder.WriteString(fmt.Sprintf("Warning: %d epochs since finality exceeds threshold of %d epochs\n", summary.EpochsSinceFinality, summary.Threshold))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n")
+}
@@ -0,0 +1,82 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainfinality
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummaryText(t *testing.T) {
+	tests := []struct {
+		name     string
+		summary  *summary
+		expected []string
+		absent   []string
+	}{
+		{
+			name: "Finalizing",
+			summary: &summary{
+				Epoch:               102,
+				FinalizedEpoch:      100,
+				EpochsSinceFinality: 2,
+				InactivityLeakEpoch: 106,
+				InactivityLeakTime:  time.Now().Add(time.Hour),
+				ProjectionEpochs:    225,
+				EffectiveBalance:    32000000000,
+				ProjectedLoss:       40000000,
+			},
+			expected: []string{
+				"Epochs since finality: 2",
+				"Inactivity leak: starts at epoch 106",
+				"Projected loss over 225 epochs without finality: 0.04 Ether for an offline validator with effective balance 32 Ether",
+			},
+			absent: []string{
+				"Warning",
+			},
+		},
+		{
+			name: "Leaking",
+			summary: &summary{
+				Epoch:               120,
+				FinalizedEpoch:      100,
+				EpochsSinceFinality: 20,
+				Threshold:           10,
+				InactivityLeak:      true,
+				InactivityLeakEpoch: 106,
+				ProjectionEpochs:    225,
+				EffectiveBalance:    32000000000,
+				ProjectedLoss:       60000000,
+			},
+			expected: []string{
+				"Inactivity leak: active since epoch 106",
+				"Warning: 20 epochs since finality exceeds threshold of 10 epochs",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := summaryText(test.summary)
+			for _, expected := range test.expected {
+				require.Contains(t, res, expected)
+			}
+			for _, absent := range test.absent {
+				require.NotContains(t, res, absent)
+			}
+		})
+	}
+}
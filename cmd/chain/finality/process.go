@@ -0,0 +1,245 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainfinality
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if c.follow {
+		return c.followFinality(ctx)
+	}
+
+	var err error
+	c.summary, err = c.obtainSummary(ctx)
+
+	return err
+}
+
+// followFinality reports finality each epoch.
+func (c *command) followFinality(ctx context.Context) error {
+	for {
+		summary, err := c.obtainSummary(ctx)
+		if err != nil {
+			if !c.quiet {
+				fmt.Printf("Failed to obtain finality: %v\n", err)
+			}
+		} else if !c.quiet {
+			res, err := c.outputSummary(summary)
+			if err == nil {
+				fmt.Println(res)
+			}
+		}
+
+		// Finality changes at epoch boundaries, so check again shortly after the start of the next epoch.
+		next := c.chainTime.StartOfEpoch(c.chainTime.CurrentEpoch() + 1).Add(c.chainTime.SlotDuration())
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(time.Until(next)):
+		}
+	}
+}
+
+func (c *command) obtainSummary(ctx context.Context) (*summary, error) {
+	response, err := c.finalityProvider.Finality(ctx, &api.FinalityOpts{
+		State: "head",
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain finality")
+	}
+
+	epoch := c.chainTime.CurrentEpoch()
+	finalizedEpoch := response.Data.Finalized.Epoch
+
+	summary := &summary{
+		Epoch:               epoch,
+		FinalizedEpoch:      finalizedEpoch,
+		EpochsSinceFinality: epochsSinceFinality(epoch, finalizedEpoch),
+		InactivityLeakEpoch: inactivityLeakEpoch(finalizedEpoch, c.minEpochsToInactivityPenalty),
+		ProjectionEpochs:    c.projectionEpochs,
+		EffectiveBalance:    c.effectiveBalance,
+	}
+	summary.Threshold = exceededThreshold(summary.EpochsSinceFinality, c.thresholds)
+	summary.InactivityLeak = epoch >= summary.InactivityLeakEpoch
+	summary.InactivityLeakTime = c.chainTime.StartOfEpoch(summary.InactivityLeakEpoch)
+	pastLeakEpochs, futureLeakEpochs := leakEpochs(epoch, summary.InactivityLeakEpoch, c.projectionEpochs)
+	summary.ProjectedLoss = projectedLoss(c.effectiveBalance,
+		pastLeakEpochs,
+		futureLeakEpochs,
+		c.inactivityScoreBias,
+		c.inactivityPenaltyQuotient,
+	)
+
+	return summary, nil
+}
+
+// epochsSinceFinality returns the number of epochs since the finalized epoch.
+func epochsSinceFinality(epoch phase0.Epoch, finalizedEpoch phase0.Epoch) uint64 {
+	if finalizedEpoch > epoch {
+		return 0
+	}
+
+	return uint64(epoch - finalizedEpoch)
+}
+
+// inactivityLeakEpoch returns the first epoch in which the chain will be in an
+// inactivity leak if it does not finalize beyond the given finalized epoch.
+func inactivityLeakEpoch(finalizedEpoch phase0.Epoch, minEpochsToInactivityPenalty uint64) phase0.Epoch {
+	// The chain is in an inactivity leak when the finality delay of the previous
+	// epoch is greater than the minimum epochs to inactivity penalty.
+	return finalizedEpoch + phase0.Epoch(minEpochsToInactivityPenalty) + 2
+}
+
+// exceededThreshold returns the highest threshold exceeded, or 0 if none.
+func exceededThreshold(epochsSinceFinality uint64, thresholds []uint64) uint64 {
+	exceeded := uint64(0)
+	for _, threshold := range thresholds {
+		if epochsSinceFinality >= threshold && threshold > exceeded {
+			exceeded = threshold
+		}
+	}
+
+	return exceeded
+}
+
+// leakEpochs returns the number of epochs of inactivity leak that have already
+// passed, and the number of epochs of inactivity leak that will occur over the
+// projection epochs, if the chain does not finalize.
+func leakEpochs(epoch phase0.Epoch, leakEpoch phase0.Epoch, projectionEpochs uint64) (uint64, uint64) {
+	if epoch >= leakEpoch {
+		return uint64(epoch - leakEpoch), projectionEpochs
+	}
+
+	preLeakEpochs := uint64(leakEpoch - epoch)
+	if preLeakEpochs >= projectionEpochs {
+		return 0, 0
+	}
+
+	return 0, projectionEpochs - preLeakEpochs
+}
+
+// projectedLoss returns the inactivity penalties incurred by an offline
+// validator over the given number of epochs of inactivity leak, having already
+// been offline for the given number of epochs of inactivity leak.
+func projectedLoss(effectiveBalance phase0.Gwei,
+	pastEpochs uint64,
+	futureEpochs uint64,
+	inactivityScoreBias uint64,
+	inactivityPenaltyQuotient uint64,
+) phase0.Gwei {
+	if futureEpochs == 0 || inactivityScoreBias == 0 || inactivityPenaltyQuotient == 0 {
+		return 0
+	}
+
+	// The inactivity score of an offline validator increases by the inactivity
+	// score bias each epoch, and the validator is penalized each epoch by
+	//   effective balance * inactivity score / (inactivity score bias * inactivity penalty quotient)
+	// so the total of the inactivity scores over the future epochs is
+	//   inactivity score bias * (future epochs * past epochs + future epochs * (future epochs + 1) / 2).
+	future := new(big.Int).SetUint64(futureEpochs)
+	scores := new(big.Int).Mul(future, new(big.Int).SetUint64(pastEpochs))
+	scores.Add(scores, new(big.Int).Rsh(new(big.Int).Mul(future, new(big.Int).Add(future, big.NewInt(1))), 1))
+	scores.Mul(scores, new(big.Int).SetUint64(inactivityScoreBias))
+
+	loss := new(big.Int).Mul(new(big.Int).SetUint64(uint64(effectiveBalance)), scores)
+	loss.Div(loss, new(big.Int).Mul(new(big.Int).SetUint64(inactivityScoreBias), new(big.Int).SetUint64(inactivityPenaltyQuotient)))
+
+	// The validator cannot lose more than its balance.
+	if !loss.IsUint64() || loss.Uint64() > uint64(effectiveBalance) {
+		return effectiveBalance
+	}
+
+	return phase0.Gwei(loss.Uint64())
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.finalityProvider, isProvider = c.eth2Client.(eth2client.FinalityProvider)
+	if !isProvider {
+		return errors.New("connection does not provide finality information")
+	}
+
+	specResponse, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+
+	return c.setChainParameters(specResponse.Data)
+}
+
+// setChainParameters sets the chain parameters required to calculate inactivity penalties.
+func (c *command) setChainParameters(spec map[string]any) error {
+	var isValue bool
+	c.minEpochsToInactivityPenalty, isValue = spec["MIN_EPOCHS_TO_INACTIVITY_PENALTY"].(uint64)
+	if !isValue {
+		return fmt.Errorf("spec missing %s", "MIN_EPOCHS_TO_INACTIVITY_PENALTY")
+	}
+	c.inactivityScoreBias, isValue = spec["INACTIVITY_SCORE_BIAS"].(uint64)
+	if !isValue {
+		return fmt.Errorf("spec missing %s", "INACTIVITY_SCORE_BIAS")
+	}
+	c.inactivityPenaltyQuotient, isValue = spec["INACTIVITY_PENALTY_QUOTIENT_BELLATRIX"].(uint64)
+	if !isValue {
+		return fmt.Errorf("spec missing %s", "INACTIVITY_PENALTY_QUOTIENT_BELLATRIX")
+	}
+
+	balanceParameters, err := util.ObtainBalanceParameters(spec)
+	if err != nil {
+		return err
+	}
+	c.effectiveBalance = balanceParameters.MaxEffectiveBalance
+	if balanceParameters.MinActivationBalance != 0 {
+		c.effectiveBalance = balanceParameters.MinActivationBalance
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainfinality
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEpochsSinceFinality(t *testing.T) {
+	require.Equal(t, uint64(2), epochsSinceFinality(102, 100))
+	require.Equal(t, uint64(0), epochsSinceFinality(100, 100))
+	require.Equal(t, uint64(0), epochsSinceFinality(99, 100))
+}
+
+func TestInactivityLeakEpoch(t *testing.T) {
+	require.Equal(t, phase0.Epoch(106), inactivityLeakEpoch(100, 4))
+}
+
+func TestExceededThreshold(t *testing.T) {
+	tests := []struct {
+		name                string
+		epochsSinceFinality uint64
+		thresholds          []uint64
+		expected            uint64
+	}{
+		{
+			name:                "NoThresholds",
+			epochsSinceFinality: 20,
+		},
+		{
+			name:                "NotExceeded",
+			epochsSinceFinality: 2,
+			thresholds:          []uint64{3, 10},
+		},
+		{
+			name:                "Equal",
+			epochsSinceFinality: 3,
+			thresholds:          []uint64{3, 10},
+			expected:            3,
+		},
+		{
+			name:                "Highest",
+			epochsSinceFinality: 12,
+			thresholds:          []uint64{10, 3},
+			expected:            10,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, exceededThreshold(test.epochsSinceFinality, test.thresholds))
+		})
+	}
+}
+
+func TestLeakEpochs(t *testing.T) {
+	tests := []struct {
+		name             string
+		epoch            phase0.Epoch
+		leakEpoch        phase0.Epoch
+		projectionEpochs uint64
+		past             uint64
+		future           uint64
+	}{
+		{
+			name:             "BeyondProjection",
+			epoch:            100,
+			leakEpoch:        110,
+			projectionEpochs: 5,
+		},
+		{
+			name:             "BeforeLeak",
+			epoch:            100,
+			leakEpoch:        104,
+			projectionEpochs: 10,
+			future:           6,
+		},
+		{
+			name:             "LeakStarts",
+			epoch:            104,
+			leakEpoch:        104,
+			projectionEpochs: 10,
+			future:           10,
+		},
+		{
+			name:             "InLeak",
+			epoch:            110,
+			leakEpoch:        104,
+			projectionEpochs: 10,
+			past:             6,
+			future:           10,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			past, future := leakEpochs(test.epoch, test.leakEpoch, test.projectionEpochs)
+			require.Equal(t, test.past, past)
+			require.Equal(t, test.future, future)
+		})
+	}
+}
+
+func TestProjectedLoss(t *testing.T) {
+	tests := []struct {
+		name             string
+		effectiveBalance phase0.Gwei
+		pastEpochs       uint64
+		futureEpochs     uint64
+		expected         phase0.Gwei
+	}{
+		{
+			name:             "None",
+			effectiveBalance: 32000000000,
+		},
+		{
+			name:             "OneEpoch",
+			effectiveBalance: 32000000000,
+			futureEpochs:     1,
+			// 32000000000 * 4 / (4 * 2^24).
+			expected: 1907,
+		},
+		{
+			name:             "Day",
+			effectiveBalance: 32000000000,
+			futureEpochs:     225,
+			// 32000000000 * 4 * 25425 / (4 * 2^24).
+			expected: 48494338,
+		},
+		{
+			name:             "DayInLeak",
+			effectiveBalance: 32000000000,
+			pastEpochs:       225,
+			futureEpochs:     225,
+			// 32000000000 * 4 * (225 * 225 + 25425) / (4 * 2^24).
+			expected: 145053863,
+		},
+		{
+			name:             "Capped",
+			effectiveBalance: 32000000000,
+			futureEpochs:     100000,
+			expected:         32000000000,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, projectedLoss(test.effectiveBalance, test.pastEpochs, test.futureEpochs, 4, 1<<24))
+		})
+	}
+}
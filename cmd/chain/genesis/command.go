@@ -0,0 +1,78 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaingenesis
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connections              []string
+	allowInsecureConnections bool
+
+	// Output.
+	genesis *genesis
+}
+
+// genesis is the genesis information of a chain.
+type genesis struct {
+	Network               string
+	GenesisTime           time.Time
+	GenesisValidatorsRoot phase0.Root
+	GenesisForkVersion    phase0.Version
+	GenesisForkDigest     phase0.ForkDigest
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	// Each connection is queried separately, so that disagreements between them can be found.
+	for _, connection := range strings.Split(viper.GetString("connection"), ",") {
+		connection = strings.TrimSpace(connection)
+		if connection != "" {
+			c.connections = append(c.connections, connection)
+		}
+	}
+	if len(c.connections) == 0 {
+		// Use the default connection.
+		c.connections = []string{""}
+	}
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	return c, nil
+}
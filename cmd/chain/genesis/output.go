@@ -0,0 +1,74 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaingenesis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type genesisJSON struct {
+	Network               string `json:"network"`
+	GenesisTime           string `json:"genesis_time"`
+	GenesisTimestamp      int64  `json:"genesis_timestamp"`
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+	GenesisForkVersion    string `json:"genesis_fork_version"`
+	GenesisForkDigest     string `json:"genesis_fork_digest"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(&genesisJSON{
+		Network:               c.genesis.Network,
+		GenesisTime:           c.genesis.GenesisTime.UTC().Format(time.RFC3339),
+		GenesisTimestamp:      c.genesis.GenesisTime.Unix(),
+		GenesisValidatorsRoot: fmt.Sprintf("%#x", c.genesis.GenesisValidatorsRoot),
+		GenesisForkVersion:    fmt.Sprintf("%#x", c.genesis.GenesisForkVersion),
+		GenesisForkDigest:     fmt.Sprintf("%#x", c.genesis.GenesisForkDigest),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Network: %s\n", c.genesis.Network))
+	builder.WriteString(fmt.Sprintf("Genesis time: %s\n", c.genesis.GenesisTime.UTC().Format(time.UnixDate)))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Genesis timestamp: %d\n", c.genesis.GenesisTime.Unix()))
+	}
+	builder.WriteString(fmt.Sprintf("Genesis validators root: %#x\n", c.genesis.GenesisValidatorsRoot))
+	builder.WriteString(fmt.Sprintf("Genesis fork version: %#x\n", c.genesis.GenesisForkVersion))
+	builder.WriteString(fmt.Sprintf("Genesis fork digest: %#x\n", c.genesis.GenesisForkDigest))
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,76 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaingenesis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	mainnet := &genesis{
+		Network:               "Mainnet",
+		GenesisTime:           time.Unix(1606824023, 0),
+		GenesisValidatorsRoot: mainnetGenesisValidatorsRoot,
+		GenesisForkDigest:     phase0.ForkDigest{0xb5, 0x30, 0x3f, 0x2a},
+	}
+
+	tests := []struct {
+		name     string
+		json     bool
+		verbose  bool
+		expected string
+	}{
+		{
+			name: "Text",
+			expected: `Network: Mainnet
+Genesis time: Tue Dec  1 12:00:23 UTC 2020
+Genesis validators root: 0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95
+Genesis fork version: 0x00000000
+Genesis fork digest: 0xb5303f2a`,
+		},
+		{
+			name:    "Verbose",
+			verbose: true,
+			expected: `Network: Mainnet
+Genesis time: Tue Dec  1 12:00:23 UTC 2020
+Genesis timestamp: 1606824023
+Genesis validators root: 0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95
+Genesis fork version: 0x00000000
+Genesis fork digest: 0xb5303f2a`,
+		},
+		{
+			name:     "JSON",
+			json:     true,
+			expected: `{"network":"Mainnet","genesis_time":"2020-12-01T12:00:23Z","genesis_timestamp":1606824023,"genesis_validators_root":"0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95","genesis_fork_version":"0x00000000","genesis_fork_digest":"0xb5303f2a"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				json:    test.json,
+				verbose: test.verbose,
+				genesis: mainnet,
+			}
+			res, err := c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.expected, res)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaingenesis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	genesisInfos := make([]*genesis, 0, len(c.connections))
+	for _, connection := range c.connections {
+		genesisInfo, err := c.obtainGenesis(ctx, connection)
+		if err != nil {
+			if connection == "" {
+				return err
+			}
+
+			return errors.Wrap(err, connection)
+		}
+		genesisInfos = append(genesisInfos, genesisInfo)
+	}
+
+	if err := checkAgreement(c.connections, genesisInfos); err != nil {
+		return err
+	}
+	c.genesis = genesisInfos[0]
+
+	return nil
+}
+
+func (c *command) obtainGenesis(ctx context.Context, connection string) (*genesis, error) {
+	eth2Client, err := util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	genesisProvider, isProvider := eth2Client.(eth2client.GenesisProvider)
+	if !isProvider {
+		return nil, errors.New("connection does not provide genesis information")
+	}
+	genesisResponse, err := genesisProvider.Genesis(ctx, &api.GenesisOpts{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain genesis")
+	}
+
+	network, err := util.Network(ctx, eth2Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain network")
+	}
+
+	forkDigest, err := genesisForkDigest(genesisResponse.Data.GenesisForkVersion, genesisResponse.Data.GenesisValidatorsRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	return &genesis{
+		Network:               network,
+		GenesisTime:           genesisResponse.Data.GenesisTime,
+		GenesisValidatorsRoot: genesisResponse.Data.GenesisValidatorsRoot,
+		GenesisForkVersion:    genesisResponse.Data.GenesisForkVersion,
+		GenesisForkDigest:     forkDigest,
+	}, nil
+}
+
+// genesisForkDigest calculates the fork digest of the chain at genesis, which
+// identifies the chain on the peer-to-peer network.
+func genesisForkDigest(forkVersion phase0.Version, genesisValidatorsRoot phase0.Root) (phase0.ForkDigest, error) {
+	var forkDigest phase0.ForkDigest
+
+	forkDataRoot, err := (&phase0.ForkData{
+		CurrentVersion:        forkVersion,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}).HashTreeRoot()
+	if err != nil {
+		return forkDigest, errors.Wrap(err, "failed to calculate fork digest")
+	}
+	copy(forkDigest[:], forkDataRoot[:])
+
+	return forkDigest, nil
+}
+
+// checkAgreement returns an error listing the differences if the connections
+// do not agree on the chain's genesis.
+func checkAgreement(connections []string, genesisInfos []*genesis) error {
+	differences := make([]string, 0)
+	reference := genesisInfos[0]
+	for i := 1; i < len(genesisInfos); i++ {
+		genesisInfo := genesisInfos[i]
+		if !genesisInfo.GenesisTime.Equal(reference.GenesisTime) {
+			differences = append(differences, fmt.Sprintf("%s has genesis time %d, %s has genesis time %d",
+				connections[0], reference.GenesisTime.Unix(),
+				connections[i], genesisInfo.GenesisTime.Unix()))
+		}
+		if genesisInfo.GenesisValidatorsRoot != reference.GenesisValidatorsRoot {
+			differences = append(differences, fmt.Sprintf("%s has genesis validators root %#x, %s has genesis validators root %#x",
+				connections[0], reference.GenesisValidatorsRoot,
+				connections[i], genesisInfo.GenesisValidatorsRoot))
+		}
+		if genesisInfo.GenesisForkVersion != reference.GenesisForkVersion {
+			differences = append(differences, fmt.Sprintf("%s has genesis fork version %#x, %s has genesis fork version %#x",
+				connections[0], reference.GenesisForkVersion,
+				connections[i], genesisInfo.GenesisForkVersion))
+		}
+	}
+
+	if len(differences) > 0 {
+		return fmt.Errorf("connections do not agree on genesis: %s", strings.Join(differences, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaingenesis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+var mainnetGenesisValidatorsRoot = phase0.Root{
+	0x4b, 0x36, 0x3d, 0xb9, 0x4e, 0x28, 0x61, 0x20, 0xd7, 0x6e, 0xb9, 0x05, 0x34, 0x0f, 0xdd, 0x4e,
+	0x54, 0xbf, 0xe9, 0xf0, 0x6b, 0xf3, 0x3f, 0xf6, 0xcf, 0x5a, 0xd2, 0x7f, 0x51, 0x1b, 0xfe, 0x95,
+}
+
+func TestGenesisForkDigest(t *testing.T) {
+	forkDigest, err := genesisForkDigest(phase0.Version{0x00, 0x00, 0x00, 0x00}, mainnetGenesisValidatorsRoot)
+	require.NoError(t, err)
+	require.Equal(t, phase0.ForkDigest{0xb5, 0x30, 0x3f, 0x2a}, forkDigest)
+}
+
+func TestCheckAgreement(t *testing.T) {
+	mainnet := &genesis{
+		GenesisTime:           time.Unix(1606824023, 0),
+		GenesisValidatorsRoot: mainnetGenesisValidatorsRoot,
+	}
+
+	tests := []struct {
+		name         string
+		connections  []string
+		genesisInfos []*genesis
+		err          string
+	}{
+		{
+			name:         "Single",
+			connections:  []string{"node1"},
+			genesisInfos: []*genesis{mainnet},
+		},
+		{
+			name:         "Agree",
+			connections:  []string{"node1", "node2"},
+			genesisInfos: []*genesis{mainnet, mainnet},
+		},
+		{
+			name:        "Disagree",
+			connections: []string{"node1", "node2", "node3"},
+			genesisInfos: []*genesis{
+				mainnet,
+				mainnet,
+				{
+					GenesisTime:           time.Unix(1695902400, 0),
+					GenesisValidatorsRoot: mainnetGenesisValidatorsRoot,
+					GenesisForkVersion:    phase0.Version{0x01, 0x01, 0x70, 0x00},
+				},
+			},
+			err: "connections do not agree on genesis: node1 has genesis time 1606824023, node3 has genesis time 1695902400; node1 has genesis fork version 0x00000000, node3 has genesis fork version 0x01017000",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkAgreement(test.connections, test.genesisInfos)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaingraffiti
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	blocks int
+
+	// Data access.
+	eth2Client     eth2client.Service
+	chainTime      chaintime.Service
+	blocksProvider eth2client.SignedBeaconBlockProvider
+
+	// Output.
+	summary *summary
+}
+
+type summary struct {
+	Blocks           int         `json:"blocks"`
+	FirstSlot        phase0.Slot `json:"first_slot"`
+	LastSlot         phase0.Slot `json:"last_slot"`
+	ConsensusClients []*count    `json:"consensus_clients"`
+	ExecutionClients []*count    `json:"execution_clients"`
+	Graffiti         []*count    `json:"graffiti"`
+}
+
+type count struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.blocks = viper.GetInt("blocks")
+	if c.blocks <= 0 {
+		return nil, errors.New("blocks must be greater than 0")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,79 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaingraffiti
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// maxGraffitiEntries is the number of custom graffiti entries shown in
+// non-verbose output.
+const maxGraffitiEntries = 10
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.summary)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.summary.Blocks == 0 {
+		return "No blocks found", nil
+	}
+
+	builder.WriteString(fmt.Sprintf("Blocks: %d (slots %d-%d)\n", c.summary.Blocks, c.summary.FirstSlot, c.summary.LastSlot))
+
+	builder.WriteString("Consensus clients:\n")
+	for _, entry := range c.summary.ConsensusClients {
+		builder.WriteString(fmt.Sprintf("  %s: %d (%.1f%%)\n", entry.Name, entry.Count, 100*float64(entry.Count)/float64(c.summary.Blocks)))
+	}
+
+	builder.WriteString("Execution clients:\n")
+	for _, entry := range c.summary.ExecutionClients {
+		builder.WriteString(fmt.Sprintf("  %s: %d (%.1f%%)\n", entry.Name, entry.Count, 100*float64(entry.Count)/float64(c.summary.Blocks)))
+	}
+
+	if len(c.summary.Graffiti) > 0 {
+		builder.WriteString("Custom graffiti:\n")
+		for i, entry := range c.summary.Graffiti {
+			if i == maxGraffitiEntries && !c.verbose {
+				builder.WriteString(fmt.Sprintf("  (%d more)\n", len(c.summary.Graffiti)-maxGraffitiEntries))
+				break
+			}
+			builder.WriteString(fmt.Sprintf("  %q: %d\n", entry.Name, entry.Count))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
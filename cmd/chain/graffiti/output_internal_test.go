@@ -0,0 +1,105 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaingraffiti
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	manyGraffiti := make([]*count, 12)
+	for i := range manyGraffiti {
+		manyGraffiti[i] = &count{Name: fmt.Sprintf("graffiti %02d", i), Count: 1}
+	}
+
+	tests := []struct {
+		name string
+		cmd  *command
+		res  string
+	}{
+		{
+			name: "Empty",
+			cmd: &command{
+				summary: &summary{},
+			},
+			res: "No blocks found",
+		},
+		{
+			name: "Good",
+			cmd: &command{
+				summary: &summary{
+					Blocks:    4,
+					FirstSlot: 100,
+					LastSlot:  104,
+					ConsensusClients: []*count{
+						{Name: "lighthouse", Count: 3},
+						{Name: "unknown", Count: 1},
+					},
+					ExecutionClients: []*count{
+						{Name: "unknown", Count: 4},
+					},
+					Graffiti: []*count{
+						{Name: "hello", Count: 2},
+					},
+				},
+			},
+			res: "Blocks: 4 (slots 100-104)\nConsensus clients:\n  lighthouse: 3 (75.0%)\n  unknown: 1 (25.0%)\nExecution clients:\n  unknown: 4 (100.0%)\nCustom graffiti:\n  \"hello\": 2",
+		},
+		{
+			name: "JSON",
+			cmd: &command{
+				json: true,
+				summary: &summary{
+					Blocks:    1,
+					FirstSlot: 100,
+					LastSlot:  100,
+					ConsensusClients: []*count{
+						{Name: "teku", Count: 1},
+					},
+					ExecutionClients: []*count{
+						{Name: "unknown", Count: 1},
+					},
+					Graffiti: []*count{},
+				},
+			},
+			res: `{"blocks":1,"first_slot":"100","last_slot":"100","consensus_clients":[{"name":"teku","count":1}],"execution_clients":[{"name":"unknown","count":1}],"graffiti":[]}`,
+		},
+		{
+			name: "Truncated",
+			cmd: &command{
+				summary: &summary{
+					Blocks:           12,
+					FirstSlot:        100,
+					LastSlot:         111,
+					ConsensusClients: []*count{{Name: "unknown", Count: 12}},
+					ExecutionClients: []*count{{Name: "unknown", Count: 12}},
+					Graffiti:         manyGraffiti,
+				},
+			},
+			res: "Blocks: 12 (slots 100-111)\nConsensus clients:\n  unknown: 12 (100.0%)\nExecution clients:\n  unknown: 12 (100.0%)\nCustom graffiti:\n  \"graffiti 00\": 1\n  \"graffiti 01\": 1\n  \"graffiti 02\": 1\n  \"graffiti 03\": 1\n  \"graffiti 04\": 1\n  \"graffiti 05\": 1\n  \"graffiti 06\": 1\n  \"graffiti 07\": 1\n  \"graffiti 08\": 1\n  \"graffiti 09\": 1\n  (2 more)",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.cmd.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaingraffiti
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// unknownClient is the name used for blocks without client identification.
+const unknownClient = "unknown"
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	c.summary = &summary{}
+	graffiti := make([]*util.Graffiti, 0, c.blocks)
+	for slot := c.chainTime.CurrentSlot(); len(graffiti) < c.blocks; slot-- {
+		if c.debug {
+			fmt.Printf("Fetching block for slot %d\n", slot)
+		}
+		blockResponse, err := c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+			Block: fmt.Sprintf("%d", slot),
+		})
+		if err != nil {
+			var apiErr *api.Error
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+				return errors.Wrapf(err, "failed to obtain block for slot %d", slot)
+			}
+			// No block for this slot.
+		}
+		if err == nil && blockResponse.Data != nil {
+			blockGraffiti, err := blockResponse.Data.Graffiti()
+			if err != nil {
+				return errors.Wrapf(err, "failed to obtain graffiti for slot %d", slot)
+			}
+			graffiti = append(graffiti, util.DecodeGraffiti(blockGraffiti[:]))
+			if c.summary.LastSlot == 0 {
+				c.summary.LastSlot = slot
+			}
+			c.summary.FirstSlot = slot
+		}
+		if slot == 0 {
+			break
+		}
+	}
+
+	c.summary.Blocks = len(graffiti)
+	c.summary.ConsensusClients, c.summary.ExecutionClients, c.summary.Graffiti = summarize(graffiti)
+
+	return nil
+}
+
+// summarize counts the clients and custom text in the graffiti.
+func summarize(graffiti []*util.Graffiti) ([]*count, []*count, []*count) {
+	consensusClients := make(map[string]int)
+	executionClients := make(map[string]int)
+	texts := make(map[string]int)
+	for _, entry := range graffiti {
+		if entry == nil {
+			entry = &util.Graffiti{}
+		}
+		consensusClient := entry.ConsensusClient
+		if consensusClient == "" {
+			consensusClient = unknownClient
+		}
+		consensusClients[consensusClient]++
+		executionClient := entry.ExecutionClient
+		if executionClient == "" {
+			executionClient = unknownClient
+		}
+		executionClients[executionClient]++
+		if entry.Text != "" {
+			texts[entry.Text]++
+		}
+	}
+
+	return sortCounts(consensusClients), sortCounts(executionClients), sortCounts(texts)
+}
+
+// sortCounts returns the counts in descending order of count, then ascending
+// order of name.
+func sortCounts(counts map[string]int) []*count {
+	res := make([]*count, 0, len(counts))
+	for name, value := range counts {
+		res = append(res, &count{
+			Name:  name,
+			Count: value,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Count != res[j].Count {
+			return res[i].Count > res[j].Count
+		}
+
+		return res[i].Name < res[j].Name
+	})
+
+	return res
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaingraffiti
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestSummarize(t *testing.T) {
+	graffiti := []*util.Graffiti{
+		util.DecodeGraffiti([]byte("Freedom To Transact TKa9f9NM220b")),
+		util.DecodeGraffiti([]byte("LHGE")),
+		util.DecodeGraffiti([]byte("Freedom To Transact LH")),
+		util.DecodeGraffiti([]byte("hello")),
+		nil,
+	}
+
+	consensusClients, executionClients, texts := summarize(graffiti)
+	require.Equal(t, []*count{
+		{Name: "lighthouse", Count: 2},
+		{Name: "unknown", Count: 2},
+		{Name: "teku", Count: 1},
+	}, consensusClients)
+	require.Equal(t, []*count{
+		{Name: "unknown", Count: 3},
+		{Name: "go-ethereum", Count: 1},
+		{Name: "nethermind", Count: 1},
+	}, executionClients)
+	require.Equal(t, []*count{
+		{Name: "Freedom To Transact", Count: 2},
+		{Name: "hello", Count: 1},
+	}, texts)
+}
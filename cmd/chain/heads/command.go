@@ -0,0 +1,86 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainheads
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Data access.
+	eth2Client         eth2client.Service
+	forkChoiceProvider eth2client.ForkChoiceProvider
+	headersProvider    eth2client.BeaconBlockHeadersProvider
+
+	// Output.
+	justifiedCheckpoint *phase0.Checkpoint
+	finalizedCheckpoint *phase0.Checkpoint
+	heads               []*head
+}
+
+// head is a candidate head of the chain in the node's fork choice.
+type head struct {
+	Slot     phase0.Slot `json:"slot"`
+	Root     phase0.Root `json:"root"`
+	Weight   phase0.Gwei `json:"weight"`
+	Validity string      `json:"validity"`
+	// Canonical is true if this is the node's current head.
+	Canonical bool `json:"canonical"`
+	// Fork is the most recent ancestor shared with another head, if any.
+	Fork *block `json:"fork,omitempty"`
+	// Ancestors are the blocks between the head and its fork, most recent first.
+	Ancestors []*block `json:"ancestors"`
+}
+
+type block struct {
+	Slot   phase0.Slot `json:"slot"`
+	Root   phase0.Root `json:"root"`
+	Weight phase0.Gwei `json:"weight"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	return c, nil
+}
@@ -0,0 +1,97 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainheads
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+type headsJSON struct {
+	JustifiedCheckpoint *phase0.Checkpoint `json:"justified_checkpoint"`
+	FinalizedCheckpoint *phase0.Checkpoint `json:"finalized_checkpoint"`
+	Heads               []*head            `json:"heads"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(&headsJSON{
+		JustifiedCheckpoint: c.justifiedCheckpoint,
+		FinalizedCheckpoint: c.finalizedCheckpoint,
+		Heads:               c.heads,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Justified epoch: %d\n", c.justifiedCheckpoint.Epoch))
+	builder.WriteString(fmt.Sprintf("Finalized epoch: %d\n", c.finalizedCheckpoint.Epoch))
+	builder.WriteString(fmt.Sprintf("Heads: %d\n", len(c.heads)))
+	for _, head := range c.heads {
+		builder.WriteString(headText(head, c.verbose))
+		builder.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func headText(head *head, verbose bool) string {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("%#x at slot %d, weight %s (%s)",
+		head.Root,
+		head.Slot,
+		string2eth.GWeiToString(uint64(head.Weight), true),
+		head.Validity,
+	))
+	if head.Canonical {
+		builder.WriteString(", canonical head")
+	}
+	if head.Fork != nil {
+		builder.WriteString(fmt.Sprintf("\n  Forks from %#x at slot %d after %d blocks", head.Fork.Root, head.Fork.Slot, len(head.Ancestors)+1))
+	}
+	if verbose {
+		for _, ancestor := range head.Ancestors {
+			builder.WriteString(fmt.Sprintf("\n  Ancestor %#x at slot %d, weight %s",
+				ancestor.Root,
+				ancestor.Slot,
+				string2eth.GWeiToString(uint64(ancestor.Weight), true),
+			))
+		}
+	}
+
+	return builder.String()
+}
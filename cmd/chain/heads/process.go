@@ -0,0 +1,133 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainheads
+
+import (
+	"context"
+	"sort"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	forkChoiceResponse, err := c.forkChoiceProvider.ForkChoice(ctx, &api.ForkChoiceOpts{})
+	if err != nil {
+		return util.ExplainNodeCapabilityFailure(ctx, c.eth2Client, util.NodeCapabilityDebug, errors.Wrap(err, "failed to obtain fork choice"))
+	}
+	c.justifiedCheckpoint = &forkChoiceResponse.Data.JustifiedCheckpoint
+	c.finalizedCheckpoint = &forkChoiceResponse.Data.FinalizedCheckpoint
+
+	headResponse, err := c.headersProvider.BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{
+		Block: "head",
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain head")
+	}
+
+	c.heads = heads(forkChoiceResponse.Data.ForkChoiceNodes, headResponse.Data.Root)
+
+	return nil
+}
+
+// heads returns the heads of the fork choice tree, highest weight first.
+func heads(nodes []*apiv1.ForkChoiceNode, canonicalRoot phase0.Root) []*head {
+	nodesByRoot := make(map[phase0.Root]*apiv1.ForkChoiceNode, len(nodes))
+	children := make(map[phase0.Root]int, len(nodes))
+	for _, node := range nodes {
+		nodesByRoot[node.BlockRoot] = node
+		children[node.ParentRoot]++
+	}
+
+	res := make([]*head, 0)
+	for _, node := range nodes {
+		if children[node.BlockRoot] > 0 {
+			// Not a tip.
+			continue
+		}
+		head := &head{
+			Slot:      node.Slot,
+			Root:      node.BlockRoot,
+			Weight:    phase0.Gwei(node.Weight),
+			Validity:  node.Validity.String(),
+			Canonical: node.BlockRoot == canonicalRoot,
+			Ancestors: make([]*block, 0),
+		}
+		// Walk back until we find a block with multiple children, which is where this head forks.
+		for parent, exists := nodesByRoot[node.ParentRoot]; exists; parent, exists = nodesByRoot[parent.ParentRoot] {
+			block := &block{
+				Slot:   parent.Slot,
+				Root:   parent.BlockRoot,
+				Weight: phase0.Gwei(parent.Weight),
+			}
+			if children[parent.BlockRoot] > 1 {
+				head.Fork = block
+
+				break
+			}
+			head.Ancestors = append(head.Ancestors, block)
+		}
+		if head.Fork == nil {
+			// No fork, so no interesting ancestors.
+			head.Ancestors = make([]*block, 0)
+		}
+		res = append(res, head)
+	}
+
+	sort.Slice(res, func(i int, j int) bool {
+		if res[i].Weight != res[j].Weight {
+			return res[i].Weight > res[j].Weight
+		}
+
+		return res[i].Slot > res[j].Slot
+	})
+
+	return res
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	var isProvider bool
+	c.forkChoiceProvider, isProvider = c.eth2Client.(eth2client.ForkChoiceProvider)
+	if !isProvider {
+		return errors.New("connection does not provide fork choice")
+	}
+	c.headersProvider, isProvider = c.eth2Client.(eth2client.BeaconBlockHeadersProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon block headers")
+	}
+
+	return nil
+}
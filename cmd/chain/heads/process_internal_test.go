@@ -0,0 +1,87 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainheads
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeads(t *testing.T) {
+	root := func(b byte) phase0.Root {
+		return phase0.Root{b}
+	}
+
+	tests := []struct {
+		name      string
+		nodes     []*apiv1.ForkChoiceNode
+		canonical phase0.Root
+		expected  []*head
+	}{
+		{
+			name:     "Empty",
+			expected: []*head{},
+		},
+		{
+			name: "Single",
+			nodes: []*apiv1.ForkChoiceNode{
+				{Slot: 1, BlockRoot: root(1), ParentRoot: root(0), Weight: 30},
+				{Slot: 2, BlockRoot: root(2), ParentRoot: root(1), Weight: 20, Validity: apiv1.ForkChoiceNodeValidityValid},
+			},
+			canonical: root(2),
+			expected: []*head{
+				{Slot: 2, Root: root(2), Weight: 20, Validity: "valid", Canonical: true, Ancestors: []*block{}},
+			},
+		},
+		{
+			name: "Fork",
+			nodes: []*apiv1.ForkChoiceNode{
+				{Slot: 1, BlockRoot: root(1), ParentRoot: root(0), Weight: 30, Validity: apiv1.ForkChoiceNodeValidityValid},
+				{Slot: 2, BlockRoot: root(2), ParentRoot: root(1), Weight: 30, Validity: apiv1.ForkChoiceNodeValidityValid},
+				{Slot: 3, BlockRoot: root(3), ParentRoot: root(2), Weight: 20, Validity: apiv1.ForkChoiceNodeValidityValid},
+				{Slot: 4, BlockRoot: root(4), ParentRoot: root(3), Weight: 20, Validity: apiv1.ForkChoiceNodeValidityValid},
+				{Slot: 3, BlockRoot: root(5), ParentRoot: root(2), Weight: 10, Validity: apiv1.ForkChoiceNodeValidityOptimistic},
+			},
+			canonical: root(4),
+			expected: []*head{
+				{
+					Slot:      4,
+					Root:      root(4),
+					Weight:    20,
+					Validity:  "valid",
+					Canonical: true,
+					Fork:      &block{Slot: 2, Root: root(2), Weight: 30},
+					Ancestors: []*block{{Slot: 3, Root: root(3), Weight: 20}},
+				},
+				{
+					Slot:      3,
+					Root:      root(5),
+					Weight:    10,
+					Validity:  "optimistic",
+					Fork:      &block{Slot: 2, Root: root(2), Weight: 30},
+					Ancestors: []*block{},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, heads(test.nodes, test.canonical))
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainpendingconsolidations
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	validators []string
+
+	// Data access.
+	eth2Client         eth2client.Service
+	chainTime          chaintime.Service
+	validatorsProvider eth2client.ValidatorsProvider
+	validatorsFilter   *util.ValidatorFilter
+
+	// Output.
+	summary *summary
+}
+
+type summary struct {
+	Slot                  phase0.Slot             `json:"slot"`
+	Epoch                 phase0.Epoch            `json:"epoch"`
+	Consolidations        uint64                  `json:"consolidations"`
+	PendingConsolidations []*pendingConsolidation `json:"pending_consolidations"`
+}
+
+type pendingConsolidation struct {
+	Position      uint64                  `json:"position"`
+	Source        *consolidationValidator `json:"source"`
+	Target        *consolidationValidator `json:"target"`
+	ExpectedEpoch phase0.Epoch            `json:"expected_epoch"`
+	// Skipped is true if the consolidation will be skipped due to the source being slashed.
+	Skipped bool `json:"skipped,omitempty"`
+}
+
+type consolidationValidator struct {
+	Index   phase0.ValidatorIndex `json:"index"`
+	PubKey  *phase0.BLSPubKey     `json:"pubkey,omitempty"`
+	Account string                `json:"account,omitempty"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.validators = viper.GetStringSlice("validators")
+
+	return c, nil
+}
@@ -0,0 +1,86 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainpendingconsolidations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.summary)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Pending consolidations: %d\n", c.summary.Consolidations))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Epoch: %d\n", c.summary.Epoch))
+	}
+
+	if c.validatorsFilter != nil && len(c.summary.PendingConsolidations) == 0 {
+		builder.WriteString("No pending consolidations for the supplied validators\n")
+	}
+	for _, consolidation := range c.summary.PendingConsolidations {
+		builder.WriteString(fmt.Sprintf("  %d: %s -> %s, ",
+			consolidation.Position,
+			c.describeValidator(consolidation.Source),
+			c.describeValidator(consolidation.Target),
+		))
+		if consolidation.Skipped {
+			builder.WriteString("will be skipped as the source validator is slashed\n")
+		} else {
+			builder.WriteString(fmt.Sprintf("expected epoch %d\n", consolidation.ExpectedEpoch))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// describeValidator describes a validator by its index along with, where
+// known, its local account name and, if verbose, its public key.
+func (c *command) describeValidator(validator *consolidationValidator) string {
+	details := make([]string, 0, 2)
+	if validator.Account != "" {
+		details = append(details, validator.Account)
+	}
+	if c.verbose && validator.PubKey != nil {
+		details = append(details, fmt.Sprintf("%#x", *validator.PubKey))
+	}
+	if len(details) == 0 {
+		return fmt.Sprintf("%d", validator.Index)
+	}
+
+	return fmt.Sprintf("%d (%s)", validator.Index, strings.Join(details, ", "))
+}
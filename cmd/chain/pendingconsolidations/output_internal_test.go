@@ -0,0 +1,111 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainpendingconsolidations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestOutput(t *testing.T) {
+	pubKey := phase0.BLSPubKey{0x01}
+	consolidations := []*pendingConsolidation{
+		{
+			Position: 0,
+			Source: &consolidationValidator{
+				Index:   12,
+				PubKey:  &pubKey,
+				Account: "Validators/1",
+			},
+			Target: &consolidationValidator{
+				Index: 34,
+			},
+			ExpectedEpoch: 356,
+		},
+		{
+			Position: 1,
+			Source: &consolidationValidator{
+				Index: 56,
+			},
+			Target: &consolidationValidator{
+				Index: 34,
+			},
+			ExpectedEpoch: 356,
+			Skipped:       true,
+		},
+	}
+
+	tests := []struct {
+		name string
+		c    *command
+		res  string
+	}{
+		{
+			name: "Quiet",
+			c: &command{
+				quiet: true,
+			},
+		},
+		{
+			name: "Good",
+			c: &command{
+				summary: &summary{
+					Consolidations:        2,
+					PendingConsolidations: consolidations,
+				},
+			},
+			res: `Pending consolidations: 2
+  0: 12 (Validators/1) -> 34, expected epoch 356
+  1: 56 -> 34, will be skipped as the source validator is slashed`,
+		},
+		{
+			name: "Verbose",
+			c: &command{
+				verbose: true,
+				summary: &summary{
+					Epoch:                 100,
+					Consolidations:        2,
+					PendingConsolidations: consolidations[:1],
+				},
+			},
+			res: `Pending consolidations: 2
+Epoch: 100
+  0: 12 (Validators/1, 0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000) -> 34, expected epoch 356`,
+		},
+		{
+			name: "Filtered",
+			c: &command{
+				validatorsFilter: util.NewValidatorFilter(nil, nil),
+				summary: &summary{
+					Consolidations:        2,
+					PendingConsolidations: []*pendingConsolidation{},
+				},
+			},
+			res: `Pending consolidations: 2
+No pending consolidations for the supplied validators`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainpendingconsolidations
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if len(c.validators) > 0 {
+		indices, pubKeys, err := util.ParseValidatorIdentifiers(ctx, c.validators)
+		if err != nil {
+			return err
+		}
+		c.validatorsFilter = util.NewValidatorFilter(indices, pubKeys)
+	}
+
+	c.summary = &summary{}
+	consolidations := make([]*util.PendingConsolidation, 0)
+	handler := &util.StateStreamHandler{
+		Header: func(header *util.StateStreamHeader) error {
+			c.summary.Slot = header.Slot
+			c.summary.Epoch = c.chainTime.SlotToEpoch(header.Slot)
+			return nil
+		},
+		PendingConsolidation: func(_ uint64, consolidation *util.PendingConsolidation) error {
+			consolidations = append(consolidations, consolidation)
+			return nil
+		},
+	}
+	if c.validatorsFilter != nil {
+		// Validators supplied by public key need to be resolved to indices.
+		handler.Validator = func(index phase0.ValidatorIndex, validator *phase0.Validator) error {
+			c.validatorsFilter.Resolve(index, validator.PublicKey)
+			return nil
+		}
+	}
+	if err := util.StreamBeaconState(ctx, c.eth2Client, "head", handler); err != nil {
+		return errors.Wrap(err, "failed to obtain state")
+	}
+	c.summary.Consolidations = uint64(len(consolidations))
+
+	// Fetch the validators involved in the consolidations, which are
+	// required to estimate when each consolidation will be processed.
+	indices := make([]phase0.ValidatorIndex, 0, 2*len(consolidations))
+	for _, consolidation := range consolidations {
+		indices = append(indices, consolidation.SourceIndex, consolidation.TargetIndex)
+	}
+	validators := make(map[phase0.ValidatorIndex]*apiv1.Validator)
+	if len(indices) > 0 {
+		var err error
+		validators, err = util.FetchValidators(ctx, c.validatorsProvider, "head", indices, nil)
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain validators")
+		}
+	}
+
+	// Local account names are a nicety, so failure to obtain them is not fatal.
+	var accountNames map[phase0.BLSPubKey]string
+	if err := util.EnsureStore(); err == nil {
+		accountNames, _ = util.AccountNamesByPubKey(ctx, e2wallet.Wallets())
+	}
+
+	// Consolidations are processed in the transition to the next epoch.
+	expectedEpochs, skipped := estimateProcessingEpochs(consolidations, validators, c.summary.Epoch+1)
+	c.summary.PendingConsolidations = make([]*pendingConsolidation, 0)
+	for i, consolidation := range consolidations {
+		if !c.validatorsFilter.MatchesIndex(consolidation.SourceIndex) &&
+			!c.validatorsFilter.MatchesIndex(consolidation.TargetIndex) {
+			continue
+		}
+		c.summary.PendingConsolidations = append(c.summary.PendingConsolidations, &pendingConsolidation{
+			Position:      uint64(i),
+			Source:        newConsolidationValidator(consolidation.SourceIndex, validators, accountNames),
+			Target:        newConsolidationValidator(consolidation.TargetIndex, validators, accountNames),
+			ExpectedEpoch: expectedEpochs[i],
+			Skipped:       skipped[i],
+		})
+	}
+
+	return nil
+}
+
+// estimateProcessingEpochs estimates the epoch at which each pending
+// consolidation will be processed.  Consolidations are processed in order
+// once their source validator is withdrawable, so a consolidation cannot
+// be processed before any that precede it.  Consolidations with a slashed
+// source validator are skipped without holding up those that follow.
+func estimateProcessingEpochs(consolidations []*util.PendingConsolidation,
+	validators map[phase0.ValidatorIndex]*apiv1.Validator,
+	epoch phase0.Epoch,
+) (
+	[]phase0.Epoch,
+	[]bool,
+) {
+	epochs := make([]phase0.Epoch, len(consolidations))
+	skipped := make([]bool, len(consolidations))
+	for i, consolidation := range consolidations {
+		source, exists := validators[consolidation.SourceIndex]
+		if exists && source.Validator.Slashed {
+			epochs[i] = epoch
+			skipped[i] = true
+
+			continue
+		}
+		if exists && source.Validator.WithdrawableEpoch > epoch {
+			epoch = source.Validator.WithdrawableEpoch
+		}
+		epochs[i] = epoch
+	}
+
+	return epochs, skipped
+}
+
+func newConsolidationValidator(index phase0.ValidatorIndex,
+	validators map[phase0.ValidatorIndex]*apiv1.Validator,
+	accountNames map[phase0.BLSPubKey]string,
+) *consolidationValidator {
+	res := &consolidationValidator{
+		Index: index,
+	}
+	if validator, exists := validators[index]; exists {
+		pubKey := validator.Validator.PublicKey
+		res.PubKey = &pubKey
+		res.Account = accountNames[pubKey]
+	}
+
+	return res
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+
+	return nil
+}
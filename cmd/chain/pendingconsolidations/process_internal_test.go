@@ -0,0 +1,71 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainpendingconsolidations
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestEstimateProcessingEpochs(t *testing.T) {
+	validator := func(withdrawableEpoch phase0.Epoch, slashed bool) *apiv1.Validator {
+		return &apiv1.Validator{
+			Validator: &phase0.Validator{
+				WithdrawableEpoch: withdrawableEpoch,
+				Slashed:           slashed,
+			},
+		}
+	}
+	validators := map[phase0.ValidatorIndex]*apiv1.Validator{
+		1: validator(90, false),
+		2: validator(150, false),
+		3: validator(120, false),
+		4: validator(500, true),
+		5: validator(200, false),
+	}
+	consolidations := []*util.PendingConsolidation{
+		{SourceIndex: 1, TargetIndex: 10},
+		{SourceIndex: 2, TargetIndex: 10},
+		{SourceIndex: 3, TargetIndex: 11},
+		{SourceIndex: 4, TargetIndex: 11},
+		{SourceIndex: 5, TargetIndex: 12},
+	}
+
+	epochs, skipped := estimateProcessingEpochs(consolidations, validators, 100)
+	require.Equal(t, []phase0.Epoch{100, 150, 150, 150, 200}, epochs)
+	require.Equal(t, []bool{false, false, false, true, false}, skipped)
+}
+
+func TestNewConsolidationValidator(t *testing.T) {
+	pubKey := phase0.BLSPubKey{0x01}
+	validators := map[phase0.ValidatorIndex]*apiv1.Validator{
+		1: {Validator: &phase0.Validator{PublicKey: pubKey}},
+	}
+	accountNames := map[phase0.BLSPubKey]string{
+		pubKey: "Wallet/Account",
+	}
+
+	require.Equal(t, &consolidationValidator{
+		Index:   1,
+		PubKey:  &pubKey,
+		Account: "Wallet/Account",
+	}, newConsolidationValidator(1, validators, accountNames))
+	require.Equal(t, &consolidationValidator{
+		Index: 2,
+	}, newConsolidationValidator(2, validators, accountNames))
+}
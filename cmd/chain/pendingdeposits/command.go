@@ -0,0 +1,91 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainpendingdeposits
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	validators []string
+
+	// Data access.
+	eth2Client       eth2client.Service
+	chainTime        chaintime.Service
+	churnParams      *util.ChurnParameters
+	validatorsFilter *util.ValidatorFilter
+
+	// Output.
+	summary *summary
+}
+
+type summary struct {
+	Slot             phase0.Slot       `json:"slot"`
+	Epoch            phase0.Epoch      `json:"epoch"`
+	Deposits         uint64            `json:"deposits"`
+	Amount           phase0.Gwei       `json:"amount"`
+	BalanceToConsume phase0.Gwei       `json:"balance_to_consume"`
+	Churn            phase0.Gwei       `json:"churn"`
+	PendingDeposits  []*pendingDeposit `json:"pending_deposits"`
+}
+
+type pendingDeposit struct {
+	Position              uint64           `json:"position"`
+	PubKey                phase0.BLSPubKey `json:"pubkey"`
+	WithdrawalCredentials string           `json:"withdrawal_credentials"`
+	Amount                phase0.Gwei      `json:"amount"`
+	Slot                  phase0.Slot      `json:"slot"`
+	EstimatedEpoch        phase0.Epoch     `json:"estimated_epoch"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.validators = viper.GetStringSlice("validators")
+
+	return c, nil
+}
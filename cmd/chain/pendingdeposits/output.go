@@ -0,0 +1,73 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainpendingdeposits
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.summary)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Pending deposits: %d (%s)\n", c.summary.Deposits, string2eth.GWeiToString(uint64(c.summary.Amount), true)))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Epoch: %d\n", c.summary.Epoch))
+		builder.WriteString(fmt.Sprintf("Deposit churn per epoch: %s\n", string2eth.GWeiToString(uint64(c.summary.Churn), true)))
+		builder.WriteString(fmt.Sprintf("Deposit balance carried over: %s\n", string2eth.GWeiToString(uint64(c.summary.BalanceToConsume), true)))
+	}
+
+	if c.validatorsFilter != nil && len(c.summary.PendingDeposits) == 0 {
+		builder.WriteString("No pending deposits for the supplied validators\n")
+	}
+	for _, deposit := range c.summary.PendingDeposits {
+		builder.WriteString(fmt.Sprintf("  %d: %#x %s, estimated epoch %d",
+			deposit.Position,
+			deposit.PubKey,
+			string2eth.GWeiToString(uint64(deposit.Amount), true),
+			deposit.EstimatedEpoch,
+		))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf(" (slot %d, withdrawal credentials %s)", deposit.Slot, deposit.WithdrawalCredentials))
+		}
+		builder.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
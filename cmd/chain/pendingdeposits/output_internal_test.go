@@ -0,0 +1,92 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainpendingdeposits
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestOutput(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *command
+		res  string
+	}{
+		{
+			name: "Quiet",
+			c: &command{
+				quiet: true,
+			},
+		},
+		{
+			name: "Good",
+			c: &command{
+				summary: &summary{
+					Slot:     3200,
+					Epoch:    100,
+					Deposits: 2,
+					Amount:   64000000000,
+					Churn:    128000000000,
+					PendingDeposits: []*pendingDeposit{
+						{
+							Position:              0,
+							PubKey:                phase0.BLSPubKey{0x01},
+							WithdrawalCredentials: "0x02",
+							Amount:                32000000000,
+							Slot:                  3100,
+							EstimatedEpoch:        101,
+						},
+						{
+							Position:              1,
+							PubKey:                phase0.BLSPubKey{0x03},
+							WithdrawalCredentials: "0x01",
+							Amount:                32000000000,
+							Slot:                  3150,
+							EstimatedEpoch:        101,
+						},
+					},
+				},
+			},
+			res: `Pending deposits: 2 (64 Ether)
+  0: 0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000 32 Ether, estimated epoch 101
+  1: 0x030000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000 32 Ether, estimated epoch 101`,
+		},
+		{
+			name: "Filtered",
+			c: &command{
+				validatorsFilter: util.NewValidatorFilter(nil, nil),
+				summary: &summary{
+					Deposits:        2,
+					Amount:          64000000000,
+					PendingDeposits: []*pendingDeposit{},
+				},
+			},
+			res: `Pending deposits: 2 (64 Ether)
+No pending deposits for the supplied validators`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
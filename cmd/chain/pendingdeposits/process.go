@@ -0,0 +1,137 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainpendingdeposits
+
+import (
+	"context"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if len(c.validators) > 0 {
+		indices, pubKeys, err := util.ParseValidatorIdentifiers(ctx, c.validators)
+		if err != nil {
+			return err
+		}
+		c.validatorsFilter = util.NewValidatorFilter(indices, pubKeys)
+	}
+
+	c.summary = &summary{}
+	var electra *util.StateStreamElectra
+	totalActiveBalance := phase0.Gwei(0)
+	deposits := make([]*util.PendingDeposit, 0)
+	err := util.StreamBeaconState(ctx, c.eth2Client, "head", &util.StateStreamHandler{
+		Header: func(header *util.StateStreamHeader) error {
+			c.summary.Slot = header.Slot
+			c.summary.Epoch = c.chainTime.SlotToEpoch(header.Slot)
+			return nil
+		},
+		Electra: func(e *util.StateStreamElectra) error {
+			electra = e
+			return nil
+		},
+		Validator: func(index phase0.ValidatorIndex, validator *phase0.Validator) error {
+			if validator.ActivationEpoch <= c.summary.Epoch && c.summary.Epoch < validator.ExitEpoch {
+				totalActiveBalance += validator.EffectiveBalance
+			}
+			c.validatorsFilter.Resolve(index, validator.PublicKey)
+			return nil
+		},
+		PendingDeposit: func(_ uint64, deposit *util.PendingDeposit) error {
+			deposits = append(deposits, deposit)
+			return nil
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain state")
+	}
+
+	c.summary.BalanceToConsume = electra.DepositBalanceToConsume
+	c.summary.Churn = c.churnParams.ActivationExitChurnLimit(totalActiveBalance)
+	// Deposits are processed in the transition to the next epoch.
+	estimates := util.EstimatePendingDepositEpochs(deposits,
+		c.summary.Epoch+1,
+		c.summary.BalanceToConsume,
+		c.summary.Churn,
+		c.churnParams.MaxPendingDepositsPerEpoch,
+	)
+
+	c.summary.PendingDeposits = make([]*pendingDeposit, 0)
+	for i, deposit := range deposits {
+		c.summary.Deposits++
+		c.summary.Amount += deposit.Amount
+		if !c.validatorsFilter.MatchesPubKey(deposit.PubKey) {
+			continue
+		}
+		c.summary.PendingDeposits = append(c.summary.PendingDeposits, &pendingDeposit{
+			Position:              uint64(i),
+			PubKey:                deposit.PubKey,
+			WithdrawalCredentials: fmt.Sprintf("%#x", deposit.WithdrawalCredentials),
+			Amount:                deposit.Amount,
+			Slot:                  deposit.Slot,
+			EstimatedEpoch:        estimates[i],
+		})
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	specResponse, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	c.churnParams, err = util.ObtainChurnParameters(specResponse.Data)
+	if err != nil {
+		return err
+	}
+	if !c.churnParams.SupportsElectra() {
+		return errors.New("chain does not support Electra")
+	}
+
+	return nil
+}
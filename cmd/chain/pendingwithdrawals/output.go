@@ -0,0 +1,76 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainpendingwithdrawals
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.summary)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Pending partial withdrawals: %d (%s)\n", c.summary.Withdrawals, string2eth.GWeiToString(uint64(c.summary.Amount), true)))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Epoch: %d\n", c.summary.Epoch))
+	}
+
+	if c.validatorsFilter != nil && len(c.summary.PendingWithdrawals) == 0 {
+		builder.WriteString("No pending partial withdrawals for the supplied validators\n")
+	}
+	for _, withdrawal := range c.summary.PendingWithdrawals {
+		builder.WriteString(fmt.Sprintf("  %d: validator %d %s, ",
+			withdrawal.Position,
+			withdrawal.ValidatorIndex,
+			string2eth.GWeiToString(uint64(withdrawal.Amount), true),
+		))
+		if withdrawal.Withdrawable {
+			builder.WriteString(fmt.Sprintf("withdrawable since epoch %d", withdrawal.WithdrawableEpoch))
+		} else {
+			builder.WriteString(fmt.Sprintf("withdrawable at epoch %d", withdrawal.WithdrawableEpoch))
+		}
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf(" (%s)", time.Unix(withdrawal.WithdrawableTime, 0)))
+		}
+		builder.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
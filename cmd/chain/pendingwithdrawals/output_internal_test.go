@@ -0,0 +1,110 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainpendingwithdrawals
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestOutput(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *command
+		res  string
+	}{
+		{
+			name: "Quiet",
+			c: &command{
+				quiet: true,
+			},
+		},
+		{
+			name: "Good",
+			c: &command{
+				summary: &summary{
+					Slot:        3200,
+					Epoch:       100,
+					Withdrawals: 2,
+					Amount:      3000000000,
+					PendingWithdrawals: []*pendingWithdrawal{
+						{
+							Position:          0,
+							ValidatorIndex:    12,
+							Amount:            1000000000,
+							WithdrawableEpoch: 99,
+							Withdrawable:      true,
+						},
+						{
+							Position:          1,
+							ValidatorIndex:    34,
+							Amount:            2000000000,
+							WithdrawableEpoch: 356,
+						},
+					},
+				},
+			},
+			res: `Pending partial withdrawals: 2 (3 Ether)
+  0: validator 12 1 Ether, withdrawable since epoch 99
+  1: validator 34 2 Ether, withdrawable at epoch 356`,
+		},
+		{
+			name: "JSON",
+			c: &command{
+				json: true,
+				summary: &summary{
+					Slot:        3200,
+					Epoch:       100,
+					Withdrawals: 1,
+					Amount:      1000000000,
+					PendingWithdrawals: []*pendingWithdrawal{
+						{
+							Position:          0,
+							ValidatorIndex:    12,
+							Amount:            1000000000,
+							WithdrawableEpoch: 99,
+							WithdrawableTime:  1606824023,
+							Withdrawable:      true,
+						},
+					},
+				},
+			},
+			res: `{"slot":"3200","epoch":"100","withdrawals":1,"amount":"1000000000","pending_withdrawals":[{"position":0,"validator_index":"12","amount":"1000000000","withdrawable_epoch":"99","withdrawable_time":1606824023,"withdrawable":true}]}`,
+		},
+		{
+			name: "Filtered",
+			c: &command{
+				validatorsFilter: util.NewValidatorFilter(nil, nil),
+				summary: &summary{
+					Withdrawals:        1,
+					Amount:             1000000000,
+					PendingWithdrawals: []*pendingWithdrawal{},
+				},
+			},
+			res: `Pending partial withdrawals: 1 (1 Ether)
+No pending partial withdrawals for the supplied validators`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainpendingwithdrawals
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if len(c.validators) > 0 {
+		indices, pubKeys, err := util.ParseValidatorIdentifiers(ctx, c.validators)
+		if err != nil {
+			return err
+		}
+		c.validatorsFilter = util.NewValidatorFilter(indices, pubKeys)
+	}
+
+	c.summary = &summary{
+		PendingWithdrawals: make([]*pendingWithdrawal, 0),
+	}
+	handler := &util.StateStreamHandler{
+		Header: func(header *util.StateStreamHeader) error {
+			c.summary.Slot = header.Slot
+			c.summary.Epoch = c.chainTime.SlotToEpoch(header.Slot)
+			return nil
+		},
+		PendingPartialWithdrawal: func(position uint64, withdrawal *util.PendingPartialWithdrawal) error {
+			c.summary.Withdrawals++
+			c.summary.Amount += withdrawal.Amount
+			if !c.validatorsFilter.MatchesIndex(withdrawal.ValidatorIndex) {
+				return nil
+			}
+			c.summary.PendingWithdrawals = append(c.summary.PendingWithdrawals, &pendingWithdrawal{
+				Position:          position,
+				ValidatorIndex:    withdrawal.ValidatorIndex,
+				Amount:            withdrawal.Amount,
+				WithdrawableEpoch: withdrawal.WithdrawableEpoch,
+				WithdrawableTime:  c.chainTime.StartOfEpoch(withdrawal.WithdrawableEpoch).Unix(),
+				Withdrawable:      withdrawal.WithdrawableEpoch <= c.summary.Epoch,
+			})
+			return nil
+		},
+	}
+	if c.validatorsFilter != nil {
+		// Validators supplied by public key need to be resolved to indices.
+		handler.Validator = func(index phase0.ValidatorIndex, validator *phase0.Validator) error {
+			c.validatorsFilter.Resolve(index, validator.PublicKey)
+			return nil
+		}
+	}
+	if err := util.StreamBeaconState(ctx, c.eth2Client, "head", handler); err != nil {
+		return errors.Wrap(err, "failed to obtain state")
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	return nil
+}
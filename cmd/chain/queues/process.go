@@ -49,7 +49,7 @@ func (c *command) process(ctx context.Context) error {
 		if validator.Validator.ActivationEligibilityEpoch <= epoch && validator.Validator.ActivationEpoch > epoch {
 			c.activationQueue++
 		}
-		if validator.Validator.ExitEpoch != 0xffffffffffffffff && validator.Validator.ExitEpoch > epoch {
+		if validator.Validator.ExitEpoch != util.FarFutureEpoch && validator.Validator.ExitEpoch > epoch {
 			c.exitQueue++
 		}
 	}
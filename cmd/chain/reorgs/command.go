@@ -0,0 +1,107 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainreorgs
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	follow    bool
+	webhook   string
+	fromEpoch string
+	toEpoch   string
+
+	// Data access.
+	eth2Client      eth2client.Service
+	chainTime       chaintime.Service
+	headersProvider eth2client.BeaconBlockHeadersProvider
+
+	// Output.
+	firstEpoch phase0.Epoch
+	lastEpoch  phase0.Epoch
+	reorgs     []*reorg
+}
+
+type reorg struct {
+	Slot  phase0.Slot `json:"slot"`
+	Depth uint64      `json:"depth"`
+	// OldHead is the head of the chain that was orphaned.
+	OldHead phase0.Root `json:"old_head_block"`
+	// NewHead is the head of the chain that replaced it, if known.
+	NewHead        *phase0.Root     `json:"new_head_block,omitempty"`
+	OrphanedBlocks []*orphanedBlock `json:"orphaned_blocks"`
+}
+
+// orphanedBlock is a block that is no longer part of the canonical chain.
+type orphanedBlock struct {
+	Slot          phase0.Slot           `json:"slot"`
+	Root          phase0.Root           `json:"root"`
+	ProposerIndex phase0.ValidatorIndex `json:"proposer_index"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.follow = viper.GetBool("follow")
+	c.webhook = viper.GetString("webhook")
+	c.fromEpoch = viper.GetString("from-epoch")
+	c.toEpoch = viper.GetString("to-epoch")
+	if c.follow {
+		if c.fromEpoch != "" || c.toEpoch != "" {
+			return nil, errors.New("epochs cannot be supplied when following")
+		}
+	} else {
+		if c.webhook != "" {
+			return nil, errors.New("webhook requires follow")
+		}
+		if c.fromEpoch == "" {
+			return nil, errors.New("from epoch is required")
+		}
+	}
+
+	return c, nil
+}
@@ -0,0 +1,94 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainreorgs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet || c.follow {
+		// Reorgs are output as they occur when following.
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.reorgs)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	if len(c.reorgs) == 0 {
+		return fmt.Sprintf("No reorgs found in epochs %d to %d", c.firstEpoch, c.lastEpoch), nil
+	}
+
+	builder := strings.Builder{}
+	for _, reorg := range c.reorgs {
+		builder.WriteString(c.reorgText(reorg))
+		builder.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// outputReorg returns the output for a single reorg.
+func (c *command) outputReorg(reorg *reorg) (string, error) {
+	if c.json {
+		data, err := json.Marshal(reorg)
+		if err != nil {
+			return "", err
+		}
+
+		return string(data), nil
+	}
+
+	return c.reorgText(reorg), nil
+}
+
+func (c *command) reorgText(reorg *reorg) string {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Slot %d: reorg of depth %d, old head %#x", reorg.Slot, reorg.Depth, reorg.OldHead))
+	if reorg.NewHead != nil {
+		builder.WriteString(fmt.Sprintf(", new head %#x", *reorg.NewHead))
+	}
+	if len(reorg.OrphanedBlocks) > 0 {
+		proposers := make([]string, 0, len(reorg.OrphanedBlocks))
+		for _, block := range reorg.OrphanedBlocks {
+			proposers = append(proposers, fmt.Sprintf("%d (slot %d)", block.ProposerIndex, block.Slot))
+		}
+		builder.WriteString(fmt.Sprintf("; orphaned proposers %s", strings.Join(proposers, ", ")))
+	}
+	if c.verbose {
+		for _, block := range reorg.OrphanedBlocks {
+			builder.WriteString(fmt.Sprintf("\n  Orphaned block %#x at slot %d", block.Root, block.Slot))
+		}
+	}
+
+	return builder.String()
+}
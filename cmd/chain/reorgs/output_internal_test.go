@@ -0,0 +1,114 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainreorgs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	newReorgs := func() []*reorg {
+		return []*reorg{
+			{
+				Slot:    12,
+				Depth:   2,
+				OldHead: phase0.Root{0x03},
+				NewHead: &phase0.Root{0x04},
+				OrphanedBlocks: []*orphanedBlock{
+					{Slot: 11, Root: phase0.Root{0x02}, ProposerIndex: 101},
+					{Slot: 12, Root: phase0.Root{0x03}, ProposerIndex: 102},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		command *command
+		res     string
+	}{
+		{
+			name: "Quiet",
+			command: &command{
+				quiet:  true,
+				reorgs: newReorgs(),
+			},
+		},
+		{
+			name: "Follow",
+			command: &command{
+				follow: true,
+			},
+		},
+		{
+			name: "None",
+			command: &command{
+				firstEpoch: 1,
+				lastEpoch:  2,
+				reorgs:     []*reorg{},
+			},
+			res: "No reorgs found in epochs 1 to 2",
+		},
+		{
+			name: "Reorgs",
+			command: &command{
+				reorgs: newReorgs(),
+			},
+			res: "Slot 12: reorg of depth 2, old head 0x0300000000000000000000000000000000000000000000000000000000000000, new head 0x0400000000000000000000000000000000000000000000000000000000000000; orphaned proposers 101 (slot 11), 102 (slot 12)",
+		},
+		{
+			name: "Verbose",
+			command: &command{
+				verbose: true,
+				reorgs: []*reorg{
+					{
+						Slot:           12,
+						Depth:          1,
+						OldHead:        phase0.Root{0x03},
+						OrphanedBlocks: []*orphanedBlock{{Slot: 12, Root: phase0.Root{0x03}, ProposerIndex: 102}},
+					},
+				},
+			},
+			res: `Slot 12: reorg of depth 1, old head 0x0300000000000000000000000000000000000000000000000000000000000000; orphaned proposers 102 (slot 12)
+  Orphaned block 0x0300000000000000000000000000000000000000000000000000000000000000 at slot 12`,
+		},
+		{
+			name: "JSON",
+			command: &command{
+				json: true,
+				reorgs: []*reorg{
+					{
+						Slot:           12,
+						Depth:          1,
+						OldHead:        phase0.Root{0x03},
+						OrphanedBlocks: []*orphanedBlock{{Slot: 12, Root: phase0.Root{0x03}, ProposerIndex: 102}},
+					},
+				},
+			},
+			res: `[{"slot":"12","depth":1,"old_head_block":"0x0300000000000000000000000000000000000000000000000000000000000000","orphaned_blocks":[{"slot":"12","root":"0x0300000000000000000000000000000000000000000000000000000000000000","proposer_index":"102"}]}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.command.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,295 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainreorgs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if c.follow {
+		return c.followReorgs(ctx)
+	}
+
+	return c.historicalReorgs(ctx)
+}
+
+// followReorgs reports reorgs as they are announced by the beacon node.
+func (c *command) followReorgs(ctx context.Context) error {
+	err := c.eth2Client.(eth2client.EventsProvider).Events(ctx, []string{"chain_reorg"}, func(event *apiv1.Event) {
+		data, isReorg := event.Data.(*apiv1.ChainReorgEvent)
+		if !isReorg {
+			return
+		}
+		c.handleReorgEvent(ctx, data)
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect for events")
+	}
+
+	<-ctx.Done()
+
+	return nil
+}
+
+func (c *command) handleReorgEvent(ctx context.Context, event *apiv1.ChainReorgEvent) {
+	newHead := event.NewHeadBlock
+	reorg := &reorg{
+		Slot:    event.Slot,
+		Depth:   event.Depth,
+		OldHead: event.OldHeadBlock,
+		NewHead: &newHead,
+	}
+	var err error
+	reorg.OrphanedBlocks, _, err = c.orphanedChain(ctx, event.OldHeadBlock, nil)
+	if err != nil && !c.quiet {
+		fmt.Printf("Failed to obtain orphaned blocks for reorg at slot %d: %v\n", event.Slot, err)
+	}
+
+	if !c.quiet {
+		res, err := c.outputReorg(reorg)
+		if err == nil {
+			fmt.Println(res)
+		}
+	}
+
+	if c.webhook != "" {
+		if err := c.postWebhook(ctx, reorg); err != nil && !c.quiet {
+			fmt.Printf("Failed to send webhook for reorg at slot %d: %v\n", event.Slot, err)
+		}
+	}
+}
+
+// historicalReorgs finds the reorgs in the given range from the
+// non-canonical blocks known to the beacon node.
+func (c *command) historicalReorgs(ctx context.Context) error {
+	var err error
+	c.firstEpoch, err = util.ParseEpoch(ctx, c.chainTime, c.fromEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse from epoch")
+	}
+	c.lastEpoch, err = util.ParseEpoch(ctx, c.chainTime, c.toEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse to epoch")
+	}
+	if c.firstEpoch > c.lastEpoch {
+		return fmt.Errorf("from epoch %d is after to epoch %d", c.firstEpoch, c.lastEpoch)
+	}
+
+	firstSlot := c.chainTime.FirstSlotOfEpoch(c.firstEpoch)
+	lastSlot := c.chainTime.LastSlotOfEpoch(c.lastEpoch)
+	if lastSlot > c.chainTime.CurrentSlot() {
+		lastSlot = c.chainTime.CurrentSlot()
+	}
+	canonical := make(map[phase0.Slot]phase0.Root)
+	orphaned := make(map[phase0.Root]*apiv1.BeaconBlockHeader)
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		if c.debug {
+			fmt.Printf("Fetching block headers for slot %d\n", slot)
+		}
+		headers, err := util.FetchBeaconBlockHeaders(ctx, c.eth2Client, slot)
+		if err != nil {
+			return errors.Wrapf(err, "failed to obtain block headers for slot %d", slot)
+		}
+		for _, header := range headers {
+			if header.Canonical {
+				canonical[slot] = header.Root
+			} else {
+				orphaned[header.Root] = header
+			}
+		}
+	}
+
+	c.reorgs = make([]*reorg, 0)
+	for _, tip := range orphanedTips(orphaned) {
+		blocks, ancestor, err := c.orphanedChain(ctx, tip.Root, orphaned)
+		if err != nil {
+			return err
+		}
+		tipSlot := tip.Header.Message.Slot
+		reorg := &reorg{
+			Slot:           tipSlot,
+			OldHead:        tip.Root,
+			NewHead:        nextCanonical(canonical, tipSlot, lastSlot),
+			OrphanedBlocks: blocks,
+		}
+		if ancestor != nil {
+			reorg.Depth = uint64(tipSlot - ancestor.Header.Message.Slot)
+		} else {
+			// The start of the orphaned chain is not known, so use what we have.
+			reorg.Depth = uint64(tipSlot-blocks[0].Slot) + 1
+		}
+		c.reorgs = append(c.reorgs, reorg)
+	}
+
+	return nil
+}
+
+// orphanedChain walks back from the given block until it reaches a canonical
+// block, returning the orphaned blocks in slot order along with the
+// canonical ancestor, if it could be obtained.
+func (c *command) orphanedChain(ctx context.Context,
+	root phase0.Root,
+	known map[phase0.Root]*apiv1.BeaconBlockHeader,
+) (
+	[]*orphanedBlock,
+	*apiv1.BeaconBlockHeader,
+	error,
+) {
+	blocks := make([]*orphanedBlock, 0)
+	for {
+		header, exists := known[root]
+		if !exists {
+			response, err := c.headersProvider.BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{
+				Block: root.String(),
+			})
+			if err != nil {
+				var apiErr *api.Error
+				if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+					// Block has been pruned.
+					break
+				}
+				return nil, nil, errors.Wrapf(err, "failed to obtain block header for %#x", root)
+			}
+			header = response.Data
+		}
+		if header.Canonical {
+			sort.Slice(blocks, func(i int, j int) bool {
+				return blocks[i].Slot < blocks[j].Slot
+			})
+			return blocks, header, nil
+		}
+		blocks = append(blocks, &orphanedBlock{
+			Slot:          header.Header.Message.Slot,
+			Root:          header.Root,
+			ProposerIndex: header.Header.Message.ProposerIndex,
+		})
+		root = header.Header.Message.ParentRoot
+	}
+
+	sort.Slice(blocks, func(i int, j int) bool {
+		return blocks[i].Slot < blocks[j].Slot
+	})
+
+	return blocks, nil, nil
+}
+
+// orphanedTips returns the orphaned blocks that are not the parent of any
+// other orphaned block, in slot order.
+func orphanedTips(orphaned map[phase0.Root]*apiv1.BeaconBlockHeader) []*apiv1.BeaconBlockHeader {
+	parents := make(map[phase0.Root]bool, len(orphaned))
+	for _, header := range orphaned {
+		parents[header.Header.Message.ParentRoot] = true
+	}
+
+	tips := make([]*apiv1.BeaconBlockHeader, 0)
+	for root, header := range orphaned {
+		if !parents[root] {
+			tips = append(tips, header)
+		}
+	}
+	sort.Slice(tips, func(i int, j int) bool {
+		if tips[i].Header.Message.Slot != tips[j].Header.Message.Slot {
+			return tips[i].Header.Message.Slot < tips[j].Header.Message.Slot
+		}
+		return bytes.Compare(tips[i].Root[:], tips[j].Root[:]) < 0
+	})
+
+	return tips
+}
+
+// nextCanonical returns the first canonical block at or after the given slot.
+func nextCanonical(canonical map[phase0.Slot]phase0.Root, slot phase0.Slot, lastSlot phase0.Slot) *phase0.Root {
+	for ; slot <= lastSlot; slot++ {
+		if root, exists := canonical[slot]; exists {
+			return &root
+		}
+	}
+
+	return nil
+}
+
+// postWebhook sends details of the reorg to the webhook.
+func (c *command) postWebhook(ctx context.Context, reorg *reorg) error {
+	data, err := json.Marshal(reorg)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal reorg")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.webhook, bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := util.HTTPClient().Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to call webhook")
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.headersProvider, isProvider = c.eth2Client.(eth2client.BeaconBlockHeadersProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon block headers")
+	}
+
+	return nil
+}
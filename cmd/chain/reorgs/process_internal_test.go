@@ -0,0 +1,113 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainreorgs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func testHeader(root byte, parent byte, slot phase0.Slot, proposer phase0.ValidatorIndex, canonical bool) *apiv1.BeaconBlockHeader {
+	return &apiv1.BeaconBlockHeader{
+		Root:      phase0.Root{root},
+		Canonical: canonical,
+		Header: &phase0.SignedBeaconBlockHeader{
+			Message: &phase0.BeaconBlockHeader{
+				Slot:          slot,
+				ProposerIndex: proposer,
+				ParentRoot:    phase0.Root{parent},
+			},
+		},
+	}
+}
+
+func TestOrphanedChain(t *testing.T) {
+	// Canonical 0x01 at slot 10, orphaned 0x02 and 0x03 at slots 11 and 12
+	// building on it, and orphaned 0x04 at slot 13 building on 0x05, which is
+	// not known.
+	headers := []*apiv1.BeaconBlockHeader{
+		testHeader(0x01, 0x00, 10, 100, true),
+		testHeader(0x02, 0x01, 11, 101, false),
+		testHeader(0x03, 0x02, 12, 102, false),
+		testHeader(0x04, 0x05, 13, 103, false),
+	}
+	known := make(map[phase0.Root]*apiv1.BeaconBlockHeader)
+	orphaned := make(map[phase0.Root]*apiv1.BeaconBlockHeader)
+	for _, header := range headers {
+		known[header.Root] = header
+		if !header.Canonical {
+			orphaned[header.Root] = header
+		}
+	}
+
+	tips := orphanedTips(orphaned)
+	require.Len(t, tips, 2)
+	require.Equal(t, phase0.Root{0x03}, tips[0].Root)
+	require.Equal(t, phase0.Root{0x04}, tips[1].Root)
+
+	c := &command{}
+	blocks, ancestor, err := c.orphanedChain(context.Background(), phase0.Root{0x03}, known)
+	require.NoError(t, err)
+	require.Equal(t, []*orphanedBlock{
+		{Slot: 11, Root: phase0.Root{0x02}, ProposerIndex: 101},
+		{Slot: 12, Root: phase0.Root{0x03}, ProposerIndex: 102},
+	}, blocks)
+	require.Equal(t, phase0.Root{0x01}, ancestor.Root)
+}
+
+func TestNextCanonical(t *testing.T) {
+	canonical := map[phase0.Slot]phase0.Root{
+		10: {0x01},
+		13: {0x02},
+	}
+
+	require.Equal(t, &phase0.Root{0x01}, nextCanonical(canonical, 10, 20))
+	require.Equal(t, &phase0.Root{0x02}, nextCanonical(canonical, 11, 20))
+	require.Nil(t, nextCanonical(canonical, 11, 12))
+}
+
+func TestPostWebhook(t *testing.T) {
+	var received *reorg
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		received = &reorg{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(received))
+		if received.Depth > 10 {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	c := &command{
+		webhook: server.URL,
+	}
+	sent := &reorg{
+		Slot:           12,
+		Depth:          2,
+		OldHead:        phase0.Root{0x03},
+		OrphanedBlocks: []*orphanedBlock{{Slot: 12, Root: phase0.Root{0x03}, ProposerIndex: 102}},
+	}
+	require.NoError(t, c.postWebhook(context.Background(), sent))
+	require.Equal(t, sent, received)
+
+	require.EqualError(t, c.postWebhook(context.Background(), &reorg{Depth: 11}), "webhook returned status 400")
+}
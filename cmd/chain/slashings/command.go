@@ -0,0 +1,88 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainslashings
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	fromEpoch string
+	toEpoch   string
+
+	// Data access.
+	eth2Client     eth2client.Service
+	chainTime      chaintime.Service
+	blocksProvider eth2client.SignedBeaconBlockProvider
+
+	// Output.
+	firstEpoch phase0.Epoch
+	lastEpoch  phase0.Epoch
+	slashings  []*slashing
+}
+
+type slashing struct {
+	Slot             phase0.Slot              `json:"slot"`
+	BlockRoot        phase0.Root              `json:"block_root"`
+	Whistleblower    phase0.ValidatorIndex    `json:"whistleblower_index"`
+	Type             string                   `json:"type"`
+	Nature           string                   `json:"nature"`
+	Slashed          []phase0.ValidatorIndex  `json:"slashed_indices"`
+	ProposerSlashing *phase0.ProposerSlashing `json:"proposer_slashing,omitempty"`
+	AttesterSlashing *phase0.AttesterSlashing `json:"attester_slashing,omitempty"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.fromEpoch = viper.GetString("from-epoch")
+	if c.fromEpoch == "" {
+		return nil, errors.New("from epoch is required")
+	}
+	c.toEpoch = viper.GetString("to-epoch")
+
+	return c, nil
+}
@@ -0,0 +1,88 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainslashings
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.slashings)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	proposerSlashings := 0
+	attesterSlashings := 0
+	for _, slashing := range c.slashings {
+		slashed := make([]string, len(slashing.Slashed))
+		for i := range slashing.Slashed {
+			slashed[i] = fmt.Sprintf("%d", slashing.Slashed[i])
+		}
+		builder.WriteString(fmt.Sprintf("Slot %d: %s slashing (%s) of validator %s included by validator %d\n", slashing.Slot, slashing.Type, slashing.Nature, strings.Join(slashed, ", "), slashing.Whistleblower))
+		if slashing.ProposerSlashing != nil {
+			proposerSlashings++
+			if c.verbose {
+				outputHeader(&builder, 1, slashing.ProposerSlashing.SignedHeader1.Message)
+				outputHeader(&builder, 2, slashing.ProposerSlashing.SignedHeader2.Message)
+			}
+		}
+		if slashing.AttesterSlashing != nil {
+			attesterSlashings++
+			if c.verbose {
+				outputAttestationData(&builder, 1, slashing.AttesterSlashing.Attestation1.Data)
+				outputAttestationData(&builder, 2, slashing.AttesterSlashing.Attestation2.Data)
+			}
+		}
+	}
+
+	if len(c.slashings) == 0 {
+		builder.WriteString(fmt.Sprintf("No slashings in epochs %d-%d", c.firstEpoch, c.lastEpoch))
+	} else {
+		builder.WriteString(fmt.Sprintf("%d proposer slashings and %d attester slashings in epochs %d-%d", proposerSlashings, attesterSlashings, c.firstEpoch, c.lastEpoch))
+	}
+
+	return builder.String(), nil
+}
+
+func outputHeader(builder *strings.Builder, index int, header *phase0.BeaconBlockHeader) {
+	builder.WriteString(fmt.Sprintf("  Block %d: slot %d, parent root %#x, state root %#x, body root %#x\n", index, header.Slot, header.ParentRoot, header.StateRoot, header.BodyRoot))
+}
+
+func outputAttestationData(builder *strings.Builder, index int, data *phase0.AttestationData) {
+	builder.WriteString(fmt.Sprintf("  Attestation %d: slot %d, beacon block root %#x, source epoch %d, target epoch %d\n", index, data.Slot, data.BeaconBlockRoot, data.Source.Epoch, data.Target.Epoch))
+}
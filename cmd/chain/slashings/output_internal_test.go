@@ -0,0 +1,87 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainslashings
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	slashings := []*slashing{
+		{
+			Slot:          1000,
+			Whistleblower: 99,
+			Type:          "proposer",
+			Nature:        "double proposal",
+			Slashed:       []phase0.ValidatorIndex{12},
+			ProposerSlashing: &phase0.ProposerSlashing{
+				SignedHeader1: header(990, 12, 0x01),
+				SignedHeader2: header(990, 12, 0x02),
+			},
+		},
+		{
+			Slot:          1001,
+			Whistleblower: 100,
+			Type:          "attester",
+			Nature:        "double vote",
+			Slashed:       []phase0.ValidatorIndex{5, 7},
+			AttesterSlashing: &phase0.AttesterSlashing{
+				Attestation1: attestation([]uint64{5, 7}, 30, 31, 0x01),
+				Attestation2: attestation([]uint64{5, 7}, 30, 31, 0x02),
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		verbose   bool
+		slashings []*slashing
+		res       string
+	}{
+		{
+			name:      "None",
+			slashings: []*slashing{},
+			res:       "No slashings in epochs 30-32",
+		},
+		{
+			name:      "Text",
+			slashings: slashings,
+			res:       "Slot 1000: proposer slashing (double proposal) of validator 12 included by validator 99\nSlot 1001: attester slashing (double vote) of validator 5, 7 included by validator 100\n1 proposer slashings and 1 attester slashings in epochs 30-32",
+		},
+		{
+			name:      "Verbose",
+			verbose:   true,
+			slashings: slashings[:1],
+			res:       "Slot 1000: proposer slashing (double proposal) of validator 12 included by validator 99\n  Block 1: slot 990, parent root 0x0000000000000000000000000000000000000000000000000000000000000000, state root 0x0000000000000000000000000000000000000000000000000000000000000000, body root 0x0100000000000000000000000000000000000000000000000000000000000000\n  Block 2: slot 990, parent root 0x0000000000000000000000000000000000000000000000000000000000000000, state root 0x0000000000000000000000000000000000000000000000000000000000000000, body root 0x0200000000000000000000000000000000000000000000000000000000000000\n1 proposer slashings and 0 attester slashings in epochs 30-32",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				verbose:    test.verbose,
+				firstEpoch: 30,
+				lastEpoch:  32,
+				slashings:  test.slashings,
+			}
+			res, err := c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
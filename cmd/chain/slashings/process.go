@@ -0,0 +1,167 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainslashings
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	var err error
+	c.firstEpoch, err = util.ParseEpoch(ctx, c.chainTime, c.fromEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse from epoch")
+	}
+	c.lastEpoch, err = util.ParseEpoch(ctx, c.chainTime, c.toEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse to epoch")
+	}
+	if c.firstEpoch > c.lastEpoch {
+		return fmt.Errorf("from epoch %d is after to epoch %d", c.firstEpoch, c.lastEpoch)
+	}
+
+	c.slashings = make([]*slashing, 0)
+	firstSlot := c.chainTime.FirstSlotOfEpoch(c.firstEpoch)
+	lastSlot := c.chainTime.LastSlotOfEpoch(c.lastEpoch)
+	if lastSlot > c.chainTime.CurrentSlot() {
+		lastSlot = c.chainTime.CurrentSlot()
+	}
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		if c.debug {
+			fmt.Printf("Fetching block for slot %d\n", slot)
+		}
+		blockResponse, err := c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+			Block: fmt.Sprintf("%d", slot),
+		})
+		if err != nil {
+			var apiErr *api.Error
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				// No block for this slot.
+				continue
+			}
+
+			return errors.Wrapf(err, "failed to obtain block for slot %d", slot)
+		}
+		if blockResponse.Data == nil {
+			continue
+		}
+		slashings, err := blockSlashings(blockResponse.Data)
+		if err != nil {
+			return errors.Wrapf(err, "failed to obtain slashings for slot %d", slot)
+		}
+		c.slashings = append(c.slashings, slashings...)
+	}
+
+	return nil
+}
+
+// blockSlashings returns the slashings included in the block.
+func blockSlashings(block *spec.VersionedSignedBeaconBlock) ([]*slashing, error) {
+	proposerSlashings, err := block.ProposerSlashings()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain proposer slashings")
+	}
+	attesterSlashings, err := block.AttesterSlashings()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain attester slashings")
+	}
+	if len(proposerSlashings) == 0 && len(attesterSlashings) == 0 {
+		return nil, nil
+	}
+
+	slot, err := block.Slot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain block slot")
+	}
+	root, err := block.Root()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain block root")
+	}
+	proposerIndex, err := block.ProposerIndex()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain block proposer")
+	}
+
+	res := make([]*slashing, 0, len(proposerSlashings)+len(attesterSlashings))
+	for _, proposerSlashing := range proposerSlashings {
+		res = append(res, &slashing{
+			Slot:             slot,
+			BlockRoot:        root,
+			Whistleblower:    proposerIndex,
+			Type:             "proposer",
+			Nature:           "double proposal",
+			Slashed:          []phase0.ValidatorIndex{proposerSlashing.SignedHeader1.Message.ProposerIndex},
+			ProposerSlashing: proposerSlashing,
+		})
+	}
+	for _, attesterSlashing := range attesterSlashings {
+		res = append(res, &slashing{
+			Slot:             slot,
+			BlockRoot:        root,
+			Whistleblower:    proposerIndex,
+			Type:             "attester",
+			Nature:           util.AttesterSlashingNature(attesterSlashing),
+			Slashed:          util.AttesterSlashingIndices(attesterSlashing),
+			AttesterSlashing: attesterSlashing,
+		})
+	}
+
+	return res, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainslashings
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func header(slot phase0.Slot, proposer phase0.ValidatorIndex, bodyRoot byte) *phase0.SignedBeaconBlockHeader {
+	return &phase0.SignedBeaconBlockHeader{
+		Message: &phase0.BeaconBlockHeader{
+			Slot:          slot,
+			ProposerIndex: proposer,
+			BodyRoot:      phase0.Root{bodyRoot},
+		},
+	}
+}
+
+func attestation(indices []uint64, source phase0.Epoch, target phase0.Epoch, root byte) *phase0.IndexedAttestation {
+	return &phase0.IndexedAttestation{
+		AttestingIndices: indices,
+		Data: &phase0.AttestationData{
+			BeaconBlockRoot: phase0.Root{root},
+			Source:          &phase0.Checkpoint{Epoch: source},
+			Target:          &phase0.Checkpoint{Epoch: target},
+		},
+	}
+}
+
+func block(proposerSlashings []*phase0.ProposerSlashing, attesterSlashings []*phase0.AttesterSlashing) *spec.VersionedSignedBeaconBlock {
+	return &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionPhase0,
+		Phase0: &phase0.SignedBeaconBlock{
+			Message: &phase0.BeaconBlock{
+				Slot:          1000,
+				ProposerIndex: 99,
+				Body: &phase0.BeaconBlockBody{
+					ETH1Data: &phase0.ETH1Data{
+						BlockHash: make([]byte, 32),
+					},
+					ProposerSlashings: proposerSlashings,
+					AttesterSlashings: attesterSlashings,
+					Attestations:      []*phase0.Attestation{},
+					Deposits:          []*phase0.Deposit{},
+					VoluntaryExits:    []*phase0.SignedVoluntaryExit{},
+				},
+			},
+		},
+	}
+}
+
+func TestBlockSlashings(t *testing.T) {
+	proposerSlashing := &phase0.ProposerSlashing{
+		SignedHeader1: header(990, 12, 0x01),
+		SignedHeader2: header(990, 12, 0x02),
+	}
+	attesterSlashing := &phase0.AttesterSlashing{
+		Attestation1: attestation([]uint64{1, 5, 7}, 8, 12, 0x01),
+		Attestation2: attestation([]uint64{5, 7, 8}, 10, 11, 0x02),
+	}
+
+	slashings, err := blockSlashings(block(nil, nil))
+	require.NoError(t, err)
+	require.Empty(t, slashings)
+
+	slashings, err = blockSlashings(block([]*phase0.ProposerSlashing{proposerSlashing}, []*phase0.AttesterSlashing{attesterSlashing}))
+	require.NoError(t, err)
+	require.Len(t, slashings, 2)
+
+	require.Equal(t, phase0.Slot(1000), slashings[0].Slot)
+	require.Equal(t, phase0.ValidatorIndex(99), slashings[0].Whistleblower)
+	require.Equal(t, "proposer", slashings[0].Type)
+	require.Equal(t, "double proposal", slashings[0].Nature)
+	require.Equal(t, []phase0.ValidatorIndex{12}, slashings[0].Slashed)
+	require.Equal(t, proposerSlashing, slashings[0].ProposerSlashing)
+
+	require.Equal(t, "attester", slashings[1].Type)
+	require.Equal(t, "surround vote", slashings[1].Nature)
+	require.Equal(t, []phase0.ValidatorIndex{5, 7}, slashings[1].Slashed)
+	require.Equal(t, attesterSlashing, slashings[1].AttesterSlashing)
+	require.Equal(t, slashings[0].BlockRoot, slashings[1].BlockRoot)
+	require.NotEqual(t, phase0.Root{}, slashings[0].BlockRoot)
+}
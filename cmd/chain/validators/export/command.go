@@ -0,0 +1,143 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainvalidatorsexport
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"io"
+	"strings"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+// validatorStates are the states, and prefixes of states, by which
+// validators can be filtered.
+var validatorStates = []string{
+	"pending",
+	"pending_initialized",
+	"pending_queued",
+	"active",
+	"active_ongoing",
+	"active_exiting",
+	"active_slashed",
+	"exited",
+	"exited_unslashed",
+	"exited_slashed",
+	"withdrawal",
+	"withdrawal_possible",
+	"withdrawal_done",
+}
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	stateID           string
+	statuses          []string
+	credentialsPrefix []byte
+	file              string
+
+	// Data access.
+	eth2Client eth2client.Service
+	chainTime  chaintime.Service
+	writer     io.Writer
+	csvWriter  *csv.Writer
+
+	// Output.
+	exported uint64
+}
+
+type validatorJSON struct {
+	Index                      uint64 `json:"index"`
+	PubKey                     string `json:"pubkey"`
+	WithdrawalCredentials      string `json:"withdrawal_credentials"`
+	Balance                    uint64 `json:"balance"`
+	EffectiveBalance           uint64 `json:"effective_balance"`
+	Status                     string `json:"status"`
+	Slashed                    bool   `json:"slashed"`
+	ActivationEligibilityEpoch uint64 `json:"activation_eligibility_epoch"`
+	ActivationEpoch            uint64 `json:"activation_epoch"`
+	ExitEpoch                  uint64 `json:"exit_epoch"`
+	WithdrawableEpoch          uint64 `json:"withdrawable_epoch"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.stateID = viper.GetString("state-id")
+	if c.stateID == "" {
+		return nil, errors.New("state ID is required")
+	}
+
+	for _, status := range viper.GetStringSlice("status") {
+		status = strings.ToLower(strings.TrimSpace(status))
+		if !validStatus(status) {
+			return nil, errors.Errorf("unknown status %s", status)
+		}
+		c.statuses = append(c.statuses, status)
+	}
+
+	if prefix := viper.GetString("credentials-prefix"); prefix != "" {
+		var err error
+		c.credentialsPrefix, err = hex.DecodeString(strings.TrimPrefix(prefix, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid credentials prefix")
+		}
+		if len(c.credentialsPrefix) == 0 || len(c.credentialsPrefix) > 32 {
+			return nil, errors.New("credentials prefix must be between 1 and 32 bytes")
+		}
+	}
+
+	c.file = viper.GetString("file")
+
+	return c, nil
+}
+
+func validStatus(status string) bool {
+	for _, validatorState := range validatorStates {
+		if status == validatorState {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,97 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainvalidatorsexport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"state-id": "head",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "StateIDMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+			},
+			err: "state ID is required",
+		},
+		{
+			name: "StatusUnknown",
+			vars: map[string]interface{}{
+				"timeout":  "5s",
+				"state-id": "head",
+				"status":   []string{"active", "retired"},
+			},
+			err: "unknown status retired",
+		},
+		{
+			name: "CredentialsPrefixInvalid",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"state-id":           "head",
+				"credentials-prefix": "0xinvalid",
+			},
+			err: "invalid credentials prefix: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name: "CredentialsPrefixTooLong",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"state-id":           "head",
+				"credentials-prefix": "0x010000000000000000000000000000000000000000000000000000000000000000",
+			},
+			err: "credentials prefix must be between 1 and 32 bytes",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":            "5s",
+				"state-id":           "finalized",
+				"status":             []string{"active", "pending_queued"},
+				"credentials-prefix": "0x01",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainvalidatorsexport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		command *command
+		res     string
+	}{
+		{
+			name: "Quiet",
+			command: &command{
+				quiet:    true,
+				file:     "validators.csv",
+				exported: 10,
+			},
+		},
+		{
+			name: "Console",
+			command: &command{
+				exported: 10,
+			},
+		},
+		{
+			name: "File",
+			command: &command{
+				file:     "validators.csv",
+				exported: 10,
+			},
+			res: "Exported 10 validators to validators.csv",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.command.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainvalidatorsexport
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+var csvHeader = []string{
+	"index",
+	"pubkey",
+	"withdrawal_credentials",
+	"balance",
+	"effective_balance",
+	"status",
+	"slashed",
+	"activation_eligibility_epoch",
+	"activation_epoch",
+	"exit_epoch",
+	"withdrawable_epoch",
+}
+
+// exportValidator is a validator that has passed the credentials filter,
+// awaiting its balance.
+type exportValidator struct {
+	index     phase0.ValidatorIndex
+	validator *phase0.Validator
+}
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if c.file == "" {
+		c.writer = os.Stdout
+		return c.export(ctx)
+	}
+
+	file, err := os.Create(c.file)
+	if err != nil {
+		return errors.Wrap(err, "failed to create file")
+	}
+	buffered := bufio.NewWriter(file)
+	c.writer = buffered
+	if err := c.export(ctx); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := buffered.Flush(); err != nil {
+		_ = file.Close()
+		return errors.Wrap(err, "failed to write file")
+	}
+
+	return file.Close()
+}
+
+// export streams the validators in the state to the writer.
+func (c *command) export(ctx context.Context) error {
+	handler, err := c.handler()
+	if err != nil {
+		return err
+	}
+
+	if err := util.StreamBeaconState(ctx, c.eth2Client, c.stateID, handler); err != nil {
+		return errors.Wrap(err, "failed to obtain state")
+	}
+
+	return c.flush()
+}
+
+// handler returns the state stream handler that writes the validators.
+// Validators precede balances in the state, so validators that pass the
+// credentials filter are held until their balance is decoded.
+func (c *command) handler() (*util.StateStreamHandler, error) {
+	if !c.json {
+		c.csvWriter = csv.NewWriter(c.writer)
+		if err := c.csvWriter.Write(csvHeader); err != nil {
+			return nil, errors.Wrap(err, "failed to write header")
+		}
+	}
+
+	var epoch phase0.Epoch
+	pending := make([]*exportValidator, 0)
+	next := 0
+
+	return &util.StateStreamHandler{
+		Header: func(header *util.StateStreamHeader) error {
+			epoch = c.chainTime.SlotToEpoch(header.Slot)
+			return nil
+		},
+		Validator: func(index phase0.ValidatorIndex, validator *phase0.Validator) error {
+			if !bytes.HasPrefix(validator.WithdrawalCredentials, c.credentialsPrefix) {
+				return nil
+			}
+			pending = append(pending, &exportValidator{
+				index:     index,
+				validator: validator,
+			})
+			return nil
+		},
+		Balance: func(index phase0.ValidatorIndex, balance phase0.Gwei) error {
+			if next >= len(pending) || pending[next].index != index {
+				return nil
+			}
+			validator := pending[next].validator
+			// Release the validator once written.
+			pending[next] = nil
+			next++
+
+			state := apiv1.ValidatorToState(validator, &balance, epoch, util.FarFutureEpoch)
+			if !c.matchesStatus(state) {
+				return nil
+			}
+
+			return c.write(index, validator, balance, state)
+		},
+	}, nil
+}
+
+// matchesStatus returns true if the state matches the status filter.
+func (c *command) matchesStatus(state apiv1.ValidatorState) bool {
+	if len(c.statuses) == 0 {
+		return true
+	}
+	for _, status := range c.statuses {
+		// Statuses such as "active" match all states that start with them.
+		if strings.HasPrefix(state.String(), status) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *command) write(index phase0.ValidatorIndex,
+	validator *phase0.Validator,
+	balance phase0.Gwei,
+	state apiv1.ValidatorState,
+) error {
+	c.exported++
+
+	if c.json {
+		data, err := json.Marshal(&validatorJSON{
+			Index:                      uint64(index),
+			PubKey:                     fmt.Sprintf("%#x", validator.PublicKey),
+			WithdrawalCredentials:      fmt.Sprintf("%#x", validator.WithdrawalCredentials),
+			Balance:                    uint64(balance),
+			EffectiveBalance:           uint64(validator.EffectiveBalance),
+			Status:                     state.String(),
+			Slashed:                    validator.Slashed,
+			ActivationEligibilityEpoch: uint64(validator.ActivationEligibilityEpoch),
+			ActivationEpoch:            uint64(validator.ActivationEpoch),
+			ExitEpoch:                  uint64(validator.ExitEpoch),
+			WithdrawableEpoch:          uint64(validator.WithdrawableEpoch),
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal validator")
+		}
+		_, err = fmt.Fprintf(c.writer, "%s\n", data)
+
+		return err
+	}
+
+	if err := c.csvWriter.Write([]string{
+		fmt.Sprintf("%d", index),
+		fmt.Sprintf("%#x", validator.PublicKey),
+		fmt.Sprintf("%#x", validator.WithdrawalCredentials),
+		fmt.Sprintf("%d", balance),
+		fmt.Sprintf("%d", validator.EffectiveBalance),
+		state.String(),
+		fmt.Sprintf("%t", validator.Slashed),
+		fmt.Sprintf("%d", validator.ActivationEligibilityEpoch),
+		fmt.Sprintf("%d", validator.ActivationEpoch),
+		fmt.Sprintf("%d", validator.ExitEpoch),
+		fmt.Sprintf("%d", validator.WithdrawableEpoch),
+	}); err != nil {
+		return errors.Wrap(err, "failed to write validator")
+	}
+
+	return nil
+}
+
+// flush flushes any buffered CSV output.
+func (c *command) flush() error {
+	if c.csvWriter == nil {
+		return nil
+	}
+	c.csvWriter.Flush()
+
+	return c.csvWriter.Error()
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainvalidatorsexport
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/testing/mock"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func testValidators() []*phase0.Validator {
+	return []*phase0.Validator{
+		{
+			// Active.
+			PublicKey:                  phase0.BLSPubKey{0x01},
+			WithdrawalCredentials:      append([]byte{0x01}, make([]byte, 31)...),
+			EffectiveBalance:           32000000000,
+			ActivationEligibilityEpoch: 0,
+			ActivationEpoch:            0,
+			ExitEpoch:                  util.FarFutureEpoch,
+			WithdrawableEpoch:          util.FarFutureEpoch,
+		},
+		{
+			// Pending.
+			PublicKey:                  phase0.BLSPubKey{0x02},
+			WithdrawalCredentials:      append([]byte{0x00}, make([]byte, 31)...),
+			EffectiveBalance:           32000000000,
+			ActivationEligibilityEpoch: 5,
+			ActivationEpoch:            util.FarFutureEpoch,
+			ExitEpoch:                  util.FarFutureEpoch,
+			WithdrawableEpoch:          util.FarFutureEpoch,
+		},
+		{
+			// Withdrawn.
+			PublicKey:                  phase0.BLSPubKey{0x03},
+			WithdrawalCredentials:      append([]byte{0x01}, make([]byte, 31)...),
+			EffectiveBalance:           0,
+			ActivationEligibilityEpoch: 0,
+			ActivationEpoch:            0,
+			ExitEpoch:                  2,
+			WithdrawableEpoch:          4,
+			Slashed:                    true,
+		},
+	}
+}
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now())),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider(12*time.Second, 32, 256)),
+	)
+	require.NoError(t, err)
+	balances := []phase0.Gwei{32001000000, 32000000000, 0}
+
+	tests := []struct {
+		name              string
+		json              bool
+		statuses          []string
+		credentialsPrefix []byte
+		exported          uint64
+		res               string
+	}{
+		{
+			name:     "CSV",
+			exported: 3,
+			res: `index,pubkey,withdrawal_credentials,balance,effective_balance,status,slashed,activation_eligibility_epoch,activation_epoch,exit_epoch,withdrawable_epoch
+0,0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000,0x0100000000000000000000000000000000000000000000000000000000000000,32001000000,32000000000,active_ongoing,false,0,0,18446744073709551615,18446744073709551615
+1,0x020000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000,0x0000000000000000000000000000000000000000000000000000000000000000,32000000000,32000000000,pending_queued,false,5,18446744073709551615,18446744073709551615,18446744073709551615
+2,0x030000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000,0x0100000000000000000000000000000000000000000000000000000000000000,0,0,withdrawal_done,true,0,0,2,4
+`,
+		},
+		{
+			name:     "Status",
+			json:     true,
+			statuses: []string{"pending", "withdrawal_done"},
+			exported: 2,
+			res: `{"index":1,"pubkey":"0x020000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","withdrawal_credentials":"0x0000000000000000000000000000000000000000000000000000000000000000","balance":32000000000,"effective_balance":32000000000,"status":"pending_queued","slashed":false,"activation_eligibility_epoch":5,"activation_epoch":18446744073709551615,"exit_epoch":18446744073709551615,"withdrawable_epoch":18446744073709551615}
+{"index":2,"pubkey":"0x030000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","withdrawal_credentials":"0x0100000000000000000000000000000000000000000000000000000000000000","balance":0,"effective_balance":0,"status":"withdrawal_done","slashed":true,"activation_eligibility_epoch":0,"activation_epoch":0,"exit_epoch":2,"withdrawable_epoch":4}
+`,
+		},
+		{
+			name:              "CredentialsPrefix",
+			json:              true,
+			credentialsPrefix: []byte{0x01},
+			statuses:          []string{"active"},
+			exported:          1,
+			res: `{"index":0,"pubkey":"0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","withdrawal_credentials":"0x0100000000000000000000000000000000000000000000000000000000000000","balance":32001000000,"effective_balance":32000000000,"status":"active_ongoing","slashed":false,"activation_eligibility_epoch":0,"activation_epoch":0,"exit_epoch":18446744073709551615,"withdrawable_epoch":18446744073709551615}
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			c := &command{
+				json:              test.json,
+				statuses:          test.statuses,
+				credentialsPrefix: test.credentialsPrefix,
+				chainTime:         chainTime,
+				writer:            buf,
+			}
+			handler, err := c.handler()
+			require.NoError(t, err)
+
+			require.NoError(t, handler.Header(&util.StateStreamHeader{Slot: 320}))
+			for i, validator := range testValidators() {
+				require.NoError(t, handler.Validator(phase0.ValidatorIndex(i), validator))
+			}
+			for i, balance := range balances {
+				require.NoError(t, handler.Balance(phase0.ValidatorIndex(i), balance))
+			}
+			require.NoError(t, c.flush())
+
+			require.Equal(t, test.exported, c.exported)
+			require.Equal(t, test.res, buf.String())
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainverifycheckpoint
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connections.
+	timeout                  time.Duration
+	providerURL              string
+	connections              []string
+	allowInsecureConnections bool
+
+	// Output.
+	checkpoint    *checkpoint
+	nodes         []*nodeState
+	disagreements []string
+}
+
+// checkpoint is the finalized checkpoint as supplied by the provider.
+type checkpoint struct {
+	Epoch     phase0.Epoch
+	Slot      phase0.Slot
+	BlockRoot phase0.Root
+	StateRoot phase0.Root
+}
+
+// nodeState is the view of the checkpoint from a single node.
+type nodeState struct {
+	Address        string
+	Error          string
+	BlockRoot      *phase0.Root
+	FinalizedEpoch phase0.Epoch
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	if viper.GetString("provider-url") == "" {
+		return nil, errors.New("provider-url is required")
+	}
+	c.providerURL = viper.GetString("provider-url")
+
+	// Nodes are queried individually, so they can be supplied either with
+	// --connections or as a comma-separated --connection.
+	connections := viper.GetString("connections")
+	if connections == "" {
+		connections = viper.GetString("connection")
+	}
+	for _, connection := range strings.Split(connections, ",") {
+		connection = strings.TrimSpace(connection)
+		if connection != "" {
+			c.connections = append(c.connections, connection)
+		}
+	}
+	if len(c.connections) == 0 {
+		return nil, errors.New("at least one beacon node connection is required")
+	}
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	return c, nil
+}
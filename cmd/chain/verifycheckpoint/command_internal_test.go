@@ -0,0 +1,92 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainverifycheckpoint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name        string
+		vars        map[string]interface{}
+		connections []string
+		err         string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"provider-url": "https://checkpoint.example.com",
+				"connections":  "http://node1:5052",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "ProviderURLMissing",
+			vars: map[string]interface{}{
+				"timeout":     "5s",
+				"connections": "http://node1:5052",
+			},
+			err: "provider-url is required",
+		},
+		{
+			name: "ConnectionsMissing",
+			vars: map[string]interface{}{
+				"timeout":      "5s",
+				"provider-url": "https://checkpoint.example.com",
+			},
+			err: "at least one beacon node connection is required",
+		},
+		{
+			name: "Connections",
+			vars: map[string]interface{}{
+				"timeout":      "5s",
+				"provider-url": "https://checkpoint.example.com",
+				"connections":  "http://node1:5052, http://node2:5052",
+				"connection":   "http://node3:5052",
+			},
+			connections: []string{"http://node1:5052", "http://node2:5052"},
+		},
+		{
+			name: "Connection",
+			vars: map[string]interface{}{
+				"timeout":      "5s",
+				"provider-url": "https://checkpoint.example.com",
+				"connection":   "http://node1:5052,http://node2:5052",
+			},
+			connections: []string{"http://node1:5052", "http://node2:5052"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			c, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.connections, c.connections)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainverifycheckpoint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type checkpointJSON struct {
+	Epoch     string `json:"epoch"`
+	Slot      string `json:"slot"`
+	BlockRoot string `json:"block_root"`
+	StateRoot string `json:"state_root"`
+}
+
+type nodeJSON struct {
+	Address        string `json:"address"`
+	Error          string `json:"error,omitempty"`
+	BlockRoot      string `json:"block_root,omitempty"`
+	FinalizedEpoch string `json:"finalized_epoch,omitempty"`
+}
+
+type jsonOutput struct {
+	Checkpoint    *checkpointJSON `json:"checkpoint"`
+	Nodes         []*nodeJSON     `json:"nodes"`
+	Disagreements []string        `json:"disagreements"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := &jsonOutput{
+		Checkpoint: &checkpointJSON{
+			Epoch:     fmt.Sprintf("%d", c.checkpoint.Epoch),
+			Slot:      fmt.Sprintf("%d", c.checkpoint.Slot),
+			BlockRoot: fmt.Sprintf("%#x", c.checkpoint.BlockRoot),
+			StateRoot: fmt.Sprintf("%#x", c.checkpoint.StateRoot),
+		},
+		Nodes:         make([]*nodeJSON, 0, len(c.nodes)),
+		Disagreements: c.disagreements,
+	}
+	for _, node := range c.nodes {
+		if node.Error != "" {
+			output.Nodes = append(output.Nodes, &nodeJSON{
+				Address: node.Address,
+				Error:   node.Error,
+			})
+			continue
+		}
+		nodeOutput := &nodeJSON{
+			Address:        node.Address,
+			FinalizedEpoch: fmt.Sprintf("%d", node.FinalizedEpoch),
+		}
+		if node.BlockRoot != nil {
+			nodeOutput.BlockRoot = fmt.Sprintf("%#x", *node.BlockRoot)
+		}
+		output.Nodes = append(output.Nodes, nodeOutput)
+	}
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Checkpoint epoch: %d\n", c.checkpoint.Epoch))
+	builder.WriteString(fmt.Sprintf("Block root: %#x (slot %d)\n", c.checkpoint.BlockRoot, c.checkpoint.Slot))
+	builder.WriteString(fmt.Sprintf("State root: %#x\n", c.checkpoint.StateRoot))
+
+	if c.verbose {
+		for _, node := range c.nodes {
+			builder.WriteString(fmt.Sprintf("%s:\n", node.Address))
+			if node.Error != "" {
+				builder.WriteString(fmt.Sprintf("  Error: %s\n", node.Error))
+				continue
+			}
+			if node.BlockRoot == nil {
+				builder.WriteString(fmt.Sprintf("  Block: none at slot %d\n", c.checkpoint.Slot))
+			} else {
+				builder.WriteString(fmt.Sprintf("  Block: %#x\n", *node.BlockRoot))
+			}
+			builder.WriteString(fmt.Sprintf("  Finalized epoch: %d\n", node.FinalizedEpoch))
+		}
+	}
+
+	if len(c.disagreements) == 0 {
+		builder.WriteString(fmt.Sprintf("All %d nodes agree with checkpoint\n", len(c.nodes)))
+	} else {
+		builder.WriteString("Disagreements:\n")
+		for _, disagreement := range c.disagreements {
+			builder.WriteString(fmt.Sprintf("  %s\n", disagreement))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
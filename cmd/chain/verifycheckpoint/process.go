@@ -0,0 +1,155 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainverifycheckpoint
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.obtainCheckpoint(ctx); err != nil {
+		return err
+	}
+
+	c.nodes = make([]*nodeState, 0, len(c.connections))
+	for _, connection := range c.connections {
+		state := &nodeState{
+			Address: connection,
+		}
+		if err := c.obtainNodeState(ctx, state); err != nil {
+			if c.debug {
+				fmt.Printf("Failed to obtain state from %s: %v\n", connection, err)
+			}
+			state.Error = err.Error()
+		}
+		c.nodes = append(c.nodes, state)
+	}
+
+	c.disagreements = disagreements(c.checkpoint, c.nodes)
+
+	return nil
+}
+
+// obtainCheckpoint obtains the finalized state from the provider, and
+// calculates the checkpoint from the state itself rather than trusting any
+// roots that the provider advertises.
+func (c *command) obtainCheckpoint(ctx context.Context) error {
+	provider, err := util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.providerURL,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   false,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to checkpoint sync provider")
+	}
+
+	specResponse, err := provider.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	slotsPerEpoch, isSlotsPerEpoch := specResponse.Data["SLOTS_PER_EPOCH"].(uint64)
+	if !isSlotsPerEpoch {
+		return errors.New("spec missing SLOTS_PER_EPOCH")
+	}
+
+	tree, err := util.ObtainBeaconStateTree(ctx, provider, "finalized")
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain finalized state from checkpoint sync provider")
+	}
+	stateRoot, err := tree.Root()
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate state root")
+	}
+	header, err := tree.LatestBlockHeader()
+	if err != nil {
+		return err
+	}
+	blockRoot, err := header.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate block root")
+	}
+
+	c.checkpoint = &checkpoint{
+		Epoch:     phase0.Epoch(uint64(tree.Slot()) / slotsPerEpoch),
+		Slot:      header.Slot,
+		BlockRoot: blockRoot,
+		StateRoot: stateRoot,
+	}
+
+	return nil
+}
+
+func (c *command) obtainNodeState(ctx context.Context, state *nodeState) error {
+	client, err := util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       state.Address,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   false,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	headerResponse, err := client.(eth2client.BeaconBlockHeadersProvider).BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{
+		Block: fmt.Sprintf("%d", c.checkpoint.Slot),
+	})
+	if err != nil {
+		var apiErr *api.Error
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+			return errors.Wrap(err, "failed to obtain block header")
+		}
+	} else {
+		state.BlockRoot = &headerResponse.Data.Root
+	}
+
+	finalityResponse, err := client.(eth2client.FinalityProvider).Finality(ctx, &api.FinalityOpts{
+		State: "head",
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain finality")
+	}
+	state.FinalizedEpoch = finalityResponse.Data.Finalized.Epoch
+
+	return nil
+}
+
+// disagreements returns a list of human-readable disagreements between the
+// nodes and the checkpoint.
+func disagreements(checkpoint *checkpoint, nodes []*nodeState) []string {
+	res := make([]string, 0)
+
+	for _, node := range nodes {
+		switch {
+		case node.Error != "":
+			res = append(res, fmt.Sprintf("%s could not be queried: %s", node.Address, node.Error))
+		case node.BlockRoot == nil:
+			res = append(res, fmt.Sprintf("%s has no block at slot %d", node.Address, checkpoint.Slot))
+		case *node.BlockRoot != checkpoint.BlockRoot:
+			res = append(res, fmt.Sprintf("%s has block %#x at slot %d", node.Address, *node.BlockRoot, checkpoint.Slot))
+		case node.FinalizedEpoch < checkpoint.Epoch:
+			res = append(res, fmt.Sprintf("%s has not finalized epoch %d (finalized epoch %d)", node.Address, checkpoint.Epoch, node.FinalizedEpoch))
+		}
+	}
+
+	return res
+}
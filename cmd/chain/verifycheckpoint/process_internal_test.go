@@ -0,0 +1,68 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainverifycheckpoint
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisagreements(t *testing.T) {
+	cp := &checkpoint{
+		Epoch:     10,
+		Slot:      319,
+		BlockRoot: phase0.Root{0x01},
+	}
+	root := phase0.Root{0x01}
+	otherRoot := phase0.Root{0x02}
+
+	tests := []struct {
+		name          string
+		nodes         []*nodeState
+		disagreements []string
+	}{
+		{
+			name: "Agree",
+			nodes: []*nodeState{
+				{Address: "a", BlockRoot: &root, FinalizedEpoch: 10},
+				{Address: "b", BlockRoot: &root, FinalizedEpoch: 11},
+			},
+			disagreements: []string{},
+		},
+		{
+			name: "Disagree",
+			nodes: []*nodeState{
+				{Address: "a", BlockRoot: &root, FinalizedEpoch: 10},
+				{Address: "b", Error: "unavailable"},
+				{Address: "c", FinalizedEpoch: 10},
+				{Address: "d", BlockRoot: &otherRoot, FinalizedEpoch: 10},
+				{Address: "e", BlockRoot: &root, FinalizedEpoch: 9},
+			},
+			disagreements: []string{
+				"b could not be queried: unavailable",
+				"c has no block at slot 319",
+				"d has block 0x0200000000000000000000000000000000000000000000000000000000000000 at slot 319",
+				"e has not finalized epoch 10 (finalized epoch 9)",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.disagreements, disagreements(cp, test.nodes))
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainverifystate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		command *command
+		res     string
+	}{
+		{
+			name: "Quiet",
+			command: &command{
+				quiet: true,
+				summary: &summary{
+					Slot:         100,
+					NodeRoot:     phase0.Root{0x01},
+					ComputedRoot: phase0.Root{0x01},
+					Verified:     true,
+				},
+			},
+		},
+		{
+			name: "Verified",
+			command: &command{
+				verbose: true,
+				summary: &summary{
+					Slot:         100,
+					NodeRoot:     phase0.Root{0x01},
+					ComputedRoot: phase0.Root{0x01},
+					Verified:     true,
+				},
+			},
+			res: `Slot: 100
+State root 0x0100000000000000000000000000000000000000000000000000000000000000 verified`,
+		},
+		{
+			name: "NotVerified",
+			command: &command{
+				summary: &summary{
+					Slot:         100,
+					NodeRoot:     phase0.Root{0x01},
+					ComputedRoot: phase0.Root{0x02},
+				},
+			},
+			res: "State root not verified: node reports 0x0100000000000000000000000000000000000000000000000000000000000000, calculated 0x0200000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name: "JSON",
+			command: &command{
+				json: true,
+				summary: &summary{
+					Slot:         100,
+					NodeRoot:     phase0.Root{0x01},
+					ComputedRoot: phase0.Root{0x01},
+					Verified:     true,
+				},
+			},
+			res: `{"slot":"100","node_root":"0x0100000000000000000000000000000000000000000000000000000000000000","computed_root":"0x0100000000000000000000000000000000000000000000000000000000000000","verified":true}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.command.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
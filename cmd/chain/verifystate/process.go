@@ -0,0 +1,77 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainverifystate
+
+import (
+	"context"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	tree, err := util.ObtainBeaconStateTree(ctx, c.eth2Client, c.stateID)
+	if err != nil {
+		return util.ExplainNodeCapabilityFailure(ctx, c.eth2Client, util.NodeCapabilityDebug, err)
+	}
+	c.summary = &summary{
+		Slot: tree.Slot(),
+	}
+	c.summary.ComputedRoot, err = tree.Root()
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate state root")
+	}
+
+	// Obtain the root by slot rather than the supplied ID, as named states
+	// such as "head" may have moved on since the state was obtained.
+	rootResponse, err := c.eth2Client.(eth2client.BeaconStateRootProvider).BeaconStateRoot(ctx, &api.BeaconStateRootOpts{
+		State: fmt.Sprintf("%d", c.summary.Slot),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain state root")
+	}
+	c.summary.NodeRoot = *rootResponse.Data
+	c.summary.Verified = c.summary.NodeRoot == c.summary.ComputedRoot
+
+	if !c.summary.Verified && c.quiet {
+		return errors.New("state root not verified")
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chaincountdown "github.com/wealdtech/ethdo/cmd/chain/countdown"
+)
+
+var chainCountdownCmd = &cobra.Command{
+	Use:   "countdown",
+	Short: "Count down to a slot, epoch, fork or time",
+	Long: `Count down to a slot, epoch, fork or time.  For example:
+
+    ethdo chain countdown --to=electra
+
+    ethdo chain countdown --to=epoch:300000 --live
+
+The target can be a slot number, "slot:<slot>", "epoch:<epoch>", the name of a fork such as "deneb", or a timestamp such as "2025-01-01T00:00:00Z" or "timestamp:<Unix timestamp>".  With --live the countdown is updated each second until the target is reached; otherwise the time remaining is output once, and with --json the number of seconds remaining is available for scripts.
+
+In quiet mode this will return 0 if the target has been reached, otherwise 1.  With --live this will wait until the target is reached.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := chaincountdown.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainCountdownCmd)
+	chainFlags(chainCountdownCmd)
+	chainCountdownCmd.Flags().String("to", "", "the target of the countdown: a slot, epoch, fork name or timestamp")
+	chainCountdownCmd.Flags().Bool("live", false, "update the countdown each second until the target is reached")
+}
+
+func chainCountdownBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("to", cmd.Flags().Lookup("to")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("live", cmd.Flags().Lookup("live")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chaincreategenesis "github.com/wealdtech/ethdo/cmd/chain/creategenesis"
+)
+
+var chainCreateGenesisCmd = &cobra.Command{
+	Use:   "creategenesis",
+	Short: "Create a genesis state for a new chain",
+	Long: `Create an SSZ-encoded genesis state for a new chain from a network configuration file and a set of deposits, for bootstrapping devnets.  For example:
+
+    ethdo chain creategenesis --network-config=config.yaml --interop-validators=64 --genesis-time=1700000000 --file=genesis.ssz
+
+    ethdo chain creategenesis --network-config=config.yaml --deposit-data=deposit_data.json --genesis-time=1700000000 --file=genesis.ssz
+
+The genesis state is for the latest fork that the network configuration schedules at epoch 0.  Only the mainnet preset is supported.
+
+In quiet mode this will return 0 if the genesis state is created, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := chaincreategenesis.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainCreateGenesisCmd)
+	chainFlags(chainCreateGenesisCmd)
+	chainCreateGenesisCmd.Flags().String("deposit-data", "", "path to a file containing deposit data for the genesis validators")
+	chainCreateGenesisCmd.Flags().Uint64("interop-validators", 0, "number of genesis validators to create with well-known interop keys")
+	chainCreateGenesisCmd.Flags().Uint64("genesis-time", 0, "genesis time of the chain, as a Unix timestamp")
+	chainCreateGenesisCmd.Flags().String("eth1-block-hash", "", "hash of the execution block on which the chain is based (defaults to 0x4242…42)")
+	chainCreateGenesisCmd.Flags().String("execution-payload-header", "", "path to a file containing the JSON execution payload header for the genesis state, for chains that start at or after bellatrix")
+	chainCreateGenesisCmd.Flags().String("file", "", "path to the file to which to write the genesis state")
+}
+
+func chainCreateGenesisBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("deposit-data", cmd.Flags().Lookup("deposit-data")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("interop-validators", cmd.Flags().Lookup("interop-validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("genesis-time", cmd.Flags().Lookup("genesis-time")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("eth1-block-hash", cmd.Flags().Lookup("eth1-block-hash")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("execution-payload-header", cmd.Flags().Lookup("execution-payload-header")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("file", cmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+}
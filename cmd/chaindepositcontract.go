@@ -0,0 +1,57 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chaindepositcontract "github.com/wealdtech/ethdo/cmd/chain/depositcontract"
+)
+
+var chainDepositContractCmd = &cobra.Command{
+	Use:   "depositcontract",
+	Short: "Obtain information about the deposit contract",
+	Long: `Obtain information about the deposit contract, comparing its state on the execution chain with that known to and processed by the beacon chain.  For example:
+
+    ethdo chain depositcontract --connection-execution=http://localhost:8545
+
+In quiet mode this will return 0 if the deposit contract information is obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := chaindepositcontract.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainDepositContractCmd)
+	chainFlags(chainDepositContractCmd)
+	chainDepositContractCmd.Flags().String("connection-execution", "", "URL to an execution node, to obtain the state of the deposit contract on the execution chain")
+}
+
+func chainDepositContractBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("connection-execution", cmd.Flags().Lookup("connection-execution")); err != nil {
+		panic(err)
+	}
+}
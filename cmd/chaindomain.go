@@ -0,0 +1,64 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chaindomain "github.com/wealdtech/ethdo/cmd/chain/domain"
+)
+
+var chainDomainCmd = &cobra.Command{
+	Use:   "domain",
+	Short: "Calculate a signing domain",
+	Long: `Calculate the signing domain for a given domain type.  For example:
+
+    ethdo chain domain --type=DOMAIN_VOLUNTARY_EXIT
+
+The domain type can be supplied as its name in the specification or as a 4-byte hex string.  If the fork version or genesis validators root are not supplied they are obtained from the beacon node.
+
+In quiet mode this will return 0 if the domain can be calculated, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := chaindomain.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainDomainCmd)
+	chainFlags(chainDomainCmd)
+	chainDomainCmd.Flags().String("type", "", "Domain type, either as a name (e.g. DOMAIN_VOLUNTARY_EXIT) or a 4-byte hex string")
+	chainDomainCmd.Flags().String("fork-version", "", "Fork version to use for the domain (default obtained from the beacon node)")
+	chainDomainCmd.Flags().String("genesis-validators-root", "", "Genesis validators root to use for the domain (default obtained from the beacon node)")
+}
+
+func chainDomainBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("type", cmd.Flags().Lookup("type")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("fork-version", cmd.Flags().Lookup("fork-version")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("genesis-validators-root", cmd.Flags().Lookup("genesis-validators-root")); err != nil {
+		panic(err)
+	}
+}
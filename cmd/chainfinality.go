@@ -0,0 +1,69 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainfinality "github.com/wealdtech/ethdo/cmd/chain/finality"
+)
+
+var chainFinalityCmd = &cobra.Command{
+	Use:   "finality",
+	Short: "Report on the chain's finality",
+	Long: `Report on the chain's finality, including the number of epochs since finality, the onset of the inactivity leak and the projected loss of an offline validator if the chain does not finalize.  For example:
+
+    ethdo chain finality --thresholds=3,10
+
+    ethdo chain finality --follow
+
+A warning is given if the number of epochs since finality reaches any of the supplied thresholds.  When following, finality is reported each epoch.
+
+In quiet mode this will return 0 if the number of epochs since finality is below all thresholds, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := chainfinality.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainFinalityCmd)
+	chainFlags(chainFinalityCmd)
+	chainFinalityCmd.Flags().Bool("follow", false, "report finality each epoch")
+	chainFinalityCmd.Flags().IntSlice("thresholds", []int{3, 10}, "numbers of epochs since finality at which to warn")
+	chainFinalityCmd.Flags().Uint64("projection-epochs", 225, "number of epochs without finality over which to project validator balance loss")
+}
+
+func chainFinalityBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("follow", cmd.Flags().Lookup("follow")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("thresholds", cmd.Flags().Lookup("thresholds")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("projection-epochs", cmd.Flags().Lookup("projection-epochs")); err != nil {
+		panic(err)
+	}
+}
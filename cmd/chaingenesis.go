@@ -0,0 +1,52 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chaingenesis "github.com/wealdtech/ethdo/cmd/chain/genesis"
+)
+
+var chainGenesisCmd = &cobra.Command{
+	Use:   "genesis",
+	Short: "Obtain genesis information for the chain",
+	Long: `Obtain genesis information for the chain, including the genesis time, genesis validators root, genesis fork version and the fork digest that identifies the chain.  For example:
+
+    ethdo chain genesis
+
+If multiple connections are supplied each is queried, and the command fails if they do not agree on the chain's genesis.
+
+In quiet mode this will return 0 if the genesis information can be obtained and all connections agree, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := chaingenesis.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainGenesisCmd)
+	chainFlags(chainGenesisCmd)
+}
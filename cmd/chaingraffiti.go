@@ -0,0 +1,57 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chaingraffiti "github.com/wealdtech/ethdo/cmd/chain/graffiti"
+)
+
+var chainGraffitiCmd = &cobra.Command{
+	Use:   "graffiti",
+	Short: "Summarise graffiti of recent blocks",
+	Long: `Summarise the graffiti of recent blocks, including the consensus and execution clients identified in the graffiti.  For example:
+
+    ethdo chain graffiti --blocks=1000
+
+In quiet mode this will return 0 if the graffiti is obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := chaingraffiti.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainGraffitiCmd)
+	chainFlags(chainGraffitiCmd)
+	chainGraffitiCmd.Flags().Int("blocks", 100, "the number of recent blocks for which to summarise graffiti")
+}
+
+func chainGraffitiBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("blocks", cmd.Flags().Lookup("blocks")); err != nil {
+		panic(err)
+	}
+}
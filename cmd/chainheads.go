@@ -0,0 +1,52 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainheads "github.com/wealdtech/ethdo/cmd/chain/heads"
+)
+
+var chainHeadsCmd = &cobra.Command{
+	Use:   "heads",
+	Short: "List the heads in the beacon node's fork choice",
+	Long: `List the candidate heads in the beacon node's fork choice, with their weights and the blocks back to where they fork from other heads.  For example:
+
+    ethdo chain heads
+
+This requires the beacon node to provide its debug API.
+
+In quiet mode this will return 0 if the fork choice can be obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := chainheads.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainHeadsCmd)
+	chainFlags(chainHeadsCmd)
+}
@@ -0,0 +1,59 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainpendingdeposits "github.com/wealdtech/ethdo/cmd/chain/pendingdeposits"
+)
+
+var chainPendingDepositsCmd = &cobra.Command{
+	Use:   "pendingdeposits",
+	Short: "List deposits awaiting processing by the chain",
+	Long: `List deposits awaiting processing by the chain, along with the epoch at which each is expected to be processed.  For example:
+
+    ethdo chain pendingdeposits --validators=Validators/1
+
+This command requires a chain that has reached Electra.
+
+In quiet mode this will return 0 if the pending deposits are obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := chainpendingdeposits.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainPendingDepositsCmd)
+	chainFlags(chainPendingDepositsCmd)
+	chainPendingDepositsCmd.Flags().StringSlice("validators", nil, "the list of validators for which to show pending deposits")
+}
+
+func chainPendingDepositsBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainreorgs "github.com/wealdtech/ethdo/cmd/chain/reorgs"
+)
+
+var chainReorgsCmd = &cobra.Command{
+	Use:   "reorgs",
+	Short: "Report chain reorganisations",
+	Long: `Report chain reorganisations, either over a range of epochs or as they occur.  For example:
+
+    ethdo chain reorgs --from-epoch=-10
+
+    ethdo chain reorgs --follow --webhook=http://localhost:8080/reorgs
+
+Historical reorgs are found from the non-canonical blocks known to the beacon node.  Beacon nodes can prune non-canonical blocks, so historical reports are only reliable for recent epochs.
+
+When following, each reorg is reported as it occurs and, if a webhook is supplied, its details are sent to the webhook as a JSON POST request.
+
+In quiet mode this will return 0 if reorgs can be obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := chainreorgs.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainReorgsCmd)
+	chainFlags(chainReorgsCmd)
+	chainReorgsCmd.Flags().String("from-epoch", "", "the first epoch for which to report reorgs")
+	chainReorgsCmd.Flags().String("to-epoch", "", "the last epoch for which to report reorgs (defaults to current epoch)")
+	chainReorgsCmd.Flags().Bool("follow", false, "report reorgs as they occur")
+	chainReorgsCmd.Flags().String("webhook", "", "URL to which to send reorgs when following")
+}
+
+func chainReorgsBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("from-epoch", cmd.Flags().Lookup("from-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-epoch", cmd.Flags().Lookup("to-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("follow", cmd.Flags().Lookup("follow")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("webhook", cmd.Flags().Lookup("webhook")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainslashings "github.com/wealdtech/ethdo/cmd/chain/slashings"
+)
+
+var chainSlashingsCmd = &cobra.Command{
+	Use:   "slashings",
+	Short: "List slashings included on the chain",
+	Long: `List the proposer and attester slashings included on the chain over a range of epochs.  For example:
+
+    ethdo chain slashings --from-epoch=1000 --to-epoch=2000
+
+In quiet mode this will return 0 if the slashings are obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := chainslashings.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainSlashingsCmd)
+	chainFlags(chainSlashingsCmd)
+	chainSlashingsCmd.Flags().String("from-epoch", "", "the first epoch for which to list slashings")
+	chainSlashingsCmd.Flags().String("to-epoch", "", "the last epoch for which to list slashings (defaults to current epoch)")
+}
+
+func chainSlashingsBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("from-epoch", cmd.Flags().Lookup("from-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-epoch", cmd.Flags().Lookup("to-epoch")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainvalidatorsexport "github.com/wealdtech/ethdo/cmd/chain/validators/export"
+)
+
+var chainValidatorsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the validator registry",
+	Long: `Export the validator registry at a given state, including each validator's index, public key, withdrawal credentials, balances and status.  For example:
+
+    ethdo chain validators export --state-id=finalized --file=validators.csv
+
+    ethdo chain validators export --status=active --credentials-prefix=0x01 --json
+
+Validators are output as CSV, or as one JSON object per line with --json.  The state is streamed, so memory use is bounded by the number of validators that pass the credentials prefix filter rather than the size of the state.
+
+In quiet mode this will return 0 if the validators are exported, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := chainvalidatorsexport.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainValidatorsCmd.AddCommand(chainValidatorsExportCmd)
+	chainFlags(chainValidatorsExportCmd)
+	chainValidatorsExportCmd.Flags().String("state-id", "head", "the ID of the state from which to export validators")
+	chainValidatorsExportCmd.Flags().StringSlice("status", nil, "only export validators with the given statuses, such as active or pending_queued")
+	chainValidatorsExportCmd.Flags().String("credentials-prefix", "", "only export validators whose withdrawal credentials start with the given hex prefix, such as 0x01")
+	chainValidatorsExportCmd.Flags().String("file", "", "path to the file to which to write the validators (defaults to the console)")
+}
+
+func chainValidatorsExportBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("state-id", cmd.Flags().Lookup("state-id")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("status", cmd.Flags().Lookup("status")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("credentials-prefix", cmd.Flags().Lookup("credentials-prefix")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("file", cmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainverifycheckpoint "github.com/wealdtech/ethdo/cmd/chain/verifycheckpoint"
+)
+
+var chainVerifyCheckpointCmd = &cobra.Command{
+	Use:   "verify-checkpoint",
+	Short: "Verify the finalized checkpoint of a checkpoint sync provider",
+	Long: `Verify the finalized checkpoint of a checkpoint sync provider against one or more independent beacon nodes.  For example:
+
+    ethdo chain verify-checkpoint --provider-url=https://checkpoint.example.com --connections=http://node1:5052,http://node2:5052
+
+The finalized state is downloaded from the provider and its state and block roots are calculated locally, so no roots advertised by the provider are trusted.  Each node must have the same block at the checkpoint slot, and must itself have finalized the checkpoint epoch.
+
+If --connections is not supplied then the nodes supplied with --connection are used.
+
+In quiet mode this will return 0 if all nodes agree with the checkpoint, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := chainverifycheckpoint.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainVerifyCheckpointCmd)
+	chainFlags(chainVerifyCheckpointCmd)
+	chainVerifyCheckpointCmd.Flags().String("provider-url", "", "URL of the checkpoint sync provider")
+	chainVerifyCheckpointCmd.Flags().String("connections", "", "comma-separated list of beacon node connections against which to verify the checkpoint")
+}
+
+func chainVerifyCheckpointBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("provider-url", cmd.Flags().Lookup("provider-url")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("connections", cmd.Flags().Lookup("connections")); err != nil {
+		panic(err)
+	}
+}
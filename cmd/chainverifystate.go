@@ -0,0 +1,59 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	chainverifystate "github.com/wealdtech/ethdo/cmd/chain/verifystate"
+)
+
+var chainVerifyStateCmd = &cobra.Command{
+	Use:   "verify-state",
+	Short: "Verify the root of a state supplied by a beacon node",
+	Long: `Verify the root of a state supplied by a beacon node, by downloading the state and calculating its root locally.  For example:
+
+    ethdo chain verify-state --state-id=finalized
+
+This command supports Deneb and Electra states.
+
+In quiet mode this will return 0 if the calculated root matches the root reported by the node, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := chainverifystate.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainVerifyStateCmd)
+	chainFlags(chainVerifyStateCmd)
+	chainVerifyStateCmd.Flags().String("state-id", "head", "the ID of the state to verify")
+}
+
+func chainVerifyStateBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("state-id", cmd.Flags().Lookup("state-id")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositbatch
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Input.
+	data          string
+	batchContract bellatrix.ExecutionAddress
+	batchFormat   util.BatchDepositFormat
+	batchSize     int
+	force         bool
+	txOpts        *util.ExecutionTransactionOpts
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Execution node connection.
+	connectionExecution string
+
+	// Data access.
+	eth2Client      eth2client.Service
+	executionClient *util.ExecutionClient
+	signer          util.ExecutionSigner
+
+	// Processing.
+	deposits        []*util.DepositInfo
+	depositContract bellatrix.ExecutionAddress
+	depositChainID  uint64
+	forkVersion     phase0.Version
+
+	// Output.
+	results []*batchResult
+}
+
+type batchResult struct {
+	To       bellatrix.ExecutionAddress `json:"to"`
+	Value    string                     `json:"value"`
+	Deposits int                        `json:"deposits"`
+	Data     string                     `json:"data"`
+	// Signed transaction, if generated.
+	Nonce       *uint64        `json:"nonce,omitempty"`
+	Transaction *phase0.Hash32 `json:"transaction,omitempty"`
+	Raw         string         `json:"raw,omitempty"`
+
+	data  []byte
+	value *big.Int
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:     viper.GetBool("quiet"),
+		verbose:   viper.GetBool("verbose"),
+		debug:     viper.GetBool("debug"),
+		json:      viper.GetBool("json"),
+		data:      viper.GetString("data"),
+		batchSize: viper.GetInt("batch-size"),
+		force:     viper.GetBool("force"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	if c.data == "" {
+		return nil, errors.New("data is required")
+	}
+
+	c.connectionExecution = viper.GetString("connection-execution")
+	if c.connectionExecution == "" {
+		return nil, errors.New("connection-execution is required")
+	}
+
+	if viper.GetString("batch-contract") == "" {
+		return nil, errors.New("batch-contract is required")
+	}
+	var err error
+	c.batchContract, err = util.ParseExecutionAddress(viper.GetString("batch-contract"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid batch contract")
+	}
+
+	if viper.GetString("batch-format") == "" {
+		return nil, errors.New("batch-format is required")
+	}
+	c.batchFormat, err = util.ParseBatchDepositFormat(viper.GetString("batch-format"))
+	if err != nil {
+		return nil, err
+	}
+
+	if c.batchSize < 1 {
+		return nil, errors.New("batch-size must be at least 1")
+	}
+
+	c.signer, err = util.ObtainExecutionSigner()
+	if err != nil {
+		return nil, err
+	}
+
+	c.txOpts, err = util.ObtainExecutionTransactionOpts()
+	if err != nil {
+		return nil, err
+	}
+	if c.signer == nil && !c.txOpts.IsEmpty() {
+		return nil, errors.New("max-fee, priority-fee and nonce can only be supplied when signing transactions")
+	}
+
+	return c, nil
+}
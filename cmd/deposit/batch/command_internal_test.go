@@ -0,0 +1,173 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositbatch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"data":                 "deposit_data.json",
+				"connection-execution": "http://localhost:8545",
+				"batch-format":         "arrays",
+				"batch-size":           100,
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "DataMissing",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"connection-execution": "http://localhost:8545",
+				"batch-contract":       "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F",
+				"batch-format":         "arrays",
+				"batch-size":           100,
+			},
+			err: "data is required",
+		},
+		{
+			name: "ConnectionExecutionMissing",
+			vars: map[string]interface{}{
+				"timeout":        "5s",
+				"data":           "deposit_data.json",
+				"batch-contract": "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F",
+				"batch-format":   "arrays",
+				"batch-size":     100,
+			},
+			err: "connection-execution is required",
+		},
+		{
+			name: "BatchContractMissing",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"data":                 "deposit_data.json",
+				"connection-execution": "http://localhost:8545",
+				"batch-format":         "arrays",
+				"batch-size":           100,
+			},
+			err: "batch-contract is required",
+		},
+		{
+			name: "BatchContractInvalid",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"data":                 "deposit_data.json",
+				"connection-execution": "http://localhost:8545",
+				"batch-contract":       "0x9d8a",
+				"batch-format":         "arrays",
+				"batch-size":           100,
+			},
+			err: "invalid batch contract: address must be exactly 20 bytes in length",
+		},
+		{
+			name: "BatchFormatMissing",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"data":                 "deposit_data.json",
+				"connection-execution": "http://localhost:8545",
+				"batch-contract":       "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F",
+				"batch-size":           100,
+			},
+			err: "batch-format is required",
+		},
+		{
+			name: "BatchFormatInvalid",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"data":                 "deposit_data.json",
+				"connection-execution": "http://localhost:8545",
+				"batch-contract":       "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F",
+				"batch-format":         "bad",
+				"batch-size":           100,
+			},
+			err: `unknown batch deposit format "bad"`,
+		},
+		{
+			name: "BatchSizeZero",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"data":                 "deposit_data.json",
+				"connection-execution": "http://localhost:8545",
+				"batch-contract":       "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F",
+				"batch-format":         "arrays",
+				"batch-size":           0,
+			},
+			err: "batch-size must be at least 1",
+		},
+		{
+			name: "FeesWithoutSigner",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"data":                 "deposit_data.json",
+				"connection-execution": "http://localhost:8545",
+				"batch-contract":       "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F",
+				"batch-format":         "arrays",
+				"batch-size":           100,
+				"max-fee":              "20gwei",
+			},
+			err: "max-fee, priority-fee and nonce can only be supplied when signing transactions",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"data":                 "deposit_data.json",
+				"connection-execution": "http://localhost:8545",
+				"batch-contract":       "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F",
+				"batch-format":         "packed",
+				"batch-size":           100,
+			},
+		},
+		{
+			name: "GoodSigned",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"data":                 "deposit_data.json",
+				"batch-contract":       "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F",
+				"batch-format":         "arrays",
+				"batch-size":           100,
+				"connection-execution": "http://localhost:8545",
+				"execution-key":        "0x4646464646464646464646464646464646464646464646464646464646464646",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
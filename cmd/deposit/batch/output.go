@@ -0,0 +1,62 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositbatch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		data, err := json.Marshal(c.results)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal results")
+		}
+
+		return string(data), nil
+	}
+
+	builder := strings.Builder{}
+	for i, result := range c.results {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		if result.Raw != "" {
+			// Signed transactions are output one per line, ready to broadcast.
+			if c.verbose {
+				builder.WriteString(fmt.Sprintf("Transaction %s with nonce %d for %d deposits totalling %s:\n", result.Transaction.String(), *result.Nonce, result.Deposits, string2eth.WeiToString(result.value, true)))
+			}
+			builder.WriteString(result.Raw)
+			continue
+		}
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("Deposits: %d\n", result.Deposits))
+		}
+		builder.WriteString(fmt.Sprintf("To: %s\n", result.To.String()))
+		builder.WriteString(fmt.Sprintf("Value: %s\n", string2eth.WeiToString(result.value, true)))
+		builder.WriteString(fmt.Sprintf("Data: %s\n", result.Data))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
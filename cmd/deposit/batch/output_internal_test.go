@@ -0,0 +1,118 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositbatch
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	value, _ := new(big.Int).SetString("64000000000000000000", 10)
+	nonce := uint64(5)
+	hash := phase0.Hash32{0x02}
+	unsigned := []*batchResult{
+		{
+			To:       bellatrix.ExecutionAddress{0x01},
+			Value:    value.String(),
+			Deposits: 2,
+			Data:     "0x1234",
+			value:    value,
+		},
+		{
+			To:       bellatrix.ExecutionAddress{0x01},
+			Value:    value.String(),
+			Deposits: 2,
+			Data:     "0x5678",
+			value:    value,
+		},
+	}
+	signed := []*batchResult{
+		{
+			To:          bellatrix.ExecutionAddress{0x01},
+			Value:       value.String(),
+			Deposits:    2,
+			Data:        "0x1234",
+			Nonce:       &nonce,
+			Transaction: &hash,
+			Raw:         "0x02abcd",
+			value:       value,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		command *command
+		res     string
+	}{
+		{
+			name: "Quiet",
+			command: &command{
+				quiet:   true,
+				results: unsigned,
+			},
+		},
+		{
+			name: "Unsigned",
+			command: &command{
+				results: unsigned,
+			},
+			res: "To: 0x0100000000000000000000000000000000000000\nValue: 64 Ether\nData: 0x1234\n\nTo: 0x0100000000000000000000000000000000000000\nValue: 64 Ether\nData: 0x5678",
+		},
+		{
+			name: "UnsignedVerbose",
+			command: &command{
+				verbose: true,
+				results: unsigned[:1],
+			},
+			res: "Deposits: 2\nTo: 0x0100000000000000000000000000000000000000\nValue: 64 Ether\nData: 0x1234",
+		},
+		{
+			name: "Signed",
+			command: &command{
+				results: signed,
+			},
+			res: "0x02abcd",
+		},
+		{
+			name: "SignedVerbose",
+			command: &command{
+				verbose: true,
+				results: signed,
+			},
+			res: "Transaction 0x0200000000000000000000000000000000000000000000000000000000000000 with nonce 5 for 2 deposits totalling 64 Ether:\n0x02abcd",
+		},
+		{
+			name: "JSON",
+			command: &command{
+				json:    true,
+				results: signed,
+			},
+			res: `[{"to":"0x0100000000000000000000000000000000000000","value":"64000000000000000000","deposits":2,"data":"0x1234","nonce":5,"transaction":"0x0200000000000000000000000000000000000000000000000000000000000000","raw":"0x02abcd"}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.command.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositbatch
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	var err error
+	c.deposits, err = util.ObtainDeposits(c.data, util.BatchDepositAmount)
+	if err != nil {
+		return err
+	}
+
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if err := c.checkChain(ctx); err != nil {
+		return err
+	}
+
+	if err := c.checkBatchContract(ctx); err != nil {
+		return err
+	}
+
+	if err := c.verifyDeposits(); err != nil {
+		return err
+	}
+
+	// Refuse to deposit to a validator twice.
+	if err := util.CheckExistingDeposits(ctx, c.eth2Client, util.DepositPublicKeys(c.deposits), &util.ExistingDepositsOpts{
+		ExecutionClient: c.executionClient,
+		DepositContract: c.depositContract,
+	}, c.force, c.quiet); err != nil {
+		return err
+	}
+
+	if err := c.generateBatches(); err != nil {
+		return err
+	}
+
+	if c.signer == nil {
+		return nil
+	}
+
+	return c.signBatches(ctx)
+}
+
+// verifyDeposits ensures that all deposits are valid for the network, and
+// that no validator is deposited more than once.
+func (c *command) verifyDeposits() error {
+	for i, deposit := range c.deposits {
+		if err := util.VerifyDeposit(deposit, c.forkVersion); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("deposit %d for %#x failed verification", i, deposit.PublicKey))
+		}
+		for j := 0; j < i; j++ {
+			if bytes.Equal(deposit.PublicKey, c.deposits[j].PublicKey) {
+				return fmt.Errorf("deposits %d and %d are both for %#x", j, i, deposit.PublicKey)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkChain ensures that the execution node is on the chain of the beacon
+// node, so that the transactions are valid for the deposits.
+func (c *command) checkChain(ctx context.Context) error {
+	chainID, err := c.executionClient.ChainID(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain chain ID")
+	}
+	if chainID != c.depositChainID {
+		return fmt.Errorf("execution node is on chain %d but deposits are for chain %d", chainID, c.depositChainID)
+	}
+
+	return nil
+}
+
+// checkBatchContract ensures that the batch contract has code on the chain,
+// as a transaction to an address without code would lose its value.
+func (c *command) checkBatchContract(ctx context.Context) error {
+	code, err := c.executionClient.Code(ctx, c.batchContract)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain code for batch contract")
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("batch contract %s has no code on chain %d", c.batchContract.String(), c.depositChainID)
+	}
+
+	return nil
+}
+
+// generateBatches generates the batch deposit calls, each containing up to
+// the batch size of deposits.
+func (c *command) generateBatches() error {
+	c.results = make([]*batchResult, 0, (len(c.deposits)+c.batchSize-1)/c.batchSize)
+	for start := 0; start < len(c.deposits); start += c.batchSize {
+		end := start + c.batchSize
+		if end > len(c.deposits) {
+			end = len(c.deposits)
+		}
+		deposits := c.deposits[start:end]
+
+		data, value, err := util.BatchDepositTransactionData(c.batchFormat, deposits)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to generate batch of deposits %d to %d", start, end-1))
+		}
+
+		c.results = append(c.results, &batchResult{
+			To:       c.batchContract,
+			Value:    value.String(),
+			Deposits: len(deposits),
+			Data:     "0x" + hex.EncodeToString(data),
+			data:     data,
+			value:    value,
+		})
+	}
+
+	return nil
+}
+
+// signBatches signs the batch deposit transactions, checking that the sending
+// account can afford them.
+func (c *command) signBatches(ctx context.Context) error {
+	builder, err := util.NewExecutionTransactionBuilder(ctx, c.executionClient, c.signer, c.txOpts)
+	if err != nil {
+		return err
+	}
+
+	txs := make([]*util.SignedExecutionTransaction, 0, len(c.results))
+	for i, result := range c.results {
+		tx, err := builder.Build(ctx, result.To, result.value, result.data)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to generate transaction for batch %d", i))
+		}
+		txs = append(txs, tx)
+
+		nonce := tx.Nonce
+		result.Nonce = &nonce
+		hash := tx.Hash
+		result.Transaction = &hash
+		result.Raw = "0x" + hex.EncodeToString(tx.Data)
+	}
+
+	return builder.CheckBalance(ctx, txs)
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	specProvider, isProvider := c.eth2Client.(eth2client.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+	specResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	addressBytes, isBytes := specResponse.Data["DEPOSIT_CONTRACT_ADDRESS"].([]byte)
+	if !isBytes || len(addressBytes) != bellatrix.ExecutionAddressLength {
+		return errors.New("DEPOSIT_CONTRACT_ADDRESS missing or invalid")
+	}
+	copy(c.depositContract[:], addressBytes)
+	var isUint bool
+	c.depositChainID, isUint = specResponse.Data["DEPOSIT_CHAIN_ID"].(uint64)
+	if !isUint {
+		return errors.New("DEPOSIT_CHAIN_ID missing or invalid")
+	}
+	var isVersion bool
+	c.forkVersion, isVersion = specResponse.Data["GENESIS_FORK_VERSION"].(phase0.Version)
+	if !isVersion {
+		return errors.New("GENESIS_FORK_VERSION missing or invalid")
+	}
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Deposit contract is %s on chain %d\n", c.depositContract.String(), c.depositChainID)
+	}
+
+	c.executionClient, err = util.NewExecutionClient(c.connectionExecution)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up execution client")
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositbatch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+const depositData = `[{"pubkey":"a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","withdrawal_credentials":"00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b","amount":32000000000,"signature":"b7a757a4c506ac6ac5f2d23e065de7d00dc9f5a6a3f9610a8b60b65f166379139ae382c91ecbbf5c9fabc34b1cd2cf8f0211488d50d8754716d8e72e17c1a00b5d9b37cc73767946790ebe66cf9669abfc5c25c67e1e2d1c2e11429d149c25a2","deposit_message_root":"139b510ea7f2788ab82da1f427d6cbe1db147c15a053db738ad5500cd83754a6","deposit_data_root":"9e51b386f4271c18149dd0f73297a26a4a8c15c3622c44af79c92446f44a3554","fork_version":"01020304"}]`
+
+// noCodeAddress is an address at which the mock execution node has no code.
+const noCodeAddress = "0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F"
+
+// executionNode returns a handler for a mock execution node with the given balance.
+func executionNode(t *testing.T, balance string) http.HandlerFunc {
+	t.Helper()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     uint64            `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		results := map[string]any{
+			"eth_chainId":              "0x1",
+			"eth_getBalance":           balance,
+			"eth_getTransactionCount":  "0x5",
+			"eth_getBlockByNumber":     map[string]string{"baseFeePerGas": "0x3b9aca00"},
+			"eth_maxPriorityFeePerGas": "0x3b9aca00",
+			"eth_estimateGas":          "0x30d40",
+			"eth_getCode":              "0x60806040",
+			"eth_blockNumber":          "0x2",
+			"eth_getLogs":              []any{},
+		}
+		if req.Method == "eth_getCode" {
+			var address string
+			require.NoError(t, json.Unmarshal(req.Params[0], &address))
+			if strings.EqualFold(address, noCodeAddress) {
+				results["eth_getCode"] = "0x"
+			}
+		}
+		result, exists := results[req.Method]
+		if !exists {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`))
+			return
+		}
+		data, err := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  result,
+		})
+		require.NoError(t, err)
+		_, _ = w.Write(data)
+	}
+}
+
+func TestVerifyDeposits(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	deposits, err := util.DepositInfoFromJSON([]byte(depositData))
+	require.NoError(t, err)
+	c := &command{
+		deposits:    deposits,
+		forkVersion: phase0.Version{0x01, 0x02, 0x03, 0x04},
+	}
+	require.NoError(t, c.verifyDeposits())
+
+	c.deposits = append(c.deposits, c.deposits[0])
+	require.EqualError(t, c.verifyDeposits(), "deposits 0 and 1 are both for 0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c")
+
+	c.forkVersion = phase0.Version{}
+	require.EqualError(t, c.verifyDeposits(), "deposit 0 for 0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c failed verification: deposit is for fork version 0x01020304 rather than 0x00000000")
+}
+
+func TestCheckChain(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(executionNode(t, "0x0"))
+	defer server.Close()
+	executionClient, err := util.NewExecutionClient(server.URL)
+	require.NoError(t, err)
+
+	c := &command{
+		executionClient: executionClient,
+		depositChainID:  1,
+	}
+	require.NoError(t, c.checkChain(ctx))
+
+	c.depositChainID = 5
+	require.EqualError(t, c.checkChain(ctx), "execution node is on chain 1 but deposits are for chain 5")
+}
+
+func TestCheckBatchContract(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(executionNode(t, "0x0"))
+	defer server.Close()
+	executionClient, err := util.NewExecutionClient(server.URL)
+	require.NoError(t, err)
+
+	batchContract, err := util.ParseExecutionAddress("0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F")
+	require.NoError(t, err)
+	noCodeContract, err := util.ParseExecutionAddress(noCodeAddress)
+	require.NoError(t, err)
+
+	c := &command{
+		batchContract:   batchContract,
+		executionClient: executionClient,
+		depositChainID:  1,
+	}
+	require.NoError(t, c.checkBatchContract(ctx))
+
+	c.batchContract = noCodeContract
+	require.EqualError(t, c.checkBatchContract(ctx), "batch contract 0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F has no code on chain 1")
+}
+
+func TestGenerateBatches(t *testing.T) {
+	ctx := context.Background()
+
+	key, err := util.ParseExecutionKey("0x4646464646464646464646464646464646464646464646464646464646464646")
+	require.NoError(t, err)
+	batchContract, err := util.ParseExecutionAddress("0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		deposits  int
+		batchSize int
+		signer    util.ExecutionSigner
+		balance   string
+		err       string
+		batches   int
+	}{
+		{
+			name:      "DuplicateInBatch",
+			deposits:  2,
+			batchSize: 2,
+			err:       "failed to generate batch of deposits 0 to 1: deposits 0 and 1 are for the same public key",
+		},
+		{
+			name:      "Single",
+			deposits:  1,
+			batchSize: 100,
+			batches:   1,
+		},
+		{
+			name:      "Split",
+			deposits:  3,
+			batchSize: 1,
+			batches:   3,
+		},
+		{
+			name:      "InsufficientBalance",
+			deposits:  1,
+			batchSize: 100,
+			signer:    key,
+			// 32 Ether.
+			balance: "0x1bc16d674ec800000",
+			err:     "balance of 0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F is 32 Ether, but up to 32.00072 Ether is required",
+		},
+		{
+			name:      "Signed",
+			deposits:  2,
+			batchSize: 1,
+			signer:    key,
+			// 100 Ether.
+			balance: "0x56bc75e2d63100000",
+			batches: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(executionNode(t, test.balance))
+			defer server.Close()
+			executionClient, err := util.NewExecutionClient(server.URL)
+			require.NoError(t, err)
+
+			c := &command{
+				batchContract:   batchContract,
+				batchFormat:     util.BatchDepositFormatArrays,
+				batchSize:       test.batchSize,
+				signer:          test.signer,
+				executionClient: executionClient,
+			}
+			deposits, err := util.DepositInfoFromJSON([]byte(depositData))
+			require.NoError(t, err)
+			for i := 0; i < test.deposits; i++ {
+				c.deposits = append(c.deposits, deposits[0])
+			}
+
+			err = c.generateBatches()
+			if err == nil && c.signer != nil {
+				err = c.signBatches(ctx)
+			}
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, c.results, test.batches)
+				for i, result := range c.results {
+					require.Equal(t, test.deposits/test.batches, result.Deposits)
+					require.Equal(t, batchContract, result.To)
+					if test.signer != nil {
+						require.Equal(t, uint64(5+i), *result.Nonce)
+						require.NotEmpty(t, result.Raw)
+					} else {
+						require.Nil(t, result.Nonce)
+						require.Empty(t, result.Raw)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositsend
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+// defaultPollInterval is the interval at which the execution node is polled for confirmations.
+var defaultPollInterval = 12 * time.Second
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Input.
+	data          string
+	confirmations uint64
+	depositAmount phase0.Gwei
+	noSend        bool
+	force         bool
+	txOpts        *util.ExecutionTransactionOpts
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Execution node connection.
+	connectionExecution string
+
+	// Data access.
+	eth2Client      eth2client.Service
+	executionClient *util.ExecutionClient
+	signer          util.ExecutionSigner
+
+	// Processing.
+	deposits        []*util.DepositInfo
+	depositContract bellatrix.ExecutionAddress
+	depositChainID  uint64
+	forkVersion     phase0.Version
+	pollInterval    time.Duration
+
+	// Output.
+	results []*depositResult
+}
+
+type depositResult struct {
+	PublicKey   phase0.BLSPubKey `json:"pubkey"`
+	Amount      phase0.Gwei      `json:"amount"`
+	Nonce       uint64           `json:"nonce"`
+	Transaction phase0.Hash32    `json:"transaction"`
+	// BlockNumber is the number of the block in which the transaction was included, if known.
+	BlockNumber   uint64 `json:"block_number,omitempty"`
+	Confirmations uint64 `json:"confirmations"`
+	// Raw is the signed transaction, if it has not been sent.
+	Raw string `json:"raw,omitempty"`
+
+	raw []byte
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:         viper.GetBool("quiet"),
+		verbose:       viper.GetBool("verbose"),
+		debug:         viper.GetBool("debug"),
+		json:          viper.GetBool("json"),
+		data:          viper.GetString("data"),
+		confirmations: viper.GetUint64("confirmations"),
+		noSend:        viper.GetBool("no-send"),
+		force:         viper.GetBool("force"),
+		pollInterval:  defaultPollInterval,
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	if c.data == "" {
+		return nil, errors.New("data is required")
+	}
+
+	// The deposit amount is only used for raw transaction data, which does not contain it.
+	if viper.GetString("depositvalue") != "" {
+		amount, err := string2eth.StringToGWei(viper.GetString("depositvalue"))
+		if err != nil {
+			return nil, errors.Wrap(err, "deposit value is invalid")
+		}
+		if amount < 1000000000 { // MIN_DEPOSIT_AMOUNT
+			return nil, errors.New("deposit value must be at least 1 Ether")
+		}
+		c.depositAmount = phase0.Gwei(amount)
+	}
+
+	c.connectionExecution = viper.GetString("connection-execution")
+	if c.connectionExecution == "" {
+		return nil, errors.New("connection-execution is required")
+	}
+
+	var err error
+	c.signer, err = util.ObtainExecutionSigner()
+	if err != nil {
+		return nil, err
+	}
+	if c.signer == nil {
+		return nil, errors.New("execution-key or execution-signer is required")
+	}
+
+	c.txOpts, err = util.ObtainExecutionTransactionOpts()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
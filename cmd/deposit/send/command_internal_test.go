@@ -0,0 +1,191 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositsend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"data":                 "deposit_data.json",
+				"connection-execution": "http://localhost:8545",
+				"execution-key":        "0x4646464646464646464646464646464646464646464646464646464646464646",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "DataMissing",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"connection-execution": "http://localhost:8545",
+				"execution-key":        "0x4646464646464646464646464646464646464646464646464646464646464646",
+			},
+			err: "data is required",
+		},
+		{
+			name: "ConnectionExecutionMissing",
+			vars: map[string]interface{}{
+				"timeout":       "5s",
+				"data":          "deposit_data.json",
+				"execution-key": "0x4646464646464646464646464646464646464646464646464646464646464646",
+			},
+			err: "connection-execution is required",
+		},
+		{
+			name: "SignerMissing",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"data":                 "deposit_data.json",
+				"connection-execution": "http://localhost:8545",
+			},
+			err: "execution-key or execution-signer is required",
+		},
+		{
+			name: "SignerMultiple",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"data":                 "deposit_data.json",
+				"connection-execution": "http://localhost:8545",
+				"execution-key":        "0x4646464646464646464646464646464646464646464646464646464646464646",
+				"execution-signer":     "http://localhost:9000",
+			},
+			err: "only one of execution-key and execution-signer can be supplied",
+		},
+		{
+			name: "ExecutionKeyInvalid",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"data":                 "deposit_data.json",
+				"connection-execution": "http://localhost:8545",
+				"execution-key":        "0x4646",
+			},
+			err: "invalid execution key: execution key must be 32 bytes",
+		},
+		{
+			name: "FromMissing",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"data":                 "deposit_data.json",
+				"connection-execution": "http://localhost:8545",
+				"execution-signer":     "http://localhost:9000",
+			},
+			err: "from is required with execution-signer",
+		},
+		{
+			name: "FromInvalid",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"data":                 "deposit_data.json",
+				"connection-execution": "http://localhost:8545",
+				"execution-signer":     "http://localhost:9000",
+				"from":                 "0x9D8A62f656a8d1615C1294fd71e9CFb3E4855A4F",
+			},
+			err: "invalid from address: address checksum does not match (expected 0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F)",
+		},
+		{
+			name: "MaxFeeInvalid",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"data":                 "deposit_data.json",
+				"connection-execution": "http://localhost:8545",
+				"execution-key":        "0x4646464646464646464646464646464646464646464646464646464646464646",
+				"max-fee":              "1.2.3",
+			},
+			err: "invalid max fee: invalid format",
+		},
+		{
+			name: "DepositValueInvalid",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"data":                 "deposit_data.json",
+				"depositvalue":         "lots",
+				"connection-execution": "http://localhost:8545",
+				"execution-key":        "0x4646464646464646464646464646464646464646464646464646464646464646",
+			},
+			err: "deposit value is invalid: failed to parse numeric value of  lots",
+		},
+		{
+			name: "DepositValueTooLow",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"data":                 "deposit_data.json",
+				"depositvalue":         "0.5 Ether",
+				"connection-execution": "http://localhost:8545",
+				"execution-key":        "0x4646464646464646464646464646464646464646464646464646464646464646",
+			},
+			err: "deposit value must be at least 1 Ether",
+		},
+		{
+			name: "GoodExecutionKey",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"data":                 "deposit_data.json",
+				"connection-execution": "http://localhost:8545",
+				"execution-key":        "0x4646464646464646464646464646464646464646464646464646464646464646",
+			},
+		},
+		{
+			name: "GoodExecutionSigner",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"data":                 "deposit_data.json",
+				"connection-execution": "http://localhost:8545",
+				"execution-signer":     "http://localhost:9000",
+				"from":                 "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F",
+			},
+		},
+		{
+			name: "GoodNoSend",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"data":                 "deposit_data.json",
+				"connection-execution": "http://localhost:8545",
+				"execution-key":        "0x4646464646464646464646464646464646464646464646464646464646464646",
+				"max-fee":              "20gwei",
+				"priority-fee":         "1gwei",
+				"nonce":                "10",
+				"no-send":              true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			c, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F", c.signer.Address().String())
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositsend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		data, err := json.Marshal(c.results)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal results")
+		}
+
+		return string(data), nil
+	}
+
+	builder := strings.Builder{}
+	for _, result := range c.results {
+		if result.Raw != "" {
+			// Unsent transactions are output one per line, ready to broadcast.
+			if c.verbose {
+				builder.WriteString(fmt.Sprintf("Deposit of %s for %#x: transaction %s with nonce %d:\n", string2eth.GWeiToString(uint64(result.Amount), true), result.PublicKey, result.Transaction.String(), result.Nonce))
+			}
+			builder.WriteString(result.Raw)
+			builder.WriteString("\n")
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("Deposit of %s for %#x: transaction %s", string2eth.GWeiToString(uint64(result.Amount), true), result.PublicKey, result.Transaction.String()))
+		switch {
+		case result.BlockNumber != 0:
+			builder.WriteString(fmt.Sprintf(" included in block %d", result.BlockNumber))
+			if c.verbose {
+				builder.WriteString(fmt.Sprintf(" (%d confirmations)", result.Confirmations))
+			}
+		default:
+			builder.WriteString(" sent")
+		}
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf(" with nonce %d", result.Nonce))
+		}
+		builder.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
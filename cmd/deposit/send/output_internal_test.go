@@ -0,0 +1,130 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositsend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	results := []*depositResult{
+		{
+			PublicKey:     phase0.BLSPubKey{0x01},
+			Amount:        32000000000,
+			Nonce:         5,
+			Transaction:   phase0.Hash32{0x02},
+			BlockNumber:   100,
+			Confirmations: 2,
+		},
+		{
+			PublicKey:   phase0.BLSPubKey{0x03},
+			Amount:      1000000000,
+			Nonce:       6,
+			Transaction: phase0.Hash32{0x04},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		command *command
+		res     string
+	}{
+		{
+			name: "Quiet",
+			command: &command{
+				quiet:   true,
+				results: results,
+			},
+		},
+		{
+			name: "Empty",
+			command: &command{
+				results: []*depositResult{},
+			},
+		},
+		{
+			name: "Good",
+			command: &command{
+				results: results,
+			},
+			res: "Deposit of 32 Ether for 0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000: transaction 0x0200000000000000000000000000000000000000000000000000000000000000 included in block 100\nDeposit of 1 Ether for 0x030000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000: transaction 0x0400000000000000000000000000000000000000000000000000000000000000 sent",
+		},
+		{
+			name: "Verbose",
+			command: &command{
+				verbose: true,
+				results: results[:1],
+			},
+			res: "Deposit of 32 Ether for 0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000: transaction 0x0200000000000000000000000000000000000000000000000000000000000000 included in block 100 (2 confirmations) with nonce 5",
+		},
+		{
+			name: "NoSend",
+			command: &command{
+				results: []*depositResult{
+					{
+						PublicKey:   phase0.BLSPubKey{0x01},
+						Amount:      32000000000,
+						Nonce:       5,
+						Transaction: phase0.Hash32{0x02},
+						Raw:         "0x02abcd",
+					},
+					{
+						PublicKey:   phase0.BLSPubKey{0x03},
+						Amount:      32000000000,
+						Nonce:       6,
+						Transaction: phase0.Hash32{0x04},
+						Raw:         "0x02ef01",
+					},
+				},
+			},
+			res: "0x02abcd\n0x02ef01",
+		},
+		{
+			name: "NoSendVerbose",
+			command: &command{
+				verbose: true,
+				results: []*depositResult{
+					{
+						PublicKey:   phase0.BLSPubKey{0x01},
+						Amount:      32000000000,
+						Nonce:       5,
+						Transaction: phase0.Hash32{0x02},
+						Raw:         "0x02abcd",
+					},
+				},
+			},
+			res: "Deposit of 32 Ether for 0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000: transaction 0x0200000000000000000000000000000000000000000000000000000000000000 with nonce 5:\n0x02abcd",
+		},
+		{
+			name: "JSON",
+			command: &command{
+				json:    true,
+				results: results[1:],
+			},
+			res: `[{"pubkey":"0x030000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","amount":"1000000000","nonce":6,"transaction":"0x0400000000000000000000000000000000000000000000000000000000000000","confirmations":0}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.command.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositsend
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	var err error
+	c.deposits, err = util.ObtainDeposits(c.data, c.depositAmount)
+	if err != nil {
+		return err
+	}
+
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if err := c.verifyDeposits(); err != nil {
+		return err
+	}
+
+	// Refuse to deposit to a validator twice.
+	if err := util.CheckExistingDeposits(ctx, c.eth2Client, util.DepositPublicKeys(c.deposits), &util.ExistingDepositsOpts{
+		ExecutionClient: c.executionClient,
+		DepositContract: c.depositContract,
+	}, c.force, c.quiet); err != nil {
+		return err
+	}
+
+	if err := c.generateTransactions(ctx); err != nil {
+		return err
+	}
+
+	if c.noSend {
+		return nil
+	}
+
+	if err := c.sendTransactions(ctx); err != nil {
+		return err
+	}
+
+	return c.awaitConfirmations(ctx)
+}
+
+// verifyDeposits ensures that all deposits are valid for the network before
+// any are sent.
+func (c *command) verifyDeposits() error {
+	for i, deposit := range c.deposits {
+		if err := util.VerifyDeposit(deposit, c.forkVersion); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("deposit %d for %#x failed verification", i, deposit.PublicKey))
+		}
+	}
+
+	return nil
+}
+
+// generateTransactions generates and signs the deposit transactions, checking
+// that the sending account can afford them.
+func (c *command) generateTransactions(ctx context.Context) error {
+	builder, err := util.NewExecutionTransactionBuilder(ctx, c.executionClient, c.signer, c.txOpts)
+	if err != nil {
+		return err
+	}
+	if builder.ChainID() != c.depositChainID {
+		return fmt.Errorf("execution node is on chain %d but deposits are for chain %d", builder.ChainID(), c.depositChainID)
+	}
+
+	txs := make([]*util.SignedExecutionTransaction, 0, len(c.deposits))
+	c.results = make([]*depositResult, 0, len(c.deposits))
+	for _, deposit := range c.deposits {
+		data, err := util.DepositTransactionData(deposit)
+		if err != nil {
+			return errors.Wrap(err, "failed to generate deposit transaction data")
+		}
+		// Deposit amounts are in Gwei, transaction values in wei.
+		value := new(big.Int).Mul(new(big.Int).SetUint64(deposit.Amount), big.NewInt(1e9))
+		tx, err := builder.Build(ctx, c.depositContract, value, data)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to generate deposit transaction for %#x", deposit.PublicKey))
+		}
+		txs = append(txs, tx)
+
+		result := &depositResult{
+			Amount:      phase0.Gwei(deposit.Amount),
+			Nonce:       tx.Nonce,
+			Transaction: tx.Hash,
+			raw:         tx.Data,
+		}
+		if c.noSend {
+			// The signed transaction is output for sending elsewhere.
+			result.Raw = fmt.Sprintf("%#x", tx.Data)
+		}
+		copy(result.PublicKey[:], deposit.PublicKey)
+		c.results = append(c.results, result)
+	}
+
+	return builder.CheckBalance(ctx, txs)
+}
+
+// sendTransactions sends the signed deposit transactions.
+func (c *command) sendTransactions(ctx context.Context) error {
+	for i, result := range c.results {
+		// The hash is output before sending so that the transaction can be
+		// tracked even if the response from the node is lost.
+		if !c.quiet {
+			fmt.Fprintf(os.Stderr, "Sending transaction %s for deposit %#x\n", result.Transaction.String(), result.PublicKey)
+		}
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Sending transaction %#x\n", result.raw)
+		}
+		if _, err := c.executionClient.SendRawTransaction(ctx, result.raw); err != nil {
+			sent := make([]string, 0, i)
+			for _, sentResult := range c.results[:i] {
+				sent = append(sent, sentResult.Transaction.String())
+			}
+			if len(sent) == 0 {
+				return errors.Wrap(err, fmt.Sprintf("failed to send deposit for %#x in transaction %s; no other deposits were sent", result.PublicKey, result.Transaction.String()))
+			}
+			return errors.Wrap(err, fmt.Sprintf("failed to send deposit for %#x in transaction %s; %d deposits were already sent in transactions %s", result.PublicKey, result.Transaction.String(), i, strings.Join(sent, ", ")))
+		}
+	}
+
+	return nil
+}
+
+// awaitConfirmations waits for the deposit transactions to be included and
+// obtain the required number of confirmations.
+func (c *command) awaitConfirmations(ctx context.Context) error {
+	if c.confirmations == 0 {
+		return nil
+	}
+
+	for {
+		blockNumber, err := c.executionClient.BlockNumber(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain block number")
+		}
+
+		confirmed := true
+		for _, result := range c.results {
+			if result.BlockNumber == 0 {
+				receipt, err := c.executionClient.TransactionReceipt(ctx, result.Transaction)
+				if err != nil {
+					return errors.Wrap(err, "failed to obtain transaction receipt")
+				}
+				if receipt == nil {
+					confirmed = false
+					continue
+				}
+				if !receipt.Succeeded {
+					return fmt.Errorf("deposit transaction %s for %#x failed in block %d", result.Transaction.String(), result.PublicKey, receipt.BlockNumber)
+				}
+				result.BlockNumber = receipt.BlockNumber
+			}
+			if blockNumber >= result.BlockNumber {
+				result.Confirmations = blockNumber - result.BlockNumber + 1
+			}
+			if result.Confirmations < c.confirmations {
+				confirmed = false
+			}
+		}
+		if confirmed {
+			return nil
+		}
+
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Awaiting confirmations at block %d\n", blockNumber)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.pollInterval):
+		}
+	}
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	specProvider, isProvider := c.eth2Client.(eth2client.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+	specResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	addressBytes, isBytes := specResponse.Data["DEPOSIT_CONTRACT_ADDRESS"].([]byte)
+	if !isBytes || len(addressBytes) != bellatrix.ExecutionAddressLength {
+		return errors.New("DEPOSIT_CONTRACT_ADDRESS missing or invalid")
+	}
+	copy(c.depositContract[:], addressBytes)
+	var isUint bool
+	c.depositChainID, isUint = specResponse.Data["DEPOSIT_CHAIN_ID"].(uint64)
+	if !isUint {
+		return errors.New("DEPOSIT_CHAIN_ID missing or invalid")
+	}
+	var isVersion bool
+	c.forkVersion, isVersion = specResponse.Data["GENESIS_FORK_VERSION"].(phase0.Version)
+	if !isVersion {
+		return errors.New("GENESIS_FORK_VERSION missing or invalid")
+	}
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Deposit contract is %s on chain %d\n", c.depositContract.String(), c.depositChainID)
+	}
+
+	c.executionClient, err = util.NewExecutionClient(c.connectionExecution)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up execution client")
+	}
+
+	return nil
+}
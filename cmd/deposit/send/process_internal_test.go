@@ -0,0 +1,360 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depositsend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+const depositData = `[{"pubkey":"a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","withdrawal_credentials":"00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b","amount":32000000000,"signature":"b7a757a4c506ac6ac5f2d23e065de7d00dc9f5a6a3f9610a8b60b65f166379139ae382c91ecbbf5c9fabc34b1cd2cf8f0211488d50d8754716d8e72e17c1a00b5d9b37cc73767946790ebe66cf9669abfc5c25c67e1e2d1c2e11429d149c25a2","deposit_message_root":"139b510ea7f2788ab82da1f427d6cbe1db147c15a053db738ad5500cd83754a6","deposit_data_root":"9e51b386f4271c18149dd0f73297a26a4a8c15c3622c44af79c92446f44a3554","fork_version":"01020304"}]`
+
+// executionNode is a mock execution node.
+type executionNode struct {
+	mu          sync.Mutex
+	chainID     string
+	balance     string
+	blockNumber uint64
+	// failSend is the number of transactions after which sending fails, if set.
+	failSend int
+	// failReceipt is true if receipts report failed transactions.
+	failReceipt bool
+	sent        []string
+}
+
+func (n *executionNode) handler(t *testing.T) http.HandlerFunc {
+	t.Helper()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+
+		var req struct {
+			ID     uint64            `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		var result any
+		switch req.Method {
+		case "eth_chainId":
+			result = n.chainID
+		case "eth_getBalance":
+			result = n.balance
+		case "eth_getTransactionCount":
+			result = "0x5"
+		case "eth_getBlockByNumber":
+			result = map[string]string{"baseFeePerGas": "0x3b9aca00"}
+		case "eth_maxPriorityFeePerGas":
+			result = "0x3b9aca00"
+		case "eth_estimateGas":
+			result = "0xc350"
+		case "eth_sendRawTransaction":
+			if n.failSend > 0 && len(n.sent) == n.failSend {
+				_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"nonce too low"}}`))
+				return
+			}
+			var raw string
+			require.NoError(t, json.Unmarshal(req.Params[0], &raw))
+			data := testutil.HexToBytes(raw)
+			result = util.ExecutionTransactionHash(data).String()
+			n.sent = append(n.sent, raw)
+		case "eth_getLogs":
+			result = []any{}
+		case "eth_blockNumber":
+			// Each poll moves the chain on by a block.
+			n.blockNumber++
+			result = fmt.Sprintf("%#x", n.blockNumber)
+		case "eth_getTransactionReceipt":
+			if n.blockNumber < 2 {
+				result = nil
+			} else {
+				status := "0x1"
+				if n.failReceipt {
+					status = "0x0"
+				}
+				result = map[string]string{"blockNumber": "0x2", "gasUsed": "0xd0e8", "status": status}
+			}
+		default:
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`))
+			return
+		}
+		data, err := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  result,
+		})
+		require.NoError(t, err)
+		_, _ = w.Write(data)
+	}
+}
+
+func TestVerifyDeposits(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	deposits, err := util.DepositInfoFromJSON([]byte(depositData))
+	require.NoError(t, err)
+	c := &command{
+		deposits:    deposits,
+		forkVersion: phase0.Version{0x01, 0x02, 0x03, 0x04},
+	}
+	require.NoError(t, c.verifyDeposits())
+
+	c.forkVersion = phase0.Version{}
+	require.EqualError(t, c.verifyDeposits(), "deposit 0 for 0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c failed verification: deposit is for fork version 0x01020304 rather than 0x00000000")
+}
+
+func TestSend(t *testing.T) {
+	ctx := context.Background()
+
+	key, err := util.ParseExecutionKey("0x4646464646464646464646464646464646464646464646464646464646464646")
+	require.NoError(t, err)
+	depositContract, err := util.ParseExecutionAddress("0x00000000219ab540356cBB839Cbe05303d7705Fa")
+	require.NoError(t, err)
+	nonce := uint64(10)
+
+	tests := []struct {
+		name          string
+		node          *executionNode
+		deposits      int
+		confirmations uint64
+		noSend        bool
+		txOpts        *util.ExecutionTransactionOpts
+		err           string
+		sent          int
+		blockNumber   uint64
+	}{
+		{
+			name: "WrongChain",
+			node: &executionNode{
+				chainID: "0x5",
+				balance: "0x56bc75e2d63100000",
+			},
+			deposits: 1,
+			err:      "execution node is on chain 5 but deposits are for chain 1",
+		},
+		{
+			name: "InsufficientBalance",
+			node: &executionNode{
+				chainID: "0x1",
+				// 32 Ether.
+				balance: "0x1bc16d674ec800000",
+			},
+			deposits: 1,
+			err:      "balance of 0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F is 32 Ether, but up to 32.00018 Ether is required",
+		},
+		{
+			name: "SendFailed",
+			node: &executionNode{
+				chainID:  "0x1",
+				balance:  "0x56bc75e2d63100000",
+				failSend: 1,
+			},
+			deposits: 2,
+			err:      "; 1 deposits were already sent in transactions",
+			sent:     1,
+		},
+		{
+			name: "NoConfirmations",
+			node: &executionNode{
+				chainID: "0x1",
+				balance: "0x56bc75e2d63100000",
+			},
+			deposits: 2,
+			sent:     2,
+		},
+		{
+			name: "TransactionFailed",
+			node: &executionNode{
+				chainID:     "0x1",
+				balance:     "0x56bc75e2d63100000",
+				failReceipt: true,
+			},
+			deposits:      1,
+			confirmations: 1,
+			err:           "failed in block 2",
+			sent:          1,
+		},
+		{
+			name: "NoSend",
+			node: &executionNode{
+				chainID: "0x1",
+				balance: "0x56bc75e2d63100000",
+			},
+			deposits: 2,
+			noSend:   true,
+			txOpts: &util.ExecutionTransactionOpts{
+				MaxFeePerGas:         big.NewInt(2000000000),
+				MaxPriorityFeePerGas: big.NewInt(1000000000),
+				Nonce:                &nonce,
+			},
+		},
+		{
+			name: "Confirmed",
+			node: &executionNode{
+				chainID: "0x1",
+				balance: "0x56bc75e2d63100000",
+			},
+			deposits:      2,
+			confirmations: 2,
+			sent:          2,
+			blockNumber:   2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(test.node.handler(t))
+			defer server.Close()
+			executionClient, err := util.NewExecutionClient(server.URL)
+			require.NoError(t, err)
+
+			c := &command{
+				confirmations:   test.confirmations,
+				noSend:          test.noSend,
+				txOpts:          test.txOpts,
+				executionClient: executionClient,
+				signer:          key,
+				depositContract: depositContract,
+				depositChainID:  1,
+				pollInterval:    time.Millisecond,
+				quiet:           true,
+			}
+			deposits, err := util.DepositInfoFromJSON([]byte(depositData))
+			require.NoError(t, err)
+			for i := 0; i < test.deposits; i++ {
+				c.deposits = append(c.deposits, deposits[0])
+			}
+
+			err = c.generateTransactions(ctx)
+			if err == nil && !c.noSend {
+				err = c.sendTransactions(ctx)
+			}
+			if err == nil && !c.noSend {
+				err = c.awaitConfirmations(ctx)
+			}
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, c.results, test.deposits)
+				startNonce := uint64(5)
+				if test.txOpts != nil && test.txOpts.Nonce != nil {
+					startNonce = *test.txOpts.Nonce
+				}
+				for i, result := range c.results {
+					require.Equal(t, startNonce+uint64(i), result.Nonce)
+					require.Equal(t, test.noSend, result.Raw != "")
+					require.Equal(t, test.blockNumber, result.BlockNumber)
+					if test.confirmations > 0 {
+						require.GreaterOrEqual(t, result.Confirmations, test.confirmations)
+					}
+				}
+			}
+			require.Len(t, test.node.sent, test.sent)
+		})
+	}
+}
+
+func TestSendRawTransactionData(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	key, err := util.ParseExecutionKey("0x4646464646464646464646464646464646464646464646464646464646464646")
+	require.NoError(t, err)
+	depositContract, err := util.ParseExecutionAddress("0x00000000219ab540356cBB839Cbe05303d7705Fa")
+	require.NoError(t, err)
+	deposits, err := util.DepositInfoFromJSON([]byte(depositData))
+	require.NoError(t, err)
+	txData, err := util.DepositTransactionData(deposits[0])
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		depositAmount phase0.Gwei
+		err           string
+		sent          int
+	}{
+		{
+			name: "AmountMissing",
+			err:  "deposit 0 for 0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c failed verification: deposit amount not supplied",
+		},
+		{
+			name:          "AmountIncorrect",
+			depositAmount: 1000000000,
+			err:           "deposit 0 for 0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c failed verification",
+		},
+		{
+			name:          "Good",
+			depositAmount: 32000000000,
+			sent:          1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			node := &executionNode{
+				chainID: "0x1",
+				balance: "0x56bc75e2d63100000",
+			}
+			server := httptest.NewServer(node.handler(t))
+			defer server.Close()
+			executionClient, err := util.NewExecutionClient(server.URL)
+			require.NoError(t, err)
+
+			c := &command{
+				quiet:           true,
+				data:            fmt.Sprintf("%#x", txData),
+				depositAmount:   test.depositAmount,
+				executionClient: executionClient,
+				signer:          key,
+				depositContract: depositContract,
+				depositChainID:  1,
+				forkVersion:     phase0.Version{0x01, 0x02, 0x03, 0x04},
+				pollInterval:    time.Millisecond,
+			}
+			c.deposits, err = util.ObtainDeposits(c.data, c.depositAmount)
+			if err == nil {
+				err = c.verifyDeposits()
+			}
+			if err == nil {
+				err = c.generateTransactions(ctx)
+			}
+			if err == nil {
+				err = c.sendTransactions(ctx)
+			}
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, c.results, 1)
+				require.Equal(t, phase0.Gwei(32000000000), c.results[0].Amount)
+			}
+			require.Len(t, node.sent, test.sent)
+		})
+	}
+}
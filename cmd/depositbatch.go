@@ -0,0 +1,109 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	depositbatch "github.com/wealdtech/ethdo/cmd/deposit/batch"
+)
+
+var depositBatchCmd = &cobra.Command{
+	Use:   "batch",
+	Short: "Generate a transaction making many deposits through a batch deposit contract",
+	Long: `Generate a transaction making many deposits with a single call to a batch deposit contract.  For example:
+
+    ethdo deposit batch --data=deposit_data.json --connection-execution=http://localhost:8545 --batch-contract=0x... --batch-format=arrays
+
+Deposits can be supplied in any format accepted by "deposit verify".  All deposits are verified against the network of the beacon node before the transaction is generated, and the value of the transaction is the total of the deposits.  Batch deposit contracts do not take per-deposit amounts, so all deposits must be for 32 Ether.
+
+The address of the batch deposit contract is supplied with --batch-contract.  Batch deposit contracts commonly use one of two calling conventions, which must be selected with --batch-format: "arrays" calls deposit(bytes[],bytes[],bytes[],bytes32[]) with one entry per deposit, and "packed" calls batchDeposit(bytes,bytes,bytes,bytes32[]) with the public keys, withdrawal credentials and signatures concatenated.  Deposits are split into multiple transactions of up to --batch-size deposits each.
+
+The execution node must be on the chain of the beacon node, and the batch contract must have code on it.  Deposits are refused if a deposit already exists for the validator in the validator registry, the pending deposits or recent logs of the deposit contract, unless --force is supplied.
+
+By default the command outputs the address, value and data of each transaction, to be submitted by the tool of choice.  If --execution-key or --execution-signer is supplied it instead outputs the signed transactions, ready to be broadcast; fees and the nonce can be set with --max-fee, --priority-fee and --nonce.  Transactions are never sent by this command.
+
+In quiet mode this will return 0 if the transactions have been generated, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := depositbatch.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	depositCmd.AddCommand(depositBatchCmd)
+	depositFlags(depositBatchCmd)
+	depositBatchCmd.Flags().String("data", "", "The deposit data, or the path to a file containing it")
+	depositBatchCmd.Flags().String("batch-contract", "", "Address of the batch deposit contract")
+	depositBatchCmd.Flags().String("batch-format", "", "Calling convention of the batch deposit contract (arrays or packed)")
+	depositBatchCmd.Flags().Int("batch-size", 100, "Maximum number of deposits in each transaction")
+	depositBatchCmd.Flags().String("connection-execution", "", "URL to an execution node with which to check the batch contract and prepare signed transactions")
+	depositBatchCmd.Flags().String("execution-key", "", "Private key of the execution account making the deposits, or the path to a file containing it")
+	depositBatchCmd.Flags().String("execution-signer", "", "URL to an external signer holding the execution account making the deposits")
+	depositBatchCmd.Flags().String("from", "", "Address of the execution account held by the external signer")
+	depositBatchCmd.Flags().String("max-fee", "", "Maximum fee per gas for the transactions, for example 20gwei (defaults to twice the base fee plus the priority fee)")
+	depositBatchCmd.Flags().String("priority-fee", "", "Maximum priority fee per gas for the transactions, for example 1gwei (defaults to the value suggested by the execution node)")
+	depositBatchCmd.Flags().String("nonce", "", "Nonce of the first transaction (defaults to the next nonce of the account)")
+	depositBatchCmd.Flags().Bool("force", false, "Generate transactions even if deposits already exist for the validators")
+}
+
+func depositBatchBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("data", cmd.Flags().Lookup("data")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("batch-contract", cmd.Flags().Lookup("batch-contract")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("batch-format", cmd.Flags().Lookup("batch-format")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("batch-size", cmd.Flags().Lookup("batch-size")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("connection-execution", cmd.Flags().Lookup("connection-execution")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("execution-key", cmd.Flags().Lookup("execution-key")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("execution-signer", cmd.Flags().Lookup("execution-signer")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("from", cmd.Flags().Lookup("from")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("max-fee", cmd.Flags().Lookup("max-fee")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("priority-fee", cmd.Flags().Lookup("priority-fee")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("nonce", cmd.Flags().Lookup("nonce")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("force", cmd.Flags().Lookup("force")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	depositsend "github.com/wealdtech/ethdo/cmd/deposit/send"
+)
+
+var depositSendCmd = &cobra.Command{
+	Use:   "send",
+	Short: "Send deposits to the deposit contract",
+	Long: `Send deposits to the deposit contract through an execution node.  For example:
+
+    ethdo deposit send --data=deposit_data.json --connection-execution=http://localhost:8545 --execution-key=/path/to/key
+
+Deposits can be supplied in any format accepted by "deposit verify".  Raw transaction data does not contain the amount of the deposit, which is supplied with --depositvalue.  All deposits are verified against the network of the beacon node, and all transactions are signed, before any are sent.  Deposits are refused if a deposit already exists for the validator in the validator registry, the pending deposits or recent logs of the deposit contract, unless --force is supplied.
+
+Transactions are signed either with a local key supplied with --execution-key, as a hex string or the path to a file containing one, or by an external signer supporting eth_signTransaction, such as one fronting a hardware wallet, supplied with --execution-signer along with the address of the account with --from.
+
+By default the command waits for each transaction to be included on the execution chain; --confirmations sets the number of blocks required, with 0 returning as soon as the transactions have been sent.
+
+Fees are obtained from the execution node unless supplied with --max-fee and --priority-fee, and the nonce of the first transaction with --nonce.  With --no-send the signed transactions are output rather than sent, for broadcast by other means.
+
+In quiet mode this will return 0 if the deposits have been sent and confirmed, or signed if --no-send is supplied, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := depositsend.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	depositCmd.AddCommand(depositSendCmd)
+	depositFlags(depositSendCmd)
+	depositSendCmd.Flags().String("data", "", "The deposit data, or the path to a file containing it")
+	depositSendCmd.Flags().String("depositvalue", "32 Ether", "Value of the deposits supplied as raw transaction data")
+	depositSendCmd.Flags().String("connection-execution", "", "URL to an execution node through which to send the deposits")
+	depositSendCmd.Flags().String("execution-key", "", "Private key of the execution account sending the deposits, or the path to a file containing it")
+	depositSendCmd.Flags().String("execution-signer", "", "URL to an external signer holding the execution account sending the deposits")
+	depositSendCmd.Flags().String("from", "", "Address of the execution account held by the external signer")
+	depositSendCmd.Flags().Uint64("confirmations", 1, "Number of blocks for which to wait for each deposit transaction to be confirmed")
+	depositSendCmd.Flags().String("max-fee", "", "Maximum fee per gas for the transactions, for example 20gwei (defaults to twice the base fee plus the priority fee)")
+	depositSendCmd.Flags().String("priority-fee", "", "Maximum priority fee per gas for the transactions, for example 1gwei (defaults to the value suggested by the execution node)")
+	depositSendCmd.Flags().String("nonce", "", "Nonce of the first transaction (defaults to the next nonce of the account)")
+	depositSendCmd.Flags().Bool("no-send", false, "Output the signed transactions rather than sending them")
+	depositSendCmd.Flags().Bool("force", false, "Send deposits even if deposits already exist for the validators")
+}
+
+func depositSendBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("data", cmd.Flags().Lookup("data")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("depositvalue", cmd.Flags().Lookup("depositvalue")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("connection-execution", cmd.Flags().Lookup("connection-execution")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("execution-key", cmd.Flags().Lookup("execution-key")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("execution-signer", cmd.Flags().Lookup("execution-signer")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("from", cmd.Flags().Lookup("from")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("confirmations", cmd.Flags().Lookup("confirmations")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("max-fee", cmd.Flags().Lookup("max-fee")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("priority-fee", cmd.Flags().Lookup("priority-fee")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("nonce", cmd.Flags().Lookup("nonce")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("no-send", cmd.Flags().Lookup("no-send")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("force", cmd.Flags().Lookup("force")); err != nil {
+		panic(err)
+	}
+}
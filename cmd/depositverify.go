@@ -49,6 +49,8 @@ var depositVerifyCmd = &cobra.Command{
 
 The deposit data is compared to the supplied withdrawal account/public key, validator public key, and value to ensure they match.
 
+The deposit data and deposit message roots are recomputed from the deposit fields and compared to the stated roots, and the signature is verified over the deposit message, to catch hand-edited or corrupted deposit data.
+
 In quiet mode this will return 0 if the data is verified correctly, otherwise 1.`,
 	Run: func(_ *cobra.Command, _ []string) {
 		assert(depositVerifyData != "", "--data is required")
@@ -228,102 +230,69 @@ func verifyDeposit(deposit *util.DepositInfo, withdrawalCredentials []byte, vali
 		outputIf(!viper.GetBool("quiet"), "Validator public key verified")
 	}
 
-	var pubKey phase0.BLSPubKey
-	copy(pubKey[:], deposit.PublicKey)
-	var signature phase0.BLSSignature
-	copy(signature[:], deposit.Signature)
-
-	depositData := &phase0.DepositData{
-		PublicKey:             pubKey,
-		WithdrawalCredentials: deposit.WithdrawalCredentials,
-		Amount:                phase0.Gwei(deposit.Amount),
-		Signature:             signature,
-	}
-	depositDataRoot, err := depositData.HashTreeRoot()
+	// Recompute the roots from the deposit fields, to catch hand-edited or corrupted data.
+	depositDataRoot, err := util.DepositDataRoot(deposit)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to generate deposit data root")
+		outputIf(!viper.GetBool("quiet"), fmt.Sprintf("Deposit data invalid: %v", err))
+		return false, nil
 	}
-
 	if bytes.Equal(deposit.DepositDataRoot, depositDataRoot[:]) {
 		outputIf(!viper.GetBool("quiet"), "Deposit data root verified")
 	} else {
-		outputIf(!viper.GetBool("quiet"), "Deposit data root incorrect")
+		outputIf(!viper.GetBool("quiet"), fmt.Sprintf("Deposit data root incorrect: stated %#x, calculated %#x", deposit.DepositDataRoot, depositDataRoot))
 		return false, nil
 	}
 
-	if len(deposit.ForkVersion) == 0 {
-		if depositVerifyForkVersion != "" {
-			outputIf(!viper.GetBool("quiet"), "Data format does not contain fork version for verification; NOT verified")
+	depositMessageRoot, err := util.DepositMessageRoot(deposit)
+	if err != nil {
+		outputIf(!viper.GetBool("quiet"), fmt.Sprintf("Deposit message invalid: %v", err))
+		return false, nil
+	}
+	switch {
+	case len(deposit.DepositMessageRoot) == 0:
+		outputIf(!viper.GetBool("quiet"), "Deposit message root not supplied; NOT checked")
+	case bytes.Equal(deposit.DepositMessageRoot, depositMessageRoot[:]):
+		outputIf(!viper.GetBool("quiet"), "Deposit message root verified")
+	default:
+		outputIf(!viper.GetBool("quiet"), fmt.Sprintf("Deposit message root incorrect: stated %#x, calculated %#x", deposit.DepositMessageRoot, depositMessageRoot))
+		return false, nil
+	}
+
+	// Select the fork version with which to verify the signature.
+	var forkVersion []byte
+	if depositVerifyForkVersion != "" {
+		forkVersion, err = hex.DecodeString(strings.TrimPrefix(depositVerifyForkVersion, "0x"))
+		if err != nil {
+			return false, errors.Wrap(err, "failed to decode fork version")
 		}
-	} else {
-		if depositVerifyForkVersion == "" {
-			outputIf(!viper.GetBool("quiet"), "fork version not supplied; not checked")
-		} else {
-			forkVersion, err := hex.DecodeString(strings.TrimPrefix(depositVerifyForkVersion, "0x"))
-			if err != nil {
-				return false, errors.Wrap(err, "failed to decode fork version")
-			}
-			if bytes.Equal(deposit.ForkVersion, forkVersion) {
-				outputIf(!viper.GetBool("quiet"), "Fork version verified")
-			} else {
+		if len(forkVersion) != phase0.ForkVersionLength {
+			return false, errors.New("fork version must be exactly 4 bytes in length")
+		}
+	}
+	if len(deposit.ForkVersion) != 0 {
+		if forkVersion != nil {
+			if !bytes.Equal(deposit.ForkVersion, forkVersion) {
 				outputIf(!viper.GetBool("quiet"), "Fork version incorrect")
 				return false, nil
 			}
-
-			switch {
-			case len(deposit.DepositMessageRoot) != 32:
-				outputIf(!viper.GetBool("quiet"), "Deposit message root not supplied; not checked")
-			case len(withdrawalCredentials) != 32:
-				outputIf(!viper.GetBool("quiet"), "Withdrawal credentials not available; cannot recreate deposit message")
-			default:
-				// We can also verify the deposit message signature.
-				depositMessage := &phase0.DepositMessage{
-					PublicKey:             pubKey,
-					WithdrawalCredentials: withdrawalCredentials,
-					Amount:                phase0.Gwei(deposit.Amount),
-				}
-				depositMessageRoot, err := depositMessage.HashTreeRoot()
-				if err != nil {
-					return false, errors.Wrap(err, "failed to generate deposit message root")
-				}
-
-				if bytes.Equal(deposit.DepositMessageRoot, depositMessageRoot[:]) {
-					outputIf(!viper.GetBool("quiet"), "Deposit message root verified")
-				} else {
-					outputIf(!viper.GetBool("quiet"), "Deposit message root incorrect")
-					return false, nil
-				}
-
-				domainBytes := e2types.Domain(e2types.DomainDeposit, forkVersion, e2types.ZeroGenesisValidatorsRoot)
-				var domain phase0.Domain
-				copy(domain[:], domainBytes)
-				container := &phase0.SigningData{
-					ObjectRoot: depositMessageRoot,
-					Domain:     domain,
-				}
-				containerRoot, err := container.HashTreeRoot()
-				if err != nil {
-					return false, errors.New("failed to generate root for container")
-				}
-
-				validatorPubKey, err := e2types.BLSPublicKeyFromBytes(pubKey[:])
-				if err != nil {
-					return false, errors.Wrap(err, "failed to generate validator public key")
-				}
-				blsSig, err := e2types.BLSSignatureFromBytes(signature[:])
-				if err != nil {
-					return false, errors.New("failed to verify BLS signature")
-				}
-				signatureVerified := blsSig.Verify(containerRoot[:], validatorPubKey)
-				if signatureVerified {
-					outputIf(!viper.GetBool("quiet"), "Deposit message signature verified")
-				} else {
-					outputIf(!viper.GetBool("quiet"), "Deposit message signature NOT verified")
-					return false, nil
-				}
-			}
+			outputIf(!viper.GetBool("quiet"), "Fork version verified")
 		}
+		forkVersion = deposit.ForkVersion
+	}
+	if len(forkVersion) != phase0.ForkVersionLength {
+		outputIf(!viper.GetBool("quiet"), "Fork version not available; deposit message signature NOT checked")
+		return true, nil
+	}
+
+	signatureVerified, err := util.VerifyDepositSignature(deposit, phase0.Version(forkVersion))
+	if err != nil {
+		return false, err
+	}
+	if !signatureVerified {
+		outputIf(!viper.GetBool("quiet"), fmt.Sprintf("Deposit message signature NOT verified with fork version %#x", forkVersion))
+		return false, nil
 	}
+	outputIf(!viper.GetBool("quiet"), "Deposit message signature verified")
 
 	return true, nil
 }
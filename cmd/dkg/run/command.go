@@ -0,0 +1,252 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkgrun
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	pb "github.com/wealdtech/eth2-signer-api/pb/v1"
+	"github.com/wealdtech/ethdo/util"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	"google.golang.org/grpc"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	timeout time.Duration
+
+	// Input.
+	ceremonyFile    string
+	passphrase      string
+	storePassphrase string
+	remote          string
+
+	// Connections to remote signers; replaced in tests.
+	accountManagerFor func(ctx context.Context, endpoint string) (pb.AccountManagerClient, func(), error)
+	listerFor         func(ctx context.Context, endpoint string) (pb.ListerClient, func(), error)
+
+	// Working.
+	ceremony *util.DKGCeremony
+
+	// Output.
+	alreadyCompleted bool
+}
+
+func newCommand(ctx context.Context) (*command, error) {
+	c := &command{
+		quiet:           viper.GetBool("quiet"),
+		verbose:         viper.GetBool("verbose"),
+		debug:           viper.GetBool("debug"),
+		timeout:         viper.GetDuration("timeout"),
+		ceremonyFile:    viper.GetString("ceremony"),
+		storePassphrase: viper.GetString("store-passphrase"),
+		remote:          viper.GetString("remote"),
+	}
+
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	if c.ceremonyFile == "" {
+		return nil, errors.New("ceremony is required")
+	}
+
+	var err error
+	c.passphrase, err = util.GetPassphrase()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain passphrase")
+	}
+
+	c.ceremony, err = util.LoadDKGCeremony(c.ceremonyFile)
+	switch {
+	case err == nil:
+		// Resuming an existing ceremony; any parameters supplied must match.
+		if err := c.checkParameters(); err != nil {
+			return nil, err
+		}
+	case errors.Is(err, os.ErrNotExist):
+		if !util.AcceptablePassphrase(c.passphrase) {
+			return nil, errors.New("supplied passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag")
+		}
+		c.ceremony, err = newCeremony()
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.Wrap(err, "failed to load ceremony")
+	}
+
+	c.accountManagerFor = func(ctx context.Context, endpoint string) (pb.AccountManagerClient, func(), error) {
+		conn, closer, err := connect(ctx, endpoint)
+		if err != nil {
+			return nil, nil, err
+		}
+		return pb.NewAccountManagerClient(conn), closer, nil
+	}
+	c.listerFor = func(ctx context.Context, endpoint string) (pb.ListerClient, func(), error) {
+		conn, closer, err := connect(ctx, endpoint)
+		if err != nil {
+			return nil, nil, err
+		}
+		return pb.NewListerClient(conn), closer, nil
+	}
+
+	return c, nil
+}
+
+// newCeremony creates a new ceremony from the supplied parameters.
+func newCeremony() (*util.DKGCeremony, error) {
+	ceremony := &util.DKGCeremony{
+		Account:          viper.GetString("account"),
+		SigningThreshold: viper.GetUint32("signing-threshold"),
+		State:            util.DKGStateCreated,
+	}
+	if ceremony.Account == "" {
+		return nil, errors.New("account is required")
+	}
+	if _, _, err := e2wallet.WalletAndAccountNames(ceremony.Account); err != nil {
+		return nil, errors.Wrap(err, "invalid account")
+	}
+
+	participants, err := parseParticipants(viper.GetStringSlice("participants"), viper.GetStringSlice("participant-base-dirs"))
+	if err != nil {
+		return nil, err
+	}
+	ceremony.Participants = participants
+
+	if ceremony.SigningThreshold <= uint32(len(participants)/2) {
+		return nil, errors.New("signing threshold must be more than half the number of participants")
+	}
+	if ceremony.SigningThreshold > uint32(len(participants)) {
+		return nil, errors.New("signing threshold cannot be higher than the number of participants")
+	}
+
+	return ceremony, nil
+}
+
+// parseParticipants parses participants in the format "id:host:port", along
+// with optional base directories for local participants in the format "id:dir".
+func parseParticipants(input []string, baseDirs []string) ([]*util.DKGParticipant, error) {
+	if len(input) < 2 {
+		return nil, errors.New("at least two participants are required")
+	}
+
+	participants := make([]*util.DKGParticipant, 0, len(input))
+	ids := make(map[uint64]*util.DKGParticipant, len(input))
+	endpoints := make(map[string]bool, len(input))
+	for _, participant := range input {
+		parts := strings.SplitN(participant, ":", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid participant %q; must be id:host:port", participant)
+		}
+		id, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil || id == 0 {
+			return nil, fmt.Errorf("invalid participant ID %q", parts[0])
+		}
+		if _, exists := ids[id]; exists {
+			return nil, fmt.Errorf("duplicate participant ID %d", id)
+		}
+		if port, err := strconv.ParseUint(parts[2], 10, 16); err != nil || port == 0 {
+			return nil, fmt.Errorf("invalid port for participant %d", id)
+		}
+		endpoint := fmt.Sprintf("%s:%s", parts[1], parts[2])
+		if endpoints[endpoint] {
+			return nil, fmt.Errorf("duplicate participant endpoint %s", endpoint)
+		}
+		endpoints[endpoint] = true
+		ids[id] = &util.DKGParticipant{
+			ID:       id,
+			Endpoint: endpoint,
+		}
+		participants = append(participants, ids[id])
+	}
+
+	if len(baseDirs) == 0 {
+		return participants, nil
+	}
+	for _, baseDir := range baseDirs {
+		parts := strings.SplitN(baseDir, ":", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, fmt.Errorf("invalid participant base directory %q; must be id:dir", baseDir)
+		}
+		id, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid participant ID %q", parts[0])
+		}
+		participant, exists := ids[id]
+		if !exists {
+			return nil, fmt.Errorf("base directory supplied for unknown participant %d", id)
+		}
+		participant.BaseDir = parts[1]
+	}
+	for _, participant := range participants {
+		if participant.BaseDir == "" {
+			return nil, fmt.Errorf("no base directory supplied for participant %d; all participants must be local or all remote", participant.ID)
+		}
+	}
+
+	return participants, nil
+}
+
+// checkParameters ensures that any ceremony parameters supplied when
+// resuming an existing ceremony match those for which it was created.
+func (c *command) checkParameters() error {
+	if account := viper.GetString("account"); account != "" && account != c.ceremony.Account {
+		return fmt.Errorf("account %s does not match ceremony account %s", account, c.ceremony.Account)
+	}
+	if threshold := viper.GetUint32("signing-threshold"); threshold != 0 && threshold != c.ceremony.SigningThreshold {
+		return fmt.Errorf("signing threshold %d does not match ceremony signing threshold %d", threshold, c.ceremony.SigningThreshold)
+	}
+	if input := viper.GetStringSlice("participants"); len(input) > 0 {
+		participants, err := parseParticipants(input, viper.GetStringSlice("participant-base-dirs"))
+		if err != nil {
+			return err
+		}
+		if len(participants) != len(c.ceremony.Participants) {
+			return errors.New("participants do not match ceremony participants")
+		}
+		for i := range participants {
+			if participants[i].ID != c.ceremony.Participants[i].ID ||
+				participants[i].Endpoint != c.ceremony.Participants[i].Endpoint ||
+				participants[i].BaseDir != c.ceremony.Participants[i].BaseDir {
+				return errors.New("participants do not match ceremony participants")
+			}
+		}
+	}
+
+	return nil
+}
+
+// connect connects to the administration interface of a remote signer.
+func connect(ctx context.Context, endpoint string) (*grpc.ClientConn, func(), error) {
+	conn, err := util.NewSignerConnectionTo(ctx, endpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return conn, func() {
+		if err := conn.Close(); err != nil {
+			util.Log.Trace().Err(err).Msg("Failed to close connection to remote signer")
+		}
+	}, nil
+}
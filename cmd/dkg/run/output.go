@@ -0,0 +1,48 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkgrun
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	builder := strings.Builder{}
+	if c.alreadyCompleted {
+		builder.WriteString("Ceremony already completed\n")
+	} else {
+		builder.WriteString("Ceremony completed\n")
+	}
+	builder.WriteString(fmt.Sprintf("Account: %s\n", c.ceremony.Account))
+	builder.WriteString(fmt.Sprintf("Composite public key: %s", c.ceremony.CompositePublicKey))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("\nSigning threshold: %d/%d", c.ceremony.SigningThreshold, len(c.ceremony.Participants)))
+		builder.WriteString("\nParticipants:")
+		for _, participant := range c.ceremony.Participants {
+			builder.WriteString(fmt.Sprintf("\n %d: %s", participant.ID, participant.Endpoint))
+			if participant.BaseDir != "" {
+				builder.WriteString(fmt.Sprintf(" (%s)", participant.BaseDir))
+			}
+			builder.WriteString(fmt.Sprintf("\n  Public key: %s", participant.PublicKey))
+		}
+	}
+
+	return builder.String(), nil
+}
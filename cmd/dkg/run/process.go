@@ -0,0 +1,453 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkgrun
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	pb "github.com/wealdtech/eth2-signer-api/pb/v1"
+	"github.com/wealdtech/ethdo/util"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	distributed "github.com/wealdtech/go-eth2-wallet-distributed"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func (c *command) process(ctx context.Context) error {
+	if c.ceremony.State == util.DKGStateCompleted {
+		c.alreadyCompleted = true
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	if c.ceremony.Local() {
+		return c.processLocal(ctx)
+	}
+
+	return c.processRemote(ctx)
+}
+
+// processRemote runs a ceremony amongst remote signers.  The signers carry
+// out the key generation between themselves, after which each participant
+// is queried to confirm that it holds a valid share of the key.
+func (c *command) processRemote(ctx context.Context) error {
+	if c.ceremony.State == util.DKGStateCreated {
+		if err := c.generateRemote(ctx); err != nil {
+			return err
+		}
+	}
+
+	sharePubKeys := make(map[uint64][]byte, len(c.ceremony.Participants))
+	failed := 0
+	for _, participant := range c.ceremony.Participants {
+		if !participant.Verified {
+			if err := c.verifyRemoteParticipant(ctx, participant); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not verify participant %d (%s): %v\n", participant.ID, participant.Endpoint, err)
+				failed++
+				continue
+			}
+			if err := c.save(); err != nil {
+				return err
+			}
+		}
+		pubKey, err := decodeHex(participant.PublicKey)
+		if err != nil {
+			return errors.Wrapf(err, "invalid public key for participant %d", participant.ID)
+		}
+		sharePubKeys[participant.ID] = pubKey
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d participant(s) could not be verified; run the command again to retry", failed)
+	}
+
+	return c.complete(nil, sharePubKeys)
+}
+
+// generateRemote requests that a remote signer generate the distributed account.
+func (c *command) generateRemote(ctx context.Context) error {
+	endpoint := c.remote
+	if endpoint == "" {
+		endpoint = c.ceremony.Participants[0].Endpoint
+	}
+	accountManager, closer, err := c.accountManagerFor(ctx, endpoint)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to remote signer")
+	}
+	defer closer()
+
+	resp, err := accountManager.Generate(ctx, &pb.GenerateRequest{
+		Account:          c.ceremony.Account,
+		Passphrase:       []byte(c.passphrase),
+		Participants:     uint32(len(c.ceremony.Participants)),
+		SigningThreshold: c.ceremony.SigningThreshold,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to generate account")
+	}
+	if err := util.SignerResponseError(resp.GetState(), resp.GetMessage()); err != nil {
+		return err
+	}
+
+	// Record the key before checking anything else, as the account now exists
+	// and generation cannot be repeated.
+	c.ceremony.CompositePublicKey = fmt.Sprintf("%#x", resp.GetPublicKey())
+	c.ceremony.State = util.DKGStateGenerated
+	if err := c.save(); err != nil {
+		return err
+	}
+
+	return checkEndpoints(c.ceremony.EndpointMap(), resp.GetParticipants())
+}
+
+// verifyRemoteParticipant confirms that a remote participant holds a share
+// of the distributed account that matches the ceremony parameters.
+func (c *command) verifyRemoteParticipant(ctx context.Context, participant *util.DKGParticipant) error {
+	lister, closer, err := c.listerFor(ctx, participant.Endpoint)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect")
+	}
+	defer closer()
+
+	resp, err := lister.ListAccounts(ctx, &pb.ListAccountsRequest{
+		Paths: []string{c.ceremony.Account},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list accounts")
+	}
+	if err := util.SignerResponseError(resp.GetState(), ""); err != nil {
+		return err
+	}
+
+	var account *pb.DistributedAccount
+	for _, distributedAccount := range resp.GetDistributedAccounts() {
+		if distributedAccount.GetName() == c.ceremony.Account {
+			account = distributedAccount
+			break
+		}
+	}
+	if account == nil {
+		return errors.New("participant does not hold the account")
+	}
+	if fmt.Sprintf("%#x", account.GetCompositePublicKey()) != c.ceremony.CompositePublicKey {
+		return errors.New("composite public key does not match")
+	}
+	if account.GetSigningThreshold() != c.ceremony.SigningThreshold {
+		return errors.New("signing threshold does not match")
+	}
+	if err := checkEndpoints(c.ceremony.EndpointMap(), account.GetParticipants()); err != nil {
+		return err
+	}
+
+	participant.PublicKey = fmt.Sprintf("%#x", account.GetPublicKey())
+	participant.Verified = true
+
+	return nil
+}
+
+// processLocal runs a ceremony amongst local participants, storing each
+// participant's share in a distributed wallet in its own base directory.
+func (c *command) processLocal(ctx context.Context) error {
+	if c.ceremony.State == util.DKGStateCreated {
+		if err := c.generateLocal(); err != nil {
+			return err
+		}
+	}
+
+	walletName, accountName, err := e2wallet.WalletAndAccountNames(c.ceremony.Account)
+	if err != nil {
+		return errors.Wrap(err, "invalid account")
+	}
+	verificationVector := make([][]byte, len(c.ceremony.VerificationVector))
+	for i := range c.ceremony.VerificationVector {
+		verificationVector[i], err = decodeHex(c.ceremony.VerificationVector[i])
+		if err != nil {
+			return errors.Wrap(err, "invalid verification vector")
+		}
+	}
+
+	sharePubKeys := make(map[uint64][]byte, len(c.ceremony.Participants))
+	for _, participant := range c.ceremony.Participants {
+		wallet, err := c.localWallet(ctx, participant, walletName)
+		if err != nil {
+			return errors.Wrapf(err, "failed to open wallet for participant %d", participant.ID)
+		}
+		if !participant.Stored {
+			if err := c.storeLocalShare(ctx, wallet, accountName, participant, verificationVector); err != nil {
+				return errors.Wrapf(err, "failed to store share for participant %d", participant.ID)
+			}
+			if err := c.save(); err != nil {
+				return err
+			}
+		}
+		if !participant.Verified {
+			if err := c.verifyLocalParticipant(ctx, wallet, accountName, participant); err != nil {
+				return errors.Wrapf(err, "failed to verify participant %d", participant.ID)
+			}
+			if err := c.save(); err != nil {
+				return err
+			}
+		}
+		sharePubKeys[participant.ID], err = decodeHex(participant.PublicKey)
+		if err != nil {
+			return errors.Wrapf(err, "invalid public key for participant %d", participant.ID)
+		}
+	}
+
+	return c.complete(verificationVector, sharePubKeys)
+}
+
+// generateLocal generates the key shares for local participants.  The shares
+// are held encrypted in the ceremony file until they have been stored.
+// As all contributions are generated in this process ethdo acts as a trusted
+// dealer that sees the full key, so this is only suitable for testing.
+func (c *command) generateLocal() error {
+	if !c.quiet {
+		fmt.Fprintln(os.Stderr, "Warning: local participants use ethdo as a trusted dealer that sees the full key; this is only suitable for testing")
+	}
+	ids := make([]uint64, len(c.ceremony.Participants))
+	for i, participant := range c.ceremony.Participants {
+		ids[i] = participant.ID
+	}
+	shares, verificationVector, err := generateShares(ids, c.ceremony.SigningThreshold)
+	if err != nil {
+		return err
+	}
+
+	encryptor := keystorev4.New()
+	for _, participant := range c.ceremony.Participants {
+		participant.Share, err = encryptor.Encrypt(shares[participant.ID].Serialize(), c.passphrase)
+		if err != nil {
+			return errors.Wrap(err, "failed to encrypt share")
+		}
+	}
+	c.ceremony.VerificationVector = make([]string, len(verificationVector))
+	for i := range verificationVector {
+		c.ceremony.VerificationVector[i] = fmt.Sprintf("%#x", verificationVector[i].Serialize())
+	}
+	c.ceremony.CompositePublicKey = c.ceremony.VerificationVector[0]
+	c.ceremony.State = util.DKGStateGenerated
+
+	return c.save()
+}
+
+// localWallet opens the distributed wallet for a local participant,
+// creating it if it does not exist.
+func (c *command) localWallet(ctx context.Context, participant *util.DKGParticipant, walletName string) (e2wtypes.Wallet, error) {
+	store, err := util.NewStore("filesystem", participant.BaseDir, c.storePassphrase)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := store.RetrieveWallet(walletName); err != nil {
+		return distributed.CreateWallet(ctx, walletName, store, keystorev4.New())
+	}
+
+	wallet, err := distributed.OpenWallet(ctx, walletName, store, keystorev4.New())
+	if err != nil {
+		return nil, err
+	}
+	if wallet.Type() != "distributed" {
+		return nil, fmt.Errorf("wallet %s is not a distributed wallet", walletName)
+	}
+
+	return wallet, nil
+}
+
+// storeLocalShare stores a local participant's share in its wallet.
+func (c *command) storeLocalShare(ctx context.Context,
+	wallet e2wtypes.Wallet,
+	accountName string,
+	participant *util.DKGParticipant,
+	verificationVector [][]byte,
+) error {
+	if participant.Share == nil {
+		return errors.New("share is no longer available")
+	}
+	key, err := keystorev4.New().Decrypt(participant.Share, c.passphrase)
+	if err != nil {
+		return errors.New("failed to decrypt share; incorrect passphrase?")
+	}
+	var share bls.SecretKey
+	if err := share.Deserialize(key); err != nil {
+		return errors.Wrap(err, "invalid share")
+	}
+	pubKey := share.GetPublicKey().Serialize()
+
+	// The share may have been stored by an earlier run that was interrupted
+	// before it could record the fact.
+	if account, err := wallet.(e2wtypes.WalletAccountByNameProvider).AccountByName(ctx, accountName); err == nil {
+		if !bytes.Equal(account.PublicKey().Marshal(), pubKey) {
+			return fmt.Errorf("a different account %s already exists", accountName)
+		}
+	} else {
+		importer, isImporter := wallet.(e2wtypes.WalletDistributedAccountImporter)
+		if !isImporter {
+			return errors.New("wallet does not support importing distributed accounts")
+		}
+		if err := wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil); err != nil {
+			return errors.Wrap(err, "failed to unlock wallet")
+		}
+		defer func() {
+			if err := wallet.(e2wtypes.WalletLocker).Lock(ctx); err != nil {
+				util.Log.Trace().Err(err).Msg("Failed to lock wallet")
+			}
+		}()
+		if _, err := importer.ImportDistributedAccount(ctx,
+			accountName,
+			key,
+			c.ceremony.SigningThreshold,
+			verificationVector,
+			c.ceremony.EndpointMap(),
+			[]byte(c.passphrase),
+		); err != nil {
+			return errors.Wrap(err, "failed to import account")
+		}
+	}
+
+	participant.Share = nil
+	participant.Stored = true
+
+	return nil
+}
+
+// verifyLocalParticipant confirms that a local participant holds a share of
+// the distributed account that matches the ceremony parameters.
+func (c *command) verifyLocalParticipant(ctx context.Context,
+	wallet e2wtypes.Wallet,
+	accountName string,
+	participant *util.DKGParticipant,
+) error {
+	account, err := wallet.(e2wtypes.WalletAccountByNameProvider).AccountByName(ctx, accountName)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain account")
+	}
+	distributedAccount, isDistributed := account.(e2wtypes.DistributedAccount)
+	if !isDistributed {
+		return errors.New("account is not a distributed account")
+	}
+	if fmt.Sprintf("%#x", distributedAccount.CompositePublicKey().Marshal()) != c.ceremony.CompositePublicKey {
+		return errors.New("composite public key does not match")
+	}
+	if distributedAccount.SigningThreshold() != c.ceremony.SigningThreshold {
+		return errors.New("signing threshold does not match")
+	}
+	participants := distributedAccount.Participants()
+	for id, endpoint := range c.ceremony.EndpointMap() {
+		if participants[id] != endpoint {
+			return errors.New("participants do not match")
+		}
+	}
+	if len(participants) != len(c.ceremony.Participants) {
+		return errors.New("participants do not match")
+	}
+
+	// Confirm that the stored key can be decrypted and matches the public key.
+	locker, isLocker := account.(e2wtypes.AccountLocker)
+	if !isLocker {
+		return errors.New("account does not support unlocking")
+	}
+	if err := locker.Unlock(ctx, []byte(c.passphrase)); err != nil {
+		return errors.New("failed to unlock account; incorrect passphrase?")
+	}
+	defer func() {
+		if err := locker.Lock(ctx); err != nil {
+			util.Log.Trace().Err(err).Msg("Failed to lock account")
+		}
+	}()
+	privateKeyProvider, isPrivateKeyProvider := account.(e2wtypes.AccountPrivateKeyProvider)
+	if !isPrivateKeyProvider {
+		return errors.New("account does not provide its private key")
+	}
+	privateKey, err := privateKeyProvider.PrivateKey(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain private key")
+	}
+	if !bytes.Equal(privateKey.PublicKey().Marshal(), account.PublicKey().Marshal()) {
+		return errors.New("private key does not match public key")
+	}
+
+	participant.PublicKey = fmt.Sprintf("%#x", account.PublicKey().Marshal())
+	participant.Verified = true
+
+	return nil
+}
+
+// complete verifies the shares against the composite public key and marks
+// the ceremony as completed.
+func (c *command) complete(verificationVector [][]byte, sharePubKeys map[uint64][]byte) error {
+	compositePubKey, err := decodeHex(c.ceremony.CompositePublicKey)
+	if err != nil {
+		return errors.Wrap(err, "invalid composite public key")
+	}
+	if err := verifyShares(compositePubKey, c.ceremony.SigningThreshold, verificationVector, sharePubKeys); err != nil {
+		return errors.Wrap(err, "failed to verify composite public key")
+	}
+
+	c.ceremony.State = util.DKGStateCompleted
+
+	return c.save()
+}
+
+func (c *command) save() error {
+	if err := util.SaveDKGCeremony(c.ceremonyFile, c.ceremony); err != nil {
+		return errors.Wrap(err, "failed to save ceremony")
+	}
+
+	return nil
+}
+
+// checkEndpoints checks that the endpoints returned by a remote signer match
+// the ceremony participants.
+func checkEndpoints(expected map[uint64]string, endpoints []*pb.Endpoint) error {
+	actual := make(map[uint64]string, len(endpoints))
+	for _, endpoint := range endpoints {
+		actual[endpoint.GetId()] = fmt.Sprintf("%s:%d", endpoint.GetName(), endpoint.GetPort())
+	}
+	matches := len(actual) == len(expected)
+	for id, endpoint := range expected {
+		if actual[id] != endpoint {
+			matches = false
+		}
+	}
+	if matches {
+		return nil
+	}
+
+	ids := make([]uint64, 0, len(actual))
+	for id := range actual {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i int, j int) bool {
+		return ids[i] < ids[j]
+	})
+	participants := make([]string, len(ids))
+	for i, id := range ids {
+		participants[i] = fmt.Sprintf("%d:%s", id, actual[id])
+	}
+
+	return fmt.Errorf("remote signer participants %s do not match ceremony participants", strings.Join(participants, ","))
+}
+
+func decodeHex(input string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(input, "0x"))
+}
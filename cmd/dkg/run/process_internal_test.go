@@ -0,0 +1,333 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkgrun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	pb "github.com/wealdtech/eth2-signer-api/pb/v1"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	"google.golang.org/grpc"
+)
+
+func TestGenerateShares(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	ids := []uint64{1, 2, 3, 4, 5}
+	shares, verificationVector, err := generateShares(ids, 3)
+	require.NoError(t, err)
+	require.Len(t, shares, 5)
+	require.Len(t, verificationVector, 3)
+
+	// Any three shares recover the same secret key, which matches the composite public key.
+	for _, combination := range util.Combinations(ids, 3) {
+		secretKeys := make([]bls.SecretKey, len(combination))
+		blsIDs := make([]bls.ID, len(combination))
+		for i, id := range combination {
+			secretKeys[i] = *shares[id]
+			blsIDs[i] = *util.BLSID(id)
+		}
+		var secretKey bls.SecretKey
+		require.NoError(t, secretKey.Recover(secretKeys, blsIDs))
+		require.True(t, secretKey.GetPublicKey().IsEqual(&verificationVector[0]))
+	}
+
+	vv := make([][]byte, len(verificationVector))
+	for i := range verificationVector {
+		vv[i] = verificationVector[i].Serialize()
+	}
+	sharePubKeys := make(map[uint64][]byte, len(shares))
+	for id, share := range shares {
+		sharePubKeys[id] = share.GetPublicKey().Serialize()
+	}
+	require.NoError(t, verifyShares(vv[0], 3, vv, sharePubKeys))
+	require.NoError(t, verifyShares(vv[0], 3, nil, sharePubKeys))
+
+	// A share from a different key is detected, with or without a verification vector.
+	var other bls.SecretKey
+	other.SetByCSPRNG()
+	sharePubKeys[4] = other.GetPublicKey().Serialize()
+	require.EqualError(t, verifyShares(vv[0], 3, vv, sharePubKeys), "share for participant 4 does not match verification vector")
+	require.EqualError(t, verifyShares(vv[0], 3, nil, sharePubKeys), "participants [1 2 4] do not recover the composite public key")
+}
+
+func TestParseParticipants(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []string
+		baseDirs []string
+		err      string
+	}{
+		{
+			name:  "Remote",
+			input: []string{"1:signer-1:9091", "2:signer-2:9091", "3:signer-3:9091"},
+		},
+		{
+			name:     "Local",
+			input:    []string{"1:signer-1:9091", "2:signer-2:9091"},
+			baseDirs: []string{"1:/tmp/1", "2:/tmp/2"},
+		},
+		{
+			name:  "TooFew",
+			input: []string{"1:signer-1:9091"},
+			err:   "at least two participants are required",
+		},
+		{
+			name:  "Malformed",
+			input: []string{"1:signer-1:9091", "signer-2:9091"},
+			err:   `invalid participant "signer-2:9091"; must be id:host:port`,
+		},
+		{
+			name:  "ZeroID",
+			input: []string{"1:signer-1:9091", "0:signer-2:9091"},
+			err:   `invalid participant ID "0"`,
+		},
+		{
+			name:  "DuplicateID",
+			input: []string{"1:signer-1:9091", "1:signer-2:9091"},
+			err:   "duplicate participant ID 1",
+		},
+		{
+			name:  "DuplicateEndpoint",
+			input: []string{"1:signer-1:9091", "2:signer-1:9091"},
+			err:   "duplicate participant endpoint signer-1:9091",
+		},
+		{
+			name:  "BadPort",
+			input: []string{"1:signer-1:9091", "2:signer-2:x"},
+			err:   "invalid port for participant 2",
+		},
+		{
+			name:     "PartiallyLocal",
+			input:    []string{"1:signer-1:9091", "2:signer-2:9091"},
+			baseDirs: []string{"1:/tmp/1"},
+			err:      "no base directory supplied for participant 2; all participants must be local or all remote",
+		},
+		{
+			name:     "UnknownBaseDir",
+			input:    []string{"1:signer-1:9091", "2:signer-2:9091"},
+			baseDirs: []string{"1:/tmp/1", "3:/tmp/3"},
+			err:      "base directory supplied for unknown participant 3",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			participants, err := parseParticipants(test.input, test.baseDirs)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, participants, len(test.input))
+		})
+	}
+}
+
+func TestProcessLocal(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	participants, err := parseParticipants(
+		[]string{"1:signer-1:9091", "2:signer-2:9091", "3:signer-3:9091"},
+		[]string{fmt.Sprintf("1:%s", filepath.Join(dir, "1")), fmt.Sprintf("2:%s", filepath.Join(dir, "2")), fmt.Sprintf("3:%s", filepath.Join(dir, "3"))},
+	)
+	require.NoError(t, err)
+	c := &command{
+		timeout:      time.Minute,
+		ceremonyFile: filepath.Join(dir, "ceremony.json"),
+		passphrase:   "secret",
+		ceremony: &util.DKGCeremony{
+			Account:          "Validators/1",
+			SigningThreshold: 2,
+			Participants:     participants,
+			State:            util.DKGStateCreated,
+		},
+	}
+
+	// Generate the shares but do not store them, as if interrupted.
+	require.NoError(t, c.generateLocal())
+	ceremony, err := util.LoadDKGCeremony(c.ceremonyFile)
+	require.NoError(t, err)
+	require.Equal(t, util.DKGStateGenerated, ceremony.State)
+	for _, participant := range ceremony.Participants {
+		require.NotNil(t, participant.Share)
+		require.False(t, participant.Stored)
+	}
+
+	// Resuming with the wrong passphrase fails without losing any state.
+	c.ceremony = ceremony
+	c.passphrase = "wrong"
+	require.ErrorContains(t, c.process(ctx), "failed to decrypt share; incorrect passphrase?")
+	ceremony, err = util.LoadDKGCeremony(c.ceremonyFile)
+	require.NoError(t, err)
+	require.Equal(t, util.DKGStateGenerated, ceremony.State)
+
+	// Resuming with the correct passphrase completes the ceremony.
+	c.ceremony = ceremony
+	c.passphrase = "secret"
+	require.NoError(t, c.process(ctx))
+	require.False(t, c.alreadyCompleted)
+	ceremony, err = util.LoadDKGCeremony(c.ceremonyFile)
+	require.NoError(t, err)
+	require.Equal(t, util.DKGStateCompleted, ceremony.State)
+	for _, participant := range ceremony.Participants {
+		require.Nil(t, participant.Share)
+		require.True(t, participant.Stored)
+		require.True(t, participant.Verified)
+		require.NotEmpty(t, participant.PublicKey)
+	}
+
+	// Running again does nothing.
+	c.ceremony = ceremony
+	require.NoError(t, c.process(ctx))
+	require.True(t, c.alreadyCompleted)
+}
+
+type mockSigner struct {
+	shares             map[uint64]*bls.SecretKey
+	compositePublicKey []byte
+	endpoints          []*pb.Endpoint
+	generated          int
+}
+
+func (m *mockSigner) Unlock(_ context.Context, _ *pb.UnlockAccountRequest, _ ...grpc.CallOption) (*pb.UnlockAccountResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockSigner) Lock(_ context.Context, _ *pb.LockAccountRequest, _ ...grpc.CallOption) (*pb.LockAccountResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockSigner) Generate(_ context.Context, in *pb.GenerateRequest, _ ...grpc.CallOption) (*pb.GenerateResponse, error) {
+	m.generated++
+	ids := make([]uint64, len(m.endpoints))
+	for i := range m.endpoints {
+		ids[i] = m.endpoints[i].GetId()
+	}
+	shares, verificationVector, err := generateShares(ids, in.GetSigningThreshold())
+	if err != nil {
+		return nil, err
+	}
+	m.shares = shares
+	m.compositePublicKey = verificationVector[0].Serialize()
+
+	return &pb.GenerateResponse{
+		State:        pb.ResponseState_SUCCEEDED,
+		PublicKey:    m.compositePublicKey,
+		Participants: m.endpoints,
+	}, nil
+}
+
+// mockParticipant provides a lister for a single participant.
+type mockParticipant struct {
+	signer *mockSigner
+	id     uint64
+}
+
+func (m *mockParticipant) ListAccounts(_ context.Context, in *pb.ListAccountsRequest, _ ...grpc.CallOption) (*pb.ListAccountsResponse, error) {
+	return &pb.ListAccountsResponse{
+		State: pb.ResponseState_SUCCEEDED,
+		DistributedAccounts: []*pb.DistributedAccount{
+			{
+				Name:               in.GetPaths()[0],
+				PublicKey:          m.signer.shares[m.id].GetPublicKey().Serialize(),
+				CompositePublicKey: m.signer.compositePublicKey,
+				SigningThreshold:   2,
+				Participants:       m.signer.endpoints,
+			},
+		},
+	}, nil
+}
+
+func TestProcessRemote(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	signer := &mockSigner{
+		endpoints: []*pb.Endpoint{
+			{Id: 1, Name: "signer-1", Port: 9091},
+			{Id: 2, Name: "signer-2", Port: 9091},
+			{Id: 3, Name: "signer-3", Port: 9091},
+		},
+	}
+	unreachable := map[string]bool{"signer-3:9091": true}
+
+	participants, err := parseParticipants([]string{"1:signer-1:9091", "2:signer-2:9091", "3:signer-3:9091"}, nil)
+	require.NoError(t, err)
+	c := &command{
+		timeout:      time.Minute,
+		ceremonyFile: filepath.Join(dir, "ceremony.json"),
+		passphrase:   "secret",
+		ceremony: &util.DKGCeremony{
+			Account:          "Validators/1",
+			SigningThreshold: 2,
+			Participants:     participants,
+			State:            util.DKGStateCreated,
+		},
+		accountManagerFor: func(_ context.Context, _ string) (pb.AccountManagerClient, func(), error) {
+			return signer, func() {}, nil
+		},
+		listerFor: func(_ context.Context, endpoint string) (pb.ListerClient, func(), error) {
+			if unreachable[endpoint] {
+				return nil, nil, errors.New("connection refused")
+			}
+			for _, participant := range participants {
+				if participant.Endpoint == endpoint {
+					return &mockParticipant{signer: signer, id: participant.ID}, func() {}, nil
+				}
+			}
+			return nil, nil, errors.New("unknown endpoint")
+		},
+	}
+
+	// One participant is unreachable, so the ceremony cannot complete.
+	require.EqualError(t, c.process(ctx), "1 participant(s) could not be verified; run the command again to retry")
+	ceremony, err := util.LoadDKGCeremony(c.ceremonyFile)
+	require.NoError(t, err)
+	require.Equal(t, util.DKGStateGenerated, ceremony.State)
+	require.True(t, ceremony.Participants[0].Verified)
+	require.True(t, ceremony.Participants[1].Verified)
+	require.False(t, ceremony.Participants[2].Verified)
+
+	// Once the participant is reachable the ceremony completes without generating again.
+	delete(unreachable, "signer-3:9091")
+	c.ceremony = ceremony
+	require.NoError(t, c.process(ctx))
+	require.Equal(t, 1, signer.generated)
+	require.Equal(t, util.DKGStateCompleted, c.ceremony.State)
+	require.Equal(t, fmt.Sprintf("%#x", signer.compositePublicKey), c.ceremony.CompositePublicKey)
+}
+
+func TestCheckEndpoints(t *testing.T) {
+	expected := map[uint64]string{1: "signer-1:9091", 2: "signer-2:9091"}
+	require.NoError(t, checkEndpoints(expected, []*pb.Endpoint{
+		{Id: 2, Name: "signer-2", Port: 9091},
+		{Id: 1, Name: "signer-1", Port: 9091},
+	}))
+	require.EqualError(t, checkEndpoints(expected, []*pb.Endpoint{
+		{Id: 1, Name: "signer-1", Port: 9091},
+		{Id: 2, Name: "signer-4", Port: 9091},
+	}), "remote signer participants 1:signer-1:9091,2:signer-4:9091 do not match ceremony participants")
+}
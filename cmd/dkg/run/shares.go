@@ -0,0 +1,152 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkgrun
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// generateShares carries out a distributed key generation amongst the given
+// participants.  Each participant contributes a random polynomial of degree
+// threshold-1 and sends every other participant its evaluation; the final
+// share held by each participant is the sum of the evaluations it received,
+// and the composite public key is the sum of each contribution's constant
+// commitment.  Every evaluation is checked against the commitments of the
+// participant that created it before being accepted.
+//
+// All of the contributions are generated by the caller, which therefore acts
+// as a trusted dealer with knowledge of the full key.
+func generateShares(ids []uint64, threshold uint32) (map[uint64]*bls.SecretKey, []bls.PublicKey, error) {
+	shares := make(map[uint64]*bls.SecretKey, len(ids))
+	for _, id := range ids {
+		shares[id] = &bls.SecretKey{}
+	}
+	verificationVector := make([]bls.PublicKey, threshold)
+
+	for i := range ids {
+		contribution := make([]bls.SecretKey, threshold)
+		for j := range contribution {
+			contribution[j].SetByCSPRNG()
+		}
+		commitments := bls.GetMasterPublicKey(contribution)
+
+		for _, id := range ids {
+			var evaluation bls.SecretKey
+			if err := evaluation.Set(contribution, util.BLSID(id)); err != nil {
+				return nil, nil, errors.Wrap(err, "failed to evaluate contribution")
+			}
+			var expected bls.PublicKey
+			if err := expected.Set(commitments, util.BLSID(id)); err != nil {
+				return nil, nil, errors.Wrap(err, "failed to evaluate commitments")
+			}
+			if !evaluation.GetPublicKey().IsEqual(&expected) {
+				return nil, nil, fmt.Errorf("contribution from participant %d does not match its commitments", ids[i])
+			}
+			if i == 0 {
+				*shares[id] = evaluation
+			} else {
+				shares[id].Add(&evaluation)
+			}
+		}
+
+		for j := range commitments {
+			if i == 0 {
+				verificationVector[j] = commitments[j]
+			} else {
+				verificationVector[j].Add(&commitments[j])
+			}
+		}
+	}
+
+	return shares, verificationVector, nil
+}
+
+// verifyShares verifies that the public keys of the participants' shares are
+// consistent with the composite public key: every combination of signing
+// threshold participants must recover the composite public key.  If a
+// verification vector is supplied each share is also checked against it.
+func verifyShares(compositePubKey []byte,
+	threshold uint32,
+	verificationVector [][]byte,
+	sharePubKeys map[uint64][]byte,
+) error {
+	ids := make([]uint64, 0, len(sharePubKeys))
+	for id := range sharePubKeys {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i int, j int) bool {
+		return ids[i] < ids[j]
+	})
+	if uint32(len(ids)) < threshold {
+		return errors.New("not enough shares to verify composite public key")
+	}
+
+	pubKeys := make(map[uint64]bls.PublicKey, len(ids))
+	for _, id := range ids {
+		var pubKey bls.PublicKey
+		if err := pubKey.Deserialize(sharePubKeys[id]); err != nil {
+			return errors.Wrapf(err, "invalid public key for participant %d", id)
+		}
+		pubKeys[id] = pubKey
+	}
+
+	if len(verificationVector) > 0 {
+		if uint32(len(verificationVector)) != threshold {
+			return errors.New("verification vector does not match signing threshold")
+		}
+		vv := make([]bls.PublicKey, len(verificationVector))
+		for i := range verificationVector {
+			if err := vv[i].Deserialize(verificationVector[i]); err != nil {
+				return errors.Wrap(err, "invalid verification vector")
+			}
+		}
+		if !bytes.Equal(vv[0].Serialize(), compositePubKey) {
+			return errors.New("verification vector does not match composite public key")
+		}
+		for _, id := range ids {
+			var expected bls.PublicKey
+			if err := expected.Set(vv, util.BLSID(id)); err != nil {
+				return errors.Wrap(err, "failed to evaluate verification vector")
+			}
+			pubKey := pubKeys[id]
+			if !expected.IsEqual(&pubKey) {
+				return fmt.Errorf("share for participant %d does not match verification vector", id)
+			}
+		}
+	}
+
+	for _, combination := range util.Combinations(ids, int(threshold)) {
+		combinationPubKeys := make([]bls.PublicKey, len(combination))
+		combinationIDs := make([]bls.ID, len(combination))
+		for i, id := range combination {
+			combinationPubKeys[i] = pubKeys[id]
+			combinationIDs[i] = *util.BLSID(id)
+		}
+		var recovered bls.PublicKey
+		if err := recovered.Recover(combinationPubKeys, combinationIDs); err != nil {
+			return errors.Wrap(err, "failed to recover composite public key")
+		}
+		if !bytes.Equal(recovered.Serialize(), compositePubKey) {
+			return fmt.Errorf("participants %v do not recover the composite public key", combination)
+		}
+	}
+
+	return nil
+}
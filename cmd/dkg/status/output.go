@@ -0,0 +1,119 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkgstatus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wealdtech/ethdo/util"
+)
+
+type participantJSON struct {
+	ID        uint64 `json:"id"`
+	Endpoint  string `json:"endpoint"`
+	BaseDir   string `json:"base_dir,omitempty"`
+	Stored    bool   `json:"stored"`
+	Verified  bool   `json:"verified"`
+	PublicKey string `json:"public_key,omitempty"`
+}
+
+type statusJSON struct {
+	Account            string             `json:"account"`
+	State              string             `json:"state"`
+	SigningThreshold   uint32             `json:"signing_threshold"`
+	CompositePublicKey string             `json:"composite_public_key,omitempty"`
+	Participants       []*participantJSON `json:"participants"`
+}
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON()
+	}
+
+	return c.outputTxt(), nil
+}
+
+func (c *command) outputJSON() (string, error) {
+	status := &statusJSON{
+		Account:            c.ceremony.Account,
+		State:              c.ceremony.State,
+		SigningThreshold:   c.ceremony.SigningThreshold,
+		CompositePublicKey: c.ceremony.CompositePublicKey,
+		Participants:       make([]*participantJSON, len(c.ceremony.Participants)),
+	}
+	for i, participant := range c.ceremony.Participants {
+		status.Participants[i] = &participantJSON{
+			ID:        participant.ID,
+			Endpoint:  participant.Endpoint,
+			BaseDir:   participant.BaseDir,
+			Stored:    c.stored(participant),
+			Verified:  participant.Verified,
+			PublicKey: participant.PublicKey,
+		}
+	}
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputTxt() string {
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("Account: %s\n", c.ceremony.Account))
+	builder.WriteString(fmt.Sprintf("State: %s\n", c.ceremony.State))
+	builder.WriteString(fmt.Sprintf("Signing threshold: %d/%d", c.ceremony.SigningThreshold, len(c.ceremony.Participants)))
+	if c.ceremony.CompositePublicKey != "" {
+		builder.WriteString(fmt.Sprintf("\nComposite public key: %s", c.ceremony.CompositePublicKey))
+	}
+	builder.WriteString("\nParticipants:")
+	for _, participant := range c.ceremony.Participants {
+		builder.WriteString(fmt.Sprintf("\n %d: %s", participant.ID, participant.Endpoint))
+		if participant.BaseDir != "" {
+			builder.WriteString(fmt.Sprintf(" (%s)", participant.BaseDir))
+		}
+		switch {
+		case participant.Verified:
+			builder.WriteString(" verified")
+		case c.stored(participant):
+			builder.WriteString(" stored")
+		default:
+			builder.WriteString(" pending")
+		}
+		if c.verbose && participant.PublicKey != "" {
+			builder.WriteString(fmt.Sprintf("\n  Public key: %s", participant.PublicKey))
+		}
+	}
+
+	return builder.String()
+}
+
+// stored returns true if the participant holds its share.  Remote
+// participants hold their shares as soon as the key is generated.
+func (c *command) stored(participant *util.DKGParticipant) bool {
+	if participant.BaseDir == "" {
+		return c.ceremony.State != util.DKGStateCreated
+	}
+
+	return participant.Stored
+}
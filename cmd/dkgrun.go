@@ -0,0 +1,70 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	dkgrun "github.com/wealdtech/ethdo/cmd/dkg/run"
+)
+
+var dkgRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run or resume a distributed key generation ceremony",
+	Long: `Run or resume a distributed key generation ceremony.  For example:
+
+    ethdo dkg run --ceremony=ceremony.json --account="Validators/1" --signing-threshold=2 --participants=1:signer-1:9091,2:signer-2:9091,3:signer-3:9091 --passphrase="secret" --client-cert=client.crt --client-key=client.key
+
+By default the participants are remote signers, which generate the key between themselves; each participant is then queried to confirm that it holds a valid share.  If --participant-base-dirs is supplied the participants are local, and the key is generated by ethdo and each share stored in a distributed wallet in the participant's base directory.  In this mode ethdo is a trusted dealer that sees the full key, so it is only suitable for testing.
+
+The state of the ceremony is kept in the ceremony file, so if the ceremony is interrupted it can be resumed by running the command again with the same ceremony file and passphrase.
+
+In quiet mode this will return 0 if the ceremony has completed, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := dkgrun.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	dkgCmd.AddCommand(dkgRunCmd)
+	dkgFlags(dkgRunCmd)
+	dkgRunCmd.Flags().String("ceremony", "", "File holding the state of the ceremony")
+	dkgRunCmd.Flags().Uint32("signing-threshold", 0, "Number of participants required to sign")
+	dkgRunCmd.Flags().StringSlice("participants", nil, "Participants in the ceremony, in the format id:host:port")
+	dkgRunCmd.Flags().StringSlice("participant-base-dirs", nil, "Base directories for local participants, in the format id:dir (trusted dealer mode, for testing only)")
+}
+
+func dkgRunBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("ceremony", cmd.Flags().Lookup("ceremony")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("signing-threshold", cmd.Flags().Lookup("signing-threshold")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("participants", cmd.Flags().Lookup("participants")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("participant-base-dirs", cmd.Flags().Lookup("participant-base-dirs")); err != nil {
+		panic(err)
+	}
+}
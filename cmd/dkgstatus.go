@@ -0,0 +1,54 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	dkgstatus "github.com/wealdtech/ethdo/cmd/dkg/status"
+)
+
+var dkgStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the status of a distributed key generation ceremony",
+	Long: `Show the status of a distributed key generation ceremony.  For example:
+
+    ethdo dkg status --ceremony=ceremony.json
+
+In quiet mode this will return 0 if the ceremony file can be read, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := dkgstatus.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	dkgCmd.AddCommand(dkgStatusCmd)
+	dkgFlags(dkgStatusCmd)
+	dkgStatusCmd.Flags().String("ceremony", "", "File holding the state of the ceremony")
+}
+
+func dkgStatusBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("ceremony", cmd.Flags().Lookup("ceremony")); err != nil {
+		panic(err)
+	}
+}
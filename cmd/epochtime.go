@@ -0,0 +1,72 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	slottime "github.com/wealdtech/ethdo/cmd/slot/time"
+)
+
+var epochTimeCmd = &cobra.Command{
+	Use:   "time",
+	Short: "Convert between epochs, slots and times",
+	Long: `Convert between epochs, slots and times.  Given one of an epoch, slot or timestamp the others are provided, along with the time until (or since) the given point.  For example:
+
+    ethdo epoch time --epoch=12345
+
+    ethdo epoch time --timestamp=now --timezone=UTC
+
+This provides the same information as "slot time".
+
+In quiet mode this will return 0.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := slottime.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	epochCmd.AddCommand(epochTimeCmd)
+	epochTimeCmd.Flags().String("epoch", "", "the epoch to convert (can be 'current', 'last' or a number)")
+	epochTimeCmd.Flags().String("slot", "", "the slot to convert, in place of --epoch")
+	epochTimeCmd.Flags().String("timestamp", "", "the timestamp to convert, in place of --epoch")
+	epochTimeCmd.Flags().String("timezone", "", "the timezone in which to show times, for example UTC or Europe/London (defaults to the local timezone)")
+}
+
+func epochTimeBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("epoch", cmd.Flags().Lookup("epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("slot", cmd.Flags().Lookup("slot")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("timestamp", cmd.Flags().Lookup("timestamp")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("timezone", cmd.Flags().Lookup("timezone")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagerfeerecipientdelete
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	builder := strings.Builder{}
+	if c.verbose {
+		for _, pubKey := range c.deleted {
+			builder.WriteString(fmt.Sprintf("%#x\n", pubKey))
+		}
+	}
+	builder.WriteString(fmt.Sprintf("Fee recipient removed for %d validators", len(c.deleted)))
+
+	return builder.String(), nil
+}
@@ -0,0 +1,50 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagerfeerecipientget
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	pubKeys, err := util.KeymanagerPubKeys(c.validators, c.csvFile, "fee recipient")
+	if err != nil {
+		return err
+	}
+
+	client, err := util.NewKeymanagerClient(c.keymanager, c.keymanagerToken)
+	if err != nil {
+		return errors.Wrap(err, "failed to create keymanager client")
+	}
+
+	c.results = make([]*feeRecipient, 0, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		address, err := client.FeeRecipient(ctx, pubKey)
+		if err != nil {
+			return errors.Wrapf(err, "failed to obtain fee recipient for %#x", pubKey)
+		}
+		c.results = append(c.results, &feeRecipient{
+			PubKey:       pubKey,
+			FeeRecipient: address,
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagerfeerecipientget
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	pubKey := "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/validator/"+pubKey+"/feerecipient" {
+			http.Error(w, `{"message":"validator not found"}`, http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"pubkey":"` + pubKey + `","ethaddress":"0x8f0844fd51e31ff6bf5babe21dccf7328e19fd9f"}}`))
+	}))
+	defer server.Close()
+
+	c := &command{
+		timeout:         10 * time.Second,
+		keymanager:      server.URL,
+		keymanagerToken: "secret",
+		validators:      []string{pubKey},
+	}
+	require.NoError(t, c.process(context.Background()))
+
+	res, err := c.output(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, pubKey+": 0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F", res)
+
+	c.jsonOutput = true
+	res, err = c.output(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, `[{"pubkey":"`+pubKey+`","fee_recipient":"0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F"}]`, res)
+
+	c.validators = []string{"0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b"}
+	require.EqualError(t, c.process(context.Background()), "failed to obtain fee recipient for 0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b: keymanager returned status 404: validator not found")
+}
@@ -0,0 +1,93 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagerfeerecipientset
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	mappings, err := c.obtainMappings()
+	if err != nil {
+		return err
+	}
+
+	client, err := util.NewKeymanagerClient(c.keymanager, c.keymanagerToken)
+	if err != nil {
+		return errors.Wrap(err, "failed to create keymanager client")
+	}
+
+	c.mappings = make([]*util.FeeRecipientMapping, 0, len(mappings))
+	for _, mapping := range mappings {
+		if err := client.SetFeeRecipient(ctx, mapping.PubKey, *mapping.FeeRecipient); err != nil {
+			return errors.Wrapf(err, "failed to set fee recipient for %#x", mapping.PubKey)
+		}
+		c.mappings = append(c.mappings, mapping)
+	}
+
+	return nil
+}
+
+// obtainMappings obtains the fee recipients to set from either the list of
+// validators or the CSV file.  Entries in the CSV file without a fee
+// recipient use the fee recipient supplied on the command line.
+func (c *command) obtainMappings() ([]*util.FeeRecipientMapping, error) {
+	var defaultFeeRecipient *bellatrix.ExecutionAddress
+	if c.feeRecipient != "" {
+		feeRecipient, err := util.ParseExecutionAddress(c.feeRecipient)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid fee recipient")
+		}
+		defaultFeeRecipient = &feeRecipient
+	}
+
+	if c.csvFile != "" {
+		mappings, err := util.ReadFeeRecipientMappings(c.csvFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, mapping := range mappings {
+			if mapping.FeeRecipient == nil {
+				if defaultFeeRecipient == nil {
+					return nil, fmt.Errorf("no fee recipient for %#x", mapping.PubKey)
+				}
+				mapping.FeeRecipient = defaultFeeRecipient
+			}
+		}
+
+		return mappings, nil
+	}
+
+	mappings := make([]*util.FeeRecipientMapping, len(c.validators))
+	for i := range c.validators {
+		pubKey, err := util.ParsePubKey(c.validators[i])
+		if err != nil {
+			return nil, err
+		}
+		mappings[i] = &util.FeeRecipientMapping{
+			PubKey:       pubKey,
+			FeeRecipient: defaultFeeRecipient,
+		}
+	}
+
+	return mappings, nil
+}
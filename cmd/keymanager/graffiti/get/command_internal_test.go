@@ -0,0 +1,103 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagergraffitiget
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"keymanager":       "http://localhost:5062",
+				"keymanager-token": "secret",
+				"validators":       []string{"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"},
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "KeymanagerMissing",
+			vars: map[string]interface{}{
+				"timeout":          "5s",
+				"keymanager-token": "secret",
+				"validators":       []string{"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"},
+			},
+			err: "keymanager is required",
+		},
+		{
+			name: "KeymanagerTokenMissing",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"keymanager": "http://localhost:5062",
+				"validators": []string{"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"},
+			},
+			err: "keymanager token is required",
+		},
+		{
+			name: "ValidatorsMissing",
+			vars: map[string]interface{}{
+				"timeout":          "5s",
+				"keymanager":       "http://localhost:5062",
+				"keymanager-token": "secret",
+			},
+			err: "validators or csv is required",
+		},
+		{
+			name: "ValidatorsAndCSV",
+			vars: map[string]interface{}{
+				"timeout":          "5s",
+				"keymanager":       "http://localhost:5062",
+				"keymanager-token": "secret",
+				"validators":       []string{"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"},
+				"csv":              "graffiti.csv",
+			},
+			err: "only one of validators and csv is allowed",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":          "5s",
+				"keymanager":       "http://localhost:5062",
+				"keymanager-token": "secret",
+				"validators":       []string{"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagergraffitiset
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	timeout time.Duration
+
+	// Input.
+	keymanager      string
+	keymanagerToken string
+	validators      []string
+	csvFile         string
+	graffiti        string
+
+	// Results.
+	mappings []*util.GraffitiMapping
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.keymanager = viper.GetString("keymanager")
+	if c.keymanager == "" {
+		return nil, errors.New("keymanager is required")
+	}
+	c.keymanagerToken = viper.GetString("keymanager-token")
+	if c.keymanagerToken == "" {
+		return nil, errors.New("keymanager token is required")
+	}
+
+	c.validators = viper.GetStringSlice("validators")
+	c.csvFile = viper.GetString("csv")
+	if len(c.validators) == 0 && c.csvFile == "" {
+		return nil, errors.New("validators or csv is required")
+	}
+	if len(c.validators) > 0 && c.csvFile != "" {
+		return nil, errors.New("only one of validators and csv is allowed")
+	}
+
+	c.graffiti = viper.GetString("graffiti")
+	if len(c.validators) > 0 && c.graffiti == "" {
+		return nil, errors.New("graffiti is required")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,91 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagergraffitiset
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	mappings, err := c.obtainMappings()
+	if err != nil {
+		return err
+	}
+
+	client, err := util.NewKeymanagerClient(c.keymanager, c.keymanagerToken)
+	if err != nil {
+		return errors.Wrap(err, "failed to create keymanager client")
+	}
+
+	c.mappings = make([]*util.GraffitiMapping, 0, len(mappings))
+	for _, mapping := range mappings {
+		if err := client.SetGraffiti(ctx, mapping.PubKey, *mapping.Graffiti); err != nil {
+			return errors.Wrapf(err, "failed to set graffiti for %#x", mapping.PubKey)
+		}
+		c.mappings = append(c.mappings, mapping)
+	}
+
+	return nil
+}
+
+// obtainMappings obtains the graffiti to set from either the list of
+// validators or the CSV file.  Entries in the CSV file without graffiti use
+// the graffiti supplied on the command line.
+func (c *command) obtainMappings() ([]*util.GraffitiMapping, error) {
+	var defaultGraffiti *string
+	if c.graffiti != "" {
+		if err := util.ValidateGraffiti(c.graffiti); err != nil {
+			return nil, err
+		}
+		defaultGraffiti = &c.graffiti
+	}
+
+	if c.csvFile != "" {
+		mappings, err := util.ReadGraffitiMappings(c.csvFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, mapping := range mappings {
+			if mapping.Graffiti == nil {
+				if defaultGraffiti == nil {
+					return nil, fmt.Errorf("no graffiti for %#x", mapping.PubKey)
+				}
+				mapping.Graffiti = defaultGraffiti
+			}
+		}
+
+		return mappings, nil
+	}
+
+	mappings := make([]*util.GraffitiMapping, len(c.validators))
+	for i := range c.validators {
+		pubKey, err := util.ParsePubKey(c.validators[i])
+		if err != nil {
+			return nil, err
+		}
+		mappings[i] = &util.GraffitiMapping{
+			PubKey:   pubKey,
+			Graffiti: defaultGraffiti,
+		}
+	}
+
+	return mappings, nil
+}
@@ -0,0 +1,130 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanagergraffitiset
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	pubKey1 := "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+	pubKey2 := "0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b"
+
+	var mu sync.Mutex
+	stored := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, `{"message":"bad request"}`, http.StatusBadRequest)
+			return
+		}
+		pubKey := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/eth/v1/validator/"), "/graffiti")
+		var req map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		mu.Lock()
+		stored[pubKey] = req["graffiti"]
+		mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	csvFile := filepath.Join(dir, "graffiti.csv")
+	require.NoError(t, os.WriteFile(csvFile, []byte(pubKey1+",\"hello, world\"\n"+pubKey2+"\n"), 0o600))
+
+	tests := []struct {
+		name   string
+		cmd    *command
+		stored map[string]string
+		err    string
+	}{
+		{
+			name: "Validators",
+			cmd: &command{
+				timeout:         10 * time.Second,
+				keymanager:      server.URL,
+				keymanagerToken: "secret",
+				validators:      []string{pubKey1, pubKey2},
+				graffiti:        "ethdo",
+			},
+			stored: map[string]string{
+				pubKey1: "ethdo",
+				pubKey2: "ethdo",
+			},
+		},
+		{
+			name: "CSV",
+			cmd: &command{
+				timeout:         10 * time.Second,
+				keymanager:      server.URL,
+				keymanagerToken: "secret",
+				csvFile:         csvFile,
+				graffiti:        "ethdo",
+			},
+			stored: map[string]string{
+				pubKey1: "hello, world",
+				pubKey2: "ethdo",
+			},
+		},
+		{
+			name: "CSVNoDefault",
+			cmd: &command{
+				timeout:         10 * time.Second,
+				keymanager:      server.URL,
+				keymanagerToken: "secret",
+				csvFile:         csvFile,
+			},
+			err: "no graffiti for " + pubKey2,
+		},
+		{
+			name: "TokenInvalid",
+			cmd: &command{
+				timeout:         10 * time.Second,
+				keymanager:      server.URL,
+				keymanagerToken: "wrong",
+				validators:      []string{pubKey1},
+				graffiti:        "ethdo",
+			},
+			err: "failed to set graffiti for " + pubKey1 + ": keymanager returned status 400: bad request",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mu.Lock()
+			stored = make(map[string]string)
+			mu.Unlock()
+			err := test.cmd.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.stored, stored)
+				res, err := test.cmd.output(context.Background())
+				require.NoError(t, err)
+				require.Equal(t, "Graffiti set for 2 validators", res)
+			}
+		})
+	}
+}
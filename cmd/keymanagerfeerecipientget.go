@@ -0,0 +1,54 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	keymanagerfeerecipientget "github.com/wealdtech/ethdo/cmd/keymanager/feerecipient/get"
+)
+
+var keymanagerFeeRecipientGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Obtain fee recipients from a validator client",
+	Long: `Obtain the fee recipients configured in a validator client through its keymanager API.  For example:
+
+    ethdo keymanager feerecipient get --keymanager=http://localhost:5062 --keymanager-token=/path/to/token --validators=0xa99a...,0x8f0e...
+
+In quiet mode this will return 0 if the fee recipients can be obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := keymanagerfeerecipientget.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	keymanagerFeeRecipientCmd.AddCommand(keymanagerFeeRecipientGetCmd)
+	keymanagerFeeRecipientFlags(keymanagerFeeRecipientGetCmd)
+}
+
+func keymanagerFeeRecipientGetBindings(cmd *cobra.Command) {
+	keymanagerFeeRecipientBindings(cmd)
+}
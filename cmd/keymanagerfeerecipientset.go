@@ -0,0 +1,60 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	keymanagerfeerecipientset "github.com/wealdtech/ethdo/cmd/keymanager/feerecipient/set"
+)
+
+var keymanagerFeeRecipientSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Set fee recipients in a validator client",
+	Long: `Set the fee recipients in a validator client through its keymanager API.  For example:
+
+    ethdo keymanager feerecipient set --keymanager=http://localhost:5062 --keymanager-token=/path/to/token --validators=0xa99a...,0x8f0e... --fee-recipient=0x8f08...
+
+Fee recipients for multiple validators can be supplied in a CSV file with --csv, where each line contains a validator public key and, optionally, its fee recipient.  Lines without a fee recipient use the value of --fee-recipient.
+
+In quiet mode this will return 0 if the fee recipients are set, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := keymanagerfeerecipientset.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	keymanagerFeeRecipientCmd.AddCommand(keymanagerFeeRecipientSetCmd)
+	keymanagerFeeRecipientFlags(keymanagerFeeRecipientSetCmd)
+	keymanagerFeeRecipientSetCmd.Flags().String("fee-recipient", "", "the fee recipient address")
+}
+
+func keymanagerFeeRecipientSetBindings(cmd *cobra.Command) {
+	keymanagerFeeRecipientBindings(cmd)
+	if err := viper.BindPFlag("fee-recipient", cmd.Flags().Lookup("fee-recipient")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// keymanagerGraffitiCmd represents the keymanager graffiti command.
+var keymanagerGraffitiCmd = &cobra.Command{
+	Use:   "graffiti",
+	Short: "Manage graffiti through the keymanager API",
+	Long:  "Manage graffiti through the keymanager API",
+}
+
+func init() {
+	keymanagerCmd.AddCommand(keymanagerGraffitiCmd)
+}
+
+func keymanagerGraffitiFlags(cmd *cobra.Command) {
+	keymanagerFlags(cmd)
+	cmd.Flags().StringSlice("validators", nil, "the public keys of the validators")
+	cmd.Flags().String("csv", "", "a CSV file of validator public keys and graffiti")
+}
+
+func keymanagerGraffitiBindings(cmd *cobra.Command) {
+	keymanagerBindings(cmd)
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("csv", cmd.Flags().Lookup("csv")); err != nil {
+		panic(err)
+	}
+}
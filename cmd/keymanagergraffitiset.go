@@ -0,0 +1,60 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	keymanagergraffitiset "github.com/wealdtech/ethdo/cmd/keymanager/graffiti/set"
+)
+
+var keymanagerGraffitiSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Set graffiti in a validator client",
+	Long: `Set the graffiti in a validator client through its keymanager API.  For example:
+
+    ethdo keymanager graffiti set --keymanager=http://localhost:5062 --keymanager-token=/path/to/token --validators=0xa99a...,0x8f0e... --graffiti="my graffiti"
+
+Graffiti for multiple validators can be supplied in a CSV file with --csv, where each line contains a validator public key and, optionally, its graffiti.  Lines without graffiti use the value of --graffiti.
+
+In quiet mode this will return 0 if the graffiti is set, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := keymanagergraffitiset.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	keymanagerGraffitiCmd.AddCommand(keymanagerGraffitiSetCmd)
+	keymanagerGraffitiFlags(keymanagerGraffitiSetCmd)
+	keymanagerGraffitiSetCmd.Flags().String("graffiti", "", "the graffiti, up to 32 bytes")
+}
+
+func keymanagerGraffitiSetBindings(cmd *cobra.Command) {
+	keymanagerGraffitiBindings(cmd)
+	if err := viper.BindPFlag("graffiti", cmd.Flags().Lookup("graffiti")); err != nil {
+		panic(err)
+	}
+}
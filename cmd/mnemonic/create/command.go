@@ -0,0 +1,88 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mnemoniccreate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+	"golang.org/x/term"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Input.
+	words              int
+	language           string
+	additionalEntropy  string
+	skipConfirmation   bool
+	wordList           *util.MnemonicWordList
+	in                 io.Reader
+	out                io.Writer
+	outputIsTerminal   bool
+	confirmationWordNo int
+
+	// Results.
+	mnemonic string
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:             viper.GetBool("quiet"),
+		verbose:           viper.GetBool("verbose"),
+		debug:             viper.GetBool("debug"),
+		words:             viper.GetInt("words"),
+		language:          strings.ToLower(viper.GetString("language")),
+		additionalEntropy: viper.GetString("additional-entropy"),
+		skipConfirmation:  viper.GetBool("skip-confirmation"),
+		in:                os.Stdin,
+		out:               os.Stdout,
+		outputIsTerminal:  term.IsTerminal(int(os.Stdout.Fd())),
+	}
+
+	if c.quiet {
+		return nil, errors.New("cannot create a mnemonic in quiet mode")
+	}
+
+	switch c.words {
+	case 12, 18, 24:
+	default:
+		return nil, errors.New("words must be one of 12, 18 or 24")
+	}
+
+	if c.language == "" {
+		c.language = "english"
+	}
+	languages := make([]string, 0)
+	for _, wordList := range util.MnemonicWordLists() {
+		if wordList.Name == c.language {
+			c.wordList = wordList
+		}
+		languages = append(languages, wordList.Name)
+	}
+	if c.wordList == nil {
+		return nil, fmt.Errorf("unknown language %q; supported languages are %s", c.language, strings.Join(languages, ", "))
+	}
+
+	return c, nil
+}
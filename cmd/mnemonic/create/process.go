@@ -0,0 +1,126 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mnemoniccreate
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/text/unicode/norm"
+)
+
+func (c *command) process(_ context.Context) error {
+	// The mnemonic must only ever be displayed on a terminal, to avoid it
+	// ending up in a file or being passed to another process.
+	if !c.outputIsTerminal {
+		return errors.New("refusing to display mnemonic as output is not a terminal")
+	}
+
+	entropy, err := c.generateEntropy()
+	if err != nil {
+		return err
+	}
+
+	bip39.SetWordList(c.wordList.Words)
+	c.mnemonic, err = bip39.NewMnemonic(entropy)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate mnemonic")
+	}
+
+	if c.skipConfirmation {
+		return nil
+	}
+
+	return c.confirm()
+}
+
+// generateEntropy generates the entropy for the mnemonic, mixing in any
+// additional entropy supplied by the user.
+func (c *command) generateEntropy() ([]byte, error) {
+	// 12 words is 128 bits of entropy, 18 words is 192 bits and 24 words is 256 bits.
+	entropy := make([]byte, c.words*4/3)
+	if _, err := rand.Read(entropy); err != nil {
+		return nil, errors.Wrap(err, "failed to generate entropy")
+	}
+
+	if c.additionalEntropy != "" {
+		// The additional entropy is mixed in by hashing, so at worst it has no effect.
+		hash := sha256.Sum256(append(entropy, []byte(c.additionalEntropy)...))
+		copy(entropy, hash[:len(entropy)])
+	}
+
+	return entropy, nil
+}
+
+// confirm displays the mnemonic and requires the user to confirm that they
+// have recorded it.
+func (c *command) confirm() error {
+	reader := bufio.NewReader(c.in)
+
+	fmt.Fprintf(c.out, "Your mnemonic is:\n\n%s\n\n", c.displayMnemonic())
+	fmt.Fprintf(c.out, "Write down the mnemonic and store it securely; anyone with access to it can access all keys generated from it.\n")
+	fmt.Fprintf(c.out, "Type 'yes' once you have written down the mnemonic: ")
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return errors.Wrap(err, "failed to read confirmation")
+	}
+	if strings.TrimSpace(strings.ToLower(response)) != "yes" {
+		return errors.New("mnemonic not confirmed")
+	}
+
+	// Clear the screen so that the mnemonic is no longer visible, then ask
+	// for a word to confirm that it was recorded correctly.
+	fmt.Fprint(c.out, "\033[H\033[2J\033[3J")
+	if c.confirmationWordNo == 0 {
+		wordNo, err := rand.Int(rand.Reader, big.NewInt(int64(c.words)))
+		if err != nil {
+			return errors.Wrap(err, "failed to select confirmation word")
+		}
+		c.confirmationWordNo = int(wordNo.Int64()) + 1
+	}
+	fmt.Fprintf(c.out, "Enter word %d of the mnemonic: ", c.confirmationWordNo)
+	response, err = reader.ReadString('\n')
+	if err != nil {
+		return errors.Wrap(err, "failed to read confirmation word")
+	}
+	if norm.NFKD.String(strings.TrimSpace(response)) != norm.NFKD.String(strings.Fields(c.mnemonic)[c.confirmationWordNo-1]) {
+		return errors.New("confirmation word does not match the mnemonic; the mnemonic has been discarded")
+	}
+
+	return nil
+}
+
+// displayMnemonic returns the mnemonic as numbered words.
+func (c *command) displayMnemonic() string {
+	builder := strings.Builder{}
+	for i, word := range strings.Fields(c.mnemonic) {
+		word = norm.NFC.String(word)
+		builder.WriteString(fmt.Sprintf("%2d: %s", i+1, word))
+		if i%4 == 3 {
+			builder.WriteString("\n")
+		} else {
+			builder.WriteString(strings.Repeat(" ", max(1, 12-utf8.RuneCountInString(word))))
+		}
+	}
+
+	return strings.TrimRight(builder.String(), " \n")
+}
@@ -0,0 +1,158 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mnemoniccreate
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tyler-smith/go-bip39"
+	"github.com/wealdtech/ethdo/util"
+	"golang.org/x/text/unicode/norm"
+)
+
+func wordList(t *testing.T, name string) *util.MnemonicWordList {
+	t.Helper()
+
+	for _, wordList := range util.MnemonicWordLists() {
+		if wordList.Name == name {
+			return wordList
+		}
+	}
+	require.Fail(t, "unknown word list")
+
+	return nil
+}
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name              string
+		words             int
+		language          string
+		additionalEntropy string
+		notTerminal       bool
+		input             string
+		err               string
+	}{
+		{
+			name:        "NotTerminal",
+			words:       24,
+			language:    "english",
+			notTerminal: true,
+			err:         "refusing to display mnemonic as output is not a terminal",
+		},
+		{
+			name:     "NotConfirmed",
+			words:    24,
+			language: "english",
+			input:    "no\n",
+			err:      "mnemonic not confirmed",
+		},
+		{
+			name:     "WrongWord",
+			words:    24,
+			language: "english",
+			input:    "yes\nnotaword\n",
+			err:      "confirmation word does not match the mnemonic; the mnemonic has been discarded",
+		},
+		{
+			name:     "Good12",
+			words:    12,
+			language: "english",
+		},
+		{
+			name:     "Good18",
+			words:    18,
+			language: "japanese",
+		},
+		{
+			name:              "Good24",
+			words:             24,
+			language:          "spanish",
+			additionalEntropy: "dice rolls 3 1 4 1 5 9 2 6",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				words:              test.words,
+				language:           test.language,
+				additionalEntropy:  test.additionalEntropy,
+				wordList:           wordList(t, test.language),
+				out:                &bytes.Buffer{},
+				outputIsTerminal:   !test.notTerminal,
+				confirmationWordNo: 1,
+			}
+			if test.input != "" {
+				c.in = strings.NewReader(test.input)
+			} else {
+				// Provide the confirmation word on demand.
+				c.in = &confirmingReader{c: c}
+			}
+			err := c.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, strings.Fields(c.mnemonic), test.words)
+			bip39.SetWordList(c.wordList.Words)
+			require.True(t, bip39.IsMnemonicValid(c.mnemonic))
+			require.Contains(t, c.out.(*bytes.Buffer).String(), norm.NFC.String(strings.Fields(c.mnemonic)[0]))
+		})
+	}
+}
+
+// confirmingReader supplies a confirmation followed by the first word of the mnemonic.
+type confirmingReader struct {
+	c    *command
+	data []byte
+	step int
+}
+
+func (r *confirmingReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		switch r.step {
+		case 0:
+			r.data = []byte("yes\n")
+		default:
+			// Supply the word in composed form, as a user would type it.
+			r.data = []byte(norm.NFC.String(strings.Fields(r.c.mnemonic)[0]) + "\n")
+		}
+		r.step++
+	}
+	n := copy(p, r.data[:bytes.IndexByte(r.data, '\n')+1])
+	r.data = r.data[n:]
+
+	return n, nil
+}
+
+func TestGenerateEntropy(t *testing.T) {
+	for _, words := range []int{12, 18, 24} {
+		c := &command{words: words}
+		entropy1, err := c.generateEntropy()
+		require.NoError(t, err)
+		require.Len(t, entropy1, words*4/3)
+
+		c.additionalEntropy = "extra"
+		entropy2, err := c.generateEntropy()
+		require.NoError(t, err)
+		require.Len(t, entropy2, words*4/3)
+		require.NotEqual(t, entropy1, entropy2)
+	}
+}
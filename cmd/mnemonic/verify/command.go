@@ -0,0 +1,74 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mnemonicverify
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Operation.
+	mnemonic string
+
+	// Results.
+	wordList      *util.MnemonicWordList
+	words         []string
+	hasPassphrase bool
+	validLength   bool
+	unknownWords  []*unknownWord
+	checksumValid bool
+	corrections   []*correction
+}
+
+// unknownWord is a word that is not in the word list.
+type unknownWord struct {
+	position   int
+	word       string
+	candidates []string
+}
+
+// correction is a single-word change that results in a valid mnemonic.
+type correction struct {
+	position    int
+	word        string
+	replacement string
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:    viper.GetBool("quiet"),
+		verbose:  viper.GetBool("verbose"),
+		debug:    viper.GetBool("debug"),
+		mnemonic: viper.GetString("mnemonic"),
+	}
+
+	if c.mnemonic == "" {
+		return nil, errors.New("mnemonic is required")
+	}
+
+	return c, nil
+}
+
+// valid returns true if the mnemonic is valid.
+func (c *command) valid() bool {
+	return c.validLength && len(c.unknownWords) == 0 && c.checksumValid
+}
@@ -0,0 +1,67 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mnemonicverify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	builder := strings.Builder{}
+
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Word list: %s\n", c.wordList.Name))
+		builder.WriteString(fmt.Sprintf("Words: %d\n", len(c.words)))
+		if c.hasPassphrase {
+			builder.WriteString("Passphrase: supplied\n")
+		}
+	}
+
+	if !c.validLength {
+		builder.WriteString(fmt.Sprintf("Mnemonic has %d words; it should have 12, 15, 18, 21 or 24 words\n", len(c.words)))
+	}
+
+	for _, unknownWord := range c.unknownWords {
+		builder.WriteString(fmt.Sprintf("Word %d %q is not in the %s word list", unknownWord.position, unknownWord.word, c.wordList.Name))
+		if len(unknownWord.candidates) > 0 {
+			builder.WriteString(fmt.Sprintf("; similar words: %s", strings.Join(unknownWord.candidates, ", ")))
+		}
+		builder.WriteString("\n")
+	}
+
+	if c.validLength && len(c.unknownWords) == 0 && !c.checksumValid {
+		builder.WriteString("Mnemonic checksum is invalid\n")
+	}
+
+	if len(c.corrections) > 0 {
+		builder.WriteString("Corrections that result in a valid mnemonic:\n")
+		for _, correction := range c.corrections {
+			builder.WriteString(fmt.Sprintf("  word %d %q -> %q\n", correction.position, correction.word, correction.replacement))
+		}
+	}
+
+	if c.valid() {
+		builder.WriteString("Mnemonic is valid")
+	} else {
+		builder.WriteString("Mnemonic is invalid")
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,136 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mnemonicverify
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+	"github.com/wealdtech/ethdo/util"
+	"golang.org/x/text/unicode/norm"
+)
+
+func (c *command) process(_ context.Context) error {
+	mnemonic, passphrase := util.SplitMnemonic(strings.Join(strings.Fields(c.mnemonic), " "))
+	c.hasPassphrase = passphrase != ""
+
+	// Use the word list that contains the most words of the mnemonic.
+	bestMatches := -1
+	for _, wordList := range util.MnemonicWordLists() {
+		bip39.SetWordList(wordList.Words)
+		words := strings.Fields(util.ExpandMnemonic(mnemonic))
+		matches := 0
+		for _, word := range words {
+			if _, exists := bip39.GetWordIndex(word); exists {
+				matches++
+			}
+		}
+		if matches > bestMatches {
+			bestMatches = matches
+			c.wordList = wordList
+			c.words = words
+		}
+	}
+	bip39.SetWordList(c.wordList.Words)
+
+	switch len(c.words) {
+	case 12, 15, 18, 21, 24:
+		c.validLength = true
+	}
+
+	for i, word := range c.words {
+		if _, exists := bip39.GetWordIndex(word); !exists {
+			c.unknownWords = append(c.unknownWords, &unknownWord{
+				position:   i + 1,
+				word:       word,
+				candidates: nearMisses(word, c.wordList.Words),
+			})
+		}
+	}
+
+	if !c.validLength {
+		return nil
+	}
+
+	switch len(c.unknownWords) {
+	case 0:
+		c.checksumValid = bip39.IsMnemonicValid(strings.Join(c.words, " "))
+		if !c.checksumValid {
+			// Look for single-word transcription errors that would result in a valid checksum.
+			for i := range c.words {
+				c.findCorrections(i, nearMisses(c.words[i], c.wordList.Words))
+			}
+		}
+	case 1:
+		// Find which of the candidates for the unknown word result in a valid checksum.
+		c.findCorrections(c.unknownWords[0].position-1, c.unknownWords[0].candidates)
+	}
+
+	return nil
+}
+
+// findCorrections adds the candidates for the word at the given index that
+// result in a valid mnemonic.
+func (c *command) findCorrections(index int, candidates []string) {
+	words := make([]string, len(c.words))
+	copy(words, c.words)
+	for _, candidate := range candidates {
+		words[index] = candidate
+		if bip39.IsMnemonicValid(strings.Join(words, " ")) {
+			c.corrections = append(c.corrections, &correction{
+				position:    index + 1,
+				word:        c.words[index],
+				replacement: candidate,
+			})
+		}
+	}
+}
+
+// nearMisses returns the words in the word list within an edit distance of 1 of the given word.
+func nearMisses(word string, wordList []string) []string {
+	res := make([]string, 0)
+	for _, candidate := range wordList {
+		if candidate != word && withinOneEdit(norm.NFKC.String(word), norm.NFKC.String(candidate)) {
+			res = append(res, candidate)
+		}
+	}
+
+	return res
+}
+
+// withinOneEdit returns true if the strings differ by at most a single
+// insertion, deletion or substitution.
+func withinOneEdit(a string, b string) bool {
+	ar := []rune(a)
+	br := []rune(b)
+	if len(ar) > len(br) {
+		ar, br = br, ar
+	}
+	if len(br)-len(ar) > 1 {
+		return false
+	}
+
+	i := 0
+	for i < len(ar) && ar[i] == br[i] {
+		i++
+	}
+	if len(ar) == len(br) {
+		// Substitution; the remainder must match.
+		return i == len(ar) || string(ar[i+1:]) == string(br[i+1:])
+	}
+
+	// Insertion; the remainder of the shorter must match the longer after skipping a character.
+	return string(ar[i:]) == string(br[i+1:])
+}
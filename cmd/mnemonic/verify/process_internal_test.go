@@ -0,0 +1,106 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mnemonicverify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name          string
+		mnemonic      string
+		valid         bool
+		wordList      string
+		hasPassphrase bool
+		unknownWords  int
+		corrections   []string
+	}{
+		{
+			name:     "Valid",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			valid:    true,
+			wordList: "english",
+		},
+		{
+			name:     "Truncated",
+			mnemonic: "aban aban aban aban aban aban aban aban aban aban aban abou",
+			valid:    true,
+			wordList: "english",
+		},
+		{
+			name:          "Passphrase",
+			mnemonic:      "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about secret",
+			valid:         true,
+			wordList:      "english",
+			hasPassphrase: true,
+		},
+		{
+			name:     "BadLength",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			wordList: "english",
+		},
+		{
+			name:         "UnknownWord",
+			mnemonic:     "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abort",
+			wordList:     "english",
+			unknownWords: 1,
+			corrections:  []string{"about"},
+		},
+		{
+			name:        "BadChecksum",
+			mnemonic:    "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+			wordList:    "english",
+			corrections: []string{},
+		},
+		{
+			name:     "Spanish",
+			mnemonic: "ábaco ábaco ábaco ábaco ábaco ábaco ábaco ábaco ábaco ábaco ábaco abierto",
+			valid:    true,
+			wordList: "spanish",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				mnemonic: test.mnemonic,
+			}
+			require.NoError(t, c.process(context.Background()))
+			require.Equal(t, test.valid, c.valid())
+			require.Equal(t, test.wordList, c.wordList.Name)
+			require.Equal(t, test.hasPassphrase, c.hasPassphrase)
+			require.Len(t, c.unknownWords, test.unknownWords)
+			if test.corrections != nil {
+				replacements := make([]string, 0, len(c.corrections))
+				for _, correction := range c.corrections {
+					replacements = append(replacements, correction.replacement)
+				}
+				require.Equal(t, test.corrections, replacements)
+			}
+		})
+	}
+}
+
+func TestWithinOneEdit(t *testing.T) {
+	require.True(t, withinOneEdit("about", "abort"))
+	require.True(t, withinOneEdit("about", "abou"))
+	require.True(t, withinOneEdit("about", "abouts"))
+	require.True(t, withinOneEdit("about", "xabout"))
+	require.False(t, withinOneEdit("about", "abuot"))
+	require.False(t, withinOneEdit("about", "ab"))
+}
@@ -0,0 +1,68 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	mnemoniccreate "github.com/wealdtech/ethdo/cmd/mnemonic/create"
+)
+
+var mnemonicCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a mnemonic",
+	Long: `Create a new BIP-39 mnemonic.  For example:
+
+    ethdo mnemonic create --words=24 --language=english
+
+The mnemonic is only displayed on a terminal, and must be confirmed before the command completes.  Additional entropy can be mixed in to that generated by the operating system with --additional-entropy.
+
+This command does not require a network connection, and should be run on an offline computer.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := mnemoniccreate.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	mnemonicCmd.AddCommand(mnemonicCreateCmd)
+	mnemonicFlags(mnemonicCreateCmd)
+	mnemonicCreateCmd.Flags().Int("words", 24, "Number of words in the mnemonic (12, 18 or 24)")
+	mnemonicCreateCmd.Flags().String("language", "english", "Language of the mnemonic word list")
+	mnemonicCreateCmd.Flags().String("additional-entropy", "", "Additional entropy to mix in to the generated entropy")
+	mnemonicCreateCmd.Flags().Bool("skip-confirmation", false, "Display the mnemonic without requiring confirmation")
+}
+
+func mnemonicCreateBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("words", cmd.Flags().Lookup("words")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("language", cmd.Flags().Lookup("language")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("additional-entropy", cmd.Flags().Lookup("additional-entropy")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("skip-confirmation", cmd.Flags().Lookup("skip-confirmation")); err != nil {
+		panic(err)
+	}
+}
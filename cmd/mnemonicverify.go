@@ -0,0 +1,50 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	mnemonicverify "github.com/wealdtech/ethdo/cmd/mnemonic/verify"
+)
+
+var mnemonicVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify a mnemonic",
+	Long: `Verify a mnemonic, reporting words that are not in the word list and near-miss corrections if the checksum fails.  For example:
+
+    ethdo mnemonic verify --mnemonic="abandon abandon abandon … art"
+
+A passphrase can be supplied as additional words after the mnemonic, in the same way as for other commands that take a mnemonic.
+
+This command does not require a network connection, and should be run offline.
+
+In quiet mode this will return 0 if the mnemonic is valid, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := mnemonicverify.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	mnemonicCmd.AddCommand(mnemonicVerifyCmd)
+	mnemonicFlags(mnemonicVerifyCmd)
+}
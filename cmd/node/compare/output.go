@@ -0,0 +1,110 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodecompare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type nodeJSON struct {
+	Address          string `json:"address"`
+	Error            string `json:"error,omitempty"`
+	HeadSlot         string `json:"head_slot,omitempty"`
+	HeadRoot         string `json:"head_root,omitempty"`
+	FinalizedEpoch   string `json:"finalized_epoch,omitempty"`
+	FinalizedRoot    string `json:"finalized_root,omitempty"`
+	ForkDigest       string `json:"fork_digest,omitempty"`
+	Validators       int    `json:"validators,omitempty"`
+	ActiveValidators int    `json:"active_validators,omitempty"`
+}
+
+type jsonOutput struct {
+	Nodes       []*nodeJSON `json:"nodes"`
+	Divergences []string    `json:"divergences"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := &jsonOutput{
+		Nodes:       make([]*nodeJSON, 0, len(c.nodes)),
+		Divergences: c.divergences,
+	}
+	for _, node := range c.nodes {
+		if node.Error != "" {
+			output.Nodes = append(output.Nodes, &nodeJSON{
+				Address: node.Address,
+				Error:   node.Error,
+			})
+			continue
+		}
+		output.Nodes = append(output.Nodes, &nodeJSON{
+			Address:          node.Address,
+			HeadSlot:         fmt.Sprintf("%d", node.HeadSlot),
+			HeadRoot:         fmt.Sprintf("%#x", node.HeadRoot),
+			FinalizedEpoch:   fmt.Sprintf("%d", node.FinalizedEpoch),
+			FinalizedRoot:    fmt.Sprintf("%#x", node.FinalizedRoot),
+			ForkDigest:       fmt.Sprintf("%#x", node.ForkDigest),
+			Validators:       node.Validators,
+			ActiveValidators: node.ActiveValidators,
+		})
+	}
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.verbose {
+		for _, node := range c.nodes {
+			builder.WriteString(fmt.Sprintf("%s:\n", node.Address))
+			if node.Error != "" {
+				builder.WriteString(fmt.Sprintf("  Error: %s\n", node.Error))
+				continue
+			}
+			builder.WriteString(fmt.Sprintf("  Head: %#x (slot %d)\n", node.HeadRoot, node.HeadSlot))
+			builder.WriteString(fmt.Sprintf("  Finalized: %#x (epoch %d)\n", node.FinalizedRoot, node.FinalizedEpoch))
+			builder.WriteString(fmt.Sprintf("  Fork digest: %#x\n", node.ForkDigest))
+			builder.WriteString(fmt.Sprintf("  Validators: %d (%d active)\n", node.Validators, node.ActiveValidators))
+		}
+	}
+
+	if len(c.divergences) == 0 {
+		builder.WriteString("All nodes agree\n")
+	} else {
+		builder.WriteString("Divergences:\n")
+		for _, divergence := range c.divergences {
+			builder.WriteString(fmt.Sprintf("  %s\n", divergence))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
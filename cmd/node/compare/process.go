@@ -0,0 +1,152 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodecompare
+
+import (
+	"context"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	c.nodes = make([]*nodeState, 0, len(c.connections))
+	for _, connection := range c.connections {
+		state := &nodeState{
+			Address: connection,
+		}
+		if err := c.obtainNodeState(ctx, state); err != nil {
+			if c.debug {
+				fmt.Printf("Failed to obtain state from %s: %v\n", connection, err)
+			}
+			state.Error = err.Error()
+		}
+		c.nodes = append(c.nodes, state)
+	}
+
+	c.divergences = divergences(c.nodes)
+
+	return nil
+}
+
+func (c *command) obtainNodeState(ctx context.Context, state *nodeState) error {
+	client, err := util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       state.Address,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   false,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	headerResponse, err := client.(eth2client.BeaconBlockHeadersProvider).BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{
+		Block: "head",
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain head")
+	}
+	state.HeadSlot = headerResponse.Data.Header.Message.Slot
+	state.HeadRoot = headerResponse.Data.Root
+
+	finalityResponse, err := client.(eth2client.FinalityProvider).Finality(ctx, &api.FinalityOpts{
+		State: "head",
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain finality")
+	}
+	state.FinalizedEpoch = finalityResponse.Data.Finalized.Epoch
+	state.FinalizedRoot = finalityResponse.Data.Finalized.Root
+
+	genesisResponse, err := client.(eth2client.GenesisProvider).Genesis(ctx, &api.GenesisOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain genesis")
+	}
+	forkResponse, err := client.(eth2client.ForkProvider).Fork(ctx, &api.ForkOpts{
+		State: "head",
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain fork")
+	}
+	forkDataRoot, err := (&phase0.ForkData{
+		CurrentVersion:        forkResponse.Data.CurrentVersion,
+		GenesisValidatorsRoot: genesisResponse.Data.GenesisValidatorsRoot,
+	}).HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate fork digest")
+	}
+	copy(state.ForkDigest[:], forkDataRoot[:])
+
+	// Validators are counted at the finalized state, as this should be
+	// identical across all nodes that agree on finality.
+	validatorsResponse, err := client.(eth2client.ValidatorsProvider).Validators(ctx, &api.ValidatorsOpts{
+		State: "finalized",
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+	for _, validator := range validatorsResponse.Data {
+		state.Validators++
+		if validator.Status.IsActive() {
+			state.ActiveValidators++
+		}
+	}
+	state.validatorsAvailable = true
+
+	return nil
+}
+
+// divergences returns a list of human-readable divergences between node states.
+func divergences(nodes []*nodeState) []string {
+	res := make([]string, 0)
+
+	var reference *nodeState
+	for _, node := range nodes {
+		if node.Error != "" {
+			res = append(res, fmt.Sprintf("%s could not be queried: %s", node.Address, node.Error))
+			continue
+		}
+		if reference == nil {
+			reference = node
+			continue
+		}
+		if node.HeadRoot != reference.HeadRoot {
+			res = append(res, fmt.Sprintf("%s has head %#x (slot %d), %s has head %#x (slot %d)",
+				reference.Address, reference.HeadRoot, reference.HeadSlot,
+				node.Address, node.HeadRoot, node.HeadSlot))
+		}
+		if node.FinalizedEpoch != reference.FinalizedEpoch || node.FinalizedRoot != reference.FinalizedRoot {
+			res = append(res, fmt.Sprintf("%s has finalized checkpoint %d/%#x, %s has finalized checkpoint %d/%#x",
+				reference.Address, reference.FinalizedEpoch, reference.FinalizedRoot,
+				node.Address, node.FinalizedEpoch, node.FinalizedRoot))
+		}
+		if node.ForkDigest != reference.ForkDigest {
+			res = append(res, fmt.Sprintf("%s has fork digest %#x, %s has fork digest %#x",
+				reference.Address, reference.ForkDigest,
+				node.Address, node.ForkDigest))
+		}
+		if node.validatorsAvailable && reference.validatorsAvailable &&
+			(node.Validators != reference.Validators || node.ActiveValidators != reference.ActiveValidators) {
+			res = append(res, fmt.Sprintf("%s has %d validators (%d active), %s has %d validators (%d active)",
+				reference.Address, reference.Validators, reference.ActiveValidators,
+				node.Address, node.Validators, node.ActiveValidators))
+		}
+	}
+
+	return res
+}
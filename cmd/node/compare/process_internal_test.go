@@ -0,0 +1,68 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodecompare
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDivergences(t *testing.T) {
+	tests := []struct {
+		name        string
+		nodes       []*nodeState
+		divergences int
+	}{
+		{
+			name: "Agree",
+			nodes: []*nodeState{
+				{Address: "a", HeadSlot: 1, Validators: 2, validatorsAvailable: true},
+				{Address: "b", HeadSlot: 1, Validators: 2, validatorsAvailable: true},
+			},
+		},
+		{
+			name: "Error",
+			nodes: []*nodeState{
+				{Address: "a"},
+				{Address: "b", Error: "unavailable"},
+			},
+			divergences: 1,
+		},
+		{
+			name: "HeadAndFinality",
+			nodes: []*nodeState{
+				{Address: "a", HeadRoot: phase0.Root{0x01}, FinalizedEpoch: 1},
+				{Address: "b", HeadRoot: phase0.Root{0x02}, FinalizedEpoch: 2},
+				{Address: "c", HeadRoot: phase0.Root{0x01}, FinalizedEpoch: 1},
+			},
+			divergences: 2,
+		},
+		{
+			name: "ForkDigestAndValidators",
+			nodes: []*nodeState{
+				{Address: "a", ForkDigest: phase0.ForkDigest{0x01}, Validators: 2, validatorsAvailable: true},
+				{Address: "b", ForkDigest: phase0.ForkDigest{0x02}, Validators: 3, validatorsAvailable: true},
+			},
+			divergences: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Len(t, divergences(test.nodes), test.divergences)
+		})
+	}
+}
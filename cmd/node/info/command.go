@@ -0,0 +1,83 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeinfo
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Data access.
+	eth2Client eth2client.Service
+
+	// Output.
+	info *info
+}
+
+type info struct {
+	Version      string                 `json:"version"`
+	Client       string                 `json:"client,omitempty"`
+	Syncing      bool                   `json:"syncing"`
+	HeadSlot     phase0.Slot            `json:"head_slot"`
+	SyncDistance phase0.Slot            `json:"sync_distance"`
+	Optimistic   bool                   `json:"optimistic"`
+	Peers        *peers                 `json:"peers"`
+	Capabilities []*util.NodeCapability `json:"capabilities"`
+}
+
+// peers are the counts of the node's peers.
+type peers struct {
+	Connected     uint64 `json:"connected"`
+	Inbound       uint64 `json:"inbound"`
+	Outbound      uint64 `json:"outbound"`
+	Connecting    uint64 `json:"connecting"`
+	Disconnecting uint64 `json:"disconnecting"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	return c, nil
+}
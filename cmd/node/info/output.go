@@ -0,0 +1,79 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeinfo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.info)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Version: %s\n", c.info.Version))
+	if c.verbose && c.info.Client != "" {
+		builder.WriteString(fmt.Sprintf("Client: %s\n", c.info.Client))
+	}
+
+	builder.WriteString(fmt.Sprintf("Syncing: %t\n", c.info.Syncing))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Head slot: %d\n", c.info.HeadSlot))
+		builder.WriteString(fmt.Sprintf("Sync distance: %d\n", c.info.SyncDistance))
+		builder.WriteString(fmt.Sprintf("Optimistic: %t\n", c.info.Optimistic))
+	}
+
+	builder.WriteString(fmt.Sprintf("Peers: %d connected (%d inbound, %d outbound)\n", c.info.Peers.Connected, c.info.Peers.Inbound, c.info.Peers.Outbound))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Connecting peers: %d\n", c.info.Peers.Connecting))
+		builder.WriteString(fmt.Sprintf("Disconnecting peers: %d\n", c.info.Peers.Disconnecting))
+	}
+
+	for _, capability := range c.info.Capabilities {
+		name := strings.ToUpper(capability.Name[:1]) + capability.Name[1:]
+		if capability.Supported {
+			builder.WriteString(fmt.Sprintf("%s API: supported\n", name))
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("%s API: not supported", name))
+		if c.verbose && capability.Reason != "" {
+			builder.WriteString(fmt.Sprintf(" (%s)", capability.Reason))
+		}
+		builder.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
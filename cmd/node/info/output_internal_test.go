@@ -0,0 +1,77 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeinfo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestOutputText(t *testing.T) {
+	nodeInfo := &info{
+		Version:  "Lighthouse/v5.3.0",
+		Client:   "lighthouse",
+		HeadSlot: 100,
+		Peers:    &peers{Connected: 3, Inbound: 1, Outbound: 2},
+		Capabilities: []*util.NodeCapability{
+			{Name: util.NodeCapabilityRewards, Supported: true},
+			{Name: util.NodeCapabilityLightClient, Reason: "beacon node returned status 404"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		verbose  bool
+		expected string
+	}{
+		{
+			name: "Standard",
+			expected: `Version: Lighthouse/v5.3.0
+Syncing: false
+Peers: 3 connected (1 inbound, 2 outbound)
+Rewards API: supported
+Light client API: not supported`,
+		},
+		{
+			name:    "Verbose",
+			verbose: true,
+			expected: `Version: Lighthouse/v5.3.0
+Client: lighthouse
+Syncing: false
+Head slot: 100
+Sync distance: 0
+Optimistic: false
+Peers: 3 connected (1 inbound, 2 outbound)
+Connecting peers: 0
+Disconnecting peers: 0
+Rewards API: supported
+Light client API: not supported (beacon node returned status 404)`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				verbose: test.verbose,
+				info:    nodeInfo,
+			}
+			res, err := c.outputText(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.expected, res)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeinfo
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if c.quiet {
+		// Connecting to the node is sufficient.
+		return nil
+	}
+
+	c.info = &info{}
+
+	versionResponse, err := c.eth2Client.(eth2client.NodeVersionProvider).NodeVersion(ctx, &api.NodeVersionOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain node version")
+	}
+	c.info.Version = versionResponse.Data
+
+	if provider, isProvider := c.eth2Client.(eth2client.NodeClientProvider); isProvider {
+		clientResponse, err := provider.NodeClient(ctx)
+		if err == nil {
+			c.info.Client = clientResponse.Data
+		}
+	}
+
+	syncStateResponse, err := c.eth2Client.(eth2client.NodeSyncingProvider).NodeSyncing(ctx, &api.NodeSyncingOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain node sync state")
+	}
+	c.info.Syncing = syncStateResponse.Data.SyncDistance != 0
+	c.info.HeadSlot = syncStateResponse.Data.HeadSlot
+	c.info.SyncDistance = syncStateResponse.Data.SyncDistance
+	c.info.Optimistic = syncStateResponse.Data.IsOptimistic
+
+	peersProvider, isProvider := c.eth2Client.(eth2client.NodePeersProvider)
+	if !isProvider {
+		return errors.New("connection does not provide peers")
+	}
+	peersResponse, err := peersProvider.NodePeers(ctx, &api.NodePeersOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain node peers")
+	}
+	c.info.Peers = countPeers(peersResponse.Data)
+
+	c.info.Capabilities, err = util.ProbeNodeCapabilities(ctx, c.eth2Client)
+	if err != nil {
+		return errors.Wrap(err, "failed to probe node capabilities")
+	}
+
+	return nil
+}
+
+// countPeers counts peers by state and, for connected peers, direction.
+func countPeers(nodePeers []*apiv1.Peer) *peers {
+	res := &peers{}
+	for _, peer := range nodePeers {
+		switch peer.State {
+		case "connected":
+			res.Connected++
+			switch peer.Direction {
+			case "inbound":
+				res.Inbound++
+			case "outbound":
+				res.Outbound++
+			}
+		case "connecting":
+			res.Connecting++
+		case "disconnecting":
+			res.Disconnecting++
+		}
+	}
+
+	return res
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	return nil
+}
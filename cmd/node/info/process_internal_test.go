@@ -0,0 +1,58 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeinfo
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountPeers(t *testing.T) {
+	tests := []struct {
+		name     string
+		peers    []*apiv1.Peer
+		expected *peers
+	}{
+		{
+			name:     "Empty",
+			expected: &peers{},
+		},
+		{
+			name: "Mixed",
+			peers: []*apiv1.Peer{
+				{State: "connected", Direction: "inbound"},
+				{State: "connected", Direction: "outbound"},
+				{State: "connected", Direction: "outbound"},
+				{State: "connecting", Direction: "outbound"},
+				{State: "disconnecting", Direction: "inbound"},
+				{State: "disconnected", Direction: "inbound"},
+			},
+			expected: &peers{
+				Connected:     3,
+				Inbound:       1,
+				Outbound:      2,
+				Connecting:    1,
+				Disconnecting: 1,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, countPeers(test.peers))
+		})
+	}
+}
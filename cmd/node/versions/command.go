@@ -0,0 +1,79 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeversions
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connections              []string
+	allowInsecureConnections bool
+
+	// Output.
+	nodes      []*nodeVersion
+	mismatches []string
+}
+
+// nodeVersion is the version information from a single node.
+type nodeVersion struct {
+	Address    string
+	Error      string
+	Client     string
+	Version    string
+	ForkDigest phase0.ForkDigest
+	// LatestFork is the latest fork in the node's fork schedule.
+	LatestFork *phase0.Fork
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	for _, connection := range strings.Split(viper.GetString("connections"), ",") {
+		connection = strings.TrimSpace(connection)
+		if connection != "" {
+			c.connections = append(c.connections, connection)
+		}
+	}
+	if len(c.connections) == 0 {
+		return nil, errors.New("at least one connection is required")
+	}
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	return c, nil
+}
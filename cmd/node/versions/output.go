@@ -0,0 +1,119 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeversions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+type nodeJSON struct {
+	Address         string `json:"address"`
+	Error           string `json:"error,omitempty"`
+	Client          string `json:"client,omitempty"`
+	Version         string `json:"version,omitempty"`
+	ForkDigest      string `json:"fork_digest,omitempty"`
+	LatestFork      string `json:"latest_fork_version,omitempty"`
+	LatestForkEpoch string `json:"latest_fork_epoch,omitempty"`
+}
+
+type jsonOutput struct {
+	Nodes      []*nodeJSON `json:"nodes"`
+	Mismatches []string    `json:"mismatches"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := &jsonOutput{
+		Nodes:      make([]*nodeJSON, 0, len(c.nodes)),
+		Mismatches: c.mismatches,
+	}
+	for _, node := range c.nodes {
+		if node.Error != "" {
+			output.Nodes = append(output.Nodes, &nodeJSON{
+				Address: node.Address,
+				Error:   node.Error,
+			})
+			continue
+		}
+		nodeJSON := &nodeJSON{
+			Address:    node.Address,
+			Client:     node.Client,
+			Version:    node.Version,
+			ForkDigest: fmt.Sprintf("%#x", node.ForkDigest),
+		}
+		if node.LatestFork != nil {
+			nodeJSON.LatestFork = fmt.Sprintf("%#x", node.LatestFork.CurrentVersion)
+			nodeJSON.LatestForkEpoch = fmt.Sprintf("%d", node.LatestFork.Epoch)
+		}
+		output.Nodes = append(output.Nodes, nodeJSON)
+	}
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	matrix := strings.Builder{}
+	writer := tabwriter.NewWriter(&matrix, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "Address\tClient\tVersion\tFork digest\tLatest scheduled fork")
+	for _, node := range c.nodes {
+		if node.Error != "" {
+			errorText := "unavailable"
+			if c.verbose {
+				errorText = node.Error
+			}
+			fmt.Fprintf(writer, "%s\t%s\t\t\t\n", node.Address, errorText)
+			continue
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%#x\t%s\n", node.Address, node.Client, node.Version, node.ForkDigest, forkText(node.LatestFork))
+	}
+	if err := writer.Flush(); err != nil {
+		return "", err
+	}
+
+	builder := strings.Builder{}
+	// Rows for nodes that could not be queried have empty cells, so trim their padding.
+	for _, line := range strings.Split(strings.TrimSuffix(matrix.String(), "\n"), "\n") {
+		builder.WriteString(strings.TrimRight(line, " "))
+		builder.WriteString("\n")
+	}
+
+	if len(c.mismatches) == 0 {
+		builder.WriteString("All nodes agree on fork schedule\n")
+	} else {
+		builder.WriteString("Mismatches:\n")
+		for _, mismatch := range c.mismatches {
+			builder.WriteString(fmt.Sprintf("  %s\n", mismatch))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,51 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeversions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputText(t *testing.T) {
+	c := &command{
+		nodes: []*nodeVersion{
+			{
+				Address:    "http://node1:5052",
+				Client:     "lighthouse",
+				Version:    "Lighthouse/v5.3.0",
+				ForkDigest: phase0.ForkDigest{0x01},
+				LatestFork: &phase0.Fork{CurrentVersion: phase0.Version{0x05}, Epoch: 300},
+			},
+			{
+				Address: "http://node2:5052",
+				Error:   "failed to connect to beacon node",
+			},
+		},
+		mismatches: []string{
+			"http://node2:5052 could not be queried: failed to connect to beacon node",
+		},
+	}
+
+	res, err := c.outputText(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, `Address            Client       Version            Fork digest  Latest scheduled fork
+http://node1:5052  lighthouse   Lighthouse/v5.3.0  0x01000000   0x05000000 (epoch 300)
+http://node2:5052  unavailable
+Mismatches:
+  http://node2:5052 could not be queried: failed to connect to beacon node`, res)
+}
@@ -0,0 +1,164 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeversions
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	c.nodes = make([]*nodeVersion, len(c.connections))
+
+	// Nodes are queried in parallel, as some of them may be slow to respond.
+	var wg sync.WaitGroup
+	for i, connection := range c.connections {
+		wg.Add(1)
+		go func(i int, connection string) {
+			defer wg.Done()
+			version := &nodeVersion{
+				Address: connection,
+			}
+			if err := c.obtainNodeVersion(ctx, version); err != nil {
+				if c.debug {
+					fmt.Printf("Failed to obtain version from %s: %v\n", connection, err)
+				}
+				version.Error = err.Error()
+			}
+			c.nodes[i] = version
+		}(i, connection)
+	}
+	wg.Wait()
+
+	c.mismatches = mismatches(c.nodes)
+
+	return nil
+}
+
+func (c *command) obtainNodeVersion(ctx context.Context, version *nodeVersion) error {
+	client, err := util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       version.Address,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   false,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	versionResponse, err := client.(eth2client.NodeVersionProvider).NodeVersion(ctx, &api.NodeVersionOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain node version")
+	}
+	version.Version = versionResponse.Data
+
+	if provider, isProvider := client.(eth2client.NodeClientProvider); isProvider {
+		clientResponse, err := provider.NodeClient(ctx)
+		if err == nil {
+			version.Client = clientResponse.Data
+		}
+	}
+
+	genesisResponse, err := client.(eth2client.GenesisProvider).Genesis(ctx, &api.GenesisOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain genesis")
+	}
+	forkResponse, err := client.(eth2client.ForkProvider).Fork(ctx, &api.ForkOpts{
+		State: "head",
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain fork")
+	}
+	forkDataRoot, err := (&phase0.ForkData{
+		CurrentVersion:        forkResponse.Data.CurrentVersion,
+		GenesisValidatorsRoot: genesisResponse.Data.GenesisValidatorsRoot,
+	}).HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate fork digest")
+	}
+	copy(version.ForkDigest[:], forkDataRoot[:])
+
+	forkScheduleResponse, err := client.(eth2client.ForkScheduleProvider).ForkSchedule(ctx, &api.ForkScheduleOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain fork schedule")
+	}
+	version.LatestFork = latestFork(forkScheduleResponse.Data)
+
+	return nil
+}
+
+// latestFork returns the fork with the highest epoch in the schedule.
+func latestFork(schedule []*phase0.Fork) *phase0.Fork {
+	var res *phase0.Fork
+	for _, fork := range schedule {
+		if res == nil || fork.Epoch > res.Epoch {
+			res = fork
+		}
+	}
+
+	return res
+}
+
+// mismatches returns a list of human-readable differences between the nodes'
+// forks, as these would cause the nodes to diverge.
+func mismatches(nodes []*nodeVersion) []string {
+	res := make([]string, 0)
+
+	var reference *nodeVersion
+	for _, node := range nodes {
+		if node.Error != "" {
+			res = append(res, fmt.Sprintf("%s could not be queried: %s", node.Address, node.Error))
+			continue
+		}
+		if reference == nil {
+			reference = node
+			continue
+		}
+		if node.ForkDigest != reference.ForkDigest {
+			res = append(res, fmt.Sprintf("%s has fork digest %#x, %s has fork digest %#x",
+				reference.Address, reference.ForkDigest,
+				node.Address, node.ForkDigest))
+		}
+		if !sameFork(node.LatestFork, reference.LatestFork) {
+			res = append(res, fmt.Sprintf("%s has latest scheduled fork %s, %s has latest scheduled fork %s",
+				reference.Address, forkText(reference.LatestFork),
+				node.Address, forkText(node.LatestFork)))
+		}
+	}
+
+	return res
+}
+
+func sameFork(fork1 *phase0.Fork, fork2 *phase0.Fork) bool {
+	if fork1 == nil || fork2 == nil {
+		return fork1 == fork2
+	}
+
+	return fork1.CurrentVersion == fork2.CurrentVersion && fork1.Epoch == fork2.Epoch
+}
+
+func forkText(fork *phase0.Fork) string {
+	if fork == nil {
+		return "none"
+	}
+
+	return fmt.Sprintf("%#x (epoch %d)", fork.CurrentVersion, fork.Epoch)
+}
@@ -0,0 +1,87 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeversions
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestFork(t *testing.T) {
+	require.Nil(t, latestFork(nil))
+	require.Equal(t, &phase0.Fork{CurrentVersion: phase0.Version{0x04}, Epoch: 200},
+		latestFork([]*phase0.Fork{
+			{CurrentVersion: phase0.Version{0x00}, Epoch: 0},
+			{CurrentVersion: phase0.Version{0x04}, Epoch: 200},
+			{CurrentVersion: phase0.Version{0x03}, Epoch: 100},
+		}))
+}
+
+func TestMismatches(t *testing.T) {
+	denebFork := &phase0.Fork{CurrentVersion: phase0.Version{0x04}, Epoch: 200}
+	electraFork := &phase0.Fork{CurrentVersion: phase0.Version{0x05}, Epoch: 300}
+
+	tests := []struct {
+		name     string
+		nodes    []*nodeVersion
+		expected []string
+	}{
+		{
+			name: "Agree",
+			nodes: []*nodeVersion{
+				{Address: "a", ForkDigest: phase0.ForkDigest{0x01}, LatestFork: electraFork},
+				{Address: "b", ForkDigest: phase0.ForkDigest{0x01}, LatestFork: electraFork},
+			},
+			expected: []string{},
+		},
+		{
+			name: "Error",
+			nodes: []*nodeVersion{
+				{Address: "a", Error: "failed"},
+				{Address: "b", ForkDigest: phase0.ForkDigest{0x01}, LatestFork: electraFork},
+			},
+			expected: []string{
+				"a could not be queried: failed",
+			},
+		},
+		{
+			name: "NotUpgraded",
+			nodes: []*nodeVersion{
+				{Address: "a", ForkDigest: phase0.ForkDigest{0x01}, LatestFork: electraFork},
+				{Address: "b", ForkDigest: phase0.ForkDigest{0x01}, LatestFork: denebFork},
+			},
+			expected: []string{
+				"a has latest scheduled fork 0x05000000 (epoch 300), b has latest scheduled fork 0x04000000 (epoch 200)",
+			},
+		},
+		{
+			name: "ForkDigest",
+			nodes: []*nodeVersion{
+				{Address: "a", ForkDigest: phase0.ForkDigest{0x01}, LatestFork: electraFork},
+				{Address: "b", ForkDigest: phase0.ForkDigest{0x02}, LatestFork: electraFork},
+			},
+			expected: []string{
+				"a has fork digest 0x01000000, b has fork digest 0x02000000",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, mismatches(test.nodes))
+		})
+	}
+}
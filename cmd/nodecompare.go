@@ -0,0 +1,57 @@
+// Copyright © 2024 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	nodecompare "github.com/wealdtech/ethdo/cmd/node/compare"
+)
+
+var nodeCompareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "Compare the state of the chain across multiple nodes",
+	Long: `Compare the head, finalized checkpoint, fork digest and validator counts across multiple nodes.  For example:
+
+    ethdo node compare --connections=http://node1:5052,http://node2:5052,http://node3:5052
+
+In quiet mode this will return 0 if all nodes agree, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := nodecompare.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	nodeCmd.AddCommand(nodeCompareCmd)
+	nodeFlags(nodeCompareCmd)
+	nodeCompareCmd.Flags().String("connections", "", "comma-separated list of beacon node connections to compare")
+}
+
+func nodeCompareBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("connections", cmd.Flags().Lookup("connections")); err != nil {
+		panic(err)
+	}
+}
@@ -14,51 +14,33 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
-	"os"
 
-	eth2client "github.com/attestantio/go-eth2-client"
-	"github.com/attestantio/go-eth2-client/api"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"github.com/wealdtech/ethdo/util"
+	nodeinfo "github.com/wealdtech/ethdo/cmd/node/info"
 )
 
 var nodeInfoCmd = &cobra.Command{
 	Use:   "info",
 	Short: "Obtain information about a node",
-	Long: `Obtain information about a node.  For example:
+	Long: `Obtain information about a node, including its version, sync state, peers and the optional APIs that it supports.  For example:
 
     ethdo node info
 
 In quiet mode this will return 0 if the node information can be obtained, otherwise 1.`,
-	Run: func(_ *cobra.Command, _ []string) {
-		ctx := context.Background()
-
-		eth2Client, err := util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
-			Address:       viper.GetString("connection"),
-			Timeout:       viper.GetDuration("timeout"),
-			AllowInsecure: viper.GetBool("allow-insecure-connections"),
-			LogFallback:   !viper.GetBool("quiet"),
-		})
-		errCheck(err, "Failed to connect to Ethereum 2 beacon node")
-
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := nodeinfo.Run(cmd)
+		if err != nil {
+			return err
+		}
 		if viper.GetBool("quiet") {
-			os.Exit(_exitSuccess)
+			return nil
 		}
-
-		if viper.GetBool("verbose") {
-			versionResponse, err := eth2Client.(eth2client.NodeVersionProvider).NodeVersion(ctx, &api.NodeVersionOpts{})
-			errCheck(err, "Failed to obtain node version")
-			fmt.Printf("Version: %s\n", versionResponse.Data)
+		if res != "" {
+			fmt.Println(res)
 		}
-
-		syncStateResponse, err := eth2Client.(eth2client.NodeSyncingProvider).NodeSyncing(ctx, &api.NodeSyncingOpts{})
-		errCheck(err, "failed to obtain node sync state")
-		fmt.Printf("Syncing: %t\n", syncStateResponse.Data.SyncDistance != 0)
-
-		os.Exit(_exitSuccess)
+		return nil
 	},
 }
 
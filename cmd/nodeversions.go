@@ -0,0 +1,59 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	nodeversions "github.com/wealdtech/ethdo/cmd/node/versions"
+)
+
+var nodeVersionsCmd = &cobra.Command{
+	Use:   "versions",
+	Short: "Show the versions of multiple nodes",
+	Long: `Show the client, version, fork digest and latest scheduled fork of multiple nodes.  For example:
+
+    ethdo node versions --connections=http://node1:5052,http://node2:5052,http://node3:5052
+
+Nodes that have been upgraded for a forthcoming fork will include it in their fork schedule.
+
+In quiet mode this will return 0 if all nodes agree on their forks, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := nodeversions.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	nodeCmd.AddCommand(nodeVersionsCmd)
+	nodeFlags(nodeVersionsCmd)
+	nodeVersionsCmd.Flags().String("connections", "", "comma-separated list of beacon node connections")
+}
+
+func nodeVersionsBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("connections", cmd.Flags().Lookup("connections")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// runProfiles runs the command once for each of the supplied comma-separated
+// profiles concurrently, outputs the results namespaced by profile, and exits.
+func runProfiles(ctx context.Context, profiles string) {
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(profiles, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		if !util.ProfileExists(name) {
+			die(fmt.Sprintf("unknown profile %s", name))
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	assert(len(names) > 0, "no profiles supplied")
+
+	executable, err := os.Executable()
+	errCheck(err, "Failed to obtain executable")
+
+	results := util.RunProfiles(ctx, executable, util.ProfileArgs(os.Args[1:]), names)
+
+	failed := false
+	for _, result := range results {
+		if result.Err != nil {
+			failed = true
+		}
+	}
+
+	if !viper.GetBool("quiet") {
+		if viper.GetBool("json") {
+			fmt.Println(profileResultsJSON(results))
+		} else {
+			fmt.Print(profileResultsText(results))
+		}
+	}
+
+	if failed {
+		os.Exit(_exitFailure)
+	}
+	os.Exit(_exitSuccess)
+}
+
+// profileResultsJSON returns a JSON object keyed by profile, in the order
+// in which the profiles were supplied.
+func profileResultsJSON(results []*util.ProfileResult) string {
+	builder := new(bytes.Buffer)
+	builder.WriteString("{")
+	for i, result := range results {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		name, err := json.Marshal(result.Profile)
+		errCheck(err, "Failed to marshal profile")
+		builder.Write(name)
+		builder.WriteString(":")
+
+		stdout := bytes.TrimSpace(result.Stdout)
+		switch {
+		case result.Err != nil:
+			data, err := json.Marshal(map[string]string{"error": profileError(result)})
+			errCheck(err, "Failed to marshal error")
+			builder.Write(data)
+		case json.Valid(stdout):
+			builder.Write(stdout)
+		default:
+			data, err := json.Marshal(string(stdout))
+			errCheck(err, "Failed to marshal output")
+			builder.Write(data)
+		}
+	}
+	builder.WriteString("}")
+
+	return builder.String()
+}
+
+// profileResultsText returns the output for each profile under a heading
+// of the profile's name.
+func profileResultsText(results []*util.ProfileResult) string {
+	builder := new(strings.Builder)
+	for _, result := range results {
+		builder.WriteString(fmt.Sprintf("%s:\n", result.Profile))
+		output := strings.TrimRight(string(result.Stdout), "\n")
+		if result.Err != nil {
+			output = strings.TrimRight(fmt.Sprintf("%s\n%s", output, profileError(result)), "\n")
+			output = strings.TrimLeft(output, "\n")
+		}
+		for _, line := range strings.Split(output, "\n") {
+			if line == "" {
+				builder.WriteString("\n")
+				continue
+			}
+			builder.WriteString(fmt.Sprintf("  %s\n", line))
+		}
+	}
+
+	return builder.String()
+}
+
+// profileError returns the error for a profile, preferring any error
+// output from the command itself.
+func profileError(result *util.ProfileResult) string {
+	if stderr := strings.TrimSpace(string(result.Stderr)); stderr != "" {
+		return stderr
+	}
+
+	return result.Err.Error()
+}
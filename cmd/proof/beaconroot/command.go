@@ -0,0 +1,109 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proofbeaconroot
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Execution node connection.
+	connectionExecution string
+
+	// Input.
+	timestamp  uint64
+	validator  string
+	field      string
+	proofInput string
+
+	// Data access.
+	eth2Client      eth2client.Service
+	executionClient *util.ExecutionClient
+
+	// Output.
+	summary *summary
+}
+
+type summary struct {
+	Timestamp       uint64      `json:"timestamp"`
+	BeaconBlockRoot phase0.Root `json:"beacon_block_root"`
+	// Slot, ValidatorIndex and Field are present if a proof was generated.
+	Slot           *phase0.Slot           `json:"slot,omitempty"`
+	ValidatorIndex *phase0.ValidatorIndex `json:"validator_index,omitempty"`
+	Field          string                 `json:"field,omitempty"`
+	// Verified and Reason are present if a proof was verified.
+	Verified *bool  `json:"verified,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+	*util.MerkleProof
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	if viper.GetString("connection-execution") == "" {
+		return nil, errors.New("connection-execution is required")
+	}
+	c.connectionExecution = viper.GetString("connection-execution")
+
+	if viper.GetUint64("timestamp") == 0 {
+		return nil, errors.New("timestamp is required")
+	}
+	c.timestamp = viper.GetUint64("timestamp")
+
+	c.validator = viper.GetString("validator")
+	c.proofInput = viper.GetString("proof")
+	if c.validator != "" && c.proofInput != "" {
+		return nil, errors.New("only one of validator and proof can be supplied")
+	}
+
+	if c.validator != "" {
+		c.field = viper.GetString("field")
+		if _, err := util.ValidatorProofPath(c.field, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
@@ -0,0 +1,111 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proofbeaconroot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"connection-execution": "http://localhost:8545",
+				"timestamp":            "1700000000",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "ConnectionExecutionMissing",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"timestamp": "1700000000",
+			},
+			err: "connection-execution is required",
+		},
+		{
+			name: "TimestampMissing",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"connection-execution": "http://localhost:8545",
+			},
+			err: "timestamp is required",
+		},
+		{
+			name: "ValidatorAndProof",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"connection-execution": "http://localhost:8545",
+				"timestamp":            "1700000000",
+				"validator":            "1",
+				"proof":                "{}",
+			},
+			err: "only one of validator and proof can be supplied",
+		},
+		{
+			name: "FieldInvalid",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"connection-execution": "http://localhost:8545",
+				"timestamp":            "1700000000",
+				"validator":            "1",
+				"field":                "invalid",
+			},
+			err: `field must be one of "validator", "balance" or "withdrawal-credentials"`,
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"connection-execution": "http://localhost:8545",
+				"timestamp":            "1700000000",
+			},
+		},
+		{
+			name: "GoodValidator",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"connection-execution": "http://localhost:8545",
+				"timestamp":            "1700000000",
+				"validator":            "1",
+				"field":                "withdrawal-credentials",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
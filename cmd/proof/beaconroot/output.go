@@ -0,0 +1,78 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proofbeaconroot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.summary)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Timestamp: %d\n", c.summary.Timestamp))
+	}
+	builder.WriteString(fmt.Sprintf("Beacon block root: %#x\n", c.summary.BeaconBlockRoot))
+
+	switch {
+	case c.summary.Verified != nil:
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("Leaf: %#x\n", c.summary.Leaf))
+			builder.WriteString(fmt.Sprintf("Generalized index: %d\n", c.summary.GeneralizedIndex))
+		}
+		if *c.summary.Verified {
+			builder.WriteString("Verified\n")
+		} else {
+			builder.WriteString(fmt.Sprintf("Not verified: %s\n", c.summary.Reason))
+		}
+	case c.summary.MerkleProof != nil:
+		builder.WriteString(fmt.Sprintf("Slot: %d\n", *c.summary.Slot))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("Validator: %d\n", *c.summary.ValidatorIndex))
+			builder.WriteString(fmt.Sprintf("Field: %s\n", c.summary.Field))
+		}
+		builder.WriteString(fmt.Sprintf("Leaf: %#x\n", c.summary.Leaf))
+		builder.WriteString(fmt.Sprintf("Generalized index: %d\n", c.summary.GeneralizedIndex))
+		builder.WriteString("Branch:\n")
+		for _, node := range c.summary.Branch {
+			builder.WriteString(fmt.Sprintf("  %#x\n", node))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,136 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proofbeaconroot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestOutput(t *testing.T) {
+	slot := phase0.Slot(100)
+	index := phase0.ValidatorIndex(5)
+	verified := true
+	proof := &util.MerkleProof{
+		Leaf:             phase0.Root{0x01},
+		Branch:           []phase0.Root{{0x02}, {0x03}},
+		GeneralizedIndex: 6,
+		Root:             phase0.Root{0x04},
+	}
+
+	tests := []struct {
+		name    string
+		command *command
+		res     string
+	}{
+		{
+			name: "Quiet",
+			command: &command{
+				quiet: true,
+				summary: &summary{
+					Timestamp:       1700000000,
+					BeaconBlockRoot: phase0.Root{0x04},
+				},
+			},
+		},
+		{
+			name: "Root",
+			command: &command{
+				summary: &summary{
+					Timestamp:       1700000000,
+					BeaconBlockRoot: phase0.Root{0x04},
+				},
+			},
+			res: "Beacon block root: 0x0400000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name: "Proof",
+			command: &command{
+				verbose: true,
+				summary: &summary{
+					Timestamp:       1700000000,
+					BeaconBlockRoot: phase0.Root{0x04},
+					Slot:            &slot,
+					ValidatorIndex:  &index,
+					Field:           util.ValidatorProofFieldWithdrawalCredentials,
+					MerkleProof:     proof,
+				},
+			},
+			res: `Timestamp: 1700000000
+Beacon block root: 0x0400000000000000000000000000000000000000000000000000000000000000
+Slot: 100
+Validator: 5
+Field: withdrawal-credentials
+Leaf: 0x0100000000000000000000000000000000000000000000000000000000000000
+Generalized index: 6
+Branch:
+  0x0200000000000000000000000000000000000000000000000000000000000000
+  0x0300000000000000000000000000000000000000000000000000000000000000`,
+		},
+		{
+			name: "Verified",
+			command: &command{
+				summary: &summary{
+					Timestamp:       1700000000,
+					BeaconBlockRoot: phase0.Root{0x04},
+					Verified:        &verified,
+					MerkleProof:     proof,
+				},
+			},
+			res: `Beacon block root: 0x0400000000000000000000000000000000000000000000000000000000000000
+Verified`,
+		},
+		{
+			name: "NotVerified",
+			command: &command{
+				summary: &summary{
+					Timestamp:       1700000000,
+					BeaconBlockRoot: phase0.Root{0x04},
+					Verified:        new(bool),
+					Reason:          "proof root does not match beacon block root",
+					MerkleProof:     proof,
+				},
+			},
+			res: `Beacon block root: 0x0400000000000000000000000000000000000000000000000000000000000000
+Not verified: proof root does not match beacon block root`,
+		},
+		{
+			name: "JSON",
+			command: &command{
+				json: true,
+				summary: &summary{
+					Timestamp:       1700000000,
+					BeaconBlockRoot: phase0.Root{0x04},
+					Slot:            &slot,
+					ValidatorIndex:  &index,
+					Field:           util.ValidatorProofFieldValidator,
+					MerkleProof:     proof,
+				},
+			},
+			res: `{"timestamp":1700000000,"beacon_block_root":"0x0400000000000000000000000000000000000000000000000000000000000000","slot":"100","validator_index":"5","field":"validator","leaf":"0x0100000000000000000000000000000000000000000000000000000000000000","branch":["0x0200000000000000000000000000000000000000000000000000000000000000","0x0300000000000000000000000000000000000000000000000000000000000000"],"gindex":6,"root":"0x0400000000000000000000000000000000000000000000000000000000000000"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.command.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
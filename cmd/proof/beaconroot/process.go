@@ -0,0 +1,172 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proofbeaconroot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	root, err := c.executionClient.BeaconBlockRoot(ctx, c.timestamp)
+	if err != nil {
+		return err
+	}
+	c.summary = &summary{
+		Timestamp:       c.timestamp,
+		BeaconBlockRoot: root,
+	}
+
+	switch {
+	case c.validator != "":
+		return c.generateProof(ctx)
+	case c.proofInput != "":
+		return c.verifyProof(ctx)
+	default:
+		return nil
+	}
+}
+
+// generateProof generates a proof of the validator's field against the beacon block root.
+func (c *command) generateProof(ctx context.Context) error {
+	if err := c.setupBeaconNode(ctx); err != nil {
+		return err
+	}
+
+	headerResponse, err := c.eth2Client.(eth2client.BeaconBlockHeadersProvider).BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{
+		Block: c.summary.BeaconBlockRoot.String(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain block header")
+	}
+	header := headerResponse.Data.Header.Message
+
+	slot := header.Slot
+	tree, err := util.ObtainBeaconStateTree(ctx, c.eth2Client, fmt.Sprintf("%d", slot))
+	if err != nil {
+		return err
+	}
+	stateRoot, err := tree.Root()
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate state root")
+	}
+	if stateRoot != header.StateRoot {
+		return fmt.Errorf("state root %#x at slot %d does not match block state root %#x", stateRoot, slot, header.StateRoot)
+	}
+
+	validator, err := util.ParseValidator(ctx, c.eth2Client.(eth2client.ValidatorsProvider), c.validator, fmt.Sprintf("%d", slot))
+	if err != nil {
+		return err
+	}
+	path, err := util.ValidatorProofPath(c.field, validator.Index)
+	if err != nil {
+		return err
+	}
+	stateProof, err := tree.Prove(path...)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate proof")
+	}
+	headerProof, err := util.ProveBeaconBlockHeaderStateRoot(header)
+	if err != nil {
+		return err
+	}
+	proof, err := stateProof.Chain(headerProof)
+	if err != nil {
+		return err
+	}
+	if proof.Root != c.summary.BeaconBlockRoot {
+		return errors.New("proof root does not match beacon block root")
+	}
+
+	c.summary.Slot = &slot
+	c.summary.ValidatorIndex = &validator.Index
+	c.summary.Field = c.field
+	c.summary.MerkleProof = proof
+
+	return nil
+}
+
+// verifyProof verifies the supplied proof against the beacon block root.
+func (c *command) verifyProof(_ context.Context) error {
+	input := c.proofInput
+	if !strings.HasPrefix(input, "{") {
+		// This looks like a file; read it in.
+		data, err := os.ReadFile(input)
+		if err != nil {
+			return errors.Wrap(err, "failed to read proof file")
+		}
+		input = string(data)
+	}
+	if err := json.Unmarshal([]byte(input), &c.summary.MerkleProof); err != nil {
+		return errors.Wrap(err, "failed to parse proof")
+	}
+
+	verified := false
+	switch {
+	case c.summary.Root != c.summary.BeaconBlockRoot:
+		c.summary.Reason = "proof root does not match beacon block root"
+	case !c.summary.MerkleProof.Verify():
+		c.summary.Reason = "proof branch does not lead from leaf to root"
+	default:
+		verified = true
+	}
+	c.summary.Verified = &verified
+
+	if !verified && c.quiet {
+		return errors.New("proof not verified")
+	}
+
+	return nil
+}
+
+func (c *command) setup(_ context.Context) error {
+	var err error
+
+	c.executionClient, err = util.NewExecutionClient(c.connectionExecution)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *command) setupBeaconNode(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	return nil
+}
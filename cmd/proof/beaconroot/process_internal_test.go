@@ -0,0 +1,139 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proofbeaconroot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	leaf := phase0.Root{0x01}
+	sibling := phase0.Root{0x02}
+	root := phase0.Root(sha256.Sum256(append(leaf[:], sibling[:]...)))
+	proof := fmt.Sprintf(`{"leaf":"%#x","branch":["%#x"],"gindex":2,"root":"%#x"}`, leaf, sibling, root)
+	badProof := fmt.Sprintf(`{"leaf":"%#x","branch":["%#x"],"gindex":3,"root":"%#x"}`, leaf, sibling, root)
+	otherProof := fmt.Sprintf(`{"leaf":"%#x","branch":["%#x"],"gindex":2,"root":"%#x"}`, leaf, sibling, phase0.Root{0x03})
+
+	// Execution node that returns the root for a single timestamp.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     uint64            `json:"id"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		var call map[string]string
+		require.NoError(t, json.Unmarshal(req.Params[0], &call))
+		if call["data"] != "0x000000000000000000000000000000000000000000000000000000006553f100" {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"execution reverted"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":"%#x"}`, req.ID, root)))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		command  *command
+		verified *bool
+		reason   string
+		err      string
+	}{
+		{
+			name: "TimestampUnknown",
+			command: &command{
+				connectionExecution: server.URL,
+				timestamp:           1,
+			},
+			err: "failed to obtain beacon block root for timestamp 1: execution node returned error -32000: execution reverted",
+		},
+		{
+			name: "RootOnly",
+			command: &command{
+				connectionExecution: server.URL,
+				timestamp:           1700000000,
+			},
+		},
+		{
+			name: "ProofInvalid",
+			command: &command{
+				connectionExecution: server.URL,
+				timestamp:           1700000000,
+				proofInput:          "{",
+			},
+			err: "failed to parse proof: unexpected end of JSON input",
+		},
+		{
+			name: "ProofVerified",
+			command: &command{
+				connectionExecution: server.URL,
+				timestamp:           1700000000,
+				proofInput:          proof,
+			},
+			verified: func() *bool { b := true; return &b }(),
+		},
+		{
+			name: "ProofBranchMismatch",
+			command: &command{
+				connectionExecution: server.URL,
+				timestamp:           1700000000,
+				proofInput:          badProof,
+			},
+			verified: new(bool),
+			reason:   "proof branch does not lead from leaf to root",
+		},
+		{
+			name: "ProofRootMismatch",
+			command: &command{
+				connectionExecution: server.URL,
+				timestamp:           1700000000,
+				proofInput:          otherProof,
+			},
+			verified: new(bool),
+			reason:   "proof root does not match beacon block root",
+		},
+		{
+			name: "ProofQuiet",
+			command: &command{
+				quiet:               true,
+				connectionExecution: server.URL,
+				timestamp:           1700000000,
+				proofInput:          otherProof,
+			},
+			err: "proof not verified",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.command.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, root, test.command.summary.BeaconBlockRoot)
+			require.Equal(t, test.verified, test.command.summary.Verified)
+			require.Equal(t, test.reason, test.command.summary.Reason)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proofgenerate
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	validator string
+	field     string
+	stateID   string
+	blockRoot bool
+
+	// Data access.
+	eth2Client eth2client.Service
+
+	// Output.
+	summary *summary
+}
+
+type summary struct {
+	Slot           phase0.Slot           `json:"slot"`
+	ValidatorIndex phase0.ValidatorIndex `json:"validator_index"`
+	Field          string                `json:"field"`
+	// StateRoot is the root of the state, present if the proof is against the block root.
+	StateRoot *phase0.Root `json:"state_root,omitempty"`
+	*util.MerkleProof
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	if viper.GetString("validator") == "" {
+		return nil, errors.New("validator is required")
+	}
+	c.validator = viper.GetString("validator")
+
+	c.field = viper.GetString("field")
+	if _, err := util.ValidatorProofPath(c.field, 0); err != nil {
+		return nil, err
+	}
+
+	c.stateID = viper.GetString("state")
+	if c.stateID == "" {
+		c.stateID = "head"
+	}
+	c.blockRoot = viper.GetBool("block-root")
+
+	return c, nil
+}
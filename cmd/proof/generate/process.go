@@ -0,0 +1,120 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proofgenerate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	tree, err := util.ObtainBeaconStateTree(ctx, c.eth2Client, c.stateID)
+	if err != nil {
+		return util.ExplainNodeCapabilityFailure(ctx, c.eth2Client, util.NodeCapabilityDebug, err)
+	}
+	slot := tree.Slot()
+
+	// Look up the validator in the same state as the proof, as named states
+	// such as "head" may have moved on since the state was obtained.
+	validator, err := util.ParseValidator(ctx, c.eth2Client.(eth2client.ValidatorsProvider), c.validator, fmt.Sprintf("%d", slot))
+	if err != nil {
+		return err
+	}
+
+	path, err := util.ValidatorProofPath(c.field, validator.Index)
+	if err != nil {
+		return err
+	}
+	proof, err := tree.Prove(path...)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate proof")
+	}
+
+	c.summary = &summary{
+		Slot:           slot,
+		ValidatorIndex: validator.Index,
+		Field:          c.field,
+		MerkleProof:    proof,
+	}
+
+	if c.blockRoot {
+		if err := c.chainToBlockRoot(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// chainToBlockRoot extends the proof from the state root to the root of the
+// block that produced the state.
+func (c *command) chainToBlockRoot(ctx context.Context) error {
+	headerResponse, err := c.eth2Client.(eth2client.BeaconBlockHeadersProvider).BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{
+		Block: fmt.Sprintf("%d", c.summary.Slot),
+	})
+	if err != nil {
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("no block at slot %d; cannot prove against the block root", c.summary.Slot)
+		}
+		return errors.Wrap(err, "failed to obtain block header")
+	}
+	header := headerResponse.Data.Header.Message
+	if header.StateRoot != c.summary.Root {
+		return fmt.Errorf("state at slot %d is not the post-state of a block; cannot prove against the block root", c.summary.Slot)
+	}
+
+	headerProof, err := util.ProveBeaconBlockHeaderStateRoot(header)
+	if err != nil {
+		return err
+	}
+	proof, err := c.summary.MerkleProof.Chain(headerProof)
+	if err != nil {
+		return err
+	}
+
+	stateRoot := c.summary.Root
+	c.summary.StateRoot = &stateRoot
+	c.summary.MerkleProof = proof
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	return nil
+}
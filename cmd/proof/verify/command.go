@@ -0,0 +1,92 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proofverify
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Input.
+	proofInput   string
+	expectedRoot *phase0.Root
+	expectedLeaf *phase0.Root
+
+	// Data.
+	proof *util.MerkleProof
+
+	// Output.
+	summary *summary
+}
+
+type summary struct {
+	Verified         bool        `json:"verified"`
+	Reason           string      `json:"reason,omitempty"`
+	Leaf             phase0.Root `json:"leaf"`
+	GeneralizedIndex uint64      `json:"gindex"`
+	Root             phase0.Root `json:"root"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	if viper.GetString("proof") == "" {
+		return nil, errors.New("proof is required")
+	}
+	c.proofInput = viper.GetString("proof")
+
+	var err error
+	c.expectedRoot, err = parseRoot("root", viper.GetString("root"))
+	if err != nil {
+		return nil, err
+	}
+	c.expectedLeaf, err = parseRoot("leaf", viper.GetString("leaf"))
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// parseRoot parses an optional root supplied as a hex string.
+func parseRoot(name string, input string) (*phase0.Root, error) {
+	if input == "" {
+		return nil, nil
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil || len(data) != phase0.RootLength {
+		return nil, fmt.Errorf("invalid %s", name)
+	}
+	root := phase0.Root(data)
+
+	return &root, nil
+}
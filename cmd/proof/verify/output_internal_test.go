@@ -0,0 +1,84 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proofverify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		command *command
+		res     string
+	}{
+		{
+			name: "Quiet",
+			command: &command{
+				quiet:   true,
+				summary: &summary{Verified: true},
+			},
+		},
+		{
+			name: "Verified",
+			command: &command{
+				summary: &summary{Verified: true},
+			},
+			res: "Verified",
+		},
+		{
+			name: "NotVerified",
+			command: &command{
+				summary: &summary{Reason: "proof root does not match expected root"},
+			},
+			res: "Not verified: proof root does not match expected root",
+		},
+		{
+			name: "Verbose",
+			command: &command{
+				verbose: true,
+				summary: &summary{
+					Verified:         true,
+					Leaf:             phase0.Root{0x01},
+					GeneralizedIndex: 2,
+					Root:             phase0.Root{0x02},
+				},
+			},
+			res: `Leaf: 0x0100000000000000000000000000000000000000000000000000000000000000
+Generalized index: 2
+Root: 0x0200000000000000000000000000000000000000000000000000000000000000
+Verified`,
+		},
+		{
+			name: "JSON",
+			command: &command{
+				json:    true,
+				summary: &summary{Reason: "proof root does not match expected root", GeneralizedIndex: 2},
+			},
+			res: `{"verified":false,"reason":"proof root does not match expected root","leaf":"0x0000000000000000000000000000000000000000000000000000000000000000","gindex":2,"root":"0x0000000000000000000000000000000000000000000000000000000000000000"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.command.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proofverify
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+func (c *command) process(_ context.Context) error {
+	if err := c.obtainProof(); err != nil {
+		return err
+	}
+
+	c.summary = &summary{
+		Leaf:             c.proof.Leaf,
+		GeneralizedIndex: c.proof.GeneralizedIndex,
+		Root:             c.proof.Root,
+	}
+	switch {
+	case c.expectedLeaf != nil && *c.expectedLeaf != c.proof.Leaf:
+		c.summary.Reason = "proof leaf does not match expected leaf"
+	case c.expectedRoot != nil && *c.expectedRoot != c.proof.Root:
+		c.summary.Reason = "proof root does not match expected root"
+	case !c.proof.Verify():
+		c.summary.Reason = "proof branch does not lead from leaf to root"
+	default:
+		c.summary.Verified = true
+	}
+
+	if !c.summary.Verified && c.quiet {
+		return errors.New("proof not verified")
+	}
+
+	return nil
+}
+
+// obtainProof obtains the proof from the input, which can be either JSON or
+// the name of a file containing JSON.
+func (c *command) obtainProof() error {
+	input := c.proofInput
+	if !strings.HasPrefix(input, "{") {
+		// This looks like a file; read it in.
+		data, err := os.ReadFile(input)
+		if err != nil {
+			return errors.Wrap(err, "failed to read proof file")
+		}
+		input = string(data)
+	}
+
+	if err := json.Unmarshal([]byte(input), &c.proof); err != nil {
+		return errors.Wrap(err, "failed to parse proof")
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proofverify
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	leaf := phase0.Root{0x01}
+	sibling := phase0.Root{0x02}
+	root := phase0.Root(sha256.Sum256(append(leaf[:], sibling[:]...)))
+	otherRoot := phase0.Root{0x03}
+	proof := fmt.Sprintf(`{"leaf":"%#x","branch":["%#x"],"gindex":2,"root":"%#x"}`, leaf, sibling, root)
+	badProof := fmt.Sprintf(`{"leaf":"%#x","branch":["%#x"],"gindex":3,"root":"%#x"}`, leaf, sibling, root)
+
+	proofFile := filepath.Join(t.TempDir(), "proof.json")
+	require.NoError(t, os.WriteFile(proofFile, []byte(proof), 0o600))
+
+	tests := []struct {
+		name     string
+		command  *command
+		verified bool
+		reason   string
+		err      string
+	}{
+		{
+			name: "ProofInvalid",
+			command: &command{
+				proofInput: "{",
+			},
+			err: "failed to parse proof: unexpected end of JSON input",
+		},
+		{
+			name: "FileMissing",
+			command: &command{
+				proofInput: filepath.Join(t.TempDir(), "missing.json"),
+			},
+			err: "failed to read proof file",
+		},
+		{
+			name: "Good",
+			command: &command{
+				proofInput: proof,
+			},
+			verified: true,
+		},
+		{
+			name: "GoodFile",
+			command: &command{
+				proofInput:   proofFile,
+				expectedRoot: &root,
+				expectedLeaf: &leaf,
+			},
+			verified: true,
+		},
+		{
+			name: "BranchMismatch",
+			command: &command{
+				proofInput: badProof,
+			},
+			reason: "proof branch does not lead from leaf to root",
+		},
+		{
+			name: "RootMismatch",
+			command: &command{
+				proofInput:   proof,
+				expectedRoot: &otherRoot,
+			},
+			reason: "proof root does not match expected root",
+		},
+		{
+			name: "LeafMismatch",
+			command: &command{
+				proofInput:   proof,
+				expectedLeaf: &otherRoot,
+			},
+			reason: "proof leaf does not match expected leaf",
+		},
+		{
+			name: "Quiet",
+			command: &command{
+				quiet:      true,
+				proofInput: badProof,
+			},
+			err: "proof not verified",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.command.process(context.Background())
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.verified, test.command.summary.Verified)
+			require.Equal(t, test.reason, test.command.summary.Reason)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	proofbeaconroot "github.com/wealdtech/ethdo/cmd/proof/beaconroot"
+)
+
+var proofBeaconRootCmd = &cobra.Command{
+	Use:   "beaconroot",
+	Short: "Obtain and prove against the EIP-4788 beacon block root of an execution block",
+	Long: `Obtain the parent beacon block root of an execution block from the EIP-4788 beacon block roots contract, and optionally generate or verify a proof against it.  For example:
+
+    ethdo proof beaconroot --connection-execution=http://localhost:8545 --timestamp=1700000000 --validator=12345 --field=withdrawal-credentials
+
+With --validator a proof of the validator's field is generated against the beacon block root, suitable for submission to contracts that verify consensus data against the EIP-4788 contract.  With --proof the supplied proof is verified against the beacon block root.
+
+The contract only holds the roots for around the last day of execution blocks.
+
+In quiet mode this will return 0 if the root is obtained and any supplied proof is verified, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := proofbeaconroot.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	proofCmd.AddCommand(proofBeaconRootCmd)
+	proofFlags(proofBeaconRootCmd)
+	proofBeaconRootCmd.Flags().String("connection-execution", "", "URL to an execution node, to obtain the beacon block root")
+	proofBeaconRootCmd.Flags().Uint64("timestamp", 0, "the timestamp of the execution block")
+	proofBeaconRootCmd.Flags().String("validator", "", "the index, public key, or account of the validator for which to generate a proof")
+	proofBeaconRootCmd.Flags().String("field", "validator", `the field to prove: "validator", "balance" or "withdrawal-credentials"`)
+	proofBeaconRootCmd.Flags().String("proof", "", "the proof to verify, or the name of a file containing the proof")
+}
+
+func proofBeaconRootBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("connection-execution", cmd.Flags().Lookup("connection-execution")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("timestamp", cmd.Flags().Lookup("timestamp")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("field", cmd.Flags().Lookup("field")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("proof", cmd.Flags().Lookup("proof")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	proofgenerate "github.com/wealdtech/ethdo/cmd/proof/generate"
+)
+
+var proofGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate a Merkle proof of validator data",
+	Long: `Generate a Merkle proof of a validator's record, balance or withdrawal credentials against the root of a beacon state.  For example:
+
+    ethdo proof generate --validator=12345 --field=withdrawal-credentials
+
+The state defaults to the head state.  With --block-root the proof is extended from the state root to the root of the block that produced the state, for example for use with the beacon block roots contract.
+
+Balances are packed four to a leaf, so the leaf of a balance proof contains the balances of four consecutive validators.
+
+This command supports Deneb and Electra states.
+
+In quiet mode this will return 0 if the proof can be generated, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := proofgenerate.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	proofCmd.AddCommand(proofGenerateCmd)
+	proofFlags(proofGenerateCmd)
+	proofGenerateCmd.Flags().String("validator", "", "the index, public key, or account of the validator")
+	proofGenerateCmd.Flags().String("field", "validator", `the field to prove: "validator", "balance" or "withdrawal-credentials"`)
+	proofGenerateCmd.Flags().String("state", "head", "the ID of the state against which to generate the proof")
+	proofGenerateCmd.Flags().Bool("block-root", false, "extend the proof to the root of the block that produced the state")
+}
+
+func proofGenerateBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("field", cmd.Flags().Lookup("field")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("state", cmd.Flags().Lookup("state")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("block-root", cmd.Flags().Lookup("block-root")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	proofverify "github.com/wealdtech/ethdo/cmd/proof/verify"
+)
+
+var proofVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify a Merkle proof",
+	Long: `Verify a Merkle proof, as generated by "proof generate".  For example:
+
+    ethdo proof verify --proof=proof.json --root=0x...
+
+The proof can be supplied either as JSON or as the name of a file containing JSON.  If a root or leaf is supplied then the proof must also match it; without a root the proof shows only that the leaf is part of the tree with the root given in the proof.
+
+In quiet mode this will return 0 if the proof is verified, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := proofverify.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	proofCmd.AddCommand(proofVerifyCmd)
+	proofFlags(proofVerifyCmd)
+	proofVerifyCmd.Flags().String("proof", "", "the proof, or the name of a file containing the proof")
+	proofVerifyCmd.Flags().String("root", "", "the root against which the proof must verify")
+	proofVerifyCmd.Flags().String("leaf", "", "the leaf that the proof must prove")
+}
+
+func proofVerifyBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("proof", cmd.Flags().Lookup("proof")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("root", cmd.Flags().Lookup("root")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("leaf", cmd.Flags().Lookup("leaf")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposerslashingcreate
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func (c *command) process(ctx context.Context) error {
+	header1, err := parseHeader(c.header1)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse header1")
+	}
+	header2, err := parseHeader(c.header2)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse header2")
+	}
+
+	if err := checkConflict(header1.Message, header2.Message); err != nil {
+		return err
+	}
+	c.slashing = &phase0.ProposerSlashing{
+		SignedHeader1: header1,
+		SignedHeader2: header2,
+	}
+
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	validators, err := util.FetchValidators(ctx, c.eth2Client.(eth2client.ValidatorsProvider), "head", []phase0.ValidatorIndex{header1.Message.ProposerIndex}, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validator")
+	}
+	validator, exists := validators[header1.Message.ProposerIndex]
+	if !exists {
+		return fmt.Errorf("proposer %d not known", header1.Message.ProposerIndex)
+	}
+	if !isSlashable(validator, c.chainTime.CurrentEpoch()) {
+		return fmt.Errorf("proposer %d is not slashable", header1.Message.ProposerIndex)
+	}
+
+	for i, header := range []*phase0.SignedBeaconBlockHeader{header1, header2} {
+		if err := c.verifySignature(ctx, header, validator); err != nil {
+			return errors.Wrapf(err, "failed to verify signature of header %d", i+1)
+		}
+	}
+
+	if c.submit {
+		submitter, isSubmitter := c.eth2Client.(eth2client.ProposalSlashingSubmitter)
+		if !isSubmitter {
+			return errors.New("connection does not support submitting proposer slashings")
+		}
+		if err := submitter.SubmitProposalSlashing(ctx, c.slashing); err != nil {
+			return errors.Wrap(err, "failed to submit proposer slashing")
+		}
+		c.submitted = true
+	}
+
+	return nil
+}
+
+// parseHeader parses a signed beacon block header supplied as JSON,
+// hex-encoded SSZ or the name of a file containing either.
+func parseHeader(input string) (*phase0.SignedBeaconBlockHeader, error) {
+	input = strings.TrimSpace(input)
+	if !strings.HasPrefix(input, "{") && !strings.HasPrefix(input, "0x") {
+		// This looks like a file; read it in.
+		data, err := os.ReadFile(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read header file")
+		}
+		input = strings.TrimSpace(string(data))
+	}
+
+	header := &phase0.SignedBeaconBlockHeader{}
+	if strings.HasPrefix(input, "0x") {
+		data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid hex")
+		}
+		if err := header.UnmarshalSSZ(data); err != nil {
+			return nil, errors.Wrap(err, "invalid SSZ header")
+		}
+
+		return header, nil
+	}
+
+	if err := json.Unmarshal([]byte(input), header); err != nil {
+		return nil, errors.Wrap(err, "invalid JSON header")
+	}
+
+	return header, nil
+}
+
+// checkConflict checks that the headers are for the same slot and proposer
+// but are not the same header.
+func checkConflict(header1 *phase0.BeaconBlockHeader, header2 *phase0.BeaconBlockHeader) error {
+	if header1.Slot != header2.Slot {
+		return fmt.Errorf("headers are for different slots (%d and %d)", header1.Slot, header2.Slot)
+	}
+	if header1.ProposerIndex != header2.ProposerIndex {
+		return fmt.Errorf("headers are for different proposers (%d and %d)", header1.ProposerIndex, header2.ProposerIndex)
+	}
+	root1, err := header1.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain root of header 1")
+	}
+	root2, err := header2.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain root of header 2")
+	}
+	if root1 == root2 {
+		return errors.New("headers are identical")
+	}
+
+	return nil
+}
+
+// isSlashable returns true if the validator is slashable at the given epoch.
+func isSlashable(validator *apiv1.Validator, epoch phase0.Epoch) bool {
+	return !validator.Validator.Slashed &&
+		validator.Validator.ActivationEpoch <= epoch &&
+		epoch < validator.Validator.WithdrawableEpoch
+}
+
+// verifySignature verifies the signature of the header against the proposer.
+func (c *command) verifySignature(ctx context.Context, header *phase0.SignedBeaconBlockHeader, validator *apiv1.Validator) error {
+	pubKey, err := e2types.BLSPublicKeyFromBytes(validator.Validator.PublicKey[:])
+	if err != nil {
+		return errors.Wrap(err, "invalid public key for proposer")
+	}
+
+	domain, err := c.domainProvider.Domain(ctx, c.proposerDomainType, c.chainTime.SlotToEpoch(header.Message.Slot))
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain proposer domain")
+	}
+	root, err := signingRoot(header.Message, domain)
+	if err != nil {
+		return err
+	}
+
+	signature, err := e2types.BLSSignatureFromBytes(header.Signature[:])
+	if err != nil {
+		return errors.Wrap(err, "invalid signature")
+	}
+	if !signature.Verify(root[:], pubKey) {
+		return errors.New("signature is invalid")
+	}
+
+	return nil
+}
+
+// signingRoot calculates the signing root of the header.
+func signingRoot(header *phase0.BeaconBlockHeader, domain phase0.Domain) (phase0.Root, error) {
+	objectRoot, err := header.HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, errors.Wrap(err, "failed to obtain header root")
+	}
+	root, err := (&phase0.SigningData{
+		ObjectRoot: objectRoot,
+		Domain:     domain,
+	}).HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, errors.Wrap(err, "failed to obtain signing root")
+	}
+
+	return root, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	if _, isProvider = c.eth2Client.(eth2client.ValidatorsProvider); !isProvider {
+		return errors.New("connection does not provide validators")
+	}
+	c.domainProvider, isProvider = c.eth2Client.(eth2client.DomainProvider)
+	if !isProvider {
+		return errors.New("connection does not provide domains")
+	}
+
+	specResponse, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	c.proposerDomainType, isProvider = specResponse.Data["DOMAIN_BEACON_PROPOSER"].(phase0.DomainType)
+	if !isProvider {
+		return errors.New("DOMAIN_BEACON_PROPOSER not found in spec")
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposerslashingcreate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHeader(t *testing.T) {
+	header := &phase0.SignedBeaconBlockHeader{
+		Message: &phase0.BeaconBlockHeader{
+			Slot:          100,
+			ProposerIndex: 5,
+			ParentRoot:    phase0.Root{0x01},
+			StateRoot:     phase0.Root{0x02},
+			BodyRoot:      phase0.Root{0x03},
+		},
+		Signature: phase0.BLSSignature{0x04},
+	}
+	ssz, err := header.MarshalSSZ()
+	require.NoError(t, err)
+	headerJSON := `{"message":{"slot":"100","proposer_index":"5","parent_root":"0x0100000000000000000000000000000000000000000000000000000000000000","state_root":"0x0200000000000000000000000000000000000000000000000000000000000000","body_root":"0x0300000000000000000000000000000000000000000000000000000000000000"},"signature":"0x040000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"}`
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "header.json")
+	require.NoError(t, os.WriteFile(file, []byte(headerJSON+"\n"), 0o600))
+
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "JSON",
+			input: headerJSON,
+		},
+		{
+			name:  "SSZ",
+			input: fmt.Sprintf("%#x", ssz),
+		},
+		{
+			name:  "File",
+			input: file,
+		},
+		{
+			name:  "FileMissing",
+			input: filepath.Join(dir, "missing.json"),
+			err:   "failed to read header file",
+		},
+		{
+			name:  "HexInvalid",
+			input: "0xzz",
+			err:   "invalid hex",
+		},
+		{
+			name:  "SSZInvalid",
+			input: "0x0102",
+			err:   "invalid SSZ header",
+		},
+		{
+			name:  "JSONInvalid",
+			input: `{"message":`,
+			err:   "invalid JSON header",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := parseHeader(test.input)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, header, res)
+			}
+		})
+	}
+}
+
+func TestCheckConflict(t *testing.T) {
+	header := func(slot phase0.Slot, proposer phase0.ValidatorIndex, root byte) *phase0.BeaconBlockHeader {
+		return &phase0.BeaconBlockHeader{
+			Slot:          slot,
+			ProposerIndex: proposer,
+			BodyRoot:      phase0.Root{root},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		header1 *phase0.BeaconBlockHeader
+		header2 *phase0.BeaconBlockHeader
+		err     string
+	}{
+		{
+			name:    "Conflicting",
+			header1: header(100, 5, 0x01),
+			header2: header(100, 5, 0x02),
+		},
+		{
+			name:    "DifferentSlots",
+			header1: header(100, 5, 0x01),
+			header2: header(101, 5, 0x02),
+			err:     "headers are for different slots (100 and 101)",
+		},
+		{
+			name:    "DifferentProposers",
+			header1: header(100, 5, 0x01),
+			header2: header(100, 6, 0x02),
+			err:     "headers are for different proposers (5 and 6)",
+		},
+		{
+			name:    "Identical",
+			header1: header(100, 5, 0x01),
+			header2: header(100, 5, 0x01),
+			err:     "headers are identical",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkConflict(test.header1, test.header2)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIsSlashable(t *testing.T) {
+	validator := func(slashed bool, activation phase0.Epoch, withdrawable phase0.Epoch) *apiv1.Validator {
+		return &apiv1.Validator{
+			Validator: &phase0.Validator{
+				Slashed:           slashed,
+				ActivationEpoch:   activation,
+				WithdrawableEpoch: withdrawable,
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		validator *apiv1.Validator
+		res       bool
+	}{
+		{
+			name:      "Active",
+			validator: validator(false, 5, 1000),
+			res:       true,
+		},
+		{
+			name:      "Slashed",
+			validator: validator(true, 5, 1000),
+		},
+		{
+			name:      "NotActivated",
+			validator: validator(false, 20, 1000),
+		},
+		{
+			name:      "Withdrawable",
+			validator: validator(false, 5, 10),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, isSlashable(test.validator, 10))
+		})
+	}
+}
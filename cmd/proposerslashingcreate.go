@@ -0,0 +1,67 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	proposerslashingcreate "github.com/wealdtech/ethdo/cmd/proposer/slashing/create"
+)
+
+var proposerSlashingCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a proposer slashing from conflicting block headers",
+	Long: `Create a proposer slashing from two conflicting signed block headers.  For example:
+
+    ethdo proposer slashing create --header1=header1.json --header2=header2.json
+
+Each header can be supplied as JSON, as hex-encoded SSZ, or as the name of a file containing either.  The headers must be for the same slot and proposer but differ, and both signatures must be valid.  The slashing is submitted to the beacon node's pool if --submit is supplied.
+
+In quiet mode this will return 0 if the slashing is created, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := proposerslashingcreate.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	proposerSlashingCmd.AddCommand(proposerSlashingCreateCmd)
+	proposerFlags(proposerSlashingCreateCmd)
+	proposerSlashingCreateCmd.Flags().String("header1", "", "the first conflicting signed block header")
+	proposerSlashingCreateCmd.Flags().String("header2", "", "the second conflicting signed block header")
+	proposerSlashingCreateCmd.Flags().Bool("submit", false, "submit the slashing to the beacon node")
+}
+
+func proposerSlashingCreateBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("header1", cmd.Flags().Lookup("header1")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("header2", cmd.Flags().Lookup("header2")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("submit", cmd.Flags().Lookup("submit")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relaycheck
+
+import (
+	"context"
+	"time"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	timeout time.Duration
+
+	// Input.
+	relays     []string
+	validators []string
+	maxAge     time.Duration
+	jsonOutput bool
+
+	// Results.
+	results []*validatorRegistrations
+}
+
+type validatorRegistrations struct {
+	PubKey phase0.BLSPubKey     `json:"pubkey"`
+	Relays []*relayRegistration `json:"relays"`
+}
+
+type relayRegistration struct {
+	Relay        string                             `json:"relay"`
+	Registered   bool                               `json:"registered"`
+	Current      bool                               `json:"current"`
+	Registration *apiv1.SignedValidatorRegistration `json:"registration,omitempty"`
+	Expires      *time.Time                         `json:"expires,omitempty"`
+	Error        string                             `json:"error,omitempty"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.relays = viper.GetStringSlice("relays")
+	if len(c.relays) == 0 {
+		return nil, errors.New("relays are required")
+	}
+	c.validators = viper.GetStringSlice("validators")
+	if len(c.validators) == 0 {
+		return nil, errors.New("validators are required")
+	}
+	c.maxAge = viper.GetDuration("max-age")
+	if c.maxAge == 0 {
+		return nil, errors.New("max age is required")
+	}
+
+	c.jsonOutput = viper.GetBool("json")
+
+	return c, nil
+}
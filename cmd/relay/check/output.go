@@ -0,0 +1,95 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relaycheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		if !c.allCurrent() {
+			os.Exit(1)
+		}
+		return "", nil
+	}
+
+	if c.jsonOutput {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputTxt(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.results)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	for i, result := range c.results {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		builder.WriteString(fmt.Sprintf("Validator %#x:\n", result.PubKey))
+		for _, relay := range result.Relays {
+			builder.WriteString(fmt.Sprintf("  %s: ", relay.Relay))
+			switch {
+			case relay.Error != "":
+				builder.WriteString(fmt.Sprintf("error: %s\n", relay.Error))
+			case !relay.Registered:
+				builder.WriteString("not registered\n")
+			default:
+				message := relay.Registration.Message
+				status := "registered"
+				expiry := "expires"
+				if !relay.Current {
+					status = "registration expired"
+					expiry = "expired"
+				}
+				builder.WriteString(fmt.Sprintf("%s with fee recipient %s and gas limit %d", status, message.FeeRecipient.String(), message.GasLimit))
+				if c.verbose {
+					builder.WriteString(fmt.Sprintf(", registered %s", message.Timestamp.Format("2006-01-02 15:04:05")))
+				}
+				builder.WriteString(fmt.Sprintf(", %s %s\n", expiry, relay.Expires.Format("2006-01-02 15:04:05")))
+			}
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// allCurrent returns true if all validators have current registrations
+// with all relays.
+func (c *command) allCurrent() bool {
+	for _, result := range c.results {
+		for _, relay := range result.Relays {
+			if !relay.Current {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,102 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relaycheck
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	timestamp := time.Unix(1700000000, 0)
+	expires := timestamp.Add(24 * time.Hour)
+	registration := &apiv1.SignedValidatorRegistration{
+		Message: &apiv1.ValidatorRegistration{
+			FeeRecipient: bellatrix.ExecutionAddress{0x8f, 0x08, 0x44, 0xfd, 0x51, 0xe3, 0x1f, 0xf6, 0xbf, 0x5b, 0xab, 0xe2, 0x1d, 0xcc, 0xf7, 0x32, 0x8e, 0x19, 0xfd, 0x9f},
+			GasLimit:     30000000,
+			Timestamp:    timestamp,
+			Pubkey:       phase0.BLSPubKey{0x01},
+		},
+	}
+	results := []*validatorRegistrations{
+		{
+			PubKey: phase0.BLSPubKey{0x01},
+			Relays: []*relayRegistration{
+				{
+					Relay:        "relay1.example.com",
+					Registered:   true,
+					Current:      true,
+					Registration: registration,
+					Expires:      &expires,
+				},
+				{
+					Relay:        "relay2.example.com",
+					Registered:   true,
+					Registration: registration,
+					Expires:      &expires,
+				},
+				{
+					Relay: "relay3.example.com",
+				},
+				{
+					Relay: "relay4.example.com",
+					Error: "relay returned status 500",
+				},
+			},
+		},
+	}
+
+	pubKey := "0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"
+	tests := []struct {
+		name    string
+		verbose bool
+		res     string
+	}{
+		{
+			name: "Text",
+			res: "Validator " + pubKey + ":\n" +
+				"  relay1.example.com: registered with fee recipient 0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F and gas limit 30000000, expires " + expires.Format("2006-01-02 15:04:05") + "\n" +
+				"  relay2.example.com: registration expired with fee recipient 0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F and gas limit 30000000, expired " + expires.Format("2006-01-02 15:04:05") + "\n" +
+				"  relay3.example.com: not registered\n" +
+				"  relay4.example.com: error: relay returned status 500",
+		},
+		{
+			name:    "TextVerbose",
+			verbose: true,
+			res: "Validator " + pubKey + ":\n" +
+				"  relay1.example.com: registered with fee recipient 0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F and gas limit 30000000, registered " + timestamp.Format("2006-01-02 15:04:05") + ", expires " + expires.Format("2006-01-02 15:04:05") + "\n" +
+				"  relay2.example.com: registration expired with fee recipient 0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F and gas limit 30000000, registered " + timestamp.Format("2006-01-02 15:04:05") + ", expired " + expires.Format("2006-01-02 15:04:05") + "\n" +
+				"  relay3.example.com: not registered\n" +
+				"  relay4.example.com: error: relay returned status 500",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				verbose: test.verbose,
+				results: results,
+			}
+			res, err := c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relaycheck
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	pubKeys, err := parsePubKeys(ctx, c.validators)
+	if err != nil {
+		return err
+	}
+
+	relays := make([]*url.URL, len(c.relays))
+	for i := range c.relays {
+		relays[i], err = util.ParseRelayURL(c.relays[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	c.results = make([]*validatorRegistrations, 0, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		result := &validatorRegistrations{
+			PubKey: pubKey,
+			Relays: make([]*relayRegistration, 0, len(relays)),
+		}
+		for _, relay := range relays {
+			registration := &relayRegistration{
+				Relay: relay.Host,
+			}
+			signedRegistration, err := fetchRegistration(ctx, relay, pubKey)
+			switch {
+			case errors.Is(err, context.DeadlineExceeded):
+				return err
+			case err != nil:
+				registration.Error = err.Error()
+			case signedRegistration != nil:
+				registration.Registered = true
+				registration.Registration = signedRegistration
+				expires := signedRegistration.Message.Timestamp.Add(c.maxAge)
+				registration.Expires = &expires
+				registration.Current = now.Before(expires)
+			}
+			result.Relays = append(result.Relays, registration)
+		}
+		c.results = append(c.results, result)
+	}
+
+	return nil
+}
+
+// parsePubKeys parses validators supplied as public keys or account
+// specifiers; an account specifier can match multiple accounts.
+func parsePubKeys(ctx context.Context, validators []string) ([]phase0.BLSPubKey, error) {
+	res := make([]phase0.BLSPubKey, 0, len(validators))
+	for _, validator := range validators {
+		switch {
+		case strings.HasPrefix(validator, "0x"):
+			data, err := hex.DecodeString(strings.TrimPrefix(validator, "0x"))
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to parse validator public key %s", validator)
+			}
+			if len(data) != phase0.PublicKeyLength {
+				return nil, fmt.Errorf("invalid length for validator public key %s", validator)
+			}
+			var pubKey phase0.BLSPubKey
+			copy(pubKey[:], data)
+			res = append(res, pubKey)
+		case strings.Contains(validator, "/"):
+			_, accounts, err := util.WalletAndAccountsFromPath(ctx, validator)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to obtain accounts for %s", validator)
+			}
+			for _, account := range accounts {
+				accPubKey, err := util.BestPublicKey(account)
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to obtain public key for account %s", account.Name())
+				}
+				var pubKey phase0.BLSPubKey
+				copy(pubKey[:], accPubKey.Marshal())
+				res = append(res, pubKey)
+			}
+		default:
+			return nil, fmt.Errorf("validator %s must be a public key or account specifier", validator)
+		}
+	}
+
+	return res, nil
+}
+
+// fetchRegistration fetches the registration for the validator held by the
+// relay, returning nil if the relay does not hold a registration.
+func fetchRegistration(ctx context.Context, relay *url.URL, pubKey phase0.BLSPubKey) (*apiv1.SignedValidatorRegistration, error) {
+	target := fmt.Sprintf("%s/relay/v1/data/validator_registration?pubkey=%#x", relay.String(), pubKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := util.HTTPClient().Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to call relay")
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read relay response")
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		registration := &apiv1.SignedValidatorRegistration{}
+		if err := json.Unmarshal(data, registration); err != nil {
+			return nil, errors.Wrap(err, "invalid registration")
+		}
+
+		return registration, nil
+	case resp.StatusCode == http.StatusNotFound,
+		resp.StatusCode == http.StatusBadRequest && strings.Contains(strings.ToLower(string(data)), "no registration"):
+		// Relays return either of these if they do not hold a registration.
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("relay returned status %d", resp.StatusCode)
+	}
+}
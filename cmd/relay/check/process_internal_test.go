@@ -0,0 +1,123 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package relaycheck
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	registeredPubKey   = "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+	unregisteredPubKey = "0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b"
+)
+
+func registrationServer(t *testing.T, timestamp int64) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/relay/v1/data/validator_registration" {
+			http.Error(w, `{"code":404,"message":"not found"}`, http.StatusNotFound)
+			return
+		}
+		switch r.URL.Query().Get("pubkey") {
+		case registeredPubKey:
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"message":{"fee_recipient":"0x8f0844fd51e31ff6bf5babe21dccf7328e19fd9f","gas_limit":"30000000","timestamp":"%d","pubkey":"%s"},"signature":"0xa39581ba5a7a5283de7b8d68fe932f0bb24eaf4ff5e4269e318c4d06bb972978ef888ac48091b82c9d0fe42c02c93d0205a05c3cdfaf1a923c78fda1fa866fb96028d40588d3339ca304fabfd573c8d6d1b6803174cfcc2527208cf64a8ec4db"}`, timestamp, registeredPubKey)))
+		case unregisteredPubKey:
+			http.Error(w, `{"code":400,"message":"no registration found for validator `+unregisteredPubKey+`"}`, http.StatusBadRequest)
+		default:
+			http.Error(w, `{"code":403,"message":"forbidden"}`, http.StatusForbidden)
+		}
+	}))
+}
+
+func TestParsePubKeys(t *testing.T) {
+	tests := []struct {
+		name       string
+		validators []string
+		res        int
+		err        string
+	}{
+		{
+			name:       "PubKeys",
+			validators: []string{registeredPubKey, unregisteredPubKey},
+			res:        2,
+		},
+		{
+			name:       "PubKeyShort",
+			validators: []string{"0xa99a76"},
+			err:        "invalid length for validator public key 0xa99a76",
+		},
+		{
+			name:       "Index",
+			validators: []string{"12345"},
+			err:        "validator 12345 must be a public key or account specifier",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := parsePubKeys(context.Background(), test.validators)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res, test.res)
+			}
+		})
+	}
+}
+
+func TestProcess(t *testing.T) {
+	now := time.Now().Unix()
+	current := registrationServer(t, now-60)
+	defer current.Close()
+	stale := registrationServer(t, now-2*24*60*60)
+	defer stale.Close()
+
+	c := &command{
+		timeout:    10 * time.Second,
+		relays:     []string{current.URL, stale.URL},
+		validators: []string{registeredPubKey, unregisteredPubKey, "0x" + strings.Repeat("00", phase0.PublicKeyLength)},
+		maxAge:     24 * time.Hour,
+	}
+	require.NoError(t, c.process(context.Background()))
+	require.Len(t, c.results, 3)
+
+	// Registered validator.
+	require.True(t, c.results[0].Relays[0].Registered)
+	require.True(t, c.results[0].Relays[0].Current)
+	require.Equal(t, uint64(30000000), c.results[0].Relays[0].Registration.Message.GasLimit)
+	require.Equal(t, now-60+24*60*60, c.results[0].Relays[0].Expires.Unix())
+	require.True(t, c.results[0].Relays[1].Registered)
+	require.False(t, c.results[0].Relays[1].Current)
+
+	// Unregistered validator.
+	require.False(t, c.results[1].Relays[0].Registered)
+	require.Empty(t, c.results[1].Relays[0].Error)
+
+	// Relay error.
+	require.False(t, c.results[2].Relays[0].Registered)
+	require.Equal(t, "relay returned status 403", c.results[2].Relays[0].Error)
+
+	require.False(t, c.allCurrent())
+}
@@ -0,0 +1,68 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	relaycheck "github.com/wealdtech/ethdo/cmd/relay/check"
+)
+
+var relayCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check validator registrations with relays",
+	Long: `Check the validator registrations held by MEV relays.  For example:
+
+    ethdo relay check --relays=https://relay1.example.com,https://relay2.example.com --validators=0xa99a...,0x8f0e...
+
+Validators can be supplied as public keys or account specifiers.  A registration is considered current if it was made within the time given by --max-age.
+
+In quiet mode this will return 0 if all of the validators have current registrations with all of the relays, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := relaycheck.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	relayCmd.AddCommand(relayCheckCmd)
+	relayFlags(relayCheckCmd)
+	relayCheckCmd.Flags().StringSlice("relays", nil, "the relays to check")
+	relayCheckCmd.Flags().StringSlice("validators", nil, "the validators for which to check registrations")
+	relayCheckCmd.Flags().Duration("max-age", 24*time.Hour, "the maximum age of a current registration")
+}
+
+func relayCheckBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("relays", cmd.Flags().Lookup("relays")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("max-age", cmd.Flags().Lookup("max-age")); err != nil {
+		panic(err)
+	}
+}
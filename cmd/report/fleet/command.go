@@ -0,0 +1,207 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reportfleet
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Operation.
+	accounts   []string
+	epoch      string
+	epochs     uint64
+	jsonOutput bool
+	csvOutput  bool
+
+	// Data access.
+	eth2Client                 eth2client.Service
+	chainTime                  chaintime.Service
+	proposerDutiesProvider     eth2client.ProposerDutiesProvider
+	attesterDutiesProvider     eth2client.AttesterDutiesProvider
+	blocksProvider             eth2client.SignedBeaconBlockProvider
+	syncCommitteesProvider     eth2client.SyncCommitteesProvider
+	validatorsProvider         eth2client.ValidatorsProvider
+	beaconBlockHeadersProvider eth2client.BeaconBlockHeadersProvider
+
+	// Processing.
+	validators map[phase0.ValidatorIndex]*validatorReport
+	blocks     map[phase0.Slot]*spec.VersionedSignedBeaconBlock
+	blocksMu   sync.Mutex
+
+	// Results.
+	report *fleetReport
+}
+
+type fleetReport struct {
+	Epoch             phase0.Epoch                       `json:"epoch"`
+	FirstEpoch        phase0.Epoch                       `json:"first_epoch"`
+	LastEpoch         phase0.Epoch                       `json:"last_epoch"`
+	Validators        int                                `json:"validators"`
+	UnknownValidators []string                           `json:"unknown_validators"`
+	Statuses          map[string]int                     `json:"statuses"`
+	Balance           phase0.Gwei                        `json:"balance"`
+	EffectiveBalance  phase0.Gwei                        `json:"effective_balance"`
+	Attestations      *attestationPerformance            `json:"attestations"`
+	Proposals         *dutyPerformance                   `json:"proposals"`
+	SyncCommittee     *dutyPerformance                   `json:"sync_committee"`
+	PendingDuties     *pendingDuties                     `json:"pending_duties"`
+	Alerts            map[string][]phase0.ValidatorIndex `json:"alerts"`
+	ValidatorReports  []*validatorReport                 `json:"validator_reports"`
+}
+
+type validatorReport struct {
+	Index            phase0.ValidatorIndex   `json:"index"`
+	PublicKey        phase0.BLSPubKey        `json:"public_key"`
+	Status           string                  `json:"status"`
+	Balance          phase0.Gwei             `json:"balance"`
+	EffectiveBalance phase0.Gwei             `json:"effective_balance"`
+	Attestations     *attestationPerformance `json:"attestations"`
+	Proposals        *dutyPerformance        `json:"proposals"`
+	SyncCommittee    *dutyPerformance        `json:"sync_committee"`
+	PendingProposals int                     `json:"pending_proposals"`
+	Alerts           []string                `json:"alerts"`
+
+	validator *phase0.Validator
+}
+
+type attestationPerformance struct {
+	Expected      int     `json:"expected"`
+	Included      int     `json:"included"`
+	CorrectHead   int     `json:"correct_head"`
+	CorrectTarget int     `json:"correct_target"`
+	Effectiveness float64 `json:"effectiveness"`
+
+	totalEffectiveness float64
+}
+
+type dutyPerformance struct {
+	Expected int `json:"expected"`
+	Included int `json:"included"`
+}
+
+type pendingDuties struct {
+	Proposals            []*pendingProposal      `json:"proposals"`
+	CurrentSyncCommittee []phase0.ValidatorIndex `json:"current_sync_committee"`
+	NextSyncCommittee    []phase0.ValidatorIndex `json:"next_sync_committee"`
+}
+
+type pendingProposal struct {
+	Slot      phase0.Slot           `json:"slot"`
+	Validator phase0.ValidatorIndex `json:"validator_index"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:      viper.GetBool("quiet"),
+		verbose:    viper.GetBool("verbose"),
+		debug:      viper.GetBool("debug"),
+		validators: make(map[phase0.ValidatorIndex]*validatorReport),
+		blocks:     make(map[phase0.Slot]*spec.VersionedSignedBeaconBlock),
+		report:     newFleetReport(),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.accounts = viper.GetStringSlice("accounts")
+	if viper.GetString("from-file") != "" {
+		if _, err := os.Stat(viper.GetString("from-file")); err != nil {
+			return nil, errors.Wrap(err, "failed to access validators file")
+		}
+		// Each line of the file is a validator.
+		fromFile, err := util.ExpandInputs([]string{viper.GetString("from-file")})
+		if err != nil {
+			return nil, err
+		}
+		c.accounts = append(c.accounts, fromFile...)
+	}
+	if len(c.accounts) == 0 {
+		return nil, errors.New("accounts or from-file is required")
+	}
+
+	c.epoch = viper.GetString("epoch")
+	if c.epoch == "" {
+		// Default to the most recent epoch for which all attestations can
+		// have been included.
+		c.epoch = "-2"
+	}
+	c.epochs = viper.GetUint64("epochs")
+	if c.epochs == 0 {
+		return nil, errors.New("epochs must be at least 1")
+	}
+
+	c.jsonOutput = viper.GetBool("json")
+	c.csvOutput = viper.GetBool("csv")
+	if c.jsonOutput && c.csvOutput {
+		return nil, errors.New("only one of json and csv output can be selected")
+	}
+
+	return c, nil
+}
+
+func newFleetReport() *fleetReport {
+	return &fleetReport{
+		UnknownValidators: make([]string, 0),
+		Statuses:          make(map[string]int),
+		Attestations:      &attestationPerformance{},
+		Proposals:         &dutyPerformance{},
+		SyncCommittee:     &dutyPerformance{},
+		PendingDuties: &pendingDuties{
+			Proposals:            make([]*pendingProposal, 0),
+			CurrentSyncCommittee: make([]phase0.ValidatorIndex, 0),
+			NextSyncCommittee:    make([]phase0.ValidatorIndex, 0),
+		},
+		Alerts:           make(map[string][]phase0.ValidatorIndex),
+		ValidatorReports: make([]*validatorReport, 0),
+	}
+}
+
+func newValidatorReport(index phase0.ValidatorIndex, validator *phase0.Validator) *validatorReport {
+	return &validatorReport{
+		Index:         index,
+		PublicKey:     validator.PublicKey,
+		Attestations:  &attestationPerformance{},
+		Proposals:     &dutyPerformance{},
+		SyncCommittee: &dutyPerformance{},
+		Alerts:        make([]string, 0),
+		validator:     validator,
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reportfleet
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	dir := t.TempDir()
+	fromFile := filepath.Join(dir, "validators.txt")
+	require.NoError(t, os.WriteFile(fromFile, []byte("1\n\n0x8000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000\n2-4\n"), 0o600))
+
+	tests := []struct {
+		name     string
+		vars     map[string]interface{}
+		accounts []string
+		err      string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"accounts": []string{"1"},
+				"epochs":   1,
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "AccountsMissing",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"epochs":  1,
+			},
+			err: "accounts or from-file is required",
+		},
+		{
+			name: "FromFileMissing",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"from-file": filepath.Join(dir, "missing.txt"),
+				"epochs":    1,
+			},
+			err: "failed to access validators file: stat " + filepath.Join(dir, "missing.txt") + ": no such file or directory",
+		},
+		{
+			name: "EpochsZero",
+			vars: map[string]interface{}{
+				"timeout":  "5s",
+				"accounts": []string{"1"},
+				"epochs":   0,
+			},
+			err: "epochs must be at least 1",
+		},
+		{
+			name: "JSONAndCSV",
+			vars: map[string]interface{}{
+				"timeout":  "5s",
+				"accounts": []string{"1"},
+				"epochs":   1,
+				"json":     true,
+				"csv":      true,
+			},
+			err: "only one of json and csv output can be selected",
+		},
+		{
+			name: "Accounts",
+			vars: map[string]interface{}{
+				"timeout":  "5s",
+				"accounts": []string{"1", "2"},
+				"epochs":   10,
+			},
+			accounts: []string{"1", "2"},
+		},
+		{
+			name: "FromFile",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"from-file": fromFile,
+				"epochs":    1,
+			},
+			accounts: []string{"1", "0x8000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000", "2-4"},
+		},
+		{
+			name: "AccountsAndFromFile",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"accounts":  []string{"10"},
+				"from-file": fromFile,
+				"epochs":    1,
+			},
+			accounts: []string{"10", "1", "0x8000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000", "2-4"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			c, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.accounts, c.accounts)
+			}
+		})
+	}
+}
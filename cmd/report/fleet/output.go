@@ -0,0 +1,223 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reportfleet
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+// maxListedValidators is the maximum number of validators listed against an
+// alert in text output, unless verbose.
+const maxListedValidators = 10
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	switch {
+	case c.jsonOutput:
+		return c.outputJSON(ctx)
+	case c.csvOutput:
+		return c.outputCSV(ctx)
+	default:
+		return c.outputTxt(ctx)
+	}
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.report)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputCSV(_ context.Context) (string, error) {
+	buf := &bytes.Buffer{}
+	writer := csv.NewWriter(buf)
+
+	records := [][]string{
+		{
+			"validator",
+			"public_key",
+			"status",
+			"balance",
+			"effective_balance",
+			"attestations_expected",
+			"attestations_included",
+			"effectiveness",
+			"proposals_expected",
+			"proposals_included",
+			"sync_committee_expected",
+			"sync_committee_included",
+			"pending_proposals",
+			"alerts",
+		},
+	}
+	for _, validator := range c.report.ValidatorReports {
+		records = append(records, []string{
+			fmt.Sprintf("%d", validator.Index),
+			fmt.Sprintf("%#x", validator.PublicKey),
+			validator.Status,
+			fmt.Sprintf("%d", validator.Balance),
+			fmt.Sprintf("%d", validator.EffectiveBalance),
+			fmt.Sprintf("%d", validator.Attestations.Expected),
+			fmt.Sprintf("%d", validator.Attestations.Included),
+			fmt.Sprintf("%.4f", validator.Attestations.Effectiveness),
+			fmt.Sprintf("%d", validator.Proposals.Expected),
+			fmt.Sprintf("%d", validator.Proposals.Included),
+			fmt.Sprintf("%d", validator.SyncCommittee.Expected),
+			fmt.Sprintf("%d", validator.SyncCommittee.Included),
+			fmt.Sprintf("%d", validator.PendingProposals),
+			strings.Join(validator.Alerts, ";"),
+		})
+	}
+	if err := writer.WriteAll(records); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Validators: %d\n", c.report.Validators))
+	if len(c.report.UnknownValidators) > 0 {
+		builder.WriteString(fmt.Sprintf("Unknown validators: %d\n", len(c.report.UnknownValidators)))
+		if c.verbose {
+			for _, unknown := range c.report.UnknownValidators {
+				builder.WriteString(fmt.Sprintf("  %s\n", unknown))
+			}
+		}
+	}
+
+	builder.WriteString("Status:\n")
+	for _, status := range sortedKeys(c.report.Statuses) {
+		builder.WriteString(fmt.Sprintf("  %s: %d\n", status, c.report.Statuses[status]))
+	}
+	builder.WriteString(fmt.Sprintf("Balance: %s\n", string2eth.GWeiToString(uint64(c.report.Balance), true)))
+	builder.WriteString(fmt.Sprintf("Effective balance: %s\n", string2eth.GWeiToString(uint64(c.report.EffectiveBalance), true)))
+
+	if c.report.FirstEpoch == c.report.LastEpoch {
+		builder.WriteString(fmt.Sprintf("Epoch %d:\n", c.report.FirstEpoch))
+	} else {
+		builder.WriteString(fmt.Sprintf("Epochs %d-%d:\n", c.report.FirstEpoch, c.report.LastEpoch))
+	}
+	c.outputPerformanceTxt(&builder)
+
+	c.outputPendingDutiesTxt(&builder)
+
+	if len(c.report.Alerts) == 0 {
+		builder.WriteString("Alerts: none\n")
+	} else {
+		builder.WriteString("Alerts:\n")
+		alerts := make(map[string]int, len(c.report.Alerts))
+		for alert, validators := range c.report.Alerts {
+			alerts[alert] = len(validators)
+		}
+		for _, alert := range sortedKeys(alerts) {
+			builder.WriteString(fmt.Sprintf("  %s: %d (%s)\n", alert, alerts[alert], c.validatorList(c.report.Alerts[alert])))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func (c *command) outputPerformanceTxt(builder *strings.Builder) {
+	attestations := c.report.Attestations
+	if attestations.Expected == 0 {
+		builder.WriteString("  Attestations: none expected\n")
+	} else {
+		builder.WriteString(fmt.Sprintf("  Attestations: %d/%d included (%s)\n", attestations.Included, attestations.Expected, percentage(attestations.Included, attestations.Expected)))
+		if attestations.Included > 0 {
+			builder.WriteString(fmt.Sprintf("    Correct head: %s\n", percentage(attestations.CorrectHead, attestations.Included)))
+			builder.WriteString(fmt.Sprintf("    Correct target: %s\n", percentage(attestations.CorrectTarget, attestations.Included)))
+		}
+		builder.WriteString(fmt.Sprintf("    Effectiveness: %.2f%%\n", attestations.Effectiveness*100))
+	}
+	if c.report.Proposals.Expected > 0 {
+		builder.WriteString(fmt.Sprintf("  Proposals: %d/%d included (%s)\n", c.report.Proposals.Included, c.report.Proposals.Expected, percentage(c.report.Proposals.Included, c.report.Proposals.Expected)))
+	}
+	if c.report.SyncCommittee.Expected > 0 {
+		builder.WriteString(fmt.Sprintf("  Sync committee: %d/%d included (%s)\n", c.report.SyncCommittee.Included, c.report.SyncCommittee.Expected, percentage(c.report.SyncCommittee.Included, c.report.SyncCommittee.Expected)))
+	}
+}
+
+func (c *command) outputPendingDutiesTxt(builder *strings.Builder) {
+	duties := c.report.PendingDuties
+	builder.WriteString("Pending duties:\n")
+	builder.WriteString(fmt.Sprintf("  Proposals: %d\n", len(duties.Proposals)))
+	for _, proposal := range duties.Proposals {
+		builder.WriteString(fmt.Sprintf("    Slot %d: validator %d\n", proposal.Slot, proposal.Validator))
+	}
+	builder.WriteString(fmt.Sprintf("  Current sync committee members: %d", len(duties.CurrentSyncCommittee)))
+	if len(duties.CurrentSyncCommittee) > 0 {
+		builder.WriteString(fmt.Sprintf(" (%s)", c.validatorList(duties.CurrentSyncCommittee)))
+	}
+	builder.WriteString("\n")
+	builder.WriteString(fmt.Sprintf("  Next sync committee members: %d", len(duties.NextSyncCommittee)))
+	if len(duties.NextSyncCommittee) > 0 {
+		builder.WriteString(fmt.Sprintf(" (%s)", c.validatorList(duties.NextSyncCommittee)))
+	}
+	builder.WriteString("\n")
+}
+
+// validatorList provides a list of validators, truncated unless verbose.
+func (c *command) validatorList(validators []phase0.ValidatorIndex) string {
+	listed := validators
+	if !c.verbose && len(listed) > maxListedValidators {
+		listed = listed[:maxListedValidators]
+	}
+
+	indices := make([]string, len(listed))
+	for i := range listed {
+		indices[i] = fmt.Sprintf("%d", listed[i])
+	}
+	res := "validators " + strings.Join(indices, ", ")
+	if len(listed) < len(validators) {
+		res = fmt.Sprintf("%s and %d more", res, len(validators)-len(listed))
+	}
+
+	return res
+}
+
+func sortedKeys(values map[string]int) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func percentage(value int, total int) string {
+	if total == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.2f%%", 100*float64(value)/float64(total))
+}
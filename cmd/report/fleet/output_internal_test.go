@@ -0,0 +1,99 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reportfleet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	validator1 := testValidator(1, 0x01)
+	validator1.Attestations.Expected = 2
+	validator1.Attestations.record(1, 1, true, true)
+	validator1.Attestations.record(1, 1, false, true)
+	validator1.PendingProposals = 1
+	validator2 := testValidator(2, 0x00)
+	validator2.Attestations.Expected = 2
+
+	c := &command{
+		validators: map[phase0.ValidatorIndex]*validatorReport{
+			1: validator1,
+			2: validator2,
+		},
+		report: newFleetReport(),
+	}
+	c.report.Epoch = 110
+	c.report.FirstEpoch = 100
+	c.report.LastEpoch = 101
+	c.report.UnknownValidators = []string{"5"}
+	c.report.PendingDuties.Proposals = []*pendingProposal{{Slot: 3525, Validator: 1}}
+	c.report.PendingDuties.NextSyncCommittee = []phase0.ValidatorIndex{2}
+	c.summarize()
+
+	tests := []struct {
+		name    string
+		json    bool
+		csv     bool
+		verbose bool
+		res     string
+	}{
+		{
+			name: "Text",
+			res:  "Validators: 2\nUnknown validators: 1\nStatus:\n  active_ongoing: 2\nBalance: 64 Ether\nEffective balance: 64 Ether\nEpochs 100-101:\n  Attestations: 2/4 included (50.00%)\n    Correct head: 50.00%\n    Correct target: 100.00%\n    Effectiveness: 50.00%\nPending duties:\n  Proposals: 1\n    Slot 3525: validator 1\n  Current sync committee members: 0\n  Next sync committee members: 1 (validators 2)\nAlerts:\n  bls_credentials: 1 (validators 2)\n  offline: 1 (validators 2)",
+		},
+		{
+			name:    "TextVerbose",
+			verbose: true,
+			res:     "Validators: 2\nUnknown validators: 1\n  5\nStatus:\n  active_ongoing: 2\nBalance: 64 Ether\nEffective balance: 64 Ether\nEpochs 100-101:\n  Attestations: 2/4 included (50.00%)\n    Correct head: 50.00%\n    Correct target: 100.00%\n    Effectiveness: 50.00%\nPending duties:\n  Proposals: 1\n    Slot 3525: validator 1\n  Current sync committee members: 0\n  Next sync committee members: 1 (validators 2)\nAlerts:\n  bls_credentials: 1 (validators 2)\n  offline: 1 (validators 2)",
+		},
+		{
+			name: "JSON",
+			json: true,
+			res:  `{"epoch":"110","first_epoch":"100","last_epoch":"101","validators":2,"unknown_validators":["5"],"statuses":{"active_ongoing":2},"balance":"64000000000","effective_balance":"64000000000","attestations":{"expected":4,"included":2,"correct_head":1,"correct_target":2,"effectiveness":0.5},"proposals":{"expected":0,"included":0},"sync_committee":{"expected":0,"included":0},"pending_duties":{"proposals":[{"slot":"3525","validator_index":"1"}],"current_sync_committee":[],"next_sync_committee":["2"]},"alerts":{"bls_credentials":["2"],"offline":["2"]},"validator_reports":[{"index":"1","public_key":"0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","status":"active_ongoing","balance":"32000000000","effective_balance":"32000000000","attestations":{"expected":2,"included":2,"correct_head":1,"correct_target":2,"effectiveness":1},"proposals":{"expected":0,"included":0},"sync_committee":{"expected":0,"included":0},"pending_proposals":1,"alerts":[]},{"index":"2","public_key":"0x020000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","status":"active_ongoing","balance":"32000000000","effective_balance":"32000000000","attestations":{"expected":2,"included":0,"correct_head":0,"correct_target":0,"effectiveness":0},"proposals":{"expected":0,"included":0},"sync_committee":{"expected":0,"included":0},"pending_proposals":0,"alerts":["bls_credentials","offline"]}]}`,
+		},
+		{
+			name: "CSV",
+			csv:  true,
+			res:  "validator,public_key,status,balance,effective_balance,attestations_expected,attestations_included,effectiveness,proposals_expected,proposals_included,sync_committee_expected,sync_committee_included,pending_proposals,alerts\n1,0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000,active_ongoing,32000000000,32000000000,2,2,1.0000,0,0,0,0,1,\n2,0x020000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000,active_ongoing,32000000000,32000000000,2,0,0.0000,0,0,0,0,0,bls_credentials;offline",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c.jsonOutput = test.json
+			c.csvOutput = test.csv
+			c.verbose = test.verbose
+			res, err := c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestValidatorList(t *testing.T) {
+	validators := make([]phase0.ValidatorIndex, 12)
+	for i := range validators {
+		validators[i] = phase0.ValidatorIndex(i)
+	}
+
+	c := &command{}
+	require.Equal(t, "validators 0, 1, 2, 3, 4, 5, 6, 7, 8, 9 and 2 more", c.validatorList(validators))
+	require.Equal(t, "validators 0, 1, 2", c.validatorList(validators[:3]))
+	c.verbose = true
+	require.Equal(t, "validators 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11", c.validatorList(validators))
+}
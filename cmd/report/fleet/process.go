@@ -0,0 +1,610 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reportfleet
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+	"golang.org/x/sync/errgroup"
+)
+
+// workers is the maximum number of concurrent calls made to the beacon node.
+var workers = 16
+
+// dutiesBatchSize is the maximum number of validators for which attester
+// duties are requested in a single call.
+var dutiesBatchSize = 1000
+
+// lowEffectiveness is the attestation effectiveness below which a validator
+// is flagged.
+const lowEffectiveness = 0.8
+
+// Alerts raised against validators.
+const (
+	alertSlashed             = "slashed"
+	alertExiting             = "exiting"
+	alertOffline             = "offline"
+	alertMissedAttestations  = "missed_attestations"
+	alertLowEffectiveness    = "low_effectiveness"
+	alertMissedProposals     = "missed_proposals"
+	alertMissedSyncCommittee = "missed_sync_committee"
+	alertBLSCredentials      = "bls_credentials"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	err := c.setup(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.report.Epoch = c.chainTime.CurrentEpoch()
+	c.report.LastEpoch, err = util.ParseEpoch(ctx, c.chainTime, c.epoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse epoch")
+	}
+	if uint64(c.report.LastEpoch)+1 < c.epochs {
+		return fmt.Errorf("cannot report on %d epochs ending at epoch %d", c.epochs, c.report.LastEpoch)
+	}
+	c.report.FirstEpoch = c.report.LastEpoch + 1 - phase0.Epoch(c.epochs)
+
+	if err := c.fetchValidators(ctx); err != nil {
+		return err
+	}
+	if len(c.validators) == 0 {
+		return errors.New("no validators found")
+	}
+
+	if err := c.fetchBlocks(ctx); err != nil {
+		return err
+	}
+
+	dutiesBySlot := make(map[phase0.Slot]map[phase0.CommitteeIndex][]*apiv1.AttesterDuty)
+	syncCommittees := make(map[uint64][]phase0.ValidatorIndex)
+	for epoch := c.report.FirstEpoch; epoch <= c.report.LastEpoch; epoch++ {
+		activeIndices := c.activeIndices(epoch)
+		if len(activeIndices) == 0 {
+			continue
+		}
+
+		if err := c.processProposerDuties(ctx, epoch); err != nil {
+			return err
+		}
+		if err := c.processAttesterDuties(ctx, epoch, activeIndices, dutiesBySlot); err != nil {
+			return err
+		}
+		if err := c.processSyncCommitteeDuties(ctx, epoch, syncCommittees); err != nil {
+			return err
+		}
+	}
+
+	if err := c.processAttestations(ctx, dutiesBySlot); err != nil {
+		return err
+	}
+
+	if err := c.fetchPendingDuties(ctx); err != nil {
+		return err
+	}
+
+	c.summarize()
+
+	return nil
+}
+
+// fetchValidators fetches the current state of the validators in the fleet.
+func (c *command) fetchValidators(ctx context.Context) error {
+	indices, pubKeys, err := util.ParseValidatorIdentifiers(ctx, c.accounts)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse validators")
+	}
+
+	validators, err := util.FetchValidators(ctx, c.validatorsProvider, "head", indices, pubKeys)
+	if err != nil {
+		return err
+	}
+	for index, validator := range validators {
+		report := newValidatorReport(index, validator.Validator)
+		report.Status = validator.Status.String()
+		report.Balance = validator.Balance
+		report.EffectiveBalance = validator.Validator.EffectiveBalance
+		c.validators[index] = report
+	}
+	c.report.UnknownValidators = util.MissingValidators(indices, pubKeys, validators)
+
+	return nil
+}
+
+// activeIndices returns the indices of the validators in the fleet that are
+// active in the given epoch.
+func (c *command) activeIndices(epoch phase0.Epoch) []phase0.ValidatorIndex {
+	res := make([]phase0.ValidatorIndex, 0, len(c.validators))
+	for index, validator := range c.validators {
+		if validator.validator.ActivationEpoch <= epoch && validator.validator.ExitEpoch > epoch {
+			res = append(res, index)
+		}
+	}
+	sort.Slice(res, func(i int, j int) bool {
+		return res[i] < res[j]
+	})
+
+	return res
+}
+
+// fetchBlocks fetches the blocks that could contain attestations for the
+// epochs covered by the report.  Blocks are fetched concurrently.
+func (c *command) fetchBlocks(ctx context.Context) error {
+	// Attestations for the last epoch can be included up to the end of the
+	// following epoch.
+	firstSlot := c.chainTime.FirstSlotOfEpoch(c.report.FirstEpoch)
+	lastSlot := c.chainTime.LastSlotOfEpoch(c.report.LastEpoch + 1)
+	if lastSlot > c.chainTime.CurrentSlot() {
+		lastSlot = c.chainTime.CurrentSlot()
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(workers)
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		blockSlot := slot
+		group.Go(func() error {
+			if c.debug {
+				fmt.Printf("Fetching block for slot %d\n", blockSlot)
+			}
+			blockResponse, err := c.blocksProvider.SignedBeaconBlock(groupCtx, &api.SignedBeaconBlockOpts{
+				Block: fmt.Sprintf("%d", blockSlot),
+			})
+			if err != nil {
+				var apiErr *api.Error
+				if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+					// No block for this slot, that's okay.
+					return nil
+				}
+
+				return errors.Wrapf(err, "failed to obtain block for slot %d", blockSlot)
+			}
+			if blockResponse.Data != nil {
+				c.blocksMu.Lock()
+				c.blocks[blockSlot] = blockResponse.Data
+				c.blocksMu.Unlock()
+			}
+
+			return nil
+		})
+	}
+
+	return group.Wait()
+}
+
+func (c *command) processProposerDuties(ctx context.Context, epoch phase0.Epoch) error {
+	response, err := c.proposerDutiesProvider.ProposerDuties(ctx, &api.ProposerDutiesOpts{
+		Epoch: epoch,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to obtain proposer duties for epoch %d", epoch)
+	}
+	for _, duty := range response.Data {
+		report, exists := c.validators[duty.ValidatorIndex]
+		if !exists {
+			continue
+		}
+		report.Proposals.Expected++
+		block, exists := c.blocks[duty.Slot]
+		if !exists {
+			continue
+		}
+		proposerIndex, err := block.ProposerIndex()
+		if err != nil {
+			return errors.Wrapf(err, "failed to obtain proposer for slot %d", duty.Slot)
+		}
+		if proposerIndex == duty.ValidatorIndex {
+			report.Proposals.Included++
+		}
+	}
+
+	return nil
+}
+
+// processAttesterDuties obtains the attester duties for the active
+// validators in the given epoch.  Duties are requested in batches, and the
+// batches are requested concurrently.
+func (c *command) processAttesterDuties(ctx context.Context,
+	epoch phase0.Epoch,
+	activeIndices []phase0.ValidatorIndex,
+	dutiesBySlot map[phase0.Slot]map[phase0.CommitteeIndex][]*apiv1.AttesterDuty,
+) error {
+	dutiesMu := sync.Mutex{}
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(workers)
+	for i := 0; i < len(activeIndices); i += dutiesBatchSize {
+		indices := activeIndices[i:min(i+dutiesBatchSize, len(activeIndices))]
+		group.Go(func() error {
+			response, err := c.attesterDutiesProvider.AttesterDuties(groupCtx, &api.AttesterDutiesOpts{
+				Epoch:   epoch,
+				Indices: indices,
+			})
+			if err != nil {
+				return errors.Wrapf(err, "failed to obtain attester duties for epoch %d", epoch)
+			}
+
+			dutiesMu.Lock()
+			defer dutiesMu.Unlock()
+			for _, duty := range response.Data {
+				report, exists := c.validators[duty.ValidatorIndex]
+				if !exists {
+					continue
+				}
+				report.Attestations.Expected++
+				if _, exists := dutiesBySlot[duty.Slot]; !exists {
+					dutiesBySlot[duty.Slot] = make(map[phase0.CommitteeIndex][]*apiv1.AttesterDuty)
+				}
+				dutiesBySlot[duty.Slot][duty.CommitteeIndex] = append(dutiesBySlot[duty.Slot][duty.CommitteeIndex], duty)
+			}
+
+			return nil
+		})
+	}
+
+	return group.Wait()
+}
+
+func (c *command) processAttestations(ctx context.Context,
+	dutiesBySlot map[phase0.Slot]map[phase0.CommitteeIndex][]*apiv1.AttesterDuty,
+) error {
+	headersCache := util.NewBeaconBlockHeaderCache(c.beaconBlockHeadersProvider)
+
+	slots := make([]phase0.Slot, 0, len(c.blocks))
+	for slot := range c.blocks {
+		slots = append(slots, slot)
+	}
+	sort.Slice(slots, func(i int, j int) bool {
+		return slots[i] < slots[j]
+	})
+
+	included := make(map[*apiv1.AttesterDuty]struct{})
+	for _, slot := range slots {
+		attestations, err := c.blocks[slot].Attestations()
+		if err != nil {
+			return errors.Wrapf(err, "failed to obtain attestations for slot %d", slot)
+		}
+		for _, attestation := range attestations {
+			duties, exists := dutiesBySlot[attestation.Data.Slot][attestation.Data.Index]
+			if !exists {
+				continue
+			}
+			for _, duty := range duties {
+				if !attestation.AggregationBits.BitAt(duty.ValidatorCommitteeIndex) {
+					continue
+				}
+				if _, exists := included[duty]; exists {
+					// Duplicate; ignore.
+					continue
+				}
+				included[duty] = struct{}{}
+
+				headCorrect, err := util.AttestationHeadCorrect(ctx, headersCache, attestation)
+				if err != nil {
+					return errors.Wrap(err, "failed to calculate if attestation had correct head vote")
+				}
+				targetCorrect, err := util.AttestationTargetCorrect(ctx, headersCache, c.chainTime, attestation)
+				if err != nil {
+					return errors.Wrap(err, "failed to calculate if attestation had correct target vote")
+				}
+				c.validators[duty.ValidatorIndex].Attestations.record(slot-duty.Slot,
+					c.optimalInclusionDistance(duty.Slot),
+					headCorrect,
+					targetCorrect,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *command) optimalInclusionDistance(slot phase0.Slot) phase0.Slot {
+	for distance := phase0.Slot(1); distance < phase0.Slot(c.chainTime.SlotsPerEpoch()); distance++ {
+		if _, exists := c.blocks[slot+distance]; exists {
+			return distance
+		}
+	}
+
+	return 1
+}
+
+// processSyncCommitteeDuties checks the participation of the fleet in the
+// sync committee for the given epoch.  Committees are cached by period, as
+// they remain the same for the whole period.
+func (c *command) processSyncCommitteeDuties(ctx context.Context,
+	epoch phase0.Epoch,
+	committees map[uint64][]phase0.ValidatorIndex,
+) error {
+	if epoch < c.chainTime.AltairInitialEpoch() {
+		// The epoch is pre-Altair.  No info but no error.
+		return nil
+	}
+
+	firstSlot := c.chainTime.FirstSlotOfEpoch(epoch)
+	period := c.chainTime.SlotToSyncCommitteePeriod(firstSlot)
+	committee, exists := committees[period]
+	if !exists {
+		committeeResponse, err := c.syncCommitteesProvider.SyncCommittee(ctx, &api.SyncCommitteeOpts{
+			State: fmt.Sprintf("%d", firstSlot),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to obtain sync committee for epoch %d", epoch)
+		}
+		committee = committeeResponse.Data.Validators
+		committees[period] = committee
+	}
+
+	positions := make(map[int]*validatorReport)
+	for i, index := range committee {
+		if report, exists := c.validators[index]; exists {
+			positions[i] = report
+		}
+	}
+	if len(positions) == 0 {
+		return nil
+	}
+
+	for slot := firstSlot; slot <= c.chainTime.LastSlotOfEpoch(epoch); slot++ {
+		block, exists := c.blocks[slot]
+		if !exists || block.Version == spec.DataVersionPhase0 {
+			// If the block is missed we don't count the sync aggregate miss.
+			continue
+		}
+		aggregate, err := block.SyncAggregate()
+		if err != nil {
+			return errors.Wrapf(err, "failed to obtain sync aggregate for slot %d", slot)
+		}
+		for position, report := range positions {
+			report.SyncCommittee.Expected++
+			if aggregate.SyncCommitteeBits.BitAt(uint64(position)) {
+				report.SyncCommittee.Included++
+			}
+		}
+	}
+
+	return nil
+}
+
+// fetchPendingDuties fetches the duties that the fleet has yet to carry out:
+// proposals in the remainder of the current epoch, and membership of the
+// current and next sync committees.
+func (c *command) fetchPendingDuties(ctx context.Context) error {
+	response, err := c.proposerDutiesProvider.ProposerDuties(ctx, &api.ProposerDutiesOpts{
+		Epoch: c.report.Epoch,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to obtain proposer duties for epoch %d", c.report.Epoch)
+	}
+	currentSlot := c.chainTime.CurrentSlot()
+	for _, duty := range response.Data {
+		if duty.Slot <= currentSlot {
+			continue
+		}
+		report, exists := c.validators[duty.ValidatorIndex]
+		if !exists {
+			continue
+		}
+		report.PendingProposals++
+		c.report.PendingDuties.Proposals = append(c.report.PendingDuties.Proposals, &pendingProposal{
+			Slot:      duty.Slot,
+			Validator: duty.ValidatorIndex,
+		})
+	}
+	sort.Slice(c.report.PendingDuties.Proposals, func(i int, j int) bool {
+		return c.report.PendingDuties.Proposals[i].Slot < c.report.PendingDuties.Proposals[j].Slot
+	})
+
+	if c.report.Epoch < c.chainTime.AltairInitialEpoch() {
+		// No sync committees prior to Altair.
+		return nil
+	}
+	period := c.chainTime.CurrentSyncCommitteePeriod()
+	c.report.PendingDuties.CurrentSyncCommittee, err = c.syncCommitteeMembers(ctx, period)
+	if err != nil {
+		return err
+	}
+	c.report.PendingDuties.NextSyncCommittee, err = c.syncCommitteeMembers(ctx, period+1)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// syncCommitteeMembers returns the validators in the fleet that are members
+// of the sync committee for the given period.
+func (c *command) syncCommitteeMembers(ctx context.Context, period uint64) ([]phase0.ValidatorIndex, error) {
+	epoch := c.chainTime.FirstEpochOfSyncPeriod(period)
+	response, err := c.syncCommitteesProvider.SyncCommittee(ctx, &api.SyncCommitteeOpts{
+		State: "head",
+		Epoch: &epoch,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to obtain sync committee for period %d", period)
+	}
+
+	res := make([]phase0.ValidatorIndex, 0)
+	seen := make(map[phase0.ValidatorIndex]bool)
+	for _, index := range response.Data.Validators {
+		if _, exists := c.validators[index]; exists && !seen[index] {
+			seen[index] = true
+			res = append(res, index)
+		}
+	}
+	sort.Slice(res, func(i int, j int) bool {
+		return res[i] < res[j]
+	})
+
+	return res, nil
+}
+
+// summarize finalizes the reports for the individual validators and
+// aggregates them in to the fleet report.
+func (c *command) summarize() {
+	for _, validator := range c.validators {
+		validator.Attestations.finalize()
+		validator.Alerts = validator.alerts()
+		c.report.ValidatorReports = append(c.report.ValidatorReports, validator)
+	}
+	sort.Slice(c.report.ValidatorReports, func(i int, j int) bool {
+		return c.report.ValidatorReports[i].Index < c.report.ValidatorReports[j].Index
+	})
+
+	for _, validator := range c.report.ValidatorReports {
+		c.report.Validators++
+		c.report.Statuses[validator.Status]++
+		c.report.Balance += validator.Balance
+		c.report.EffectiveBalance += validator.EffectiveBalance
+		c.report.Attestations.add(validator.Attestations)
+		c.report.Proposals.Expected += validator.Proposals.Expected
+		c.report.Proposals.Included += validator.Proposals.Included
+		c.report.SyncCommittee.Expected += validator.SyncCommittee.Expected
+		c.report.SyncCommittee.Included += validator.SyncCommittee.Included
+		for _, alert := range validator.Alerts {
+			c.report.Alerts[alert] = append(c.report.Alerts[alert], validator.Index)
+		}
+	}
+	c.report.Attestations.finalize()
+}
+
+// alerts returns the alerts for the validator.
+func (r *validatorReport) alerts() []string {
+	res := make([]string, 0)
+
+	if r.validator.Slashed {
+		res = append(res, alertSlashed)
+	}
+	if r.Status == apiv1.ValidatorStateActiveExiting.String() {
+		res = append(res, alertExiting)
+	}
+	if len(r.validator.WithdrawalCredentials) > 0 && r.validator.WithdrawalCredentials[0] == util.BLSWithdrawalPrefix {
+		res = append(res, alertBLSCredentials)
+	}
+
+	switch {
+	case r.Attestations.Expected > 0 && r.Attestations.Included == 0:
+		res = append(res, alertOffline)
+	case r.Attestations.Included < r.Attestations.Expected:
+		res = append(res, alertMissedAttestations)
+	case r.Attestations.Expected > 0 && r.Attestations.Effectiveness < lowEffectiveness:
+		res = append(res, alertLowEffectiveness)
+	}
+	if r.Proposals.Included < r.Proposals.Expected {
+		res = append(res, alertMissedProposals)
+	}
+	if r.SyncCommittee.Included < r.SyncCommittee.Expected {
+		res = append(res, alertMissedSyncCommittee)
+	}
+
+	return res
+}
+
+func (a *attestationPerformance) record(inclusionDistance phase0.Slot,
+	optimalInclusionDistance phase0.Slot,
+	headCorrect bool,
+	targetCorrect bool,
+) {
+	a.Included++
+	if inclusionDistance < optimalInclusionDistance {
+		// Can happen if the optimal block is not canonical.
+		optimalInclusionDistance = inclusionDistance
+	}
+	a.totalEffectiveness += float64(optimalInclusionDistance) / float64(inclusionDistance)
+	if headCorrect {
+		a.CorrectHead++
+	}
+	if targetCorrect {
+		a.CorrectTarget++
+	}
+}
+
+func (a *attestationPerformance) add(other *attestationPerformance) {
+	a.Expected += other.Expected
+	a.Included += other.Included
+	a.CorrectHead += other.CorrectHead
+	a.CorrectTarget += other.CorrectTarget
+	a.totalEffectiveness += other.totalEffectiveness
+}
+
+func (a *attestationPerformance) finalize() {
+	if a.Expected > 0 {
+		// Missed attestations have an effectiveness of 0.
+		a.Effectiveness = a.totalEffectiveness / float64(a.Expected)
+	}
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.proposerDutiesProvider, isProvider = c.eth2Client.(eth2client.ProposerDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide proposer duties")
+	}
+	c.attesterDutiesProvider, isProvider = c.eth2Client.(eth2client.AttesterDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide attester duties")
+	}
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+	c.syncCommitteesProvider, isProvider = c.eth2Client.(eth2client.SyncCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide sync committee duties")
+	}
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validators")
+	}
+	c.beaconBlockHeadersProvider, isProvider = c.eth2Client.(eth2client.BeaconBlockHeadersProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon block headers")
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reportfleet
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func testValidator(index phase0.ValidatorIndex, credentialsPrefix byte) *validatorReport {
+	credentials := make([]byte, 32)
+	credentials[0] = credentialsPrefix
+	report := newValidatorReport(index, &phase0.Validator{
+		PublicKey:             phase0.BLSPubKey{byte(index)},
+		WithdrawalCredentials: credentials,
+		EffectiveBalance:      32000000000,
+		ActivationEpoch:       10,
+		ExitEpoch:             0xffffffffffffffff,
+	})
+	report.Status = apiv1.ValidatorStateActiveOngoing.String()
+	report.Balance = 32000000000
+	report.EffectiveBalance = 32000000000
+
+	return report
+}
+
+func TestAlerts(t *testing.T) {
+	tests := []struct {
+		name   string
+		setup  func(*validatorReport)
+		alerts []string
+	}{
+		{
+			name:   "None",
+			setup:  func(_ *validatorReport) {},
+			alerts: []string{},
+		},
+		{
+			name: "Perfect",
+			setup: func(r *validatorReport) {
+				r.Attestations.Expected = 2
+				r.Attestations.record(1, 1, true, true)
+				r.Attestations.record(1, 1, true, true)
+				r.Proposals.Expected = 1
+				r.Proposals.Included = 1
+			},
+			alerts: []string{},
+		},
+		{
+			name: "Slashed",
+			setup: func(r *validatorReport) {
+				r.validator.Slashed = true
+				r.Status = apiv1.ValidatorStateActiveSlashed.String()
+			},
+			alerts: []string{alertSlashed},
+		},
+		{
+			name: "Exiting",
+			setup: func(r *validatorReport) {
+				r.Status = apiv1.ValidatorStateActiveExiting.String()
+			},
+			alerts: []string{alertExiting},
+		},
+		{
+			name: "BLSCredentials",
+			setup: func(r *validatorReport) {
+				r.validator.WithdrawalCredentials[0] = 0x00
+			},
+			alerts: []string{alertBLSCredentials},
+		},
+		{
+			name: "Offline",
+			setup: func(r *validatorReport) {
+				r.Attestations.Expected = 2
+			},
+			alerts: []string{alertOffline},
+		},
+		{
+			name: "MissedAttestations",
+			setup: func(r *validatorReport) {
+				r.Attestations.Expected = 2
+				r.Attestations.record(1, 1, true, true)
+			},
+			alerts: []string{alertMissedAttestations},
+		},
+		{
+			name: "LowEffectiveness",
+			setup: func(r *validatorReport) {
+				r.Attestations.Expected = 1
+				r.Attestations.record(2, 1, true, true)
+			},
+			alerts: []string{alertLowEffectiveness},
+		},
+		{
+			name: "MissedProposalsAndSyncCommittee",
+			setup: func(r *validatorReport) {
+				r.Proposals.Expected = 1
+				r.SyncCommittee.Expected = 32
+				r.SyncCommittee.Included = 31
+			},
+			alerts: []string{alertMissedProposals, alertMissedSyncCommittee},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			report := testValidator(1, 0x01)
+			test.setup(report)
+			report.Attestations.finalize()
+			require.Equal(t, test.alerts, report.alerts())
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	validator1 := testValidator(1, 0x01)
+	validator1.Attestations.Expected = 2
+	validator1.Attestations.record(1, 1, true, true)
+	validator1.Attestations.record(2, 2, false, true)
+	validator1.Proposals.Expected = 1
+	validator1.Proposals.Included = 1
+
+	validator2 := testValidator(2, 0x00)
+	validator2.Status = apiv1.ValidatorStatePendingQueued.String()
+	validator2.Balance = 32000000000
+	validator2.EffectiveBalance = 32000000000
+
+	validator3 := testValidator(3, 0x02)
+	validator3.Balance = 2047000000000
+	validator3.EffectiveBalance = 2047000000000
+	validator3.Attestations.Expected = 2
+	validator3.SyncCommittee.Expected = 32
+	validator3.SyncCommittee.Included = 30
+
+	c := &command{
+		validators: map[phase0.ValidatorIndex]*validatorReport{
+			3: validator3,
+			1: validator1,
+			2: validator2,
+		},
+		report: newFleetReport(),
+	}
+	c.summarize()
+
+	require.Equal(t, 3, c.report.Validators)
+	require.Len(t, c.report.ValidatorReports, 3)
+	require.Equal(t, phase0.ValidatorIndex(1), c.report.ValidatorReports[0].Index)
+	require.Equal(t, phase0.ValidatorIndex(3), c.report.ValidatorReports[2].Index)
+	require.Equal(t, map[string]int{"active_ongoing": 2, "pending_queued": 1}, c.report.Statuses)
+	require.Equal(t, phase0.Gwei(2111000000000), c.report.Balance)
+	require.Equal(t, phase0.Gwei(2111000000000), c.report.EffectiveBalance)
+	require.Equal(t, 4, c.report.Attestations.Expected)
+	require.Equal(t, 2, c.report.Attestations.Included)
+	require.Equal(t, 1, c.report.Attestations.CorrectHead)
+	require.Equal(t, 2, c.report.Attestations.CorrectTarget)
+	require.InDelta(t, 0.5, c.report.Attestations.Effectiveness, 0.0001)
+	require.InDelta(t, 1.0, validator1.Attestations.Effectiveness, 0.0001)
+	require.Equal(t, 1, c.report.Proposals.Expected)
+	require.Equal(t, 1, c.report.Proposals.Included)
+	require.Equal(t, 32, c.report.SyncCommittee.Expected)
+	require.Equal(t, 30, c.report.SyncCommittee.Included)
+	require.Equal(t, map[string][]phase0.ValidatorIndex{
+		alertBLSCredentials:      {2},
+		alertOffline:             {3},
+		alertMissedSyncCommittee: {3},
+	}, c.report.Alerts)
+}
+
+func TestActiveIndices(t *testing.T) {
+	validator1 := testValidator(1, 0x01)
+	validator2 := testValidator(2, 0x01)
+	validator2.validator.ActivationEpoch = 20
+	validator3 := testValidator(3, 0x01)
+	validator3.validator.ExitEpoch = 15
+
+	c := &command{
+		validators: map[phase0.ValidatorIndex]*validatorReport{
+			1: validator1,
+			2: validator2,
+			3: validator3,
+		},
+	}
+
+	require.Equal(t, []phase0.ValidatorIndex{}, c.activeIndices(5))
+	require.Equal(t, []phase0.ValidatorIndex{1, 3}, c.activeIndices(10))
+	require.Equal(t, []phase0.ValidatorIndex{1}, c.activeIndices(15))
+	require.Equal(t, []phase0.ValidatorIndex{1, 2}, c.activeIndices(20))
+}
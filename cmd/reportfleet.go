@@ -0,0 +1,77 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	reportfleet "github.com/wealdtech/ethdo/cmd/report/fleet"
+)
+
+var reportFleetCmd = &cobra.Command{
+	Use:   "fleet",
+	Short: "Report on a fleet of validators",
+	Long: `Report on the status, balances, effectiveness, pending duties and alerts for a fleet of validators.  For example:
+
+    ethdo report fleet --accounts=Validators/.* --epochs=10
+
+    ethdo report fleet --from-file=validators.txt --csv
+
+Validators can be supplied as indices, ranges of indices, public keys or account specifiers, either directly with --accounts or one per line in the file given with --from-file.  Requests to the beacon node are batched and run concurrently, so large fleets can be reported on in a few minutes.
+
+In quiet mode this will return 0 if the report is generated, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := reportfleet.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	reportCmd.AddCommand(reportFleetCmd)
+	reportFlags(reportFleetCmd)
+	reportFleetCmd.Flags().StringSlice("accounts", nil, "the validators on which to report, as indices, ranges of indices, public keys or account specifiers")
+	reportFleetCmd.Flags().String("from-file", "", "the path to a file containing the validators on which to report, one per line")
+	reportFleetCmd.Flags().String("epoch", "", "the last epoch for which to report performance (defaults to the most recent epoch with all attestations included)")
+	reportFleetCmd.Flags().Uint64("epochs", 1, "the number of epochs for which to report performance")
+	reportFleetCmd.Flags().Bool("csv", false, "output the report for each validator as CSV")
+}
+
+func reportFleetBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("accounts", cmd.Flags().Lookup("accounts")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("from-file", cmd.Flags().Lookup("from-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("epoch", cmd.Flags().Lookup("epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("epochs", cmd.Flags().Lookup("epochs")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("csv", cmd.Flags().Lookup("csv")); err != nil {
+		panic(err)
+	}
+}
@@ -45,42 +45,105 @@ var RootCmd = &cobra.Command{
 
 // bindings are the command-specific bindings.
 var bindings = map[string]func(cmd *cobra.Command){
-	"account/create":     accountCreateBindings,
-	"account/derive":     accountDeriveBindings,
-	"account/import":     accountImportBindings,
-	"attester/duties":    attesterDutiesBindings,
-	"attester/inclusion": attesterInclusionBindings,
-	"block/analyze":      blockAnalyzeBindings,
-	"block/info":         blockInfoBindings,
-	"chain/eth1votes":    chainEth1VotesBindings,
-	"chain/info":         chainInfoBindings,
-	"chain/queues":       chainQueuesBindings,
-	"chain/spec":         chainSpecBindings,
-	"chain/time":         chainTimeBindings,
+	"account/create":                          accountCreateBindings,
+	"account/derive":                          accountDeriveBindings,
+	"account/import":                          accountImportBindings,
+	"account/passphrase/change":               accountPassphraseChangeBindings,
+	"approve":                                 approveBindings,
+	"attester/committees":                     attesterCommitteesBindings,
+	"attester/duties":                         attesterDutiesBindings,
+	"attester/inclusion":                      attesterInclusionBindings,
+	"attester/slashing/create":                attesterSlashingCreateBindings,
+	"audit/verify":                            auditVerifyBindings,
+	"block/analyze":                           blockAnalyzeBindings,
+	"block/attestations":                      blockAttestationsBindings,
+	"block/info":                              blockInfoBindings,
+	"block/rewards":                           blockRewardsBindings,
+	"chain/countdown":                         chainCountdownBindings,
+	"chain/creategenesis":                     chainCreateGenesisBindings,
+	"chain/depositcontract":                   chainDepositContractBindings,
+	"chain/domain":                            chainDomainBindings,
+	"chain/eth1votes":                         chainEth1VotesBindings,
+	"chain/finality":                          chainFinalityBindings,
+	"chain/graffiti":                          chainGraffitiBindings,
+	"chain/info":                              chainInfoBindings,
+	"chain/pendingconsolidations":             chainPendingConsolidationsBindings,
+	"chain/pendingdeposits":                   chainPendingDepositsBindings,
+	"chain/pendingwithdrawals":                chainPendingWithdrawalsBindings,
+	"chain/queues":                            chainQueuesBindings,
+	"chain/reorgs":                            chainReorgsBindings,
+	"chain/slashings":                         chainSlashingsBindings,
+	"chain/spec":                              chainSpecBindings,
+	"chain/time":                              chainTimeBindings,
+	"chain/validators/export":                 chainValidatorsExportBindings,
+	"chain/verify-checkpoint":                 chainVerifyCheckpointBindings,
+	"chain/verify-state":                      chainVerifyStateBindings,
 	"chain/verify/signedcontributionandproof": chainVerifySignedContributionAndProofBindings,
-	"epoch/summary":             epochSummaryBindings,
-	"exit/verify":               exitVerifyBindings,
-	"node/events":               nodeEventsBindings,
-	"proposer/duties":           proposerDutiesBindings,
-	"slot/time":                 slotTimeBindings,
-	"synccommittee/inclusion":   synccommitteeInclusionBindings,
-	"synccommittee/members":     synccommitteeMembersBindings,
-	"validator/credentials/get": validatorCredentialsGetBindings,
-	"validator/credentials/set": validatorCredentialsSetBindings,
-	"validator/depositdata":     validatorDepositdataBindings,
-	"validator/duties":          validatorDutiesBindings,
-	"validator/exit":            validatorExitBindings,
-	"validator/info":            validatorInfoBindings,
-	"validator/keycheck":        validatorKeycheckBindings,
-	"validator/summary":         validatorSummaryBindings,
-	"validator/yield":           validatorYieldBindings,
-	"validator/expectation":     validatorExpectationBindings,
-	"validator/withdrawal":      validatorWithdrawalBindings,
-	"wallet/batch":              walletBatchBindings,
-	"wallet/create":             walletCreateBindings,
-	"wallet/import":             walletImportBindings,
-	"wallet/sharedexport":       walletSharedExportBindings,
-	"wallet/sharedimport":       walletSharedImportBindings,
+	"deposit/batch":                           depositBatchBindings,
+	"deposit/send":                            depositSendBindings,
+	"dkg/run":                                 dkgRunBindings,
+	"dkg/status":                              dkgStatusBindings,
+	"epoch/summary":                           epochSummaryBindings,
+	"epoch/time":                              epochTimeBindings,
+	"exit/verify":                             exitVerifyBindings,
+	"keymanager/feerecipient/delete":          keymanagerFeeRecipientDeleteBindings,
+	"keymanager/feerecipient/get":             keymanagerFeeRecipientGetBindings,
+	"keymanager/feerecipient/set":             keymanagerFeeRecipientSetBindings,
+	"keymanager/graffiti/get":                 keymanagerGraffitiGetBindings,
+	"keymanager/graffiti/set":                 keymanagerGraffitiSetBindings,
+	"mnemonic/create":                         mnemonicCreateBindings,
+	"node/compare":                            nodeCompareBindings,
+	"node/events":                             nodeEventsBindings,
+	"node/versions":                           nodeVersionsBindings,
+	"proof/beaconroot":                        proofBeaconRootBindings,
+	"proof/generate":                          proofGenerateBindings,
+	"proof/verify":                            proofVerifyBindings,
+	"proposer/duties":                         proposerDutiesBindings,
+	"proposer/slashing/create":                proposerSlashingCreateBindings,
+	"relay/check":                             relayCheckBindings,
+	"report/fleet":                            reportFleetBindings,
+	"signature/info":                          signatureInfoBindings,
+	"signature/pop/create":                    signaturePopCreateBindings,
+	"signature/pop/verify":                    signaturePopVerifyBindings,
+	"signature/pubkey/aggregate":              signaturePubkeyAggregateBindings,
+	"signature/recombine":                     signatureRecombineBindings,
+	"signature/sign":                          signatureSignBindings,
+	"signature/verify":                        signatureVerifyBindings,
+	"signer/account/create":                   signerAccountCreateBindings,
+	"slot/time":                               slotTimeBindings,
+	"synccommittee/inclusion":                 synccommitteeInclusionBindings,
+	"synccommittee/members":                   synccommitteeMembersBindings,
+	"validator/balances":                      validatorBalancesBindings,
+	"validator/consolidate":                   validatorConsolidateBindings,
+	"validator/credentials/get":               validatorCredentialsGetBindings,
+	"validator/credentials/set":               validatorCredentialsSetBindings,
+	"validator/depositdata":                   validatorDepositdataBindings,
+	"validator/doppelganger":                  validatorDoppelgangerBindings,
+	"validator/duties":                        validatorDutiesBindings,
+	"validator/exit":                          validatorExitBindings,
+	"validator/info":                          validatorInfoBindings,
+	"validator/keycheck":                      validatorKeycheckBindings,
+	"validator/performance":                   validatorPerformanceBindings,
+	"validator/proposals":                     validatorProposalsBindings,
+	"validator/queue":                         validatorQueueBindings,
+	"validator/registration":                  validatorRegistrationBindings,
+	"validator/recover":                       validatorRecoverBindings,
+	"validator/slashing":                      validatorSlashingBindings,
+	"validator/summary":                       validatorSummaryBindings,
+	"validator/sweep":                         validatorSweepBindings,
+	"validator/synccommittee":                 validatorSyncCommitteeBindings,
+	"validator/yield":                         validatorYieldBindings,
+	"validator/expectation":                   validatorExpectationBindings,
+	"validator/withdrawal":                    validatorWithdrawalBindings,
+	"wallet/audit":                            walletAuditBindings,
+	"wallet/batch":                            walletBatchBindings,
+	"wallet/copy":                             walletCopyBindings,
+	"wallet/create":                           walletCreateBindings,
+	"wallet/import":                           walletImportBindings,
+	"wallet/passphrase/change":                walletPassphraseChangeBindings,
+	"wallet/seed":                             walletSeedBindings,
+	"wallet/sharedexport":                     walletSharedExportBindings,
+	"wallet/sharedimport":                     walletSharedImportBindings,
 }
 
 func persistentPreRunE(cmd *cobra.Command, _ []string) error {
@@ -94,6 +157,13 @@ func persistentPreRunE(cmd *cobra.Command, _ []string) error {
 		return nil
 	}
 
+	if profiles := cmd.Flag("profiles"); profiles != nil && profiles.Value.String() != "" {
+		if profile := cmd.Flag("profile"); profile != nil && profile.Changed {
+			return errors.New("cannot supply both profile and profiles")
+		}
+		runProfiles(context.Background(), profiles.Value.String())
+	}
+
 	// Disable service logging.
 	zerolog.SetGlobalLevel(zerolog.Disabled)
 
@@ -107,6 +177,16 @@ func persistentPreRunE(cmd *cobra.Command, _ []string) error {
 		bindingsFunc(cmd)
 	}
 
+	// Apply the selected profile, without overriding explicitly supplied flags.
+	if profile := viper.GetString("profile"); profile != "" {
+		if err := util.ApplyProfile(profile, func(key string) bool {
+			flag := cmd.Flag(key)
+			return flag != nil && flag.Changed
+		}); err != nil {
+			return err
+		}
+	}
+
 	if quiet && verbose {
 		fmt.Println("Cannot supply both quiet and verbose flags")
 	}
@@ -114,6 +194,11 @@ func persistentPreRunE(cmd *cobra.Command, _ []string) error {
 		fmt.Println("Cannot supply both quiet and debug flags")
 	}
 
+	// Resolve references to secrets held in external secret stores.
+	if err := util.ResolveSecrets(context.Background()); err != nil {
+		return err
+	}
+
 	return util.SetupStore()
 }
 
@@ -143,6 +228,10 @@ func addPersistentFlags() {
 	if err := viper.BindPFlag("log", RootCmd.PersistentFlags().Lookup("log")); err != nil {
 		panic(err)
 	}
+	RootCmd.PersistentFlags().Bool("override-policy", false, "sign regardless of the signing policy, logging the override to the activity log")
+	if err := viper.BindPFlag("override-policy", RootCmd.PersistentFlags().Lookup("override-policy")); err != nil {
+		panic(err)
+	}
 	RootCmd.PersistentFlags().String("store", "filesystem", "Store for accounts")
 	if err := viper.BindPFlag("store", RootCmd.PersistentFlags().Lookup("store")); err != nil {
 		panic(err)
@@ -204,6 +293,10 @@ func addPersistentFlags() {
 	if err := viper.BindPFlag("passphrase", RootCmd.PersistentFlags().Lookup("passphrase")); err != nil {
 		panic(err)
 	}
+	RootCmd.PersistentFlags().StringSlice("accounts-tagged", nil, "Only select accounts carrying all of the given tags when selecting multiple accounts")
+	if err := viper.BindPFlag("accounts-tagged", RootCmd.PersistentFlags().Lookup("accounts-tagged")); err != nil {
+		panic(err)
+	}
 	RootCmd.PersistentFlags().Bool("quiet", false, "do not generate any output")
 	if err := viper.BindPFlag("quiet", RootCmd.PersistentFlags().Lookup("quiet")); err != nil {
 		panic(err)
@@ -220,7 +313,7 @@ func addPersistentFlags() {
 	if err := viper.BindPFlag("debug", RootCmd.PersistentFlags().Lookup("debug")); err != nil {
 		panic(err)
 	}
-	RootCmd.PersistentFlags().String("connection", "", "URL to an Ethereum 2 node's REST API endpoint")
+	RootCmd.PersistentFlags().String("connection", "", "URL to an Ethereum 2 node's REST API endpoint; multiple comma-separated URLs can be supplied for failover")
 	if err := viper.BindPFlag("connection", RootCmd.PersistentFlags().Lookup("connection")); err != nil {
 		panic(err)
 	}
@@ -252,6 +345,36 @@ func addPersistentFlags() {
 	if err := viper.BindPFlag("allow-insecure-connections", RootCmd.PersistentFlags().Lookup("allow-insecure-connections")); err != nil {
 		panic(err)
 	}
+	RootCmd.PersistentFlags().Bool("offline", false, "do not allow any network connections, failing any operation that attempts one")
+	if err := viper.BindPFlag("offline", RootCmd.PersistentFlags().Lookup("offline")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("network-config", "", "path to a consensus configuration file for the network, such as config.yaml, overriding the configuration provided by the beacon node and mainnet defaults")
+	if err := viper.BindPFlag("network-config", RootCmd.PersistentFlags().Lookup("network-config")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("profile", "", "name of the profile in the configuration file from which to take settings such as the connection")
+	if err := viper.BindPFlag("profile", RootCmd.PersistentFlags().Lookup("profile")); err != nil {
+		panic(err)
+	}
+	// Profiles are not bound to viper, as the configuration holds the profiles themselves.
+	RootCmd.PersistentFlags().String("profiles", "", "comma-separated names of profiles against which to run the command concurrently, with the output of each namespaced by profile")
+	RootCmd.PersistentFlags().Int("max-retries", 3, "the number of times to retry a read request that fails with a transient error; submissions are never retried")
+	if err := viper.BindPFlag("max-retries", RootCmd.PersistentFlags().Lookup("max-retries")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().Float64("rate-limit", 0, "the maximum number of beacon node requests to make per second (0 for no limit)")
+	if err := viper.BindPFlag("rate-limit", RootCmd.PersistentFlags().Lookup("rate-limit")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().Int("unlock-workers", 0, "the number of accounts to unlock in parallel when unlocking multiple accounts (defaults to the number of CPUs)")
+	if err := viper.BindPFlag("unlock-workers", RootCmd.PersistentFlags().Lookup("unlock-workers")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("cache-dir", "", "directory in which to cache validator information (default is the user cache directory)")
+	if err := viper.BindPFlag("cache-dir", RootCmd.PersistentFlags().Lookup("cache-dir")); err != nil {
+		panic(err)
+	}
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -306,6 +429,9 @@ func walletFromPath(ctx context.Context, path string) (e2wtypes.Wallet, error) {
 		return nil, err
 	}
 	if viper.GetString("remote") != "" {
+		if util.Offline() {
+			return nil, errors.Wrap(util.ErrOffline, "cannot connect to remote wallet")
+		}
 		assert(viper.GetString("client-cert") != "", "remote connections require client-cert")
 		assert(viper.GetString("client-key") != "", "remote connections require client-key")
 		credentials, err := dirk.ComposeCredentials(ctx, viper.GetString("client-cert"), viper.GetString("client-key"), viper.GetString("server-ca-cert"))
@@ -325,6 +451,9 @@ func walletFromPath(ctx context.Context, path string) (e2wtypes.Wallet, error) {
 			dirk.WithTimeout(viper.GetDuration("timeout")),
 		)
 	}
+	if err := util.EnsureStore(); err != nil {
+		return nil, err
+	}
 	wallet, err := e2wallet.OpenWallet(walletName)
 	if err != nil {
 		if strings.Contains(err.Error(), "failed to decrypt wallet") {
@@ -0,0 +1,91 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatureinfo
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/go-bytesutil"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Input.
+	signature []byte
+	pubKeys   []string
+	data      []byte
+	domain    []byte
+
+	// Output.
+	validPoint       bool
+	inSubgroup       bool
+	infinity         bool
+	verifyingPubKeys [][]byte
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	if viper.GetString("signature") == "" {
+		return nil, errors.New("signature is required")
+	}
+	var err error
+	c.signature, err = bytesutil.FromHexString(viper.GetString("signature"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signature")
+	}
+	if len(c.signature) != 96 {
+		return nil, errors.New("signature must be 96 bytes")
+	}
+
+	c.pubKeys, err = util.ExpandInputs(viper.GetStringSlice("pubkeys"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain public keys")
+	}
+
+	if viper.GetString("signature-data") != "" {
+		c.data, err = bytesutil.FromHexString(viper.GetString("signature-data"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid data")
+		}
+		if len(c.data) != 32 {
+			return nil, errors.New("data must be 32 bytes")
+		}
+	}
+	if len(c.pubKeys) > 0 && c.data == nil {
+		return nil, errors.New("data is required to identify the signing public key")
+	}
+
+	c.domain, err = bytesutil.FromHexString(viper.GetString("signature-domain"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid domain")
+	}
+	if len(c.domain) != 32 {
+		return nil, errors.New("domain must be 32 bytes")
+	}
+
+	return c, nil
+}
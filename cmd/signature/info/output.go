@@ -0,0 +1,99 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatureinfo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+type jsonOutput struct {
+	Signature        string   `json:"signature"`
+	ValidPoint       bool     `json:"valid_point"`
+	InSubgroup       bool     `json:"in_subgroup"`
+	Infinity         bool     `json:"infinity"`
+	VerifyingPubKeys []string `json:"verifying_pubkeys,omitempty"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		if !c.valid() {
+			os.Exit(1)
+		}
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+// valid returns true if the signature is a usable signature and, if
+// candidate public keys were supplied, is verified by one of them.
+func (c *command) valid() bool {
+	if !c.validPoint || !c.inSubgroup || c.infinity {
+		return false
+	}
+
+	return len(c.pubKeys) == 0 || len(c.verifyingPubKeys) > 0
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := &jsonOutput{
+		Signature:  fmt.Sprintf("%#x", c.signature),
+		ValidPoint: c.validPoint,
+		InSubgroup: c.inSubgroup,
+		Infinity:   c.infinity,
+	}
+	for _, pubKey := range c.verifyingPubKeys {
+		output.VerifyingPubKeys = append(output.VerifyingPubKeys, fmt.Sprintf("%#x", pubKey))
+	}
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Signature: %#x\n", c.signature))
+	}
+	if !c.validPoint {
+		builder.WriteString("Valid G2 point: false")
+		return builder.String(), nil
+	}
+	builder.WriteString("Valid G2 point: true\n")
+	builder.WriteString(fmt.Sprintf("In G2 subgroup: %t\n", c.inSubgroup))
+	builder.WriteString(fmt.Sprintf("Infinity: %t\n", c.infinity))
+
+	if len(c.pubKeys) > 0 {
+		if len(c.verifyingPubKeys) == 0 {
+			builder.WriteString("Not verified by any supplied public key\n")
+		}
+		for _, pubKey := range c.verifyingPubKeys {
+			builder.WriteString(fmt.Sprintf("Verified by public key %#x\n", pubKey))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,64 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatureinfo
+
+import (
+	"context"
+	"fmt"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/go-bytesutil"
+)
+
+func (c *command) process(_ context.Context) error {
+	var signature bls.Sign
+	if err := signature.Deserialize(c.signature); err != nil {
+		// Not a point on the curve, so nothing further to report.
+		return nil
+	}
+	c.validPoint = true
+	c.infinity = signature.IsZero()
+	c.inSubgroup = signature.IsValidOrder()
+
+	if len(c.pubKeys) == 0 || c.infinity || !c.inSubgroup {
+		return nil
+	}
+
+	container := &spec.SigningData{
+		ObjectRoot: spec.Root(c.data),
+		Domain:     spec.Domain(c.domain),
+	}
+	signingRoot, err := container.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate signing root")
+	}
+
+	for i, input := range c.pubKeys {
+		data, err := bytesutil.FromHexString(input)
+		if err != nil {
+			return fmt.Errorf("public key %d is not a hex string", i+1)
+		}
+		var pubKey bls.PublicKey
+		if err := pubKey.Deserialize(data); err != nil {
+			return fmt.Errorf("public key %d is not a valid public key", i+1)
+		}
+		if signature.VerifyByte(&pubKey, signingRoot[:]) {
+			c.verifyingPubKeys = append(c.verifyingPubKeys, data)
+		}
+	}
+
+	return nil
+}
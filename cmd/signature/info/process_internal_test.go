@@ -0,0 +1,130 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signatureinfo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	signature := testutil.HexToBytes("0x85673777effa18b5b43c6381c3e512e346ebef9b6d37dcba391452756d624e2f3afa490cc1e5c29870b2eeccb52c89cd1293883779e44bc1d5ca3d0b6847a5f2d078ad574422c0a06bc759f1a82f5442cf8112813b9ec5c5cd11122fd2309abe")
+	data := testutil.HexToBytes("0x5f24e819400c6a8ee2bfc014343cd971b7eb707320025a7bcd83e621e26c35b7")
+	domain := make([]byte, 32)
+	signer := "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+	other := "0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b"
+
+	tests := []struct {
+		name             string
+		cmd              *command
+		validPoint       bool
+		inSubgroup       bool
+		infinity         bool
+		verifyingPubKeys []string
+		err              string
+	}{
+		{
+			name: "NotPoint",
+			cmd: &command{
+				signature: testutil.HexToBytes("0x8" + strings.Repeat("f", 191)),
+			},
+		},
+		{
+			name: "Infinity",
+			cmd: &command{
+				signature: testutil.HexToBytes("0xc0" + strings.Repeat("0", 190)),
+			},
+			validPoint: true,
+			inSubgroup: true,
+			infinity:   true,
+		},
+		{
+			name: "Good",
+			cmd: &command{
+				signature: signature,
+			},
+			validPoint: true,
+			inSubgroup: true,
+		},
+		{
+			name: "PubKeyInvalid",
+			cmd: &command{
+				signature: signature,
+				pubKeys:   []string{"0x01"},
+				data:      data,
+				domain:    domain,
+			},
+			err: "public key 1 is not a valid public key",
+		},
+		{
+			name: "Verified",
+			cmd: &command{
+				signature: signature,
+				pubKeys:   []string{other, signer},
+				data:      data,
+				domain:    domain,
+			},
+			validPoint:       true,
+			inSubgroup:       true,
+			verifyingPubKeys: []string{signer},
+		},
+		{
+			name: "NotVerified",
+			cmd: &command{
+				signature: signature,
+				pubKeys:   []string{other},
+				data:      data,
+				domain:    domain,
+			},
+			validPoint: true,
+			inSubgroup: true,
+		},
+		{
+			name: "WrongDomain",
+			cmd: &command{
+				signature: signature,
+				pubKeys:   []string{signer},
+				data:      data,
+				domain:    testutil.HexToBytes("0x0100000000000000000000000000000000000000000000000000000000000000"),
+			},
+			validPoint: true,
+			inSubgroup: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.cmd.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.validPoint, test.cmd.validPoint)
+			require.Equal(t, test.inSubgroup, test.cmd.inSubgroup)
+			require.Equal(t, test.infinity, test.cmd.infinity)
+			require.Len(t, test.cmd.verifyingPubKeys, len(test.verifyingPubKeys))
+			for i := range test.verifyingPubKeys {
+				require.Equal(t, testutil.HexToBytes(test.verifyingPubKeys[i]), test.cmd.verifyingPubKeys[i])
+			}
+		})
+	}
+}
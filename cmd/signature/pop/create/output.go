@@ -0,0 +1,46 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signaturepopcreate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		data, err := json.Marshal(&util.ProofOfPossession{
+			PublicKey:   fmt.Sprintf("%#x", c.pubKey),
+			Signature:   fmt.Sprintf("%#x", c.signature),
+			ForkVersion: fmt.Sprintf("%#x", c.forkVersion),
+		})
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	if !c.verbose {
+		return fmt.Sprintf("%#x", c.signature), nil
+	}
+
+	return fmt.Sprintf("Public key: %#x\nFork version: %#x\nProof of possession: %#x", c.pubKey, c.forkVersion, c.signature), nil
+}
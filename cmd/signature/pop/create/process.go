@@ -0,0 +1,68 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signaturepopcreate
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func (c *command) process(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var account e2wtypes.Account
+	var err error
+	if c.account != "" {
+		account, err = util.ParseAccount(ctx, c.account, c.passphrases, true)
+	} else {
+		account, err = util.ParseAccount(ctx, c.privateKey, nil, true)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain account")
+	}
+
+	pubKey, err := util.BestPublicKey(account)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain public key")
+	}
+	root, err := util.ProofOfPossessionRoot(pubKey.Marshal())
+	if err != nil {
+		return err
+	}
+	domain := util.ProofOfPossessionDomain(c.forkVersion)
+
+	signature, err := util.SignRoot(account, root, domain)
+	if err != nil {
+		return errors.Wrap(err, "failed to sign proof of possession")
+	}
+
+	// Confirm that the signature is valid, as a distributed account held
+	// locally signs with its share rather than the composite key.
+	verified, err := util.VerifyRoot(account, root, domain, signature)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify proof of possession")
+	}
+	if !verified {
+		return errors.New("account cannot generate a valid proof of possession for its public key")
+	}
+
+	c.pubKey = pubKey.Marshal()
+	c.signature = signature.Marshal()
+
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signaturepopcreate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	pubKey := testutil.HexToBytes("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c")
+
+	tests := []struct {
+		name        string
+		cmd         *command
+		forkVersion spec.Version
+		err         string
+	}{
+		{
+			name: "AccountUnknown",
+			cmd: &command{
+				timeout: 10 * time.Second,
+				account: "Unknown/1",
+			},
+			err: "failed to obtain account: unable to obtain account: failed to open wallet for account: wallet not found",
+		},
+		{
+			name: "PrivateKeyInvalid",
+			cmd: &command{
+				timeout:    10 * time.Second,
+				privateKey: "0xinvalid",
+			},
+			err: "failed to obtain account: failed to parse account key: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name: "Mainnet",
+			cmd: &command{
+				timeout:    10 * time.Second,
+				privateKey: "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866",
+			},
+		},
+		{
+			name: "ForkVersion",
+			cmd: &command{
+				timeout:     10 * time.Second,
+				privateKey:  "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866",
+				forkVersion: spec.Version{0x01, 0x01, 0x70, 0x00},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.cmd.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, pubKey, test.cmd.pubKey)
+
+			key, err := e2types.BLSPublicKeyFromBytes(test.cmd.pubKey)
+			require.NoError(t, err)
+			signature, err := e2types.BLSSignatureFromBytes(test.cmd.signature)
+			require.NoError(t, err)
+			root, err := util.ProofOfPossessionRoot(test.cmd.pubKey)
+			require.NoError(t, err)
+			container := &spec.SigningData{
+				ObjectRoot: root,
+				Domain:     util.ProofOfPossessionDomain(test.cmd.forkVersion),
+			}
+			signingRoot, err := container.HashTreeRoot()
+			require.NoError(t, err)
+			require.True(t, signature.Verify(signingRoot[:], key))
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signaturepopverify
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/go-bytesutil"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Input.
+	pubKey      []byte
+	signature   []byte
+	forkVersion spec.Version
+}
+
+func newCommand(ctx context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	forkVersion := viper.GetString("forkversion")
+	var err error
+	if viper.GetString("proof") != "" {
+		proof, err := readProof(viper.GetString("proof"))
+		if err != nil {
+			return nil, err
+		}
+		c.pubKey, err = bytesutil.FromHexString(proof.PublicKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid public key in proof")
+		}
+		c.signature, err = bytesutil.FromHexString(proof.Signature)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid signature in proof")
+		}
+		if forkVersion == "" {
+			forkVersion = proof.ForkVersion
+		}
+	} else {
+		switch {
+		case viper.GetString("account") != "":
+			account, err := util.ParseAccount(ctx, viper.GetString("account"), nil, false)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to obtain account")
+			}
+			pubKey, err := util.BestPublicKey(account)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to obtain public key")
+			}
+			c.pubKey = pubKey.Marshal()
+		case viper.GetString("public-key") != "":
+			c.pubKey, err = bytesutil.FromHexString(viper.GetString("public-key"))
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid public key")
+			}
+		default:
+			return nil, errors.New("proof, account or public-key is required")
+		}
+		if viper.GetString("signature") == "" {
+			return nil, errors.New("signature is required")
+		}
+		c.signature, err = bytesutil.FromHexString(viper.GetString("signature"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid signature")
+		}
+	}
+
+	c.forkVersion, err = util.GenesisForkVersion(forkVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// readProof reads a proof of possession, supplied either as JSON or as the
+// path to a file containing JSON.
+func readProof(input string) (*util.ProofOfPossession, error) {
+	data := []byte(input)
+	if !strings.HasPrefix(strings.TrimSpace(input), "{") {
+		var err error
+		data, err = os.ReadFile(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read proof file")
+		}
+	}
+
+	proof := &util.ProofOfPossession{}
+	if err := json.Unmarshal(data, proof); err != nil {
+		return nil, errors.Wrap(err, "invalid proof")
+	}
+
+	return proof, nil
+}
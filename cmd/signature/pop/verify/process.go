@@ -0,0 +1,52 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signaturepopverify
+
+import (
+	"context"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func (c *command) process(_ context.Context) error {
+	pubKey, err := e2types.BLSPublicKeyFromBytes(c.pubKey)
+	if err != nil {
+		return errors.Wrap(err, "invalid public key")
+	}
+	signature, err := e2types.BLSSignatureFromBytes(c.signature)
+	if err != nil {
+		return errors.Wrap(err, "invalid signature")
+	}
+
+	root, err := util.ProofOfPossessionRoot(c.pubKey)
+	if err != nil {
+		return err
+	}
+	container := &spec.SigningData{
+		ObjectRoot: root,
+		Domain:     util.ProofOfPossessionDomain(c.forkVersion),
+	}
+	signingRoot, err := container.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate signing root")
+	}
+	if !signature.Verify(signingRoot[:], pubKey) {
+		return errors.New("proof of possession is not valid")
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signaturepopverify
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+const (
+	testPubKey    = "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+	testSignature = "0x94752cf25d1e1955bd0b5ababbd30286c42407c558d4ef0735f9927a0aba6273c626b0a6265e7603eed47f7f2745676e0a03372e26b9182ab2f56f59bc067cf8da9c93bef86491712ec78aecdd8dc87104177f6ee486a4fb19be0f6f94606830"
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	tests := []struct {
+		name string
+		cmd  *command
+		err  string
+	}{
+		{
+			name: "PubKeyInvalid",
+			cmd: &command{
+				pubKey:    testutil.HexToBytes("0x01"),
+				signature: testutil.HexToBytes(testSignature),
+			},
+			err: "invalid public key: public key must be 48 bytes",
+		},
+		{
+			name: "SignatureInvalid",
+			cmd: &command{
+				pubKey:    testutil.HexToBytes(testPubKey),
+				signature: testutil.HexToBytes("0x01"),
+			},
+			err: "invalid signature: failed to deserialize signature: err blsSignatureDeserialize 01",
+		},
+		{
+			name: "ForkVersionIncorrect",
+			cmd: &command{
+				pubKey:      testutil.HexToBytes(testPubKey),
+				signature:   testutil.HexToBytes(testSignature),
+				forkVersion: spec.Version{0x01, 0x01, 0x70, 0x00},
+			},
+			err: "proof of possession is not valid",
+		},
+		{
+			name: "PubKeyIncorrect",
+			cmd: &command{
+				pubKey:    testutil.HexToBytes("0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b"),
+				signature: testutil.HexToBytes(testSignature),
+			},
+			err: "proof of possession is not valid",
+		},
+		{
+			name: "Good",
+			cmd: &command{
+				pubKey:    testutil.HexToBytes(testPubKey),
+				signature: testutil.HexToBytes(testSignature),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.cmd.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestReadProof(t *testing.T) {
+	proofJSON := `{"pubkey":"` + testPubKey + `","signature":"` + testSignature + `","fork_version":"0x00000000"}`
+	proofFile := filepath.Join(t.TempDir(), "proof.json")
+	require.NoError(t, os.WriteFile(proofFile, []byte(proofJSON), 0o600))
+
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "JSONInvalid",
+			input: `{"pubkey":`,
+			err:   "invalid proof: unexpected end of JSON input",
+		},
+		{
+			name:  "FileMissing",
+			input: filepath.Join(t.TempDir(), "missing.json"),
+			err:   "failed to read proof file",
+		},
+		{
+			name:  "JSON",
+			input: proofJSON,
+		},
+		{
+			name:  "File",
+			input: proofFile,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			proof, err := readProof(test.input)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testPubKey, proof.PublicKey)
+			require.Equal(t, testSignature, proof.Signature)
+			require.Equal(t, "0x00000000", proof.ForkVersion)
+		})
+	}
+}
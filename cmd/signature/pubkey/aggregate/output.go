@@ -0,0 +1,56 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signaturepubkeyaggregate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type outputJSON struct {
+	AggregatePublicKey string `json:"aggregate_public_key"`
+	PublicKeys         int    `json:"public_keys"`
+	Duplicates         int    `json:"duplicates"`
+}
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		data, err := json.Marshal(&outputJSON{
+			AggregatePublicKey: fmt.Sprintf("%#x", c.aggregatePubKey),
+			PublicKeys:         c.count,
+			Duplicates:         c.duplicates,
+		})
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("%#x", c.aggregatePubKey))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("\nAggregated %d public keys", c.count))
+		if c.duplicates > 0 {
+			builder.WriteString(fmt.Sprintf(" (%d duplicates)", c.duplicates))
+		}
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,64 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signaturepubkeyaggregate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/wealdtech/go-bytesutil"
+)
+
+func (c *command) process(_ context.Context) error {
+	var aggregate bls.PublicKey
+	seen := make(map[string]bool, len(c.pubKeys))
+	for i, input := range c.pubKeys {
+		data, err := bytesutil.FromHexString(input)
+		if err != nil {
+			return fmt.Errorf("public key %d is not a hex string", i+1)
+		}
+		if len(data) != 48 {
+			return fmt.Errorf("public key %d must be 48 bytes", i+1)
+		}
+		var pubKey bls.PublicKey
+		if err := pubKey.Deserialize(data); err != nil {
+			return fmt.Errorf("public key %d is not a valid G1 point", i+1)
+		}
+		if pubKey.IsZero() {
+			return fmt.Errorf("public key %d is the point at infinity", i+1)
+		}
+		if !pubKey.IsValidOrder() {
+			return fmt.Errorf("public key %d is not in the G1 subgroup", i+1)
+		}
+
+		// Duplicates are permitted, as they occur in sync committees, and
+		// contribute to the aggregate once for each occurrence.
+		if seen[string(data)] {
+			c.duplicates++
+		}
+		seen[string(data)] = true
+
+		if i == 0 {
+			aggregate = pubKey
+		} else {
+			aggregate.Add(&pubKey)
+		}
+	}
+
+	c.aggregatePubKey = aggregate.Serialize()
+	c.count = len(c.pubKeys)
+
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signaturepubkeyaggregate
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	secretKeys := make([]bls.SecretKey, 3)
+	pubKeys := make([]string, 3)
+	for i := range secretKeys {
+		secretKeys[i].SetByCSPRNG()
+		pubKeys[i] = fmt.Sprintf("%#x", secretKeys[i].GetPublicKey().Serialize())
+	}
+	// The aggregate public key is the public key of the sum of the secret keys.
+	sum := secretKeys[0]
+	sum.Add(&secretKeys[1])
+	sum.Add(&secretKeys[2])
+	withDuplicate := sum
+	withDuplicate.Add(&secretKeys[1])
+
+	tests := []struct {
+		name       string
+		pubKeys    []string
+		res        []byte
+		duplicates int
+		err        string
+	}{
+		{
+			name:    "Single",
+			pubKeys: pubKeys[:1],
+			res:     secretKeys[0].GetPublicKey().Serialize(),
+		},
+		{
+			name:    "Multiple",
+			pubKeys: pubKeys,
+			res:     sum.GetPublicKey().Serialize(),
+		},
+		{
+			name:       "Duplicate",
+			pubKeys:    append([]string{pubKeys[1]}, pubKeys...),
+			res:        withDuplicate.GetPublicKey().Serialize(),
+			duplicates: 1,
+		},
+		{
+			name:    "NotHex",
+			pubKeys: []string{pubKeys[0], "invalid"},
+			err:     "public key 2 is not a hex string",
+		},
+		{
+			name:    "Short",
+			pubKeys: []string{"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e4"},
+			err:     "public key 1 must be 48 bytes",
+		},
+		{
+			name:    "NotOnCurve",
+			pubKeys: []string{"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44d"},
+			err:     "public key 1 is not a valid G1 point",
+		},
+		{
+			name:    "Infinity",
+			pubKeys: []string{"0xc00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"},
+			err:     "public key 1 is the point at infinity",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				pubKeys: test.pubKeys,
+			}
+			err := c.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.res, c.aggregatePubKey)
+			require.Equal(t, len(test.pubKeys), c.count)
+			require.Equal(t, test.duplicates, c.duplicates)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signaturerecombine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/go-bytesutil"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	timeout time.Duration
+
+	// Input.
+	ids              []uint64
+	signatures       []*bls.Sign
+	signingThreshold uint32
+	root             spec.Root
+	domain           spec.Domain
+	account          e2wtypes.Account
+
+	// Output.
+	signature *bls.Sign
+	used      []uint64
+	invalid   []uint64
+}
+
+func newCommand(ctx context.Context) (*command, error) {
+	c := &command{
+		quiet:            viper.GetBool("quiet"),
+		verbose:          viper.GetBool("verbose"),
+		debug:            viper.GetBool("debug"),
+		timeout:          viper.GetDuration("timeout"),
+		signingThreshold: viper.GetUint32("signing-threshold"),
+	}
+
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	inputs := viper.GetStringSlice("signatures")
+	if len(inputs) < 2 {
+		return nil, errors.New("multiple signatures are required to recombine")
+	}
+	seen := make(map[uint64]bool, len(inputs))
+	for _, input := range inputs {
+		id, signature, err := util.ParseThresholdSignature(input)
+		if err != nil {
+			return nil, err
+		}
+		if id == 0 {
+			return nil, errors.New("participant IDs must be greater than 0")
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("multiple signatures supplied for participant %d", id)
+		}
+		seen[id] = true
+		c.ids = append(c.ids, id)
+		c.signatures = append(c.signatures, signature)
+	}
+	if c.signingThreshold != 0 && uint32(len(c.ids)) < c.signingThreshold {
+		return nil, fmt.Errorf("%d signatures supplied but the signing threshold is %d", len(c.ids), c.signingThreshold)
+	}
+
+	if viper.GetString("signature-data") == "" {
+		return nil, errors.New("data is required")
+	}
+	data, err := bytesutil.FromHexString(viper.GetString("signature-data"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse data")
+	}
+	if len(data) != 32 {
+		return nil, errors.New("data must be 32 bytes")
+	}
+	copy(c.root[:], data)
+
+	domain, err := bytesutil.FromHexString(viper.GetString("signature-domain"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse domain")
+	}
+	if len(domain) != 32 {
+		return nil, errors.New("domain must be 32 bytes")
+	}
+	copy(c.domain[:], domain)
+
+	switch {
+	case viper.GetString("account") != "":
+		c.account, err = util.ParseAccount(ctx, viper.GetString("account"), nil, false)
+	case viper.GetString("public-key") != "":
+		c.account, err = util.ParseAccount(ctx, viper.GetString("public-key"), nil, false)
+	default:
+		return nil, errors.New("account or public-key is required")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain composite public key")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,46 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signaturerecombine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("%#x", c.signature.Serialize()))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("\nRecombined from participants %s", joinIDs(c.used)))
+	}
+	if len(c.invalid) > 0 {
+		builder.WriteString(fmt.Sprintf("\nInvalid signatures from participants %s", joinIDs(c.invalid)))
+	}
+
+	return builder.String(), nil
+}
+
+func joinIDs(ids []uint64) string {
+	res := make([]string, len(ids))
+	for i := range ids {
+		res[i] = fmt.Sprintf("%d", ids[i])
+	}
+
+	return strings.Join(res, ", ")
+}
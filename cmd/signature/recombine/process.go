@@ -0,0 +1,107 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signaturerecombine
+
+import (
+	"context"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func (c *command) process(_ context.Context) error {
+	// Start with all of the signatures; if they are all valid this will
+	// recover the full signature regardless of the threshold.
+	signature, valid, err := c.recoverAndVerify(c.ids)
+	if err != nil {
+		return err
+	}
+	if valid {
+		c.signature = signature
+		c.used = c.ids
+		return nil
+	}
+
+	// If more signatures than required have been supplied then one or more
+	// of them may be invalid, in which case there could be a subset that
+	// recovers the full signature.
+	if c.signingThreshold == 0 || uint32(len(c.ids)) == c.signingThreshold {
+		return errors.New("recombined signature does not verify against the composite public key")
+	}
+	for _, combination := range util.Combinations(c.ids, int(c.signingThreshold)) {
+		signature, valid, err := c.recoverAndVerify(combination)
+		if err != nil {
+			return err
+		}
+		if valid {
+			c.signature = signature
+			c.used = combination
+			break
+		}
+	}
+	if c.used == nil {
+		return errors.New("no combination of signatures verifies against the composite public key")
+	}
+
+	// Find the unused signatures that are invalid, by substituting each in
+	// turn for a signature that is known to be valid.
+	used := make(map[uint64]bool, len(c.used))
+	for _, id := range c.used {
+		used[id] = true
+	}
+	for _, id := range c.ids {
+		if used[id] {
+			continue
+		}
+		_, valid, err := c.recoverAndVerify(append([]uint64{id}, c.used[1:]...))
+		if err != nil {
+			return err
+		}
+		if !valid {
+			c.invalid = append(c.invalid, id)
+		}
+	}
+
+	return nil
+}
+
+// recoverAndVerify recovers the signature from the participants with the
+// given IDs, and verifies it against the composite public key.
+func (c *command) recoverAndVerify(ids []uint64) (*bls.Sign, bool, error) {
+	signatures := make([]*bls.Sign, len(ids))
+	for i, id := range ids {
+		for j := range c.ids {
+			if c.ids[j] == id {
+				signatures[i] = c.signatures[j]
+			}
+		}
+	}
+
+	recovered, err := util.RecoverSignature(ids, signatures)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to recombine signature")
+	}
+	signature, err := e2types.BLSSignatureFromBytes(recovered.Serialize())
+	if err != nil {
+		return nil, false, errors.Wrap(err, "invalid recombined signature")
+	}
+	verified, err := util.VerifyRoot(c.account, c.root, c.domain, signature)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to verify recombined signature")
+	}
+
+	return recovered, verified, nil
+}
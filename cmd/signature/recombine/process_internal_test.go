@@ -0,0 +1,133 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signaturerecombine
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx := context.Background()
+
+	// Create a 3-of-5 key, and sign with each share.
+	masterKey := make([]bls.SecretKey, 3)
+	for i := range masterKey {
+		masterKey[i].SetByCSPRNG()
+	}
+	root := spec.Root{0x01, 0x02, 0x03}
+	domain := spec.Domain{0x07}
+	signingRoot, err := (&spec.SigningData{ObjectRoot: root, Domain: domain}).HashTreeRoot()
+	require.NoError(t, err)
+	signatures := make(map[uint64]*bls.Sign)
+	for id := uint64(1); id <= 5; id++ {
+		var share bls.SecretKey
+		require.NoError(t, share.Set(masterKey, util.BLSID(id)))
+		signatures[id] = share.SignByte(signingRoot[:])
+	}
+	var other bls.SecretKey
+	other.SetByCSPRNG()
+	invalidSignature := other.SignByte(signingRoot[:])
+	expected := masterKey[0].SignByte(signingRoot[:]).Serialize()
+
+	account, err := util.ParseAccount(ctx, fmt.Sprintf("%#x", masterKey[0].GetPublicKey().Serialize()), nil, false)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name             string
+		ids              []uint64
+		invalidIDs       []uint64
+		signingThreshold uint32
+		used             []uint64
+		invalid          []uint64
+		err              string
+	}{
+		{
+			name: "Threshold",
+			ids:  []uint64{1, 3, 5},
+			used: []uint64{1, 3, 5},
+		},
+		{
+			name: "All",
+			ids:  []uint64{1, 2, 3, 4, 5},
+			used: []uint64{1, 2, 3, 4, 5},
+		},
+		{
+			name: "BelowThreshold",
+			ids:  []uint64{1, 3},
+			err:  "recombined signature does not verify against the composite public key",
+		},
+		{
+			name:       "InvalidNoThreshold",
+			ids:        []uint64{1, 2, 3, 4},
+			invalidIDs: []uint64{2},
+			err:        "recombined signature does not verify against the composite public key",
+		},
+		{
+			name:             "InvalidWithThreshold",
+			ids:              []uint64{1, 2, 3, 4, 5},
+			invalidIDs:       []uint64{2, 4},
+			signingThreshold: 3,
+			used:             []uint64{1, 3, 5},
+			invalid:          []uint64{2, 4},
+		},
+		{
+			name:             "TooManyInvalid",
+			ids:              []uint64{1, 2, 3, 4},
+			invalidIDs:       []uint64{2, 4},
+			signingThreshold: 3,
+			err:              "no combination of signatures verifies against the composite public key",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			invalid := make(map[uint64]bool)
+			for _, id := range test.invalidIDs {
+				invalid[id] = true
+			}
+			c := &command{
+				ids:              test.ids,
+				signingThreshold: test.signingThreshold,
+				root:             root,
+				domain:           domain,
+				account:          account,
+			}
+			for _, id := range test.ids {
+				if invalid[id] {
+					c.signatures = append(c.signatures, invalidSignature)
+				} else {
+					c.signatures = append(c.signatures, signatures[id])
+				}
+			}
+			err := c.process(ctx)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, expected, c.signature.Serialize())
+			require.Equal(t, test.used, c.used)
+			require.Equal(t, test.invalid, c.invalid)
+		})
+	}
+}
@@ -17,17 +17,21 @@ import (
 	"encoding/hex"
 	"fmt"
 	"os"
-	"strconv"
 	"strings"
 
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/go-bytesutil"
 )
 
-var signatureAggregateSignatures []string
+var (
+	signatureAggregateSignatures []string
+	signatureAggregateSigners    []string
+)
 
 // signatureAggregateCmd represents the signature aggregate command.
 var signatureAggregateCmd = &cobra.Command{
@@ -35,62 +39,57 @@ var signatureAggregateCmd = &cobra.Command{
 	Short: "Aggregate signatures",
 	Long: `Aggregate signatures, either threshold or absolute.  For example:
 
-    ethdo signature aggregate --signatures=0x5f24e819400c6a8ee2bfc014343cd971b7eb707320025a7bcd83e621e26c35b7,
+    ethdo signature aggregate --signature=0x8888... --signature=0x9999... --signer=0xa99a... --signer=0xb89b... --data=0x5f24e819400c6a8ee2bfc014343cd971b7eb707320025a7bcd83e621e26c35b7
+
+Signatures are specified as "signature" for simple aggregation, and as "id:signature" for threshold aggregation.  Signatures can also be supplied in files, with one signature per line.
 
-Signatures are specified as "signature" for simple aggregation, and as "id:signature" for threshold aggregation.
+If signers are supplied with --signer the aggregate signature is verified against their public keys, on the basis that all of the signers signed the same data supplied with --data and --domain.  For threshold aggregation the single signer is the composite public key.
 
 In quiet mode this will return 0 if the signatures can be aggregated, otherwise 1.`,
 	Run: func(_ *cobra.Command, _ []string) {
-		assert(len(signatureAggregateSignatures) > 1, "multiple signatures required to aggregate")
+		signatures, err := util.ExpandInputs(signatureAggregateSignatures)
+		errCheck(err, "Failed to obtain signatures")
+		assert(len(signatures) > 1, "multiple signatures required to aggregate")
 		var signature *bls.Sign
-		var err error
-		if strings.Contains(signatureAggregateSignatures[0], ":") {
-			signature, err = generateThresholdSignature()
+		if strings.Contains(signatures[0], ":") {
+			signature, err = generateThresholdSignature(signatures)
 		} else {
-			signature, err = generateAggregateSignature()
+			signature, err = generateAggregateSignature(signatures)
 		}
 		errCheck(err, "Failed to aggregate signature")
 
+		if len(signatureAggregateSigners) > 0 {
+			assert(viper.GetString("signature-data") != "", "--data is required to verify the aggregate signature")
+			signers, err := util.ExpandInputs(signatureAggregateSigners)
+			errCheck(err, "Failed to obtain signers")
+			verified, err := verifyAggregateSignature(signature, signers)
+			errCheck(err, "Failed to verify aggregate signature")
+			assert(verified, "Aggregate signature does not verify")
+		}
+
 		outputIf(!viper.GetBool("quiet"), fmt.Sprintf("%#x", signature.Serialize()))
 		os.Exit(_exitSuccess)
 	},
 }
 
-func generateThresholdSignature() (*bls.Sign, error) {
-	ids := make([]bls.ID, len(signatureAggregateSignatures))
-	sigs := make([]bls.Sign, len(signatureAggregateSignatures))
-
-	for i := range signatureAggregateSignatures {
-		parts := strings.Split(signatureAggregateSignatures[i], ":")
-		if len(parts) != 2 {
-			return nil, errors.New("invalid threshold signature format")
-		}
-		id, err := strconv.ParseUint(parts[0], 10, 64)
-		if err != nil {
-			return nil, errors.Wrap(err, "invalid threshold signature ID")
-		}
-		ids[i] = *util.BLSID(id)
-		sigBytes, err := hex.DecodeString(strings.TrimPrefix(parts[1], "0x"))
+func generateThresholdSignature(signatures []string) (*bls.Sign, error) {
+	ids := make([]uint64, len(signatures))
+	sigs := make([]*bls.Sign, len(signatures))
+	for i := range signatures {
+		var err error
+		ids[i], sigs[i], err = util.ParseThresholdSignature(signatures[i])
 		if err != nil {
-			return nil, errors.Wrap(err, "invalid threshold signature ID")
+			return nil, err
 		}
-		if err := sigs[i].Deserialize(sigBytes); err != nil {
-			return nil, errors.Wrap(err, "invalid signature")
-		}
-	}
-
-	var compositeSig bls.Sign
-	if err := compositeSig.Recover(sigs, ids); err != nil {
-		return nil, err
 	}
 
-	return &compositeSig, nil
+	return util.RecoverSignature(ids, sigs)
 }
 
-func generateAggregateSignature() (*bls.Sign, error) {
-	sigs := make([]bls.Sign, len(signatureAggregateSignatures))
-	for i := range signatureAggregateSignatures {
-		sigBytes, err := hex.DecodeString(strings.TrimPrefix(signatureAggregateSignatures[i], "0x"))
+func generateAggregateSignature(signatures []string) (*bls.Sign, error) {
+	sigs := make([]bls.Sign, len(signatures))
+	for i := range signatures {
+		sigBytes, err := hex.DecodeString(strings.TrimPrefix(signatures[i], "0x"))
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to decode signature")
 		}
@@ -104,8 +103,48 @@ func generateAggregateSignature() (*bls.Sign, error) {
 	return &aggregateSig, nil
 }
 
+// verifyAggregateSignature verifies an aggregate signature against the
+// public keys of the signers, all of which signed the same data.
+func verifyAggregateSignature(signature *bls.Sign, signers []string) (bool, error) {
+	data, err := bytesutil.FromHexString(viper.GetString("signature-data"))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to parse data")
+	}
+	if len(data) != 32 {
+		return false, errors.New("data to verify must be 32 bytes")
+	}
+	domain, err := bytesutil.FromHexString(viper.GetString("signature-domain"))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to parse domain")
+	}
+	if len(domain) != 32 {
+		return false, errors.New("domain must be 32 bytes")
+	}
+	container := &spec.SigningData{}
+	copy(container.ObjectRoot[:], data)
+	copy(container.Domain[:], domain)
+	signingRoot, err := container.HashTreeRoot()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to generate signing root")
+	}
+
+	pubKeys := make([]bls.PublicKey, len(signers))
+	for i := range signers {
+		pubKeyBytes, err := bytesutil.FromHexString(signers[i])
+		if err != nil {
+			return false, errors.Wrap(err, "failed to decode public key")
+		}
+		if err := pubKeys[i].Deserialize(pubKeyBytes); err != nil {
+			return false, errors.Wrap(err, "invalid public key")
+		}
+	}
+
+	return signature.FastAggregateVerify(pubKeys, signingRoot[:]), nil
+}
+
 func init() {
 	signatureCmd.AddCommand(signatureAggregateCmd)
-	signatureAggregateCmd.Flags().StringArrayVar(&signatureAggregateSignatures, "signature", nil, "a signature to aggregate (supply once for each signature)")
+	signatureAggregateCmd.Flags().StringArrayVar(&signatureAggregateSignatures, "signature", nil, "a signature to aggregate, or a file containing one signature per line (supply once for each signature or file)")
+	signatureAggregateCmd.Flags().StringArrayVar(&signatureAggregateSigners, "signer", nil, "the public key of a signer against which to verify the aggregate signature, or a file containing one public key per line (supply once for each signer or file)")
 	signatureFlags(signatureAggregateCmd)
 }
@@ -0,0 +1,62 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	signatureinfo "github.com/wealdtech/ethdo/cmd/signature/info"
+)
+
+var signatureInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Obtain information about a signature",
+	Long: `Obtain information about a BLS signature.  For example:
+
+    ethdo signature info --signature=0x8888...
+
+If candidate public keys are supplied along with the data and domain that were signed, the public keys that verify the signature are also reported.  For example:
+
+    ethdo signature info --signature=0x8888... --data=0x5f24... --pubkey=0xa99a... --pubkey=0xb89b...
+
+In quiet mode this will return 0 if the signature is a valid signature and, if public keys are supplied, is verified by one of them, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := signatureinfo.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	signatureCmd.AddCommand(signatureInfoCmd)
+	signatureFlags(signatureInfoCmd)
+	signatureInfoCmd.Flags().String("signature", "", "the signature, as a hex string")
+	signatureInfoCmd.Flags().StringArray("pubkey", nil, "a candidate public key, or a file containing one public key per line (supply once for each public key or file)")
+}
+
+func signatureInfoBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("signature", cmd.Flags().Lookup("signature")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("pubkeys", cmd.Flags().Lookup("pubkey")); err != nil {
+		panic(err)
+	}
+}
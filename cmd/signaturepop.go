@@ -0,0 +1,41 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// signaturePopCmd represents the signature pop command.
+var signaturePopCmd = &cobra.Command{
+	Use:     "pop",
+	Aliases: []string{"proof-of-possession"},
+	Short:   "Manage proofs of possession",
+	Long:    `Create and verify proofs of possession of the private keys for BLS public keys.  A proof of possession is a signature of the public key in the deposit domain.`,
+}
+
+func init() {
+	signatureCmd.AddCommand(signaturePopCmd)
+}
+
+func signaturePopFlags(cmd *cobra.Command) {
+	cmd.Flags().String("forkversion", "", "Genesis fork version of the chain (default is to use mainnet value)")
+}
+
+func signaturePopBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("forkversion", cmd.Flags().Lookup("forkversion")); err != nil {
+		panic(err)
+	}
+}
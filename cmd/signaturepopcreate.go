@@ -0,0 +1,52 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	signaturepopcreate "github.com/wealdtech/ethdo/cmd/signature/pop/create"
+)
+
+var signaturePopCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a proof of possession",
+	Long: `Create a proof of possession of the private key for an account.  For example:
+
+    ethdo signature pop create --account="Validators/1" --passphrase="secret"
+
+The proof of possession is a signature of the account's public key in the deposit domain, using the genesis fork version supplied with --forkversion or mainnet's if not supplied.
+
+In quiet mode this will return 0 if the proof of possession has been created, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := signaturepopcreate.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	signaturePopCmd.AddCommand(signaturePopCreateCmd)
+	signaturePopFlags(signaturePopCreateCmd)
+}
+
+func signaturePopCreateBindings(cmd *cobra.Command) {
+	signaturePopBindings(cmd)
+}
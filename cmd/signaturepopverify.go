@@ -0,0 +1,61 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	signaturepopverify "github.com/wealdtech/ethdo/cmd/signature/pop/verify"
+)
+
+var signaturePopVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify a proof of possession",
+	Long: `Verify a proof of possession of the private key for a public key.  For example:
+
+    ethdo signature pop verify --public-key=0xa99a... --signature=0x8888...
+
+The proof of possession can also be supplied with --proof, as the JSON output of "ethdo signature pop create --json" or the path to a file containing it.
+
+In quiet mode this will return 0 if the proof of possession is valid, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := signaturepopverify.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	signaturePopCmd.AddCommand(signaturePopVerifyCmd)
+	signaturePopFlags(signaturePopVerifyCmd)
+	signaturePopVerifyCmd.Flags().String("signature", "", "The proof of possession signature")
+	signaturePopVerifyCmd.Flags().String("proof", "", "The proof of possession as JSON, or the path to a file containing it")
+}
+
+func signaturePopVerifyBindings(cmd *cobra.Command) {
+	signaturePopBindings(cmd)
+	if err := viper.BindPFlag("signature", cmd.Flags().Lookup("signature")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("proof", cmd.Flags().Lookup("proof")); err != nil {
+		panic(err)
+	}
+}
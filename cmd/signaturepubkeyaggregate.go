@@ -0,0 +1,56 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	signaturepubkeyaggregate "github.com/wealdtech/ethdo/cmd/signature/pubkey/aggregate"
+)
+
+var signaturePubkeyAggregateCmd = &cobra.Command{
+	Use:   "aggregate",
+	Short: "Aggregate public keys",
+	Long: `Aggregate BLS public keys in to a single public key.  For example:
+
+    ethdo signature pubkey aggregate --pubkey=0xa99a... --pubkey=0xb89b...
+
+Public keys can also be supplied in files, with one public key per line.  Each public key must be a valid point in the G1 subgroup.  Duplicate public keys are included once for each time they are supplied, as with sync committees.
+
+In quiet mode this will return 0 if the public keys can be aggregated, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := signaturepubkeyaggregate.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	signaturePubkeyCmd.AddCommand(signaturePubkeyAggregateCmd)
+	signaturePubkeyFlags(signaturePubkeyAggregateCmd)
+	signaturePubkeyAggregateCmd.Flags().StringArray("pubkey", nil, "a public key to aggregate, or a file containing one public key per line (supply once for each public key or file)")
+}
+
+func signaturePubkeyAggregateBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("pubkeys", cmd.Flags().Lookup("pubkey")); err != nil {
+		panic(err)
+	}
+}
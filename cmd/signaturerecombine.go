@@ -0,0 +1,60 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	signaturerecombine "github.com/wealdtech/ethdo/cmd/signature/recombine"
+)
+
+var signatureRecombineCmd = &cobra.Command{
+	Use:   "recombine",
+	Short: "Recombine threshold signatures in to a full signature",
+	Long: `Recombine threshold signatures from the participants of a distributed account in to a full signature, and verify it against the composite public key.  For example:
+
+    ethdo signature recombine --data=0x5f24e819400c6a8ee2bfc014343cd971b7eb707320025a7bcd83e621e26c35b7 --signature=1:0x8888... --signature=3:0x9999... --public-key=0xa99a...
+
+Signatures are specified as "id:signature", where id is the participant's ID in the distributed account.  If --signing-threshold is supplied along with more signatures than the threshold, invalid signatures are identified and excluded.
+
+In quiet mode this will return 0 if the signatures can be recombined and verified, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := signaturerecombine.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	signatureCmd.AddCommand(signatureRecombineCmd)
+	signatureFlags(signatureRecombineCmd)
+	signatureRecombineCmd.Flags().StringArray("signature", nil, "a threshold signature in the format id:signature (supply once for each signature)")
+	signatureRecombineCmd.Flags().Uint32("signing-threshold", 0, "the signing threshold of the distributed account")
+}
+
+func signatureRecombineBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("signatures", cmd.Flags().Lookup("signature")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("signing-threshold", cmd.Flags().Lookup("signing-threshold")); err != nil {
+		panic(err)
+	}
+}
@@ -14,19 +14,39 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	spec0 "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/slashingprotection"
 	"github.com/wealdtech/ethdo/util"
 	"github.com/wealdtech/go-bytesutil"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
+var (
+	beaconProposerDomainType = [4]byte{0x00, 0x00, 0x00, 0x00}
+	beaconAttesterDomainType = [4]byte{0x01, 0x00, 0x00, 0x00}
+
+	// signatureSignClient is the connection to the beacon node, if required.
+	signatureSignClient eth2client.Service
+)
+
 // signatureSignCmd represents the signature sign command.
 var signatureSignCmd = &cobra.Command{
 	Use:   "sign",
@@ -35,23 +55,78 @@ var signatureSignCmd = &cobra.Command{
 
     ethdo signature sign --data=0x5f24e819400c6a8ee2bfc014343cd971b7eb707320025a7bcd83e621e26c35b7 --account="Personal wallet/Operations" --passphrase="my account passphrase"
 
+Beacon block headers and attestation data can be signed with --block-header and --attestation-data respectively, in which case they are checked against a local EIP-3076 slashing protection database and refused if they conflict with previously signed messages.  For example:
+
+    ethdo signature sign --attestation-data=attestation.json --domain=0x01000000... --genesis-validators-root=0x4b36... --account="Personal wallet/Operations" --passphrase="my account passphrase"
+
+Multiple roots can be signed with a single unlock of the account with --roots-file, which takes a file containing one root per line and returns a JSON array of roots and their signatures.  Roots are not checked against the slashing protection database, so cannot be signed with the beacon proposer or beacon attester domain types.
+
+SSZ-encoded containers can be signed with --ssz-file and --type, and the domain calculated from its type with --domain-type.  Any information about the chain that is not supplied is obtained from the beacon node.  For example:
+
+    ethdo signature sign --ssz-file=block_header.ssz --type=BeaconBlockHeader --domain-type=DOMAIN_BEACON_PROPOSER --account="Personal wallet/Operations" --passphrase="my account passphrase"
+
 In quiet mode this will return 0 if the data can be signed, otherwise 1.`,
 	Run: func(_ *cobra.Command, _ []string) {
 		ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
 		defer cancel()
 
-		assert(viper.GetString("signature-data") != "", "--data is required")
-		data, err := bytesutil.FromHexString(viper.GetString("signature-data"))
-		errCheck(err, "Failed to parse data")
-		assert(len(data) == 32, "data to sign must be 32 bytes")
+		var data []byte
+		var roots []spec.Root
+		var err error
+		var blockHeader *spec.BeaconBlockHeader
+		var attestationData *spec.AttestationData
+		var genesisValidatorsRoot *spec.Root
+		switch {
+		case viper.GetString("signature-data") != "":
+			data, err = bytesutil.FromHexString(viper.GetString("signature-data"))
+			errCheck(err, "Failed to parse data")
+		case viper.GetString("block-header") != "":
+			blockHeader = &spec.BeaconBlockHeader{}
+			errCheck(json.Unmarshal(signatureSignInput(viper.GetString("block-header")), blockHeader), "Failed to parse block header")
+			root, err := blockHeader.HashTreeRoot()
+			errCheck(err, "Failed to obtain hash tree root of block header")
+			data = root[:]
+		case viper.GetString("attestation-data") != "":
+			attestationData = &spec.AttestationData{}
+			errCheck(json.Unmarshal(signatureSignInput(viper.GetString("attestation-data")), attestationData), "Failed to parse attestation data")
+			root, err := attestationData.HashTreeRoot()
+			errCheck(err, "Failed to obtain hash tree root of attestation data")
+			data = root[:]
+		case viper.GetString("ssz-file") != "":
+			container, err := signatureSignSSZContainer(ctx)
+			errCheck(err, "Failed to parse SSZ file")
+			root, err := container.HashTreeRoot()
+			errCheck(err, "Failed to obtain hash tree root of SSZ container")
+			data = root[:]
+			blockHeader, attestationData, err = signatureSignProtectable(container)
+			errCheck(err, "Failed to obtain slashing protection information")
+		case viper.GetString("roots-file") != "":
+			roots, err = signatureSignRoots(viper.GetString("roots-file"))
+			errCheck(err, "Failed to parse roots file")
+		default:
+			die("--data, --block-header, --attestation-data, --ssz-file or --roots-file is required")
+		}
+		assert(roots != nil || len(data) == 32, "data to sign must be 32 bytes")
 
 		domain := e2types.Domain(e2types.DomainType([4]byte{0, 0, 0, 0}), e2types.ZeroForkVersion, e2types.ZeroGenesisValidatorsRoot)
-		if viper.GetString("signature-domain") != "" {
+		switch {
+		case viper.GetString("domain-type") != "":
+			assert(!domainFlag.Changed, "only one of --domain and --domain-type can be supplied")
+			var specDomain spec.Domain
+			specDomain, genesisValidatorsRoot, err = signatureSignDomain(ctx)
+			errCheck(err, "Failed to calculate domain")
+			domain = specDomain[:]
+		case viper.GetString("signature-domain") != "":
 			domain, err = bytesutil.FromHexString(viper.GetString("signature-domain"))
 			errCheck(err, "Failed to parse domain")
 			assert(len(domain) == 32, "Domain data invalid")
 		}
 		outputIf(viper.GetBool("debug"), fmt.Sprintf("Domain is %#x", domain))
+		if roots != nil && (viper.GetString("domain-type") != "" || viper.GetString("signature-domain") != "") {
+			// Roots are not checked against the slashing protection database, so refuse slashable domains.
+			assert(!bytes.Equal(domain[:4], beaconProposerDomainType[:]) && !bytes.Equal(domain[:4], beaconAttesterDomainType[:]),
+				"--roots-file cannot be used with the beacon proposer or beacon attester domain types; sign block headers or attestation data individually")
+		}
 
 		var account e2wtypes.Account
 		switch {
@@ -64,18 +139,402 @@ In quiet mode this will return 0 if the data can be signed, otherwise 1.`,
 
 		var specDomain spec.Domain
 		copy(specDomain[:], domain)
+
+		if roots != nil {
+			// The account remains unlocked, so is unlocked once for all roots.
+			res := make([]*signatureSignRootJSON, 0, len(roots))
+			for _, root := range roots {
+				outputIf(viper.GetBool("debug"), fmt.Sprintf("Signing %#x with domain %#x by public key %#x", root, specDomain, account.PublicKey().Marshal()))
+				signature, err := util.SignRoot(account, root, specDomain)
+				errCheck(err, fmt.Sprintf("Failed to sign %#x", root))
+				res = append(res, &signatureSignRootJSON{
+					Root:      fmt.Sprintf("%#x", root),
+					Signature: fmt.Sprintf("%#x", signature.Marshal()),
+				})
+			}
+			output, err := json.Marshal(res)
+			errCheck(err, "Failed to generate output")
+			outputIf(!viper.GetBool("quiet"), string(output))
+			os.Exit(_exitSuccess)
+		}
+
 		var fixedSizeData [32]byte
 		copy(fixedSizeData[:], data)
+		var recordSigning func() error
+		if blockHeader != nil || attestationData != nil {
+			recordSigning, err = signatureSignProtect(account, fixedSizeData, specDomain, genesisValidatorsRoot, blockHeader, attestationData)
+			errCheck(err, "Failed slashing protection check")
+		}
 		outputIf(viper.GetBool("debug"), fmt.Sprintf("Signing %#x with domain %#x by public key %#x", fixedSizeData, specDomain, account.PublicKey().Marshal()))
 		signature, err := util.SignRoot(account, fixedSizeData, specDomain)
 		errCheck(err, "Failed to sign")
+		if recordSigning != nil {
+			// Only record the signing once the signature has been obtained.
+			errCheck(recordSigning(), "Failed to record signing in slashing protection database")
+		}
 
 		outputIf(!viper.GetBool("quiet"), fmt.Sprintf("%#x", signature.Marshal()))
 		os.Exit(_exitSuccess)
 	},
 }
 
+// signatureSignRootJSON is the output for a root signed from a roots file.
+type signatureSignRootJSON struct {
+	Root      string `json:"root"`
+	Signature string `json:"signature"`
+}
+
+// signatureSignRoots reads the roots to sign from a file containing one
+// 32-byte hex root per line.  Blank lines are ignored.
+func signatureSignRoots(path string) ([]spec.Root, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read file")
+	}
+
+	roots := make([]spec.Root, 0)
+	for i, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		root, err := bytesutil.FromHexString(line)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid root on line %d", i+1)
+		}
+		if len(root) != spec.RootLength {
+			return nil, fmt.Errorf("root on line %d must be 32 bytes", i+1)
+		}
+		roots = append(roots, spec.Root(root))
+	}
+	if len(roots) == 0 {
+		return nil, errors.New("no roots found")
+	}
+
+	return roots, nil
+}
+
+// signatureSignInput returns the supplied input, reading it from a file if
+// it is not itself JSON.
+func signatureSignInput(input string) []byte {
+	if strings.HasPrefix(strings.TrimSpace(input), "{") {
+		return []byte(input)
+	}
+	data, err := os.ReadFile(input)
+	errCheck(err, "Failed to read input file")
+
+	return data
+}
+
+// signatureSignChainClient connects to the beacon node, if not already
+// connected, for information about the chain that has not been supplied.
+func signatureSignChainClient(ctx context.Context) (eth2client.Service, error) {
+	if signatureSignClient != nil {
+		return signatureSignClient, nil
+	}
+
+	var err error
+	signatureSignClient, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       viper.GetString("connection"),
+		Timeout:       viper.GetDuration("timeout"),
+		AllowInsecure: viper.GetBool("allow-insecure-connections"),
+		LogFallback:   !viper.GetBool("quiet"),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	return signatureSignClient, nil
+}
+
+// signatureSignSSZContainer decodes the SSZ file as the container type
+// supplied, using the schema for the fork supplied or, if not supplied, that
+// active on the chain.
+func signatureSignSSZContainer(ctx context.Context) (util.SSZContainer, error) {
+	typeName := viper.GetString("type")
+	if typeName == "" {
+		return nil, errors.New("--type is required with --ssz-file")
+	}
+
+	fork := spec0.DataVersionUnknown
+	if util.IsForkDependentSSZContainer(typeName) {
+		var err error
+		if viper.GetString("fork") != "" {
+			fork, err = util.ParseFork(viper.GetString("fork"))
+		} else {
+			var eth2Client eth2client.Service
+			eth2Client, err = signatureSignChainClient(ctx)
+			if err != nil {
+				return nil, err
+			}
+			fork, err = util.ActiveFork(ctx, eth2Client.(eth2client.SpecProvider), eth2Client.(eth2client.ForkProvider))
+		}
+		if err != nil {
+			return nil, err
+		}
+		outputIf(viper.GetBool("debug"), fmt.Sprintf("Using %s schema for %s", fork, typeName))
+	}
+
+	container, err := util.NewSSZContainer(typeName, fork)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(viper.GetString("ssz-file"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read file")
+	}
+	// Allow the file to contain the SSZ data as a hex string.
+	if hexData := strings.TrimSpace(string(data)); strings.HasPrefix(hexData, "0x") {
+		data, err = bytesutil.FromHexString(hexData)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid hex data")
+		}
+	}
+
+	if err := container.UnmarshalSSZ(data); err != nil {
+		return nil, errors.Wrap(err, "failed to decode SSZ data")
+	}
+
+	return container, nil
+}
+
+// signatureSignProtectable returns the block header or attestation data that
+// a container represents, for slashing protection.  A block is represented by
+// its header, which has the same hash tree root.
+func signatureSignProtectable(container util.SSZContainer) (*spec.BeaconBlockHeader, *spec.AttestationData, error) {
+	var slot spec.Slot
+	var proposerIndex spec.ValidatorIndex
+	var parentRoot, stateRoot spec.Root
+	var body util.SSZContainer
+	switch block := container.(type) {
+	case *spec.BeaconBlockHeader:
+		return block, nil, nil
+	case *spec.AttestationData:
+		return nil, block, nil
+	case *spec.BeaconBlock:
+		slot, proposerIndex, parentRoot, stateRoot, body = block.Slot, block.ProposerIndex, block.ParentRoot, block.StateRoot, block.Body
+	case *altair.BeaconBlock:
+		slot, proposerIndex, parentRoot, stateRoot, body = block.Slot, block.ProposerIndex, block.ParentRoot, block.StateRoot, block.Body
+	case *bellatrix.BeaconBlock:
+		slot, proposerIndex, parentRoot, stateRoot, body = block.Slot, block.ProposerIndex, block.ParentRoot, block.StateRoot, block.Body
+	case *capella.BeaconBlock:
+		slot, proposerIndex, parentRoot, stateRoot, body = block.Slot, block.ProposerIndex, block.ParentRoot, block.StateRoot, block.Body
+	case *deneb.BeaconBlock:
+		slot, proposerIndex, parentRoot, stateRoot, body = block.Slot, block.ProposerIndex, block.ParentRoot, block.StateRoot, block.Body
+	default:
+		return nil, nil, nil
+	}
+
+	bodyRoot, err := body.HashTreeRoot()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to obtain hash tree root of block body")
+	}
+
+	return &spec.BeaconBlockHeader{
+		Slot:          slot,
+		ProposerIndex: proposerIndex,
+		ParentRoot:    parentRoot,
+		StateRoot:     stateRoot,
+		BodyRoot:      bodyRoot,
+	}, nil, nil
+}
+
+// signatureSignDomain calculates the domain for the supplied domain type,
+// obtaining the fork version and genesis validators root from the chain if
+// they are not supplied.  It returns the genesis validators root used, if
+// any.
+func signatureSignDomain(ctx context.Context) (spec.Domain, *spec.Root, error) {
+	name, domainType, err := util.ParseDomainType(viper.GetString("domain-type"))
+	if err != nil {
+		return spec.Domain{}, nil, err
+	}
+	forkIndependent := util.IsForkIndependentDomainType(name)
+
+	var forkVersion spec.Version
+	if viper.GetString("fork-version") != "" {
+		forkVersion, err = util.ParseForkVersion(viper.GetString("fork-version"))
+		if err != nil {
+			return spec.Domain{}, nil, err
+		}
+	} else {
+		eth2Client, err := signatureSignChainClient(ctx)
+		if err != nil {
+			return spec.Domain{}, nil, err
+		}
+		forkVersion, err = util.DomainForkVersion(ctx, eth2Client.(eth2client.SpecProvider), eth2Client.(eth2client.ForkProvider), name)
+		if err != nil {
+			return spec.Domain{}, nil, err
+		}
+	}
+
+	var genesisValidatorsRoot *spec.Root
+	switch {
+	case viper.GetString("genesis-validators-root") != "":
+		genesisValidatorsRoot, err = signatureSignGenesisValidatorsRoot()
+		if err != nil {
+			return spec.Domain{}, nil, err
+		}
+	case !forkIndependent:
+		eth2Client, err := signatureSignChainClient(ctx)
+		if err != nil {
+			return spec.Domain{}, nil, err
+		}
+		genesisResponse, err := eth2Client.(eth2client.GenesisProvider).Genesis(ctx, &api.GenesisOpts{})
+		if err != nil {
+			return spec.Domain{}, nil, errors.Wrap(err, "failed to obtain genesis information")
+		}
+		genesisValidatorsRoot = &genesisResponse.Data.GenesisValidatorsRoot
+	}
+
+	var root spec.Root
+	if genesisValidatorsRoot != nil && !forkIndependent {
+		root = *genesisValidatorsRoot
+	}
+	domain, err := util.ComputeDomain(domainType, forkVersion, root)
+	if err != nil {
+		return spec.Domain{}, nil, err
+	}
+
+	return domain, genesisValidatorsRoot, nil
+}
+
+// signatureSignGenesisValidatorsRoot parses the supplied genesis validators
+// root.
+func signatureSignGenesisValidatorsRoot() (*spec.Root, error) {
+	tmp, err := bytesutil.FromHexString(viper.GetString("genesis-validators-root"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid genesis validators root")
+	}
+	if len(tmp) != spec.RootLength {
+		return nil, errors.New("invalid length for genesis validators root")
+	}
+	genesisValidatorsRoot := spec.Root(tmp)
+
+	return &genesisValidatorsRoot, nil
+}
+
+// signatureSignProtect checks a block header or attestation data against the
+// local slashing protection database, returning a function to record it in
+// the database once it has been signed.
+func signatureSignProtect(account e2wtypes.Account,
+	root spec.Root,
+	domain spec.Domain,
+	genesisValidatorsRoot *spec.Root,
+	blockHeader *spec.BeaconBlockHeader,
+	attestationData *spec.AttestationData,
+) (
+	func() error,
+	error,
+) {
+	if genesisValidatorsRoot == nil {
+		if viper.GetString("genesis-validators-root") == "" {
+			return nil, errors.New("--genesis-validators-root is required for slashing protection")
+		}
+		var err error
+		genesisValidatorsRoot, err = signatureSignGenesisValidatorsRoot()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	path := viper.GetString("slashing-protection-file")
+	if path == "" {
+		path, err = slashingprotection.DefaultPath()
+		if err != nil {
+			return nil, err
+		}
+	}
+	db, err := slashingprotection.Open(path, *genesisValidatorsRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKey, err := util.BestPublicKey(account)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain public key")
+	}
+	var blsPubKey spec.BLSPubKey
+	copy(blsPubKey[:], pubKey.Marshal())
+
+	signingRoot, err := (&spec.SigningData{
+		ObjectRoot: root,
+		Domain:     domain,
+	}).HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to calculate signing root")
+	}
+
+	if blockHeader != nil {
+		if !bytes.Equal(domain[:4], beaconProposerDomainType[:]) {
+			return nil, fmt.Errorf("domain type %#x is not the beacon proposer domain type %#x", domain[:4], beaconProposerDomainType)
+		}
+		if err := db.CheckBlock(blsPubKey, blockHeader.Slot, signingRoot); err != nil {
+			return nil, err
+		}
+
+		return func() error {
+			return db.RecordBlock(blsPubKey, blockHeader.Slot, signingRoot)
+		}, nil
+	}
+
+	if !bytes.Equal(domain[:4], beaconAttesterDomainType[:]) {
+		return nil, fmt.Errorf("domain type %#x is not the beacon attester domain type %#x", domain[:4], beaconAttesterDomainType)
+	}
+	if err := db.CheckAttestation(blsPubKey, attestationData.Source.Epoch, attestationData.Target.Epoch, signingRoot); err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		return db.RecordAttestation(blsPubKey, attestationData.Source.Epoch, attestationData.Target.Epoch, signingRoot)
+	}, nil
+}
+
 func init() {
 	signatureCmd.AddCommand(signatureSignCmd)
 	signatureFlags(signatureSignCmd)
+	signatureSignCmd.Flags().String("block-header", "", "Beacon block header to sign, as JSON or a path to a JSON file, checked against the slashing protection database")
+	signatureSignCmd.Flags().String("attestation-data", "", "Attestation data to sign, as JSON or a path to a JSON file, checked against the slashing protection database")
+	signatureSignCmd.Flags().String("genesis-validators-root", "", "Genesis validators root of the chain, required when signing block headers or attestation data unless obtained from the chain with --domain-type")
+	signatureSignCmd.Flags().String("ssz-file", "", "Path to a file containing an SSZ-encoded container to sign, either as binary or as a hex string")
+	signatureSignCmd.Flags().String("roots-file", "", "Path to a file containing 32-byte roots to sign, one per line, in place of --data")
+	signatureSignCmd.Flags().String("type", "", "Type of the container in the SSZ file, for example BeaconBlockHeader")
+	signatureSignCmd.Flags().String("fork", "", "Fork whose schema is used for the container in the SSZ file, if the schema changes with the fork (defaults to the fork active on the chain)")
+	signatureSignCmd.Flags().String("domain-type", "", "Domain type in which to sign, either as a name (e.g. DOMAIN_BEACON_PROPOSER) or a 4-byte hex string; used to calculate the domain if --domain is not supplied")
+	signatureSignCmd.Flags().String("fork-version", "", "Fork version used to calculate the domain with --domain-type (defaults to the fork version the chain uses for the domain type)")
+	signatureSignCmd.Flags().String("slashing-protection-file", "", "Path to the EIP-3076 slashing protection database (defaults to slashing-protection.json in the user configuration directory)")
+}
+
+func signatureSignBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("block-header", cmd.Flags().Lookup("block-header")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("attestation-data", cmd.Flags().Lookup("attestation-data")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("genesis-validators-root", cmd.Flags().Lookup("genesis-validators-root")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("ssz-file", cmd.Flags().Lookup("ssz-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("roots-file", cmd.Flags().Lookup("roots-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("type", cmd.Flags().Lookup("type")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("fork", cmd.Flags().Lookup("fork")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("domain-type", cmd.Flags().Lookup("domain-type")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("fork-version", cmd.Flags().Lookup("fork-version")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("slashing-protection-file", cmd.Flags().Lookup("slashing-protection-file")); err != nil {
+		panic(err)
+	}
 }
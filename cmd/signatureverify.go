@@ -27,11 +27,6 @@ import (
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
-var (
-	signatureVerifySignature string
-	signatureVerifySigner    string
-)
-
 // signatureVerifyCmd represents the signature verify command.
 var signatureVerifyCmd = &cobra.Command{
 	Use:   "verify",
@@ -40,18 +35,27 @@ var signatureVerifyCmd = &cobra.Command{
 
     ethdo signature verify --data=0x5f24e819400c6a8ee2bfc014343cd971b7eb707320025a7bcd83e621e26c35b7 --signature=0x8888... --account="Personal wallet/Operations"
 
+Signatures from signers that are not available as accounts can be verified with the signer's public key, without any wallets.  For example:
+
+    ethdo signature verify --root=0x5f24e819400c6a8ee2bfc014343cd971b7eb707320025a7bcd83e621e26c35b7 --domain=0x04000000... --signature=0x8888... --pubkey=0xa99a...
+
 In quiet mode this will return 0 if the data can be signed, otherwise 1.`,
 	Run: func(_ *cobra.Command, _ []string) {
 		ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
 		defer cancel()
 
-		assert(viper.GetString("signature-data") != "", "--data is required")
-		data, err := bytesutil.FromHexString(viper.GetString("signature-data"))
+		input := viper.GetString("signature-data")
+		if viper.GetString("signature-root") != "" {
+			assert(input == "", "only one of --data and --root can be supplied")
+			input = viper.GetString("signature-root")
+		}
+		assert(input != "", "--data or --root is required")
+		data, err := bytesutil.FromHexString(input)
 		errCheck(err, "Failed to parse data")
 		assert(len(data) == 32, "data to verify must be 32 bytes")
 
-		assert(signatureVerifySignature != "", "--signature is required")
-		signatureBytes, err := bytesutil.FromHexString(signatureVerifySignature)
+		assert(viper.GetString("signature") != "", "--signature is required")
+		signatureBytes, err := bytesutil.FromHexString(viper.GetString("signature"))
 		errCheck(err, "Failed to parse signature")
 		signature, err := e2types.BLSSignatureFromBytes(signatureBytes)
 		errCheck(err, "Invalid signature")
@@ -63,34 +67,82 @@ In quiet mode this will return 0 if the data can be signed, otherwise 1.`,
 			assert(len(domain) == 32, "Domain data invalid")
 		}
 
-		var account e2wtypes.Account
-		switch {
-		case viper.GetString("account") != "":
-			account, err = util.ParseAccount(ctx, viper.GetString("account"), nil, false)
-		case viper.GetString("private-key") != "":
-			account, err = util.ParseAccount(ctx, viper.GetString("private-key"), nil, false)
-		case viper.GetString("public-key") != "":
-			account, err = util.ParseAccount(ctx, viper.GetString("public-key"), nil, false)
-		}
-		errCheck(err, "Failed to obtain account")
-		outputIf(viper.GetBool("debug"), fmt.Sprintf("Public key is %#x", account.PublicKey().Marshal()))
-
 		var specDomain spec.Domain
 		copy(specDomain[:], domain)
 		var root [32]byte
 		copy(root[:], data)
-		verified, err := util.VerifyRoot(account, root, specDomain, signature)
-		errCheck(err, "Failed to verify data")
-		assert(verified, "Failed to verify")
+
+		var verified bool
+		if pubKeyStr := signatureVerifyPubKey(); pubKeyStr != "" {
+			// A bare public key, which does not require any wallets.
+			pubKeyBytes, err := bytesutil.FromHexString(pubKeyStr)
+			errCheck(err, "Failed to parse public key")
+			pubKey, err := e2types.BLSPublicKeyFromBytes(pubKeyBytes)
+			errCheck(err, "Invalid public key")
+			outputIf(viper.GetBool("debug"), fmt.Sprintf("Public key is %#x", pubKey.Marshal()))
+			signingRoot, err := (&spec.SigningData{
+				ObjectRoot: root,
+				Domain:     specDomain,
+			}).HashTreeRoot()
+			errCheck(err, "Failed to generate signing root")
+			verified = signature.Verify(signingRoot[:], pubKey)
+		} else {
+			var account e2wtypes.Account
+			switch {
+			case viper.GetString("account") != "":
+				account, err = util.ParseAccount(ctx, viper.GetString("account"), nil, false)
+			case viper.GetString("private-key") != "":
+				account, err = util.ParseAccount(ctx, viper.GetString("private-key"), nil, false)
+			default:
+				die("--account, --private-key or --pubkey is required")
+			}
+			errCheck(err, "Failed to obtain account")
+			outputIf(viper.GetBool("debug"), fmt.Sprintf("Public key is %#x", account.PublicKey().Marshal()))
+			verified, err = util.VerifyRoot(account, root, specDomain, signature)
+			errCheck(err, "Failed to verify data")
+		}
+		assert(verified, "Not verified")
 
 		outputIf(viper.GetBool("verbose"), "Verified")
 		os.Exit(_exitSuccess)
 	},
 }
 
+// signatureVerifyPubKey returns the public key of the signer, if supplied
+// directly rather than as an account.
+func signatureVerifyPubKey() string {
+	for _, key := range []string{"pubkey", "signer", "public-key"} {
+		if viper.GetString(key) != "" {
+			return viper.GetString(key)
+		}
+	}
+
+	return ""
+}
+
 func init() {
 	signatureCmd.AddCommand(signatureVerifyCmd)
 	signatureFlags(signatureVerifyCmd)
-	signatureVerifyCmd.Flags().StringVar(&signatureVerifySignature, "signature", "", "the signature to verify")
-	signatureVerifyCmd.Flags().StringVar(&signatureVerifySigner, "signer", "", "the public key of the signer (only if --account is not supplied)")
+	signatureVerifyCmd.Flags().String("signature", "", "the signature to verify")
+	signatureVerifyCmd.Flags().String("root", "", "the 32-byte root whose signature to verify, in place of --data")
+	signatureVerifyCmd.Flags().String("pubkey", "", "the public key of the signer (only if --account is not supplied)")
+	signatureVerifyCmd.Flags().String("signer", "", "the public key of the signer (only if --account is not supplied)")
+	if err := signatureVerifyCmd.Flags().MarkDeprecated("signer", "use --pubkey"); err != nil {
+		panic(err)
+	}
+}
+
+func signatureVerifyBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("signature", cmd.Flags().Lookup("signature")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("signature-root", cmd.Flags().Lookup("root")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("pubkey", cmd.Flags().Lookup("pubkey")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("signer", cmd.Flags().Lookup("signer")); err != nil {
+		panic(err)
+	}
 }
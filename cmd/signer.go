@@ -0,0 +1,29 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// signerCmd represents the signer command.
+var signerCmd = &cobra.Command{
+	Use:   "signer",
+	Short: "Manage a remote signer",
+	Long:  `Manage a remote signer.  Commands connect to the remote wallet daemon supplied with --remote, using the credentials supplied with --client-cert, --client-key and --server-ca-cert.`,
+}
+
+func init() {
+	RootCmd.AddCommand(signerCmd)
+}
@@ -0,0 +1,128 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signeraccountcreate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	pb "github.com/wealdtech/eth2-signer-api/pb/v1"
+	"google.golang.org/grpc"
+)
+
+type mockAccountManager struct {
+	resp *pb.GenerateResponse
+	err  error
+	req  *pb.GenerateRequest
+}
+
+func (m *mockAccountManager) Unlock(_ context.Context, _ *pb.UnlockAccountRequest, _ ...grpc.CallOption) (*pb.UnlockAccountResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockAccountManager) Lock(_ context.Context, _ *pb.LockAccountRequest, _ ...grpc.CallOption) (*pb.LockAccountResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockAccountManager) Generate(_ context.Context, in *pb.GenerateRequest, _ ...grpc.CallOption) (*pb.GenerateResponse, error) {
+	m.req = in
+
+	return m.resp, m.err
+}
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name             string
+		accountManager   *mockAccountManager
+		participants     uint32
+		signingThreshold uint32
+		err              string
+	}{
+		{
+			name: "Succeeded",
+			accountManager: &mockAccountManager{
+				resp: &pb.GenerateResponse{
+					State:     pb.ResponseState_SUCCEEDED,
+					PublicKey: []byte{0x01, 0x02},
+				},
+			},
+			participants:     1,
+			signingThreshold: 1,
+		},
+		{
+			name: "Distributed",
+			accountManager: &mockAccountManager{
+				resp: &pb.GenerateResponse{
+					State:     pb.ResponseState_SUCCEEDED,
+					PublicKey: []byte{0x01, 0x02},
+					Participants: []*pb.Endpoint{
+						{Id: 1, Name: "signer-1", Port: 9091},
+						{Id: 2, Name: "signer-2", Port: 9091},
+						{Id: 3, Name: "signer-3", Port: 9091},
+					},
+				},
+			},
+			participants:     3,
+			signingThreshold: 2,
+		},
+		{
+			name: "Denied",
+			accountManager: &mockAccountManager{
+				resp: &pb.GenerateResponse{
+					State:   pb.ResponseState_DENIED,
+					Message: "account already exists",
+				},
+			},
+			participants:     1,
+			signingThreshold: 1,
+			err:              "request denied by remote signer: account already exists",
+		},
+		{
+			name: "Failed",
+			accountManager: &mockAccountManager{
+				err: errors.New("connection refused"),
+			},
+			participants:     1,
+			signingThreshold: 1,
+			err:              "failed to create account: connection refused",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				timeout:          time.Minute,
+				accountManager:   test.accountManager,
+				account:          "Test/1",
+				passphrase:       "secret",
+				participants:     test.participants,
+				signingThreshold: test.signingThreshold,
+			}
+			err := c.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "Test/1", test.accountManager.req.GetAccount())
+			require.Equal(t, test.participants, test.accountManager.req.GetParticipants())
+			require.Equal(t, test.signingThreshold, test.accountManager.req.GetSigningThreshold())
+			require.Equal(t, []byte{0x01, 0x02}, c.pubKey)
+			require.Len(t, c.participantsUsed, len(test.accountManager.resp.GetParticipants()))
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signeraccountlist
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	pb "github.com/wealdtech/eth2-signer-api/pb/v1"
+	"github.com/wealdtech/ethdo/util"
+	"google.golang.org/grpc"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	timeout time.Duration
+
+	// Input.
+	conn   *grpc.ClientConn
+	lister pb.ListerClient
+	path   string
+
+	// Output.
+	accounts []*account
+}
+
+type account struct {
+	Name               string   `json:"name"`
+	UUID               string   `json:"uuid"`
+	PublicKey          string   `json:"public_key"`
+	CompositePublicKey string   `json:"composite_public_key,omitempty"`
+	SigningThreshold   uint32   `json:"signing_threshold,omitempty"`
+	Participants       []string `json:"participants,omitempty"`
+}
+
+func newCommand(ctx context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+		timeout: viper.GetDuration("timeout"),
+	}
+
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	// The path can be either a wallet or an account specifier.
+	switch {
+	case viper.GetString("account") != "":
+		c.path = viper.GetString("account")
+	case viper.GetString("wallet") != "":
+		c.path = viper.GetString("wallet")
+	default:
+		return nil, errors.New("wallet or account is required")
+	}
+
+	var err error
+	c.conn, err = util.NewSignerConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.lister = pb.NewListerClient(c.conn)
+
+	return c, nil
+}
@@ -0,0 +1,58 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signeraccountlist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		data, err := json.Marshal(c.accounts)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	builder := strings.Builder{}
+	for i, account := range c.accounts {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		builder.WriteString(account.Name)
+		if !c.verbose {
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("\n UUID: %s", account.UUID))
+		builder.WriteString(fmt.Sprintf("\n Public key: %s", account.PublicKey))
+		if account.CompositePublicKey != "" {
+			builder.WriteString(fmt.Sprintf("\n Composite public key: %s", account.CompositePublicKey))
+			builder.WriteString(fmt.Sprintf("\n Signing threshold: %d/%d", account.SigningThreshold, len(account.Participants)))
+			builder.WriteString("\n Participants:")
+			for _, participant := range account.Participants {
+				builder.WriteString(fmt.Sprintf("\n  %s", participant))
+			}
+		}
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,78 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signeraccountlist
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	pb "github.com/wealdtech/eth2-signer-api/pb/v1"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.lister.ListAccounts(ctx, &pb.ListAccountsRequest{
+		Paths: []string{c.path},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list accounts")
+	}
+	if err := util.SignerResponseError(resp.GetState(), ""); err != nil {
+		return err
+	}
+
+	c.accounts = make([]*account, 0, len(resp.GetAccounts())+len(resp.GetDistributedAccounts()))
+	for _, acc := range resp.GetAccounts() {
+		c.accounts = append(c.accounts, &account{
+			Name:      acc.GetName(),
+			UUID:      formatUUID(acc.GetUuid()),
+			PublicKey: fmt.Sprintf("%#x", acc.GetPublicKey()),
+		})
+	}
+	for _, acc := range resp.GetDistributedAccounts() {
+		participants := make([]string, 0, len(acc.GetParticipants()))
+		for _, participant := range acc.GetParticipants() {
+			participants = append(participants, fmt.Sprintf("%d: %s:%d", participant.GetId(), participant.GetName(), participant.GetPort()))
+		}
+		c.accounts = append(c.accounts, &account{
+			Name:               acc.GetName(),
+			UUID:               formatUUID(acc.GetUuid()),
+			PublicKey:          fmt.Sprintf("%#x", acc.GetPublicKey()),
+			CompositePublicKey: fmt.Sprintf("%#x", acc.GetCompositePublicKey()),
+			SigningThreshold:   acc.GetSigningThreshold(),
+			Participants:       participants,
+		})
+	}
+	sort.Slice(c.accounts, func(i int, j int) bool {
+		return c.accounts[i].Name < c.accounts[j].Name
+	})
+
+	return nil
+}
+
+// formatUUID formats a binary UUID, falling back to hex if it is malformed.
+func formatUUID(data []byte) string {
+	id, err := uuid.FromBytes(data)
+	if err != nil {
+		return fmt.Sprintf("%#x", data)
+	}
+
+	return id.String()
+}
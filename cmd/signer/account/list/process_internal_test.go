@@ -0,0 +1,87 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signeraccountlist
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	pb "github.com/wealdtech/eth2-signer-api/pb/v1"
+	"google.golang.org/grpc"
+)
+
+type mockLister struct {
+	resp *pb.ListAccountsResponse
+	req  *pb.ListAccountsRequest
+}
+
+func (m *mockLister) ListAccounts(_ context.Context, in *pb.ListAccountsRequest, _ ...grpc.CallOption) (*pb.ListAccountsResponse, error) {
+	m.req = in
+
+	return m.resp, nil
+}
+
+func TestProcess(t *testing.T) {
+	lister := &mockLister{
+		resp: &pb.ListAccountsResponse{
+			State: pb.ResponseState_SUCCEEDED,
+			Accounts: []*pb.Account{
+				{
+					Name:      "Test/2",
+					PublicKey: []byte{0x02},
+					Uuid:      []byte{0x5b, 0x0e, 0x0d, 0x22, 0x46, 0x1b, 0x4a, 0x44, 0x9a, 0xd6, 0x4e, 0x8b, 0x3f, 0x2e, 0x2a, 0x4e},
+				},
+			},
+			DistributedAccounts: []*pb.DistributedAccount{
+				{
+					Name:               "Test/1",
+					PublicKey:          []byte{0x01},
+					CompositePublicKey: []byte{0x11},
+					Uuid:               []byte{0x01},
+					SigningThreshold:   2,
+					Participants: []*pb.Endpoint{
+						{Id: 1, Name: "signer-1", Port: 9091},
+						{Id: 2, Name: "signer-2", Port: 9091},
+						{Id: 3, Name: "signer-3", Port: 9091},
+					},
+				},
+			},
+		},
+	}
+
+	c := &command{
+		timeout: time.Minute,
+		lister:  lister,
+		path:    "Test",
+	}
+	require.NoError(t, c.process(context.Background()))
+	require.Equal(t, []string{"Test"}, lister.req.GetPaths())
+	require.Len(t, c.accounts, 2)
+
+	require.Equal(t, "Test/1", c.accounts[0].Name)
+	require.Equal(t, "0x01", c.accounts[0].UUID)
+	require.Equal(t, "0x11", c.accounts[0].CompositePublicKey)
+	require.Equal(t, uint32(2), c.accounts[0].SigningThreshold)
+	require.Equal(t, []string{"1: signer-1:9091", "2: signer-2:9091", "3: signer-3:9091"}, c.accounts[0].Participants)
+
+	require.Equal(t, "Test/2", c.accounts[1].Name)
+	require.Equal(t, "5b0e0d22-461b-4a44-9ad6-4e8b3f2e2a4e", c.accounts[1].UUID)
+	require.Equal(t, "0x02", c.accounts[1].PublicKey)
+	require.Empty(t, c.accounts[1].CompositePublicKey)
+
+	lister.resp = &pb.ListAccountsResponse{State: pb.ResponseState_DENIED}
+	require.EqualError(t, c.process(context.Background()), "request denied by remote signer")
+}
@@ -0,0 +1,70 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signeraccountunlock
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	pb "github.com/wealdtech/eth2-signer-api/pb/v1"
+	"github.com/wealdtech/ethdo/util"
+	"google.golang.org/grpc"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	timeout time.Duration
+
+	// Input.
+	conn           *grpc.ClientConn
+	accountManager pb.AccountManagerClient
+	account        string
+	passphrases    []string
+}
+
+func newCommand(ctx context.Context) (*command, error) {
+	c := &command{
+		quiet:       viper.GetBool("quiet"),
+		verbose:     viper.GetBool("verbose"),
+		debug:       viper.GetBool("debug"),
+		timeout:     viper.GetDuration("timeout"),
+		account:     viper.GetString("account"),
+		passphrases: util.GetPassphrases(),
+	}
+
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if c.account == "" {
+		return nil, errors.New("account is required")
+	}
+	if len(c.passphrases) == 0 {
+		return nil, errors.New("passphrase is required")
+	}
+
+	var err error
+	c.conn, err = util.NewSignerConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.accountManager = pb.NewAccountManagerClient(c.conn)
+
+	return c, nil
+}
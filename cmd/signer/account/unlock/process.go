@@ -0,0 +1,45 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signeraccountunlock
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	pb "github.com/wealdtech/eth2-signer-api/pb/v1"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	// Try each passphrase in turn, as the signer does not say which is correct.
+	var state pb.ResponseState
+	for _, passphrase := range c.passphrases {
+		resp, err := c.accountManager.Unlock(ctx, &pb.UnlockAccountRequest{
+			Account:    c.account,
+			Passphrase: []byte(passphrase),
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to unlock account")
+		}
+		state = resp.GetState()
+		if state == pb.ResponseState_SUCCEEDED {
+			return nil
+		}
+	}
+
+	return util.SignerResponseError(state, "")
+}
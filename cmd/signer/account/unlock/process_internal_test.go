@@ -0,0 +1,92 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signeraccountunlock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	pb "github.com/wealdtech/eth2-signer-api/pb/v1"
+	"google.golang.org/grpc"
+)
+
+type mockAccountManager struct {
+	passphrase string
+	attempts   int
+}
+
+func (m *mockAccountManager) Unlock(_ context.Context, in *pb.UnlockAccountRequest, _ ...grpc.CallOption) (*pb.UnlockAccountResponse, error) {
+	m.attempts++
+	if string(in.GetPassphrase()) == m.passphrase {
+		return &pb.UnlockAccountResponse{State: pb.ResponseState_SUCCEEDED}, nil
+	}
+
+	return &pb.UnlockAccountResponse{State: pb.ResponseState_DENIED}, nil
+}
+
+func (m *mockAccountManager) Lock(_ context.Context, _ *pb.LockAccountRequest, _ ...grpc.CallOption) (*pb.LockAccountResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockAccountManager) Generate(_ context.Context, _ *pb.GenerateRequest, _ ...grpc.CallOption) (*pb.GenerateResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name        string
+		passphrases []string
+		attempts    int
+		err         string
+	}{
+		{
+			name:        "Single",
+			passphrases: []string{"secret"},
+			attempts:    1,
+		},
+		{
+			name:        "SecondPassphrase",
+			passphrases: []string{"wrong", "secret"},
+			attempts:    2,
+		},
+		{
+			name:        "NoMatch",
+			passphrases: []string{"wrong", "also wrong"},
+			attempts:    2,
+			err:         "request denied by remote signer",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			accountManager := &mockAccountManager{passphrase: "secret"}
+			c := &command{
+				timeout:        time.Minute,
+				accountManager: accountManager,
+				account:        "Test/1",
+				passphrases:    test.passphrases,
+			}
+			err := c.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, test.attempts, accountManager.attempts)
+		})
+	}
+}
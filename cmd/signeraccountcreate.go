@@ -0,0 +1,60 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	signeraccountcreate "github.com/wealdtech/ethdo/cmd/signer/account/create"
+)
+
+var signerAccountCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an account on a remote signer",
+	Long: `Create an account on a remote signer.  For example:
+
+    ethdo signer account create --remote=signer.example.com:9091 --client-cert=client.crt --client-key=client.key --account="Validators/1" --passphrase="secret"
+
+A distributed account can be created by supplying --participants and --signing-threshold.
+
+In quiet mode this will return 0 if the account has been created, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := signeraccountcreate.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	signerAccountCmd.AddCommand(signerAccountCreateCmd)
+	signerAccountFlags(signerAccountCreateCmd)
+	signerAccountCreateCmd.Flags().Uint32("participants", 1, "Number of participants (1 for non-distributed accounts, >1 for distributed accounts)")
+	signerAccountCreateCmd.Flags().Uint32("signing-threshold", 1, "Signing threshold (1 for non-distributed accounts)")
+}
+
+func signerAccountCreateBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("participants", cmd.Flags().Lookup("participants")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("signing-threshold", cmd.Flags().Lookup("signing-threshold")); err != nil {
+		panic(err)
+	}
+}
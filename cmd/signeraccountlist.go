@@ -0,0 +1,50 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	signeraccountlist "github.com/wealdtech/ethdo/cmd/signer/account/list"
+)
+
+var signerAccountListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List accounts on a remote signer",
+	Long: `List accounts on a remote signer.  For example:
+
+    ethdo signer account list --remote=signer.example.com:9091 --client-cert=client.crt --client-key=client.key --wallet=Validators
+
+Only accounts that the client is permitted to access are listed.
+
+In quiet mode this will return 0 if the accounts have been listed, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := signeraccountlist.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	signerAccountCmd.AddCommand(signerAccountListCmd)
+	signerAccountFlags(signerAccountListCmd)
+	walletFlags(signerAccountListCmd)
+}
@@ -0,0 +1,47 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	signeraccountlock "github.com/wealdtech/ethdo/cmd/signer/account/lock"
+)
+
+var signerAccountLockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Lock an account on a remote signer",
+	Long: `Lock an account on a remote signer.  Locked accounts cannot carry out signing requests.  For example:
+
+    ethdo signer account lock --remote=signer.example.com:9091 --client-cert=client.crt --client-key=client.key --account="Validators/1"
+
+In quiet mode this will return 0 if the account has been locked, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := signeraccountlock.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	signerAccountCmd.AddCommand(signerAccountLockCmd)
+	signerAccountFlags(signerAccountLockCmd)
+}
@@ -1,4 +1,4 @@
-// Copyright © 2021 Weald Technology Trading
+// Copyright © 2021, 2024 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -29,8 +29,12 @@ type dataIn struct {
 	quiet   bool
 	verbose bool
 	debug   bool
+	json    bool
 	// Operation.
 	slot       string
+	epoch      string
+	timestamp  string
+	location   *time.Location
 	eth2Client eth2client.Service
 }
 
@@ -44,11 +48,37 @@ func input(ctx context.Context) (*dataIn, error) {
 	data.quiet = viper.GetBool("quiet")
 	data.verbose = viper.GetBool("verbose")
 	data.debug = viper.GetBool("debug")
+	data.json = viper.GetBool("json")
 
-	if viper.GetString("slot") == "" {
-		return nil, errors.New("slot is required")
+	inputs := 0
+	if viper.GetString("slot") != "" {
+		data.slot = viper.GetString("slot")
+		inputs++
+	}
+	if viper.GetString("epoch") != "" {
+		data.epoch = viper.GetString("epoch")
+		inputs++
+	}
+	if viper.GetString("timestamp") != "" {
+		data.timestamp = viper.GetString("timestamp")
+		inputs++
+	}
+	switch inputs {
+	case 0:
+		return nil, errors.New("one of slot, epoch or timestamp required")
+	case 1:
+	default:
+		return nil, errors.New("only one of slot, epoch and timestamp allowed")
+	}
+
+	data.location = time.Local
+	if viper.GetString("timezone") != "" {
+		var err error
+		data.location, err = time.LoadLocation(viper.GetString("timezone"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid timezone")
+		}
 	}
-	data.slot = viper.GetString("slot")
 
 	// Ethereum 2 client.
 	var err error
@@ -65,7 +65,25 @@ func TestInput(t *testing.T) {
 			vars: map[string]interface{}{
 				"timeout": "5s",
 			},
-			err: "slot is required",
+			err: "one of slot, epoch or timestamp required",
+		},
+		{
+			name: "MultipleInputs",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"slot":    "1",
+				"epoch":   "1",
+			},
+			err: "only one of slot, epoch and timestamp allowed",
+		},
+		{
+			name: "TimezoneInvalid",
+			vars: map[string]interface{}{
+				"timeout":  "5s",
+				"slot":     "1",
+				"timezone": "Invalid/Zone",
+			},
+			err: "invalid timezone: unknown time zone Invalid/Zone",
 		},
 	}
 
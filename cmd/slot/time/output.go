@@ -1,4 +1,4 @@
-// Copyright © 2021 Weald Technology Trading
+// Copyright © 2021, 2024 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -15,18 +15,41 @@ package slottime
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
 )
 
 type dataOut struct {
-	debug     bool
-	quiet     bool
-	verbose   bool
-	startTime time.Time
-	endTime   time.Time
+	debug          bool
+	quiet          bool
+	verbose        bool
+	json           bool
+	location       *time.Location
+	slot           phase0.Slot
+	epoch          phase0.Epoch
+	time           time.Time
+	until          time.Duration
+	startTime      time.Time
+	endTime        time.Time
+	epochStartTime time.Time
+	epochEndTime   time.Time
+}
+
+type dataOutJSON struct {
+	Slot           phase0.Slot  `json:"slot"`
+	Epoch          phase0.Epoch `json:"epoch"`
+	Time           time.Time    `json:"time"`
+	SecondsUntil   int64        `json:"seconds_until"`
+	SlotStartTime  time.Time    `json:"slot_start_time"`
+	SlotEndTime    time.Time    `json:"slot_end_time"`
+	EpochStartTime time.Time    `json:"epoch_start_time"`
+	EpochEndTime   time.Time    `json:"epoch_end_time"`
 }
 
 func output(_ context.Context, data *dataOut) (string, error) {
@@ -37,8 +60,58 @@ func output(_ context.Context, data *dataOut) (string, error) {
 	if data.quiet {
 		return "", nil
 	}
+
+	location := data.location
+	if location == nil {
+		location = time.Local
+	}
+
+	if data.json {
+		res, err := json.Marshal(&dataOutJSON{
+			Slot:           data.slot,
+			Epoch:          data.epoch,
+			Time:           data.time.In(location),
+			SecondsUntil:   int64(data.until.Truncate(time.Second).Seconds()),
+			SlotStartTime:  data.startTime.In(location),
+			SlotEndTime:    data.endTime.In(location),
+			EpochStartTime: data.epochStartTime.In(location),
+			EpochEndTime:   data.epochEndTime.In(location),
+		})
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal JSON")
+		}
+
+		return string(res), nil
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("Slot: %d\n", data.slot))
+	builder.WriteString(fmt.Sprintf("Epoch: %d\n", data.epoch))
+	builder.WriteString(fmt.Sprintf("Time: %s (%s)\n", formatTime(data.time, location), relative(data.until)))
 	if data.verbose {
-		return fmt.Sprintf("%s - %s", data.startTime, data.endTime), nil
+		builder.WriteString(fmt.Sprintf("Slot start: %s (%d)\n", formatTime(data.startTime, location), data.startTime.Unix()))
+		builder.WriteString(fmt.Sprintf("Slot end: %s (%d)\n", formatTime(data.endTime, location), data.endTime.Unix()))
+		builder.WriteString(fmt.Sprintf("Epoch start: %s (%d)\n", formatTime(data.epochStartTime, location), data.epochStartTime.Unix()))
+		builder.WriteString(fmt.Sprintf("Epoch end: %s (%d)\n", formatTime(data.epochEndTime, location), data.epochEndTime.Unix()))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func formatTime(timestamp time.Time, location *time.Location) string {
+	return timestamp.In(location).Format("2006-01-02 15:04:05 -0700 MST")
+}
+
+// relative returns a human-readable description of the duration until
+// a time, for example "in 1d2h3m4s" or "1d2h3m4s ago".
+func relative(until time.Duration) string {
+	until = until.Truncate(time.Second)
+	switch {
+	case until > 0:
+		return fmt.Sprintf("in %s", util.FormatDuration(until))
+	case until < 0:
+		return fmt.Sprintf("%s ago", util.FormatDuration(-until))
+	default:
+		return "now"
 	}
-	return data.startTime.String(), nil
 }
@@ -1,4 +1,4 @@
-// Copyright © 2021 Weald Technology Trading
+// Copyright © 2021, 2024 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -32,21 +32,77 @@ func TestOutput(t *testing.T) {
 			name: "Nil",
 			err:  "no data",
 		},
+		{
+			name: "Quiet",
+			dataOut: &dataOut{
+				quiet: true,
+			},
+		},
 		{
 			name: "Normal",
 			dataOut: &dataOut{
-				startTime: time.Unix(1606824023, 0),
+				location:  time.UTC,
+				slot:      1,
+				time:      time.Unix(1606824035, 0),
+				until:     -(26*time.Hour + 3*time.Minute + 500*time.Millisecond),
+				startTime: time.Unix(1606824035, 0),
+			},
+			res: "Slot: 1\nEpoch: 0\nTime: 2020-12-01 12:00:35 +0000 UTC (1d2h3m0s ago)",
+		},
+		{
+			name: "Future",
+			dataOut: &dataOut{
+				location: time.UTC,
+				slot:     1,
+				time:     time.Unix(1606824035, 0),
+				until:    90 * time.Second,
 			},
-			res: "2020-12-01 12:00:23 +0000 UTC",
+			res: "Slot: 1\nEpoch: 0\nTime: 2020-12-01 12:00:35 +0000 UTC (in 1m30s)",
+		},
+		{
+			name: "Timezone",
+			dataOut: &dataOut{
+				location: time.FixedZone("EST", -5*60*60),
+				slot:     1,
+				time:     time.Unix(1606824035, 0),
+			},
+			res: "Slot: 1\nEpoch: 0\nTime: 2020-12-01 07:00:35 -0500 EST (now)",
 		},
 		{
 			name: "Verbose",
 			dataOut: &dataOut{
-				startTime: time.Unix(1606824023, 0),
-				endTime:   time.Unix(1606824035, 0),
-				verbose:   true,
+				verbose:        true,
+				location:       time.UTC,
+				slot:           1,
+				time:           time.Unix(1606824035, 0),
+				until:          48 * time.Hour,
+				startTime:      time.Unix(1606824035, 0),
+				endTime:        time.Unix(1606824047, 0),
+				epochStartTime: time.Unix(1606824023, 0),
+				epochEndTime:   time.Unix(1606824407, 0),
+			},
+			res: `Slot: 1
+Epoch: 0
+Time: 2020-12-01 12:00:35 +0000 UTC (in 2d)
+Slot start: 2020-12-01 12:00:35 +0000 UTC (1606824035)
+Slot end: 2020-12-01 12:00:47 +0000 UTC (1606824047)
+Epoch start: 2020-12-01 12:00:23 +0000 UTC (1606824023)
+Epoch end: 2020-12-01 12:06:47 +0000 UTC (1606824407)`,
+		},
+		{
+			name: "JSON",
+			dataOut: &dataOut{
+				json:           true,
+				location:       time.UTC,
+				slot:           1,
+				time:           time.Unix(1606824035, 0),
+				until:          -1500 * time.Millisecond,
+				startTime:      time.Unix(1606824035, 0),
+				endTime:        time.Unix(1606824047, 0),
+				epochStartTime: time.Unix(1606824023, 0),
+				epochEndTime:   time.Unix(1606824407, 0),
 			},
-			res: "2020-12-01 12:00:23 +0000 UTC - 2020-12-01 12:00:35 +0000 UTC",
+			res: `{"slot":"1","epoch":"0","time":"2020-12-01T12:00:35Z","seconds_until":-1,"slot_start_time":"2020-12-01T12:00:35Z","slot_end_time":"2020-12-01T12:00:47Z","epoch_start_time":"2020-12-01T12:00:23Z","epoch_end_time":"2020-12-01T12:06:47Z"}`,
 		},
 	}
 
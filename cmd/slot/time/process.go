@@ -1,4 +1,4 @@
-// Copyright © 2021 Weald Technology Trading
+// Copyright © 2021, 2024 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -19,8 +19,11 @@ import (
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
-	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
 )
 
 func process(ctx context.Context, data *dataIn) (*dataOut, error) {
@@ -28,35 +31,78 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		return nil, errors.New("no data")
 	}
 
-	results := &dataOut{
-		debug:   data.debug,
-		quiet:   data.quiet,
-		verbose: data.verbose,
-	}
-
-	slot, err := strconv.ParseInt(data.slot, 10, 64)
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(data.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(data.eth2Client.(eth2client.GenesisProvider)),
+	)
 	if err != nil {
-		return nil, errors.Wrap(err, "invalid slot specified")
-	}
-	if slot < 0 {
-		return nil, errors.New("slot must be a positive integer")
+		return nil, errors.Wrap(err, "failed to set up chaintime service")
 	}
 
-	genesisResponse, err := data.eth2Client.(eth2client.GenesisProvider).Genesis(ctx, &api.GenesisOpts{})
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to obtain genesis information")
+	return convert(ctx, chainTime, data, time.Now())
+}
+
+// convert converts the slot, epoch or timestamp in the input to the others,
+// relative to the supplied current time.
+func convert(ctx context.Context,
+	chainTime chaintime.Service,
+	data *dataIn,
+	now time.Time,
+) (
+	*dataOut,
+	error,
+) {
+	location := data.location
+	if location == nil {
+		location = time.Local
 	}
-	genesis := genesisResponse.Data
 
-	specResponse, err := data.eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to obtain chain specifications")
+	results := &dataOut{
+		debug:    data.debug,
+		quiet:    data.quiet,
+		verbose:  data.verbose,
+		json:     data.json,
+		location: location,
 	}
 
-	slotDuration := specResponse.Data["SECONDS_PER_SLOT"].(time.Duration)
+	switch {
+	case data.slot != "":
+		slot, err := strconv.ParseInt(data.slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid slot specified")
+		}
+		if slot < 0 {
+			return nil, errors.New("slot must be a positive integer")
+		}
+		results.slot = phase0.Slot(slot)
+		results.time = chainTime.StartOfSlot(results.slot)
+	case data.epoch != "":
+		epoch, err := util.ParseEpoch(ctx, chainTime, data.epoch)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid epoch specified")
+		}
+		results.slot = chainTime.FirstSlotOfEpoch(epoch)
+		results.time = chainTime.StartOfEpoch(epoch)
+	case data.timestamp != "":
+		timestamp, err := util.ParseTimestamp(data.timestamp, location, now)
+		if err != nil {
+			return nil, err
+		}
+		if timestamp.Before(chainTime.GenesisTime()) {
+			return nil, errors.New("timestamp is before genesis")
+		}
+		results.slot = chainTime.TimestampToSlot(timestamp)
+		results.time = timestamp
+	default:
+		return nil, errors.New("no slot, epoch or timestamp specified")
+	}
 
-	results.startTime = genesis.GenesisTime.Add((time.Duration(slot*int64(slotDuration.Seconds())) * time.Second))
-	results.endTime = results.startTime.Add(slotDuration)
+	results.epoch = chainTime.SlotToEpoch(results.slot)
+	results.startTime = chainTime.StartOfSlot(results.slot)
+	results.endTime = chainTime.StartOfSlot(results.slot + 1)
+	results.epochStartTime = chainTime.StartOfEpoch(results.epoch)
+	results.epochEndTime = chainTime.StartOfEpoch(results.epoch + 1)
+	results.until = results.time.Sub(now)
 
 	return results, nil
 }
@@ -1,4 +1,4 @@
-// Copyright © 2021 Weald Technology Trading
+// Copyright © 2021, 2024 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -20,8 +20,11 @@ import (
 	"time"
 
 	"github.com/attestantio/go-eth2-client/auto"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/testing/mock"
 )
 
 func TestProcess(t *testing.T) {
@@ -74,3 +77,147 @@ func TestProcess(t *testing.T) {
 		})
 	}
 }
+
+func TestConvert(t *testing.T) {
+	ctx := context.Background()
+	genesisTime := time.Unix(1606824023, 0)
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(genesisTime)),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider(12*time.Second, 32, 256)),
+	)
+	require.NoError(t, err)
+	now := time.Unix(1606824407, 0)
+
+	tests := []struct {
+		name      string
+		dataIn    *dataIn
+		slot      phase0.Slot
+		epoch     phase0.Epoch
+		time      time.Time
+		startTime time.Time
+		until     time.Duration
+		err       string
+	}{
+		{
+			name:   "None",
+			dataIn: &dataIn{},
+			err:    "no slot, epoch or timestamp specified",
+		},
+		{
+			name: "SlotInvalid",
+			dataIn: &dataIn{
+				slot: "one",
+			},
+			err: `invalid slot specified: strconv.ParseInt: parsing "one": invalid syntax`,
+		},
+		{
+			name: "SlotNegative",
+			dataIn: &dataIn{
+				slot: "-1",
+			},
+			err: "slot must be a positive integer",
+		},
+		{
+			name: "Slot",
+			dataIn: &dataIn{
+				slot: "33",
+			},
+			slot:      33,
+			epoch:     1,
+			time:      time.Unix(1606824419, 0),
+			startTime: time.Unix(1606824419, 0),
+			until:     12 * time.Second,
+		},
+		{
+			name: "Epoch",
+			dataIn: &dataIn{
+				epoch: "2",
+			},
+			slot:      64,
+			epoch:     2,
+			time:      time.Unix(1606824791, 0),
+			startTime: time.Unix(1606824791, 0),
+			until:     384 * time.Second,
+		},
+		{
+			name: "EpochInvalid",
+			dataIn: &dataIn{
+				epoch: "two",
+			},
+			err: `invalid epoch specified: failed to parse epoch: strconv.ParseInt: parsing "two": invalid syntax`,
+		},
+		{
+			name: "TimestampUnix",
+			dataIn: &dataIn{
+				timestamp: "1606824050",
+			},
+			slot:      2,
+			epoch:     0,
+			time:      time.Unix(1606824050, 0),
+			startTime: time.Unix(1606824047, 0),
+			until:     -357 * time.Second,
+		},
+		{
+			name: "TimestampNow",
+			dataIn: &dataIn{
+				timestamp: "now",
+			},
+			slot:      32,
+			epoch:     1,
+			time:      now,
+			startTime: time.Unix(1606824407, 0),
+		},
+		{
+			name: "TimestampTimezone",
+			dataIn: &dataIn{
+				timestamp: "2020-12-01 07:06:47",
+				location:  time.FixedZone("EST", -5*60*60),
+			},
+			slot:      32,
+			epoch:     1,
+			time:      time.Unix(1606824407, 0),
+			startTime: time.Unix(1606824407, 0),
+		},
+		{
+			name: "TimestampRFC3339",
+			dataIn: &dataIn{
+				timestamp: "2020-12-01T12:06:47Z",
+			},
+			slot:      32,
+			epoch:     1,
+			time:      time.Unix(1606824407, 0),
+			startTime: time.Unix(1606824407, 0),
+		},
+		{
+			name: "TimestampBeforeGenesis",
+			dataIn: &dataIn{
+				timestamp: "1606824000",
+			},
+			err: "timestamp is before genesis",
+		},
+		{
+			name: "TimestampInvalid",
+			dataIn: &dataIn{
+				timestamp: "yesterday",
+			},
+			err: "invalid timestamp specified",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := convert(ctx, chainTime, test.dataIn, now)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.slot, res.slot)
+				require.Equal(t, test.epoch, res.epoch)
+				require.True(t, test.time.Equal(res.time))
+				require.True(t, test.startTime.Equal(res.startTime))
+				require.Equal(t, test.until, res.until)
+			}
+		})
+	}
+}
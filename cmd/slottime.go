@@ -23,11 +23,15 @@ import (
 
 var slotTimeCmd = &cobra.Command{
 	Use:   "time",
-	Short: "Obtain the time for a slot",
-	Long: `Obtain the time(s) for a slot.  For example:
+	Short: "Convert between slots, epochs and times",
+	Long: `Convert between slots, epochs and times.  Given one of a slot, epoch or timestamp the others are provided, along with the time until (or since) the given point.  For example:
 
     ethdo slot time --slot=12345
 
+    ethdo slot time --timestamp="2025-01-01 00:00" --timezone=Europe/London
+
+Timestamps can be "now", a Unix timestamp, or a date and time such as 2006-01-02T15:04:05Z.  Times without a zone are in the timezone supplied with --timezone, which defaults to the local timezone.
+
 In quiet mode this will return 0.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := slottime.Run(cmd)
@@ -48,10 +52,22 @@ func init() {
 	slotCmd.AddCommand(slotTimeCmd)
 	slotFlags(slotTimeCmd)
 	slotTimeCmd.Flags().String("slot", "", "the ID of the slot to fetch")
+	slotTimeCmd.Flags().String("epoch", "", "the epoch to convert, in place of --slot")
+	slotTimeCmd.Flags().String("timestamp", "", "the timestamp to convert, in place of --slot")
+	slotTimeCmd.Flags().String("timezone", "", "the timezone in which to show times, for example UTC or Europe/London (defaults to the local timezone)")
 }
 
 func slotTimeBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("slot", cmd.Flags().Lookup("slot")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("epoch", cmd.Flags().Lookup("epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("timestamp", cmd.Flags().Lookup("timestamp")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("timezone", cmd.Flags().Lookup("timezone")); err != nil {
+		panic(err)
+	}
 }
@@ -0,0 +1,101 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorbalances
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Operation.
+	fromEpoch  string
+	toEpoch    string
+	interval   uint64
+	validators []string
+	jsonOutput bool
+	csvOutput  bool
+
+	// Data access.
+	eth2Client         eth2client.Service
+	chainTime          chaintime.Service
+	validatorsProvider eth2client.ValidatorsProvider
+
+	// Results.
+	samples []*balanceSample
+}
+
+type balanceSample struct {
+	Epoch            phase0.Epoch          `json:"epoch"`
+	Slot             phase0.Slot           `json:"slot"`
+	Validator        phase0.ValidatorIndex `json:"validator_index"`
+	Balance          phase0.Gwei           `json:"balance"`
+	EffectiveBalance phase0.Gwei           `json:"effective_balance"`
+	Delta            int64                 `json:"delta"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.validators = viper.GetStringSlice("validators")
+	if len(c.validators) == 0 {
+		return nil, errors.New("validators are required")
+	}
+
+	c.fromEpoch = viper.GetString("from-epoch")
+	if c.fromEpoch == "" {
+		return nil, errors.New("from epoch is required")
+	}
+	c.toEpoch = viper.GetString("to-epoch")
+	c.interval = viper.GetUint64("interval")
+	if c.interval == 0 {
+		return nil, errors.New("interval must be at least 1")
+	}
+
+	c.jsonOutput = viper.GetBool("json")
+	c.csvOutput = viper.GetBool("csv")
+	if c.jsonOutput && c.csvOutput {
+		return nil, errors.New("only one of json and csv output can be selected")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,106 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorbalances
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"validators": []string{"1"},
+				"from-epoch": "100",
+				"interval":   1,
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "ValidatorsMissing",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"from-epoch": "100",
+				"interval":   1,
+			},
+			err: "validators are required",
+		},
+		{
+			name: "FromEpochMissing",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"validators": []string{"1"},
+				"interval":   1,
+			},
+			err: "from epoch is required",
+		},
+		{
+			name: "IntervalZero",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"validators": []string{"1"},
+				"from-epoch": "100",
+				"interval":   0,
+			},
+			err: "interval must be at least 1",
+		},
+		{
+			name: "JSONAndCSV",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"validators": []string{"1"},
+				"from-epoch": "100",
+				"interval":   1,
+				"json":       true,
+				"csv":        true,
+			},
+			err: "only one of json and csv output can be selected",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"validators": []string{"1"},
+				"from-epoch": "100",
+				"to-epoch":   "200",
+				"interval":   10,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
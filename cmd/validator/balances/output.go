@@ -0,0 +1,93 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorbalances
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	switch {
+	case c.jsonOutput:
+		return c.outputJSON(ctx)
+	case c.csvOutput:
+		return c.outputCSV(ctx)
+	default:
+		return c.outputTxt(ctx)
+	}
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.samples)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputCSV(_ context.Context) (string, error) {
+	buf := &bytes.Buffer{}
+	writer := csv.NewWriter(buf)
+
+	records := [][]string{
+		{"epoch", "slot", "validator", "balance", "effective_balance", "delta"},
+	}
+	for _, sample := range c.samples {
+		records = append(records, []string{
+			fmt.Sprintf("%d", sample.Epoch),
+			fmt.Sprintf("%d", sample.Slot),
+			fmt.Sprintf("%d", sample.Validator),
+			fmt.Sprintf("%d", sample.Balance),
+			fmt.Sprintf("%d", sample.EffectiveBalance),
+			fmt.Sprintf("%d", sample.Delta),
+		})
+	}
+	if err := writer.WriteAll(records); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	for _, sample := range c.samples {
+		builder.WriteString(fmt.Sprintf("Epoch %d validator %d: %s", sample.Epoch, sample.Validator, string2eth.GWeiToString(uint64(sample.Balance), true)))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf(" (effective %s)", string2eth.GWeiToString(uint64(sample.EffectiveBalance), true)))
+		}
+		switch {
+		case sample.Delta > 0:
+			builder.WriteString(fmt.Sprintf(" +%s", string2eth.GWeiToString(uint64(sample.Delta), true)))
+		case sample.Delta < 0:
+			builder.WriteString(fmt.Sprintf(" -%s", string2eth.GWeiToString(uint64(-sample.Delta), true)))
+		}
+		builder.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,134 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorbalances
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	err := c.setup(ctx)
+	if err != nil {
+		return err
+	}
+
+	fromEpoch, err := util.ParseEpoch(ctx, c.chainTime, c.fromEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse from epoch")
+	}
+	toEpoch, err := util.ParseEpoch(ctx, c.chainTime, c.toEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse to epoch")
+	}
+	if fromEpoch > toEpoch {
+		return fmt.Errorf("from epoch %d is after to epoch %d", fromEpoch, toEpoch)
+	}
+
+	validators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validators, fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(toEpoch)))
+	if err != nil {
+		return errors.Wrap(err, "failed to parse validators")
+	}
+	if len(validators) == 0 {
+		return errors.New("no validators found")
+	}
+	indices := make([]phase0.ValidatorIndex, 0, len(validators))
+	for _, validator := range validators {
+		indices = append(indices, validator.Index)
+	}
+
+	previous := make(map[phase0.ValidatorIndex]phase0.Gwei)
+	for epoch := fromEpoch; epoch <= toEpoch; epoch += phase0.Epoch(c.interval) {
+		slot := c.chainTime.FirstSlotOfEpoch(epoch)
+		if c.debug {
+			fmt.Printf("Fetching balances at slot %d\n", slot)
+		}
+		sampled, err := util.FetchValidators(ctx, c.validatorsProvider, fmt.Sprintf("%d", slot), indices, nil)
+		if err != nil {
+			return errors.Wrapf(err, "failed to obtain balances for epoch %d", epoch)
+		}
+		c.samples = append(c.samples, samples(epoch, slot, sampled, previous)...)
+	}
+
+	return nil
+}
+
+// samples creates balance samples from the validators, updating previous
+// with the latest balance for each validator.
+func samples(epoch phase0.Epoch,
+	slot phase0.Slot,
+	validators map[phase0.ValidatorIndex]*apiv1.Validator,
+	previous map[phase0.ValidatorIndex]phase0.Gwei,
+) []*balanceSample {
+	res := make([]*balanceSample, 0, len(validators))
+	for index, validator := range validators {
+		sample := &balanceSample{
+			Epoch:            epoch,
+			Slot:             slot,
+			Validator:        index,
+			Balance:          validator.Balance,
+			EffectiveBalance: validator.Validator.EffectiveBalance,
+		}
+		if previousBalance, exists := previous[index]; exists {
+			sample.Delta = int64(validator.Balance) - int64(previousBalance)
+		}
+		previous[index] = validator.Balance
+		res = append(res, sample)
+	}
+	sort.Slice(res, func(i int, j int) bool {
+		return res[i].Validator < res[j].Validator
+	})
+
+	return res
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validators")
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorbalances
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func validator(index phase0.ValidatorIndex, balance phase0.Gwei, effectiveBalance phase0.Gwei) *apiv1.Validator {
+	return &apiv1.Validator{
+		Index:   index,
+		Balance: balance,
+		Validator: &phase0.Validator{
+			EffectiveBalance: effectiveBalance,
+		},
+	}
+}
+
+func TestSamples(t *testing.T) {
+	previous := make(map[phase0.ValidatorIndex]phase0.Gwei)
+
+	first := samples(10, 320, map[phase0.ValidatorIndex]*apiv1.Validator{
+		2: validator(2, 32000000000, 32000000000),
+		1: validator(1, 32000100000, 32000000000),
+	}, previous)
+	require.Equal(t, []*balanceSample{
+		{Epoch: 10, Slot: 320, Validator: 1, Balance: 32000100000, EffectiveBalance: 32000000000},
+		{Epoch: 10, Slot: 320, Validator: 2, Balance: 32000000000, EffectiveBalance: 32000000000},
+	}, first)
+
+	// Validator 3 first appears, validator 2 is penalized.
+	second := samples(11, 352, map[phase0.ValidatorIndex]*apiv1.Validator{
+		1: validator(1, 32000120000, 32000000000),
+		2: validator(2, 31999990000, 32000000000),
+		3: validator(3, 32000000000, 32000000000),
+	}, previous)
+	require.Equal(t, []*balanceSample{
+		{Epoch: 11, Slot: 352, Validator: 1, Balance: 32000120000, EffectiveBalance: 32000000000, Delta: 20000},
+		{Epoch: 11, Slot: 352, Validator: 2, Balance: 31999990000, EffectiveBalance: 32000000000, Delta: -10000},
+		{Epoch: 11, Slot: 352, Validator: 3, Balance: 32000000000, EffectiveBalance: 32000000000},
+	}, second)
+
+	c := &command{
+		csvOutput: true,
+		samples:   second,
+	}
+	res, err := c.output(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "epoch,slot,validator,balance,effective_balance,delta\n11,352,1,32000120000,32000000000,20000\n11,352,2,31999990000,32000000000,-10000\n11,352,3,32000000000,32000000000,0", res)
+
+	c.csvOutput = false
+	res, err = c.output(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "Epoch 11 validator 1: 32.00012 Ether +20000 GWei\nEpoch 11 validator 2: 31.99999 Ether -10000 GWei\nEpoch 11 validator 3: 32 Ether", res)
+}
@@ -0,0 +1,116 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorconsolidate
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	sourceValidator string
+	targetValidator string
+	sourceAddress   *bellatrix.ExecutionAddress
+	check           bool
+
+	// Data access.
+	eth2Client  eth2client.Service
+	chainTime   chaintime.Service
+	churnParams *util.ChurnParameters
+	params      *consolidationParams
+
+	// Output.
+	summary *summary
+}
+
+type summary struct {
+	Epoch  phase0.Epoch            `json:"epoch"`
+	Source *consolidationValidator `json:"source"`
+	Target *consolidationValidator `json:"target"`
+	// SwitchToCompounding is true if the request switches the source to compounding withdrawal credentials.
+	SwitchToCompounding bool `json:"switch_to_compounding"`
+	// Reasons are the reasons for which the request would be skipped.
+	Reasons []string `json:"reasons"`
+	// Request is the consolidation request, if it would be processed and is wanted.
+	Request *consolidationRequest `json:"request,omitempty"`
+}
+
+type consolidationValidator struct {
+	Index  phase0.ValidatorIndex `json:"index"`
+	PubKey phase0.BLSPubKey      `json:"pubkey"`
+}
+
+type consolidationRequest struct {
+	From bellatrix.ExecutionAddress `json:"from"`
+	To   bellatrix.ExecutionAddress `json:"to"`
+	Data string                     `json:"data"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.sourceValidator = viper.GetString("source-validator")
+	if c.sourceValidator == "" {
+		return nil, errors.New("source validator is required")
+	}
+	c.targetValidator = viper.GetString("target-validator")
+	if c.targetValidator == "" {
+		return nil, errors.New("target validator is required")
+	}
+
+	if viper.GetString("source-address") != "" {
+		address, err := util.ParseWithdrawalAddress(viper.GetString("source-address"), true)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid source address")
+		}
+		c.sourceAddress = &address
+	}
+
+	c.check = viper.GetBool("check")
+
+	return c, nil
+}
@@ -0,0 +1,99 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorconsolidate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"source-validator": "1",
+				"target-validator": "2",
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "SourceValidatorMissing",
+			vars: map[string]interface{}{
+				"timeout":          "5s",
+				"target-validator": "2",
+			},
+			err: "source validator is required",
+		},
+		{
+			name: "TargetValidatorMissing",
+			vars: map[string]interface{}{
+				"timeout":          "5s",
+				"source-validator": "1",
+			},
+			err: "target validator is required",
+		},
+		{
+			name: "SourceAddressInvalid",
+			vars: map[string]interface{}{
+				"timeout":          "5s",
+				"source-validator": "1",
+				"target-validator": "2",
+				"source-address":   "0x30C99930617B7b793beaB603ecEB08691005f2",
+			},
+			err: "invalid source address: withdrawal address must be exactly 20 bytes in length",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":          "5s",
+				"source-validator": "1",
+				"target-validator": "2",
+			},
+		},
+		{
+			name: "GoodCheck",
+			vars: map[string]interface{}{
+				"timeout":          "5s",
+				"source-validator": "1",
+				"target-validator": "2",
+				"source-address":   "0x30C99930617B7b793beaB603ecEB08691005f2E5",
+				"check":            true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorconsolidate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.summary)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Epoch: %d\n", c.summary.Epoch))
+	}
+
+	if c.summary.Request != nil {
+		builder.WriteString(fmt.Sprintf("From: %s\n", c.summary.Request.From.String()))
+		builder.WriteString(fmt.Sprintf("To: %s\n", c.summary.Request.To.String()))
+		builder.WriteString(fmt.Sprintf("Data: %s\n", c.summary.Request.Data))
+
+		return strings.TrimSuffix(builder.String(), "\n"), nil
+	}
+
+	operation := fmt.Sprintf("Consolidation of validator %d in to validator %d", c.summary.Source.Index, c.summary.Target.Index)
+	if c.summary.SwitchToCompounding {
+		operation = fmt.Sprintf("Switch of validator %d to compounding withdrawal credentials", c.summary.Source.Index)
+	}
+	if len(c.summary.Reasons) == 0 {
+		builder.WriteString(fmt.Sprintf("%s would be processed\n", operation))
+	} else {
+		builder.WriteString(fmt.Sprintf("%s would be skipped:\n", operation))
+		for _, reason := range c.summary.Reasons {
+			builder.WriteString(fmt.Sprintf("  - %s\n", reason))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,104 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorconsolidate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+)
+
+func TestOutput(t *testing.T) {
+	newSummary := func(reasons []string, request *consolidationRequest) *summary {
+		return &summary{
+			Epoch:   1000,
+			Source:  &consolidationValidator{Index: 1, PubKey: phase0.BLSPubKey{0x01}},
+			Target:  &consolidationValidator{Index: 2, PubKey: phase0.BLSPubKey{0x02}},
+			Reasons: reasons,
+			Request: request,
+		}
+	}
+	request := &consolidationRequest{
+		To:   consolidationRequestAddress,
+		Data: "0x0102",
+	}
+	copy(request.From[:], testutil.HexToBytes("0x30C99930617B7b793beaB603ecEB08691005f2E5"))
+
+	tests := []struct {
+		name    string
+		command *command
+		res     string
+	}{
+		{
+			name: "Quiet",
+			command: &command{
+				quiet:   true,
+				summary: newSummary([]string{}, nil),
+			},
+		},
+		{
+			name: "Processed",
+			command: &command{
+				summary: newSummary([]string{}, nil),
+			},
+			res: "Consolidation of validator 1 in to validator 2 would be processed",
+		},
+		{
+			name: "Skipped",
+			command: &command{
+				verbose: true,
+				summary: newSummary([]string{"reason 1", "reason 2"}, nil),
+			},
+			res: "Epoch: 1000\nConsolidation of validator 1 in to validator 2 would be skipped:\n  - reason 1\n  - reason 2",
+		},
+		{
+			name: "SwitchToCompounding",
+			command: &command{
+				summary: func() *summary {
+					s := newSummary([]string{}, nil)
+					s.SwitchToCompounding = true
+
+					return s
+				}(),
+			},
+			res: "Switch of validator 1 to compounding withdrawal credentials would be processed",
+		},
+		{
+			name: "Request",
+			command: &command{
+				summary: newSummary([]string{}, request),
+			},
+			res: "From: 0x30C99930617B7b793beaB603ecEB08691005f2E5\nTo: 0x0000BBdDc7CE488642fb579F8B00f3a590007251\nData: 0x0102",
+		},
+		{
+			name: "JSON",
+			command: &command{
+				json:    true,
+				summary: newSummary([]string{"reason 1"}, nil),
+			},
+			res: `{"epoch":"1000","source":{"index":"1","pubkey":"0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"},"target":{"index":"2","pubkey":"0x020000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"},"switch_to_compounding":false,"reasons":["reason 1"]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.command.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,308 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorconsolidate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+// consolidationRequestAddress is the address of the system contract that receives consolidation requests.
+var consolidationRequestAddress = bellatrix.ExecutionAddress{
+	0x00, 0x00, 0xbb, 0xdd, 0xc7, 0xce, 0x48, 0x86, 0x42, 0xfb,
+	0x57, 0x9f, 0x8b, 0x00, 0xf3, 0xa5, 0x90, 0x00, 0x72, 0x51,
+}
+
+// consolidationParams are the chain parameters that govern consolidation requests.
+type consolidationParams struct {
+	pendingConsolidationsLimit uint64
+	minActivationBalance       phase0.Gwei
+	shardCommitteePeriod       phase0.Epoch
+}
+
+// consolidationState is the state of the chain relevant to a consolidation request.
+type consolidationState struct {
+	epoch       phase0.Epoch
+	source      *phase0.Validator
+	sourceIndex phase0.ValidatorIndex
+	target      *phase0.Validator
+	targetIndex phase0.ValidatorIndex
+	// sourcePendingWithdrawals is the balance of the source awaiting partial withdrawal.
+	sourcePendingWithdrawals phase0.Gwei
+	pendingConsolidations    uint64
+	consolidationChurn       phase0.Gwei
+}
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	source, err := parseValidatorFilter(ctx, c.sourceValidator)
+	if err != nil {
+		return errors.Wrap(err, "invalid source validator")
+	}
+	target, err := parseValidatorFilter(ctx, c.targetValidator)
+	if err != nil {
+		return errors.Wrap(err, "invalid target validator")
+	}
+
+	state := &consolidationState{}
+	totalActiveBalance := phase0.Gwei(0)
+	err = util.StreamBeaconState(ctx, c.eth2Client, "head", &util.StateStreamHandler{
+		Header: func(header *util.StateStreamHeader) error {
+			state.epoch = c.chainTime.SlotToEpoch(header.Slot)
+			return nil
+		},
+		Validator: func(index phase0.ValidatorIndex, validator *phase0.Validator) error {
+			if isActive(validator, state.epoch) {
+				totalActiveBalance += validator.EffectiveBalance
+			}
+			if source.MatchesIndex(index) || source.MatchesPubKey(validator.PublicKey) {
+				state.source = validator
+				state.sourceIndex = index
+			}
+			if target.MatchesIndex(index) || target.MatchesPubKey(validator.PublicKey) {
+				state.target = validator
+				state.targetIndex = index
+			}
+			return nil
+		},
+		PendingPartialWithdrawal: func(_ uint64, withdrawal *util.PendingPartialWithdrawal) error {
+			if state.source != nil && withdrawal.ValidatorIndex == state.sourceIndex {
+				state.sourcePendingWithdrawals += withdrawal.Amount
+			}
+			return nil
+		},
+		PendingConsolidation: func(_ uint64, _ *util.PendingConsolidation) error {
+			state.pendingConsolidations++
+			return nil
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain state")
+	}
+	// The total active balance is never less than a single increment.
+	state.consolidationChurn = c.churnParams.ConsolidationChurnLimit(max(totalActiveBalance, c.churnParams.EffectiveBalanceIncrement))
+
+	if state.source == nil {
+		return errors.New("source validator not found")
+	}
+	if state.target == nil {
+		return errors.New("target validator not found")
+	}
+
+	c.summary = &summary{
+		Epoch: state.epoch,
+		Source: &consolidationValidator{
+			Index:  state.sourceIndex,
+			PubKey: state.source.PublicKey,
+		},
+		Target: &consolidationValidator{
+			Index:  state.targetIndex,
+			PubKey: state.target.PublicKey,
+		},
+		SwitchToCompounding: state.source.PublicKey == state.target.PublicKey,
+		Reasons:             consolidationSkipReasons(state, c.params, c.sourceAddress),
+	}
+
+	if len(c.summary.Reasons) > 0 {
+		if !c.check {
+			return fmt.Errorf("consolidation request would be skipped:\n  - %s", strings.Join(c.summary.Reasons, "\n  - "))
+		}
+		if c.quiet {
+			return errors.New("consolidation request would be skipped")
+		}
+
+		return nil
+	}
+
+	if !c.check {
+		var from bellatrix.ExecutionAddress
+		copy(from[:], state.source.WithdrawalCredentials[12:])
+		c.summary.Request = &consolidationRequest{
+			From: from,
+			To:   consolidationRequestAddress,
+			Data: fmt.Sprintf("%#x%x", state.source.PublicKey, state.target.PublicKey),
+		}
+	}
+
+	return nil
+}
+
+// parseValidatorFilter parses a single validator identifier in to a filter that selects it.
+func parseValidatorFilter(ctx context.Context, validator string) (*util.ValidatorFilter, error) {
+	indices, pubKeys, err := util.ParseValidatorIdentifiers(ctx, []string{validator})
+	if err != nil {
+		return nil, err
+	}
+	if len(indices)+len(pubKeys) != 1 {
+		return nil, fmt.Errorf("%s does not specify a single validator", validator)
+	}
+
+	return util.NewValidatorFilter(indices, pubKeys), nil
+}
+
+// consolidationSkipReasons returns the reasons, if any, for which the chain
+// would skip the consolidation request, in the order that the chain checks them.
+// If the source address is not supplied it is assumed to match that of the source.
+func consolidationSkipReasons(state *consolidationState,
+	params *consolidationParams,
+	sourceAddress *bellatrix.ExecutionAddress,
+) []string {
+	reasons := make([]string, 0)
+
+	if state.source.PublicKey == state.target.PublicKey {
+		// This is a request to switch the validator to compounding withdrawal credentials.
+		if state.source.WithdrawalCredentials[0] != util.ETH1AddressWithdrawalPrefix {
+			reasons = append(reasons, fmt.Sprintf("validator %d has %s withdrawal credentials; only execution address (0x01) withdrawal credentials can be switched to compounding", state.sourceIndex, util.WithdrawalCredentialsType(state.source.WithdrawalCredentials)))
+		} else if reason := sourceAddressReason(state, sourceAddress); reason != "" {
+			reasons = append(reasons, reason)
+		}
+		if !isActive(state.source, state.epoch) {
+			reasons = append(reasons, fmt.Sprintf("validator %d is not active", state.sourceIndex))
+		}
+		if state.source.ExitEpoch != util.FarFutureEpoch {
+			reasons = append(reasons, fmt.Sprintf("validator %d is exiting, with exit epoch %d", state.sourceIndex, state.source.ExitEpoch))
+		}
+
+		return reasons
+	}
+
+	if state.pendingConsolidations >= params.pendingConsolidationsLimit {
+		reasons = append(reasons, fmt.Sprintf("the pending consolidations queue is full, with %d consolidations", state.pendingConsolidations))
+	}
+	if state.consolidationChurn <= params.minActivationBalance {
+		reasons = append(reasons, fmt.Sprintf("the consolidation churn limit of %s is not above the minimum activation balance of %s",
+			string2eth.GWeiToString(uint64(state.consolidationChurn), true),
+			string2eth.GWeiToString(uint64(params.minActivationBalance), true),
+		))
+	}
+	switch state.source.WithdrawalCredentials[0] {
+	case util.ETH1AddressWithdrawalPrefix, util.CompoundingWithdrawalPrefix:
+		if reason := sourceAddressReason(state, sourceAddress); reason != "" {
+			reasons = append(reasons, reason)
+		}
+	default:
+		reasons = append(reasons, fmt.Sprintf("source validator %d has %s withdrawal credentials; execution address withdrawal credentials are required", state.sourceIndex, util.WithdrawalCredentialsType(state.source.WithdrawalCredentials)))
+	}
+	if !util.HasCompoundingWithdrawalCredentials(state.target.WithdrawalCredentials) {
+		reasons = append(reasons, fmt.Sprintf("target validator %d has %s withdrawal credentials; compounding withdrawal credentials are required", state.targetIndex, util.WithdrawalCredentialsType(state.target.WithdrawalCredentials)))
+	}
+	if !isActive(state.source, state.epoch) {
+		reasons = append(reasons, fmt.Sprintf("source validator %d is not active", state.sourceIndex))
+	}
+	if !isActive(state.target, state.epoch) {
+		reasons = append(reasons, fmt.Sprintf("target validator %d is not active", state.targetIndex))
+	}
+	if state.source.ExitEpoch != util.FarFutureEpoch {
+		reasons = append(reasons, fmt.Sprintf("source validator %d is exiting, with exit epoch %d", state.sourceIndex, state.source.ExitEpoch))
+	}
+	if state.target.ExitEpoch != util.FarFutureEpoch {
+		reasons = append(reasons, fmt.Sprintf("target validator %d is exiting, with exit epoch %d", state.targetIndex, state.target.ExitEpoch))
+	}
+	if isActive(state.source, state.epoch) && state.epoch < state.source.ActivationEpoch+params.shardCommitteePeriod {
+		reasons = append(reasons, fmt.Sprintf("source validator %d has not been active long enough to consolidate; it can consolidate from epoch %d", state.sourceIndex, state.source.ActivationEpoch+params.shardCommitteePeriod))
+	}
+	if state.sourcePendingWithdrawals > 0 {
+		reasons = append(reasons, fmt.Sprintf("source validator %d has %s of partial withdrawals awaiting processing", state.sourceIndex, string2eth.GWeiToString(uint64(state.sourcePendingWithdrawals), true)))
+	}
+
+	return reasons
+}
+
+// sourceAddressReason returns the reason, if any, that the source address
+// does not match the withdrawal address of the source validator.
+func sourceAddressReason(state *consolidationState, sourceAddress *bellatrix.ExecutionAddress) string {
+	if sourceAddress == nil {
+		return ""
+	}
+	var withdrawalAddress bellatrix.ExecutionAddress
+	copy(withdrawalAddress[:], state.source.WithdrawalCredentials[12:])
+	if *sourceAddress != withdrawalAddress {
+		return fmt.Sprintf("source address %s does not match the withdrawal address %s of validator %d", sourceAddress.String(), withdrawalAddress.String(), state.sourceIndex)
+	}
+
+	return ""
+}
+
+// isActive returns true if the validator is active at the given epoch.
+func isActive(validator *phase0.Validator, epoch phase0.Epoch) bool {
+	return validator.ActivationEpoch <= epoch && epoch < validator.ExitEpoch
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	specResponse, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	c.churnParams, err = util.ObtainChurnParameters(specResponse.Data)
+	if err != nil {
+		return err
+	}
+	balanceParams, err := util.ObtainBalanceParameters(specResponse.Data)
+	if err != nil {
+		return err
+	}
+	if !c.churnParams.SupportsElectra() || !balanceParams.SupportsElectra() {
+		return errors.New("chain does not support Electra")
+	}
+	c.params = &consolidationParams{
+		minActivationBalance: balanceParams.MinActivationBalance,
+	}
+	for name, param := range map[string]*uint64{
+		"PENDING_CONSOLIDATIONS_LIMIT": &c.params.pendingConsolidationsLimit,
+		"SHARD_COMMITTEE_PERIOD":       (*uint64)(&c.params.shardCommitteePeriod),
+	} {
+		value, isValue := specResponse.Data[name].(uint64)
+		if !isValue {
+			return fmt.Errorf("spec missing %s", name)
+		}
+		*param = value
+	}
+
+	return nil
+}
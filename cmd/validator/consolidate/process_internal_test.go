@@ -0,0 +1,217 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorconsolidate
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestConsolidationSkipReasons(t *testing.T) {
+	params := &consolidationParams{
+		pendingConsolidationsLimit: 64,
+		minActivationBalance:       32000000000,
+		shardCommitteePeriod:       256,
+	}
+	address := bellatrix.ExecutionAddress{}
+	copy(address[:], testutil.HexToBytes("0x30C99930617B7b793beaB603ecEB08691005f2E5"))
+	otherAddress := bellatrix.ExecutionAddress{}
+	copy(otherAddress[:], testutil.HexToBytes("0x8e7f60d5c5b2fd5f5bc4b0b9aa8b0f6d4f1c8e2a"))
+
+	validator := func(prefix byte, b byte) *phase0.Validator {
+		return &phase0.Validator{
+			PublicKey:             phase0.BLSPubKey{b},
+			WithdrawalCredentials: append([]byte{prefix, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, address[:]...),
+			ActivationEpoch:       0,
+			ExitEpoch:             util.FarFutureEpoch,
+		}
+	}
+	goodState := func() *consolidationState {
+		return &consolidationState{
+			epoch:              1000,
+			source:             validator(0x01, 1),
+			sourceIndex:        1,
+			target:             validator(0x02, 2),
+			targetIndex:        2,
+			consolidationChurn: 64000000000,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		state         func() *consolidationState
+		sourceAddress *bellatrix.ExecutionAddress
+		reasons       []string
+	}{
+		{
+			name:    "Good",
+			state:   goodState,
+			reasons: []string{},
+		},
+		{
+			name:          "GoodSourceAddress",
+			state:         goodState,
+			sourceAddress: &address,
+			reasons:       []string{},
+		},
+		{
+			name:          "SourceAddressMismatch",
+			state:         goodState,
+			sourceAddress: &otherAddress,
+			reasons:       []string{"source address 0x8E7f60D5c5B2fD5f5bc4B0B9Aa8B0f6d4f1c8E2a does not match the withdrawal address 0x30C99930617B7b793beaB603ecEB08691005f2E5 of validator 1"},
+		},
+		{
+			name: "QueueFull",
+			state: func() *consolidationState {
+				state := goodState()
+				state.pendingConsolidations = 64
+
+				return state
+			},
+			reasons: []string{"the pending consolidations queue is full, with 64 consolidations"},
+		},
+		{
+			name: "InsufficientChurn",
+			state: func() *consolidationState {
+				state := goodState()
+				state.consolidationChurn = 0
+
+				return state
+			},
+			reasons: []string{"the consolidation churn limit of 0 is not above the minimum activation balance of 32 Ether"},
+		},
+		{
+			name: "SourceBLS",
+			state: func() *consolidationState {
+				state := goodState()
+				state.source.WithdrawalCredentials[0] = 0x00
+
+				return state
+			},
+			reasons: []string{"source validator 1 has BLS (0x00) withdrawal credentials; execution address withdrawal credentials are required"},
+		},
+		{
+			name: "TargetNotCompounding",
+			state: func() *consolidationState {
+				state := goodState()
+				state.target.WithdrawalCredentials[0] = 0x01
+
+				return state
+			},
+			reasons: []string{"target validator 2 has execution address (0x01) withdrawal credentials; compounding withdrawal credentials are required"},
+		},
+		{
+			name: "SourceNotActive",
+			state: func() *consolidationState {
+				state := goodState()
+				state.source.ActivationEpoch = util.FarFutureEpoch
+
+				return state
+			},
+			reasons: []string{"source validator 1 is not active"},
+		},
+		{
+			name: "TargetExiting",
+			state: func() *consolidationState {
+				state := goodState()
+				state.target.ExitEpoch = 1005
+
+				return state
+			},
+			reasons: []string{"target validator 2 is exiting, with exit epoch 1005"},
+		},
+		{
+			name: "SourceTooNew",
+			state: func() *consolidationState {
+				state := goodState()
+				state.source.ActivationEpoch = 900
+
+				return state
+			},
+			reasons: []string{"source validator 1 has not been active long enough to consolidate; it can consolidate from epoch 1156"},
+		},
+		{
+			name: "SourcePendingWithdrawals",
+			state: func() *consolidationState {
+				state := goodState()
+				state.sourcePendingWithdrawals = 1000000000
+
+				return state
+			},
+			reasons: []string{"source validator 1 has 1 Ether of partial withdrawals awaiting processing"},
+		},
+		{
+			name: "Multiple",
+			state: func() *consolidationState {
+				state := goodState()
+				state.target.WithdrawalCredentials[0] = 0x01
+				state.source.ExitEpoch = 1005
+
+				return state
+			},
+			reasons: []string{
+				"target validator 2 has execution address (0x01) withdrawal credentials; compounding withdrawal credentials are required",
+				"source validator 1 is exiting, with exit epoch 1005",
+			},
+		},
+		{
+			name: "SwitchToCompounding",
+			state: func() *consolidationState {
+				state := goodState()
+				state.target = state.source
+				state.targetIndex = state.sourceIndex
+				// Switching does not depend on the consolidation queue.
+				state.pendingConsolidations = 64
+
+				return state
+			},
+			reasons: []string{},
+		},
+		{
+			name: "SwitchToCompoundingAlreadyCompounding",
+			state: func() *consolidationState {
+				state := goodState()
+				state.source = state.target
+				state.sourceIndex = state.targetIndex
+
+				return state
+			},
+			reasons: []string{"validator 2 has compounding execution address (0x02) withdrawal credentials; only execution address (0x01) withdrawal credentials can be switched to compounding"},
+		},
+		{
+			name: "SwitchToCompoundingExiting",
+			state: func() *consolidationState {
+				state := goodState()
+				state.source.ExitEpoch = 1005
+				state.target = state.source
+				state.targetIndex = state.sourceIndex
+
+				return state
+			},
+			reasons: []string{"validator 1 is exiting, with exit epoch 1005"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reasons := consolidationSkipReasons(test.state(), params, test.sourceAddress)
+			require.Equal(t, test.reasons, reasons)
+		})
+	}
+}
@@ -15,11 +15,10 @@ package validatorcredentialsget
 
 import (
 	"context"
-	"encoding/hex"
 	"fmt"
 	"strings"
 
-	ethutil "github.com/wealdtech/go-eth2-util"
+	"github.com/wealdtech/ethdo/util"
 )
 
 func (c *command) output(_ context.Context) (string, error) {
@@ -35,7 +34,7 @@ func (c *command) output(_ context.Context) (string, error) {
 		builder.WriteString(fmt.Sprintf("%#x", c.validatorInfo.Validator.WithdrawalCredentials))
 	case 1:
 		builder.WriteString("Ethereum execution address: ")
-		builder.WriteString(addressBytesToEIP55(c.validatorInfo.Validator.WithdrawalCredentials[12:]))
+		builder.WriteString(util.AddressBytesToEIP55(c.validatorInfo.Validator.WithdrawalCredentials[12:]))
 		if c.verbose {
 			builder.WriteString("\n")
 			builder.WriteString("Withdrawal credentials: ")
@@ -45,22 +44,3 @@ func (c *command) output(_ context.Context) (string, error) {
 
 	return builder.String(), nil
 }
-
-// addressBytesToEIP55 converts a byte array in to an EIP-55 string format.
-func addressBytesToEIP55(address []byte) string {
-	bytes := []byte(hex.EncodeToString(address))
-	hash := ethutil.Keccak256(bytes)
-	for i := 0; i < len(bytes); i++ {
-		hashByte := hash[i/2]
-		if i%2 == 0 {
-			hashByte >>= 4
-		} else {
-			hashByte &= 0xf
-		}
-		if bytes[i] > '9' && hashByte > 7 {
-			bytes[i] -= 32
-		}
-	}
-
-	return fmt.Sprintf("0x%s", string(bytes))
-}
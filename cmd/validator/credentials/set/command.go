@@ -44,27 +44,35 @@ type command struct {
 	privateKey            string
 	validator             string
 	withdrawalAddressStr  string
+	allowUnchecksummed    bool
 	forkVersion           string
 	genesisValidatorsRoot string
 	prepareOffline        bool
 	signedOperationsInput string
 	maxDistance           uint64
+	approvalRequest       string
 
 	// Beacon node connection.
 	timeout                  time.Duration
 	connection               string
 	allowInsecureConnections bool
 
+	// Execution node connection.
+	connectionExecution string
+
 	// Information required to generate the operations.
-	withdrawalAddress bellatrix.ExecutionAddress
-	chainInfo         *beacon.ChainInfo
-	domain            phase0.Domain
+	withdrawalAddress        bellatrix.ExecutionAddress
+	withdrawalAddressChecked bool
+	chainInfo                *beacon.ChainInfo
+	domain                   phase0.Domain
 
 	// Processing.
+	approvalGate    *util.ApprovalGate
 	consensusClient consensusclient.Service
 	chainTime       chaintime.Service
 
 	// Output.
+	approvalStatus   string
 	signedOperations []*capella.SignedBLSToExecutionChange
 }
 
@@ -78,6 +86,7 @@ func newCommand(_ context.Context) (*command, error) {
 		timeout:                  viper.GetDuration("timeout"),
 		connection:               viper.GetString("connection"),
 		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		connectionExecution:      viper.GetString("connection-execution"),
 		prepareOffline:           viper.GetBool("prepare-offline"),
 		account:                  viper.GetString("account"),
 		withdrawalAccount:        viper.GetString("withdrawal-account"),
@@ -89,9 +98,11 @@ func newCommand(_ context.Context) (*command, error) {
 
 		validator:             viper.GetString("validator"),
 		withdrawalAddressStr:  viper.GetString("withdrawal-address"),
+		allowUnchecksummed:    viper.GetBool("allow-unchecksummed"),
 		forkVersion:           viper.GetString("fork-version"),
 		genesisValidatorsRoot: viper.GetString("genesis-validators-root"),
 		maxDistance:           viper.GetUint64("max-distance"),
+		approvalRequest:       viper.GetString("approval-request"),
 	}
 
 	// Timeout is required.
@@ -18,8 +18,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
 )
 
 //nolint:unparam
@@ -28,6 +30,10 @@ func (c *command) output(_ context.Context) (string, error) {
 		return "", nil
 	}
 
+	if c.approvalStatus != "" {
+		return c.approvalStatus, nil
+	}
+
 	if c.prepareOffline {
 		return fmt.Sprintf("%s generated", offlinePreparationFilename), nil
 	}
@@ -46,5 +52,14 @@ func (c *command) output(_ context.Context) (string, error) {
 		return "", nil
 	}
 
-	return "", nil
+	// Echo the addresses back, to allow them to be confirmed.
+	builder := strings.Builder{}
+	for i, op := range c.signedOperations {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		builder.WriteString(fmt.Sprintf("Validator %d withdrawal address set to %s", op.Message.ValidatorIndex, util.AddressBytesToEIP55(op.Message.ToExecutionAddress[:])))
+	}
+
+	return builder.String(), nil
 }
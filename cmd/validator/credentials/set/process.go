@@ -25,11 +25,9 @@ import (
 	"time"
 
 	consensusclient "github.com/attestantio/go-eth2-client"
-	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	capella "github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
-	"github.com/prysmaticlabs/go-ssz"
 	"github.com/wealdtech/ethdo/beacon"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
 	"github.com/wealdtech/ethdo/signing"
@@ -72,10 +70,22 @@ func (c *command) process(ctx context.Context) error {
 		return err
 	}
 
+	var err error
+	c.approvalGate, err = util.NewApprovalGate(util.ApprovalOperationCredentialsChange, c.approvalRequest)
+	if err != nil {
+		return err
+	}
+
 	if err := c.obtainOperations(ctx); err != nil {
 		return err
 	}
 
+	if c.approvalGate.Pending() {
+		// Operations cannot be signed until they have been approved.
+		c.approvalStatus, err = c.approvalGate.WritePending()
+		return err
+	}
+
 	if len(c.signedOperations) == 0 {
 		return errors.New("no suitable validators found; no operations generated")
 	}
@@ -584,6 +594,17 @@ func (c *command) createSignedOperation(ctx context.Context,
 		return nil, errors.Wrap(err, "failed to generate root for credentials change operation")
 	}
 
+	approved, err := c.approvalGate.Approved(c.domain, root, operation)
+	if err != nil {
+		return nil, err
+	}
+	if !approved {
+		// Operation is awaiting approval, so is not signed.
+		return &capella.SignedBLSToExecutionChange{
+			Message: operation,
+		}, nil
+	}
+
 	// Sign the operation.
 	if c.debug {
 		fmt.Fprintf(os.Stderr, "Signing %#x with domain %#x by public key %#x\n", root, c.domain, withdrawalAccount.PublicKey().Marshal())
@@ -599,28 +620,28 @@ func (c *command) createSignedOperation(ctx context.Context,
 	}, nil
 }
 
-func (c *command) parseWithdrawalAddress(_ context.Context) error {
+func (c *command) parseWithdrawalAddress(ctx context.Context) error {
 	// Check that a withdrawal address has been provided.
 	if c.withdrawalAddressStr == "" {
 		return errors.New("no withdrawal address provided")
 	}
-	// Check that the withdrawal address contains a 0x prefix.
-	if !strings.HasPrefix(c.withdrawalAddressStr, "0x") {
-		return fmt.Errorf("withdrawal address %s does not contain a 0x prefix", c.withdrawalAddressStr)
-	}
-	withdrawalAddressBytes, err := hex.DecodeString(strings.TrimPrefix(c.withdrawalAddressStr, "0x"))
+	var err error
+	c.withdrawalAddress, err = util.ParseWithdrawalAddress(c.withdrawalAddressStr, c.allowUnchecksummed)
 	if err != nil {
-		return errors.Wrap(err, "failed to obtain execution address")
-	}
-	if len(withdrawalAddressBytes) != bellatrix.ExecutionAddressLength {
-		return errors.New("withdrawal address must be exactly 20 bytes in length")
+		return err
 	}
-	// Ensure the address is properly checksummed.
-	checksummedAddress := addressBytesToEIP55(withdrawalAddressBytes)
-	if checksummedAddress != c.withdrawalAddressStr {
-		return fmt.Errorf("withdrawal address checksum does not match (expected %s)", checksummedAddress)
+
+	// If an execution node is available use it to check the address, once.
+	if c.connectionExecution != "" && !c.offline && !c.withdrawalAddressChecked {
+		executionClient, err := util.NewExecutionClient(c.connectionExecution)
+		if err != nil {
+			return errors.Wrap(err, "failed to set up execution client")
+		}
+		if err := util.CheckWithdrawalAddress(ctx, executionClient, c.withdrawalAddress); err != nil {
+			return err
+		}
+		c.withdrawalAddressChecked = true
 	}
-	copy(c.withdrawalAddress[:], withdrawalAddressBytes)
 
 	return nil
 }
@@ -657,7 +678,7 @@ func (c *command) verifyOperation(_ context.Context, op *capella.SignedBLSToExec
 		ObjectRoot: root,
 		Domain:     c.domain,
 	}
-	signingRoot, err := ssz.HashTreeRoot(container)
+	signingRoot, err := container.HashTreeRoot()
 	if err != nil {
 		return errors.Wrap(err, "failed to generate signing root")
 	}
@@ -708,6 +729,16 @@ func (c *command) validateOperation(_ context.Context,
 }
 
 func (c *command) broadcastOperations(ctx context.Context) error {
+	if err := util.CheckOperationsChain(ctx,
+		c.consensusClient,
+		c.obtainGenesisValidatorsRoot,
+		c.obtainForkVersion,
+		c.domain,
+		util.CheckBLSToExecutionChangeDomain,
+	); err != nil {
+		return err
+	}
+
 	return c.consensusClient.(consensusclient.BLSToExecutionChangesSubmitter).SubmitBLSToExecutionChanges(ctx, c.signedOperations)
 }
 
@@ -831,22 +862,3 @@ func (c *command) obtainForkVersion(_ context.Context) (phase0.Version, error) {
 	}
 	return forkVersion, nil
 }
-
-// addressBytesToEIP55 converts a byte array in to an EIP-55 string format.
-func addressBytesToEIP55(address []byte) string {
-	bytes := []byte(hex.EncodeToString(address))
-	hash := ethutil.Keccak256(bytes)
-	for i := 0; i < len(bytes); i++ {
-		hashByte := hash[i/2]
-		if i%2 == 0 {
-			hashByte >>= 4
-		} else {
-			hashByte &= 0xf
-		}
-		if bytes[i] > '9' && hashByte > 7 {
-			bytes[i] -= 32
-		}
-	}
-
-	return fmt.Sprintf("0x%s", string(bytes))
-}
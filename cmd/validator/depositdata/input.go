@@ -1,4 +1,4 @@
-// Copyright © 2019, 2020 Weald Technology Trading
+// Copyright © 2019, 2020, 2024 Weald Technology Trading
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -15,10 +15,11 @@ package depositdata
 
 import (
 	"context"
-	"encoding/hex"
-	"strings"
+	"fmt"
 	"time"
 
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -29,16 +30,22 @@ import (
 )
 
 type dataIn struct {
-	format            string
-	timeout           time.Duration
-	withdrawalAccount string
-	withdrawalPubKey  string
-	withdrawalAddress string
-	amount            spec.Gwei
-	validatorAccounts []e2wtypes.Account
-	forkVersion       *spec.Version
-	domain            *spec.Domain
-	passphrases       []string
+	format             string
+	timeout            time.Duration
+	withdrawalAccount  string
+	withdrawalPubKey   string
+	withdrawalAddress  string
+	compounding        bool
+	amount             spec.Gwei
+	validatorAccounts  []e2wtypes.Account
+	forkVersion        *spec.Version
+	domain             *spec.Domain
+	passphrases        []string
+	unlockWorkers      int
+	force              bool
+	allowUnchecksummed bool
+	eth2Client         eth2client.Service
+	executionClient    *ethdoutil.ExecutionClient
 }
 
 func input() (*dataIn, error) {
@@ -77,10 +84,13 @@ func input() (*dataIn, error) {
 	}
 
 	data.passphrases = ethdoutil.GetPassphrases()
+	data.unlockWorkers = ethdoutil.UnlockWorkers()
 
 	data.withdrawalAccount = viper.GetString("withdrawalaccount")
 	data.withdrawalPubKey = viper.GetString("withdrawalpubkey")
 	data.withdrawalAddress = viper.GetString("withdrawaladdress")
+	data.allowUnchecksummed = viper.GetBool("allow-unchecksummed")
+	data.compounding = viper.GetBool("compounding")
 	withdrawalDetailsPresent := 0
 	if data.withdrawalAccount != "" {
 		withdrawalDetailsPresent++
@@ -97,6 +107,9 @@ func input() (*dataIn, error) {
 	if withdrawalDetailsPresent > 1 {
 		return nil, errors.New("only one of withdrawal account, public key or address is allowed")
 	}
+	if data.compounding && data.withdrawalAddress == "" {
+		return nil, errors.New("compounding withdrawal credentials require a withdrawal address")
+	}
 
 	if viper.GetString("depositvalue") == "" {
 		return nil, errors.New("deposit value is required")
@@ -118,24 +131,76 @@ func input() (*dataIn, error) {
 
 	copy(data.domain[:], e2types.Domain(e2types.DomainDeposit, data.forkVersion[:], e2types.ZeroGenesisValidatorsRoot))
 
-	return data, nil
-}
-
-func inputForkVersion(_ context.Context) (*spec.Version, error) {
-	// Default to mainnet.
-	forkVersion := &spec.Version{0x00, 0x00, 0x00, 0x00}
+	data.force = viper.GetBool("force")
+
+	// Deposit data can be generated without a connection to the beacon node,
+	// but if one is supplied it is used to check for existing deposits.
+	if viper.GetString("connection") != "" && !ethdoutil.Offline() {
+		data.eth2Client, err = ethdoutil.ConnectToBeaconNode(ctx, &ethdoutil.ConnectOpts{
+			Address:       viper.GetString("connection"),
+			Timeout:       viper.GetDuration("timeout"),
+			AllowInsecure: viper.GetBool("allow-insecure-connections"),
+			LogFallback:   !viper.GetBool("quiet"),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	// An execution node is optional, and used to check the withdrawal address
+	// and the deposit contract for recent deposits.
+	if viper.GetString("connection-execution") != "" && !ethdoutil.Offline() {
+		data.executionClient, err = ethdoutil.NewExecutionClient(viper.GetString("connection-execution"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to set up execution client")
+		}
+	}
 
-	// Override if supplied.
-	if viper.GetString("forkversion") != "" {
-		data, err := hex.DecodeString(strings.TrimPrefix(viper.GetString("forkversion"), "0x"))
+	if data.compounding {
+		maxAmount, err := inputMaxCompoundingAmount(ctx, data.eth2Client)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to decode fork version")
+			return nil, err
 		}
-		if len(data) != 4 {
-			return nil, errors.New("fork version must be exactly 4 bytes in length")
+		if data.amount > maxAmount {
+			return nil, fmt.Errorf("deposit value must be at most %s for compounding withdrawal credentials", string2eth.GWeiToString(uint64(maxAmount), true))
 		}
+	}
+
+	return data, nil
+}
 
-		copy(forkVersion[:], data)
+// inputMaxCompoundingAmount obtains the maximum effective balance of a validator
+// with compounding withdrawal credentials.
+func inputMaxCompoundingAmount(ctx context.Context, eth2Client eth2client.Service) (spec.Gwei, error) {
+	if eth2Client == nil {
+		// This is hard-coded, to allow deposit data to be generated without a connection to the beacon node.
+		return spec.Gwei(2048000000000), nil // MAX_EFFECTIVE_BALANCE_ELECTRA
 	}
-	return forkVersion, nil
+
+	specProvider, isProvider := eth2Client.(eth2client.SpecProvider)
+	if !isProvider {
+		return 0, errors.New("beacon node does not provide spec information")
+	}
+	specResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to obtain spec")
+	}
+	balanceParams, err := ethdoutil.ObtainBalanceParameters(specResponse.Data)
+	if err != nil {
+		return 0, err
+	}
+	if !balanceParams.SupportsElectra() {
+		return 0, errors.New("chain does not support compounding withdrawal credentials")
+	}
+
+	return balanceParams.MaxEffectiveBalanceElectra, nil
+}
+
+func inputForkVersion(_ context.Context) (*spec.Version, error) {
+	// Defaults to the network configuration, or mainnet, if not supplied.
+	forkVersion, err := ethdoutil.GenesisForkVersion(viper.GetString("forkversion"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &forkVersion, nil
 }
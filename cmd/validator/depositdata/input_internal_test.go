@@ -180,6 +180,30 @@ func TestInput(t *testing.T) {
 			},
 			err: "only one of withdrawal account, public key or address is allowed",
 		},
+		{
+			name: "CompoundingWithoutWithdrawalAddress",
+			vars: map[string]interface{}{
+				"timeout":           "10s",
+				"validatoraccount":  "Test/Interop 0",
+				"withdrawalaccount": "Test/Interop 0",
+				"compounding":       true,
+				"depositvalue":      "32 Ether",
+				"forkversion":       "0x01020304",
+			},
+			err: "compounding withdrawal credentials require a withdrawal address",
+		},
+		{
+			name: "CompoundingDepositValueTooLarge",
+			vars: map[string]interface{}{
+				"timeout":           "10s",
+				"validatoraccount":  "Test/Interop 0",
+				"withdrawaladdress": "0x30C99930617B7b793beaB603ecEB08691005f2E5",
+				"compounding":       true,
+				"depositvalue":      "2049 Ether",
+				"forkversion":       "0x01020304",
+			},
+			err: "deposit value must be at most 2048 Ether for compounding withdrawal credentials",
+		},
 		{
 			name: "DepositValueMissing",
 			vars: map[string]interface{}{
@@ -287,6 +311,26 @@ func TestInput(t *testing.T) {
 				domain:            domain,
 			},
 		},
+		{
+			name: "GoodCompounding",
+			vars: map[string]interface{}{
+				"timeout":           "10s",
+				"validatoraccount":  "Test/Interop 0",
+				"withdrawaladdress": "0x30C99930617B7b793beaB603ecEB08691005f2E5",
+				"compounding":       true,
+				"depositvalue":      "2048 Ether",
+				"forkversion":       "0x01020304",
+			},
+			res: &dataIn{
+				format:            "json",
+				withdrawalAddress: "0x30C99930617B7b793beaB603ecEB08691005f2E5",
+				compounding:       true,
+				amount:            2048000000000,
+				validatorAccounts: []e2wtypes.Account{interop0},
+				forkVersion:       forkVersion,
+				domain:            domain,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -305,6 +349,7 @@ func TestInput(t *testing.T) {
 				require.Equal(t, test.res.withdrawalAccount, res.withdrawalAccount)
 				require.Equal(t, test.res.withdrawalAddress, res.withdrawalAddress)
 				require.Equal(t, test.res.withdrawalPubKey, res.withdrawalPubKey)
+				require.Equal(t, test.res.compounding, res.compounding)
 				require.Equal(t, test.res.amount, res.amount)
 				require.Equal(t, test.res.forkVersion, res.forkVersion)
 				require.Equal(t, test.res.domain, res.domain)
@@ -1,4 +1,4 @@
-// Copyright © 2019-2021 Weald Technology Limited.
+// Copyright © 2019-2024 Weald Technology Limited.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -40,6 +40,24 @@ func process(data *dataIn) ([]*dataOut, error) {
 		return nil, err
 	}
 
+	if err := checkExistingDeposits(data); err != nil {
+		return nil, err
+	}
+
+	// Use a single session for signing, so that each account is unlocked
+	// once regardless of the number of signatures.
+	ctx := context.Background()
+	session := signing.NewSession(data.passphrases)
+	defer func() {
+		_ = session.Close(ctx)
+	}()
+	if len(data.validatorAccounts) > 1 {
+		// Decrypting keys dominates the time taken for multiple accounts, so unlock them in parallel up front.
+		if err := session.UnlockAll(ctx, data.validatorAccounts, data.unlockWorkers); err != nil {
+			return nil, err
+		}
+	}
+
 	for _, validatorAccount := range data.validatorAccounts {
 		validatorPubKey, err := ethdoutil.BestPublicKey(validatorAccount)
 		if err != nil {
@@ -60,7 +78,7 @@ func process(data *dataIn) ([]*dataOut, error) {
 		var depositMessageRoot spec.Root
 		copy(depositMessageRoot[:], root[:])
 
-		sig, err := signing.SignRoot(context.Background(), validatorAccount, data.passphrases, depositMessageRoot, *data.domain)
+		sig, err := session.SignRoot(ctx, validatorAccount, depositMessageRoot, *data.domain)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to sign deposit message")
 		}
@@ -95,7 +113,35 @@ func process(data *dataIn) ([]*dataOut, error) {
 	return results, nil
 }
 
+// checkExistingDeposits checks if any of the validator accounts have already
+// been deposited, to avoid accidentally depositing to a validator twice.
+func checkExistingDeposits(data *dataIn) error {
+	if data.eth2Client == nil {
+		// No connection, so cannot check.
+		return nil
+	}
+
+	pubKeys := make([]spec.BLSPubKey, 0, len(data.validatorAccounts))
+	for _, validatorAccount := range data.validatorAccounts {
+		validatorPubKey, err := ethdoutil.BestPublicKey(validatorAccount)
+		if err != nil {
+			return errors.Wrap(err, "validator account does not provide a public key")
+		}
+		var pubKey spec.BLSPubKey
+		copy(pubKey[:], validatorPubKey.Marshal())
+		pubKeys = append(pubKeys, pubKey)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), data.timeout)
+	defer cancel()
+
+	return ethdoutil.CheckExistingDeposits(ctx, data.eth2Client, pubKeys, &ethdoutil.ExistingDepositsOpts{
+		ExecutionClient: data.executionClient,
+	}, data.force, false)
+}
+
 // createWithdrawalCredentials creates withdrawal credentials given an account, public key or Ethereum 1 address.
+// Withdrawal credentials for an Ethereum 1 address are compounding if requested.
 func createWithdrawalCredentials(data *dataIn) ([]byte, error) {
 	var withdrawalCredentials []byte
 
@@ -130,44 +176,27 @@ func createWithdrawalCredentials(data *dataIn) ([]byte, error) {
 		// This is hard-coded, to allow deposit data to be generated without a connection to the beacon node.
 		withdrawalCredentials[0] = byte(0) // BLS_WITHDRAWAL_PREFIX
 	case data.withdrawalAddress != "":
-		withdrawalAddressBytes, err := hex.DecodeString(strings.TrimPrefix(data.withdrawalAddress, "0x"))
+		withdrawalAddress, err := ethdoutil.ParseWithdrawalAddress(data.withdrawalAddress, data.allowUnchecksummed)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to decode withdrawal address")
-		}
-		if len(withdrawalAddressBytes) != 20 {
-			return nil, errors.New("withdrawal address must be exactly 20 bytes in length")
+			return nil, err
 		}
-		// Ensure the address is properly checksummed.
-		checksummedAddress := addressBytesToEIP55(withdrawalAddressBytes)
-		if checksummedAddress != data.withdrawalAddress {
-			return nil, fmt.Errorf("withdrawal address checksum does not match (expected %s)", checksummedAddress)
+		if data.executionClient != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), data.timeout)
+			defer cancel()
+			if err := ethdoutil.CheckWithdrawalAddress(ctx, data.executionClient, withdrawalAddress); err != nil {
+				return nil, err
+			}
 		}
 		withdrawalCredentials = make([]byte, 32)
-		copy(withdrawalCredentials[12:32], withdrawalAddressBytes)
+		copy(withdrawalCredentials[12:32], withdrawalAddress[:])
 		// This is hard-coded, to allow deposit data to be generated without a connection to the beacon node.
 		withdrawalCredentials[0] = byte(1) // ETH1_ADDRESS_WITHDRAWAL_PREFIX
+		if data.compounding {
+			withdrawalCredentials[0] = byte(2) // COMPOUNDING_WITHDRAWAL_PREFIX
+		}
 	default:
 		return nil, errors.New("withdrawal account, public key or address is required")
 	}
 
 	return withdrawalCredentials, nil
 }
-
-// addressBytesToEIP55 converts a byte array in to an EIP-55 string format.
-func addressBytesToEIP55(address []byte) string {
-	bytes := []byte(hex.EncodeToString(address))
-	hash := util.Keccak256(bytes)
-	for i := 0; i < len(bytes); i++ {
-		hashByte := hash[i/2]
-		if i%2 == 0 {
-			hashByte >>= 4
-		} else {
-			hashByte &= 0xf
-		}
-		if bytes[i] > '9' && hashByte > 7 {
-			bytes[i] -= 32
-		}
-	}
-
-	return fmt.Sprintf("0x%s", string(bytes))
-}
@@ -15,13 +15,15 @@ package depositdata
 
 import (
 	"context"
-	"encoding/hex"
-	"strings"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testing/mock"
 	"github.com/wealdtech/ethdo/testutil"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
@@ -123,6 +125,22 @@ func TestProcess(t *testing.T) {
 		signature3 = &tmp
 	}
 
+	var depositDataRoot4 *spec.Root
+	{
+		tmp := testutil.HexToRoot("0x284072a579b35b6432ae5022bb3ac59463c0fea5eb84683f506856f8f7aa9226")
+		depositDataRoot4 = &tmp
+	}
+	var depositMessageRoot4 *spec.Root
+	{
+		tmp := testutil.HexToRoot("0xd30cbaad298b43a64b4e44869c4dd34d314ddce47485ca10c6801c81e1294048")
+		depositMessageRoot4 = &tmp
+	}
+	var signature4 *spec.BLSSignature
+	{
+		tmp := testutil.HexToSignature("0x85341c0c7dab8f267c4952f6999636897c5fa18563d99dd5be44201432b582c9c33a63fb6a9061e20ef34f9813f5274910e3656ab60160585819fd0009378f5c2ba4d9ca3ae04fc4780437ca341d4e4cc582c3f217e5ff7c597a01540aecc110")
+		signature4 = &tmp
+	}
+
 	tests := []struct {
 		name   string
 		dataIn *dataIn
@@ -208,7 +226,7 @@ func TestProcess(t *testing.T) {
 				forkVersion:       forkVersion,
 				domain:            domain,
 			},
-			err: "failed to decode withdrawal address: encoding/hex: invalid byte: U+0069 'i'",
+			err: "withdrawal address invalid does not contain a 0x prefix",
 		},
 		{
 			name: "WithdrawalAddressWrongLength",
@@ -322,6 +340,32 @@ func TestProcess(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Compounding",
+			dataIn: &dataIn{
+				format:            "raw",
+				passphrases:       []string{"pass"},
+				withdrawalAddress: withdrawalAddress,
+				compounding:       true,
+				amount:            64000000000,
+				validatorAccounts: []e2wtypes.Account{interop0},
+				forkVersion:       forkVersion,
+				domain:            domain,
+			},
+			res: []*dataOut{
+				{
+					format:                "raw",
+					account:               "Test/Interop 0",
+					validatorPubKey:       validatorPubKey,
+					amount:                64000000000,
+					withdrawalCredentials: testutil.HexToBytes("0x02000000000000000000000030C99930617B7b793beaB603ecEB08691005f2E5"),
+					signature:             signature4,
+					forkVersion:           forkVersion,
+					depositDataRoot:       depositDataRoot4,
+					depositMessageRoot:    depositMessageRoot4,
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -337,17 +381,96 @@ func TestProcess(t *testing.T) {
 	}
 }
 
-func TestAddressBytesToEIP55(t *testing.T) {
-	tests := []string{
-		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
-		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
-		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
-		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+func TestCheckExistingDeposits(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	testWallet, err := nd.CreateWallet(context.Background(), "Test", scratch.New(), keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, testWallet.(e2wtypes.WalletLocker).Unlock(context.Background(), nil))
+	interop0, err := testWallet.(e2wtypes.WalletAccountImporter).ImportAccount(context.Background(),
+		"Interop 0",
+		testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"),
+		[]byte("pass"),
+	)
+	require.NoError(t, err)
+	interop1, err := testWallet.(e2wtypes.WalletAccountImporter).ImportAccount(context.Background(),
+		"Interop 1",
+		testutil.HexToBytes("0x51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000"),
+		[]byte("pass"),
+	)
+	require.NoError(t, err)
+
+	noPending := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer noPending.Close()
+	pending := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"pubkey":"0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b"}]}`))
+	}))
+	defer pending.Close()
+
+	validators := map[spec.BLSPubKey]spec.ValidatorIndex{
+		testutil.HexToPubKey("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"): 5,
+	}
+	service := mock.NewValidatorsService(noPending.URL, validators)
+
+	tests := []struct {
+		name   string
+		dataIn *dataIn
+		err    string
+	}{
+		{
+			name: "NoConnection",
+			dataIn: &dataIn{
+				timeout:           5 * time.Second,
+				validatorAccounts: []e2wtypes.Account{interop0},
+			},
+		},
+		{
+			name: "NotDeposited",
+			dataIn: &dataIn{
+				timeout:           5 * time.Second,
+				validatorAccounts: []e2wtypes.Account{interop1},
+				eth2Client:        service,
+			},
+		},
+		{
+			name: "Deposited",
+			dataIn: &dataIn{
+				timeout:           5 * time.Second,
+				validatorAccounts: []e2wtypes.Account{interop0, interop1},
+				eth2Client:        service,
+			},
+			err: "deposits already exist for 0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c (index 5); use --force to continue regardless",
+		},
+		{
+			name: "Pending",
+			dataIn: &dataIn{
+				timeout:           5 * time.Second,
+				validatorAccounts: []e2wtypes.Account{interop0, interop1},
+				eth2Client:        mock.NewValidatorsService(pending.URL, validators),
+			},
+			err: "deposits already exist for 0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c (index 5), 0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b (pending deposit); use --force to continue regardless",
+		},
+		{
+			name: "DepositedForce",
+			dataIn: &dataIn{
+				timeout:           5 * time.Second,
+				validatorAccounts: []e2wtypes.Account{interop0, interop1},
+				eth2Client:        service,
+				force:             true,
+			},
+		},
 	}
 
 	for _, test := range tests {
-		bytes, err := hex.DecodeString(strings.TrimPrefix(test, "0x"))
-		require.NoError(t, err)
-		require.Equal(t, addressBytesToEIP55(bytes), test)
+		t.Run(test.name, func(t *testing.T) {
+			err := checkExistingDeposits(test.dataIn)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
 	}
 }
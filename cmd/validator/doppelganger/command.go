@@ -0,0 +1,92 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatordoppelganger
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Operation.
+	validators []string
+	epochs     uint64
+	jsonOutput bool
+
+	// Data access.
+	eth2Client         eth2client.Service
+	chainTime          chaintime.Service
+	validatorsProvider eth2client.ValidatorsProvider
+
+	// Results.
+	results *results
+}
+
+type results struct {
+	FromEpoch  phase0.Epoch         `json:"from_epoch"`
+	ToEpoch    phase0.Epoch         `json:"to_epoch"`
+	Validators []*validatorLiveness `json:"validators"`
+}
+
+type validatorLiveness struct {
+	Index      phase0.ValidatorIndex `json:"index"`
+	PubKey     phase0.BLSPubKey      `json:"pubkey"`
+	LiveEpochs []phase0.Epoch        `json:"live_epochs"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.validators = viper.GetStringSlice("validators")
+	if len(c.validators) == 0 {
+		return nil, errors.New("validators are required")
+	}
+
+	c.epochs = viper.GetUint64("epochs")
+	if c.epochs == 0 {
+		return nil, errors.New("epochs must be at least 1")
+	}
+
+	c.jsonOutput = viper.GetBool("json")
+
+	return c, nil
+}
@@ -0,0 +1,77 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatordoppelganger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		if len(c.results.liveValidators()) > 0 {
+			os.Exit(1)
+		}
+		return "", nil
+	}
+
+	if c.jsonOutput {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputTxt(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.results)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if len(c.results.Validators) == 0 {
+		return "None of the validators are known to the chain", nil
+	}
+
+	live := c.results.liveValidators()
+	for _, validator := range c.results.Validators {
+		if len(validator.LiveEpochs) == 0 {
+			if c.verbose {
+				builder.WriteString(fmt.Sprintf("Validator %d (%#x) not seen\n", validator.Index, validator.PubKey))
+			}
+			continue
+		}
+		epochs := make([]string, len(validator.LiveEpochs))
+		for i := range validator.LiveEpochs {
+			epochs[i] = fmt.Sprintf("%d", validator.LiveEpochs[i])
+		}
+		builder.WriteString(fmt.Sprintf("Validator %d (%#x) seen in epochs %s\n", validator.Index, validator.PubKey, strings.Join(epochs, ", ")))
+	}
+
+	if len(live) == 0 {
+		builder.WriteString(fmt.Sprintf("No activity seen for %d validators in epochs %d-%d", len(c.results.Validators), c.results.FromEpoch, c.results.ToEpoch))
+	} else {
+		builder.WriteString(fmt.Sprintf("%d of %d validators appear to be active elsewhere; do not start them", len(live), len(c.results.Validators)))
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,110 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatordoppelganger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	quiet := &results{
+		FromEpoch: 99,
+		ToEpoch:   100,
+		Validators: []*validatorLiveness{
+			{
+				Index:      1,
+				PubKey:     phase0.BLSPubKey{0x01},
+				LiveEpochs: []phase0.Epoch{},
+			},
+			{
+				Index:      2,
+				PubKey:     phase0.BLSPubKey{0x02},
+				LiveEpochs: []phase0.Epoch{},
+			},
+		},
+	}
+	live := &results{
+		FromEpoch: 99,
+		ToEpoch:   100,
+		Validators: []*validatorLiveness{
+			{
+				Index:      1,
+				PubKey:     phase0.BLSPubKey{0x01},
+				LiveEpochs: []phase0.Epoch{},
+			},
+			{
+				Index:      2,
+				PubKey:     phase0.BLSPubKey{0x02},
+				LiveEpochs: []phase0.Epoch{99, 100},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		results *results
+		json    bool
+		verbose bool
+		res     string
+	}{
+		{
+			name: "Unknown",
+			results: &results{
+				FromEpoch:  99,
+				ToEpoch:    100,
+				Validators: []*validatorLiveness{},
+			},
+			res: "None of the validators are known to the chain",
+		},
+		{
+			name:    "NotSeen",
+			results: quiet,
+			res:     "No activity seen for 2 validators in epochs 99-100",
+		},
+		{
+			name:    "NotSeenVerbose",
+			results: quiet,
+			verbose: true,
+			res:     "Validator 1 (0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000) not seen\nValidator 2 (0x020000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000) not seen\nNo activity seen for 2 validators in epochs 99-100",
+		},
+		{
+			name:    "Seen",
+			results: live,
+			res:     "Validator 2 (0x020000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000) seen in epochs 99, 100\n1 of 2 validators appear to be active elsewhere; do not start them",
+		},
+		{
+			name:    "JSON",
+			results: live,
+			json:    true,
+			res:     `{"from_epoch":"99","to_epoch":"100","validators":[{"index":"1","pubkey":"0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","live_epochs":[]},{"index":"2","pubkey":"0x020000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","live_epochs":["99","100"]}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				verbose:    test.verbose,
+				jsonOutput: test.json,
+				results:    test.results,
+			}
+			res, err := c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatordoppelganger
+
+import (
+	"context"
+	"sort"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	validators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validators, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to parse validators")
+	}
+	sort.Slice(validators, func(i int, j int) bool {
+		return validators[i].Index < validators[j].Index
+	})
+
+	c.results = &results{
+		Validators: make([]*validatorLiveness, 0, len(validators)),
+	}
+	c.results.FromEpoch, c.results.ToEpoch = epochRange(c.chainTime.CurrentEpoch(), c.epochs)
+
+	indices := make([]phase0.ValidatorIndex, 0, len(validators))
+	livenesses := make(map[phase0.ValidatorIndex]*validatorLiveness, len(validators))
+	for _, validator := range validators {
+		liveness := &validatorLiveness{
+			Index:      validator.Index,
+			PubKey:     validator.Validator.PublicKey,
+			LiveEpochs: make([]phase0.Epoch, 0),
+		}
+		c.results.Validators = append(c.results.Validators, liveness)
+		livenesses[validator.Index] = liveness
+		indices = append(indices, validator.Index)
+	}
+	if len(indices) == 0 {
+		// No validators known to the chain, so none can be live.
+		return nil
+	}
+
+	for epoch := c.results.FromEpoch; epoch <= c.results.ToEpoch; epoch++ {
+		live, err := util.FetchValidatorLiveness(ctx, c.eth2Client, epoch, indices)
+		if err != nil {
+			return errors.Wrapf(err, "failed to obtain liveness for epoch %d", epoch)
+		}
+		for index, isLive := range live {
+			liveness, exists := livenesses[index]
+			if isLive && exists {
+				liveness.LiveEpochs = append(liveness.LiveEpochs, epoch)
+			}
+		}
+	}
+
+	return nil
+}
+
+// epochRange returns the range of epochs that covers the given number of
+// epochs up to and including the current epoch.
+func epochRange(currentEpoch phase0.Epoch, epochs uint64) (phase0.Epoch, phase0.Epoch) {
+	if uint64(currentEpoch)+1 < epochs {
+		return 0, currentEpoch
+	}
+
+	return currentEpoch + 1 - phase0.Epoch(epochs), currentEpoch
+}
+
+// liveValidators returns the validators that have been seen to be live.
+func (r *results) liveValidators() []*validatorLiveness {
+	res := make([]*validatorLiveness, 0)
+	for _, validator := range r.Validators {
+		if len(validator.LiveEpochs) > 0 {
+			res = append(res, validator)
+		}
+	}
+
+	return res
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validators")
+	}
+
+	return nil
+}
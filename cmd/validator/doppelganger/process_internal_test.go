@@ -0,0 +1,68 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatordoppelganger
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEpochRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		current phase0.Epoch
+		epochs  uint64
+		from    phase0.Epoch
+		to      phase0.Epoch
+	}{
+		{
+			name:    "Single",
+			current: 100,
+			epochs:  1,
+			from:    100,
+			to:      100,
+		},
+		{
+			name:    "Multiple",
+			current: 100,
+			epochs:  3,
+			from:    98,
+			to:      100,
+		},
+		{
+			name:    "Genesis",
+			current: 1,
+			epochs:  3,
+			from:    0,
+			to:      1,
+		},
+		{
+			name:    "Exact",
+			current: 2,
+			epochs:  3,
+			from:    0,
+			to:      2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			from, to := epochRange(test.current, test.epochs)
+			require.Equal(t, test.from, from)
+			require.Equal(t, test.to, to)
+		})
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/signing"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+	"golang.org/x/sync/errgroup"
+)
+
+// remoteSignerConcurrency is the maximum number of concurrent signing
+// requests made to a remote wallet daemon.
+const remoteSignerConcurrency = 4
+
+// manifestFilename is the name of the manifest written to the output directory.
+const manifestFilename = "manifest.json"
+
+// exitTarget is a validator for which to generate an exit operation.
+type exitTarget struct {
+	name      string
+	account   e2wtypes.Account
+	validator *beacon.ValidatorInfo
+}
+
+// manifestEntry is the result of generating an exit operation for a single validator.
+type manifestEntry struct {
+	Account        string                 `json:"account,omitempty"`
+	ValidatorIndex *phase0.ValidatorIndex `json:"validator_index,omitempty"`
+	PublicKey      string                 `json:"public_key,omitempty"`
+	File           string                 `json:"file,omitempty"`
+	Error          string                 `json:"error,omitempty"`
+}
+
+// workers returns the number of operations to generate concurrently.
+func (c *command) workers() int {
+	if c.approvalGate != nil {
+		// Operations awaiting approval must be recorded in a stable order.
+		return 1
+	}
+
+	workers := c.concurrency
+	if c.remote && workers > remoteSignerConcurrency {
+		workers = remoteSignerConcurrency
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	return workers
+}
+
+// unsuitableState returns true if a validator in the given state cannot exit.
+func unsuitableState(state apiv1.ValidatorState) bool {
+	return state == apiv1.ValidatorStateActiveExiting ||
+		state == apiv1.ValidatorStateActiveSlashed ||
+		state == apiv1.ValidatorStateExitedUnslashed ||
+		state == apiv1.ValidatorStateExitedSlashed ||
+		state == apiv1.ValidatorStateWithdrawalPossible ||
+		state == apiv1.ValidatorStateWithdrawalDone
+}
+
+// generateOperationsFromAccounts generates exit operations for multiple accounts.
+func (c *command) generateOperationsFromAccounts(ctx context.Context,
+	walletName string,
+	accounts []e2wtypes.Account,
+) error {
+	// Turn the validators in to a map for easy lookup.
+	validators := make(map[string]*beacon.ValidatorInfo, len(c.chainInfo.Validators))
+	for _, validator := range c.chainInfo.Validators {
+		validators[fmt.Sprintf("%#x", validator.Pubkey)] = validator
+	}
+
+	targets := make([]*exitTarget, 0, len(accounts))
+	for _, account := range accounts {
+		name := fmt.Sprintf("%s/%s", walletName, account.Name())
+		pubKey, err := util.BestPublicKey(account)
+		if err != nil {
+			c.addFailure(&manifestEntry{Account: name}, err)
+			continue
+		}
+		entry := &manifestEntry{
+			Account:   name,
+			PublicKey: fmt.Sprintf("%#x", pubKey.Marshal()),
+		}
+		validator, exists := validators[entry.PublicKey]
+		if !exists {
+			c.addFailure(entry, errors.New("unknown validator"))
+			continue
+		}
+		index := validator.Index
+		entry.ValidatorIndex = &index
+		if unsuitableState(validator.State) {
+			c.addFailure(entry, fmt.Errorf("validator is in state %v, not suitable to generate an exit", validator.State))
+			continue
+		}
+		targets = append(targets, &exitTarget{
+			name:      name,
+			account:   account,
+			validator: validator,
+		})
+	}
+
+	return c.generateOperationsForTargets(ctx, targets)
+}
+
+// generateOperationsForTargets generates exit operations for the targets
+// concurrently.  Failures are recorded in the manifest rather than halting
+// generation for the remaining targets.
+func (c *command) generateOperationsForTargets(ctx context.Context, targets []*exitTarget) error {
+	epoch, err := c.selectEpoch()
+	if err != nil {
+		return err
+	}
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Using %d for epoch\n", epoch)
+	}
+
+	c.session = signing.NewSession(c.passphrases)
+	defer func() {
+		if err := c.session.Close(ctx); err != nil && c.debug {
+			fmt.Fprintf(os.Stderr, "Failed to close signing session: %v\n", err)
+		}
+		c.session = nil
+	}()
+
+	progress := util.NewProgress(util.ProgressWriter(c.quiet), "Generating exit operations", len(targets))
+	operations := make([]*phase0.SignedVoluntaryExit, len(targets))
+	errs := make([]error, len(targets))
+	group := errgroup.Group{}
+	group.SetLimit(c.workers())
+	for i := range targets {
+		i := i
+		group.Go(func() error {
+			operations[i], errs[i] = c.createSignedOperation(ctx, targets[i].validator, targets[i].account, epoch)
+			progress.Increment()
+			return nil
+		})
+	}
+	_ = group.Wait()
+	progress.Finish()
+
+	// Results are recorded in the order of the targets regardless of the order of completion.
+	for i, target := range targets {
+		index := target.validator.Index
+		entry := &manifestEntry{
+			Account:        target.name,
+			ValidatorIndex: &index,
+			PublicKey:      target.validator.Pubkey.String(),
+		}
+		if errs[i] != nil {
+			c.addFailure(entry, errs[i])
+			continue
+		}
+		c.signedOperations = append(c.signedOperations, operations[i])
+		c.manifest = append(c.manifest, entry)
+	}
+
+	return nil
+}
+
+// addFailure records a failure to generate an exit operation.
+func (c *command) addFailure(entry *manifestEntry, err error) {
+	entry.Error = err.Error()
+	c.manifest = append(c.manifest, entry)
+	if !c.quiet && c.outputDir == "" {
+		name := entry.Account
+		if name == "" {
+			name = entry.PublicKey
+		}
+		fmt.Fprintf(os.Stderr, "Failed to generate exit operation for %s: %v\n", name, err)
+	}
+}
+
+// failures returns the number of failures recorded in the manifest.
+func (c *command) failures() int {
+	failures := 0
+	for _, entry := range c.manifest {
+		if entry.Error != "" {
+			failures++
+		}
+	}
+
+	return failures
+}
+
+// writeOutputDir writes each operation to its own file in the output
+// directory, along with a manifest of the results.
+func (c *command) writeOutputDir(ctx context.Context) error {
+	if err := os.MkdirAll(c.outputDir, 0o700); err != nil {
+		return errors.Wrap(err, "failed to create output directory")
+	}
+
+	entries := make(map[phase0.ValidatorIndex]*manifestEntry, len(c.manifest))
+	for _, entry := range c.manifest {
+		if entry.Error == "" && entry.ValidatorIndex != nil {
+			entries[*entry.ValidatorIndex] = entry
+		}
+	}
+
+	for _, op := range c.signedOperations {
+		entry, exists := entries[op.Message.ValidatorIndex]
+		if !exists {
+			// Operation generated outside of a bulk run.
+			index := op.Message.ValidatorIndex
+			entry = &manifestEntry{
+				ValidatorIndex: &index,
+			}
+			if info, err := c.chainInfo.FetchValidatorInfo(ctx, fmt.Sprintf("%d", index)); err == nil {
+				entry.PublicKey = info.Pubkey.String()
+			}
+			c.manifest = append(c.manifest, entry)
+		}
+
+		data, err := json.Marshal(op)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal exit operation")
+		}
+		entry.File = fmt.Sprintf("exit-%d.json", op.Message.ValidatorIndex)
+		if err := os.WriteFile(filepath.Join(c.outputDir, entry.File), data, 0o600); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to write %s", entry.File))
+		}
+	}
+
+	data, err := json.MarshalIndent(c.manifest, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal manifest")
+	}
+	if err := os.WriteFile(filepath.Join(c.outputDir, manifestFilename), data, 0o600); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to write %s", manifestFilename))
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexit
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func blsPubKey(t *testing.T, input string) phase0.BLSPubKey {
+	t.Helper()
+
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	require.NoError(t, err)
+	res := phase0.BLSPubKey{}
+	copy(res[:], data)
+
+	return res
+}
+
+func TestWorkers(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  *command
+		expected int
+	}{
+		{
+			name:     "Unset",
+			command:  &command{},
+			expected: 1,
+		},
+		{
+			name: "Concurrency",
+			command: &command{
+				concurrency: 8,
+			},
+			expected: 8,
+		},
+		{
+			name: "RemoteLimited",
+			command: &command{
+				concurrency: 8,
+				remote:      true,
+			},
+			expected: remoteSignerConcurrency,
+		},
+		{
+			name: "RemoteBelowLimit",
+			command: &command{
+				concurrency: 2,
+				remote:      true,
+			},
+			expected: 2,
+		},
+		{
+			name: "ApprovalGate",
+			command: &command{
+				concurrency:  8,
+				approvalGate: &util.ApprovalGate{},
+			},
+			expected: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, test.command.workers())
+		})
+	}
+}
+
+func TestGenerateOperationsFromMnemonicConcurrent(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, e2types.InitBLS())
+
+	chainInfo := &beacon.ChainInfo{
+		Version: 1,
+		Validators: []*beacon.ValidatorInfo{
+			{
+				Index:  0,
+				Pubkey: blsPubKey(t, "0xb384f767d964e100c8a9b21018d08c25ffebae268b3ab6d610353897541971726dbfc3c7463884c68a531515aab94c87"),
+				State:  apiv1.ValidatorStateActiveOngoing,
+			},
+			{
+				Index:  1,
+				Pubkey: blsPubKey(t, "0xb3d89e2f29c712c6a9f8e5a269b97617c4a94dd6f6662ab3b07ce9e5434573f15b5c988cd14bbd5804f77156a8af1cfa"),
+				State:  apiv1.ValidatorStateActiveOngoing,
+			},
+			{
+				Index:  2,
+				Pubkey: blsPubKey(t, "0xaf9ce44f50148db412194af0baf0bab36bd5c3e0c4938911a4e502e398b59e5cca7c78e3fe034195478879eeb23db0a6"),
+				State:  apiv1.ValidatorStateExitedUnslashed,
+			},
+			{
+				Index:  3,
+				Pubkey: blsPubKey(t, "0x86d330af51fa593fa9f93edb9d16640186be2e93ea94d259781e1eb34deb844c3968d75ea91d19f159dbd0523c6c5ba5"),
+				State:  apiv1.ValidatorStateActiveOngoing,
+			},
+		},
+		Epoch: 1,
+	}
+
+	sequential := &command{
+		mnemonic:    "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+		maxDistance: 2,
+		concurrency: 1,
+		quiet:       true,
+		chainInfo:   chainInfo,
+	}
+	require.NoError(t, sequential.generateOperationsFromMnemonic(ctx))
+
+	concurrent := &command{
+		mnemonic:    "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+		maxDistance: 2,
+		concurrency: 4,
+		quiet:       true,
+		chainInfo:   chainInfo,
+	}
+	require.NoError(t, concurrent.generateOperationsFromMnemonic(ctx))
+
+	// Exited validator 2 is skipped.
+	require.Len(t, sequential.signedOperations, 3)
+	require.Equal(t, phase0.ValidatorIndex(0), sequential.signedOperations[0].Message.ValidatorIndex)
+	require.Equal(t, phase0.ValidatorIndex(1), sequential.signedOperations[1].Message.ValidatorIndex)
+	require.Equal(t, phase0.ValidatorIndex(3), sequential.signedOperations[2].Message.ValidatorIndex)
+	require.Equal(t, sequential.signedOperations, concurrent.signedOperations)
+	require.Len(t, concurrent.manifest, 3)
+	require.Equal(t, 0, concurrent.failures())
+	require.Equal(t, "m/12381/3600/3/0/0", concurrent.manifest[2].Account)
+}
+
+func TestWriteOutputDir(t *testing.T) {
+	ctx := context.Background()
+
+	index0 := phase0.ValidatorIndex(0)
+	chainInfo := &beacon.ChainInfo{
+		Version: 1,
+		Validators: []*beacon.ValidatorInfo{
+			{
+				Index:  0,
+				Pubkey: blsPubKey(t, "0xb384f767d964e100c8a9b21018d08c25ffebae268b3ab6d610353897541971726dbfc3c7463884c68a531515aab94c87"),
+			},
+			{
+				Index:  1,
+				Pubkey: blsPubKey(t, "0xb3d89e2f29c712c6a9f8e5a269b97617c4a94dd6f6662ab3b07ce9e5434573f15b5c988cd14bbd5804f77156a8af1cfa"),
+			},
+		},
+	}
+
+	outputDir := filepath.Join(t.TempDir(), "exits")
+	c := &command{
+		quiet:     true,
+		outputDir: outputDir,
+		chainInfo: chainInfo,
+		signedOperations: []*phase0.SignedVoluntaryExit{
+			{
+				Message: &phase0.VoluntaryExit{
+					Epoch:          1,
+					ValidatorIndex: 0,
+				},
+			},
+			{
+				Message: &phase0.VoluntaryExit{
+					Epoch:          1,
+					ValidatorIndex: 1,
+				},
+			},
+		},
+		manifest: []*manifestEntry{
+			{
+				Account:        "Validators/0",
+				ValidatorIndex: &index0,
+				PublicKey:      "0xb384f767d964e100c8a9b21018d08c25ffebae268b3ab6d610353897541971726dbfc3c7463884c68a531515aab94c87",
+			},
+			{
+				Account: "Validators/2",
+				Error:   "unknown validator",
+			},
+		},
+	}
+	require.NoError(t, c.writeOutputDir(ctx))
+
+	for _, filename := range []string{"exit-0.json", "exit-1.json"} {
+		data, err := os.ReadFile(filepath.Join(outputDir, filename))
+		require.NoError(t, err)
+		op := &phase0.SignedVoluntaryExit{}
+		require.NoError(t, json.Unmarshal(data, op))
+	}
+
+	data, err := os.ReadFile(filepath.Join(outputDir, manifestFilename))
+	require.NoError(t, err)
+	manifest := make([]*manifestEntry, 0)
+	require.NoError(t, json.Unmarshal(data, &manifest))
+	require.Len(t, manifest, 3)
+	require.Equal(t, "exit-0.json", manifest[0].File)
+	require.Equal(t, "Validators/0", manifest[0].Account)
+	require.Empty(t, manifest[1].File)
+	require.Equal(t, "unknown validator", manifest[1].Error)
+	require.Equal(t, "exit-1.json", manifest[2].File)
+	require.Equal(t, "0xb3d89e2f29c712c6a9f8e5a269b97617c4a94dd6f6662ab3b07ce9e5434573f15b5c988cd14bbd5804f77156a8af1cfa", manifest[2].PublicKey)
+
+	res, err := (&command{outputDir: outputDir, signedOperations: c.signedOperations, manifest: c.manifest}).output(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "Generated 2 exit operations in "+outputDir+"; 1 failed, see "+filepath.Join(outputDir, manifestFilename), res)
+}
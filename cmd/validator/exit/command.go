@@ -23,6 +23,7 @@ import (
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/beacon"
 	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/signing"
 	"github.com/wealdtech/ethdo/util"
 )
 
@@ -45,6 +46,11 @@ type command struct {
 	signedOperationsInput string
 	epoch                 string
 	maxDistance           uint64
+	approvalRequest       string
+	estimate              bool
+	concurrency           int
+	outputDir             string
+	remote                bool
 
 	// Beacon node connection.
 	timeout                  time.Duration
@@ -56,11 +62,16 @@ type command struct {
 	domain    phase0.Domain
 
 	// Processing.
+	approvalGate    *util.ApprovalGate
 	consensusClient consensusclient.Service
 	chainTime       chaintime.Service
+	session         *signing.Session
 
 	// Output.
+	approvalStatus   string
 	signedOperations []*phase0.SignedVoluntaryExit
+	estimateResults  *exitEstimate
+	manifest         []*manifestEntry
 }
 
 func newCommand(_ context.Context) (*command, error) {
@@ -84,6 +95,11 @@ func newCommand(_ context.Context) (*command, error) {
 		genesisValidatorsRoot:    viper.GetString("genesis-validators-root"),
 		epoch:                    viper.GetString("epoch"),
 		maxDistance:              viper.GetUint64("max-distance"),
+		approvalRequest:          viper.GetString("approval-request"),
+		estimate:                 viper.GetBool("estimate"),
+		concurrency:              viper.GetInt("concurrency"),
+		outputDir:                viper.GetString("output-dir"),
+		remote:                   viper.GetString("remote") != "",
 		signedOperations:         make([]*phase0.SignedVoluntaryExit, 0),
 	}
 
@@ -97,6 +113,24 @@ func newCommand(_ context.Context) (*command, error) {
 		return nil, errors.New("timeout is required")
 	}
 
+	if c.concurrency < 1 {
+		return nil, errors.New("concurrency must be at least 1")
+	}
+
+	if c.json && c.outputDir != "" {
+		return nil, errors.New("only one of json and output-dir can be selected")
+	}
+
+	// Estimates require a beacon node.
+	if c.estimate {
+		if c.offline {
+			return nil, errors.New("cannot estimate exit when offline")
+		}
+		if c.validator == "" {
+			return nil, errors.New("validator is required to estimate exit")
+		}
+	}
+
 	// We are generating information for offline use, we don't need any information
 	// related to the accounts or signing.
 	if c.prepareOffline {
@@ -0,0 +1,333 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// exitEstimate is the estimated progress of a validator through the exit
+// queue and on to its final withdrawal.
+type exitEstimate struct {
+	Validator         phase0.ValidatorIndex `json:"validator_index"`
+	Initiated         bool                  `json:"exit_initiated"`
+	Position          int                   `json:"position"`
+	QueueLength       int                   `json:"queue_length"`
+	ChurnLimit        uint64                `json:"churn_limit,omitempty"`
+	BalanceChurnLimit phase0.Gwei           `json:"balance_churn_limit,omitempty"`
+	ExitEpoch         phase0.Epoch          `json:"exit_epoch"`
+	ExitTime          time.Time             `json:"exit_time"`
+	WithdrawableEpoch phase0.Epoch          `json:"withdrawable_epoch"`
+	WithdrawableTime  time.Time             `json:"withdrawable_time"`
+	SweepSlot         *phase0.Slot          `json:"sweep_slot,omitempty"`
+	SweepTime         *time.Time            `json:"sweep_time,omitempty"`
+	SweepNote         string                `json:"sweep_note,omitempty"`
+}
+
+// estimateExit estimates the exit and withdrawal of the validator.
+func (c *command) estimateExit(ctx context.Context) error {
+	specResponse, err := c.consensusClient.(consensusclient.SpecProvider).Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	params, err := util.ObtainChurnParameters(specResponse.Data)
+	if err != nil {
+		return err
+	}
+	sweepParams := util.ObtainSweepParameters(specResponse.Data)
+	balanceParams, err := util.ObtainBalanceParameters(specResponse.Data)
+	if err != nil {
+		return err
+	}
+
+	validatorsProvider := c.consensusClient.(consensusclient.ValidatorsProvider)
+	validator, err := util.ParseValidator(ctx, validatorsProvider, c.validator, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to parse validator")
+	}
+
+	blockResponse, err := c.consensusClient.(consensusclient.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+		Block: "head",
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain head block")
+	}
+	headSlot, err := blockResponse.Data.Slot()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain head slot")
+	}
+
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Fetching all validators at slot %d\n", headSlot)
+	}
+	validatorsResponse, err := validatorsProvider.Validators(ctx, &api.ValidatorsOpts{
+		State: fmt.Sprintf("%d", headSlot),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+	validators := make([]*apiv1.Validator, len(validatorsResponse.Data))
+	for _, v := range validatorsResponse.Data {
+		if int(v.Index) >= len(validators) {
+			return errors.New("validator indices are not contiguous")
+		}
+		validators[v.Index] = v
+	}
+
+	currentEpoch := c.chainTime.SlotToEpoch(headSlot)
+	postElectra := currentEpoch >= c.chainTime.ElectraInitialEpoch()
+	var electra *util.StateStreamElectra
+	if postElectra && params.SupportsElectra() {
+		// The exit queue is tracked in the beacon state post-Electra.
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Fetching beacon state at slot %d\n", headSlot)
+		}
+		err := util.StreamBeaconState(ctx, c.consensusClient, fmt.Sprintf("%d", headSlot), &util.StateStreamHandler{
+			Electra: func(e *util.StateStreamElectra) error {
+				electra = e
+				return nil
+			},
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain state")
+		}
+	}
+	c.estimateResults = estimateExitQueue(validators, validator.Index, currentEpoch, params, electra)
+	c.estimateResults.ExitTime = c.chainTime.StartOfEpoch(c.estimateResults.ExitEpoch)
+	c.estimateResults.WithdrawableTime = c.chainTime.StartOfEpoch(c.estimateResults.WithdrawableEpoch)
+
+	switch {
+	case !util.HasExecutionWithdrawalCredentials(validator.Validator.WithdrawalCredentials):
+		c.estimateResults.SweepNote = "validator does not have execution withdrawal credentials, so will not be swept until its credentials are changed"
+	case currentEpoch < c.chainTime.CapellaInitialEpoch():
+		c.estimateResults.SweepNote = "withdrawals are not yet enabled on this chain"
+	default:
+		withdrawals, err := blockResponse.Data.Withdrawals()
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain withdrawals from block")
+		}
+		if len(withdrawals) == 0 {
+			c.estimateResults.SweepNote = "head block has no withdrawals; cannot obtain position of withdrawal sweep"
+			break
+		}
+		nextIndex := phase0.ValidatorIndex((int(withdrawals[len(withdrawals)-1].ValidatorIndex) + 1) % len(validators))
+		sweepSlot := estimateSweepSlot(validators,
+			nextIndex,
+			validator.Index,
+			headSlot,
+			c.chainTime.FirstSlotOfEpoch(c.estimateResults.WithdrawableEpoch),
+			currentEpoch,
+			sweepParams,
+			balanceParams,
+			postElectra,
+		)
+		sweepTime := c.chainTime.StartOfSlot(sweepSlot)
+		c.estimateResults.SweepSlot = &sweepSlot
+		c.estimateResults.SweepTime = &sweepTime
+	}
+
+	return nil
+}
+
+// estimateExitQueue estimates the position of the validator in the exit queue
+// and its exit and withdrawable epochs.  If the validator has not yet
+// initiated its exit the estimate is for an exit initiated now.  If the
+// Electra fields of the beacon state are supplied the exit churn is by
+// balance, otherwise it is by number of validators.
+func estimateExitQueue(validators []*apiv1.Validator,
+	index phase0.ValidatorIndex,
+	currentEpoch phase0.Epoch,
+	params *util.ChurnParameters,
+	electra *util.StateStreamElectra,
+) *exitEstimate {
+	estimate := &exitEstimate{
+		Validator: index,
+	}
+
+	activeValidators := uint64(0)
+	totalActiveBalance := phase0.Gwei(0)
+	exiting := make([]*apiv1.Validator, 0)
+	maxExitEpoch := phase0.Epoch(0)
+	for _, v := range validators {
+		if v.Validator.ActivationEpoch <= currentEpoch && currentEpoch < v.Validator.ExitEpoch {
+			activeValidators++
+			totalActiveBalance += v.Validator.EffectiveBalance
+		}
+		if v.Validator.ExitEpoch == util.FarFutureEpoch {
+			continue
+		}
+		if v.Validator.ExitEpoch > maxExitEpoch {
+			maxExitEpoch = v.Validator.ExitEpoch
+		}
+		if v.Validator.ExitEpoch > currentEpoch {
+			exiting = append(exiting, v)
+		}
+	}
+	sort.Slice(exiting, func(i int, j int) bool {
+		if exiting[i].Validator.ExitEpoch != exiting[j].Validator.ExitEpoch {
+			return exiting[i].Validator.ExitEpoch < exiting[j].Validator.ExitEpoch
+		}
+		return exiting[i].Index < exiting[j].Index
+	})
+	estimate.QueueLength = len(exiting)
+	if electra != nil {
+		estimate.BalanceChurnLimit = params.ActivationExitChurnLimit(totalActiveBalance)
+	} else {
+		estimate.ChurnLimit = params.ChurnLimit(activeValidators)
+	}
+
+	validator := validators[index].Validator
+	if validator.ExitEpoch != util.FarFutureEpoch {
+		// Exit has already been initiated.
+		estimate.Initiated = true
+		estimate.ExitEpoch = validator.ExitEpoch
+		estimate.WithdrawableEpoch = validator.WithdrawableEpoch
+		for i := range exiting {
+			if exiting[i].Index == index {
+				estimate.Position = i + 1
+				break
+			}
+		}
+
+		return estimate
+	}
+
+	// Exit would be placed at the back of the queue.
+	estimate.Position = len(exiting) + 1
+	if electra != nil {
+		estimate.ExitEpoch = electraExitEpoch(validator.EffectiveBalance,
+			currentEpoch,
+			electra.EarliestExitEpoch,
+			electra.ExitBalanceToConsume,
+			estimate.BalanceChurnLimit,
+			params,
+		)
+		estimate.WithdrawableEpoch = estimate.ExitEpoch + phase0.Epoch(params.MinValidatorWithdrawabilityDelay)
+
+		return estimate
+	}
+
+	exitQueueEpoch := max(maxExitEpoch, params.ActivationExitEpoch(currentEpoch))
+	exitQueueChurn := uint64(0)
+	for _, v := range exiting {
+		if v.Validator.ExitEpoch == exitQueueEpoch {
+			exitQueueChurn++
+		}
+	}
+	if exitQueueChurn >= estimate.ChurnLimit {
+		exitQueueEpoch++
+	}
+	estimate.ExitEpoch = exitQueueEpoch
+	estimate.WithdrawableEpoch = exitQueueEpoch + phase0.Epoch(params.MinValidatorWithdrawabilityDelay)
+
+	return estimate
+}
+
+// electraExitEpoch calculates the exit epoch for a validator exiting now
+// with the given effective balance post-Electra, following the beacon
+// chain's compute_exit_epoch_and_update_churn.
+func electraExitEpoch(exitBalance phase0.Gwei,
+	currentEpoch phase0.Epoch,
+	earliestExitEpoch phase0.Epoch,
+	exitBalanceToConsume phase0.Gwei,
+	churn phase0.Gwei,
+	params *util.ChurnParameters,
+) phase0.Epoch {
+	exitEpoch := max(earliestExitEpoch, params.ActivationExitEpoch(currentEpoch))
+	if earliestExitEpoch < exitEpoch {
+		// No exits have yet been scheduled for this epoch.
+		exitBalanceToConsume = churn
+	}
+	if exitBalance > exitBalanceToConsume && churn > 0 {
+		exitEpoch += phase0.Epoch((exitBalance-exitBalanceToConsume-1)/churn + 1)
+	}
+
+	return exitEpoch
+}
+
+// estimateSweepSlot estimates the slot at which the withdrawal sweep will
+// withdraw the validator's balance, being the first time that the sweep
+// reaches the validator after it becomes withdrawable.  It assumes that the
+// balances and withdrawal credentials of other validators remain unchanged,
+// and that there is a block in every slot.
+func estimateSweepSlot(validators []*apiv1.Validator,
+	nextIndex phase0.ValidatorIndex,
+	index phase0.ValidatorIndex,
+	headSlot phase0.Slot,
+	withdrawableSlot phase0.Slot,
+	currentEpoch phase0.Epoch,
+	params *util.SweepParameters,
+	balanceParams *util.BalanceParameters,
+	postElectra bool,
+) phase0.Slot {
+	// Work out how many withdrawals the sweep will make before it
+	// reaches the validator, and in total.
+	distance := (len(validators) + int(index) - int(nextIndex)) % len(validators)
+	withdrawalsBefore := uint64(0)
+	totalWithdrawals := uint64(0)
+	for i := range validators {
+		v := validators[(int(nextIndex)+i)%len(validators)]
+		if !hasWithdrawal(v, currentEpoch, balanceParams.MaxEffectiveBalanceFor(v.Validator.WithdrawalCredentials, postElectra)) {
+			continue
+		}
+		totalWithdrawals++
+		if i < distance {
+			withdrawalsBefore++
+		}
+	}
+
+	// Each payload is limited in both the number of withdrawals it can
+	// contain and the number of validators it can sweep.
+	slotsFor := func(withdrawals uint64, validators uint64) phase0.Slot {
+		return phase0.Slot(max(ceilDiv(withdrawals, params.MaxWithdrawalsPerPayload), ceilDiv(validators, params.MaxValidatorsPerWithdrawalsSweep)))
+	}
+	sweepSlot := headSlot + max(1, slotsFor(withdrawalsBefore+1, uint64(distance)+1))
+	cycleSlots := max(1, slotsFor(max(totalWithdrawals, 1), uint64(len(validators))))
+	if sweepSlot < withdrawableSlot {
+		cycles := (withdrawableSlot - sweepSlot + cycleSlots - 1) / cycleSlots
+		sweepSlot += cycles * cycleSlots
+	}
+
+	return sweepSlot
+}
+
+// hasWithdrawal returns true if the validator would have a withdrawal made
+// when reached by the withdrawal sweep.
+func hasWithdrawal(validator *apiv1.Validator, epoch phase0.Epoch, maxEffectiveBalance phase0.Gwei) bool {
+	if !util.HasExecutionWithdrawalCredentials(validator.Validator.WithdrawalCredentials) || validator.Balance == 0 {
+		return false
+	}
+	if validator.Validator.WithdrawableEpoch <= epoch {
+		// Full withdrawal.
+		return true
+	}
+
+	// Partial withdrawal.
+	return validator.Validator.EffectiveBalance == maxEffectiveBalance && validator.Balance > maxEffectiveBalance
+}
+
+func ceilDiv(numerator uint64, denominator uint64) uint64 {
+	return (numerator + denominator - 1) / denominator
+}
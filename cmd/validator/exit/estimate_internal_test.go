@@ -0,0 +1,361 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexit
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestEstimateExitQueue(t *testing.T) {
+	params := &util.ChurnParameters{
+		MinPerEpochChurnLimit:            2,
+		ChurnLimitQuotient:               65536,
+		MaxSeedLookahead:                 4,
+		MinValidatorWithdrawabilityDelay: 256,
+	}
+	validators := func(exitEpochs map[phase0.ValidatorIndex]phase0.Epoch) []*apiv1.Validator {
+		res := make([]*apiv1.Validator, 6)
+		for i := range res {
+			exitEpoch := util.FarFutureEpoch
+			withdrawableEpoch := util.FarFutureEpoch
+			if epoch, exists := exitEpochs[phase0.ValidatorIndex(i)]; exists {
+				exitEpoch = epoch
+				withdrawableEpoch = epoch + 256
+			}
+			res[i] = &apiv1.Validator{
+				Index: phase0.ValidatorIndex(i),
+				Validator: &phase0.Validator{
+					ActivationEpoch:   0,
+					ExitEpoch:         exitEpoch,
+					WithdrawableEpoch: withdrawableEpoch,
+				},
+			}
+		}
+		return res
+	}
+
+	tests := []struct {
+		name       string
+		validators []*apiv1.Validator
+		expected   *exitEstimate
+	}{
+		{
+			name:       "NotInitiated",
+			validators: validators(map[phase0.ValidatorIndex]phase0.Epoch{}),
+			expected: &exitEstimate{
+				Position:          1,
+				ChurnLimit:        2,
+				ExitEpoch:         105,
+				WithdrawableEpoch: 361,
+			},
+		},
+		{
+			name:       "QueueSpace",
+			validators: validators(map[phase0.ValidatorIndex]phase0.Epoch{1: 110}),
+			expected: &exitEstimate{
+				Position:          2,
+				QueueLength:       1,
+				ChurnLimit:        2,
+				ExitEpoch:         110,
+				WithdrawableEpoch: 366,
+			},
+		},
+		{
+			name:       "QueueFull",
+			validators: validators(map[phase0.ValidatorIndex]phase0.Epoch{1: 110, 2: 110}),
+			expected: &exitEstimate{
+				Position:          3,
+				QueueLength:       2,
+				ChurnLimit:        2,
+				ExitEpoch:         111,
+				WithdrawableEpoch: 367,
+			},
+		},
+		{
+			name:       "Initiated",
+			validators: validators(map[phase0.ValidatorIndex]phase0.Epoch{0: 110, 1: 108, 2: 110}),
+			expected: &exitEstimate{
+				Initiated:         true,
+				Position:          2,
+				QueueLength:       3,
+				ChurnLimit:        2,
+				ExitEpoch:         110,
+				WithdrawableEpoch: 366,
+			},
+		},
+		{
+			name:       "Exited",
+			validators: validators(map[phase0.ValidatorIndex]phase0.Epoch{0: 50, 1: 108}),
+			expected: &exitEstimate{
+				Initiated:         true,
+				Position:          0,
+				QueueLength:       1,
+				ChurnLimit:        2,
+				ExitEpoch:         50,
+				WithdrawableEpoch: 306,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, estimateExitQueue(test.validators, 0, 100, params, nil))
+		})
+	}
+}
+
+func TestEstimateExitQueueElectra(t *testing.T) {
+	params := &util.ChurnParameters{
+		MinPerEpochChurnLimit:               2,
+		ChurnLimitQuotient:                  65536,
+		MaxSeedLookahead:                    4,
+		MinValidatorWithdrawabilityDelay:    256,
+		MinPerEpochChurnLimitElectra:        128000000000,
+		MaxPerEpochActivationExitChurnLimit: 256000000000,
+		EffectiveBalanceIncrement:           1000000000,
+		MaxPendingDepositsPerEpoch:          16,
+	}
+	validators := []*apiv1.Validator{
+		{
+			Index: 0,
+			Validator: &phase0.Validator{
+				EffectiveBalance:  32000000000,
+				ExitEpoch:         util.FarFutureEpoch,
+				WithdrawableEpoch: util.FarFutureEpoch,
+			},
+		},
+		{
+			Index: 1,
+			Validator: &phase0.Validator{
+				EffectiveBalance:  32000000000,
+				ExitEpoch:         110,
+				WithdrawableEpoch: 366,
+			},
+		},
+	}
+
+	require.Equal(t, &exitEstimate{
+		Position:          2,
+		QueueLength:       1,
+		BalanceChurnLimit: 128000000000,
+		ExitEpoch:         111,
+		WithdrawableEpoch: 367,
+	}, estimateExitQueue(validators, 0, 100, params, &util.StateStreamElectra{
+		EarliestExitEpoch:    110,
+		ExitBalanceToConsume: 16000000000,
+	}))
+}
+
+func TestElectraExitEpoch(t *testing.T) {
+	params := &util.ChurnParameters{
+		MaxSeedLookahead: 4,
+	}
+
+	tests := []struct {
+		name                 string
+		exitBalance          phase0.Gwei
+		earliestExitEpoch    phase0.Epoch
+		exitBalanceToConsume phase0.Gwei
+		expected             phase0.Epoch
+	}{
+		{
+			name:              "EarliestExitEpochPassed",
+			exitBalance:       32000000000,
+			earliestExitEpoch: 50,
+			expected:          105,
+		},
+		{
+			name:                 "ChurnAvailable",
+			exitBalance:          32000000000,
+			earliestExitEpoch:    110,
+			exitBalanceToConsume: 64000000000,
+			expected:             110,
+		},
+		{
+			name:                 "ChurnExhausted",
+			exitBalance:          32000000000,
+			earliestExitEpoch:    110,
+			exitBalanceToConsume: 16000000000,
+			expected:             111,
+		},
+		{
+			name:                 "LargeBalance",
+			exitBalance:          2048000000000,
+			earliestExitEpoch:    105,
+			exitBalanceToConsume: 128000000000,
+			expected:             120,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, electraExitEpoch(test.exitBalance, 100, test.earliestExitEpoch, test.exitBalanceToConsume, 128000000000, params))
+		})
+	}
+}
+
+func TestEstimateSweepSlot(t *testing.T) {
+	params := &util.SweepParameters{
+		MaxWithdrawalsPerPayload:         2,
+		MaxValidatorsPerWithdrawalsSweep: 4,
+	}
+	balanceParams := &util.BalanceParameters{
+		MaxEffectiveBalance:        32000000000,
+		MinActivationBalance:       32000000000,
+		MaxEffectiveBalanceElectra: 2048000000000,
+	}
+	validators := func(balance phase0.Gwei) []*apiv1.Validator {
+		res := make([]*apiv1.Validator, 10)
+		for i := range res {
+			res[i] = &apiv1.Validator{
+				Index:   phase0.ValidatorIndex(i),
+				Balance: balance,
+				Validator: &phase0.Validator{
+					WithdrawalCredentials: []byte{util.ETH1AddressWithdrawalPrefix},
+					EffectiveBalance:      32000000000,
+					WithdrawableEpoch:     util.FarFutureEpoch,
+				},
+			}
+		}
+		return res
+	}
+
+	tests := []struct {
+		name             string
+		validators       []*apiv1.Validator
+		nextIndex        phase0.ValidatorIndex
+		index            phase0.ValidatorIndex
+		withdrawableSlot phase0.Slot
+		expected         phase0.Slot
+	}{
+		{
+			name:       "AlreadyWithdrawable",
+			validators: validators(33000000000),
+			nextIndex:  0,
+			index:      5,
+			expected:   103,
+		},
+		{
+			name:             "NextCycle",
+			validators:       validators(33000000000),
+			nextIndex:        0,
+			index:            5,
+			withdrawableSlot: 110,
+			expected:         113,
+		},
+		{
+			name:             "NoOtherWithdrawals",
+			validators:       validators(32000000000),
+			nextIndex:        0,
+			index:            5,
+			withdrawableSlot: 110,
+			expected:         111,
+		},
+		{
+			name: "Compounding",
+			validators: func() []*apiv1.Validator {
+				// Compounding validators at 32 Ether are below their maximum
+				// effective balance, so do not have partial withdrawals.
+				res := validators(33000000000)
+				for _, v := range res {
+					v.Validator.WithdrawalCredentials = []byte{util.CompoundingWithdrawalPrefix}
+				}
+				return res
+			}(),
+			nextIndex:        0,
+			index:            5,
+			withdrawableSlot: 110,
+			expected:         111,
+		},
+		{
+			name:       "Wrap",
+			validators: validators(33000000000),
+			nextIndex:  8,
+			index:      1,
+			expected:   102,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, estimateSweepSlot(test.validators, test.nextIndex, test.index, 100, test.withdrawableSlot, 10, params, balanceParams, true))
+		})
+	}
+}
+
+func TestHasWithdrawal(t *testing.T) {
+	validator := func(prefix byte, effectiveBalance phase0.Gwei, balance phase0.Gwei, withdrawableEpoch phase0.Epoch) *apiv1.Validator {
+		return &apiv1.Validator{
+			Balance: balance,
+			Validator: &phase0.Validator{
+				WithdrawalCredentials: []byte{prefix},
+				EffectiveBalance:      effectiveBalance,
+				WithdrawableEpoch:     withdrawableEpoch,
+			},
+		}
+	}
+
+	tests := []struct {
+		name                string
+		validator           *apiv1.Validator
+		maxEffectiveBalance phase0.Gwei
+		expected            bool
+	}{
+		{
+			name:                "BLS",
+			validator:           validator(util.BLSWithdrawalPrefix, 32000000000, 33000000000, util.FarFutureEpoch),
+			maxEffectiveBalance: 32000000000,
+		},
+		{
+			name:                "ETH1Partial",
+			validator:           validator(util.ETH1AddressWithdrawalPrefix, 32000000000, 33000000000, util.FarFutureEpoch),
+			maxEffectiveBalance: 32000000000,
+			expected:            true,
+		},
+		{
+			name:                "ETH1Full",
+			validator:           validator(util.ETH1AddressWithdrawalPrefix, 16000000000, 16000000000, 5),
+			maxEffectiveBalance: 32000000000,
+			expected:            true,
+		},
+		{
+			name:                "CompoundingBelowMax",
+			validator:           validator(util.CompoundingWithdrawalPrefix, 32000000000, 33000000000, util.FarFutureEpoch),
+			maxEffectiveBalance: 2048000000000,
+		},
+		{
+			name:                "CompoundingPartial",
+			validator:           validator(util.CompoundingWithdrawalPrefix, 2048000000000, 2049000000000, util.FarFutureEpoch),
+			maxEffectiveBalance: 2048000000000,
+			expected:            true,
+		},
+		{
+			name:                "CompoundingFull",
+			validator:           validator(util.CompoundingWithdrawalPrefix, 64000000000, 64000000000, 5),
+			maxEffectiveBalance: 2048000000000,
+			expected:            true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, hasWithdrawal(test.validator, 10, test.maxEffectiveBalance))
+		})
+	}
+}
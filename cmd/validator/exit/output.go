@@ -18,8 +18,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
+	string2eth "github.com/wealdtech/go-string2eth"
 )
 
 //nolint:unparam
@@ -28,10 +32,26 @@ func (c *command) output(_ context.Context) (string, error) {
 		return "", nil
 	}
 
+	if c.approvalStatus != "" {
+		return c.approvalStatus, nil
+	}
+
+	if c.estimateResults != nil {
+		return c.outputEstimate()
+	}
+
 	if c.prepareOffline {
 		return fmt.Sprintf("%s generated", offlinePreparationFilename), nil
 	}
 
+	if c.outputDir != "" {
+		res := fmt.Sprintf("Generated %d exit operations in %s", len(c.signedOperations), c.outputDir)
+		if failures := c.failures(); failures > 0 {
+			res = fmt.Sprintf("%s; %d failed, see %s", res, failures, filepath.Join(c.outputDir, manifestFilename))
+		}
+		return res, nil
+	}
+
 	if c.json || c.offline {
 		var data []byte
 		var err error
@@ -54,3 +74,47 @@ func (c *command) output(_ context.Context) (string, error) {
 
 	return "", nil
 }
+
+func (c *command) outputEstimate() (string, error) {
+	if c.json {
+		data, err := json.Marshal(c.estimateResults)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal estimate")
+		}
+
+		return string(data), nil
+	}
+
+	estimate := c.estimateResults
+	builder := strings.Builder{}
+	switch {
+	case estimate.Initiated && estimate.Position == 0:
+		builder.WriteString(fmt.Sprintf("Exited: epoch %d (%s)\n", estimate.ExitEpoch, estimate.ExitTime.Format("2006-01-02 15:04:05")))
+	case estimate.Initiated:
+		builder.WriteString(fmt.Sprintf("Exit queue position: %d of %d\n", estimate.Position, estimate.QueueLength))
+	default:
+		builder.WriteString(fmt.Sprintf("Exit not initiated; an exit initiated now would be at position %d\n", estimate.Position))
+	}
+	if c.verbose {
+		if estimate.BalanceChurnLimit > 0 {
+			builder.WriteString(fmt.Sprintf("Exit churn limit: %s per epoch\n", string2eth.GWeiToString(uint64(estimate.BalanceChurnLimit), true)))
+		} else {
+			builder.WriteString(fmt.Sprintf("Exit churn limit: %d per epoch\n", estimate.ChurnLimit))
+		}
+	}
+	if !estimate.Initiated || estimate.Position != 0 {
+		builder.WriteString(fmt.Sprintf("Exit epoch: %d (%s, in %s)\n", estimate.ExitEpoch, estimate.ExitTime.Format("2006-01-02 15:04:05"), time.Until(estimate.ExitTime).Round(time.Second)))
+	}
+	builder.WriteString(fmt.Sprintf("Withdrawable epoch: %d (%s", estimate.WithdrawableEpoch, estimate.WithdrawableTime.Format("2006-01-02 15:04:05")))
+	if time.Until(estimate.WithdrawableTime) > 0 {
+		builder.WriteString(fmt.Sprintf(", in %s", time.Until(estimate.WithdrawableTime).Round(time.Second)))
+	}
+	builder.WriteString(")\n")
+	if estimate.SweepSlot != nil {
+		builder.WriteString(fmt.Sprintf("Estimated final withdrawal: slot %d (%s, in %s)", *estimate.SweepSlot, estimate.SweepTime.Format("2006-01-02 15:04:05"), time.Until(*estimate.SweepTime).Round(time.Second)))
+	} else {
+		builder.WriteString(fmt.Sprintf("Final withdrawal cannot be estimated: %s", estimate.SweepNote))
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,99 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// preflightOperations checks the operations against the current state of
+// the chain before they are broadcast, to provide clear reasons for any
+// that the beacon node would reject.
+func (c *command) preflightOperations(ctx context.Context) error {
+	specProvider, isProvider := c.consensusClient.(consensusclient.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+	specResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	shardCommitteePeriod, isPeriod := specResponse.Data["SHARD_COMMITTEE_PERIOD"].(uint64)
+	if !isPeriod {
+		return errors.New("failed to obtain SHARD_COMMITTEE_PERIOD")
+	}
+
+	indices := make([]phase0.ValidatorIndex, 0, len(c.signedOperations))
+	for _, op := range c.signedOperations {
+		indices = append(indices, op.Message.ValidatorIndex)
+	}
+	validatorsProvider, isProvider := c.consensusClient.(consensusclient.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+	validators, err := util.FetchValidators(ctx, validatorsProvider, "head", indices, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+
+	currentEpoch := c.chainTime.CurrentEpoch()
+	reasons := make([]string, 0)
+	for _, op := range c.signedOperations {
+		reasons = append(reasons, preflightOperation(op, validators[op.Message.ValidatorIndex], currentEpoch, phase0.Epoch(shardCommitteePeriod))...)
+	}
+	if len(reasons) > 0 {
+		return fmt.Errorf("exit preflight checks failed:\n  - %s", strings.Join(reasons, "\n  - "))
+	}
+
+	return nil
+}
+
+// preflightOperation returns the reasons, if any, that the beacon node would reject the operation.
+func preflightOperation(op *phase0.SignedVoluntaryExit,
+	validator *apiv1.Validator,
+	currentEpoch phase0.Epoch,
+	shardCommitteePeriod phase0.Epoch,
+) []string {
+	index := op.Message.ValidatorIndex
+	if validator == nil || validator.Validator == nil {
+		return []string{fmt.Sprintf("validator %d is not known to the beacon node", index)}
+	}
+
+	reasons := make([]string, 0)
+	if op.Message.Epoch > currentEpoch {
+		reasons = append(reasons, fmt.Sprintf("validator %d exit epoch %d is after the current epoch %d", index, op.Message.Epoch, currentEpoch))
+	}
+	if validator.Validator.Slashed {
+		reasons = append(reasons, fmt.Sprintf("validator %d has been slashed", index))
+	}
+	if validator.Validator.ExitEpoch != util.FarFutureEpoch {
+		reasons = append(reasons, fmt.Sprintf("validator %d is already exiting, with exit epoch %d", index, validator.Validator.ExitEpoch))
+	}
+	if validator.Validator.ActivationEpoch > currentEpoch {
+		reasons = append(reasons, fmt.Sprintf("validator %d is not active (state %s)", index, validator.Status))
+	} else if currentEpoch < validator.Validator.ActivationEpoch+shardCommitteePeriod {
+		reasons = append(reasons, fmt.Sprintf("validator %d has not been active long enough to exit; it can exit from epoch %d", index, validator.Validator.ActivationEpoch+shardCommitteePeriod))
+	}
+
+	return reasons
+}
@@ -0,0 +1,103 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorexit
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestPreflightOperation(t *testing.T) {
+	op := func(epoch phase0.Epoch) *phase0.SignedVoluntaryExit {
+		return &phase0.SignedVoluntaryExit{
+			Message: &phase0.VoluntaryExit{
+				Epoch:          epoch,
+				ValidatorIndex: 12,
+			},
+		}
+	}
+	validator := func(status apiv1.ValidatorState, activationEpoch phase0.Epoch, exitEpoch phase0.Epoch, slashed bool) *apiv1.Validator {
+		return &apiv1.Validator{
+			Index:  12,
+			Status: status,
+			Validator: &phase0.Validator{
+				ActivationEpoch: activationEpoch,
+				ExitEpoch:       exitEpoch,
+				Slashed:         slashed,
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		op        *phase0.SignedVoluntaryExit
+		validator *apiv1.Validator
+		reasons   []string
+	}{
+		{
+			name:    "Unknown",
+			op:      op(1000),
+			reasons: []string{"validator 12 is not known to the beacon node"},
+		},
+		{
+			name:      "Good",
+			op:        op(1000),
+			validator: validator(apiv1.ValidatorStateActiveOngoing, 10, util.FarFutureEpoch, false),
+			reasons:   []string{},
+		},
+		{
+			name:      "FutureEpoch",
+			op:        op(1001),
+			validator: validator(apiv1.ValidatorStateActiveOngoing, 10, util.FarFutureEpoch, false),
+			reasons:   []string{"validator 12 exit epoch 1001 is after the current epoch 1000"},
+		},
+		{
+			name:      "Pending",
+			op:        op(1000),
+			validator: validator(apiv1.ValidatorStatePendingQueued, util.FarFutureEpoch, util.FarFutureEpoch, false),
+			reasons:   []string{"validator 12 is not active (state pending_queued)"},
+		},
+		{
+			name:      "TooNew",
+			op:        op(1000),
+			validator: validator(apiv1.ValidatorStateActiveOngoing, 900, util.FarFutureEpoch, false),
+			reasons:   []string{"validator 12 has not been active long enough to exit; it can exit from epoch 1156"},
+		},
+		{
+			name:      "Exiting",
+			op:        op(1000),
+			validator: validator(apiv1.ValidatorStateActiveExiting, 10, 1005, false),
+			reasons:   []string{"validator 12 is already exiting, with exit epoch 1005"},
+		},
+		{
+			name:      "SlashedAndExiting",
+			op:        op(1000),
+			validator: validator(apiv1.ValidatorStateActiveSlashed, 10, 1005, true),
+			reasons: []string{
+				"validator 12 has been slashed",
+				"validator 12 is already exiting, with exit epoch 1005",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.reasons, preflightOperation(test.op, test.validator, 1000, 256))
+		})
+	}
+}
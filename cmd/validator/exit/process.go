@@ -29,7 +29,6 @@ import (
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
-	"github.com/prysmaticlabs/go-ssz"
 	"github.com/wealdtech/ethdo/beacon"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
 	"github.com/wealdtech/ethdo/signing"
@@ -57,6 +56,10 @@ func (c *command) process(ctx context.Context) error {
 		return err
 	}
 
+	if c.estimate {
+		return c.estimateExit(ctx)
+	}
+
 	if err := c.obtainChainInfo(ctx); err != nil {
 		return err
 	}
@@ -69,10 +72,22 @@ func (c *command) process(ctx context.Context) error {
 		return err
 	}
 
+	var err error
+	c.approvalGate, err = util.NewApprovalGate(util.ApprovalOperationValidatorExit, c.approvalRequest)
+	if err != nil {
+		return err
+	}
+
 	if err := c.obtainOperations(ctx); err != nil {
 		return err
 	}
 
+	if c.approvalGate.Pending() {
+		// Operations cannot be signed until they have been approved.
+		c.approvalStatus, err = c.approvalGate.WritePending()
+		return err
+	}
+
 	if len(c.signedOperations) == 0 {
 		return errors.New("no suitable validators found; no operations generated")
 	}
@@ -81,6 +96,13 @@ func (c *command) process(ctx context.Context) error {
 		return fmt.Errorf("operations failed validation: %s", reason)
 	}
 
+	if c.outputDir != "" {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Writing exit operations to %s rather than broadcasting\n", c.outputDir)
+		}
+		return c.writeOutputDir(ctx)
+	}
+
 	if c.json || c.offline {
 		if c.debug {
 			fmt.Fprintf(os.Stderr, "Not broadcasting exit operations\n")
@@ -229,32 +251,32 @@ func (c *command) generateOperationsFromMnemonic(ctx context.Context) error {
 	}
 	// Start scanning the validator keys.
 	lastFoundIndex := 0
-	foundValidatorCount := 0
+	targets := make([]*exitTarget, 0)
 	for i := 0; ; i++ {
 		// If no validators have been found in the last maxDistance indices, stop scanning.
 		if i-lastFoundIndex > maxDistance {
 			// If no validators were found at all, return an error.
-			if foundValidatorCount == 0 {
+			if len(targets) == 0 {
 				return fmt.Errorf("failed to find validators using the provided mnemonic: searched %d indices without finding a validator", maxDistance)
 			}
 			break
 		}
 		validatorKeyPath := fmt.Sprintf("m/12381/3600/%d/0/0", i)
 
-		found, err := c.generateOperationFromSeedAndPath(ctx, validators, seed, validatorKeyPath)
+		target, err := c.targetFromSeedAndPath(ctx, validators, seed, validatorKeyPath)
 		if err != nil {
 			// We log errors but keep going.
 			if c.debug {
 				fmt.Fprintf(os.Stderr, "Failed to generate for path %s: %v\n", validatorKeyPath, err.Error())
 			}
 		}
-		if found {
+		if target != nil {
 			lastFoundIndex = i
-			foundValidatorCount++
+			targets = append(targets, target)
 		}
 	}
 
-	return nil
+	return c.generateOperationsForTargets(ctx, targets)
 }
 
 func (c *command) generateOperationFromPrivateKey(ctx context.Context) error {
@@ -267,6 +289,16 @@ func (c *command) generateOperationFromPrivateKey(ctx context.Context) error {
 }
 
 func (c *command) generateOperationFromValidator(ctx context.Context) error {
+	if strings.Contains(c.validator, "/") {
+		if _, err := os.Stat(c.validator); err != nil {
+			// An account specifier, which could match multiple accounts.
+			wallet, accounts, err := util.WalletAndAccountsFromPath(ctx, c.validator)
+			if err == nil && len(accounts) > 1 {
+				return c.generateOperationsFromAccounts(ctx, wallet.Name(), accounts)
+			}
+		}
+	}
+
 	validatorAccount, err := util.ParseAccount(ctx, c.validator, c.passphrases, true)
 	if err != nil {
 		return errors.Wrap(err, "failed to parse validator account")
@@ -333,25 +365,48 @@ func (c *command) generateOperationFromSeedAndPath(ctx context.Context,
 ) (
 	bool,
 	error,
+) {
+	target, err := c.targetFromSeedAndPath(ctx, validators, seed, path)
+	if err != nil {
+		return false, err
+	}
+	if target == nil {
+		return false, nil
+	}
+
+	if err := c.generateOperationFromAccount(ctx, target.account); err != nil {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "failed to generate operation at path %s: %v\n", path, err)
+		}
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// targetFromSeedAndPath returns the exit target for the validator at the
+// given path, or nil if there is no suitable validator.
+func (c *command) targetFromSeedAndPath(ctx context.Context,
+	validators map[string]*beacon.ValidatorInfo,
+	seed []byte,
+	path string,
+) (
+	*exitTarget,
+	error,
 ) {
 	validatorPrivkey, err := ethutil.PrivateKeyFromSeedAndPath(seed, path)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to generate validator private key")
+		return nil, errors.Wrap(err, "failed to generate validator private key")
 	}
 
 	privateKey := fmt.Sprintf("%#x", validatorPrivkey.Marshal())
 
 	validatorInfo, exists := validators[fmt.Sprintf("%#x", validatorPrivkey.PublicKey().Marshal())]
 	if !exists {
-		return false, errors.New("unknown validator")
+		return nil, errors.New("unknown validator")
 	}
-	if validatorInfo.State == apiv1.ValidatorStateActiveExiting ||
-		validatorInfo.State == apiv1.ValidatorStateActiveSlashed ||
-		validatorInfo.State == apiv1.ValidatorStateExitedUnslashed ||
-		validatorInfo.State == apiv1.ValidatorStateExitedSlashed ||
-		validatorInfo.State == apiv1.ValidatorStateWithdrawalPossible ||
-		validatorInfo.State == apiv1.ValidatorStateWithdrawalDone {
-		return false, fmt.Errorf("validator is in state %v, not suitable to generate an exit", validatorInfo.State)
+	if unsuitableState(validatorInfo.State) {
+		return nil, fmt.Errorf("validator is in state %v, not suitable to generate an exit", validatorInfo.State)
 	}
 
 	validatorAccount, err := util.ParseAccount(ctx, privateKey, nil, true)
@@ -359,20 +414,17 @@ func (c *command) generateOperationFromSeedAndPath(ctx context.Context,
 		if c.debug {
 			fmt.Fprintf(os.Stderr, "no validator found at path %s: %v\n", path, err)
 		}
-		return false, nil
+		return nil, nil
 	}
 	if validatorAccount == nil {
-		return false, nil
-	}
-
-	if err := c.generateOperationFromAccount(ctx, validatorAccount); err != nil {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "failed to generate operation at path %s: %v\n", path, err)
-		}
-		return false, nil
+		return nil, nil
 	}
 
-	return true, nil
+	return &exitTarget{
+		name:      path,
+		account:   validatorAccount,
+		validator: validatorInfo,
+	}, nil
 }
 
 func (c *command) generateOperationFromAccount(ctx context.Context,
@@ -407,6 +459,14 @@ func (c *command) generateOperationFromAccount(ctx context.Context,
 
 func (c *command) selectEpoch() (phase0.Epoch, error) {
 	if c.epoch == "" {
+		if request := c.approvalGate.Request(); request != nil && len(request.Operations) > 0 {
+			// Use the epoch of the operations awaiting approval, so that they match.
+			operation := &phase0.VoluntaryExit{}
+			if err := json.Unmarshal(request.Operations[0], operation); err != nil {
+				return 0, errors.Wrap(err, "invalid operation in approval request")
+			}
+			return operation.Epoch, nil
+		}
 		// No user-supplied epoch; use the one from chain info.
 		return c.chainInfo.Epoch, nil
 	}
@@ -451,11 +511,27 @@ func (c *command) createSignedOperation(ctx context.Context,
 		return nil, errors.Wrap(err, "failed to generate root for exit operation")
 	}
 
+	approved, err := c.approvalGate.Approved(c.domain, root, operation)
+	if err != nil {
+		return nil, err
+	}
+	if !approved {
+		// Operation is awaiting approval, so is not signed.
+		return &phase0.SignedVoluntaryExit{
+			Message: operation,
+		}, nil
+	}
+
 	// Sign the operation.
 	if c.debug {
 		fmt.Fprintf(os.Stderr, "Signing %#x with domain %#x by public key %#x\n", root, c.domain, account.PublicKey().Marshal())
 	}
-	signature, err := signing.SignRoot(ctx, account, nil, root, c.domain)
+	var signature phase0.BLSSignature
+	if c.session != nil {
+		signature, err = c.session.SignRoot(ctx, account, root, c.domain)
+	} else {
+		signature, err = signing.SignRoot(ctx, account, nil, root, c.domain)
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to sign exit operation")
 	}
@@ -495,7 +571,7 @@ func (c *command) verifySignedOperation(ctx context.Context, op *phase0.SignedVo
 		ObjectRoot: root,
 		Domain:     c.domain,
 	}
-	signingRoot, err := ssz.HashTreeRoot(container)
+	signingRoot, err := container.HashTreeRoot()
 	if err != nil {
 		return errors.Wrap(err, "failed to generate signing root")
 	}
@@ -564,6 +640,19 @@ func (c *command) validateOperation(_ context.Context,
 }
 
 func (c *command) broadcastOperations(ctx context.Context) error {
+	if err := util.CheckOperationsChain(ctx,
+		c.consensusClient,
+		c.obtainGenesisValidatorsRoot,
+		c.obtainExitForkVersion,
+		c.domain,
+		util.CheckVoluntaryExitDomain,
+	); err != nil {
+		return err
+	}
+	if err := c.preflightOperations(ctx); err != nil {
+		return err
+	}
+
 	for _, op := range c.signedOperations {
 		if c.debug {
 			data, err := json.Marshal(op)
@@ -0,0 +1,153 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorperformance
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Operation.
+	epoch      string
+	epochs     uint64
+	validators []string
+	jsonOutput bool
+	csvOutput  bool
+
+	// Data access.
+	eth2Client                 eth2client.Service
+	chainTime                  chaintime.Service
+	proposerDutiesProvider     eth2client.ProposerDutiesProvider
+	attesterDutiesProvider     eth2client.AttesterDutiesProvider
+	blocksProvider             eth2client.SignedBeaconBlockProvider
+	syncCommitteesProvider     eth2client.SyncCommitteesProvider
+	validatorsProvider         eth2client.ValidatorsProvider
+	beaconBlockHeadersProvider eth2client.BeaconBlockHeadersProvider
+
+	// Processing.
+	blocks      map[phase0.Slot]*spec.VersionedSignedBeaconBlock
+	performance map[phase0.ValidatorIndex]*validatorPerformance
+
+	// Results.
+	report *performanceReport
+}
+
+type performanceReport struct {
+	FirstEpoch phase0.Epoch            `json:"first_epoch"`
+	LastEpoch  phase0.Epoch            `json:"last_epoch"`
+	Validators []*validatorPerformance `json:"validators"`
+	Fleet      *validatorPerformance   `json:"fleet"`
+}
+
+type validatorPerformance struct {
+	Index         *phase0.ValidatorIndex    `json:"index,omitempty"`
+	PublicKey     *phase0.BLSPubKey         `json:"public_key,omitempty"`
+	Validators    int                       `json:"validators,omitempty"`
+	Attestations  *attestationPerformance   `json:"attestations"`
+	Proposals     *proposalPerformance      `json:"proposals"`
+	SyncCommittee *syncCommitteePerformance `json:"sync_committee"`
+}
+
+type attestationPerformance struct {
+	Expected              int     `json:"expected"`
+	Included              int     `json:"included"`
+	CorrectHead           int     `json:"correct_head"`
+	CorrectTarget         int     `json:"correct_target"`
+	TimelySource          int     `json:"timely_source"`
+	MeanInclusionDistance float64 `json:"mean_inclusion_distance"`
+	Effectiveness         float64 `json:"effectiveness"`
+
+	totalInclusionDistance int
+	totalEffectiveness     float64
+}
+
+type proposalPerformance struct {
+	Expected int `json:"expected"`
+	Included int `json:"included"`
+}
+
+type syncCommitteePerformance struct {
+	Expected int `json:"expected"`
+	Included int `json:"included"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:       viper.GetBool("quiet"),
+		verbose:     viper.GetBool("verbose"),
+		debug:       viper.GetBool("debug"),
+		blocks:      make(map[phase0.Slot]*spec.VersionedSignedBeaconBlock),
+		performance: make(map[phase0.ValidatorIndex]*validatorPerformance),
+		report:      &performanceReport{},
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.validators = viper.GetStringSlice("validators")
+	if len(c.validators) == 0 {
+		return nil, errors.New("validators are required")
+	}
+
+	c.epoch = viper.GetString("epoch")
+	if c.epoch == "" {
+		// Default to the most recent epoch for which all attestations can
+		// have been included.
+		c.epoch = "-2"
+	}
+	c.epochs = viper.GetUint64("epochs")
+	if c.epochs == 0 {
+		return nil, errors.New("epochs must be at least 1")
+	}
+
+	c.jsonOutput = viper.GetBool("json")
+	c.csvOutput = viper.GetBool("csv")
+	if c.jsonOutput && c.csvOutput {
+		return nil, errors.New("only one of json and csv output can be selected")
+	}
+
+	return c, nil
+}
+
+// newValidatorPerformance creates an empty performance record.
+func newValidatorPerformance() *validatorPerformance {
+	return &validatorPerformance{
+		Attestations:  &attestationPerformance{},
+		Proposals:     &proposalPerformance{},
+		SyncCommittee: &syncCommitteePerformance{},
+	}
+}
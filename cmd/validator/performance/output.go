@@ -0,0 +1,150 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorperformance
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	switch {
+	case c.jsonOutput:
+		return c.outputJSON(ctx)
+	case c.csvOutput:
+		return c.outputCSV(ctx)
+	default:
+		return c.outputTxt(ctx)
+	}
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.report)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputCSV(_ context.Context) (string, error) {
+	buf := &bytes.Buffer{}
+	writer := csv.NewWriter(buf)
+
+	records := [][]string{
+		{
+			"validator",
+			"public_key",
+			"attestations_expected",
+			"attestations_included",
+			"correct_head",
+			"correct_target",
+			"timely_source",
+			"mean_inclusion_distance",
+			"effectiveness",
+			"proposals_expected",
+			"proposals_included",
+			"sync_committee_expected",
+			"sync_committee_included",
+		},
+	}
+	for _, validator := range c.report.Validators {
+		records = append(records, csvRecord(fmt.Sprintf("%d", *validator.Index), fmt.Sprintf("%#x", *validator.PublicKey), validator))
+	}
+	records = append(records, csvRecord("fleet", "", c.report.Fleet))
+	if err := writer.WriteAll(records); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+func csvRecord(validator string, publicKey string, performance *validatorPerformance) []string {
+	return []string{
+		validator,
+		publicKey,
+		fmt.Sprintf("%d", performance.Attestations.Expected),
+		fmt.Sprintf("%d", performance.Attestations.Included),
+		fmt.Sprintf("%d", performance.Attestations.CorrectHead),
+		fmt.Sprintf("%d", performance.Attestations.CorrectTarget),
+		fmt.Sprintf("%d", performance.Attestations.TimelySource),
+		fmt.Sprintf("%.4f", performance.Attestations.MeanInclusionDistance),
+		fmt.Sprintf("%.4f", performance.Attestations.Effectiveness),
+		fmt.Sprintf("%d", performance.Proposals.Expected),
+		fmt.Sprintf("%d", performance.Proposals.Included),
+		fmt.Sprintf("%d", performance.SyncCommittee.Expected),
+		fmt.Sprintf("%d", performance.SyncCommittee.Included),
+	}
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.report.FirstEpoch == c.report.LastEpoch {
+		builder.WriteString(fmt.Sprintf("Epoch %d\n", c.report.FirstEpoch))
+	} else {
+		builder.WriteString(fmt.Sprintf("Epochs %d-%d\n", c.report.FirstEpoch, c.report.LastEpoch))
+	}
+	for _, validator := range c.report.Validators {
+		builder.WriteString(fmt.Sprintf("Validator %d:\n", *validator.Index))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("  Public key: %#x\n", *validator.PublicKey))
+		}
+		outputPerformanceTxt(&builder, validator)
+	}
+	if len(c.report.Validators) > 1 {
+		builder.WriteString(fmt.Sprintf("Fleet (%d validators):\n", c.report.Fleet.Validators))
+		outputPerformanceTxt(&builder, c.report.Fleet)
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func outputPerformanceTxt(builder *strings.Builder, performance *validatorPerformance) {
+	attestations := performance.Attestations
+	if attestations.Expected == 0 {
+		builder.WriteString("  Attestations: none expected\n")
+	} else {
+		builder.WriteString(fmt.Sprintf("  Attestations: %d/%d included (%s)\n", attestations.Included, attestations.Expected, percentage(attestations.Included, attestations.Expected)))
+		if attestations.Included > 0 {
+			builder.WriteString(fmt.Sprintf("    Correct head: %s\n", percentage(attestations.CorrectHead, attestations.Included)))
+			builder.WriteString(fmt.Sprintf("    Correct target: %s\n", percentage(attestations.CorrectTarget, attestations.Included)))
+			builder.WriteString(fmt.Sprintf("    Timely source: %s\n", percentage(attestations.TimelySource, attestations.Included)))
+			builder.WriteString(fmt.Sprintf("    Mean inclusion distance: %.2f\n", attestations.MeanInclusionDistance))
+		}
+		builder.WriteString(fmt.Sprintf("    Effectiveness: %.2f%%\n", attestations.Effectiveness*100))
+	}
+	if performance.Proposals.Expected > 0 {
+		builder.WriteString(fmt.Sprintf("  Proposals: %d/%d included (%s)\n", performance.Proposals.Included, performance.Proposals.Expected, percentage(performance.Proposals.Included, performance.Proposals.Expected)))
+	}
+	if performance.SyncCommittee.Expected > 0 {
+		builder.WriteString(fmt.Sprintf("  Sync committee: %d/%d included (%s)\n", performance.SyncCommittee.Included, performance.SyncCommittee.Expected, percentage(performance.SyncCommittee.Included, performance.SyncCommittee.Expected)))
+	}
+}
+
+func percentage(value int, total int) string {
+	if total == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.2f%%", 100*float64(value)/float64(total))
+}
@@ -0,0 +1,75 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorperformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	index := phase0.ValidatorIndex(12)
+	pubKey := phase0.BLSPubKey{0x01}
+	validator := newValidatorPerformance()
+	validator.Index = &index
+	validator.PublicKey = &pubKey
+	validator.Attestations.Expected = 2
+	validator.Attestations.record(1, 1, true, true)
+	validator.Attestations.record(2, 1, false, true)
+	validator.finalize()
+	report := &performanceReport{
+		FirstEpoch: 100,
+		LastEpoch:  101,
+		Validators: []*validatorPerformance{validator},
+		Fleet:      rollup([]*validatorPerformance{validator}),
+	}
+
+	tests := []struct {
+		name string
+		json bool
+		csv  bool
+		res  string
+	}{
+		{
+			name: "Text",
+			res:  "Epochs 100-101\nValidator 12:\n  Attestations: 2/2 included (100.00%)\n    Correct head: 50.00%\n    Correct target: 100.00%\n    Timely source: 100.00%\n    Mean inclusion distance: 1.50\n    Effectiveness: 75.00%",
+		},
+		{
+			name: "JSON",
+			json: true,
+			res:  `{"first_epoch":"100","last_epoch":"101","validators":[{"index":"12","public_key":"0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","attestations":{"expected":2,"included":2,"correct_head":1,"correct_target":2,"timely_source":2,"mean_inclusion_distance":1.5,"effectiveness":0.75},"proposals":{"expected":0,"included":0},"sync_committee":{"expected":0,"included":0}}],"fleet":{"validators":1,"attestations":{"expected":2,"included":2,"correct_head":1,"correct_target":2,"timely_source":2,"mean_inclusion_distance":1.5,"effectiveness":0.75},"proposals":{"expected":0,"included":0},"sync_committee":{"expected":0,"included":0}}}`,
+		},
+		{
+			name: "CSV",
+			csv:  true,
+			res:  "validator,public_key,attestations_expected,attestations_included,correct_head,correct_target,timely_source,mean_inclusion_distance,effectiveness,proposals_expected,proposals_included,sync_committee_expected,sync_committee_included\n12,0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000,2,2,1,2,2,1.5000,0.7500,0,0,0,0\nfleet,,2,2,1,2,2,1.5000,0.7500,0,0,0,0",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				jsonOutput: test.json,
+				csvOutput:  test.csv,
+				report:     report,
+			}
+			res, err := c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
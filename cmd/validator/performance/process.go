@@ -0,0 +1,416 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorperformance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	err := c.setup(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.report.LastEpoch, err = util.ParseEpoch(ctx, c.chainTime, c.epoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse epoch")
+	}
+	if uint64(c.report.LastEpoch)+1 < c.epochs {
+		return fmt.Errorf("cannot report on %d epochs ending at epoch %d", c.epochs, c.report.LastEpoch)
+	}
+	c.report.FirstEpoch = c.report.LastEpoch + 1 - phase0.Epoch(c.epochs)
+
+	validators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validators, fmt.Sprintf("%d", c.chainTime.FirstSlotOfEpoch(c.report.LastEpoch)))
+	if err != nil {
+		return errors.Wrap(err, "failed to parse validators")
+	}
+	if len(validators) == 0 {
+		return errors.New("no validators found")
+	}
+	for _, validator := range validators {
+		performance := newValidatorPerformance()
+		performance.Index = &validator.Index
+		performance.PublicKey = &validator.Validator.PublicKey
+		c.performance[validator.Index] = performance
+	}
+
+	if err := c.fetchBlocks(ctx); err != nil {
+		return err
+	}
+
+	dutiesBySlot := make(map[phase0.Slot]map[phase0.CommitteeIndex][]*apiv1.AttesterDuty)
+	for epoch := c.report.FirstEpoch; epoch <= c.report.LastEpoch; epoch++ {
+		activeIndices := make([]phase0.ValidatorIndex, 0, len(validators))
+		for _, validator := range validators {
+			if validator.Validator.ActivationEpoch <= epoch && validator.Validator.ExitEpoch > epoch {
+				activeIndices = append(activeIndices, validator.Index)
+			}
+		}
+		if len(activeIndices) == 0 {
+			continue
+		}
+
+		if err := c.processProposerDuties(ctx, epoch); err != nil {
+			return err
+		}
+		if err := c.processAttesterDuties(ctx, epoch, activeIndices, dutiesBySlot); err != nil {
+			return err
+		}
+		if err := c.processSyncCommitteeDuties(ctx, epoch); err != nil {
+			return err
+		}
+	}
+
+	if err := c.processAttestations(ctx, dutiesBySlot); err != nil {
+		return err
+	}
+
+	c.report.Validators = make([]*validatorPerformance, 0, len(c.performance))
+	for _, performance := range c.performance {
+		performance.finalize()
+		c.report.Validators = append(c.report.Validators, performance)
+	}
+	sort.Slice(c.report.Validators, func(i int, j int) bool {
+		return *c.report.Validators[i].Index < *c.report.Validators[j].Index
+	})
+	c.report.Fleet = rollup(c.report.Validators)
+
+	return nil
+}
+
+// fetchBlocks fetches the blocks that can contain information about the
+// validators' duties in the requested epochs.
+func (c *command) fetchBlocks(ctx context.Context) error {
+	// Attestations for the last epoch can be included up to the end of the
+	// following epoch.
+	firstSlot := c.chainTime.FirstSlotOfEpoch(c.report.FirstEpoch)
+	lastSlot := c.chainTime.LastSlotOfEpoch(c.report.LastEpoch + 1)
+	if lastSlot > c.chainTime.CurrentSlot() {
+		lastSlot = c.chainTime.CurrentSlot()
+	}
+
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		if c.debug {
+			fmt.Printf("Fetching block for slot %d\n", slot)
+		}
+		blockResponse, err := c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+			Block: fmt.Sprintf("%d", slot),
+		})
+		if err != nil {
+			var apiErr *api.Error
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				// No block for this slot, that's okay.
+				continue
+			}
+
+			return errors.Wrapf(err, "failed to obtain block for slot %d", slot)
+		}
+		if blockResponse.Data != nil {
+			c.blocks[slot] = blockResponse.Data
+		}
+	}
+
+	return nil
+}
+
+func (c *command) processProposerDuties(ctx context.Context, epoch phase0.Epoch) error {
+	response, err := c.proposerDutiesProvider.ProposerDuties(ctx, &api.ProposerDutiesOpts{
+		Epoch: epoch,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to obtain proposer duties for epoch %d", epoch)
+	}
+	for _, duty := range response.Data {
+		performance, exists := c.performance[duty.ValidatorIndex]
+		if !exists {
+			continue
+		}
+		performance.Proposals.Expected++
+		block, exists := c.blocks[duty.Slot]
+		if !exists {
+			continue
+		}
+		proposerIndex, err := block.ProposerIndex()
+		if err != nil {
+			return errors.Wrapf(err, "failed to obtain proposer for slot %d", duty.Slot)
+		}
+		if proposerIndex == duty.ValidatorIndex {
+			performance.Proposals.Included++
+		}
+	}
+
+	return nil
+}
+
+func (c *command) processAttesterDuties(ctx context.Context,
+	epoch phase0.Epoch,
+	activeIndices []phase0.ValidatorIndex,
+	dutiesBySlot map[phase0.Slot]map[phase0.CommitteeIndex][]*apiv1.AttesterDuty,
+) error {
+	response, err := c.attesterDutiesProvider.AttesterDuties(ctx, &api.AttesterDutiesOpts{
+		Epoch:   epoch,
+		Indices: activeIndices,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to obtain attester duties for epoch %d", epoch)
+	}
+	for _, duty := range response.Data {
+		performance, exists := c.performance[duty.ValidatorIndex]
+		if !exists {
+			continue
+		}
+		performance.Attestations.Expected++
+		if _, exists := dutiesBySlot[duty.Slot]; !exists {
+			dutiesBySlot[duty.Slot] = make(map[phase0.CommitteeIndex][]*apiv1.AttesterDuty)
+		}
+		dutiesBySlot[duty.Slot][duty.CommitteeIndex] = append(dutiesBySlot[duty.Slot][duty.CommitteeIndex], duty)
+	}
+
+	return nil
+}
+
+// processAttestations hunts through the blocks for the validators' attestations.
+func (c *command) processAttestations(ctx context.Context,
+	dutiesBySlot map[phase0.Slot]map[phase0.CommitteeIndex][]*apiv1.AttesterDuty,
+) error {
+	headersCache := util.NewBeaconBlockHeaderCache(c.beaconBlockHeadersProvider)
+
+	slots := make([]phase0.Slot, 0, len(c.blocks))
+	for slot := range c.blocks {
+		slots = append(slots, slot)
+	}
+	sort.Slice(slots, func(i int, j int) bool {
+		return slots[i] < slots[j]
+	})
+
+	included := make(map[*apiv1.AttesterDuty]struct{})
+	for _, slot := range slots {
+		attestations, err := c.blocks[slot].Attestations()
+		if err != nil {
+			return errors.Wrapf(err, "failed to obtain attestations for slot %d", slot)
+		}
+		for _, attestation := range attestations {
+			duties, exists := dutiesBySlot[attestation.Data.Slot][attestation.Data.Index]
+			if !exists {
+				continue
+			}
+			for _, duty := range duties {
+				if !attestation.AggregationBits.BitAt(duty.ValidatorCommitteeIndex) {
+					continue
+				}
+				if _, exists := included[duty]; exists {
+					// Duplicate; ignore.
+					continue
+				}
+				included[duty] = struct{}{}
+
+				headCorrect, err := util.AttestationHeadCorrect(ctx, headersCache, attestation)
+				if err != nil {
+					return errors.Wrap(err, "failed to calculate if attestation had correct head vote")
+				}
+				targetCorrect, err := util.AttestationTargetCorrect(ctx, headersCache, c.chainTime, attestation)
+				if err != nil {
+					return errors.Wrap(err, "failed to calculate if attestation had correct target vote")
+				}
+				c.performance[duty.ValidatorIndex].Attestations.record(slot-duty.Slot,
+					c.optimalInclusionDistance(duty.Slot),
+					headCorrect,
+					targetCorrect,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// optimalInclusionDistance returns the smallest inclusion distance possible
+// for an attestation at the given slot, given the blocks on the chain.
+func (c *command) optimalInclusionDistance(slot phase0.Slot) phase0.Slot {
+	for distance := phase0.Slot(1); distance < phase0.Slot(c.chainTime.SlotsPerEpoch()); distance++ {
+		if _, exists := c.blocks[slot+distance]; exists {
+			return distance
+		}
+	}
+
+	return 1
+}
+
+func (c *command) processSyncCommitteeDuties(ctx context.Context, epoch phase0.Epoch) error {
+	if epoch < c.chainTime.AltairInitialEpoch() {
+		// The epoch is pre-Altair.  No info but no error.
+		return nil
+	}
+
+	firstSlot := c.chainTime.FirstSlotOfEpoch(epoch)
+	committeeResponse, err := c.syncCommitteesProvider.SyncCommittee(ctx, &api.SyncCommitteeOpts{
+		State: fmt.Sprintf("%d", firstSlot),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to obtain sync committee for epoch %d", epoch)
+	}
+	committee := committeeResponse.Data.Validators
+
+	positions := make(map[int]*validatorPerformance)
+	for i, index := range committee {
+		if performance, exists := c.performance[index]; exists {
+			positions[i] = performance
+		}
+	}
+	if len(positions) == 0 {
+		return nil
+	}
+
+	for slot := firstSlot; slot <= c.chainTime.LastSlotOfEpoch(epoch); slot++ {
+		block, exists := c.blocks[slot]
+		if !exists || block.Version == spec.DataVersionPhase0 {
+			// If the block is missed we don't count the sync aggregate miss.
+			continue
+		}
+		aggregate, err := block.SyncAggregate()
+		if err != nil {
+			return errors.Wrapf(err, "failed to obtain sync aggregate for slot %d", slot)
+		}
+		for position, performance := range positions {
+			performance.SyncCommittee.Expected++
+			if aggregate.SyncCommitteeBits.BitAt(uint64(position)) {
+				performance.SyncCommittee.Included++
+			}
+		}
+	}
+
+	return nil
+}
+
+// record records an included attestation.
+func (a *attestationPerformance) record(inclusionDistance phase0.Slot,
+	optimalInclusionDistance phase0.Slot,
+	headCorrect bool,
+	targetCorrect bool,
+) {
+	a.Included++
+	a.totalInclusionDistance += int(inclusionDistance)
+	if inclusionDistance < optimalInclusionDistance {
+		// Can happen if the optimal block is not canonical.
+		optimalInclusionDistance = inclusionDistance
+	}
+	a.totalEffectiveness += float64(optimalInclusionDistance) / float64(inclusionDistance)
+	if headCorrect {
+		a.CorrectHead++
+	}
+	if targetCorrect {
+		a.CorrectTarget++
+	}
+	if inclusionDistance <= 5 {
+		a.TimelySource++
+	}
+}
+
+// finalize calculates the derived values for the performance.
+func (p *validatorPerformance) finalize() {
+	if p.Attestations.Included > 0 {
+		p.Attestations.MeanInclusionDistance = float64(p.Attestations.totalInclusionDistance) / float64(p.Attestations.Included)
+	}
+	if p.Attestations.Expected > 0 {
+		// Missed attestations have an effectiveness of 0.
+		p.Attestations.Effectiveness = p.Attestations.totalEffectiveness / float64(p.Attestations.Expected)
+	}
+}
+
+// rollup combines the performance of multiple validators.
+func rollup(validators []*validatorPerformance) *validatorPerformance {
+	res := newValidatorPerformance()
+	res.Validators = len(validators)
+	for _, validator := range validators {
+		res.Attestations.Expected += validator.Attestations.Expected
+		res.Attestations.Included += validator.Attestations.Included
+		res.Attestations.CorrectHead += validator.Attestations.CorrectHead
+		res.Attestations.CorrectTarget += validator.Attestations.CorrectTarget
+		res.Attestations.TimelySource += validator.Attestations.TimelySource
+		res.Attestations.totalInclusionDistance += validator.Attestations.totalInclusionDistance
+		res.Attestations.totalEffectiveness += validator.Attestations.totalEffectiveness
+		res.Proposals.Expected += validator.Proposals.Expected
+		res.Proposals.Included += validator.Proposals.Included
+		res.SyncCommittee.Expected += validator.SyncCommittee.Expected
+		res.SyncCommittee.Included += validator.SyncCommittee.Included
+	}
+	res.finalize()
+
+	return res
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.proposerDutiesProvider, isProvider = c.eth2Client.(eth2client.ProposerDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide proposer duties")
+	}
+	c.attesterDutiesProvider, isProvider = c.eth2Client.(eth2client.AttesterDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide attester duties")
+	}
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+	c.syncCommitteesProvider, isProvider = c.eth2Client.(eth2client.SyncCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide sync committee duties")
+	}
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validators")
+	}
+	c.beaconBlockHeadersProvider, isProvider = c.eth2Client.(eth2client.BeaconBlockHeadersProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon block headers")
+	}
+
+	return nil
+}
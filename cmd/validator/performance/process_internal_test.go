@@ -0,0 +1,77 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorperformance
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestationPerformance(t *testing.T) {
+	performance := newValidatorPerformance()
+	performance.Attestations.Expected = 4
+	// Optimal inclusion.
+	performance.Attestations.record(1, 1, true, true)
+	// Late inclusion, after a missed slot.
+	performance.Attestations.record(2, 2, true, true)
+	// Late inclusion, incorrect head.
+	performance.Attestations.record(4, 1, false, true)
+	performance.finalize()
+
+	require.Equal(t, 3, performance.Attestations.Included)
+	require.Equal(t, 2, performance.Attestations.CorrectHead)
+	require.Equal(t, 3, performance.Attestations.CorrectTarget)
+	require.Equal(t, 3, performance.Attestations.TimelySource)
+	require.InDelta(t, 7.0/3.0, performance.Attestations.MeanInclusionDistance, 0.0001)
+	// (1 + 1 + 0.25 + 0) / 4.
+	require.InDelta(t, 0.5625, performance.Attestations.Effectiveness, 0.0001)
+}
+
+func TestRollup(t *testing.T) {
+	index1 := phase0.ValidatorIndex(1)
+	validator1 := newValidatorPerformance()
+	validator1.Index = &index1
+	validator1.Attestations.Expected = 2
+	validator1.Attestations.record(1, 1, true, true)
+	validator1.Attestations.record(1, 1, true, true)
+	validator1.Proposals.Expected = 1
+	validator1.Proposals.Included = 1
+	validator1.finalize()
+
+	index2 := phase0.ValidatorIndex(2)
+	validator2 := newValidatorPerformance()
+	validator2.Index = &index2
+	validator2.Attestations.Expected = 2
+	validator2.Attestations.record(3, 1, false, false)
+	validator2.SyncCommittee.Expected = 32
+	validator2.SyncCommittee.Included = 30
+	validator2.finalize()
+
+	fleet := rollup([]*validatorPerformance{validator1, validator2})
+	require.Nil(t, fleet.Index)
+	require.Equal(t, 2, fleet.Validators)
+	require.Equal(t, 4, fleet.Attestations.Expected)
+	require.Equal(t, 3, fleet.Attestations.Included)
+	require.Equal(t, 2, fleet.Attestations.CorrectHead)
+	require.Equal(t, 2, fleet.Attestations.CorrectTarget)
+	require.Equal(t, 3, fleet.Attestations.TimelySource)
+	require.InDelta(t, 5.0/3.0, fleet.Attestations.MeanInclusionDistance, 0.0001)
+	require.InDelta(t, (2+1.0/3.0)/4, fleet.Attestations.Effectiveness, 0.0001)
+	require.Equal(t, 1, fleet.Proposals.Expected)
+	require.Equal(t, 1, fleet.Proposals.Included)
+	require.Equal(t, 32, fleet.SyncCommittee.Expected)
+	require.Equal(t, 30, fleet.SyncCommittee.Included)
+}
@@ -0,0 +1,100 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorproposals
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Operation.
+	validator  string
+	fromEpoch  string
+	toEpoch    string
+	jsonOutput bool
+	csvOutput  bool
+
+	// Data access.
+	eth2Client             eth2client.Service
+	chainTime              chaintime.Service
+	validatorsProvider     eth2client.ValidatorsProvider
+	proposerDutiesProvider eth2client.ProposerDutiesProvider
+	blockHeadersProvider   eth2client.BeaconBlockHeadersProvider
+	blocksProvider         eth2client.SignedBeaconBlockProvider
+
+	// Results.
+	index     phase0.ValidatorIndex
+	proposals []*proposal
+}
+
+type proposal struct {
+	Slot     phase0.Slot  `json:"slot"`
+	Epoch    phase0.Epoch `json:"epoch"`
+	Proposed bool         `json:"proposed"`
+	Root     *phase0.Root `json:"root,omitempty"`
+	Graffiti string       `json:"graffiti,omitempty"`
+	Reward   *phase0.Gwei `json:"reward,omitempty"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.validator = viper.GetString("validator")
+	if c.validator == "" {
+		return nil, errors.New("validator is required")
+	}
+
+	c.fromEpoch = viper.GetString("from-epoch")
+	if c.fromEpoch == "" {
+		return nil, errors.New("from epoch is required")
+	}
+	c.toEpoch = viper.GetString("to-epoch")
+
+	c.jsonOutput = viper.GetBool("json")
+	c.csvOutput = viper.GetBool("csv")
+	if c.jsonOutput && c.csvOutput {
+		return nil, errors.New("only one of json and csv output can be selected")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,111 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorproposals
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	switch {
+	case c.jsonOutput:
+		return c.outputJSON(ctx)
+	case c.csvOutput:
+		return c.outputCSV(ctx)
+	default:
+		return c.outputTxt(ctx)
+	}
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	proposals := c.proposals
+	if proposals == nil {
+		proposals = make([]*proposal, 0)
+	}
+	data, err := json.Marshal(proposals)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputCSV(_ context.Context) (string, error) {
+	buf := &bytes.Buffer{}
+	writer := csv.NewWriter(buf)
+
+	records := [][]string{
+		{"slot", "epoch", "proposed", "root", "graffiti", "reward"},
+	}
+	for _, proposal := range c.proposals {
+		record := []string{
+			fmt.Sprintf("%d", proposal.Slot),
+			fmt.Sprintf("%d", proposal.Epoch),
+			fmt.Sprintf("%t", proposal.Proposed),
+			"",
+			proposal.Graffiti,
+			"",
+		}
+		if proposal.Root != nil {
+			record[3] = fmt.Sprintf("%#x", *proposal.Root)
+		}
+		if proposal.Reward != nil {
+			record[5] = fmt.Sprintf("%d", *proposal.Reward)
+		}
+		records = append(records, record)
+	}
+	if err := writer.WriteAll(records); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	proposed := 0
+	for _, proposal := range c.proposals {
+		if !proposal.Proposed {
+			builder.WriteString(fmt.Sprintf("Slot %d (epoch %d): missed\n", proposal.Slot, proposal.Epoch))
+			continue
+		}
+		proposed++
+		builder.WriteString(fmt.Sprintf("Slot %d (epoch %d): proposed", proposal.Slot, proposal.Epoch))
+		if c.verbose && proposal.Root != nil {
+			builder.WriteString(fmt.Sprintf(" %#x", *proposal.Root))
+		}
+		if proposal.Reward != nil {
+			builder.WriteString(fmt.Sprintf(", reward %s", string2eth.GWeiToString(uint64(*proposal.Reward), true)))
+		}
+		if proposal.Graffiti != "" {
+			builder.WriteString(fmt.Sprintf(", graffiti %q", proposal.Graffiti))
+		}
+		builder.WriteString("\n")
+	}
+	builder.WriteString(fmt.Sprintf("Validator %d proposed %d of %d blocks", c.index, proposed, len(c.proposals)))
+
+	return builder.String(), nil
+}
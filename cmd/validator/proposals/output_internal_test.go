@@ -0,0 +1,84 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorproposals
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	root := phase0.Root{0x01}
+	reward := phase0.Gwei(40000000)
+	proposals := []*proposal{
+		{
+			Slot:     3201,
+			Epoch:    100,
+			Proposed: true,
+			Root:     &root,
+			Graffiti: "ethdo",
+			Reward:   &reward,
+		},
+		{
+			Slot:  3250,
+			Epoch: 101,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		json    bool
+		csv     bool
+		verbose bool
+		res     string
+	}{
+		{
+			name: "Text",
+			res:  "Slot 3201 (epoch 100): proposed, reward 0.04 Ether, graffiti \"ethdo\"\nSlot 3250 (epoch 101): missed\nValidator 12 proposed 1 of 2 blocks",
+		},
+		{
+			name:    "TextVerbose",
+			verbose: true,
+			res:     "Slot 3201 (epoch 100): proposed 0x0100000000000000000000000000000000000000000000000000000000000000, reward 0.04 Ether, graffiti \"ethdo\"\nSlot 3250 (epoch 101): missed\nValidator 12 proposed 1 of 2 blocks",
+		},
+		{
+			name: "JSON",
+			json: true,
+			res:  `[{"slot":"3201","epoch":"100","proposed":true,"root":"0x0100000000000000000000000000000000000000000000000000000000000000","graffiti":"ethdo","reward":"40000000"},{"slot":"3250","epoch":"101","proposed":false}]`,
+		},
+		{
+			name: "CSV",
+			csv:  true,
+			res:  "slot,epoch,proposed,root,graffiti,reward\n3201,100,true,0x0100000000000000000000000000000000000000000000000000000000000000,ethdo,40000000\n3250,101,false,,,",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				verbose:    test.verbose,
+				jsonOutput: test.json,
+				csvOutput:  test.csv,
+				index:      12,
+				proposals:  proposals,
+			}
+			res, err := c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
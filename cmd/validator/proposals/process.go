@@ -0,0 +1,194 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorproposals
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"unicode/utf8"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	err := c.setup(ctx)
+	if err != nil {
+		return err
+	}
+
+	fromEpoch, err := util.ParseEpoch(ctx, c.chainTime, c.fromEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse from epoch")
+	}
+	toEpoch, err := util.ParseEpoch(ctx, c.chainTime, c.toEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse to epoch")
+	}
+	if fromEpoch > toEpoch {
+		return fmt.Errorf("from epoch %d is after to epoch %d", fromEpoch, toEpoch)
+	}
+
+	validator, err := util.ParseValidator(ctx, c.validatorsProvider, c.validator, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to parse validator")
+	}
+	c.index = validator.Index
+
+	currentSlot := c.chainTime.CurrentSlot()
+	for epoch := fromEpoch; epoch <= toEpoch; epoch++ {
+		if c.debug {
+			fmt.Printf("Fetching proposer duties for epoch %d\n", epoch)
+		}
+		response, err := c.proposerDutiesProvider.ProposerDuties(ctx, &api.ProposerDutiesOpts{
+			Epoch:   epoch,
+			Indices: []phase0.ValidatorIndex{c.index},
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to obtain proposer duties for epoch %d", epoch)
+		}
+		for _, duty := range response.Data {
+			if duty.ValidatorIndex != c.index || duty.Slot > currentSlot {
+				continue
+			}
+			proposal, err := c.obtainProposal(ctx, duty.Slot)
+			if err != nil {
+				return err
+			}
+			c.proposals = append(c.proposals, proposal)
+		}
+	}
+
+	return nil
+}
+
+// obtainProposal obtains the details of the validator's proposal at the given slot.
+func (c *command) obtainProposal(ctx context.Context, slot phase0.Slot) (*proposal, error) {
+	res := &proposal{
+		Slot:  slot,
+		Epoch: c.chainTime.SlotToEpoch(slot),
+	}
+
+	if c.debug {
+		fmt.Printf("Fetching block header for slot %d\n", slot)
+	}
+	headerResponse, err := c.blockHeadersProvider.BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{
+		Block: fmt.Sprintf("%d", slot),
+	})
+	if err != nil {
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			// No block for this slot, so the proposal was missed.
+			return res, nil
+		}
+
+		return nil, errors.Wrapf(err, "failed to obtain block header for slot %d", slot)
+	}
+	header := headerResponse.Data
+	if header == nil || header.Header == nil || header.Header.Message == nil ||
+		header.Header.Message.Slot != slot || header.Header.Message.ProposerIndex != c.index {
+		return res, nil
+	}
+	res.Proposed = true
+	root := header.Root
+	res.Root = &root
+
+	blockResponse, err := c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+		Block: fmt.Sprintf("%#x", root),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to obtain block for slot %d", slot)
+	}
+	graffiti, err := blockResponse.Data.Graffiti()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to obtain graffiti for slot %d", slot)
+	}
+	res.Graffiti = graffitiString(graffiti)
+
+	// Rewards are not available from all beacon nodes, or for all blocks.
+	rewards, err := util.FetchBlockRewards(ctx, c.eth2Client, fmt.Sprintf("%#x", root))
+	if err != nil {
+		if c.debug {
+			fmt.Printf("Failed to obtain rewards for slot %d: %v\n", slot, err)
+		}
+	} else {
+		res.Reward = &rewards.Total
+	}
+
+	return res, nil
+}
+
+// graffitiString returns the graffiti as a string if it is valid UTF-8,
+// otherwise as hex.
+func graffitiString(graffiti [32]byte) string {
+	data := bytes.TrimRight(graffiti[:], "\u0000")
+	if len(data) == 0 {
+		return ""
+	}
+	if !utf8.Valid(data) {
+		return fmt.Sprintf("%#x", data)
+	}
+
+	return string(data)
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validators")
+	}
+	c.proposerDutiesProvider, isProvider = c.eth2Client.(eth2client.ProposerDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide proposer duties")
+	}
+	c.blockHeadersProvider, isProvider = c.eth2Client.(eth2client.BeaconBlockHeadersProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon block headers")
+	}
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorproposals
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraffitiString(t *testing.T) {
+	tests := []struct {
+		name     string
+		graffiti [32]byte
+		res      string
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name:     "Text",
+			graffiti: [32]byte{'e', 't', 'h', 'd', 'o'},
+			res:      "ethdo",
+		},
+		{
+			name:     "Binary",
+			graffiti: [32]byte{0xff, 0xfe},
+			res:      "0xfffe",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, graffitiString(test.graffiti))
+		})
+	}
+}
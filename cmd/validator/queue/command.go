@@ -0,0 +1,91 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorqueue
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	validator string
+
+	// Data access.
+	eth2Client         eth2client.Service
+	chainTime          chaintime.Service
+	validatorsProvider eth2client.ValidatorsProvider
+
+	// Output.
+	results *results
+}
+
+type results struct {
+	Validator         phase0.ValidatorIndex `json:"validator_index"`
+	Status            string                `json:"status"`
+	CurrentEpoch      phase0.Epoch          `json:"current_epoch"`
+	FinalizedEpoch    phase0.Epoch          `json:"finalized_epoch"`
+	Electra           bool                  `json:"electra,omitempty"`
+	Eligible          bool                  `json:"eligible"`
+	EligibilityEpoch  phase0.Epoch          `json:"eligibility_epoch,omitempty"`
+	Position          int                   `json:"position,omitempty"`
+	QueueLength       int                   `json:"queue_length"`
+	ChurnLimit        uint64                `json:"churn_limit,omitempty"`
+	BalanceChurnLimit phase0.Gwei           `json:"balance_churn_limit,omitempty"`
+	ActivationEpoch   phase0.Epoch          `json:"activation_epoch,omitempty"`
+	ActivationTime    *time.Time            `json:"activation_time,omitempty"`
+	Estimated         bool                  `json:"estimated"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+		json:    viper.GetBool("json"),
+		results: &results{},
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.validator = viper.GetString("validator")
+	if c.validator == "" {
+		return nil, errors.New("validator is required")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,84 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorqueue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		data, err := json.Marshal(c.results)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal results")
+		}
+		return string(data), nil
+	}
+
+	return c.outputTxt(), nil
+}
+
+func (c *command) outputTxt() string {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Validator %d (%s)\n", c.results.Validator, c.results.Status))
+	if c.results.ActivationTime == nil {
+		builder.WriteString("Validator has insufficient effective balance to enter the activation queue")
+		return builder.String()
+	}
+
+	if !c.results.Estimated {
+		if c.results.ActivationEpoch <= c.results.CurrentEpoch {
+			builder.WriteString(fmt.Sprintf("Activated in epoch %d (%s)", c.results.ActivationEpoch, c.results.ActivationTime.Format("2006-01-02 15:04:05")))
+		} else {
+			builder.WriteString(fmt.Sprintf("Activation epoch: %d (%s, in %s)", c.results.ActivationEpoch, c.results.ActivationTime.Format("2006-01-02 15:04:05"), time.Until(*c.results.ActivationTime).Round(time.Second)))
+		}
+		return builder.String()
+	}
+
+	switch {
+	case c.results.Electra && c.results.Eligible:
+		builder.WriteString(fmt.Sprintf("Eligible for activation in epoch %d; awaiting finality of eligibility\n", c.results.EligibilityEpoch))
+	case c.results.Electra:
+		if c.results.Position > 0 {
+			builder.WriteString(fmt.Sprintf("Position in pending deposits queue: %d/%d\n", c.results.Position, c.results.QueueLength))
+		}
+		builder.WriteString(fmt.Sprintf("Expected to become eligible for activation in epoch %d\n", c.results.EligibilityEpoch))
+		builder.WriteString(fmt.Sprintf("Deposit churn limit: %s per epoch\n", string2eth.GWeiToString(uint64(c.results.BalanceChurnLimit), true)))
+	case c.results.Eligible:
+		builder.WriteString(fmt.Sprintf("Position in activation queue: %d/%d\n", c.results.Position, c.results.QueueLength))
+		builder.WriteString(fmt.Sprintf("Churn limit: %d validators per epoch\n", c.results.ChurnLimit))
+	default:
+		builder.WriteString(fmt.Sprintf("Not yet eligible for activation; expected to join the back of the activation queue (%d validators) at the next epoch\n", c.results.QueueLength))
+		builder.WriteString(fmt.Sprintf("Churn limit: %d validators per epoch\n", c.results.ChurnLimit))
+	}
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Current epoch: %d\n", c.results.CurrentEpoch))
+		builder.WriteString(fmt.Sprintf("Finalized epoch: %d\n", c.results.FinalizedEpoch))
+	}
+	builder.WriteString(fmt.Sprintf("Estimated activation epoch: %d (%s, in %s)", c.results.ActivationEpoch, c.results.ActivationTime.Format("2006-01-02 15:04:05"), time.Until(*c.results.ActivationTime).Round(time.Second)))
+
+	return builder.String()
+}
@@ -0,0 +1,359 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorqueue
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// queueEntry is a validator in the activation queue.
+type queueEntry struct {
+	index            phase0.ValidatorIndex
+	eligibilityEpoch phase0.Epoch
+}
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	specResponse, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	params, err := util.ObtainChurnParameters(specResponse.Data)
+	if err != nil {
+		return err
+	}
+
+	finalityResponse, err := c.eth2Client.(eth2client.FinalityProvider).Finality(ctx, &api.FinalityOpts{
+		State: "head",
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain finality")
+	}
+	c.results.CurrentEpoch = c.chainTime.CurrentEpoch()
+	c.results.FinalizedEpoch = finalityResponse.Data.Finalized.Epoch
+
+	validator, err := util.ParseValidator(ctx, c.validatorsProvider, c.validator, "head")
+	if err != nil {
+		return err
+	}
+	c.results.Validator = validator.Index
+	c.results.Status = validator.Status.String()
+
+	if validator.Validator.ActivationEpoch != util.FarFutureEpoch {
+		// Activation epoch is already known.
+		c.results.Eligible = true
+		c.results.ActivationEpoch = validator.Validator.ActivationEpoch
+		activationTime := c.chainTime.StartOfEpoch(c.results.ActivationEpoch)
+		c.results.ActivationTime = &activationTime
+
+		return nil
+	}
+
+	balanceParams, err := util.ObtainBalanceParameters(specResponse.Data)
+	if err != nil {
+		return err
+	}
+	postElectra := c.results.CurrentEpoch >= c.chainTime.ElectraInitialEpoch()
+	if postElectra && params.SupportsElectra() && balanceParams.SupportsElectra() {
+		return c.processElectra(ctx, params, balanceParams, validator)
+	}
+
+	// Need the full validator set to build the queue.
+	if c.debug {
+		fmt.Println("Fetching all validators")
+	}
+	validatorsResponse, err := c.validatorsProvider.Validators(ctx, &api.ValidatorsOpts{
+		State: "head",
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+
+	activeValidators := uint64(0)
+	queue := make([]*queueEntry, 0)
+	for _, v := range validatorsResponse.Data {
+		if v.Validator == nil {
+			continue
+		}
+		if v.Validator.ActivationEpoch <= c.results.CurrentEpoch && c.results.CurrentEpoch < v.Validator.ExitEpoch {
+			activeValidators++
+		}
+		if v.Validator.ActivationEpoch == util.FarFutureEpoch && v.Validator.ActivationEligibilityEpoch != util.FarFutureEpoch {
+			queue = append(queue, &queueEntry{
+				index:            v.Index,
+				eligibilityEpoch: v.Validator.ActivationEligibilityEpoch,
+			})
+		}
+	}
+	postDeneb := c.results.CurrentEpoch >= c.chainTime.DenebInitialEpoch()
+	c.results.ChurnLimit = params.ActivationChurnLimit(activeValidators, postDeneb)
+	c.results.QueueLength = len(queue)
+
+	c.results.Eligible = validator.Validator.ActivationEligibilityEpoch != util.FarFutureEpoch
+	if !c.results.Eligible {
+		if validator.Validator.EffectiveBalance < balanceParams.MaxEffectiveBalance {
+			// The validator does not have sufficient balance to become
+			// eligible, so cannot be placed in the queue.
+			return nil
+		}
+		// Eligibility is set at the next epoch transition, placing the
+		// validator at the back of the queue.
+		queue = append(queue, &queueEntry{
+			index:            validator.Index,
+			eligibilityEpoch: c.results.CurrentEpoch + 1,
+		})
+	}
+
+	position := sortQueue(queue, validator.Index)
+	if position < 0 {
+		return errors.New("validator not found in activation queue")
+	}
+	c.results.Position = position + 1
+
+	dequeueEpoch := estimateDequeueEpoch(queue, position, c.results.CurrentEpoch, c.results.FinalizedEpoch, activeValidators, func(active uint64) uint64 {
+		return max(1, params.ActivationChurnLimit(active, postDeneb))
+	})
+	c.setActivation(params, dequeueEpoch)
+
+	return nil
+}
+
+// sortQueue sorts the queue in to activation order, returning the position
+// of the given validator.
+func sortQueue(queue []*queueEntry, index phase0.ValidatorIndex) int {
+	sort.Slice(queue, func(i int, j int) bool {
+		if queue[i].eligibilityEpoch != queue[j].eligibilityEpoch {
+			return queue[i].eligibilityEpoch < queue[j].eligibilityEpoch
+		}
+		return queue[i].index < queue[j].index
+	})
+	for i := range queue {
+		if queue[i].index == index {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// estimateDequeueEpoch estimates the epoch in which the validator at the
+// given position in the queue will be dequeued and have its activation epoch
+// set.  It assumes that the chain continues to finalize normally.
+func estimateDequeueEpoch(queue []*queueEntry,
+	position int,
+	currentEpoch phase0.Epoch,
+	finalizedEpoch phase0.Epoch,
+	activeValidators uint64,
+	churnLimit func(activeValidators uint64) uint64,
+) phase0.Epoch {
+	next := 0
+	for epoch := currentEpoch; ; epoch++ {
+		// Validators are only dequeued once their eligibility is finalized.
+		finalized := finalizedEpoch + (epoch - currentEpoch)
+		churn := int(churnLimit(activeValidators))
+		for dequeued := 0; dequeued < churn && next < len(queue) && queue[next].eligibilityEpoch <= finalized; dequeued++ {
+			if next == position {
+				return epoch
+			}
+			next++
+			activeValidators++
+		}
+	}
+}
+
+// processElectra estimates the activation of a validator post-Electra.  Here
+// the churn applies to the balance of pending deposits rather than to the
+// number of validators, and a validator is activated as soon as its
+// eligibility is finalized.
+func (c *command) processElectra(ctx context.Context,
+	params *util.ChurnParameters,
+	balanceParams *util.BalanceParameters,
+	validator *apiv1.Validator,
+) error {
+	c.results.Electra = true
+
+	if validator.Validator.ActivationEligibilityEpoch != util.FarFutureEpoch {
+		// The validator is activated once its eligibility is finalized.
+		c.results.Eligible = true
+		c.results.EligibilityEpoch = validator.Validator.ActivationEligibilityEpoch
+		c.setActivation(params, finalizedDequeueEpoch(c.results.EligibilityEpoch, c.results.CurrentEpoch, c.results.FinalizedEpoch))
+
+		return nil
+	}
+
+	if c.debug {
+		fmt.Println("Fetching beacon state")
+	}
+	var electra *util.StateStreamElectra
+	totalActiveBalance := phase0.Gwei(0)
+	deposits := make([]*util.PendingDeposit, 0)
+	err := util.StreamBeaconState(ctx, c.eth2Client, "head", &util.StateStreamHandler{
+		Electra: func(e *util.StateStreamElectra) error {
+			electra = e
+			return nil
+		},
+		Validator: func(_ phase0.ValidatorIndex, v *phase0.Validator) error {
+			if v.ActivationEpoch <= c.results.CurrentEpoch && c.results.CurrentEpoch < v.ExitEpoch {
+				totalActiveBalance += v.EffectiveBalance
+			}
+			return nil
+		},
+		PendingDeposit: func(_ uint64, deposit *util.PendingDeposit) error {
+			deposits = append(deposits, deposit)
+			return nil
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain state")
+	}
+
+	c.results.BalanceChurnLimit = params.ActivationExitChurnLimit(totalActiveBalance)
+	c.results.QueueLength = len(deposits)
+	// Deposits are processed in the transition to the next epoch.
+	estimates := util.EstimatePendingDepositEpochs(deposits,
+		c.results.CurrentEpoch+1,
+		electra.DepositBalanceToConsume,
+		c.results.BalanceChurnLimit,
+		params.MaxPendingDepositsPerEpoch,
+	)
+
+	eligibilityEpoch, position := electraEligibilityEpoch(validator.Validator.PublicKey,
+		validator.Balance,
+		validator.Validator.EffectiveBalance,
+		deposits,
+		estimates,
+		c.results.CurrentEpoch,
+		balanceParams.MinActivationBalance,
+		params.EffectiveBalanceIncrement,
+	)
+	if eligibilityEpoch == util.FarFutureEpoch {
+		// The validator will not have sufficient balance to become
+		// eligible, even once its pending deposits are processed.
+		return nil
+	}
+	c.results.Position = position + 1
+	c.results.EligibilityEpoch = eligibilityEpoch
+	c.setActivation(params, finalizedDequeueEpoch(eligibilityEpoch, c.results.CurrentEpoch, c.results.FinalizedEpoch))
+
+	return nil
+}
+
+// setActivation sets the estimated activation of the validator given the
+// epoch in which it is dequeued.
+func (c *command) setActivation(params *util.ChurnParameters, dequeueEpoch phase0.Epoch) {
+	c.results.ActivationEpoch = params.ActivationExitEpoch(dequeueEpoch)
+	activationTime := c.chainTime.StartOfEpoch(c.results.ActivationEpoch)
+	c.results.ActivationTime = &activationTime
+	c.results.Estimated = true
+}
+
+// electraEligibilityEpoch estimates the epoch at which a validator becomes
+// eligible for activation post-Electra, along with the position in the
+// pending deposits queue of the deposit that makes it eligible, or -1 if it
+// does not need any further deposits.  If the validator will not have
+// sufficient balance to become eligible it returns the far future epoch.
+//
+// Effective balance hysteresis is ignored, so the estimate may be early for
+// validators that have been topped up.
+func electraEligibilityEpoch(pubKey phase0.BLSPubKey,
+	balance phase0.Gwei,
+	effectiveBalance phase0.Gwei,
+	deposits []*util.PendingDeposit,
+	estimates []phase0.Epoch,
+	currentEpoch phase0.Epoch,
+	minActivationBalance phase0.Gwei,
+	effectiveBalanceIncrement phase0.Gwei,
+) (
+	phase0.Epoch,
+	int,
+) {
+	if effectiveBalance >= minActivationBalance {
+		// Eligibility is set at the next epoch transition.
+		return currentEpoch + 1, -1
+	}
+
+	for i := range deposits {
+		if deposits[i].PubKey != pubKey {
+			continue
+		}
+		balance += deposits[i].Amount
+		if balance-balance%effectiveBalanceIncrement >= minActivationBalance {
+			// The effective balance is updated in the same epoch transition
+			// as the deposit is processed, and eligibility set at the next.
+			return estimates[i] + 1, i
+		}
+	}
+
+	return util.FarFutureEpoch, -1
+}
+
+// finalizedDequeueEpoch estimates the epoch in which a validator with the
+// given eligibility epoch will be activated post-Electra, when its
+// eligibility has been finalized.  It assumes that the chain continues to
+// finalize normally.
+func finalizedDequeueEpoch(eligibilityEpoch phase0.Epoch,
+	currentEpoch phase0.Epoch,
+	finalizedEpoch phase0.Epoch,
+) phase0.Epoch {
+	if eligibilityEpoch <= finalizedEpoch {
+		return currentEpoch
+	}
+
+	return currentEpoch + (eligibilityEpoch - finalizedEpoch)
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+
+	return nil
+}
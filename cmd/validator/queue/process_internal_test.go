@@ -0,0 +1,183 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorqueue
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestSortQueue(t *testing.T) {
+	queue := []*queueEntry{
+		{index: 5, eligibilityEpoch: 100},
+		{index: 3, eligibilityEpoch: 101},
+		{index: 4, eligibilityEpoch: 100},
+	}
+	require.Equal(t, 2, sortQueue(queue, 3))
+	require.Equal(t, phase0.ValidatorIndex(4), queue[0].index)
+	require.Equal(t, 1, sortQueue(queue, 5))
+	require.Equal(t, -1, sortQueue(queue, 6))
+}
+
+func TestEstimateDequeueEpoch(t *testing.T) {
+	fixedChurn := func(_ uint64) uint64 {
+		return 2
+	}
+	// Churn grows by 1 for every 10 active validators.
+	growingChurn := func(activeValidators uint64) uint64 {
+		return activeValidators / 10
+	}
+
+	tests := []struct {
+		name             string
+		queue            []*queueEntry
+		position         int
+		activeValidators uint64
+		churnLimit       func(uint64) uint64
+		expected         phase0.Epoch
+	}{
+		{
+			name: "Front",
+			queue: []*queueEntry{
+				{index: 1, eligibilityEpoch: 90},
+			},
+			position:   0,
+			churnLimit: fixedChurn,
+			expected:   100,
+		},
+		{
+			name: "Churn",
+			queue: []*queueEntry{
+				{index: 1, eligibilityEpoch: 90},
+				{index: 2, eligibilityEpoch: 90},
+				{index: 3, eligibilityEpoch: 90},
+				{index: 4, eligibilityEpoch: 90},
+				{index: 5, eligibilityEpoch: 90},
+			},
+			position:   4,
+			churnLimit: fixedChurn,
+			expected:   102,
+		},
+		{
+			name: "AwaitingFinality",
+			queue: []*queueEntry{
+				{index: 1, eligibilityEpoch: 90},
+				{index: 2, eligibilityEpoch: 101},
+			},
+			position:   1,
+			churnLimit: fixedChurn,
+			// Finalized epoch reaches 101 in epoch 103.
+			expected: 103,
+		},
+		{
+			name: "GrowingChurn",
+			queue: []*queueEntry{
+				{index: 1, eligibilityEpoch: 90},
+				{index: 2, eligibilityEpoch: 90},
+				{index: 3, eligibilityEpoch: 90},
+				{index: 4, eligibilityEpoch: 90},
+			},
+			position:         3,
+			activeValidators: 19,
+			churnLimit:       growingChurn,
+			// 1 in epoch 100, then 2 in epoch 101, then 1 in epoch 102.
+			expected: 102,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, estimateDequeueEpoch(test.queue, test.position, 100, 98, test.activeValidators, test.churnLimit))
+		})
+	}
+}
+
+func TestElectraEligibilityEpoch(t *testing.T) {
+	pubKey := phase0.BLSPubKey{0x01}
+	otherPubKey := phase0.BLSPubKey{0x02}
+	deposits := []*util.PendingDeposit{
+		{PubKey: otherPubKey, Amount: 32000000000},
+		{PubKey: pubKey, Amount: 16000000000},
+		{PubKey: otherPubKey, Amount: 32000000000},
+		{PubKey: pubKey, Amount: 16000000000},
+	}
+	estimates := []phase0.Epoch{101, 101, 102, 103}
+
+	tests := []struct {
+		name             string
+		pubKey           phase0.BLSPubKey
+		balance          phase0.Gwei
+		effectiveBalance phase0.Gwei
+		expectedEpoch    phase0.Epoch
+		expectedPosition int
+	}{
+		{
+			name:             "SufficientBalance",
+			balance:          32000000000,
+			effectiveBalance: 32000000000,
+			expectedEpoch:    101,
+			expectedPosition: -1,
+		},
+		{
+			name:             "FirstDeposit",
+			balance:          16000000000,
+			effectiveBalance: 16000000000,
+			expectedEpoch:    102,
+			expectedPosition: 1,
+		},
+		{
+			name:             "SecondDeposit",
+			expectedEpoch:    104,
+			expectedPosition: 3,
+		},
+		{
+			name:             "PartialIncrement",
+			balance:          16000000000,
+			effectiveBalance: 15000000000,
+			expectedEpoch:    102,
+			expectedPosition: 1,
+		},
+		{
+			name:             "Insufficient",
+			pubKey:           phase0.BLSPubKey{0x03},
+			balance:          16000000000,
+			effectiveBalance: 16000000000,
+			expectedEpoch:    util.FarFutureEpoch,
+			expectedPosition: -1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			validatorPubKey := pubKey
+			if test.pubKey != (phase0.BLSPubKey{}) {
+				validatorPubKey = test.pubKey
+			}
+			epoch, position := electraEligibilityEpoch(validatorPubKey, test.balance, test.effectiveBalance, deposits, estimates, 100, 32000000000, 1000000000)
+			require.Equal(t, test.expectedEpoch, epoch)
+			require.Equal(t, test.expectedPosition, position)
+		})
+	}
+}
+
+func TestFinalizedDequeueEpoch(t *testing.T) {
+	// Eligibility already finalized.
+	require.Equal(t, phase0.Epoch(100), finalizedDequeueEpoch(95, 100, 98))
+	require.Equal(t, phase0.Epoch(100), finalizedDequeueEpoch(98, 100, 98))
+	// Awaiting finality.
+	require.Equal(t, phase0.Epoch(103), finalizedDequeueEpoch(101, 100, 98))
+}
@@ -0,0 +1,87 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorrecover
+
+import (
+	"context"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Input.
+	mnemonic   string
+	startIndex uint64
+	count      uint64
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Processing.
+	validatorsProvider consensusclient.ValidatorsProvider
+
+	// Output.
+	recovered []*recoveredValidator
+}
+
+// recoveredValidator is a validator whose key was derived from the mnemonic.
+type recoveredValidator struct {
+	Index     uint64           `json:"index"`
+	Path      string           `json:"path"`
+	Validator *apiv1.Validator `json:"validator"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		json:                     viper.GetBool("json"),
+		timeout:                  viper.GetDuration("timeout"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		mnemonic:                 viper.GetString("mnemonic"),
+		startIndex:               viper.GetUint64("start-index"),
+		count:                    viper.GetUint64("count"),
+	}
+
+	// Timeout is required.
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if c.mnemonic == "" {
+		return nil, errors.New("mnemonic is required")
+	}
+
+	if c.count == 0 {
+		return nil, errors.New("count must be at least 1")
+	}
+	if c.count > 100000 {
+		return nil, errors.New("count must be no more than 100000")
+	}
+
+	return c, nil
+}
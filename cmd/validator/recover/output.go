@@ -0,0 +1,60 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorrecover
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		data, err := json.Marshal(c.recovered)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal JSON")
+		}
+		return string(data), nil
+	}
+
+	builder := strings.Builder{}
+
+	if len(c.recovered) == 0 {
+		builder.WriteString(fmt.Sprintf("No validators found for indices %d to %d", c.startIndex, c.startIndex+c.count-1))
+		return builder.String(), nil
+	}
+
+	for _, recovered := range c.recovered {
+		builder.WriteString(fmt.Sprintf("Index %d (%s): validator %d, %s", recovered.Index, recovered.Path, recovered.Validator.Index, recovered.Validator.Status))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf(", public key %#x", recovered.Validator.Validator.PublicKey))
+		}
+		builder.WriteString("\n")
+	}
+
+	highest := c.recovered[len(c.recovered)-1].Index
+	builder.WriteString(fmt.Sprintf("Found %d validators for indices %d to %d; highest index in use is %d", len(c.recovered), c.startIndex, c.startIndex+c.count-1, highest))
+	if highest == c.startIndex+c.count-1 {
+		builder.WriteString("; further validators may exist beyond this window, increase --count to check")
+	}
+
+	return builder.String(), nil
+}
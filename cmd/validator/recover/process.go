@@ -0,0 +1,102 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorrecover
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	ethutil "github.com/wealdtech/go-eth2-util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	seed, err := util.SeedFromMnemonic(c.mnemonic)
+	if err != nil {
+		return err
+	}
+
+	// Derive the validator signing keys for each index in the window.
+	paths := make(map[phase0.BLSPubKey]string, c.count)
+	indices := make(map[phase0.BLSPubKey]uint64, c.count)
+	pubKeys := make([]phase0.BLSPubKey, 0, c.count)
+	for index := c.startIndex; index < c.startIndex+c.count; index++ {
+		path := fmt.Sprintf("m/12381/3600/%d/0/0", index)
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Deriving key for path %s\n", path)
+		}
+		key, err := ethutil.PrivateKeyFromSeedAndPath(seed, path)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to derive key for path %s", path))
+		}
+		pubKey := phase0.BLSPubKey(key.PublicKey().Marshal())
+		paths[pubKey] = path
+		indices[pubKey] = index
+		pubKeys = append(pubKeys, pubKey)
+	}
+
+	validators, err := util.FetchValidators(ctx, c.validatorsProvider, "head", nil, pubKeys)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validators")
+	}
+
+	c.recovered = make([]*recoveredValidator, 0, len(validators))
+	for _, validator := range validators {
+		c.recovered = append(c.recovered, &recoveredValidator{
+			Index:     indices[validator.Validator.PublicKey],
+			Path:      paths[validator.Validator.PublicKey],
+			Validator: validator,
+		})
+	}
+	sort.Slice(c.recovered, func(i int, j int) bool {
+		return c.recovered[i].Index < c.recovered[j].Index
+	})
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	if c.validatorsProvider != nil {
+		// Already set up.
+		return nil
+	}
+
+	// Connect to the consensus node.
+	consensusClient, err := util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return err
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = consensusClient.(consensusclient.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+
+	return nil
+}
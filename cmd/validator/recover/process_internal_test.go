@@ -0,0 +1,140 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorrecover
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// validatorsService is a mock beacon node that knows of a set of validators.
+type validatorsService struct {
+	validators map[phase0.ValidatorIndex]*apiv1.Validator
+}
+
+func (*validatorsService) Name() string    { return "mock" }
+func (*validatorsService) Address() string { return "mock" }
+func (*validatorsService) IsActive() bool  { return true }
+func (*validatorsService) IsSynced() bool  { return true }
+
+func (s *validatorsService) Validators(_ context.Context, opts *api.ValidatorsOpts) (*api.Response[map[phase0.ValidatorIndex]*apiv1.Validator], error) {
+	res := make(map[phase0.ValidatorIndex]*apiv1.Validator)
+	for index, validator := range s.validators {
+		for _, pubKey := range opts.PubKeys {
+			if validator.Validator.PublicKey == pubKey {
+				res[index] = validator
+			}
+		}
+	}
+
+	return &api.Response[map[phase0.ValidatorIndex]*apiv1.Validator]{
+		Data:     res,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	service := &validatorsService{
+		validators: map[phase0.ValidatorIndex]*apiv1.Validator{
+			10: {
+				Index:  10,
+				Status: apiv1.ValidatorStateActiveOngoing,
+				Validator: &phase0.Validator{
+					PublicKey: testutil.HexToPubKey("0xb384f767d964e100c8a9b21018d08c25ffebae268b3ab6d610353897541971726dbfc3c7463884c68a531515aab94c87"),
+				},
+			},
+			20: {
+				Index:  20,
+				Status: apiv1.ValidatorStateWithdrawalDone,
+				Validator: &phase0.Validator{
+					PublicKey: testutil.HexToPubKey("0xb3d89e2f29c712c6a9f8e5a269b97617c4a94dd6f6662ab3b07ce9e5434573f15b5c988cd14bbd5804f77156a8af1cfa"),
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		mnemonic   string
+		startIndex uint64
+		count      uint64
+		indices    []uint64
+		paths      []string
+		err        string
+	}{
+		{
+			name:     "MnemonicInvalid",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+			count:    10,
+			err:      "mnemonic is invalid",
+		},
+		{
+			name:     "Good",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			count:    10,
+			indices:  []uint64{0, 1},
+			paths:    []string{"m/12381/3600/0/0/0", "m/12381/3600/1/0/0"},
+		},
+		{
+			name:       "Window",
+			mnemonic:   "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			startIndex: 1,
+			count:      5,
+			indices:    []uint64{1},
+			paths:      []string{"m/12381/3600/1/0/0"},
+		},
+		{
+			name:       "NoneFound",
+			mnemonic:   "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			startIndex: 2,
+			count:      5,
+			indices:    []uint64{},
+			paths:      []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				mnemonic:           test.mnemonic,
+				startIndex:         test.startIndex,
+				count:              test.count,
+				validatorsProvider: service,
+			}
+			err := c.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			indices := make([]uint64, 0, len(c.recovered))
+			paths := make([]string, 0, len(c.recovered))
+			for _, recovered := range c.recovered {
+				indices = append(indices, recovered.Index)
+				paths = append(paths, recovered.Path)
+			}
+			require.Equal(t, test.indices, indices)
+			require.Equal(t, test.paths, paths)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorregistration
+
+import (
+	"context"
+	"time"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	timeout time.Duration
+
+	// Input.
+	account       string
+	privateKey    string
+	passphrases   []string
+	unlockWorkers int
+	feeRecipient  string
+	gasLimit      uint64
+	timestamp     string
+	forkVersion   phase0.Version
+
+	// Output.
+	registrations []*apiv1.SignedValidatorRegistration
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:         viper.GetBool("quiet"),
+		verbose:       viper.GetBool("verbose"),
+		debug:         viper.GetBool("debug"),
+		timeout:       viper.GetDuration("timeout"),
+		account:       viper.GetString("account"),
+		privateKey:    viper.GetString("private-key"),
+		passphrases:   util.GetPassphrases(),
+		unlockWorkers: util.UnlockWorkers(),
+		feeRecipient:  viper.GetString("fee-recipient"),
+		gasLimit:      viper.GetUint64("gas-limit"),
+		timestamp:     viper.GetString("timestamp"),
+	}
+
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if c.account == "" && c.privateKey == "" {
+		return nil, errors.New("account or private-key is required")
+	}
+	if c.account != "" && c.privateKey != "" {
+		return nil, errors.New("only one of account and private-key is required")
+	}
+
+	if c.feeRecipient == "" {
+		return nil, errors.New("fee recipient is required")
+	}
+	if c.gasLimit == 0 {
+		return nil, errors.New("gas limit is required")
+	}
+
+	var err error
+	c.forkVersion, err = util.GenesisForkVersion(viper.GetString("forkversion"))
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
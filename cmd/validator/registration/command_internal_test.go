@@ -0,0 +1,115 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorregistration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{
+				"account":       "Wallet/Account",
+				"fee-recipient": "0x8f0844fd51e31ff6bf5babe21dccf7328e19fd9f",
+				"gas-limit":     30000000,
+			},
+			err: "timeout is required",
+		},
+		{
+			name: "AccountMissing",
+			vars: map[string]interface{}{
+				"timeout":       "5s",
+				"fee-recipient": "0x8f0844fd51e31ff6bf5babe21dccf7328e19fd9f",
+				"gas-limit":     30000000,
+			},
+			err: "account or private-key is required",
+		},
+		{
+			name: "AccountAndPrivateKey",
+			vars: map[string]interface{}{
+				"timeout":       "5s",
+				"account":       "Wallet/Account",
+				"private-key":   "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866",
+				"fee-recipient": "0x8f0844fd51e31ff6bf5babe21dccf7328e19fd9f",
+				"gas-limit":     30000000,
+			},
+			err: "only one of account and private-key is required",
+		},
+		{
+			name: "FeeRecipientMissing",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"account":   "Wallet/Account",
+				"gas-limit": 30000000,
+			},
+			err: "fee recipient is required",
+		},
+		{
+			name: "GasLimitZero",
+			vars: map[string]interface{}{
+				"timeout":       "5s",
+				"account":       "Wallet/Account",
+				"fee-recipient": "0x8f0844fd51e31ff6bf5babe21dccf7328e19fd9f",
+				"gas-limit":     0,
+			},
+			err: "gas limit is required",
+		},
+		{
+			name: "ForkVersionInvalid",
+			vars: map[string]interface{}{
+				"timeout":       "5s",
+				"account":       "Wallet/Account",
+				"fee-recipient": "0x8f0844fd51e31ff6bf5babe21dccf7328e19fd9f",
+				"gas-limit":     30000000,
+				"forkversion":   "0x0102",
+			},
+			err: "fork version must be exactly 4 bytes in length",
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"timeout":       "5s",
+				"account":       "Wallet/Account",
+				"fee-recipient": "0x8f0844fd51e31ff6bf5babe21dccf7328e19fd9f",
+				"gas-limit":     30000000,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorregistration
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/signing"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// applicationBuilderDomainType is the domain type for builder API messages.
+var applicationBuilderDomainType = phase0.DomainType{0x00, 0x00, 0x00, 0x01}
+
+func (c *command) process(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	feeRecipient, err := util.ParseExecutionAddress(c.feeRecipient)
+	if err != nil {
+		return errors.Wrap(err, "invalid fee recipient")
+	}
+	timestamp, err := parseTimestamp(c.timestamp, time.Now())
+	if err != nil {
+		return err
+	}
+
+	accounts, err := c.obtainAccounts(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Builder API messages are signed with the genesis fork version and a
+	// zero genesis validators root, so are valid across forks.
+	domain, err := util.ComputeDomain(applicationBuilderDomainType, c.forkVersion, phase0.Root{})
+	if err != nil {
+		return err
+	}
+
+	// Use a single session for signing, so that each account is unlocked
+	// once regardless of the number of signatures.
+	session := signing.NewSession(c.passphrases)
+	defer func() {
+		_ = session.Close(ctx)
+	}()
+	if len(accounts) > 1 {
+		if err := session.UnlockAll(ctx, accounts, c.unlockWorkers); err != nil {
+			return err
+		}
+	}
+
+	c.registrations = make([]*apiv1.SignedValidatorRegistration, 0, len(accounts))
+	for _, account := range accounts {
+		pubKey, err := util.BestPublicKey(account)
+		if err != nil {
+			return errors.Wrapf(err, "failed to obtain public key for account %s", account.Name())
+		}
+		registration := &apiv1.ValidatorRegistration{
+			FeeRecipient: feeRecipient,
+			GasLimit:     c.gasLimit,
+			Timestamp:    timestamp,
+		}
+		copy(registration.Pubkey[:], pubKey.Marshal())
+
+		root, err := registration.HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "failed to generate registration root")
+		}
+		signature, err := session.SignRoot(ctx, account, root, domain)
+		if err != nil {
+			return errors.Wrapf(err, "failed to sign registration for account %s", account.Name())
+		}
+
+		c.registrations = append(c.registrations, &apiv1.SignedValidatorRegistration{
+			Message:   registration,
+			Signature: signature,
+		})
+	}
+
+	return nil
+}
+
+// obtainAccounts obtains the accounts for which to generate registrations.
+// An account specifier can match multiple accounts.
+func (c *command) obtainAccounts(ctx context.Context) ([]e2wtypes.Account, error) {
+	if c.privateKey != "" {
+		account, err := util.ParseAccount(ctx, c.privateKey, nil, true)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain account")
+		}
+
+		return []e2wtypes.Account{account}, nil
+	}
+
+	if !strings.Contains(c.account, "/") {
+		account, err := util.ParseAccount(ctx, c.account, c.passphrases, false)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain account")
+		}
+
+		return []e2wtypes.Account{account}, nil
+	}
+
+	_, accounts, err := util.WalletAndAccountsFromPath(ctx, c.account)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain accounts")
+	}
+	if len(accounts) == 0 {
+		return nil, fmt.Errorf("no accounts match %s", c.account)
+	}
+
+	return accounts, nil
+}
+
+// parseTimestamp parses a timestamp supplied as either a Unix time in
+// seconds or an RFC 3339 time, defaulting to the supplied time if empty.
+func parseTimestamp(input string, now time.Time) (time.Time, error) {
+	if input == "" {
+		return time.Unix(now.Unix(), 0), nil
+	}
+
+	if seconds, err := strconv.ParseInt(input, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, input)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %s", input)
+	}
+
+	return time.Unix(timestamp.Unix(), 0), nil
+}
@@ -0,0 +1,156 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorregistration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	pubKey := testutil.HexToBytes("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c")
+
+	tests := []struct {
+		name string
+		cmd  *command
+		err  string
+	}{
+		{
+			name: "FeeRecipientInvalid",
+			cmd: &command{
+				timeout:      10 * time.Second,
+				privateKey:   "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866",
+				feeRecipient: "0x8f08",
+				gasLimit:     30000000,
+			},
+			err: "invalid fee recipient: address must be exactly 20 bytes in length",
+		},
+		{
+			name: "PrivateKeyInvalid",
+			cmd: &command{
+				timeout:      10 * time.Second,
+				privateKey:   "0xinvalid",
+				feeRecipient: "0x8f0844fd51e31ff6bf5babe21dccf7328e19fd9f",
+				gasLimit:     30000000,
+			},
+			err: "failed to obtain account: failed to parse account key: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name: "Mainnet",
+			cmd: &command{
+				timeout:      10 * time.Second,
+				privateKey:   "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866",
+				feeRecipient: "0x8f0844fd51e31ff6bf5babe21dccf7328e19fd9f",
+				gasLimit:     30000000,
+				timestamp:    "1700000000",
+			},
+		},
+		{
+			name: "ForkVersion",
+			cmd: &command{
+				timeout:      10 * time.Second,
+				privateKey:   "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866",
+				feeRecipient: "0x8f0844fd51e31ff6bf5babe21dccf7328e19fd9f",
+				gasLimit:     36000000,
+				timestamp:    "1700000000",
+				forkVersion:  phase0.Version{0x01, 0x01, 0x70, 0x00},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.cmd.process(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, test.cmd.registrations, 1)
+			registration := test.cmd.registrations[0]
+			require.Equal(t, pubKey, registration.Message.Pubkey[:])
+			require.Equal(t, test.cmd.gasLimit, registration.Message.GasLimit)
+			require.Equal(t, int64(1700000000), registration.Message.Timestamp.Unix())
+
+			key, err := e2types.BLSPublicKeyFromBytes(pubKey)
+			require.NoError(t, err)
+			sigBytes := make([]byte, len(registration.Signature))
+			copy(sigBytes, registration.Signature[:])
+			signature, err := e2types.BLSSignatureFromBytes(sigBytes)
+			require.NoError(t, err)
+			root, err := registration.Message.HashTreeRoot()
+			require.NoError(t, err)
+			domain, err := util.ComputeDomain(phase0.DomainType{0x00, 0x00, 0x00, 0x01}, test.cmd.forkVersion, phase0.Root{})
+			require.NoError(t, err)
+			signingRoot, err := (&phase0.SigningData{
+				ObjectRoot: root,
+				Domain:     domain,
+			}).HashTreeRoot()
+			require.NoError(t, err)
+			require.True(t, signature.Verify(signingRoot[:], key))
+		})
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	now := time.Unix(1700000000, 500)
+
+	tests := []struct {
+		name  string
+		input string
+		res   int64
+		err   string
+	}{
+		{
+			name: "Default",
+			res:  1700000000,
+		},
+		{
+			name:  "Unix",
+			input: "1600000000",
+			res:   1600000000,
+		},
+		{
+			name:  "RFC3339",
+			input: "2023-11-14T22:13:20Z",
+			res:   1700000000,
+		},
+		{
+			name:  "Invalid",
+			input: "yesterday",
+			err:   "invalid timestamp yesterday",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := parseTimestamp(test.input, now)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res.Unix())
+			}
+		})
+	}
+}
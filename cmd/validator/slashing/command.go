@@ -0,0 +1,101 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashing
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Operation.
+	validator  string
+	jsonOutput bool
+
+	// Data access.
+	eth2Client         eth2client.Service
+	chainTime          chaintime.Service
+	specProvider       eth2client.SpecProvider
+	validatorsProvider eth2client.ValidatorsProvider
+	blocksProvider     eth2client.SignedBeaconBlockProvider
+
+	// Results.
+	report *slashingReport
+}
+
+type slashingReport struct {
+	Validator         phase0.ValidatorIndex    `json:"validator_index"`
+	Slashed           bool                     `json:"slashed"`
+	Type              string                   `json:"type,omitempty"`
+	Slot              *phase0.Slot             `json:"slot,omitempty"`
+	BlockRoot         *phase0.Root             `json:"block_root,omitempty"`
+	Whistleblower     *phase0.ValidatorIndex   `json:"whistleblower_index,omitempty"`
+	ProposerSlashing  *phase0.ProposerSlashing `json:"proposer_slashing,omitempty"`
+	AttesterSlashing  *phase0.AttesterSlashing `json:"attester_slashing,omitempty"`
+	SlashingEpoch     phase0.Epoch             `json:"slashing_epoch,omitempty"`
+	ExitEpoch         phase0.Epoch             `json:"exit_epoch,omitempty"`
+	CorrelationEpoch  phase0.Epoch             `json:"correlation_epoch,omitempty"`
+	WithdrawableEpoch phase0.Epoch             `json:"withdrawable_epoch,omitempty"`
+	Penalties         []*penalty               `json:"penalties,omitempty"`
+}
+
+// penalty is a penalty applied to the validator as a result of being
+// slashed, as observed from its balance either side of the penalty.
+type penalty struct {
+	Name   string       `json:"name"`
+	Epoch  phase0.Epoch `json:"epoch"`
+	Before phase0.Gwei  `json:"balance_before"`
+	After  phase0.Gwei  `json:"balance_after"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.validator = viper.GetString("validator")
+	if c.validator == "" {
+		return nil, errors.New("validator is required")
+	}
+
+	c.jsonOutput = viper.GetBool("json")
+
+	return c, nil
+}
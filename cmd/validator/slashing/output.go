@@ -0,0 +1,101 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/util"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.jsonOutput {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputTxt(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.report)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	report := c.report
+	builder := strings.Builder{}
+
+	if !report.Slashed {
+		builder.WriteString(fmt.Sprintf("Validator %d has not been slashed", report.Validator))
+		return builder.String(), nil
+	}
+
+	if report.Slot == nil {
+		builder.WriteString(fmt.Sprintf("Validator %d slashed in epoch %d; slashing not found\n", report.Validator, report.SlashingEpoch))
+	} else {
+		builder.WriteString(fmt.Sprintf("Validator %d slashed by %s slashing in slot %d (epoch %d)\n", report.Validator, report.Type, *report.Slot, report.SlashingEpoch))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("Block root: %#x\n", *report.BlockRoot))
+		}
+		builder.WriteString(fmt.Sprintf("Whistleblower: validator %d\n", *report.Whistleblower))
+	}
+
+	if report.ProposerSlashing != nil {
+		builder.WriteString(fmt.Sprintf("Conflicting blocks for slot %d:\n", report.ProposerSlashing.SignedHeader1.Message.Slot))
+		outputHeader(&builder, 1, report.ProposerSlashing.SignedHeader1.Message)
+		outputHeader(&builder, 2, report.ProposerSlashing.SignedHeader2.Message)
+	}
+	if report.AttesterSlashing != nil {
+		builder.WriteString(fmt.Sprintf("Conflicting attestations (%s):\n", util.AttesterSlashingNature(report.AttesterSlashing)))
+		outputAttestationData(&builder, 1, report.AttesterSlashing.Attestation1.Data)
+		outputAttestationData(&builder, 2, report.AttesterSlashing.Attestation2.Data)
+	}
+
+	builder.WriteString(fmt.Sprintf("Exit epoch: %d\n", report.ExitEpoch))
+	builder.WriteString(fmt.Sprintf("Correlation penalty epoch: %d\n", report.CorrelationEpoch))
+	builder.WriteString(fmt.Sprintf("Withdrawable epoch: %d", report.WithdrawableEpoch))
+	for _, penalty := range report.Penalties {
+		amount := phase0.Gwei(0)
+		if penalty.Before > penalty.After {
+			amount = penalty.Before - penalty.After
+		}
+		builder.WriteString(fmt.Sprintf("\n%s%s penalty (epoch %d): %s", strings.ToUpper(penalty.Name[:1]), penalty.Name[1:], penalty.Epoch, string2eth.GWeiToString(uint64(amount), true)))
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf(" (balance %s to %s)", string2eth.GWeiToString(uint64(penalty.Before), true), string2eth.GWeiToString(uint64(penalty.After), true)))
+		}
+	}
+
+	return builder.String(), nil
+}
+
+func outputHeader(builder *strings.Builder, index int, header *phase0.BeaconBlockHeader) {
+	builder.WriteString(fmt.Sprintf("  Block %d: parent root %#x, state root %#x, body root %#x\n", index, header.ParentRoot, header.StateRoot, header.BodyRoot))
+}
+
+func outputAttestationData(builder *strings.Builder, index int, data *phase0.AttestationData) {
+	builder.WriteString(fmt.Sprintf("  Attestation %d: slot %d, beacon block root %#x, source epoch %d, target epoch %d\n", index, data.Slot, data.BeaconBlockRoot, data.Source.Epoch, data.Target.Epoch))
+}
@@ -0,0 +1,89 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	slot := phase0.Slot(320010)
+	root := phase0.Root{0x01}
+	whistleblower := phase0.ValidatorIndex(99)
+	slashing := &phase0.AttesterSlashing{
+		Attestation1: attestation([]uint64{12}, 9999, 10000, 0x01),
+		Attestation2: attestation([]uint64{12}, 9999, 10000, 0x02),
+	}
+
+	tests := []struct {
+		name   string
+		report *slashingReport
+		res    string
+	}{
+		{
+			name: "NotSlashed",
+			report: &slashingReport{
+				Validator: 12,
+			},
+			res: "Validator 12 has not been slashed",
+		},
+		{
+			name: "NotFound",
+			report: &slashingReport{
+				Validator:         12,
+				Slashed:           true,
+				SlashingEpoch:     10000,
+				ExitEpoch:         10005,
+				CorrelationEpoch:  14096,
+				WithdrawableEpoch: 18192,
+			},
+			res: "Validator 12 slashed in epoch 10000; slashing not found\nExit epoch: 10005\nCorrelation penalty epoch: 14096\nWithdrawable epoch: 18192",
+		},
+		{
+			name: "Attester",
+			report: &slashingReport{
+				Validator:         12,
+				Slashed:           true,
+				Type:              "attester",
+				Slot:              &slot,
+				BlockRoot:         &root,
+				Whistleblower:     &whistleblower,
+				AttesterSlashing:  slashing,
+				SlashingEpoch:     10000,
+				ExitEpoch:         10005,
+				CorrelationEpoch:  14096,
+				WithdrawableEpoch: 18192,
+				Penalties: []*penalty{
+					{Name: "initial", Epoch: 10000, Before: 32000000000, After: 31000000000},
+				},
+			},
+			res: "Validator 12 slashed by attester slashing in slot 320010 (epoch 10000)\nWhistleblower: validator 99\nConflicting attestations (double vote):\n  Attestation 1: slot 0, beacon block root 0x0100000000000000000000000000000000000000000000000000000000000000, source epoch 9999, target epoch 10000\n  Attestation 2: slot 0, beacon block root 0x0200000000000000000000000000000000000000000000000000000000000000, source epoch 9999, target epoch 10000\nExit epoch: 10005\nCorrelation penalty epoch: 14096\nWithdrawable epoch: 18192\nInitial penalty (epoch 10000): 1 Ether",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				report: test.report,
+			}
+			res, err := c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	err := c.setup(ctx)
+	if err != nil {
+		return err
+	}
+
+	validator, err := util.ParseValidator(ctx, c.validatorsProvider, c.validator, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to parse validator")
+	}
+	c.report = &slashingReport{
+		Validator: validator.Index,
+		Slashed:   validator.Validator.Slashed,
+	}
+	if !c.report.Slashed {
+		return nil
+	}
+
+	specResponse, err := c.specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	epochsPerSlashingsVector, isVal := specResponse.Data["EPOCHS_PER_SLASHINGS_VECTOR"].(uint64)
+	if !isVal {
+		return errors.New("EPOCHS_PER_SLASHINGS_VECTOR not found in spec")
+	}
+
+	// When a validator is slashed its withdrawable epoch is set to at least
+	// EPOCHS_PER_SLASHINGS_VECTOR epochs after the epoch of slashing, which
+	// in practice is always later than the withdrawable epoch set by its exit.
+	c.report.ExitEpoch = validator.Validator.ExitEpoch
+	c.report.WithdrawableEpoch = validator.Validator.WithdrawableEpoch
+	c.report.SlashingEpoch = validator.Validator.WithdrawableEpoch - phase0.Epoch(epochsPerSlashingsVector)
+	c.report.CorrelationEpoch = validator.Validator.WithdrawableEpoch - phase0.Epoch(epochsPerSlashingsVector/2)
+
+	if err := c.findSlashing(ctx, c.report.SlashingEpoch); err != nil {
+		return err
+	}
+
+	c.obtainPenalties(ctx)
+
+	return nil
+}
+
+// findSlashing searches the blocks of the given epoch for the slashing of the validator.
+func (c *command) findSlashing(ctx context.Context, epoch phase0.Epoch) error {
+	firstSlot := c.chainTime.FirstSlotOfEpoch(epoch)
+	lastSlot := c.chainTime.LastSlotOfEpoch(epoch)
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		if c.debug {
+			fmt.Printf("Fetching block for slot %d\n", slot)
+		}
+		blockResponse, err := c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+			Block: fmt.Sprintf("%d", slot),
+		})
+		if err != nil {
+			var apiErr *api.Error
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				// No block for this slot.
+				continue
+			}
+
+			return errors.Wrapf(err, "failed to obtain block for slot %d", slot)
+		}
+		if blockResponse.Data == nil {
+			continue
+		}
+		found, err := c.checkBlock(blockResponse.Data)
+		if err != nil {
+			return err
+		}
+		if found {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// checkBlock checks the block for the slashing of the validator, updating
+// the report if found.
+func (c *command) checkBlock(block *spec.VersionedSignedBeaconBlock) (bool, error) {
+	proposerSlashings, err := block.ProposerSlashings()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain proposer slashings")
+	}
+	attesterSlashings, err := block.AttesterSlashings()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain attester slashings")
+	}
+
+	proposerSlashing, attesterSlashing := findValidatorSlashing(c.report.Validator, proposerSlashings, attesterSlashings)
+	if proposerSlashing == nil && attesterSlashing == nil {
+		return false, nil
+	}
+
+	slot, err := block.Slot()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain block slot")
+	}
+	root, err := block.Root()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain block root")
+	}
+	proposerIndex, err := block.ProposerIndex()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain block proposer")
+	}
+	c.report.Slot = &slot
+	c.report.BlockRoot = &root
+	c.report.Whistleblower = &proposerIndex
+	if proposerSlashing != nil {
+		c.report.Type = "proposer"
+		c.report.ProposerSlashing = proposerSlashing
+	} else {
+		c.report.Type = "attester"
+		c.report.AttesterSlashing = attesterSlashing
+	}
+
+	return true, nil
+}
+
+// findValidatorSlashing returns the slashing, if any, that slashes the validator.
+func findValidatorSlashing(index phase0.ValidatorIndex,
+	proposerSlashings []*phase0.ProposerSlashing,
+	attesterSlashings []*phase0.AttesterSlashing,
+) (
+	*phase0.ProposerSlashing,
+	*phase0.AttesterSlashing,
+) {
+	for _, slashing := range proposerSlashings {
+		if slashing.SignedHeader1 != nil && slashing.SignedHeader1.Message != nil &&
+			slashing.SignedHeader1.Message.ProposerIndex == index {
+			return slashing, nil
+		}
+	}
+
+	for _, slashing := range attesterSlashings {
+		for _, slashedIndex := range util.AttesterSlashingIndices(slashing) {
+			if slashedIndex == index {
+				return nil, slashing
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// obtainPenalties obtains the penalties applied to the validator from its
+// balances either side of each penalty.  Historical balances are not
+// available from all beacon nodes, in which case the penalties are omitted.
+func (c *command) obtainPenalties(ctx context.Context) {
+	c.report.Penalties = make([]*penalty, 0)
+	currentSlot := c.chainTime.CurrentSlot()
+
+	if c.report.Slot != nil && *c.report.Slot > 0 {
+		if initial := c.observePenalty(ctx, "initial", c.report.SlashingEpoch, *c.report.Slot-1, *c.report.Slot); initial != nil {
+			c.report.Penalties = append(c.report.Penalties, initial)
+		}
+	}
+
+	// The correlation penalty is applied when processing the end of the epoch.
+	correlationSlot := c.chainTime.FirstSlotOfEpoch(c.report.CorrelationEpoch + 1)
+	if correlationSlot <= currentSlot {
+		if correlation := c.observePenalty(ctx, "correlation", c.report.CorrelationEpoch, correlationSlot-1, correlationSlot); correlation != nil {
+			c.report.Penalties = append(c.report.Penalties, correlation)
+		}
+	}
+}
+
+func (c *command) observePenalty(ctx context.Context,
+	name string,
+	epoch phase0.Epoch,
+	beforeSlot phase0.Slot,
+	afterSlot phase0.Slot,
+) *penalty {
+	before, err := c.fetchBalance(ctx, beforeSlot)
+	if err != nil {
+		if c.debug {
+			fmt.Printf("Failed to obtain balance at slot %d: %v\n", beforeSlot, err)
+		}
+		return nil
+	}
+	after, err := c.fetchBalance(ctx, afterSlot)
+	if err != nil {
+		if c.debug {
+			fmt.Printf("Failed to obtain balance at slot %d: %v\n", afterSlot, err)
+		}
+		return nil
+	}
+
+	return &penalty{
+		Name:   name,
+		Epoch:  epoch,
+		Before: before,
+		After:  after,
+	}
+}
+
+func (c *command) fetchBalance(ctx context.Context, slot phase0.Slot) (phase0.Gwei, error) {
+	validators, err := util.FetchValidators(ctx, c.validatorsProvider, fmt.Sprintf("%d", slot), []phase0.ValidatorIndex{c.report.Validator}, nil)
+	if err != nil {
+		return 0, err
+	}
+	validator, exists := validators[c.report.Validator]
+	if !exists {
+		return 0, errors.New("validator not found")
+	}
+
+	return validator.Balance, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.specProvider, isProvider = c.eth2Client.(eth2client.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validators")
+	}
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorslashing
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func attestation(indices []uint64, source phase0.Epoch, target phase0.Epoch, root byte) *phase0.IndexedAttestation {
+	return &phase0.IndexedAttestation{
+		AttestingIndices: indices,
+		Data: &phase0.AttestationData{
+			BeaconBlockRoot: phase0.Root{root},
+			Source:          &phase0.Checkpoint{Epoch: source},
+			Target:          &phase0.Checkpoint{Epoch: target},
+		},
+	}
+}
+
+func TestFindValidatorSlashing(t *testing.T) {
+	proposerSlashing := &phase0.ProposerSlashing{
+		SignedHeader1: &phase0.SignedBeaconBlockHeader{Message: &phase0.BeaconBlockHeader{ProposerIndex: 12}},
+		SignedHeader2: &phase0.SignedBeaconBlockHeader{Message: &phase0.BeaconBlockHeader{ProposerIndex: 12}},
+	}
+	attesterSlashing := &phase0.AttesterSlashing{
+		Attestation1: attestation([]uint64{1, 5, 12}, 10, 11, 0x01),
+		Attestation2: attestation([]uint64{5, 13}, 10, 11, 0x02),
+	}
+
+	tests := []struct {
+		name             string
+		index            phase0.ValidatorIndex
+		proposerSlashing *phase0.ProposerSlashing
+		attesterSlashing *phase0.AttesterSlashing
+	}{
+		{
+			name:             "Proposer",
+			index:            12,
+			proposerSlashing: proposerSlashing,
+		},
+		{
+			name:             "Attester",
+			index:            5,
+			attesterSlashing: attesterSlashing,
+		},
+		{
+			name:  "FirstAttestationOnly",
+			index: 1,
+		},
+		{
+			name:  "SecondAttestationOnly",
+			index: 13,
+		},
+		{
+			name:  "Absent",
+			index: 99,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			proposer, attester := findValidatorSlashing(test.index, []*phase0.ProposerSlashing{proposerSlashing}, []*phase0.AttesterSlashing{attesterSlashing})
+			require.Equal(t, test.proposerSlashing, proposer)
+			require.Equal(t, test.attesterSlashing, attester)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorsweep
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.summary)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Slot: %d\n", c.summary.Slot))
+	}
+	builder.WriteString(fmt.Sprintf("Next validator to sweep: %d of %d\n", c.summary.NextIndex, c.summary.Validators))
+
+	for _, estimate := range c.summary.Estimates {
+		builder.WriteString(fmt.Sprintf("Validator %d: reached in slot %d (%s", estimate.Index, estimate.Slot, estimate.Time.Format("2006-01-02 15:04:05")))
+		if time.Until(estimate.Time) > 0 {
+			builder.WriteString(fmt.Sprintf(", in %s", time.Until(estimate.Time).Round(time.Second)))
+		}
+		builder.WriteString(")")
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf(" after %d validators", estimate.Distance))
+		}
+		switch estimate.Type {
+		case withdrawalTypeFull:
+			builder.WriteString(fmt.Sprintf("; expected full withdrawal of %s\n", string2eth.GWeiToString(uint64(estimate.Amount), true)))
+		case withdrawalTypePartial:
+			builder.WriteString(fmt.Sprintf("; expected partial withdrawal of %s\n", string2eth.GWeiToString(uint64(estimate.Amount), true)))
+		default:
+			builder.WriteString("; no withdrawal expected\n")
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,109 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorsweep
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	estimateTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	newSummary := func() *summary {
+		return &summary{
+			Slot:       100,
+			Validators: 1000,
+			NextIndex:  10,
+			Estimates: []*sweepEstimate{
+				{Index: 12, Distance: 2, Slot: 101, Time: estimateTime, Type: withdrawalTypePartial, Amount: 12345678},
+				{Index: 15, Distance: 5, Slot: 101, Time: estimateTime, Type: withdrawalTypeNone},
+				{Index: 20, Distance: 10, Slot: 102, Time: estimateTime, Type: withdrawalTypeFull, Amount: 32000000000},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		command *command
+		res     string
+	}{
+		{
+			name: "Quiet",
+			command: &command{
+				quiet:   true,
+				summary: newSummary(),
+			},
+		},
+		{
+			name: "NoEstimates",
+			command: &command{
+				summary: &summary{
+					Slot:       100,
+					Validators: 1000,
+					NextIndex:  10,
+					Estimates:  []*sweepEstimate{},
+				},
+			},
+			res: "Next validator to sweep: 10 of 1000",
+		},
+		{
+			name: "Estimates",
+			command: &command{
+				summary: newSummary(),
+			},
+			res: `Next validator to sweep: 10 of 1000
+Validator 12: reached in slot 101 (2024-01-02 03:04:05); expected partial withdrawal of 0.012345678 Ether
+Validator 15: reached in slot 101 (2024-01-02 03:04:05); no withdrawal expected
+Validator 20: reached in slot 102 (2024-01-02 03:04:05); expected full withdrawal of 32 Ether`,
+		},
+		{
+			name: "Verbose",
+			command: &command{
+				verbose: true,
+				summary: newSummary(),
+			},
+			res: `Slot: 100
+Next validator to sweep: 10 of 1000
+Validator 12: reached in slot 101 (2024-01-02 03:04:05) after 2 validators; expected partial withdrawal of 0.012345678 Ether
+Validator 15: reached in slot 101 (2024-01-02 03:04:05) after 5 validators; no withdrawal expected
+Validator 20: reached in slot 102 (2024-01-02 03:04:05) after 10 validators; expected full withdrawal of 32 Ether`,
+		},
+		{
+			name: "JSON",
+			command: &command{
+				json: true,
+				summary: &summary{
+					Slot:       100,
+					Validators: 1000,
+					NextIndex:  10,
+					Estimates: []*sweepEstimate{
+						{Index: 12, Distance: 2, Slot: 101, Time: estimateTime, Type: withdrawalTypePartial, Amount: 12345678},
+					},
+				},
+			},
+			res: `{"slot":"100","validators":1000,"next_validator_index":"10","estimates":[{"index":"12","distance":2,"slot":"101","time":"2024-01-02T03:04:05Z","type":"partial","amount":"12345678"}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.command.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorsweep
+
+import (
+	"context"
+	"sort"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+const (
+	withdrawalTypeNone    = "none"
+	withdrawalTypePartial = "partial"
+	withdrawalTypeFull    = "full"
+)
+
+// sweepValidator contains the information about a validator required to
+// decide if the sweep will make a withdrawal for it.
+type sweepValidator struct {
+	withdrawalPrefix    byte
+	effectiveBalance    phase0.Gwei
+	maxEffectiveBalance phase0.Gwei
+	balance             phase0.Gwei
+	withdrawableEpoch   phase0.Epoch
+}
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if len(c.validators) > 0 {
+		indices, pubKeys, err := util.ParseValidatorIdentifiers(ctx, c.validators)
+		if err != nil {
+			return err
+		}
+		c.validatorsFilter = util.NewValidatorFilter(indices, pubKeys)
+	}
+
+	c.summary = &summary{}
+	var validators []sweepValidator
+	pendingPartials := make([]phase0.Epoch, 0)
+	err := util.StreamBeaconState(ctx, c.eth2Client, "head", &util.StateStreamHandler{
+		Header: func(header *util.StateStreamHeader) error {
+			c.summary.Slot = header.Slot
+			c.summary.Validators = header.Validators
+			validators = make([]sweepValidator, 0, header.Validators)
+			return nil
+		},
+		Electra: func(electra *util.StateStreamElectra) error {
+			c.summary.NextIndex = electra.NextWithdrawalValidatorIndex
+			return nil
+		},
+		Validator: func(index phase0.ValidatorIndex, validator *phase0.Validator) error {
+			validators = append(validators, sweepValidator{
+				withdrawalPrefix:    validator.WithdrawalCredentials[0],
+				effectiveBalance:    validator.EffectiveBalance,
+				maxEffectiveBalance: c.balanceParams.MaxEffectiveBalanceFor(validator.WithdrawalCredentials, true),
+				withdrawableEpoch:   validator.WithdrawableEpoch,
+			})
+			c.validatorsFilter.Resolve(index, validator.PublicKey)
+			return nil
+		},
+		Balance: func(index phase0.ValidatorIndex, balance phase0.Gwei) error {
+			validators[index].balance = balance
+			return nil
+		},
+		PendingPartialWithdrawal: func(_ uint64, withdrawal *util.PendingPartialWithdrawal) error {
+			pendingPartials = append(pendingPartials, withdrawal.WithdrawableEpoch)
+			return nil
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain state")
+	}
+
+	if c.validatorsFilter == nil {
+		c.summary.Estimates = make([]*sweepEstimate, 0)
+		return nil
+	}
+
+	targets := make([]phase0.ValidatorIndex, 0)
+	for i := range validators {
+		if c.validatorsFilter.MatchesIndex(phase0.ValidatorIndex(i)) {
+			targets = append(targets, phase0.ValidatorIndex(i))
+		}
+	}
+	if len(targets) == 0 {
+		return errors.New("no supplied validators found")
+	}
+
+	c.summary.Estimates = estimateSweep(validators,
+		c.summary.NextIndex,
+		c.summary.Slot,
+		pendingPartials,
+		targets,
+		c.sweepParams,
+		c.chainTime.SlotToEpoch,
+	)
+	for _, estimate := range c.summary.Estimates {
+		estimate.Time = c.chainTime.StartOfSlot(estimate.Slot)
+	}
+
+	return nil
+}
+
+// withdrawal returns the type and amount of the withdrawal that the sweep
+// would make for the validator at the given epoch.
+func (v *sweepValidator) withdrawal(epoch phase0.Epoch) (string, phase0.Gwei) {
+	if v.withdrawalPrefix != util.ETH1AddressWithdrawalPrefix && v.withdrawalPrefix != util.CompoundingWithdrawalPrefix {
+		return withdrawalTypeNone, 0
+	}
+	if v.withdrawableEpoch <= epoch && v.balance > 0 {
+		return withdrawalTypeFull, v.balance
+	}
+	if v.effectiveBalance == v.maxEffectiveBalance && v.balance > v.maxEffectiveBalance {
+		return withdrawalTypePartial, v.balance - v.maxEffectiveBalance
+	}
+
+	return withdrawalTypeNone, 0
+}
+
+// estimateSweep estimates the slot at which the withdrawal sweep will next
+// reach each of the target validators, along with the withdrawal it will
+// make.  It assumes that there is a block in every slot, and that the
+// balances of the validators remain unchanged.
+func estimateSweep(validators []sweepValidator,
+	nextIndex phase0.ValidatorIndex,
+	slot phase0.Slot,
+	pendingPartials []phase0.Epoch,
+	targets []phase0.ValidatorIndex,
+	params *util.SweepParameters,
+	slotToEpoch func(phase0.Slot) phase0.Epoch,
+) []*sweepEstimate {
+	res := make([]*sweepEstimate, 0, len(targets))
+	if len(validators) == 0 || params.MaxWithdrawalsPerPayload == 0 {
+		return res
+	}
+
+	remaining := make(map[phase0.ValidatorIndex]struct{}, len(targets))
+	for _, target := range targets {
+		remaining[target] = struct{}{}
+	}
+
+	bound := min(uint64(len(validators)), params.MaxValidatorsPerWithdrawalsSweep)
+	index := uint64(nextIndex) % uint64(len(validators))
+	distance := uint64(0)
+	for len(remaining) > 0 {
+		slot++
+		epoch := slotToEpoch(slot)
+		withdrawals := uint64(0)
+
+		// Pending partial withdrawals are processed ahead of the sweep.
+		for len(pendingPartials) > 0 &&
+			withdrawals < params.MaxPendingPartialsPerWithdrawalsSweep &&
+			pendingPartials[0] <= epoch {
+			withdrawals++
+			pendingPartials = pendingPartials[1:]
+		}
+
+		for i := uint64(0); i < bound && withdrawals < params.MaxWithdrawalsPerPayload; i++ {
+			withdrawalType, amount := validators[index].withdrawal(epoch)
+			if _, exists := remaining[phase0.ValidatorIndex(index)]; exists {
+				res = append(res, &sweepEstimate{
+					Index:    phase0.ValidatorIndex(index),
+					Distance: distance,
+					Slot:     slot,
+					Type:     withdrawalType,
+					Amount:   amount,
+				})
+				delete(remaining, phase0.ValidatorIndex(index))
+			}
+			if withdrawalType != withdrawalTypeNone {
+				withdrawals++
+			}
+			index = (index + 1) % uint64(len(validators))
+			distance++
+		}
+	}
+
+	sort.Slice(res, func(i int, j int) bool {
+		return res[i].Index < res[j].Index
+	})
+
+	return res
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	specResponse, err := c.eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	c.balanceParams, err = util.ObtainBalanceParameters(specResponse.Data)
+	if err != nil {
+		return err
+	}
+	if !c.balanceParams.SupportsElectra() {
+		return errors.New("chain does not support Electra")
+	}
+	c.sweepParams = util.ObtainSweepParameters(specResponse.Data)
+
+	return nil
+}
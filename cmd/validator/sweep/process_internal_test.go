@@ -0,0 +1,225 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorsweep
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestWithdrawal(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator *sweepValidator
+		epoch     phase0.Epoch
+		resType   string
+		resAmount phase0.Gwei
+	}{
+		{
+			name: "BLS",
+			validator: &sweepValidator{
+				withdrawalPrefix:    0x00,
+				effectiveBalance:    32000000000,
+				maxEffectiveBalance: 32000000000,
+				balance:             32100000000,
+				withdrawableEpoch:   0xffffffffffffffff,
+			},
+			resType: withdrawalTypeNone,
+		},
+		{
+			name: "Partial",
+			validator: &sweepValidator{
+				withdrawalPrefix:    0x01,
+				effectiveBalance:    32000000000,
+				maxEffectiveBalance: 32000000000,
+				balance:             32100000000,
+				withdrawableEpoch:   0xffffffffffffffff,
+			},
+			resType:   withdrawalTypePartial,
+			resAmount: 100000000,
+		},
+		{
+			name: "PartialCompounding",
+			validator: &sweepValidator{
+				withdrawalPrefix:    0x02,
+				effectiveBalance:    2048000000000,
+				maxEffectiveBalance: 2048000000000,
+				balance:             2048500000000,
+				withdrawableEpoch:   0xffffffffffffffff,
+			},
+			resType:   withdrawalTypePartial,
+			resAmount: 500000000,
+		},
+		{
+			name: "CompoundingBelowMaximum",
+			validator: &sweepValidator{
+				withdrawalPrefix:    0x02,
+				effectiveBalance:    64000000000,
+				maxEffectiveBalance: 2048000000000,
+				balance:             64500000000,
+				withdrawableEpoch:   0xffffffffffffffff,
+			},
+			resType: withdrawalTypeNone,
+		},
+		{
+			name: "Full",
+			validator: &sweepValidator{
+				withdrawalPrefix:    0x01,
+				effectiveBalance:    31000000000,
+				maxEffectiveBalance: 32000000000,
+				balance:             31500000000,
+				withdrawableEpoch:   100,
+			},
+			epoch:     100,
+			resType:   withdrawalTypeFull,
+			resAmount: 31500000000,
+		},
+		{
+			name: "FullNotYetWithdrawable",
+			validator: &sweepValidator{
+				withdrawalPrefix:    0x01,
+				effectiveBalance:    31000000000,
+				maxEffectiveBalance: 32000000000,
+				balance:             31500000000,
+				withdrawableEpoch:   101,
+			},
+			epoch:   100,
+			resType: withdrawalTypeNone,
+		},
+		{
+			name: "FullAlreadyWithdrawn",
+			validator: &sweepValidator{
+				withdrawalPrefix:    0x01,
+				maxEffectiveBalance: 32000000000,
+				withdrawableEpoch:   100,
+			},
+			epoch:   100,
+			resType: withdrawalTypeNone,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resType, resAmount := test.validator.withdrawal(test.epoch)
+			require.Equal(t, test.resType, resType)
+			require.Equal(t, test.resAmount, resAmount)
+		})
+	}
+}
+
+func TestEstimateSweep(t *testing.T) {
+	params := &util.SweepParameters{
+		MaxWithdrawalsPerPayload:              2,
+		MaxValidatorsPerWithdrawalsSweep:      4,
+		MaxPendingPartialsPerWithdrawalsSweep: 1,
+	}
+	slotToEpoch := func(slot phase0.Slot) phase0.Epoch {
+		return phase0.Epoch(slot / 32)
+	}
+	partial := sweepValidator{
+		withdrawalPrefix:    0x01,
+		effectiveBalance:    32000000000,
+		maxEffectiveBalance: 32000000000,
+		balance:             32100000000,
+		withdrawableEpoch:   0xffffffffffffffff,
+	}
+	none := sweepValidator{
+		withdrawalPrefix:    0x00,
+		effectiveBalance:    32000000000,
+		maxEffectiveBalance: 32000000000,
+		balance:             32100000000,
+		withdrawableEpoch:   0xffffffffffffffff,
+	}
+
+	tests := []struct {
+		name            string
+		validators      []sweepValidator
+		nextIndex       phase0.ValidatorIndex
+		pendingPartials []phase0.Epoch
+		targets         []phase0.ValidatorIndex
+		res             []*sweepEstimate
+	}{
+		{
+			name:    "Empty",
+			targets: []phase0.ValidatorIndex{0},
+			res:     []*sweepEstimate{},
+		},
+		{
+			name:       "Next",
+			validators: []sweepValidator{partial, partial, partial},
+			nextIndex:  1,
+			targets:    []phase0.ValidatorIndex{1},
+			res: []*sweepEstimate{
+				{Index: 1, Distance: 0, Slot: 101, Type: withdrawalTypePartial, Amount: 100000000},
+			},
+		},
+		{
+			name:       "PayloadFull",
+			validators: []sweepValidator{partial, partial, partial, partial, partial, partial},
+			targets:    []phase0.ValidatorIndex{1, 2, 5},
+			res: []*sweepEstimate{
+				{Index: 1, Distance: 1, Slot: 101, Type: withdrawalTypePartial, Amount: 100000000},
+				{Index: 2, Distance: 2, Slot: 102, Type: withdrawalTypePartial, Amount: 100000000},
+				{Index: 5, Distance: 5, Slot: 103, Type: withdrawalTypePartial, Amount: 100000000},
+			},
+		},
+		{
+			name:       "SweepBound",
+			validators: []sweepValidator{none, none, none, none, none, partial},
+			targets:    []phase0.ValidatorIndex{3, 5},
+			res: []*sweepEstimate{
+				{Index: 3, Distance: 3, Slot: 101, Type: withdrawalTypeNone},
+				{Index: 5, Distance: 5, Slot: 102, Type: withdrawalTypePartial, Amount: 100000000},
+			},
+		},
+		{
+			name:       "Wrap",
+			validators: []sweepValidator{partial, none, none, partial},
+			nextIndex:  3,
+			targets:    []phase0.ValidatorIndex{0},
+			res: []*sweepEstimate{
+				{Index: 0, Distance: 1, Slot: 101, Type: withdrawalTypePartial, Amount: 100000000},
+			},
+		},
+		{
+			name:            "PendingPartials",
+			validators:      []sweepValidator{partial, partial, partial},
+			pendingPartials: []phase0.Epoch{0, 0},
+			targets:         []phase0.ValidatorIndex{1},
+			res: []*sweepEstimate{
+				// Each payload has one pending partial withdrawal, leaving space for one from the sweep.
+				{Index: 1, Distance: 1, Slot: 102, Type: withdrawalTypePartial, Amount: 100000000},
+			},
+		},
+		{
+			name:            "PendingPartialsNotWithdrawable",
+			validators:      []sweepValidator{partial, partial, partial},
+			pendingPartials: []phase0.Epoch{10},
+			targets:         []phase0.ValidatorIndex{1},
+			res: []*sweepEstimate{
+				{Index: 1, Distance: 1, Slot: 101, Type: withdrawalTypePartial, Amount: 100000000},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := estimateSweep(test.validators, test.nextIndex, 100, test.pendingPartials, test.targets, params, slotToEpoch)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorsynccommittee
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Operation.
+	validator  string
+	period     string
+	jsonOutput bool
+
+	// Data access.
+	eth2Client             eth2client.Service
+	chainTime              chaintime.Service
+	specProvider           eth2client.SpecProvider
+	validatorsProvider     eth2client.ValidatorsProvider
+	syncCommitteesProvider eth2client.SyncCommitteesProvider
+	blocksProvider         eth2client.SignedBeaconBlockProvider
+
+	// Results.
+	report *participationReport
+}
+
+type participationReport struct {
+	Validator        phase0.ValidatorIndex `json:"validator_index"`
+	Period           uint64                `json:"period"`
+	FirstSlot        phase0.Slot           `json:"first_slot"`
+	LastSlot         phase0.Slot           `json:"last_slot"`
+	CommitteeIndices []uint64              `json:"committee_indices"`
+	Slots            []*slotParticipation  `json:"slots,omitempty"`
+	Included         uint64                `json:"included"`
+	Missed           uint64                `json:"missed"`
+	NoBlock          uint64                `json:"no_block"`
+	Earned           int64                 `json:"earned"`
+	Maximum          phase0.Gwei           `json:"maximum"`
+}
+
+type slotParticipation struct {
+	Slot  phase0.Slot `json:"slot"`
+	Block bool        `json:"block"`
+	// Participated is the number of the validator's positions in the
+	// sync committee that were included in the block.
+	Participated uint64 `json:"participated"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.validator = viper.GetString("validator")
+	if c.validator == "" {
+		return nil, errors.New("validator is required")
+	}
+
+	c.period = viper.GetString("period")
+	c.jsonOutput = viper.GetBool("json")
+
+	return c, nil
+}
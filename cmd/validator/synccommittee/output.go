@@ -0,0 +1,101 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorsynccommittee
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.jsonOutput {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputTxt(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.report)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	report := c.report
+	builder := strings.Builder{}
+
+	if len(report.CommitteeIndices) == 0 {
+		builder.WriteString(fmt.Sprintf("Validator %d not in sync committee for period %d", report.Validator, report.Period))
+		return builder.String(), nil
+	}
+
+	builder.WriteString(fmt.Sprintf("Period %d (slots %d-%d)\n", report.Period, report.FirstSlot, report.LastSlot))
+	if c.verbose {
+		indices := make([]string, len(report.CommitteeIndices))
+		for i := range report.CommitteeIndices {
+			indices[i] = fmt.Sprintf("%d", report.CommitteeIndices[i])
+		}
+		builder.WriteString(fmt.Sprintf("Sync committee indices: %s\n", strings.Join(indices, ", ")))
+	}
+	builder.WriteString(fmt.Sprintf("Expected: %d\n", report.Included+report.Missed+report.NoBlock))
+	builder.WriteString(fmt.Sprintf("Included: %d\n", report.Included))
+	builder.WriteString(fmt.Sprintf("Missed: %d\n", report.Missed))
+	builder.WriteString(fmt.Sprintf("No block: %d\n", report.NoBlock))
+
+	earned := string2eth.GWeiToString(uint64(report.Earned), true)
+	if report.Earned < 0 {
+		earned = fmt.Sprintf("-%s", string2eth.GWeiToString(uint64(-report.Earned), true))
+	}
+	builder.WriteString(fmt.Sprintf("Rewards: %s of a maximum %s", earned, string2eth.GWeiToString(uint64(report.Maximum), true)))
+	if report.Maximum > 0 {
+		builder.WriteString(fmt.Sprintf(" (%.2f%%)", 100*float64(report.Earned)/float64(report.Maximum)))
+	}
+
+	if c.verbose && len(report.Slots) > 0 {
+		builder.WriteString("\nPer-slot result:")
+		positions := uint64(len(report.CommitteeIndices))
+		slotsPerEpoch := c.chainTime.SlotsPerEpoch()
+		for _, slot := range report.Slots {
+			if uint64(slot.Slot)%slotsPerEpoch == 0 {
+				builder.WriteString(fmt.Sprintf("\nEpoch %d: ", c.chainTime.SlotToEpoch(slot.Slot)))
+			} else if uint64(slot.Slot)%8 == 0 {
+				builder.WriteString(" ")
+			}
+			switch {
+			case !slot.Block:
+				builder.WriteString("-")
+			case slot.Participated == positions:
+				builder.WriteString("✓")
+			case slot.Participated == 0:
+				builder.WriteString("✕")
+			default:
+				builder.WriteString("~")
+			}
+		}
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,100 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorsynccommittee
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		json   bool
+		report *participationReport
+		res    string
+	}{
+		{
+			name: "NotInCommittee",
+			report: &participationReport{
+				Validator:        12,
+				Period:           500,
+				CommitteeIndices: []uint64{},
+			},
+			res: "Validator 12 not in sync committee for period 500",
+		},
+		{
+			name: "Text",
+			report: &participationReport{
+				Validator:        12,
+				Period:           500,
+				FirstSlot:        4096000,
+				LastSlot:         4104191,
+				CommitteeIndices: []uint64{5},
+				Included:         8000,
+				Missed:           100,
+				NoBlock:          92,
+				Earned:           157000000,
+				Maximum:          180000000,
+			},
+			res: "Period 500 (slots 4096000-4104191)\nExpected: 8192\nIncluded: 8000\nMissed: 100\nNo block: 92\nRewards: 0.157 Ether of a maximum 0.18 Ether (87.22%)",
+		},
+		{
+			name: "TextNegative",
+			report: &participationReport{
+				Validator:        12,
+				Period:           500,
+				FirstSlot:        4096000,
+				LastSlot:         4104191,
+				CommitteeIndices: []uint64{5},
+				Missed:           2,
+				Earned:           -40000,
+				Maximum:          40000,
+			},
+			res: "Period 500 (slots 4096000-4104191)\nExpected: 2\nIncluded: 0\nMissed: 2\nNo block: 0\nRewards: -40000 GWei of a maximum 40000 GWei (-100.00%)",
+		},
+		{
+			name: "JSON",
+			json: true,
+			report: &participationReport{
+				Validator:        12,
+				Period:           500,
+				FirstSlot:        4096000,
+				LastSlot:         4104191,
+				CommitteeIndices: []uint64{5},
+				Slots: []*slotParticipation{
+					{Slot: 4096000, Block: true, Participated: 1},
+				},
+				Included: 1,
+				Earned:   20000,
+				Maximum:  20000,
+			},
+			res: `{"validator_index":"12","period":500,"first_slot":"4096000","last_slot":"4104191","committee_indices":[5],"slots":[{"slot":"4096000","block":true,"participated":1}],"included":1,"missed":0,"no_block":0,"earned":20000,"maximum":"20000"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				jsonOutput: test.json,
+				report:     test.report,
+			}
+			res, err := c.output(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
@@ -0,0 +1,316 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorsynccommittee
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// These values are obtained from https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/beacon-chain.md#incentivization-weights
+const (
+	syncRewardWeight  = 2
+	weightDenominator = 64
+)
+
+// rewardParameters are the chain parameters required to calculate sync
+// committee rewards.
+type rewardParameters struct {
+	effectiveBalanceIncrement uint64
+	baseRewardFactor          uint64
+	slotsPerEpoch             uint64
+	syncCommitteeSize         uint64
+}
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	err := c.setup(ctx)
+	if err != nil {
+		return err
+	}
+
+	period, err := parsePeriod(c.period, c.chainTime.CurrentSyncCommitteePeriod(), c.chainTime.AltairInitialSyncCommitteePeriod())
+	if err != nil {
+		return err
+	}
+
+	validator, err := util.ParseValidator(ctx, c.validatorsProvider, c.validator, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to parse validator")
+	}
+
+	firstEpoch := c.chainTime.FirstEpochOfSyncPeriod(period)
+	c.report = &participationReport{
+		Validator:        validator.Index,
+		Period:           period,
+		FirstSlot:        c.chainTime.FirstSlotOfEpoch(firstEpoch),
+		LastSlot:         c.chainTime.FirstSlotOfEpoch(c.chainTime.FirstEpochOfSyncPeriod(period+1)) - 1,
+		CommitteeIndices: make([]uint64, 0),
+	}
+	stateID := fmt.Sprintf("%d", c.report.FirstSlot)
+
+	if c.debug {
+		fmt.Printf("Fetching sync committee for period %d\n", period)
+	}
+	syncCommitteeResponse, err := c.syncCommitteesProvider.SyncCommittee(ctx, &api.SyncCommitteeOpts{
+		State: stateID,
+		Epoch: &firstEpoch,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain sync committee information")
+	}
+	if syncCommitteeResponse.Data == nil {
+		return errors.New("no sync committee returned")
+	}
+	for i, index := range syncCommitteeResponse.Data.Validators {
+		if index == validator.Index {
+			c.report.CommitteeIndices = append(c.report.CommitteeIndices, uint64(i))
+		}
+	}
+	if len(c.report.CommitteeIndices) == 0 {
+		return nil
+	}
+
+	params, err := c.obtainRewardParameters(ctx)
+	if err != nil {
+		return err
+	}
+	totalActiveBalance, err := c.obtainTotalActiveBalance(ctx, stateID, firstEpoch)
+	if err != nil {
+		return err
+	}
+	reward := participantReward(totalActiveBalance, params)
+
+	lastSlot := c.report.LastSlot
+	if lastSlot > c.chainTime.CurrentSlot() {
+		lastSlot = c.chainTime.CurrentSlot()
+	}
+	for slot := c.report.FirstSlot; slot <= lastSlot; slot++ {
+		participation, err := c.obtainParticipation(ctx, slot)
+		if err != nil {
+			return err
+		}
+		c.report.Slots = append(c.report.Slots, participation)
+	}
+	tally(c.report, reward)
+
+	return nil
+}
+
+// obtainParticipation obtains the participation of the validator in the
+// sync aggregate of the block at the given slot.
+func (c *command) obtainParticipation(ctx context.Context, slot phase0.Slot) (*slotParticipation, error) {
+	res := &slotParticipation{
+		Slot: slot,
+	}
+
+	if c.debug {
+		fmt.Printf("Fetching block for slot %d\n", slot)
+	}
+	blockResponse, err := c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+		Block: fmt.Sprintf("%d", slot),
+	})
+	if err != nil {
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			// No block for this slot.
+			return res, nil
+		}
+
+		return nil, errors.Wrapf(err, "failed to obtain block for slot %d", slot)
+	}
+	if blockResponse.Data == nil {
+		return res, nil
+	}
+	res.Block = true
+
+	aggregate, err := blockResponse.Data.SyncAggregate()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to obtain sync aggregate for slot %d", slot)
+	}
+	for _, index := range c.report.CommitteeIndices {
+		if aggregate.SyncCommitteeBits.BitAt(index) {
+			res.Participated++
+		}
+	}
+
+	return res, nil
+}
+
+// tally totals the participation of the validator, and the rewards earned
+// against the maximum available.
+func tally(report *participationReport, reward phase0.Gwei) {
+	positions := uint64(len(report.CommitteeIndices))
+	for _, slot := range report.Slots {
+		report.Maximum += reward * phase0.Gwei(positions)
+		if !slot.Block {
+			report.NoBlock += positions
+			continue
+		}
+		report.Included += slot.Participated
+		report.Missed += positions - slot.Participated
+		report.Earned += int64(reward) * int64(slot.Participated)
+		report.Earned -= int64(reward) * int64(positions-slot.Participated)
+	}
+}
+
+// participantReward calculates the reward for a single sync committee
+// participant in a single slot given the total active balance.
+func participantReward(totalActiveBalance phase0.Gwei, params *rewardParameters) phase0.Gwei {
+	if totalActiveBalance == 0 {
+		return 0
+	}
+	sqrt := new(big.Int).Sqrt(new(big.Int).SetUint64(uint64(totalActiveBalance))).Uint64()
+	baseRewardPerIncrement := params.effectiveBalanceIncrement * params.baseRewardFactor / sqrt
+	totalActiveIncrements := uint64(totalActiveBalance) / params.effectiveBalanceIncrement
+	totalBaseRewards := baseRewardPerIncrement * totalActiveIncrements
+	maxParticipantRewards := totalBaseRewards * syncRewardWeight / weightDenominator / params.slotsPerEpoch
+
+	return phase0.Gwei(maxParticipantRewards / params.syncCommitteeSize)
+}
+
+// parsePeriod parses the period, which can be a specific period, "current",
+// "last" or a negative value relative to the current period.
+func parsePeriod(input string, currentPeriod uint64, altairPeriod uint64) (uint64, error) {
+	var period uint64
+	switch strings.ToLower(input) {
+	case "", "current", "-0":
+		period = currentPeriod
+	case "last":
+		if currentPeriod == 0 {
+			return 0, errors.New("there is no last period")
+		}
+		period = currentPeriod - 1
+	default:
+		val, err := strconv.ParseInt(input, 10, 64)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to parse period")
+		}
+		if val >= 0 {
+			period = uint64(val)
+		} else {
+			if uint64(-val) > currentPeriod {
+				return 0, fmt.Errorf("period %s is before genesis", input)
+			}
+			period = currentPeriod - uint64(-val)
+		}
+	}
+
+	if period > currentPeriod {
+		return 0, fmt.Errorf("period %d is in the future", period)
+	}
+	if period < altairPeriod {
+		return 0, fmt.Errorf("period %d is before sync committees were introduced", period)
+	}
+
+	return period, nil
+}
+
+func (c *command) obtainRewardParameters(ctx context.Context) (*rewardParameters, error) {
+	specResponse, err := c.specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain spec")
+	}
+
+	params := &rewardParameters{}
+	for name, dest := range map[string]*uint64{
+		"EFFECTIVE_BALANCE_INCREMENT": &params.effectiveBalanceIncrement,
+		"BASE_REWARD_FACTOR":          &params.baseRewardFactor,
+		"SLOTS_PER_EPOCH":             &params.slotsPerEpoch,
+		"SYNC_COMMITTEE_SIZE":         &params.syncCommitteeSize,
+	} {
+		val, isVal := specResponse.Data[name].(uint64)
+		if !isVal || val == 0 {
+			return nil, fmt.Errorf("%s not found in spec", name)
+		}
+		*dest = val
+	}
+
+	return params, nil
+}
+
+func (c *command) obtainTotalActiveBalance(ctx context.Context, stateID string, epoch phase0.Epoch) (phase0.Gwei, error) {
+	if c.debug {
+		fmt.Printf("Fetching validators at slot %s\n", stateID)
+	}
+	response, err := c.validatorsProvider.Validators(ctx, &api.ValidatorsOpts{
+		State: stateID,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to obtain validators")
+	}
+
+	total := phase0.Gwei(0)
+	for _, validator := range response.Data {
+		if validator.Validator.ActivationEpoch <= epoch && epoch < validator.Validator.ExitEpoch {
+			total += validator.Validator.EffectiveBalance
+		}
+	}
+
+	return total, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.specProvider, isProvider = c.eth2Client.(eth2client.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validators")
+	}
+	c.syncCommitteesProvider, isProvider = c.eth2Client.(eth2client.SyncCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide sync committees")
+	}
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorsynccommittee
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		period uint64
+		err    string
+	}{
+		{
+			name:   "Default",
+			period: 500,
+		},
+		{
+			name:   "Current",
+			input:  "current",
+			period: 500,
+		},
+		{
+			name:   "Last",
+			input:  "last",
+			period: 499,
+		},
+		{
+			name:   "Specific",
+			input:  "450",
+			period: 450,
+		},
+		{
+			name:   "Relative",
+			input:  "-10",
+			period: 490,
+		},
+		{
+			name:  "Invalid",
+			input: "bad",
+			err:   "failed to parse period: strconv.ParseInt: parsing \"bad\": invalid syntax",
+		},
+		{
+			name:  "Future",
+			input: "501",
+			err:   "period 501 is in the future",
+		},
+		{
+			name:  "PreAltair",
+			input: "10",
+			err:   "period 10 is before sync committees were introduced",
+		},
+		{
+			name:  "PreGenesis",
+			input: "-501",
+			err:   "period -501 is before genesis",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			period, err := parsePeriod(test.input, 500, 20)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.period, period)
+			}
+		})
+	}
+}
+
+func TestParticipantReward(t *testing.T) {
+	params := &rewardParameters{
+		effectiveBalanceIncrement: 1000000000,
+		baseRewardFactor:          64,
+		slotsPerEpoch:             32,
+		syncCommitteeSize:         512,
+	}
+
+	require.Equal(t, phase0.Gwei(0), participantReward(0, params))
+	// 1,000,000 validators with 32 Ether each.
+	require.Equal(t, phase0.Gwei(21789), participantReward(32000000000000000, params))
+}
+
+func TestTally(t *testing.T) {
+	report := &participationReport{
+		CommitteeIndices: []uint64{5, 300},
+		Slots: []*slotParticipation{
+			{Slot: 1, Block: true, Participated: 2},
+			{Slot: 2, Block: true, Participated: 1},
+			{Slot: 3, Block: false},
+			{Slot: 4, Block: true, Participated: 0},
+		},
+	}
+	tally(report, 100)
+	require.Equal(t, uint64(3), report.Included)
+	require.Equal(t, uint64(3), report.Missed)
+	require.Equal(t, uint64(2), report.NoBlock)
+	require.Equal(t, int64(0), report.Earned)
+	require.Equal(t, phase0.Gwei(800), report.Maximum)
+}
@@ -0,0 +1,76 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorbalances "github.com/wealdtech/ethdo/cmd/validator/balances"
+)
+
+var validatorBalancesCmd = &cobra.Command{
+	Use:   "balances",
+	Short: "Export the balance history of validator(s)",
+	Long: `Export the balances of one or more validators, sampled over a range of epochs.  For example:
+
+    ethdo validator balances --validators=1,2,3 --from-epoch=1000 --to-epoch=2000 --interval=225 --csv
+
+Obtaining historical balances requires a beacon node with access to historical states.
+
+In quiet mode this will return 0 if the balances are obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := validatorbalances.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorBalancesCmd)
+	validatorFlags(validatorBalancesCmd)
+	validatorBalancesCmd.Flags().StringSlice("validators", nil, "the list of validators for which to obtain balances")
+	validatorBalancesCmd.Flags().String("from-epoch", "", "the first epoch at which to sample balances")
+	validatorBalancesCmd.Flags().String("to-epoch", "", "the last epoch at which to sample balances (defaults to current epoch)")
+	validatorBalancesCmd.Flags().Uint64("interval", 1, "the number of epochs between samples")
+	validatorBalancesCmd.Flags().Bool("csv", false, "output the balances as CSV")
+}
+
+func validatorBalancesBindings(cmd *cobra.Command) {
+	validatorBindings()
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("from-epoch", cmd.Flags().Lookup("from-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-epoch", cmd.Flags().Lookup("to-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("interval", cmd.Flags().Lookup("interval")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("csv", cmd.Flags().Lookup("csv")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorconsolidate "github.com/wealdtech/ethdo/cmd/validator/consolidate"
+)
+
+var validatorConsolidateCmd = &cobra.Command{
+	Use:   "consolidate",
+	Short: "Generate a consolidation request for a validator",
+	Long: `Generate a consolidation request to consolidate a source validator in to a target validator.  For example:
+
+    ethdo validator consolidate --source-validator=12345 --target-validator=23456
+
+The request is checked against the current state of the chain, and the details of the transaction to send to the consolidation request contract are output.  The transaction must be sent from the withdrawal address of the source validator, along with the fee required by the contract.  If the source and target validators are the same the request switches the validator to compounding withdrawal credentials.
+
+The --check flag reports whether the request would be processed by the chain and, if not, the conditions that would cause it to be skipped, rather than generating the request.  For example:
+
+    ethdo validator consolidate --source-validator=12345 --target-validator=23456 --check
+
+This command requires a chain that has reached Electra.
+
+In quiet mode this will return 0 if the consolidation request would be processed, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := validatorconsolidate.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorConsolidateCmd)
+	validatorFlags(validatorConsolidateCmd)
+	validatorConsolidateCmd.Flags().String("source-validator", "", "Validator to consolidate")
+	validatorConsolidateCmd.Flags().String("target-validator", "", "Validator in to which to consolidate")
+	validatorConsolidateCmd.Flags().String("source-address", "", "Address from which the consolidation request will be sent (defaults to the withdrawal address of the source validator)")
+	validatorConsolidateCmd.Flags().Bool("check", false, "Check if the consolidation request would be processed rather than generating it")
+}
+
+func validatorConsolidateBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("source-validator", cmd.Flags().Lookup("source-validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("target-validator", cmd.Flags().Lookup("target-validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("source-address", cmd.Flags().Lookup("source-address")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("check", cmd.Flags().Lookup("check")); err != nil {
+		panic(err)
+	}
+}
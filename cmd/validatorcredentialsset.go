@@ -61,10 +61,12 @@ func init() {
 	validatorCredentialsSetCmd.Flags().String("withdrawal-account", "", "Account with which the validator's withdrawal credentials were set")
 	validatorCredentialsSetCmd.Flags().String("withdrawal-address", "", "Execution address to which to direct withdrawals")
 	validatorCredentialsSetCmd.Flags().String("signed-operations", "", "Use pre-defined JSON signed operation as created by --json to transmit the credentials change operation (reads from change-operations.json if not present)")
-	validatorCredentialsSetCmd.Flags().Bool("offline", false, "Do not attempt to connect to a beacon node to obtain information for the operation")
 	validatorCredentialsSetCmd.Flags().String("fork-version", "", "Fork version to use for signing (overrides fetching from beacon node)")
 	validatorCredentialsSetCmd.Flags().String("genesis-validators-root", "", "Genesis validators root to use for signing (overrides fetching from beacon node)")
 	validatorCredentialsSetCmd.Flags().Uint64("max-distance", 1024, "Maximum indices to scan for finding the validator.")
+	validatorCredentialsSetCmd.Flags().String("approval-request", "change-approval-request.json", "File holding the request for approval of the credentials change operations, if approval is required by the approval policy")
+	validatorCredentialsSetCmd.Flags().Bool("allow-unchecksummed", false, "Allow an all lower-case or all upper-case withdrawal address without an EIP-55 checksum")
+	validatorCredentialsSetCmd.Flags().String("connection-execution", "", "URL to an execution node with which to check the withdrawal address")
 }
 
 func validatorCredentialsSetBindings(cmd *cobra.Command) {
@@ -83,9 +85,6 @@ func validatorCredentialsSetBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("withdrawal-address", cmd.Flags().Lookup("withdrawal-address")); err != nil {
 		panic(err)
 	}
-	if err := viper.BindPFlag("offline", cmd.Flags().Lookup("offline")); err != nil {
-		panic(err)
-	}
 	if err := viper.BindPFlag("fork-version", cmd.Flags().Lookup("fork-version")); err != nil {
 		panic(err)
 	}
@@ -95,4 +94,13 @@ func validatorCredentialsSetBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("max-distance", cmd.Flags().Lookup("max-distance")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("approval-request", cmd.Flags().Lookup("approval-request")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("allow-unchecksummed", cmd.Flags().Lookup("allow-unchecksummed")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("connection-execution", cmd.Flags().Lookup("connection-execution")); err != nil {
+		panic(err)
+	}
 }
@@ -1,4 +1,4 @@
-// Copyright © 2019 - 2022 Weald Technology Trading
+// Copyright © 2019 - 2024 Weald Technology Trading
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -28,8 +28,14 @@ var validatorDepositDataCmd = &cobra.Command{
 
     ethdo validator depositdata --validatoraccount=primary/validator --withdrawalaccount=primary/current --value="32 Ether"
 
+To generate deposit data for a validator with compounding withdrawal credentials, which can have an effective balance above 32 Ether, supply --compounding along with a withdrawal address.  For example:
+
+    ethdo validator depositdata --validatoraccount=primary/validator --withdrawaladdress=0x30C99930617B7b793beaB603ecEB08691005f2E5 --compounding --depositvalue="64 Ether"
+
 If validatoraccount is provided with an account path it will generate deposit data for all matching accounts.
 
+If a connection to a beacon node is supplied it will be used to check for existing deposits for the validators, and deposit data will not be generated for validators that already have deposits unless --force is supplied.
+
 The information generated can be passed to ethereal to create a deposit from the Ethereum 1 chain.
 
 In quiet mode this will return 0 if the data can be generated correctly, otherwise 1.`,
@@ -53,10 +59,14 @@ func init() {
 	validatorDepositDataCmd.Flags().String("withdrawalaccount", "", "Account to which the validator funds will be withdrawn")
 	validatorDepositDataCmd.Flags().String("withdrawalpubkey", "", "Public key of the account to which the validator funds will be withdrawn")
 	validatorDepositDataCmd.Flags().String("withdrawaladdress", "", "Ethereum 1 address of the account to which the validator funds will be withdrawn")
+	validatorDepositDataCmd.Flags().Bool("compounding", false, "Generate compounding withdrawal credentials for the withdrawal address")
 	validatorDepositDataCmd.Flags().String("depositvalue", "", "Value of the amount to be deposited")
 	validatorDepositDataCmd.Flags().Bool("raw", false, "Print raw deposit data transaction data")
 	validatorDepositDataCmd.Flags().String("forkversion", "", "Use a hard-coded fork version (default is to use mainnet value)")
 	validatorDepositDataCmd.Flags().Bool("launchpad", false, "Print launchpad-compatible JSON")
+	validatorDepositDataCmd.Flags().Bool("force", false, "Generate deposit data even if deposits already exist for the validator")
+	validatorDepositDataCmd.Flags().String("connection-execution", "", "URL to an execution node with which to check the withdrawal address and the deposit contract for existing deposits")
+	validatorDepositDataCmd.Flags().Bool("allow-unchecksummed", false, "Allow an all lower-case or all upper-case withdrawal address without an EIP-55 checksum")
 }
 
 func validatorDepositdataBindings(cmd *cobra.Command) {
@@ -72,6 +82,9 @@ func validatorDepositdataBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("withdrawaladdress", cmd.Flags().Lookup("withdrawaladdress")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("compounding", cmd.Flags().Lookup("compounding")); err != nil {
+		panic(err)
+	}
 	if err := viper.BindPFlag("depositvalue", cmd.Flags().Lookup("depositvalue")); err != nil {
 		panic(err)
 	}
@@ -84,4 +97,13 @@ func validatorDepositdataBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("launchpad", cmd.Flags().Lookup("launchpad")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("force", cmd.Flags().Lookup("force")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("connection-execution", cmd.Flags().Lookup("connection-execution")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("allow-unchecksummed", cmd.Flags().Lookup("allow-unchecksummed")); err != nil {
+		panic(err)
+	}
 }
@@ -0,0 +1,64 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatordoppelganger "github.com/wealdtech/ethdo/cmd/validator/doppelganger"
+)
+
+var validatorDoppelgangerCmd = &cobra.Command{
+	Use:   "doppelganger",
+	Short: "Check if validators are active elsewhere",
+	Long: `Check if validators have been active in recent epochs, to avoid running them on more than one machine.  For example:
+
+    ethdo validator doppelganger --validators=0x8f...,0x93... --epochs=3
+
+A validator is considered active if the beacon node has seen it attest, propose or take part in a sync committee in any of the epochs checked.  Many beacon nodes only hold this information for the current and previous epochs, so the check should be run over at least two epochs with the validators stopped.
+
+In quiet mode this will return 0 if none of the validators are active, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := validatordoppelganger.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorDoppelgangerCmd)
+	validatorFlags(validatorDoppelgangerCmd)
+	validatorDoppelgangerCmd.Flags().StringSlice("validators", nil, "the list of validators to check")
+	validatorDoppelgangerCmd.Flags().Uint64("epochs", 2, "the number of recent epochs to check")
+}
+
+func validatorDoppelgangerBindings(cmd *cobra.Command) {
+	validatorBindings()
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("epochs", cmd.Flags().Lookup("epochs")); err != nil {
+		panic(err)
+	}
+}
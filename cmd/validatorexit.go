@@ -36,7 +36,15 @@ The validator and key can be specified in one of a number of ways:
   - mnemonic and path to the validator using --mnemonic and --path
   - mnemonic and validator index or public key using --mnemonic and --validator
   - validator private key using --private-key
-  - validator account using --validator
+  - validator account using --validator; an account specifier that matches multiple accounts will generate an operation for each of them
+
+When generating operations for multiple validators the --concurrency flag sets the number of operations generated in parallel; this is limited to 4 when using a remote wallet daemon.  The --output-dir flag writes each operation to its own file in the given directory, along with a manifest.json file listing the operations generated and any failures, rather than broadcasting them.  For example:
+
+    ethdo validator exit --validator="Validators/.*" --concurrency=8 --output-dir=exits
+
+The --estimate flag reports the position of the validator in the exit queue, its expected exit and withdrawable epochs, and an estimate of when its final withdrawal will be made, rather than exiting the validator.  For example:
+
+    ethdo validator exit --validator=12345 --estimate
 
 In quiet mode this will return 0 if the exit operation has been generated (and successfully broadcast if online), otherwise 1.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
@@ -61,10 +69,13 @@ func init() {
 	validatorExitCmd.Flags().Bool("prepare-offline", false, "Create files for offline use")
 	validatorExitCmd.Flags().String("validator", "", "Validator to exit")
 	validatorExitCmd.Flags().String("signed-operations", "", "Use pre-defined JSON signed operation as created by --json to transmit the exit operations (reads from exit-operations.json if not present)")
-	validatorExitCmd.Flags().Bool("offline", false, "Do not attempt to connect to a beacon node to obtain information for the operation")
 	validatorExitCmd.Flags().String("fork-version", "", "Fork version to use for signing (overrides fetching from beacon node)")
 	validatorExitCmd.Flags().String("genesis-validators-root", "", "Genesis validators root to use for signing (overrides fetching from beacon node)")
 	validatorExitCmd.Flags().Uint64("max-distance", 1024, "Maximum indices to scan for finding the validator.")
+	validatorExitCmd.Flags().String("approval-request", "exit-approval-request.json", "File holding the request for approval of the exit operations, if approval is required by the approval policy")
+	validatorExitCmd.Flags().Bool("estimate", false, "Estimate the exit and final withdrawal of the validator rather than exiting it")
+	validatorExitCmd.Flags().Int("concurrency", 1, "Number of exit operations to generate concurrently when exiting multiple validators")
+	validatorExitCmd.Flags().String("output-dir", "", "Directory in which to write individual exit operations and a manifest, rather than broadcasting them")
 }
 
 func validatorExitBindings(cmd *cobra.Command) {
@@ -80,9 +91,6 @@ func validatorExitBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("signed-operations", cmd.Flags().Lookup("signed-operations")); err != nil {
 		panic(err)
 	}
-	if err := viper.BindPFlag("offline", cmd.Flags().Lookup("offline")); err != nil {
-		panic(err)
-	}
 	if err := viper.BindPFlag("fork-version", cmd.Flags().Lookup("fork-version")); err != nil {
 		panic(err)
 	}
@@ -92,4 +100,16 @@ func validatorExitBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("max-distance", cmd.Flags().Lookup("max-distance")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("approval-request", cmd.Flags().Lookup("approval-request")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("estimate", cmd.Flags().Lookup("estimate")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("concurrency", cmd.Flags().Lookup("concurrency")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("output-dir", cmd.Flags().Lookup("output-dir")); err != nil {
+		panic(err)
+	}
 }
@@ -1,4 +1,4 @@
-// Copyright © 2020 - 2022 Weald Technology Trading
+// Copyright © 2020 - 2024 Weald Technology Trading
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -21,11 +21,14 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 
 	eth2client "github.com/attestantio/go-eth2-client"
-	api "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -42,6 +45,12 @@ var validatorInfoCmd = &cobra.Command{
 
     ethdo validator info --validator=primary/validator
 
+Information for many validators can be obtained at once by listing them, one per line, in a file.  For example:
+
+    ethdo validator info --from-file=pubkeys.txt
+
+The validators are obtained in batches and output as a table, or as one JSON object per validator with --json.
+
 In quiet mode this will return 0 if the validator information can be obtained, otherwise 1.`,
 	Run: func(_ *cobra.Command, _ []string) {
 		ctx := context.Background()
@@ -62,14 +71,40 @@ In quiet mode this will return 0 if the validator information can be obtained, o
 			errCheck(err, "failed to set up chaintime service")
 		}
 
+		if viper.GetString("from-file") != "" {
+			if viper.GetString("validator") != "" {
+				fmt.Println("only one of validator and from-file can be supplied")
+				os.Exit(_exitFailure)
+			}
+			found, err := validatorInfoBatch(ctx, eth2Client, viper.GetString("from-file"))
+			errCheck(err, "Failed to obtain validators")
+			if !found {
+				os.Exit(_exitFailure)
+			}
+			os.Exit(_exitSuccess)
+		}
+
 		if viper.GetString("validator") == "" {
-			fmt.Println("validator is required")
+			fmt.Println("validator or from-file is required")
 			os.Exit(_exitFailure)
 		}
 
 		validator, err := util.ParseValidator(ctx, eth2Client.(eth2client.ValidatorsProvider), viper.GetString("validator"), "head")
 		errCheck(err, "Failed to obtain validator")
 
+		specResponse, err := eth2Client.(eth2client.SpecProvider).Spec(ctx, &api.SpecOpts{})
+		errCheck(err, "Failed to obtain spec")
+		balanceParams, err := util.ObtainBalanceParameters(specResponse.Data)
+		errCheck(err, "Failed to obtain balance parameters")
+		postElectra := chainTime.CurrentEpoch() >= chainTime.ElectraInitialEpoch()
+
+		// Operations awaiting processing are only tracked in the state from Electra.
+		var pendingOperations *util.ValidatorPendingOperations
+		if postElectra {
+			pendingOperations, err = util.ObtainValidatorPendingOperations(ctx, eth2Client, "head", validator.Index, validator.Validator.PublicKey)
+			errCheck(err, "Failed to obtain pending operations")
+		}
+
 		if viper.GetBool("verbose") {
 			network, err := util.Network(ctx, eth2Client)
 			errCheck(err, "Failed to obtain network")
@@ -93,7 +128,7 @@ In quiet mode this will return 0 if the validator information can be obtained, o
 		}
 		if viper.GetBool("verbose") {
 			if validator.Status.IsPending() {
-				if validator.Validator.ActivationEpoch == 0xffffffffffffffff {
+				if validator.Validator.ActivationEpoch == util.FarFutureEpoch {
 					fmt.Printf("Activation eligibility epoch: %d\n", validator.Validator.ActivationEligibilityEpoch)
 					fmt.Printf("Activation eligibility timestamp: %v\n", chainTime.StartOfEpoch(validator.Validator.ActivationEligibilityEpoch))
 				} else {
@@ -108,23 +143,126 @@ In quiet mode this will return 0 if the validator information can be obtained, o
 		}
 		fmt.Printf("Status: %v\n", validator.Status)
 		switch validator.Status {
-		case api.ValidatorStateActiveExiting, api.ValidatorStateActiveSlashed:
+		case apiv1.ValidatorStateActiveExiting, apiv1.ValidatorStateActiveSlashed:
 			fmt.Printf("Exit epoch: %d\n", validator.Validator.ExitEpoch)
-		case api.ValidatorStateExitedUnslashed, api.ValidatorStateExitedSlashed:
+		case apiv1.ValidatorStateExitedUnslashed, apiv1.ValidatorStateExitedSlashed:
 			fmt.Printf("Withdrawable epoch: %d\n", validator.Validator.WithdrawableEpoch)
 		}
 		fmt.Printf("Balance: %s\n", string2eth.GWeiToString(uint64(validator.Balance), true))
 		if validator.Status.IsActive() {
-			fmt.Printf("Effective balance: %s\n", string2eth.GWeiToString(uint64(validator.Validator.EffectiveBalance), true))
+			fmt.Printf("Effective balance: %s (maximum %s)\n",
+				string2eth.GWeiToString(uint64(validator.Validator.EffectiveBalance), true),
+				string2eth.GWeiToString(uint64(balanceParams.MaxEffectiveBalanceFor(validator.Validator.WithdrawalCredentials, postElectra)), true),
+			)
 		}
+		fmt.Printf("Withdrawal credentials type: %s\n", util.WithdrawalCredentialsType(validator.Validator.WithdrawalCredentials))
 		if viper.GetBool("verbose") {
 			fmt.Printf("Withdrawal credentials: %#x\n", validator.Validator.WithdrawalCredentials)
 		}
+		if pendingOperations != nil {
+			outputValidatorPendingOperations(pendingOperations)
+		}
 
 		os.Exit(_exitSuccess)
 	},
 }
 
+// validatorInfoBatch outputs information about the validators listed in the
+// given file, which are obtained from the beacon node in batches.  It returns
+// true if all of the validators were found.
+func validatorInfoBatch(ctx context.Context, eth2Client eth2client.Service, path string) (bool, error) {
+	if _, err := os.Stat(path); err != nil {
+		return false, errors.Wrap(err, "failed to access validators file")
+	}
+	inputs, err := util.ExpandInputs([]string{path})
+	if err != nil {
+		return false, err
+	}
+	indices, pubKeys, err := util.ParseValidatorIdentifiers(ctx, inputs)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to parse validators")
+	}
+
+	fetched, err := util.FetchValidators(ctx, eth2Client.(eth2client.ValidatorsProvider), "head", indices, pubKeys)
+	if err != nil {
+		return false, err
+	}
+	missing := util.MissingValidators(indices, pubKeys, fetched)
+	validators := make([]*apiv1.Validator, 0, len(fetched))
+	for _, validator := range fetched {
+		validators = append(validators, validator)
+	}
+	sort.Slice(validators, func(i int, j int) bool {
+		return validators[i].Index < validators[j].Index
+	})
+
+	if viper.GetBool("quiet") {
+		return len(missing) == 0, nil
+	}
+
+	if viper.GetBool("json") {
+		// One line per validator.
+		for _, validator := range validators {
+			data, err := json.Marshal(validator)
+			if err != nil {
+				return false, errors.Wrap(err, "failed to marshal validator")
+			}
+			fmt.Println(string(data))
+		}
+		for _, validator := range missing {
+			fmt.Fprintf(os.Stderr, "Validator %s not found\n", validator)
+		}
+
+		return len(missing) == 0, nil
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "Index\tPublic key\tStatus\tBalance\tEffective balance\tCredentials")
+	for _, validator := range validators {
+		fmt.Fprintf(writer, "%d\t%#x\t%v\t%s\t%s\t%#02x\n",
+			validator.Index,
+			validator.Validator.PublicKey,
+			validator.Status,
+			string2eth.GWeiToString(uint64(validator.Balance), true),
+			string2eth.GWeiToString(uint64(validator.Validator.EffectiveBalance), true),
+			validator.Validator.WithdrawalCredentials[0],
+		)
+	}
+	for _, validator := range missing {
+		if strings.HasPrefix(validator, "0x") {
+			fmt.Fprintf(writer, "-\t%s\tunknown\n", validator)
+		} else {
+			fmt.Fprintf(writer, "%s\t-\tunknown\n", validator)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return false, errors.Wrap(err, "failed to output validators")
+	}
+
+	return len(missing) == 0, nil
+}
+
+// outputValidatorPendingOperations outputs the operations for the validator
+// that are awaiting processing by the chain.
+func outputValidatorPendingOperations(operations *util.ValidatorPendingOperations) {
+	if len(operations.Deposits) > 0 {
+		fmt.Printf("Pending deposits: %d (%s)\n", len(operations.Deposits), string2eth.GWeiToString(uint64(operations.DepositsAmount()), true))
+	}
+	if len(operations.PartialWithdrawals) > 0 {
+		fmt.Printf("Pending partial withdrawals: %d (%s)\n", len(operations.PartialWithdrawals), string2eth.GWeiToString(uint64(operations.PartialWithdrawalsAmount()), true))
+	}
+	for _, consolidation := range operations.ConsolidationsTo {
+		fmt.Printf("Consolidating in to validator %d\n", consolidation.TargetIndex)
+	}
+	if len(operations.ConsolidationsFrom) > 0 {
+		sources := make([]string, 0, len(operations.ConsolidationsFrom))
+		for _, consolidation := range operations.ConsolidationsFrom {
+			sources = append(sources, fmt.Sprintf("%d", consolidation.SourceIndex))
+		}
+		fmt.Printf("Consolidating from validators: %s\n", strings.Join(sources, ", "))
+	}
+}
+
 // graphData returns data from the graph about number and amount of deposits.
 func graphData(network string, validatorPubKey []byte) (uint64, spec.Gwei, error) {
 	subgraph := ""
@@ -186,6 +324,7 @@ func graphData(network string, validatorPubKey []byte) (uint64, spec.Gwei, error
 func init() {
 	validatorCmd.AddCommand(validatorInfoCmd)
 	validatorInfoCmd.Flags().String("validator", "", "Public key for which to obtain status")
+	validatorInfoCmd.Flags().String("from-file", "", "path to a file containing the validators for which to obtain status, one per line")
 	validatorFlags(validatorInfoCmd)
 }
 
@@ -193,4 +332,7 @@ func validatorInfoBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("from-file", cmd.Flags().Lookup("from-file")); err != nil {
+		panic(err)
+	}
 }
@@ -0,0 +1,70 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorperformance "github.com/wealdtech/ethdo/cmd/validator/performance"
+)
+
+var validatorPerformanceCmd = &cobra.Command{
+	Use:   "performance",
+	Short: "Report on the performance of validator(s) over a number of epochs",
+	Long: `Report on the attestation, proposal and sync committee performance of one or more validators over a number of epochs.  For example:
+
+    ethdo validator performance --validators=1,2,3 --epochs=10
+
+In quiet mode this will return 0 if the performance is obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := validatorperformance.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorPerformanceCmd)
+	validatorFlags(validatorPerformanceCmd)
+	validatorPerformanceCmd.Flags().String("epoch", "", "the last epoch for which to report (defaults to the most recent epoch with all attestations included)")
+	validatorPerformanceCmd.Flags().Uint64("epochs", 1, "the number of epochs for which to report")
+	validatorPerformanceCmd.Flags().StringSlice("validators", nil, "the list of validators for which to report")
+	validatorPerformanceCmd.Flags().Bool("csv", false, "output the report as CSV")
+}
+
+func validatorPerformanceBindings(cmd *cobra.Command) {
+	validatorBindings()
+	if err := viper.BindPFlag("epoch", cmd.Flags().Lookup("epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("epochs", cmd.Flags().Lookup("epochs")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("csv", cmd.Flags().Lookup("csv")); err != nil {
+		panic(err)
+	}
+}
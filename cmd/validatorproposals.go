@@ -0,0 +1,72 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorproposals "github.com/wealdtech/ethdo/cmd/validator/proposals"
+)
+
+var validatorProposalsCmd = &cobra.Command{
+	Use:   "proposals",
+	Short: "List the block proposals of a validator",
+	Long: `List the blocks proposed and missed by a validator over a range of epochs.  For example:
+
+    ethdo validator proposals --validator=12345 --from-epoch=1000 --to-epoch=2000
+
+Rewards are shown where the beacon node provides them.
+
+In quiet mode this will return 0 if the proposals are obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := validatorproposals.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorProposalsCmd)
+	validatorFlags(validatorProposalsCmd)
+	validatorProposalsCmd.Flags().String("validator", "", "the validator for which to list proposals")
+	validatorProposalsCmd.Flags().String("from-epoch", "", "the first epoch for which to list proposals")
+	validatorProposalsCmd.Flags().String("to-epoch", "", "the last epoch for which to list proposals (defaults to current epoch)")
+	validatorProposalsCmd.Flags().Bool("csv", false, "output the proposals as CSV")
+}
+
+func validatorProposalsBindings(cmd *cobra.Command) {
+	validatorBindings()
+	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("from-epoch", cmd.Flags().Lookup("from-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-epoch", cmd.Flags().Lookup("to-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("csv", cmd.Flags().Lookup("csv")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorqueue "github.com/wealdtech/ethdo/cmd/validator/queue"
+)
+
+var validatorQueueCmd = &cobra.Command{
+	Use:   "queue",
+	Short: "Obtain the activation queue position of a validator",
+	Long: `Obtain the position of a pending validator in the activation queue, along with an estimate of its activation epoch.  For example:
+
+    ethdo validator queue --validator=primary/validator
+
+The estimate is based on the current activation queue and churn limit, and assumes that the chain continues to finalize normally.
+
+In quiet mode this will return 0 if the position is obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := validatorqueue.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorQueueCmd)
+	validatorFlags(validatorQueueCmd)
+	validatorQueueCmd.Flags().String("validator", "", "the index, public key, or account of the validator")
+}
+
+func validatorQueueBindings(cmd *cobra.Command) {
+	validatorBindings()
+	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+}
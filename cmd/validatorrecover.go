@@ -0,0 +1,63 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorrecover "github.com/wealdtech/ethdo/cmd/validator/recover"
+)
+
+var validatorRecoverCmd = &cobra.Command{
+	Use:   "recover",
+	Short: "Recover validators from a mnemonic",
+	Long: `Recover validators from a mnemonic, by deriving validator keys over a range of indices and checking them against the chain.  For example:
+
+    ethdo validator recover --mnemonic="..." --start-index=0 --count=1000
+
+Keys are derived using the standard path m/12381/3600/<index>/0/0.
+
+In quiet mode this will return 0 if any validators are found, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := validatorrecover.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorRecoverCmd)
+	validatorFlags(validatorRecoverCmd)
+	validatorRecoverCmd.Flags().Uint64("start-index", 0, "First index to derive")
+	validatorRecoverCmd.Flags().Uint64("count", 1024, "Number of indices to derive")
+}
+
+func validatorRecoverBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("start-index", cmd.Flags().Lookup("start-index")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("count", cmd.Flags().Lookup("count")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorregistration "github.com/wealdtech/ethdo/cmd/validator/registration"
+)
+
+var validatorRegistrationCmd = &cobra.Command{
+	Use:   "registration",
+	Short: "Create builder API validator registrations",
+	Long: `Create signed validator registrations for the builder API, for submission to relays or mev-boost.  For example:
+
+    ethdo validator registration --account="Validators/.*" --fee-recipient=0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F
+
+A registration is created for each account that matches the account specifier.  Registrations are signed in the application builder domain, using the genesis fork version supplied with --forkversion or mainnet's if not supplied.
+
+In quiet mode this will return 0 if the registrations have been created, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := validatorregistration.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorRegistrationCmd)
+	validatorFlags(validatorRegistrationCmd)
+	validatorRegistrationCmd.Flags().String("fee-recipient", "", "the execution address to receive fees")
+	validatorRegistrationCmd.Flags().Uint64("gas-limit", 30000000, "the preferred gas limit for blocks")
+	validatorRegistrationCmd.Flags().String("timestamp", "", "the time of the registration, as a Unix time or RFC 3339 time (defaults to now)")
+	validatorRegistrationCmd.Flags().String("forkversion", "", "genesis fork version of the chain (default is to use mainnet value)")
+}
+
+func validatorRegistrationBindings(cmd *cobra.Command) {
+	validatorBindings()
+	if err := viper.BindPFlag("fee-recipient", cmd.Flags().Lookup("fee-recipient")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("gas-limit", cmd.Flags().Lookup("gas-limit")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("timestamp", cmd.Flags().Lookup("timestamp")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("forkversion", cmd.Flags().Lookup("forkversion")); err != nil {
+		panic(err)
+	}
+}
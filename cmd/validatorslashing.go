@@ -0,0 +1,60 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorslashing "github.com/wealdtech/ethdo/cmd/validator/slashing"
+)
+
+var validatorSlashingCmd = &cobra.Command{
+	Use:   "slashing",
+	Short: "Obtain details of the slashing of a validator",
+	Long: `Obtain details of the slashing of a validator, including the block in which it was slashed, the conflicting messages that caused it, and the resulting penalties.  For example:
+
+    ethdo validator slashing --validator=12345
+
+Penalties are calculated from historical balances, so require a beacon node with access to historical states.
+
+In quiet mode this will return 0 if the slashing details are obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := validatorslashing.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorSlashingCmd)
+	validatorFlags(validatorSlashingCmd)
+	validatorSlashingCmd.Flags().String("validator", "", "the validator for which to obtain slashing details")
+}
+
+func validatorSlashingBindings(cmd *cobra.Command) {
+	validatorBindings()
+	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorsweep "github.com/wealdtech/ethdo/cmd/validator/sweep"
+)
+
+var validatorSweepCmd = &cobra.Command{
+	Use:   "sweep",
+	Short: "Preview the withdrawal sweep for one or more validators",
+	Long: `Preview the withdrawal sweep, showing the next validator that it will reach and, for the supplied validators, when it will next reach them and the withdrawal that it will make.  For example:
+
+    ethdo validator sweep --validators=Validators/1,12345
+
+Estimates assume that there is a block in every slot and that validator balances remain unchanged, so the withdrawal amounts do not include any rewards received before the sweep reaches the validator.
+
+This command requires a chain that has reached Electra.
+
+In quiet mode this will return 0 if the sweep can be previewed, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := validatorsweep.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorSweepCmd)
+	validatorFlags(validatorSweepCmd)
+	validatorSweepCmd.Flags().StringSlice("validators", nil, "the list of validators for which to preview the sweep")
+}
+
+func validatorSweepBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorsynccommittee "github.com/wealdtech/ethdo/cmd/validator/synccommittee"
+)
+
+var validatorSyncCommitteeCmd = &cobra.Command{
+	Use:   "synccommittee",
+	Short: "Report the sync committee participation of a validator",
+	Long: `Report the participation of a validator in a sync committee, including missed contributions and rewards earned against the theoretical maximum.  For example:
+
+    ethdo validator synccommittee --validator=12345 --period=current
+
+period can be a specific period, 'current' for the current period, 'last' for the previous period, or a negative number for a period relative to the current period.
+
+In quiet mode this will return 0 if the participation is obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := validatorsynccommittee.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorSyncCommitteeCmd)
+	validatorFlags(validatorSyncCommitteeCmd)
+	validatorSyncCommitteeCmd.Flags().String("validator", "", "the validator for which to report participation")
+	validatorSyncCommitteeCmd.Flags().String("period", "", "the sync committee period for which to report participation (defaults to current period)")
+}
+
+func validatorSyncCommitteeBindings(cmd *cobra.Command) {
+	validatorBindings()
+	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("period", cmd.Flags().Lookup("period")); err != nil {
+		panic(err)
+	}
+}
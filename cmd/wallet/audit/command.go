@@ -0,0 +1,68 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletaudit
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	timeout time.Duration
+
+	// Operation.
+	duplicates         bool
+	listing            string
+	additionalBaseDirs []string
+
+	// Results.
+	accounts       int
+	duplicatedKeys []*duplicatedKey
+}
+
+type duplicatedKey struct {
+	PubKey    string   `json:"pubkey"`
+	Locations []string `json:"locations"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:              viper.GetBool("quiet"),
+		verbose:            viper.GetBool("verbose"),
+		debug:              viper.GetBool("debug"),
+		json:               viper.GetBool("json"),
+		timeout:            viper.GetDuration("timeout"),
+		duplicates:         viper.GetBool("duplicates"),
+		listing:            viper.GetString("listing"),
+		additionalBaseDirs: viper.GetStringSlice("additional-base-dirs"),
+	}
+
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if !c.duplicates {
+		return nil, errors.New("no audit checks requested; supply --duplicates")
+	}
+
+	return c, nil
+}
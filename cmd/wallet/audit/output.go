@@ -0,0 +1,50 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletaudit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		data, err := json.Marshal(c.duplicatedKeys)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	if len(c.duplicatedKeys) == 0 {
+		return fmt.Sprintf("No duplicate public keys found in %d accounts", c.accounts), nil
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("%d duplicate public keys found:", len(c.duplicatedKeys)))
+	for _, duplicatedKey := range c.duplicatedKeys {
+		builder.WriteString(fmt.Sprintf("\n%s", duplicatedKey.PubKey))
+		for _, location := range duplicatedKey.Locations {
+			builder.WriteString(fmt.Sprintf("\n  %s", location))
+		}
+	}
+
+	return builder.String(), nil
+}
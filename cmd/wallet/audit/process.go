@@ -0,0 +1,143 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletaudit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	"github.com/wealdtech/go-bytesutil"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	filesystem "github.com/wealdtech/go-eth2-wallet-store-filesystem"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func (c *command) process(ctx context.Context) error {
+	if err := util.EnsureStore(); err != nil {
+		return errors.Wrap(err, "failed to set up wallet store")
+	}
+
+	// locations is a map of public key to the locations in which it is found.
+	locations := make(map[string][]string)
+
+	if err := c.scanWallets(ctx, e2wallet.Wallets(), "", locations); err != nil {
+		return err
+	}
+	for _, baseDir := range c.additionalBaseDirs {
+		store := filesystem.New(filesystem.WithLocation(baseDir))
+		if err := c.scanWallets(ctx, e2wallet.Wallets(e2wallet.WithStore(store)), fmt.Sprintf("%s:", baseDir), locations); err != nil {
+			return err
+		}
+	}
+
+	if c.listing != "" {
+		pubKeys, err := c.readListing()
+		if err != nil {
+			return err
+		}
+		for _, pubKey := range pubKeys {
+			locations[pubKey] = append(locations[pubKey], fmt.Sprintf("listing %s", c.listing))
+		}
+	}
+
+	for pubKey, pubKeyLocations := range locations {
+		if len(pubKeyLocations) > 1 {
+			sort.Strings(pubKeyLocations)
+			c.duplicatedKeys = append(c.duplicatedKeys, &duplicatedKey{
+				PubKey:    pubKey,
+				Locations: pubKeyLocations,
+			})
+		}
+	}
+	sort.Slice(c.duplicatedKeys, func(i int, j int) bool {
+		return c.duplicatedKeys[i].PubKey < c.duplicatedKeys[j].PubKey
+	})
+
+	return nil
+}
+
+// scanWallets adds the public keys of all accounts in the supplied wallets to the locations.
+func (c *command) scanWallets(ctx context.Context,
+	wallets <-chan e2wtypes.Wallet,
+	prefix string,
+	locations map[string][]string,
+) error {
+	for wallet := range wallets {
+		accountsProvider, isAccountsProvider := wallet.(e2wtypes.WalletAccountsProvider)
+		if !isAccountsProvider {
+			continue
+		}
+		for account := range accountsProvider.Accounts(ctx) {
+			pubKey, err := util.BestPublicKey(account)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("failed to obtain public key for %s/%s", wallet.Name(), account.Name()))
+			}
+			key := fmt.Sprintf("%#x", pubKey.Marshal())
+			locations[key] = append(locations[key], fmt.Sprintf("%s%s/%s", prefix, wallet.Name(), account.Name()))
+			c.accounts++
+			if c.debug {
+				fmt.Fprintf(os.Stderr, "Found %s in %s%s/%s\n", key, prefix, wallet.Name(), account.Name())
+			}
+		}
+	}
+
+	return nil
+}
+
+// readListing reads public keys from a keymanager API or Web3Signer listing.
+func (c *command) readListing() ([]string, error) {
+	data, err := os.ReadFile(c.listing)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read listing")
+	}
+
+	var pubKeys []string
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		// Web3Signer listing, an array of public keys.
+		if err := json.Unmarshal(data, &pubKeys); err != nil {
+			return nil, errors.Wrap(err, "invalid Web3Signer listing")
+		}
+	} else {
+		// Keymanager API listing.
+		listing := struct {
+			Data []*struct {
+				ValidatingPubkey string `json:"validating_pubkey"`
+			} `json:"data"`
+		}{}
+		if err := json.Unmarshal(data, &listing); err != nil {
+			return nil, errors.Wrap(err, "invalid keymanager listing")
+		}
+		for _, entry := range listing.Data {
+			pubKeys = append(pubKeys, entry.ValidatingPubkey)
+		}
+	}
+
+	// Normalise the public keys.
+	res := make([]string, 0, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		tmp, err := bytesutil.FromHexString(pubKey)
+		if err != nil || len(tmp) != 48 {
+			return nil, fmt.Errorf("invalid public key %q in listing", pubKey)
+		}
+		res = append(res, fmt.Sprintf("%#x", tmp))
+	}
+
+	return res, nil
+}
@@ -0,0 +1,154 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletaudit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	filesystem "github.com/wealdtech/go-eth2-wallet-store-filesystem"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func importAccount(t *testing.T, wallet e2wtypes.Wallet, name string, key string) {
+	t.Helper()
+
+	_, err := wallet.(e2wtypes.WalletAccountImporter).ImportAccount(context.Background(), name, testutil.HexToBytes(key), []byte("pass"))
+	require.NoError(t, err)
+}
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx := context.Background()
+
+	store := scratch.New()
+	require.NoError(t, e2wallet.UseStore(store))
+	wallet1, err := nd.CreateWallet(ctx, "Wallet 1", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet1.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	importAccount(t, wallet1, "Interop 0", "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866")
+	wallet2, err := nd.CreateWallet(ctx, "Wallet 2", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet2.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	importAccount(t, wallet2, "Interop 1", "0x51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000")
+
+	baseDir := t.TempDir()
+	wallet3, err := nd.CreateWallet(ctx, "Wallet 3", filesystem.New(filesystem.WithLocation(baseDir)), keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet3.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	importAccount(t, wallet3, "Copy of interop 0", "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866")
+
+	listingDir := t.TempDir()
+	keymanagerListing := filepath.Join(listingDir, "keymanager.json")
+	require.NoError(t, os.WriteFile(keymanagerListing, []byte(`{"data":[{"validating_pubkey":"0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b","derivation_path":"","readonly":false}]}`), 0o600))
+	web3SignerListing := filepath.Join(listingDir, "web3signer.json")
+	require.NoError(t, os.WriteFile(web3SignerListing, []byte(`["0xB89BEBC699769726A318C8E9971BD3171297C61AEA4A6578A7A4F94B547DCBA5BAC16A89108B6B6A1FE3695D1A874A0B"]`), 0o600))
+	badListing := filepath.Join(listingDir, "bad.json")
+	require.NoError(t, os.WriteFile(badListing, []byte(`["0x01"]`), 0o600))
+
+	tests := []struct {
+		name       string
+		command    *command
+		err        string
+		accounts   int
+		duplicates []*duplicatedKey
+	}{
+		{
+			name:     "Store",
+			command:  &command{duplicates: true},
+			accounts: 2,
+		},
+		{
+			name: "AdditionalBaseDir",
+			command: &command{
+				duplicates:         true,
+				additionalBaseDirs: []string{baseDir},
+			},
+			accounts: 3,
+			duplicates: []*duplicatedKey{
+				{
+					PubKey: "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+					Locations: []string{
+						baseDir + ":Wallet 3/Copy of interop 0",
+						"Wallet 1/Interop 0",
+					},
+				},
+			},
+		},
+		{
+			name: "KeymanagerListing",
+			command: &command{
+				duplicates: true,
+				listing:    keymanagerListing,
+			},
+			accounts: 2,
+			duplicates: []*duplicatedKey{
+				{
+					PubKey: "0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b",
+					Locations: []string{
+						"Wallet 2/Interop 1",
+						"listing " + keymanagerListing,
+					},
+				},
+			},
+		},
+		{
+			name: "Web3SignerListing",
+			command: &command{
+				duplicates: true,
+				listing:    web3SignerListing,
+			},
+			accounts: 2,
+			duplicates: []*duplicatedKey{
+				{
+					PubKey: "0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b",
+					Locations: []string{
+						"Wallet 2/Interop 1",
+						"listing " + web3SignerListing,
+					},
+				},
+			},
+		},
+		{
+			name: "BadListing",
+			command: &command{
+				duplicates: true,
+				listing:    badListing,
+			},
+			err: `invalid public key "0x01" in listing`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.command.process(ctx)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.accounts, test.command.accounts)
+				require.Equal(t, test.duplicates, test.command.duplicatedKeys)
+			}
+		})
+	}
+}
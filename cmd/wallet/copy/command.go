@@ -0,0 +1,92 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletcopy
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	timeout time.Duration
+
+	// Input.
+	wallet       e2wtypes.Wallet
+	toStore      e2wtypes.Store
+	deleteSource bool
+
+	// Output.
+	accounts       int
+	sourceDeleted  bool
+	toStoreName    string
+	toStoreDetails string
+}
+
+func newCommand(ctx context.Context) (*command, error) {
+	c := &command{
+		quiet:        viper.GetBool("quiet"),
+		verbose:      viper.GetBool("verbose"),
+		debug:        viper.GetBool("debug"),
+		timeout:      viper.GetDuration("timeout"),
+		deleteSource: viper.GetBool("delete-source"),
+		toStoreName:  viper.GetString("to-store"),
+	}
+
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if viper.GetString("wallet") == "" {
+		return nil, errors.New("wallet is required")
+	}
+	if c.toStoreName == "" {
+		return nil, errors.New("to-store is required")
+	}
+
+	// Obtain the source store type before the store is set up, as setting up
+	// the store replaces its configuration.
+	fromStoreName := viper.GetString("store")
+	toBaseDir := viper.GetString("to-base-dir")
+	if fromStoreName == c.toStoreName && filepath.Clean(util.GetBaseDir()) == filepath.Clean(toBaseDir) {
+		return nil, errors.New("source and destination stores are the same")
+	}
+	var err error
+	c.toStore, err = util.NewStore(c.toStoreName, toBaseDir, viper.GetString("to-store-passphrase"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set up destination store")
+	}
+	c.toStoreDetails = c.toStoreName
+	if toBaseDir != "" {
+		c.toStoreDetails = toBaseDir
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	c.wallet, err = util.WalletFromInput(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain wallet")
+	}
+
+	return c, nil
+}
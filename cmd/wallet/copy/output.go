@@ -0,0 +1,34 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletcopy
+
+import (
+	"context"
+	"fmt"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	res := fmt.Sprintf("Copied wallet %s with %d accounts to %s and verified the copy", c.wallet.Name(), c.accounts, c.toStoreDetails)
+	if c.sourceDeleted {
+		res += "\nDeleted the source wallet"
+	} else {
+		res += fmt.Sprintf("\nThe source wallet has not been changed; once the copy is in use it can be removed with: ethdo wallet delete --wallet=%q", c.wallet.Name())
+	}
+
+	return res, nil
+}
@@ -0,0 +1,198 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletcopy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// storedAccount is the stored data for an account.
+type storedAccount struct {
+	id   uuid.UUID
+	data []byte
+}
+
+func (c *command) process(ctx context.Context) error {
+	storeProvider, isStoreProvider := c.wallet.(e2wtypes.StoreProvider)
+	if !isStoreProvider {
+		return errors.New("wallet does not provide its store")
+	}
+	fromStore := storeProvider.Store()
+	walletIDProvider, isWalletIDProvider := c.wallet.(e2wtypes.WalletIDProvider)
+	if !isWalletIDProvider {
+		return errors.New("wallet does not provide its ID")
+	}
+	walletID := walletIDProvider.ID()
+	if c.deleteSource && fromStore.Name() != "filesystem" {
+		return fmt.Errorf("cannot delete wallets from %s stores; remove the source wallet manually", fromStore.Name())
+	}
+
+	if _, err := c.toStore.RetrieveWallet(c.wallet.Name()); err == nil {
+		return fmt.Errorf("wallet %q already exists in the destination store", c.wallet.Name())
+	}
+	if _, err := c.toStore.RetrieveWalletByID(walletID); err == nil {
+		return fmt.Errorf("wallet with ID %s already exists in the destination store", walletID)
+	}
+
+	// Obtain all of the wallet's data before writing anything.
+	walletData, err := fromStore.RetrieveWallet(c.wallet.Name())
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve wallet")
+	}
+	accounts, err := retrieveAccounts(fromStore, walletID)
+	if err != nil {
+		return err
+	}
+	// The index and batch are optional, so ignore errors retrieving them.
+	index, _ := fromStore.RetrieveAccountsIndex(walletID)
+	var batch []byte
+	if batchRetriever, isBatchRetriever := fromStore.(e2wtypes.BatchRetriever); isBatchRetriever {
+		batch, _ = batchRetriever.RetrieveBatch(ctx, walletID)
+	}
+	batchStorer, isBatchStorer := c.toStore.(e2wtypes.BatchStorer)
+	if len(batch) > 0 && !isBatchStorer {
+		return errors.New("destination store does not support account batches")
+	}
+
+	if err := c.toStore.StoreWallet(walletID, c.wallet.Name(), walletData); err != nil {
+		return errors.Wrap(err, "failed to store wallet")
+	}
+	for _, account := range accounts {
+		if err := c.toStore.StoreAccount(walletID, account.id, account.data); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to store account %s", account.id))
+		}
+	}
+	if len(index) > 0 {
+		if err := c.toStore.StoreAccountsIndex(walletID, index); err != nil {
+			return errors.Wrap(err, "failed to store accounts index")
+		}
+	}
+	if len(batch) > 0 {
+		if err := batchStorer.StoreBatch(ctx, walletID, c.wallet.Name(), batch); err != nil {
+			return errors.Wrap(err, "failed to store account batch")
+		}
+	}
+
+	if err := c.verifyCopy(ctx, walletID, walletData, accounts); err != nil {
+		return errors.Wrap(err, "copy of wallet failed verification; the source wallet has not been changed")
+	}
+	c.accounts = len(accounts)
+
+	if c.deleteSource {
+		if err := util.DeleteWallet(c.wallet); err != nil {
+			return errors.Wrap(err, "failed to delete source wallet")
+		}
+		c.sourceDeleted = true
+	}
+
+	return nil
+}
+
+// retrieveAccounts retrieves the stored data for all accounts in a wallet.
+func retrieveAccounts(store e2wtypes.Store, walletID uuid.UUID) ([]*storedAccount, error) {
+	accounts := make([]*storedAccount, 0)
+	for data := range store.RetrieveAccounts(walletID) {
+		fields := struct {
+			UUID string `json:"uuid"`
+			ID   string `json:"id"`
+		}{}
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, errors.Wrap(err, "failed to parse account")
+		}
+		idStr := fields.UUID
+		if idStr == "" {
+			// Older accounts use "id".
+			idStr = fields.ID
+		}
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse account ID")
+		}
+		accounts = append(accounts, &storedAccount{
+			id:   id,
+			data: data,
+		})
+	}
+
+	return accounts, nil
+}
+
+// verifyCopy confirms that the wallet and all of its accounts can be read
+// back from the destination store unchanged.
+func (c *command) verifyCopy(ctx context.Context,
+	walletID uuid.UUID,
+	walletData []byte,
+	accounts []*storedAccount,
+) error {
+	copiedWalletData, err := c.toStore.RetrieveWallet(c.wallet.Name())
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve copied wallet")
+	}
+	if !bytes.Equal(copiedWalletData, walletData) {
+		return errors.New("copied wallet does not match the source wallet")
+	}
+	for _, account := range accounts {
+		copiedData, err := c.toStore.RetrieveAccount(walletID, account.id)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to retrieve copied account %s", account.id))
+		}
+		if !bytes.Equal(copiedData, account.data) {
+			return fmt.Errorf("copied account %s does not match the source account", account.id)
+		}
+	}
+
+	// Ensure that the copied wallet can be opened and provides the same accounts.
+	copiedWallet, err := e2wallet.OpenWallet(c.wallet.Name(), e2wallet.WithStore(c.toStore))
+	if err != nil {
+		return errors.Wrap(err, "failed to open copied wallet")
+	}
+	if copiedWallet.ID() != walletID {
+		return errors.New("copied wallet has a different ID")
+	}
+	copiedAccounts := make(map[uuid.UUID]e2wtypes.Account)
+	for account := range copiedWallet.Accounts(ctx) {
+		copiedAccounts[account.ID()] = account
+	}
+	for account := range c.wallet.Accounts(ctx) {
+		copiedAccount, exists := copiedAccounts[account.ID()]
+		if !exists {
+			return fmt.Errorf("account %s is missing from the copied wallet", account.Name())
+		}
+		if copiedAccount.Name() != account.Name() {
+			return fmt.Errorf("account %s has a different name in the copied wallet", account.Name())
+		}
+		pubKey, err := util.BestPublicKey(account)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain public key for account %s", account.Name()))
+		}
+		copiedPubKey, err := util.BestPublicKey(copiedAccount)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain public key for copied account %s", account.Name()))
+		}
+		if !bytes.Equal(copiedPubKey.Marshal(), pubKey.Marshal()) {
+			return fmt.Errorf("account %s has a different public key in the copied wallet", account.Name())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletcopy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	filesystem "github.com/wealdtech/go-eth2-wallet-store-filesystem"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx := context.Background()
+
+	newWallet := func(t *testing.T, store e2wtypes.Store) e2wtypes.Wallet {
+		t.Helper()
+		wallet, err := nd.CreateWallet(ctx, "Test", store, keystorev4.New())
+		require.NoError(t, err)
+		require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+		account, err := wallet.(e2wtypes.WalletAccountImporter).ImportAccount(ctx, "Interop 0", testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"), []byte("pass"))
+		require.NoError(t, err)
+		require.NoError(t, util.SetAccountTags(ctx, wallet, account, []string{"batch-1"}))
+		_, err = util.ImportWatchOnlyAccount(ctx, wallet, "Watched", testutil.HexToBytes("0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b"))
+		require.NoError(t, err)
+
+		return wallet
+	}
+
+	t.Run("Copy", func(t *testing.T) {
+		wallet := newWallet(t, scratch.New())
+		toStore := filesystem.New(filesystem.WithLocation(t.TempDir()), filesystem.WithPassphrase([]byte("store secret")))
+		c := &command{
+			wallet:  wallet,
+			toStore: toStore,
+		}
+		require.NoError(t, c.process(ctx))
+		require.Equal(t, 2, c.accounts)
+		require.False(t, c.sourceDeleted)
+
+		copied, err := e2wallet.OpenWallet("Test", e2wallet.WithStore(toStore))
+		require.NoError(t, err)
+		require.Equal(t, wallet.ID(), copied.ID())
+		account, err := copied.(e2wtypes.WalletAccountByNameProvider).AccountByName(ctx, "Interop 0")
+		require.NoError(t, err)
+		tags, err := util.AccountTags(ctx, copied, account)
+		require.NoError(t, err)
+		require.Equal(t, []string{"batch-1"}, tags)
+		require.NoError(t, account.(e2wtypes.AccountLocker).Unlock(ctx, []byte("pass")))
+		watched, err := copied.(e2wtypes.WalletAccountByNameProvider).AccountByName(ctx, "Watched")
+		require.NoError(t, err)
+		require.True(t, util.IsWatchOnlyAccount(watched))
+
+		// A second copy should be refused.
+		require.EqualError(t, c.process(ctx), `wallet "Test" already exists in the destination store`)
+	})
+
+	t.Run("DeleteSourceUnsupported", func(t *testing.T) {
+		c := &command{
+			wallet:       newWallet(t, scratch.New()),
+			toStore:      scratch.New(),
+			deleteSource: true,
+		}
+		require.EqualError(t, c.process(ctx), "cannot delete wallets from scratch stores; remove the source wallet manually")
+	})
+
+	t.Run("DeleteSource", func(t *testing.T) {
+		fromStore := filesystem.New(filesystem.WithLocation(t.TempDir()))
+		c := &command{
+			wallet:       newWallet(t, fromStore),
+			toStore:      scratch.New(),
+			deleteSource: true,
+		}
+		require.NoError(t, c.process(ctx))
+		require.True(t, c.sourceDeleted)
+		_, err := fromStore.RetrieveWallet("Test")
+		require.Error(t, err)
+		_, err = c.toStore.RetrieveWallet("Test")
+		require.NoError(t, err)
+	})
+}
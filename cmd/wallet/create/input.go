@@ -56,6 +56,9 @@ func input(_ context.Context) (*dataIn, error) {
 	data.verbose = viper.GetBool("verbose")
 	data.debug = viper.GetBool("debug")
 
+	if err := util.EnsureStore(); err != nil {
+		return nil, err
+	}
 	store, isStore := viper.Get("store").(e2wtypes.Store)
 	if !isStore {
 		return nil, errors.New("store is required")
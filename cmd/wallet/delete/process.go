@@ -15,12 +15,9 @@ package walletdelete
 
 import (
 	"context"
-	"fmt"
-	"os"
-	"path/filepath"
 
 	"github.com/pkg/errors"
-	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+	"github.com/wealdtech/ethdo/util"
 )
 
 func process(_ context.Context, data *dataIn) (*dataOut, error) {
@@ -31,22 +28,8 @@ func process(_ context.Context, data *dataIn) (*dataOut, error) {
 		return nil, errors.New("wallet is required")
 	}
 
-	storeProvider, isProvider := data.wallet.(e2wtypes.StoreProvider)
-	if !isProvider {
-		return nil, errors.New("cannot obtain store for the wallet")
-	}
-	store := storeProvider.Store()
-
-	if store.Name() != "filesystem" {
-		return nil, fmt.Errorf("cannot delete %s wallet automatically, please remove manually", store.Name())
-	}
-	storeLocationProvider, isProvider := store.(e2wtypes.StoreLocationProvider)
-	if !isProvider {
-		return nil, errors.New("cannot obtain store location for the wallet")
-	}
-	walletLocation := filepath.Join(storeLocationProvider.Location(), data.wallet.ID().String())
-	if err := os.RemoveAll(walletLocation); err != nil {
-		return nil, errors.Wrap(err, "failed to delete wallet")
+	if err := util.DeleteWallet(data.wallet); err != nil {
+		return nil, err
 	}
 
 	return &dataOut{}, nil
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
 	"github.com/wealdtech/go-ecodec"
 	e2wallet "github.com/wealdtech/go-eth2-wallet"
 )
@@ -39,6 +40,8 @@ func process(_ context.Context, data *dataIn) (*dataOut, error) {
 		if err := json.Unmarshal(data, ext); err != nil {
 			return nil, errors.Wrap(err, "failed to read export")
 		}
+	} else if err := util.EnsureStore(); err != nil {
+		return nil, err
 	} else if _, err := e2wallet.ImportWallet(data.data, []byte(data.passphrase)); err != nil {
 		return nil, errors.Wrap(err, "failed to import wallet")
 	}
@@ -0,0 +1,81 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletseed
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	timeout time.Duration
+
+	// Input.
+	wallet           e2wtypes.Wallet
+	walletPassphrase string
+	ageRecipient     string
+	pgpKey           string
+
+	// Output.
+	seed      []byte
+	encrypted string
+}
+
+func newCommand(ctx context.Context) (*command, error) {
+	c := &command{
+		quiet:            viper.GetBool("quiet"),
+		verbose:          viper.GetBool("verbose"),
+		debug:            viper.GetBool("debug"),
+		timeout:          viper.GetDuration("timeout"),
+		walletPassphrase: util.GetWalletPassphrase(),
+		ageRecipient:     viper.GetString("age-recipient"),
+		pgpKey:           viper.GetString("pgp-key"),
+	}
+
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if viper.GetString("wallet") == "" {
+		return nil, errors.New("wallet is required")
+	}
+	if c.walletPassphrase == "" {
+		return nil, errors.New("wallet passphrase is required")
+	}
+	if !viper.GetBool("i-know-what-i-am-doing") {
+		return nil, errors.New("the seed gives access to every account in the wallet; supply --i-know-what-i-am-doing to confirm that you wish to display it")
+	}
+	if c.ageRecipient != "" && c.pgpKey != "" {
+		return nil, errors.New("only one of --age-recipient and --pgp-key can be supplied")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	var err error
+	c.wallet, err = util.WalletFromInput(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain wallet")
+	}
+
+	return c, nil
+}
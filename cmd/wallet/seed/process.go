@@ -0,0 +1,109 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletseed
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgparmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.decryptSeed(ctx); err != nil {
+		return err
+	}
+
+	switch {
+	case c.ageRecipient != "":
+		return c.encryptAge()
+	case c.pgpKey != "":
+		return c.encryptPGP()
+	default:
+		return nil
+	}
+}
+
+// decryptSeed decrypts the seed of the wallet using the wallet passphrase.
+func (c *command) decryptSeed(_ context.Context) error {
+	var err error
+	c.seed, err = util.DecryptWalletSeed(c.wallet, c.walletPassphrase)
+
+	return err
+}
+
+// encryptAge encrypts the seed to an age recipient.
+func (c *command) encryptAge() error {
+	recipient, err := age.ParseX25519Recipient(c.ageRecipient)
+	if err != nil {
+		return errors.Wrap(err, "invalid age recipient")
+	}
+
+	buf := &bytes.Buffer{}
+	armorWriter := armor.NewWriter(buf)
+	writer, err := age.Encrypt(armorWriter, recipient)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up age encryption")
+	}
+
+	return c.writeEncrypted(buf, writer, armorWriter)
+}
+
+// encryptPGP encrypts the seed to a PGP public key.
+func (c *command) encryptPGP() error {
+	keyData, err := os.ReadFile(c.pgpKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to read PGP key")
+	}
+	entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(keyData))
+	if err != nil {
+		return errors.Wrap(err, "failed to parse PGP key")
+	}
+
+	buf := &bytes.Buffer{}
+	armorWriter, err := pgparmor.Encode(buf, "PGP MESSAGE", nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up PGP armor")
+	}
+	writer, err := openpgp.Encrypt(armorWriter, entities, nil, nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up PGP encryption")
+	}
+
+	return c.writeEncrypted(buf, writer, armorWriter)
+}
+
+// writeEncrypted writes the seed through the encrypting writer, storing the armored result.
+func (c *command) writeEncrypted(buf *bytes.Buffer, writer io.WriteCloser, armorWriter io.WriteCloser) error {
+	if _, err := fmt.Fprintf(writer, "%#x\n", c.seed); err != nil {
+		return errors.Wrap(err, "failed to encrypt seed")
+	}
+	if err := writer.Close(); err != nil {
+		return errors.Wrap(err, "failed to finish encryption")
+	}
+	if err := armorWriter.Close(); err != nil {
+		return errors.Wrap(err, "failed to finish armor")
+	}
+	c.encrypted = buf.String()
+
+	return nil
+}
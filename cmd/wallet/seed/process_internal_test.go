@@ -0,0 +1,148 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletseed
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgparmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	hd "github.com/wealdtech/go-eth2-wallet-hd/v2"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx := context.Background()
+
+	seed := testutil.HexToBytes("0x408b285c123836004f4b8842c89324c1f01382450c0d439af345ba7fc49acf705489c6fc77dbd4e3dc1dd8cc6bc9f043db8ada1e243c4a0eafb290d399480840")
+	store := scratch.New()
+	hdWallet, err := hd.CreateWallet(ctx, "HD wallet", []byte("wallet secret"), store, keystorev4.New(), seed)
+	require.NoError(t, err)
+	ndWallet, err := nd.CreateWallet(ctx, "ND wallet", store, keystorev4.New())
+	require.NoError(t, err)
+
+	ageIdentity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	pgpEntity, err := openpgp.NewEntity("Test", "", "test@example.com", nil)
+	require.NoError(t, err)
+	pgpKeyFile := filepath.Join(t.TempDir(), "key.asc")
+	pgpKey, err := os.Create(pgpKeyFile)
+	require.NoError(t, err)
+	armorWriter, err := pgparmor.Encode(pgpKey, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, pgpEntity.Serialize(armorWriter))
+	require.NoError(t, armorWriter.Close())
+	require.NoError(t, pgpKey.Close())
+
+	tests := []struct {
+		name    string
+		command *command
+		err     string
+	}{
+		{
+			name: "NotHD",
+			command: &command{
+				wallet:           ndWallet,
+				walletPassphrase: "wallet secret",
+			},
+			err: "only hierarchical deterministic wallets have a seed",
+		},
+		{
+			name: "BadPassphrase",
+			command: &command{
+				wallet:           hdWallet,
+				walletPassphrase: "wrong",
+			},
+			err: "incorrect wallet passphrase",
+		},
+		{
+			name: "BadAgeRecipient",
+			command: &command{
+				wallet:           hdWallet,
+				walletPassphrase: "wallet secret",
+				ageRecipient:     "age1invalid",
+			},
+			err: "invalid age recipient: malformed recipient \"age1invalid\"",
+		},
+		{
+			name: "Good",
+			command: &command{
+				wallet:           hdWallet,
+				walletPassphrase: "wallet secret",
+			},
+		},
+		{
+			name: "Age",
+			command: &command{
+				wallet:           hdWallet,
+				walletPassphrase: "wallet secret",
+				ageRecipient:     ageIdentity.Recipient().String(),
+			},
+		},
+		{
+			name: "PGP",
+			command: &command{
+				wallet:           hdWallet,
+				walletPassphrase: "wallet secret",
+				pgpKey:           pgpKeyFile,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.command.process(ctx)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, seed, test.command.seed)
+
+			switch {
+			case test.command.ageRecipient != "":
+				reader, err := age.Decrypt(armor.NewReader(strings.NewReader(test.command.encrypted)), ageIdentity)
+				require.NoError(t, err)
+				plaintext, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				require.Equal(t, fmt.Sprintf("%#x\n", seed), string(plaintext))
+			case test.command.pgpKey != "":
+				block, err := pgparmor.Decode(strings.NewReader(test.command.encrypted))
+				require.NoError(t, err)
+				md, err := openpgp.ReadMessage(block.Body, openpgp.EntityList{pgpEntity}, nil, nil)
+				require.NoError(t, err)
+				plaintext, err := io.ReadAll(md.UnverifiedBody)
+				require.NoError(t, err)
+				require.Equal(t, fmt.Sprintf("%#x\n", seed), string(plaintext))
+			default:
+				require.Empty(t, test.command.encrypted)
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/wealdtech/ethdo/shamir"
+	"github.com/wealdtech/ethdo/util"
 	e2wallet "github.com/wealdtech/go-eth2-wallet"
 )
 
@@ -65,6 +66,9 @@ func process(_ context.Context, data *dataIn) (*dataOut, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to obtain data from export")
 	}
+	if err := util.EnsureStore(); err != nil {
+		return nil, err
+	}
 	if _, err := e2wallet.ImportWallet(wallet, passphrase); err != nil {
 		return nil, errors.Wrap(err, "failed to import wallet")
 	}
@@ -0,0 +1,85 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletverify
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	timeout time.Duration
+
+	// Input.
+	wallet           e2wtypes.Wallet
+	passphrases      []string
+	walletPassphrase string
+
+	// Results.
+	report *report
+}
+
+type report struct {
+	Wallet      string           `json:"wallet"`
+	Passed      bool             `json:"passed"`
+	Accounts    []*accountReport `json:"accounts"`
+	IndexIssues []string         `json:"index_issues,omitempty"`
+}
+
+type accountReport struct {
+	Name   string   `json:"name"`
+	ID     string   `json:"uuid,omitempty"`
+	Passed bool     `json:"passed"`
+	Issues []string `json:"issues,omitempty"`
+}
+
+func newCommand(ctx context.Context) (*command, error) {
+	c := &command{
+		quiet:            viper.GetBool("quiet"),
+		verbose:          viper.GetBool("verbose"),
+		debug:            viper.GetBool("debug"),
+		json:             viper.GetBool("json"),
+		timeout:          viper.GetDuration("timeout"),
+		passphrases:      util.GetPassphrases(),
+		walletPassphrase: util.GetWalletPassphrase(),
+	}
+
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if viper.GetString("wallet") == "" {
+		return nil, errors.New("wallet is required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	var err error
+	c.wallet, err = util.WalletFromInput(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain wallet")
+	}
+
+	return c, nil
+}
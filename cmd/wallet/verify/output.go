@@ -0,0 +1,67 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletverify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		data, err := json.Marshal(c.report)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	builder := strings.Builder{}
+	failed := 0
+	for _, account := range c.report.Accounts {
+		if account.Passed {
+			if c.verbose {
+				builder.WriteString(fmt.Sprintf("%s: pass\n", account.Name))
+			}
+			continue
+		}
+		failed++
+		builder.WriteString(fmt.Sprintf("%s: fail\n", account.Name))
+		for _, issue := range account.Issues {
+			builder.WriteString(fmt.Sprintf("  %s\n", issue))
+		}
+	}
+	if len(c.report.IndexIssues) > 0 {
+		builder.WriteString("Index: fail\n")
+		for _, issue := range c.report.IndexIssues {
+			builder.WriteString(fmt.Sprintf("  %s\n", issue))
+		}
+	} else if c.verbose {
+		builder.WriteString("Index: pass\n")
+	}
+
+	result := "pass"
+	if !c.report.Passed {
+		result = "fail"
+	}
+	builder.WriteString(fmt.Sprintf("Wallet %s: %s (%d accounts checked, %d failed)", c.report.Wallet, result, len(c.report.Accounts), failed))
+
+	return builder.String(), nil
+}
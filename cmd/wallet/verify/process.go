@@ -0,0 +1,286 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletverify
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	ethutil "github.com/wealdtech/go-eth2-util"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// storedAccount is the information about an account required to check the index.
+type storedAccount struct {
+	id   uuid.UUID
+	name string
+}
+
+func (c *command) process(ctx context.Context) error {
+	hd := false
+	switch c.wallet.Type() {
+	case "non-deterministic":
+	case "hierarchical deterministic":
+		hd = true
+	default:
+		return fmt.Errorf("%s wallets cannot be verified", c.wallet.Type())
+	}
+	storeProvider, isStoreProvider := c.wallet.(e2wtypes.StoreProvider)
+	if !isStoreProvider {
+		return errors.New("wallet does not provide its store")
+	}
+	store := storeProvider.Store()
+	walletIDProvider, isWalletIDProvider := c.wallet.(e2wtypes.WalletIDProvider)
+	if !isWalletIDProvider {
+		return errors.New("wallet does not provide its ID")
+	}
+	walletID := walletIDProvider.ID()
+
+	var seed []byte
+	if hd && c.walletPassphrase != "" {
+		var err error
+		seed, err = util.DecryptWalletSeed(c.wallet, c.walletPassphrase)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.report = &report{
+		Wallet:   c.wallet.Name(),
+		Accounts: make([]*accountReport, 0),
+	}
+	encryptor := keystorev4.New()
+	storedAccounts := make([]*storedAccount, 0)
+	for data := range store.RetrieveAccounts(walletID) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		accountReport, stored := c.verifyAccount(data, len(c.report.Accounts)+1, hd, seed, encryptor)
+		c.report.Accounts = append(c.report.Accounts, accountReport)
+		if stored != nil {
+			storedAccounts = append(storedAccounts, stored)
+		}
+	}
+	sort.Slice(c.report.Accounts, func(i int, j int) bool {
+		return c.report.Accounts[i].Name < c.report.Accounts[j].Name
+	})
+
+	c.report.IndexIssues = verifyIndex(store, walletID, storedAccounts)
+
+	c.report.Passed = len(c.report.IndexIssues) == 0
+	for _, account := range c.report.Accounts {
+		if !account.Passed {
+			c.report.Passed = false
+		}
+	}
+
+	return nil
+}
+
+// verifyAccount verifies the stored data for a single account.
+//
+//nolint:gocyclo
+func (c *command) verifyAccount(data []byte,
+	position int,
+	hd bool,
+	seed []byte,
+	encryptor *keystorev4.Encryptor,
+) (
+	*accountReport,
+	*storedAccount,
+) {
+	res := &accountReport{
+		Name:   fmt.Sprintf("<account %d>", position),
+		Issues: make([]string, 0),
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	fields := make(map[string]any)
+	if err := decoder.Decode(&fields); err != nil {
+		res.Issues = append(res.Issues, "account data is not valid JSON")
+		return res, nil
+	}
+
+	name, isString := fields["name"].(string)
+	if isString && name != "" {
+		res.Name = name
+	} else {
+		res.Issues = append(res.Issues, "name is missing or invalid")
+	}
+
+	idStr, isString := fields["uuid"].(string)
+	if !isString {
+		// Older accounts use "id".
+		idStr, isString = fields["id"].(string)
+	}
+	var stored *storedAccount
+	if id, err := uuid.Parse(idStr); isString && err == nil {
+		res.ID = id.String()
+		if name != "" {
+			stored = &storedAccount{id: id, name: name}
+		}
+	} else {
+		res.Issues = append(res.Issues, "UUID is missing or invalid")
+	}
+
+	var pubKey e2types.PublicKey
+	if pubKeyStr, isString := fields["pubkey"].(string); isString {
+		pubKeyBytes, err := hex.DecodeString(pubKeyStr)
+		if err == nil {
+			pubKey, err = e2types.BLSPublicKeyFromBytes(pubKeyBytes)
+		}
+		if err != nil {
+			res.Issues = append(res.Issues, "public key is invalid")
+		}
+	} else {
+		res.Issues = append(res.Issues, "public key is missing")
+	}
+
+	if version, isNumber := fields["version"].(json.Number); !isNumber || version.String() != "4" {
+		res.Issues = append(res.Issues, "keystore version is missing or unsupported")
+	}
+	if encryptorName, exists := fields["encryptor"]; exists && encryptorName != "keystore" && encryptorName != encryptor.String() {
+		res.Issues = append(res.Issues, fmt.Sprintf("encryptor %v is unsupported", encryptorName))
+	}
+
+	if hd {
+		path, isString := fields["path"].(string)
+		if !isString {
+			res.Issues = append(res.Issues, "path is missing")
+		} else if _, err := util.ValidateDerivationPath(path); err != nil {
+			res.Issues = append(res.Issues, fmt.Sprintf("path is invalid: %v", err))
+		} else if seed != nil && pubKey != nil {
+			derivedKey, err := ethutil.PrivateKeyFromSeedAndPath(seed, path)
+			switch {
+			case err != nil:
+				res.Issues = append(res.Issues, fmt.Sprintf("failed to derive key for path %s", path))
+			case !bytes.Equal(derivedKey.PublicKey().Marshal(), pubKey.Marshal()):
+				res.Issues = append(res.Issues, fmt.Sprintf("public key does not match the key derived from path %s", path))
+			}
+		}
+	}
+
+	if watchOnly, isBool := fields["watchonly"].(bool); isBool && watchOnly {
+		// Watch-only accounts have no key material to check.
+		res.Passed = len(res.Issues) == 0
+		return res, stored
+	}
+
+	crypto, isMap := fields["crypto"].(map[string]any)
+	switch {
+	case !isMap:
+		res.Issues = append(res.Issues, "encrypted key is missing")
+	case !hasKeystoreModules(crypto):
+		res.Issues = append(res.Issues, "encrypted key is malformed")
+	case len(c.passphrases) > 0:
+		var secret []byte
+		for _, passphrase := range c.passphrases {
+			var err error
+			secret, err = encryptor.Decrypt(crypto, passphrase)
+			if err == nil {
+				break
+			}
+		}
+		if secret == nil {
+			res.Issues = append(res.Issues, "cannot be decrypted with the supplied passphrases")
+			break
+		}
+		privKey, err := e2types.BLSPrivateKeyFromBytes(secret)
+		if err != nil {
+			res.Issues = append(res.Issues, "decrypted private key is invalid")
+			break
+		}
+		if pubKey != nil && !bytes.Equal(privKey.PublicKey().Marshal(), pubKey.Marshal()) {
+			res.Issues = append(res.Issues, "public key does not match the decrypted private key")
+		}
+	}
+
+	res.Passed = len(res.Issues) == 0
+
+	return res, stored
+}
+
+// hasKeystoreModules returns true if the encrypted key contains the modules required by EIP-2335.
+func hasKeystoreModules(crypto map[string]any) bool {
+	for _, module := range []string{"kdf", "checksum", "cipher"} {
+		if _, isMap := crypto[module].(map[string]any); !isMap {
+			return false
+		}
+	}
+
+	return true
+}
+
+// verifyIndex confirms that the wallet's index of accounts matches the stored accounts.
+func verifyIndex(store e2wtypes.Store, walletID uuid.UUID, accounts []*storedAccount) []string {
+	issues := make([]string, 0)
+
+	names := make(map[string]uuid.UUID, len(accounts))
+	ids := make(map[uuid.UUID]string, len(accounts))
+	for _, account := range accounts {
+		if _, exists := names[account.name]; exists {
+			issues = append(issues, fmt.Sprintf("name %q is used by more than one account", account.name))
+		}
+		names[account.name] = account.id
+		if _, exists := ids[account.id]; exists {
+			issues = append(issues, fmt.Sprintf("UUID %s is used by more than one account", account.id))
+		}
+		ids[account.id] = account.name
+	}
+
+	data, err := store.RetrieveAccountsIndex(walletID)
+	if err != nil {
+		if len(accounts) > 0 {
+			issues = append(issues, "index is missing")
+		}
+		return issues
+	}
+	entries := make([]*struct {
+		ID   uuid.UUID `json:"uuid"`
+		Name string    `json:"name"`
+	}, 0)
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return append(issues, "index is not valid JSON")
+	}
+
+	indexed := make(map[uuid.UUID]string, len(entries))
+	for _, entry := range entries {
+		indexed[entry.ID] = entry.Name
+		name, exists := ids[entry.ID]
+		switch {
+		case !exists:
+			issues = append(issues, fmt.Sprintf("index refers to missing account %q (%s)", entry.Name, entry.ID))
+		case name != entry.Name:
+			issues = append(issues, fmt.Sprintf("index has name %q for account %q (%s)", entry.Name, name, entry.ID))
+		}
+	}
+	for _, account := range accounts {
+		if _, exists := indexed[account.id]; !exists {
+			issues = append(issues, fmt.Sprintf("account %q (%s) is missing from the index", account.name, account.id))
+		}
+	}
+	sort.Strings(issues)
+
+	return issues
+}
@@ -0,0 +1,240 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletverify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	hd "github.com/wealdtech/go-eth2-wallet-hd/v2"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// alterAccount alters the stored data for an account.
+func alterAccount(t *testing.T, wallet e2wtypes.Wallet, account e2wtypes.Account, field string, value any) {
+	t.Helper()
+
+	store := wallet.(e2wtypes.StoreProvider).Store()
+	walletID := wallet.(e2wtypes.WalletIDProvider).ID()
+	accountID := account.(e2wtypes.AccountIDProvider).ID()
+	data, err := store.RetrieveAccount(walletID, accountID)
+	require.NoError(t, err)
+	fields := make(map[string]any)
+	require.NoError(t, json.Unmarshal(data, &fields))
+	fields[field] = value
+	data, err = json.Marshal(fields)
+	require.NoError(t, err)
+	require.NoError(t, store.StoreAccount(walletID, accountID, data))
+}
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx := context.Background()
+
+	newNDWallet := func(t *testing.T) (e2wtypes.Wallet, e2wtypes.Account, e2wtypes.Account) {
+		t.Helper()
+		wallet, err := nd.CreateWallet(ctx, "Test", scratch.New(), keystorev4.New())
+		require.NoError(t, err)
+		require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+		account0, err := wallet.(e2wtypes.WalletAccountImporter).ImportAccount(ctx, "Interop 0", testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"), []byte("pass"))
+		require.NoError(t, err)
+		account1, err := wallet.(e2wtypes.WalletAccountImporter).ImportAccount(ctx, "Interop 1", testutil.HexToBytes("0x51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000"), []byte("pass"))
+		require.NoError(t, err)
+
+		return wallet, account0, account1
+	}
+	newHDWallet := func(t *testing.T) (e2wtypes.Wallet, e2wtypes.Account, e2wtypes.Account) {
+		t.Helper()
+		seed := testutil.HexToBytes("0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f200102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20")
+		wallet, err := hd.CreateWallet(ctx, "Test", []byte("wallet pass"), scratch.New(), keystorev4.New(), seed)
+		require.NoError(t, err)
+		require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, []byte("wallet pass")))
+		account0, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "Account 0", []byte("pass"))
+		require.NoError(t, err)
+		account1, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "Account 1", []byte("pass"))
+		require.NoError(t, err)
+
+		return wallet, account0, account1
+	}
+
+	tests := []struct {
+		name             string
+		hd               bool
+		alter            func(t *testing.T, wallet e2wtypes.Wallet, account0 e2wtypes.Account, account1 e2wtypes.Account)
+		passphrases      []string
+		walletPassphrase string
+		err              string
+		passed           bool
+		issues           map[string][]string
+		indexIssues      []string
+	}{
+		{
+			name:   "Good",
+			passed: true,
+		},
+		{
+			name:        "GoodWithPassphrase",
+			passphrases: []string{"pass"},
+			passed:      true,
+		},
+		{
+			name:        "WrongPassphrase",
+			passphrases: []string{"wrong"},
+			issues: map[string][]string{
+				"Interop 0": {"cannot be decrypted with the supplied passphrases"},
+				"Interop 1": {"cannot be decrypted with the supplied passphrases"},
+			},
+		},
+		{
+			name: "MismatchedPublicKey",
+			alter: func(t *testing.T, wallet e2wtypes.Wallet, account0 e2wtypes.Account, account1 e2wtypes.Account) {
+				t.Helper()
+				alterAccount(t, wallet, account0, "pubkey", "b89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b")
+			},
+			passphrases: []string{"pass"},
+			issues: map[string][]string{
+				"Interop 0": {"public key does not match the decrypted private key"},
+			},
+		},
+		{
+			name: "MalformedAccount",
+			alter: func(t *testing.T, wallet e2wtypes.Wallet, account0 e2wtypes.Account, account1 e2wtypes.Account) {
+				t.Helper()
+				alterAccount(t, wallet, account0, "pubkey", "0102")
+				alterAccount(t, wallet, account0, "crypto", map[string]any{"kdf": map[string]any{}})
+				alterAccount(t, wallet, account1, "version", 3)
+			},
+			issues: map[string][]string{
+				"Interop 0": {"public key is invalid", "encrypted key is malformed"},
+				"Interop 1": {"keystore version is missing or unsupported"},
+			},
+		},
+		{
+			name: "InvalidJSON",
+			alter: func(t *testing.T, wallet e2wtypes.Wallet, _ e2wtypes.Account, _ e2wtypes.Account) {
+				t.Helper()
+				store := wallet.(e2wtypes.StoreProvider).Store()
+				require.NoError(t, store.StoreAccount(wallet.(e2wtypes.WalletIDProvider).ID(), uuid.New(), []byte("{")))
+			},
+			issues: map[string][]string{
+				// Unreadable accounts are named by their position, which depends on store ordering.
+				"<account>": {"account data is not valid JSON"},
+			},
+		},
+		{
+			name: "IndexMismatch",
+			alter: func(t *testing.T, wallet e2wtypes.Wallet, _ e2wtypes.Account, account1 e2wtypes.Account) {
+				t.Helper()
+				store := wallet.(e2wtypes.StoreProvider).Store()
+				index := `[{"uuid":"` + account1.ID().String() + `","name":"Renamed"},{"uuid":"8f5f7ec0-9a5a-4b4b-8a46-4b4b3b1e2d11","name":"Deleted"}]`
+				require.NoError(t, store.StoreAccountsIndex(wallet.(e2wtypes.WalletIDProvider).ID(), []byte(index)))
+			},
+			indexIssues: []string{
+				`index has name "Renamed" for account "Interop 1" (` + "%s" + `)`,
+				`index refers to missing account "Deleted" (8f5f7ec0-9a5a-4b4b-8a46-4b4b3b1e2d11)`,
+				`account "Interop 0" (` + "%s" + `) is missing from the index`,
+			},
+		},
+		{
+			name:             "HDGood",
+			hd:               true,
+			passphrases:      []string{"pass"},
+			walletPassphrase: "wallet pass",
+			passed:           true,
+		},
+		{
+			name:             "HDWrongWalletPassphrase",
+			hd:               true,
+			walletPassphrase: "wrong",
+			err:              "incorrect wallet passphrase",
+		},
+		{
+			name: "HDMismatchedPath",
+			hd:   true,
+			alter: func(t *testing.T, wallet e2wtypes.Wallet, account0 e2wtypes.Account, _ e2wtypes.Account) {
+				t.Helper()
+				alterAccount(t, wallet, account0, "path", "m/12381/3600/5/0/0")
+			},
+			walletPassphrase: "wallet pass",
+			issues: map[string][]string{
+				"Account 0": {"public key does not match the key derived from path m/12381/3600/5/0/0"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var wallet e2wtypes.Wallet
+			var account0, account1 e2wtypes.Account
+			if test.hd {
+				wallet, account0, account1 = newHDWallet(t)
+			} else {
+				wallet, account0, account1 = newNDWallet(t)
+			}
+			if test.alter != nil {
+				test.alter(t, wallet, account0, account1)
+			}
+
+			c := &command{
+				wallet:           wallet,
+				passphrases:      test.passphrases,
+				walletPassphrase: test.walletPassphrase,
+			}
+			err := c.process(ctx)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.passed, c.report.Passed)
+			for _, account := range c.report.Accounts {
+				name := account.Name
+				if strings.HasPrefix(name, "<account ") {
+					name = "<account>"
+				}
+				if issues, exists := test.issues[name]; exists {
+					require.Equal(t, issues, account.Issues, account.Name)
+					require.False(t, account.Passed)
+				} else {
+					require.Empty(t, account.Issues, account.Name)
+					require.True(t, account.Passed)
+				}
+			}
+			if len(test.indexIssues) > 0 {
+				expected := []string{
+					replaceID(test.indexIssues[0], account1),
+					test.indexIssues[1],
+					replaceID(test.indexIssues[2], account0),
+				}
+				require.ElementsMatch(t, expected, c.report.IndexIssues)
+			} else {
+				require.Empty(t, c.report.IndexIssues)
+			}
+		})
+	}
+}
+
+func replaceID(format string, account e2wtypes.Account) string {
+	return fmt.Sprintf(format, account.ID())
+}
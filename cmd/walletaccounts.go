@@ -23,6 +23,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
@@ -33,6 +34,8 @@ var walletAccountsCmd = &cobra.Command{
 
     ethdo wallet accounts --wallet=primary
 
+Only accounts carrying specific tags can be listed with the --accounts-tagged option.
+
 In quiet mode this will return 0 if the wallet holds any addresses, otherwise 1.`,
 	Run: func(_ *cobra.Command, _ []string) {
 		ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
@@ -47,6 +50,8 @@ In quiet mode this will return 0 if the wallet holds any addresses, otherwise 1.
 		for account := range wallet.Accounts(ctx) {
 			accounts = append(accounts, account)
 		}
+		accounts, err = util.FilterAccountsByTags(ctx, wallet, accounts, util.GetAccountsTagged())
+		errCheck(err, "Failed to filter accounts by tag")
 		assert(len(accounts) > 0, "")
 
 		if _, isPathProvider := accounts[0].(e2wtypes.AccountPathProvider); isPathProvider {
@@ -100,6 +105,9 @@ In quiet mode this will return 0 if the wallet holds any addresses, otherwise 1.
 				if compositePubKeyProvider, isProvider := account.(e2wtypes.AccountCompositePublicKeyProvider); isProvider {
 					fmt.Printf(" Composite public key: %#x\n", compositePubKeyProvider.CompositePublicKey().Marshal())
 				}
+				if tags, err := util.AccountTags(ctx, wallet, account); err == nil && len(tags) > 0 {
+					fmt.Printf(" Tags: %s\n", strings.Join(tags, ", "))
+				}
 			}
 		}
 		os.Exit(_exitSuccess)
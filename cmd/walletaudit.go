@@ -0,0 +1,63 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	walletaudit "github.com/wealdtech/ethdo/cmd/wallet/audit"
+)
+
+var walletAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Audit wallets",
+	Long: `Audit wallets for potential problems.  For example:
+
+    ethdo wallet audit --duplicates
+
+--duplicates reports public keys that appear in more than one account, across all wallets in the store and any additional base directories supplied with --additional-base-dirs.  A keymanager API or Web3Signer listing of public keys can be supplied with --listing to also report accounts whose keys are already in use elsewhere.
+
+In quiet mode this will return 0 if no problems are found, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := walletaudit.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	walletCmd.AddCommand(walletAuditCmd)
+	walletAuditCmd.Flags().Bool("duplicates", false, "Report public keys that appear more than once")
+	walletAuditCmd.Flags().String("listing", "", "Path to a keymanager API or Web3Signer listing of public keys to check against")
+	walletAuditCmd.Flags().StringSlice("additional-base-dirs", nil, "Additional filesystem wallet base directories to scan")
+}
+
+func walletAuditBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("duplicates", cmd.Flags().Lookup("duplicates")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("listing", cmd.Flags().Lookup("listing")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("additional-base-dirs", cmd.Flags().Lookup("additional-base-dirs")); err != nil {
+		panic(err)
+	}
+}
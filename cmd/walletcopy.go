@@ -0,0 +1,68 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	walletcopy "github.com/wealdtech/ethdo/cmd/wallet/copy"
+)
+
+var walletCopyCmd = &cobra.Command{
+	Use:   "copy",
+	Short: "Copy a wallet to another store",
+	Long: `Copy a wallet to another store.  For example:
+
+    ethdo wallet copy --wallet=primary --to-store=s3
+
+The wallet and its accounts are copied as-is, retaining their IDs and all stored information, and every account is read back from the destination store and checked before the copy is considered successful.  The source wallet is only removed if --delete-source is supplied, and only after the copy has been verified.
+
+In quiet mode this will return 0 if the wallet has been copied and verified, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := walletcopy.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	walletCmd.AddCommand(walletCopyCmd)
+	walletFlags(walletCopyCmd)
+	walletCopyCmd.Flags().String("to-store", "", "Store to which to copy the wallet")
+	walletCopyCmd.Flags().String("to-base-dir", "", "Base directory of the filesystem store to which to copy the wallet")
+	walletCopyCmd.Flags().String("to-store-passphrase", "", "Passphrase for the store to which to copy the wallet (if applicable)")
+	walletCopyCmd.Flags().Bool("delete-source", false, "Delete the source wallet once the copy has been verified")
+}
+
+func walletCopyBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("to-store", cmd.Flags().Lookup("to-store")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-base-dir", cmd.Flags().Lookup("to-base-dir")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-store-passphrase", cmd.Flags().Lookup("to-store-passphrase")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("delete-source", cmd.Flags().Lookup("delete-source")); err != nil {
+		panic(err)
+	}
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
 	e2wallet "github.com/wealdtech/go-eth2-wallet"
 )
 
@@ -34,6 +35,8 @@ In quiet mode this will return 0 if any wallets are found, otherwise 1.`,
 		assert(viper.GetString("remote") == "", "wallet list not available with remote wallets")
 		assert(viper.GetString("wallet") == "", "wallet list does not take a --wallet parameter")
 
+		errCheck(util.EnsureStore(), "failed to set up wallet store")
+
 		walletsFound := false
 		for w := range e2wallet.Wallets() {
 			walletsFound = true
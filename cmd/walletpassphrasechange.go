@@ -0,0 +1,57 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	walletpassphrasechange "github.com/wealdtech/ethdo/cmd/wallet/passphrase/change"
+)
+
+var walletPassphraseChangeCmd = &cobra.Command{
+	Use:   "change",
+	Short: "Change the passphrase of all accounts in a wallet",
+	Long: `Change the passphrase of all accounts in a wallet.  For example:
+
+    ethdo wallet passphrase change --wallet="primary" --passphrase="old secret" --new-passphrase="new secret"
+
+Multiple existing passphrases can be supplied if accounts in the wallet have different passphrases.  All accounts are decrypted before any are changed, so if any account cannot be decrypted no passphrases are changed.
+
+In quiet mode this will return 0 if the passphrases have been changed, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := walletpassphrasechange.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	walletPassphraseCmd.AddCommand(walletPassphraseChangeCmd)
+	walletPassphraseFlags(walletPassphraseChangeCmd)
+	walletFlags(walletPassphraseChangeCmd)
+	walletPassphraseChangeCmd.Flags().String("new-passphrase", "", "New passphrase for the accounts")
+}
+
+func walletPassphraseChangeBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("new-passphrase", cmd.Flags().Lookup("new-passphrase")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	walletseed "github.com/wealdtech/ethdo/cmd/wallet/seed"
+)
+
+var walletSeedCmd = &cobra.Command{
+	Use:   "seed",
+	Short: "Display the seed of a hierarchical deterministic wallet",
+	Long: `Display the seed of a hierarchical deterministic wallet.  For example:
+
+    ethdo wallet seed --wallet="Personal wallet" --wallet-passphrase="secret" --i-know-what-i-am-doing
+
+The seed gives access to every account in the wallet, so it is only displayed if --i-know-what-i-am-doing is supplied.  Rather than display the seed in plaintext it can be encrypted to an age recipient with --age-recipient, or to a PGP public key with --pgp-key.
+
+Note that the wallet stores the seed rather than the mnemonic from which it was generated, and the mnemonic cannot be recovered from the seed.
+
+In quiet mode this will return 0 if the seed can be obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := walletseed.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	walletCmd.AddCommand(walletSeedCmd)
+	walletFlags(walletSeedCmd)
+	walletSeedCmd.Flags().Bool("i-know-what-i-am-doing", false, "Acknowledge that displaying the seed exposes every account in the wallet")
+	walletSeedCmd.Flags().String("age-recipient", "", "age recipient (age1…) to which to encrypt the seed")
+	walletSeedCmd.Flags().String("pgp-key", "", "Path to an armored PGP public key to which to encrypt the seed")
+}
+
+func walletSeedBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("i-know-what-i-am-doing", cmd.Flags().Lookup("i-know-what-i-am-doing")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("age-recipient", cmd.Flags().Lookup("age-recipient")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("pgp-key", cmd.Flags().Lookup("pgp-key")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	walletverify "github.com/wealdtech/ethdo/cmd/wallet/verify"
+)
+
+var walletVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify the integrity of a wallet",
+	Long: `Verify the integrity of a wallet.  For example:
+
+    ethdo wallet verify --wallet=primary
+
+Every account in the wallet is checked to ensure that its stored data is well-formed, and the wallet's index of accounts is checked against the stored accounts.  If --passphrase is supplied each account is also decrypted and its private key checked against its stored public key.  For hierarchical deterministic wallets, if --wallet-passphrase is supplied the key for each account is also derived from the wallet seed and checked against its stored public key.
+
+In quiet mode this will return 0 if the wallet passes verification, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := walletverify.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	walletCmd.AddCommand(walletVerifyCmd)
+	walletFlags(walletVerifyCmd)
+}
@@ -0,0 +1,127 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+	"golang.org/x/sync/errgroup"
+)
+
+// Session keeps accounts unlocked across multiple signing operations, so
+// that batch operations unlock each account once rather than unlocking and
+// locking it for every signature.  Accounts unlocked by the session are
+// locked again when the session is closed.
+type Session struct {
+	passphrases []string
+
+	mu       sync.Mutex
+	accounts map[uuid.UUID]*sessionAccount
+}
+
+type sessionAccount struct {
+	account e2wtypes.Account
+	// unlocked is true if the account was unlocked by the session.
+	unlocked bool
+}
+
+// NewSession creates a new signing session, using the supplied passphrases to unlock accounts.
+func NewSession(passphrases []string) *Session {
+	return &Session{
+		passphrases: passphrases,
+		accounts:    make(map[uuid.UUID]*sessionAccount),
+	}
+}
+
+// Unlock unlocks an account for the duration of the session.
+// Accounts that are already part of the session are not checked again.
+func (s *Session) Unlock(ctx context.Context, account e2wtypes.Account) error {
+	if account == nil {
+		return errors.New("account not specified")
+	}
+
+	s.mu.Lock()
+	_, exists := s.accounts[account.ID()]
+	s.mu.Unlock()
+	if exists {
+		return nil
+	}
+
+	alreadyUnlocked, err := Unlock(ctx, account, s.passphrases)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to unlock account %s", account.Name()))
+	}
+
+	s.mu.Lock()
+	s.accounts[account.ID()] = &sessionAccount{
+		account:  account,
+		unlocked: !alreadyUnlocked,
+	}
+	s.mu.Unlock()
+
+	return nil
+}
+
+// UnlockAll unlocks multiple accounts for the duration of the session,
+// decrypting their keys concurrently using the given number of workers.
+func (s *Session) UnlockAll(ctx context.Context, accounts []e2wtypes.Account, workers int) error {
+	if workers < 1 {
+		workers = 1
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(workers)
+	for _, account := range accounts {
+		account := account
+		group.Go(func() error {
+			return s.Unlock(groupCtx, account)
+		})
+	}
+
+	return group.Wait()
+}
+
+// SignRoot signs a root with a domain, unlocking the account for the
+// remainder of the session if required.
+func (s *Session) SignRoot(ctx context.Context, account e2wtypes.Account, root spec.Root, domain spec.Domain) (spec.BLSSignature, error) {
+	if err := s.Unlock(ctx, account); err != nil {
+		return spec.BLSSignature{}, err
+	}
+
+	return signRoot(ctx, account, root, domain)
+}
+
+// Close locks all accounts that were unlocked by the session.
+func (s *Session) Close(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var err error
+	for id, sessionAccount := range s.accounts {
+		if sessionAccount.unlocked {
+			if lockErr := Lock(ctx, sessionAccount.account); lockErr != nil && err == nil {
+				err = errors.Wrap(lockErr, fmt.Sprintf("failed to lock account %s", sessionAccount.account.Name()))
+			}
+		}
+		delete(s.accounts, id)
+	}
+
+	return err
+}
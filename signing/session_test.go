@@ -0,0 +1,79 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/signing"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func isUnlocked(t *testing.T, account e2wtypes.Account) bool {
+	t.Helper()
+	unlocked, err := account.(e2wtypes.AccountLocker).IsUnlocked(context.Background())
+	require.NoError(t, err)
+	return unlocked
+}
+
+func TestSession(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	store := scratch.New()
+	wallet, err := nd.CreateWallet(ctx, "Test", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	accounts := make([]e2wtypes.Account, 0)
+	for i := 0; i < 4; i++ {
+		account, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, fmt.Sprintf("Account %d", i), []byte("pass"))
+		require.NoError(t, err)
+		accounts = append(accounts, account)
+	}
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Lock(ctx))
+
+	// Incorrect passphrase.
+	badSession := signing.NewSession([]string{"bad"})
+	require.ErrorContains(t, badSession.UnlockAll(ctx, accounts, 2), "failed to unlock account")
+	_, err = badSession.SignRoot(ctx, accounts[0], spec.Root{}, spec.Domain{})
+	require.ErrorContains(t, err, "failed to unlock account")
+	require.NoError(t, badSession.Close(ctx))
+
+	// Pre-unlock one account, which should remain unlocked after the session closes.
+	require.NoError(t, accounts[0].(e2wtypes.AccountLocker).Unlock(ctx, []byte("pass")))
+
+	session := signing.NewSession([]string{"bad", "pass"})
+	require.NoError(t, session.UnlockAll(ctx, accounts[:2], 2))
+	for _, account := range accounts {
+		for i := 0; i < 3; i++ {
+			_, err := session.SignRoot(ctx, account, spec.Root{byte(i)}, spec.Domain{})
+			require.NoError(t, err)
+		}
+		require.True(t, isUnlocked(t, account))
+	}
+
+	require.NoError(t, session.Close(ctx))
+	require.True(t, isUnlocked(t, accounts[0]))
+	for _, account := range accounts[1:] {
+		require.False(t, isUnlocked(t, account))
+	}
+}
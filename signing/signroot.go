@@ -18,6 +18,7 @@ import (
 
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
@@ -34,7 +35,40 @@ func SignRoot(ctx context.Context, account e2wtypes.Account, passphrases []strin
 		return spec.BLSSignature{}, err
 	}
 
+	sig, err := signRoot(ctx, account, root, domain)
+	if err != nil {
+		return spec.BLSSignature{}, err
+	}
+
+	if !alreadyUnlocked {
+		if err := Lock(ctx, account); err != nil {
+			return spec.BLSSignature{}, errors.Wrap(err, "failed to lock account")
+		}
+	}
+
+	return sig, nil
+}
+
+// signRoot signs a root with a domain, using an account that is already unlocked,
+// and records the result in the audit log.
+func signRoot(ctx context.Context, account e2wtypes.Account, root spec.Root, domain spec.Domain) (spec.BLSSignature, error) {
+	sig, err := checkAndSignRoot(ctx, account, root, domain)
+	if auditErr := util.AuditSigning(account, root, domain, err); auditErr != nil && err == nil {
+		// Do not release a signature that is not recorded.
+		return spec.BLSSignature{}, auditErr
+	}
+
+	return sig, err
+}
+
+// checkAndSignRoot signs a root with a domain if allowed by the signing policy.
+func checkAndSignRoot(ctx context.Context, account e2wtypes.Account, root spec.Root, domain spec.Domain) (spec.BLSSignature, error) {
+	if err := util.CheckSigningPolicy(account, domain); err != nil {
+		return spec.BLSSignature{}, err
+	}
+
 	var signature e2types.Signature
+	var err error
 	// outputIf(debug, fmt.Sprintf("Signing %x (%d)", data, len(data)))
 	if protectingSigner, isProtectingSigner := account.(e2wtypes.AccountProtectingSigner); isProtectingSigner {
 		// Signer takes root and domain.
@@ -48,12 +82,6 @@ func SignRoot(ctx context.Context, account e2wtypes.Account, passphrases []strin
 		return spec.BLSSignature{}, err
 	}
 
-	if !alreadyUnlocked {
-		if err := Lock(ctx, account); err != nil {
-			return spec.BLSSignature{}, errors.Wrap(err, "failed to lock account")
-		}
-	}
-
 	var sig spec.BLSSignature
 	copy(sig[:], signature.Marshal())
 	return sig, nil
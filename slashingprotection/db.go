@@ -0,0 +1,462 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package slashingprotection provides a local slashing protection database
+// for block and attestation signing, stored in the EIP-3076 interchange format.
+package slashingprotection
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/go-bytesutil"
+)
+
+// interchangeFormatVersion is the version of the EIP-3076 interchange format.
+const interchangeFormatVersion = "5"
+
+// DB is a slashing protection database.
+type DB struct {
+	mutex                 sync.Mutex
+	path                  string
+	genesisValidatorsRoot phase0.Root
+	validators            map[phase0.BLSPubKey]*validatorRecord
+}
+
+type validatorRecord struct {
+	blocks       []*signedBlock
+	attestations []*signedAttestation
+}
+
+type signedBlock struct {
+	slot        phase0.Slot
+	signingRoot *phase0.Root
+}
+
+type signedAttestation struct {
+	sourceEpoch phase0.Epoch
+	targetEpoch phase0.Epoch
+	signingRoot *phase0.Root
+}
+
+// DefaultPath returns the default path of the slashing protection database.
+func DefaultPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to obtain user configuration directory")
+	}
+
+	return filepath.Join(configDir, "ethdo", "slashing-protection.json"), nil
+}
+
+// Open opens the slashing protection database at the given path, creating
+// an empty database if the file does not exist.
+func Open(path string, genesisValidatorsRoot phase0.Root) (*DB, error) {
+	db := &DB{
+		path:                  path,
+		genesisValidatorsRoot: genesisValidatorsRoot,
+		validators:            make(map[phase0.BLSPubKey]*validatorRecord),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return db, nil
+		}
+		return nil, errors.Wrap(err, "failed to read slashing protection database")
+	}
+
+	if err := db.unmarshal(data); err != nil {
+		return nil, errors.Wrap(err, "invalid slashing protection database")
+	}
+
+	return db, nil
+}
+
+// CheckAndRecordBlock checks that signing a block at the given slot with the
+// given signing root would not be slashable, and records it if so.
+func (db *DB) CheckAndRecordBlock(pubKey phase0.BLSPubKey, slot phase0.Slot, signingRoot phase0.Root) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	return db.recordBlock(pubKey, slot, signingRoot)
+}
+
+// CheckBlock checks that signing a block at the given slot with the given
+// signing root would not be slashable, without recording it.
+func (db *DB) CheckBlock(pubKey phase0.BLSPubKey, slot phase0.Slot, signingRoot phase0.Root) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	_, err := db.checkBlock(pubKey, slot, signingRoot)
+
+	return err
+}
+
+// RecordBlock records the signing of a block at the given slot with the given
+// signing root, once it has been signed.  The block is checked again, in case
+// another block has been recorded since it was checked.
+func (db *DB) RecordBlock(pubKey phase0.BLSPubKey, slot phase0.Slot, signingRoot phase0.Root) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	return db.recordBlock(pubKey, slot, signingRoot)
+}
+
+func (db *DB) recordBlock(pubKey phase0.BLSPubKey, slot phase0.Slot, signingRoot phase0.Root) error {
+	repeat, err := db.checkBlock(pubKey, slot, signingRoot)
+	if err != nil {
+		return err
+	}
+	if repeat {
+		return nil
+	}
+
+	record := db.record(pubKey)
+	record.blocks = append(record.blocks, &signedBlock{
+		slot:        slot,
+		signingRoot: &signingRoot,
+	})
+
+	return db.save()
+}
+
+// checkBlock checks that signing a block would not be slashable, returning
+// true if the block has already been signed.
+func (db *DB) checkBlock(pubKey phase0.BLSPubKey, slot phase0.Slot, signingRoot phase0.Root) (bool, error) {
+	record, exists := db.validators[pubKey]
+	if !exists || len(record.blocks) == 0 {
+		return false, nil
+	}
+
+	minSlot := record.blocks[0].slot
+	for _, block := range record.blocks {
+		if block.slot == slot {
+			if block.signingRoot != nil && *block.signingRoot == signingRoot {
+				// Repeat signing of the same block.
+				return true, nil
+			}
+			return false, fmt.Errorf("refusing to sign block: a different block has already been signed for slot %d", slot)
+		}
+		if block.slot < minSlot {
+			minSlot = block.slot
+		}
+	}
+	if slot <= minSlot {
+		return false, fmt.Errorf("refusing to sign block: slot %d is not after the earliest signed slot %d", slot, minSlot)
+	}
+
+	return false, nil
+}
+
+// CheckAndRecordAttestation checks that signing an attestation with the given
+// source and target epochs and signing root would not be slashable, and
+// records it if so.
+func (db *DB) CheckAndRecordAttestation(pubKey phase0.BLSPubKey, sourceEpoch phase0.Epoch, targetEpoch phase0.Epoch, signingRoot phase0.Root) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	return db.recordAttestation(pubKey, sourceEpoch, targetEpoch, signingRoot)
+}
+
+// CheckAttestation checks that signing an attestation with the given source
+// and target epochs and signing root would not be slashable, without
+// recording it.
+func (db *DB) CheckAttestation(pubKey phase0.BLSPubKey, sourceEpoch phase0.Epoch, targetEpoch phase0.Epoch, signingRoot phase0.Root) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	_, err := db.checkAttestation(pubKey, sourceEpoch, targetEpoch, signingRoot)
+
+	return err
+}
+
+// RecordAttestation records the signing of an attestation with the given
+// source and target epochs and signing root, once it has been signed.  The
+// attestation is checked again, in case another attestation has been
+// recorded since it was checked.
+func (db *DB) RecordAttestation(pubKey phase0.BLSPubKey, sourceEpoch phase0.Epoch, targetEpoch phase0.Epoch, signingRoot phase0.Root) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	return db.recordAttestation(pubKey, sourceEpoch, targetEpoch, signingRoot)
+}
+
+func (db *DB) recordAttestation(pubKey phase0.BLSPubKey, sourceEpoch phase0.Epoch, targetEpoch phase0.Epoch, signingRoot phase0.Root) error {
+	repeat, err := db.checkAttestation(pubKey, sourceEpoch, targetEpoch, signingRoot)
+	if err != nil {
+		return err
+	}
+	if repeat {
+		return nil
+	}
+
+	record := db.record(pubKey)
+	record.attestations = append(record.attestations, &signedAttestation{
+		sourceEpoch: sourceEpoch,
+		targetEpoch: targetEpoch,
+		signingRoot: &signingRoot,
+	})
+
+	return db.save()
+}
+
+// checkAttestation checks that signing an attestation would not be
+// slashable, returning true if the attestation has already been signed.
+func (db *DB) checkAttestation(pubKey phase0.BLSPubKey, sourceEpoch phase0.Epoch, targetEpoch phase0.Epoch, signingRoot phase0.Root) (bool, error) {
+	if sourceEpoch > targetEpoch {
+		return false, fmt.Errorf("refusing to sign attestation: source epoch %d is after target epoch %d", sourceEpoch, targetEpoch)
+	}
+
+	record, exists := db.validators[pubKey]
+	if !exists || len(record.attestations) == 0 {
+		return false, nil
+	}
+
+	minSourceEpoch := record.attestations[0].sourceEpoch
+	minTargetEpoch := record.attestations[0].targetEpoch
+	for _, attestation := range record.attestations {
+		if attestation.targetEpoch == targetEpoch {
+			if attestation.signingRoot != nil && *attestation.signingRoot == signingRoot {
+				// Repeat signing of the same attestation.
+				return true, nil
+			}
+			return false, fmt.Errorf("refusing to sign attestation: double vote for target epoch %d", targetEpoch)
+		}
+		if sourceEpoch < attestation.sourceEpoch && targetEpoch > attestation.targetEpoch {
+			return false, fmt.Errorf("refusing to sign attestation: it would surround the signed attestation with source epoch %d and target epoch %d", attestation.sourceEpoch, attestation.targetEpoch)
+		}
+		if sourceEpoch > attestation.sourceEpoch && targetEpoch < attestation.targetEpoch {
+			return false, fmt.Errorf("refusing to sign attestation: it would be surrounded by the signed attestation with source epoch %d and target epoch %d", attestation.sourceEpoch, attestation.targetEpoch)
+		}
+		if attestation.sourceEpoch < minSourceEpoch {
+			minSourceEpoch = attestation.sourceEpoch
+		}
+		if attestation.targetEpoch < minTargetEpoch {
+			minTargetEpoch = attestation.targetEpoch
+		}
+	}
+	if sourceEpoch < minSourceEpoch {
+		return false, fmt.Errorf("refusing to sign attestation: source epoch %d is before the earliest signed source epoch %d", sourceEpoch, minSourceEpoch)
+	}
+	if targetEpoch <= minTargetEpoch {
+		return false, fmt.Errorf("refusing to sign attestation: target epoch %d is not after the earliest signed target epoch %d", targetEpoch, minTargetEpoch)
+	}
+
+	return false, nil
+}
+
+// record returns the record for the given public key, creating it if required.
+func (db *DB) record(pubKey phase0.BLSPubKey) *validatorRecord {
+	record, exists := db.validators[pubKey]
+	if !exists {
+		record = &validatorRecord{}
+		db.validators[pubKey] = record
+	}
+
+	return record
+}
+
+// save writes the database to disk.
+func (db *DB) save() error {
+	data, err := db.marshal()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(db.path), 0o700); err != nil {
+		return errors.Wrap(err, "failed to create slashing protection database directory")
+	}
+
+	// Write to a temporary file and rename, to avoid a partially-written database.
+	tmpPath := fmt.Sprintf("%s.tmp", db.path)
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return errors.Wrap(err, "failed to write slashing protection database")
+	}
+	if err := os.Rename(tmpPath, db.path); err != nil {
+		return errors.Wrap(err, "failed to write slashing protection database")
+	}
+
+	return nil
+}
+
+type interchangeJSON struct {
+	Metadata *interchangeMetadataJSON `json:"metadata"`
+	Data     []*interchangeDataJSON   `json:"data"`
+}
+
+type interchangeMetadataJSON struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+}
+
+type interchangeDataJSON struct {
+	Pubkey             string                   `json:"pubkey"`
+	SignedBlocks       []*signedBlockJSON       `json:"signed_blocks"`
+	SignedAttestations []*signedAttestationJSON `json:"signed_attestations"`
+}
+
+type signedBlockJSON struct {
+	Slot        string `json:"slot"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+type signedAttestationJSON struct {
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+func (db *DB) marshal() ([]byte, error) {
+	pubKeys := make([]phase0.BLSPubKey, 0, len(db.validators))
+	for pubKey := range db.validators {
+		pubKeys = append(pubKeys, pubKey)
+	}
+	sort.Slice(pubKeys, func(i int, j int) bool {
+		return fmt.Sprintf("%#x", pubKeys[i]) < fmt.Sprintf("%#x", pubKeys[j])
+	})
+
+	data := &interchangeJSON{
+		Metadata: &interchangeMetadataJSON{
+			InterchangeFormatVersion: interchangeFormatVersion,
+			GenesisValidatorsRoot:    fmt.Sprintf("%#x", db.genesisValidatorsRoot),
+		},
+		Data: make([]*interchangeDataJSON, 0, len(pubKeys)),
+	}
+	for _, pubKey := range pubKeys {
+		record := db.validators[pubKey]
+		entry := &interchangeDataJSON{
+			Pubkey:             fmt.Sprintf("%#x", pubKey),
+			SignedBlocks:       make([]*signedBlockJSON, 0, len(record.blocks)),
+			SignedAttestations: make([]*signedAttestationJSON, 0, len(record.attestations)),
+		}
+		for _, block := range record.blocks {
+			blockJSON := &signedBlockJSON{
+				Slot: fmt.Sprintf("%d", block.slot),
+			}
+			if block.signingRoot != nil {
+				blockJSON.SigningRoot = fmt.Sprintf("%#x", *block.signingRoot)
+			}
+			entry.SignedBlocks = append(entry.SignedBlocks, blockJSON)
+		}
+		for _, attestation := range record.attestations {
+			attestationJSON := &signedAttestationJSON{
+				SourceEpoch: fmt.Sprintf("%d", attestation.sourceEpoch),
+				TargetEpoch: fmt.Sprintf("%d", attestation.targetEpoch),
+			}
+			if attestation.signingRoot != nil {
+				attestationJSON.SigningRoot = fmt.Sprintf("%#x", *attestation.signingRoot)
+			}
+			entry.SignedAttestations = append(entry.SignedAttestations, attestationJSON)
+		}
+		data.Data = append(data.Data, entry)
+	}
+
+	res, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal slashing protection database")
+	}
+
+	return res, nil
+}
+
+func (db *DB) unmarshal(input []byte) error {
+	var data interchangeJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	if data.Metadata == nil {
+		return errors.New("metadata missing")
+	}
+	if data.Metadata.InterchangeFormatVersion != interchangeFormatVersion {
+		return fmt.Errorf("unsupported interchange format version %q", data.Metadata.InterchangeFormatVersion)
+	}
+	genesisValidatorsRoot, err := parseRoot(data.Metadata.GenesisValidatorsRoot)
+	if err != nil {
+		return errors.Wrap(err, "invalid genesis validators root")
+	}
+	if *genesisValidatorsRoot != db.genesisValidatorsRoot {
+		return fmt.Errorf("database is for genesis validators root %#x, not %#x", *genesisValidatorsRoot, db.genesisValidatorsRoot)
+	}
+
+	for _, entry := range data.Data {
+		tmp, err := bytesutil.FromHexString(entry.Pubkey)
+		if err != nil || len(tmp) != phase0.PublicKeyLength {
+			return fmt.Errorf("invalid public key %q", entry.Pubkey)
+		}
+		pubKey := phase0.BLSPubKey(tmp)
+		record := db.record(pubKey)
+
+		for _, blockJSON := range entry.SignedBlocks {
+			slot, err := strconv.ParseUint(blockJSON.Slot, 10, 64)
+			if err != nil {
+				return errors.Wrap(err, "invalid slot")
+			}
+			block := &signedBlock{
+				slot: phase0.Slot(slot),
+			}
+			if blockJSON.SigningRoot != "" {
+				block.signingRoot, err = parseRoot(blockJSON.SigningRoot)
+				if err != nil {
+					return errors.Wrap(err, "invalid block signing root")
+				}
+			}
+			record.blocks = append(record.blocks, block)
+		}
+
+		for _, attestationJSON := range entry.SignedAttestations {
+			sourceEpoch, err := strconv.ParseUint(attestationJSON.SourceEpoch, 10, 64)
+			if err != nil {
+				return errors.Wrap(err, "invalid source epoch")
+			}
+			targetEpoch, err := strconv.ParseUint(attestationJSON.TargetEpoch, 10, 64)
+			if err != nil {
+				return errors.Wrap(err, "invalid target epoch")
+			}
+			attestation := &signedAttestation{
+				sourceEpoch: phase0.Epoch(sourceEpoch),
+				targetEpoch: phase0.Epoch(targetEpoch),
+			}
+			if attestationJSON.SigningRoot != "" {
+				attestation.signingRoot, err = parseRoot(attestationJSON.SigningRoot)
+				if err != nil {
+					return errors.Wrap(err, "invalid attestation signing root")
+				}
+			}
+			record.attestations = append(record.attestations, attestation)
+		}
+	}
+
+	return nil
+}
+
+func parseRoot(input string) (*phase0.Root, error) {
+	tmp, err := bytesutil.FromHexString(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(tmp) != phase0.RootLength {
+		return nil, errors.New("incorrect length")
+	}
+	root := phase0.Root(tmp)
+
+	return &root, nil
+}
@@ -0,0 +1,149 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotection_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/slashingprotection"
+	"github.com/wealdtech/ethdo/testutil"
+)
+
+func TestBlocks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slashing-protection.json")
+	genesisValidatorsRoot := phase0.Root{0x01}
+	pubKey := phase0.BLSPubKey{0x02}
+
+	db, err := slashingprotection.Open(path, genesisValidatorsRoot)
+	require.NoError(t, err)
+
+	require.NoError(t, db.CheckAndRecordBlock(pubKey, 10, phase0.Root{0x10}))
+	// Repeat signing is allowed.
+	require.NoError(t, db.CheckAndRecordBlock(pubKey, 10, phase0.Root{0x10}))
+	// Double proposal is refused.
+	require.EqualError(t, db.CheckAndRecordBlock(pubKey, 10, phase0.Root{0x11}), "refusing to sign block: a different block has already been signed for slot 10")
+	// Earlier slot is refused.
+	require.EqualError(t, db.CheckAndRecordBlock(pubKey, 9, phase0.Root{0x09}), "refusing to sign block: slot 9 is not after the earliest signed slot 10")
+	// Later slot is allowed.
+	require.NoError(t, db.CheckAndRecordBlock(pubKey, 11, phase0.Root{0x12}))
+	// Other validators are unaffected.
+	require.NoError(t, db.CheckAndRecordBlock(phase0.BLSPubKey{0x03}, 9, phase0.Root{0x09}))
+
+	// Ensure the records persist.
+	db, err = slashingprotection.Open(path, genesisValidatorsRoot)
+	require.NoError(t, err)
+	require.EqualError(t, db.CheckAndRecordBlock(pubKey, 11, phase0.Root{0x13}), "refusing to sign block: a different block has already been signed for slot 11")
+}
+
+func TestAttestations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slashing-protection.json")
+	pubKey := phase0.BLSPubKey{0x02}
+
+	db, err := slashingprotection.Open(path, phase0.Root{0x01})
+	require.NoError(t, err)
+
+	require.EqualError(t, db.CheckAndRecordAttestation(pubKey, 5, 4, phase0.Root{0x01}), "refusing to sign attestation: source epoch 5 is after target epoch 4")
+	require.NoError(t, db.CheckAndRecordAttestation(pubKey, 2, 3, phase0.Root{0x01}))
+	// Repeat signing is allowed.
+	require.NoError(t, db.CheckAndRecordAttestation(pubKey, 2, 3, phase0.Root{0x01}))
+	// Double vote is refused.
+	require.EqualError(t, db.CheckAndRecordAttestation(pubKey, 2, 3, phase0.Root{0x02}), "refusing to sign attestation: double vote for target epoch 3")
+	require.NoError(t, db.CheckAndRecordAttestation(pubKey, 4, 6, phase0.Root{0x03}))
+	// Surrounding vote is refused.
+	require.EqualError(t, db.CheckAndRecordAttestation(pubKey, 3, 7, phase0.Root{0x04}), "refusing to sign attestation: it would surround the signed attestation with source epoch 4 and target epoch 6")
+	// Surrounded vote is refused.
+	require.EqualError(t, db.CheckAndRecordAttestation(pubKey, 5, 5, phase0.Root{0x05}), "refusing to sign attestation: it would be surrounded by the signed attestation with source epoch 4 and target epoch 6")
+	// Votes below the low watermarks are refused.
+	require.EqualError(t, db.CheckAndRecordAttestation(pubKey, 1, 2, phase0.Root{0x06}), "refusing to sign attestation: source epoch 1 is before the earliest signed source epoch 2")
+	require.NoError(t, db.CheckAndRecordAttestation(pubKey, 6, 8, phase0.Root{0x07}))
+}
+
+func TestCheckThenRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slashing-protection.json")
+	pubKey := phase0.BLSPubKey{0x02}
+
+	db, err := slashingprotection.Open(path, phase0.Root{0x01})
+	require.NoError(t, err)
+
+	// Checking does not record.
+	require.NoError(t, db.CheckBlock(pubKey, 10, phase0.Root{0x10}))
+	require.NoError(t, db.CheckBlock(pubKey, 10, phase0.Root{0x11}))
+	require.NoError(t, db.CheckAttestation(pubKey, 2, 3, phase0.Root{0x01}))
+	require.NoError(t, db.CheckAttestation(pubKey, 2, 3, phase0.Root{0x02}))
+
+	// Recording does.
+	require.NoError(t, db.RecordBlock(pubKey, 10, phase0.Root{0x10}))
+	require.NoError(t, db.CheckBlock(pubKey, 10, phase0.Root{0x10}))
+	require.EqualError(t, db.CheckBlock(pubKey, 10, phase0.Root{0x11}), "refusing to sign block: a different block has already been signed for slot 10")
+	require.NoError(t, db.RecordAttestation(pubKey, 2, 3, phase0.Root{0x01}))
+	require.NoError(t, db.CheckAttestation(pubKey, 2, 3, phase0.Root{0x01}))
+	require.EqualError(t, db.CheckAttestation(pubKey, 2, 3, phase0.Root{0x02}), "refusing to sign attestation: double vote for target epoch 3")
+
+	// Recording re-checks.
+	require.EqualError(t, db.RecordBlock(pubKey, 10, phase0.Root{0x11}), "refusing to sign block: a different block has already been signed for slot 10")
+	require.EqualError(t, db.RecordAttestation(pubKey, 2, 3, phase0.Root{0x02}), "refusing to sign attestation: double vote for target epoch 3")
+}
+
+func TestOpen(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "slashing-protection.json")
+	db, err := slashingprotection.Open(path, phase0.Root{0x01})
+	require.NoError(t, err)
+	require.NoError(t, db.CheckAndRecordBlock(phase0.BLSPubKey{0x02}, 1, phase0.Root{0x03}))
+
+	_, err = slashingprotection.Open(path, phase0.Root{0x02})
+	require.EqualError(t, err, "invalid slashing protection database: database is for genesis validators root 0x0100000000000000000000000000000000000000000000000000000000000000, not 0x0200000000000000000000000000000000000000000000000000000000000000")
+
+	// Interchange data from another client, without signing roots.
+	interchangePath := filepath.Join(dir, "interchange.json")
+	require.NoError(t, os.WriteFile(interchangePath, []byte(`{
+  "metadata": {
+    "interchange_format_version": "5",
+    "genesis_validators_root": "0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"
+  },
+  "data": [
+    {
+      "pubkey": "0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed",
+      "signed_blocks": [
+        {
+          "slot": "81952"
+        }
+      ],
+      "signed_attestations": [
+        {
+          "source_epoch": "2290",
+          "target_epoch": "3007"
+        }
+      ]
+    }
+  ]
+}`), 0o600))
+	genesisValidatorsRoot := phase0.Root(testutil.HexToBytes("0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"))
+	db, err = slashingprotection.Open(interchangePath, genesisValidatorsRoot)
+	require.NoError(t, err)
+	pubKey := phase0.BLSPubKey(testutil.HexToBytes("0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed"))
+	// Without a signing root even an identical block cannot be re-signed.
+	require.EqualError(t, db.CheckAndRecordBlock(pubKey, 81952, phase0.Root{}), "refusing to sign block: a different block has already been signed for slot 81952")
+	require.EqualError(t, db.CheckAndRecordAttestation(pubKey, 2290, 3007, phase0.Root{}), "refusing to sign attestation: double vote for target epoch 3007")
+	require.NoError(t, db.CheckAndRecordAttestation(pubKey, 3007, 3008, phase0.Root{0x01}))
+
+	require.NoError(t, os.WriteFile(interchangePath, []byte(`{"metadata":{"interchange_format_version":"4","genesis_validators_root":"0x00"},"data":[]}`), 0o600))
+	_, err = slashingprotection.Open(interchangePath, genesisValidatorsRoot)
+	require.EqualError(t, err, `invalid slashing protection database: unsupported interchange format version "4"`)
+}
@@ -0,0 +1,79 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mock
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// ValidatorsService is a mock beacon node that knows of a set of validators.
+// Requests made directly to the beacon node API, such as for pending
+// deposits, are served from its address.
+type ValidatorsService struct {
+	address    string
+	validators map[phase0.BLSPubKey]phase0.ValidatorIndex
+}
+
+// NewValidatorsService returns a mock validators service with the provided values.
+func NewValidatorsService(address string,
+	validators map[phase0.BLSPubKey]phase0.ValidatorIndex,
+) eth2client.Service {
+	return &ValidatorsService{
+		address:    address,
+		validators: validators,
+	}
+}
+
+// Name is a mock.
+func (*ValidatorsService) Name() string {
+	return "mock"
+}
+
+// Address is a mock.
+func (m *ValidatorsService) Address() string {
+	return m.address
+}
+
+// IsActive is a mock.
+func (*ValidatorsService) IsActive() bool {
+	return true
+}
+
+// IsSynced is a mock.
+func (*ValidatorsService) IsSynced() bool {
+	return true
+}
+
+// Validators is a mock.
+func (m *ValidatorsService) Validators(_ context.Context, opts *api.ValidatorsOpts) (*api.Response[map[phase0.ValidatorIndex]*apiv1.Validator], error) {
+	res := make(map[phase0.ValidatorIndex]*apiv1.Validator)
+	for _, pubKey := range opts.PubKeys {
+		if index, exists := m.validators[pubKey]; exists {
+			res[index] = &apiv1.Validator{
+				Index:     index,
+				Validator: &phase0.Validator{PublicKey: pubKey},
+			}
+		}
+	}
+
+	return &api.Response[map[phase0.ValidatorIndex]*apiv1.Validator]{
+		Data:     res,
+		Metadata: make(map[string]any),
+	}, nil
+}
@@ -48,7 +48,14 @@ func ParseAccount(ctx context.Context,
 		// This could be a keystore.
 		return parseAccountFromKeystore(ctx, accountStr, supplementary, unlock)
 	case strings.Contains(accountStr, "/"):
-		// An account specifier.
+		// An account specifier, which could be for an account held in a PKCS#11 token.
+		pkcs11Account, err := parseAccountFromPKCS11(ctx, accountStr, supplementary, unlock)
+		if err != nil {
+			return nil, err
+		}
+		if pkcs11Account != nil {
+			return pkcs11Account, nil
+		}
 		account, err := parseAccountFromSpecifier(ctx, accountStr, supplementary, unlock)
 		if err != nil {
 			// It is possible that this is actually a path to a keystore, so try that instead.
@@ -251,10 +258,10 @@ func accountFromMnemonicAndPath(mnemonic string, path string) (e2wtypes.Account,
 		return nil, err
 	}
 
-	// Ensure the path is valid.
-	match := hdPathRegex.MatchString(path)
-	if !match {
-		return nil, errors.New("path does not match expected format m/…")
+	// Ensure the path is valid.  Non-standard paths are allowed here; it is
+	// up to the caller to warn about them.
+	if _, err := ValidateDerivationPath(path); err != nil {
+		return nil, err
 	}
 
 	// Derive private key from seed and path.
@@ -306,6 +313,9 @@ func UnlockAccount(ctx context.Context, account e2wtypes.Account, passphrases []
 	}
 
 	// Failed to unlock it.
+	if IsWatchOnlyAccount(account) {
+		return false, errors.New("account is watch-only and cannot sign")
+	}
 	return false, errors.New("failed to unlock account")
 }
 
@@ -0,0 +1,42 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// AccountNamesByPubKey returns the names, in the form wallet/account, of the
+// accounts in the supplied wallets keyed by their public keys.
+func AccountNamesByPubKey(ctx context.Context, wallets <-chan e2wtypes.Wallet) (map[phase0.BLSPubKey]string, error) {
+	res := make(map[phase0.BLSPubKey]string)
+	for wallet := range wallets {
+		for account := range wallet.Accounts(ctx) {
+			accPubKey, err := BestPublicKey(account)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain public key for %s/%s", wallet.Name(), account.Name()))
+			}
+			var pubKey phase0.BLSPubKey
+			copy(pubKey[:], accPubKey.Marshal())
+			res[pubKey] = fmt.Sprintf("%s/%s", wallet.Name(), account.Name())
+		}
+	}
+
+	return res, nil
+}
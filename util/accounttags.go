@@ -0,0 +1,225 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// ValidateAccountTag ensures that a tag is suitable for attaching to an account.
+func ValidateAccountTag(tag string) error {
+	if tag == "" {
+		return errors.New("tag cannot be empty")
+	}
+	for _, r := range tag {
+		if unicode.IsSpace(r) || r == ',' || !unicode.IsPrint(r) {
+			return fmt.Errorf("tag %q contains invalid characters", tag)
+		}
+	}
+
+	return nil
+}
+
+// AccountTags returns the tags attached to an account.
+func AccountTags(_ context.Context, wallet e2wtypes.Wallet, account e2wtypes.Account) ([]string, error) {
+	store, walletID, accountID, err := accountStoreDetails(wallet, account)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := store.RetrieveAccount(walletID, accountID)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to retrieve account %s", account.Name()))
+	}
+	data, err := parseAccountData(stored)
+	if err != nil {
+		return nil, err
+	}
+
+	return tagsFromAccountData(data)
+}
+
+// SetAccountTags replaces the tags attached to an account.
+func SetAccountTags(_ context.Context, wallet e2wtypes.Wallet, account e2wtypes.Account, tags []string) error {
+	for _, tag := range tags {
+		if err := ValidateAccountTag(tag); err != nil {
+			return err
+		}
+	}
+
+	store, walletID, accountID, err := accountStoreDetails(wallet, account)
+	if err != nil {
+		return err
+	}
+	stored, err := store.RetrieveAccount(walletID, accountID)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to retrieve account %s", account.Name()))
+	}
+	data, err := parseAccountData(stored)
+	if err != nil {
+		return err
+	}
+
+	// Store a sorted set of tags, removing the field entirely if there are none.
+	tagSet := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tagSet[tag] = struct{}{}
+	}
+	if len(tagSet) == 0 {
+		delete(data, "tags")
+	} else {
+		sortedTags := make([]string, 0, len(tagSet))
+		for tag := range tagSet {
+			sortedTags = append(sortedTags, tag)
+		}
+		sort.Strings(sortedTags)
+		data["tags"] = sortedTags
+	}
+
+	updated, err := json.Marshal(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate account")
+	}
+	if err := store.StoreAccount(walletID, accountID, updated); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to store account %s", account.Name()))
+	}
+
+	return nil
+}
+
+// GetAccountsTagged returns the tags that accounts must carry to be selected, if any.
+func GetAccountsTagged() []string {
+	tags := make([]string, 0)
+	for _, tag := range viper.GetStringSlice("accounts-tagged") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// FilterAccountsByTags returns the accounts that carry all of the supplied tags.
+func FilterAccountsByTags(ctx context.Context,
+	wallet e2wtypes.Wallet,
+	accounts []e2wtypes.Account,
+	tags []string,
+) (
+	[]e2wtypes.Account,
+	error,
+) {
+	if len(tags) == 0 {
+		return accounts, nil
+	}
+
+	filtered := make([]e2wtypes.Account, 0, len(accounts))
+	for _, account := range accounts {
+		accountTags, err := AccountTags(ctx, wallet, account)
+		if err != nil {
+			return nil, err
+		}
+		if hasAllTags(accountTags, tags) {
+			filtered = append(filtered, account)
+		}
+	}
+
+	return filtered, nil
+}
+
+func hasAllTags(accountTags []string, tags []string) bool {
+	for _, tag := range tags {
+		found := false
+		for _, accountTag := range accountTags {
+			if accountTag == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// accountStoreDetails obtains the information required to access an account's stored data.
+func accountStoreDetails(wallet e2wtypes.Wallet,
+	account e2wtypes.Account,
+) (
+	e2wtypes.Store,
+	[16]byte,
+	[16]byte,
+	error,
+) {
+	storeProvider, isStoreProvider := wallet.(e2wtypes.StoreProvider)
+	if !isStoreProvider {
+		return nil, [16]byte{}, [16]byte{}, errors.New("wallet does not support account tags")
+	}
+	walletIDProvider, isWalletIDProvider := wallet.(e2wtypes.WalletIDProvider)
+	if !isWalletIDProvider {
+		return nil, [16]byte{}, [16]byte{}, errors.New("wallet does not provide its ID")
+	}
+	accountIDProvider, isAccountIDProvider := account.(e2wtypes.AccountIDProvider)
+	if !isAccountIDProvider {
+		return nil, [16]byte{}, [16]byte{}, fmt.Errorf("account %s does not provide its ID", account.Name())
+	}
+
+	return storeProvider.Store(), walletIDProvider.ID(), accountIDProvider.ID(), nil
+}
+
+// parseAccountData parses stored account data, retaining all fields.
+func parseAccountData(stored []byte) (map[string]any, error) {
+	// Use numbers to avoid losing precision in large values such as participant IDs.
+	decoder := json.NewDecoder(bytes.NewReader(stored))
+	decoder.UseNumber()
+	data := make(map[string]any)
+	if err := decoder.Decode(&data); err != nil {
+		return nil, errors.Wrap(err, "failed to parse account")
+	}
+
+	return data, nil
+}
+
+// tagsFromAccountData obtains the tags from parsed account data.
+func tagsFromAccountData(data map[string]any) ([]string, error) {
+	rawTags, exists := data["tags"]
+	if !exists {
+		return []string{}, nil
+	}
+	tagList, isList := rawTags.([]any)
+	if !isList {
+		return nil, errors.New("account tags are invalid")
+	}
+	tags := make([]string, 0, len(tagList))
+	for _, rawTag := range tagList {
+		tag, isString := rawTag.(string)
+		if !isString {
+			return nil, errors.New("account tags are invalid")
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,122 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func TestValidateAccountTag(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+		err  string
+	}{
+		{
+			name: "Empty",
+			err:  "tag cannot be empty",
+		},
+		{
+			name: "Space",
+			tag:  "batch 3",
+			err:  `tag "batch 3" contains invalid characters`,
+		},
+		{
+			name: "Comma",
+			tag:  "batch,3",
+			err:  `tag "batch,3" contains invalid characters`,
+		},
+		{
+			name: "Good",
+			tag:  "mainnet-batch-3",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := util.ValidateAccountTag(test.tag)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAccountTags(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx := context.Background()
+
+	store := scratch.New()
+	wallet, err := nd.CreateWallet(ctx, "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	account0, err := wallet.(e2wtypes.WalletAccountImporter).ImportAccount(ctx, "Interop 0", testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"), []byte("pass0"))
+	require.NoError(t, err)
+	account1, err := wallet.(e2wtypes.WalletAccountImporter).ImportAccount(ctx, "Interop 1", testutil.HexToBytes("0x51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000"), []byte("pass1"))
+	require.NoError(t, err)
+
+	tags, err := util.AccountTags(ctx, wallet, account0)
+	require.NoError(t, err)
+	require.Empty(t, tags)
+
+	require.EqualError(t, util.SetAccountTags(ctx, wallet, account0, []string{"bad tag"}), `tag "bad tag" contains invalid characters`)
+	require.NoError(t, util.SetAccountTags(ctx, wallet, account0, []string{"red", "batch-3", "red"}))
+	require.NoError(t, util.SetAccountTags(ctx, wallet, account1, []string{"batch-3"}))
+	tags, err = util.AccountTags(ctx, wallet, account0)
+	require.NoError(t, err)
+	require.Equal(t, []string{"batch-3", "red"}, tags)
+
+	accounts := []e2wtypes.Account{account0, account1}
+	filtered, err := util.FilterAccountsByTags(ctx, wallet, accounts, nil)
+	require.NoError(t, err)
+	require.Len(t, filtered, 2)
+	filtered, err = util.FilterAccountsByTags(ctx, wallet, accounts, []string{"batch-3"})
+	require.NoError(t, err)
+	require.Len(t, filtered, 2)
+	filtered, err = util.FilterAccountsByTags(ctx, wallet, accounts, []string{"batch-3", "red"})
+	require.NoError(t, err)
+	require.Equal(t, []e2wtypes.Account{account0}, filtered)
+	filtered, err = util.FilterAccountsByTags(ctx, wallet, accounts, []string{"blue"})
+	require.NoError(t, err)
+	require.Empty(t, filtered)
+
+	// Tags should survive a passphrase change.
+	require.NoError(t, util.ChangeAccountPassphrases(ctx, wallet, []e2wtypes.Account{account0}, []string{"pass0"}, "new passphrase"))
+	tags, err = util.AccountTags(ctx, wallet, account0)
+	require.NoError(t, err)
+	require.Equal(t, []string{"batch-3", "red"}, tags)
+
+	// Removing all tags should leave the account usable.
+	require.NoError(t, util.SetAccountTags(ctx, wallet, account0, nil))
+	tags, err = util.AccountTags(ctx, wallet, account0)
+	require.NoError(t, err)
+	require.Empty(t, tags)
+	reopened, err := nd.OpenWallet(ctx, "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+	account, err := reopened.(e2wtypes.WalletAccountByNameProvider).AccountByName(ctx, "Interop 0")
+	require.NoError(t, err)
+	require.NoError(t, account.(e2wtypes.AccountLocker).Unlock(ctx, []byte("new passphrase")))
+}
@@ -0,0 +1,61 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// LogActivity appends an entry for an action to the activity log, which is
+// the file given by the "log" option or ethdo.log in the user's home
+// directory.  Each entry is a line of JSON containing the time, the action
+// and the supplied details.
+func LogActivity(action string, details map[string]any) error {
+	path := viper.GetString("log")
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain home directory")
+		}
+		path = filepath.Join(home, "ethdo.log")
+	}
+
+	entry := make(map[string]any, len(details)+2)
+	for k, v := range details {
+		entry[k] = v
+	}
+	entry["time"] = time.Now().UTC().Format(time.RFC3339)
+	entry["action"] = action
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate log entry")
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "failed to open activity log")
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return errors.Wrap(err, "failed to write activity log")
+	}
+
+	return f.Close()
+}
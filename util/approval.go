@@ -0,0 +1,420 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/go-bytesutil"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+const (
+	// ApprovalOperationValidatorExit is the approval operation for validator exits.
+	ApprovalOperationValidatorExit = "validator exit"
+	// ApprovalOperationCredentialsChange is the approval operation for withdrawal credentials changes.
+	ApprovalOperationCredentialsChange = "validator credentials set"
+)
+
+// ApprovalDomainType is the application domain type used to sign approvals.
+var ApprovalDomainType = phase0.DomainType{0x00, 0x00, 0x01, 0x01}
+
+// ApprovalPolicy is a policy requiring approval of sensitive operations
+// before they are signed, configured with the "approval" option.
+type ApprovalPolicy struct {
+	// Approvers are the public keys of the approvers.
+	Approvers []string `mapstructure:"approvers"`
+	// Threshold is the number of approvers required to approve an operation.
+	Threshold int `mapstructure:"threshold"`
+
+	approvers []e2types.PublicKey
+}
+
+// ApprovalRequest is a request for approval of a set of operations.
+type ApprovalRequest struct {
+	Operation  string            `json:"operation"`
+	Domain     string            `json:"domain"`
+	Roots      []string          `json:"roots"`
+	Operations []json.RawMessage `json:"operations"`
+	Approvals  []*Approval       `json:"approvals"`
+}
+
+// Approval is an approval of a request.
+type Approval struct {
+	PublicKey string `json:"public_key"`
+	Signature string `json:"signature"`
+}
+
+// ObtainApprovalPolicy obtains the approval policy from the configuration,
+// returning nil if there is no policy.
+func ObtainApprovalPolicy() (*ApprovalPolicy, error) {
+	if !viper.IsSet("approval") {
+		return nil, nil
+	}
+
+	policy := &ApprovalPolicy{}
+	if err := viper.UnmarshalKey("approval", policy); err != nil {
+		return nil, errors.Wrap(err, "invalid approval policy")
+	}
+
+	if len(policy.Approvers) == 0 {
+		return nil, errors.New("invalid approval policy: no approvers")
+	}
+	for _, approver := range policy.Approvers {
+		data, err := bytesutil.FromHexString(approver)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid approval policy: invalid approver %s", approver)
+		}
+		pubKey, err := e2types.BLSPublicKeyFromBytes(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid approval policy: invalid approver %s", approver)
+		}
+		policy.approvers = append(policy.approvers, pubKey)
+	}
+	if policy.Threshold == 0 {
+		policy.Threshold = 1
+	}
+	if policy.Threshold < 0 || policy.Threshold > len(policy.approvers) {
+		return nil, fmt.Errorf("invalid approval policy: threshold must be between 1 and %d", len(policy.approvers))
+	}
+
+	return policy, nil
+}
+
+// IsApprover returns true if the public key is that of an approver.
+func (p *ApprovalPolicy) IsApprover(pubKey e2types.PublicKey) bool {
+	for _, approver := range p.approvers {
+		if bytes.Equal(approver.Marshal(), pubKey.Marshal()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Approvals returns the number of distinct approvers that have validly
+// approved the request.
+func (p *ApprovalPolicy) Approvals(request *ApprovalRequest) int {
+	approvals := 0
+	for _, approver := range p.approvers {
+		for _, approval := range request.Approvals {
+			pubKey, err := bytesutil.FromHexString(approval.PublicKey)
+			if err != nil || !bytes.Equal(pubKey, approver.Marshal()) {
+				continue
+			}
+			if err := request.VerifyApproval(approval); err == nil {
+				approvals++
+
+				break
+			}
+		}
+	}
+
+	return approvals
+}
+
+// ApprovalDomain returns the domain used to sign approvals.
+func ApprovalDomain() phase0.Domain {
+	// Cannot fail, as all inputs are fixed length.
+	domain, _ := ComputeDomain(ApprovalDomainType, phase0.Version{}, phase0.Root{})
+
+	return domain
+}
+
+// ReadApprovalRequest reads an approval request from a file.
+func ReadApprovalRequest(filename string) (*ApprovalRequest, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read approval request")
+	}
+	request := &ApprovalRequest{}
+	if err := json.Unmarshal(data, request); err != nil {
+		return nil, errors.Wrap(err, "failed to parse approval request")
+	}
+	if err := request.verifyOperations(); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// Write writes the approval request to a file.
+func (r *ApprovalRequest) Write(filename string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal approval request")
+	}
+	if err := os.WriteFile(filename, data, 0o600); err != nil {
+		return errors.Wrapf(err, "failed to write %s", filename)
+	}
+
+	return nil
+}
+
+// Root returns the root of the request that approvers sign.
+func (r *ApprovalRequest) Root() (phase0.Root, error) {
+	hash := sha256.New()
+	hash.Write([]byte(r.Operation))
+	hash.Write([]byte{0x00})
+	domain, err := bytesutil.FromHexString(r.Domain)
+	if err != nil {
+		return phase0.Root{}, errors.Wrap(err, "invalid domain in approval request")
+	}
+	hash.Write(domain)
+	for _, root := range r.Roots {
+		data, err := bytesutil.FromHexString(root)
+		if err != nil {
+			return phase0.Root{}, errors.Wrap(err, "invalid root in approval request")
+		}
+		hash.Write(data)
+	}
+
+	var root phase0.Root
+	copy(root[:], hash.Sum(nil))
+
+	return root, nil
+}
+
+// Contains returns true if the request is for the given operation, domain and root.
+func (r *ApprovalRequest) Contains(operation string, domain phase0.Domain, root phase0.Root) bool {
+	if r.Operation != operation || r.Domain != fmt.Sprintf("%#x", domain) {
+		return false
+	}
+	for _, requestRoot := range r.Roots {
+		if requestRoot == fmt.Sprintf("%#x", root) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AddApproval adds an approval to the request, replacing any existing
+// approval from the same public key.
+func (r *ApprovalRequest) AddApproval(pubKey e2types.PublicKey, signature phase0.BLSSignature) error {
+	approval := &Approval{
+		PublicKey: fmt.Sprintf("%#x", pubKey.Marshal()),
+		Signature: fmt.Sprintf("%#x", signature),
+	}
+	if err := r.VerifyApproval(approval); err != nil {
+		return err
+	}
+
+	approvals := make([]*Approval, 0, len(r.Approvals)+1)
+	for _, existing := range r.Approvals {
+		if existing.PublicKey != approval.PublicKey {
+			approvals = append(approvals, existing)
+		}
+	}
+	r.Approvals = append(approvals, approval)
+
+	return nil
+}
+
+// VerifyApproval verifies an approval of the request.
+func (r *ApprovalRequest) VerifyApproval(approval *Approval) error {
+	data, err := bytesutil.FromHexString(approval.PublicKey)
+	if err != nil {
+		return errors.Wrap(err, "invalid approval public key")
+	}
+	pubKey, err := e2types.BLSPublicKeyFromBytes(data)
+	if err != nil {
+		return errors.Wrap(err, "invalid approval public key")
+	}
+	data, err = bytesutil.FromHexString(approval.Signature)
+	if err != nil {
+		return errors.Wrap(err, "invalid approval signature")
+	}
+	signature, err := e2types.BLSSignatureFromBytes(data)
+	if err != nil {
+		return errors.Wrap(err, "invalid approval signature")
+	}
+
+	root, err := r.Root()
+	if err != nil {
+		return err
+	}
+	signingRoot, err := (&phase0.SigningData{
+		ObjectRoot: root,
+		Domain:     ApprovalDomain(),
+	}).HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate signing root")
+	}
+	if !signature.Verify(signingRoot[:], pubKey) {
+		return errors.New("approval signature does not verify")
+	}
+
+	return nil
+}
+
+// verifyOperations ensures that the operations in the request match its
+// roots, so that approvers see the operations that they are approving.
+func (r *ApprovalRequest) verifyOperations() error {
+	if len(r.Operations) != len(r.Roots) {
+		return errors.New("approval request has mismatched operations and roots")
+	}
+	for i, data := range r.Operations {
+		var root phase0.Root
+		var err error
+		switch r.Operation {
+		case ApprovalOperationValidatorExit:
+			operation := &phase0.VoluntaryExit{}
+			if err := json.Unmarshal(data, operation); err != nil {
+				return errors.Wrap(err, "invalid operation in approval request")
+			}
+			root, err = operation.HashTreeRoot()
+		case ApprovalOperationCredentialsChange:
+			operation := &capella.BLSToExecutionChange{}
+			if err := json.Unmarshal(data, operation); err != nil {
+				return errors.Wrap(err, "invalid operation in approval request")
+			}
+			root, err = operation.HashTreeRoot()
+		default:
+			return fmt.Errorf("unknown operation %s in approval request", r.Operation)
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to generate root for operation in approval request")
+		}
+		if fmt.Sprintf("%#x", root) != r.Roots[i] {
+			return fmt.Errorf("operation %d in approval request does not match its root", i+1)
+		}
+	}
+
+	return nil
+}
+
+// ApprovalGate holds back the signing of operations until they have been
+// approved according to the approval policy.
+type ApprovalGate struct {
+	operation string
+	filename  string
+	policy    *ApprovalPolicy
+	request   *ApprovalRequest
+	pending   *ApprovalRequest
+}
+
+// NewApprovalGate creates a gate for the given operation, using the named
+// file for the approval request.  It returns nil if no approval is required.
+func NewApprovalGate(operation string, filename string) (*ApprovalGate, error) {
+	policy, err := ObtainApprovalPolicy()
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return nil, nil
+	}
+
+	gate := &ApprovalGate{
+		operation: operation,
+		filename:  filename,
+		policy:    policy,
+	}
+	if _, err := os.Stat(filename); err == nil {
+		gate.request, err = ReadApprovalRequest(filename)
+		if err != nil {
+			return nil, err
+		}
+		if gate.request.Operation != operation {
+			return nil, fmt.Errorf("approval request in %s is for %s rather than %s", filename, gate.request.Operation, operation)
+		}
+	}
+
+	return gate, nil
+}
+
+// Request returns the existing approval request, if any.
+func (g *ApprovalGate) Request() *ApprovalRequest {
+	if g == nil {
+		return nil
+	}
+
+	return g.request
+}
+
+// Approved returns true if the operation with the given root has been
+// approved.  If not, the operation is added to the pending approval request.
+func (g *ApprovalGate) Approved(domain phase0.Domain, root phase0.Root, operation any) (bool, error) {
+	if g == nil {
+		return true, nil
+	}
+
+	if g.request != nil &&
+		g.request.Contains(g.operation, domain, root) &&
+		g.policy.Approvals(g.request) >= g.policy.Threshold {
+		return true, nil
+	}
+
+	data, err := json.Marshal(operation)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to marshal operation")
+	}
+	if g.pending == nil {
+		g.pending = &ApprovalRequest{
+			Operation:  g.operation,
+			Domain:     fmt.Sprintf("%#x", domain),
+			Roots:      make([]string, 0),
+			Operations: make([]json.RawMessage, 0),
+			Approvals:  make([]*Approval, 0),
+		}
+	}
+	g.pending.Roots = append(g.pending.Roots, fmt.Sprintf("%#x", root))
+	g.pending.Operations = append(g.pending.Operations, data)
+
+	return false, nil
+}
+
+// Pending returns true if there are operations awaiting approval.
+func (g *ApprovalGate) Pending() bool {
+	return g != nil && g.pending != nil
+}
+
+// WritePending writes the pending approval request, returning a description
+// of its state.  An existing request for the same operations is retained
+// along with its approvals.
+func (g *ApprovalGate) WritePending() (string, error) {
+	if !g.Pending() {
+		return "", errors.New("no operations awaiting approval")
+	}
+
+	if g.request != nil {
+		existingRoot, err := g.request.Root()
+		if err != nil {
+			return "", err
+		}
+		pendingRoot, err := g.pending.Root()
+		if err != nil {
+			return "", err
+		}
+		if !bytes.Equal(existingRoot[:], pendingRoot[:]) {
+			return "", fmt.Errorf("approval request in %s does not match the operations; remove it to create a new request", g.filename)
+		}
+
+		return fmt.Sprintf("Approval request %s has %d of %d required approvals", g.filename, g.policy.Approvals(g.request), g.policy.Threshold), nil
+	}
+
+	if err := g.pending.Write(g.filename); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Approval request written to %s; it requires %d approvals with \"ethdo approve\"", g.filename, g.policy.Threshold), nil
+}
@@ -0,0 +1,200 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func approvalKey(t *testing.T, seed byte) e2types.PrivateKey {
+	t.Helper()
+	data := make([]byte, 32)
+	data[31] = seed
+	key, err := e2types.BLSPrivateKeyFromBytes(data)
+	require.NoError(t, err)
+
+	return key
+}
+
+func approve(t *testing.T, request *util.ApprovalRequest, key e2types.PrivateKey) {
+	t.Helper()
+	root, err := request.Root()
+	require.NoError(t, err)
+	signingRoot, err := (&phase0.SigningData{
+		ObjectRoot: root,
+		Domain:     util.ApprovalDomain(),
+	}).HashTreeRoot()
+	require.NoError(t, err)
+	var signature phase0.BLSSignature
+	copy(signature[:], key.Sign(signingRoot[:]).Marshal())
+	require.NoError(t, request.AddApproval(key.PublicKey(), signature))
+}
+
+func TestObtainApprovalPolicy(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	approver := fmt.Sprintf("%#x", approvalKey(t, 1).PublicKey().Marshal())
+
+	tests := []struct {
+		name      string
+		policy    map[string]any
+		threshold int
+		err       string
+	}{
+		{
+			name: "None",
+		},
+		{
+			name:   "ApproversMissing",
+			policy: map[string]any{},
+			err:    "invalid approval policy: no approvers",
+		},
+		{
+			name: "ApproverInvalid",
+			policy: map[string]any{
+				"approvers": []string{"0x01"},
+			},
+			err: "invalid approval policy: invalid approver 0x01: public key must be 48 bytes",
+		},
+		{
+			name: "ThresholdTooHigh",
+			policy: map[string]any{
+				"approvers": []string{approver},
+				"threshold": 2,
+			},
+			err: "invalid approval policy: threshold must be between 1 and 1",
+		},
+		{
+			name: "Good",
+			policy: map[string]any{
+				"approvers": []string{approver},
+			},
+			threshold: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			t.Cleanup(viper.Reset)
+			if test.policy != nil {
+				viper.Set("approval", test.policy)
+			}
+			policy, err := util.ObtainApprovalPolicy()
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			if test.policy == nil {
+				require.Nil(t, policy)
+				return
+			}
+			require.Equal(t, test.threshold, policy.Threshold)
+			require.True(t, policy.IsApprover(approvalKey(t, 1).PublicKey()))
+			require.False(t, policy.IsApprover(approvalKey(t, 2).PublicKey()))
+		})
+	}
+}
+
+func TestApprovalGate(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set("approval", map[string]any{
+		"approvers": []string{
+			fmt.Sprintf("%#x", approvalKey(t, 1).PublicKey().Marshal()),
+			fmt.Sprintf("%#x", approvalKey(t, 2).PublicKey().Marshal()),
+		},
+		"threshold": 2,
+	})
+
+	filename := filepath.Join(t.TempDir(), "approval-request.json")
+	domain := phase0.Domain{0x04}
+	operation := &phase0.VoluntaryExit{Epoch: 100, ValidatorIndex: 12}
+	root, err := operation.HashTreeRoot()
+	require.NoError(t, err)
+
+	// No request, so the operation is pending.
+	gate, err := util.NewApprovalGate(util.ApprovalOperationValidatorExit, filename)
+	require.NoError(t, err)
+	approved, err := gate.Approved(domain, root, operation)
+	require.NoError(t, err)
+	require.False(t, approved)
+	require.True(t, gate.Pending())
+	_, err = gate.WritePending()
+	require.NoError(t, err)
+
+	// Request for a different operation.
+	_, err = util.NewApprovalGate(util.ApprovalOperationCredentialsChange, filename)
+	require.EqualError(t, err, fmt.Sprintf("approval request in %s is for validator exit rather than validator credentials set", filename))
+
+	// Insufficient approvals.
+	request, err := util.ReadApprovalRequest(filename)
+	require.NoError(t, err)
+	approve(t, request, approvalKey(t, 1))
+	approve(t, request, approvalKey(t, 3))
+	require.NoError(t, request.Write(filename))
+	gate, err = util.NewApprovalGate(util.ApprovalOperationValidatorExit, filename)
+	require.NoError(t, err)
+	approved, err = gate.Approved(domain, root, operation)
+	require.NoError(t, err)
+	require.False(t, approved)
+	status, err := gate.WritePending()
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("Approval request %s has 1 of 2 required approvals", filename), status)
+
+	// Sufficient approvals.
+	approve(t, request, approvalKey(t, 2))
+	require.NoError(t, request.Write(filename))
+	gate, err = util.NewApprovalGate(util.ApprovalOperationValidatorExit, filename)
+	require.NoError(t, err)
+	approved, err = gate.Approved(domain, root, operation)
+	require.NoError(t, err)
+	require.True(t, approved)
+	require.False(t, gate.Pending())
+
+	// Different operation is not approved.
+	otherOperation := &phase0.VoluntaryExit{Epoch: 101, ValidatorIndex: 12}
+	otherRoot, err := otherOperation.HashTreeRoot()
+	require.NoError(t, err)
+	approved, err = gate.Approved(domain, otherRoot, otherOperation)
+	require.NoError(t, err)
+	require.False(t, approved)
+	_, err = gate.WritePending()
+	require.EqualError(t, err, fmt.Sprintf("approval request in %s does not match the operations; remove it to create a new request", filename))
+}
+
+func TestApprovalRequestTampered(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	request := &util.ApprovalRequest{
+		Operation:  util.ApprovalOperationValidatorExit,
+		Domain:     fmt.Sprintf("%#x", phase0.Domain{0x04}),
+		Roots:      []string{fmt.Sprintf("%#x", phase0.Root{0x01})},
+		Operations: []json.RawMessage{json.RawMessage(`{"epoch":"100","validator_index":"12"}`)},
+	}
+	filename := filepath.Join(t.TempDir(), "approval-request.json")
+	require.NoError(t, request.Write(filename))
+
+	_, err := util.ReadApprovalRequest(filename)
+	require.EqualError(t, err, "operation 1 in approval request does not match its root")
+}
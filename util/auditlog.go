@@ -0,0 +1,280 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// AuditLogEntry is an entry in the signing audit log.  Each entry contains
+// the hash of the entry before it, so that any modification of the log
+// breaks the chain of hashes.
+//
+// Without a key the hashes are plain SHA-256 hashes, which detect accidental
+// corruption and casual editing but can be recomputed by anyone able to write
+// the log.  If a key is supplied with "audit-log-key" the hashes are HMACs,
+// which cannot be recomputed without the key.  Neither detects the removal
+// of entries from the end of the log; that requires the hash of the last
+// entry to be recorded elsewhere, for example with "audit verify --head".
+type AuditLogEntry struct {
+	Index    uint64 `json:"index"`
+	Time     string `json:"time"`
+	Account  string `json:"account"`
+	PubKey   string `json:"pubkey"`
+	Domain   string `json:"domain"`
+	Root     string `json:"root"`
+	Result   string `json:"result"`
+	Error    string `json:"error,omitempty"`
+	Keyed    bool   `json:"keyed,omitempty"`
+	Previous string `json:"previous"`
+	Hash     string `json:"hash,omitempty"`
+}
+
+// auditLogGenesisHash is the previous hash of the first entry in an audit log.
+var auditLogGenesisHash = fmt.Sprintf("%#x", spec.Root{})
+
+// auditLogHead is the last entry of an audit log, along with the size of the
+// log when it was written.
+type auditLogHead struct {
+	entry *AuditLogEntry
+	size  int64
+}
+
+var (
+	auditLogMu sync.Mutex
+	// auditLogHeads holds the last entry of each audit log written by this process.
+	auditLogHeads = make(map[string]*auditLogHead)
+)
+
+// AuditLogKey returns the key supplied with the "audit-log-key" option, either
+// as a hex string or as the path to a file containing one.  It returns nil if
+// no key is supplied.
+func AuditLogKey() ([]byte, error) {
+	input := viper.GetString("audit-log-key")
+	if input == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(input, "0x") {
+		// Assume it's a path to the key.
+		data, err := os.ReadFile(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read audit log key file")
+		}
+		input = strings.TrimSpace(string(data))
+	}
+	key, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid audit log key")
+	}
+	if len(key) < 32 {
+		return nil, errors.New("audit log key must be at least 32 bytes")
+	}
+
+	return key, nil
+}
+
+// AuditSigning records a signing operation in the audit log given by the
+// "audit-log" option, if present.
+func AuditSigning(account e2wtypes.Account, root spec.Root, domain spec.Domain, signingErr error) error {
+	path := viper.GetString("audit-log")
+	if path == "" {
+		return nil
+	}
+	key, err := AuditLogKey()
+	if err != nil {
+		return err
+	}
+
+	entry := &AuditLogEntry{
+		Time:    time.Now().UTC().Format(time.RFC3339Nano),
+		Account: qualifiedAccountName(account),
+		Domain:  fmt.Sprintf("%#x", domain),
+		Root:    fmt.Sprintf("%#x", root),
+		Result:  "signed",
+		Keyed:   key != nil,
+	}
+	if pubKey, err := BestPublicKey(account); err == nil {
+		entry.PubKey = fmt.Sprintf("%#x", pubKey.Marshal())
+	}
+	if signingErr != nil {
+		entry.Result = "failed"
+		entry.Error = signingErr.Error()
+	}
+
+	auditLogMu.Lock()
+	defer auditLogMu.Unlock()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "failed to open audit log")
+	}
+	defer f.Close()
+	// Other processes may be writing to the same log, so hold a lock on it
+	// between reading the head and appending the entry.
+	if err := lockFile(f); err != nil {
+		return errors.Wrap(err, "failed to lock audit log")
+	}
+	defer func() {
+		_ = unlockFile(f)
+	}()
+
+	head, err := currentAuditLogHead(f, path, key)
+	if err != nil {
+		return err
+	}
+	if head == nil {
+		entry.Previous = auditLogGenesisHash
+	} else {
+		entry.Index = head.Index + 1
+		entry.Previous = head.Hash
+	}
+	hash, err := entry.hash(key)
+	if err != nil {
+		return err
+	}
+	entry.Hash = hash
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate audit log entry")
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return errors.Wrap(err, "failed to write audit log")
+	}
+	if err := f.Sync(); err != nil {
+		return errors.Wrap(err, "failed to sync audit log")
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain audit log information")
+	}
+	auditLogHeads[path] = &auditLogHead{
+		entry: entry,
+		size:  info.Size(),
+	}
+
+	return nil
+}
+
+// VerifyAuditLog verifies the chain of hashes in an audit log, returning
+// the last entry of the log.  The last entry is nil if the log is empty.
+// If the log was written with a key then the same key must be supplied.
+func VerifyAuditLog(path string, key []byte) (*AuditLogEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open audit log")
+	}
+	defer f.Close()
+
+	return verifyAuditLog(f, key)
+}
+
+func verifyAuditLog(r io.Reader, key []byte) (*AuditLogEntry, error) {
+	var head *AuditLogEntry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			return nil, fmt.Errorf("line %d: empty entry", line)
+		}
+		entry := &AuditLogEntry{}
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			return nil, errors.Wrapf(err, "line %d: invalid entry", line)
+		}
+		switch {
+		case entry.Keyed && key == nil:
+			return nil, fmt.Errorf("line %d: entry is keyed but no audit log key was supplied", line)
+		case !entry.Keyed && key != nil:
+			return nil, fmt.Errorf("line %d: entry is not keyed", line)
+		}
+		hash, err := entry.hash(key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", line)
+		}
+		if hash != entry.Hash {
+			return nil, fmt.Errorf("line %d: entry has been modified", line)
+		}
+		switch {
+		case head == nil && (entry.Index != 0 || entry.Previous != auditLogGenesisHash):
+			return nil, fmt.Errorf("line %d: log does not start with the first entry", line)
+		case head != nil && entry.Index != head.Index+1:
+			return nil, fmt.Errorf("line %d: expected index %d but found %d", line, head.Index+1, entry.Index)
+		case head != nil && entry.Previous != head.Hash:
+			return nil, fmt.Errorf("line %d: entry does not follow the previous entry", line)
+		}
+		head = entry
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read audit log")
+	}
+
+	return head, nil
+}
+
+// currentAuditLogHead returns the last entry of a locked audit log, or nil if
+// the log is empty.  The log is only verified if it has changed since this
+// process last wrote to it.
+func currentAuditLogHead(f *os.File, path string, key []byte) (*AuditLogEntry, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain audit log information")
+	}
+	if head, exists := auditLogHeads[path]; exists && head.size == info.Size() {
+		return head.entry, nil
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.Wrap(err, "failed to read audit log")
+	}
+	head, err := verifyAuditLog(f, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "audit log failed verification")
+	}
+
+	return head, nil
+}
+
+// hash returns the hash of the entry, excluding its own hash.  If a key is
+// supplied the hash is an HMAC with that key.
+func (e *AuditLogEntry) hash(key []byte) (string, error) {
+	entry := *e
+	entry.Hash = ""
+	data, err := json.Marshal(&entry)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate audit log entry")
+	}
+
+	if key != nil {
+		mac := hmac.New(sha256.New, key)
+		mac.Write(data)
+		return fmt.Sprintf("%#x", mac.Sum(nil)), nil
+	}
+
+	return fmt.Sprintf("%#x", sha256.Sum256(data)), nil
+}
@@ -0,0 +1,171 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestAuditLog(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	account, err := util.NewScratchAccount(testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"), nil)
+	require.NoError(t, err)
+	require.NoError(t, account.Unlock(context.Background(), nil))
+
+	// No audit log configured.
+	require.NoError(t, util.AuditSigning(account, phase0.Root{}, phase0.Domain{}, nil))
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	viper.Set("audit-log", path)
+	require.NoError(t, util.AuditSigning(account, phase0.Root{0x01}, phase0.Domain{0x04}, nil))
+	require.NoError(t, util.AuditSigning(account, phase0.Root{0x02}, phase0.Domain{0x04}, errors.New("denied")))
+	_, err = util.SignRoot(account, phase0.Root{0x03}, phase0.Domain{0x04})
+	require.NoError(t, err)
+
+	head, err := util.VerifyAuditLog(path, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), head.Index)
+	require.Equal(t, fmt.Sprintf("%#x", phase0.Root{0x03}), head.Root)
+	require.Equal(t, "signed", head.Result)
+	require.Equal(t, "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c", head.PubKey)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 3)
+	require.Contains(t, lines[1], `"result":"failed","error":"denied"`)
+
+	tests := []struct {
+		name  string
+		lines []string
+		err   string
+	}{
+		{
+			name:  "Modified",
+			lines: []string{lines[0], strings.Replace(lines[1], `"failed"`, `"signed"`, 1), lines[2]},
+			err:   "line 2: entry has been modified",
+		},
+		{
+			name:  "Removed",
+			lines: []string{lines[0], lines[2]},
+			err:   "line 2: expected index 1 but found 2",
+		},
+		{
+			name:  "Reordered",
+			lines: []string{lines[1], lines[0], lines[2]},
+			err:   "line 1: log does not start with the first entry",
+		},
+		{
+			name:  "Invalid",
+			lines: []string{lines[0], "{"},
+			err:   "line 2: invalid entry: unexpected end of JSON input",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tamperedPath := filepath.Join(t.TempDir(), "audit.log")
+			require.NoError(t, os.WriteFile(tamperedPath, []byte(strings.Join(test.lines, "\n")+"\n"), 0o600))
+			_, err := util.VerifyAuditLog(tamperedPath, nil)
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
+
+func TestAuditLogSharedWriters(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	account, err := util.NewScratchAccount(testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"), nil)
+	require.NoError(t, err)
+
+	// Writing through a second path to the same file behaves as a second
+	// process writing to the log.
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+	link := filepath.Join(dir, "link.log")
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+	require.NoError(t, os.Symlink(path, link))
+
+	for i, writer := range []string{path, link, path, link} {
+		viper.Set("audit-log", writer)
+		require.NoError(t, util.AuditSigning(account, phase0.Root{byte(i)}, phase0.Domain{0x04}, nil))
+	}
+
+	head, err := util.VerifyAuditLog(path, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), head.Index)
+}
+
+func TestAuditLogKeyed(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	account, err := util.NewScratchAccount(testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"), nil)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "audit.key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("0x0101010101010101010101010101010101010101010101010101010101010101\n"), 0o600))
+
+	viper.Set("audit-log-key", "0x01")
+	_, err = util.AuditLogKey()
+	require.EqualError(t, err, "audit log key must be at least 32 bytes")
+
+	viper.Set("audit-log-key", keyFile)
+	key, err := util.AuditLogKey()
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, "audit.log")
+	viper.Set("audit-log", path)
+	require.NoError(t, util.AuditSigning(account, phase0.Root{0x01}, phase0.Domain{0x04}, nil))
+	require.NoError(t, util.AuditSigning(account, phase0.Root{0x02}, phase0.Domain{0x04}, nil))
+
+	head, err := util.VerifyAuditLog(path, key)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), head.Index)
+	require.True(t, head.Keyed)
+
+	_, err = util.VerifyAuditLog(path, nil)
+	require.EqualError(t, err, "line 1: entry is keyed but no audit log key was supplied")
+
+	_, err = util.VerifyAuditLog(path, testutil.HexToBytes("0x0202020202020202020202020202020202020202020202020202020202020202"))
+	require.EqualError(t, err, "line 1: entry has been modified")
+
+	// An unkeyed log cannot be verified with a key.
+	unkeyedPath := filepath.Join(dir, "unkeyed.log")
+	viper.Set("audit-log-key", "")
+	viper.Set("audit-log", unkeyedPath)
+	require.NoError(t, util.AuditSigning(account, phase0.Root{0x01}, phase0.Domain{0x04}, nil))
+	_, err = util.VerifyAuditLog(unkeyedPath, key)
+	require.EqualError(t, err, "line 1: entry is not keyed")
+}
@@ -0,0 +1,122 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// awsSession creates a session for AWS services.  Credentials are obtained
+// from the standard AWS credential chain, so this works with IAM roles.
+func awsSession() (*session.Session, error) {
+	config := aws.NewConfig()
+	if region := viper.GetString("aws.region"); region != "" {
+		config = config.WithRegion(region)
+	}
+	if endpoint := viper.GetString("aws.endpoint"); endpoint != "" {
+		config = config.WithEndpoint(endpoint)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *config,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create AWS session")
+	}
+
+	return sess, nil
+}
+
+// resolveAWSSecretsManagerSecret resolves a secret held in AWS Secrets
+// Manager.  The reference is the ID or ARN of the secret, optionally followed
+// by "#" and the field within a secret that holds JSON key/value pairs.
+func resolveAWSSecretsManagerSecret(ctx context.Context, reference string) (string, error) {
+	secretID, field, _ := strings.Cut(reference, "#")
+	if secretID == "" {
+		return "", errors.New("no AWS secret ID supplied")
+	}
+
+	sess, err := awsSession()
+	if err != nil {
+		return "", err
+	}
+	res, err := secretsmanager.New(sess).GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secretID),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to obtain AWS secret %s", secretID)
+	}
+
+	var secret string
+	switch {
+	case res.SecretString != nil:
+		secret = *res.SecretString
+	case res.SecretBinary != nil:
+		secret = string(res.SecretBinary)
+	default:
+		return "", fmt.Errorf("AWS secret %s has no value", secretID)
+	}
+	if field == "" {
+		return secret, nil
+	}
+
+	fields := make(map[string]any)
+	if err := json.Unmarshal([]byte(secret), &fields); err != nil {
+		return "", errors.Wrapf(err, "AWS secret %s does not contain fields", secretID)
+	}
+	value, exists := fields[field]
+	if !exists {
+		return "", fmt.Errorf("AWS secret %s has no field %s", secretID, field)
+	}
+	fieldValue, isString := value.(string)
+	if !isString {
+		return "", fmt.Errorf("AWS secret %s has non-string field %s", secretID, field)
+	}
+
+	return fieldValue, nil
+}
+
+// resolveAWSKMSSecret resolves a secret that has been encrypted with AWS KMS.
+// The reference is the base64-encoded ciphertext.
+func resolveAWSKMSSecret(ctx context.Context, reference string) (string, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(reference)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid AWS KMS ciphertext")
+	}
+
+	sess, err := awsSession()
+	if err != nil {
+		return "", err
+	}
+	res, err := kms.New(sess).DecryptWithContext(ctx, &kms.DecryptInput{
+		CiphertextBlob: ciphertext,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decrypt with AWS KMS")
+	}
+
+	return string(res.Plaintext), nil
+}
@@ -0,0 +1,136 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// newAWSServer creates a server that behaves as AWS Secrets Manager and KMS.
+func newAWSServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := make(map[string]string)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		switch r.Header.Get("X-Amz-Target") {
+		case "secretsmanager.GetSecretValue":
+			switch req["SecretId"] {
+			case "ethdo/wallet1":
+				_, _ = w.Write([]byte(`{"Name":"ethdo/wallet1","SecretString":"wallet1 secret"}`))
+			case "ethdo/fields":
+				_, _ = w.Write([]byte(`{"Name":"ethdo/fields","SecretString":"{\"passphrase\":\"fields secret\"}"}`))
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"__type":"ResourceNotFoundException","message":"Secrets Manager can't find the specified secret."}`))
+			}
+		case "TrentService.Decrypt":
+			ciphertext, err := base64.StdEncoding.DecodeString(req["CiphertextBlob"])
+			require.NoError(t, err)
+			if string(ciphertext) != "encrypted" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"__type":"InvalidCiphertextException"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"Plaintext":"` + base64.StdEncoding.EncodeToString([]byte("kms secret")) + `"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+}
+
+func TestResolveAWSSecret(t *testing.T) {
+	server := newAWSServer(t)
+	defer server.Close()
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "id")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_CONFIG_FILE", "/nonexistent")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/nonexistent")
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+
+	tests := []struct {
+		name   string
+		value  string
+		secret string
+		err    string
+	}{
+		{
+			name:   "SecretsManager",
+			value:  "aws-sm:ethdo/wallet1",
+			secret: "wallet1 secret",
+		},
+		{
+			name:  "SecretsManagerMissing",
+			value: "aws-sm:ethdo/missing",
+			err:   "failed to obtain AWS secret ethdo/missing: ResourceNotFoundException: Secrets Manager can't find the specified secret.",
+		},
+		{
+			name:   "SecretsManagerField",
+			value:  "aws-sm:ethdo/fields#passphrase",
+			secret: "fields secret",
+		},
+		{
+			name:  "SecretsManagerFieldMissing",
+			value: "aws-sm:ethdo/fields#mnemonic",
+			err:   "AWS secret ethdo/fields has no field mnemonic",
+		},
+		{
+			name:  "SecretsManagerNoFields",
+			value: "aws-sm:ethdo/wallet1#passphrase",
+			err:   "AWS secret ethdo/wallet1 does not contain fields: invalid character 'w' looking for beginning of value",
+		},
+		{
+			name:   "KMS",
+			value:  "aws-kms:" + base64.StdEncoding.EncodeToString([]byte("encrypted")),
+			secret: "kms secret",
+		},
+		{
+			name:  "KMSInvalid",
+			value: "aws-kms:not base64",
+			err:   "invalid AWS KMS ciphertext: illegal base64 data at input byte 3",
+		},
+		{
+			name:  "KMSBad",
+			value: "aws-kms:" + base64.StdEncoding.EncodeToString([]byte("bad")),
+			err:   "failed to decrypt with AWS KMS: InvalidCiphertextException: ",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			t.Cleanup(viper.Reset)
+			viper.Set("aws.region", "us-east-1")
+			viper.Set("aws.endpoint", server.URL)
+			secret, err := util.ResolveSecret(context.Background(), test.value)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.secret, secret)
+		})
+	}
+}
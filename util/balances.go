@@ -0,0 +1,106 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+const (
+	// BLSWithdrawalPrefix is the prefix for withdrawal credentials based on a BLS key.
+	BLSWithdrawalPrefix = 0x00
+	// ETH1AddressWithdrawalPrefix is the prefix for withdrawal credentials based on an execution address.
+	ETH1AddressWithdrawalPrefix = 0x01
+	// CompoundingWithdrawalPrefix is the prefix for withdrawal credentials based on an execution
+	// address, for which the validator's balance compounds.
+	CompoundingWithdrawalPrefix = 0x02
+)
+
+// BalanceParameters are the chain parameters that govern the effective
+// balances of validators.
+type BalanceParameters struct {
+	MaxEffectiveBalance phase0.Gwei
+	// Electra parameters.  These are zero if the chain does not support Electra.
+	MinActivationBalance       phase0.Gwei
+	MaxEffectiveBalanceElectra phase0.Gwei
+}
+
+// ObtainBalanceParameters obtains the balance parameters from the chain specification.
+func ObtainBalanceParameters(spec map[string]any) (*BalanceParameters, error) {
+	params := &BalanceParameters{}
+	value, isValue := spec["MAX_EFFECTIVE_BALANCE"].(uint64)
+	if !isValue {
+		return nil, fmt.Errorf("spec missing %s", "MAX_EFFECTIVE_BALANCE")
+	}
+	params.MaxEffectiveBalance = phase0.Gwei(value)
+	// The Electra parameters are optional.
+	if value, isValue := spec["MIN_ACTIVATION_BALANCE"].(uint64); isValue {
+		params.MinActivationBalance = phase0.Gwei(value)
+	}
+	if value, isValue := spec["MAX_EFFECTIVE_BALANCE_ELECTRA"].(uint64); isValue {
+		params.MaxEffectiveBalanceElectra = phase0.Gwei(value)
+	}
+
+	return params, nil
+}
+
+// SupportsElectra returns true if the parameters include the Electra balances.
+func (p *BalanceParameters) SupportsElectra() bool {
+	return p.MinActivationBalance > 0 && p.MaxEffectiveBalanceElectra > 0
+}
+
+// MaxEffectiveBalanceFor returns the maximum effective balance of a validator
+// with the given withdrawal credentials.  Prior to Electra all validators have
+// the same maximum; afterwards it depends on whether the credentials are compounding.
+func (p *BalanceParameters) MaxEffectiveBalanceFor(withdrawalCredentials []byte, postElectra bool) phase0.Gwei {
+	if !postElectra || !p.SupportsElectra() {
+		return p.MaxEffectiveBalance
+	}
+	if HasCompoundingWithdrawalCredentials(withdrawalCredentials) {
+		return p.MaxEffectiveBalanceElectra
+	}
+
+	return p.MinActivationBalance
+}
+
+// HasExecutionWithdrawalCredentials returns true if the withdrawal credentials
+// are for an execution address, whether or not they are compounding.
+func HasExecutionWithdrawalCredentials(withdrawalCredentials []byte) bool {
+	return len(withdrawalCredentials) > 0 &&
+		(withdrawalCredentials[0] == ETH1AddressWithdrawalPrefix || withdrawalCredentials[0] == CompoundingWithdrawalPrefix)
+}
+
+// HasCompoundingWithdrawalCredentials returns true if the withdrawal credentials are compounding.
+func HasCompoundingWithdrawalCredentials(withdrawalCredentials []byte) bool {
+	return len(withdrawalCredentials) > 0 && withdrawalCredentials[0] == CompoundingWithdrawalPrefix
+}
+
+// WithdrawalCredentialsType returns a description of the type of the withdrawal credentials.
+func WithdrawalCredentialsType(withdrawalCredentials []byte) string {
+	if len(withdrawalCredentials) == 0 {
+		return "unknown"
+	}
+	switch withdrawalCredentials[0] {
+	case BLSWithdrawalPrefix:
+		return "BLS (0x00)"
+	case ETH1AddressWithdrawalPrefix:
+		return "execution address (0x01)"
+	case CompoundingWithdrawalPrefix:
+		return "compounding execution address (0x02)"
+	default:
+		return fmt.Sprintf("unknown (%#02x)", withdrawalCredentials[0])
+	}
+}
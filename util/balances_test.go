@@ -0,0 +1,62 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestBalanceParameters(t *testing.T) {
+	bls := append([]byte{0x00}, make([]byte, 31)...)
+	eth1 := append([]byte{0x01}, make([]byte, 31)...)
+	compounding := append([]byte{0x02}, make([]byte, 31)...)
+
+	spec := map[string]any{}
+	_, err := util.ObtainBalanceParameters(spec)
+	require.EqualError(t, err, "spec missing MAX_EFFECTIVE_BALANCE")
+
+	spec["MAX_EFFECTIVE_BALANCE"] = uint64(32000000000)
+	params, err := util.ObtainBalanceParameters(spec)
+	require.NoError(t, err)
+	require.False(t, params.SupportsElectra())
+	require.EqualValues(t, 32000000000, params.MaxEffectiveBalanceFor(compounding, true))
+
+	spec["MIN_ACTIVATION_BALANCE"] = uint64(32000000000)
+	spec["MAX_EFFECTIVE_BALANCE_ELECTRA"] = uint64(2048000000000)
+	params, err = util.ObtainBalanceParameters(spec)
+	require.NoError(t, err)
+	require.True(t, params.SupportsElectra())
+	require.EqualValues(t, 32000000000, params.MaxEffectiveBalanceFor(compounding, false))
+	require.EqualValues(t, 2048000000000, params.MaxEffectiveBalanceFor(compounding, true))
+	require.EqualValues(t, 32000000000, params.MaxEffectiveBalanceFor(eth1, true))
+	require.EqualValues(t, 32000000000, params.MaxEffectiveBalanceFor(bls, true))
+}
+
+func TestWithdrawalCredentialsType(t *testing.T) {
+	require.Equal(t, "unknown", util.WithdrawalCredentialsType(nil))
+	require.Equal(t, "BLS (0x00)", util.WithdrawalCredentialsType([]byte{0x00, 0x01}))
+	require.Equal(t, "execution address (0x01)", util.WithdrawalCredentialsType([]byte{0x01, 0x00}))
+	require.Equal(t, "compounding execution address (0x02)", util.WithdrawalCredentialsType([]byte{0x02, 0x00}))
+	require.Equal(t, "unknown (0x03)", util.WithdrawalCredentialsType([]byte{0x03, 0x00}))
+	require.True(t, util.HasCompoundingWithdrawalCredentials([]byte{0x02}))
+	require.False(t, util.HasCompoundingWithdrawalCredentials([]byte{0x01}))
+	require.False(t, util.HasCompoundingWithdrawalCredentials(nil))
+	require.True(t, util.HasExecutionWithdrawalCredentials([]byte{0x01}))
+	require.True(t, util.HasExecutionWithdrawalCredentials([]byte{0x02}))
+	require.False(t, util.HasExecutionWithdrawalCredentials([]byte{0x00}))
+	require.False(t, util.HasExecutionWithdrawalCredentials(nil))
+}
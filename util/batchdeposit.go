@@ -0,0 +1,192 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	ethutil "github.com/wealdtech/go-eth2-util"
+)
+
+// BatchDepositFormat is the calling convention of a batch deposit contract.
+type BatchDepositFormat int
+
+const (
+	// BatchDepositFormatArrays is deposit(bytes[],bytes[],bytes[],bytes32[]),
+	// with one entry per deposit in each array.
+	BatchDepositFormatArrays BatchDepositFormat = iota
+	// BatchDepositFormatPacked is batchDeposit(bytes,bytes,bytes,bytes32[]),
+	// with the public keys, withdrawal credentials and signatures concatenated.
+	BatchDepositFormatPacked
+)
+
+var batchDepositFormatStrings = map[BatchDepositFormat]string{
+	BatchDepositFormatArrays: "arrays",
+	BatchDepositFormatPacked: "packed",
+}
+
+var batchDepositFormatSignatures = map[BatchDepositFormat]string{
+	BatchDepositFormatArrays: "deposit(bytes[],bytes[],bytes[],bytes32[])",
+	BatchDepositFormatPacked: "batchDeposit(bytes,bytes,bytes,bytes32[])",
+}
+
+// String returns the name of the format.
+func (f BatchDepositFormat) String() string {
+	return batchDepositFormatStrings[f]
+}
+
+// Signature returns the signature of the batch deposit function.
+func (f BatchDepositFormat) Signature() string {
+	return batchDepositFormatSignatures[f]
+}
+
+// ParseBatchDepositFormat parses the name of a batch deposit format.
+func ParseBatchDepositFormat(input string) (BatchDepositFormat, error) {
+	for format, name := range batchDepositFormatStrings {
+		if strings.EqualFold(input, name) {
+			return format, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown batch deposit format %q", input)
+}
+
+// BatchDepositAmount is the amount of each deposit made through a batch
+// deposit contract, in Gwei.  Neither calling convention carries a per-deposit
+// amount, so contracts split the value of the transaction equally between
+// the deposits.
+const BatchDepositAmount = phase0.Gwei(32000000000)
+
+// BatchDepositTransactionData returns the data for a transaction to a batch
+// deposit contract that makes all of the deposits, along with the value that
+// the transaction must carry.  All deposits must be for 32 Ether.
+func BatchDepositTransactionData(format BatchDepositFormat, deposits []*DepositInfo) ([]byte, *big.Int, error) {
+	signature := format.Signature()
+	if signature == "" {
+		return nil, nil, errors.New("unknown batch deposit format")
+	}
+	if len(deposits) == 0 {
+		return nil, nil, errors.New("no deposits supplied")
+	}
+
+	pubKeys := make([][]byte, len(deposits))
+	withdrawalCredentials := make([][]byte, len(deposits))
+	signatures := make([][]byte, len(deposits))
+	roots := make([][]byte, len(deposits))
+	// Deposit amounts are in Gwei, transaction values in wei.
+	value := new(big.Int)
+	for i, deposit := range deposits {
+		if len(deposit.PublicKey) != 48 {
+			return nil, nil, fmt.Errorf("deposit %d has invalid public key length", i)
+		}
+		if len(deposit.WithdrawalCredentials) != 32 {
+			return nil, nil, fmt.Errorf("deposit %d has invalid withdrawal credentials length", i)
+		}
+		if len(deposit.Signature) != 96 {
+			return nil, nil, fmt.Errorf("deposit %d has invalid signature length", i)
+		}
+		if len(deposit.DepositDataRoot) != 32 {
+			return nil, nil, fmt.Errorf("deposit %d has invalid deposit data root length", i)
+		}
+		if phase0.Gwei(deposit.Amount) != BatchDepositAmount {
+			return nil, nil, fmt.Errorf("deposit %d is for %d Gwei but batch deposits must be for %d Gwei", i, deposit.Amount, BatchDepositAmount)
+		}
+		for j := 0; j < i; j++ {
+			if bytes.Equal(deposit.PublicKey, pubKeys[j]) {
+				return nil, nil, fmt.Errorf("deposits %d and %d are for the same public key", j, i)
+			}
+		}
+		pubKeys[i] = deposit.PublicKey
+		withdrawalCredentials[i] = deposit.WithdrawalCredentials
+		signatures[i] = deposit.Signature
+		roots[i] = deposit.DepositDataRoot
+		value.Add(value, new(big.Int).Mul(new(big.Int).SetUint64(deposit.Amount), big.NewInt(1e9)))
+	}
+
+	var params [][]byte
+	switch format {
+	case BatchDepositFormatArrays:
+		params = [][]byte{
+			abiBytesArray(pubKeys),
+			abiBytesArray(withdrawalCredentials),
+			abiBytesArray(signatures),
+			abiBytes32Array(roots),
+		}
+	case BatchDepositFormatPacked:
+		params = [][]byte{
+			abiBytes(bytes.Join(pubKeys, nil)),
+			abiBytes(bytes.Join(withdrawalCredentials, nil)),
+			abiBytes(bytes.Join(signatures, nil)),
+			abiBytes32Array(roots),
+		}
+	}
+
+	data := make([]byte, 0)
+	data = append(data, ethutil.Keccak256([]byte(signature))[:4]...)
+	data = append(data, abiDynamic(params)...)
+
+	return data, value, nil
+}
+
+// abiDynamic returns the ABI encoding of a sequence of dynamic values, being
+// their offsets followed by their contents.
+func abiDynamic(values [][]byte) []byte {
+	offset := uint64(32 * len(values))
+	res := make([]byte, 0)
+	for _, value := range values {
+		res = append(res, abiWord(offset)...)
+		offset += uint64(len(value))
+	}
+	for _, value := range values {
+		res = append(res, value...)
+	}
+
+	return res
+}
+
+// abiBytes returns the ABI encoding of a byte array, padded to a multiple of 32 bytes.
+func abiBytes(value []byte) []byte {
+	res := abiWord(uint64(len(value)))
+	res = append(res, value...)
+	if len(value)%32 != 0 {
+		res = append(res, make([]byte, 32-len(value)%32)...)
+	}
+
+	return res
+}
+
+// abiBytesArray returns the ABI encoding of an array of byte arrays.
+func abiBytesArray(values [][]byte) []byte {
+	encoded := make([][]byte, len(values))
+	for i, value := range values {
+		encoded[i] = abiBytes(value)
+	}
+
+	return append(abiWord(uint64(len(values))), abiDynamic(encoded)...)
+}
+
+// abiBytes32Array returns the ABI encoding of an array of 32-byte values.
+func abiBytes32Array(values [][]byte) []byte {
+	res := abiWord(uint64(len(values)))
+	for _, value := range values {
+		res = append(res, value...)
+	}
+
+	return res
+}
@@ -0,0 +1,147 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestBatchDepositTransactionData(t *testing.T) {
+	deposit := func(pubKey string) *util.DepositInfo {
+		return &util.DepositInfo{
+			PublicKey:             testutil.HexToBytes(pubKey),
+			WithdrawalCredentials: testutil.HexToBytes("0x00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b"),
+			Signature:             testutil.HexToBytes("0xb7a757a4c506ac6ac5f2d23e065de7d00dc9f5a6a3f9610a8b60b65f166379139ae382c91ecbbf5c9fabc34b1cd2cf8f0211488d50d8754716d8e72e17c1a00b5d9b37cc73767946790ebe66cf9669abfc5c25c67e1e2d1c2e11429d149c25a2"),
+			DepositDataRoot:       testutil.HexToBytes("0x9e51b386f4271c18149dd0f73297a26a4a8c15c3622c44af79c92446f44a3554"),
+			Amount:                32000000000,
+		}
+	}
+	pubKey1 := "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+	sixtyFourEther, _ := new(big.Int).SetString("64000000000000000000", 10)
+	pubKey2 := "0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b"
+
+	tests := []struct {
+		name     string
+		format   util.BatchDepositFormat
+		deposits []*util.DepositInfo
+		len      int
+		value    *big.Int
+		err      string
+	}{
+		{
+			name:   "Empty",
+			format: util.BatchDepositFormatArrays,
+			err:    "no deposits supplied",
+		},
+		{
+			name:     "UnknownFormat",
+			format:   util.BatchDepositFormat(-1),
+			deposits: []*util.DepositInfo{deposit(pubKey1)},
+			err:      "unknown batch deposit format",
+		},
+		{
+			name:     "Duplicate",
+			format:   util.BatchDepositFormatArrays,
+			deposits: []*util.DepositInfo{deposit(pubKey1), deposit(pubKey2), deposit(pubKey1)},
+			err:      "deposits 0 and 2 are for the same public key",
+		},
+		{
+			name:   "BadSignature",
+			format: util.BatchDepositFormatArrays,
+			deposits: []*util.DepositInfo{deposit(pubKey1), func() *util.DepositInfo {
+				d := deposit(pubKey2)
+				d.Signature = d.Signature[1:]
+				return d
+			}()},
+			err: "deposit 1 has invalid signature length",
+		},
+		{
+			name:   "NoAmount",
+			format: util.BatchDepositFormatPacked,
+			deposits: []*util.DepositInfo{func() *util.DepositInfo {
+				d := deposit(pubKey1)
+				d.Amount = 0
+				return d
+			}()},
+			err: "deposit 0 is for 0 Gwei but batch deposits must be for 32000000000 Gwei",
+		},
+		{
+			name:   "PartialAmount",
+			format: util.BatchDepositFormatArrays,
+			deposits: []*util.DepositInfo{deposit(pubKey1), func() *util.DepositInfo {
+				d := deposit(pubKey2)
+				d.Amount = 1000000000
+				return d
+			}()},
+			err: "deposit 1 is for 1000000000 Gwei but batch deposits must be for 32000000000 Gwei",
+		},
+		{
+			name:   "LargeAmount",
+			format: util.BatchDepositFormatPacked,
+			deposits: []*util.DepositInfo{func() *util.DepositInfo {
+				d := deposit(pubKey1)
+				d.Amount = 2048000000000
+				return d
+			}()},
+			err: "deposit 0 is for 2048000000000 Gwei but batch deposits must be for 32000000000 Gwei",
+		},
+		{
+			name:     "Arrays",
+			format:   util.BatchDepositFormatArrays,
+			deposits: []*util.DepositInfo{deposit(pubKey1), deposit(pubKey2)},
+			len:      1092,
+			value:    sixtyFourEther,
+		},
+		{
+			name:     "Packed",
+			format:   util.BatchDepositFormatPacked,
+			deposits: []*util.DepositInfo{deposit(pubKey1), deposit(pubKey2)},
+			len:      676,
+			value:    sixtyFourEther,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, value, err := util.BatchDepositTransactionData(test.format, test.deposits)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, data, test.len)
+				require.Equal(t, test.value, value)
+				// Offset of the first parameter follows the four offsets in the head.
+				require.Equal(t, testutil.HexToBytes("0x0000000000000000000000000000000000000000000000000000000000000080"), data[4:36])
+				// Deposit data roots are the final words of the data.
+				require.Equal(t, test.deposits[1].DepositDataRoot, data[len(data)-32:])
+				require.Equal(t, test.deposits[0].DepositDataRoot, data[len(data)-64:len(data)-32])
+			}
+		})
+	}
+}
+
+func TestParseBatchDepositFormat(t *testing.T) {
+	format, err := util.ParseBatchDepositFormat("Packed")
+	require.NoError(t, err)
+	require.Equal(t, util.BatchDepositFormatPacked, format)
+	require.Equal(t, "batchDeposit(bytes,bytes,bytes,bytes32[])", format.Signature())
+
+	_, err = util.ParseBatchDepositFormat("unknown")
+	require.EqualError(t, err, `unknown batch deposit format "unknown"`)
+}
@@ -0,0 +1,76 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+)
+
+// BeaconNodeGet issues a GET request for the given path against the
+// beacon node API of the client, returning the body of the response.
+// It is used for endpoints that are not supported by the client library,
+// or where the response should be streamed rather than decoded in full.
+// The caller is responsible for closing the returned body.
+func BeaconNodeGet(ctx context.Context, client eth2client.Service, path string, accept string) (io.ReadCloser, error) {
+	return beaconNodeRequest(ctx, client, http.MethodGet, path, nil, accept)
+}
+
+// BeaconNodePost issues a POST request with a JSON body for the given path
+// against the beacon node API of the client, returning the body of the
+// response.  The caller is responsible for closing the returned body.
+func BeaconNodePost(ctx context.Context, client eth2client.Service, path string, body []byte) (io.ReadCloser, error) {
+	return beaconNodeRequest(ctx, client, http.MethodPost, path, body, "application/json")
+}
+
+func beaconNodeRequest(ctx context.Context, client eth2client.Service, method string, path string, body []byte, accept string) (io.ReadCloser, error) {
+	address := strings.TrimSuffix(client.Address(), "/")
+	if !strings.HasPrefix(address, "http") {
+		return nil, errors.New("connection does not provide a beacon node API address")
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/%s", address, strings.TrimPrefix(path, "/")), reqBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	resp, err := HTTPClient().Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to call beacon node")
+	}
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("beacon node returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	return resp.Body, nil
+}
@@ -1,4 +1,4 @@
-// Copyright © 2020, 2023 Weald Technology Trading
+// Copyright © 2020 - 2024 Weald Technology Trading
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -23,6 +23,7 @@ import (
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/http"
+	"github.com/attestantio/go-eth2-client/multi"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
@@ -45,7 +46,18 @@ type ConnectOpts struct {
 }
 
 // ConnectToBeaconNode connects to a beacon node at the given address.
+// If a network configuration is supplied, it overrides the specification
+// provided by the beacon node.
 func ConnectToBeaconNode(ctx context.Context, opts *ConnectOpts) (eth2client.Service, error) {
+	client, err := connectToAnyBeaconNode(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return withNetworkConfig(client)
+}
+
+func connectToAnyBeaconNode(ctx context.Context, opts *ConnectOpts) (eth2client.Service, error) {
 	if opts == nil {
 		return nil, errors.New("no options specified")
 	}
@@ -54,6 +66,15 @@ func ConnectToBeaconNode(ctx context.Context, opts *ConnectOpts) (eth2client.Ser
 		return nil, errors.New("no timeout specified")
 	}
 
+	if Offline() {
+		return nil, errors.Wrap(ErrOffline, "cannot connect to beacon node")
+	}
+
+	if strings.Contains(opts.Address, ",") {
+		// We have multiple explicit addresses; use them all.
+		return connectToBeaconNodes(ctx, strings.Split(opts.Address, ","), opts.Timeout, opts.AllowInsecure)
+	}
+
 	if opts.Address != "" {
 		// We have an explicit address; use it.
 		return connectToBeaconNode(ctx, opts.Address, opts.Timeout, opts.AllowInsecure)
@@ -80,6 +101,69 @@ func ConnectToBeaconNode(ctx context.Context, opts *ConnectOpts) (eth2client.Ser
 }
 
 func connectToBeaconNode(ctx context.Context, address string, timeout time.Duration, allowInsecure bool) (eth2client.Service, error) {
+	address, err := checkedAddress(address, allowInsecure)
+	if err != nil {
+		return nil, err
+	}
+	eth2Client, err := http.New(ctx,
+		http.WithLogLevel(zerolog.Disabled),
+		http.WithAddress(address),
+		http.WithTimeout(timeout),
+		http.WithHTTPClient(HTTPClient()),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	return eth2Client, nil
+}
+
+// connectToBeaconNodes connects to multiple beacon nodes, returning a client
+// that prefers the first healthy node and fails over to the others as required.
+func connectToBeaconNodes(ctx context.Context, addresses []string, timeout time.Duration, allowInsecure bool) (eth2client.Service, error) {
+	clients := make([]eth2client.Service, 0, len(addresses))
+	for _, address := range addresses {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		}
+		address, err := checkedAddress(address, allowInsecure)
+		if err != nil {
+			return nil, err
+		}
+		// Allow delayed start so that nodes that are currently unavailable
+		// can be brought in to use if they become available.
+		client, err := http.New(ctx,
+			http.WithLogLevel(zerolog.Disabled),
+			http.WithAddress(address),
+			http.WithTimeout(timeout),
+			http.WithAllowDelayedStart(true),
+			http.WithHTTPClient(HTTPClient()),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to create client for beacon node %s", address))
+		}
+		clients = append(clients, client)
+	}
+	if len(clients) == 0 {
+		return nil, errors.New("no beacon node addresses supplied")
+	}
+
+	multiClient, err := multi.New(ctx,
+		multi.WithLogLevel(zerolog.Disabled),
+		multi.WithTimeout(timeout),
+		multi.WithClients(clients),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to beacon nodes")
+	}
+
+	return newFailoverService(multiClient, clients)
+}
+
+// checkedAddress normalises a beacon node address, and warns if the connection
+// is insecure.
+func checkedAddress(address string, allowInsecure bool) (string, error) {
 	if !strings.HasPrefix(address, "http") {
 		address = fmt.Sprintf("http://%s", address)
 	}
@@ -87,7 +171,7 @@ func connectToBeaconNode(ctx context.Context, address string, timeout time.Durat
 		// Ensure the connection is either secure or local.
 		connectionURL, err := url.Parse(address)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to parse connection")
+			return "", errors.Wrap(err, "failed to parse connection")
 		}
 		if connectionURL.Scheme == "http" &&
 			connectionURL.Host != "localhost" &&
@@ -97,14 +181,6 @@ func connectToBeaconNode(ctx context.Context, address string, timeout time.Durat
 			fmt.Println("Connections to remote beacon nodes should be secure.  This warning can be silenced with --allow-insecure-connections")
 		}
 	}
-	eth2Client, err := http.New(ctx,
-		http.WithLogLevel(zerolog.Disabled),
-		http.WithAddress(address),
-		http.WithTimeout(timeout),
-	)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to connect to beacon node")
-	}
 
-	return eth2Client, nil
+	return address, nil
 }
@@ -0,0 +1,75 @@
+// Copyright © 2024 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckedAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		address  string
+		expected string
+	}{
+		{
+			name:     "NoScheme",
+			address:  "localhost:5052",
+			expected: "http://localhost:5052",
+		},
+		{
+			name:     "HTTPS",
+			address:  "https://beacon.example.com/",
+			expected: "https://beacon.example.com/",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			address, err := checkedAddress(test.address, true)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, address)
+		})
+	}
+}
+
+func TestConnectToBeaconNodes(t *testing.T) {
+	tests := []struct {
+		name      string
+		addresses []string
+		err       string
+	}{
+		{
+			name:      "Empty",
+			addresses: []string{"", " "},
+			err:       "no beacon node addresses supplied",
+		},
+		{
+			name:      "Unavailable",
+			addresses: []string{"localhost:1", "localhost:2"},
+			err:       "failed to connect to beacon nodes: client is not active",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := connectToBeaconNodes(context.Background(), test.addresses, time.Second, true)
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
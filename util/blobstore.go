@@ -0,0 +1,337 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/go-ecodec"
+)
+
+// errBlobNotFound is returned by blob backends when a blob does not exist.
+var errBlobNotFound = errors.New("not found")
+
+// blobBackend holds blobs against keys, allowing wallet stores to be built
+// on top of object storage services.
+type blobBackend interface {
+	// Get obtains the blob with the given key.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Put stores the blob with the given key, overwriting any existing blob.
+	Put(ctx context.Context, key string, data []byte) error
+	// List lists the keys of the blobs with the given prefix.
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// blobStore is a wallet store held in a blob backend.  It uses the same
+// layout and encryption as the S3 store, so wallets can be copied between
+// the stores.
+type blobStore struct {
+	name       string
+	location   string
+	backend    blobBackend
+	path       string
+	passphrase []byte
+	timeout    time.Duration
+}
+
+// newBlobStore creates a new wallet store held in a blob backend.
+func newBlobStore(name string,
+	location string,
+	backend blobBackend,
+	path string,
+	passphrase []byte,
+	timeout time.Duration,
+) *blobStore {
+	if timeout == 0 {
+		timeout = time.Minute
+	}
+
+	return &blobStore{
+		name:       name,
+		location:   location,
+		backend:    backend,
+		path:       strings.Trim(path, "/"),
+		passphrase: passphrase,
+		timeout:    timeout,
+	}
+}
+
+// Name returns the name of the store.
+func (s *blobStore) Name() string {
+	return s.name
+}
+
+// Location returns the location of the store.
+func (s *blobStore) Location() string {
+	return s.location
+}
+
+// StoreWallet stores wallet-level data.
+func (s *blobStore) StoreWallet(walletID uuid.UUID, _ string, data []byte) error {
+	if err := s.put(s.walletHeaderKey(walletID), data); err != nil {
+		return errors.Wrap(err, "failed to store wallet")
+	}
+
+	return nil
+}
+
+// RetrieveWallet retrieves wallet-level data for the wallet with the given name.
+func (s *blobStore) RetrieveWallet(walletName string) ([]byte, error) {
+	for data := range s.RetrieveWallets() {
+		info := &struct {
+			Name string `json:"name"`
+		}{}
+		if err := json.Unmarshal(data, info); err == nil && info.Name == walletName {
+			return data, nil
+		}
+	}
+
+	return nil, errors.New("wallet not found")
+}
+
+// RetrieveWalletByID retrieves wallet-level data for the wallet with the given ID.
+func (s *blobStore) RetrieveWalletByID(walletID uuid.UUID) ([]byte, error) {
+	data, err := s.get(s.walletHeaderKey(walletID))
+	if err != nil {
+		return nil, errors.New("wallet not found")
+	}
+
+	return data, nil
+}
+
+// RetrieveWallets retrieves wallet-level data for all wallets.
+func (s *blobStore) RetrieveWallets() <-chan []byte {
+	ch := make(chan []byte, 1024)
+	go func() {
+		defer close(ch)
+		keys, err := s.list(s.prefix())
+		if err != nil {
+			return
+		}
+		walletKeys := make([]string, 0, len(keys))
+		for _, key := range keys {
+			// This is only a wallet if the last two components of the key are the same.
+			components := strings.Split(key, "/")
+			if len(components) >= 2 && components[len(components)-1] == components[len(components)-2] {
+				walletKeys = append(walletKeys, key)
+			}
+		}
+		s.retrieveConcurrently(walletKeys, ch)
+	}()
+
+	return ch
+}
+
+// StoreAccount stores account-level data.
+func (s *blobStore) StoreAccount(walletID uuid.UUID, accountID uuid.UUID, data []byte) error {
+	if _, err := s.RetrieveWalletByID(walletID); err != nil {
+		return errors.New("unknown wallet")
+	}
+	if err := s.put(s.accountKey(walletID, accountID), data); err != nil {
+		return errors.Wrap(err, "failed to store account")
+	}
+
+	return nil
+}
+
+// RetrieveAccount retrieves account-level data.
+func (s *blobStore) RetrieveAccount(walletID uuid.UUID, accountID uuid.UUID) ([]byte, error) {
+	return s.get(s.accountKey(walletID, accountID))
+}
+
+// RetrieveAccounts retrieves all account-level data for a wallet.
+func (s *blobStore) RetrieveAccounts(walletID uuid.UUID) <-chan []byte {
+	ch := make(chan []byte, 1024)
+	go func() {
+		defer close(ch)
+		keys, err := s.list(s.walletKey(walletID) + "/")
+		if err != nil {
+			return
+		}
+		accountKeys := make([]string, 0, len(keys))
+		for _, key := range keys {
+			switch {
+			case strings.HasSuffix(key, walletID.String()),
+				strings.HasSuffix(key, "/index"),
+				strings.HasSuffix(key, "/batch"):
+				// Not an account.
+			default:
+				accountKeys = append(accountKeys, key)
+			}
+		}
+		s.retrieveConcurrently(accountKeys, ch)
+	}()
+
+	return ch
+}
+
+// StoreAccountsIndex stores the index of accounts for a wallet.
+func (s *blobStore) StoreAccountsIndex(walletID uuid.UUID, data []byte) error {
+	var err error
+	// Do not encrypt empty index, as it is too short.
+	if len(data) == 2 {
+		err = s.putRaw(s.walletIndexKey(walletID), data)
+	} else {
+		err = s.put(s.walletIndexKey(walletID), data)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to store index")
+	}
+
+	return nil
+}
+
+// RetrieveAccountsIndex retrieves the index of accounts for a wallet.
+func (s *blobStore) RetrieveAccountsIndex(walletID uuid.UUID) ([]byte, error) {
+	data, err := s.getRaw(s.walletIndexKey(walletID))
+	if err != nil {
+		return nil, err
+	}
+	// Do not decrypt empty index.
+	if len(data) == 2 {
+		return data, nil
+	}
+
+	return s.decryptIfRequired(data)
+}
+
+// StoreBatch stores the batch of accounts for a wallet.
+func (s *blobStore) StoreBatch(_ context.Context, walletID uuid.UUID, _ string, data []byte) error {
+	if err := s.put(s.walletBatchKey(walletID), data); err != nil {
+		return errors.Wrap(err, "failed to store batch")
+	}
+
+	return nil
+}
+
+// RetrieveBatch retrieves the batch of accounts for a wallet.
+func (s *blobStore) RetrieveBatch(_ context.Context, walletID uuid.UUID) ([]byte, error) {
+	return s.get(s.walletBatchKey(walletID))
+}
+
+// retrieveConcurrently retrieves the blobs with the given keys, sending those
+// successfully retrieved to the channel.
+func (s *blobStore) retrieveConcurrently(keys []string, ch chan<- []byte) {
+	sem := make(chan struct{}, 16)
+	wg := sync.WaitGroup{}
+	for _, key := range keys {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(key string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if data, err := s.get(key); err == nil {
+				ch <- data
+			}
+		}(key)
+	}
+	wg.Wait()
+}
+
+func (s *blobStore) get(key string) ([]byte, error) {
+	data, err := s.getRaw(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.decryptIfRequired(data)
+}
+
+func (s *blobStore) getRaw(key string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	return s.backend.Get(ctx, key)
+}
+
+func (s *blobStore) put(key string, data []byte) error {
+	data, err := s.encryptIfRequired(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to encrypt data")
+	}
+
+	return s.putRaw(key, data)
+}
+
+func (s *blobStore) putRaw(key string, data []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	return s.backend.Put(ctx, key, data)
+}
+
+func (s *blobStore) list(prefix string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	return s.backend.List(ctx, prefix)
+}
+
+// encryptIfRequired encrypts data if the store has a passphrase.
+func (s *blobStore) encryptIfRequired(data []byte) ([]byte, error) {
+	if len(data) == 0 || len(s.passphrase) == 0 {
+		return data, nil
+	}
+	if len(data) < 16 {
+		return nil, errors.New("data must be at least 16 bytes")
+	}
+
+	return ecodec.Encrypt(data, s.passphrase)
+}
+
+// decryptIfRequired decrypts data if the store has a passphrase.
+func (s *blobStore) decryptIfRequired(data []byte) ([]byte, error) {
+	if len(data) == 0 || len(s.passphrase) == 0 {
+		return data, nil
+	}
+	if len(data) < 16 {
+		return nil, errors.New("data must be at least 16 bytes")
+	}
+
+	return ecodec.Decrypt(data, s.passphrase)
+}
+
+func (s *blobStore) prefix() string {
+	if s.path == "" {
+		return ""
+	}
+
+	return s.path + "/"
+}
+
+func (s *blobStore) walletKey(walletID uuid.UUID) string {
+	return s.prefix() + walletID.String()
+}
+
+func (s *blobStore) walletHeaderKey(walletID uuid.UUID) string {
+	return s.walletKey(walletID) + "/" + walletID.String()
+}
+
+func (s *blobStore) accountKey(walletID uuid.UUID, accountID uuid.UUID) string {
+	return s.walletKey(walletID) + "/" + accountID.String()
+}
+
+func (s *blobStore) walletIndexKey(walletID uuid.UUID) string {
+	return s.walletKey(walletID) + "/index"
+}
+
+func (s *blobStore) walletBatchKey(walletID uuid.UUID) string {
+	return s.walletKey(walletID) + "/batch"
+}
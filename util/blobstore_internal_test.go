@@ -0,0 +1,130 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// memoryBackend holds blobs in memory.
+type memoryBackend struct {
+	mu    sync.Mutex
+	blobs map[string][]byte
+}
+
+func (b *memoryBackend) Get(_ context.Context, key string) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	data, exists := b.blobs[key]
+	if !exists {
+		return nil, errBlobNotFound
+	}
+
+	return data, nil
+}
+
+func (b *memoryBackend) Put(_ context.Context, key string, data []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.blobs[key] = data
+
+	return nil
+}
+
+func (b *memoryBackend) List(_ context.Context, prefix string) ([]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	keys := make([]string, 0)
+	for key := range b.blobs {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+func TestBlobStore(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		path       string
+		passphrase []byte
+	}{
+		{
+			name: "Plain",
+		},
+		{
+			name: "Path",
+			path: "/ethdo/wallets/",
+		},
+		{
+			name:       "Encrypted",
+			path:       "ethdo",
+			passphrase: []byte("store secret"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			backend := &memoryBackend{blobs: make(map[string][]byte)}
+			store := newBlobStore("test", "test://", backend, test.path, test.passphrase, 0)
+
+			wallet, err := nd.CreateWallet(ctx, "Test wallet", store, keystorev4.New())
+			require.NoError(t, err)
+			require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+			_, err = wallet.(e2wtypes.WalletAccountImporter).ImportAccount(ctx, "Interop 0", testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"), []byte("pass0"))
+			require.NoError(t, err)
+
+			// Ensure the data is held where expected, and encrypted if required.
+			header := backend.blobs[store.walletHeaderKey(wallet.ID())]
+			require.NotNil(t, header)
+			require.Equal(t, len(test.passphrase) == 0, strings.Contains(string(header), "Test wallet"))
+			_, err = store.RetrieveAccountsIndex(wallet.ID())
+			require.NoError(t, err)
+
+			// Ensure the wallet and account are available when reopened.
+			reopened, err := nd.OpenWallet(ctx, "Test wallet", store, keystorev4.New())
+			require.NoError(t, err)
+			require.Equal(t, wallet.ID(), reopened.ID())
+			account, err := reopened.(e2wtypes.WalletAccountByNameProvider).AccountByName(ctx, "Interop 0")
+			require.NoError(t, err)
+			require.Equal(t, testutil.HexToBytes("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"), account.(e2wtypes.AccountPublicKeyProvider).PublicKey().Marshal())
+			accounts := 0
+			for range store.RetrieveAccounts(wallet.ID()) {
+				accounts++
+			}
+			require.Equal(t, 1, accounts)
+
+			_, err = store.RetrieveWallet("Unknown wallet")
+			require.EqualError(t, err, "wallet not found")
+			require.EqualError(t, store.StoreAccount(uuid.New(), uuid.New(), []byte("0123456789abcdef")), "unknown wallet")
+		})
+	}
+}
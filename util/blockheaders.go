@@ -0,0 +1,49 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// FetchBeaconBlockHeaders obtains the headers of all blocks that the beacon
+// node knows about at the given slot, including those that are not canonical.
+// Beacon nodes may prune non-canonical blocks, so they are only reliably
+// available for recent slots.
+func FetchBeaconBlockHeaders(ctx context.Context, client eth2client.Service, slot phase0.Slot) ([]*apiv1.BeaconBlockHeader, error) {
+	body, err := BeaconNodeGet(ctx, client, fmt.Sprintf("/eth/v1/beacon/headers?slot=%d", slot), "application/json")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain block headers")
+	}
+	defer body.Close()
+
+	var response struct {
+		Data []*apiv1.BeaconBlockHeader `json:"data"`
+	}
+	if err := json.NewDecoder(body).Decode(&response); err != nil {
+		return nil, errors.Wrap(err, "failed to decode block headers")
+	}
+	if response.Data == nil {
+		return nil, errors.New("block headers not returned")
+	}
+
+	return response.Data, nil
+}
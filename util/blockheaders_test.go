@@ -0,0 +1,64 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestFetchBeaconBlockHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/headers" {
+			http.Error(w, `{"code":404,"message":"not found"}`, http.StatusNotFound)
+			return
+		}
+		switch r.URL.Query().Get("slot") {
+		case "100":
+			_, _ = w.Write([]byte(`{"data":[` +
+				`{"root":"0x0100000000000000000000000000000000000000000000000000000000000000","canonical":true,"header":{"message":{"slot":"100","proposer_index":"12","parent_root":"0x0000000000000000000000000000000000000000000000000000000000000000","state_root":"0x0000000000000000000000000000000000000000000000000000000000000000","body_root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"signature":"0x000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"}},` +
+				`{"root":"0x0200000000000000000000000000000000000000000000000000000000000000","canonical":false,"header":{"message":{"slot":"100","proposer_index":"13","parent_root":"0x0000000000000000000000000000000000000000000000000000000000000000","state_root":"0x0000000000000000000000000000000000000000000000000000000000000000","body_root":"0x0000000000000000000000000000000000000000000000000000000000000000"},"signature":"0x000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"}}` +
+				`]}`))
+		case "101":
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	client := &addressETH2Client{address: server.URL}
+
+	headers, err := util.FetchBeaconBlockHeaders(context.Background(), client, 100)
+	require.NoError(t, err)
+	require.Len(t, headers, 2)
+	require.Equal(t, phase0.Root{0x01}, headers[0].Root)
+	require.True(t, headers[0].Canonical)
+	require.Equal(t, phase0.Root{0x02}, headers[1].Root)
+	require.False(t, headers[1].Canonical)
+	require.Equal(t, phase0.ValidatorIndex(13), headers[1].Header.Message.ProposerIndex)
+
+	headers, err = util.FetchBeaconBlockHeaders(context.Background(), client, 101)
+	require.NoError(t, err)
+	require.Empty(t, headers)
+
+	_, err = util.FetchBeaconBlockHeaders(context.Background(), client, 102)
+	require.EqualError(t, err, "block headers not returned")
+}
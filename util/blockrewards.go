@@ -0,0 +1,88 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// BlockRewards are the consensus rewards paid to the proposer of a block.
+type BlockRewards struct {
+	ProposerIndex     phase0.ValidatorIndex
+	Total             phase0.Gwei
+	Attestations      phase0.Gwei
+	SyncAggregate     phase0.Gwei
+	ProposerSlashings phase0.Gwei
+	AttesterSlashings phase0.Gwei
+}
+
+type blockRewardsJSON struct {
+	ProposerIndex     string `json:"proposer_index"`
+	Total             string `json:"total"`
+	Attestations      string `json:"attestations"`
+	SyncAggregate     string `json:"sync_aggregate"`
+	ProposerSlashings string `json:"proposer_slashings"`
+	AttesterSlashings string `json:"attester_slashings"`
+}
+
+// FetchBlockRewards obtains the consensus rewards for the proposer of the
+// given block from the beacon node.
+func FetchBlockRewards(ctx context.Context, client eth2client.Service, blockID string) (*BlockRewards, error) {
+	body, err := BeaconNodeGet(ctx, client, fmt.Sprintf("/eth/v1/beacon/rewards/blocks/%s", blockID), "application/json")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain block rewards")
+	}
+	defer body.Close()
+
+	var response struct {
+		Data *blockRewardsJSON `json:"data"`
+	}
+	if err := json.NewDecoder(body).Decode(&response); err != nil {
+		return nil, errors.Wrap(err, "failed to decode block rewards")
+	}
+	if response.Data == nil {
+		return nil, errors.New("block rewards not returned")
+	}
+
+	values := make([]uint64, 6)
+	for i, input := range []string{
+		response.Data.ProposerIndex,
+		response.Data.Total,
+		response.Data.Attestations,
+		response.Data.SyncAggregate,
+		response.Data.ProposerSlashings,
+		response.Data.AttesterSlashings,
+	} {
+		values[i], err = strconv.ParseUint(input, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid value in block rewards")
+		}
+	}
+
+	return &BlockRewards{
+		ProposerIndex:     phase0.ValidatorIndex(values[0]),
+		Total:             phase0.Gwei(values[1]),
+		Attestations:      phase0.Gwei(values[2]),
+		SyncAggregate:     phase0.Gwei(values[3]),
+		ProposerSlashings: phase0.Gwei(values[4]),
+		AttesterSlashings: phase0.Gwei(values[5]),
+	}, nil
+}
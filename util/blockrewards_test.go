@@ -0,0 +1,82 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// A mock Ethereum 2 client service that returns an address.
+type addressETH2Client struct {
+	address string
+}
+
+// Name returns the name of the client implementation.
+func (c *addressETH2Client) Name() string {
+	return "address mock"
+}
+
+// Address returns the address of the client.
+func (c *addressETH2Client) Address() string {
+	return c.address
+}
+
+// IsActive returns true if the client is active.
+func (c *addressETH2Client) IsActive() bool {
+	return true
+}
+
+// IsSynced returns true if the client is synced.
+func (c *addressETH2Client) IsSynced() bool {
+	return true
+}
+
+func TestFetchBlockRewards(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/beacon/rewards/blocks/100":
+			_, _ = w.Write([]byte(`{"execution_optimistic":false,"finalized":true,"data":{"proposer_index":"12","total":"40000","attestations":"30000","sync_aggregate":"8000","proposer_slashings":"0","attester_slashings":"2000"}}`))
+		case "/eth/v1/beacon/rewards/blocks/101":
+			_, _ = w.Write([]byte(`{"data":{"proposer_index":"12","total":"bad"}}`))
+		default:
+			http.Error(w, `{"code":404,"message":"not found"}`, http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &addressETH2Client{address: server.URL}
+
+	rewards, err := util.FetchBlockRewards(context.Background(), client, "100")
+	require.NoError(t, err)
+	require.Equal(t, &util.BlockRewards{
+		ProposerIndex:     12,
+		Total:             40000,
+		Attestations:      30000,
+		SyncAggregate:     8000,
+		ProposerSlashings: 0,
+		AttesterSlashings: 2000,
+	}, rewards)
+
+	_, err = util.FetchBlockRewards(context.Background(), client, "101")
+	require.ErrorContains(t, err, "invalid value in block rewards")
+
+	_, err = util.FetchBlockRewards(context.Background(), client, "102")
+	require.ErrorContains(t, err, "beacon node returned status 404")
+}
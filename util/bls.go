@@ -15,8 +15,12 @@ package util
 
 import (
 	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+	"strings"
 
 	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
 )
 
 // BLSID turns a uint64 in to a BLS identifier.
@@ -29,3 +33,67 @@ func BLSID(id uint64) *bls.ID {
 	}
 	return &res
 }
+
+// ParseThresholdSignature parses a threshold signature in the format "id:signature".
+func ParseThresholdSignature(input string) (uint64, *bls.Sign, error) {
+	parts := strings.Split(input, ":")
+	if len(parts) != 2 {
+		return 0, nil, errors.New("invalid threshold signature format")
+	}
+	id, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "invalid threshold signature ID")
+	}
+	sigBytes, err := hex.DecodeString(strings.TrimPrefix(parts[1], "0x"))
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "invalid threshold signature")
+	}
+	var sig bls.Sign
+	if err := sig.Deserialize(sigBytes); err != nil {
+		return 0, nil, errors.Wrap(err, "invalid signature")
+	}
+
+	return id, &sig, nil
+}
+
+// RecoverSignature recovers a full signature from threshold signatures
+// created by the participants with the given IDs.
+func RecoverSignature(ids []uint64, sigs []*bls.Sign) (*bls.Sign, error) {
+	if len(ids) != len(sigs) {
+		return nil, errors.New("mismatch between number of IDs and signatures")
+	}
+	blsIDs := make([]bls.ID, len(ids))
+	blsSigs := make([]bls.Sign, len(sigs))
+	for i := range ids {
+		blsIDs[i] = *BLSID(ids[i])
+		blsSigs[i] = *sigs[i]
+	}
+
+	var sig bls.Sign
+	if err := sig.Recover(blsSigs, blsIDs); err != nil {
+		return nil, err
+	}
+
+	return &sig, nil
+}
+
+// Combinations returns all combinations of k items from the supplied IDs, in order.
+func Combinations(ids []uint64, k int) [][]uint64 {
+	res := make([][]uint64, 0)
+	combination := make([]uint64, 0, k)
+	var generate func(start int)
+	generate = func(start int) {
+		if len(combination) == k {
+			res = append(res, append([]uint64{}, combination...))
+			return
+		}
+		for i := start; i <= len(ids)-(k-len(combination)); i++ {
+			combination = append(combination, ids[i])
+			generate(i + 1)
+			combination = combination[:len(combination)-1]
+		}
+	}
+	generate(0)
+
+	return res
+}
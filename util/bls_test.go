@@ -14,8 +14,10 @@
 package util_test
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/stretchr/testify/require"
 	"github.com/wealdtech/ethdo/util"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
@@ -53,3 +55,58 @@ func TestBLSID(t *testing.T) {
 		})
 	}
 }
+
+func TestParseThresholdSignature(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	var secretKey bls.SecretKey
+	secretKey.SetByCSPRNG()
+	sig := fmt.Sprintf("%#x", secretKey.SignByte([]byte("test")).Serialize())
+
+	tests := []struct {
+		name  string
+		input string
+		id    uint64
+		err   string
+	}{
+		{
+			name:  "Good",
+			input: "3:" + sig,
+			id:    3,
+		},
+		{
+			name:  "NoID",
+			input: sig,
+			err:   "invalid threshold signature format",
+		},
+		{
+			name:  "BadID",
+			input: "x:" + sig,
+			err:   `invalid threshold signature ID: strconv.ParseUint: parsing "x": invalid syntax`,
+		},
+		{
+			name:  "BadSignature",
+			input: "3:0x1234",
+			err:   "invalid signature: err blsSignatureDeserialize 1234",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			id, sig, err := util.ParseThresholdSignature(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.id, id)
+			require.NotNil(t, sig)
+		})
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	require.Equal(t, [][]uint64{{1, 2}, {1, 3}, {2, 3}}, util.Combinations([]uint64{1, 2, 3}, 2))
+	require.Len(t, util.Combinations([]uint64{1, 2, 3, 4, 5, 6, 7}, 5), 21)
+	require.Empty(t, util.Combinations([]uint64{1, 2}, 3))
+}
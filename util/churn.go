@@ -0,0 +1,128 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// ChurnParameters are the chain parameters that govern the rate at which
+// validators enter and leave the active validator set.
+type ChurnParameters struct {
+	MinPerEpochChurnLimit            uint64
+	ChurnLimitQuotient               uint64
+	MaxPerEpochActivationChurnLimit  uint64
+	MaxSeedLookahead                 uint64
+	MinValidatorWithdrawabilityDelay uint64
+
+	// Electra parameters, where churn is measured by balance rather than by
+	// number of validators.  These are zero if the chain does not support Electra.
+	MinPerEpochChurnLimitElectra        phase0.Gwei
+	MaxPerEpochActivationExitChurnLimit phase0.Gwei
+	EffectiveBalanceIncrement           phase0.Gwei
+	MaxPendingDepositsPerEpoch          uint64
+}
+
+// ObtainChurnParameters obtains the churn parameters from the chain specification.
+func ObtainChurnParameters(spec map[string]any) (*ChurnParameters, error) {
+	params := &ChurnParameters{}
+	for name, param := range map[string]*uint64{
+		"MIN_PER_EPOCH_CHURN_LIMIT":           &params.MinPerEpochChurnLimit,
+		"CHURN_LIMIT_QUOTIENT":                &params.ChurnLimitQuotient,
+		"MAX_SEED_LOOKAHEAD":                  &params.MaxSeedLookahead,
+		"MIN_VALIDATOR_WITHDRAWABILITY_DELAY": &params.MinValidatorWithdrawabilityDelay,
+	} {
+		value, isValue := spec[name].(uint64)
+		if !isValue {
+			return nil, fmt.Errorf("spec missing %s", name)
+		}
+		*param = value
+	}
+	if params.ChurnLimitQuotient == 0 {
+		return nil, errors.New("spec has invalid CHURN_LIMIT_QUOTIENT")
+	}
+	// The activation churn limit was introduced in Deneb, so is optional.
+	if value, isValue := spec["MAX_PER_EPOCH_ACTIVATION_CHURN_LIMIT"].(uint64); isValue {
+		params.MaxPerEpochActivationChurnLimit = value
+	}
+	// The balance churn limits were introduced in Electra, so are optional.
+	for name, param := range map[string]*phase0.Gwei{
+		"MIN_PER_EPOCH_CHURN_LIMIT_ELECTRA":         &params.MinPerEpochChurnLimitElectra,
+		"MAX_PER_EPOCH_ACTIVATION_EXIT_CHURN_LIMIT": &params.MaxPerEpochActivationExitChurnLimit,
+		"EFFECTIVE_BALANCE_INCREMENT":               &params.EffectiveBalanceIncrement,
+	} {
+		if value, isValue := spec[name].(uint64); isValue {
+			*param = phase0.Gwei(value)
+		}
+	}
+	if value, isValue := spec["MAX_PENDING_DEPOSITS_PER_EPOCH"].(uint64); isValue {
+		params.MaxPendingDepositsPerEpoch = value
+	}
+
+	return params, nil
+}
+
+// SupportsElectra returns true if the parameters include the Electra balance churn limits.
+func (p *ChurnParameters) SupportsElectra() bool {
+	return p.MinPerEpochChurnLimitElectra > 0 &&
+		p.MaxPerEpochActivationExitChurnLimit > 0 &&
+		p.EffectiveBalanceIncrement > 0 &&
+		p.MaxPendingDepositsPerEpoch > 0
+}
+
+// BalanceChurnLimit returns the maximum balance that can enter or leave the
+// active validator set in an epoch post-Electra, given the total active balance.
+func (p *ChurnParameters) BalanceChurnLimit(totalActiveBalance phase0.Gwei) phase0.Gwei {
+	churn := max(p.MinPerEpochChurnLimitElectra, totalActiveBalance/phase0.Gwei(p.ChurnLimitQuotient))
+
+	return churn - churn%p.EffectiveBalanceIncrement
+}
+
+// ActivationExitChurnLimit returns the maximum balance that can be deposited
+// or exited in an epoch post-Electra, given the total active balance.
+func (p *ChurnParameters) ActivationExitChurnLimit(totalActiveBalance phase0.Gwei) phase0.Gwei {
+	return min(p.MaxPerEpochActivationExitChurnLimit, p.BalanceChurnLimit(totalActiveBalance))
+}
+
+// ConsolidationChurnLimit returns the maximum balance that can be
+// consolidated in an epoch post-Electra, given the total active balance.
+func (p *ChurnParameters) ConsolidationChurnLimit(totalActiveBalance phase0.Gwei) phase0.Gwei {
+	return p.BalanceChurnLimit(totalActiveBalance) - p.ActivationExitChurnLimit(totalActiveBalance)
+}
+
+// ChurnLimit returns the maximum number of validators that can leave the
+// active validator set in an epoch, given the number of active validators.
+func (p *ChurnParameters) ChurnLimit(activeValidators uint64) uint64 {
+	return max(p.MinPerEpochChurnLimit, activeValidators/p.ChurnLimitQuotient)
+}
+
+// ActivationChurnLimit returns the maximum number of validators that can join
+// the active validator set in an epoch, given the number of active validators.
+func (p *ChurnParameters) ActivationChurnLimit(activeValidators uint64, postDeneb bool) uint64 {
+	limit := p.ChurnLimit(activeValidators)
+	if postDeneb && p.MaxPerEpochActivationChurnLimit > 0 {
+		limit = min(limit, p.MaxPerEpochActivationChurnLimit)
+	}
+
+	return limit
+}
+
+// ActivationExitEpoch returns the epoch at which activations and exits
+// processed in the given epoch take effect.
+func (p *ChurnParameters) ActivationExitEpoch(epoch phase0.Epoch) phase0.Epoch {
+	return epoch + 1 + phase0.Epoch(p.MaxSeedLookahead)
+}
@@ -0,0 +1,71 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestChurnParameters(t *testing.T) {
+	spec := map[string]any{
+		"MIN_PER_EPOCH_CHURN_LIMIT":           uint64(4),
+		"CHURN_LIMIT_QUOTIENT":                uint64(65536),
+		"MAX_SEED_LOOKAHEAD":                  uint64(4),
+		"MIN_VALIDATOR_WITHDRAWABILITY_DELAY": uint64(256),
+	}
+
+	params, err := util.ObtainChurnParameters(spec)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), params.ChurnLimit(100000))
+	require.Equal(t, uint64(15), params.ChurnLimit(1000000))
+	// No activation churn limit in the spec.
+	require.Equal(t, uint64(15), params.ActivationChurnLimit(1000000, true))
+	require.EqualValues(t, 105, params.ActivationExitEpoch(100))
+
+	spec["MAX_PER_EPOCH_ACTIVATION_CHURN_LIMIT"] = uint64(8)
+	params, err = util.ObtainChurnParameters(spec)
+	require.NoError(t, err)
+	require.Equal(t, uint64(15), params.ActivationChurnLimit(1000000, false))
+	require.Equal(t, uint64(8), params.ActivationChurnLimit(1000000, true))
+	require.Equal(t, uint64(4), params.ActivationChurnLimit(100000, true))
+
+	// No Electra parameters in the spec.
+	require.False(t, params.SupportsElectra())
+
+	spec["MIN_PER_EPOCH_CHURN_LIMIT_ELECTRA"] = uint64(128000000000)
+	spec["MAX_PER_EPOCH_ACTIVATION_EXIT_CHURN_LIMIT"] = uint64(256000000000)
+	spec["EFFECTIVE_BALANCE_INCREMENT"] = uint64(1000000000)
+	spec["MAX_PENDING_DEPOSITS_PER_EPOCH"] = uint64(16)
+	params, err = util.ObtainChurnParameters(spec)
+	require.NoError(t, err)
+	require.True(t, params.SupportsElectra())
+	// Minimum churn.
+	require.EqualValues(t, 128000000000, params.BalanceChurnLimit(1000000000000000))
+	require.EqualValues(t, 128000000000, params.ActivationExitChurnLimit(1000000000000000))
+	require.EqualValues(t, 0, params.ConsolidationChurnLimit(1000000000000000))
+	// Churn rounded down to the effective balance increment.
+	require.EqualValues(t, 218000000000, params.BalanceChurnLimit(14336000000000000))
+	require.EqualValues(t, 218000000000, params.ActivationExitChurnLimit(14336000000000000))
+	// Churn above the activation and exit limit.
+	require.EqualValues(t, 512000000000, params.BalanceChurnLimit(33554432000000000))
+	require.EqualValues(t, 256000000000, params.ActivationExitChurnLimit(33554432000000000))
+	require.EqualValues(t, 256000000000, params.ConsolidationChurnLimit(33554432000000000))
+
+	delete(spec, "CHURN_LIMIT_QUOTIENT")
+	_, err = util.ObtainChurnParameters(spec)
+	require.EqualError(t, err, "spec missing CHURN_LIMIT_QUOTIENT")
+}
@@ -15,11 +15,14 @@ package util
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 )
 
@@ -74,6 +77,9 @@ type depositInfoCLI struct {
 	Amount                uint64 `json:"amount"`
 }
 
+// depositSelector is the function selector for deposit(bytes,bytes,bytes,bytes32) on the deposit contract.
+var depositSelector = []byte{0x22, 0x89, 0x51, 0x18}
+
 // DepositInfoFromJSON obtains deposit info from various possibly formx of JSON.
 func DepositInfoFromJSON(input []byte) ([]*DepositInfo, error) {
 	if len(input) == 0 {
@@ -286,6 +292,47 @@ func tryV1DepositInfoFromJSON(data []byte) ([]*DepositInfo, error) {
 	return depositInfos, nil
 }
 
+// ObtainDeposits obtains deposits from the input, which can be JSON, raw
+// transaction data, or the path to a file containing either.  Raw transaction
+// data does not contain the amount of the deposit, so amount is used instead.
+func ObtainDeposits(input string, amount phase0.Gwei) ([]*DepositInfo, error) {
+	data := []byte(input)
+	if !strings.HasPrefix(input, "0x") &&
+		!strings.HasPrefix(input, "{") &&
+		!strings.HasPrefix(input, "[") {
+		var err error
+		data, err = os.ReadFile(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read deposit data file")
+		}
+	}
+
+	deposits, err := DepositInfoFromJSON(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse deposit data")
+	}
+
+	for _, deposit := range deposits {
+		if deposit.Amount == 0 {
+			deposit.Amount = uint64(amount)
+		}
+	}
+
+	return deposits, nil
+}
+
+// DepositPublicKeys returns the public keys of the deposits.
+func DepositPublicKeys(deposits []*DepositInfo) []phase0.BLSPubKey {
+	pubKeys := make([]phase0.BLSPubKey, 0, len(deposits))
+	for _, deposit := range deposits {
+		var pubKey phase0.BLSPubKey
+		copy(pubKey[:], deposit.PublicKey)
+		pubKeys = append(pubKeys, pubKey)
+	}
+
+	return pubKeys
+}
+
 func tryRawTxData(data []byte) ([]*DepositInfo, error) {
 	txData, err := hex.DecodeString(strings.TrimPrefix(string(data), "0x"))
 	if err != nil {
@@ -297,7 +344,7 @@ func tryRawTxData(data []byte) ([]*DepositInfo, error) {
 	if len(txData) != 420 {
 		return nil, errors.New("invalid transaction length")
 	}
-	if !bytes.Equal(txData[0:4], []byte{0x22, 0x89, 0x51, 0x18}) {
+	if !bytes.Equal(txData[0:4], depositSelector) {
 		return nil, errors.New("invalid function signature")
 	}
 
@@ -310,3 +357,46 @@ func tryRawTxData(data []byte) ([]*DepositInfo, error) {
 
 	return depositInfos, nil
 }
+
+// DepositTransactionData returns the data for a transaction to the deposit
+// contract that makes the deposit.
+func DepositTransactionData(deposit *DepositInfo) ([]byte, error) {
+	if len(deposit.PublicKey) != 48 {
+		return nil, errors.New("invalid public key length")
+	}
+	if len(deposit.WithdrawalCredentials) != 32 {
+		return nil, errors.New("invalid withdrawal credentials length")
+	}
+	if len(deposit.Signature) != 96 {
+		return nil, errors.New("invalid signature length")
+	}
+	if len(deposit.DepositDataRoot) != 32 {
+		return nil, errors.New("invalid deposit data root length")
+	}
+
+	data := make([]byte, 0, 420)
+	data = append(data, depositSelector...)
+	// Offsets of the public key, withdrawal credentials and signature.
+	data = append(data, abiWord(0x80)...)
+	data = append(data, abiWord(0xe0)...)
+	data = append(data, abiWord(0x120)...)
+	data = append(data, deposit.DepositDataRoot...)
+	// Public key, padded to a multiple of 32 bytes.
+	data = append(data, abiWord(48)...)
+	data = append(data, deposit.PublicKey...)
+	data = append(data, make([]byte, 16)...)
+	data = append(data, abiWord(32)...)
+	data = append(data, deposit.WithdrawalCredentials...)
+	data = append(data, abiWord(96)...)
+	data = append(data, deposit.Signature...)
+
+	return data, nil
+}
+
+// abiWord returns a value as a 32-byte ABI word.
+func abiWord(value uint64) []byte {
+	word := make([]byte, 32)
+	binary.BigEndian.PutUint64(word[24:], value)
+
+	return word
+}
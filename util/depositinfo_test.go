@@ -14,8 +14,11 @@
 package util_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/require"
 	"github.com/wealdtech/ethdo/util"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
@@ -78,3 +81,64 @@ func TestDepositInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestObtainDeposits(t *testing.T) {
+	depositData := `[{"pubkey":"a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","withdrawal_credentials":"00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b","amount":32000000000,"signature":"b7a757a4c506ac6ac5f2d23e065de7d00dc9f5a6a3f9610a8b60b65f166379139ae382c91ecbbf5c9fabc34b1cd2cf8f0211488d50d8754716d8e72e17c1a00b5d9b37cc73767946790ebe66cf9669abfc5c25c67e1e2d1c2e11429d149c25a2","deposit_message_root":"139b510ea7f2788ab82da1f427d6cbe1db147c15a053db738ad5500cd83754a6","deposit_data_root":"9e51b386f4271c18149dd0f73297a26a4a8c15c3622c44af79c92446f44a3554","fork_version":"01020304"}]`
+	rawData := `0x22895118000000000000000000000000000000000000000000000000000000000000008000000000000000000000000000000000000000000000000000000000000000e000000000000000000000000000000000000000000000000000000000000001209e51b386f4271c18149dd0f73297a26a4a8c15c3622c44af79c92446f44a35540000000000000000000000000000000000000000000000000000000000000030a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000002000fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b0000000000000000000000000000000000000000000000000000000000000060b7a757a4c506ac6ac5f2d23e065de7d00dc9f5a6a3f9610a8b60b65f166379139ae382c91ecbbf5c9fabc34b1cd2cf8f0211488d50d8754716d8e72e17c1a00b5d9b37cc73767946790ebe66cf9669abfc5c25c67e1e2d1c2e11429d149c25a2`
+	dataFile := filepath.Join(t.TempDir(), "deposit_data.json")
+	require.NoError(t, os.WriteFile(dataFile, []byte(depositData), 0o600))
+
+	tests := []struct {
+		name   string
+		input  string
+		amount phase0.Gwei
+		err    string
+		res    uint64
+	}{
+		{
+			name:  "FileMissing",
+			input: filepath.Join(t.TempDir(), "missing.json"),
+			err:   "failed to read deposit data file",
+		},
+		{
+			name:  "Invalid",
+			input: "[]",
+			err:   "failed to parse deposit data",
+		},
+		{
+			name:   "JSON",
+			input:  depositData,
+			amount: 1000000000,
+			res:    32000000000,
+		},
+		{
+			name:  "File",
+			input: dataFile,
+			res:   32000000000,
+		},
+		{
+			name:  "RawNoAmount",
+			input: rawData,
+		},
+		{
+			name:   "Raw",
+			input:  rawData,
+			amount: 32000000000,
+			res:    32000000000,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			deposits, err := util.ObtainDeposits(test.input, test.amount)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, deposits, 1)
+				require.Equal(t, test.res, deposits[0].Amount)
+				require.Equal(t, []phase0.BLSPubKey{phase0.BLSPubKey(deposits[0].PublicKey)}, util.DepositPublicKeys(deposits))
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// DepositMessageRoot recomputes the deposit message root from the fields of the deposit.
+func DepositMessageRoot(deposit *DepositInfo) (phase0.Root, error) {
+	if len(deposit.PublicKey) != phase0.PublicKeyLength {
+		return phase0.Root{}, errors.New("invalid public key length")
+	}
+	if len(deposit.WithdrawalCredentials) != 32 {
+		return phase0.Root{}, errors.New("invalid withdrawal credentials length")
+	}
+
+	depositMessage := &phase0.DepositMessage{
+		PublicKey:             phase0.BLSPubKey(deposit.PublicKey),
+		WithdrawalCredentials: deposit.WithdrawalCredentials,
+		Amount:                phase0.Gwei(deposit.Amount),
+	}
+	root, err := depositMessage.HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, errors.Wrap(err, "failed to generate deposit message root")
+	}
+
+	return root, nil
+}
+
+// DepositDataRoot recomputes the deposit data root from the fields of the deposit.
+func DepositDataRoot(deposit *DepositInfo) (phase0.Root, error) {
+	if len(deposit.PublicKey) != phase0.PublicKeyLength {
+		return phase0.Root{}, errors.New("invalid public key length")
+	}
+	if len(deposit.WithdrawalCredentials) != 32 {
+		return phase0.Root{}, errors.New("invalid withdrawal credentials length")
+	}
+	if len(deposit.Signature) != phase0.SignatureLength {
+		return phase0.Root{}, errors.New("invalid signature length")
+	}
+
+	depositData := &phase0.DepositData{
+		PublicKey:             phase0.BLSPubKey(deposit.PublicKey),
+		WithdrawalCredentials: deposit.WithdrawalCredentials,
+		Amount:                phase0.Gwei(deposit.Amount),
+		Signature:             phase0.BLSSignature(deposit.Signature),
+	}
+	root, err := depositData.HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, errors.Wrap(err, "failed to generate deposit data root")
+	}
+
+	return root, nil
+}
+
+// VerifyDepositSignature verifies the signature of the deposit over its
+// deposit message, using the deposit domain for the given fork version.
+func VerifyDepositSignature(deposit *DepositInfo, forkVersion phase0.Version) (bool, error) {
+	depositMessageRoot, err := DepositMessageRoot(deposit)
+	if err != nil {
+		return false, err
+	}
+
+	var domain phase0.Domain
+	copy(domain[:], e2types.Domain(e2types.DomainDeposit, forkVersion[:], e2types.ZeroGenesisValidatorsRoot))
+	signingRoot, err := (&phase0.SigningData{
+		ObjectRoot: depositMessageRoot,
+		Domain:     domain,
+	}).HashTreeRoot()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to generate signing root")
+	}
+
+	pubKey, err := e2types.BLSPublicKeyFromBytes(deposit.PublicKey)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid public key")
+	}
+	signature, err := e2types.BLSSignatureFromBytes(deposit.Signature)
+	if err != nil {
+		// An invalid signature cannot verify.
+		return false, nil
+	}
+
+	return signature.Verify(signingRoot[:], pubKey), nil
+}
+
+// VerifyDeposit verifies that a deposit is suitable for submission to the
+// deposit contract of the network with the given genesis fork version: its
+// stated roots must match those recomputed from its fields, and its signature
+// must verify.
+func VerifyDeposit(deposit *DepositInfo, forkVersion phase0.Version) error {
+	if deposit.Amount == 0 {
+		return errors.New("deposit amount not supplied")
+	}
+
+	depositDataRoot, err := DepositDataRoot(deposit)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(deposit.DepositDataRoot, depositDataRoot[:]) {
+		return fmt.Errorf("deposit data root incorrect: stated %#x, calculated %#x", deposit.DepositDataRoot, depositDataRoot)
+	}
+
+	if len(deposit.DepositMessageRoot) != 0 {
+		depositMessageRoot, err := DepositMessageRoot(deposit)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(deposit.DepositMessageRoot, depositMessageRoot[:]) {
+			return fmt.Errorf("deposit message root incorrect: stated %#x, calculated %#x", deposit.DepositMessageRoot, depositMessageRoot)
+		}
+	}
+
+	if len(deposit.ForkVersion) != 0 && !bytes.Equal(deposit.ForkVersion, forkVersion[:]) {
+		return fmt.Errorf("deposit is for fork version %#x rather than %#x", deposit.ForkVersion, forkVersion)
+	}
+
+	verified, err := VerifyDepositSignature(deposit, forkVersion)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		return errors.New("deposit signature does not verify")
+	}
+
+	return nil
+}
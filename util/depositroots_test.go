@@ -0,0 +1,200 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"fmt"
+
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestDepositRoots(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	deposit := func() *util.DepositInfo {
+		return &util.DepositInfo{
+			PublicKey:             testutil.HexToBytes("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"),
+			WithdrawalCredentials: testutil.HexToBytes("0x00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b"),
+			Signature:             testutil.HexToBytes("0xb7a757a4c506ac6ac5f2d23e065de7d00dc9f5a6a3f9610a8b60b65f166379139ae382c91ecbbf5c9fabc34b1cd2cf8f0211488d50d8754716d8e72e17c1a00b5d9b37cc73767946790ebe66cf9669abfc5c25c67e1e2d1c2e11429d149c25a2"),
+			Amount:                32000000000,
+		}
+	}
+	forkVersion := phase0.Version{0x01, 0x02, 0x03, 0x04}
+
+	t.Run("Good", func(t *testing.T) {
+		messageRoot, err := util.DepositMessageRoot(deposit())
+		require.NoError(t, err)
+		require.Equal(t, phase0.Root(testutil.HexToBytes("0x139b510ea7f2788ab82da1f427d6cbe1db147c15a053db738ad5500cd83754a6")), messageRoot)
+
+		dataRoot, err := util.DepositDataRoot(deposit())
+		require.NoError(t, err)
+		require.Equal(t, phase0.Root(testutil.HexToBytes("0x9e51b386f4271c18149dd0f73297a26a4a8c15c3622c44af79c92446f44a3554")), dataRoot)
+
+		verified, err := util.VerifyDepositSignature(deposit(), forkVersion)
+		require.NoError(t, err)
+		require.True(t, verified)
+	})
+
+	t.Run("WrongForkVersion", func(t *testing.T) {
+		verified, err := util.VerifyDepositSignature(deposit(), phase0.Version{})
+		require.NoError(t, err)
+		require.False(t, verified)
+	})
+
+	t.Run("EditedAmount", func(t *testing.T) {
+		edited := deposit()
+		edited.Amount = 1000000000
+		messageRoot, err := util.DepositMessageRoot(edited)
+		require.NoError(t, err)
+		require.NotEqual(t, phase0.Root(testutil.HexToBytes("0x139b510ea7f2788ab82da1f427d6cbe1db147c15a053db738ad5500cd83754a6")), messageRoot)
+
+		verified, err := util.VerifyDepositSignature(edited, forkVersion)
+		require.NoError(t, err)
+		require.False(t, verified)
+	})
+
+	t.Run("BadWithdrawalCredentials", func(t *testing.T) {
+		edited := deposit()
+		edited.WithdrawalCredentials = edited.WithdrawalCredentials[1:]
+		_, err := util.DepositMessageRoot(edited)
+		require.EqualError(t, err, "invalid withdrawal credentials length")
+	})
+
+	t.Run("BadSignature", func(t *testing.T) {
+		edited := deposit()
+		edited.Signature = edited.Signature[1:]
+		_, err := util.DepositDataRoot(edited)
+		require.EqualError(t, err, "invalid signature length")
+	})
+}
+
+func TestVerifyDeposit(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	deposit := func() *util.DepositInfo {
+		return &util.DepositInfo{
+			PublicKey:             testutil.HexToBytes("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"),
+			WithdrawalCredentials: testutil.HexToBytes("0x00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b"),
+			Signature:             testutil.HexToBytes("0xb7a757a4c506ac6ac5f2d23e065de7d00dc9f5a6a3f9610a8b60b65f166379139ae382c91ecbbf5c9fabc34b1cd2cf8f0211488d50d8754716d8e72e17c1a00b5d9b37cc73767946790ebe66cf9669abfc5c25c67e1e2d1c2e11429d149c25a2"),
+			DepositDataRoot:       testutil.HexToBytes("0x9e51b386f4271c18149dd0f73297a26a4a8c15c3622c44af79c92446f44a3554"),
+			DepositMessageRoot:    testutil.HexToBytes("0x139b510ea7f2788ab82da1f427d6cbe1db147c15a053db738ad5500cd83754a6"),
+			ForkVersion:           testutil.HexToBytes("0x01020304"),
+			Amount:                32000000000,
+		}
+	}
+	forkVersion := phase0.Version{0x01, 0x02, 0x03, 0x04}
+
+	tests := []struct {
+		name        string
+		deposit     func() *util.DepositInfo
+		forkVersion phase0.Version
+		err         string
+	}{
+		{
+			name:        "Good",
+			deposit:     deposit,
+			forkVersion: forkVersion,
+		},
+		{
+			name: "AmountMissing",
+			deposit: func() *util.DepositInfo {
+				res := deposit()
+				res.Amount = 0
+				return res
+			},
+			forkVersion: forkVersion,
+			err:         "deposit amount not supplied",
+		},
+		{
+			name: "DataRootIncorrect",
+			deposit: func() *util.DepositInfo {
+				res := deposit()
+				res.Amount = 1000000000
+				return res
+			},
+			forkVersion: forkVersion,
+			err:         "deposit data root incorrect: stated 0x9e51b386f4271c18149dd0f73297a26a4a8c15c3622c44af79c92446f44a3554, calculated 0xcb3885e7f3006854a0a999c05d2c2cb5082a7198dc6e5ba26825116dc8d9b107",
+		},
+		{
+			name: "MessageRootIncorrect",
+			deposit: func() *util.DepositInfo {
+				res := deposit()
+				res.DepositMessageRoot = testutil.HexToBytes("0x0000000000000000000000000000000000000000000000000000000000000000")
+				return res
+			},
+			forkVersion: forkVersion,
+			err:         "deposit message root incorrect: stated 0x0000000000000000000000000000000000000000000000000000000000000000, calculated 0x139b510ea7f2788ab82da1f427d6cbe1db147c15a053db738ad5500cd83754a6",
+		},
+		{
+			name:        "WrongNetwork",
+			deposit:     deposit,
+			forkVersion: phase0.Version{},
+			err:         "deposit is for fork version 0x01020304 rather than 0x00000000",
+		},
+		{
+			name: "SignatureIncorrect",
+			deposit: func() *util.DepositInfo {
+				res := deposit()
+				res.ForkVersion = nil
+				return res
+			},
+			forkVersion: phase0.Version{},
+			err:         "deposit signature does not verify",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := util.VerifyDeposit(test.deposit(), test.forkVersion)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDepositTransactionData(t *testing.T) {
+	deposit := &util.DepositInfo{
+		PublicKey:             testutil.HexToBytes("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"),
+		WithdrawalCredentials: testutil.HexToBytes("0x00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b"),
+		Signature:             testutil.HexToBytes("0xb7a757a4c506ac6ac5f2d23e065de7d00dc9f5a6a3f9610a8b60b65f166379139ae382c91ecbbf5c9fabc34b1cd2cf8f0211488d50d8754716d8e72e17c1a00b5d9b37cc73767946790ebe66cf9669abfc5c25c67e1e2d1c2e11429d149c25a2"),
+		DepositDataRoot:       testutil.HexToBytes("0x9e51b386f4271c18149dd0f73297a26a4a8c15c3622c44af79c92446f44a3554"),
+		Amount:                32000000000,
+	}
+
+	data, err := util.DepositTransactionData(deposit)
+	require.NoError(t, err)
+	require.Len(t, data, 420)
+
+	// Ensure that the data parses back to the same deposit.
+	deposits, err := util.DepositInfoFromJSON([]byte(fmt.Sprintf("%#x", data)))
+	require.NoError(t, err)
+	require.Len(t, deposits, 1)
+	require.Equal(t, deposit.PublicKey, deposits[0].PublicKey)
+	require.Equal(t, deposit.WithdrawalCredentials, deposits[0].WithdrawalCredentials)
+	require.Equal(t, deposit.Signature, deposits[0].Signature)
+	require.Equal(t, deposit.DepositDataRoot, deposits[0].DepositDataRoot)
+
+	deposit.Signature = deposit.Signature[1:]
+	_, err = util.DepositTransactionData(deposit)
+	require.EqualError(t, err, "invalid signature length")
+}
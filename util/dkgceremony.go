@@ -0,0 +1,120 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DKGStateCreated is the state of a ceremony that has yet to generate its key.
+	DKGStateCreated = "created"
+	// DKGStateGenerated is the state of a ceremony that has generated its key
+	// but has yet to verify all participants.
+	DKGStateGenerated = "generated"
+	// DKGStateCompleted is the state of a ceremony that has generated and
+	// verified its key.
+	DKGStateCompleted = "completed"
+)
+
+// DKGParticipant is a participant in a distributed key generation ceremony.
+type DKGParticipant struct {
+	ID       uint64 `json:"id"`
+	Endpoint string `json:"endpoint"`
+	// BaseDir is the base directory of the wallet store for a local participant.
+	BaseDir string `json:"base_dir,omitempty"`
+	// Share is the encrypted key share for a local participant, held
+	// until the share has been stored in the participant's wallet.
+	Share     map[string]any `json:"share,omitempty"`
+	Stored    bool           `json:"stored,omitempty"`
+	Verified  bool           `json:"verified"`
+	PublicKey string         `json:"public_key,omitempty"`
+}
+
+// DKGCeremony is the state of a distributed key generation ceremony.
+type DKGCeremony struct {
+	Account            string            `json:"account"`
+	SigningThreshold   uint32            `json:"signing_threshold"`
+	Participants       []*DKGParticipant `json:"participants"`
+	State              string            `json:"state"`
+	CompositePublicKey string            `json:"composite_public_key,omitempty"`
+	VerificationVector []string          `json:"verification_vector,omitempty"`
+}
+
+// Local returns true if the ceremony is carried out by local participants.
+func (c *DKGCeremony) Local() bool {
+	return len(c.Participants) > 0 && c.Participants[0].BaseDir != ""
+}
+
+// EndpointMap returns the participants of the ceremony as a map of ID to endpoint.
+func (c *DKGCeremony) EndpointMap() map[uint64]string {
+	res := make(map[uint64]string, len(c.Participants))
+	for _, participant := range c.Participants {
+		res[participant.ID] = participant.Endpoint
+	}
+
+	return res
+}
+
+// LoadDKGCeremony loads the state of a ceremony from the given file.
+func LoadDKGCeremony(path string) (*DKGCeremony, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ceremony := &DKGCeremony{}
+	if err := json.Unmarshal(data, ceremony); err != nil {
+		return nil, errors.Wrap(err, "invalid ceremony file")
+	}
+	switch ceremony.State {
+	case DKGStateCreated, DKGStateGenerated, DKGStateCompleted:
+	default:
+		return nil, fmt.Errorf("unknown ceremony state %q", ceremony.State)
+	}
+
+	return ceremony, nil
+}
+
+// SaveDKGCeremony saves the state of a ceremony to the given file.
+// The file is replaced atomically, so an interrupted save does not lose the
+// existing state.
+func SaveDKGCeremony(path string, ceremony *DKGCeremony) error {
+	data, err := json.MarshalIndent(ceremony, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal ceremony")
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), ".ceremony-*")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary ceremony file")
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return errors.Wrap(err, "failed to write ceremony file")
+	}
+	if err := tmpFile.Close(); err != nil {
+		return errors.Wrap(err, "failed to write ceremony file")
+	}
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		return errors.Wrap(err, "failed to replace ceremony file")
+	}
+
+	return nil
+}
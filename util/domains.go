@@ -0,0 +1,298 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// knownDomainTypes are the domain types defined by the specification.
+var knownDomainTypes = map[string]phase0.DomainType{
+	"DOMAIN_BEACON_PROPOSER":                {0x00, 0x00, 0x00, 0x00},
+	"DOMAIN_BEACON_ATTESTER":                {0x01, 0x00, 0x00, 0x00},
+	"DOMAIN_RANDAO":                         {0x02, 0x00, 0x00, 0x00},
+	"DOMAIN_DEPOSIT":                        {0x03, 0x00, 0x00, 0x00},
+	"DOMAIN_VOLUNTARY_EXIT":                 {0x04, 0x00, 0x00, 0x00},
+	"DOMAIN_SELECTION_PROOF":                {0x05, 0x00, 0x00, 0x00},
+	"DOMAIN_AGGREGATE_AND_PROOF":            {0x06, 0x00, 0x00, 0x00},
+	"DOMAIN_SYNC_COMMITTEE":                 {0x07, 0x00, 0x00, 0x00},
+	"DOMAIN_SYNC_COMMITTEE_SELECTION_PROOF": {0x08, 0x00, 0x00, 0x00},
+	"DOMAIN_CONTRIBUTION_AND_PROOF":         {0x09, 0x00, 0x00, 0x00},
+	"DOMAIN_BLS_TO_EXECUTION_CHANGE":        {0x0a, 0x00, 0x00, 0x00},
+	"DOMAIN_APPLICATION_BUILDER":            {0x00, 0x00, 0x00, 0x01},
+}
+
+// ParseDomainType parses a domain type, supplied either as its name in the
+// specification (with or without the "DOMAIN_" prefix, in any case) or as a
+// 4-byte hex string.  It returns the name of the domain type, if known, along
+// with the domain type itself.
+func ParseDomainType(input string) (string, phase0.DomainType, error) {
+	if input == "" {
+		return "", phase0.DomainType{}, errors.New("no domain type supplied")
+	}
+
+	if strings.HasPrefix(input, "0x") {
+		data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+		if err != nil {
+			return "", phase0.DomainType{}, errors.Wrap(err, "failed to decode domain type")
+		}
+		if len(data) != phase0.DomainTypeLength {
+			return "", phase0.DomainType{}, errors.New("domain type must be exactly 4 bytes in length")
+		}
+		var domainType phase0.DomainType
+		copy(domainType[:], data)
+
+		return DomainTypeName(domainType), domainType, nil
+	}
+
+	name := strings.ToUpper(input)
+	if !strings.HasPrefix(name, "DOMAIN_") {
+		name = "DOMAIN_" + name
+	}
+	domainType, exists := knownDomainTypes[name]
+	if !exists {
+		return "", phase0.DomainType{}, fmt.Errorf("unknown domain type %s", input)
+	}
+
+	return name, domainType, nil
+}
+
+// DomainTypeName returns the name of the domain type in the specification, or
+// an empty string if the domain type is not known.
+func DomainTypeName(domainType phase0.DomainType) string {
+	for name, knownDomainType := range knownDomainTypes {
+		if knownDomainType == domainType {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// IsForkIndependentDomainType returns true if the named domain type is signed
+// with the genesis fork version and a zero genesis validators root, allowing
+// it to be generated before the chain starts and to remain valid across forks.
+func IsForkIndependentDomainType(name string) bool {
+	return name == "DOMAIN_DEPOSIT" || name == "DOMAIN_APPLICATION_BUILDER"
+}
+
+// DomainForkVersion returns the fork version that the chain uses for the
+// named domain type: the genesis fork version for fork-independent domain
+// types and BLS to execution changes, the Capella fork version for voluntary
+// exits (EIP-7044), and the current fork version otherwise.
+func DomainForkVersion(ctx context.Context,
+	specProvider eth2client.SpecProvider,
+	forkProvider eth2client.ForkProvider,
+	name string,
+) (
+	phase0.Version,
+	error,
+) {
+	specResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return phase0.Version{}, errors.Wrap(err, "failed to obtain spec")
+	}
+
+	switch {
+	case IsForkIndependentDomainType(name) || name == "DOMAIN_BLS_TO_EXECUTION_CHANGE":
+		forkVersion, isForkVersion := specResponse.Data["GENESIS_FORK_VERSION"].(phase0.Version)
+		if !isForkVersion {
+			return phase0.Version{}, errors.New("failed to obtain GENESIS_FORK_VERSION")
+		}
+
+		return forkVersion, nil
+	case name == "DOMAIN_VOLUNTARY_EXIT":
+		if forkVersion, isForkVersion := specResponse.Data["CAPELLA_FORK_VERSION"].(phase0.Version); isForkVersion {
+			return forkVersion, nil
+		}
+	}
+
+	forkResponse, err := forkProvider.Fork(ctx, &api.ForkOpts{State: "head"})
+	if err != nil {
+		return phase0.Version{}, errors.Wrap(err, "failed to obtain current fork")
+	}
+
+	return forkResponse.Data.CurrentVersion, nil
+}
+
+// ComputeDomain computes the domain for the given domain type, fork version
+// and genesis validators root.
+func ComputeDomain(domainType phase0.DomainType,
+	forkVersion phase0.Version,
+	genesisValidatorsRoot phase0.Root,
+) (
+	phase0.Domain,
+	error,
+) {
+	root, err := (&phase0.ForkData{
+		CurrentVersion:        forkVersion,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}).HashTreeRoot()
+	if err != nil {
+		return phase0.Domain{}, errors.Wrap(err, "failed to calculate signature domain")
+	}
+
+	var domain phase0.Domain
+	copy(domain[:], domainType[:])
+	copy(domain[4:], root[:])
+
+	return domain, nil
+}
+
+// CheckVoluntaryExitDomain checks that the supplied domain matches the domain
+// that the chain to which the client is connected expects for voluntary exits.
+func CheckVoluntaryExitDomain(ctx context.Context,
+	eth2Client eth2client.Service,
+	domain phase0.Domain,
+) error {
+	expected, err := expectedDomain(ctx, eth2Client, "DOMAIN_VOLUNTARY_EXIT", func(spec map[string]any, forkSchedule []*phase0.Fork) (phase0.Version, error) {
+		// Voluntary exits are signed with the Capella fork version (EIP-7044).
+		capellaForkVersion, isForkVersion := spec["CAPELLA_FORK_VERSION"].(phase0.Version)
+		if !isForkVersion {
+			return phase0.Version{}, errors.New("failed to obtain CAPELLA_FORK_VERSION")
+		}
+		for _, fork := range forkSchedule {
+			if fork.CurrentVersion == capellaForkVersion {
+				return capellaForkVersion, nil
+			}
+		}
+
+		return phase0.Version{}, errors.New("beacon node's fork schedule does not contain the Capella fork")
+	})
+	if err != nil {
+		return err
+	}
+
+	return checkDomain(expected, domain)
+}
+
+// CheckBLSToExecutionChangeDomain checks that the supplied domain matches the
+// domain that the chain to which the client is connected expects for BLS to
+// execution change operations.
+func CheckBLSToExecutionChangeDomain(ctx context.Context,
+	eth2Client eth2client.Service,
+	domain phase0.Domain,
+) error {
+	expected, err := expectedDomain(ctx, eth2Client, "DOMAIN_BLS_TO_EXECUTION_CHANGE", func(_ map[string]any, forkSchedule []*phase0.Fork) (phase0.Version, error) {
+		// BLS to execution changes are signed with the genesis fork version.
+		for _, fork := range forkSchedule {
+			if fork.Epoch == 0 {
+				return fork.CurrentVersion, nil
+			}
+		}
+
+		return phase0.Version{}, errors.New("beacon node's fork schedule does not contain the genesis fork")
+	})
+	if err != nil {
+		return err
+	}
+
+	return checkDomain(expected, domain)
+}
+
+// CheckOperationsChain checks that operations were signed for the network of
+// the beacon node to which the client is connected, and with the domain that
+// it expects.  The fork version with which the operations were signed, and the
+// check of their domain, depend on the type of operation so are supplied.
+func CheckOperationsChain(ctx context.Context,
+	eth2Client eth2client.Service,
+	genesisValidatorsRootFunc func(ctx context.Context) (phase0.Root, error),
+	forkVersionFunc func(ctx context.Context) (phase0.Version, error),
+	domain phase0.Domain,
+	checkDomainFunc func(ctx context.Context, eth2Client eth2client.Service, domain phase0.Domain) error,
+) error {
+	genesisValidatorsRoot, err := genesisValidatorsRootFunc(ctx)
+	if err != nil {
+		return err
+	}
+	forkVersion, err := forkVersionFunc(ctx)
+	if err != nil {
+		return err
+	}
+	if err := CheckNetwork(ctx, eth2Client, genesisValidatorsRoot, forkVersion); err != nil {
+		return err
+	}
+
+	return checkDomainFunc(ctx, eth2Client, domain)
+}
+
+func checkDomain(expected phase0.Domain, domain phase0.Domain) error {
+	if expected != domain {
+		return fmt.Errorf("operations were signed with domain %#x but the beacon node expects domain %#x; check the fork version used to generate them", domain, expected)
+	}
+
+	return nil
+}
+
+// expectedDomain calculates the domain of the given type for the chain to
+// which the client is connected, using the supplied function to select the
+// fork version from the fork schedule.
+func expectedDomain(ctx context.Context,
+	eth2Client eth2client.Service,
+	domainTypeName string,
+	forkVersionFunc func(spec map[string]any, forkSchedule []*phase0.Fork) (phase0.Version, error),
+) (
+	phase0.Domain,
+	error,
+) {
+	if eth2Client == nil {
+		return phase0.Domain{}, errors.New("no Ethereum 2 client supplied")
+	}
+
+	specProvider, isProvider := eth2Client.(eth2client.SpecProvider)
+	if !isProvider {
+		return phase0.Domain{}, errors.New("client does not provide spec")
+	}
+	specResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return phase0.Domain{}, errors.Wrap(err, "failed to obtain spec")
+	}
+	domainType, isDomainType := specResponse.Data[domainTypeName].(phase0.DomainType)
+	if !isDomainType {
+		return phase0.Domain{}, fmt.Errorf("failed to obtain %s", domainTypeName)
+	}
+
+	genesisProvider, isProvider := eth2Client.(eth2client.GenesisProvider)
+	if !isProvider {
+		return phase0.Domain{}, errors.New("client does not provide genesis information")
+	}
+	genesisResponse, err := genesisProvider.Genesis(ctx, &api.GenesisOpts{})
+	if err != nil {
+		return phase0.Domain{}, errors.Wrap(err, "failed to obtain genesis information")
+	}
+
+	forkScheduleProvider, isProvider := eth2Client.(eth2client.ForkScheduleProvider)
+	if !isProvider {
+		return phase0.Domain{}, errors.New("client does not provide fork schedule")
+	}
+	forkScheduleResponse, err := forkScheduleProvider.ForkSchedule(ctx, &api.ForkScheduleOpts{})
+	if err != nil {
+		return phase0.Domain{}, errors.Wrap(err, "failed to obtain fork schedule")
+	}
+
+	forkVersion, err := forkVersionFunc(specResponse.Data, forkScheduleResponse.Data)
+	if err != nil {
+		return phase0.Domain{}, err
+	}
+
+	return ComputeDomain(domainType, forkVersion, genesisResponse.Data.GenesisValidatorsRoot)
+}
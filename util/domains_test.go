@@ -0,0 +1,286 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// A mock Ethereum 2 client service that returns domain information.
+type domainETH2Client struct {
+	networkETH2Client
+}
+
+// Spec provides the spec information of the chain.
+func (c *domainETH2Client) Spec(_ context.Context, _ *api.SpecOpts) (*api.Response[map[string]any], error) {
+	return &api.Response[map[string]any]{
+		Data: map[string]any{
+			"CAPELLA_FORK_VERSION":           phase0.Version{0x03},
+			"DOMAIN_VOLUNTARY_EXIT":          phase0.DomainType{0x04, 0x00, 0x00, 0x00},
+			"DOMAIN_BLS_TO_EXECUTION_CHANGE": phase0.DomainType{0x0a, 0x00, 0x00, 0x00},
+		},
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func domainFor(t *testing.T, domainType phase0.DomainType, forkVersion phase0.Version, genesisValidatorsRoot phase0.Root) phase0.Domain {
+	t.Helper()
+
+	root, err := (&phase0.ForkData{
+		CurrentVersion:        forkVersion,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}).HashTreeRoot()
+	require.NoError(t, err)
+
+	var domain phase0.Domain
+	copy(domain[:], domainType[:])
+	copy(domain[4:], root[:])
+
+	return domain
+}
+
+func TestCheckDomain(t *testing.T) {
+	genesisValidatorsRoot := phase0.Root{0x4b, 0x36, 0x3d, 0xb9}
+	service := &domainETH2Client{
+		networkETH2Client: networkETH2Client{
+			genesisValidatorsRoot: genesisValidatorsRoot,
+			forkSchedule: []*phase0.Fork{
+				{PreviousVersion: phase0.Version{0x00}, CurrentVersion: phase0.Version{0x00}, Epoch: 0},
+				{PreviousVersion: phase0.Version{0x00}, CurrentVersion: phase0.Version{0x01}, Epoch: 10},
+				{PreviousVersion: phase0.Version{0x01}, CurrentVersion: phase0.Version{0x03}, Epoch: 20},
+				{PreviousVersion: phase0.Version{0x03}, CurrentVersion: phase0.Version{0x04}, Epoch: 30},
+			},
+		},
+	}
+	preCapellaService := &domainETH2Client{
+		networkETH2Client: networkETH2Client{
+			genesisValidatorsRoot: genesisValidatorsRoot,
+			forkSchedule: []*phase0.Fork{
+				{PreviousVersion: phase0.Version{0x00}, CurrentVersion: phase0.Version{0x00}, Epoch: 0},
+			},
+		},
+	}
+
+	exitDomainType := phase0.DomainType{0x04, 0x00, 0x00, 0x00}
+	changeDomainType := phase0.DomainType{0x0a, 0x00, 0x00, 0x00}
+
+	t.Run("ExitNil", func(t *testing.T) {
+		require.EqualError(t, util.CheckVoluntaryExitDomain(context.Background(), nil, phase0.Domain{}), "no Ethereum 2 client supplied")
+	})
+
+	t.Run("ExitNoSpec", func(t *testing.T) {
+		require.EqualError(t, util.CheckVoluntaryExitDomain(context.Background(), &networkETH2Client{}, phase0.Domain{}), "failed to obtain DOMAIN_VOLUNTARY_EXIT")
+	})
+
+	t.Run("ExitGood", func(t *testing.T) {
+		domain := domainFor(t, exitDomainType, phase0.Version{0x03}, genesisValidatorsRoot)
+		require.NoError(t, util.CheckVoluntaryExitDomain(context.Background(), service, domain))
+	})
+
+	t.Run("ExitStaleForkVersion", func(t *testing.T) {
+		domain := domainFor(t, exitDomainType, phase0.Version{0x01}, genesisValidatorsRoot)
+		err := util.CheckVoluntaryExitDomain(context.Background(), service, domain)
+		require.ErrorContains(t, err, "operations were signed with domain")
+	})
+
+	t.Run("ExitLatestForkVersion", func(t *testing.T) {
+		domain := domainFor(t, exitDomainType, phase0.Version{0x04}, genesisValidatorsRoot)
+		err := util.CheckVoluntaryExitDomain(context.Background(), service, domain)
+		require.ErrorContains(t, err, "operations were signed with domain")
+	})
+
+	t.Run("ExitPreCapella", func(t *testing.T) {
+		domain := domainFor(t, exitDomainType, phase0.Version{0x03}, genesisValidatorsRoot)
+		err := util.CheckVoluntaryExitDomain(context.Background(), preCapellaService, domain)
+		require.EqualError(t, err, "beacon node's fork schedule does not contain the Capella fork")
+	})
+
+	t.Run("ChangeGood", func(t *testing.T) {
+		domain := domainFor(t, changeDomainType, phase0.Version{0x00}, genesisValidatorsRoot)
+		require.NoError(t, util.CheckBLSToExecutionChangeDomain(context.Background(), service, domain))
+	})
+
+	t.Run("ChangeCurrentForkVersion", func(t *testing.T) {
+		domain := domainFor(t, changeDomainType, phase0.Version{0x04}, genesisValidatorsRoot)
+		err := util.CheckBLSToExecutionChangeDomain(context.Background(), service, domain)
+		require.ErrorContains(t, err, "operations were signed with domain")
+	})
+
+	t.Run("ChangeWrongDomainType", func(t *testing.T) {
+		domain := domainFor(t, exitDomainType, phase0.Version{0x00}, genesisValidatorsRoot)
+		err := util.CheckBLSToExecutionChangeDomain(context.Background(), service, domain)
+		require.ErrorContains(t, err, "operations were signed with domain")
+	})
+}
+
+func TestCheckOperationsChain(t *testing.T) {
+	genesisValidatorsRoot := phase0.Root{0x4b, 0x36, 0x3d, 0xb9}
+	service := &domainETH2Client{
+		networkETH2Client: networkETH2Client{
+			genesisValidatorsRoot: genesisValidatorsRoot,
+			forkSchedule: []*phase0.Fork{
+				{PreviousVersion: phase0.Version{0x00}, CurrentVersion: phase0.Version{0x00}, Epoch: 0},
+				{PreviousVersion: phase0.Version{0x00}, CurrentVersion: phase0.Version{0x03}, Epoch: 20},
+			},
+		},
+	}
+	rootFunc := func(root phase0.Root, err error) func(context.Context) (phase0.Root, error) {
+		return func(_ context.Context) (phase0.Root, error) {
+			return root, err
+		}
+	}
+	versionFunc := func(version phase0.Version, err error) func(context.Context) (phase0.Version, error) {
+		return func(_ context.Context) (phase0.Version, error) {
+			return version, err
+		}
+	}
+	exitDomain := domainFor(t, phase0.DomainType{0x04, 0x00, 0x00, 0x00}, phase0.Version{0x03}, genesisValidatorsRoot)
+
+	tests := []struct {
+		name                  string
+		genesisValidatorsRoot func(context.Context) (phase0.Root, error)
+		forkVersion           func(context.Context) (phase0.Version, error)
+		domain                phase0.Domain
+		err                   string
+	}{
+		{
+			name:                  "GenesisValidatorsRootError",
+			genesisValidatorsRoot: rootFunc(phase0.Root{}, errors.New("no root")),
+			forkVersion:           versionFunc(phase0.Version{0x03}, nil),
+			domain:                exitDomain,
+			err:                   "no root",
+		},
+		{
+			name:                  "ForkVersionError",
+			genesisValidatorsRoot: rootFunc(genesisValidatorsRoot, nil),
+			forkVersion:           versionFunc(phase0.Version{}, errors.New("no version")),
+			domain:                exitDomain,
+			err:                   "no version",
+		},
+		{
+			name:                  "WrongNetwork",
+			genesisValidatorsRoot: rootFunc(phase0.Root{0x01}, nil),
+			forkVersion:           versionFunc(phase0.Version{0x03}, nil),
+			domain:                exitDomain,
+			err:                   "operations are for a different network: genesis validators root 0x0100000000000000000000000000000000000000000000000000000000000000 does not match the beacon node's genesis validators root 0x4b363db900000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:                  "WrongDomain",
+			genesisValidatorsRoot: rootFunc(genesisValidatorsRoot, nil),
+			forkVersion:           versionFunc(phase0.Version{0x03}, nil),
+			domain:                domainFor(t, phase0.DomainType{0x04, 0x00, 0x00, 0x00}, phase0.Version{0x00}, genesisValidatorsRoot),
+			err:                   "operations were signed with domain",
+		},
+		{
+			name:                  "Good",
+			genesisValidatorsRoot: rootFunc(genesisValidatorsRoot, nil),
+			forkVersion:           versionFunc(phase0.Version{0x03}, nil),
+			domain:                exitDomain,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := util.CheckOperationsChain(context.Background(), service, test.genesisValidatorsRoot, test.forkVersion, test.domain, util.CheckVoluntaryExitDomain)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseDomainType(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		typeName   string
+		domainType phase0.DomainType
+		err        string
+	}{
+		{
+			name: "Empty",
+			err:  "no domain type supplied",
+		},
+		{
+			name:  "Unknown",
+			input: "DOMAIN_UNKNOWN",
+			err:   "unknown domain type DOMAIN_UNKNOWN",
+		},
+		{
+			name:  "HexInvalid",
+			input: "0xinvalid",
+			err:   "failed to decode domain type: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name:  "HexShort",
+			input: "0x0400",
+			err:   "domain type must be exactly 4 bytes in length",
+		},
+		{
+			name:       "Name",
+			input:      "DOMAIN_VOLUNTARY_EXIT",
+			typeName:   "DOMAIN_VOLUNTARY_EXIT",
+			domainType: phase0.DomainType{0x04, 0x00, 0x00, 0x00},
+		},
+		{
+			name:       "NameShort",
+			input:      "bls_to_execution_change",
+			typeName:   "DOMAIN_BLS_TO_EXECUTION_CHANGE",
+			domainType: phase0.DomainType{0x0a, 0x00, 0x00, 0x00},
+		},
+		{
+			name:       "Hex",
+			input:      "0x00000001",
+			typeName:   "DOMAIN_APPLICATION_BUILDER",
+			domainType: phase0.DomainType{0x00, 0x00, 0x00, 0x01},
+		},
+		{
+			name:       "HexUnknown",
+			input:      "0x0b000000",
+			domainType: phase0.DomainType{0x0b, 0x00, 0x00, 0x00},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			typeName, domainType, err := util.ParseDomainType(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.typeName, typeName)
+				require.Equal(t, test.domainType, domainType)
+			}
+		})
+	}
+}
+
+func TestComputeDomain(t *testing.T) {
+	domainType := phase0.DomainType{0x04, 0x00, 0x00, 0x00}
+	forkVersion := phase0.Version{0x03, 0x00, 0x00, 0x00}
+	genesisValidatorsRoot := phase0.Root{0x4b, 0x36, 0x3d, 0xb9}
+
+	domain, err := util.ComputeDomain(domainType, forkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	require.Equal(t, domainFor(t, domainType, forkVersion, genesisValidatorsRoot), domain)
+}
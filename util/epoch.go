@@ -22,6 +22,9 @@ import (
 	"github.com/wealdtech/ethdo/services/chaintime"
 )
 
+// FarFutureEpoch is the epoch used to denote an unset epoch.
+const FarFutureEpoch = phase0.Epoch(0xffffffffffffffff)
+
 // ParseEpoch parses input to calculate the desired epoch.
 func ParseEpoch(_ context.Context, chainTime chaintime.Service, epochStr string) (phase0.Epoch, error) {
 	currentEpoch := chainTime.CurrentEpoch()
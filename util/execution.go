@@ -0,0 +1,581 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	ethutil "github.com/wealdtech/go-eth2-util"
+)
+
+// ExecutionClient is a client for the JSON-RPC API of an execution node.
+type ExecutionClient struct {
+	address *url.URL
+	id      atomic.Uint64
+}
+
+var (
+	// getDepositCountSelector is the function selector for get_deposit_count() on the deposit contract.
+	getDepositCountSelector = []byte{0x62, 0x1f, 0xd1, 0x30}
+	// getDepositRootSelector is the function selector for get_deposit_root() on the deposit contract.
+	getDepositRootSelector = []byte{0xc5, 0xf2, 0x89, 0x2f}
+	// beaconRootsContract is the address of the EIP-4788 beacon block roots contract.
+	beaconRootsContract = bellatrix.ExecutionAddress{
+		0x00, 0x0f, 0x3d, 0xf6, 0xd7, 0x32, 0x80, 0x7e, 0xf1, 0x31,
+		0x9f, 0xb7, 0xb8, 0xbb, 0x85, 0x22, 0xd0, 0xbe, 0xac, 0x02,
+	}
+)
+
+// NewExecutionClient creates a client for the execution node at the given address.
+func NewExecutionClient(address string) (*ExecutionClient, error) {
+	if address == "" {
+		return nil, errors.New("no execution node address supplied")
+	}
+	if !strings.HasPrefix(address, "http://") && !strings.HasPrefix(address, "https://") {
+		address = "http://" + address
+	}
+	base, err := url.Parse(address)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid execution node address")
+	}
+
+	return &ExecutionClient{
+		address: base,
+	}, nil
+}
+
+// BlockNumber obtains the number of the execution node's latest block.
+func (c *ExecutionClient) BlockNumber(ctx context.Context) (uint64, error) {
+	var res string
+	if err := c.call(ctx, "eth_blockNumber", []any{}, &res); err != nil {
+		return 0, err
+	}
+
+	return parseQuantity(res)
+}
+
+// Call executes a read-only call against a contract at the latest block,
+// returning the result.
+func (c *ExecutionClient) Call(ctx context.Context, to bellatrix.ExecutionAddress, data []byte) ([]byte, error) {
+	params := []any{
+		map[string]string{
+			"to":   to.String(),
+			"data": fmt.Sprintf("%#x", data),
+		},
+		"latest",
+	}
+	var res string
+	if err := c.call(ctx, "eth_call", params, &res); err != nil {
+		return nil, err
+	}
+
+	data, err := hex.DecodeString(strings.TrimPrefix(res, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid call result")
+	}
+
+	return data, nil
+}
+
+// DepositCount obtains the number of deposits made to the deposit contract.
+func (c *ExecutionClient) DepositCount(ctx context.Context, depositContract bellatrix.ExecutionAddress) (uint64, error) {
+	res, err := c.Call(ctx, depositContract, getDepositCountSelector)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to obtain deposit count")
+	}
+
+	// The result is ABI-encoded bytes: an offset, a length, and the
+	// little-endian deposit count itself.
+	if len(res) < 96 {
+		return 0, errors.New("deposit count result too short")
+	}
+	offset := binary.BigEndian.Uint64(res[24:32])
+	if offset+64 > uint64(len(res)) {
+		return 0, errors.New("deposit count result invalid")
+	}
+	length := binary.BigEndian.Uint64(res[offset+24 : offset+32])
+	if length != 8 {
+		return 0, fmt.Errorf("deposit count has unexpected length %d", length)
+	}
+
+	return binary.LittleEndian.Uint64(res[offset+32 : offset+40]), nil
+}
+
+// DepositRoot obtains the root of the deposit contract's deposit tree.
+func (c *ExecutionClient) DepositRoot(ctx context.Context, depositContract bellatrix.ExecutionAddress) (phase0.Root, error) {
+	res, err := c.Call(ctx, depositContract, getDepositRootSelector)
+	if err != nil {
+		return phase0.Root{}, errors.Wrap(err, "failed to obtain deposit root")
+	}
+	if len(res) != 32 {
+		return phase0.Root{}, fmt.Errorf("deposit root has unexpected length %d", len(res))
+	}
+
+	var root phase0.Root
+	copy(root[:], res)
+
+	return root, nil
+}
+
+// DepositLog is a deposit event emitted by the deposit contract.
+type DepositLog struct {
+	BlockNumber uint64
+	PubKey      phase0.BLSPubKey
+}
+
+// depositEventTopic is the topic of DepositEvent(bytes,bytes,bytes,bytes,bytes) logs.
+var depositEventTopic = fmt.Sprintf("%#x", ethutil.Keccak256([]byte("DepositEvent(bytes,bytes,bytes,bytes,bytes)")))
+
+// DepositLogs obtains the deposit events emitted by the deposit contract
+// between the given blocks, inclusive.
+func (c *ExecutionClient) DepositLogs(ctx context.Context,
+	depositContract bellatrix.ExecutionAddress,
+	fromBlock uint64,
+	toBlock uint64,
+) (
+	[]*DepositLog,
+	error,
+) {
+	params := []any{
+		map[string]any{
+			"address":   depositContract.String(),
+			"fromBlock": fmt.Sprintf("%#x", fromBlock),
+			"toBlock":   fmt.Sprintf("%#x", toBlock),
+			"topics":    []string{depositEventTopic},
+		},
+	}
+	var res []struct {
+		BlockNumber string `json:"blockNumber"`
+		Data        string `json:"data"`
+	}
+	if err := c.call(ctx, "eth_getLogs", params, &res); err != nil {
+		return nil, err
+	}
+
+	logs := make([]*DepositLog, 0, len(res))
+	for _, entry := range res {
+		blockNumber, err := parseQuantity(entry.BlockNumber)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid log block number")
+		}
+		data, err := hex.DecodeString(strings.TrimPrefix(entry.Data, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid log data")
+		}
+		// The public key is the first of the ABI-encoded byte arrays.
+		if len(data) < 32 {
+			return nil, errors.New("deposit log data too short")
+		}
+		offset := binary.BigEndian.Uint64(data[24:32])
+		if offset+32+phase0.PublicKeyLength > uint64(len(data)) {
+			return nil, errors.New("deposit log data invalid")
+		}
+		if length := binary.BigEndian.Uint64(data[offset+24 : offset+32]); length != phase0.PublicKeyLength {
+			return nil, fmt.Errorf("deposit log public key has unexpected length %d", length)
+		}
+		log := &DepositLog{
+			BlockNumber: blockNumber,
+		}
+		copy(log.PubKey[:], data[offset+32:offset+32+phase0.PublicKeyLength])
+		logs = append(logs, log)
+	}
+
+	return logs, nil
+}
+
+// BeaconBlockRoot obtains the parent beacon block root of the execution block
+// with the given timestamp from the EIP-4788 beacon block roots contract.
+// The contract only holds roots for recent blocks.
+func (c *ExecutionClient) BeaconBlockRoot(ctx context.Context, timestamp uint64) (phase0.Root, error) {
+	data := make([]byte, 32)
+	binary.BigEndian.PutUint64(data[24:], timestamp)
+	res, err := c.Call(ctx, beaconRootsContract, data)
+	if err != nil {
+		return phase0.Root{}, errors.Wrapf(err, "failed to obtain beacon block root for timestamp %d", timestamp)
+	}
+	if len(res) != 32 {
+		return phase0.Root{}, fmt.Errorf("beacon block root has unexpected length %d", len(res))
+	}
+
+	var root phase0.Root
+	copy(root[:], res)
+
+	return root, nil
+}
+
+// ExecutionReceipt is the receipt of a transaction included on the execution chain.
+type ExecutionReceipt struct {
+	BlockNumber uint64
+	GasUsed     uint64
+	// Succeeded is true if the transaction executed successfully.
+	Succeeded bool
+}
+
+// Code obtains the code deployed at the given address as of the latest block.
+func (c *ExecutionClient) Code(ctx context.Context, address bellatrix.ExecutionAddress) ([]byte, error) {
+	var res string
+	if err := c.call(ctx, "eth_getCode", []any{address.String(), "latest"}, &res); err != nil {
+		return nil, err
+	}
+	code, err := hex.DecodeString(strings.TrimPrefix(res, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid code")
+	}
+
+	return code, nil
+}
+
+// ChainID obtains the chain ID of the execution node.
+func (c *ExecutionClient) ChainID(ctx context.Context) (uint64, error) {
+	var res string
+	if err := c.call(ctx, "eth_chainId", []any{}, &res); err != nil {
+		return 0, err
+	}
+
+	return parseQuantity(res)
+}
+
+// Balance obtains the balance of an address at the latest block, in wei.
+func (c *ExecutionClient) Balance(ctx context.Context, address bellatrix.ExecutionAddress) (*big.Int, error) {
+	var res string
+	if err := c.call(ctx, "eth_getBalance", []any{address.String(), "latest"}, &res); err != nil {
+		return nil, err
+	}
+
+	return parseBigQuantity(res)
+}
+
+// PendingNonce obtains the next nonce for an address, including transactions
+// that are pending inclusion.
+func (c *ExecutionClient) PendingNonce(ctx context.Context, address bellatrix.ExecutionAddress) (uint64, error) {
+	var res string
+	if err := c.call(ctx, "eth_getTransactionCount", []any{address.String(), "pending"}, &res); err != nil {
+		return 0, err
+	}
+
+	return parseQuantity(res)
+}
+
+// BaseFee obtains the base fee per gas of the latest block, in wei.
+func (c *ExecutionClient) BaseFee(ctx context.Context) (*big.Int, error) {
+	var res struct {
+		BaseFeePerGas string `json:"baseFeePerGas"`
+	}
+	if err := c.call(ctx, "eth_getBlockByNumber", []any{"latest", false}, &res); err != nil {
+		return nil, err
+	}
+	if res.BaseFeePerGas == "" {
+		return nil, errors.New("latest block does not have a base fee")
+	}
+
+	return parseBigQuantity(res.BaseFeePerGas)
+}
+
+// MaxPriorityFeePerGas obtains the execution node's suggested priority fee per gas, in wei.
+func (c *ExecutionClient) MaxPriorityFeePerGas(ctx context.Context) (*big.Int, error) {
+	var res string
+	if err := c.call(ctx, "eth_maxPriorityFeePerGas", []any{}, &res); err != nil {
+		return nil, err
+	}
+
+	return parseBigQuantity(res)
+}
+
+// EstimateGas estimates the gas used by a transaction.
+func (c *ExecutionClient) EstimateGas(ctx context.Context,
+	from bellatrix.ExecutionAddress,
+	to bellatrix.ExecutionAddress,
+	value *big.Int,
+	data []byte,
+) (
+	uint64,
+	error,
+) {
+	params := []any{
+		map[string]string{
+			"from":  from.String(),
+			"to":    to.String(),
+			"value": fmt.Sprintf("%#x", value),
+			"data":  "0x" + hex.EncodeToString(data),
+		},
+	}
+	var res string
+	if err := c.call(ctx, "eth_estimateGas", params, &res); err != nil {
+		return 0, err
+	}
+
+	return parseQuantity(res)
+}
+
+// SignTransaction signs a transaction with the node's account for the given
+// address, returning the signed transaction in its binary form.
+func (c *ExecutionClient) SignTransaction(ctx context.Context,
+	from bellatrix.ExecutionAddress,
+	tx *ExecutionTransaction,
+) (
+	[]byte,
+	error,
+) {
+	params := []any{
+		map[string]string{
+			"type":                 fmt.Sprintf("%#x", executionTransactionType),
+			"chainId":              fmt.Sprintf("%#x", tx.ChainID),
+			"from":                 from.String(),
+			"to":                   tx.To.String(),
+			"nonce":                fmt.Sprintf("%#x", tx.Nonce),
+			"gas":                  fmt.Sprintf("%#x", tx.Gas),
+			"maxFeePerGas":         fmt.Sprintf("%#x", tx.MaxFeePerGas),
+			"maxPriorityFeePerGas": fmt.Sprintf("%#x", tx.MaxPriorityFeePerGas),
+			"value":                fmt.Sprintf("%#x", tx.Value),
+			"data":                 "0x" + hex.EncodeToString(tx.Data),
+		},
+	}
+	// Signers return either the raw transaction, or an object containing it.
+	var res json.RawMessage
+	if err := c.call(ctx, "eth_signTransaction", params, &res); err != nil {
+		return nil, err
+	}
+	var raw string
+	if err := json.Unmarshal(res, &raw); err != nil {
+		var obj struct {
+			Raw string `json:"raw"`
+		}
+		if err := json.Unmarshal(res, &obj); err != nil {
+			return nil, errors.Wrap(err, "invalid signed transaction")
+		}
+		raw = obj.Raw
+	}
+
+	data, err := hex.DecodeString(strings.TrimPrefix(raw, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signed transaction")
+	}
+	if len(data) == 0 {
+		return nil, errors.New("signer did not return a signed transaction")
+	}
+
+	return data, nil
+}
+
+// alreadyKnownErrors are the errors returned by execution nodes when they
+// already hold a transaction that is submitted to them.
+var alreadyKnownErrors = []string{
+	"already known",
+	"alreadyknown",
+	"known transaction",
+}
+
+// SendRawTransaction submits a signed transaction, returning its hash.
+// The request is not retried automatically, as a resent transaction could be
+// rejected or replace another.  If the node already holds the transaction
+// this is treated as success.
+func (c *ExecutionClient) SendRawTransaction(ctx context.Context, data []byte) (phase0.Hash32, error) {
+	hash := ExecutionTransactionHash(data)
+
+	var res phase0.Hash32
+	if err := c.call(ctx, "eth_sendRawTransaction", []any{fmt.Sprintf("%#x", data)}, &res); err != nil {
+		if !isAlreadyKnownError(err) {
+			return phase0.Hash32{}, err
+		}
+		// Confirm that the transaction the node knows is this one.
+		known, knownErr := c.knownTransaction(ctx, hash)
+		if knownErr != nil {
+			return phase0.Hash32{}, errors.Wrap(knownErr, "failed to check known transaction")
+		}
+		if !known {
+			return phase0.Hash32{}, err
+		}
+
+		return hash, nil
+	}
+	if res != hash {
+		return phase0.Hash32{}, fmt.Errorf("execution node returned hash %s for transaction %s", res.String(), hash.String())
+	}
+
+	return res, nil
+}
+
+// knownTransaction returns true if the execution node holds the transaction
+// with the given hash, whether or not it has been included in a block.
+func (c *ExecutionClient) knownTransaction(ctx context.Context, hash phase0.Hash32) (bool, error) {
+	var res *struct {
+		Hash phase0.Hash32 `json:"hash"`
+	}
+	if err := c.call(ctx, "eth_getTransactionByHash", []any{hash.String()}, &res); err != nil {
+		return false, err
+	}
+
+	return res != nil && res.Hash == hash, nil
+}
+
+// isAlreadyKnownError returns true if the error is an execution node stating
+// that it already holds the transaction.
+func isAlreadyKnownError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, alreadyKnown := range alreadyKnownErrors {
+		if strings.Contains(msg, alreadyKnown) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TransactionReceipt obtains the receipt for a transaction, returning nil if
+// the transaction has not been included on the execution chain.
+func (c *ExecutionClient) TransactionReceipt(ctx context.Context, hash phase0.Hash32) (*ExecutionReceipt, error) {
+	var res *struct {
+		BlockNumber string `json:"blockNumber"`
+		GasUsed     string `json:"gasUsed"`
+		Status      string `json:"status"`
+	}
+	if err := c.call(ctx, "eth_getTransactionReceipt", []any{hash.String()}, &res); err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, nil
+	}
+
+	blockNumber, err := parseQuantity(res.BlockNumber)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid receipt block number")
+	}
+	gasUsed, err := parseQuantity(res.GasUsed)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid receipt gas used")
+	}
+
+	return &ExecutionReceipt{
+		BlockNumber: blockNumber,
+		GasUsed:     gasUsed,
+		Succeeded:   res.Status == "0x1",
+	}, nil
+}
+
+// retryableMethods are the JSON-RPC methods that only read data, and so are
+// safe to resend on transient failure.
+var retryableMethods = map[string]bool{
+	"eth_blockNumber":           true,
+	"eth_call":                  true,
+	"eth_chainId":               true,
+	"eth_estimateGas":           true,
+	"eth_getBalance":            true,
+	"eth_getBlockByNumber":      true,
+	"eth_getCode":               true,
+	"eth_getLogs":               true,
+	"eth_getTransactionByHash":  true,
+	"eth_getTransactionCount":   true,
+	"eth_getTransactionReceipt": true,
+	"eth_maxPriorityFeePerGas":  true,
+}
+
+func (c *ExecutionClient) call(ctx context.Context, method string, params []any, result any) error {
+	if retryableMethods[method] {
+		ctx = WithRetries(ctx)
+	}
+
+	reqData, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      c.id.Add(1),
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.address.String(), bytes.NewReader(reqData))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := HTTPClient().Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to call execution node")
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read execution node response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("execution node returned status %d", resp.StatusCode)
+	}
+
+	var response struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return errors.Wrap(err, "failed to decode execution node response")
+	}
+	if response.Error != nil {
+		return fmt.Errorf("execution node returned error %d: %s", response.Error.Code, response.Error.Message)
+	}
+	if len(response.Result) == 0 {
+		return errors.New("execution node did not return a result")
+	}
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return errors.Wrapf(err, "failed to decode %s result", method)
+	}
+
+	return nil
+}
+
+// parseQuantity parses a hex-encoded JSON-RPC quantity.
+func parseQuantity(input string) (uint64, error) {
+	if !strings.HasPrefix(input, "0x") {
+		return 0, fmt.Errorf("invalid quantity %s", input)
+	}
+	res, err := strconv.ParseUint(strings.TrimPrefix(input, "0x"), 16, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid quantity %s", input)
+	}
+
+	return res, nil
+}
+
+// parseBigQuantity parses a hex-encoded JSON-RPC quantity that can exceed 64 bits.
+func parseBigQuantity(input string) (*big.Int, error) {
+	if !strings.HasPrefix(input, "0x") {
+		return nil, fmt.Errorf("invalid quantity %s", input)
+	}
+	res, success := new(big.Int).SetString(strings.TrimPrefix(input, "0x"), 16)
+	if !success {
+		return nil, fmt.Errorf("invalid quantity %s", input)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,316 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestExecutionClient(t *testing.T) {
+	depositContract, err := util.ParseExecutionAddress("0x00000000219ab540356cBB839Cbe05303d7705Fa")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     uint64            `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		var result string
+		switch req.Method {
+		case "eth_blockNumber":
+			result = "0x12d687"
+		case "eth_call":
+			var call map[string]string
+			require.NoError(t, json.Unmarshal(req.Params[0], &call))
+			switch call["data"] {
+			case "0x621fd130":
+				// ABI-encoded 8 bytes containing little-endian 1000.
+				result = "0x" +
+					"0000000000000000000000000000000000000000000000000000000000000020" +
+					"0000000000000000000000000000000000000000000000000000000000000008" +
+					"e803000000000000000000000000000000000000000000000000000000000000"
+			case "0xc5f2892f":
+				result = "0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"
+			case "0x000000000000000000000000000000000000000000000000000000006553f100":
+				require.Equal(t, "0x000F3df6D732807Ef1319fB7B8bB8522d0Beac02", call["to"])
+				result = "0x2122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f40"
+			default:
+				_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"execution reverted"}}`))
+				return
+			}
+		default:
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`))
+			return
+		}
+		data, err := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  result,
+		})
+		require.NoError(t, err)
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	_, err = util.NewExecutionClient("")
+	require.EqualError(t, err, "no execution node address supplied")
+
+	client, err := util.NewExecutionClient(server.URL)
+	require.NoError(t, err)
+
+	blockNumber, err := client.BlockNumber(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1234567), blockNumber)
+
+	depositCount, err := client.DepositCount(context.Background(), depositContract)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), depositCount)
+
+	depositRoot, err := client.DepositRoot(context.Background(), depositContract)
+	require.NoError(t, err)
+	require.Equal(t, "0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", depositRoot.String())
+
+	beaconBlockRoot, err := client.BeaconBlockRoot(context.Background(), 1700000000)
+	require.NoError(t, err)
+	require.Equal(t, "0x2122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f40", beaconBlockRoot.String())
+
+	_, err = client.BeaconBlockRoot(context.Background(), 1)
+	require.EqualError(t, err, "failed to obtain beacon block root for timestamp 1: execution node returned error -32000: execution reverted")
+
+	_, err = client.Call(context.Background(), depositContract, []byte{0x01, 0x02, 0x03, 0x04})
+	require.EqualError(t, err, "execution node returned error -32000: execution reverted")
+}
+
+func TestExecutionClientTransactions(t *testing.T) {
+	address, err := util.ParseExecutionAddress("0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F")
+	require.NoError(t, err)
+	depositContract, err := util.ParseExecutionAddress("0x00000000219ab540356cBB839Cbe05303d7705Fa")
+	require.NoError(t, err)
+	txHash := util.ExecutionTransactionHash([]byte{0x02, 0xc0}).String()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     uint64            `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		var result any
+		switch req.Method {
+		case "eth_chainId":
+			result = "0x1"
+		case "eth_getBalance":
+			// 100 Ether.
+			result = "0x56bc75e2d63100000"
+		case "eth_getTransactionCount":
+			require.JSONEq(t, `"pending"`, string(req.Params[1]))
+			result = "0x9"
+		case "eth_getBlockByNumber":
+			result = map[string]string{"number": "0x12d687", "baseFeePerGas": "0x3b9aca00"}
+		case "eth_maxPriorityFeePerGas":
+			result = "0x77359400"
+		case "eth_estimateGas":
+			var call map[string]string
+			require.NoError(t, json.Unmarshal(req.Params[0], &call))
+			require.Equal(t, "0x", call["data"])
+			result = "0x5208"
+		case "eth_signTransaction":
+			var call map[string]string
+			require.NoError(t, json.Unmarshal(req.Params[0], &call))
+			if call["nonce"] == "0x1" {
+				result = map[string]string{"raw": "0x02c0"}
+			} else {
+				result = "0x02c1c0"
+			}
+		case "eth_sendRawTransaction":
+			result = txHash
+		case "eth_getTransactionReceipt":
+			var hash string
+			require.NoError(t, json.Unmarshal(req.Params[0], &hash))
+			if hash != txHash {
+				result = nil
+			} else {
+				result = map[string]string{"blockNumber": "0x12d688", "gasUsed": "0xd0e8", "status": "0x1"}
+			}
+		default:
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`))
+			return
+		}
+		data, err := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  result,
+		})
+		require.NoError(t, err)
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client, err := util.NewExecutionClient(server.URL)
+	require.NoError(t, err)
+
+	chainID, err := client.ChainID(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), chainID)
+
+	balance, err := client.Balance(ctx, address)
+	require.NoError(t, err)
+	require.Equal(t, "100000000000000000000", balance.String())
+
+	nonce, err := client.PendingNonce(ctx, address)
+	require.NoError(t, err)
+	require.Equal(t, uint64(9), nonce)
+
+	baseFee, err := client.BaseFee(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "1000000000", baseFee.String())
+
+	priorityFee, err := client.MaxPriorityFeePerGas(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "2000000000", priorityFee.String())
+
+	gas, err := client.EstimateGas(ctx, address, depositContract, big.NewInt(0), nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(21000), gas)
+
+	tx := &util.ExecutionTransaction{
+		ChainID:              1,
+		MaxPriorityFeePerGas: priorityFee,
+		MaxFeePerGas:         baseFee,
+		Gas:                  gas,
+		To:                   depositContract,
+		Value:                big.NewInt(0),
+	}
+	signed, err := client.SignTransaction(ctx, address, tx)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x02, 0xc1, 0xc0}, signed)
+	tx.Nonce = 1
+	signed, err = client.SignTransaction(ctx, address, tx)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x02, 0xc0}, signed)
+
+	hash, err := client.SendRawTransaction(ctx, signed)
+	require.NoError(t, err)
+	require.Equal(t, txHash, hash.String())
+
+	receipt, err := client.TransactionReceipt(ctx, hash)
+	require.NoError(t, err)
+	require.Equal(t, &util.ExecutionReceipt{BlockNumber: 1234568, GasUsed: 53480, Succeeded: true}, receipt)
+
+	receipt, err = client.TransactionReceipt(ctx, phase0.Hash32{})
+	require.NoError(t, err)
+	require.Nil(t, receipt)
+}
+
+func TestExecutionClientSendRawTransaction(t *testing.T) {
+	data := []byte{0x02, 0xc0}
+	txHash := util.ExecutionTransactionHash(data).String()
+
+	tests := []struct {
+		name     string
+		status   int
+		sendRes  string
+		knownRes string
+		calls    int32
+		err      string
+	}{
+		{
+			name:    "Good",
+			status:  http.StatusOK,
+			sendRes: fmt.Sprintf(`"result":%q`, txHash),
+			calls:   1,
+		},
+		{
+			name:    "HashMismatch",
+			status:  http.StatusOK,
+			sendRes: `"result":"0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"`,
+			calls:   1,
+			err:     fmt.Sprintf("execution node returned hash 0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20 for transaction %s", txHash),
+		},
+		{
+			name:     "AlreadyKnown",
+			status:   http.StatusOK,
+			sendRes:  `"error":{"code":-32000,"message":"already known"}`,
+			knownRes: fmt.Sprintf(`"result":{"hash":%q}`, txHash),
+			calls:    2,
+		},
+		{
+			name:     "AlreadyKnownMissing",
+			status:   http.StatusOK,
+			sendRes:  `"error":{"code":-32000,"message":"already known"}`,
+			knownRes: `"result":null`,
+			calls:    2,
+			err:      "execution node returned error -32000: already known",
+		},
+		{
+			name:    "Rejected",
+			status:  http.StatusOK,
+			sendRes: `"error":{"code":-32000,"message":"nonce too low"}`,
+			calls:   1,
+			err:     "execution node returned error -32000: nonce too low",
+		},
+		{
+			name:   "NotRetried",
+			status: http.StatusServiceUnavailable,
+			calls:  1,
+			err:    "execution node returned status 503",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				var req struct {
+					Method string `json:"method"`
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				w.WriteHeader(test.status)
+				switch req.Method {
+				case "eth_sendRawTransaction":
+					_, _ = w.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":1,%s}`, test.sendRes)))
+				case "eth_getTransactionByHash":
+					_, _ = w.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":1,%s}`, test.knownRes)))
+				}
+			}))
+			defer server.Close()
+
+			client, err := util.NewExecutionClient(server.URL)
+			require.NoError(t, err)
+			hash, err := client.SendRawTransaction(context.Background(), data)
+			require.Equal(t, test.calls, calls.Load())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, txHash, hash.String())
+			}
+		})
+	}
+}
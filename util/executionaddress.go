@@ -0,0 +1,147 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/pkg/errors"
+	ethutil "github.com/wealdtech/go-eth2-util"
+)
+
+// burnAddresses are well-known addresses to which funds are sent to be burned.
+var burnAddresses = map[string]bool{
+	"0x000000000000000000000000000000000000dead": true,
+	"0xdead000000000000000042069420694206942069": true,
+}
+
+// burnPatternZeroBytes is the number of zero bytes at which an address is
+// considered to follow a burn pattern.
+const burnPatternZeroBytes = 16
+
+// ParseWithdrawalAddress parses an execution address supplied for withdrawal
+// credentials, ensuring that it is checksummed unless allowUnchecksummed is
+// set, and that it is not an obviously incorrect value.
+func ParseWithdrawalAddress(input string, allowUnchecksummed bool) (bellatrix.ExecutionAddress, error) {
+	var address bellatrix.ExecutionAddress
+
+	// Check that the withdrawal address contains a 0x prefix.
+	if !strings.HasPrefix(input, "0x") {
+		return address, fmt.Errorf("withdrawal address %s does not contain a 0x prefix", input)
+	}
+	addressBytes, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return address, errors.Wrap(err, "failed to obtain execution address")
+	}
+	if len(addressBytes) != bellatrix.ExecutionAddressLength {
+		return address, errors.New("withdrawal address must be exactly 20 bytes in length")
+	}
+
+	// Ensure the address is properly checksummed.
+	checksummedAddress := AddressBytesToEIP55(addressBytes)
+	if checksummedAddress != input {
+		unchecksummed := input == strings.ToLower(input) || input == "0x"+strings.ToUpper(input[2:])
+		if !unchecksummed || !allowUnchecksummed {
+			return address, fmt.Errorf("withdrawal address checksum does not match (expected %s)", checksummedAddress)
+		}
+	}
+
+	// Reject obviously incorrect addresses.
+	if bytes.Equal(addressBytes, bytes.Repeat(addressBytes[:1], len(addressBytes))) {
+		return address, fmt.Errorf("withdrawal address %s is not a usable address", checksummedAddress)
+	}
+	if burnAddresses[strings.ToLower(checksummedAddress)] {
+		return address, fmt.Errorf("withdrawal address %s is a burn address", checksummedAddress)
+	}
+
+	copy(address[:], addressBytes)
+
+	return address, nil
+}
+
+// CheckWithdrawalAddress uses an execution node to check that a withdrawal
+// address is not a burn address.  Addresses that are mostly zero bytes are
+// used to burn funds, but are also popular for vanity contracts, so such an
+// address is only rejected if it has no contract code.
+func CheckWithdrawalAddress(ctx context.Context,
+	executionClient *ExecutionClient,
+	address bellatrix.ExecutionAddress,
+) error {
+	zeroBytes := 0
+	for _, b := range address {
+		if b == 0 {
+			zeroBytes++
+		}
+	}
+	if zeroBytes < burnPatternZeroBytes {
+		return nil
+	}
+
+	code, err := executionClient.Code(ctx, address)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain code for withdrawal address")
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("withdrawal address %s has no contract code and appears to be a burn address", AddressBytesToEIP55(address[:]))
+	}
+
+	return nil
+}
+
+// ParseExecutionAddress parses an execution address, ensuring that it is
+// checksummed if it contains mixed case.
+func ParseExecutionAddress(input string) (bellatrix.ExecutionAddress, error) {
+	var address bellatrix.ExecutionAddress
+
+	if !strings.HasPrefix(input, "0x") {
+		return address, fmt.Errorf("address %s does not contain a 0x prefix", input)
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return address, errors.Wrap(err, "invalid address")
+	}
+	if len(data) != bellatrix.ExecutionAddressLength {
+		return address, errors.New("address must be exactly 20 bytes in length")
+	}
+	if input != strings.ToLower(input) && input != AddressBytesToEIP55(data) {
+		return address, fmt.Errorf("address checksum does not match (expected %s)", AddressBytesToEIP55(data))
+	}
+	copy(address[:], data)
+
+	return address, nil
+}
+
+// AddressBytesToEIP55 converts a byte array in to an EIP-55 string format.
+func AddressBytesToEIP55(address []byte) string {
+	chars := []byte(hex.EncodeToString(address))
+	hash := ethutil.Keccak256(chars)
+	for i := 0; i < len(chars); i++ {
+		hashByte := hash[i/2]
+		if i%2 == 0 {
+			hashByte >>= 4
+		} else {
+			hashByte &= 0xf
+		}
+		if chars[i] > '9' && hashByte > 7 {
+			chars[i] -= 32
+		}
+	}
+
+	return fmt.Sprintf("0x%s", string(chars))
+}
@@ -0,0 +1,234 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestAddressBytesToEIP55(t *testing.T) {
+	tests := []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	}
+
+	for _, test := range tests {
+		bytes, err := hex.DecodeString(strings.TrimPrefix(test, "0x"))
+		require.NoError(t, err)
+		require.Equal(t, util.AddressBytesToEIP55(bytes), test)
+	}
+}
+
+func TestParseWithdrawalAddress(t *testing.T) {
+	tests := []struct {
+		name               string
+		input              string
+		allowUnchecksummed bool
+		err                string
+	}{
+		{
+			name:  "NoPrefix",
+			input: "5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+			err:   "withdrawal address 5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed does not contain a 0x prefix",
+		},
+		{
+			name:  "Short",
+			input: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA",
+			err:   "withdrawal address must be exactly 20 bytes in length",
+		},
+		{
+			name:  "Good",
+			input: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		},
+		{
+			name:  "LowerCase",
+			input: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+			err:   "withdrawal address checksum does not match (expected 0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed)",
+		},
+		{
+			name:               "LowerCaseAllowed",
+			input:              "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+			allowUnchecksummed: true,
+		},
+		{
+			name:               "UpperCaseAllowed",
+			input:              "0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED",
+			allowUnchecksummed: true,
+		},
+		{
+			name:               "BadChecksumNotAllowed",
+			input:              "0x5AAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+			allowUnchecksummed: true,
+			err:                "withdrawal address checksum does not match (expected 0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed)",
+		},
+		{
+			name:  "Zero",
+			input: "0x0000000000000000000000000000000000000000",
+			err:   "withdrawal address 0x0000000000000000000000000000000000000000 is not a usable address",
+		},
+		{
+			name:               "Repeated",
+			input:              "0xffffffffffffffffffffffffffffffffffffffff",
+			allowUnchecksummed: true,
+			err:                "withdrawal address 0xFFfFfFffFFfffFFfFFfFFFFFffFFFffffFfFFFfF is not a usable address",
+		},
+		{
+			name:  "Burn",
+			input: "0x000000000000000000000000000000000000dEaD",
+			err:   "withdrawal address 0x000000000000000000000000000000000000dEaD is a burn address",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			address, err := util.ParseWithdrawalAddress(test.input, test.allowUnchecksummed)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				expected, err := hex.DecodeString(strings.TrimPrefix(test.input, "0x"))
+				require.NoError(t, err)
+				require.Equal(t, bellatrix.ExecutionAddress(expected), address)
+			}
+		})
+	}
+}
+
+func TestParseExecutionAddress(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		res   bellatrix.ExecutionAddress
+		err   string
+	}{
+		{
+			name:  "NoPrefix",
+			input: "8f0844fd51e31ff6bf5babe21dccf7328e19fd9f",
+			err:   "address 8f0844fd51e31ff6bf5babe21dccf7328e19fd9f does not contain a 0x prefix",
+		},
+		{
+			name:  "Short",
+			input: "0x8f0844fd51e31ff6bf5babe21dccf7328e19fd",
+			err:   "address must be exactly 20 bytes in length",
+		},
+		{
+			name:  "BadChecksum",
+			input: "0x8F0844fd51e31ff6bf5babe21dccf7328e19fd9f",
+			err:   "address checksum does not match (expected 0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F)",
+		},
+		{
+			name:  "Lowercase",
+			input: "0x8f0844fd51e31ff6bf5babe21dccf7328e19fd9f",
+			res:   bellatrix.ExecutionAddress{0x8f, 0x08, 0x44, 0xfd, 0x51, 0xe3, 0x1f, 0xf6, 0xbf, 0x5b, 0xab, 0xe2, 0x1d, 0xcc, 0xf7, 0x32, 0x8e, 0x19, 0xfd, 0x9f},
+		},
+		{
+			name:  "Checksummed",
+			input: "0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F",
+			res:   bellatrix.ExecutionAddress{0x8f, 0x08, 0x44, 0xfd, 0x51, 0xe3, 0x1f, 0xf6, 0xbf, 0x5b, 0xab, 0xe2, 0x1d, 0xcc, 0xf7, 0x32, 0x8e, 0x19, 0xfd, 0x9f},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.ParseExecutionAddress(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestCheckWithdrawalAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     uint64            `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "eth_getCode", req.Method)
+		var address string
+		require.NoError(t, json.Unmarshal(req.Params[0], &address))
+		result := "0x"
+		if strings.EqualFold(address, "0x00000000219ab540356cBB839Cbe05303d7705Fa") {
+			result = "0x60806040"
+		}
+		data, err := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  result,
+		})
+		require.NoError(t, err)
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	client, err := util.NewExecutionClient(server.URL)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		address string
+		err     string
+	}{
+		{
+			name:    "Good",
+			address: "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F",
+		},
+		{
+			name:    "VanityContract",
+			address: "0x00000000219ab540356cBB839Cbe05303d7705Fa",
+		},
+		{
+			name:    "BurnPattern",
+			address: "0x0000000000000000000000000000000000000001",
+			err:     "withdrawal address 0x0000000000000000000000000000000000000001 has no contract code and appears to be a burn address",
+		},
+		{
+			name:    "BurnPatternDead",
+			address: "0xdEAD000000000000000000000000000000000000",
+			err:     "withdrawal address 0xdEad000000000000000000000000000000000000 has no contract code and appears to be a burn address",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			addressBytes, err := hex.DecodeString(strings.TrimPrefix(test.address, "0x"))
+			require.NoError(t, err)
+			var address bellatrix.ExecutionAddress
+			copy(address[:], addressBytes)
+			err = util.CheckWithdrawalAddress(context.Background(), client, address)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
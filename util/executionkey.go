@@ -0,0 +1,102 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/pkg/errors"
+	ethutil "github.com/wealdtech/go-eth2-util"
+)
+
+// ExecutionKey is a secp256k1 private key held locally to sign execution layer transactions.
+type ExecutionKey struct {
+	key     *secp256k1.PrivateKey
+	address bellatrix.ExecutionAddress
+}
+
+// ParseExecutionKey parses an execution layer private key, supplied either
+// as a hex string or as the path to a file containing one.
+func ParseExecutionKey(input string) (*ExecutionKey, error) {
+	if input == "" {
+		return nil, errors.New("no execution key supplied")
+	}
+	if !strings.HasPrefix(input, "0x") {
+		// Assume it's a path to the key.
+		data, err := os.ReadFile(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read execution key file")
+		}
+		input = strings.TrimSpace(string(data))
+	}
+
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid execution key")
+	}
+
+	return NewExecutionKey(data)
+}
+
+// NewExecutionKey creates an execution layer key from its private key bytes.
+func NewExecutionKey(data []byte) (*ExecutionKey, error) {
+	if len(data) != 32 {
+		return nil, errors.New("execution key must be 32 bytes")
+	}
+	var scalar secp256k1.ModNScalar
+	if overflow := scalar.SetByteSlice(data); overflow || scalar.IsZero() {
+		return nil, errors.New("execution key out of range")
+	}
+	key := secp256k1.NewPrivateKey(&scalar)
+
+	res := &ExecutionKey{
+		key: key,
+	}
+	// The address is taken from the uncompressed public key without its prefix.
+	copy(res.address[:], ethutil.Keccak256(key.PubKey().SerializeUncompressed()[1:])[12:])
+
+	return res, nil
+}
+
+// Address returns the address of the key.
+func (k *ExecutionKey) Address() bellatrix.ExecutionAddress {
+	return k.address
+}
+
+// SignTransaction signs the transaction, returning it in its binary form.
+func (k *ExecutionKey) SignTransaction(_ context.Context, tx *ExecutionTransaction) ([]byte, error) {
+	hash := tx.SigningHash()
+	r, s, recoveryID := k.sign(hash[:])
+
+	return tx.signedBinary(recoveryID, r, s), nil
+}
+
+// sign signs a 32-byte hash, returning a low-s signature and its recovery ID.
+// Signing is constant time, with the nonce generated as per RFC 6979.
+func (k *ExecutionKey) sign(hash []byte) (*big.Int, *big.Int, byte) {
+	// The compact signature is the recovery code followed by r and s.
+	sig := ecdsa.SignCompact(k.key, hash, false)
+	recoveryID := sig[0] - 27
+	r := new(big.Int).SetBytes(sig[1:33])
+	s := new(big.Int).SetBytes(sig[33:65])
+
+	return r, s, recoveryID
+}
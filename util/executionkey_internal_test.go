@@ -0,0 +1,153 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/stretchr/testify/require"
+	ethutil "github.com/wealdtech/go-eth2-util"
+)
+
+func TestParseExecutionKey(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("0x4646464646464646464646464646464646464646464646464646464646464646\n"), 0o600))
+
+	tests := []struct {
+		name    string
+		input   string
+		err     string
+		address string
+	}{
+		{
+			name: "Missing",
+			err:  "no execution key supplied",
+		},
+		{
+			name:  "BadHex",
+			input: "0xinvalid",
+			err:   "invalid execution key: encoding/hex: invalid byte: U+0069 'i'",
+		},
+		{
+			name:  "Short",
+			input: "0x4646",
+			err:   "execution key must be 32 bytes",
+		},
+		{
+			name:  "Zero",
+			input: "0x0000000000000000000000000000000000000000000000000000000000000000",
+			err:   "execution key out of range",
+		},
+		{
+			name:  "FileMissing",
+			input: filepath.Join(t.TempDir(), "missing"),
+			err:   "failed to read execution key file",
+		},
+		{
+			name:    "Good",
+			input:   "0x4646464646464646464646464646464646464646464646464646464646464646",
+			address: "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F",
+		},
+		{
+			name:    "File",
+			input:   keyFile,
+			address: "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			key, err := ParseExecutionKey(test.input)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				address := key.Address()
+				require.Equal(t, test.address, address.String())
+			}
+		})
+	}
+}
+
+func TestExecutionKeySign(t *testing.T) {
+	key, err := ParseExecutionKey("0x4646464646464646464646464646464646464646464646464646464646464646")
+	require.NoError(t, err)
+
+	// Signing data for the example transaction in EIP-155.
+	to := bytes.Repeat([]byte{0x35}, 20)
+	gasPrice, _ := new(big.Int).SetString("20000000000", 10)
+	value, _ := new(big.Int).SetString("1000000000000000000", 10)
+	hash := ethutil.Keccak256(rlpList(rlpUint(9), rlpBigInt(gasPrice), rlpUint(21000), rlpBytes(to), rlpBigInt(value), rlpBytes(nil), rlpUint(1), rlpUint(0), rlpUint(0)))
+	require.Equal(t, "daf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53", hex.EncodeToString(hash))
+
+	r, s, recoveryID := key.sign(hash)
+	require.Equal(t, "18515461264373351373200002665853028612451056578545711640558177340181847433846", r.String())
+	require.Equal(t, "46948507304638947509940763649030358759909902576025900602547168820602576006531", s.String())
+	require.Equal(t, byte(0), recoveryID)
+}
+
+func TestExecutionKeySignTransaction(t *testing.T) {
+	ctx := context.Background()
+
+	key, err := ParseExecutionKey("0x4646464646464646464646464646464646464646464646464646464646464646")
+	require.NoError(t, err)
+
+	tx := &ExecutionTransaction{
+		ChainID:              1,
+		Nonce:                0,
+		MaxPriorityFeePerGas: big.NewInt(1),
+		MaxFeePerGas:         big.NewInt(2),
+		Gas:                  21000,
+		To:                   bellatrix.ExecutionAddress(bytes.Repeat([]byte{0x35}, 20)),
+	}
+	unsigned := "02df0180010282520894" + hex.EncodeToString(bytes.Repeat([]byte{0x35}, 20)) + "8080c0"
+	unsignedData, err := hex.DecodeString(unsigned)
+	require.NoError(t, err)
+	hash := tx.SigningHash()
+	require.Equal(t, ethutil.Keccak256(unsignedData), hash[:])
+
+	signed, err := key.SignTransaction(ctx, tx)
+	require.NoError(t, err)
+	// Type, list prefix, unsigned fields, recovery ID, r and s.
+	require.Equal(t, byte(0x02), signed[0])
+	require.Equal(t, byte(0xf8), signed[1])
+	require.Equal(t, unsignedData[2:], signed[3:34])
+	require.Len(t, signed, 3+31+1+33+33)
+
+	// Recover the public key from the signature, which also checks the recovery ID.
+	recoveryID := signed[34]
+	if recoveryID == 0x80 {
+		// RLP encoding of 0.
+		recoveryID = 0
+	}
+	compact := append([]byte{27 + recoveryID}, signed[36:68]...)
+	compact = append(compact, signed[69:101]...)
+	pubKey, compressed, err := ecdsa.RecoverCompact(compact, hash[:])
+	require.NoError(t, err)
+	require.False(t, compressed)
+	require.True(t, pubKey.IsEqual(key.key.PubKey()))
+	// Signatures must have a low s value.
+	var s secp256k1.ModNScalar
+	s.SetByteSlice(signed[69:101])
+	require.False(t, s.IsOverHalfOrder())
+}
@@ -0,0 +1,57 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/pkg/errors"
+)
+
+// remoteExecutionSigner signs transactions with an external signer, such as
+// one fronting a hardware wallet, that supports eth_signTransaction.
+type remoteExecutionSigner struct {
+	client  *ExecutionClient
+	address bellatrix.ExecutionAddress
+}
+
+// NewRemoteExecutionSigner creates a signer for the account with the given
+// address held by the external signer at the given URL.
+func NewRemoteExecutionSigner(url string, address bellatrix.ExecutionAddress) (ExecutionSigner, error) {
+	client, err := NewExecutionClient(url)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to set up execution signer")
+	}
+
+	return &remoteExecutionSigner{
+		client:  client,
+		address: address,
+	}, nil
+}
+
+// Address returns the address of the account that signs transactions.
+func (s *remoteExecutionSigner) Address() bellatrix.ExecutionAddress {
+	return s.address
+}
+
+// SignTransaction signs the transaction, returning it in its binary form.
+func (s *remoteExecutionSigner) SignTransaction(ctx context.Context, tx *ExecutionTransaction) ([]byte, error) {
+	data, err := s.client.SignTransaction(ctx, s.address, tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign transaction")
+	}
+
+	return data, nil
+}
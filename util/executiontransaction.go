@@ -0,0 +1,78 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ethutil "github.com/wealdtech/go-eth2-util"
+)
+
+// executionTransactionType is the type of EIP-1559 transactions.
+const executionTransactionType = 0x02
+
+// ExecutionTransaction is an EIP-1559 transaction on the execution chain.
+type ExecutionTransaction struct {
+	ChainID              uint64
+	Nonce                uint64
+	MaxPriorityFeePerGas *big.Int
+	MaxFeePerGas         *big.Int
+	Gas                  uint64
+	To                   bellatrix.ExecutionAddress
+	Value                *big.Int
+	Data                 []byte
+}
+
+// ExecutionSigner signs execution layer transactions.
+type ExecutionSigner interface {
+	// Address returns the address of the account that signs transactions.
+	Address() bellatrix.ExecutionAddress
+	// SignTransaction signs the transaction, returning it in its binary form.
+	SignTransaction(ctx context.Context, tx *ExecutionTransaction) ([]byte, error)
+}
+
+// SigningHash returns the hash of the transaction that is signed.
+func (t *ExecutionTransaction) SigningHash() phase0.Hash32 {
+	return phase0.Hash32(ethutil.Keccak256(append([]byte{executionTransactionType}, rlpList(t.fields()...)...)))
+}
+
+// signedBinary returns the binary form of the transaction with the given signature.
+func (t *ExecutionTransaction) signedBinary(recoveryID byte, r *big.Int, s *big.Int) []byte {
+	fields := append(t.fields(), rlpUint(uint64(recoveryID)), rlpBigInt(r), rlpBigInt(s))
+
+	return append([]byte{executionTransactionType}, rlpList(fields...)...)
+}
+
+func (t *ExecutionTransaction) fields() [][]byte {
+	return [][]byte{
+		rlpUint(t.ChainID),
+		rlpUint(t.Nonce),
+		rlpBigInt(t.MaxPriorityFeePerGas),
+		rlpBigInt(t.MaxFeePerGas),
+		rlpUint(t.Gas),
+		rlpBytes(t.To[:]),
+		rlpBigInt(t.Value),
+		rlpBytes(t.Data),
+		// Access list.
+		rlpList(),
+	}
+}
+
+// ExecutionTransactionHash returns the hash of a signed transaction in its binary form.
+func ExecutionTransactionHash(data []byte) phase0.Hash32 {
+	return phase0.Hash32(ethutil.Keccak256(data))
+}
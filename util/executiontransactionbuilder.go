@@ -0,0 +1,266 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+// executionGasHeadroom is the percentage added to gas estimates, as the gas
+// used by a transaction can change between estimation and inclusion.
+const executionGasHeadroom = 20
+
+// ExecutionTransactionBuilder builds and signs consecutive transactions from
+// a single execution account.
+type ExecutionTransactionBuilder struct {
+	client               *ExecutionClient
+	signer               ExecutionSigner
+	chainID              uint64
+	nonce                uint64
+	maxFeePerGas         *big.Int
+	maxPriorityFeePerGas *big.Int
+}
+
+// SignedExecutionTransaction is a signed transaction ready to be sent.
+type SignedExecutionTransaction struct {
+	Nonce uint64
+	Hash  phase0.Hash32
+	// Data is the signed transaction in its binary form.
+	Data []byte
+	// Cost is the maximum cost of the transaction including its value, in wei.
+	Cost *big.Int
+}
+
+// ExecutionTransactionOpts are options that override the values otherwise
+// obtained from the execution node.
+type ExecutionTransactionOpts struct {
+	// MaxFeePerGas is the maximum fee per gas, in wei.
+	MaxFeePerGas *big.Int
+	// MaxPriorityFeePerGas is the maximum priority fee per gas, in wei.
+	MaxPriorityFeePerGas *big.Int
+	// Nonce is the nonce of the first transaction.
+	Nonce *uint64
+}
+
+// NewExecutionTransactionBuilder creates a transaction builder for the
+// signer, obtaining the chain ID, and any nonce and fees not supplied in the
+// options, from the execution node.
+func NewExecutionTransactionBuilder(ctx context.Context,
+	client *ExecutionClient,
+	signer ExecutionSigner,
+	opts *ExecutionTransactionOpts,
+) (
+	*ExecutionTransactionBuilder,
+	error,
+) {
+	if opts == nil {
+		opts = &ExecutionTransactionOpts{}
+	}
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain execution chain ID")
+	}
+
+	var nonce uint64
+	if opts.Nonce != nil {
+		nonce = *opts.Nonce
+	} else {
+		nonce, err = client.PendingNonce(ctx, signer.Address())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain nonce")
+		}
+	}
+
+	maxPriorityFeePerGas := opts.MaxPriorityFeePerGas
+	if maxPriorityFeePerGas == nil {
+		maxPriorityFeePerGas, err = client.MaxPriorityFeePerGas(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain priority fee")
+		}
+	}
+
+	maxFeePerGas := opts.MaxFeePerGas
+	switch {
+	case maxFeePerGas == nil:
+		baseFee, err := client.BaseFee(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain base fee")
+		}
+		// Allow for the base fee to double before the transactions are included.
+		maxFeePerGas = new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), maxPriorityFeePerGas)
+	case opts.MaxPriorityFeePerGas == nil && maxPriorityFeePerGas.Cmp(maxFeePerGas) > 0:
+		// The priority fee suggested by the node cannot exceed the supplied maximum fee.
+		maxPriorityFeePerGas = maxFeePerGas
+	}
+
+	return &ExecutionTransactionBuilder{
+		client:               client,
+		signer:               signer,
+		chainID:              chainID,
+		nonce:                nonce,
+		maxFeePerGas:         maxFeePerGas,
+		maxPriorityFeePerGas: maxPriorityFeePerGas,
+	}, nil
+}
+
+// ChainID returns the chain ID of the execution node.
+func (b *ExecutionTransactionBuilder) ChainID() uint64 {
+	return b.chainID
+}
+
+// Build builds and signs a transaction, using the next nonce of the account.
+func (b *ExecutionTransactionBuilder) Build(ctx context.Context,
+	to bellatrix.ExecutionAddress,
+	value *big.Int,
+	data []byte,
+) (
+	*SignedExecutionTransaction,
+	error,
+) {
+	gas, err := b.client.EstimateGas(ctx, b.signer.Address(), to, value, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to estimate gas")
+	}
+	gas += gas * executionGasHeadroom / 100
+
+	tx := &ExecutionTransaction{
+		ChainID:              b.chainID,
+		Nonce:                b.nonce,
+		MaxPriorityFeePerGas: b.maxPriorityFeePerGas,
+		MaxFeePerGas:         b.maxFeePerGas,
+		Gas:                  gas,
+		To:                   to,
+		Value:                value,
+		Data:                 data,
+	}
+	signed, err := b.signer.SignTransaction(ctx, tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign transaction")
+	}
+
+	cost := new(big.Int).Mul(new(big.Int).SetUint64(gas), b.maxFeePerGas)
+	cost.Add(cost, value)
+	res := &SignedExecutionTransaction{
+		Nonce: b.nonce,
+		Hash:  ExecutionTransactionHash(signed),
+		Data:  signed,
+		Cost:  cost,
+	}
+	b.nonce++
+
+	return res, nil
+}
+
+// CheckBalance ensures that the account can afford the transactions.
+func (b *ExecutionTransactionBuilder) CheckBalance(ctx context.Context, txs []*SignedExecutionTransaction) error {
+	required := new(big.Int)
+	for _, tx := range txs {
+		required.Add(required, tx.Cost)
+	}
+
+	balance, err := b.client.Balance(ctx, b.signer.Address())
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain balance")
+	}
+	if balance.Cmp(required) < 0 {
+		address := b.signer.Address()
+		return fmt.Errorf("balance of %s is %s, but up to %s is required", address.String(), string2eth.WeiToString(balance, true), string2eth.WeiToString(required, true))
+	}
+
+	return nil
+}
+
+// ObtainExecutionSigner obtains the signer for execution layer transactions
+// from the configuration, returning nil if no signer is configured.  The
+// signer is either a local key supplied with "execution-key", or an external
+// signer supplied with "execution-signer" along with the "from" address.
+func ObtainExecutionSigner() (ExecutionSigner, error) {
+	executionKey := viper.GetString("execution-key")
+	executionSigner := viper.GetString("execution-signer")
+	switch {
+	case executionKey != "" && executionSigner != "":
+		return nil, errors.New("only one of execution-key and execution-signer can be supplied")
+	case executionKey != "":
+		key, err := ParseExecutionKey(executionKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid execution key")
+		}
+		return key, nil
+	case executionSigner != "":
+		if viper.GetString("from") == "" {
+			return nil, errors.New("from is required with execution-signer")
+		}
+		from, err := ParseExecutionAddress(viper.GetString("from"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid from address")
+		}
+		return NewRemoteExecutionSigner(executionSigner, from)
+	default:
+		return nil, nil
+	}
+}
+
+// ObtainExecutionTransactionOpts obtains the transaction options from the
+// configuration values "max-fee", "priority-fee" and "nonce".  Fees are per
+// gas, and can be supplied with units, for example "20gwei".
+func ObtainExecutionTransactionOpts() (*ExecutionTransactionOpts, error) {
+	opts := &ExecutionTransactionOpts{}
+
+	if viper.GetString("max-fee") != "" {
+		maxFeePerGas, err := string2eth.StringToWei(viper.GetString("max-fee"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid max fee")
+		}
+		opts.MaxFeePerGas = maxFeePerGas
+	}
+
+	if viper.GetString("priority-fee") != "" {
+		maxPriorityFeePerGas, err := string2eth.StringToWei(viper.GetString("priority-fee"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid priority fee")
+		}
+		opts.MaxPriorityFeePerGas = maxPriorityFeePerGas
+	}
+
+	if opts.MaxFeePerGas != nil &&
+		opts.MaxPriorityFeePerGas != nil &&
+		opts.MaxPriorityFeePerGas.Cmp(opts.MaxFeePerGas) > 0 {
+		return nil, errors.New("priority fee cannot be greater than max fee")
+	}
+
+	if viper.GetString("nonce") != "" {
+		nonce, err := strconv.ParseUint(viper.GetString("nonce"), 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid nonce")
+		}
+		opts.Nonce = &nonce
+	}
+
+	return opts, nil
+}
+
+// IsEmpty returns true if no options are set.
+func (o *ExecutionTransactionOpts) IsEmpty() bool {
+	return o.MaxFeePerGas == nil && o.MaxPriorityFeePerGas == nil && o.Nonce == nil
+}
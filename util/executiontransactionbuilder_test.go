@@ -0,0 +1,195 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestObtainExecutionTransactionOpts(t *testing.T) {
+	nonce := uint64(10)
+
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		opts *util.ExecutionTransactionOpts
+		err  string
+	}{
+		{
+			name: "Empty",
+			opts: &util.ExecutionTransactionOpts{},
+		},
+		{
+			name: "MaxFeeInvalid",
+			vars: map[string]interface{}{
+				"max-fee": "1.2.3",
+			},
+			err: "invalid max fee: invalid format",
+		},
+		{
+			name: "PriorityFeeInvalid",
+			vars: map[string]interface{}{
+				"priority-fee": "1.2.3",
+			},
+			err: "invalid priority fee: invalid format",
+		},
+		{
+			name: "PriorityFeeTooHigh",
+			vars: map[string]interface{}{
+				"max-fee":      "1gwei",
+				"priority-fee": "2gwei",
+			},
+			err: "priority fee cannot be greater than max fee",
+		},
+		{
+			name: "NonceInvalid",
+			vars: map[string]interface{}{
+				"nonce": "-1",
+			},
+			err: `invalid nonce: strconv.ParseUint: parsing "-1": invalid syntax`,
+		},
+		{
+			name: "Good",
+			vars: map[string]interface{}{
+				"max-fee":      "20gwei",
+				"priority-fee": "1000000000",
+				"nonce":        "10",
+			},
+			opts: &util.ExecutionTransactionOpts{
+				MaxFeePerGas:         big.NewInt(20000000000),
+				MaxPriorityFeePerGas: big.NewInt(1000000000),
+				Nonce:                &nonce,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			opts, err := util.ObtainExecutionTransactionOpts()
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.opts, opts)
+			}
+		})
+	}
+}
+
+func TestExecutionTransactionBuilder(t *testing.T) {
+	ctx := context.Background()
+
+	key, err := util.ParseExecutionKey("0x4646464646464646464646464646464646464646464646464646464646464646")
+	require.NoError(t, err)
+	to, err := util.ParseExecutionAddress("0x00000000219ab540356cBB839Cbe05303d7705Fa")
+	require.NoError(t, err)
+	nonce := uint64(10)
+
+	tests := []struct {
+		name  string
+		opts  *util.ExecutionTransactionOpts
+		nonce uint64
+		// cost is the maximum cost of a transaction, given 60000 gas.
+		cost *big.Int
+		// queried are the methods that the builder is expected to call.
+		queried []string
+	}{
+		{
+			name:    "Defaults",
+			nonce:   5,
+			cost:    big.NewInt(60000 * 3000000000),
+			queried: []string{"eth_chainId", "eth_getTransactionCount", "eth_maxPriorityFeePerGas", "eth_getBlockByNumber", "eth_estimateGas"},
+		},
+		{
+			name: "Overrides",
+			opts: &util.ExecutionTransactionOpts{
+				MaxFeePerGas:         big.NewInt(5000000000),
+				MaxPriorityFeePerGas: big.NewInt(2000000000),
+				Nonce:                &nonce,
+			},
+			nonce:   10,
+			cost:    big.NewInt(60000 * 5000000000),
+			queried: []string{"eth_chainId", "eth_estimateGas"},
+		},
+		{
+			name: "MaxFeeOnly",
+			opts: &util.ExecutionTransactionOpts{
+				MaxFeePerGas: big.NewInt(500000000),
+			},
+			nonce:   5,
+			cost:    big.NewInt(60000 * 500000000),
+			queried: []string{"eth_chainId", "eth_getTransactionCount", "eth_maxPriorityFeePerGas", "eth_estimateGas"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			queried := make([]string, 0)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					ID     uint64 `json:"id"`
+					Method string `json:"method"`
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				queried = append(queried, req.Method)
+				results := map[string]any{
+					"eth_chainId":              "0x1",
+					"eth_getTransactionCount":  "0x5",
+					"eth_getBlockByNumber":     map[string]string{"baseFeePerGas": "0x3b9aca00"},
+					"eth_maxPriorityFeePerGas": "0x3b9aca00",
+					"eth_estimateGas":          "0xc350",
+				}
+				data, err := json.Marshal(map[string]any{
+					"jsonrpc": "2.0",
+					"id":      req.ID,
+					"result":  results[req.Method],
+				})
+				require.NoError(t, err)
+				_, _ = w.Write(data)
+			}))
+			defer server.Close()
+			client, err := util.NewExecutionClient(server.URL)
+			require.NoError(t, err)
+
+			builder, err := util.NewExecutionTransactionBuilder(ctx, client, key, test.opts)
+			require.NoError(t, err)
+			require.Equal(t, uint64(1), builder.ChainID())
+
+			tx, err := builder.Build(ctx, to, big.NewInt(0), nil)
+			require.NoError(t, err)
+			require.Equal(t, test.nonce, tx.Nonce)
+			require.Equal(t, test.cost, tx.Cost)
+			require.Equal(t, util.ExecutionTransactionHash(tx.Data), tx.Hash)
+			require.Equal(t, test.queried, queried)
+
+			// Subsequent transactions use the following nonce.
+			tx, err = builder.Build(ctx, to, big.NewInt(0), nil)
+			require.NoError(t, err)
+			require.Equal(t, test.nonce+1, tx.Nonce)
+		})
+	}
+}
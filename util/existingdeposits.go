@@ -0,0 +1,246 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+const (
+	// depositLogBlocks is the number of recent blocks whose deposit contract
+	// logs are searched, covering the time for which a deposit can be in the
+	// contract without being visible on the beacon chain.
+	depositLogBlocks = 65536
+	// depositLogBatchBlocks is the number of blocks searched with each request,
+	// to stay within the limits that execution nodes place on log requests.
+	depositLogBatchBlocks = 8192
+)
+
+// ExistingDepositsOpts are options for ExistingDeposits.
+type ExistingDepositsOpts struct {
+	// ExecutionClient is a connection to an execution node, used to search
+	// the logs of the deposit contract.  If nil, logs are not searched.
+	ExecutionClient *ExecutionClient
+	// DepositContract is the address of the deposit contract.  If not
+	// supplied, it is obtained from the beacon node.
+	DepositContract bellatrix.ExecutionAddress
+}
+
+// ExistingDeposits finds deposits already made for the given public keys,
+// returning a description of where each was found.  The validator registry
+// is checked, along with the pending deposits of the beacon state and, if an
+// execution client is supplied, the recent logs of the deposit contract, as
+// a deposit can be pending or in the contract for some time before its
+// validator appears in the registry.
+func ExistingDeposits(ctx context.Context,
+	eth2Client eth2client.Service,
+	pubKeys []phase0.BLSPubKey,
+	opts *ExistingDepositsOpts,
+) (
+	map[phase0.BLSPubKey]string,
+	error,
+) {
+	if opts == nil {
+		opts = &ExistingDepositsOpts{}
+	}
+	res := make(map[phase0.BLSPubKey]string)
+	wanted := make(map[phase0.BLSPubKey]bool, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		wanted[pubKey] = true
+	}
+
+	validatorsProvider, isProvider := eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return nil, errors.New("beacon node does not provide validator information")
+	}
+	validators, err := FetchValidators(ctx, validatorsProvider, "head", nil, pubKeys)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain existing validators")
+	}
+	for _, validator := range validators {
+		res[validator.Validator.PublicKey] = fmt.Sprintf("index %d", validator.Index)
+	}
+
+	pendingDeposits, err := pendingDepositPubKeys(ctx, eth2Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain pending deposits")
+	}
+	for _, pubKey := range pendingDeposits {
+		if _, exists := res[pubKey]; !exists && wanted[pubKey] {
+			res[pubKey] = "pending deposit"
+		}
+	}
+
+	if opts.ExecutionClient != nil {
+		depositContract := opts.DepositContract
+		if depositContract == (bellatrix.ExecutionAddress{}) {
+			depositContract, err = DepositContractAddress(ctx, eth2Client)
+			if err != nil {
+				return nil, err
+			}
+		}
+		logs, err := recentDepositLogs(ctx, opts.ExecutionClient, depositContract)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain deposit contract logs")
+		}
+		for _, log := range logs {
+			if _, exists := res[log.PubKey]; !exists && wanted[log.PubKey] {
+				res[log.PubKey] = fmt.Sprintf("deposit contract block %d", log.BlockNumber)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// DescribeExistingDeposits returns a sorted description of existing deposits.
+func DescribeExistingDeposits(existing map[phase0.BLSPubKey]string) string {
+	descriptions := make([]string, 0, len(existing))
+	for pubKey, description := range existing {
+		descriptions = append(descriptions, fmt.Sprintf("%#x (%s)", pubKey, description))
+	}
+	sort.Strings(descriptions)
+
+	return strings.Join(descriptions, ", ")
+}
+
+// CheckExistingDeposits returns an error if deposits already exist for any of
+// the public keys, as a second deposit would top up the existing validator
+// rather than create a new one.  If force is set a warning is printed instead.
+func CheckExistingDeposits(ctx context.Context,
+	eth2Client eth2client.Service,
+	pubKeys []phase0.BLSPubKey,
+	opts *ExistingDepositsOpts,
+	force bool,
+	quiet bool,
+) error {
+	existing, err := ExistingDeposits(ctx, eth2Client, pubKeys, opts)
+	if err != nil {
+		return err
+	}
+	if len(existing) == 0 {
+		return nil
+	}
+
+	if !force {
+		return fmt.Errorf("deposits already exist for %s; use --force to continue regardless", DescribeExistingDeposits(existing))
+	}
+	if !quiet {
+		fmt.Fprintf(os.Stderr, "Warning: deposits already exist for %s; further deposits will increase their balance rather than create new validators\n", DescribeExistingDeposits(existing))
+	}
+
+	return nil
+}
+
+// DepositContractAddress obtains the address of the deposit contract from the beacon node.
+func DepositContractAddress(ctx context.Context, eth2Client eth2client.Service) (bellatrix.ExecutionAddress, error) {
+	specProvider, isProvider := eth2Client.(eth2client.SpecProvider)
+	if !isProvider {
+		return bellatrix.ExecutionAddress{}, errors.New("connection does not provide spec information")
+	}
+	specResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return bellatrix.ExecutionAddress{}, errors.Wrap(err, "failed to obtain spec")
+	}
+	addressBytes, isBytes := specResponse.Data["DEPOSIT_CONTRACT_ADDRESS"].([]byte)
+	if !isBytes || len(addressBytes) != bellatrix.ExecutionAddressLength {
+		return bellatrix.ExecutionAddress{}, errors.New("DEPOSIT_CONTRACT_ADDRESS missing or invalid")
+	}
+	var res bellatrix.ExecutionAddress
+	copy(res[:], addressBytes)
+
+	return res, nil
+}
+
+// pendingDepositPubKeys obtains the public keys of the pending deposits in
+// the head state, using the pending deposits endpoint if the beacon node
+// supports it and otherwise decoding the state.
+func pendingDepositPubKeys(ctx context.Context, eth2Client eth2client.Service) ([]phase0.BLSPubKey, error) {
+	body, err := BeaconNodeGet(ctx, eth2Client, "/eth/v1/beacon/states/head/pending_deposits", "application/json")
+	if err == nil {
+		defer body.Close()
+		var response struct {
+			Data []*struct {
+				PubKey phase0.BLSPubKey `json:"pubkey"`
+			} `json:"data"`
+		}
+		if err := json.NewDecoder(body).Decode(&response); err != nil {
+			return nil, errors.Wrap(err, "failed to decode pending deposits")
+		}
+		res := make([]phase0.BLSPubKey, 0, len(response.Data))
+		for _, deposit := range response.Data {
+			res = append(res, deposit.PubKey)
+		}
+
+		return res, nil
+	}
+
+	res := make([]phase0.BLSPubKey, 0)
+	err = StreamBeaconState(ctx, eth2Client, "head", &StateStreamHandler{
+		PendingDeposit: func(_ uint64, deposit *PendingDeposit) error {
+			res = append(res, deposit.PubKey)
+			return nil
+		},
+	})
+	if errors.Is(err, ErrNotElectraState) {
+		// Deposits are not pending before Electra.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// recentDepositLogs obtains the deposit logs of recent blocks.
+func recentDepositLogs(ctx context.Context,
+	executionClient *ExecutionClient,
+	depositContract bellatrix.ExecutionAddress,
+) (
+	[]*DepositLog,
+	error,
+) {
+	latest, err := executionClient.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	from := uint64(0)
+	if latest > depositLogBlocks {
+		from = latest - depositLogBlocks
+	}
+
+	res := make([]*DepositLog, 0)
+	for start := from; start <= latest; start += depositLogBatchBlocks {
+		end := min(start+depositLogBatchBlocks-1, latest)
+		logs, err := executionClient.DepositLogs(ctx, depositContract, start, end)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, logs...)
+	}
+
+	return res, nil
+}
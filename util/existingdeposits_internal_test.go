@@ -0,0 +1,155 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testing/mock"
+)
+
+// depositLogData returns the data of a deposit event for the public key.
+func depositLogData(pubKey phase0.BLSPubKey) string {
+	data := make([]byte, 5*32)
+	binary.BigEndian.PutUint64(data[24:32], 5*32)
+	data = append(data, abiBytes(pubKey[:])...)
+
+	return "0x" + hex.EncodeToString(data)
+}
+
+func TestExistingDeposits(t *testing.T) {
+	ctx := context.Background()
+
+	registered := phase0.BLSPubKey{0x01}
+	pending := phase0.BLSPubKey{0x02}
+	logged := phase0.BLSPubKey{0x03}
+	unknown := phase0.BLSPubKey{0x04}
+
+	beaconNode := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/eth/v1/beacon/states/head/pending_deposits", r.URL.Path)
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"data":[{"pubkey":"%#x","amount":"32000000000"},{"pubkey":"%#x","amount":"1000000000"}]}`, pending, registered)))
+	}))
+	defer beaconNode.Close()
+	service := mock.NewValidatorsService(beaconNode.URL, map[phase0.BLSPubKey]phase0.ValidatorIndex{registered: 5})
+
+	logRequests := 0
+	executionNode := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     uint64 `json:"id"`
+			Method string `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		var result any
+		switch req.Method {
+		case "eth_blockNumber":
+			result = "0x14000"
+		case "eth_getLogs":
+			logRequests++
+			result = []map[string]string{}
+			if logRequests == 1 {
+				result = []map[string]string{{"blockNumber": "0x4100", "data": depositLogData(logged)}}
+			}
+		}
+		data, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result})
+		require.NoError(t, err)
+		_, _ = w.Write(data)
+	}))
+	defer executionNode.Close()
+	executionClient, err := NewExecutionClient(executionNode.URL)
+	require.NoError(t, err)
+
+	pubKeys := []phase0.BLSPubKey{registered, pending, logged, unknown}
+
+	existing, err := ExistingDeposits(ctx, service, pubKeys, nil)
+	require.NoError(t, err)
+	require.Equal(t, map[phase0.BLSPubKey]string{
+		registered: "index 5",
+		pending:    "pending deposit",
+	}, existing)
+
+	existing, err = ExistingDeposits(ctx, service, pubKeys, &ExistingDepositsOpts{
+		ExecutionClient: executionClient,
+		DepositContract: bellatrix.ExecutionAddress{0x01},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[phase0.BLSPubKey]string{
+		registered: "index 5",
+		pending:    "pending deposit",
+		logged:     "deposit contract block 16640",
+	}, existing)
+	// 65536 blocks searched in batches of 8192, plus the latest block.
+	require.Equal(t, 9, logRequests)
+
+	require.Equal(t, fmt.Sprintf("%#x (index 5), %#x (pending deposit)", registered, pending), DescribeExistingDeposits(map[phase0.BLSPubKey]string{
+		pending:    "pending deposit",
+		registered: "index 5",
+	}))
+}
+
+func TestCheckExistingDeposits(t *testing.T) {
+	ctx := context.Background()
+
+	registered := phase0.BLSPubKey{0x01}
+	pending := phase0.BLSPubKey{0x02}
+	unknown := phase0.BLSPubKey{0x04}
+
+	beaconNode := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"data":[{"pubkey":"%#x","amount":"32000000000"}]}`, pending)))
+	}))
+	defer beaconNode.Close()
+	service := mock.NewValidatorsService(beaconNode.URL, map[phase0.BLSPubKey]phase0.ValidatorIndex{registered: 5})
+
+	tests := []struct {
+		name    string
+		pubKeys []phase0.BLSPubKey
+		force   bool
+		err     string
+	}{
+		{
+			name:    "NotDeposited",
+			pubKeys: []phase0.BLSPubKey{unknown},
+		},
+		{
+			name:    "Deposited",
+			pubKeys: []phase0.BLSPubKey{unknown, pending, registered},
+			err:     fmt.Sprintf("deposits already exist for %#x (index 5), %#x (pending deposit); use --force to continue regardless", registered, pending),
+		},
+		{
+			name:    "DepositedForce",
+			pubKeys: []phase0.BLSPubKey{unknown, pending, registered},
+			force:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := CheckExistingDeposits(ctx, service, test.pubKeys, nil, test.force, true)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright © 2024 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/multi"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// failoverService is a multi-node service that also provides failover for
+// the functions that the underlying multi-node service does not support.
+type failoverService struct {
+	*multi.Service
+
+	clients []eth2client.Service
+}
+
+func newFailoverService(service eth2client.Service, clients []eth2client.Service) (*failoverService, error) {
+	multiService, isMultiService := service.(*multi.Service)
+	if !isMultiService {
+		return nil, errors.New("service is not a multi-node service")
+	}
+
+	return &failoverService{
+		Service: multiService,
+		clients: clients,
+	}, nil
+}
+
+// failover calls the supplied function against each active client in turn,
+// returning the result from the first client that succeeds.
+func failover[T any, R any](s *failoverService, fn func(provider T) (R, error)) (R, error) {
+	var res R
+	err := errors.New("no active beacon node supports the request")
+	for _, client := range s.clients {
+		if !client.IsActive() {
+			continue
+		}
+		provider, isProvider := client.(T)
+		if !isProvider {
+			continue
+		}
+		res, err = fn(provider)
+		if err == nil {
+			return res, nil
+		}
+	}
+
+	return res, err
+}
+
+// SubmitBLSToExecutionChanges submits BLS to execution address change operations.
+func (s *failoverService) SubmitBLSToExecutionChanges(ctx context.Context, blsToExecutionChanges []*capella.SignedBLSToExecutionChange) error {
+	_, err := failover(s, func(submitter eth2client.BLSToExecutionChangesSubmitter) (bool, error) {
+		return true, submitter.SubmitBLSToExecutionChanges(ctx, blsToExecutionChanges)
+	})
+
+	return err
+}
+
+// SubmitAttesterSlashing submits an attester slashing.
+func (s *failoverService) SubmitAttesterSlashing(ctx context.Context, slashing *phase0.AttesterSlashing) error {
+	_, err := failover(s, func(submitter eth2client.AttesterSlashingSubmitter) (bool, error) {
+		return true, submitter.SubmitAttesterSlashing(ctx, slashing)
+	})
+
+	return err
+}
+
+// SubmitProposalSlashing submits a proposal slashing.
+func (s *failoverService) SubmitProposalSlashing(ctx context.Context, slashing *phase0.ProposerSlashing) error {
+	_, err := failover(s, func(submitter eth2client.ProposalSlashingSubmitter) (bool, error) {
+		return true, submitter.SubmitProposalSlashing(ctx, slashing)
+	})
+
+	return err
+}
+
+// NodeClient returns the client for the node.
+func (s *failoverService) NodeClient(ctx context.Context) (*api.Response[string], error) {
+	return failover(s, func(provider eth2client.NodeClientProvider) (*api.Response[string], error) {
+		return provider.NodeClient(ctx)
+	})
+}
+
+// BeaconStateRandao provides the randao of a given state.
+func (s *failoverService) BeaconStateRandao(ctx context.Context, opts *api.BeaconStateRandaoOpts) (*api.Response[*phase0.Root], error) {
+	return failover(s, func(provider eth2client.BeaconStateRandaoProvider) (*api.Response[*phase0.Root], error) {
+		return provider.BeaconStateRandao(ctx, opts)
+	})
+}
@@ -0,0 +1,185 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// gcpMetadataTokenURL is the URL from which the metadata server of a Google
+// Cloud instance provides access tokens for the instance's service account.
+var gcpMetadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+
+// gcpAccessToken obtains the token with which to access Google Cloud, either
+// from the configuration or from the metadata server of the instance.
+func gcpAccessToken(ctx context.Context) (string, error) {
+	if token := viper.GetString("gcp.access-token"); token != "" {
+		return token, nil
+	}
+	if token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+		return token, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gcpMetadataTokenURL, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	var res struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := gcpDo(req, &res); err != nil {
+		return "", errors.Wrap(err, "no Google Cloud credentials supplied; set gcp.access-token or GOOGLE_OAUTH_ACCESS_TOKEN, or run on a Google Cloud instance")
+	}
+	if res.AccessToken == "" {
+		return "", errors.New("metadata server did not return an access token")
+	}
+
+	return res.AccessToken, nil
+}
+
+// gcpRequest makes an authenticated request to a Google Cloud API.
+func gcpRequest(ctx context.Context, method string, url string, contentType string, body []byte) (*http.Request, error) {
+	token, err := gcpAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	return req, nil
+}
+
+// gcpDo carries out a request to a Google Cloud API.  If res is a byte
+// slice the body of the response is returned as-is, otherwise it is decoded
+// as JSON into res.
+func gcpDo(req *http.Request, res any) error {
+	resp, err := HTTPClient().Do(req)
+	if err != nil {
+		return errors.Wrap(err, "request failed")
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response")
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return errBlobNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		var errRes struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(data, &errRes) == nil && errRes.Error.Message != "" {
+			return fmt.Errorf("status code %d: %s", resp.StatusCode, errRes.Error.Message)
+		}
+		return fmt.Errorf("status code %d", resp.StatusCode)
+	}
+
+	if out, isBytes := res.(*[]byte); isBytes {
+		*out = data
+		return nil
+	}
+	if err := json.Unmarshal(data, res); err != nil {
+		return errors.Wrap(err, "failed to parse response")
+	}
+
+	return nil
+}
+
+// resolveGCPSecretManagerSecret resolves a secret held in Google Cloud
+// Secret Manager.  The reference is the name of the secret, either in full
+// as "projects/<project>/secrets/<secret>[/versions/<version>]" or in short
+// as "<project>/<secret>[/<version>]".  The version defaults to "latest".
+func resolveGCPSecretManagerSecret(ctx context.Context, reference string) (string, error) {
+	components := strings.Split(strings.Trim(reference, "/"), "/")
+	if len(components) > 0 && components[0] == "projects" {
+		// Full name; remove the collection identifiers.
+		if (len(components) != 4 && len(components) != 6) ||
+			components[2] != "secrets" ||
+			(len(components) == 6 && components[4] != "versions") {
+			return "", fmt.Errorf("invalid Secret Manager secret name %s", reference)
+		}
+		short := []string{components[1], components[3]}
+		if len(components) == 6 {
+			short = append(short, components[5])
+		}
+		components = short
+	}
+	if len(components) < 2 || len(components) > 3 {
+		return "", fmt.Errorf("invalid Secret Manager secret name %s; expected <project>/<secret>[/<version>]", reference)
+	}
+	for _, component := range components {
+		if component == "" {
+			return "", fmt.Errorf("invalid Secret Manager secret name %s", reference)
+		}
+	}
+	version := "latest"
+	if len(components) == 3 {
+		version = components[2]
+	}
+
+	endpoint := strings.TrimSuffix(viper.GetString("gcp.secret-manager-endpoint"), "/")
+	if endpoint == "" {
+		endpoint = "https://secretmanager.googleapis.com"
+	}
+	name := fmt.Sprintf("projects/%s/secrets/%s/versions/%s", components[0], components[1], version)
+
+	req, err := gcpRequest(ctx, http.MethodGet, fmt.Sprintf("%s/v1/%s:access", endpoint, name), "", nil)
+	if err != nil {
+		return "", err
+	}
+	var res struct {
+		Payload struct {
+			Data string `json:"data"`
+		} `json:"payload"`
+	}
+	if err := gcpDo(req, &res); err != nil {
+		if errors.Is(err, errBlobNotFound) {
+			return "", fmt.Errorf("secret %s not found in Secret Manager", name)
+		}
+		return "", errors.Wrapf(err, "failed to obtain Secret Manager secret %s", name)
+	}
+	secret, err := base64.StdEncoding.DecodeString(res.Payload.Data)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid payload for Secret Manager secret %s", name)
+	}
+
+	return string(secret), nil
+}
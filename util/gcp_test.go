@@ -0,0 +1,212 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// newGCPServer creates a server that behaves as Google Cloud Secret Manager
+// and Google Cloud Storage.
+func newGCPServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mu := sync.Mutex{}
+	objects := make(map[string][]byte)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"code":401,"message":"Request had invalid authentication credentials."}}`))
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.URL.Path == "/v1/projects/ethdo/secrets/wallet1/versions/latest:access":
+			_, _ = w.Write([]byte(`{"payload":{"data":"` + base64.StdEncoding.EncodeToString([]byte("wallet1 secret")) + `"}}`))
+		case r.URL.Path == "/v1/projects/ethdo/secrets/wallet1/versions/1:access":
+			_, _ = w.Write([]byte(`{"payload":{"data":"` + base64.StdEncoding.EncodeToString([]byte("wallet1 old secret")) + `"}}`))
+		case r.URL.Path == "/upload/storage/v1/b/wallets/o" && r.Method == http.MethodPost:
+			data, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			objects[r.URL.Query().Get("name")] = data
+			_, _ = w.Write([]byte(`{}`))
+		case r.URL.Path == "/storage/v1/b/wallets/o":
+			names := make([]string, 0)
+			for name := range objects {
+				if strings.HasPrefix(name, r.URL.Query().Get("prefix")) {
+					names = append(names, `{"name":"`+name+`"}`)
+				}
+			}
+			sort.Strings(names)
+			_, _ = w.Write([]byte(`{"items":[` + strings.Join(names, ",") + `]}`))
+		case strings.HasPrefix(r.URL.Path, "/storage/v1/b/wallets/o/"):
+			data, exists := objects[strings.TrimPrefix(r.URL.Path, "/storage/v1/b/wallets/o/")]
+			if !exists {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(data)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":404,"message":"Not found."}}`))
+		}
+	}))
+}
+
+func TestResolveGCPSecret(t *testing.T) {
+	server := newGCPServer(t)
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		value  string
+		token  string
+		secret string
+		err    string
+	}{
+		{
+			name:   "Short",
+			value:  "gcp-sm:ethdo/wallet1",
+			token:  "token",
+			secret: "wallet1 secret",
+		},
+		{
+			name:   "ShortVersion",
+			value:  "gcp-sm:ethdo/wallet1/1",
+			token:  "token",
+			secret: "wallet1 old secret",
+		},
+		{
+			name:   "Full",
+			value:  "gcp-sm:projects/ethdo/secrets/wallet1",
+			token:  "token",
+			secret: "wallet1 secret",
+		},
+		{
+			name:   "FullVersion",
+			value:  "gcp-sm:projects/ethdo/secrets/wallet1/versions/1",
+			token:  "token",
+			secret: "wallet1 old secret",
+		},
+		{
+			name:  "Invalid",
+			value: "gcp-sm:wallet1",
+			token: "token",
+			err:   "invalid Secret Manager secret name wallet1; expected <project>/<secret>[/<version>]",
+		},
+		{
+			name:  "InvalidFull",
+			value: "gcp-sm:projects/ethdo/keys/wallet1",
+			token: "token",
+			err:   "invalid Secret Manager secret name projects/ethdo/keys/wallet1",
+		},
+		{
+			name:  "Missing",
+			value: "gcp-sm:ethdo/missing",
+			token: "token",
+			err:   "secret projects/ethdo/secrets/missing/versions/latest not found in Secret Manager",
+		},
+		{
+			name:  "BadToken",
+			value: "gcp-sm:ethdo/wallet1",
+			token: "bad",
+			err:   "failed to obtain Secret Manager secret projects/ethdo/secrets/wallet1/versions/latest: status code 401: Request had invalid authentication credentials.",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			t.Cleanup(viper.Reset)
+			viper.Set("gcp.secret-manager-endpoint", server.URL)
+			viper.Set("gcp.access-token", test.token)
+			secret, err := util.ResolveSecret(context.Background(), test.value)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.secret, secret)
+		})
+	}
+}
+
+func TestGCSStore(t *testing.T) {
+	server := newGCPServer(t)
+	defer server.Close()
+
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	_, err := util.NewStore("gcs", "", "")
+	require.EqualError(t, err, "failed to access Google Cloud Storage wallet store: no Google Cloud Storage bucket supplied; set stores.gcs.bucket")
+
+	viper.Set("stores.gcs.bucket", "wallets")
+	viper.Set("stores.gcs.path", "ethdo")
+	viper.Set("stores.gcs.endpoint", server.URL)
+	t.Setenv("GOOGLE_OAUTH_ACCESS_TOKEN", "token")
+
+	_, err = util.NewStore("gcs", "/tmp", "")
+	require.EqualError(t, err, "basedir does not apply to the gcs store")
+
+	store, err := util.NewStore("gcs", "", "store secret")
+	require.NoError(t, err)
+	require.Equal(t, "gcs", store.Name())
+
+	walletID := uuid.New()
+	walletData := []byte(`{"uuid":"` + walletID.String() + `","name":"Test wallet","type":"non-deterministic","version":1}`)
+	require.NoError(t, store.StoreWallet(walletID, "Test wallet", walletData))
+	data, err := store.RetrieveWalletByID(walletID)
+	require.NoError(t, err)
+	require.Equal(t, walletData, data)
+	data, err = store.RetrieveWallet("Test wallet")
+	require.NoError(t, err)
+	require.Equal(t, walletData, data)
+	_, err = store.RetrieveWalletByID(uuid.New())
+	require.EqualError(t, err, "wallet not found")
+
+	accountID := uuid.New()
+	accountData := []byte(`{"uuid":"` + accountID.String() + `","name":"Test account"}`)
+	require.NoError(t, store.StoreAccount(walletID, accountID, accountData))
+	data, err = store.RetrieveAccount(walletID, accountID)
+	require.NoError(t, err)
+	require.Equal(t, accountData, data)
+	accounts := make([][]byte, 0)
+	for account := range store.RetrieveAccounts(walletID) {
+		accounts = append(accounts, account)
+	}
+	require.Equal(t, [][]byte{accountData}, accounts)
+
+	// Ensure that the data cannot be read without the passphrase.
+	plainStore, err := util.NewStore("gcs", "", "")
+	require.NoError(t, err)
+	data, err = plainStore.RetrieveWalletByID(walletID)
+	require.NoError(t, err)
+	require.NotEqual(t, walletData, data)
+}
@@ -0,0 +1,137 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// gcsBackend holds blobs as objects in a Google Cloud Storage bucket, using
+// the JSON API.
+type gcsBackend struct {
+	endpoint string
+	bucket   string
+}
+
+// newGCSStore creates a wallet store held in Google Cloud Storage, using the
+// stores.gcs options from the configuration.
+func newGCSStore(passphrase string) (e2wtypes.Store, error) {
+	bucket := viper.GetString("stores.gcs.bucket")
+	if bucket == "" {
+		return nil, errors.New("no Google Cloud Storage bucket supplied; set stores.gcs.bucket")
+	}
+	endpoint := strings.TrimSuffix(viper.GetString("stores.gcs.endpoint"), "/")
+	if endpoint == "" {
+		endpoint = "https://storage.googleapis.com"
+	}
+	path := strings.Trim(viper.GetString("stores.gcs.path"), "/")
+
+	location := fmt.Sprintf("gs://%s", bucket)
+	if path != "" {
+		location = fmt.Sprintf("%s/%s", location, path)
+	}
+
+	return newBlobStore("gcs",
+		location,
+		&gcsBackend{
+			endpoint: endpoint,
+			bucket:   bucket,
+		},
+		path,
+		[]byte(passphrase),
+		viper.GetDuration("timeout"),
+	), nil
+}
+
+// Get obtains the object with the given name.
+func (b *gcsBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	req, err := gcpRequest(ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/storage/v1/b/%s/o/%s?alt=media", b.endpoint, url.PathEscape(b.bucket), url.PathEscape(key)),
+		"",
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	if err := gcpDo(req, &data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Put stores the object with the given name.
+func (b *gcsBackend) Put(ctx context.Context, key string, data []byte) error {
+	req, err := gcpRequest(ctx,
+		http.MethodPost,
+		fmt.Sprintf("%s/upload/storage/v1/b/%s/o?uploadType=media&name=%s", b.endpoint, url.PathEscape(b.bucket), url.QueryEscape(key)),
+		"application/octet-stream",
+		data,
+	)
+	if err != nil {
+		return err
+	}
+	res := make(map[string]any)
+
+	return gcpDo(req, &res)
+}
+
+// List lists the names of the objects with the given prefix.
+func (b *gcsBackend) List(ctx context.Context, prefix string) ([]string, error) {
+	keys := make([]string, 0)
+	pageToken := ""
+	for {
+		query := url.Values{}
+		query.Set("prefix", prefix)
+		query.Set("fields", "items/name,nextPageToken")
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+		req, err := gcpRequest(ctx,
+			http.MethodGet,
+			fmt.Sprintf("%s/storage/v1/b/%s/o?%s", b.endpoint, url.PathEscape(b.bucket), query.Encode()),
+			"",
+			nil,
+		)
+		if err != nil {
+			return nil, err
+		}
+		var res struct {
+			Items []struct {
+				Name string `json:"name"`
+			} `json:"items"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		if err := gcpDo(req, &res); err != nil {
+			return nil, err
+		}
+		for _, item := range res.Items {
+			keys = append(keys, item.Name)
+		}
+		if res.NextPageToken == "" {
+			return keys, nil
+		}
+		pageToken = res.NextPageToken
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+)
+
+// Consensus and execution client values come from
+// https://github.com/ethereum/execution-apis/blob/main/src/engine/identification.md
+var (
+	graffitiConsensusClients = map[string]string{
+		"GR": "grandine",
+		"LH": "lighthouse",
+		"LS": "lodestar",
+		"NB": "nimbus",
+		"PM": "prysm",
+		"TK": "teku",
+	}
+	graffitiConsensusRegex = regexp.MustCompile(`(GR|LH|LS|NB|PM|TK)([0-9a-f]*)`)
+
+	graffitiExecutionClients = map[string]string{
+		"BU": "besu",
+		"EG": "erigon",
+		"EJ": "ethereumJS",
+		"GE": "go-ethereum",
+		"NM": "nethermind",
+		"RH": "reth",
+	}
+	graffitiExecutionRegex = regexp.MustCompile(`(BU|EG|EJ|GE|NM|RH)([0-9a-f]*)`)
+)
+
+// Graffiti is graffiti from a block, decoded in to its custom text and any
+// client identification information.
+type Graffiti struct {
+	// Text is the custom text of the graffiti, with any client identification
+	// removed.  If the graffiti is not valid UTF-8 this is the hex value.
+	Text string
+	// ConsensusClient is the consensus client identified in the graffiti.
+	ConsensusClient string
+	// ConsensusVersion is the version hash of the consensus client.
+	ConsensusVersion string
+	// ExecutionClient is the execution client identified in the graffiti.
+	ExecutionClient string
+	// ExecutionVersion is the version hash of the execution client.
+	ExecutionVersion string
+}
+
+// DecodeGraffiti decodes block graffiti.  It returns nil if the graffiti is
+// empty.
+func DecodeGraffiti(graffiti []byte) *Graffiti {
+	// Remove any trailing null characters.
+	graffiti = bytes.TrimRight(graffiti, "\u0000")
+	if len(graffiti) == 0 {
+		// No graffiti.
+		return nil
+	}
+
+	if !utf8.Valid(graffiti) {
+		// Graffiti is not valid UTF-8, return hex.
+		return &Graffiti{
+			Text: fmt.Sprintf("%#x", graffiti),
+		}
+	}
+
+	// See if there is client identification information present in the graffiti.
+	// The client identification will always be the last entry in the graffiti, with a space beforehand.
+	parts := bytes.Split(graffiti, []byte{' '})
+	consensusData := graffitiConsensusRegex.Find(parts[len(parts)-1])
+	executionData := graffitiExecutionRegex.Find(parts[len(parts)-1])
+	if len(consensusData) == 0 && len(executionData) == 0 {
+		// There is no identifier; return the graffiti as-is.
+		return &Graffiti{
+			Text: string(graffiti),
+		}
+	}
+
+	res := &Graffiti{
+		Text: string(bytes.Join(parts[0:len(parts)-1], []byte(" "))),
+	}
+	if len(consensusData) > 0 {
+		res.ConsensusClient = graffitiConsensusClients[string(consensusData[0:2])]
+		res.ConsensusVersion = string(consensusData[2:])
+	}
+	if len(executionData) > 0 {
+		res.ExecutionClient = graffitiExecutionClients[string(executionData[0:2])]
+		res.ExecutionVersion = string(executionData[2:])
+	}
+
+	return res
+}
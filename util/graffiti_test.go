@@ -0,0 +1,71 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestDecodeGraffiti(t *testing.T) {
+	tests := []struct {
+		name     string
+		graffiti []byte
+		res      *util.Graffiti
+	}{
+		{
+			name:     "Empty",
+			graffiti: make([]byte, 32),
+		},
+		{
+			name:     "NoID",
+			graffiti: []byte("No identifier\u0000\u0000"),
+			res:      &util.Graffiti{Text: "No identifier"},
+		},
+		{
+			name:     "NotUTF8",
+			graffiti: []byte{0xff, 0xfe},
+			res:      &util.Graffiti{Text: "0xfffe"},
+		},
+		{
+			name:     "SingleClient",
+			graffiti: []byte("Graffiti TK"),
+			res:      &util.Graffiti{Text: "Graffiti", ConsensusClient: "teku"},
+		},
+		{
+			name:     "DualClientsTruncatedHash",
+			graffiti: []byte("Freedom To Transact TKa9f9NM220b"),
+			res: &util.Graffiti{
+				Text:             "Freedom To Transact",
+				ConsensusClient:  "teku",
+				ConsensusVersion: "a9f9",
+				ExecutionClient:  "nethermind",
+				ExecutionVersion: "220b",
+			},
+		},
+		{
+			name:     "DualClientsOnly",
+			graffiti: []byte("GELH"),
+			res:      &util.Graffiti{ConsensusClient: "lighthouse", ExecutionClient: "go-ethereum"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, util.DecodeGraffiti(test.graffiti))
+		})
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// defaultMaxRetries is the number of times a failed request is retried if not otherwise specified.
+	defaultMaxRetries = 3
+	// retryBaseDelay is the delay before the first retry; subsequent retries double the delay.
+	retryBaseDelay = 250 * time.Millisecond
+	// retryMaxDelay is the maximum delay between retries.
+	retryMaxDelay = 10 * time.Second
+)
+
+var (
+	httpClientOnce sync.Once
+	httpClient     *http.Client
+)
+
+// retryableKey is the context key marking requests as safe to retry.
+type retryableKey struct{}
+
+// WithRetries returns a context whose requests are retried on transient
+// failure regardless of their method.  By default only GET and HEAD requests
+// are retried, as resending other requests could repeat their effect; this
+// should be used only for requests that are safe to resend, such as POSTs
+// that read rather than submit data.
+func WithRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryableKey{}, true)
+}
+
+// HTTPClient returns the HTTP client shared by all beacon node connections.
+// The client pools connections, limits the rate of requests if required,
+// and retries idempotent requests that fail with transient errors.
+func HTTPClient() *http.Client {
+	httpClientOnce.Do(func() {
+		maxRetries := defaultMaxRetries
+		if viper.IsSet("max-retries") {
+			maxRetries = viper.GetInt("max-retries")
+		}
+		var transport http.RoundTripper = &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          64,
+			MaxIdleConnsPerHost:   16,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: time.Second,
+		}
+		if Offline() {
+			// Belt and braces; no requests should reach here in offline mode.
+			transport = &offlineTransport{}
+		}
+		if viper.GetFloat64("rate-limit") > 0 {
+			transport = newRateLimitedTransport(transport, viper.GetFloat64("rate-limit"))
+		}
+		httpClient = &http.Client{
+			Transport: &retryTransport{
+				next:       transport,
+				maxRetries: maxRetries,
+			},
+		}
+	})
+
+	return httpClient
+}
+
+// retryTransport retries requests that fail with transient errors,
+// backing off exponentially between attempts.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		if attempt >= t.maxRetries || !retryable(req, resp, err) {
+			return resp, err
+		}
+
+		delay := retryDelay(attempt, resp)
+		if resp != nil {
+			resp.Body.Close()
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryable returns true if the request can be retried given its outcome.
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Method != http.MethodGet &&
+		req.Method != http.MethodHead &&
+		req.Context().Value(retryableKey{}) == nil {
+		// Resending the request could repeat its effect.
+		return false
+	}
+	if req.Body != nil && req.GetBody == nil {
+		// Unable to resend the body.
+		return false
+	}
+	if req.Context().Err() != nil {
+		// The overall request has been cancelled or timed out.
+		return false
+	}
+
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return true
+		}
+		var opErr *net.OpError
+		return errors.As(err, &opErr) || errors.Is(err, context.DeadlineExceeded)
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode == http.StatusBadGateway ||
+		resp.StatusCode == http.StatusServiceUnavailable ||
+		resp.StatusCode == http.StatusGatewayTimeout ||
+		resp.StatusCode == http.StatusInternalServerError
+}
+
+// retryDelay returns the delay before the next attempt, honouring any
+// Retry-After header supplied by the server.
+func retryDelay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs >= 0 {
+			return min(time.Duration(secs)*time.Second, retryMaxDelay)
+		}
+	}
+
+	delay := min(retryBaseDelay<<attempt, retryMaxDelay)
+	// Add jitter to avoid multiple requests retrying in lockstep.
+	//nolint:gosec
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// rateLimitedTransport limits the rate at which requests are sent.
+type rateLimitedTransport struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+func newRateLimitedTransport(next http.RoundTripper, requestsPerSecond float64) *rateLimitedTransport {
+	return &rateLimitedTransport{
+		next:    next,
+		limiter: rate.NewLimiter(rate.Limit(requestsPerSecond), max(1, int(requestsPerSecond))),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,171 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name       string
+		failures   int32
+		status     int
+		maxRetries int
+		body       []byte
+		method     string
+		optIn      bool
+		expected   int
+		calls      int32
+	}{
+		{
+			name:       "Success",
+			maxRetries: 3,
+			expected:   http.StatusOK,
+			calls:      1,
+		},
+		{
+			name:       "RetrySucceeds",
+			failures:   2,
+			status:     http.StatusServiceUnavailable,
+			maxRetries: 3,
+			expected:   http.StatusOK,
+			calls:      3,
+		},
+		{
+			name:       "RetryWithBody",
+			failures:   1,
+			status:     http.StatusTooManyRequests,
+			maxRetries: 3,
+			body:       []byte(`{"a":"b"}`),
+			method:     http.MethodPost,
+			optIn:      true,
+			expected:   http.StatusOK,
+			calls:      2,
+		},
+		{
+			name:       "PostNotRetried",
+			failures:   1,
+			status:     http.StatusServiceUnavailable,
+			maxRetries: 3,
+			body:       []byte(`{"a":"b"}`),
+			method:     http.MethodPost,
+			expected:   http.StatusServiceUnavailable,
+			calls:      1,
+		},
+		{
+			name:       "DeleteNotRetried",
+			failures:   1,
+			status:     http.StatusBadGateway,
+			maxRetries: 3,
+			method:     http.MethodDelete,
+			expected:   http.StatusBadGateway,
+			calls:      1,
+		},
+		{
+			name:       "RetriesExhausted",
+			failures:   5,
+			status:     http.StatusBadGateway,
+			maxRetries: 1,
+			expected:   http.StatusBadGateway,
+			calls:      2,
+		},
+		{
+			name:       "NotRetryable",
+			failures:   5,
+			status:     http.StatusNotFound,
+			maxRetries: 3,
+			expected:   http.StatusNotFound,
+			calls:      1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := int32(0)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := atomic.AddInt32(&calls, 1)
+				if test.body != nil {
+					body, err := io.ReadAll(r.Body)
+					require.NoError(t, err)
+					require.Equal(t, test.body, body)
+				}
+				if call <= test.failures {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(test.status)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			client := &http.Client{
+				Transport: &retryTransport{
+					next:       http.DefaultTransport,
+					maxRetries: test.maxRetries,
+				},
+			}
+			ctx := context.Background()
+			if test.optIn {
+				ctx = WithRetries(ctx)
+			}
+			method := test.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			var body io.Reader
+			if test.body != nil {
+				body = bytes.NewReader(test.body)
+			}
+			req, err := http.NewRequestWithContext(ctx, method, server.URL, body)
+			require.NoError(t, err)
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+			require.Equal(t, test.expected, resp.StatusCode)
+			require.Equal(t, test.calls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestRateLimitedTransport(t *testing.T) {
+	calls := int32(0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: newRateLimitedTransport(http.DefaultTransport, 20),
+	}
+	started := time.Now()
+	for i := 0; i < 30; i++ {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	// A burst of 20 followed by 10 at 20/s should take at least 0.5s.
+	require.GreaterOrEqual(t, time.Since(started), 450*time.Millisecond)
+	require.Equal(t, int32(30), atomic.LoadInt32(&calls))
+}
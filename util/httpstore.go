@@ -0,0 +1,151 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// httpBackend holds blobs on a remote HTTP service.  The service provides
+// the following endpoints relative to its base URL:
+//   - GET /<key> returns the blob, or 404 if it does not exist
+//   - PUT /<key> stores the blob
+//   - GET /?prefix=<prefix> returns a JSON object {"keys":[...]} listing
+//     keys that start with the prefix
+type httpBackend struct {
+	base  *url.URL
+	token string
+}
+
+// newHTTPStore creates a wallet store held on a remote HTTP service, using
+// the stores.http options from the configuration.
+func newHTTPStore(passphrase string) (e2wtypes.Store, error) {
+	baseURL := viper.GetString("stores.http.url")
+	if baseURL == "" {
+		return nil, errors.New("no HTTP store URL supplied; set stores.http.url")
+	}
+	base, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid HTTP store URL")
+	}
+	if base.Scheme != "http" && base.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported HTTP store URL scheme %q", base.Scheme)
+	}
+	if base.User != nil {
+		return nil, errors.New("HTTP store URL must not contain credentials; set stores.http.token")
+	}
+
+	return newBlobStore("http",
+		base.String(),
+		&httpBackend{
+			base:  base,
+			token: viper.GetString("stores.http.token"),
+		},
+		viper.GetString("stores.http.path"),
+		[]byte(passphrase),
+		viper.GetDuration("timeout"),
+	), nil
+}
+
+// Get obtains the blob with the given key.
+func (b *httpBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	return b.do(ctx, http.MethodGet, b.keyURL(key), nil)
+}
+
+// Put stores the blob with the given key.
+func (b *httpBackend) Put(ctx context.Context, key string, data []byte) error {
+	_, err := b.do(ctx, http.MethodPut, b.keyURL(key), data)
+
+	return err
+}
+
+// List lists the keys of the blobs with the given prefix.
+func (b *httpBackend) List(ctx context.Context, prefix string) ([]string, error) {
+	listURL := *b.base
+	listURL.Path += "/"
+	listURL.RawQuery = url.Values{"prefix": []string{prefix}}.Encode()
+
+	data, err := b.do(ctx, http.MethodGet, listURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Keys []string `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, errors.Wrap(err, "failed to parse key list")
+	}
+
+	return res.Keys, nil
+}
+
+// keyURL returns the URL for the blob with the given key.
+func (b *httpBackend) keyURL(key string) string {
+	components := strings.Split(key, "/")
+	for i := range components {
+		components[i] = url.PathEscape(components[i])
+	}
+
+	return fmt.Sprintf("%s/%s", b.base.String(), strings.Join(components, "/"))
+}
+
+func (b *httpBackend) do(ctx context.Context, method string, target string, body []byte) ([]byte, error) {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reqBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+	if b.token != "" {
+		req.Header.Set("Authorization", "Bearer "+b.token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+
+	resp, err := HTTPClient().Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "request failed")
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response")
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, errBlobNotFound
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		if msg := strings.TrimSpace(string(data)); msg != "" && len(msg) < 256 {
+			return nil, fmt.Errorf("status code %d: %s", resp.StatusCode, msg)
+		}
+		return nil, fmt.Errorf("status code %d", resp.StatusCode)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,48 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ExpandInputs expands the supplied inputs, each of which is either a value
+// or the path to a file containing one value per line.  Blank lines in files
+// are ignored.
+func ExpandInputs(inputs []string) ([]string, error) {
+	res := make([]string, 0, len(inputs))
+	for _, input := range inputs {
+		info, err := os.Stat(input)
+		if err != nil || info.IsDir() {
+			res = append(res, input)
+			continue
+		}
+		data, err := os.ReadFile(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read file")
+		}
+		for _, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			res = append(res, line)
+		}
+	}
+
+	return res, nil
+}
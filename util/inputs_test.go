@@ -0,0 +1,37 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestExpandInputs(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "values.txt")
+	require.NoError(t, os.WriteFile(path, []byte("0x02\r\n\n  0x03  \n0x04"), 0o600))
+
+	res, err := util.ExpandInputs([]string{"0x01", path, dir, "0x05"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"0x01", "0x02", "0x03", "0x04", dir, "0x05"}, res)
+
+	res, err = util.ExpandInputs(nil)
+	require.NoError(t, err)
+	require.Empty(t, res)
+}
@@ -0,0 +1,365 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// KeymanagerClient is a client for the keymanager API of a validator client.
+type KeymanagerClient struct {
+	address *url.URL
+	token   string
+}
+
+// FeeRecipientMapping maps a validator public key to a fee recipient.
+type FeeRecipientMapping struct {
+	PubKey       phase0.BLSPubKey
+	FeeRecipient *bellatrix.ExecutionAddress
+}
+
+// GraffitiMapping maps a validator public key to a graffiti.
+type GraffitiMapping struct {
+	PubKey   phase0.BLSPubKey
+	Graffiti *string
+}
+
+// maxGraffitiLength is the maximum length of graffiti, in bytes.
+const maxGraffitiLength = 32
+
+// NewKeymanagerClient creates a client for the keymanager API at the given
+// address.  The token can be supplied directly, or as the path to a file
+// containing it as generated by most validator clients.
+func NewKeymanagerClient(address string, token string) (*KeymanagerClient, error) {
+	if address == "" {
+		return nil, errors.New("no keymanager address supplied")
+	}
+	if !strings.HasPrefix(address, "http://") && !strings.HasPrefix(address, "https://") {
+		address = "http://" + address
+	}
+	base, err := url.Parse(strings.TrimSuffix(address, "/"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid keymanager address")
+	}
+
+	if token == "" {
+		return nil, errors.New("no keymanager token supplied")
+	}
+	if data, err := os.ReadFile(token); err == nil {
+		token = string(data)
+	}
+
+	return &KeymanagerClient{
+		address: base,
+		token:   strings.TrimSpace(token),
+	}, nil
+}
+
+// FeeRecipient obtains the fee recipient for the validator.
+func (c *KeymanagerClient) FeeRecipient(ctx context.Context, pubKey phase0.BLSPubKey) (bellatrix.ExecutionAddress, error) {
+	body, err := c.do(ctx, http.MethodGet, feeRecipientPath(pubKey), nil, http.StatusOK)
+	if err != nil {
+		return bellatrix.ExecutionAddress{}, err
+	}
+
+	var response struct {
+		Data *struct {
+			EthAddress string `json:"ethaddress"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return bellatrix.ExecutionAddress{}, errors.Wrap(err, "failed to decode fee recipient")
+	}
+	if response.Data == nil {
+		return bellatrix.ExecutionAddress{}, errors.New("fee recipient not returned")
+	}
+
+	return ParseExecutionAddress(strings.ToLower(response.Data.EthAddress))
+}
+
+// SetFeeRecipient sets the fee recipient for the validator.
+func (c *KeymanagerClient) SetFeeRecipient(ctx context.Context, pubKey phase0.BLSPubKey, feeRecipient bellatrix.ExecutionAddress) error {
+	reqData, err := json.Marshal(map[string]string{
+		"ethaddress": feeRecipient.String(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal fee recipient")
+	}
+	_, err = c.do(ctx, http.MethodPost, feeRecipientPath(pubKey), reqData, http.StatusAccepted)
+
+	return err
+}
+
+// DeleteFeeRecipient removes the fee recipient for the validator, returning
+// it to the validator client's default.
+func (c *KeymanagerClient) DeleteFeeRecipient(ctx context.Context, pubKey phase0.BLSPubKey) error {
+	_, err := c.do(ctx, http.MethodDelete, feeRecipientPath(pubKey), nil, http.StatusNoContent)
+
+	return err
+}
+
+func feeRecipientPath(pubKey phase0.BLSPubKey) string {
+	return fmt.Sprintf("/eth/v1/validator/%#x/feerecipient", pubKey)
+}
+
+// Graffiti obtains the graffiti for the validator.
+func (c *KeymanagerClient) Graffiti(ctx context.Context, pubKey phase0.BLSPubKey) (string, error) {
+	body, err := c.do(ctx, http.MethodGet, graffitiPath(pubKey), nil, http.StatusOK)
+	if err != nil {
+		return "", err
+	}
+
+	var response struct {
+		Data *struct {
+			Graffiti string `json:"graffiti"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", errors.Wrap(err, "failed to decode graffiti")
+	}
+	if response.Data == nil {
+		return "", errors.New("graffiti not returned")
+	}
+
+	return response.Data.Graffiti, nil
+}
+
+// SetGraffiti sets the graffiti for the validator.
+func (c *KeymanagerClient) SetGraffiti(ctx context.Context, pubKey phase0.BLSPubKey, graffiti string) error {
+	if err := ValidateGraffiti(graffiti); err != nil {
+		return err
+	}
+	reqData, err := json.Marshal(map[string]string{
+		"graffiti": graffiti,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal graffiti")
+	}
+	_, err = c.do(ctx, http.MethodPost, graffitiPath(pubKey), reqData, http.StatusAccepted)
+
+	return err
+}
+
+func graffitiPath(pubKey phase0.BLSPubKey) string {
+	return fmt.Sprintf("/eth/v1/validator/%#x/graffiti", pubKey)
+}
+
+// ValidateGraffiti checks that the graffiti fits in a block.
+func ValidateGraffiti(graffiti string) error {
+	if len(graffiti) > maxGraffitiLength {
+		return fmt.Errorf("graffiti %q is longer than %d bytes", graffiti, maxGraffitiLength)
+	}
+
+	return nil
+}
+
+func (c *KeymanagerClient) do(ctx context.Context, method string, path string, body []byte, expectedStatus int) ([]byte, error) {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.address.String()+path, reqBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := HTTPClient().Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to call keymanager")
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read keymanager response")
+	}
+	if resp.StatusCode != expectedStatus {
+		var response struct {
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(data, &response); err == nil && response.Message != "" {
+			return nil, fmt.Errorf("keymanager returned status %d: %s", resp.StatusCode, response.Message)
+		}
+
+		return nil, fmt.Errorf("keymanager returned status %d", resp.StatusCode)
+	}
+
+	return data, nil
+}
+
+// ParsePubKey parses a validator public key.
+func ParsePubKey(input string) (phase0.BLSPubKey, error) {
+	var pubKey phase0.BLSPubKey
+
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return pubKey, errors.Wrapf(err, "failed to parse validator public key %s", input)
+	}
+	if len(data) != phase0.PublicKeyLength {
+		return pubKey, fmt.Errorf("invalid length for validator public key %s", input)
+	}
+	copy(pubKey[:], data)
+
+	return pubKey, nil
+}
+
+// KeymanagerPubKeys returns the validator public keys supplied either
+// directly or in the first column of a CSV file, as accepted by the
+// keymanager commands.  The name describes the contents of the CSV file.
+func KeymanagerPubKeys(validators []string, csvFile string, name string) ([]phase0.BLSPubKey, error) {
+	if csvFile != "" {
+		entries, err := readValidatorCSV(csvFile, name)
+		if err != nil {
+			return nil, err
+		}
+		pubKeys := make([]phase0.BLSPubKey, len(entries))
+		for i := range entries {
+			pubKeys[i] = entries[i].pubKey
+		}
+
+		return pubKeys, nil
+	}
+
+	pubKeys := make([]phase0.BLSPubKey, len(validators))
+	for i := range validators {
+		var err error
+		pubKeys[i], err = ParsePubKey(validators[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pubKeys, nil
+}
+
+// ReadFeeRecipientMappings reads mappings of validator public keys to fee
+// recipients from a CSV file, with the public key in the first column and the
+// fee recipient, if present, in the second.  A header line is ignored.
+func ReadFeeRecipientMappings(path string) ([]*FeeRecipientMapping, error) {
+	entries, err := readValidatorCSV(path, "fee recipient")
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*FeeRecipientMapping, 0, len(entries))
+	for _, entry := range entries {
+		mapping := &FeeRecipientMapping{
+			PubKey: entry.pubKey,
+		}
+		if entry.value != "" {
+			feeRecipient, err := ParseExecutionAddress(entry.value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid entry on line %d", entry.line)
+			}
+			mapping.FeeRecipient = &feeRecipient
+		}
+		res = append(res, mapping)
+	}
+
+	return res, nil
+}
+
+// ReadGraffitiMappings reads mappings of validator public keys to graffiti
+// from a CSV file, with the public key in the first column and the graffiti,
+// if present, in the second.  A header line is ignored.
+func ReadGraffitiMappings(path string) ([]*GraffitiMapping, error) {
+	entries, err := readValidatorCSV(path, "graffiti")
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*GraffitiMapping, 0, len(entries))
+	for _, entry := range entries {
+		mapping := &GraffitiMapping{
+			PubKey: entry.pubKey,
+		}
+		if entry.value != "" {
+			if err := ValidateGraffiti(entry.value); err != nil {
+				return nil, errors.Wrapf(err, "invalid entry on line %d", entry.line)
+			}
+			graffiti := entry.value
+			mapping.Graffiti = &graffiti
+		}
+		res = append(res, mapping)
+	}
+
+	return res, nil
+}
+
+// validatorCSVEntry is a single entry from a CSV file keyed by validator
+// public key.
+type validatorCSVEntry struct {
+	line   int
+	pubKey phase0.BLSPubKey
+	value  string
+}
+
+// readValidatorCSV reads a CSV file with a validator public key in the first
+// column and an optional value in the second.  A header line is ignored.
+func readValidatorCSV(path string, name string) ([]*validatorCSVEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %s file", name)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s file", name)
+	}
+
+	res := make([]*validatorCSVEntry, 0, len(records))
+	for i, record := range records {
+		if i == 0 && !strings.HasPrefix(record[0], "0x") {
+			// Header.
+			continue
+		}
+		pubKey, err := ParsePubKey(record[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid entry on line %d", i+1)
+		}
+		entry := &validatorCSVEntry{
+			line:   i + 1,
+			pubKey: pubKey,
+		}
+		if len(record) > 1 {
+			entry.value = record[1]
+		}
+		res = append(res, entry)
+	}
+
+	return res, nil
+}
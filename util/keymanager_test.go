@@ -0,0 +1,245 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestKeymanagerFeeRecipient(t *testing.T) {
+	pubKeyStr := "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+	pubKey, err := util.ParsePubKey(pubKeyStr)
+	require.NoError(t, err)
+	unknownPubKey, err := util.ParsePubKey("0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b")
+	require.NoError(t, err)
+	feeRecipient, err := util.ParseExecutionAddress("0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F")
+	require.NoError(t, err)
+
+	var stored string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, `{"message":"invalid token"}`, http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/eth/v1/validator/"+pubKeyStr+"/feerecipient" {
+			http.Error(w, `{"message":"validator not found"}`, http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"data":{"pubkey":"` + pubKeyStr + `","ethaddress":"` + stored + `"}}`))
+		case http.MethodPost:
+			var req map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			stored = req["ethaddress"]
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodDelete:
+			stored = "0x0000000000000000000000000000000000000001"
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "api-token.txt")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("secret\n"), 0o600))
+
+	_, err = util.NewKeymanagerClient("", "secret")
+	require.EqualError(t, err, "no keymanager address supplied")
+	_, err = util.NewKeymanagerClient(server.URL, "")
+	require.EqualError(t, err, "no keymanager token supplied")
+
+	badClient, err := util.NewKeymanagerClient(server.URL, "wrong")
+	require.NoError(t, err)
+	_, err = badClient.FeeRecipient(context.Background(), pubKey)
+	require.EqualError(t, err, "keymanager returned status 401: invalid token")
+
+	client, err := util.NewKeymanagerClient(server.URL, tokenFile)
+	require.NoError(t, err)
+
+	require.NoError(t, client.SetFeeRecipient(context.Background(), pubKey, feeRecipient))
+	res, err := client.FeeRecipient(context.Background(), pubKey)
+	require.NoError(t, err)
+	require.Equal(t, feeRecipient, res)
+
+	require.NoError(t, client.DeleteFeeRecipient(context.Background(), pubKey))
+	res, err = client.FeeRecipient(context.Background(), pubKey)
+	require.NoError(t, err)
+	require.Equal(t, bellatrix.ExecutionAddress{19: 0x01}, res)
+
+	err = client.DeleteFeeRecipient(context.Background(), unknownPubKey)
+	require.EqualError(t, err, "keymanager returned status 404: validator not found")
+}
+
+func TestReadFeeRecipientMappings(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		contents string
+		res      int
+		err      string
+	}{
+		{
+			name:     "Good",
+			contents: "pubkey,fee_recipient\n0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c,0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F\n0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b\n",
+			res:      2,
+		},
+		{
+			name:     "PubKeyInvalid",
+			contents: "0xa99a76,0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F\n",
+			err:      "invalid entry on line 1: invalid length for validator public key 0xa99a76",
+		},
+		{
+			name:     "FeeRecipientInvalid",
+			contents: "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c,0x8f08\n",
+			err:      "invalid entry on line 1: address must be exactly 20 bytes in length",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(dir, test.name+".csv")
+			require.NoError(t, os.WriteFile(path, []byte(test.contents), 0o600))
+			res, err := util.ReadFeeRecipientMappings(path)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res, test.res)
+				require.NotNil(t, res[0].FeeRecipient)
+				require.Nil(t, res[1].FeeRecipient)
+			}
+		})
+	}
+
+	_, err := util.ReadFeeRecipientMappings(filepath.Join(dir, "missing.csv"))
+	require.ErrorContains(t, err, "failed to open fee recipient file")
+}
+
+func TestKeymanagerGraffiti(t *testing.T) {
+	pubKeyStr := "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+	pubKey, err := util.ParsePubKey(pubKeyStr)
+	require.NoError(t, err)
+
+	var stored string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/validator/"+pubKeyStr+"/graffiti" {
+			http.Error(w, `{"message":"validator not found"}`, http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			data, err := json.Marshal(map[string]any{"data": map[string]string{"pubkey": pubKeyStr, "graffiti": stored}})
+			require.NoError(t, err)
+			_, _ = w.Write(data)
+		case http.MethodPost:
+			var req map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			stored = req["graffiti"]
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer server.Close()
+
+	client, err := util.NewKeymanagerClient(server.URL, "secret")
+	require.NoError(t, err)
+
+	require.NoError(t, client.SetGraffiti(context.Background(), pubKey, "ethdo \"test\""))
+	res, err := client.Graffiti(context.Background(), pubKey)
+	require.NoError(t, err)
+	require.Equal(t, "ethdo \"test\"", res)
+
+	err = client.SetGraffiti(context.Background(), pubKey, "0123456789012345678901234567890123456789")
+	require.EqualError(t, err, `graffiti "0123456789012345678901234567890123456789" is longer than 32 bytes`)
+}
+
+func TestReadGraffitiMappings(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		contents string
+		res      int
+		err      string
+	}{
+		{
+			name:     "Good",
+			contents: "pubkey,graffiti\n0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c,\"hello, world\"\n0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b\n",
+			res:      2,
+		},
+		{
+			name:     "PubKeyInvalid",
+			contents: "0xa99a76,hello\n",
+			err:      "invalid entry on line 1: invalid length for validator public key 0xa99a76",
+		},
+		{
+			name:     "GraffitiInvalid",
+			contents: "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c,0123456789012345678901234567890123456789\n",
+			err:      `invalid entry on line 1: graffiti "0123456789012345678901234567890123456789" is longer than 32 bytes`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(dir, test.name+".csv")
+			require.NoError(t, os.WriteFile(path, []byte(test.contents), 0o600))
+			res, err := util.ReadGraffitiMappings(path)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res, test.res)
+				require.NotNil(t, res[0].Graffiti)
+				require.Equal(t, "hello, world", *res[0].Graffiti)
+				require.Nil(t, res[1].Graffiti)
+			}
+		})
+	}
+
+	_, err := util.ReadGraffitiMappings(filepath.Join(dir, "missing.csv"))
+	require.ErrorContains(t, err, "failed to open graffiti file")
+}
+
+func TestKeymanagerPubKeys(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "validators.csv")
+	require.NoError(t, os.WriteFile(path, []byte("pubkey,graffiti\n0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c,hello\n0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b\n"), 0o600))
+
+	pubKeys, err := util.KeymanagerPubKeys(nil, path, "graffiti")
+	require.NoError(t, err)
+	require.Len(t, pubKeys, 2)
+	require.Equal(t, "0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b", pubKeys[1].String())
+
+	pubKeys, err = util.KeymanagerPubKeys([]string{"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"}, "", "graffiti")
+	require.NoError(t, err)
+	require.Len(t, pubKeys, 1)
+	require.Equal(t, "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c", pubKeys[0].String())
+
+	_, err = util.KeymanagerPubKeys([]string{"0xa99a76"}, "", "graffiti")
+	require.EqualError(t, err, "invalid length for validator public key 0xa99a76")
+
+	_, err = util.KeymanagerPubKeys(nil, filepath.Join(dir, "missing.csv"), "fee recipient")
+	require.ErrorContains(t, err, "failed to open fee recipient file")
+}
@@ -0,0 +1,71 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+type validatorLivenessJSON struct {
+	Index  string `json:"index"`
+	IsLive bool   `json:"is_live"`
+}
+
+// FetchValidatorLiveness obtains the liveness of the given validators in
+// the given epoch from the beacon node.  A validator is live if the beacon
+// node has seen it attest, propose or participate in a sync committee in
+// the epoch.  Beacon nodes generally only hold liveness information for the
+// current and previous epochs.
+func FetchValidatorLiveness(ctx context.Context, client eth2client.Service, epoch phase0.Epoch, indices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]bool, error) {
+	req := make([]string, len(indices))
+	for i := range indices {
+		req[i] = fmt.Sprintf("%d", indices[i])
+	}
+	reqData, err := json.Marshal(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal liveness request")
+	}
+
+	// The liveness request only reads data, so is safe to retry.
+	body, err := BeaconNodePost(WithRetries(ctx), client, fmt.Sprintf("/eth/v1/validator/liveness/%d", epoch), reqData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain validator liveness")
+	}
+	defer body.Close()
+
+	var response struct {
+		Data []*validatorLivenessJSON `json:"data"`
+	}
+	if err := json.NewDecoder(body).Decode(&response); err != nil {
+		return nil, errors.Wrap(err, "failed to decode validator liveness")
+	}
+
+	res := make(map[phase0.ValidatorIndex]bool, len(response.Data))
+	for _, liveness := range response.Data {
+		index, err := strconv.ParseUint(liveness.Index, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid index in validator liveness")
+		}
+		res[phase0.ValidatorIndex(index)] = liveness.IsLive
+	}
+
+	return res, nil
+}
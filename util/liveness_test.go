@@ -0,0 +1,62 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestFetchValidatorLiveness(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, `{"code":405,"message":"method not allowed"}`, http.StatusMethodNotAllowed)
+			return
+		}
+		var indices []string
+		if err := json.NewDecoder(r.Body).Decode(&indices); err != nil {
+			http.Error(w, `{"code":400,"message":"bad request"}`, http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/eth/v1/validator/liveness/100":
+			require.Equal(t, []string{"1", "2"}, indices)
+			_, _ = w.Write([]byte(`{"data":[{"index":"1","is_live":true},{"index":"2","is_live":false}]}`))
+		case "/eth/v1/validator/liveness/101":
+			_, _ = w.Write([]byte(`{"data":[{"index":"bad","is_live":true}]}`))
+		default:
+			http.Error(w, `{"code":400,"message":"epoch out of range"}`, http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := &addressETH2Client{address: server.URL}
+
+	liveness, err := util.FetchValidatorLiveness(context.Background(), client, 100, []phase0.ValidatorIndex{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, map[phase0.ValidatorIndex]bool{1: true, 2: false}, liveness)
+
+	_, err = util.FetchValidatorLiveness(context.Background(), client, 101, []phase0.ValidatorIndex{1})
+	require.ErrorContains(t, err, "invalid index in validator liveness")
+
+	_, err = util.FetchValidatorLiveness(context.Background(), client, 102, []phase0.ValidatorIndex{1})
+	require.ErrorContains(t, err, "beacon node returned status 400")
+}
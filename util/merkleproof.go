@@ -0,0 +1,73 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// MerkleProof is a proof that a leaf is part of the Merkle tree with the
+// given root, at the position given by its generalized index.
+type MerkleProof struct {
+	Leaf             phase0.Root   `json:"leaf"`
+	Branch           []phase0.Root `json:"branch"`
+	GeneralizedIndex uint64        `json:"gindex"`
+	Root             phase0.Root   `json:"root"`
+}
+
+// Verify returns true if the proof is valid.
+func (p *MerkleProof) Verify() bool {
+	if p.GeneralizedIndex == 0 || uint64(len(p.Branch)) != uint64(bits.Len64(p.GeneralizedIndex)-1) {
+		return false
+	}
+
+	node := [sszChunkLength]byte(p.Leaf)
+	index := p.GeneralizedIndex
+	for _, sibling := range p.Branch {
+		if index&1 == 1 {
+			node = sszHashPair(sibling, node)
+		} else {
+			node = sszHashPair(node, sibling)
+		}
+		index >>= 1
+	}
+
+	return node == p.Root
+}
+
+// Chain chains the proof to a parent proof whose leaf is the root of this
+// proof, returning a proof of this proof's leaf against the parent's root.
+func (p *MerkleProof) Chain(parent *MerkleProof) (*MerkleProof, error) {
+	if p.Root != parent.Leaf {
+		return nil, fmt.Errorf("proof root %#x does not match parent leaf %#x", p.Root, parent.Leaf)
+	}
+	depth := bits.Len64(p.GeneralizedIndex) - 1
+	if bits.Len64(parent.GeneralizedIndex)+depth > 64 {
+		return nil, fmt.Errorf("chained proof is too deep")
+	}
+
+	branch := make([]phase0.Root, 0, len(p.Branch)+len(parent.Branch))
+	branch = append(branch, p.Branch...)
+	branch = append(branch, parent.Branch...)
+
+	return &MerkleProof{
+		Leaf:             p.Leaf,
+		Branch:           branch,
+		GeneralizedIndex: parent.GeneralizedIndex<<depth | (p.GeneralizedIndex ^ uint64(1)<<depth),
+		Root:             parent.Root,
+	}, nil
+}
@@ -20,6 +20,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -31,22 +32,82 @@ import (
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
-// SetupStore sets up the account store.
+var (
+	storeMu sync.Mutex
+	// storePending is true if the store has been configured but not yet set up.
+	storePending bool
+)
+
+// SetupStore checks the configuration of the account store.  The store itself
+// is not set up until it is required, as opening some stores can be expensive
+// and many commands do not access wallets.
 func SetupStore() error {
-	var store e2wtypes.Store
-	var err error
 	if viper.GetString("remote") != "" {
 		// We are using a remote account manager, so no local setup required.
 		return nil
 	}
 
-	// Set up our wallet store.
 	switch viper.GetString("store") {
 	case "s3":
 		if GetBaseDir() != "" {
 			return errors.New("basedir does not apply to the s3 store")
 		}
-		store, err = s3.New(s3.WithPassphrase([]byte(GetStorePassphrase("s3"))),
+	case "gcs":
+		if GetBaseDir() != "" {
+			return errors.New("basedir does not apply to the gcs store")
+		}
+	case "http":
+		if GetBaseDir() != "" {
+			return errors.New("basedir does not apply to the http store")
+		}
+	case "filesystem":
+	default:
+		return fmt.Errorf("unsupported wallet store %s", viper.GetString("store"))
+	}
+
+	storeMu.Lock()
+	storePending = true
+	storeMu.Unlock()
+
+	return nil
+}
+
+// EnsureStore sets up the account store configured by SetupStore, if it
+// has not already been set up.
+func EnsureStore() error {
+	storeMu.Lock()
+	defer storeMu.Unlock()
+
+	if !storePending {
+		return nil
+	}
+
+	store, err := NewStore(viper.GetString("store"), GetBaseDir(), GetStorePassphrase(viper.GetString("store")))
+	if err != nil {
+		return err
+	}
+	if err := e2wallet.UseStore(store); err != nil {
+		return errors.Wrap(err, "failed to use defined wallet store")
+	}
+	viper.Set("store", store)
+	storePending = false
+
+	return nil
+}
+
+// NewStore creates a wallet store of the given type.  The base directory only
+// applies to the filesystem store; other stores obtain their configuration
+// from the "stores" section of the configuration.
+func NewStore(storeType string, baseDir string, passphrase string) (e2wtypes.Store, error) {
+	switch storeType {
+	case "s3":
+		if baseDir != "" {
+			return nil, errors.New("basedir does not apply to the s3 store")
+		}
+		if Offline() {
+			return nil, errors.Wrap(ErrOffline, "cannot access Amazon S3 wallet store")
+		}
+		store, err := s3.New(s3.WithPassphrase([]byte(passphrase)),
 			s3.WithID([]byte(viper.GetString("stores.s3.id"))),
 			s3.WithEndpoint(viper.GetString("stores.s3.endpoint")),
 			s3.WithRegion(viper.GetString("stores.s3.region")),
@@ -56,26 +117,45 @@ func SetupStore() error {
 			s3.WithCredentialsSecret(viper.GetString("stores.s3.credentials.secret")),
 		)
 		if err != nil {
-			return errors.Wrap(err, "failed to access Amazon S3 wallet store")
+			return nil, errors.Wrap(err, "failed to access Amazon S3 wallet store")
 		}
+		return store, nil
+	case "gcs":
+		if baseDir != "" {
+			return nil, errors.New("basedir does not apply to the gcs store")
+		}
+		if Offline() {
+			return nil, errors.Wrap(ErrOffline, "cannot access Google Cloud Storage wallet store")
+		}
+		store, err := newGCSStore(passphrase)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to access Google Cloud Storage wallet store")
+		}
+		return store, nil
+	case "http":
+		if baseDir != "" {
+			return nil, errors.New("basedir does not apply to the http store")
+		}
+		if Offline() {
+			return nil, errors.Wrap(ErrOffline, "cannot access HTTP wallet store")
+		}
+		store, err := newHTTPStore(passphrase)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to access HTTP wallet store")
+		}
+		return store, nil
 	case "filesystem":
 		opts := make([]filesystem.Option, 0)
-		if GetStorePassphrase("filesystem") != "" {
-			opts = append(opts, filesystem.WithPassphrase([]byte(GetStorePassphrase("filesystem"))))
+		if passphrase != "" {
+			opts = append(opts, filesystem.WithPassphrase([]byte(passphrase)))
 		}
-		if GetBaseDir() != "" {
-			opts = append(opts, filesystem.WithLocation(GetBaseDir()))
+		if baseDir != "" {
+			opts = append(opts, filesystem.WithLocation(baseDir))
 		}
-		store = filesystem.New(opts...)
+		return filesystem.New(opts...), nil
 	default:
-		return fmt.Errorf("unsupported wallet store %s", viper.GetString("store"))
-	}
-	if err := e2wallet.UseStore(store); err != nil {
-		return errors.Wrap(err, "failed to use defined wallet store")
+		return nil, fmt.Errorf("unsupported wallet store %s", storeType)
 	}
-	viper.Set("store", store)
-
-	return nil
 }
 
 // WalletFromInput obtains a wallet given the information in the viper variable
@@ -98,15 +178,9 @@ func WalletFromPath(ctx context.Context, path string) (e2wtypes.Wallet, error) {
 		return nil, err
 	}
 	if viper.GetString("remote") != "" {
-		if viper.GetString("client-cert") == "" {
-			return nil, errors.New("remote connections require client-cert")
-		}
-		if viper.GetString("client-key") == "" {
-			return nil, errors.New("remote connections require client-key")
-		}
-		credentials, err := dirk.ComposeCredentials(ctx, viper.GetString("client-cert"), viper.GetString("client-key"), viper.GetString("server-ca-cert"))
+		credentials, err := remoteCredentials(ctx)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to build dirk credentials")
+			return nil, err
 		}
 
 		endpoints, err := remotesToEndpoints([]string{viper.GetString("remote")})
@@ -121,6 +195,9 @@ func WalletFromPath(ctx context.Context, path string) (e2wtypes.Wallet, error) {
 			dirk.WithTimeout(viper.GetDuration("timeout")),
 		)
 	}
+	if err := EnsureStore(); err != nil {
+		return nil, err
+	}
 	wallet, err := e2wallet.OpenWallet(walletName)
 	if err != nil {
 		if strings.Contains(err.Error(), "failed to decrypt wallet") {
@@ -187,6 +264,25 @@ func WalletAndAccountsFromPath(ctx context.Context, path string) (e2wtypes.Walle
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to obtain account specification")
 	}
+
+	if accountSpec != "" &&
+		accountSpec == regexp.QuoteMeta(accountSpec) &&
+		!strings.HasPrefix(accountSpec, "m/") {
+		// A single named account; fetch it directly rather than enumerating the wallet.
+		if provider, isProvider := wallet.(e2wtypes.WalletAccountByNameProvider); isProvider {
+			account, err := provider.AccountByName(ctx, accountSpec)
+			if err != nil {
+				// Not found.
+				return wallet, []e2wtypes.Account{}, nil
+			}
+			accounts, err := FilterAccountsByTags(ctx, wallet, []e2wtypes.Account{account}, GetAccountsTagged())
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "failed to filter accounts by tag")
+			}
+			return wallet, accounts, nil
+		}
+	}
+
 	if accountSpec == "" {
 		accountSpec = "^.*$"
 	} else {
@@ -201,6 +297,11 @@ func WalletAndAccountsFromPath(ctx context.Context, path string) (e2wtypes.Walle
 		}
 	}
 
+	accounts, err = FilterAccountsByTags(ctx, wallet, accounts, GetAccountsTagged())
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to filter accounts by tag")
+	}
+
 	// Tidy up accounts by name.
 	sort.Slice(accounts, func(i, j int) bool {
 		return accounts[i].Name() < accounts[j].Name()
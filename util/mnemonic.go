@@ -14,7 +14,6 @@
 package util
 
 import (
-	"regexp"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -23,23 +22,32 @@ import (
 	"golang.org/x/text/unicode/norm"
 )
 
-// hdPathRegex is the regular expression that matches an HD path.
-var hdPathRegex = regexp.MustCompile("^m/[0-9]+/[0-9]+(/[0-9+])+")
+// MnemonicWordList is a named BIP-39 word list.
+type MnemonicWordList struct {
+	Name  string
+	Words []string
+}
 
-var mnemonicWordLists = [][]string{
-	wordlists.English,
-	wordlists.ChineseSimplified,
-	wordlists.ChineseTraditional,
-	wordlists.Czech,
-	wordlists.French,
-	wordlists.Italian,
-	wordlists.Japanese,
-	wordlists.Korean,
-	wordlists.Spanish,
+var mnemonicWordLists = []*MnemonicWordList{
+	{Name: "english", Words: wordlists.English},
+	{Name: "chinese_simplified", Words: wordlists.ChineseSimplified},
+	{Name: "chinese_traditional", Words: wordlists.ChineseTraditional},
+	{Name: "czech", Words: wordlists.Czech},
+	{Name: "french", Words: wordlists.French},
+	{Name: "italian", Words: wordlists.Italian},
+	{Name: "japanese", Words: wordlists.Japanese},
+	{Name: "korean", Words: wordlists.Korean},
+	{Name: "spanish", Words: wordlists.Spanish},
 }
 
-// SeedFromMnemonic creates a seed from a mnemonic.
-func SeedFromMnemonic(mnemonic string) ([]byte, error) {
+// MnemonicWordLists returns the BIP-39 word lists supported for mnemonics.
+func MnemonicWordLists() []*MnemonicWordList {
+	return mnemonicWordLists
+}
+
+// SplitMnemonic splits a mnemonic in to its words and any passphrase
+// supplied after the words.
+func SplitMnemonic(mnemonic string) (string, string) {
 	// Handle situations where there may be a passphrase with the mnemonic.
 	mnemonicParts := strings.Split(mnemonic, " ")
 	mnemonicPassphrase := ""
@@ -61,10 +69,17 @@ func SeedFromMnemonic(mnemonic string) ([]byte, error) {
 	mnemonic = string(norm.NFKD.Bytes([]byte(mnemonic)))
 	mnemonicPassphrase = string(norm.NFKD.Bytes([]byte(mnemonicPassphrase)))
 
+	return mnemonic, mnemonicPassphrase
+}
+
+// SeedFromMnemonic creates a seed from a mnemonic.
+func SeedFromMnemonic(mnemonic string) ([]byte, error) {
+	mnemonic, mnemonicPassphrase := SplitMnemonic(mnemonic)
+
 	// Try with the various word lists.
 	for _, wl := range mnemonicWordLists {
-		bip39.SetWordList(wl)
-		seed, err := bip39.NewSeedWithErrorChecking(expandMnemonic(mnemonic), mnemonicPassphrase)
+		bip39.SetWordList(wl.Words)
+		seed, err := bip39.NewSeedWithErrorChecking(ExpandMnemonic(mnemonic), mnemonicPassphrase)
 		if err == nil {
 			return seed, nil
 		}
@@ -73,8 +88,9 @@ func SeedFromMnemonic(mnemonic string) ([]byte, error) {
 	return nil, errors.New("mnemonic is invalid")
 }
 
-// expandMnmenonic expands mnemonics from their 4-letter versions.
-func expandMnemonic(input string) string {
+// ExpandMnemonic expands mnemonics from their 4-letter versions, using the
+// current BIP-39 word list.
+func ExpandMnemonic(input string) string {
 	wordList := bip39.GetWordList()
 	truncatedWords := make(map[string]string, len(wordList))
 	for _, word := range wordList {
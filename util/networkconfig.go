@@ -0,0 +1,151 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"os"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/http"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// NetworkConfig returns the consensus configuration of the network supplied
+// with --network-config, or nil if no network configuration was supplied.
+func NetworkConfig() (map[string]any, error) {
+	path := viper.GetString("network-config")
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read network config")
+	}
+	config, err := SpecFromYAML(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid network config")
+	}
+
+	return config, nil
+}
+
+// GenesisForkVersion returns the fork version given in the input if present,
+// otherwise the genesis fork version of the network configuration if supplied,
+// otherwise the mainnet genesis fork version.
+func GenesisForkVersion(input string) (phase0.Version, error) {
+	if input != "" {
+		return ParseForkVersion(input)
+	}
+
+	config, err := NetworkConfig()
+	if err != nil {
+		return phase0.Version{}, err
+	}
+	if config == nil {
+		// Mainnet.
+		return phase0.Version{}, nil
+	}
+	forkVersion, isVersion := config["GENESIS_FORK_VERSION"].(phase0.Version)
+	if !isVersion {
+		return phase0.Version{}, errors.New("network config missing GENESIS_FORK_VERSION")
+	}
+
+	return forkVersion, nil
+}
+
+// withNetworkConfig wraps the client so that its specification is overridden
+// by the network configuration, if supplied.
+func withNetworkConfig(client eth2client.Service) (eth2client.Service, error) {
+	config, err := NetworkConfig()
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return client, nil
+	}
+
+	switch service := client.(type) {
+	case *http.Service:
+		return &networkConfigService{
+			Service: service,
+			config:  config,
+		}, nil
+	case *failoverService:
+		return &networkConfigFailoverService{
+			failoverService: service,
+			config:          config,
+		}, nil
+	default:
+		return nil, errors.New("unsupported client for network config")
+	}
+}
+
+// networkConfigService is a single-node service with a network configuration.
+type networkConfigService struct {
+	*http.Service
+
+	config map[string]any
+}
+
+// Spec provides the spec information of the chain.
+func (s *networkConfigService) Spec(ctx context.Context, opts *api.SpecOpts) (*api.Response[map[string]any], error) {
+	return specWithConfig(ctx, s.Service, opts, s.config)
+}
+
+// networkConfigFailoverService is a multi-node service with a network configuration.
+type networkConfigFailoverService struct {
+	*failoverService
+
+	config map[string]any
+}
+
+// Spec provides the spec information of the chain.
+func (s *networkConfigFailoverService) Spec(ctx context.Context, opts *api.SpecOpts) (*api.Response[map[string]any], error) {
+	return specWithConfig(ctx, s.failoverService, opts, s.config)
+}
+
+// specWithConfig returns the specification from the provider, with values
+// overridden by those in the network configuration.
+func specWithConfig(ctx context.Context,
+	provider eth2client.SpecProvider,
+	opts *api.SpecOpts,
+	config map[string]any,
+) (
+	*api.Response[map[string]any],
+	error,
+) {
+	response, err := provider.Spec(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// The provider's specification may be shared, so copy it rather than altering it.
+	spec := make(map[string]any, len(response.Data)+len(config))
+	for k, v := range response.Data {
+		spec[k] = v
+	}
+	for k, v := range config {
+		spec[k] = v
+	}
+
+	return &api.Response[map[string]any]{
+		Data:     spec,
+		Metadata: response.Metadata,
+	}, nil
+}
@@ -0,0 +1,59 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+// staticSpecProvider provides a fixed specification.
+type staticSpecProvider struct {
+	spec map[string]any
+}
+
+func (p *staticSpecProvider) Spec(_ context.Context, _ *api.SpecOpts) (*api.Response[map[string]any], error) {
+	return &api.Response[map[string]any]{
+		Data:     p.spec,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func TestSpecWithConfig(t *testing.T) {
+	provider := &staticSpecProvider{
+		spec: map[string]any{
+			"SECONDS_PER_SLOT":     uint64(12),
+			"GENESIS_FORK_VERSION": phase0.Version{0x00, 0x00, 0x00, 0x00},
+		},
+	}
+	config := map[string]any{
+		"GENESIS_FORK_VERSION": phase0.Version{0x10, 0x00, 0x00, 0x38},
+		"CONFIG_NAME":          "devnet",
+	}
+
+	response, err := specWithConfig(context.Background(), provider, &api.SpecOpts{}, config)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"SECONDS_PER_SLOT":     uint64(12),
+		"GENESIS_FORK_VERSION": phase0.Version{0x10, 0x00, 0x00, 0x38},
+		"CONFIG_NAME":          "devnet",
+	}, response.Data)
+
+	// The provider's specification must not be altered.
+	require.Equal(t, phase0.Version{0x00, 0x00, 0x00, 0x00}, provider.spec["GENESIS_FORK_VERSION"])
+}
@@ -0,0 +1,123 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func writeNetworkConfig(t *testing.T, data string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+
+	return path
+}
+
+func TestGenesisForkVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		networkConfig string
+		expected      phase0.Version
+		err           string
+	}{
+		{
+			name:     "Mainnet",
+			expected: phase0.Version{0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			name:          "Input",
+			input:         "0x01017000",
+			networkConfig: "GENESIS_FORK_VERSION: 0x10000038\n",
+			expected:      phase0.Version{0x01, 0x01, 0x70, 0x00},
+		},
+		{
+			name:          "InputInvalid",
+			input:         "0x010170",
+			networkConfig: "GENESIS_FORK_VERSION: 0x10000038\n",
+			err:           "fork version must be exactly 4 bytes in length",
+		},
+		{
+			name:          "NetworkConfig",
+			networkConfig: "CONFIG_NAME: 'devnet'\nGENESIS_FORK_VERSION: 0x10000038\n",
+			expected:      phase0.Version{0x10, 0x00, 0x00, 0x38},
+		},
+		{
+			name:          "NetworkConfigMissingForkVersion",
+			networkConfig: "CONFIG_NAME: 'devnet'\n",
+			err:           "network config missing GENESIS_FORK_VERSION",
+		},
+		{
+			name:          "NetworkConfigInvalid",
+			networkConfig: "- a\n",
+			err:           "invalid network config: configuration is not a map",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			if test.networkConfig != "" {
+				viper.Set("network-config", writeNetworkConfig(t, test.networkConfig))
+			}
+
+			forkVersion, err := util.GenesisForkVersion(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, forkVersion)
+			}
+		})
+	}
+}
+
+func TestNetworkConfigMissing(t *testing.T) {
+	viper.Reset()
+	viper.Set("network-config", filepath.Join(t.TempDir(), "missing.yaml"))
+
+	_, err := util.NetworkConfig()
+	require.ErrorContains(t, err, "failed to read network config")
+}
+
+func TestNetworkFromNetworkConfig(t *testing.T) {
+	viper.Reset()
+	viper.Set("network-config", writeNetworkConfig(t, "CONFIG_NAME: 'devnet'\nGENESIS_FORK_VERSION: 0x10000038\n"))
+	defer viper.Reset()
+
+	// Known networks are named from their deposit contract address.
+	network, err := util.Network(context.Background(), &specETH2Client{
+		address: testutil.HexToBytes("0x00000000219ab540356cbb839cbe05303d7705fa"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Mainnet", network)
+
+	// Unknown networks are named from the network configuration.
+	network, err = util.Network(context.Background(), &specETH2Client{
+		address: testutil.HexToBytes("0x1111111111111111111111111111111111111111"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "devnet", network)
+}
@@ -16,9 +16,11 @@ package util
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 )
 
@@ -34,7 +36,7 @@ var networks = map[string]string{
 }
 
 // Network returns the name of the network., calculated from the deposit contract information.
-// If not known, returns "Unknown".
+// If not known, returns the name in the network configuration if supplied, otherwise "Unknown".
 func Network(ctx context.Context, eth2Client eth2client.Service) (string, error) {
 	var address []byte
 
@@ -55,7 +57,18 @@ func Network(ctx context.Context, eth2Client eth2client.Service) (string, error)
 		address = depositContractAddress.([]byte)
 	}
 
-	return network(address), nil
+	name := network(address)
+	if name == "Unknown" {
+		config, err := NetworkConfig()
+		if err != nil {
+			return "", err
+		}
+		if configName, isString := config["CONFIG_NAME"].(string); isString && configName != "" {
+			name = configName
+		}
+	}
+
+	return name, nil
 }
 
 // network returns a network given an Ethereum 1 contract address.
@@ -65,3 +78,44 @@ func network(address []byte) string {
 	}
 	return "Unknown"
 }
+
+// CheckNetwork checks that the genesis validators root and fork version used
+// to sign an operation match those of the chain to which the client is
+// connected, to avoid broadcasting operations signed for a different network.
+func CheckNetwork(ctx context.Context,
+	eth2Client eth2client.Service,
+	genesisValidatorsRoot phase0.Root,
+	forkVersion phase0.Version,
+) error {
+	if eth2Client == nil {
+		return errors.New("no Ethereum 2 client supplied")
+	}
+
+	genesisProvider, isProvider := eth2Client.(eth2client.GenesisProvider)
+	if !isProvider {
+		return errors.New("client does not provide genesis information")
+	}
+	genesisResponse, err := genesisProvider.Genesis(ctx, &api.GenesisOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain genesis information")
+	}
+	if genesisResponse.Data.GenesisValidatorsRoot != genesisValidatorsRoot {
+		return fmt.Errorf("operations are for a different network: genesis validators root %#x does not match the beacon node's genesis validators root %#x", genesisValidatorsRoot, genesisResponse.Data.GenesisValidatorsRoot)
+	}
+
+	forkScheduleProvider, isProvider := eth2Client.(eth2client.ForkScheduleProvider)
+	if !isProvider {
+		return errors.New("client does not provide fork schedule")
+	}
+	forkScheduleResponse, err := forkScheduleProvider.ForkSchedule(ctx, &api.ForkScheduleOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain fork schedule")
+	}
+	for _, fork := range forkScheduleResponse.Data {
+		if fork.CurrentVersion == forkVersion || fork.PreviousVersion == forkVersion {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("operations are for a different network: fork version %#x is not in the beacon node's fork schedule", forkVersion)
+}
@@ -19,6 +19,8 @@ import (
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/require"
 	"github.com/wealdtech/ethdo/testutil"
 	"github.com/wealdtech/ethdo/util"
@@ -98,3 +100,94 @@ func TestNetworks(t *testing.T) {
 		})
 	}
 }
+
+// A mock Ethereum 2 client service that returns genesis and fork schedule information.
+type networkETH2Client struct {
+	specETH2Client
+	genesisValidatorsRoot phase0.Root
+	forkSchedule          []*phase0.Fork
+}
+
+// Genesis provides the genesis information of the chain.
+func (c *networkETH2Client) Genesis(_ context.Context, _ *api.GenesisOpts) (*api.Response[*apiv1.Genesis], error) {
+	return &api.Response[*apiv1.Genesis]{
+		Data: &apiv1.Genesis{
+			GenesisValidatorsRoot: c.genesisValidatorsRoot,
+		},
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+// ForkSchedule provides the fork schedule of the chain.
+func (c *networkETH2Client) ForkSchedule(_ context.Context, _ *api.ForkScheduleOpts) (*api.Response[[]*phase0.Fork], error) {
+	return &api.Response[[]*phase0.Fork]{
+		Data:     c.forkSchedule,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func TestCheckNetwork(t *testing.T) {
+	service := &networkETH2Client{
+		genesisValidatorsRoot: phase0.Root{0x4b, 0x36, 0x3d, 0xb9},
+		forkSchedule: []*phase0.Fork{
+			{PreviousVersion: phase0.Version{0x00}, CurrentVersion: phase0.Version{0x00}, Epoch: 0},
+			{PreviousVersion: phase0.Version{0x00}, CurrentVersion: phase0.Version{0x01}, Epoch: 10},
+			{PreviousVersion: phase0.Version{0x01}, CurrentVersion: phase0.Version{0x03}, Epoch: 20},
+		},
+	}
+
+	tests := []struct {
+		name                  string
+		service               eth2client.Service
+		genesisValidatorsRoot phase0.Root
+		forkVersion           phase0.Version
+		err                   string
+	}{
+		{
+			name: "Nil",
+			err:  "no Ethereum 2 client supplied",
+		},
+		{
+			name:    "NoGenesis",
+			service: &specETH2Client{},
+			err:     "client does not provide genesis information",
+		},
+		{
+			name:                  "GenesisValidatorsRootMismatch",
+			service:               service,
+			genesisValidatorsRoot: phase0.Root{0x04, 0x3d, 0xb0, 0xd9},
+			forkVersion:           phase0.Version{0x03},
+			err:                   "operations are for a different network: genesis validators root 0x043db0d900000000000000000000000000000000000000000000000000000000 does not match the beacon node's genesis validators root 0x4b363db900000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:                  "ForkVersionMismatch",
+			service:               service,
+			genesisValidatorsRoot: phase0.Root{0x4b, 0x36, 0x3d, 0xb9},
+			forkVersion:           phase0.Version{0x90, 0x00, 0x00, 0x69},
+			err:                   "operations are for a different network: fork version 0x90000069 is not in the beacon node's fork schedule",
+		},
+		{
+			name:                  "Genesis",
+			service:               service,
+			genesisValidatorsRoot: phase0.Root{0x4b, 0x36, 0x3d, 0xb9},
+			forkVersion:           phase0.Version{0x00},
+		},
+		{
+			name:                  "Capella",
+			service:               service,
+			genesisValidatorsRoot: phase0.Root{0x4b, 0x36, 0x3d, 0xb9},
+			forkVersion:           phase0.Version{0x03},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := util.CheckNetwork(context.Background(), test.service, test.genesisValidatorsRoot, test.forkVersion)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+)
+
+const (
+	// NodeCapabilityRewards is the capability to provide rewards.
+	NodeCapabilityRewards = "rewards"
+	// NodeCapabilityLightClient is the capability to provide light client data.
+	NodeCapabilityLightClient = "light client"
+	// NodeCapabilityDebug is the capability to provide debug data.
+	NodeCapabilityDebug = "debug"
+)
+
+// nodeCapabilityPaths are the paths used to probe for each capability.
+var nodeCapabilityPaths = map[string]string{
+	NodeCapabilityRewards:     "/eth/v1/beacon/rewards/blocks/head",
+	NodeCapabilityLightClient: "/eth/v1/beacon/light_client/finality_update",
+	NodeCapabilityDebug:       "/eth/v2/debug/beacon/heads",
+}
+
+// NodeCapabilities are the capabilities that can be probed, in display order.
+var NodeCapabilities = []string{
+	NodeCapabilityRewards,
+	NodeCapabilityLightClient,
+	NodeCapabilityDebug,
+}
+
+// NodeCapability is the result of probing a beacon node for a capability.
+type NodeCapability struct {
+	Name      string `json:"name"`
+	Supported bool   `json:"supported"`
+	// Reason is the reason the capability is not supported.
+	Reason string `json:"reason,omitempty"`
+}
+
+// ProbeNodeCapability probes the beacon node for the given capability.
+func ProbeNodeCapability(ctx context.Context, client eth2client.Service, name string) (*NodeCapability, error) {
+	path, exists := nodeCapabilityPaths[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown capability %s", name)
+	}
+
+	capability := &NodeCapability{
+		Name: name,
+	}
+	body, err := BeaconNodeGet(ctx, client, path, "application/json")
+	if err != nil {
+		capability.Reason = err.Error()
+
+		return capability, nil
+	}
+	body.Close()
+	capability.Supported = true
+
+	return capability, nil
+}
+
+// ProbeNodeCapabilities probes the beacon node for all known capabilities.
+func ProbeNodeCapabilities(ctx context.Context, client eth2client.Service) ([]*NodeCapability, error) {
+	capabilities := make([]*NodeCapability, 0, len(NodeCapabilities))
+	for _, name := range NodeCapabilities {
+		capability, err := ProbeNodeCapability(ctx, client, name)
+		if err != nil {
+			return nil, err
+		}
+		capabilities = append(capabilities, capability)
+	}
+
+	return capabilities, nil
+}
+
+// ExplainNodeCapabilityFailure adds an explanation to the error if it was caused by
+// the beacon node not supporting the given capability.
+func ExplainNodeCapabilityFailure(ctx context.Context, client eth2client.Service, name string, err error) error {
+	capability, probeErr := ProbeNodeCapability(ctx, client, name)
+	if probeErr != nil || capability.Supported {
+		return err
+	}
+
+	return fmt.Errorf("%w (beacon node does not support the %s API; see \"ethdo node info\" for details)", err, name)
+}
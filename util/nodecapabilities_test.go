@@ -0,0 +1,61 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestProbeNodeCapabilities(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/beacon/rewards/blocks/head":
+			_, _ = w.Write([]byte(`{"data":{}}`))
+		case "/eth/v2/debug/beacon/heads":
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		default:
+			http.Error(w, `{"code":404,"message":"not found"}`, http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &addressETH2Client{address: server.URL}
+
+	capabilities, err := util.ProbeNodeCapabilities(context.Background(), client)
+	require.NoError(t, err)
+	require.Len(t, capabilities, 3)
+	require.Equal(t, util.NodeCapabilityRewards, capabilities[0].Name)
+	require.True(t, capabilities[0].Supported)
+	require.Equal(t, util.NodeCapabilityLightClient, capabilities[1].Name)
+	require.False(t, capabilities[1].Supported)
+	require.Contains(t, capabilities[1].Reason, "status 404")
+	require.Equal(t, util.NodeCapabilityDebug, capabilities[2].Name)
+	require.True(t, capabilities[2].Supported)
+
+	_, err = util.ProbeNodeCapability(context.Background(), client, "unknown")
+	require.EqualError(t, err, "unknown capability unknown")
+
+	baseErr := errors.New("failed")
+	require.Equal(t, baseErr, util.ExplainNodeCapabilityFailure(context.Background(), client, util.NodeCapabilityDebug, baseErr))
+	err = util.ExplainNodeCapabilityFailure(context.Background(), client, util.NodeCapabilityLightClient, baseErr)
+	require.ErrorIs(t, err, baseErr)
+	require.EqualError(t, err, `failed (beacon node does not support the light client API; see "ethdo node info" for details)`)
+}
@@ -0,0 +1,37 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// ErrOffline is returned when a network connection is attempted in offline mode.
+var ErrOffline = errors.New("network access is not permitted in offline mode")
+
+// Offline returns true if ethdo is running in offline mode.
+func Offline() bool {
+	return viper.GetBool("offline")
+}
+
+// offlineTransport refuses all requests.
+type offlineTransport struct{}
+
+// RoundTrip implements http.RoundTripper.
+func (*offlineTransport) RoundTrip(_ *http.Request) (*http.Response, error) {
+	return nil, ErrOffline
+}
@@ -0,0 +1,44 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOffline(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("offline", true)
+	viper.Set("remote", "localhost:9091")
+
+	_, err := ConnectToBeaconNode(context.Background(), &ConnectOpts{
+		Address: "http://localhost:5052",
+		Timeout: time.Second,
+	})
+	require.ErrorIs(t, err, ErrOffline)
+
+	_, err = WalletFromPath(context.Background(), "Test/Account")
+	require.ErrorIs(t, err, ErrOffline)
+
+	client := &http.Client{Transport: &offlineTransport{}}
+	_, err = client.Get("http://localhost:5052/eth/v1/node/version")
+	require.ErrorIs(t, err, ErrOffline)
+}
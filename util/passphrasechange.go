@@ -0,0 +1,145 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// passphraseChange is the pending change for a single account.
+type passphraseChange struct {
+	name     string
+	id       [16]byte
+	original []byte
+	updated  []byte
+}
+
+// ChangeAccountPassphrases re-encrypts the secret keys of the supplied
+// accounts with a new passphrase.  All accounts are decrypted and
+// re-encrypted before any are stored, and if storing any account fails the
+// accounts that have already been stored are restored, so either all of the
+// accounts are changed or none of them are.
+func ChangeAccountPassphrases(_ context.Context,
+	wallet e2wtypes.Wallet,
+	accounts []e2wtypes.Account,
+	passphrases []string,
+	newPassphrase string,
+) error {
+	storeProvider, isStoreProvider := wallet.(e2wtypes.StoreProvider)
+	if !isStoreProvider {
+		return errors.New("wallet does not support changing account passphrases")
+	}
+	store := storeProvider.Store()
+	walletIDProvider, isWalletIDProvider := wallet.(e2wtypes.WalletIDProvider)
+	if !isWalletIDProvider {
+		return errors.New("wallet does not provide its ID")
+	}
+	walletID := walletIDProvider.ID()
+
+	encryptor := keystorev4.New()
+	changes := make([]*passphraseChange, 0, len(accounts))
+	for _, account := range accounts {
+		accountIDProvider, isAccountIDProvider := account.(e2wtypes.AccountIDProvider)
+		if !isAccountIDProvider {
+			return fmt.Errorf("account %s does not provide its ID", account.Name())
+		}
+		change := &passphraseChange{
+			name: account.Name(),
+			id:   accountIDProvider.ID(),
+		}
+		var err error
+		change.original, err = store.RetrieveAccount(walletID, change.id)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to retrieve account %s", change.name))
+		}
+		change.updated, err = reencryptAccount(encryptor, change.original, passphrases, newPassphrase)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to change passphrase for account %s", change.name))
+		}
+		changes = append(changes, change)
+	}
+
+	for i, change := range changes {
+		if err := store.StoreAccount(walletID, change.id, change.updated); err != nil {
+			// Restore the accounts that have already been changed.
+			for _, stored := range changes[:i] {
+				if restoreErr := store.StoreAccount(walletID, stored.id, stored.original); restoreErr != nil {
+					Log.Error().Err(restoreErr).Str("account", stored.name).Msg("Failed to restore account after failed passphrase change")
+				}
+			}
+			return errors.Wrap(err, fmt.Sprintf("failed to store account %s; no passphrases have been changed", change.name))
+		}
+	}
+
+	return nil
+}
+
+// reencryptAccount re-encrypts the secret key of stored account data with a new passphrase.
+func reencryptAccount(encryptor *keystorev4.Encryptor,
+	original []byte,
+	passphrases []string,
+	newPassphrase string,
+) (
+	[]byte,
+	error,
+) {
+	data, err := parseAccountData(original)
+	if err != nil {
+		return nil, err
+	}
+	if encryptorName, exists := data["encryptor"]; exists && encryptorName != encryptor.Name() && encryptorName != encryptor.String() {
+		return nil, fmt.Errorf("unsupported encryptor %v", encryptorName)
+	}
+	crypto, isMap := data["crypto"].(map[string]any)
+	if !isMap {
+		return nil, errors.New("account does not contain an encrypted key")
+	}
+
+	var secret []byte
+	for _, passphrase := range passphrases {
+		var err error
+		secret, err = encryptor.Decrypt(crypto, passphrase)
+		if err == nil {
+			break
+		}
+	}
+	if secret == nil {
+		return nil, errors.New("failed to decrypt with the supplied passphrases")
+	}
+
+	newCrypto, err := encryptor.Encrypt(secret, newPassphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt")
+	}
+	// Ensure that the new passphrase works before replacing the old one.
+	check, err := encryptor.Decrypt(newCrypto, newPassphrase)
+	if err != nil || !bytes.Equal(check, secret) {
+		return nil, errors.New("failed to confirm encryption with new passphrase")
+	}
+	data["crypto"] = newCrypto
+
+	updated, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate account")
+	}
+
+	return updated, nil
+}
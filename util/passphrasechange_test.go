@@ -0,0 +1,116 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// failingStore is a store that fails a single account write after a given number of writes.
+type failingStore struct {
+	e2wtypes.Store
+	writes    int
+	failAfter int
+}
+
+func (s *failingStore) StoreAccount(walletID uuid.UUID, accountID uuid.UUID, data []byte) error {
+	s.writes++
+	if s.failAfter > 0 && s.writes == s.failAfter+1 {
+		return errors.New("store failed")
+	}
+
+	return s.Store.StoreAccount(walletID, accountID, data)
+}
+
+func TestChangeAccountPassphrases(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		passphrases []string
+		failAfter   int
+		changed     bool
+		err         string
+	}{
+		{
+			name:        "WrongPassphrase",
+			passphrases: []string{"wrong"},
+			err:         "failed to change passphrase for account Interop 0: failed to decrypt with the supplied passphrases",
+		},
+		{
+			name:        "PartialPassphrases",
+			passphrases: []string{"pass0"},
+			err:         "failed to change passphrase for account Interop 1: failed to decrypt with the supplied passphrases",
+		},
+		{
+			name:        "StoreFails",
+			passphrases: []string{"pass0", "pass1"},
+			failAfter:   1,
+			err:         "failed to store account Interop 1; no passphrases have been changed: store failed",
+		},
+		{
+			name:        "Good",
+			passphrases: []string{"pass0", "pass1"},
+			changed:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := &failingStore{Store: scratch.New()}
+			wallet, err := nd.CreateWallet(ctx, "Test wallet", store, keystorev4.New())
+			require.NoError(t, err)
+			require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+			account0, err := wallet.(e2wtypes.WalletAccountImporter).ImportAccount(ctx, "Interop 0", testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"), []byte("pass0"))
+			require.NoError(t, err)
+			account1, err := wallet.(e2wtypes.WalletAccountImporter).ImportAccount(ctx, "Interop 1", testutil.HexToBytes("0x51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000"), []byte("pass1"))
+			require.NoError(t, err)
+			store.writes = 0
+			store.failAfter = test.failAfter
+
+			err = util.ChangeAccountPassphrases(ctx, wallet, []e2wtypes.Account{account0, account1}, test.passphrases, "new passphrase")
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			// Reopen the wallet to pick up the stored accounts.
+			store.failAfter = 0
+			reopened, err := nd.OpenWallet(ctx, "Test wallet", store, keystorev4.New())
+			require.NoError(t, err)
+			for account := range reopened.Accounts(ctx) {
+				locker := account.(e2wtypes.AccountLocker)
+				if test.changed {
+					require.NoError(t, locker.Unlock(ctx, []byte("new passphrase")))
+				} else {
+					require.Error(t, locker.Unlock(ctx, []byte("new passphrase")))
+				}
+			}
+		})
+	}
+}
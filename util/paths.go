@@ -0,0 +1,105 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// maxPathRangeExpansion is the maximum number of paths that a path range can expand to.
+const maxPathRangeExpansion = 10000
+
+// pathRangeRegex is the regular expression that matches a path range component.
+var pathRangeRegex = regexp.MustCompile(`^\{([0-9]+)\.\.([0-9]+)\}$`)
+
+// derivationPathRegex is the regular expression that matches a derivation path.
+var derivationPathRegex = regexp.MustCompile(`^m(/[0-9]+)+$`)
+
+// ValidateDerivationPath checks that a derivation path is well-formed.  Paths
+// that are well-formed but do not follow EIP-2334 are accepted, to allow
+// interoperation with wallets that do not follow the standard, but return a
+// warning that should be shown to the user.
+func ValidateDerivationPath(path string) (string, error) {
+	if !derivationPathRegex.MatchString(path) {
+		return "", errors.New("path does not match expected format m/…")
+	}
+	components := strings.Split(path, "/")[1:]
+	for _, component := range components {
+		if _, err := strconv.ParseUint(component, 10, 32); err != nil {
+			return "", fmt.Errorf("path component %s is out of range", component)
+		}
+	}
+
+	// EIP-2334 paths are of the form m/12381/3600/<account>/<use>[/…].
+	if len(components) < 4 || components[0] != "12381" || components[1] != "3600" {
+		return fmt.Sprintf("path %s does not follow EIP-2334; keys derived from it will not match those generated by standard tools", path), nil
+	}
+
+	return "", nil
+}
+
+// ExpandPathRange expands a path containing ranges of the form {start..end}
+// as path components in to the individual paths, for example
+// m/12381/3600/{0..2}/0/0 expands to m/12381/3600/0/0/0, m/12381/3600/1/0/0
+// and m/12381/3600/2/0/0.  Ranges are inclusive.  A path without ranges is
+// returned unchanged.
+func ExpandPathRange(path string) ([]string, error) {
+	paths := []string{""}
+	for i, component := range strings.Split(path, "/") {
+		values := []string{component}
+		if strings.ContainsAny(component, "{}") {
+			match := pathRangeRegex.FindStringSubmatch(component)
+			if match == nil {
+				return nil, fmt.Errorf("invalid range %q in path", component)
+			}
+			start, err := strconv.ParseUint(match[1], 10, 32)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid range start")
+			}
+			end, err := strconv.ParseUint(match[2], 10, 32)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid range end")
+			}
+			if end < start {
+				return nil, fmt.Errorf("range %q in path ends before it starts", component)
+			}
+			if (end-start+1)*uint64(len(paths)) > maxPathRangeExpansion {
+				return nil, fmt.Errorf("path expands to more than %d paths", maxPathRangeExpansion)
+			}
+			values = make([]string, 0, end-start+1)
+			for value := start; value <= end; value++ {
+				values = append(values, strconv.FormatUint(value, 10))
+			}
+		}
+
+		expanded := make([]string, 0, len(paths)*len(values))
+		for _, prefix := range paths {
+			for _, value := range values {
+				if i == 0 {
+					expanded = append(expanded, value)
+				} else {
+					expanded = append(expanded, fmt.Sprintf("%s/%s", prefix, value))
+				}
+			}
+		}
+		paths = expanded
+	}
+
+	return paths, nil
+}
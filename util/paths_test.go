@@ -0,0 +1,158 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestExpandPathRange(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		paths []string
+		err   string
+	}{
+		{
+			name:  "NoRange",
+			path:  "m/12381/3600/0/0/0",
+			paths: []string{"m/12381/3600/0/0/0"},
+		},
+		{
+			name:  "Range",
+			path:  "m/12381/3600/{0..2}/0/0",
+			paths: []string{"m/12381/3600/0/0/0", "m/12381/3600/1/0/0", "m/12381/3600/2/0/0"},
+		},
+		{
+			name:  "SingleValueRange",
+			path:  "m/12381/3600/{5..5}/0/0",
+			paths: []string{"m/12381/3600/5/0/0"},
+		},
+		{
+			name:  "MultipleRanges",
+			path:  "m/12381/3600/{0..1}/{0..1}",
+			paths: []string{"m/12381/3600/0/0", "m/12381/3600/0/1", "m/12381/3600/1/0", "m/12381/3600/1/1"},
+		},
+		{
+			name: "Reversed",
+			path: "m/12381/3600/{2..1}/0/0",
+			err:  `range "{2..1}" in path ends before it starts`,
+		},
+		{
+			name: "Malformed",
+			path: "m/12381/3600/{0-2}/0/0",
+			err:  `invalid range "{0-2}" in path`,
+		},
+		{
+			name: "PartialComponent",
+			path: "m/12381/3600/1{0..2}/0/0",
+			err:  `invalid range "1{0..2}" in path`,
+		},
+		{
+			name: "TooLarge",
+			path: "m/12381/3600/{0..100}/{0..100}/0",
+			err:  "path expands to more than 10000 paths",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			paths, err := util.ExpandPathRange(test.path)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.paths, paths)
+			}
+		})
+	}
+}
+
+func TestValidateDerivationPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		warning bool
+		err     string
+	}{
+		{
+			name: "Empty",
+			path: "",
+			err:  "path does not match expected format m/…",
+		},
+		{
+			name: "MasterOnly",
+			path: "m",
+			err:  "path does not match expected format m/…",
+		},
+		{
+			name: "BadMaster",
+			path: "n/12381/3600/0/0",
+			err:  "path does not match expected format m/…",
+		},
+		{
+			name: "TrailingSlash",
+			path: "m/12381/3600/0/0/",
+			err:  "path does not match expected format m/…",
+		},
+		{
+			name: "Hardened",
+			path: "m/12381'/3600'/0'/0'",
+			err:  "path does not match expected format m/…",
+		},
+		{
+			name: "OutOfRange",
+			path: "m/12381/3600/4294967296/0",
+			err:  "path component 4294967296 is out of range",
+		},
+		{
+			name: "Withdrawal",
+			path: "m/12381/3600/0/0",
+		},
+		{
+			name: "Signing",
+			path: "m/12381/3600/10/0/0",
+		},
+		{
+			name:    "NonStandardPurpose",
+			path:    "m/44/3600/0/0/0",
+			warning: true,
+		},
+		{
+			name:    "NonStandardCoin",
+			path:    "m/12381/60/0/0/0",
+			warning: true,
+		},
+		{
+			name:    "Short",
+			path:    "m/12381/3600/0",
+			warning: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			warning, err := util.ValidateDerivationPath(test.path)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.warning, warning != "")
+			}
+		})
+	}
+}
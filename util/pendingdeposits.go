@@ -0,0 +1,61 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// EstimatePendingDepositEpochs estimates the epoch at which each pending deposit
+// will be processed, following the rules for processing pending deposits.
+// The estimate assumes that the chain continues to finalize and that the
+// churn remains constant.
+func EstimatePendingDepositEpochs(deposits []*PendingDeposit,
+	epoch phase0.Epoch,
+	balanceToConsume phase0.Gwei,
+	churn phase0.Gwei,
+	maxPerEpoch uint64,
+) []phase0.Epoch {
+	res := make([]phase0.Epoch, len(deposits))
+	if churn == 0 || maxPerEpoch == 0 {
+		return res
+	}
+
+	available := balanceToConsume + churn
+	processed := phase0.Gwei(0)
+	count := uint64(0)
+	for i := 0; i < len(deposits); {
+		if count < maxPerEpoch && processed+deposits[i].Amount <= available {
+			processed += deposits[i].Amount
+			count++
+			res[i] = epoch
+			i++
+
+			continue
+		}
+
+		// Any churn left unused because the next deposit did not fit is
+		// carried over to the next epoch.
+		if count < maxPerEpoch {
+			available = available - processed + churn
+		} else {
+			available = churn
+		}
+		processed = 0
+		count = 0
+		epoch++
+	}
+
+	return res
+}
@@ -0,0 +1,80 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestEstimatePendingDepositEpochs(t *testing.T) {
+	deposit := func(amount phase0.Gwei) *util.PendingDeposit {
+		return &util.PendingDeposit{Amount: amount}
+	}
+
+	tests := []struct {
+		name             string
+		deposits         []*util.PendingDeposit
+		balanceToConsume phase0.Gwei
+		churn            phase0.Gwei
+		maxPerEpoch      uint64
+		expected         []phase0.Epoch
+	}{
+		{
+			name:        "Empty",
+			churn:       128000000000,
+			maxPerEpoch: 16,
+			expected:    []phase0.Epoch{},
+		},
+		{
+			name:        "ChurnLimited",
+			deposits:    []*util.PendingDeposit{deposit(32000000000), deposit(32000000000), deposit(32000000000), deposit(32000000000), deposit(32000000000)},
+			churn:       64000000000,
+			maxPerEpoch: 16,
+			expected:    []phase0.Epoch{100, 100, 101, 101, 102},
+		},
+		{
+			name:        "CountLimited",
+			deposits:    []*util.PendingDeposit{deposit(1000000000), deposit(1000000000), deposit(1000000000)},
+			churn:       64000000000,
+			maxPerEpoch: 2,
+			expected:    []phase0.Epoch{100, 100, 101},
+		},
+		{
+			name:             "BalanceToConsume",
+			deposits:         []*util.PendingDeposit{deposit(32000000000), deposit(32000000000)},
+			balanceToConsume: 32000000000,
+			churn:            32000000000,
+			maxPerEpoch:      16,
+			expected:         []phase0.Epoch{100, 100},
+		},
+		{
+			name:        "LargeDeposit",
+			deposits:    []*util.PendingDeposit{deposit(2048000000000), deposit(1000000000)},
+			churn:       256000000000,
+			maxPerEpoch: 16,
+			expected:    []phase0.Epoch{107, 108},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := util.EstimatePendingDepositEpochs(test.deposits, 100, test.balanceToConsume, test.churn, test.maxPerEpoch)
+			require.Equal(t, test.expected, res)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// ValidatorPendingOperations are the operations for a validator that are
+// awaiting processing by the chain.
+type ValidatorPendingOperations struct {
+	Deposits           []*PendingDeposit
+	PartialWithdrawals []*PendingPartialWithdrawal
+	// ConsolidationsTo are the consolidations for which the validator is the source.
+	ConsolidationsTo []*PendingConsolidation
+	// ConsolidationsFrom are the consolidations for which the validator is the target.
+	ConsolidationsFrom []*PendingConsolidation
+}
+
+// ObtainValidatorPendingOperations obtains the pending operations for the
+// validator with the given index and public key from an Electra state.
+func ObtainValidatorPendingOperations(ctx context.Context,
+	client eth2client.Service,
+	stateID string,
+	index phase0.ValidatorIndex,
+	pubKey phase0.BLSPubKey,
+) (
+	*ValidatorPendingOperations,
+	error,
+) {
+	res := &ValidatorPendingOperations{}
+	if err := StreamBeaconState(ctx, client, stateID, res.handler(index, pubKey)); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// handler returns a state stream handler that gathers the pending operations for the validator.
+func (o *ValidatorPendingOperations) handler(index phase0.ValidatorIndex, pubKey phase0.BLSPubKey) *StateStreamHandler {
+	o.Deposits = make([]*PendingDeposit, 0)
+	o.PartialWithdrawals = make([]*PendingPartialWithdrawal, 0)
+	o.ConsolidationsTo = make([]*PendingConsolidation, 0)
+	o.ConsolidationsFrom = make([]*PendingConsolidation, 0)
+
+	return &StateStreamHandler{
+		PendingDeposit: func(_ uint64, deposit *PendingDeposit) error {
+			if deposit.PubKey == pubKey {
+				o.Deposits = append(o.Deposits, deposit)
+			}
+			return nil
+		},
+		PendingPartialWithdrawal: func(_ uint64, withdrawal *PendingPartialWithdrawal) error {
+			if withdrawal.ValidatorIndex == index {
+				o.PartialWithdrawals = append(o.PartialWithdrawals, withdrawal)
+			}
+			return nil
+		},
+		PendingConsolidation: func(_ uint64, consolidation *PendingConsolidation) error {
+			if consolidation.SourceIndex == index {
+				o.ConsolidationsTo = append(o.ConsolidationsTo, consolidation)
+			}
+			if consolidation.TargetIndex == index {
+				o.ConsolidationsFrom = append(o.ConsolidationsFrom, consolidation)
+			}
+			return nil
+		},
+	}
+}
+
+// DepositsAmount returns the total amount of the pending deposits.
+func (o *ValidatorPendingOperations) DepositsAmount() phase0.Gwei {
+	amount := phase0.Gwei(0)
+	for _, deposit := range o.Deposits {
+		amount += deposit.Amount
+	}
+
+	return amount
+}
+
+// PartialWithdrawalsAmount returns the total amount of the pending partial withdrawals.
+func (o *ValidatorPendingOperations) PartialWithdrawalsAmount() phase0.Gwei {
+	amount := phase0.Gwei(0)
+	for _, withdrawal := range o.PartialWithdrawals {
+		amount += withdrawal.Amount
+	}
+
+	return amount
+}
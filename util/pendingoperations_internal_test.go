@@ -0,0 +1,50 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatorPendingOperations(t *testing.T) {
+	pubKey := phase0.BLSPubKey{0x01}
+	deposits := []*PendingDeposit{
+		{PubKey: pubKey, WithdrawalCredentials: make([]byte, 32), Amount: 1000000000},
+		{PubKey: phase0.BLSPubKey{0x02}, WithdrawalCredentials: make([]byte, 32), Amount: 32000000000},
+		{PubKey: pubKey, WithdrawalCredentials: make([]byte, 32), Amount: 2000000000},
+	}
+	withdrawals := []*PendingPartialWithdrawal{
+		{ValidatorIndex: 3, Amount: 5000000000, WithdrawableEpoch: 100},
+		{ValidatorIndex: 4, Amount: 1000000000, WithdrawableEpoch: 100},
+	}
+	consolidations := []*PendingConsolidation{
+		{SourceIndex: 3, TargetIndex: 4},
+		{SourceIndex: 5, TargetIndex: 3},
+		{SourceIndex: 6, TargetIndex: 3},
+	}
+	data := testElectraStateData(t, deposits, withdrawals, consolidations)
+
+	operations := &ValidatorPendingOperations{}
+	require.NoError(t, DecodeBeaconStateStream(bytes.NewReader(data), testStateStreamConfig, operations.handler(3, pubKey)))
+	require.Equal(t, []*PendingDeposit{deposits[0], deposits[2]}, operations.Deposits)
+	require.EqualValues(t, 3000000000, operations.DepositsAmount())
+	require.Equal(t, withdrawals[:1], operations.PartialWithdrawals)
+	require.EqualValues(t, 5000000000, operations.PartialWithdrawalsAmount())
+	require.Equal(t, consolidations[:1], operations.ConsolidationsTo)
+	require.Equal(t, consolidations[1:], operations.ConsolidationsFrom)
+}
@@ -0,0 +1,456 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/miekg/pkcs11"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/go-bytesutil"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// PKCS11AccountConfig is the configuration for an account whose key is held
+// in a PKCS#11 token or HSM, configured under "pkcs11.accounts".
+type PKCS11AccountConfig struct {
+	// Name is the name of the account, in the format "wallet/account".
+	Name string `mapstructure:"name"`
+	// Library is the path to the PKCS#11 library for the token.
+	Library string `mapstructure:"library"`
+	// TokenLabel is the label of the token holding the key.
+	TokenLabel string `mapstructure:"token-label"`
+	// KeyLabel is the label of the key in the token.
+	KeyLabel string `mapstructure:"key-label"`
+	// Mechanism is the token's mechanism for BLS signing.
+	Mechanism string `mapstructure:"mechanism"`
+	// PublicKey is the public key of the account.
+	PublicKey string `mapstructure:"public-key"`
+}
+
+// PKCS11Token is a token that can sign with a BLS key.
+type PKCS11Token interface {
+	// Login logs in to the token.
+	Login(pin string) error
+	// Logout logs out of the token.
+	Logout() error
+	// Sign signs data with the key.
+	Sign(data []byte) ([]byte, error)
+	// Close closes the session with the token and releases its library.
+	Close() error
+}
+
+// PKCS11Account is an account whose key is held in a PKCS#11 token.  The
+// token generates the signature, so the private key is never available.
+// The token is closed when the account is locked, and reopened if required
+// when the account is unlocked.
+type PKCS11Account struct {
+	id       uuid.UUID
+	name     string
+	pubKey   e2types.PublicKey
+	token    PKCS11Token
+	open     func() (PKCS11Token, error)
+	mu       sync.Mutex
+	unlocked bool
+}
+
+// PKCS11AccountConfigs returns the configured PKCS#11 accounts.
+func PKCS11AccountConfigs() ([]*PKCS11AccountConfig, error) {
+	configs := make([]*PKCS11AccountConfig, 0)
+	if err := viper.UnmarshalKey("pkcs11.accounts", &configs); err != nil {
+		return nil, errors.Wrap(err, "invalid PKCS#11 configuration")
+	}
+
+	return configs, nil
+}
+
+// NewPKCS11Account creates a new account using a PKCS#11 token.
+func NewPKCS11Account(name string, pubKey e2types.PublicKey, token PKCS11Token) *PKCS11Account {
+	return &PKCS11Account{
+		id:     uuid.New(),
+		name:   name,
+		pubKey: pubKey,
+		token:  token,
+	}
+}
+
+// ID returns the account ID.
+func (a *PKCS11Account) ID() uuid.UUID {
+	return a.id
+}
+
+// Name returns the account name.
+func (a *PKCS11Account) Name() string {
+	return a.name
+}
+
+// PublicKey returns the account public key.
+func (a *PKCS11Account) PublicKey() e2types.PublicKey {
+	return a.pubKey
+}
+
+// Path returns the account path.
+func (a *PKCS11Account) Path() string {
+	return ""
+}
+
+// Lock locks the account, logging out of and closing the token.
+func (a *PKCS11Account) Lock(_ context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token == nil {
+		return nil
+	}
+	if a.unlocked {
+		if err := a.token.Logout(); err != nil {
+			return err
+		}
+		a.unlocked = false
+	}
+
+	return a.closeToken()
+}
+
+// Unlock unlocks the account, using the passphrase as the PIN for the token.
+func (a *PKCS11Account) Unlock(_ context.Context, passphrase []byte) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.unlocked {
+		return nil
+	}
+	if a.token == nil {
+		if a.open == nil {
+			return errors.New("token has been closed")
+		}
+		token, err := a.open()
+		if err != nil {
+			return err
+		}
+		a.token = token
+	}
+	if err := a.token.Login(string(passphrase)); err != nil {
+		return err
+	}
+	a.unlocked = true
+
+	return nil
+}
+
+// closeToken closes the token.  It must be called with the mutex held.
+func (a *PKCS11Account) closeToken() error {
+	token := a.token
+	a.token = nil
+
+	return token.Close()
+}
+
+// IsUnlocked returns true if the account is unlocked.
+func (a *PKCS11Account) IsUnlocked(_ context.Context) (bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.unlocked, nil
+}
+
+// Sign signs data with the token.
+func (a *PKCS11Account) Sign(_ context.Context, data []byte) (e2types.Signature, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.unlocked {
+		return nil, errors.New("locked")
+	}
+	sig, err := a.token.Sign(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "token failed to sign")
+	}
+	signature, err := e2types.BLSSignatureFromBytes(sig)
+	if err != nil {
+		return nil, errors.Wrap(err, "token returned an invalid signature")
+	}
+	// Ensure that the token signed with the expected key.
+	if !signature.Verify(data, a.pubKey) {
+		return nil, errors.New("token signature does not verify with the account public key")
+	}
+
+	return signature, nil
+}
+
+// parseAccountFromPKCS11 returns the PKCS#11 account with the given name, or
+// nil if there is no such account.
+func parseAccountFromPKCS11(ctx context.Context,
+	accountStr string,
+	supplementary []string,
+	unlock bool,
+) (
+	*PKCS11Account,
+	error,
+) {
+	configs, err := PKCS11AccountConfigs()
+	if err != nil {
+		return nil, err
+	}
+	var config *PKCS11AccountConfig
+	for i := range configs {
+		if configs[i].Name == accountStr {
+			config = configs[i]
+			break
+		}
+	}
+	if config == nil {
+		return nil, nil
+	}
+
+	data, err := bytesutil.FromHexString(config.PublicKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid public key for PKCS#11 account %s", config.Name)
+	}
+	pubKey, err := e2types.BLSPublicKeyFromBytes(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid public key for PKCS#11 account %s", config.Name)
+	}
+	// Open the token to check the configuration.
+	token, err := OpenPKCS11Token(config)
+	if err != nil {
+		return nil, err
+	}
+	account := NewPKCS11Account(config.Name, pubKey, token)
+	account.open = func() (PKCS11Token, error) {
+		return OpenPKCS11Token(config)
+	}
+	if !unlock {
+		// The token will be reopened if the account is unlocked.
+		account.mu.Lock()
+		err := account.closeToken()
+		account.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		return account, nil
+	}
+
+	// Supplementary will be the PIN(s).
+	if _, err := UnlockAccount(ctx, account, supplementary); err != nil {
+		_ = account.Lock(ctx)
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// pkcs11Token is a PKCS#11 token accessed through its library.
+type pkcs11Token struct {
+	ctx       *pkcs11.Ctx
+	library   string
+	session   pkcs11.SessionHandle
+	keyLabel  string
+	mechanism uint
+}
+
+var (
+	pkcs11LibrariesMu sync.Mutex
+	// pkcs11Libraries holds the number of open tokens for each initialized library.
+	pkcs11Libraries = make(map[string]int)
+)
+
+// OpenPKCS11Token opens a session with the token given in the configuration.
+func OpenPKCS11Token(config *PKCS11AccountConfig) (PKCS11Token, error) {
+	if config.Library == "" {
+		return nil, fmt.Errorf("no PKCS#11 library for account %s", config.Name)
+	}
+	if config.KeyLabel == "" {
+		return nil, fmt.Errorf("no key label for PKCS#11 account %s", config.Name)
+	}
+	mechanism, err := strconv.ParseUint(config.Mechanism, 0, 32)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid mechanism for PKCS#11 account %s", config.Name)
+	}
+
+	ctx := pkcs11.New(config.Library)
+	if ctx == nil {
+		return nil, fmt.Errorf("failed to load PKCS#11 library %s", config.Library)
+	}
+	opened := false
+	defer func() {
+		if !opened {
+			ctx.Destroy()
+		}
+	}()
+	if err := initializePKCS11Library(ctx, config.Library); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if !opened {
+			_ = finalizePKCS11Library(ctx, config.Library)
+		}
+	}()
+
+	slot, err := pkcs11TokenSlot(ctx, config.TokenLabel)
+	if err != nil {
+		return nil, err
+	}
+	session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open PKCS#11 session")
+	}
+	opened = true
+
+	return &pkcs11Token{
+		ctx:       ctx,
+		library:   config.Library,
+		session:   session,
+		keyLabel:  config.KeyLabel,
+		mechanism: uint(mechanism),
+	}, nil
+}
+
+// initializePKCS11Library initializes a PKCS#11 library, if it is not
+// already initialized by another token.
+func initializePKCS11Library(ctx *pkcs11.Ctx, library string) error {
+	pkcs11LibrariesMu.Lock()
+	defer pkcs11LibrariesMu.Unlock()
+
+	if pkcs11Libraries[library] == 0 {
+		if err := ctx.Initialize(); err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_CRYPTOKI_ALREADY_INITIALIZED)) {
+			return errors.Wrap(err, "failed to initialize PKCS#11 library")
+		}
+	}
+	pkcs11Libraries[library]++
+
+	return nil
+}
+
+// finalizePKCS11Library finalizes a PKCS#11 library once it is no longer
+// used by any token.
+func finalizePKCS11Library(ctx *pkcs11.Ctx, library string) error {
+	pkcs11LibrariesMu.Lock()
+	defer pkcs11LibrariesMu.Unlock()
+
+	pkcs11Libraries[library]--
+	if pkcs11Libraries[library] > 0 {
+		return nil
+	}
+	delete(pkcs11Libraries, library)
+	if err := ctx.Finalize(); err != nil {
+		return errors.Wrap(err, "failed to finalize PKCS#11 library")
+	}
+
+	return nil
+}
+
+// pkcs11TokenSlot returns the slot holding the token with the given label,
+// or the only slot with a token if no label is supplied.
+func pkcs11TokenSlot(ctx *pkcs11.Ctx, label string) (uint, error) {
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to obtain PKCS#11 slots")
+	}
+	if label == "" {
+		if len(slots) != 1 {
+			return 0, fmt.Errorf("%d PKCS#11 tokens present; a token label is required", len(slots))
+		}
+		return slots[0], nil
+	}
+	for _, slot := range slots {
+		info, err := ctx.GetTokenInfo(slot)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to obtain PKCS#11 token information")
+		}
+		if strings.TrimSpace(info.Label) == label {
+			return slot, nil
+		}
+	}
+
+	return 0, fmt.Errorf("PKCS#11 token %s not found", label)
+}
+
+// Login logs in to the token.
+func (t *pkcs11Token) Login(pin string) error {
+	if err := t.ctx.Login(t.session, pkcs11.CKU_USER, pin); err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN)) {
+		return errors.Wrap(err, "failed to log in to PKCS#11 token")
+	}
+
+	return nil
+}
+
+// Logout logs out of the token.
+func (t *pkcs11Token) Logout() error {
+	if err := t.ctx.Logout(t.session); err != nil {
+		return errors.Wrap(err, "failed to log out of PKCS#11 token")
+	}
+
+	return nil
+}
+
+// Close closes the session with the token and releases its library.
+func (t *pkcs11Token) Close() error {
+	defer t.ctx.Destroy()
+
+	if err := t.ctx.CloseSession(t.session); err != nil {
+		_ = finalizePKCS11Library(t.ctx, t.library)
+		return errors.Wrap(err, "failed to close PKCS#11 session")
+	}
+
+	return finalizePKCS11Library(t.ctx, t.library)
+}
+
+// Sign signs data with the key.
+func (t *pkcs11Token) Sign(data []byte) ([]byte, error) {
+	key, err := t.key()
+	if err != nil {
+		return nil, err
+	}
+	if err := t.ctx.SignInit(t.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(t.mechanism, nil)}, key); err != nil {
+		return nil, errors.Wrap(err, "failed to initialize PKCS#11 signing")
+	}
+	signature, err := t.ctx.Sign(t.session, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign with PKCS#11 token")
+	}
+
+	return signature, nil
+}
+
+// key returns the handle of the private key.
+func (t *pkcs11Token) key() (pkcs11.ObjectHandle, error) {
+	if err := t.ctx.FindObjectsInit(t.session, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, t.keyLabel),
+	}); err != nil {
+		return 0, errors.Wrap(err, "failed to search PKCS#11 token")
+	}
+	objects, _, err := t.ctx.FindObjects(t.session, 2)
+	if finalErr := t.ctx.FindObjectsFinal(t.session); err == nil && finalErr != nil {
+		err = finalErr
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to search PKCS#11 token")
+	}
+	switch len(objects) {
+	case 0:
+		return 0, fmt.Errorf("key %s not found in PKCS#11 token", t.keyLabel)
+	case 1:
+		return objects[0], nil
+	default:
+		return 0, fmt.Errorf("multiple keys %s found in PKCS#11 token", t.keyLabel)
+	}
+}
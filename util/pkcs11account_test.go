@@ -0,0 +1,166 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// mockPKCS11Token is a token that signs with a local key.
+type mockPKCS11Token struct {
+	pin      string
+	key      e2types.PrivateKey
+	loggedIn bool
+	closed   bool
+}
+
+func (t *mockPKCS11Token) Login(pin string) error {
+	if pin != t.pin {
+		return errors.New("CKR_PIN_INCORRECT")
+	}
+	t.loggedIn = true
+
+	return nil
+}
+
+func (t *mockPKCS11Token) Logout() error {
+	t.loggedIn = false
+
+	return nil
+}
+
+func (t *mockPKCS11Token) Close() error {
+	t.closed = true
+
+	return nil
+}
+
+func (t *mockPKCS11Token) Sign(data []byte) ([]byte, error) {
+	if !t.loggedIn {
+		return nil, errors.New("CKR_USER_NOT_LOGGED_IN")
+	}
+
+	return t.key.Sign(data).Marshal(), nil
+}
+
+func TestPKCS11Account(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx := context.Background()
+
+	key, err := e2types.BLSPrivateKeyFromBytes(testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"))
+	require.NoError(t, err)
+	otherKey, err := e2types.BLSPrivateKeyFromBytes(testutil.HexToBytes("0x3b1e738ec1d333fd9fc4b70c364d2e6bcfa1ac4dec6ba7b4c41bb7ec4b99c1d1"))
+	require.NoError(t, err)
+	data := testutil.HexToBytes("0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20")
+
+	token := &mockPKCS11Token{pin: "1234", key: key}
+	account := util.NewPKCS11Account("Withdrawals/Primary", key.PublicKey(), token)
+	require.Equal(t, "Withdrawals/Primary", account.Name())
+	require.Equal(t, key.PublicKey().Marshal(), account.PublicKey().Marshal())
+
+	_, err = account.Sign(ctx, data)
+	require.EqualError(t, err, "locked")
+
+	require.EqualError(t, account.Unlock(ctx, []byte("bad")), "CKR_PIN_INCORRECT")
+	unlocked, err := account.IsUnlocked(ctx)
+	require.NoError(t, err)
+	require.False(t, unlocked)
+
+	require.NoError(t, account.Unlock(ctx, []byte("1234")))
+	signature, err := account.Sign(ctx, data)
+	require.NoError(t, err)
+	require.True(t, signature.Verify(data, key.PublicKey()))
+
+	require.NoError(t, account.Lock(ctx))
+	require.False(t, token.loggedIn)
+	require.True(t, token.closed)
+	_, err = account.Sign(ctx, data)
+	require.EqualError(t, err, "locked")
+	require.EqualError(t, account.Unlock(ctx, []byte("1234")), "token has been closed")
+
+	// Token signs with a different key.
+	account = util.NewPKCS11Account("Withdrawals/Primary", key.PublicKey(), &mockPKCS11Token{pin: "1234", key: otherKey})
+	require.NoError(t, account.Unlock(ctx, []byte("1234")))
+	_, err = account.Sign(ctx, data)
+	require.EqualError(t, err, "token signature does not verify with the account public key")
+}
+
+func TestParseAccountPKCS11(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		accounts []map[string]any
+		err      string
+	}{
+		{
+			name: "PublicKeyInvalid",
+			accounts: []map[string]any{
+				{
+					"name":       "Withdrawals/Primary",
+					"library":    "/nonexistent/libpkcs11.so",
+					"key-label":  "primary",
+					"mechanism":  "0x80000001",
+					"public-key": "0x01",
+				},
+			},
+			err: "invalid public key for PKCS#11 account Withdrawals/Primary: public key must be 48 bytes",
+		},
+		{
+			name: "MechanismInvalid",
+			accounts: []map[string]any{
+				{
+					"name":       "Withdrawals/Primary",
+					"library":    "/nonexistent/libpkcs11.so",
+					"key-label":  "primary",
+					"mechanism":  "bad",
+					"public-key": "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+				},
+			},
+			err: `invalid mechanism for PKCS#11 account Withdrawals/Primary: strconv.ParseUint: parsing "bad": invalid syntax`,
+		},
+		{
+			name: "LibraryMissing",
+			accounts: []map[string]any{
+				{
+					"name":       "Withdrawals/Primary",
+					"library":    "/nonexistent/libpkcs11.so",
+					"key-label":  "primary",
+					"mechanism":  "0x80000001",
+					"public-key": "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+				},
+			},
+			err: "failed to load PKCS#11 library /nonexistent/libpkcs11.so",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			t.Cleanup(viper.Reset)
+			viper.Set("pkcs11.accounts", test.accounts)
+			_, err := util.ParseAccount(ctx, "Withdrawals/Primary", nil, false)
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// ProfileResult is the result of running a command against a profile.
+type ProfileResult struct {
+	Profile string
+	Stdout  []byte
+	Stderr  []byte
+	Err     error
+}
+
+// ProfileExists returns true if the named profile is defined in the configuration.
+func ProfileExists(name string) bool {
+	return len(viper.GetStringMap(fmt.Sprintf("profiles.%s", name))) > 0
+}
+
+// ApplyProfile applies the settings of the named profile, held in the
+// configuration as "profiles.<name>", for example:
+//
+//	profiles:
+//	  holesky:
+//	    connection: http://holesky-node:5052
+//	    network-config: /data/holesky/config.yaml
+//
+// Settings that have been explicitly supplied, as reported by isSet, are
+// not overridden by the profile.
+func ApplyProfile(name string, isSet func(key string) bool) error {
+	if !ProfileExists(name) {
+		return fmt.Errorf("unknown profile %s", name)
+	}
+
+	for key, value := range viper.GetStringMap(fmt.Sprintf("profiles.%s", name)) {
+		if isSet != nil && isSet(key) {
+			continue
+		}
+		viper.Set(key, value)
+	}
+
+	return nil
+}
+
+// ProfileArgs returns the supplied command-line arguments with any
+// profile selection removed, ready to be run against an individual profile.
+func ProfileArgs(args []string) []string {
+	res := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--profiles", args[i] == "--profile":
+			// Value is the following argument.
+			i++
+		case strings.HasPrefix(args[i], "--profiles="), strings.HasPrefix(args[i], "--profile="):
+		default:
+			res = append(res, args[i])
+		}
+	}
+
+	return res
+}
+
+// RunProfiles runs the executable with the supplied arguments once for
+// each profile concurrently, returning the results in the order of the profiles.
+func RunProfiles(ctx context.Context,
+	executable string,
+	args []string,
+	profiles []string,
+) []*ProfileResult {
+	results := make([]*ProfileResult, len(profiles))
+
+	var wg sync.WaitGroup
+	for i, profile := range profiles {
+		wg.Add(1)
+		go func(i int, profile string) {
+			defer wg.Done()
+
+			profileArgs := make([]string, 0, len(args)+1)
+			profileArgs = append(profileArgs, args...)
+			profileArgs = append(profileArgs, fmt.Sprintf("--profile=%s", profile))
+
+			var stdout, stderr bytes.Buffer
+			cmd := exec.CommandContext(ctx, executable, profileArgs...)
+			cmd.Stdout = &stdout
+			cmd.Stderr = &stderr
+			err := cmd.Run()
+			if err != nil {
+				err = errors.Wrap(err, fmt.Sprintf("command failed for profile %s", profile))
+			}
+
+			results[i] = &ProfileResult{
+				Profile: profile,
+				Stdout:  stdout.Bytes(),
+				Stderr:  stderr.Bytes(),
+				Err:     err,
+			}
+		}(i, profile)
+	}
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,96 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestApplyProfile(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("connection", "http://localhost:5052")
+	viper.Set("profiles", map[string]any{
+		"holesky": map[string]any{
+			"connection": "http://holesky:5052",
+			"timeout":    "10s",
+		},
+	})
+
+	require.EqualError(t, util.ApplyProfile("unknown", nil), "unknown profile unknown")
+
+	// Explicitly supplied settings are not overridden.
+	require.NoError(t, util.ApplyProfile("holesky", func(key string) bool { return key == "timeout" }))
+	require.Equal(t, "http://holesky:5052", viper.GetString("connection"))
+	require.Empty(t, viper.GetString("timeout"))
+}
+
+func TestProfileArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected []string
+	}{
+		{
+			name:     "Empty",
+			args:     []string{},
+			expected: []string{},
+		},
+		{
+			name:     "NoProfiles",
+			args:     []string{"validator", "info", "--validator=1"},
+			expected: []string{"validator", "info", "--validator=1"},
+		},
+		{
+			name:     "ProfilesEquals",
+			args:     []string{"validator", "info", "--profiles=mainnet,holesky", "--validator=1"},
+			expected: []string{"validator", "info", "--validator=1"},
+		},
+		{
+			name:     "ProfilesSeparate",
+			args:     []string{"validator", "info", "--profiles", "mainnet,holesky", "--validator=1"},
+			expected: []string{"validator", "info", "--validator=1"},
+		},
+		{
+			name:     "Profile",
+			args:     []string{"--profile=mainnet", "validator", "info", "--validator=1"},
+			expected: []string{"validator", "info", "--validator=1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, util.ProfileArgs(test.args))
+		})
+	}
+}
+
+func TestRunProfiles(t *testing.T) {
+	results := util.RunProfiles(context.Background(), "echo", []string{"chain", "info"}, []string{"mainnet", "holesky"})
+	require.Len(t, results, 2)
+	require.Equal(t, "mainnet", results[0].Profile)
+	require.NoError(t, results[0].Err)
+	require.Equal(t, "chain info --profile=mainnet\n", string(results[0].Stdout))
+	require.Equal(t, "holesky", results[1].Profile)
+	require.Equal(t, "chain info --profile=holesky\n", string(results[1].Stdout))
+
+	results = util.RunProfiles(context.Background(), "false", nil, []string{"mainnet"})
+	require.Len(t, results, 1)
+	require.ErrorContains(t, results[0].Err, "command failed for profile mainnet")
+}
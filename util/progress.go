@@ -0,0 +1,87 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+// progressWidth is the width of the progress bar, in characters.
+const progressWidth = 40
+
+// Progress is a progress bar for long-running operations, safe for
+// concurrent use.
+type Progress struct {
+	mu     sync.Mutex
+	writer io.Writer
+	label  string
+	total  int
+	done   int
+}
+
+// NewProgress creates a progress bar for the given number of items.
+func NewProgress(writer io.Writer, label string, total int) *Progress {
+	p := &Progress{
+		writer: writer,
+		label:  label,
+		total:  total,
+	}
+	p.draw()
+
+	return p
+}
+
+// Increment marks an item as complete.
+func (p *Progress) Increment() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.done < p.total {
+		p.done++
+	}
+	p.draw()
+}
+
+// Finish ends the progress bar, moving output to the next line.
+func (p *Progress) Finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	fmt.Fprintln(p.writer)
+}
+
+// draw draws the progress bar; it must be called with the lock held.
+func (p *Progress) draw() {
+	filled := progressWidth
+	if p.total > 0 {
+		filled = progressWidth * p.done / p.total
+	}
+	fmt.Fprintf(p.writer, "\r\033[K%s [%s%s] %d/%d", p.label, strings.Repeat("=", filled), strings.Repeat(" ", progressWidth-filled), p.done, p.total)
+}
+
+// ProgressWriter returns the writer to use for progress bars.  Progress is
+// only shown on an interactive terminal, and never in quiet mode.
+func ProgressWriter(quiet bool) io.Writer {
+	if quiet || !term.IsTerminal(int(os.Stderr.Fd())) {
+		return io.Discard
+	}
+
+	return os.Stderr
+}
@@ -0,0 +1,97 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestProgress(t *testing.T) {
+	tests := []struct {
+		name       string
+		total      int
+		increments int
+		expected   string
+	}{
+		{
+			name:     "Empty",
+			total:    0,
+			expected: "\r\033[Ktest [========================================] 0/0",
+		},
+		{
+			name:     "Start",
+			total:    4,
+			expected: "\r\033[Ktest [                                        ] 0/4",
+		},
+		{
+			name:       "Partial",
+			total:      4,
+			increments: 1,
+			expected:   "\r\033[Ktest [==========                              ] 1/4",
+		},
+		{
+			name:       "Complete",
+			total:      4,
+			increments: 4,
+			expected:   "\r\033[Ktest [========================================] 4/4",
+		},
+		{
+			name:       "Overrun",
+			total:      4,
+			increments: 6,
+			expected:   "\r\033[Ktest [========================================] 4/4",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			progress := util.NewProgress(buf, "test", test.total)
+			for i := 0; i < test.increments; i++ {
+				progress.Increment()
+			}
+			output := buf.String()
+			require.Equal(t, test.expected, output[strings.LastIndex(output, "\r"):])
+			progress.Finish()
+			require.True(t, strings.HasSuffix(buf.String(), "\n"))
+		})
+	}
+}
+
+func TestProgressConcurrent(t *testing.T) {
+	buf := &bytes.Buffer{}
+	progress := util.NewProgress(buf, "test", 100)
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			progress.Increment()
+		}()
+	}
+	wg.Wait()
+	output := buf.String()
+	require.True(t, strings.HasSuffix(output, "] 100/100"))
+}
+
+func TestProgressWriterQuiet(t *testing.T) {
+	require.Equal(t, io.Discard, util.ProgressWriter(true))
+}
@@ -0,0 +1,76 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// ProofOfPossession is the JSON representation of a proof of possession.
+type ProofOfPossession struct {
+	PublicKey   string `json:"pubkey"`
+	Signature   string `json:"signature"`
+	ForkVersion string `json:"fork_version,omitempty"`
+}
+
+// ProofOfPossessionRoot returns the object root that is signed to prove
+// possession of the private key for a public key.  This is the hash tree
+// root of the public key.
+func ProofOfPossessionRoot(pubKey []byte) (spec.Root, error) {
+	if len(pubKey) != 48 {
+		return spec.Root{}, errors.New("public key must be 48 bytes")
+	}
+
+	// The public key is merkleized as two chunks, the second zero-padded.
+	chunks := make([]byte, 64)
+	copy(chunks, pubKey)
+
+	return sha256.Sum256(chunks), nil
+}
+
+// ProofOfPossessionDomain returns the domain in which proofs of possession
+// are signed.  This is the deposit domain for the given genesis fork version,
+// as used to prove possession of validator keys in deposits.
+func ProofOfPossessionDomain(forkVersion spec.Version) spec.Domain {
+	var domain spec.Domain
+	copy(domain[:], e2types.Domain(e2types.DomainDeposit, forkVersion[:], e2types.ZeroGenesisValidatorsRoot))
+
+	return domain
+}
+
+// ParseForkVersion parses a genesis fork version, defaulting to mainnet if
+// none is supplied.
+func ParseForkVersion(input string) (spec.Version, error) {
+	var forkVersion spec.Version
+	if input == "" {
+		return forkVersion, nil
+	}
+
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return forkVersion, errors.Wrap(err, "failed to decode fork version")
+	}
+	if len(data) != 4 {
+		return forkVersion, errors.New("fork version must be exactly 4 bytes in length")
+	}
+	copy(forkVersion[:], data)
+
+	return forkVersion, nil
+}
@@ -0,0 +1,56 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestProofOfPossessionRoot(t *testing.T) {
+	_, err := util.ProofOfPossessionRoot([]byte{0x01})
+	require.EqualError(t, err, "public key must be 48 bytes")
+
+	pubKey := testutil.HexToBytes("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c")
+	root, err := util.ProofOfPossessionRoot(pubKey)
+	require.NoError(t, err)
+
+	// Confirm against the SSZ hash tree root of the public key.
+	hh := ssz.NewHasher()
+	hh.PutBytes(pubKey)
+	expected, err := hh.HashRoot()
+	require.NoError(t, err)
+	require.Equal(t, spec.Root(expected), root)
+}
+
+func TestParseForkVersion(t *testing.T) {
+	forkVersion, err := util.ParseForkVersion("")
+	require.NoError(t, err)
+	require.Equal(t, spec.Version{}, forkVersion)
+
+	forkVersion, err = util.ParseForkVersion("0x01017000")
+	require.NoError(t, err)
+	require.Equal(t, spec.Version{0x01, 0x01, 0x70, 0x00}, forkVersion)
+
+	_, err = util.ParseForkVersion("0x0101")
+	require.EqualError(t, err, "fork version must be exactly 4 bytes in length")
+
+	_, err = util.ParseForkVersion("invalid")
+	require.ErrorContains(t, err, "failed to decode fork version")
+}
@@ -0,0 +1,136 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// DeliveredPayload is a payload delivered to a proposer by a relay.
+type DeliveredPayload struct {
+	Slot                 phase0.Slot
+	BlockHash            phase0.Hash32
+	ProposerFeeRecipient bellatrix.ExecutionAddress
+	Value                *big.Int
+}
+
+type deliveredPayloadJSON struct {
+	Slot                 string `json:"slot"`
+	BlockHash            string `json:"block_hash"`
+	ProposerFeeRecipient string `json:"proposer_fee_recipient"`
+	Value                string `json:"value"`
+}
+
+// ParseRelayURL parses a relay URL.  Relay URLs commonly contain the relay's
+// public key as user information, which is removed.
+func ParseRelayURL(input string) (*url.URL, error) {
+	if !strings.HasPrefix(input, "http://") && !strings.HasPrefix(input, "https://") {
+		input = "https://" + input
+	}
+	relay, err := url.Parse(input)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid relay %s", input)
+	}
+	if relay.Host == "" {
+		return nil, fmt.Errorf("invalid relay %s", input)
+	}
+	relay.User = nil
+	relay.Path = strings.TrimSuffix(relay.Path, "/")
+
+	return relay, nil
+}
+
+// FetchDeliveredPayload fetches the payload delivered by the relay for the
+// given slot, returning nil if the relay did not deliver a payload.
+func FetchDeliveredPayload(ctx context.Context, relay *url.URL, slot phase0.Slot) (*DeliveredPayload, error) {
+	target := fmt.Sprintf("%s/relay/v1/data/bidtraces/proposer_payload_delivered?slot=%d", relay.String(), slot)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := HTTPClient().Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to call relay")
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read relay response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("relay returned status %d", resp.StatusCode)
+	}
+
+	var payloads []*deliveredPayloadJSON
+	if err := json.Unmarshal(data, &payloads); err != nil {
+		return nil, errors.Wrap(err, "invalid delivered payloads")
+	}
+	for _, payload := range payloads {
+		if payload.Slot != fmt.Sprintf("%d", slot) {
+			continue
+		}
+
+		return payload.parse()
+	}
+
+	return nil, nil
+}
+
+func (p *deliveredPayloadJSON) parse() (*DeliveredPayload, error) {
+	res := &DeliveredPayload{}
+
+	slot, err := strconv.ParseUint(p.Slot, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid slot in delivered payload")
+	}
+	res.Slot = phase0.Slot(slot)
+
+	blockHash, err := hex.DecodeString(strings.TrimPrefix(p.BlockHash, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid block hash in delivered payload")
+	}
+	if len(blockHash) != len(res.BlockHash) {
+		return nil, errors.New("incorrect length for block hash in delivered payload")
+	}
+	copy(res.BlockHash[:], blockHash)
+
+	res.ProposerFeeRecipient, err = ParseExecutionAddress(p.ProposerFeeRecipient)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid proposer fee recipient in delivered payload")
+	}
+
+	var success bool
+	res.Value, success = new(big.Int).SetString(p.Value, 10)
+	if !success {
+		return nil, errors.New("invalid value in delivered payload")
+	}
+
+	return res, nil
+}
@@ -0,0 +1,107 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestParseRelayURL(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		res   string
+		err   string
+	}{
+		{
+			name:  "Host",
+			input: "relay.example.com",
+			res:   "https://relay.example.com",
+		},
+		{
+			name:  "PubKey",
+			input: "https://0xac6e77dfe25ecd6110b8e780608cce0dab71fdd5ebea22a16c0205200f2f8e2e3ad3b71d3499c54ad14d6c21b41a37ae@relay.example.com/",
+			res:   "https://relay.example.com",
+		},
+		{
+			name:  "HTTP",
+			input: "http://localhost:18550",
+			res:   "http://localhost:18550",
+		},
+		{
+			name:  "Invalid",
+			input: "https://",
+			err:   "invalid relay https://",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.ParseRelayURL(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res.String())
+			}
+		})
+	}
+}
+
+func TestFetchDeliveredPayload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/relay/v1/data/bidtraces/proposer_payload_delivered" {
+			http.NotFound(w, r)
+			return
+		}
+		switch r.URL.Query().Get("slot") {
+		case "100":
+			_, _ = w.Write([]byte(`[{"slot":"100","parent_hash":"0x0101010101010101010101010101010101010101010101010101010101010101","block_hash":"0x0202020202020202020202020202020202020202020202020202020202020202","proposer_fee_recipient":"0x8f0844fd51e31ff6bf5babe21dccf7328e19fd9f","value":"123456789012345678"}]`))
+		case "102":
+			_, _ = w.Write([]byte(`[{"slot":"102","block_hash":"0x02","proposer_fee_recipient":"0x8f0844fd51e31ff6bf5babe21dccf7328e19fd9f","value":"1"}]`))
+		case "103":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+
+	relay, err := util.ParseRelayURL(server.URL)
+	require.NoError(t, err)
+
+	payload, err := util.FetchDeliveredPayload(context.Background(), relay, 100)
+	require.NoError(t, err)
+	require.NotNil(t, payload)
+	require.Equal(t, "0x0202020202020202020202020202020202020202020202020202020202020202", payload.BlockHash.String())
+	require.Equal(t, "0x8f0844Fd51E31ff6Bf5baBe21DCcf7328E19Fd9F", payload.ProposerFeeRecipient.String())
+	require.Equal(t, big.NewInt(123456789012345678), payload.Value)
+
+	payload, err = util.FetchDeliveredPayload(context.Background(), relay, 101)
+	require.NoError(t, err)
+	require.Nil(t, payload)
+
+	_, err = util.FetchDeliveredPayload(context.Background(), relay, 102)
+	require.EqualError(t, err, "incorrect length for block hash in delivered payload")
+
+	_, err = util.FetchDeliveredPayload(context.Background(), relay, 103)
+	require.EqualError(t, err, "relay returned status 403")
+}
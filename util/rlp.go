@@ -0,0 +1,74 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/binary"
+	"math/big"
+)
+
+// rlpBytes RLP-encodes a byte string.
+func rlpBytes(data []byte) []byte {
+	if len(data) == 1 && data[0] < 0x80 {
+		return []byte{data[0]}
+	}
+
+	return append(rlpPrefix(len(data), 0x80), data...)
+}
+
+// rlpUint RLP-encodes an unsigned integer.
+func rlpUint(value uint64) []byte {
+	return rlpBytes(trimLeadingZeros(binary.BigEndian.AppendUint64(nil, value)))
+}
+
+// rlpBigInt RLP-encodes a non-negative big integer, with nil encoded as zero.
+func rlpBigInt(value *big.Int) []byte {
+	if value == nil {
+		return rlpBytes(nil)
+	}
+
+	return rlpBytes(value.Bytes())
+}
+
+// rlpList RLP-encodes a list of already-encoded items.
+func rlpList(items ...[]byte) []byte {
+	length := 0
+	for _, item := range items {
+		length += len(item)
+	}
+	res := rlpPrefix(length, 0xc0)
+	for _, item := range items {
+		res = append(res, item...)
+	}
+
+	return res
+}
+
+// rlpPrefix returns the prefix for an item of the given length.
+func rlpPrefix(length int, offset byte) []byte {
+	if length < 56 {
+		return []byte{offset + byte(length)}
+	}
+	lengthBytes := trimLeadingZeros(binary.BigEndian.AppendUint64(nil, uint64(length)))
+
+	return append([]byte{offset + 55 + byte(len(lengthBytes))}, lengthBytes...)
+}
+
+func trimLeadingZeros(data []byte) []byte {
+	for len(data) > 0 && data[0] == 0 {
+		data = data[1:]
+	}
+
+	return data
+}
@@ -0,0 +1,89 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRLP(t *testing.T) {
+	long := bytes.Repeat([]byte{0x61}, 56)
+
+	tests := []struct {
+		name     string
+		encoded  []byte
+		expected string
+	}{
+		{
+			name:     "Empty",
+			encoded:  rlpBytes(nil),
+			expected: "80",
+		},
+		{
+			name:     "SingleByte",
+			encoded:  rlpBytes([]byte{0x7f}),
+			expected: "7f",
+		},
+		{
+			name:     "SingleHighByte",
+			encoded:  rlpBytes([]byte{0x80}),
+			expected: "8180",
+		},
+		{
+			name:     "String",
+			encoded:  rlpBytes([]byte("dog")),
+			expected: "83646f67",
+		},
+		{
+			name:     "LongString",
+			encoded:  rlpBytes(long),
+			expected: "b838" + hex.EncodeToString(long),
+		},
+		{
+			name:     "Zero",
+			encoded:  rlpUint(0),
+			expected: "80",
+		},
+		{
+			name:     "Uint",
+			encoded:  rlpUint(1024),
+			expected: "820400",
+		},
+		{
+			name:     "EmptyList",
+			encoded:  rlpList(),
+			expected: "c0",
+		},
+		{
+			name:     "List",
+			encoded:  rlpList(rlpBytes([]byte("cat")), rlpBytes([]byte("dog"))),
+			expected: "c88363617483646f67",
+		},
+		{
+			name:     "LongList",
+			encoded:  rlpList(rlpBytes(long)),
+			expected: "f83ab838" + hex.EncodeToString(long),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, hex.EncodeToString(test.encoded))
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// secretResolvers resolve references to secrets held in external secret
+// stores, keyed by the prefix of the reference.
+var secretResolvers = map[string]func(ctx context.Context, reference string) (string, error){
+	"vault:":   resolveVaultSecret,
+	"aws-sm:":  resolveAWSSecretsManagerSecret,
+	"aws-kms:": resolveAWSKMSSecret,
+	"gcp-sm:":  resolveGCPSecretManagerSecret,
+}
+
+// secretKeys are the options that can contain references to secrets.
+var secretKeys = []string{
+	"passphrase",
+	"wallet-passphrase",
+	"walletpassphrase",
+	"store-passphrase",
+	"storepassphrase",
+	"new-passphrase",
+	"keystore-passphrase",
+	"to-store-passphrase",
+	"mnemonic",
+}
+
+// ResolveSecrets replaces references to secrets in the options that hold
+// passphrases and mnemonics with the secrets themselves, for example
+// "vault:secret/ethdo/wallet1" with the passphrase held in Vault.
+func ResolveSecrets(ctx context.Context) error {
+	keys := append([]string{}, secretKeys...)
+	for _, key := range viper.AllKeys() {
+		if strings.HasPrefix(key, "stores.") && (strings.HasSuffix(key, ".passphrase") || strings.HasSuffix(key, ".token")) {
+			keys = append(keys, key)
+		}
+	}
+
+	for _, key := range keys {
+		if !viper.IsSet(key) {
+			continue
+		}
+		if key == "passphrase" {
+			// Passphrases can be supplied multiple times.
+			passphrases := viper.GetStringSlice(key)
+			resolved := make([]string, len(passphrases))
+			changed := false
+			for i := range passphrases {
+				var err error
+				resolved[i], err = ResolveSecret(ctx, passphrases[i])
+				if err != nil {
+					return errors.Wrap(err, "failed to resolve passphrase")
+				}
+				changed = changed || resolved[i] != passphrases[i]
+			}
+			if changed {
+				viper.Set(key, resolved)
+			}
+			continue
+		}
+
+		value := viper.GetString(key)
+		resolved, err := ResolveSecret(ctx, value)
+		if err != nil {
+			return errors.Wrapf(err, "failed to resolve %s", key)
+		}
+		if resolved != value {
+			viper.Set(key, resolved)
+		}
+	}
+
+	return nil
+}
+
+// ResolveSecret resolves a reference to a secret.  Values that are not
+// references are returned unaltered.
+func ResolveSecret(ctx context.Context, value string) (string, error) {
+	for prefix, resolver := range secretResolvers {
+		if strings.HasPrefix(value, prefix) {
+			if Offline() {
+				return "", errors.Wrapf(ErrOffline, "cannot resolve %s secret", strings.TrimSuffix(prefix, ":"))
+			}
+			return resolver(ctx, strings.TrimPrefix(value, prefix))
+		}
+	}
+
+	return value, nil
+}
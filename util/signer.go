@@ -0,0 +1,112 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	pb "github.com/wealdtech/eth2-signer-api/pb/v1"
+	dirk "github.com/wealdtech/go-eth2-wallet-dirk"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// remoteCredentials composes the credentials for connecting to the remote
+// wallet daemon supplied with --remote.
+func remoteCredentials(ctx context.Context) (credentials.TransportCredentials, error) {
+	if Offline() {
+		return nil, errors.Wrap(ErrOffline, "cannot connect to remote wallet")
+	}
+	if viper.GetString("client-cert") == "" {
+		return nil, errors.New("remote connections require client-cert")
+	}
+	if viper.GetString("client-key") == "" {
+		return nil, errors.New("remote connections require client-key")
+	}
+	credentials, err := dirk.ComposeCredentials(ctx, viper.GetString("client-cert"), viper.GetString("client-key"), viper.GetString("server-ca-cert"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build dirk credentials")
+	}
+
+	return credentials, nil
+}
+
+// NewSignerConnection opens a connection to the administration interface of
+// the remote wallet daemon supplied with --remote.
+func NewSignerConnection(ctx context.Context) (*grpc.ClientConn, error) {
+	remote := viper.GetString("remote")
+	if remote == "" {
+		return nil, errors.New("remote is required")
+	}
+
+	return NewSignerConnectionTo(ctx, remote)
+}
+
+// NewSignerConnectionTo opens a connection to the administration interface of
+// the remote wallet daemon at the given address, using the credentials
+// supplied with --client-cert and --client-key.
+func NewSignerConnectionTo(ctx context.Context, remote string) (*grpc.ClientConn, error) {
+	// Validate the remote.
+	if _, err := remotesToEndpoints([]string{remote}); err != nil {
+		return nil, errors.Wrap(err, "failed to parse remote server")
+	}
+	credentials, err := remoteCredentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.NewClient(remote, grpc.WithTransportCredentials(credentials))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to remote server")
+	}
+
+	return conn, nil
+}
+
+// CloseSignerConnection closes a connection to the remote wallet daemon
+// opened by NewSignerConnection, if it is present.
+func CloseSignerConnection(conn *grpc.ClientConn) {
+	if conn == nil {
+		return
+	}
+	if err := conn.Close(); err != nil {
+		Log.Trace().Err(err).Msg("Failed to close connection to remote signer")
+	}
+}
+
+// SignerResponseError returns an error if the state of a response from the
+// remote wallet daemon is not successful.
+func SignerResponseError(state pb.ResponseState, message string) error {
+	if state == pb.ResponseState_SUCCEEDED {
+		return nil
+	}
+
+	var msg string
+	switch state {
+	case pb.ResponseState_DENIED:
+		msg = "request denied by remote signer"
+	case pb.ResponseState_FAILED:
+		msg = "request failed on remote signer"
+	default:
+		msg = fmt.Sprintf("unexpected response %v from remote signer", state)
+	}
+	if message != "" {
+		msg = fmt.Sprintf("%s: %s", msg, message)
+	}
+
+	return errors.New(msg)
+}
@@ -18,14 +18,30 @@ import (
 
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
-	"github.com/prysmaticlabs/go-ssz"
 	"github.com/spf13/viper"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
-// SignRoot signs the hash tree root of a data structure.
+// SignRoot signs the hash tree root of a data structure, and records the
+// result in the audit log.
 func SignRoot(account e2wtypes.Account, root spec.Root, domain spec.Domain) (e2types.Signature, error) {
+	signature, err := checkAndSignRoot(account, root, domain)
+	if auditErr := AuditSigning(account, root, domain, err); auditErr != nil && err == nil {
+		// Do not release a signature that is not recorded.
+		return nil, auditErr
+	}
+
+	return signature, err
+}
+
+// checkAndSignRoot signs the hash tree root of a data structure if allowed by
+// the signing policy.
+func checkAndSignRoot(account e2wtypes.Account, root spec.Root, domain spec.Domain) (e2types.Signature, error) {
+	if err := CheckSigningPolicy(account, domain); err != nil {
+		return nil, err
+	}
+
 	if _, isProtectingSigner := account.(e2wtypes.AccountProtectingSigner); isProtectingSigner {
 		// Signer builds the signing data.
 		return signGeneric(account, root, domain)
@@ -53,7 +69,7 @@ func VerifyRoot(account e2wtypes.Account, root spec.Root, domain spec.Domain, si
 		Domain:     domain,
 	}
 	// outputIf(debug, fmt.Sprintf("Signing container:\n root: %#x\n domain: %#x", container.ObjectRoot, container.Domain))
-	signingRoot, err := ssz.HashTreeRoot(container)
+	signingRoot, err := container.HashTreeRoot()
 	if err != nil {
 		return false, err
 	}
@@ -147,6 +163,9 @@ func unlock(account e2wtypes.Account) (bool, error) {
 	}
 
 	// Failed to unlock it.
+	if IsWatchOnlyAccount(account) {
+		return false, errors.New("account is watch-only and cannot sign")
+	}
 	return false, errors.New("failed to unlock account")
 }
 
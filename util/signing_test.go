@@ -0,0 +1,50 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestSignAndVerifyRoot(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	viper.Set("timeout", "5s")
+
+	account, err := util.NewScratchAccount(testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"), nil)
+	require.NoError(t, err)
+	require.NoError(t, account.Unlock(context.Background(), nil))
+
+	root := phase0.Root{0x01}
+	domain := phase0.Domain{0x02}
+
+	signature, err := util.SignRoot(account, root, domain)
+	require.NoError(t, err)
+
+	verified, err := util.VerifyRoot(account, root, domain, signature)
+	require.NoError(t, err)
+	require.True(t, verified)
+
+	// Alter the root and ensure verification fails.
+	verified, err = util.VerifyRoot(account, phase0.Root{0x03}, domain, signature)
+	require.NoError(t, err)
+	require.False(t, verified)
+}
@@ -0,0 +1,207 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/go-bytesutil"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// SigningPolicy is a policy restricting the signatures that can be
+// generated, configured with the "signing-policy" option.
+type SigningPolicy struct {
+	// DenyDomainTypes are domain types that are never signed.
+	DenyDomainTypes []string `mapstructure:"deny-domain-types"`
+	// Rules restrict the domain types that accounts can sign.
+	Rules []*SigningPolicyRule `mapstructure:"rules"`
+}
+
+// SigningPolicyRule restricts the domain types that matching accounts can
+// sign.  An account that matches any rule can only sign the domain types of
+// the rules that it matches.
+type SigningPolicyRule struct {
+	// Accounts are the accounts to which the rule applies.  Each is either a
+	// public key, a pattern matching "wallet/account", or a pattern matching
+	// the account name; patterns use the syntax of path.Match.
+	Accounts []string `mapstructure:"accounts"`
+	// DomainTypes are the domain types that matching accounts can sign.
+	DomainTypes []string `mapstructure:"domain-types"`
+}
+
+// ObtainSigningPolicy obtains the signing policy from the configuration,
+// returning nil if there is no policy.
+func ObtainSigningPolicy() (*SigningPolicy, error) {
+	if !viper.IsSet("signing-policy") {
+		return nil, nil
+	}
+
+	policy := &SigningPolicy{}
+	if err := viper.UnmarshalKey("signing-policy", policy); err != nil {
+		return nil, errors.Wrap(err, "invalid signing policy")
+	}
+
+	// Ensure that the domain types are valid.
+	for _, domainType := range policy.DenyDomainTypes {
+		if _, _, err := ParseDomainType(domainType); err != nil {
+			return nil, errors.Wrap(err, "invalid signing policy")
+		}
+	}
+	for i, rule := range policy.Rules {
+		if len(rule.Accounts) == 0 {
+			return nil, fmt.Errorf("invalid signing policy: rule %d has no accounts", i+1)
+		}
+		for _, pattern := range rule.Accounts {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, errors.Wrapf(err, "invalid signing policy: rule %d has invalid account %s", i+1, pattern)
+			}
+		}
+		for _, domainType := range rule.DomainTypes {
+			if _, _, err := ParseDomainType(domainType); err != nil {
+				return nil, errors.Wrap(err, "invalid signing policy")
+			}
+		}
+	}
+
+	return policy, nil
+}
+
+// Check returns an error if the policy does not allow the account to sign in
+// the domain.
+func (p *SigningPolicy) Check(account e2wtypes.Account, domain spec.Domain) error {
+	var domainType spec.DomainType
+	copy(domainType[:], domain[:4])
+	domainTypeDesc := fmt.Sprintf("%#x", domainType)
+	if name := DomainTypeName(domainType); name != "" {
+		domainTypeDesc = name
+	}
+
+	if signingPolicyDomainTypesContain(p.DenyDomainTypes, domainType) {
+		return fmt.Errorf("signing policy does not allow signing domain type %s", domainTypeDesc)
+	}
+
+	matched := false
+	for _, rule := range p.Rules {
+		if !signingPolicyRuleMatches(rule, account) {
+			continue
+		}
+		if signingPolicyDomainTypesContain(rule.DomainTypes, domainType) {
+			return nil
+		}
+		matched = true
+	}
+	if matched {
+		return fmt.Errorf("signing policy does not allow account %s to sign domain type %s", qualifiedAccountName(account), domainTypeDesc)
+	}
+
+	return nil
+}
+
+// CheckSigningPolicy checks the signature against the configured signing
+// policy, if any.  If the policy does not allow the signature but the
+// "override-policy" option is set then the override is logged to the activity
+// log and the signature is allowed.
+func CheckSigningPolicy(account e2wtypes.Account, domain spec.Domain) error {
+	policy, err := ObtainSigningPolicy()
+	if err != nil {
+		return err
+	}
+	if policy == nil {
+		return nil
+	}
+
+	policyErr := policy.Check(account, domain)
+	if policyErr == nil {
+		return nil
+	}
+	if !viper.GetBool("override-policy") {
+		return fmt.Errorf("%v; use --override-policy to sign regardless", policyErr)
+	}
+
+	if err := LogActivity("signing policy override", map[string]any{
+		"account": qualifiedAccountName(account),
+		"pubkey":  fmt.Sprintf("%#x", account.PublicKey().Marshal()),
+		"domain":  fmt.Sprintf("%#x", domain),
+		"reason":  policyErr.Error(),
+	}); err != nil {
+		// Only allow the override if it is recorded.
+		return errors.Wrap(err, "failed to log signing policy override")
+	}
+	if !viper.GetBool("quiet") {
+		fmt.Fprintf(os.Stderr, "Warning: overriding %v\n", policyErr)
+	}
+
+	return nil
+}
+
+func signingPolicyDomainTypesContain(domainTypes []string, domainType spec.DomainType) bool {
+	for _, input := range domainTypes {
+		// Domain types are validated when the policy is obtained.
+		if _, candidate, err := ParseDomainType(input); err == nil && candidate == domainType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func signingPolicyRuleMatches(rule *SigningPolicyRule, account e2wtypes.Account) bool {
+	for _, pattern := range rule.Accounts {
+		switch {
+		case strings.HasPrefix(pattern, "0x"):
+			pubKey, err := bytesutil.FromHexString(pattern)
+			if err != nil {
+				continue
+			}
+			if bytes.Equal(pubKey, account.PublicKey().Marshal()) {
+				return true
+			}
+			if compositeProvider, isProvider := account.(e2wtypes.AccountCompositePublicKeyProvider); isProvider &&
+				bytes.Equal(pubKey, compositeProvider.CompositePublicKey().Marshal()) {
+				return true
+			}
+		case strings.Contains(pattern, "/"):
+			if _, isProvider := account.(e2wtypes.AccountWalletProvider); !isProvider {
+				continue
+			}
+			if matched, err := path.Match(pattern, qualifiedAccountName(account)); err == nil && matched {
+				return true
+			}
+		default:
+			if matched, err := path.Match(pattern, account.Name()); err == nil && matched {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// qualifiedAccountName returns the name of the account in the format
+// "wallet/account" if its wallet is known, otherwise the account name.
+func qualifiedAccountName(account e2wtypes.Account) string {
+	if walletProvider, isProvider := account.(e2wtypes.AccountWalletProvider); isProvider {
+		return fmt.Sprintf("%s/%s", walletProvider.Wallet().Name(), account.Name())
+	}
+
+	return account.Name()
+}
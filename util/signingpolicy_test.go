@@ -0,0 +1,206 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestObtainSigningPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy map[string]any
+		err    string
+	}{
+		{
+			name: "None",
+		},
+		{
+			name: "DenyDomainTypeInvalid",
+			policy: map[string]any{
+				"deny-domain-types": []string{"DOMAIN_UNKNOWN"},
+			},
+			err: "invalid signing policy: unknown domain type DOMAIN_UNKNOWN",
+		},
+		{
+			name: "RuleAccountsMissing",
+			policy: map[string]any{
+				"rules": []map[string]any{
+					{"domain-types": []string{"DOMAIN_VOLUNTARY_EXIT"}},
+				},
+			},
+			err: "invalid signing policy: rule 1 has no accounts",
+		},
+		{
+			name: "RuleAccountInvalid",
+			policy: map[string]any{
+				"rules": []map[string]any{
+					{"accounts": []string{"Wallet/["}, "domain-types": []string{"DOMAIN_VOLUNTARY_EXIT"}},
+				},
+			},
+			err: "invalid signing policy: rule 1 has invalid account Wallet/[: syntax error in pattern",
+		},
+		{
+			name: "Good",
+			policy: map[string]any{
+				"deny-domain-types": []string{"DOMAIN_BEACON_ATTESTER"},
+				"rules": []map[string]any{
+					{"accounts": []string{"Wallet/*"}, "domain-types": []string{"DOMAIN_VOLUNTARY_EXIT", "0x0a000000"}},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			t.Cleanup(viper.Reset)
+			if test.policy != nil {
+				viper.Set("signing-policy", test.policy)
+			}
+			_, err := util.ObtainSigningPolicy()
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSigningPolicyCheck(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	account, err := util.NewScratchAccount(testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"), nil)
+	require.NoError(t, err)
+	pubKey := "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+
+	exitDomain := phase0.Domain{0x04}
+	attesterDomain := phase0.Domain{0x01}
+	proposerDomain := phase0.Domain{0x00}
+
+	tests := []struct {
+		name   string
+		policy *util.SigningPolicy
+		domain phase0.Domain
+		err    string
+	}{
+		{
+			name:   "Empty",
+			policy: &util.SigningPolicy{},
+			domain: attesterDomain,
+		},
+		{
+			name: "Denied",
+			policy: &util.SigningPolicy{
+				DenyDomainTypes: []string{"DOMAIN_BEACON_ATTESTER"},
+			},
+			domain: attesterDomain,
+			err:    "signing policy does not allow signing domain type DOMAIN_BEACON_ATTESTER",
+		},
+		{
+			name: "NotDenied",
+			policy: &util.SigningPolicy{
+				DenyDomainTypes: []string{"DOMAIN_BEACON_ATTESTER"},
+			},
+			domain: exitDomain,
+		},
+		{
+			name: "RulePubKeyAllowed",
+			policy: &util.SigningPolicy{
+				Rules: []*util.SigningPolicyRule{
+					{Accounts: []string{pubKey}, DomainTypes: []string{"DOMAIN_VOLUNTARY_EXIT"}},
+				},
+			},
+			domain: exitDomain,
+		},
+		{
+			name: "RulePubKeyNotAllowed",
+			policy: &util.SigningPolicy{
+				Rules: []*util.SigningPolicyRule{
+					{Accounts: []string{pubKey}, DomainTypes: []string{"DOMAIN_VOLUNTARY_EXIT"}},
+				},
+			},
+			domain: proposerDomain,
+			err:    "signing policy does not allow account " + account.Name() + " to sign domain type DOMAIN_BEACON_PROPOSER",
+		},
+		{
+			name: "RuleMultipleMatches",
+			policy: &util.SigningPolicy{
+				Rules: []*util.SigningPolicyRule{
+					{Accounts: []string{pubKey}, DomainTypes: []string{"DOMAIN_VOLUNTARY_EXIT"}},
+					{Accounts: []string{"*"}, DomainTypes: []string{"DOMAIN_BEACON_PROPOSER"}},
+				},
+			},
+			domain: proposerDomain,
+		},
+		{
+			name: "RuleNoMatch",
+			policy: &util.SigningPolicy{
+				Rules: []*util.SigningPolicyRule{
+					{Accounts: []string{"Wallet/*"}, DomainTypes: []string{"DOMAIN_VOLUNTARY_EXIT"}},
+				},
+			},
+			domain: proposerDomain,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.policy.Check(account, test.domain)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckSigningPolicyOverride(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	account, err := util.NewScratchAccount(testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"), nil)
+	require.NoError(t, err)
+	logFile := filepath.Join(t.TempDir(), "ethdo.log")
+
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set("quiet", true)
+	viper.Set("log", logFile)
+	viper.Set("signing-policy", map[string]any{
+		"deny-domain-types": []string{"DOMAIN_BEACON_ATTESTER"},
+	})
+
+	require.EqualError(t, util.CheckSigningPolicy(account, phase0.Domain{0x01}), "signing policy does not allow signing domain type DOMAIN_BEACON_ATTESTER; use --override-policy to sign regardless")
+	_, err = os.Stat(logFile)
+	require.True(t, os.IsNotExist(err))
+
+	viper.Set("override-policy", true)
+	require.NoError(t, util.CheckSigningPolicy(account, phase0.Domain{0x01}))
+	data, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	require.Equal(t, 1, strings.Count(string(data), "\n"))
+	require.Contains(t, string(data), `"action":"signing policy override"`)
+}
@@ -0,0 +1,63 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// AttesterSlashingNature returns the nature of the conflict between the
+// attestations of an attester slashing: "double vote", "surround vote" or
+// "unknown".
+func AttesterSlashingNature(slashing *phase0.AttesterSlashing) string {
+	data1 := slashing.Attestation1.Data
+	data2 := slashing.Attestation2.Data
+	switch {
+	case data1.Target.Epoch == data2.Target.Epoch:
+		return "double vote"
+	case data1.Source.Epoch < data2.Source.Epoch && data2.Target.Epoch < data1.Target.Epoch,
+		data2.Source.Epoch < data1.Source.Epoch && data1.Target.Epoch < data2.Target.Epoch:
+		return "surround vote"
+	default:
+		return "unknown"
+	}
+}
+
+// AttesterSlashingIndices returns the indices of the validators slashed by
+// an attester slashing, being those present in both attestations, in
+// increasing order.
+func AttesterSlashingIndices(slashing *phase0.AttesterSlashing) []phase0.ValidatorIndex {
+	res := make([]phase0.ValidatorIndex, 0)
+	if slashing.Attestation1 == nil || slashing.Attestation2 == nil {
+		return res
+	}
+
+	first := make(map[uint64]struct{}, len(slashing.Attestation1.AttestingIndices))
+	for _, index := range slashing.Attestation1.AttestingIndices {
+		first[index] = struct{}{}
+	}
+	for _, index := range slashing.Attestation2.AttestingIndices {
+		if _, exists := first[index]; exists {
+			res = append(res, phase0.ValidatorIndex(index))
+			delete(first, index)
+		}
+	}
+	sort.Slice(res, func(i int, j int) bool {
+		return res[i] < res[j]
+	})
+
+	return res
+}
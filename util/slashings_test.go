@@ -0,0 +1,87 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func slashingAttestation(indices []uint64, source phase0.Epoch, target phase0.Epoch) *phase0.IndexedAttestation {
+	return &phase0.IndexedAttestation{
+		AttestingIndices: indices,
+		Data: &phase0.AttestationData{
+			Source: &phase0.Checkpoint{Epoch: source},
+			Target: &phase0.Checkpoint{Epoch: target},
+		},
+	}
+}
+
+func TestAttesterSlashingNature(t *testing.T) {
+	tests := []struct {
+		name     string
+		slashing *phase0.AttesterSlashing
+		nature   string
+	}{
+		{
+			name: "DoubleVote",
+			slashing: &phase0.AttesterSlashing{
+				Attestation1: slashingAttestation([]uint64{1}, 10, 11),
+				Attestation2: slashingAttestation([]uint64{1}, 10, 11),
+			},
+			nature: "double vote",
+		},
+		{
+			name: "Surrounds",
+			slashing: &phase0.AttesterSlashing{
+				Attestation1: slashingAttestation([]uint64{1}, 8, 12),
+				Attestation2: slashingAttestation([]uint64{1}, 10, 11),
+			},
+			nature: "surround vote",
+		},
+		{
+			name: "Surrounded",
+			slashing: &phase0.AttesterSlashing{
+				Attestation1: slashingAttestation([]uint64{1}, 10, 11),
+				Attestation2: slashingAttestation([]uint64{1}, 8, 12),
+			},
+			nature: "surround vote",
+		},
+		{
+			name: "Unknown",
+			slashing: &phase0.AttesterSlashing{
+				Attestation1: slashingAttestation([]uint64{1}, 8, 10),
+				Attestation2: slashingAttestation([]uint64{1}, 10, 11),
+			},
+			nature: "unknown",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.nature, util.AttesterSlashingNature(test.slashing))
+		})
+	}
+}
+
+func TestAttesterSlashingIndices(t *testing.T) {
+	require.Equal(t, []phase0.ValidatorIndex{}, util.AttesterSlashingIndices(&phase0.AttesterSlashing{}))
+	require.Equal(t, []phase0.ValidatorIndex{3, 5}, util.AttesterSlashingIndices(&phase0.AttesterSlashing{
+		Attestation1: slashingAttestation([]uint64{1, 3, 5, 7}, 10, 11),
+		Attestation2: slashingAttestation([]uint64{5, 3, 3, 8}, 10, 11),
+	}))
+}
@@ -0,0 +1,99 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// SpecFromYAML parses a consensus configuration file, such as the config.yaml
+// published for a network, in to a chain specification.  Values are converted
+// to the same types as those in the specification provided by beacon nodes.
+func SpecFromYAML(data []byte) (map[string]any, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, errors.Wrap(err, "failed to parse configuration")
+	}
+	if len(doc.Content) == 0 {
+		return nil, errors.New("configuration is empty")
+	}
+	mapping := doc.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return nil, errors.New("configuration is not a map")
+	}
+
+	spec := make(map[string]any)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key := mapping.Content[i].Value
+		value := mapping.Content[i+1]
+		if value.Kind != yaml.ScalarNode {
+			// Complex values, such as blob schedules, are not part of the specification.
+			continue
+		}
+		spec[key] = specValue(key, value.Value)
+	}
+
+	return spec, nil
+}
+
+// specValue converts a single specification value to its typed form.
+func specValue(key string, value string) any {
+	switch {
+	case strings.HasPrefix(key, "DOMAIN_"):
+		if byteVal, err := hex.DecodeString(strings.TrimPrefix(value, "0x")); err == nil {
+			var domainType phase0.DomainType
+			copy(domainType[:], byteVal)
+
+			return domainType
+		}
+	case strings.HasSuffix(key, "_FORK_VERSION"):
+		if byteVal, err := hex.DecodeString(strings.TrimPrefix(value, "0x")); err == nil {
+			var version phase0.Version
+			copy(version[:], byteVal)
+
+			return version
+		}
+	}
+
+	if strings.HasPrefix(value, "0x") {
+		if byteVal, err := hex.DecodeString(strings.TrimPrefix(value, "0x")); err == nil {
+			return byteVal
+		}
+	}
+
+	if strings.HasSuffix(key, "_TIME") {
+		if intVal, err := strconv.ParseInt(value, 10, 64); err == nil && intVal != 0 {
+			return time.Unix(intVal, 0)
+		}
+	}
+
+	if strings.HasPrefix(key, "SECONDS_PER_") || key == "GENESIS_DELAY" {
+		if intVal, err := strconv.ParseInt(value, 10, 64); err == nil && intVal >= 0 {
+			return time.Duration(intVal) * time.Second
+		}
+	}
+
+	if intVal, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return intVal
+	}
+
+	return value
+}
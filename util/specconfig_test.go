@@ -0,0 +1,88 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestSpecFromYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected map[string]any
+		err      string
+	}{
+		{
+			name:  "Empty",
+			input: "",
+			err:   "configuration is empty",
+		},
+		{
+			name:  "NotMap",
+			input: "- a\n- b\n",
+			err:   "configuration is not a map",
+		},
+		{
+			name:  "Invalid",
+			input: "a: [\n",
+			err:   "failed to parse configuration: yaml: line 1: did not find expected node content",
+		},
+		{
+			name: "Good",
+			input: `# Extended quantity
+PRESET_BASE: 'mainnet'
+CONFIG_NAME: 'devnet'
+MIN_GENESIS_TIME: 1695902100
+GENESIS_FORK_VERSION: 0x10000000
+GENESIS_DELAY: 300
+ALTAIR_FORK_EPOCH: 0
+SECONDS_PER_SLOT: 12
+DEPOSIT_CONTRACT_ADDRESS: 0x4242424242424242424242424242424242424242
+DOMAIN_BEACON_PROPOSER: 0x00000000
+BLOB_SCHEDULE:
+  - EPOCH: 100
+    MAX_BLOBS_PER_BLOCK: 12
+`,
+			expected: map[string]any{
+				"PRESET_BASE":              "mainnet",
+				"CONFIG_NAME":              "devnet",
+				"MIN_GENESIS_TIME":         time.Unix(1695902100, 0),
+				"GENESIS_FORK_VERSION":     phase0.Version{0x10, 0x00, 0x00, 0x00},
+				"GENESIS_DELAY":            300 * time.Second,
+				"ALTAIR_FORK_EPOCH":        uint64(0),
+				"SECONDS_PER_SLOT":         12 * time.Second,
+				"DEPOSIT_CONTRACT_ADDRESS": []byte{0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42, 0x42},
+				"DOMAIN_BEACON_PROPOSER":   phase0.DomainType{0x00, 0x00, 0x00, 0x00},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spec, err := util.SpecFromYAML([]byte(test.input))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, spec)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// SSZContainer is a container that can be decoded from SSZ and merkleized.
+type SSZContainer interface {
+	UnmarshalSSZ(buf []byte) error
+	HashTreeRoot() ([32]byte, error)
+}
+
+// forkIndependentSSZContainers are the signable containers whose schema is
+// the same for all forks.
+var forkIndependentSSZContainers = map[string]func() SSZContainer{
+	"AggregateAndProof":           func() SSZContainer { return &phase0.AggregateAndProof{} },
+	"AttestationData":             func() SSZContainer { return &phase0.AttestationData{} },
+	"BeaconBlockHeader":           func() SSZContainer { return &phase0.BeaconBlockHeader{} },
+	"BLSToExecutionChange":        func() SSZContainer { return &capella.BLSToExecutionChange{} },
+	"ContributionAndProof":        func() SSZContainer { return &altair.ContributionAndProof{} },
+	"DepositMessage":              func() SSZContainer { return &phase0.DepositMessage{} },
+	"SyncAggregatorSelectionData": func() SSZContainer { return &altair.SyncAggregatorSelectionData{} },
+	"ValidatorRegistration":       func() SSZContainer { return &apiv1.ValidatorRegistration{} },
+	"VoluntaryExit":               func() SSZContainer { return &phase0.VoluntaryExit{} },
+}
+
+// forkDependentSSZContainers are the signable containers whose schema
+// changes with the fork.
+var forkDependentSSZContainers = map[string]map[spec.DataVersion]func() SSZContainer{
+	"BeaconBlock": {
+		spec.DataVersionPhase0:    func() SSZContainer { return &phase0.BeaconBlock{} },
+		spec.DataVersionAltair:    func() SSZContainer { return &altair.BeaconBlock{} },
+		spec.DataVersionBellatrix: func() SSZContainer { return &bellatrix.BeaconBlock{} },
+		spec.DataVersionCapella:   func() SSZContainer { return &capella.BeaconBlock{} },
+		spec.DataVersionDeneb:     func() SSZContainer { return &deneb.BeaconBlock{} },
+	},
+}
+
+// forkVersionNames are the names of the fork versions in the chain
+// specification, by fork.
+var forkVersionNames = map[spec.DataVersion]string{
+	spec.DataVersionPhase0:    "GENESIS_FORK_VERSION",
+	spec.DataVersionAltair:    "ALTAIR_FORK_VERSION",
+	spec.DataVersionBellatrix: "BELLATRIX_FORK_VERSION",
+	spec.DataVersionCapella:   "CAPELLA_FORK_VERSION",
+	spec.DataVersionDeneb:     "DENEB_FORK_VERSION",
+}
+
+// SSZContainerTypes returns the names of the SSZ container types that can be
+// created with NewSSZContainer.
+func SSZContainerTypes() []string {
+	types := make([]string, 0, len(forkIndependentSSZContainers)+len(forkDependentSSZContainers))
+	for name := range forkIndependentSSZContainers {
+		types = append(types, name)
+	}
+	for name := range forkDependentSSZContainers {
+		types = append(types, name)
+	}
+	sort.Strings(types)
+
+	return types
+}
+
+// IsForkDependentSSZContainer returns true if the schema of the named SSZ
+// container type changes with the fork.
+func IsForkDependentSSZContainer(typeName string) bool {
+	for name := range forkDependentSSZContainers {
+		if strings.EqualFold(name, typeName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewSSZContainer returns an empty SSZ container of the named type, using the
+// schema for the given fork if the type changes with the fork.
+func NewSSZContainer(typeName string, fork spec.DataVersion) (SSZContainer, error) {
+	for name, fn := range forkIndependentSSZContainers {
+		if strings.EqualFold(name, typeName) {
+			return fn(), nil
+		}
+	}
+
+	for name, forks := range forkDependentSSZContainers {
+		if strings.EqualFold(name, typeName) {
+			fn, exists := forks[fork]
+			if !exists {
+				return nil, fmt.Errorf("type %s is not supported for fork %s", name, fork)
+			}
+
+			return fn(), nil
+		}
+	}
+
+	return nil, fmt.Errorf("unsupported type %s; supported types are %s", typeName, strings.Join(SSZContainerTypes(), ", "))
+}
+
+// ParseFork parses the name of a fork.
+func ParseFork(input string) (spec.DataVersion, error) {
+	var fork spec.DataVersion
+	if err := fork.UnmarshalJSON([]byte(fmt.Sprintf("%q", input))); err != nil {
+		return spec.DataVersionUnknown, fmt.Errorf("unknown fork %s", input)
+	}
+
+	return fork, nil
+}
+
+// ActiveFork returns the fork that is active at the head of the chain to
+// which the client is connected.
+func ActiveFork(ctx context.Context,
+	specProvider eth2client.SpecProvider,
+	forkProvider eth2client.ForkProvider,
+) (
+	spec.DataVersion,
+	error,
+) {
+	specResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return spec.DataVersionUnknown, errors.Wrap(err, "failed to obtain spec")
+	}
+	forkResponse, err := forkProvider.Fork(ctx, &api.ForkOpts{State: "head"})
+	if err != nil {
+		return spec.DataVersionUnknown, errors.Wrap(err, "failed to obtain current fork")
+	}
+
+	for fork, name := range forkVersionNames {
+		if forkVersion, isForkVersion := specResponse.Data[name].(phase0.Version); isForkVersion && forkVersion == forkResponse.Data.CurrentVersion {
+			return fork, nil
+		}
+	}
+
+	return spec.DataVersionUnknown, fmt.Errorf("current fork version %#x is not supported", forkResponse.Data.CurrentVersion)
+}
@@ -0,0 +1,137 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// A mock Ethereum 2 client service that returns fork information.
+type forkETH2Client struct {
+	currentVersion phase0.Version
+}
+
+// Spec provides the spec information of the chain.
+func (*forkETH2Client) Spec(_ context.Context, _ *api.SpecOpts) (*api.Response[map[string]any], error) {
+	return &api.Response[map[string]any]{
+		Data: map[string]any{
+			"GENESIS_FORK_VERSION": phase0.Version{0x00, 0x00, 0x00, 0x00},
+			"ALTAIR_FORK_VERSION":  phase0.Version{0x01, 0x00, 0x00, 0x00},
+		},
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+// Fork provides the fork information of the chain.
+func (c *forkETH2Client) Fork(_ context.Context, _ *api.ForkOpts) (*api.Response[*phase0.Fork], error) {
+	return &api.Response[*phase0.Fork]{
+		Data: &phase0.Fork{
+			CurrentVersion: c.currentVersion,
+		},
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func TestNewSSZContainer(t *testing.T) {
+	tests := []struct {
+		name     string
+		typeName string
+		fork     spec.DataVersion
+		res      util.SSZContainer
+		err      string
+	}{
+		{
+			name:     "Unknown",
+			typeName: "Unknown",
+			err:      "unsupported type Unknown; supported types are AggregateAndProof, AttestationData, BLSToExecutionChange, BeaconBlock, BeaconBlockHeader, ContributionAndProof, DepositMessage, SyncAggregatorSelectionData, ValidatorRegistration, VoluntaryExit",
+		},
+		{
+			name:     "ForkUnknown",
+			typeName: "BeaconBlock",
+			err:      "type BeaconBlock is not supported for fork unknown",
+		},
+		{
+			name:     "ForkIndependent",
+			typeName: "voluntaryexit",
+			res:      &phase0.VoluntaryExit{},
+		},
+		{
+			name:     "ForkDependent",
+			typeName: "BeaconBlock",
+			fork:     spec.DataVersionAltair,
+			res:      &altair.BeaconBlock{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.NewSSZContainer(test.typeName, test.fork)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.IsType(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestSSZContainerRoundTrip(t *testing.T) {
+	header := &phase0.BeaconBlockHeader{
+		Slot:          100,
+		ProposerIndex: 5,
+		ParentRoot:    phase0.Root{0x01},
+		StateRoot:     phase0.Root{0x02},
+		BodyRoot:      phase0.Root{0x03},
+	}
+	data, err := header.MarshalSSZ()
+	require.NoError(t, err)
+	expected, err := header.HashTreeRoot()
+	require.NoError(t, err)
+
+	container, err := util.NewSSZContainer("BeaconBlockHeader", spec.DataVersionUnknown)
+	require.NoError(t, err)
+	require.NoError(t, container.UnmarshalSSZ(data))
+	root, err := container.HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, expected, root)
+}
+
+func TestParseFork(t *testing.T) {
+	fork, err := util.ParseFork("Capella")
+	require.NoError(t, err)
+	require.Equal(t, spec.DataVersionCapella, fork)
+
+	_, err = util.ParseFork("unknown")
+	require.EqualError(t, err, "unknown fork unknown")
+}
+
+func TestActiveFork(t *testing.T) {
+	client := &forkETH2Client{currentVersion: phase0.Version{0x01, 0x00, 0x00, 0x00}}
+	fork, err := util.ActiveFork(context.Background(), client, client)
+	require.NoError(t, err)
+	require.Equal(t, spec.DataVersionAltair, fork)
+
+	client = &forkETH2Client{currentVersion: phase0.Version{0x09, 0x00, 0x00, 0x00}}
+	_, err = util.ActiveFork(context.Background(), client, client)
+	require.EqualError(t, err, "current fork version 0x09000000 is not supported")
+}
@@ -0,0 +1,412 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// sszChunkLength is the length of an SSZ chunk.
+const sszChunkLength = 32
+
+// sszZeroHashes are the roots of empty trees of increasing depth.
+var sszZeroHashes = func() [][sszChunkLength]byte {
+	res := make([][sszChunkLength]byte, 65)
+	for i := 1; i < len(res); i++ {
+		res[i] = sszHashPair(res[i-1], res[i-1])
+	}
+
+	return res
+}()
+
+// sszType is an SSZ type that can be merkleized from its serialized form.
+type sszType interface {
+	// fixedSize returns the serialized size of the type, or 0 if it is variable-size.
+	fixedSize() uint64
+	// leaves returns the leaves of the Merkle tree of the type, along with the
+	// limit on the number of leaves and, for lists, the length to mix in.
+	leaves(data []byte) ([][sszChunkLength]byte, uint64, *uint64, error)
+	// child returns the serialized data and type of the leaf at the given index,
+	// if it is composite.
+	child(data []byte, index uint64) ([]byte, sszType, error)
+}
+
+// sszBasic is an SSZ type whose value is packed directly in to chunks,
+// such as an integer or fixed-length byte vector.
+type sszBasic struct {
+	size uint64
+}
+
+func (t *sszBasic) fixedSize() uint64 {
+	return t.size
+}
+
+func (t *sszBasic) leaves(data []byte) ([][sszChunkLength]byte, uint64, *uint64, error) {
+	if uint64(len(data)) != t.size {
+		return nil, 0, nil, fmt.Errorf("expected %d bytes, found %d", t.size, len(data))
+	}
+
+	return sszPack(data), (t.size + sszChunkLength - 1) / sszChunkLength, nil, nil
+}
+
+func (*sszBasic) child(_ []byte, _ uint64) ([]byte, sszType, error) {
+	return nil, nil, errors.New("basic type has no children")
+}
+
+// sszVector is an SSZ vector of fixed-size elements.  Elements of basic types
+// are packed in to chunks; others are merkleized individually.
+type sszVector struct {
+	elem   sszType
+	length uint64
+	packed bool
+}
+
+func (t *sszVector) fixedSize() uint64 {
+	return t.elem.fixedSize() * t.length
+}
+
+func (t *sszVector) leaves(data []byte) ([][sszChunkLength]byte, uint64, *uint64, error) {
+	if uint64(len(data)) != t.fixedSize() {
+		return nil, 0, nil, fmt.Errorf("expected %d bytes, found %d", t.fixedSize(), len(data))
+	}
+	leaves, limit, err := sszElementLeaves(t.elem, t.packed, data, t.length)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	return leaves, limit, nil, nil
+}
+
+func (t *sszVector) child(data []byte, index uint64) ([]byte, sszType, error) {
+	return sszElement(t.elem, t.packed, data, index)
+}
+
+// sszList is an SSZ list of fixed-size elements.  Elements of basic types
+// are packed in to chunks; others are merkleized individually.
+type sszList struct {
+	elem   sszType
+	limit  uint64
+	packed bool
+}
+
+func (*sszList) fixedSize() uint64 {
+	return 0
+}
+
+func (t *sszList) leaves(data []byte) ([][sszChunkLength]byte, uint64, *uint64, error) {
+	elemSize := t.elem.fixedSize()
+	if uint64(len(data))%elemSize != 0 {
+		return nil, 0, nil, fmt.Errorf("list data length %d is not a multiple of its element size %d", len(data), elemSize)
+	}
+	length := uint64(len(data)) / elemSize
+	if length > t.limit {
+		return nil, 0, nil, fmt.Errorf("list length %d exceeds its limit %d", length, t.limit)
+	}
+	leaves, limit, err := sszElementLeaves(t.elem, t.packed, data, t.limit)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	return leaves, limit, &length, nil
+}
+
+func (t *sszList) child(data []byte, index uint64) ([]byte, sszType, error) {
+	return sszElement(t.elem, t.packed, data, index)
+}
+
+// sszContainer is an SSZ container.
+type sszContainer struct {
+	fields []sszType
+}
+
+func (t *sszContainer) fixedSize() uint64 {
+	size := uint64(0)
+	for _, field := range t.fields {
+		fieldSize := field.fixedSize()
+		if fieldSize == 0 {
+			return 0
+		}
+		size += fieldSize
+	}
+
+	return size
+}
+
+func (t *sszContainer) leaves(data []byte) ([][sszChunkLength]byte, uint64, *uint64, error) {
+	fields, err := t.split(data)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	leaves := make([][sszChunkLength]byte, len(fields))
+	for i := range fields {
+		leaves[i], err = sszRoot(t.fields[i], fields[i])
+		if err != nil {
+			return nil, 0, nil, errors.Wrapf(err, "failed to obtain root of field %d", i)
+		}
+	}
+
+	return leaves, uint64(len(t.fields)), nil, nil
+}
+
+func (t *sszContainer) child(data []byte, index uint64) ([]byte, sszType, error) {
+	if index >= uint64(len(t.fields)) {
+		return nil, nil, fmt.Errorf("container has no field %d", index)
+	}
+	fields, err := t.split(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return fields[index], t.fields[index], nil
+}
+
+// split splits the serialized container in to the serialized data of its fields.
+func (t *sszContainer) split(data []byte) ([][]byte, error) {
+	res := make([][]byte, len(t.fields))
+	variable := make([]int, 0)
+	offsets := make([]uint64, 0)
+	pos := uint64(0)
+	for i, field := range t.fields {
+		size := field.fixedSize()
+		if size == 0 {
+			size = 4
+			variable = append(variable, i)
+		}
+		if pos+size > uint64(len(data)) {
+			return nil, errors.New("container data too short")
+		}
+		if field.fixedSize() == 0 {
+			offsets = append(offsets, uint64(binary.LittleEndian.Uint32(data[pos:pos+4])))
+		} else {
+			res[i] = data[pos : pos+size]
+		}
+		pos += size
+	}
+	if len(variable) == 0 {
+		if pos != uint64(len(data)) {
+			return nil, errors.New("container data length incorrect")
+		}
+
+		return res, nil
+	}
+	if offsets[0] != pos {
+		return nil, errors.New("container first offset incorrect")
+	}
+	offsets = append(offsets, uint64(len(data)))
+	for i, index := range variable {
+		if offsets[i+1] < offsets[i] || offsets[i+1] > uint64(len(data)) {
+			return nil, errors.New("container offsets invalid")
+		}
+		res[index] = data[offsets[i]:offsets[i+1]]
+	}
+
+	return res, nil
+}
+
+// sszElementLeaves returns the leaves for the elements of a vector or list,
+// along with the limit on the number of leaves given the limit on the number of elements.
+func sszElementLeaves(elem sszType, packed bool, data []byte, limit uint64) ([][sszChunkLength]byte, uint64, error) {
+	elemSize := elem.fixedSize()
+	if packed {
+		return sszPack(data), (limit*elemSize + sszChunkLength - 1) / sszChunkLength, nil
+	}
+
+	leaves := make([][sszChunkLength]byte, uint64(len(data))/elemSize)
+	for i := range leaves {
+		var err error
+		leaves[i], err = sszRoot(elem, data[uint64(i)*elemSize:uint64(i+1)*elemSize])
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "failed to obtain root of element %d", i)
+		}
+	}
+
+	return leaves, limit, nil
+}
+
+// sszElement returns the serialized data of the element of a vector or list at the given index.
+func sszElement(elem sszType, packed bool, data []byte, index uint64) ([]byte, sszType, error) {
+	if packed {
+		return nil, nil, errors.New("packed elements have no children")
+	}
+	elemSize := elem.fixedSize()
+	if (index+1)*elemSize > uint64(len(data)) {
+		return nil, nil, fmt.Errorf("no element %d", index)
+	}
+
+	return data[index*elemSize : (index+1)*elemSize], elem, nil
+}
+
+// sszRoot returns the hash tree root of the serialized data of the given type.
+func sszRoot(t sszType, data []byte) ([sszChunkLength]byte, error) {
+	leaves, limit, length, err := t.leaves(data)
+	if err != nil {
+		return [sszChunkLength]byte{}, err
+	}
+	root, _, err := sszMerkleize(leaves, limit, -1)
+	if err != nil {
+		return [sszChunkLength]byte{}, err
+	}
+	if length != nil {
+		root = sszMixInLength(root, *length)
+	}
+
+	return root, nil
+}
+
+// sszProve returns a proof for the leaf reached by following the path of
+// leaf indices down from the root of the serialized data of the given type.
+func sszProve(t sszType, data []byte, path []uint64) (*MerkleProof, error) {
+	if len(path) == 0 {
+		return nil, errors.New("no path supplied")
+	}
+	leaves, limit, length, err := t.leaves(data)
+	if err != nil {
+		return nil, err
+	}
+	index := path[0]
+	if index >= uint64(len(leaves)) {
+		return nil, fmt.Errorf("no leaf %d", index)
+	}
+	root, branch, err := sszMerkleize(leaves, limit, int64(index))
+	if err != nil {
+		return nil, err
+	}
+	depth := uint64(len(branch))
+	if length != nil {
+		// The length is mixed in as the sibling of the root of the data.
+		root = sszMixInLength(root, *length)
+		branch = append(branch, sszLengthChunk(*length))
+	}
+
+	proof := &MerkleProof{
+		Leaf:             leaves[index],
+		Branch:           make([]phase0.Root, 0, len(branch)),
+		GeneralizedIndex: uint64(1)<<(uint64(len(branch))) | index,
+		Root:             root,
+	}
+	if length != nil {
+		// The data is the left-hand child of the root.
+		proof.GeneralizedIndex = uint64(2)<<depth | index
+	}
+	for _, node := range branch {
+		proof.Branch = append(proof.Branch, node)
+	}
+
+	if len(path) == 1 {
+		return proof, nil
+	}
+
+	childData, childType, err := t.child(data, index)
+	if err != nil {
+		return nil, err
+	}
+	childProof, err := sszProve(childType, childData, path[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return childProof.Chain(proof)
+}
+
+// sszMerkleize merkleizes the leaves padded to the limit, returning the root
+// and, if index is not negative, the branch for the leaf at that index.
+func sszMerkleize(leaves [][sszChunkLength]byte, limit uint64, index int64) ([sszChunkLength]byte, [][sszChunkLength]byte, error) {
+	if uint64(len(leaves)) > limit {
+		return [sszChunkLength]byte{}, nil, fmt.Errorf("%d leaves exceed the limit %d", len(leaves), limit)
+	}
+	depth := 0
+	for uint64(1)<<depth < limit {
+		depth++
+	}
+
+	var branch [][sszChunkLength]byte
+	if index >= 0 {
+		branch = make([][sszChunkLength]byte, 0, depth)
+	}
+	layer := leaves
+	for i := 0; i < depth; i++ {
+		if index >= 0 {
+			sibling := index ^ 1
+			if sibling < int64(len(layer)) {
+				branch = append(branch, layer[sibling])
+			} else {
+				branch = append(branch, sszZeroHashes[i])
+			}
+			index >>= 1
+		}
+		next := make([][sszChunkLength]byte, (len(layer)+1)/2)
+		for j := range next {
+			right := sszZeroHashes[i]
+			if 2*j+1 < len(layer) {
+				right = layer[2*j+1]
+			}
+			next[j] = sszHashPair(layer[2*j], right)
+		}
+		layer = next
+	}
+	if len(layer) == 0 {
+		return sszZeroHashes[depth], branch, nil
+	}
+
+	return layer[0], branch, nil
+}
+
+// sszPack packs the data in to chunks, padding the final chunk with zeros.
+func sszPack(data []byte) [][sszChunkLength]byte {
+	chunks := make([][sszChunkLength]byte, (len(data)+sszChunkLength-1)/sszChunkLength)
+	for i := range chunks {
+		copy(chunks[i][:], data[i*sszChunkLength:])
+	}
+
+	return chunks
+}
+
+func sszLengthChunk(length uint64) [sszChunkLength]byte {
+	var chunk [sszChunkLength]byte
+	binary.LittleEndian.PutUint64(chunk[:], length)
+
+	return chunk
+}
+
+func sszMixInLength(root [sszChunkLength]byte, length uint64) [sszChunkLength]byte {
+	return sszHashPair(root, sszLengthChunk(length))
+}
+
+func sszHashPair(left [sszChunkLength]byte, right [sszChunkLength]byte) [sszChunkLength]byte {
+	data := make([]byte, 2*sszChunkLength)
+	copy(data, left[:])
+	copy(data[sszChunkLength:], right[:])
+
+	return sha256.Sum256(data)
+}
+
+// SSZListRoot returns the hash tree root of a list of composite SSZ elements
+// with the given limit, given the hash tree roots of its elements.
+func SSZListRoot(roots []phase0.Root, limit uint64) (phase0.Root, error) {
+	leaves := make([][sszChunkLength]byte, len(roots))
+	for i := range roots {
+		leaves[i] = roots[i]
+	}
+	root, _, err := sszMerkleize(leaves, limit, -1)
+	if err != nil {
+		return phase0.Root{}, err
+	}
+
+	return sszMixInLength(root, uint64(len(roots))), nil
+}
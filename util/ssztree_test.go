@@ -0,0 +1,86 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestSSZListRoot(t *testing.T) {
+	tests := []struct {
+		name     string
+		roots    []phase0.Root
+		limit    uint64
+		expected phase0.Root
+		err      string
+	}{
+		{
+			name:  "EmptyDeposits",
+			limit: 1 << 32,
+			// The root of the empty deposit contract tree.
+			expected: phase0.Root{
+				0xd7, 0x0a, 0x23, 0x47, 0x31, 0x28, 0x5c, 0x68, 0x04, 0xc2, 0xa4, 0xf5, 0x67, 0x11, 0xdd, 0xb8,
+				0xc8, 0x2c, 0x99, 0x74, 0x0f, 0x20, 0x78, 0x54, 0x89, 0x10, 0x28, 0xaf, 0x34, 0xe2, 0x7e, 0x5e,
+			},
+		},
+		{
+			name:  "TooLong",
+			roots: []phase0.Root{{}, {}, {}},
+			limit: 2,
+			err:   "3 leaves exceed the limit 2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root, err := util.SSZListRoot(test.roots, test.limit)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, root)
+			}
+		})
+	}
+}
+
+func TestSSZListRootValidators(t *testing.T) {
+	validators := []*phase0.Validator{
+		{PublicKey: phase0.BLSPubKey{0x01}, WithdrawalCredentials: make([]byte, 32), EffectiveBalance: 32000000000},
+		{PublicKey: phase0.BLSPubKey{0x02}, WithdrawalCredentials: make([]byte, 32), ExitEpoch: 10},
+		{PublicKey: phase0.BLSPubKey{0x03}, WithdrawalCredentials: make([]byte, 32), Slashed: true},
+	}
+
+	roots := make([]phase0.Root, len(validators))
+	hh := ssz.NewHasher()
+	indx := hh.Index()
+	for i, validator := range validators {
+		var err error
+		roots[i], err = validator.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, validator.HashTreeRootWith(hh))
+	}
+	hh.MerkleizeWithMixin(indx, uint64(len(validators)), 1099511627776)
+	expected, err := hh.HashRoot()
+	require.NoError(t, err)
+
+	root, err := util.SSZListRoot(roots, 1099511627776)
+	require.NoError(t, err)
+	require.Equal(t, phase0.Root(expected), root)
+}
@@ -0,0 +1,369 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+const (
+	// BeaconStateValidatorsField is the index of the validators field of a beacon state.
+	BeaconStateValidatorsField = 11
+	// BeaconStateBalancesField is the index of the balances field of a beacon state.
+	BeaconStateBalancesField = 12
+	// ValidatorWithdrawalCredentialsField is the index of the withdrawal credentials field of a validator.
+	ValidatorWithdrawalCredentialsField = 1
+	// BeaconBlockHeaderStateRootField is the index of the state root field of a beacon block header.
+	BeaconBlockHeaderStateRootField = 3
+)
+
+const (
+	// ValidatorProofFieldValidator is the validator record.
+	ValidatorProofFieldValidator = "validator"
+	// ValidatorProofFieldBalance is the validator balance.
+	ValidatorProofFieldBalance = "balance"
+	// ValidatorProofFieldWithdrawalCredentials is the validator withdrawal credentials.
+	ValidatorProofFieldWithdrawalCredentials = "withdrawal-credentials"
+)
+
+var (
+	sszUint64  = &sszBasic{size: 8}
+	sszBytes32 = &sszBasic{size: 32}
+	sszBytes48 = &sszBasic{size: 48}
+
+	sszBeaconBlockHeader = &sszContainer{fields: []sszType{
+		sszUint64,  // slot
+		sszUint64,  // proposer_index
+		sszBytes32, // parent_root
+		sszBytes32, // state_root
+		sszBytes32, // body_root
+	}}
+	sszValidator = &sszContainer{fields: []sszType{
+		sszBytes48,         // pubkey
+		sszBytes32,         // withdrawal_credentials
+		sszUint64,          // effective_balance
+		&sszBasic{size: 1}, // slashed
+		sszUint64,          // activation_eligibility_epoch
+		sszUint64,          // activation_epoch
+		sszUint64,          // exit_epoch
+		sszUint64,          // withdrawable_epoch
+	}}
+)
+
+// beaconStateSchemaConfig contains the chain configuration that defines the schema of a beacon state.
+type beaconStateSchemaConfig struct {
+	slotsPerHistoricalRoot         uint64
+	historicalRootsLimit           uint64
+	eth1DataVotesLimit             uint64
+	validatorRegistryLimit         uint64
+	epochsPerHistoricalVector      uint64
+	epochsPerSlashingsVector       uint64
+	syncCommitteeSize              uint64
+	pendingDepositsLimit           uint64
+	pendingPartialWithdrawalsLimit uint64
+	pendingConsolidationsLimit     uint64
+}
+
+// BeaconStateTree provides the root of, and Merkle proofs for the fields of,
+// an SSZ-encoded beacon state.  Deneb and Electra states are supported.
+type BeaconStateTree struct {
+	data   []byte
+	schema *sszContainer
+}
+
+// ObtainBeaconStateTree obtains the SSZ-encoded beacon state from the beacon node.
+func ObtainBeaconStateTree(ctx context.Context, client eth2client.Service, stateID string) (*BeaconStateTree, error) {
+	specProvider, isProvider := client.(eth2client.SpecProvider)
+	if !isProvider {
+		return nil, errors.New("connection does not provide spec information")
+	}
+	specResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain spec")
+	}
+
+	body, err := BeaconNodeGet(ctx, client, fmt.Sprintf("/eth/v2/debug/beacon/states/%s", stateID), "application/octet-stream")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain state")
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read state")
+	}
+
+	return NewBeaconStateTree(data, specResponse.Data)
+}
+
+// NewBeaconStateTree creates a tree for the SSZ-encoded beacon state, given the chain specification.
+func NewBeaconStateTree(data []byte, spec map[string]any) (*BeaconStateTree, error) {
+	config, err := obtainBeaconStateSchemaConfig(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// The fork of the state is obtained from its current fork version.
+	if len(data) < 176 {
+		return nil, errors.New("state data too short")
+	}
+	version := data[52:56]
+	var schema *sszContainer
+	switch {
+	case forkVersionMatches(spec, "ELECTRA_FORK_VERSION", version):
+		schema = beaconStateSchema(config, true)
+	case forkVersionMatches(spec, "DENEB_FORK_VERSION", version):
+		schema = beaconStateSchema(config, false)
+	default:
+		return nil, fmt.Errorf("state with fork version %#x is not supported", version)
+	}
+
+	return &BeaconStateTree{
+		data:   data,
+		schema: schema,
+	}, nil
+}
+
+// Slot returns the slot of the state.
+func (t *BeaconStateTree) Slot() phase0.Slot {
+	return phase0.Slot(uint64(t.data[40]) | uint64(t.data[41])<<8 | uint64(t.data[42])<<16 | uint64(t.data[43])<<24 |
+		uint64(t.data[44])<<32 | uint64(t.data[45])<<40 | uint64(t.data[46])<<48 | uint64(t.data[47])<<56)
+}
+
+// Root returns the hash tree root of the state.
+func (t *BeaconStateTree) Root() (phase0.Root, error) {
+	return sszRoot(t.schema, t.data)
+}
+
+// LatestBlockHeader returns the latest block header of the state.  If the
+// state is the post-state of the block then the header's state root is not
+// yet recorded, in which case it is set to the root of the state, so that
+// the root of the header is the root of the block.
+func (t *BeaconStateTree) LatestBlockHeader() (*phase0.BeaconBlockHeader, error) {
+	header := &phase0.BeaconBlockHeader{}
+	if err := header.UnmarshalSSZ(t.data[64:176]); err != nil {
+		return nil, errors.Wrap(err, "failed to decode latest block header")
+	}
+	if header.StateRoot.IsZero() {
+		root, err := t.Root()
+		if err != nil {
+			return nil, err
+		}
+		header.StateRoot = root
+	}
+
+	return header, nil
+}
+
+// Prove returns a proof of the leaf reached by following the path of field
+// and element indices from the root of the state.  For example, the path
+// (BeaconStateValidatorsField, 5, ValidatorWithdrawalCredentialsField) proves
+// the withdrawal credentials of validator 5.  Balances are packed four to a
+// leaf, so the leaf for the balance of validator i is at index i/4.
+func (t *BeaconStateTree) Prove(path ...uint64) (*MerkleProof, error) {
+	return sszProve(t.schema, t.data, path)
+}
+
+// ValidatorProofPath returns the path through a beacon state to the leaf for
+// the given field of the validator, for use with BeaconStateTree.Prove.
+func ValidatorProofPath(field string, index phase0.ValidatorIndex) ([]uint64, error) {
+	switch field {
+	case ValidatorProofFieldValidator:
+		return []uint64{BeaconStateValidatorsField, uint64(index)}, nil
+	case ValidatorProofFieldBalance:
+		// Balances are packed four to a leaf.
+		return []uint64{BeaconStateBalancesField, uint64(index) / 4}, nil
+	case ValidatorProofFieldWithdrawalCredentials:
+		return []uint64{BeaconStateValidatorsField, uint64(index), ValidatorWithdrawalCredentialsField}, nil
+	default:
+		return nil, fmt.Errorf("field must be one of %q, %q or %q", ValidatorProofFieldValidator, ValidatorProofFieldBalance, ValidatorProofFieldWithdrawalCredentials)
+	}
+}
+
+// ProveBeaconBlockHeaderStateRoot returns a proof of the state root of the
+// block header against the root of the block.
+func ProveBeaconBlockHeaderStateRoot(header *phase0.BeaconBlockHeader) (*MerkleProof, error) {
+	data, err := header.MarshalSSZ()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode block header")
+	}
+
+	return sszProve(sszBeaconBlockHeader, data, []uint64{BeaconBlockHeaderStateRootField})
+}
+
+func forkVersionMatches(spec map[string]any, name string, version []byte) bool {
+	specVersion, isVersion := spec[name].(phase0.Version)
+
+	return isVersion && bytes.Equal(specVersion[:], version)
+}
+
+func obtainBeaconStateSchemaConfig(spec map[string]any) (*beaconStateSchemaConfig, error) {
+	config := &beaconStateSchemaConfig{}
+	for name, param := range map[string]*uint64{
+		"SLOTS_PER_HISTORICAL_ROOT":    &config.slotsPerHistoricalRoot,
+		"EPOCHS_PER_HISTORICAL_VECTOR": &config.epochsPerHistoricalVector,
+		"EPOCHS_PER_SLASHINGS_VECTOR":  &config.epochsPerSlashingsVector,
+		"SYNC_COMMITTEE_SIZE":          &config.syncCommitteeSize,
+	} {
+		value, isValue := spec[name].(uint64)
+		if !isValue {
+			return nil, fmt.Errorf("spec missing %s", name)
+		}
+		*param = value
+	}
+	slotsPerEpoch, isValue := spec["SLOTS_PER_EPOCH"].(uint64)
+	if !isValue {
+		return nil, fmt.Errorf("spec missing %s", "SLOTS_PER_EPOCH")
+	}
+	epochsPerEth1VotingPeriod, isValue := spec["EPOCHS_PER_ETH1_VOTING_PERIOD"].(uint64)
+	if !isValue {
+		return nil, fmt.Errorf("spec missing %s", "EPOCHS_PER_ETH1_VOTING_PERIOD")
+	}
+	config.eth1DataVotesLimit = slotsPerEpoch * epochsPerEth1VotingPeriod
+
+	// Limits are not always supplied by beacon nodes, so default to their preset values.
+	for name, param := range map[string]struct {
+		target       *uint64
+		defaultValue uint64
+	}{
+		"HISTORICAL_ROOTS_LIMIT":            {&config.historicalRootsLimit, 1 << 24},
+		"VALIDATOR_REGISTRY_LIMIT":          {&config.validatorRegistryLimit, 1 << 40},
+		"PENDING_DEPOSITS_LIMIT":            {&config.pendingDepositsLimit, 1 << 27},
+		"PENDING_PARTIAL_WITHDRAWALS_LIMIT": {&config.pendingPartialWithdrawalsLimit, 1 << 27},
+		"PENDING_CONSOLIDATIONS_LIMIT":      {&config.pendingConsolidationsLimit, 1 << 18},
+	} {
+		*param.target = param.defaultValue
+		if value, isValue := spec[name].(uint64); isValue {
+			*param.target = value
+		}
+	}
+
+	return config, nil
+}
+
+// beaconStateSchema returns the schema of a Deneb or, if electra is set, an Electra beacon state.
+func beaconStateSchema(config *beaconStateSchemaConfig, electra bool) *sszContainer {
+	fork := &sszContainer{fields: []sszType{
+		&sszBasic{size: 4}, // previous_version
+		&sszBasic{size: 4}, // current_version
+		sszUint64,          // epoch
+	}}
+	eth1Data := &sszContainer{fields: []sszType{
+		sszBytes32, // deposit_root
+		sszUint64,  // deposit_count
+		sszBytes32, // block_hash
+	}}
+	checkpoint := &sszContainer{fields: []sszType{
+		sszUint64,  // epoch
+		sszBytes32, // root
+	}}
+	syncCommittee := &sszContainer{fields: []sszType{
+		&sszVector{elem: sszBytes48, length: config.syncCommitteeSize}, // pubkeys
+		sszBytes48, // aggregate_pubkey
+	}}
+	executionPayloadHeader := &sszContainer{fields: []sszType{
+		sszBytes32,           // parent_hash
+		&sszBasic{size: 20},  // fee_recipient
+		sszBytes32,           // state_root
+		sszBytes32,           // receipts_root
+		&sszBasic{size: 256}, // logs_bloom
+		sszBytes32,           // prev_randao
+		sszUint64,            // block_number
+		sszUint64,            // gas_limit
+		sszUint64,            // gas_used
+		sszUint64,            // timestamp
+		&sszList{elem: &sszBasic{size: 1}, limit: 32, packed: true}, // extra_data
+		sszBytes32, // base_fee_per_gas
+		sszBytes32, // block_hash
+		sszBytes32, // transactions_root
+		sszBytes32, // withdrawals_root
+		sszUint64,  // blob_gas_used
+		sszUint64,  // excess_blob_gas
+	}}
+	historicalSummary := &sszContainer{fields: []sszType{
+		sszBytes32, // block_summary_root
+		sszBytes32, // state_summary_root
+	}}
+
+	fields := []sszType{
+		sszUint64,            // genesis_time
+		sszBytes32,           // genesis_validators_root
+		sszUint64,            // slot
+		fork,                 // fork
+		sszBeaconBlockHeader, // latest_block_header
+		&sszVector{elem: sszBytes32, length: config.slotsPerHistoricalRoot}, // block_roots
+		&sszVector{elem: sszBytes32, length: config.slotsPerHistoricalRoot}, // state_roots
+		&sszList{elem: sszBytes32, limit: config.historicalRootsLimit},      // historical_roots
+		eth1Data, // eth1_data
+		&sszList{elem: eth1Data, limit: config.eth1DataVotesLimit}, // eth1_data_votes
+		sszUint64, // eth1_deposit_index
+		&sszList{elem: sszValidator, limit: config.validatorRegistryLimit},                     // validators
+		&sszList{elem: sszUint64, limit: config.validatorRegistryLimit, packed: true},          // balances
+		&sszVector{elem: sszBytes32, length: config.epochsPerHistoricalVector},                 // randao_mixes
+		&sszVector{elem: sszUint64, length: config.epochsPerSlashingsVector, packed: true},     // slashings
+		&sszList{elem: &sszBasic{size: 1}, limit: config.validatorRegistryLimit, packed: true}, // previous_epoch_participation
+		&sszList{elem: &sszBasic{size: 1}, limit: config.validatorRegistryLimit, packed: true}, // current_epoch_participation
+		&sszBasic{size: 1}, // justification_bits
+		checkpoint,         // previous_justified_checkpoint
+		checkpoint,         // current_justified_checkpoint
+		checkpoint,         // finalized_checkpoint
+		&sszList{elem: sszUint64, limit: config.validatorRegistryLimit, packed: true}, // inactivity_scores
+		syncCommittee,          // current_sync_committee
+		syncCommittee,          // next_sync_committee
+		executionPayloadHeader, // latest_execution_payload_header
+		sszUint64,              // next_withdrawal_index
+		sszUint64,              // next_withdrawal_validator_index
+		&sszList{elem: historicalSummary, limit: config.historicalRootsLimit}, // historical_summaries
+	}
+	if !electra {
+		return &sszContainer{fields: fields}
+	}
+
+	pendingDeposit := &sszContainer{fields: []sszType{
+		sszBytes48,          // pubkey
+		sszBytes32,          // withdrawal_credentials
+		sszUint64,           // amount
+		&sszBasic{size: 96}, // signature
+		sszUint64,           // slot
+	}}
+	pendingPartialWithdrawal := &sszContainer{fields: []sszType{
+		sszUint64, // validator_index
+		sszUint64, // amount
+		sszUint64, // withdrawable_epoch
+	}}
+	pendingConsolidation := &sszContainer{fields: []sszType{
+		sszUint64, // source_index
+		sszUint64, // target_index
+	}}
+	fields = append(fields,
+		sszUint64, // deposit_requests_start_index
+		sszUint64, // deposit_balance_to_consume
+		sszUint64, // exit_balance_to_consume
+		sszUint64, // earliest_exit_epoch
+		sszUint64, // consolidation_balance_to_consume
+		sszUint64, // earliest_consolidation_epoch
+		&sszList{elem: pendingDeposit, limit: config.pendingDepositsLimit},                     // pending_deposits
+		&sszList{elem: pendingPartialWithdrawal, limit: config.pendingPartialWithdrawalsLimit}, // pending_partial_withdrawals
+		&sszList{elem: pendingConsolidation, limit: config.pendingConsolidationsLimit},         // pending_consolidations
+	)
+
+	return &sszContainer{fields: fields}
+}
@@ -0,0 +1,248 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func testStateProofSpec(denebVersion phase0.Version, electraVersion phase0.Version) map[string]any {
+	return map[string]any{
+		"SLOTS_PER_EPOCH":               uint64(32),
+		"EPOCHS_PER_ETH1_VOTING_PERIOD": uint64(64),
+		"SLOTS_PER_HISTORICAL_ROOT":     uint64(testSlotsPerHistoricalRoot),
+		"EPOCHS_PER_HISTORICAL_VECTOR":  uint64(65536),
+		"EPOCHS_PER_SLASHINGS_VECTOR":   uint64(8192),
+		"SYNC_COMMITTEE_SIZE":           uint64(512),
+		"DENEB_FORK_VERSION":            denebVersion,
+		"ELECTRA_FORK_VERSION":          electraVersion,
+	}
+}
+
+func TestBeaconStateTreeDeneb(t *testing.T) {
+	state := testDenebState(t)
+	state.Validators[3].WithdrawalCredentials[0] = 0x01
+	state.Validators[3].WithdrawalCredentials[31] = 0xff
+	data, err := state.MarshalSSZ()
+	require.NoError(t, err)
+	expectedRoot, err := state.HashTreeRoot()
+	require.NoError(t, err)
+
+	tree, err := NewBeaconStateTree(data, testStateProofSpec(phase0.Version{0x01}, phase0.Version{0x02}))
+	require.NoError(t, err)
+	require.Equal(t, phase0.Slot(12345), tree.Slot())
+	root, err := tree.Root()
+	require.NoError(t, err)
+	require.Equal(t, phase0.Root(expectedRoot), root)
+
+	validatorRoot, err := state.Validators[3].HashTreeRoot()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		path   []uint64
+		leaf   phase0.Root
+		gindex uint64
+	}{
+		{
+			name:   "Validator",
+			path:   []uint64{BeaconStateValidatorsField, 3},
+			leaf:   validatorRoot,
+			gindex: (32+BeaconStateValidatorsField)<<41 | 3,
+		},
+		{
+			name:   "WithdrawalCredentials",
+			path:   []uint64{BeaconStateValidatorsField, 3, ValidatorWithdrawalCredentialsField},
+			leaf:   phase0.Root(state.Validators[3].WithdrawalCredentials),
+			gindex: ((32+BeaconStateValidatorsField)<<41|3)<<3 | ValidatorWithdrawalCredentialsField,
+		},
+		{
+			name: "Balance",
+			// Balances are packed four to a leaf, so this leaf holds only the balance of validator 4.
+			path: []uint64{BeaconStateBalancesField, 1},
+			leaf: phase0.Root{
+				0x04, 0x40, 0x59, 0x73, 0x07, 0x00, 0x00, 0x00,
+			},
+			gindex: (32+BeaconStateBalancesField)<<39 | 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			proof, err := tree.Prove(test.path...)
+			require.NoError(t, err)
+			require.Equal(t, test.leaf, proof.Leaf)
+			require.Equal(t, test.gindex, proof.GeneralizedIndex)
+			require.Equal(t, root, proof.Root)
+			require.True(t, proof.Verify())
+
+			proof.Leaf[0] ^= 0x01
+			require.False(t, proof.Verify())
+		})
+	}
+}
+
+func TestBeaconStateTreeElectra(t *testing.T) {
+	data := testElectraStateData(t,
+		[]*PendingDeposit{{PubKey: phase0.BLSPubKey{0x01}, WithdrawalCredentials: make([]byte, 32), Amount: 1000000000}},
+		[]*PendingPartialWithdrawal{{ValidatorIndex: 2, Amount: 1000000000, WithdrawableEpoch: 20}},
+		nil,
+	)
+
+	// Electra states are not decoded by the client library, so check the
+	// tree against a Deneb tree over the same data.
+	_, err := NewBeaconStateTree(data, testStateProofSpec(phase0.Version{0x02}, phase0.Version{0x03}))
+	require.EqualError(t, err, "state with fork version 0x01000000 is not supported")
+
+	tree, err := NewBeaconStateTree(data, testStateProofSpec(phase0.Version{0x02}, phase0.Version{0x01}))
+	require.NoError(t, err)
+	root, err := tree.Root()
+	require.NoError(t, err)
+
+	denebState := testDenebState(t)
+	expectedValidatorRoot, err := denebState.Validators[4].HashTreeRoot()
+	require.NoError(t, err)
+
+	proof, err := tree.Prove(BeaconStateValidatorsField, 4)
+	require.NoError(t, err)
+	require.Equal(t, phase0.Root(expectedValidatorRoot), proof.Leaf)
+	require.Equal(t, uint64(64+BeaconStateValidatorsField)<<41|4, proof.GeneralizedIndex)
+	require.Equal(t, root, proof.Root)
+	require.True(t, proof.Verify())
+
+	// Pending partial withdrawals are field 35.
+	proof, err = tree.Prove(35, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, phase0.Root{0x02}, proof.Leaf)
+	require.True(t, proof.Verify())
+}
+
+func TestBeaconStateTreeLatestBlockHeader(t *testing.T) {
+	spec := testStateProofSpec(phase0.Version{0x01}, phase0.Version{0x02})
+
+	// Post-state of the block, so the header does not yet have its state root.
+	state := testDenebState(t)
+	state.LatestBlockHeader.ProposerIndex = 3
+	stateRoot, err := state.HashTreeRoot()
+	require.NoError(t, err)
+	data, err := state.MarshalSSZ()
+	require.NoError(t, err)
+	tree, err := NewBeaconStateTree(data, spec)
+	require.NoError(t, err)
+	header, err := tree.LatestBlockHeader()
+	require.NoError(t, err)
+	require.Equal(t, &phase0.BeaconBlockHeader{
+		Slot:          12344,
+		ProposerIndex: 3,
+		StateRoot:     stateRoot,
+	}, header)
+
+	// Header with its state root already recorded.
+	state.LatestBlockHeader.StateRoot = phase0.Root{0x01}
+	data, err = state.MarshalSSZ()
+	require.NoError(t, err)
+	tree, err = NewBeaconStateTree(data, spec)
+	require.NoError(t, err)
+	header, err = tree.LatestBlockHeader()
+	require.NoError(t, err)
+	require.Equal(t, phase0.Root{0x01}, header.StateRoot)
+}
+
+func TestValidatorProofPath(t *testing.T) {
+	tests := []struct {
+		name  string
+		field string
+		index phase0.ValidatorIndex
+		path  []uint64
+		err   string
+	}{
+		{
+			name:  "Validator",
+			field: ValidatorProofFieldValidator,
+			index: 5,
+			path:  []uint64{11, 5},
+		},
+		{
+			name:  "Balance",
+			field: ValidatorProofFieldBalance,
+			index: 5,
+			path:  []uint64{12, 1},
+		},
+		{
+			name:  "WithdrawalCredentials",
+			field: ValidatorProofFieldWithdrawalCredentials,
+			index: 5,
+			path:  []uint64{11, 5, 1},
+		},
+		{
+			name:  "Invalid",
+			field: "invalid",
+			err:   `field must be one of "validator", "balance" or "withdrawal-credentials"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path, err := ValidatorProofPath(test.field, test.index)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.path, path)
+		})
+	}
+}
+
+func TestProveBeaconBlockHeaderStateRoot(t *testing.T) {
+	state := testDenebState(t)
+	stateRoot, err := state.HashTreeRoot()
+	require.NoError(t, err)
+	data, err := state.MarshalSSZ()
+	require.NoError(t, err)
+	tree, err := NewBeaconStateTree(data, testStateProofSpec(phase0.Version{0x01}, phase0.Version{0x02}))
+	require.NoError(t, err)
+
+	header := &phase0.BeaconBlockHeader{
+		Slot:          12345,
+		ProposerIndex: 2,
+		ParentRoot:    phase0.Root{0x01},
+		StateRoot:     stateRoot,
+		BodyRoot:      phase0.Root{0x02},
+	}
+	blockRoot, err := header.HashTreeRoot()
+	require.NoError(t, err)
+
+	headerProof, err := ProveBeaconBlockHeaderStateRoot(header)
+	require.NoError(t, err)
+	require.Equal(t, phase0.Root(stateRoot), headerProof.Leaf)
+	require.Equal(t, phase0.Root(blockRoot), headerProof.Root)
+	require.Equal(t, uint64(8|BeaconBlockHeaderStateRootField), headerProof.GeneralizedIndex)
+	require.True(t, headerProof.Verify())
+
+	stateProof, err := tree.Prove(BeaconStateValidatorsField, 0, ValidatorWithdrawalCredentialsField)
+	require.NoError(t, err)
+	proof, err := stateProof.Chain(headerProof)
+	require.NoError(t, err)
+	require.Equal(t, stateProof.Leaf, proof.Leaf)
+	require.Equal(t, phase0.Root(blockRoot), proof.Root)
+	require.Len(t, proof.Branch, len(stateProof.Branch)+len(headerProof.Branch))
+	require.True(t, proof.Verify())
+
+	_, err = headerProof.Chain(stateProof)
+	require.ErrorContains(t, err, "does not match parent leaf")
+}
@@ -0,0 +1,508 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+const (
+	sszOffsetLength                   = 4
+	sszETH1DataLength                 = 72
+	sszValidatorLength                = 121
+	sszBalanceLength                  = 8
+	sszCheckpointLength               = 40
+	sszPendingDepositLength           = 192
+	sszPendingPartialWithdrawalLength = 24
+	sszPendingConsolidationLength     = 16
+)
+
+// StateStreamConfig contains the chain configuration required to decode a
+// beacon state.
+type StateStreamConfig struct {
+	SlotsPerHistoricalRoot    uint64
+	EpochsPerHistoricalVector uint64
+	EpochsPerSlashingsVector  uint64
+	SyncCommitteeSize         uint64
+}
+
+// StateStreamHeader contains the fixed-size fields of a beacon state
+// that precede its validators.
+type StateStreamHeader struct {
+	GenesisTime           uint64
+	GenesisValidatorsRoot phase0.Root
+	Slot                  phase0.Slot
+	Fork                  *phase0.Fork
+	LatestBlockHeader     *phase0.BeaconBlockHeader
+	ETH1Data              *phase0.ETH1Data
+	ETH1DepositIndex      uint64
+	Validators            uint64
+}
+
+// StateStreamElectra contains the fixed-size fields added to the beacon
+// state in Electra, along with those of earlier forks that are required
+// to process them.
+type StateStreamElectra struct {
+	FinalizedCheckpoint           *phase0.Checkpoint
+	NextWithdrawalIndex           uint64
+	NextWithdrawalValidatorIndex  phase0.ValidatorIndex
+	DepositRequestsStartIndex     uint64
+	DepositBalanceToConsume       phase0.Gwei
+	ExitBalanceToConsume          phase0.Gwei
+	EarliestExitEpoch             phase0.Epoch
+	ConsolidationBalanceToConsume phase0.Gwei
+	EarliestConsolidationEpoch    phase0.Epoch
+	PendingDeposits               uint64
+	PendingPartialWithdrawals     uint64
+}
+
+// PendingDeposit is a deposit awaiting processing by the beacon chain.
+type PendingDeposit struct {
+	PubKey                phase0.BLSPubKey
+	WithdrawalCredentials []byte
+	Amount                phase0.Gwei
+	Signature             phase0.BLSSignature
+	Slot                  phase0.Slot
+}
+
+// PendingPartialWithdrawal is a partial withdrawal awaiting processing by
+// the beacon chain.
+type PendingPartialWithdrawal struct {
+	ValidatorIndex    phase0.ValidatorIndex
+	Amount            phase0.Gwei
+	WithdrawableEpoch phase0.Epoch
+}
+
+// PendingConsolidation is a consolidation awaiting processing by the
+// beacon chain.
+type PendingConsolidation struct {
+	SourceIndex phase0.ValidatorIndex
+	TargetIndex phase0.ValidatorIndex
+}
+
+// StateStreamHandler contains the functions called as the fields of a
+// beacon state are decoded.  Any function may be nil, in which case the
+// relevant data is skipped.  Decoding stops as soon as no further
+// functions remain to be called, so the remainder of the state is not read.
+// The Electra functions return an error if the state is not an Electra state.
+type StateStreamHandler struct {
+	Header                   func(header *StateStreamHeader) error
+	Electra                  func(electra *StateStreamElectra) error
+	ETH1DataVote             func(vote *phase0.ETH1Data) error
+	Validator                func(index phase0.ValidatorIndex, validator *phase0.Validator) error
+	Balance                  func(index phase0.ValidatorIndex, balance phase0.Gwei) error
+	PendingDeposit           func(index uint64, deposit *PendingDeposit) error
+	PendingPartialWithdrawal func(index uint64, withdrawal *PendingPartialWithdrawal) error
+	PendingConsolidation     func(index uint64, consolidation *PendingConsolidation) error
+}
+
+func (h *StateStreamHandler) wantsElectra() bool {
+	return h.Electra != nil || h.wantsPending()
+}
+
+func (h *StateStreamHandler) wantsPending() bool {
+	return h.PendingDeposit != nil || h.PendingPartialWithdrawal != nil || h.PendingConsolidation != nil
+}
+
+// ErrNotElectraState is returned when Electra data is requested from an earlier state.
+var ErrNotElectraState = errors.New("state is not an Electra state")
+
+// StreamBeaconState obtains the SSZ-encoded beacon state from the beacon
+// node and decodes it as a stream, calling the handler's functions as data
+// is decoded.  This keeps memory use bounded regardless of the size of the state.
+func StreamBeaconState(ctx context.Context, client eth2client.Service, stateID string, handler *StateStreamHandler) error {
+	specProvider, isProvider := client.(eth2client.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+	specResponse, err := specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain spec")
+	}
+	config := &StateStreamConfig{}
+	for name, target := range map[string]*uint64{
+		"SLOTS_PER_HISTORICAL_ROOT":    &config.SlotsPerHistoricalRoot,
+		"EPOCHS_PER_HISTORICAL_VECTOR": &config.EpochsPerHistoricalVector,
+		"EPOCHS_PER_SLASHINGS_VECTOR":  &config.EpochsPerSlashingsVector,
+		"SYNC_COMMITTEE_SIZE":          &config.SyncCommitteeSize,
+	} {
+		tmp, exists := specResponse.Data[name]
+		if !exists {
+			return fmt.Errorf("spec did not contain %s", name)
+		}
+		val, isUint := tmp.(uint64)
+		if !isUint {
+			return fmt.Errorf("%s value invalid", name)
+		}
+		*target = val
+	}
+
+	body, err := BeaconNodeGet(ctx, client, fmt.Sprintf("/eth/v2/debug/beacon/states/%s", stateID), "application/octet-stream")
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain state")
+	}
+	defer body.Close()
+
+	return DecodeBeaconStateStream(body, config, handler)
+}
+
+// stateStreamReader tracks the position within a state as it is read.
+type stateStreamReader struct {
+	reader *bufio.Reader
+	pos    uint64
+}
+
+func (r *stateStreamReader) read(data []byte, name string) error {
+	if _, err := io.ReadFull(r.reader, data); err != nil {
+		return errors.Wrapf(err, "failed to read %s", name)
+	}
+	r.pos += uint64(len(data))
+
+	return nil
+}
+
+func (r *stateStreamReader) skipTo(pos uint64, name string) error {
+	if pos < r.pos {
+		return fmt.Errorf("cannot skip backwards to %s", name)
+	}
+	if _, err := io.CopyN(io.Discard, r.reader, int64(pos-r.pos)); err != nil {
+		return errors.Wrapf(err, "failed to skip to %s", name)
+	}
+	r.pos = pos
+
+	return nil
+}
+
+// DecodeBeaconStateStream decodes an SSZ-encoded beacon state from the
+// supplied reader, calling the handler's functions as data is decoded.
+// The layout of the state up to and including its balances is common to
+// all forks, so the fork of the state does not need to be known unless
+// Electra data is requested.
+func DecodeBeaconStateStream(r io.Reader, config *StateStreamConfig, handler *StateStreamHandler) error {
+	reader := &stateStreamReader{
+		reader: bufio.NewReaderSize(r, 1024*1024),
+	}
+
+	// The fixed-size part of the state up to the offset of the balances.
+	historicalRootsPos := 176 + 64*config.SlotsPerHistoricalRoot
+	headerLength := historicalRootsPos + 96
+	fixed := make([]byte, headerLength)
+	if err := reader.read(fixed, "state header"); err != nil {
+		return err
+	}
+
+	historicalRootsOffset := uint64(binary.LittleEndian.Uint32(fixed[historicalRootsPos:]))
+	eth1DataVotesOffset := uint64(binary.LittleEndian.Uint32(fixed[historicalRootsPos+76:]))
+	validatorsOffset := uint64(binary.LittleEndian.Uint32(fixed[historicalRootsPos+88:]))
+	balancesOffset := uint64(binary.LittleEndian.Uint32(fixed[historicalRootsPos+92:]))
+	if historicalRootsOffset < headerLength ||
+		eth1DataVotesOffset < historicalRootsOffset ||
+		validatorsOffset < eth1DataVotesOffset ||
+		balancesOffset < validatorsOffset {
+		return errors.New("state offsets invalid")
+	}
+	if (eth1DataVotesOffset-historicalRootsOffset)%32 != 0 ||
+		(validatorsOffset-eth1DataVotesOffset)%sszETH1DataLength != 0 ||
+		(balancesOffset-validatorsOffset)%sszValidatorLength != 0 {
+		return errors.New("state field lengths invalid")
+	}
+	validators := (balancesOffset - validatorsOffset) / sszValidatorLength
+
+	if handler.Header != nil {
+		header, err := decodeStateStreamHeader(fixed, historicalRootsPos)
+		if err != nil {
+			return err
+		}
+		header.Validators = validators
+		if err := handler.Header(header); err != nil {
+			return err
+		}
+	}
+
+	var pending *statePendingLayout
+	if handler.wantsElectra() {
+		// The remainder of the fixed-size part is required, which is only
+		// decoded if the state has the fixed-size length of an Electra state.
+		layout := newElectraStateLayout(config, headerLength)
+		if historicalRootsOffset != layout.fixedLength {
+			return ErrNotElectraState
+		}
+		rest := make([]byte, layout.fixedLength-headerLength)
+		if err := reader.read(rest, "state fixed fields"); err != nil {
+			return err
+		}
+		electra, err := layout.decode(rest)
+		if err != nil {
+			return err
+		}
+		pending = layout.pending(rest)
+		if pending.depositsOffset < balancesOffset ||
+			pending.partialWithdrawalsOffset < pending.depositsOffset ||
+			pending.consolidationsOffset < pending.partialWithdrawalsOffset ||
+			(pending.partialWithdrawalsOffset-pending.depositsOffset)%sszPendingDepositLength != 0 ||
+			(pending.consolidationsOffset-pending.partialWithdrawalsOffset)%sszPendingPartialWithdrawalLength != 0 {
+			return errors.New("state pending queue offsets invalid")
+		}
+		electra.PendingDeposits = (pending.partialWithdrawalsOffset - pending.depositsOffset) / sszPendingDepositLength
+		electra.PendingPartialWithdrawals = (pending.consolidationsOffset - pending.partialWithdrawalsOffset) / sszPendingPartialWithdrawalLength
+		if handler.Electra != nil {
+			if err := handler.Electra(electra); err != nil {
+				return err
+			}
+		}
+	}
+
+	if handler.ETH1DataVote == nil && handler.Validator == nil && handler.Balance == nil && !handler.wantsPending() {
+		return nil
+	}
+
+	// Skip the remainder of the fixed-size part and the historical roots.
+	if err := reader.skipTo(eth1DataVotesOffset, "ETH1 data votes"); err != nil {
+		return err
+	}
+
+	if handler.ETH1DataVote != nil {
+		data := make([]byte, sszETH1DataLength)
+		for i := uint64(0); i < (validatorsOffset-eth1DataVotesOffset)/sszETH1DataLength; i++ {
+			if err := reader.read(data, "ETH1 data vote"); err != nil {
+				return err
+			}
+			vote := &phase0.ETH1Data{}
+			if err := vote.UnmarshalSSZ(data); err != nil {
+				return errors.Wrap(err, "failed to decode ETH1 data vote")
+			}
+			if err := handler.ETH1DataVote(vote); err != nil {
+				return err
+			}
+		}
+	} else if err := reader.skipTo(validatorsOffset, "validators"); err != nil {
+		return err
+	}
+
+	if handler.Validator == nil && handler.Balance == nil && !handler.wantsPending() {
+		return nil
+	}
+
+	if handler.Validator != nil {
+		data := make([]byte, sszValidatorLength)
+		for i := uint64(0); i < validators; i++ {
+			if err := reader.read(data, "validator"); err != nil {
+				return err
+			}
+			validator := &phase0.Validator{}
+			if err := validator.UnmarshalSSZ(data); err != nil {
+				return errors.Wrap(err, "failed to decode validator")
+			}
+			if err := handler.Validator(phase0.ValidatorIndex(i), validator); err != nil {
+				return err
+			}
+		}
+	} else if err := reader.skipTo(balancesOffset, "balances"); err != nil {
+		return err
+	}
+
+	if handler.Balance != nil {
+		data := make([]byte, sszBalanceLength)
+		for i := uint64(0); i < validators; i++ {
+			if err := reader.read(data, "balance"); err != nil {
+				return err
+			}
+			if err := handler.Balance(phase0.ValidatorIndex(i), phase0.Gwei(binary.LittleEndian.Uint64(data))); err != nil {
+				return err
+			}
+		}
+	}
+
+	if pending == nil || !handler.wantsPending() {
+		return nil
+	}
+
+	return decodePendingQueues(reader, pending, handler)
+}
+
+// electraStateLayout contains the positions of the fixed-size fields of an
+// Electra state that follow the offset of the balances.
+type electraStateLayout struct {
+	base                uint64
+	finalizedCheckpoint uint64
+	electraFields       uint64
+	fixedLength         uint64
+}
+
+// statePendingLayout contains the offsets of the pending queues of an Electra state.
+type statePendingLayout struct {
+	depositsOffset           uint64
+	partialWithdrawalsOffset uint64
+	consolidationsOffset     uint64
+}
+
+func newElectraStateLayout(config *StateStreamConfig, base uint64) *electraStateLayout {
+	syncCommitteeLength := (config.SyncCommitteeSize + 1) * phase0.PublicKeyLength
+	// RANDAO mixes, slashings, participation offsets and justification bits.
+	justificationBits := base + 32*config.EpochsPerHistoricalVector + 8*config.EpochsPerSlashingsVector + 2*sszOffsetLength
+	finalizedCheckpoint := justificationBits + 1 + 2*sszCheckpointLength
+	// Finalized checkpoint, inactivity scores offset, sync committees,
+	// execution payload header offset, withdrawal indices and historical
+	// summaries offset.
+	electraFields := finalizedCheckpoint + sszCheckpointLength + sszOffsetLength + 2*syncCommitteeLength + sszOffsetLength + 16 + sszOffsetLength
+
+	return &electraStateLayout{
+		base:                base,
+		finalizedCheckpoint: finalizedCheckpoint,
+		electraFields:       electraFields,
+		fixedLength:         electraFields + 48 + 3*sszOffsetLength,
+	}
+}
+
+// decode decodes the Electra fields from the fixed-size data that follows the base.
+func (l *electraStateLayout) decode(data []byte) (*StateStreamElectra, error) {
+	checkpoint := &phase0.Checkpoint{}
+	pos := l.finalizedCheckpoint - l.base
+	if err := checkpoint.UnmarshalSSZ(data[pos : pos+sszCheckpointLength]); err != nil {
+		return nil, errors.Wrap(err, "failed to decode finalized checkpoint")
+	}
+	// The withdrawal indices precede the historical summaries offset.
+	withdrawalsPos := l.electraFields - l.base - sszOffsetLength - 16
+	pos = l.electraFields - l.base
+
+	return &StateStreamElectra{
+		FinalizedCheckpoint:           checkpoint,
+		NextWithdrawalIndex:           binary.LittleEndian.Uint64(data[withdrawalsPos : withdrawalsPos+8]),
+		NextWithdrawalValidatorIndex:  phase0.ValidatorIndex(binary.LittleEndian.Uint64(data[withdrawalsPos+8 : withdrawalsPos+16])),
+		DepositRequestsStartIndex:     binary.LittleEndian.Uint64(data[pos : pos+8]),
+		DepositBalanceToConsume:       phase0.Gwei(binary.LittleEndian.Uint64(data[pos+8 : pos+16])),
+		ExitBalanceToConsume:          phase0.Gwei(binary.LittleEndian.Uint64(data[pos+16 : pos+24])),
+		EarliestExitEpoch:             phase0.Epoch(binary.LittleEndian.Uint64(data[pos+24 : pos+32])),
+		ConsolidationBalanceToConsume: phase0.Gwei(binary.LittleEndian.Uint64(data[pos+32 : pos+40])),
+		EarliestConsolidationEpoch:    phase0.Epoch(binary.LittleEndian.Uint64(data[pos+40 : pos+48])),
+	}, nil
+}
+
+// pending obtains the offsets of the pending queues from the fixed-size data that follows the base.
+func (l *electraStateLayout) pending(data []byte) *statePendingLayout {
+	pos := l.electraFields + 48 - l.base
+
+	return &statePendingLayout{
+		depositsOffset:           uint64(binary.LittleEndian.Uint32(data[pos:])),
+		partialWithdrawalsOffset: uint64(binary.LittleEndian.Uint32(data[pos+4:])),
+		consolidationsOffset:     uint64(binary.LittleEndian.Uint32(data[pos+8:])),
+	}
+}
+
+// decodePendingQueues decodes the pending queues at the end of an Electra state.
+func decodePendingQueues(reader *stateStreamReader, layout *statePendingLayout, handler *StateStreamHandler) error {
+	if err := reader.skipTo(layout.depositsOffset, "pending deposits"); err != nil {
+		return err
+	}
+	if handler.PendingDeposit != nil {
+		data := make([]byte, sszPendingDepositLength)
+		for i := uint64(0); reader.pos < layout.partialWithdrawalsOffset; i++ {
+			if err := reader.read(data, "pending deposit"); err != nil {
+				return err
+			}
+			deposit := &PendingDeposit{
+				WithdrawalCredentials: make([]byte, 32),
+				Amount:                phase0.Gwei(binary.LittleEndian.Uint64(data[80:88])),
+				Slot:                  phase0.Slot(binary.LittleEndian.Uint64(data[184:192])),
+			}
+			copy(deposit.PubKey[:], data[0:48])
+			copy(deposit.WithdrawalCredentials, data[48:80])
+			copy(deposit.Signature[:], data[88:184])
+			if err := handler.PendingDeposit(i, deposit); err != nil {
+				return err
+			}
+		}
+	}
+
+	if handler.PendingPartialWithdrawal == nil && handler.PendingConsolidation == nil {
+		return nil
+	}
+	if err := reader.skipTo(layout.partialWithdrawalsOffset, "pending partial withdrawals"); err != nil {
+		return err
+	}
+	if handler.PendingPartialWithdrawal != nil {
+		data := make([]byte, sszPendingPartialWithdrawalLength)
+		for i := uint64(0); reader.pos < layout.consolidationsOffset; i++ {
+			if err := reader.read(data, "pending partial withdrawal"); err != nil {
+				return err
+			}
+			withdrawal := &PendingPartialWithdrawal{
+				ValidatorIndex:    phase0.ValidatorIndex(binary.LittleEndian.Uint64(data[0:8])),
+				Amount:            phase0.Gwei(binary.LittleEndian.Uint64(data[8:16])),
+				WithdrawableEpoch: phase0.Epoch(binary.LittleEndian.Uint64(data[16:24])),
+			}
+			if err := handler.PendingPartialWithdrawal(i, withdrawal); err != nil {
+				return err
+			}
+		}
+	}
+
+	if handler.PendingConsolidation == nil {
+		return nil
+	}
+	if err := reader.skipTo(layout.consolidationsOffset, "pending consolidations"); err != nil {
+		return err
+	}
+	// Pending consolidations are the final field of the state, so are read
+	// until the end of the data.
+	data := make([]byte, sszPendingConsolidationLength)
+	for i := uint64(0); ; i++ {
+		n, err := io.ReadFull(reader.reader, data)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil || n != len(data) {
+			return errors.New("failed to read pending consolidation")
+		}
+		reader.pos += uint64(n)
+		consolidation := &PendingConsolidation{
+			SourceIndex: phase0.ValidatorIndex(binary.LittleEndian.Uint64(data[0:8])),
+			TargetIndex: phase0.ValidatorIndex(binary.LittleEndian.Uint64(data[8:16])),
+		}
+		if err := handler.PendingConsolidation(i, consolidation); err != nil {
+			return err
+		}
+	}
+}
+func decodeStateStreamHeader(fixed []byte, historicalRootsPos uint64) (*StateStreamHeader, error) {
+	header := &StateStreamHeader{
+		GenesisTime:       binary.LittleEndian.Uint64(fixed[0:8]),
+		Slot:              phase0.Slot(binary.LittleEndian.Uint64(fixed[40:48])),
+		Fork:              &phase0.Fork{},
+		LatestBlockHeader: &phase0.BeaconBlockHeader{},
+		ETH1Data:          &phase0.ETH1Data{},
+		ETH1DepositIndex:  binary.LittleEndian.Uint64(fixed[historicalRootsPos+80 : historicalRootsPos+88]),
+	}
+	copy(header.GenesisValidatorsRoot[:], fixed[8:40])
+	if err := header.Fork.UnmarshalSSZ(fixed[48:64]); err != nil {
+		return nil, errors.Wrap(err, "failed to decode fork")
+	}
+	if err := header.LatestBlockHeader.UnmarshalSSZ(fixed[64:176]); err != nil {
+		return nil, errors.Wrap(err, "failed to decode latest block header")
+	}
+	if err := header.ETH1Data.UnmarshalSSZ(fixed[historicalRootsPos+sszOffsetLength : historicalRootsPos+sszOffsetLength+sszETH1DataLength]); err != nil {
+		return nil, errors.Wrap(err, "failed to decode ETH1 data")
+	}
+
+	return header, nil
+}
@@ -0,0 +1,389 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+const testSlotsPerHistoricalRoot = 8192
+
+var testStateStreamConfig = &StateStreamConfig{
+	SlotsPerHistoricalRoot:    testSlotsPerHistoricalRoot,
+	EpochsPerHistoricalVector: 65536,
+	EpochsPerSlashingsVector:  8192,
+	SyncCommitteeSize:         512,
+}
+
+func testStateValidators(count int) ([]*phase0.Validator, []phase0.Gwei) {
+	validators := make([]*phase0.Validator, count)
+	balances := make([]phase0.Gwei, count)
+	for i := 0; i < count; i++ {
+		validators[i] = &phase0.Validator{
+			PublicKey:                  phase0.BLSPubKey{byte(i)},
+			WithdrawalCredentials:      make([]byte, 32),
+			EffectiveBalance:           32000000000,
+			ActivationEligibilityEpoch: phase0.Epoch(i),
+			ActivationEpoch:            phase0.Epoch(i + 1),
+			ExitEpoch:                  0xffffffffffffffff,
+			WithdrawableEpoch:          0xffffffffffffffff,
+		}
+		balances[i] = phase0.Gwei(32000000000 + i)
+	}
+
+	return validators, balances
+}
+
+func testPhase0State(t *testing.T) *phase0.BeaconState {
+	t.Helper()
+
+	validators, balances := testStateValidators(5)
+	return &phase0.BeaconState{
+		GenesisTime:           1606824023,
+		GenesisValidatorsRoot: phase0.Root{0x4b},
+		Slot:                  12345,
+		Fork: &phase0.Fork{
+			PreviousVersion: phase0.Version{0x00},
+			CurrentVersion:  phase0.Version{0x01},
+			Epoch:           10,
+		},
+		LatestBlockHeader: &phase0.BeaconBlockHeader{
+			Slot: 12344,
+		},
+		BlockRoots:      make([]phase0.Root, testSlotsPerHistoricalRoot),
+		StateRoots:      make([]phase0.Root, testSlotsPerHistoricalRoot),
+		HistoricalRoots: []phase0.Root{{0x01}, {0x02}},
+		ETH1Data: &phase0.ETH1Data{
+			DepositRoot:  phase0.Root{0x03},
+			DepositCount: 100,
+			BlockHash:    make([]byte, 32),
+		},
+		ETH1DataVotes: []*phase0.ETH1Data{
+			{DepositRoot: phase0.Root{0x04}, DepositCount: 101, BlockHash: make([]byte, 32)},
+			{DepositRoot: phase0.Root{0x05}, DepositCount: 102, BlockHash: make([]byte, 32)},
+		},
+		ETH1DepositIndex:            99,
+		Validators:                  validators,
+		Balances:                    balances,
+		RANDAOMixes:                 make([]phase0.Root, 65536),
+		Slashings:                   make([]phase0.Gwei, 8192),
+		JustificationBits:           []byte{0x00},
+		PreviousJustifiedCheckpoint: &phase0.Checkpoint{},
+		CurrentJustifiedCheckpoint:  &phase0.Checkpoint{},
+		FinalizedCheckpoint:         &phase0.Checkpoint{},
+	}
+}
+
+func testDenebState(t *testing.T) *deneb.BeaconState {
+	t.Helper()
+
+	base := testPhase0State(t)
+	syncCommittee := &altair.SyncCommittee{
+		Pubkeys: make([]phase0.BLSPubKey, 512),
+	}
+	return &deneb.BeaconState{
+		GenesisTime:                 base.GenesisTime,
+		GenesisValidatorsRoot:       base.GenesisValidatorsRoot,
+		Slot:                        base.Slot,
+		Fork:                        base.Fork,
+		LatestBlockHeader:           base.LatestBlockHeader,
+		BlockRoots:                  base.BlockRoots,
+		StateRoots:                  base.StateRoots,
+		HistoricalRoots:             base.HistoricalRoots,
+		ETH1Data:                    base.ETH1Data,
+		ETH1DataVotes:               base.ETH1DataVotes,
+		ETH1DepositIndex:            base.ETH1DepositIndex,
+		Validators:                  base.Validators,
+		Balances:                    base.Balances,
+		RANDAOMixes:                 base.RANDAOMixes,
+		Slashings:                   base.Slashings,
+		PreviousEpochParticipation:  make([]altair.ParticipationFlags, len(base.Validators)),
+		CurrentEpochParticipation:   make([]altair.ParticipationFlags, len(base.Validators)),
+		JustificationBits:           base.JustificationBits,
+		PreviousJustifiedCheckpoint: base.PreviousJustifiedCheckpoint,
+		CurrentJustifiedCheckpoint:  base.CurrentJustifiedCheckpoint,
+		FinalizedCheckpoint:         base.FinalizedCheckpoint,
+		InactivityScores:            make([]uint64, len(base.Validators)),
+		CurrentSyncCommittee:        syncCommittee,
+		NextSyncCommittee:           syncCommittee,
+		LatestExecutionPayloadHeader: &deneb.ExecutionPayloadHeader{
+			LogsBloom:     [256]byte{},
+			BaseFeePerGas: uint256.NewInt(0),
+			ExtraData:     []byte{},
+		},
+		NextWithdrawalIndex:          100,
+		NextWithdrawalValidatorIndex: 3,
+	}
+}
+
+func TestDecodeBeaconStateStream(t *testing.T) {
+	phase0State := testPhase0State(t)
+	phase0Data, err := phase0State.MarshalSSZ()
+	require.NoError(t, err)
+	denebData, err := testDenebState(t).MarshalSSZ()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		data []byte
+		err  string
+	}{
+		{
+			name: "Truncated",
+			data: phase0Data[:1000],
+			err:  "failed to read state header: unexpected EOF",
+		},
+		{
+			name: "Phase0",
+			data: phase0Data,
+		},
+		{
+			name: "Deneb",
+			data: denebData,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var header *StateStreamHeader
+			votes := make([]*phase0.ETH1Data, 0)
+			validators := make([]*phase0.Validator, 0)
+			balances := make([]phase0.Gwei, 0)
+			err := DecodeBeaconStateStream(bytes.NewReader(test.data), testStateStreamConfig, &StateStreamHandler{
+				Header: func(h *StateStreamHeader) error {
+					header = h
+					return nil
+				},
+				ETH1DataVote: func(vote *phase0.ETH1Data) error {
+					votes = append(votes, vote)
+					return nil
+				},
+				Validator: func(_ phase0.ValidatorIndex, validator *phase0.Validator) error {
+					validators = append(validators, validator)
+					return nil
+				},
+				Balance: func(_ phase0.ValidatorIndex, balance phase0.Gwei) error {
+					balances = append(balances, balance)
+					return nil
+				},
+			})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, phase0State.GenesisTime, header.GenesisTime)
+			require.Equal(t, phase0State.GenesisValidatorsRoot, header.GenesisValidatorsRoot)
+			require.Equal(t, phase0State.Slot, header.Slot)
+			require.Equal(t, phase0State.Fork, header.Fork)
+			require.Equal(t, phase0State.ETH1Data, header.ETH1Data)
+			require.Equal(t, phase0State.ETH1DepositIndex, header.ETH1DepositIndex)
+			require.Equal(t, uint64(len(phase0State.Validators)), header.Validators)
+			require.Equal(t, phase0State.ETH1DataVotes, votes)
+			require.Equal(t, phase0State.Validators, validators)
+			require.Equal(t, phase0State.Balances, balances)
+		})
+	}
+}
+
+func TestDecodeBeaconStateStreamPartial(t *testing.T) {
+	data, err := testPhase0State(t).MarshalSSZ()
+	require.NoError(t, err)
+
+	// Balances are the final data in this state, and should not be read.
+	reader := bytes.NewReader(data[:len(data)-5*8])
+	count := 0
+	err = DecodeBeaconStateStream(reader, testStateStreamConfig, &StateStreamHandler{
+		Validator: func(_ phase0.ValidatorIndex, _ *phase0.Validator) error {
+			count++
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 5, count)
+}
+
+// testElectraStateData creates an SSZ-encoded Electra state from a Deneb
+// state, as their layouts differ only in the fields added by Electra.
+func testElectraStateData(t *testing.T,
+	deposits []*PendingDeposit,
+	withdrawals []*PendingPartialWithdrawal,
+	consolidations []*PendingConsolidation,
+) []byte {
+	t.Helper()
+
+	denebData, err := testDenebState(t).MarshalSSZ()
+	require.NoError(t, err)
+
+	historicalRootsPos := 176 + 64*testSlotsPerHistoricalRoot
+	participationPos := historicalRootsPos + 96 + 32*65536 + 8*8192
+	inactivityScoresPos := participationPos + 8 + 1 + 3*40
+	payloadHeaderPos := inactivityScoresPos + 4 + 2*513*48
+	historicalSummariesPos := payloadHeaderPos + 4 + 16
+	denebFixedLength := historicalSummariesPos + 4
+	electraExtraLength := 48 + 3*4
+
+	data := make([]byte, 0, len(denebData)+electraExtraLength)
+	data = append(data, denebData[:denebFixedLength]...)
+	for _, pos := range []int{
+		historicalRootsPos,
+		historicalRootsPos + 76,
+		historicalRootsPos + 88,
+		historicalRootsPos + 92,
+		participationPos,
+		participationPos + 4,
+		inactivityScoresPos,
+		payloadHeaderPos,
+		historicalSummariesPos,
+	} {
+		offset := binary.LittleEndian.Uint32(data[pos:])
+		binary.LittleEndian.PutUint32(data[pos:], offset+uint32(electraExtraLength))
+	}
+
+	// Electra fields.
+	data = binary.LittleEndian.AppendUint64(data, 1000) // deposit_requests_start_index
+	data = binary.LittleEndian.AppendUint64(data, 1)    // deposit_balance_to_consume
+	data = binary.LittleEndian.AppendUint64(data, 2)    // exit_balance_to_consume
+	data = binary.LittleEndian.AppendUint64(data, 300)  // earliest_exit_epoch
+	data = binary.LittleEndian.AppendUint64(data, 3)    // consolidation_balance_to_consume
+	data = binary.LittleEndian.AppendUint64(data, 400)  // earliest_consolidation_epoch
+	pendingDepositsOffset := uint32(len(denebData) + electraExtraLength)
+	pendingPartialWithdrawalsOffset := pendingDepositsOffset + uint32(len(deposits)*sszPendingDepositLength)
+	pendingConsolidationsOffset := pendingPartialWithdrawalsOffset + uint32(len(withdrawals)*sszPendingPartialWithdrawalLength)
+	data = binary.LittleEndian.AppendUint32(data, pendingDepositsOffset)
+	data = binary.LittleEndian.AppendUint32(data, pendingPartialWithdrawalsOffset)
+	data = binary.LittleEndian.AppendUint32(data, pendingConsolidationsOffset)
+
+	data = append(data, denebData[denebFixedLength:]...)
+	for _, deposit := range deposits {
+		data = append(data, deposit.PubKey[:]...)
+		data = append(data, deposit.WithdrawalCredentials...)
+		data = binary.LittleEndian.AppendUint64(data, uint64(deposit.Amount))
+		data = append(data, deposit.Signature[:]...)
+		data = binary.LittleEndian.AppendUint64(data, uint64(deposit.Slot))
+	}
+	for _, withdrawal := range withdrawals {
+		data = binary.LittleEndian.AppendUint64(data, uint64(withdrawal.ValidatorIndex))
+		data = binary.LittleEndian.AppendUint64(data, uint64(withdrawal.Amount))
+		data = binary.LittleEndian.AppendUint64(data, uint64(withdrawal.WithdrawableEpoch))
+	}
+	for _, consolidation := range consolidations {
+		data = binary.LittleEndian.AppendUint64(data, uint64(consolidation.SourceIndex))
+		data = binary.LittleEndian.AppendUint64(data, uint64(consolidation.TargetIndex))
+	}
+
+	return data
+}
+
+func TestDecodeBeaconStateStreamElectra(t *testing.T) {
+	deposits := []*PendingDeposit{
+		{
+			PubKey:                phase0.BLSPubKey{0x01},
+			WithdrawalCredentials: append([]byte{0x02}, make([]byte, 31)...),
+			Amount:                32000000000,
+			Signature:             phase0.BLSSignature{0x03},
+			Slot:                  12000,
+		},
+		{
+			PubKey:                phase0.BLSPubKey{0x04},
+			WithdrawalCredentials: append([]byte{0x01}, make([]byte, 31)...),
+			Amount:                1000000000,
+			Signature:             phase0.BLSSignature{0x05},
+			Slot:                  12001,
+		},
+	}
+	withdrawals := []*PendingPartialWithdrawal{
+		{ValidatorIndex: 1, Amount: 2000000000, WithdrawableEpoch: 500},
+	}
+	consolidations := []*PendingConsolidation{
+		{SourceIndex: 2, TargetIndex: 3},
+		{SourceIndex: 4, TargetIndex: 3},
+	}
+	data := testElectraStateData(t, deposits, withdrawals, consolidations)
+
+	var electra *StateStreamElectra
+	balances := 0
+	decodedDeposits := make([]*PendingDeposit, 0)
+	decodedWithdrawals := make([]*PendingPartialWithdrawal, 0)
+	decodedConsolidations := make([]*PendingConsolidation, 0)
+	err := DecodeBeaconStateStream(bytes.NewReader(data), testStateStreamConfig, &StateStreamHandler{
+		Electra: func(e *StateStreamElectra) error {
+			electra = e
+			return nil
+		},
+		Balance: func(_ phase0.ValidatorIndex, _ phase0.Gwei) error {
+			balances++
+			return nil
+		},
+		PendingDeposit: func(_ uint64, deposit *PendingDeposit) error {
+			decodedDeposits = append(decodedDeposits, deposit)
+			return nil
+		},
+		PendingPartialWithdrawal: func(_ uint64, withdrawal *PendingPartialWithdrawal) error {
+			decodedWithdrawals = append(decodedWithdrawals, withdrawal)
+			return nil
+		},
+		PendingConsolidation: func(_ uint64, consolidation *PendingConsolidation) error {
+			decodedConsolidations = append(decodedConsolidations, consolidation)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &StateStreamElectra{
+		FinalizedCheckpoint:           &phase0.Checkpoint{},
+		NextWithdrawalIndex:           100,
+		NextWithdrawalValidatorIndex:  3,
+		DepositRequestsStartIndex:     1000,
+		DepositBalanceToConsume:       1,
+		ExitBalanceToConsume:          2,
+		EarliestExitEpoch:             300,
+		ConsolidationBalanceToConsume: 3,
+		EarliestConsolidationEpoch:    400,
+		PendingDeposits:               2,
+		PendingPartialWithdrawals:     1,
+	}, electra)
+	require.Equal(t, 5, balances)
+	require.Equal(t, deposits, decodedDeposits)
+	require.Equal(t, withdrawals, decodedWithdrawals)
+	require.Equal(t, consolidations, decodedConsolidations)
+
+	// Only the consolidations.
+	decodedConsolidations = make([]*PendingConsolidation, 0)
+	err = DecodeBeaconStateStream(bytes.NewReader(data), testStateStreamConfig, &StateStreamHandler{
+		PendingConsolidation: func(_ uint64, consolidation *PendingConsolidation) error {
+			decodedConsolidations = append(decodedConsolidations, consolidation)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, consolidations, decodedConsolidations)
+
+	// Electra data from a pre-Electra state.
+	denebData, err := testDenebState(t).MarshalSSZ()
+	require.NoError(t, err)
+	err = DecodeBeaconStateStream(bytes.NewReader(denebData), testStateStreamConfig, &StateStreamHandler{
+		PendingDeposit: func(_ uint64, _ *PendingDeposit) error {
+			return nil
+		},
+	})
+	require.EqualError(t, err, "state is not an Electra state")
+}
@@ -0,0 +1,42 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+// SweepParameters are the chain parameters that govern the withdrawal sweep.
+type SweepParameters struct {
+	MaxWithdrawalsPerPayload              uint64
+	MaxValidatorsPerWithdrawalsSweep      uint64
+	MaxPendingPartialsPerWithdrawalsSweep uint64
+}
+
+// ObtainSweepParameters obtains the withdrawal sweep parameters from the
+// chain specification, using the mainnet value for any that are not present.
+func ObtainSweepParameters(spec map[string]any) *SweepParameters {
+	params := &SweepParameters{
+		MaxWithdrawalsPerPayload:              16,
+		MaxValidatorsPerWithdrawalsSweep:      16384,
+		MaxPendingPartialsPerWithdrawalsSweep: 8,
+	}
+	for name, param := range map[string]*uint64{
+		"MAX_WITHDRAWALS_PER_PAYLOAD":                &params.MaxWithdrawalsPerPayload,
+		"MAX_VALIDATORS_PER_WITHDRAWALS_SWEEP":       &params.MaxValidatorsPerWithdrawalsSweep,
+		"MAX_PENDING_PARTIALS_PER_WITHDRAWALS_SWEEP": &params.MaxPendingPartialsPerWithdrawalsSweep,
+	} {
+		if value, isValue := spec[name].(uint64); isValue {
+			*param = value
+		}
+	}
+
+	return params
+}
@@ -0,0 +1,38 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestSweepParameters(t *testing.T) {
+	// Mainnet values if not in the spec.
+	params := util.ObtainSweepParameters(map[string]any{})
+	require.Equal(t, uint64(16), params.MaxWithdrawalsPerPayload)
+	require.Equal(t, uint64(16384), params.MaxValidatorsPerWithdrawalsSweep)
+	require.Equal(t, uint64(8), params.MaxPendingPartialsPerWithdrawalsSweep)
+
+	params = util.ObtainSweepParameters(map[string]any{
+		"MAX_WITHDRAWALS_PER_PAYLOAD":                uint64(4),
+		"MAX_VALIDATORS_PER_WITHDRAWALS_SWEEP":       uint64(16),
+		"MAX_PENDING_PARTIALS_PER_WITHDRAWALS_SWEEP": uint64(2),
+	})
+	require.Equal(t, uint64(4), params.MaxWithdrawalsPerPayload)
+	require.Equal(t, uint64(16), params.MaxValidatorsPerWithdrawalsSweep)
+	require.Equal(t, uint64(2), params.MaxPendingPartialsPerWithdrawalsSweep)
+}
@@ -0,0 +1,77 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// timestampFormats are the formats accepted for timestamps, in addition
+// to Unix timestamps.  Formats without a zone are in the supplied location.
+var timestampFormats = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05-0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseTimestamp parses a timestamp, which can be "now", a Unix timestamp,
+// or a date and time such as 2006-01-02T15:04:05Z.  Dates and times without
+// a zone are taken to be in the supplied location.
+func ParseTimestamp(input string, location *time.Location, now time.Time) (time.Time, error) {
+	if input == "now" {
+		return now, nil
+	}
+
+	if unix, err := strconv.ParseInt(input, 10, 64); err == nil {
+		return time.Unix(unix, 0), nil
+	}
+
+	if location == nil {
+		location = time.Local
+	}
+	for _, format := range timestampFormats {
+		if timestamp, err := time.ParseInLocation(format, input, location); err == nil {
+			return timestamp, nil
+		}
+	}
+
+	return time.Time{}, errors.New("invalid timestamp specified")
+}
+
+// FormatDuration formats a duration to the second, with days as the largest
+// unit, for example "1d2h3m4s".
+func FormatDuration(duration time.Duration) string {
+	if duration < 0 {
+		return fmt.Sprintf("-%s", FormatDuration(-duration))
+	}
+	duration = duration.Truncate(time.Second)
+
+	day := 24 * time.Hour
+	if duration < day {
+		return duration.String()
+	}
+	remainder := duration % day
+	if remainder == 0 {
+		return fmt.Sprintf("%dd", duration/day)
+	}
+
+	return fmt.Sprintf("%dd%s", duration/day, remainder.String())
+}
@@ -0,0 +1,99 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	est := time.FixedZone("EST", -5*60*60)
+
+	tests := []struct {
+		name     string
+		input    string
+		location *time.Location
+		expected time.Time
+		err      string
+	}{
+		{
+			name:  "Empty",
+			input: "",
+			err:   "invalid timestamp specified",
+		},
+		{
+			name:     "Now",
+			input:    "now",
+			expected: now,
+		},
+		{
+			name:     "Unix",
+			input:    "1606824023",
+			expected: time.Unix(1606824023, 0),
+		},
+		{
+			name:     "RFC3339",
+			input:    "2020-12-01T12:00:23Z",
+			location: est,
+			expected: time.Unix(1606824023, 0),
+		},
+		{
+			name:     "Offset",
+			input:    "2020-12-01T13:00:23+0100",
+			expected: time.Unix(1606824023, 0),
+		},
+		{
+			name:     "Location",
+			input:    "2020-12-01 07:00:23",
+			location: est,
+			expected: time.Unix(1606824023, 0),
+		},
+		{
+			name:     "Date",
+			input:    "2020-12-01",
+			location: time.UTC,
+			expected: time.Unix(1606780800, 0),
+		},
+		{
+			name:  "Invalid",
+			input: "yesterday",
+			err:   "invalid timestamp specified",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.ParseTimestamp(test.input, test.location, now)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.True(t, test.expected.Equal(res), res.String())
+			}
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	require.Equal(t, "0s", util.FormatDuration(0))
+	require.Equal(t, "1m30s", util.FormatDuration(90*time.Second+500*time.Millisecond))
+	require.Equal(t, "2d", util.FormatDuration(48*time.Hour))
+	require.Equal(t, "1d2h3m0s", util.FormatDuration(26*time.Hour+3*time.Minute))
+	require.Equal(t, "-1d1s", util.FormatDuration(-(24*time.Hour + time.Second)))
+}
@@ -68,6 +68,12 @@ func accountToIndex(ctx context.Context, account e2wtypes.Account, client consen
 
 	pubKeys := make([]phase0.BLSPubKey, 1)
 	copy(pubKeys[0][:], pubKey.Marshal())
+
+	cache := ValidatorCacheForClient(ctx, client)
+	if entry, exists := cache.Entry(pubKeys[0]); exists {
+		return entry.Index, nil
+	}
+
 	validatorsResponse, err := client.(consensusclient.ValidatorsProvider).Validators(ctx, &api.ValidatorsOpts{
 		State:   "head",
 		PubKeys: pubKeys,
@@ -77,6 +83,9 @@ func accountToIndex(ctx context.Context, account e2wtypes.Account, client consen
 	}
 
 	for index := range validatorsResponse.Data {
+		cache.Update(validatorsResponse.Data)
+		// A failure to save the cache is not fatal.
+		_ = cache.Save()
 		return index, nil
 	}
 	return 0, errors.New("validator not found")
@@ -0,0 +1,239 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// ValidatorCache is a persistent cache of validator public keys to their
+// index and activation information for a single chain.
+type ValidatorCache struct {
+	mu      sync.RWMutex
+	path    string
+	entries map[phase0.BLSPubKey]*ValidatorCacheEntry
+	updated bool
+}
+
+// ValidatorCacheEntry is an entry in the validator cache.
+type ValidatorCacheEntry struct {
+	Index                      phase0.ValidatorIndex
+	ActivationEligibilityEpoch phase0.Epoch
+	ActivationEpoch            phase0.Epoch
+}
+
+type validatorCacheEntryJSON struct {
+	Index                      string `json:"index"`
+	ActivationEligibilityEpoch string `json:"activation_eligibility_epoch"`
+	ActivationEpoch            string `json:"activation_epoch"`
+}
+
+var (
+	validatorCachesMu sync.Mutex
+	validatorCaches   = make(map[phase0.Root]*ValidatorCache)
+)
+
+// ValidatorCacheDir returns the directory in which validator caches are stored.
+func ValidatorCacheDir() (string, error) {
+	if viper.GetString("cache-dir") != "" {
+		return viper.GetString("cache-dir"), nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to obtain user cache directory")
+	}
+
+	return filepath.Join(dir, "ethdo"), nil
+}
+
+// ClearValidatorCaches removes all validator caches, returning the number of caches removed.
+func ClearValidatorCaches() (int, error) {
+	dir, err := ValidatorCacheDir()
+	if err != nil {
+		return 0, err
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "validators-*.json"))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list validator caches")
+	}
+	for _, file := range files {
+		if err := os.Remove(file); err != nil {
+			return 0, errors.Wrap(err, fmt.Sprintf("failed to remove validator cache %s", file))
+		}
+	}
+
+	validatorCachesMu.Lock()
+	validatorCaches = make(map[phase0.Root]*ValidatorCache)
+	validatorCachesMu.Unlock()
+
+	return len(files), nil
+}
+
+// ValidatorCacheForClient returns the validator cache for the chain of the
+// given client.  It returns nil if a cache is not available, in which case
+// the caller should continue without the cache.
+func ValidatorCacheForClient(ctx context.Context, client any) *ValidatorCache {
+	genesisProvider, isProvider := client.(eth2client.GenesisProvider)
+	if !isProvider {
+		return nil
+	}
+	genesisResponse, err := genesisProvider.Genesis(ctx, &api.GenesisOpts{})
+	if err != nil {
+		return nil
+	}
+	dir, err := ValidatorCacheDir()
+	if err != nil {
+		return nil
+	}
+
+	validatorCachesMu.Lock()
+	defer validatorCachesMu.Unlock()
+	cache, exists := validatorCaches[genesisResponse.Data.GenesisValidatorsRoot]
+	if !exists {
+		cache = loadValidatorCache(filepath.Join(dir, fmt.Sprintf("validators-%#x.json", genesisResponse.Data.GenesisValidatorsRoot[:8])))
+		validatorCaches[genesisResponse.Data.GenesisValidatorsRoot] = cache
+	}
+
+	return cache
+}
+
+// loadValidatorCache loads a validator cache from disk.
+// A missing or corrupt cache results in an empty cache.
+func loadValidatorCache(path string) *ValidatorCache {
+	cache := &ValidatorCache{
+		path:    path,
+		entries: make(map[phase0.BLSPubKey]*ValidatorCacheEntry),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	entries := make(map[string]*validatorCacheEntryJSON)
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return cache
+	}
+	for pubKeyStr, entryJSON := range entries {
+		pubKeyBytes, err := hex.DecodeString(strings.TrimPrefix(pubKeyStr, "0x"))
+		if err != nil || len(pubKeyBytes) != phase0.PublicKeyLength {
+			continue
+		}
+		entry := &ValidatorCacheEntry{}
+		if _, err := fmt.Sscanf(entryJSON.Index, "%d", &entry.Index); err != nil {
+			continue
+		}
+		if _, err := fmt.Sscanf(entryJSON.ActivationEligibilityEpoch, "%d", &entry.ActivationEligibilityEpoch); err != nil {
+			continue
+		}
+		if _, err := fmt.Sscanf(entryJSON.ActivationEpoch, "%d", &entry.ActivationEpoch); err != nil {
+			continue
+		}
+		var pubKey phase0.BLSPubKey
+		copy(pubKey[:], pubKeyBytes)
+		cache.entries[pubKey] = entry
+	}
+
+	return cache
+}
+
+// Entry returns the cache entry for the given public key.
+func (c *ValidatorCache) Entry(pubKey phase0.BLSPubKey) (*ValidatorCacheEntry, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, exists := c.entries[pubKey]
+
+	return entry, exists
+}
+
+// Update updates the cache with information from the given validators.
+func (c *ValidatorCache) Update(validators map[phase0.ValidatorIndex]*apiv1.Validator) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for index, validator := range validators {
+		if validator == nil || validator.Validator == nil {
+			continue
+		}
+		entry, exists := c.entries[validator.Validator.PublicKey]
+		if exists &&
+			entry.ActivationEligibilityEpoch == validator.Validator.ActivationEligibilityEpoch &&
+			entry.ActivationEpoch == validator.Validator.ActivationEpoch {
+			continue
+		}
+		c.entries[validator.Validator.PublicKey] = &ValidatorCacheEntry{
+			Index:                      index,
+			ActivationEligibilityEpoch: validator.Validator.ActivationEligibilityEpoch,
+			ActivationEpoch:            validator.Validator.ActivationEpoch,
+		}
+		c.updated = true
+	}
+}
+
+// Save saves the cache to disk if it has been updated.
+func (c *ValidatorCache) Save() error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.updated {
+		return nil
+	}
+
+	entries := make(map[string]*validatorCacheEntryJSON, len(c.entries))
+	for pubKey, entry := range c.entries {
+		entries[fmt.Sprintf("%#x", pubKey)] = &validatorCacheEntryJSON{
+			Index:                      fmt.Sprintf("%d", entry.Index),
+			ActivationEligibilityEpoch: fmt.Sprintf("%d", entry.ActivationEligibilityEpoch),
+			ActivationEpoch:            fmt.Sprintf("%d", entry.ActivationEpoch),
+		}
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal validator cache")
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return errors.Wrap(err, "failed to create validator cache directory")
+	}
+	// Write to a temporary file and rename, to avoid a partially-written cache.
+	tmpPath := fmt.Sprintf("%s.tmp", c.path)
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return errors.Wrap(err, "failed to write validator cache")
+	}
+	if err := os.Rename(tmpPath, c.path); err != nil {
+		return errors.Wrap(err, "failed to replace validator cache")
+	}
+	c.updated = false
+
+	return nil
+}
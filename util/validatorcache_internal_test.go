@@ -0,0 +1,70 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatorCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "validators-test.json")
+
+	cache := loadValidatorCache(path)
+	pubKey := phase0.BLSPubKey{0x01}
+	_, exists := cache.Entry(pubKey)
+	require.False(t, exists)
+
+	cache.Update(map[phase0.ValidatorIndex]*apiv1.Validator{
+		12: {
+			Index: 12,
+			Validator: &phase0.Validator{
+				PublicKey:                  pubKey,
+				ActivationEligibilityEpoch: 5,
+				ActivationEpoch:            10,
+			},
+		},
+	})
+	require.NoError(t, cache.Save())
+
+	reloaded := loadValidatorCache(path)
+	entry, exists := reloaded.Entry(pubKey)
+	require.True(t, exists)
+	require.Equal(t, phase0.ValidatorIndex(12), entry.Index)
+	require.Equal(t, phase0.Epoch(5), entry.ActivationEligibilityEpoch)
+	require.Equal(t, phase0.Epoch(10), entry.ActivationEpoch)
+}
+
+func TestValidatorCacheCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "validators-test.json")
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0o600))
+
+	cache := loadValidatorCache(path)
+	require.NotNil(t, cache)
+	_, exists := cache.Entry(phase0.BLSPubKey{0x01})
+	require.False(t, exists)
+}
+
+func TestValidatorCacheNil(t *testing.T) {
+	var cache *ValidatorCache
+	_, exists := cache.Entry(phase0.BLSPubKey{0x01})
+	require.False(t, exists)
+	cache.Update(nil)
+	require.NoError(t, cache.Save())
+}
@@ -0,0 +1,78 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// ValidatorFilter selects validators by index or public key.  As validators
+// are resolved, those selected by index become selected by public key and
+// vice versa, so that data referencing either can be matched.
+type ValidatorFilter struct {
+	indices map[phase0.ValidatorIndex]struct{}
+	pubKeys map[phase0.BLSPubKey]struct{}
+}
+
+// NewValidatorFilter creates a filter selecting the given validators.
+func NewValidatorFilter(indices []phase0.ValidatorIndex, pubKeys []phase0.BLSPubKey) *ValidatorFilter {
+	filter := &ValidatorFilter{
+		indices: make(map[phase0.ValidatorIndex]struct{}, len(indices)),
+		pubKeys: make(map[phase0.BLSPubKey]struct{}, len(pubKeys)),
+	}
+	for _, index := range indices {
+		filter.indices[index] = struct{}{}
+	}
+	for _, pubKey := range pubKeys {
+		filter.pubKeys[pubKey] = struct{}{}
+	}
+
+	return filter
+}
+
+// Resolve links the index and public key of a validator, so that if the
+// filter selects either it selects both.
+func (f *ValidatorFilter) Resolve(index phase0.ValidatorIndex, pubKey phase0.BLSPubKey) {
+	if f == nil {
+		return
+	}
+	_, indexSelected := f.indices[index]
+	_, pubKeySelected := f.pubKeys[pubKey]
+	if indexSelected || pubKeySelected {
+		f.indices[index] = struct{}{}
+		f.pubKeys[pubKey] = struct{}{}
+	}
+}
+
+// MatchesIndex returns true if the filter selects the validator with the
+// given index.  A nil filter selects all validators.
+func (f *ValidatorFilter) MatchesIndex(index phase0.ValidatorIndex) bool {
+	if f == nil {
+		return true
+	}
+	_, exists := f.indices[index]
+
+	return exists
+}
+
+// MatchesPubKey returns true if the filter selects the validator with the
+// given public key.  A nil filter selects all validators.
+func (f *ValidatorFilter) MatchesPubKey(pubKey phase0.BLSPubKey) bool {
+	if f == nil {
+		return true
+	}
+	_, exists := f.pubKeys[pubKey]
+
+	return exists
+}
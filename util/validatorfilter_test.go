@@ -0,0 +1,42 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestValidatorFilter(t *testing.T) {
+	var filter *util.ValidatorFilter
+	require.True(t, filter.MatchesPubKey(phase0.BLSPubKey{0x01}))
+	require.True(t, filter.MatchesIndex(1))
+
+	filter = util.NewValidatorFilter([]phase0.ValidatorIndex{2}, []phase0.BLSPubKey{{0x01}})
+	require.True(t, filter.MatchesPubKey(phase0.BLSPubKey{0x01}))
+	require.False(t, filter.MatchesPubKey(phase0.BLSPubKey{0x02}))
+	require.True(t, filter.MatchesIndex(2))
+	require.False(t, filter.MatchesIndex(5))
+
+	filter.Resolve(1, phase0.BLSPubKey{0x03})
+	filter.Resolve(2, phase0.BLSPubKey{0x02})
+	filter.Resolve(5, phase0.BLSPubKey{0x01})
+	require.True(t, filter.MatchesPubKey(phase0.BLSPubKey{0x02}))
+	require.False(t, filter.MatchesPubKey(phase0.BLSPubKey{0x03}))
+	require.False(t, filter.MatchesIndex(1))
+	require.True(t, filter.MatchesIndex(5))
+}
@@ -1,4 +1,4 @@
-// Copyright © 2022 Weald Technology Trading
+// Copyright © 2022, 2024 Weald Technology Trading
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -15,56 +15,236 @@ package util
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
 )
 
+// validatorsBatchSize is the maximum number of validators requested in a single call.
+var validatorsBatchSize = 100
+
+// validatorsWorkers is the maximum number of concurrent calls made when fetching validators.
+var validatorsWorkers = 8
+
 // ParseValidators parses input to obtain the list of validators.
+// Validators can be supplied as indices, ranges of indices, public keys, or
+// account specifiers (which can match multiple accounts in a wallet).
 func ParseValidators(ctx context.Context, validatorsProvider eth2client.ValidatorsProvider, validatorsStr []string, stateID string) ([]*apiv1.Validator, error) {
-	validators := make([]*apiv1.Validator, 0, len(validatorsStr))
+	indices, pubKeys, err := ParseValidatorIdentifiers(ctx, validatorsStr)
+	if err != nil {
+		return nil, err
+	}
+
+	fetched, err := FetchValidators(ctx, validatorsProvider, stateID, indices, pubKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	validators := make([]*apiv1.Validator, 0, len(fetched))
+	for _, validator := range fetched {
+		validators = append(validators, validator)
+	}
+	sort.Slice(validators, func(i int, j int) bool {
+		return validators[i].Index < validators[j].Index
+	})
+
+	return validators, nil
+}
+
+// ParseValidatorIdentifiers parses input to obtain the indices and public
+// keys of validators, without requiring the validators to be on the chain.
+// Input is in the same format as that for ParseValidators.
+func ParseValidatorIdentifiers(ctx context.Context, validatorsStr []string) ([]phase0.ValidatorIndex, []phase0.BLSPubKey, error) {
 	indices := make([]phase0.ValidatorIndex, 0)
+	pubKeys := make([]phase0.BLSPubKey, 0)
 	for i := range validatorsStr {
-		if strings.Contains(validatorsStr[i], "-") {
+		switch {
+		case strings.HasPrefix(validatorsStr[i], "0x"):
+			// Public key.
+			data, err := hex.DecodeString(strings.TrimPrefix(validatorsStr[i], "0x"))
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "failed to parse validator public key %s", validatorsStr[i])
+			}
+			if len(data) != phase0.PublicKeyLength {
+				return nil, nil, fmt.Errorf("invalid length for validator public key %s", validatorsStr[i])
+			}
+			pubKey := phase0.BLSPubKey{}
+			copy(pubKey[:], data)
+			pubKeys = append(pubKeys, pubKey)
+		case strings.Contains(validatorsStr[i], "/"):
+			// Account specifier; can match multiple accounts.
+			_, accounts, err := WalletAndAccountsFromPath(ctx, validatorsStr[i])
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "failed to obtain accounts for %s", validatorsStr[i])
+			}
+			for _, account := range accounts {
+				accPubKey, err := BestPublicKey(account)
+				if err != nil {
+					return nil, nil, errors.Wrapf(err, "failed to obtain public key for account %s", account.Name())
+				}
+				pubKey := phase0.BLSPubKey{}
+				copy(pubKey[:], accPubKey.Marshal())
+				pubKeys = append(pubKeys, pubKey)
+			}
+		case strings.Contains(validatorsStr[i], "-"):
 			// Range.
 			bits := strings.Split(validatorsStr[i], "-")
 			if len(bits) != 2 {
-				return nil, fmt.Errorf("invalid range %s", validatorsStr[i])
+				return nil, nil, fmt.Errorf("invalid range %s", validatorsStr[i])
 			}
 			low, err := strconv.ParseUint(bits[0], 10, 64)
 			if err != nil {
-				return nil, errors.Wrap(err, "invalid range start")
+				return nil, nil, errors.Wrap(err, "invalid range start")
 			}
 			high, err := strconv.ParseUint(bits[1], 10, 64)
 			if err != nil {
-				return nil, errors.Wrap(err, "invalid range end")
+				return nil, nil, errors.Wrap(err, "invalid range end")
 			}
 			for index := low; index <= high; index++ {
 				indices = append(indices, phase0.ValidatorIndex(index))
 			}
-		} else {
+		default:
 			index, err := strconv.ParseUint(validatorsStr[i], 10, 64)
 			if err != nil {
-				return nil, errors.Wrapf(err, "failed to parse validator %s", validatorsStr[i])
+				return nil, nil, errors.Wrapf(err, "failed to parse validator %s", validatorsStr[i])
 			}
 			indices = append(indices, phase0.ValidatorIndex(index))
 		}
 	}
 
-	response, err := validatorsProvider.Validators(ctx, &api.ValidatorsOpts{State: stateID, Indices: indices})
-	if err != nil {
-		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain validators %v", indices))
+	return indices, pubKeys, nil
+}
+
+// FetchValidators fetches the validators with the given indices and public keys.
+// Requests are split in to batches, and the batches are fetched concurrently.
+func FetchValidators(ctx context.Context,
+	validatorsProvider eth2client.ValidatorsProvider,
+	stateID string,
+	indices []phase0.ValidatorIndex,
+	pubKeys []phase0.BLSPubKey,
+) (
+	map[phase0.ValidatorIndex]*apiv1.Validator,
+	error,
+) {
+	// Public keys with a known index are fetched by index, which is
+	// cheaper for the beacon node to serve.
+	cache := ValidatorCacheForClient(ctx, validatorsProvider)
+	if cache != nil && len(pubKeys) > 0 {
+		known := make(map[phase0.ValidatorIndex]bool, len(indices))
+		for _, index := range indices {
+			known[index] = true
+		}
+		uncachedPubKeys := make([]phase0.BLSPubKey, 0, len(pubKeys))
+		for _, pubKey := range pubKeys {
+			entry, exists := cache.Entry(pubKey)
+			if !exists {
+				uncachedPubKeys = append(uncachedPubKeys, pubKey)
+				continue
+			}
+			if !known[entry.Index] {
+				indices = append(indices, entry.Index)
+				known[entry.Index] = true
+			}
+		}
+		pubKeys = uncachedPubKeys
 	}
-	for _, validator := range response.Data {
-		validators = append(validators, validator)
+
+	batches := make([]*api.ValidatorsOpts, 0, (len(indices)+len(pubKeys))/validatorsBatchSize+2)
+	for i := 0; i < len(indices); i += validatorsBatchSize {
+		batches = append(batches, &api.ValidatorsOpts{
+			State:   stateID,
+			Indices: indices[i:min(i+validatorsBatchSize, len(indices))],
+		})
 	}
-	return validators, nil
+	for i := 0; i < len(pubKeys); i += validatorsBatchSize {
+		batches = append(batches, &api.ValidatorsOpts{
+			State:   stateID,
+			PubKeys: pubKeys[i:min(i+validatorsBatchSize, len(pubKeys))],
+		})
+	}
+
+	res := make(map[phase0.ValidatorIndex]*apiv1.Validator, len(indices)+len(pubKeys))
+	resMu := sync.Mutex{}
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(validatorsWorkers)
+	for _, batch := range batches {
+		opts := batch
+		group.Go(func() error {
+			// Large requests are sent as POSTs, but only read data so are safe to retry.
+			response, err := validatorsProvider.Validators(WithRetries(groupCtx), opts)
+			if err != nil {
+				if len(opts.Indices) > 0 {
+					return errors.Wrap(err, fmt.Sprintf("failed to obtain validators %v", opts.Indices))
+				}
+				return errors.Wrap(err, "failed to obtain validators by public key")
+			}
+			resMu.Lock()
+			for index, validator := range response.Data {
+				res[index] = validator
+			}
+			resMu.Unlock()
+
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	cache.Update(res)
+	// A failure to save the cache is not fatal.
+	_ = cache.Save()
+
+	return res, nil
+}
+
+// MissingValidators returns the requested validators that are not present
+// in the fetched validators, in the order in which they were requested.
+func MissingValidators(indices []phase0.ValidatorIndex,
+	pubKeys []phase0.BLSPubKey,
+	validators map[phase0.ValidatorIndex]*apiv1.Validator,
+) []string {
+	fetchedPubKeys := make(map[phase0.BLSPubKey]bool, len(validators))
+	for _, validator := range validators {
+		if validator.Validator != nil {
+			fetchedPubKeys[validator.Validator.PublicKey] = true
+		}
+	}
+
+	res := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, index := range indices {
+		if _, exists := validators[index]; exists {
+			continue
+		}
+		missing := fmt.Sprintf("%d", index)
+		if !seen[missing] {
+			seen[missing] = true
+			res = append(res, missing)
+		}
+	}
+	for _, pubKey := range pubKeys {
+		if fetchedPubKeys[pubKey] {
+			continue
+		}
+		missing := fmt.Sprintf("%#x", pubKey)
+		if !seen[missing] {
+			seen[missing] = true
+			res = append(res, missing)
+		}
+	}
+
+	return res
 }
 
 // ParseValidator parses input to obtain the validator.
@@ -0,0 +1,134 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+// validatorsProvider is a mock validators provider that returns a validator for each requested index or public key.
+type validatorsProvider struct {
+	calls atomic.Int32
+}
+
+func (p *validatorsProvider) Validators(_ context.Context, opts *api.ValidatorsOpts) (*api.Response[map[phase0.ValidatorIndex]*apiv1.Validator], error) {
+	p.calls.Add(1)
+	res := make(map[phase0.ValidatorIndex]*apiv1.Validator)
+	for _, index := range opts.Indices {
+		res[index] = &apiv1.Validator{Index: index}
+	}
+	for _, pubKey := range opts.PubKeys {
+		// Use the first byte of the public key as the index.
+		index := phase0.ValidatorIndex(1000 + int(pubKey[0]))
+		res[index] = &apiv1.Validator{Index: index, Validator: &phase0.Validator{PublicKey: pubKey}}
+	}
+
+	return &api.Response[map[phase0.ValidatorIndex]*apiv1.Validator]{
+		Data:     res,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func TestFetchValidators(t *testing.T) {
+	ctx := context.Background()
+
+	indices := make([]phase0.ValidatorIndex, 0, 250)
+	for i := 0; i < 250; i++ {
+		indices = append(indices, phase0.ValidatorIndex(i))
+	}
+	pubKeys := []phase0.BLSPubKey{{0x01}, {0x02}}
+
+	provider := &validatorsProvider{}
+	validators, err := FetchValidators(ctx, provider, "head", indices, pubKeys)
+	require.NoError(t, err)
+	require.Len(t, validators, 252)
+	// 3 batches of indices and 1 batch of public keys.
+	require.Equal(t, int32(4), provider.calls.Load())
+}
+
+func TestMissingValidators(t *testing.T) {
+	validators := map[phase0.ValidatorIndex]*apiv1.Validator{
+		1: {Index: 1, Validator: &phase0.Validator{PublicKey: phase0.BLSPubKey{0x01}}},
+		2: {Index: 2, Validator: &phase0.Validator{PublicKey: phase0.BLSPubKey{0x02}}},
+	}
+
+	require.Equal(t, []string{}, MissingValidators([]phase0.ValidatorIndex{1, 2}, []phase0.BLSPubKey{{0x02}}, validators))
+	require.Equal(t, []string{
+		"5",
+		"0x090000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+	}, MissingValidators([]phase0.ValidatorIndex{1, 5, 5, 2},
+		[]phase0.BLSPubKey{{0x02}, {0x09}, {0x09}},
+		validators,
+	))
+}
+
+func TestParseValidators(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		validators []string
+		err        string
+		indices    []phase0.ValidatorIndex
+	}{
+		{
+			name:       "Index",
+			validators: []string{"5"},
+			indices:    []phase0.ValidatorIndex{5},
+		},
+		{
+			name:       "Range",
+			validators: []string{"7-9", "1"},
+			indices:    []phase0.ValidatorIndex{1, 7, 8, 9},
+		},
+		{
+			name:       "PubKey",
+			validators: []string{"0x030000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"},
+			indices:    []phase0.ValidatorIndex{1003},
+		},
+		{
+			name:       "PubKeyShort",
+			validators: []string{"0x0300"},
+			err:        "invalid length for validator public key 0x0300",
+		},
+		{
+			name:       "InvalidRange",
+			validators: []string{"1-2-3"},
+			err:        "invalid range 1-2-3",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			validators, err := ParseValidators(ctx, &validatorsProvider{}, test.validators, "head")
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				indices := make([]phase0.ValidatorIndex, 0, len(validators))
+				for _, validator := range validators {
+					indices = append(indices, validator.Index)
+				}
+				require.Equal(t, test.indices, indices)
+			}
+		})
+	}
+}
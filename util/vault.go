@@ -0,0 +1,189 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// vaultOption returns the named Vault option from the configuration,
+// falling back to the standard Vault environment variable.
+func vaultOption(name string, envVar string) string {
+	if value := viper.GetString(fmt.Sprintf("vault.%s", name)); value != "" {
+		return value
+	}
+
+	return os.Getenv(envVar)
+}
+
+// resolveVaultSecret resolves a secret held in HashiCorp Vault.  The
+// reference is the path to the secret, optionally followed by "#" and the
+// field within the secret, for example "secret/ethdo/wallet1#passphrase".
+func resolveVaultSecret(ctx context.Context, reference string) (string, error) {
+	secretPath, field, _ := strings.Cut(reference, "#")
+	secretPath = strings.Trim(secretPath, "/")
+	if secretPath == "" {
+		return "", errors.New("no Vault secret path supplied")
+	}
+
+	address := strings.TrimSuffix(vaultOption("address", "VAULT_ADDR"), "/")
+	if address == "" {
+		return "", errors.New("no Vault address supplied; set vault.address or VAULT_ADDR")
+	}
+
+	token, err := vaultToken(ctx, address)
+	if err != nil {
+		return "", err
+	}
+
+	kvVersion := 2
+	if viper.IsSet("vault.kv-version") {
+		kvVersion = viper.GetInt("vault.kv-version")
+	}
+	requestPath := secretPath
+	if kvVersion == 2 {
+		// Version 2 of the key/value engine places secrets under "data" in the mount.
+		mount, rest, _ := strings.Cut(secretPath, "/")
+		requestPath = fmt.Sprintf("%s/data/%s", mount, rest)
+	}
+
+	var res struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := vaultRequest(ctx, http.MethodGet, fmt.Sprintf("%s/v1/%s", address, requestPath), token, nil, &res); err != nil {
+		return "", errors.Wrapf(err, "failed to obtain Vault secret %s", secretPath)
+	}
+	data := res.Data
+	if kvVersion == 2 {
+		nested, isMap := data["data"].(map[string]any)
+		if !isMap {
+			return "", fmt.Errorf("secret %s in Vault has no data", secretPath)
+		}
+		data = nested
+	}
+
+	if field == "" {
+		if len(data) != 1 {
+			return "", fmt.Errorf("secret %s in Vault has %d fields; specify one with %s#<field>", secretPath, len(data), secretPath)
+		}
+		for k := range data {
+			field = k
+		}
+	}
+	value, exists := data[field]
+	if !exists {
+		return "", fmt.Errorf("secret %s in Vault has no field %s", secretPath, field)
+	}
+	secret, isString := value.(string)
+	if !isString {
+		return "", fmt.Errorf("secret %s in Vault has non-string field %s", secretPath, field)
+	}
+
+	return secret, nil
+}
+
+// vaultToken obtains the token with which to access Vault, either directly
+// or by logging in with AppRole credentials.
+func vaultToken(ctx context.Context, address string) (string, error) {
+	if token := vaultOption("token", "VAULT_TOKEN"); token != "" {
+		return token, nil
+	}
+
+	roleID := vaultOption("role-id", "VAULT_ROLE_ID")
+	secretID := vaultOption("secret-id", "VAULT_SECRET_ID")
+	if roleID == "" || secretID == "" {
+		return "", errors.New("no Vault credentials supplied; set vault.token, or vault.role-id and vault.secret-id")
+	}
+	mount := viper.GetString("vault.approle-mount")
+	if mount == "" {
+		mount = "approle"
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"role_id":   roleID,
+		"secret_id": secretID,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate Vault login request")
+	}
+	var res struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	if err := vaultRequest(ctx, http.MethodPost, fmt.Sprintf("%s/v1/auth/%s/login", address, mount), "", body, &res); err != nil {
+		return "", errors.Wrap(err, "failed to log in to Vault")
+	}
+	if res.Auth.ClientToken == "" {
+		return "", errors.New("login to Vault did not return a token")
+	}
+
+	return res.Auth.ClientToken, nil
+}
+
+// vaultRequest makes a request to Vault, decoding the response into res.
+func vaultRequest(ctx context.Context, method string, url string, token string, body []byte, res any) error {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if namespace := vaultOption("namespace", "VAULT_NAMESPACE"); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := HTTPClient().Do(req)
+	if err != nil {
+		return errors.Wrap(err, "request failed")
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		var errRes struct {
+			Errors []string `json:"errors"`
+		}
+		if json.Unmarshal(data, &errRes) == nil && len(errRes.Errors) > 0 {
+			return fmt.Errorf("status code %d: %s", resp.StatusCode, strings.Join(errRes.Errors, "; "))
+		}
+		return fmt.Errorf("status code %d", resp.StatusCode)
+	}
+	if err := json.Unmarshal(data, res); err != nil {
+		return errors.Wrap(err, "failed to parse response")
+	}
+
+	return nil
+}
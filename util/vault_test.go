@@ -0,0 +1,203 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// newVaultServer creates a server that behaves as Vault, with a version 2
+// key/value engine mounted at "secret" and a version 1 engine mounted at "kv".
+func newVaultServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/v1/auth/approle/login" {
+			var req map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req["role_id"] != "role" || req["secret_id"] != "secret" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"errors":["invalid role or secret ID"]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"auth":{"client_token":"approle-token"}}`))
+			return
+		}
+
+		token := r.Header.Get("X-Vault-Token")
+		if token != "token" && token != "approle-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/ethdo/wallet1":
+			_, _ = w.Write([]byte(`{"data":{"data":{"passphrase":"wallet1 secret"},"metadata":{"version":1}}}`))
+		case "/v1/secret/data/ethdo/multiple":
+			_, _ = w.Write([]byte(`{"data":{"data":{"passphrase":"multiple secret","mnemonic":"abandon abandon art"}}}`))
+		case "/v1/kv/ethdo/wallet1":
+			_, _ = w.Write([]byte(`{"data":{"passphrase":"kv secret"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+}
+
+func TestResolveSecret(t *testing.T) {
+	server := newVaultServer(t)
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		vault  map[string]any
+		value  string
+		secret string
+		err    string
+	}{
+		{
+			name:   "NotReference",
+			value:  "plain passphrase",
+			secret: "plain passphrase",
+		},
+		{
+			name:  "AddressMissing",
+			vault: map[string]any{"token": "token"},
+			value: "vault:secret/ethdo/wallet1",
+			err:   "no Vault address supplied; set vault.address or VAULT_ADDR",
+		},
+		{
+			name:  "CredentialsMissing",
+			vault: map[string]any{"address": server.URL},
+			value: "vault:secret/ethdo/wallet1",
+			err:   "no Vault credentials supplied; set vault.token, or vault.role-id and vault.secret-id",
+		},
+		{
+			name:  "TokenBad",
+			vault: map[string]any{"address": server.URL, "token": "bad"},
+			value: "vault:secret/ethdo/wallet1",
+			err:   "failed to obtain Vault secret secret/ethdo/wallet1: status code 403: permission denied",
+		},
+		{
+			name:   "Token",
+			vault:  map[string]any{"address": server.URL, "token": "token"},
+			value:  "vault:secret/ethdo/wallet1",
+			secret: "wallet1 secret",
+		},
+		{
+			name:  "AppRoleBad",
+			vault: map[string]any{"address": server.URL, "role-id": "role", "secret-id": "bad"},
+			value: "vault:secret/ethdo/wallet1",
+			err:   "failed to log in to Vault: status code 400: invalid role or secret ID",
+		},
+		{
+			name:   "AppRole",
+			vault:  map[string]any{"address": server.URL, "role-id": "role", "secret-id": "secret"},
+			value:  "vault:secret/ethdo/wallet1",
+			secret: "wallet1 secret",
+		},
+		{
+			name:   "KVVersion1",
+			vault:  map[string]any{"address": server.URL, "token": "token", "kv-version": 1},
+			value:  "vault:kv/ethdo/wallet1",
+			secret: "kv secret",
+		},
+		{
+			name:  "FieldRequired",
+			vault: map[string]any{"address": server.URL, "token": "token"},
+			value: "vault:secret/ethdo/multiple",
+			err:   "secret secret/ethdo/multiple in Vault has 2 fields; specify one with secret/ethdo/multiple#<field>",
+		},
+		{
+			name:   "Field",
+			vault:  map[string]any{"address": server.URL, "token": "token"},
+			value:  "vault:secret/ethdo/multiple#mnemonic",
+			secret: "abandon abandon art",
+		},
+		{
+			name:  "FieldMissing",
+			vault: map[string]any{"address": server.URL, "token": "token"},
+			value: "vault:secret/ethdo/multiple#other",
+			err:   "secret secret/ethdo/multiple in Vault has no field other",
+		},
+		{
+			name:  "SecretMissing",
+			vault: map[string]any{"address": server.URL, "token": "token"},
+			value: "vault:secret/ethdo/missing",
+			err:   "failed to obtain Vault secret secret/ethdo/missing: status code 404",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			t.Cleanup(viper.Reset)
+			t.Setenv("VAULT_ADDR", "")
+			t.Setenv("VAULT_TOKEN", "")
+			if test.vault != nil {
+				viper.Set("vault", test.vault)
+			}
+			secret, err := util.ResolveSecret(context.Background(), test.value)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.secret, secret)
+		})
+	}
+}
+
+func TestResolveSecrets(t *testing.T) {
+	server := newVaultServer(t)
+	defer server.Close()
+
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set("vault", map[string]any{"address": server.URL, "token": "token"})
+	viper.Set("passphrase", []string{"vault:secret/ethdo/wallet1", "plain"})
+	viper.Set("mnemonic", "vault:secret/ethdo/multiple#mnemonic")
+	viper.Set("stores.s3.passphrase", "vault:kv/ethdo/wallet1")
+
+	err := util.ResolveSecrets(context.Background())
+	require.EqualError(t, err, "failed to resolve stores.s3.passphrase: failed to obtain Vault secret kv/ethdo/wallet1: status code 404")
+
+	viper.Set("stores.s3.passphrase", "vault:secret/ethdo/wallet1")
+	viper.Set("stores.http.token", "vault:secret/ethdo/wallet1")
+	viper.Set("new-passphrase", "vault:secret/ethdo/wallet1")
+	viper.Set("keystore-passphrase", "vault:secret/ethdo/wallet1")
+	viper.Set("to-store-passphrase", "vault:secret/ethdo/wallet1")
+	require.NoError(t, util.ResolveSecrets(context.Background()))
+	require.Equal(t, []string{"wallet1 secret", "plain"}, util.GetPassphrases())
+	require.Equal(t, "abandon abandon art", viper.GetString("mnemonic"))
+	require.Equal(t, "wallet1 secret", util.GetStorePassphrase("s3"))
+	require.Equal(t, "wallet1 secret", viper.GetString("stores.http.token"))
+	require.Equal(t, "wallet1 secret", viper.GetString("new-passphrase"))
+	require.Equal(t, "wallet1 secret", viper.GetString("keystore-passphrase"))
+	require.Equal(t, "wallet1 secret", viper.GetString("to-store-passphrase"))
+
+	// Offline.
+	viper.Set("offline", true)
+	viper.Set("wallet-passphrase", "vault:secret/ethdo/wallet1")
+	err = util.ResolveSecrets(context.Background())
+	require.EqualError(t, err, "failed to resolve wallet-passphrase: cannot resolve vault secret: network access is not permitted in offline mode")
+}
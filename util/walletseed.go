@@ -0,0 +1,54 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// DecryptWalletSeed decrypts the seed of a hierarchical deterministic wallet
+// using the wallet passphrase.
+func DecryptWalletSeed(wallet e2wtypes.Wallet, passphrase string) ([]byte, error) {
+	if wallet.Type() != "hierarchical deterministic" {
+		return nil, errors.New("only hierarchical deterministic wallets have a seed")
+	}
+	storeProvider, isStoreProvider := wallet.(e2wtypes.StoreProvider)
+	if !isStoreProvider {
+		return nil, errors.New("wallet does not provide its store")
+	}
+
+	data, err := storeProvider.Store().RetrieveWallet(wallet.Name())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve wallet")
+	}
+	walletData := make(map[string]any)
+	if err := json.Unmarshal(data, &walletData); err != nil {
+		return nil, errors.Wrap(err, "failed to parse wallet")
+	}
+	crypto, isMap := walletData["crypto"].(map[string]any)
+	if !isMap {
+		return nil, errors.New("wallet does not contain an encrypted seed")
+	}
+
+	seed, err := keystorev4.New().Decrypt(crypto, passphrase)
+	if err != nil {
+		return nil, errors.New("incorrect wallet passphrase")
+	}
+
+	return seed, nil
+}
@@ -0,0 +1,154 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+	indexer "github.com/wealdtech/go-indexer"
+)
+
+// ImportWatchOnlyAccount imports a public key as an account that cannot sign.
+// Watch-only accounts allow commands that only require the public key, such as
+// those that obtain information about validators, to refer to keys whose
+// private material is held elsewhere.
+func ImportWatchOnlyAccount(ctx context.Context,
+	wallet e2wtypes.Wallet,
+	name string,
+	pubKey []byte,
+) (
+	e2wtypes.Account,
+	error,
+) {
+	if wallet.Type() != "non-deterministic" {
+		return nil, fmt.Errorf("%s wallets do not support watch-only accounts", wallet.Type())
+	}
+	if name == "" {
+		return nil, errors.New("account name missing")
+	}
+	if strings.HasPrefix(name, "_") {
+		return nil, fmt.Errorf("invalid account name %q", name)
+	}
+	publicKey, err := e2types.BLSPublicKeyFromBytes(pubKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid public key")
+	}
+	accountByNameProvider, isAccountByNameProvider := wallet.(e2wtypes.WalletAccountByNameProvider)
+	if !isAccountByNameProvider {
+		return nil, errors.New("wallet cannot obtain accounts by name")
+	}
+	if _, err := accountByNameProvider.AccountByName(ctx, name); err == nil {
+		return nil, fmt.Errorf("account with name %q already exists", name)
+	}
+	storeProvider, isStoreProvider := wallet.(e2wtypes.StoreProvider)
+	if !isStoreProvider {
+		return nil, errors.New("wallet does not support watch-only accounts")
+	}
+	store := storeProvider.Store()
+	walletIDProvider, isWalletIDProvider := wallet.(e2wtypes.WalletIDProvider)
+	if !isWalletIDProvider {
+		return nil, errors.New("wallet does not provide its ID")
+	}
+	walletID := walletIDProvider.ID()
+
+	accountID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate UUID")
+	}
+	// The account is stored in the same format as a regular account so that
+	// the wallet can load it, but with empty encrypted key material.
+	encryptor := keystorev4.New()
+	data, err := json.Marshal(map[string]any{
+		"uuid":      accountID.String(),
+		"name":      name,
+		"pubkey":    hex.EncodeToString(publicKey.Marshal()),
+		"crypto":    map[string]any{},
+		"encryptor": encryptor.String(),
+		"version":   encryptor.Version(),
+		"watchonly": true,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate account")
+	}
+
+	// Add the account to the wallet's index before storing it, so that it can
+	// be found by name.
+	index := indexer.New()
+	if serializedIndex, err := store.RetrieveAccountsIndex(walletID); err == nil {
+		index, err = indexer.Deserialize(serializedIndex)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to deserialize index")
+		}
+	} else {
+		for account := range wallet.Accounts(ctx) {
+			index.Add(account.ID(), account.Name())
+		}
+	}
+	index.Add(accountID, name)
+	serializedIndex, err := index.Serialize()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize index")
+	}
+	if err := store.StoreAccountsIndex(walletID, serializedIndex); err != nil {
+		return nil, errors.Wrap(err, "failed to store accounts index")
+	}
+	if err := store.StoreAccount(walletID, accountID, data); err != nil {
+		return nil, errors.Wrap(err, "failed to store account")
+	}
+
+	// Reopen the wallet to confirm that the account can be retrieved.
+	reopened, err := nd.OpenWallet(ctx, wallet.Name(), store, encryptor)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to reopen wallet")
+	}
+	account, err := reopened.(e2wtypes.WalletAccountByNameProvider).AccountByName(ctx, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to confirm account when retrieving by name")
+	}
+
+	return account, nil
+}
+
+// IsWatchOnlyAccount returns true if the account is a watch-only account.
+func IsWatchOnlyAccount(account e2wtypes.Account) bool {
+	walletProvider, isWalletProvider := account.(e2wtypes.AccountWalletProvider)
+	if !isWalletProvider {
+		return false
+	}
+	store, walletID, accountID, err := accountStoreDetails(walletProvider.Wallet(), account)
+	if err != nil {
+		return false
+	}
+	stored, err := store.RetrieveAccount(walletID, accountID)
+	if err != nil {
+		return false
+	}
+	data, err := parseAccountData(stored)
+	if err != nil {
+		return false
+	}
+	watchOnly, isBool := data["watchonly"].(bool)
+
+	return isBool && watchOnly
+}
@@ -0,0 +1,68 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+func TestImportWatchOnlyAccount(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx := context.Background()
+
+	store := scratch.New()
+	wallet, err := nd.CreateWallet(ctx, "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	regular, err := wallet.(e2wtypes.WalletAccountImporter).ImportAccount(ctx, "Interop 0", testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"), []byte("pass0"))
+	require.NoError(t, err)
+
+	pubKey := testutil.HexToBytes("0xb89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b")
+
+	_, err = util.ImportWatchOnlyAccount(ctx, wallet, "Interop 0", pubKey)
+	require.EqualError(t, err, `account with name "Interop 0" already exists`)
+	_, err = util.ImportWatchOnlyAccount(ctx, wallet, "_hidden", pubKey)
+	require.EqualError(t, err, `invalid account name "_hidden"`)
+
+	account, err := util.ImportWatchOnlyAccount(ctx, wallet, "Watched", pubKey)
+	require.NoError(t, err)
+	require.Equal(t, "Watched", account.Name())
+	require.Equal(t, pubKey, account.(e2wtypes.AccountPublicKeyProvider).PublicKey().Marshal())
+	require.True(t, util.IsWatchOnlyAccount(account))
+	require.False(t, util.IsWatchOnlyAccount(regular))
+
+	_, err = util.UnlockAccount(ctx, account, []string{"pass0"})
+	require.EqualError(t, err, "account is watch-only and cannot sign")
+
+	// Ensure that both accounts are present when the wallet is reopened.
+	reopened, err := nd.OpenWallet(ctx, "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+	names := make([]string, 0)
+	for account := range reopened.Accounts(ctx) {
+		names = append(names, account.Name())
+	}
+	require.ElementsMatch(t, []string{"Interop 0", "Watched"}, names)
+	_, err = reopened.(e2wtypes.WalletAccountByNameProvider).AccountByName(ctx, "Interop 0")
+	require.NoError(t, err)
+}